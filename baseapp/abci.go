@@ -9,6 +9,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
 
@@ -23,6 +25,7 @@ import (
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/legacytm"
 )
@@ -121,6 +124,7 @@ func (app *BaseApp) InitChain(ctx context.Context, req *abci.RequestInitChain) (
 
 // Info implements the ABCI interface.
 func (app *BaseApp) Info(ctx context.Context, req *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	app.waitForCommit()
 	lastCommitID := app.cms.LastCommitID()
 
 	return &abci.ResponseInfo{
@@ -134,7 +138,10 @@ func (app *BaseApp) Info(ctx context.Context, req *abci.RequestInfo) (*abci.Resp
 
 // BeginBlock implements the ABCI application interface.
 func (app *BaseApp) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
-	defer telemetry.MeasureSince(time.Now(), "abci", "begin_block")
+	defer telemetry.ObserveStage(telemetry.StageBeginBlock, time.Now())
+	_, span := app.TracingInfo.StartWithContext("BeginBlock", ctx.TraceSpanContext())
+	defer span.End()
+	span.SetAttributes(attribute.Int64("blockHeight", req.Header.Height))
 
 	if err := app.validateHeight(req); err != nil {
 		panic(err)
@@ -177,7 +184,11 @@ func (app *BaseApp) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) (res abc
 	// Clear DeliverTx Events
 	ctx.MultiStore().ResetEvents()
 
-	defer telemetry.MeasureSince(time.Now(), "abci", "end_block")
+	defer telemetry.ObserveStage(telemetry.StageEndBlock, time.Now())
+	telemetry.ObserveStageDuration(telemetry.StageDeliverTxTotal, time.Duration(atomic.SwapInt64(&app.deliverTxTime, 0)))
+	_, span := app.TracingInfo.StartWithContext("EndBlock", ctx.TraceSpanContext())
+	defer span.End()
+	span.SetAttributes(attribute.Int64("blockHeight", req.Height))
 
 	if app.endBlocker != nil {
 		res = app.endBlocker(ctx, req)
@@ -220,8 +231,22 @@ func (app *BaseApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abc
 		panic(fmt.Sprintf("unknown RequestCheckTx type: %s", req.Type))
 	}
 
-	sdkCtx := app.getContextForTx(mode, req.Tx)
-	gInfo, result, _, priority, err := app.runTx(sdkCtx, mode, req.Tx)
+	// the tx is decoded once for both its senders and its execution
+	sdkCtx := app.getContextForTx(mode, req.Tx).WithTxCache(sdk.NewTxCache().RecordSignatures(app.sigCache))
+	unlock := app.lockCheckTx(sdkCtx, req.Tx)
+	defer unlock()
+	var (
+		gInfo    sdk.GasInfo
+		result   *sdk.Result
+		priority int64
+	)
+	sdkCtx, err := app.mempoolCheckContext(sdkCtx, mode, req.Tx)
+	if err == nil {
+		gInfo, result, _, priority, err = app.runTx(sdkCtx, mode, req.Tx)
+	}
+	if mode == runTxModeReCheck && app.mempool != nil {
+		app.mempool.rechecked(req.Tx, err == nil, priority)
+	}
 	if err != nil {
 		res := sdkerrors.ResponseCheckTx(err, gInfo.GasWanted, gInfo.GasUsed, app.trace)
 		return &res, err
@@ -239,34 +264,125 @@ func (app *BaseApp) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abc
 // Otherwise, the ResponseDeliverTx will contain releveant error information.
 // Regardless of tx execution outcome, the ResponseDeliverTx will contain relevant
 // gas execution context.
-func (app *BaseApp) DeliverTx(ctx sdk.Context, req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
-	defer telemetry.MeasureSince(time.Now(), "abci", "deliver_tx")
-	defer func() {
-		for _, streamingListener := range app.abciListeners {
-			if err := streamingListener.ListenDeliverTx(app.deliverState.ctx, req, res); err != nil {
-				app.logger.Error("DeliverTx listening hook failed", "err", err)
+func (app *BaseApp) DeliverTx(ctx sdk.Context, req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	defer app.addDeliverTxTime(time.Now())
+	res := app.deliverTx(ctx, req)
+	app.afterDeliverTx(req, res)
+	return res
+}
+
+// DeliverTxBatch executes the txs of a batch and returns their responses in tx
+// order. With OCC enabled the txs are executed concurrently by the scheduler,
+// which only writes to ctx's multi-store once every tx has been validated. If
+// the scheduler fails, the batch is executed sequentially instead, which yields
+// the same state. The execution params may disable OCC from some height, see
+// ExecutionParams. With the ante prepass, the ante stage of every tx runs before
+// the msgs of any, see SetAntePrepass. The txs whose entry declares no access
+// operations are given those registered with RegisterTxAccessOps, if any.
+func (app *BaseApp) DeliverTxBatch(ctx sdk.Context, req sdk.DeliverTxBatchRequest) sdk.DeliverTxBatchResponse {
+	defer app.addDeliverTxTime(time.Now())
+	reqs := make([]abci.RequestDeliverTx, len(req.TxEntries))
+	var hints [][]acltypes.AccessOperation
+	for i, entry := range req.TxEntries {
+		reqs[i] = entry.Request
+		accessOps := entry.AccessOperations
+		if accessOps == nil {
+			accessOps = app.TxAccessOps(entry.Request.Tx)
+		}
+		if accessOps != nil {
+			if hints == nil {
+				hints = make([][]acltypes.AccessOperation, len(req.TxEntries))
 			}
+			hints[i] = accessOps
 		}
-	}()
+	}
 
-	gInfo := sdk.GasInfo{}
-	resultStr := "successful"
+	if app.txBatchVerifier != nil {
+		// the results are shared with the executions of the txs through the
+		// tx cache, which the scheduler keeps
+		if ctx.TxCache() == nil {
+			ctx = ctx.WithTxCache(sdk.NewTxCache())
+		}
+		app.verifyTxBatch(ctx, reqs)
+	}
+	if app.antePrepass {
+		ctx = app.runAntePrepass(ctx, reqs)
+	}
 
-	defer func() {
-		telemetry.IncrCounter(1, "tx", "count")
-		telemetry.IncrCounter(1, "tx", resultStr)
-		telemetry.SetGauge(float32(gInfo.GasUsed), "tx", "gas", "used")
-		telemetry.SetGauge(float32(gInfo.GasWanted), "tx", "gas", "wanted")
-	}()
+	var responses []abci.ResponseDeliverTx
+	switch {
+	case app.scheduler == nil:
+		app.modeReason = ModeReasonOccNotEnabled
+	case app.occDisabled(ctx):
+		app.modeReason = ModeReasonSequentialFromHeight
+		telemetry.IncrCounter(1, "scheduler", "disabled")
+	default:
+		var err error
+		responses, err = app.scheduler.ProcessAllWithHints(ctx, reqs, hints)
+		app.modeReason = app.scheduler.ModeSelectionReason()
+		if err != nil {
+			codespace, code, _ := sdkerrors.ABCIInfo(err, false)
+			app.logger.Error("scheduler failed, delivering the txs sequentially", "height", ctx.BlockHeight(),
+				"codespace", codespace, "code", code, "err", err)
+			telemetry.IncrCounter(1, "scheduler", "fallback")
+			app.modeReason = ModeReasonSchedulerFailed
+			responses = nil
+		}
+	}
+	if responses == nil {
+		responses = make([]abci.ResponseDeliverTx, len(reqs))
+		for i, r := range reqs {
+			responses[i] = app.deliverTx(ctx.WithTxIndex(i), r)
+		}
+	}
+
+	res := sdk.DeliverTxBatchResponse{Results: make([]*sdk.DeliverTxResult, len(responses))}
+	for i, r := range responses {
+		app.afterDeliverTx(reqs[i], r)
+		res.Results[i] = &sdk.DeliverTxResult{Response: r}
+	}
+	return res
+}
 
+// verifyTxBatch passes the txs of reqs that can be decoded to the tx batch
+// verifier. The ones that cannot are rejected by their execution.
+func (app *BaseApp) verifyTxBatch(ctx sdk.Context, reqs []abci.RequestDeliverTx) {
+	defer telemetry.MeasureSince(time.Now(), "abci", "verify_tx_batch")
+
+	txs := make([]sdk.Tx, 0, len(reqs))
+	txsBytes := make([][]byte, 0, len(reqs))
+	for _, req := range reqs {
+		tx, err := app.decodeTx(ctx, req.Tx)
+		if err != nil {
+			continue
+		}
+		txs = append(txs, tx)
+		txsBytes = append(txsBytes, req.Tx)
+	}
+	app.txBatchVerifier(ctx, txs, txsBytes)
+}
+
+// addDeliverTxTime adds the time spent delivering txs since start to the
+// delivery time of the current block.
+func (app *BaseApp) addDeliverTxTime(start time.Time) {
+	atomic.AddInt64(&app.deliverTxTime, int64(time.Since(start)))
+}
+
+// deliverTx executes a tx in DeliverTx mode. It is called for every execution
+// of a tx by the scheduler, including the ones it discards, so the delivered
+// txs are reported by afterDeliverTx instead.
+func (app *BaseApp) deliverTx(ctx sdk.Context, req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	defer telemetry.ObserveStage(telemetry.StageDeliverTx, time.Now())
+	if app.txResourceEvents {
+		ctx = ctx.WithTxResources(sdk.NewTxResources())
+	}
 	gInfo, result, anteEvents, _, err := app.runTx(ctx.WithTxBytes(req.Tx).WithVoteInfos(app.voteInfos), runTxModeDeliver, req.Tx)
 	if err != nil {
-		resultStr = "failed"
 		// if we have a result, use those events instead of just the anteEvents
 		if result != nil {
-			return sdkerrors.ResponseDeliverTxWithEvents(err, gInfo.GasWanted, gInfo.GasUsed, sdk.MarkEventsToIndex(result.Events, app.indexEvents), app.trace)
+			return sdkerrors.ResponseDeliverTxWithEvents(err, gInfo.GasWanted, gInfo.GasUsed, app.txEvents(ctx, result.Events), app.trace)
 		}
-		return sdkerrors.ResponseDeliverTxWithEvents(err, gInfo.GasWanted, gInfo.GasUsed, sdk.MarkEventsToIndex(anteEvents, app.indexEvents), app.trace)
+		return sdkerrors.ResponseDeliverTxWithEvents(err, gInfo.GasWanted, gInfo.GasUsed, app.txEvents(ctx, anteEvents), app.trace)
 	}
 
 	return abci.ResponseDeliverTx{
@@ -274,11 +390,55 @@ func (app *BaseApp) DeliverTx(ctx sdk.Context, req abci.RequestDeliverTx) (res a
 		GasUsed:   int64(gInfo.GasUsed),   // TODO: Should type accept unsigned ints?
 		Log:       result.Log,
 		Data:      result.Data,
-		Events:    sdk.MarkEventsToIndex(result.Events, app.indexEvents),
+		Events:    app.txEvents(ctx, result.Events),
+	}
+}
+
+// txEvents returns the events of the response of the tx executed with ctx.
+// With OCC enabled they are tagged with the indexes of the tx and of the msgs
+// emitting them, and put in msg order, see sdk.SequenceEvents. The event of
+// the resources the tx used, if counted, comes last.
+func (app *BaseApp) txEvents(ctx sdk.Context, events []abci.Event) []abci.Event {
+	if app.occEnabled {
+		events = sdk.SequenceEvents(ctx.TxIndex(), events)
+	}
+	if resources := ctx.TxResources(); resources != nil {
+		events = append(events, abci.Event(resources.Event(ctx.TxIncarnation()+1)))
+	}
+	return sdk.MarkEventsToIndex(events, app.indexEvents)
+}
+
+// afterDeliverTx records the telemetry of a delivered tx and passes it to the
+// ABCI listeners.
+func (app *BaseApp) afterDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx) {
+	if app.optimisticBlock != nil {
+		// reported once the block is finalized
+		app.optimisticBlock.deliveredTxs = append(app.optimisticBlock.deliveredTxs, deliveredTx{req, res})
+		return
+	}
+
+	if app.mempool != nil {
+		app.mempool.remove(req.Tx)
+	}
+
+	resultStr := "successful"
+	if !res.IsOK() {
+		resultStr = "failed"
+	}
+	telemetry.IncrCounter(1, "tx", "count")
+	telemetry.IncrCounter(1, "tx", resultStr)
+	telemetry.SetGauge(float32(res.GasUsed), "tx", "gas", "used")
+	telemetry.SetGauge(float32(res.GasWanted), "tx", "gas", "wanted")
+
+	for _, streamingListener := range app.abciListeners {
+		if err := streamingListener.ListenDeliverTx(app.deliverState.ctx, req, res); err != nil {
+			app.logger.Error("DeliverTx listening hook failed", "err", err)
+		}
 	}
 }
 
 func (app *BaseApp) WriteStateToCommitAndGetWorkingHash() []byte {
+	app.captureWriteSet()
 	app.stateToCommit.ms.Write()
 	hash, err := app.cms.GetWorkingHash()
 	if err != nil {
@@ -302,11 +462,17 @@ func (app *BaseApp) SetDeliverStateToCommit() {
 // latest header and reset the deliver state. Also, if a non-zero halt height is
 // defined in config, Commit will execute a deferred function call to check
 // against that height and gracefully halt if it matches the latest committed
-// height.
+// height. With pipelined commit, the state is committed in the background, and
+// the check state is set from the writes of the block instead.
 func (app *BaseApp) Commit(ctx context.Context) (res *abci.ResponseCommit, err error) {
-	defer telemetry.MeasureSince(time.Now(), "abci", "commit")
+	defer telemetry.ObserveStage(telemetry.StageCommit, time.Now())
 	app.commitLock.Lock()
 	defer app.commitLock.Unlock()
+	app.waitForCommit()
+
+	// a block processed optimistically but never finalized reads the
+	// multistore being committed
+	app.discardOptimisticBlock()
 
 	if app.stateToCommit == nil {
 		panic("no state to commit")
@@ -314,10 +480,44 @@ func (app *BaseApp) Commit(ctx context.Context) (res *abci.ResponseCommit, err e
 	header := app.stateToCommit.ctx.BlockHeader()
 	retainHeight := app.GetBlockRetentionHeight(header.Height)
 
-	app.WriteStateToCommitAndGetWorkingHash()
-	app.cms.Commit(true)
+	// the commit is the last span of the block
+	_, span := app.TracingInfo.Start("Commit")
+	span.SetAttributes(attribute.Int64("blockHeight", header.Height))
+	defer app.TracingInfo.EndBlock()
+	defer span.End()
+	defer app.slowBlockProfiler.endBlock()
+
+	if app.pipelinedCommit {
+		app.captureWriteSet()
+		app.commitInBackground(app.stateToCommit.ms)
+	} else {
+		app.WriteStateToCommitAndGetWorkingHash()
+		app.cms.Commit(true)
+	}
+
+	// the signatures looked up by the committed block belong to txs that
+	// cannot be delivered again
+	if app.mempool != nil {
+		app.mempool.commit()
+		telemetry.SetGauge(float32(app.mempool.CountTx()), "mempool", "txs")
+		telemetry.SetGauge(float32(app.mempool.Bytes()), "mempool", "bytes")
+	}
+	if app.sigCache != nil {
+		telemetry.IncrCounter(float32(app.sigCache.Prune()), "signature_cache", "hits")
+		telemetry.SetGauge(float32(app.sigCache.Len()), "signature_cache", "entries")
+	}
+
+	// call the streaming service hooks with the committed height
+	for _, streamingListener := range app.abciListeners {
+		if commitListener, ok := streamingListener.(CommitListener); ok {
+			if err := commitListener.ListenCommit(ctx, header.Height); err != nil {
+				app.logger.Error("Commit listening hook failed", "height", header.Height, "err", err)
+			}
+		}
+	}
 
-	// Reset the Check state to the latest committed.
+	// Reset the Check state to the latest committed, or to the write-set of
+	// the block being committed in the background.
 	//
 	// NOTE: This is safe because Tendermint holds a lock on the mempool for
 	// Commit. Use the header from this latest block.
@@ -337,6 +537,7 @@ func (app *BaseApp) Commit(ctx context.Context) (res *abci.ResponseCommit, err e
 	}
 
 	if halt {
+		app.waitForCommit()
 		// Halt the binary and allow Tendermint to receive the ResponseCommit
 		// response with the commit ID hash. This will allow the node to successfully
 		// restart and process blocks assuming the halt configuration has been
@@ -382,6 +583,8 @@ func (app *BaseApp) snapshot(height int64) {
 		return
 	}
 
+	// the version must be written before it is exported
+	app.waitForCommit()
 	app.logger.Info("creating state snapshot", "height", height)
 
 	snapshot, err := app.snapshotManager.Create(uint64(height))
@@ -592,8 +795,12 @@ func (app *BaseApp) handleQueryGRPC(handler GRPCQueryHandler, req abci.RequestQu
 	if err != nil {
 		return sdkerrors.QueryResultWithDebug(err, app.trace)
 	}
+	ctx = app.withQueryGasMeter(ctx, req.Path)
 
-	res, err := handler(ctx, req)
+	res, err := func() (res abci.ResponseQuery, err error) {
+		defer recoverQueryOutOfGas(ctx, &err)
+		return handler(ctx, req)
+	}()
 	if err != nil {
 		res = sdkerrors.QueryResultWithDebug(gRPCErrorToSDKError(err), app.trace)
 		res.Height = req.Height
@@ -618,6 +825,8 @@ func gRPCErrorToSDKError(err error) error {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	case codes.Unauthenticated:
 		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+	case codes.ResourceExhausted:
+		return sdkerrors.Wrap(sdkerrors.ErrOutOfGas, err.Error())
 	default:
 		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
 	}
@@ -875,12 +1084,16 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) abci.
 	if err != nil {
 		return sdkerrors.QueryResultWithDebug(err, app.trace)
 	}
+	ctx = app.withQueryGasMeter(ctx, req.Path)
 
 	// Passes the rest of the path as an argument to the querier.
 	//
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets
 	// []string{"proposal", "test"} as the path.
-	resBytes, err := querier(ctx, path[2:], req)
+	resBytes, err := func() (res []byte, err error) {
+		defer recoverQueryOutOfGas(ctx, &err)
+		return querier(ctx, path[2:], req)
+	}()
 	if err != nil {
 		res := sdkerrors.QueryResultWithDebug(err, app.trace)
 		res.Height = req.Height
@@ -910,6 +1123,7 @@ func splitPath(requestPath string) (path []string) {
 // ABCI++
 func (app *BaseApp) PrepareProposal(ctx context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
 	defer telemetry.MeasureSince(time.Now(), "abci", "prepare_proposal")
+	app.waitForCommit()
 
 	header := tmproto.Header{
 		ChainID:            app.ChainID,
@@ -943,7 +1157,7 @@ func (app *BaseApp) PrepareProposal(ctx context.Context, req *abci.RequestPrepar
 	app.preparePrepareProposalState()
 
 	if app.prepareProposalHandler != nil {
-		res, err := app.prepareProposalHandler(app.prepareProposalState.ctx, req)
+		res, err := app.prepareProposalHandler(app.withLocalLastCommit(app.prepareProposalState.ctx, req), req)
 		if err != nil {
 			return nil, err
 		}
@@ -958,6 +1172,7 @@ func (app *BaseApp) PrepareProposal(ctx context.Context, req *abci.RequestPrepar
 
 func (app *BaseApp) ProcessProposal(ctx context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
 	defer telemetry.MeasureSince(time.Now(), "abci", "process_proposal")
+	app.waitForCommit()
 
 	header := tmproto.Header{
 		ChainID:            app.ChainID,
@@ -1008,6 +1223,9 @@ func (app *BaseApp) ProcessProposal(ctx context.Context, req *abci.RequestProces
 		if cp := app.GetConsensusParams(app.processProposalState.ctx); cp != nil {
 			res.ConsensusParamUpdates = cp
 		}
+		if app.optimisticProcessing && res.Status == abci.ResponseProcessProposal_ACCEPT {
+			app.processOptimistically(req)
+		}
 		return res, nil
 	} else {
 		return nil, errors.New("no process proposal handler")
@@ -1016,6 +1234,7 @@ func (app *BaseApp) ProcessProposal(ctx context.Context, req *abci.RequestProces
 
 func (app *BaseApp) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
 	defer telemetry.MeasureSince(time.Now(), "abci", "finalize_block")
+	app.waitForCommit()
 
 	if app.cms.TracingEnabled() {
 		app.cms.SetTracingContext(sdk.TraceContext(
@@ -1023,6 +1242,42 @@ func (app *BaseApp) FinalizeBlock(ctx context.Context, req *abci.RequestFinalize
 		))
 	}
 
+	// the block span and the slow block timer are ended by Commit
+	blockSpanCtx := app.TracingInfo.StartBlock(req.Height)
+	app.slowBlockProfiler.startBlock(req.Height)
+
+	res, gasMeter, ok := app.takeOptimisticBlock(req)
+	if !ok {
+		gasMeter = app.prepareFinalizeBlockState(req)
+		app.deliverState.SetContext(app.deliverState.ctx.WithTraceSpanContext(blockSpanCtx))
+	}
+
+	// we also set block gas meter to checkState in case the application needs to
+	// verify gas consumption during (Re)CheckTx
+	if app.checkState != nil {
+		app.checkState.SetContext(app.checkState.ctx.WithBlockGasMeter(gasMeter).WithHeaderHash(req.Hash))
+	}
+
+	if !ok {
+		if app.finalizeBlocker == nil {
+			return nil, errors.New("finalize block handler not set")
+		}
+		var err error
+		res, err = app.finalizeBlocker(app.deliverState.ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
+	// set the signed validators for addition to context in deliverTx
+	app.setVotesInfo(req.DecidedLastCommit.GetVotes())
+
+	return res, nil
+}
+
+// prepareFinalizeBlockState sets up the deliver state to execute the block of
+// req and returns its block gas meter.
+func (app *BaseApp) prepareFinalizeBlockState(req *abci.RequestFinalizeBlock) sdk.GasMeter {
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.deliverState will be
 	// nil, since it is reset on Commit.
@@ -1066,34 +1321,7 @@ func (app *BaseApp) FinalizeBlock(ctx context.Context, req *abci.RequestFinalize
 	// NOTE: header hash is not set in NewContext, so we manually set it here
 
 	app.prepareDeliverState(gasMeter, req.Hash)
-
-	// we also set block gas meter to checkState in case the application needs to
-	// verify gas consumption during (Re)CheckTx
-	if app.checkState != nil {
-		app.checkState.SetContext(app.checkState.ctx.WithBlockGasMeter(gasMeter).WithHeaderHash(req.Hash))
-	}
-
-	if app.finalizeBlocker != nil {
-		res, err := app.finalizeBlocker(app.deliverState.ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
-		// set the signed validators for addition to context in deliverTx
-		app.setVotesInfo(req.DecidedLastCommit.GetVotes())
-
-		return res, nil
-	} else {
-		return nil, errors.New("finalize block handler not set")
-	}
-}
-
-func (app *BaseApp) ExtendVote(ctx context.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
-	return &abci.ResponseExtendVote{}, nil
-}
-
-func (app *BaseApp) VerifyVoteExtension(ctx context.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
-	return &abci.ResponseVerifyVoteExtension{}, nil
+	return gasMeter
 }
 
 func (app *BaseApp) LoadLatest(ctx context.Context, req *abci.RequestLoadLatest) (*abci.ResponseLoadLatest, error) {
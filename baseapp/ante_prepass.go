@@ -0,0 +1,109 @@
+package baseapp
+
+import (
+	"context"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// antePrepassKey is the key of the antePrepass of a block on the Go context of
+// its txs.
+type antePrepassKey struct{}
+
+// antePrepass holds the outcomes of the ante stage of the txs of a block, which
+// runs for every tx before the msgs of any, see SetAntePrepass. The outcome of
+// a tx is recorded at its tx index by each execution of its ante stage, the
+// last one being the one the scheduler validated.
+type antePrepass struct {
+	outcomes []anteOutcome
+	// msgs is set once the ante stage of every tx has run, runTx then skips
+	// the ante handler and only runs the msgs of the tx
+	msgs bool
+}
+
+// anteOutcome is the outcome of the ante stage of a tx.
+type anteOutcome struct {
+	gasWanted uint64
+	gasUsed   uint64
+	priority  int64
+	events    []abci.Event
+	err       error
+}
+
+// withAntePrepass returns ctx with the txs running the given stage of prepass.
+func withAntePrepass(ctx sdk.Context, prepass *antePrepass) sdk.Context {
+	return ctx.WithContext(context.WithValue(ctx.Context(), antePrepassKey{}, prepass))
+}
+
+// antePrepassFrom returns the antePrepass of the block ctx belongs to, if any.
+func antePrepassFrom(ctx sdk.Context) *antePrepass {
+	prepass, _ := ctx.Context().Value(antePrepassKey{}).(*antePrepass)
+	return prepass
+}
+
+// msgStage returns the prepass the msgs of the txs run with once the ante stage
+// of every tx has run.
+func (p *antePrepass) msgStage() *antePrepass {
+	return &antePrepass{outcomes: p.outcomes, msgs: true}
+}
+
+// record saves the outcome of the ante stage of the tx at index.
+func (p *antePrepass) record(index int, gInfo sdk.GasInfo, events []abci.Event, priority int64, err error) {
+	p.outcomes[index] = anteOutcome{
+		gasWanted: gInfo.GasWanted,
+		gasUsed:   gInfo.GasUsed,
+		priority:  priority,
+		events:    events,
+		err:       err,
+	}
+}
+
+// msgContext returns ctx with the gas meter the msgs of the tx continue from:
+// one of the gas limit its ante handler set, or an infinite one if it set no
+// limit, having consumed the gas of its ante stage.
+func (o anteOutcome) msgContext(ctx sdk.Context) sdk.Context {
+	gasMeter := sdk.NewInfiniteGasMeter()
+	if o.gasWanted > 0 {
+		gasMeter = sdk.NewGasMeter(o.gasWanted)
+	}
+	func() {
+		// a failed ante stage may have consumed past the limit, which the
+		// meter records before panicking
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+					panic(r)
+				}
+			}
+		}()
+		gasMeter.ConsumeGas(o.gasUsed, "ante prepass")
+	}()
+	return ctx.WithGasMeter(gasMeter)
+}
+
+// runAntePrepass runs the ante stage of every tx of reqs on ctx's multi-store,
+// concurrently if OCC is enabled, and returns the context the msgs of the txs
+// then run with. The ante stage consumes no block gas of its own; the block
+// gas of a tx is consumed by its msg stage.
+func (app *BaseApp) runAntePrepass(ctx sdk.Context, reqs []abci.RequestDeliverTx) sdk.Context {
+	prepass := &antePrepass{outcomes: make([]anteOutcome, len(reqs))}
+	anteCtx := withAntePrepass(ctx, prepass).WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+
+	var done bool
+	if app.scheduler != nil {
+		if _, err := app.scheduler.ProcessAll(anteCtx, reqs); err != nil {
+			app.logger.Error("scheduler failed, running the ante stage of the txs sequentially", "height", ctx.BlockHeight(), "err", err)
+		} else {
+			done = true
+		}
+	}
+	if !done {
+		for i, r := range reqs {
+			app.deliverTx(anteCtx.WithTxIndex(i), r)
+		}
+	}
+
+	return withAntePrepass(ctx, prepass.msgStage())
+}
@@ -0,0 +1,149 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestDeliverTxBatchAntePrepass(t *testing.T) {
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+	feesKey := []byte("fees")
+	newApp := func(options ...func(*BaseApp)) *BaseApp {
+		anteOpt := func(bapp *BaseApp) {
+			// every tx pays a fee to the same total
+			bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				ctx = ctx.WithGasMeter(sdk.NewGasMeter(100000))
+				ctx.GasMeter().ConsumeGas(10, "ante")
+				if tx.(txTest).FailOnAnte {
+					return ctx, sdkerrors.Wrap(sdkerrors.ErrInsufficientFee, "ante handler failure")
+				}
+				store := ctx.KVStore(capKey1)
+				setIntOnStore(store, feesKey, getIntFromStore(store, feesKey)+1)
+				ctx.EventManager().EmitEvents(counterEvent("ante_handler", tx.(txTest).Counter))
+				return ctx, nil
+			})
+		}
+		routerOpt := func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+				ctx.GasMeter().ConsumeGas(5, "msg")
+				fees := getIntFromStore(ctx.KVStore(capKey1), feesKey)
+				return &sdk.Result{Data: []byte(fmt.Sprint(fees))}, nil
+			}))
+		}
+		app := setupBaseApp(t, append(options, anteOpt, routerOpt)...)
+		app.InitChain(context.Background(), &abci.RequestInitChain{})
+		return app
+	}
+
+	seqApp := newApp(SetAntePrepass(true))
+	occApp := newApp(SetAntePrepass(true), SetOccEnabled(true), SetConcurrencyWorkers(4))
+	fallbackApp := newApp(SetAntePrepass(true), SetOccEnabled(true), SetConcurrencyWorkers(4))
+
+	header := tmproto.Header{Height: 1}
+	batch := sdk.DeliverTxBatchRequest{}
+	for i := 0; i < 6; i++ {
+		tx := newTxCounter(int64(i), int64(i))
+		tx.setFailOnAnte(i == 2)
+		batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: cdc.MustMarshal(tx)}})
+	}
+
+	var results [][]*sdk.DeliverTxResult
+	for _, app := range []*BaseApp{seqApp, occApp, fallbackApp} {
+		app.setDeliverState(header)
+		app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		ctx := app.deliverState.ctx
+		if app == fallbackApp {
+			// the scheduler fails on a cancelled context
+			goCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+			ctx = ctx.WithContext(goCtx)
+		}
+		res := app.DeliverTxBatch(ctx, batch)
+		require.Len(t, res.Results, 6)
+		results = append(results, res.Results)
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+	}
+
+	for i, result := range results[0] {
+		res := result.Response
+		require.Equal(t, int64(100000), res.GasWanted)
+		if i == 2 {
+			require.ErrorIs(t, sdkerrors.ABCIError(res.Codespace, res.Code, res.Log), sdkerrors.ErrInsufficientFee)
+			require.Equal(t, int64(10), res.GasUsed)
+			require.Empty(t, res.Events)
+			continue
+		}
+		require.True(t, res.IsOK(), res.Log)
+		// the msgs continue from the gas consumed by the ante stage
+		require.Greater(t, res.GasUsed, int64(15))
+		require.Equal(t, sdk.MarkEventsToIndex(counterEvent("ante_handler", int64(i)).ToABCIEvents(), map[string]struct{}{})[0], res.Events[0])
+
+		// the msgs of every tx see the fees of the whole block
+		var data sdk.TxMsgData
+		require.NoError(t, data.Unmarshal(res.Data))
+		require.Equal(t, []byte("5"), data.Data[0].Data)
+	}
+	requireSequencedResults(t, results[0], results[1])
+	requireSequencedResults(t, results[0], results[2])
+	require.Equal(t, seqApp.LastCommitID(), occApp.LastCommitID())
+	require.Equal(t, seqApp.LastCommitID(), fallbackApp.LastCommitID())
+}
+
+func TestTraceTxAntePrepass(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		SetTxTracing(true),
+		SetAntePrepass(true),
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	header := tmproto.Header{Height: 1}
+	batch := sdk.DeliverTxBatchRequest{}
+	var txs [][]byte
+	for i := int64(0); i < 3; i++ {
+		txBytes := cdc.MustMarshal(newTxCounter(i, i))
+		txs = append(txs, txBytes)
+		batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: txBytes}})
+	}
+	app.setDeliverState(header)
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+	for _, result := range app.DeliverTxBatch(app.deliverState.ctx, batch).Results {
+		require.True(t, result.Response.IsOK(), result.Response.Log)
+	}
+	app.SetDeliverStateToCommit()
+	_, err := app.Commit(context.Background())
+	require.NoError(t, err)
+
+	// the ante stage of the tx runs after the ante stage of the txs before it,
+	// and its msg after the ante stage of every tx
+	trace, err := app.TraceTx(header, txs, 1)
+	require.NoError(t, err)
+	require.Empty(t, trace.Error)
+	require.Len(t, trace.Phases, 2)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: anteKey, Value: []byte{2}},
+		{Store: "key1", Operation: "write", Key: anteKey, Value: []byte{4}},
+	}, trace.Phases[0].StoreAccesses)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: deliverKey, Value: []byte{2}},
+		{Store: "key1", Operation: "write", Key: deliverKey, Value: []byte{4}},
+	}, trace.Phases[1].StoreAccesses)
+	require.Equal(t, trace.GasInfo.GasUsed, trace.Phases[0].GasUsed+trace.Phases[1].GasUsed)
+}
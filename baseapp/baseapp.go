@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/armon/go-metrics"
 	"github.com/cosmos/cosmos-sdk/utils/tracing"
 	"github.com/gogo/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru/v2"
 	sdbm "github.com/sei-protocol/sei-tm-db/backends"
 	"github.com/spf13/cast"
 	leveldbutils "github.com/syndtr/goleveldb/leveldb/util"
@@ -25,12 +27,19 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
 
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/snapshots"
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
+	storeiavl "github.com/cosmos/cosmos-sdk/store/iavl"
 	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/tasks"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
@@ -61,10 +70,16 @@ const (
 	FlagArchivalArweaveNodeURL         = "archival-arweave-node-url"
 
 	FlagChainID = "chain-id"
+
+	// FlagAppDBBackend is the backend of the application database, used when
+	// it is reopened
+	FlagAppDBBackend = "app-db-backend"
 )
 
 var (
-	_ abci.Application = (*BaseApp)(nil)
+	_ abci.Application                     = (*BaseApp)(nil)
+	_ servertypes.GRPCInterceptorsProvider = (*BaseApp)(nil)
+	_ sdk.DeliverTxBatcher                 = (*BaseApp)(nil)
 )
 
 type (
@@ -90,9 +105,19 @@ type BaseApp struct { //nolint: maligned
 	prepareProposalHandler sdk.PrepareProposalHandler
 	processProposalHandler sdk.ProcessProposalHandler
 	finalizeBlocker        sdk.FinalizeBlocker
-	anteHandler            sdk.AnteHandler // ante handler for fee and auth
+	anteHandler            sdk.AnteHandler     // ante handler for fee and auth
+	txBatchVerifier        sdk.TxBatchVerifier // verifies the txs of DeliverTxBatch ahead of their execution
 	loadVersionHandler     sdk.LoadVersionHandler
 
+	// txPriorityHandler overrides the priority set by the anteHandler for
+	// CheckTx, see SetTxPriorityHandler
+	txPriorityHandler sdk.TxPriorityHandler
+
+	// extendVoteHandler and verifyVoteExtensionHandler handle the vote
+	// extensions, see vote_extensions.go
+	extendVoteHandler          sdk.ExtendVoteHandler
+	verifyVoteExtensionHandler sdk.VerifyVoteExtensionHandler
+
 	appStore
 	baseappVersions
 	peerFilters
@@ -148,6 +173,71 @@ type BaseApp struct { //nolint: maligned
 	// trace set will return full stack traces for errors in ABCI Log field
 	trace bool
 
+	// occEnabled is set when the txs of a block are executed concurrently, in
+	// which case they share a concurrency-safe branch of the deliver state
+	occEnabled bool
+	// concurrencyWorkers and schedulerOptions configure the scheduler that
+	// executes the txs of DeliverTxBatch when OCC is enabled
+	concurrencyWorkers int
+	schedulerOptions   []tasks.Option
+	scheduler          tasks.Scheduler
+	// antePrepass makes DeliverTxBatch run the ante stage of every tx before
+	// the msgs of any, see runAntePrepass
+	antePrepass bool
+	// modeReason is why the last batch was executed the way it was, see
+	// ModeSelectionReason
+	modeReason string
+
+	// optimisticProcessing is set when the blocks of accepted proposals are
+	// executed before they are finalized, see processOptimistically
+	optimisticProcessing bool
+	optimisticBlock      *optimisticBlock
+
+	// checkTxLock serializes CheckTx, unless concurrentCheckTx is set, in
+	// which case only the txs sharing a sender are serialized by
+	// checkTxAccounts
+	checkTxLock       sync.Mutex
+	concurrentCheckTx bool
+	checkTxAccounts   *accountLocks
+
+	// sigCache holds the signatures verified by CheckTx, which the txs of a
+	// block look up instead of verifying them again
+	sigCache *sdk.SignatureCache
+
+	// mempool tracks the txs accepted by CheckTx for the proposals to order
+	// them by priority, see PriorityMempool
+	mempool *PriorityMempool
+
+	// txTracing enables TraceTx, which re-executes committed txs
+	txTracing bool
+
+	// txResourceEvents adds the resources used by the delivered txs to their
+	// responses, see sdk.TxResources
+	txResourceEvents bool
+
+	// eventLimits bounds the events of every tx, see SetEventLimits
+	eventLimits sdk.EventLimits
+
+	// txAccessHints holds the access operations declared for the txs by their
+	// senders, see RegisterTxAccessOps, nil unless enabled by
+	// SetTxAccessHintsCacheSize
+	txAccessHints *lru.Cache[[32]byte, []acltypes.AccessOperation]
+	// accessOpsValidator checks the access operations declared for the txs
+	// against the accesses of their simulation, see SetAccessOpsValidator
+	accessOpsValidator *acltypes.MsgValidator
+
+	// slowBlockProfiler captures the profiles of the slow blocks, nil unless
+	// enabled by SetSlowBlockProfiling
+	slowBlockProfiler *slowBlockProfiler
+
+	// simulationTracer is passed the trace of every simulated tx, see
+	// SetSimulationTracer
+	simulationTracer func(*sdk.TxTrace)
+
+	// dependencyMismatchHandler is called with the msgs whose store accesses
+	// exceed their access operations
+	dependencyMismatchHandler DependencyMismatchHandler
+
 	// indexEvents defines the set of events in the form {eventType}.{attributeKey},
 	// which informs Tendermint what to index. If empty, all events will be indexed.
 	indexEvents map[string]struct{}
@@ -165,9 +255,40 @@ type BaseApp struct { //nolint: maligned
 
 	orphanConfig *iavl.Options
 
+	asyncCommitBuffer int
+
+	// pipelinedCommit makes Commit write the committed block to the stores in
+	// the background, the check state being reset from commitWriteSet
+	pipelinedCommit bool
+	// commitWriteSet is the branch of the last committed version holding the
+	// writes of the block being committed
+	commitWriteSet sdk.CacheMultiStore
+	// backgroundCommit is write-locked while a block is committed in the
+	// background
+	backgroundCommit *sync.RWMutex
+
+	pruningBatchSize          int
+	pruningBackgroundInterval time.Duration
+
+	historicalStoreCacheSize int
+	// knobsMtx guards concurrencyWorkers and historicalStoreCacheSize, which
+	// can change while the app runs, see UpdateConcurrencyWorkers
+	knobsMtx sync.RWMutex
+
+	fastNodeMigration storeiavl.FastNodeMigrationConfig
+
+	// defaultQueryGasLimit bounds the gas of the queries, except those to the
+	// modules of moduleQueryGasLimits, 0 leaving it unbounded
+	defaultQueryGasLimit uint64
+	moduleQueryGasLimits map[string]uint64
+
 	TmConfig *tmcfg.Config
 
 	TracingInfo *tracing.Info
+
+	// deliverTxTime is the time, in nanoseconds, spent delivering the txs of
+	// the current block so far. It is observed and reset by EndBlock.
+	deliverTxTime int64
 }
 
 type appStore struct {
@@ -178,6 +299,9 @@ type appStore struct {
 	// an inter-block write-through cache provided to the context during deliverState
 	interBlockCache sdk.MultiStorePersistentCache
 
+	// dbBackend is the backend the db was opened with, empty for the default one
+	dbBackend dbm.BackendType
+
 	fauxMerkleMode bool // if true, IAVL MountStores uses MountStoresDB for simulation speed.
 }
 
@@ -186,6 +310,10 @@ type moduleRouter struct {
 	queryRouter      sdk.QueryRouter   // router for redirecting query calls
 	grpcQueryRouter  *GRPCQueryRouter  // router for redirecting gRPC query calls
 	msgServiceRouter *MsgServiceRouter // router for redirecting Msg service messages
+
+	// interceptors of the calls to the gRPC server, see RegisterGRPCInterceptors
+	grpcUnaryInterceptors  []grpc.UnaryServerInterceptor
+	grpcStreamInterceptors []grpc.StreamServerInterceptor
 }
 
 type abciData struct {
@@ -193,6 +321,7 @@ type abciData struct {
 	beginBlocker sdk.BeginBlocker // logic to run before any txs
 	midBlocker   sdk.MidBlocker   // logic to run after all txs, and to determine valset changes
 	endBlocker   sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
+	warmUpper    sdk.WarmUpper    // logic to run before serving the first requests, see WarmUp
 
 	// absent validators from begin block
 	voteInfos []abci.VoteInfo
@@ -211,10 +340,12 @@ type baseappVersions struct {
 // which then has a sub-item, persistence fields
 type snapshotData struct {
 	// manages snapshots, i.e. dumps of app state at certain intervals
-	snapshotManager    *snapshots.Manager
-	snapshotInterval   uint64 // block interval between state sync snapshots
-	snapshotKeepRecent uint32 // recent state sync snapshots to keep
-	snapshotDirectory  string //  state sync snapshots directory
+	snapshotManager     *snapshots.Manager
+	snapshotInterval    uint64 // block interval between state sync snapshots
+	snapshotKeepRecent  uint32 // recent state sync snapshots to keep
+	snapshotCompression string // compression of the state sync snapshots taken
+	snapshotChunkSize   uint64 // size of the chunks of the state sync snapshots taken
+	snapshotDirectory   string //  state sync snapshots directory
 }
 
 // NewBaseApp returns a reference to an initialized BaseApp. It accepts a
@@ -244,7 +375,10 @@ func NewBaseApp(
 	otel.SetTracerProvider(trace.NewNoopTracerProvider())
 	tr := tp.Tracer("component-main")
 	if tracingEnabled := cast.ToBool(appOpts.Get(tracing.FlagTracing)); tracingEnabled {
-		tp, err := tracing.DefaultTracerProvider()
+		tp, err := tracing.NewTracerProvider(
+			cast.ToString(appOpts.Get(tracing.FlagTracingExporter)),
+			cast.ToString(appOpts.Get(tracing.FlagTracingEndpoint)),
+		)
 		if err != nil {
 			panic(err)
 		}
@@ -259,6 +393,7 @@ func NewBaseApp(
 			cms:            cms,
 			storeLoader:    DefaultStoreLoader,
 			fauxMerkleMode: false,
+			dbBackend:      dbm.BackendType(cast.ToString(appOpts.Get(FlagAppDBBackend))),
 		},
 		moduleRouter: moduleRouter{
 			router:           NewRouter(),
@@ -271,7 +406,9 @@ func NewBaseApp(
 		TracingInfo: &tracing.Info{
 			Tracer: &tr,
 		},
-		commitLock: &sync.Mutex{},
+		commitLock:       &sync.Mutex{},
+		backgroundCommit: &sync.RWMutex{},
+		checkTxAccounts:  newAccountLocks(),
 	}
 
 	app.TracingInfo.SetContext(context.Background())
@@ -284,7 +421,7 @@ func NewBaseApp(
 		app.cms.SetInterBlockCache(app.interBlockCache)
 	}
 
-	app.runTxRecoveryMiddleware = newDefaultRecoveryMiddleware()
+	app.runTxRecoveryMiddleware = newOCCAbortRecoveryMiddleware(newDefaultRecoveryMiddleware())
 	app.ChainID = cast.ToString(appOpts.Get(FlagChainID))
 	if app.ChainID == "" {
 		panic("must pass --chain-id when calling 'seid start' or set in ~/.sei/config/client.toml")
@@ -293,6 +430,22 @@ func NewBaseApp(
 	if app.orphanConfig != nil {
 		app.cms.(*rootmulti.Store).SetOrphanConfig(app.orphanConfig)
 	}
+	if app.asyncCommitBuffer > 0 {
+		app.cms.(*rootmulti.Store).SetAsyncCommit(app.asyncCommitBuffer)
+	}
+	if app.pruningBackgroundInterval > 0 {
+		app.cms.(*rootmulti.Store).SetBackgroundPruning(app.pruningBatchSize, app.pruningBackgroundInterval)
+	}
+	if app.historicalStoreCacheSize > 0 {
+		app.cms.(*rootmulti.Store).SetHistoricalStoreCacheSize(app.historicalStoreCacheSize)
+	}
+	if rs, ok := app.cms.(*rootmulti.Store); ok {
+		rs.SetFastNodeMigration(app.fastNodeMigration)
+	}
+	if app.occEnabled {
+		opts := append([]tasks.Option{tasks.WithTracingInfo(app.TracingInfo)}, app.schedulerOptions...)
+		app.scheduler = tasks.NewScheduler(app.concurrencyWorkers, app.deliverTx, opts...)
+	}
 
 	return app
 }
@@ -413,6 +566,12 @@ func (app *BaseApp) SnapshotManager() *snapshots.Manager {
 	return app.snapshotManager
 }
 
+// Mempool returns the mempool tracking the txs accepted by CheckTx, or nil if
+// none is set. Applications pass its TxOrderer to their ProposalHandler.
+func (app *BaseApp) Mempool() *PriorityMempool {
+	return app.mempool
+}
+
 // LoadVersion loads the BaseApp application version. It will panic if called
 // more than once on a running baseapp.
 func (app *BaseApp) LoadVersion(version int64) error {
@@ -457,6 +616,19 @@ func (app *BaseApp) init() error {
 		}
 	}
 
+	if app.snapshotManager != nil {
+		format, err := snapshottypes.FormatFromCompression(app.snapshotCompression)
+		if err != nil {
+			return err
+		}
+		if err := app.snapshotManager.SetFormat(format); err != nil {
+			return err
+		}
+		if app.snapshotChunkSize > 0 {
+			app.snapshotManager.SetChunkSize(app.snapshotChunkSize)
+		}
+	}
+
 	return nil
 }
 
@@ -476,6 +648,32 @@ func (app *BaseApp) setOrphanConfig(opts *iavl.Options) {
 	app.orphanConfig = opts
 }
 
+func (app *BaseApp) setAsyncCommitBuffer(size int) {
+	app.asyncCommitBuffer = size
+}
+
+func (app *BaseApp) setPipelinedCommit(enabled bool) {
+	app.pipelinedCommit = enabled
+}
+
+func (app *BaseApp) setBackgroundPruning(batchSize int, interval time.Duration) {
+	app.pruningBatchSize = batchSize
+	app.pruningBackgroundInterval = interval
+}
+
+func (app *BaseApp) setHistoricalStoreCacheSize(size int) {
+	app.historicalStoreCacheSize = size
+}
+
+func (app *BaseApp) setFastNodeMigration(cfg storeiavl.FastNodeMigrationConfig) {
+	app.fastNodeMigration = cfg
+}
+
+func (app *BaseApp) setQueryGasLimits(defaultLimit uint64, moduleLimits map[string]uint64) {
+	app.defaultQueryGasLimit = defaultLimit
+	app.moduleQueryGasLimits = moduleLimits
+}
+
 func (app *BaseApp) setMinRetainBlocks(minRetainBlocks uint64) {
 	app.minRetainBlocks = minRetainBlocks
 }
@@ -492,6 +690,70 @@ func (app *BaseApp) setTrace(trace bool) {
 	app.trace = trace
 }
 
+func (app *BaseApp) setOccEnabled(occEnabled bool) {
+	app.occEnabled = occEnabled
+}
+
+func (app *BaseApp) setAntePrepass(antePrepass bool) {
+	app.antePrepass = antePrepass
+}
+
+func (app *BaseApp) setConcurrencyWorkers(workers int) {
+	app.concurrencyWorkers = workers
+}
+
+func (app *BaseApp) setOptimisticProcessing(optimisticProcessing bool) {
+	app.optimisticProcessing = optimisticProcessing
+}
+
+func (app *BaseApp) setConcurrentCheckTx(concurrentCheckTx bool) {
+	app.concurrentCheckTx = concurrentCheckTx
+}
+
+func (app *BaseApp) setMempool(mempool *PriorityMempool) {
+	app.mempool = mempool
+}
+
+func (app *BaseApp) setTxTracing(txTracing bool) {
+	app.txTracing = txTracing
+}
+
+func (app *BaseApp) setTxResourceEvents(txResourceEvents bool) {
+	app.txResourceEvents = txResourceEvents
+}
+
+func (app *BaseApp) setEventLimits(limits sdk.EventLimits) {
+	app.eventLimits = limits
+}
+
+func (app *BaseApp) setTxAccessHintsCacheSize(size int) {
+	if size <= 0 {
+		app.txAccessHints = nil
+		return
+	}
+	cache, err := lru.New[[32]byte, []acltypes.AccessOperation](size)
+	if err != nil {
+		panic(fmt.Errorf("failed to create tx access hints cache: %w", err))
+	}
+	app.txAccessHints = cache
+}
+
+func (app *BaseApp) setSlowBlockProfiling(threshold time.Duration, dir string) {
+	if threshold <= 0 {
+		app.slowBlockProfiler = nil
+		return
+	}
+	app.slowBlockProfiler = newSlowBlockProfiler(threshold, dir, app.logger)
+}
+
+func (app *BaseApp) setSignatureCacheSize(size int) {
+	if size <= 0 {
+		app.sigCache = nil
+		return
+	}
+	app.sigCache = sdk.NewSignatureCache(size)
+}
+
 func (app *BaseApp) setIndexEvents(ie []string) {
 	app.indexEvents = make(map[string]struct{})
 
@@ -526,6 +788,11 @@ func (app *BaseApp) IsSealed() bool { return app.sealed }
 // on Commit.
 func (app *BaseApp) setCheckState(header tmproto.Header) {
 	ms := app.cms.CacheMultiStore()
+	if app.commitWriteSet != nil {
+		// the stores are being committed in the background
+		ms = app.commitWriteSet.CacheMultiStore()
+		app.commitWriteSet = nil
+	}
 	ctx := sdk.NewContext(ms, header, true, app.logger).WithMinGasPrices(app.minGasPrices)
 	if app.checkState == nil {
 		app.checkState = &state{
@@ -544,7 +811,7 @@ func (app *BaseApp) setCheckState(header tmproto.Header) {
 // and provided header. It is set on InitChain and BeginBlock and set to nil on
 // Commit.
 func (app *BaseApp) setDeliverState(header tmproto.Header) {
-	ms := app.cms.CacheMultiStore()
+	ms := app.deliverMultiStore()
 	ctx := sdk.NewContext(ms, header, false, app.logger)
 	if app.deliverState == nil {
 		app.deliverState = &state{
@@ -558,6 +825,19 @@ func (app *BaseApp) setDeliverState(header tmproto.Header) {
 	app.deliverState.SetContext(ctx)
 }
 
+// deliverMultiStore branches the commit multi-store for the deliver state. With
+// OCC enabled the txs of a block read and write the branch concurrently, so its
+// substores are ConcurrentStores rather than cachekv stores, which serialize
+// every access behind a single lock.
+func (app *BaseApp) deliverMultiStore() sdk.CacheMultiStore {
+	if !app.occEnabled {
+		return app.cms.CacheMultiStore()
+	}
+	return cachemulti.NewBranchStore(app.cms, func(key sdk.StoreKey, parent sdk.KVStore) sdk.CacheWrap {
+		return cachekv.NewConcurrentStore(parent, key, storetypes.DefaultCacheSizeLimit)
+	})
+}
+
 func (app *BaseApp) setPrepareProposalState(header tmproto.Header) {
 	ms := app.cms.CacheMultiStore()
 	ctx := sdk.NewContext(ms, header, false, app.logger)
@@ -593,6 +873,7 @@ func (app *BaseApp) resetStatesExceptCheckState() {
 	app.processProposalState = nil
 	app.deliverState = nil
 	app.stateToCommit = nil
+	app.commitWriteSet = nil
 }
 
 func (app *BaseApp) setPrepareProposalHeader(header tmproto.Header) {
@@ -608,6 +889,9 @@ func (app *BaseApp) setDeliverStateHeader(header tmproto.Header) {
 }
 
 func (app *BaseApp) preparePrepareProposalState() {
+	app.prepareProposalState.SetContext(app.prepareProposalState.Context().
+		WithConsensusParams(app.GetConsensusParams(app.prepareProposalState.Context())))
+
 	if app.prepareProposalState.MultiStore().TracingEnabled() {
 		app.prepareProposalState.SetMultiStore(app.prepareProposalState.MultiStore().SetTracingContext(nil).(sdk.CacheMultiStore))
 	}
@@ -627,7 +911,8 @@ func (app *BaseApp) prepareDeliverState(gasMeter sdk.GasMeter, headerHash []byte
 	app.deliverState.SetContext(app.deliverState.Context().
 		WithBlockGasMeter(gasMeter).
 		WithHeaderHash(headerHash).
-		WithConsensusParams(app.GetConsensusParams(app.deliverState.Context())))
+		WithConsensusParams(app.GetConsensusParams(app.deliverState.Context())).
+		WithTxCache(sdk.NewTxCache().LookUpSignatures(app.sigCache)))
 }
 
 func (app *BaseApp) setVotesInfo(votes []abci.VoteInfo) {
@@ -698,6 +983,44 @@ func (app *BaseApp) GetConsensusParams(ctx sdk.Context) *tmproto.ConsensusParams
 	return cp
 }
 
+// GetExecutionParams returns the execution params from the BaseApp's
+// ParamStore, or the zero params if they are not set.
+func (app *BaseApp) GetExecutionParams(ctx sdk.Context) ExecutionParams {
+	var ep ExecutionParams
+	if app.paramStore != nil && app.paramStore.Has(ctx, ParamStoreKeyExecutionParams) {
+		app.paramStore.Get(ctx, ParamStoreKeyExecutionParams, &ep)
+	}
+	return ep
+}
+
+// The reasons of the way DeliverTxBatch executed a batch besides those of the
+// scheduler, the tasks.ModeReason constants, see ModeSelectionReason.
+const (
+	// ModeReasonOccNotEnabled is the reason of a batch executed sequentially
+	// as OCC is not enabled, see SetOccEnabled.
+	ModeReasonOccNotEnabled = "occ not enabled"
+	// ModeReasonSequentialFromHeight is the reason of a batch executed
+	// sequentially as the execution params disable OCC from its height.
+	ModeReasonSequentialFromHeight = "sequential from height"
+	// ModeReasonSchedulerFailed is the reason of a batch executed sequentially
+	// after the scheduler failed to execute it.
+	ModeReasonSchedulerFailed = "scheduler failed"
+)
+
+// ModeSelectionReason returns why the last batch of DeliverTxBatch was
+// executed the way it was: one of the ModeReason constants of this package if
+// it was executed sequentially, or the reason given by the scheduler.
+func (app *BaseApp) ModeSelectionReason() string {
+	return app.modeReason
+}
+
+// occDisabled reports whether the execution params make the txs of the block of
+// ctx execute sequentially.
+func (app *BaseApp) occDisabled(ctx sdk.Context) bool {
+	sequentialFrom := app.GetExecutionParams(ctx.WithGasMeter(sdk.NewInfiniteGasMeter())).SequentialFromHeight
+	return sequentialFrom > 0 && ctx.BlockHeight() >= sequentialFrom
+}
+
 // AddRunTxRecoveryHandler adds custom app.runTx method panic handlers.
 func (app *BaseApp) AddRunTxRecoveryHandler(handlers ...RecoveryHandler) {
 	for _, h := range handlers {
@@ -838,6 +1161,24 @@ func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte) (sdk.Context
 	return ctx.WithMultiStore(msCache), msCache
 }
 
+// decodeTx decodes txBytes, reusing the result of an earlier decode of the same
+// bytes when ctx carries a tx cache.
+func (app *BaseApp) decodeTx(ctx sdk.Context, txBytes []byte) (sdk.Tx, error) {
+	txCache := ctx.TxCache()
+	if txCache == nil {
+		return app.txDecoder(txBytes)
+	}
+	if tx, ok := txCache.GetTx(txBytes); ok {
+		return tx, nil
+	}
+	tx, err := app.txDecoder(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	txCache.SetTx(txBytes, tx)
+	return tx, nil
+}
+
 // runTx processes a transaction within a given execution mode, encoded transaction
 // bytes, and the decoded transaction itself. All state transitions occur through
 // a cached Context depending on the mode provided. State only gets persisted
@@ -869,13 +1210,25 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 	spanCtx, span := app.TracingInfo.StartWithContext("RunTx", ctx.TraceSpanContext())
 	defer span.End()
 	ctx = ctx.WithTraceSpanContext(spanCtx)
-	span.SetAttributes(attribute.String("txHash", fmt.Sprintf("%X", sha256.Sum256(txBytes))))
+	span.SetAttributes(
+		attribute.String("txHash", fmt.Sprintf("%X", sha256.Sum256(txBytes))),
+		attribute.Int64("blockHeight", ctx.BlockHeight()),
+	)
 
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted uint64
 
+	if mode == runTxModeSimulate && app.simulationTracer != nil {
+		tracer := ctx.TxTracer()
+		if tracer == nil {
+			tracer = sdk.NewTxTracer()
+			ctx = ctx.WithTxTracer(tracer)
+		}
+		defer func() { app.simulationTracer(tracer.Trace()) }()
+	}
+
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
@@ -883,6 +1236,16 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		return gInfo, nil, nil, -1, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "no block gas left to run tx")
 	}
 
+	// with the ante prepass, the ante stage of a tx runs apart from its msgs
+	var prepass *antePrepass
+	if mode == runTxModeDeliver {
+		prepass = antePrepassFrom(ctx)
+	}
+	if prepass != nil && !prepass.msgs {
+		// deferred first, so that it sees the gas info and error set on recovery
+		defer func() { prepass.record(ctx.TxIndex(), gInfo, anteEvents, priority, err) }()
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			acltypes.SendAllSignalsForTx(ctx.TxCompletionChannels())
@@ -916,7 +1279,7 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		defer consumeBlockGas()
 	}
 
-	tx, err := app.txDecoder(txBytes)
+	tx, err := app.decodeTx(ctx, txBytes)
 	if err != nil {
 		return sdk.GasInfo{}, nil, nil, 0, err
 	}
@@ -927,7 +1290,15 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		return sdk.GasInfo{}, nil, nil, 0, err
 	}
 
-	if app.anteHandler != nil {
+	if prepass != nil && prepass.msgs {
+		outcome := prepass.outcomes[ctx.TxIndex()]
+		ctx = outcome.msgContext(ctx)
+		gasWanted, priority = outcome.gasWanted, outcome.priority
+		if outcome.err != nil {
+			return gInfo, nil, nil, 0, outcome.err
+		}
+		anteEvents = outcome.events
+	} else if app.anteHandler != nil {
 		// trace AnteHandler
 		_, anteSpan := app.TracingInfo.StartWithContext("AnteHandler", ctx.TraceSpanContext())
 		defer anteSpan.End()
@@ -943,7 +1314,10 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		// writes do not happen if aborted/failed.  This may have some
 		// performance benefits, but it'll be more difficult to get right.
 		anteCtx, msCache = app.cacheTxContext(ctx, txBytes)
-		anteCtx = anteCtx.WithEventManager(sdk.NewEventManager())
+		anteCtx = anteCtx.WithEventManager(sdk.NewEventManagerWithLimits(app.eventLimits))
+		if tracer := ctx.TxTracer(); tracer != nil {
+			tracer.StartPhase(sdk.TxTraceAntePhase)
+		}
 		newCtx, err := app.anteHandler(anteCtx, tx, mode == runTxModeSimulate)
 
 		if !newCtx.IsZero() {
@@ -962,6 +1336,9 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		}
 
 		events := ctx.EventManager().Events()
+		if tracer := ctx.TxTracer(); tracer != nil {
+			tracer.EndPhase(ctx.GasMeter().GasConsumed(), events.ToABCIEvents(), err)
+		}
 
 		// GasMeter expected to be set in AnteHandler
 		gasWanted = ctx.GasMeter().Limit()
@@ -986,11 +1363,28 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		}
 
 		priority = ctx.Priority()
+		if app.txPriorityHandler != nil && (mode == runTxModeCheck || mode == runTxModeReCheck) {
+			if priority, err = app.txPriorityHandler(ctx, tx); err != nil {
+				return gInfo, nil, nil, 0, err
+			}
+		}
+		if mode == runTxModeCheck && app.mempool != nil {
+			// a tx that the mempool has no room for must not reach the
+			// check state, where it would advance the sequence of its signers
+			if err := app.mempool.insert(tx, txBytes, priority); err != nil {
+				return gInfo, nil, nil, 0, err
+			}
+		}
 		msCache.Write()
 		anteEvents = events.ToABCIEvents()
 		anteSpan.End()
 	}
 
+	if prepass != nil && !prepass.msgs {
+		// the msgs run once the ante stage of every tx of the block has
+		return gInfo, &sdk.Result{}, anteEvents, priority, nil
+	}
+
 	// Create a new Context based off of the existing Context with a MultiStore branch
 	// in case message processing fails. At this point, the MultiStore
 	// is a branch of a branch.
@@ -1005,13 +1399,18 @@ func (app *BaseApp) runTx(ctx sdk.Context, mode runTxMode, txBytes []byte) (gInf
 		// When block gas exceeds, it'll panic and won't commit the cached store.
 		consumeBlockGas()
 
+		_, writeSpan := app.TracingInfo.StartWithContext("WriteTxCache", ctx.TraceSpanContext())
 		msCache.Write()
+		writeSpan.End()
 	}
 	// we do this since we will only be looking at result in DeliverTx
 	if result != nil && len(anteEvents) > 0 {
 		// append the events in the order of occurrence
 		result.Events = append(anteEvents, result.Events...)
 	}
+	if result != nil {
+		result.Events = app.eventLimits.Apply(result.Events)
+	}
 	return gInfo, result, anteEvents, priority, err
 }
 
@@ -1057,8 +1456,17 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 			err          error
 		)
 
+		msgSpanCtx, msgSpan := app.TracingInfo.StartWithContext("RunMsg", ctx.TraceSpanContext())
+		msgSpan.SetAttributes(attribute.String("msgType", sdk.MsgTypeURL(msg)), attribute.Int("msgIndex", i))
 		msgCtx, msgMsCache := app.cacheTxContext(ctx, []byte{})
-		msgCtx = msgCtx.WithMessageIndex(i)
+		// the events of the msg are bounded as they are emitted
+		msgCtx = msgCtx.WithMessageIndex(i).WithTraceSpanContext(msgSpanCtx).WithEventManager(sdk.NewEventManagerWithLimits(app.eventLimits))
+
+		tracer := ctx.TxTracer()
+		gasBefore := msgCtx.GasMeter().GasConsumed()
+		if tracer != nil {
+			tracer.StartPhase(sdk.MsgTypeURL(msg))
+		}
 
 		startTime := time.Now()
 		if handler := app.msgServiceRouter.Handler(msg); handler != nil {
@@ -1076,10 +1484,15 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 			// proto messages and has registered all `Msg services`, then this
 			// path should never be called, because all those Msgs should be
 			// registered within the `msgServiceRouter` already.
+			if err := app.msgServiceRouter.checkCircuitBreaker(msgCtx, sdk.MsgTypeURL(msg)); err != nil {
+				msgSpan.End()
+				return nil, sdkerrors.Wrapf(err, "message index: %d", i)
+			}
 			msgRoute := legacyMsg.Route()
 			eventMsgName = legacyMsg.Type()
 			handler := app.router.Route(msgCtx, msgRoute)
 			if handler == nil {
+				msgSpan.End()
 				return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized message route: %s; message index: %d", msgRoute, i)
 			}
 			msgResult, err = handler(msgCtx, msg)
@@ -1089,9 +1502,19 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 				[]metrics.Label{{Name: "type", Value: eventMsgName}},
 			)
 		} else {
+			msgSpan.End()
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "can't route message %+v", msg)
 		}
 
+		if tracer != nil {
+			var msgEvents []abci.Event
+			if msgResult != nil {
+				msgEvents = msgResult.Events
+			}
+			tracer.EndPhase(msgCtx.GasMeter().GasConsumed()-gasBefore, msgEvents, err)
+		}
+		msgSpan.End()
+
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "failed to execute message; message index: %d", i)
 		}
@@ -1105,7 +1528,12 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 		//
 		// Note: Each message result's data must be length-prefixed in order to
 		// separate each result.
-		events = events.AppendEvents(msgEvents)
+		if app.occEnabled && mode == runTxModeDeliver {
+			// the msg logs already have the index, see sdk.SequenceEvents
+			events = events.AppendEvents(msgEvents.WithMsgIndex(i))
+		} else {
+			events = events.AppendEvents(msgEvents)
+		}
 
 		txMsgData.Data = append(txMsgData.Data, &sdk.MsgData{MsgType: sdk.MsgTypeURL(msg), Data: msgResult.Data})
 		msgLogs = append(msgLogs, sdk.NewABCIMessageLog(uint32(i), msgResult.Log, msgEvents))
@@ -1123,6 +1551,7 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 				ctx.Logger().Info((fmt.Sprintf("eventMsgName=%s Missing Access Operation:%s ", eventMsgName, op.String())))
 				op.EmitValidationFailMetrics()
 			}
+			events = events.AppendEvents(app.handleDependencyMismatch(ctx, i, msg, missingAccessOps))
 			errMessage := fmt.Sprintf("Invalid Concurrent Execution messageIndex=%d, missing %d access operations", i, len(missingAccessOps))
 			// we need to bubble up the events for inspection
 			return &sdk.Result{
@@ -1171,28 +1600,80 @@ func (app *BaseApp) Close() error {
 	// and metadata in a non-atomic way
 	app.commitLock.Lock()
 	defer app.commitLock.Unlock()
+	app.waitForCommit()
+	app.stopPruning()
+	if err := app.flushCommits(); err != nil {
+		return err
+	}
+	// let the streaming services notified of commits deliver the committed blocks
+	// they still hold
+	for _, streamingListener := range app.abciListeners {
+		if _, ok := streamingListener.(CommitListener); !ok {
+			continue
+		}
+		if closer, ok := streamingListener.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				app.logger.Error("failed to close streaming service", "err", err)
+			}
+		}
+	}
 	if err := app.appStore.db.Close(); err != nil {
 		return err
 	}
+	if app.snapshotManager == nil {
+		return nil
+	}
 	return app.snapshotManager.Close()
 }
 
 func (app *BaseApp) ReloadDB() error {
+	app.waitForCommit()
+	app.stopPruning()
+	if err := app.flushCommits(); err != nil {
+		return err
+	}
 	if err := app.db.Close(); err != nil {
 		return err
 	}
-	db, err := sdk.NewLevelDB("application", app.TmConfig.DBDir())
+	db, err := sdk.NewDB("application", app.dbBackend, app.TmConfig.DBDir())
 	if err != nil {
 		return err
 	}
 	app.db = db
 	app.cms = store.NewCommitMultiStore(db)
+	if app.asyncCommitBuffer > 0 {
+		app.cms.(*rootmulti.Store).SetAsyncCommit(app.asyncCommitBuffer)
+	}
+	if app.pruningBackgroundInterval > 0 {
+		app.cms.(*rootmulti.Store).SetBackgroundPruning(app.pruningBatchSize, app.pruningBackgroundInterval)
+	}
+	if app.historicalStoreCacheSize > 0 {
+		app.cms.(*rootmulti.Store).SetHistoricalStoreCacheSize(app.historicalStoreCacheSize)
+	}
+	app.cms.(*rootmulti.Store).SetFastNodeMigration(app.fastNodeMigration)
 	if app.snapshotManager != nil {
 		app.snapshotManager.SetMultiStore(app.cms)
 	}
 	return nil
 }
 
+// flushCommits waits for the versions committed in the background to be
+// written to the application database.
+func (app *BaseApp) flushCommits() error {
+	if rs, ok := app.cms.(*rootmulti.Store); ok {
+		return rs.FlushCommits()
+	}
+	return nil
+}
+
+// stopPruning stops the background pruner so that it does not write to the
+// application database once it is closed.
+func (app *BaseApp) stopPruning() {
+	if rs, ok := app.cms.(*rootmulti.Store); ok {
+		rs.StopPruning()
+	}
+}
+
 func (app *BaseApp) GetCheckCtx() sdk.Context {
 	return app.checkState.ctx
 }
@@ -3,7 +3,9 @@ package baseapp
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +14,8 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	store "github.com/cosmos/cosmos-sdk/store/types"
 	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -127,6 +131,112 @@ func TestLoadVersionPruning(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(7), lastCommitID)
 }
 
+func TestLoadVersionAsyncCommit(t *testing.T) {
+	logger := log.NewNopLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	app := NewBaseApp(name, logger, db, nil, nil, &testutil.TestAppOpts{}, SetAsyncCommitBuffer(2))
+	capKey := sdk.NewKVStoreKey("key1")
+	app.MountStores(capKey)
+	require.NoError(t, app.LoadLatestVersion())
+
+	for i := int64(1); i <= 5; i++ {
+		app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: i})
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+	}
+	lastCommitID := app.LastCommitID()
+	require.NoError(t, app.flushCommits())
+
+	// the versions committed in the background are all on disk
+	app = NewBaseApp(name, logger, db, nil, nil, &testutil.TestAppOpts{})
+	app.MountStores(capKey)
+	require.NoError(t, app.LoadLatestVersion())
+	testLoadVersionHelper(t, app, int64(5), lastCommitID)
+	require.Equal(t, lastCommitID, app.LastCommitID())
+}
+
+func TestLoadVersionBackgroundPruning(t *testing.T) {
+	logger := log.NewNopLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	app := NewBaseApp(name, logger, db, nil, nil, &testutil.TestAppOpts{},
+		SetPruning(store.PruneEverything), SetBackgroundPruning(0, time.Millisecond))
+	capKey := sdk.NewKVStoreKey("key1")
+	app.MountStores(capKey)
+	require.NoError(t, app.LoadLatestVersion())
+
+	for i := int64(1); i <= 10; i++ {
+		app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: i})
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+	}
+
+	// the heights below the kept ones are pruned outside of Commit
+	iavlStore := app.cms.(*rootmulti.Store).GetCommitKVStore(capKey).(*iavl.Store)
+	require.Eventually(t, func() bool {
+		for v := int64(1); v <= 7; v++ {
+			if iavlStore.VersionExists(v) {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+	for _, v := range []int64{8, 9, 10} {
+		require.True(t, iavlStore.VersionExists(v))
+	}
+	app.stopPruning()
+}
+
+// commitStreamingService records the heights it is notified of as a
+// CommitListener.
+type commitStreamingService struct {
+	heights []int64
+	closed  bool
+}
+
+func (s *commitStreamingService) Stream(*sync.WaitGroup) error { return nil }
+
+func (s *commitStreamingService) Listeners() map[store.StoreKey][]store.WriteListener { return nil }
+
+func (s *commitStreamingService) ListenBeginBlock(sdk.Context, abci.RequestBeginBlock, abci.ResponseBeginBlock) error {
+	return nil
+}
+
+func (s *commitStreamingService) ListenEndBlock(sdk.Context, abci.RequestEndBlock, abci.ResponseEndBlock) error {
+	return nil
+}
+
+func (s *commitStreamingService) ListenDeliverTx(sdk.Context, abci.RequestDeliverTx, abci.ResponseDeliverTx) error {
+	return nil
+}
+
+func (s *commitStreamingService) ListenCommit(_ context.Context, height int64) error {
+	s.heights = append(s.heights, height)
+	return nil
+}
+
+func (s *commitStreamingService) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestCommitListener(t *testing.T) {
+	app := setupBaseApp(t)
+	service := &commitStreamingService{}
+	app.SetStreamingService(service)
+
+	for i := int64(1); i <= 3; i++ {
+		app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: i})
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+	}
+	require.Equal(t, []int64{1, 2, 3}, service.heights)
+
+	require.NoError(t, app.Close())
+	require.True(t, service.closed)
+}
+
 func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, expectedID sdk.CommitID) {
 	lastHeight := app.LastBlockHeight()
 	lastID := app.LastCommitID()
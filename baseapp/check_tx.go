@@ -0,0 +1,107 @@
+package baseapp
+
+import (
+	"sort"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// accountLocks serializes the holders of the same accounts, while holders of
+// disjoint accounts proceed concurrently.
+type accountLocks struct {
+	mtx   sync.Mutex
+	locks map[string]*accountLock
+}
+
+type accountLock struct {
+	sync.Mutex
+	// holders is the number of goroutines holding or waiting for the lock,
+	// which is dropped once there are none
+	holders int
+}
+
+func newAccountLocks() *accountLocks {
+	return &accountLocks{locks: make(map[string]*accountLock)}
+}
+
+// lock locks the given accounts and returns the function unlocking them. The
+// accounts are locked in order, so that holders of overlapping accounts cannot
+// deadlock.
+func (l *accountLocks) lock(accounts []string) (unlock func()) {
+	sort.Strings(accounts)
+	unique := accounts[:0]
+	for _, account := range accounts {
+		if len(unique) == 0 || account != unique[len(unique)-1] {
+			unique = append(unique, account)
+		}
+	}
+
+	held := make([]*accountLock, len(unique))
+	for i, account := range unique {
+		l.mtx.Lock()
+		lock, ok := l.locks[account]
+		if !ok {
+			lock = &accountLock{}
+			l.locks[account] = lock
+		}
+		lock.holders++
+		l.mtx.Unlock()
+
+		lock.Lock()
+		held[i] = lock
+	}
+
+	return func() {
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+		for i, lock := range held {
+			lock.Unlock()
+			if lock.holders--; lock.holders == 0 {
+				delete(l.locks, unique[i])
+			}
+		}
+	}
+}
+
+// lockCheckTx serializes the CheckTx of txBytes with every other CheckTx, or,
+// with concurrent CheckTx enabled, only with the CheckTxs of the txs sharing
+// one of its senders, so that their sequences are checked and incremented in
+// order. The check state written by txs of different senders, e.g. the balance
+// of the fee collector, may then interleave, which the checks of txs do not
+// depend on.
+func (app *BaseApp) lockCheckTx(ctx sdk.Context, txBytes []byte) (unlock func()) {
+	if !app.concurrentCheckTx {
+		app.checkTxLock.Lock()
+		return app.checkTxLock.Unlock
+	}
+	tx, err := app.decodeTx(ctx, txBytes)
+	if err != nil {
+		// runTx rejects the tx before it reads any state
+		return func() {}
+	}
+	return app.checkTxAccounts.lock(txSenders(tx))
+}
+
+// txSenders returns the addresses of the accounts whose state tx changes in
+// CheckTx: its signers, whose sequences are incremented, and its fee payer.
+func txSenders(tx sdk.Tx) []string {
+	var senders []string
+	if sigTx, ok := tx.(sdk.TxWithSigners); ok {
+		for _, signer := range sigTx.GetSigners() {
+			senders = append(senders, string(signer))
+		}
+	} else {
+		for _, msg := range tx.GetMsgs() {
+			for _, signer := range msg.GetSigners() {
+				senders = append(senders, string(signer))
+			}
+		}
+	}
+	if feeTx, ok := tx.(sdk.FeeTx); ok {
+		if payer := feeTx.FeePayer(); payer != nil {
+			senders = append(senders, string(payer))
+		}
+	}
+	return senders
+}
@@ -0,0 +1,190 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// senderMsg is a msg signed by its sender, which is the only content of the
+// txs of TestConcurrentCheckTx.
+type senderMsg struct {
+	sender sdk.AccAddress
+}
+
+func (msg senderMsg) Reset()                       {}
+func (msg senderMsg) String() string               { return msg.sender.String() }
+func (msg senderMsg) ProtoMessage()                {}
+func (msg senderMsg) ValidateBasic() error         { return nil }
+func (msg senderMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.sender} }
+
+type senderTx struct {
+	senderMsg
+}
+
+func (tx senderTx) GetMsgs() []sdk.Msg { return []sdk.Msg{tx.senderMsg} }
+
+func TestAccountLocks(t *testing.T) {
+	locks := newAccountLocks()
+	accounts := []string{"a", "b", "c", "d"}
+	var holders [4]int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		// overlapping sets of accounts, listed in any order and with repeats
+		held := []int{i % 4, (i + 1) % 4, i % 4}
+		if i%2 == 0 {
+			held = []int{(i + 3) % 4, i % 4}
+		}
+		go func() {
+			defer wg.Done()
+			var names []string
+			distinct := make(map[int]struct{})
+			for _, account := range held {
+				names = append(names, accounts[account])
+				distinct[account] = struct{}{}
+			}
+			unlock := locks.lock(names)
+			for account := range distinct {
+				require.Equal(t, int32(1), atomic.AddInt32(&holders[account], 1))
+			}
+			time.Sleep(time.Millisecond)
+			for account := range distinct {
+				atomic.AddInt32(&holders[account], -1)
+			}
+			unlock()
+		}()
+	}
+	wg.Wait()
+	require.Empty(t, locks.locks)
+}
+
+func TestConcurrentCheckTx(t *testing.T) {
+	senders := []sdk.AccAddress{[]byte("sender-1"), []byte("sender-2"), []byte("sender-3"), []byte("sender-4")}
+	txDecoder := func(txBytes []byte) (sdk.Tx, error) {
+		for _, sender := range senders {
+			if string(txBytes[:len(sender)]) == string(sender) {
+				return senderTx{senderMsg{sender}}, nil
+			}
+		}
+		return nil, errors.New("unknown sender")
+	}
+
+	for _, concurrent := range []bool{false, true} {
+		t.Run(fmt.Sprintf("concurrent=%t", concurrent), func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			senderInFlight := make(map[string]*int32)
+			for _, sender := range senders {
+				senderInFlight[sender.String()] = new(int32)
+			}
+
+			app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), txDecoder, nil, &testutil.TestAppOpts{}, SetConcurrentCheckTx(concurrent))
+			app.MountStores(capKey1)
+			app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+			app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				sender := tx.GetMsgs()[0].GetSigners()[0]
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+				require.Equal(t, int32(1), atomic.AddInt32(senderInFlight[sender.String()], 1))
+				defer atomic.AddInt32(senderInFlight[sender.String()], -1)
+
+				// a read-modify-write of the sequence of the sender, which
+				// would lose increments if the txs of a sender interleaved
+				store := ctx.KVStore(capKey1)
+				seq := getIntFromStore(store, sender)
+				time.Sleep(time.Millisecond)
+				setIntOnStore(store, sender, seq+1)
+				return ctx, nil
+			})
+			require.NoError(t, app.LoadLatestVersion())
+			app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				for _, sender := range senders {
+					wg.Add(1)
+					go func(txBytes []byte) {
+						defer wg.Done()
+						_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: txBytes})
+						require.NoError(t, err)
+					}(append(append([]byte(nil), sender...), byte(i)))
+				}
+			}
+			wg.Wait()
+
+			store := app.checkState.ctx.KVStore(capKey1)
+			for _, sender := range senders {
+				require.Equal(t, int64(20), getIntFromStore(store, sender))
+			}
+			if concurrent {
+				require.Greater(t, maxInFlight, int32(1))
+			} else {
+				require.Equal(t, int32(1), maxInFlight)
+			}
+			require.Empty(t, app.checkTxAccounts.locks)
+		})
+	}
+}
+
+func TestSignatureCache(t *testing.T) {
+	txDecoder := func(txBytes []byte) (sdk.Tx, error) {
+		return senderTx{senderMsg{txBytes}}, nil
+	}
+	// the "signature" of a tx is its bytes, which is verified by the ante
+	// handler unless it is in the tx cache
+	verifications := make(map[bool]int)
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), txDecoder, nil, &testutil.TestAppOpts{}, SetSignatureCacheSize(10))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		if key := string(ctx.TxBytes()); !ctx.TxCache().IsSignatureVerified(key) {
+			verifications[ctx.IsCheckTx()]++
+			ctx.TxCache().SetSignatureVerified(key)
+		}
+		return ctx, nil
+	})
+	app.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+		for _, tx := range req.Txs {
+			app.DeliverTx(ctx, abci.RequestDeliverTx{Tx: tx})
+		}
+		return &abci.ResponseFinalizeBlock{}, nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	for _, tx := range []string{"tx1", "tx2"} {
+		_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte(tx)})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, verifications[true])
+
+	// only the tx missing from the local mempool is verified by the block
+	_, err := app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 1, Txs: [][]byte{[]byte("tx1"), []byte("tx3")}})
+	require.NoError(t, err)
+	require.Equal(t, 1, verifications[false])
+
+	// and the signature of the committed tx is evicted
+	require.Equal(t, 2, app.sigCache.Len())
+	app.SetDeliverStateToCommit()
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, app.sigCache.Len())
+}
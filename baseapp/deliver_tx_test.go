@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -23,8 +24,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
 	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	store "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/tasks"
 	"github.com/cosmos/cosmos-sdk/testutil"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -1535,6 +1539,370 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+func TestDeliverTxOccEnabled(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	newApp := func(options ...func(*BaseApp)) *BaseApp {
+		anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, []byte("ante-key"))) }
+		routerOpt := func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, []byte("deliver-key"))))
+		}
+		app := setupBaseApp(t, append(options, anteOpt, routerOpt)...)
+		app.InitChain(context.Background(), &abci.RequestInitChain{})
+		return app
+	}
+	app, occApp := newApp(), newApp(SetOccEnabled(true))
+
+	for blockN := 0; blockN < 3; blockN++ {
+		header := tmproto.Header{Height: int64(blockN) + 1}
+		for _, app := range []*BaseApp{app, occApp} {
+			app.setDeliverState(header)
+			app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+			app.BeginBlock(app.deliverState.ctx, abci.RequestBeginBlock{Header: header})
+			for i := 0; i < 5; i++ {
+				counter := int64(blockN*5 + i)
+				txBytes, err := codec.Marshal(newTxCounter(counter, counter))
+				require.NoError(t, err)
+				res := app.DeliverTx(app.deliverState.ctx, abci.RequestDeliverTx{Tx: txBytes})
+				require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+			}
+			app.EndBlock(app.deliverState.ctx, abci.RequestEndBlock{})
+			app.SetDeliverStateToCommit()
+			app.Commit(context.Background())
+		}
+		// the concurrency-safe branch of the deliver state commits the same state
+		require.Equal(t, app.LastCommitID(), occApp.LastCommitID())
+	}
+
+	occApp.setDeliverState(tmproto.Header{Height: 4})
+	require.IsType(t, &cachemulti.BranchStore{}, occApp.deliverState.ms)
+	require.IsType(t, &cachekv.ConcurrentStore{}, occApp.deliverState.ms.GetKVStore(capKey1))
+}
+
+// deliverTxRecorder records the txs passed to the ABCI listeners.
+type deliverTxRecorder struct {
+	commitStreamingService
+	txs [][]byte
+}
+
+func (r *deliverTxRecorder) ListenDeliverTx(_ sdk.Context, req abci.RequestDeliverTx, _ abci.ResponseDeliverTx) error {
+	r.txs = append(r.txs, req.Tx)
+	return nil
+}
+
+func TestDeliverTxBatch(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	totalKey := []byte("total")
+	newApp := func(options ...func(*BaseApp)) (*BaseApp, *deliverTxRecorder) {
+		anteOpt := func(bapp *BaseApp) {
+			bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil
+			})
+		}
+		routerOpt := func(bapp *BaseApp) {
+			// every tx increments the same total, so that they all conflict
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+				counter := msg.(*msgCounter).Counter
+				store := ctx.KVStore(capKey1)
+				total := getIntFromStore(store, totalKey)
+				setIntOnStore(store, totalKey, total+1)
+				if counter%4 == 3 {
+					return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+				}
+				setIntOnStore(store, []byte(fmt.Sprintf("tx-%d", counter)), total)
+				return &sdk.Result{Data: []byte(fmt.Sprint(total))}, nil
+			}))
+		}
+		app := setupBaseApp(t, append(options, anteOpt, routerOpt)...)
+		recorder := &deliverTxRecorder{}
+		app.SetStreamingService(recorder)
+		app.InitChain(context.Background(), &abci.RequestInitChain{})
+		return app, recorder
+	}
+
+	seqApp, seqRecorder := newApp()
+	occApp, occRecorder := newApp(SetOccEnabled(true), SetConcurrencyWorkers(4))
+	fallbackApp, fallbackRecorder := newApp(SetOccEnabled(true), SetConcurrencyWorkers(4))
+	require.Nil(t, seqApp.scheduler)
+	require.NotNil(t, occApp.scheduler)
+
+	var txs [][]byte
+	var total int
+	for blockN := 0; blockN < 2; blockN++ {
+		header := tmproto.Header{Height: int64(blockN) + 1}
+		batch := sdk.DeliverTxBatchRequest{}
+		for i := 0; i < 10; i++ {
+			txBytes, err := codec.Marshal(newTxCounter(int64(blockN*10+i), int64(blockN*10+i)))
+			require.NoError(t, err)
+			txs = append(txs, txBytes)
+			batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: txBytes}})
+		}
+
+		var results [][]*sdk.DeliverTxResult
+		for _, app := range []*BaseApp{seqApp, occApp, fallbackApp} {
+			app.setDeliverState(header)
+			app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+			ctx := app.deliverState.ctx
+			if app == fallbackApp {
+				// the scheduler fails on a cancelled context
+				goCtx, cancel := context.WithCancel(context.Background())
+				cancel()
+				ctx = ctx.WithContext(goCtx)
+			}
+			res := app.DeliverTxBatch(ctx, batch)
+			require.Len(t, res.Results, 10)
+			results = append(results, res.Results)
+			switch app {
+			case seqApp:
+				require.Equal(t, ModeReasonOccNotEnabled, app.ModeSelectionReason())
+			case occApp:
+				require.Contains(t, []string{tasks.ModeReasonConcurrent, tasks.ModeReasonMaxRoundsExceeded, tasks.ModeReasonMaxIncarnationsExceeded}, app.ModeSelectionReason())
+			case fallbackApp:
+				require.Equal(t, ModeReasonSchedulerFailed, app.ModeSelectionReason())
+			}
+			app.SetDeliverStateToCommit()
+			app.Commit(context.Background())
+		}
+
+		// the responses are in tx order, as if the txs were delivered sequentially
+		for i, result := range results[0] {
+			if (blockN*10+i)%4 == 3 {
+				// the writes of the failed txs are discarded
+				require.False(t, result.Response.IsOK())
+				continue
+			}
+			require.True(t, result.Response.IsOK(), result.Response.Log)
+			var data sdk.TxMsgData
+			require.NoError(t, data.Unmarshal(result.Response.Data))
+			require.Equal(t, []byte(fmt.Sprint(total)), data.Data[0].Data)
+			total++
+		}
+		requireSequencedResults(t, results[0], results[1])
+		requireSequencedResults(t, results[0], results[2])
+		require.Equal(t, seqApp.LastCommitID(), occApp.LastCommitID())
+		require.Equal(t, seqApp.LastCommitID(), fallbackApp.LastCommitID())
+	}
+
+	// the listeners only see the final execution of each tx
+	require.Equal(t, txs, seqRecorder.txs)
+	require.Equal(t, txs, occRecorder.txs)
+	require.Equal(t, txs, fallbackRecorder.txs)
+}
+
+func TestDeliverTxResourceEvents(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	totalKey := []byte("total")
+	newApp := func(options ...func(*BaseApp)) *BaseApp {
+		anteOpt := func(bapp *BaseApp) {
+			bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil
+			})
+		}
+		routerOpt := func(bapp *BaseApp) {
+			// every tx increments the same total, so that they all conflict
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+				counter := msg.(*msgCounter).Counter
+				store := ctx.KVStore(capKey1)
+				total := sdk.BigEndianToUint64(store.Get(totalKey))
+				store.Set(totalKey, sdk.Uint64ToBigEndian(total+1))
+				if counter%4 == 3 {
+					return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+				}
+				store.Delete([]byte(fmt.Sprintf("tx-%d", counter-1)))
+				store.Set([]byte(fmt.Sprintf("tx-%d", counter)), sdk.Uint64ToBigEndian(total))
+				return &sdk.Result{}, nil
+			}))
+		}
+		app := setupBaseApp(t, append(options, SetTxResourceEvents(true), anteOpt, routerOpt)...)
+		app.InitChain(context.Background(), &abci.RequestInitChain{})
+		return app
+	}
+	resources := func(res abci.ResponseDeliverTx) map[string]string {
+		last := res.Events[len(res.Events)-1]
+		require.Equal(t, sdk.EventTypeTxResources, last.Type)
+		attrs := make(map[string]string)
+		for _, attr := range last.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		return attrs
+	}
+
+	seqApp, occApp := newApp(), newApp(SetOccEnabled(true), SetConcurrencyWorkers(4))
+	batch := sdk.DeliverTxBatchRequest{}
+	for i := 0; i < 8; i++ {
+		txBytes, err := codec.Marshal(newTxCounter(int64(i), int64(i)))
+		require.NoError(t, err)
+		batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: txBytes}})
+	}
+	var results [][]*sdk.DeliverTxResult
+	for _, app := range []*BaseApp{seqApp, occApp} {
+		app.setDeliverState(tmproto.Header{Height: 1})
+		app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		results = append(results, app.DeliverTxBatch(app.deliverState.ctx, batch).Results)
+	}
+
+	for i, result := range results[0] {
+		attrs := resources(result.Response)
+		require.Equal(t, "1", attrs[sdk.AttributeKeyReads])
+		require.Equal(t, "1", attrs[sdk.AttributeKeyIncarnations])
+		if i%4 == 3 {
+			// the writes of the failed txs are counted, although discarded
+			require.Equal(t, "1", attrs[sdk.AttributeKeyWrites])
+			require.Equal(t, "13", attrs[sdk.AttributeKeyBytesWritten])
+			continue
+		}
+		require.Equal(t, "3", attrs[sdk.AttributeKeyWrites])
+		// the total and the written key with their 8-byte values, and the
+		// deleted key
+		bytesWritten := len(totalKey) + 8 + len(fmt.Sprintf("tx-%d", i-1)) + len(fmt.Sprintf("tx-%d", i)) + 8
+		require.Equal(t, fmt.Sprint(bytesWritten), attrs[sdk.AttributeKeyBytesWritten])
+
+		// the concurrent execution accesses the same keys, but may execute the
+		// txs more than once
+		occAttrs := resources(results[1][i].Response)
+		incarnations, err := strconv.Atoi(occAttrs[sdk.AttributeKeyIncarnations])
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, incarnations, 1)
+		delete(attrs, sdk.AttributeKeyIncarnations)
+		delete(occAttrs, sdk.AttributeKeyIncarnations)
+		delete(occAttrs, sdk.AttributeKeyTxIndex)
+		require.Equal(t, attrs, occAttrs)
+	}
+}
+
+func TestDeliverTxEventLimits(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+			ctx.EventManager().EmitEvent(sdk.NewEvent("ante", sdk.NewAttribute("a", "1"), sdk.NewAttribute("b", "2")))
+			return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			// the events of the msgs are bounded as they are emitted
+			require.Equal(t, sdk.EventLimits{MaxEvents: 3, MaxAttributes: 1}, ctx.EventManager().Limits())
+			for i := 0; i < 5; i++ {
+				ctx.EventManager().EmitEvent(sdk.NewEvent("msg", sdk.NewAttribute("index", fmt.Sprint(i))))
+			}
+			return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+		}))
+	}
+	app := setupBaseApp(t, SetEventLimits(3, 1), anteOpt, routerOpt)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	app.setDeliverState(tmproto.Header{Height: 1})
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+
+	txBytes, err := codec.Marshal(newTxCounter(0, 0, 1))
+	require.NoError(t, err)
+	res := app.DeliverTx(app.deliverState.ctx, abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, res.IsOK(), res.Log)
+
+	// out of the ante event and the message event and 5 events of each of the
+	// 2 msgs, the first 3 are kept
+	require.Len(t, res.Events, 4)
+	require.Equal(t, "ante", res.Events[0].Type)
+	require.Len(t, res.Events[0].Attributes, 1)
+	require.Equal(t, sdk.EventTypeMessage, res.Events[1].Type)
+	require.Equal(t, "msg", res.Events[2].Type)
+	truncated := res.Events[3]
+	require.Equal(t, sdk.EventTypeEventsTruncated, truncated.Type)
+	require.Equal(t, sdk.AttributeKeyDroppedEvents, string(truncated.Attributes[0].Key))
+	require.Equal(t, "10", string(truncated.Attributes[0].Value))
+	require.Equal(t, sdk.AttributeKeyDroppedAttributes, string(truncated.Attributes[1].Key))
+	require.Equal(t, "1", string(truncated.Attributes[1].Value))
+}
+
+func TestDeliverTxBatchExecutionParams(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+			return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			store := ctx.KVStore(capKey1)
+			setIntOnStore(store, []byte("total"), getIntFromStore(store, []byte("total"))+1)
+			return &sdk.Result{}, nil
+		}))
+	}
+	app := setupBaseApp(t, SetOccEnabled(true), SetConcurrencyWorkers(4), anteOpt, routerOpt)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	// deliverBlock delivers a block of txs txs and returns the number of txs
+	// the scheduler processed last
+	var counter int64
+	deliverBlock := func(height int64, txs int, ep *ExecutionParams) int {
+		app.setDeliverState(tmproto.Header{Height: height})
+		app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		if ep != nil {
+			app.paramStore.Set(app.deliverState.ctx, ParamStoreKeyExecutionParams, ep)
+		}
+		batch := sdk.DeliverTxBatchRequest{}
+		for i := 0; i < txs; i++ {
+			txBytes, err := codec.Marshal(newTxCounter(counter, counter))
+			require.NoError(t, err)
+			counter++
+			batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: txBytes}})
+		}
+		for _, res := range app.DeliverTxBatch(app.deliverState.ctx, batch).Results {
+			require.True(t, res.Response.IsOK(), res.Response.Log)
+		}
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+		return app.scheduler.Stats().Txs
+	}
+
+	require.Equal(t, 1, deliverBlock(1, 1, &ExecutionParams{SequentialFromHeight: 3}))
+	require.Equal(t, 2, deliverBlock(2, 2, nil))
+	require.Equal(t, tasks.ModeReasonConcurrent, app.ModeSelectionReason())
+	// the txs are delivered sequentially from height 3
+	require.Equal(t, 2, deliverBlock(3, 3, nil))
+	require.Equal(t, ModeReasonSequentialFromHeight, app.ModeSelectionReason())
+	require.Equal(t, 2, deliverBlock(4, 4, nil))
+	require.Equal(t, ExecutionParams{SequentialFromHeight: 3}, app.GetExecutionParams(app.NewContext(true, tmproto.Header{})))
+	require.Equal(t, 5, deliverBlock(5, 5, &ExecutionParams{}))
+	require.Equal(t, tasks.ModeReasonConcurrent, app.ModeSelectionReason())
+
+	store := app.cms.GetKVStore(capKey1)
+	require.Equal(t, int64(15), getIntFromStore(store, []byte("total")))
+}
+
+// requireSequencedResults checks that the results of a batch delivered with OCC
+// enabled are the sequential ones, but for the indexes of the tx and of the msg
+// the events are tagged with.
+func requireSequencedResults(t *testing.T, expected, actual []*sdk.DeliverTxResult) {
+	require.Len(t, actual, len(expected))
+	for i, res := range actual {
+		untagged := res.Response
+		untagged.Events = make([]abci.Event, len(res.Response.Events))
+		for j, e := range res.Response.Events {
+			untagged.Events[j] = abci.Event{Type: e.Type, Attributes: []abci.EventAttribute{}}
+			txIndex, msgIndex := "", ""
+			for _, attr := range e.Attributes {
+				switch string(attr.Key) {
+				case sdk.AttributeKeyTxIndex:
+					txIndex = string(attr.Value)
+				case sdk.AttributeKeyMsgIndex:
+					msgIndex = string(attr.Value)
+				default:
+					untagged.Events[j].Attributes = append(untagged.Events[j].Attributes, attr)
+				}
+			}
+			require.Equal(t, fmt.Sprint(i), txIndex)
+			if e.Type == sdk.EventTypeMessage {
+				require.Equal(t, "0", msgIndex)
+			}
+		}
+		require.Equal(t, expected[i].Response, untagged)
+	}
+}
+
 func TestOptionFunction(t *testing.T) {
 	logger := defaultLogger()
 	db := dbm.NewMemDB()
@@ -0,0 +1,48 @@
+package baseapp
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// DependencyMismatchHandler is called with a msg whose store accesses exceeded
+// the access operations declared for it, e.g. to stop trusting the dependency
+// mapping at fault. The events it returns are added to the ones of the failed
+// tx, see SetDependencyMismatchHandler.
+type DependencyMismatchHandler func(ctx sdk.Context, msg sdk.Msg, missingAccessOps []acltypes.Comparator) sdk.Events
+
+// handleDependencyMismatch records the mismatch of the access operations of the
+// msg at index msgIndex of a tx and returns the events identifying it.
+func (app *BaseApp) handleDependencyMismatch(ctx sdk.Context, msgIndex int, msg sdk.Msg, missingAccessOps map[acltypes.Comparator]bool) sdk.Events {
+	missing := make([]acltypes.Comparator, 0, len(missingAccessOps))
+	for op := range missingAccessOps {
+		missing = append(missing, op)
+	}
+	// the events must not depend on the iteration order of the map
+	sort.Slice(missing, func(i, j int) bool { return missing[i].String() < missing[j].String() })
+
+	msgTypeURL := sdk.MsgTypeURL(msg)
+	telemetry.IncrCounterWithLabels(
+		[]string{"sei", "concurrent", "tx", "dependency", "mismatch"},
+		1,
+		[]metrics.Label{telemetry.NewLabel("msg_type", msgTypeURL)},
+	)
+	events := sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeDependencyMismatch,
+			sdk.NewAttribute(sdk.AttributeKeyMsgTypeURL, msgTypeURL),
+			sdk.NewAttribute(sdk.AttributeKeyMsgIndex, fmt.Sprint(msgIndex)),
+			sdk.NewAttribute(sdk.AttributeKeyMissingAccessOps, fmt.Sprint(len(missing))),
+		),
+	}
+	if app.dependencyMismatchHandler != nil {
+		events = events.AppendEvents(app.dependencyMismatchHandler(ctx, msg, missing))
+	}
+	return events
+}
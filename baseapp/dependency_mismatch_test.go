@@ -0,0 +1,74 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestDependencyMismatch(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	var mismatched []sdk.Msg
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		func(bapp *BaseApp) {
+			bapp.SetDependencyMismatchHandler(func(ctx sdk.Context, msg sdk.Msg, missingAccessOps []acltypes.Comparator) sdk.Events {
+				mismatched = append(mismatched, msg)
+				require.NotEmpty(t, missingAccessOps)
+				return sdk.Events{sdk.NewEvent("fallback")}
+			})
+		},
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	app.setDeliverState(tmproto.Header{Height: 1})
+	ctx := app.deliverState.ctx.
+		WithBlockGasMeter(sdk.NewInfiniteGasMeter()).
+		WithMsgValidator(acltypes.NewMsgValidator(acltypes.DefaultStoreKeyToResourceTypePrefixMap()))
+	declare := func(msgOps []acltypes.AccessOperation) sdk.Context {
+		return ctx.WithTxMsgAccessOps(map[int][]acltypes.AccessOperation{
+			acltypes.ANTE_MSG_INDEX: acltypes.SynchronousAccessOps(),
+			0:                       msgOps,
+		})
+	}
+
+	// the msg writes to a store its access operations do not cover
+	tx := newTxCounter(0, 0)
+	res := app.DeliverTx(declare([]acltypes.AccessOperation{
+		{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV_BANK, IdentifierTemplate: "*"},
+		{AccessType: acltypes.AccessType_COMMIT, ResourceType: acltypes.ResourceType_ANY, IdentifierTemplate: "*"},
+	}), abci.RequestDeliverTx{Tx: cdc.MustMarshal(tx)})
+	require.ErrorIs(t, sdkerrors.ABCIError(res.Codespace, res.Code, res.Log), sdkerrors.ErrInvalidConcurrencyExecution)
+	require.Len(t, mismatched, 1)
+	require.Equal(t, sdk.MsgTypeURL(tx.Msgs[0]), sdk.MsgTypeURL(mismatched[0]))
+	var mismatch abci.Event
+	for _, event := range res.Events {
+		if event.Type == sdk.EventTypeDependencyMismatch {
+			mismatch = event
+		}
+	}
+	require.Equal(t, []abci.EventAttribute{
+		{Key: []byte(sdk.AttributeKeyMsgTypeURL), Value: []byte(sdk.MsgTypeURL(tx.Msgs[0])), Index: true},
+		{Key: []byte(sdk.AttributeKeyMsgIndex), Value: []byte("0"), Index: true},
+		{Key: []byte(sdk.AttributeKeyMissingAccessOps), Value: []byte("2"), Index: true},
+	}, mismatch.Attributes)
+	require.Equal(t, "fallback", res.Events[len(res.Events)-1].Type)
+
+	// while the synchronous access operations cover every access
+	res = app.DeliverTx(declare(acltypes.SynchronousAccessOps()), abci.RequestDeliverTx{Tx: cdc.MustMarshal(newTxCounter(1, 0))})
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, mismatched, 1)
+}
@@ -1,6 +1,7 @@
 package baseapp
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/client/grpc/reflection"
@@ -8,8 +9,10 @@ import (
 	gogogrpc "github.com/gogo/protobuf/grpc"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/status"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -22,8 +25,15 @@ type GRPCQueryRouter struct {
 	routes            map[string]GRPCQueryHandler
 	interfaceRegistry codectypes.InterfaceRegistry
 	serviceData       []serviceData
+	// maxQueryDepth bounds the nesting of the queries routed while handling
+	// another one, e.g. by contracts querying each other, 0 leaving it unbounded
+	maxQueryDepth int
 }
 
+// queryDepthKey is the key of the depth of the query being handled in the
+// context passed to the query handlers.
+type queryDepthKey struct{}
+
 // serviceData represents a gRPC service, along with its handler.
 type serviceData struct {
 	serviceDesc *grpc.ServiceDesc
@@ -80,6 +90,12 @@ func (qrt *GRPCQueryRouter) RegisterService(sd *grpc.ServiceDesc, handler interf
 		}
 
 		qrt.routes[fqName] = func(ctx sdk.Context, req abci.RequestQuery) (abci.ResponseQuery, error) {
+			depth, _ := ctx.Context().Value(queryDepthKey{}).(int)
+			if qrt.maxQueryDepth > 0 && depth >= qrt.maxQueryDepth {
+				return abci.ResponseQuery{}, status.Errorf(codes.ResourceExhausted, "query %s nested more than %d queries deep", fqName, qrt.maxQueryDepth)
+			}
+			ctx = ctx.WithContext(context.WithValue(ctx.Context(), queryDepthKey{}, depth+1))
+
 			// call the method handler from the service description with the handler object,
 			// a wrapped sdk.Context with proto-unmarshaled data from the ABCI request data
 			res, err := methodHandler(handler, sdk.WrapSDKContext(ctx), func(i interface{}) error {
@@ -116,6 +132,13 @@ func (qrt *GRPCQueryRouter) RegisterService(sd *grpc.ServiceDesc, handler interf
 	})
 }
 
+// SetMaxQueryDepth sets the number of queries that may be nested while handling
+// a query routed by qrt, the query itself included, 0 leaving it unbounded.
+// A query nested deeper fails with codes.ResourceExhausted.
+func (qrt *GRPCQueryRouter) SetMaxQueryDepth(depth int) {
+	qrt.maxQueryDepth = depth
+}
+
 // SetInterfaceRegistry sets the interface registry for the router. This will
 // also register the interface reflection gRPC service.
 func (qrt *GRPCQueryRouter) SetInterfaceRegistry(interfaceRegistry codectypes.InterfaceRegistry) {
@@ -2,6 +2,7 @@ package baseapp
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	gogogrpc "github.com/gogo/protobuf/grpc"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
@@ -20,11 +22,25 @@ import (
 // GRPCQueryRouter returns the GRPCQueryRouter of a BaseApp.
 func (app *BaseApp) GRPCQueryRouter() *GRPCQueryRouter { return app.grpcQueryRouter }
 
-// RegisterGRPCServer registers gRPC services directly with the gRPC server.
+// GRPCUnaryInterceptors returns the interceptors of the unary gRPC calls
+// registered with RegisterGRPCInterceptors.
+func (app *BaseApp) GRPCUnaryInterceptors() []grpc.UnaryServerInterceptor {
+	return app.grpcUnaryInterceptors
+}
+
+// GRPCStreamInterceptors returns the interceptors of the streaming gRPC calls
+// registered with RegisterGRPCInterceptors.
+func (app *BaseApp) GRPCStreamInterceptors() []grpc.StreamServerInterceptor {
+	return app.grpcStreamInterceptors
+}
+
+// RegisterGRPCServer registers gRPC services directly with the gRPC server:
+// the query services, which run on the state of the requested height, and the
+// node service reporting the status of the app.
 func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 	// Define an interceptor for all gRPC queries: this interceptor will create
 	// a new sdk.Context, and pass it into the query handler.
-	interceptor := func(grpcCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	interceptor := func(grpcCtx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		// If there's some metadata in the context, retrieve it.
 		md, ok := metadata.FromIncomingContext(grpcCtx)
 		if !ok {
@@ -52,6 +68,8 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 			return nil, err
 		}
 
+		sdkCtx = app.withQueryGasMeter(sdkCtx, info.FullMethod)
+
 		// Add relevant gRPC headers
 		if height == 0 {
 			height = sdkCtx.BlockHeight() // If height was not set in the request, set it to the latest
@@ -63,6 +81,12 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 		md = metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
 		grpc.SetHeader(grpcCtx, md)
 
+		defer func() {
+			recoverQueryOutOfGas(sdkCtx, &err)
+			if errors.Is(err, sdkerrors.ErrOutOfGas) {
+				err = status.Error(codes.ResourceExhausted, err.Error())
+			}
+		}()
 		return handler(grpcCtx, req)
 	}
 
@@ -76,11 +100,14 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 			methodHandler := method.Handler
 			newMethods[i] = grpc.MethodDesc{
 				MethodName: method.MethodName,
-				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
-					return methodHandler(srv, ctx, dec, grpcmiddleware.ChainUnaryServer(
-						grpcrecovery.UnaryServerInterceptor(),
-						interceptor,
-					))
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, serverInterceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+					interceptors := []grpc.UnaryServerInterceptor{grpcrecovery.UnaryServerInterceptor()}
+					// the interceptors of the server, e.g. the ones of the app,
+					// run before the query gets its context
+					if serverInterceptor != nil {
+						interceptors = append(interceptors, serverInterceptor)
+					}
+					return methodHandler(srv, ctx, dec, grpcmiddleware.ChainUnaryServer(append(interceptors, interceptor)...))
 				},
 			}
 		}
@@ -95,4 +122,6 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 
 		server.RegisterService(newDesc, data.handler)
 	}
+
+	node.RegisterServiceServer(server, nodeService{app: app})
 }
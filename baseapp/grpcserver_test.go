@@ -0,0 +1,72 @@
+package baseapp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+)
+
+func TestRegisterGRPCInterceptors(t *testing.T) {
+	encCfg := simapp.MakeTestEncodingConfig()
+	app := baseapp.NewBaseApp("test", log.NewTestingLogger(t), dbm.NewMemDB(), encCfg.TxConfig.TxDecoder(), nil, &testutil.TestAppOpts{})
+	app.SetInterfaceRegistry(encCfg.InterfaceRegistry)
+	testdata.RegisterQueryServer(app.GRPCQueryRouter(), testdata.QueryImpl{})
+
+	var methods []string
+	auth := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if len(md.Get("authorization")) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "no authorization")
+		}
+		return handler(ctx, req)
+	}
+	logging := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		methods = append(methods, info.FullMethod)
+		return handler(ctx, req)
+	}
+	app.RegisterGRPCInterceptors([]grpc.UnaryServerInterceptor{logging}, nil)
+	app.RegisterGRPCInterceptors([]grpc.UnaryServerInterceptor{auth}, nil)
+	require.NoError(t, app.LoadLatestVersion())
+	require.Panics(t, func() { app.RegisterGRPCInterceptors(nil, nil) })
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(app.GRPCUnaryInterceptors()...),
+		grpc.ChainStreamInterceptor(app.GRPCStreamInterceptors()...),
+	)
+	app.RegisterGRPCServer(srv)
+	listener := bufconn.Listen(1024 * 1024)
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := testdata.NewQueryClient(conn)
+
+	// the interceptors run before the query, in the order they were registered
+	_, err = client.Echo(context.Background(), &testdata.EchoRequest{Message: "hello"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Equal(t, []string{"/testdata.Query/Echo"}, methods)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "token")
+	res, err := client.Echo(ctx, &testdata.EchoRequest{Message: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "hello", res.Message)
+	require.Len(t, methods, 2)
+}
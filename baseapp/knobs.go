@@ -0,0 +1,42 @@
+package baseapp
+
+import (
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+)
+
+// UpdateConcurrencyWorkers changes the number of workers executing the txs of
+// a block, as set by SetConcurrencyWorkers, from the next block on. It may be
+// called while a block is executed, e.g. once the app config is reloaded.
+func (app *BaseApp) UpdateConcurrencyWorkers(workers int) {
+	app.knobsMtx.Lock()
+	defer app.knobsMtx.Unlock()
+	if workers == app.concurrencyWorkers {
+		return
+	}
+	app.concurrencyWorkers = workers
+	if app.scheduler != nil {
+		app.scheduler.SetWorkers(workers)
+	}
+}
+
+// UpdateHistoricalStoreCacheSize changes the number of past versions of the
+// stores kept open for the queries, as set by SetHistoricalStoreCacheSize,
+// while queries are served. The cache cannot be enabled or disabled this way.
+func (app *BaseApp) UpdateHistoricalStoreCacheSize(size int) error {
+	rs, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		return errors.New("the historical store cache requires a rootmulti store")
+	}
+	app.knobsMtx.Lock()
+	defer app.knobsMtx.Unlock()
+	if size == app.historicalStoreCacheSize {
+		return nil
+	}
+	if err := rs.ResizeHistoricalStoreCache(size); err != nil {
+		return err
+	}
+	app.historicalStoreCacheSize = size
+	return nil
+}
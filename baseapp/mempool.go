@@ -0,0 +1,560 @@
+package baseapp
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// MempoolConfig bounds the txs of a PriorityMempool.
+type MempoolConfig struct {
+	// MaxBytes bounds the total size of the txs, 0 leaving it unbounded.
+	MaxBytes int64
+	// MaxTxsPerSender bounds the number of txs of a sender, 0 leaving it
+	// unbounded.
+	MaxTxsPerSender int
+	// MaxBytesPerSender bounds the total size of the txs of a sender, 0
+	// leaving it unbounded.
+	MaxBytesPerSender int64
+	// TTLNumBlocks is the number of blocks after which a tx fails its recheck
+	// and is evicted, 0 keeping the txs whatever the blocks committed.
+	TTLNumBlocks uint64
+	// TTL is the time after which a tx fails its recheck and is evicted, 0
+	// keeping the txs however long they wait.
+	TTL time.Duration
+	// SequenceWindow lets CheckTx accept the txs of a sender signed with a
+	// sequence up to SequenceWindow-1 ahead of the sequence of the sender, for
+	// a sender to submit consecutive txs that may reach the node out of
+	// order. 0 or 1 requires the exact sequence.
+	SequenceWindow uint64
+}
+
+// PriorityMempool mirrors the txs of the mempool of Tendermint that passed
+// CheckTx, along with their priorities, so that proposals order them by
+// priority rather than in arrival order. A sender's txs are still proposed in
+// nonce order, as a tx cannot be executed before the ones preceding it.
+//
+// CheckTx rejects the txs that would exceed the bounds of the mempool, before
+// they reach the check state. A tx with the sender and nonce of a pending tx
+// replaces it if its priority is higher, so that a sender can bump the fee of
+// a tx. The replaced tx is no longer proposed, and fails its next recheck for
+// Tendermint to evict it. The txs are removed once delivered in a committed
+// block or when their recheck fails. As the txs that Tendermint evicts are not
+// reported to the app, the txs that are not rechecked after a commit are
+// removed at the next one, which relies on Tendermint rechecking its mempool.
+//
+// A tx fails its recheck once it has been in the mempool for longer than the
+// TTLs of the mempool, so that the txs that are never proposed do not hold the
+// bounds of the mempool forever. The txs evicted from the mempool are passed
+// to the subscribers of SubscribeEvictions.
+//
+// With a sequence window, a tx signed with a sequence ahead of the sequence of
+// its sender waits in the mempool without using its sequence, so that the
+// missing txs of the sender are still accepted. As the txs of a sender are
+// proposed in nonce order, the consecutive txs of the mempool are delivered in
+// the same block, and DeliverTx still requires the exact sequence.
+type PriorityMempool struct {
+	mtx    sync.Mutex
+	config MempoolConfig
+
+	txs map[[sha256.Size]byte]*mempoolTx
+	// pending holds the hashes of the txs that are not replaced by their
+	// sender and nonce, which bytes and senderTxs account for
+	pending map[senderNonce][sha256.Size]byte
+	bytes   int64
+	// senderTxs and senderBytes are the number and the total size of the
+	// pending txs of each sender
+	senderTxs   map[string]int
+	senderBytes map[string]int64
+	// generation is the number of commits, a tx being removed once two
+	// commits have passed since it was last checked
+	generation uint64
+
+	// now returns the current time, which the TTL is measured with
+	now         func() time.Time
+	subscribers map[chan MempoolEviction]struct{}
+}
+
+type mempoolTx struct {
+	size       int64
+	sender     senderNonce
+	signed     bool
+	priority   int64
+	generation uint64
+	replaced   bool
+	// insertedGeneration and insertedAt are the generation and the time the
+	// tx was inserted at, for its TTLs
+	insertedGeneration uint64
+	insertedAt         time.Time
+}
+
+type senderNonce struct {
+	sender string
+	nonce  uint64
+}
+
+// NewPriorityMempool returns an empty PriorityMempool with the given bounds.
+func NewPriorityMempool(config MempoolConfig) *PriorityMempool {
+	return &PriorityMempool{
+		config:      config,
+		txs:         make(map[[sha256.Size]byte]*mempoolTx),
+		pending:     make(map[senderNonce][sha256.Size]byte),
+		senderTxs:   make(map[string]int),
+		senderBytes: make(map[string]int64),
+		now:         time.Now,
+		subscribers: make(map[chan MempoolEviction]struct{}),
+	}
+}
+
+// EvictionReason tells why a tx was evicted from the mempool.
+type EvictionReason string
+
+const (
+	// EvictionExpired is the reason of the txs that stayed in the mempool for
+	// longer than its TTLs.
+	EvictionExpired EvictionReason = "expired"
+	// EvictionReplaced is the reason of the txs replaced by a tx with the same
+	// sender and nonce and a higher priority.
+	EvictionReplaced EvictionReason = "replaced"
+	// EvictionRecheckFailed is the reason of the txs that failed their recheck.
+	EvictionRecheckFailed EvictionReason = "recheck_failed"
+	// EvictionDropped is the reason of the txs that were not rechecked after a
+	// commit, which Tendermint evicted from its mempool.
+	EvictionDropped EvictionReason = "dropped"
+)
+
+// MempoolEviction is the eviction of a tx from the mempool.
+type MempoolEviction struct {
+	// TxHash is the hash of the tx, as Tendermint computes it
+	TxHash []byte
+	// Sender is the first signer of the tx, if any
+	Sender sdk.AccAddress
+	Reason EvictionReason
+}
+
+// SubscribeEvictions returns a channel receiving the evictions of txs from the
+// mempool, buffering up to capacity evictions, and a function ending the
+// subscription. The evictions are dropped when the channel is full rather than
+// holding up CheckTx.
+func (mp *PriorityMempool) SubscribeEvictions(capacity int) (<-chan MempoolEviction, func()) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	ch := make(chan MempoolEviction, capacity)
+	mp.subscribers[ch] = struct{}{}
+	return ch, func() {
+		mp.mtx.Lock()
+		defer mp.mtx.Unlock()
+
+		if _, ok := mp.subscribers[ch]; ok {
+			delete(mp.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// evictLocked removes the tx with hash, if any, and passes its eviction to the
+// subscribers unless it was replaced, the replacement having been passed.
+func (mp *PriorityMempool) evictLocked(hash [sha256.Size]byte, reason EvictionReason) {
+	mtx, ok := mp.txs[hash]
+	if !ok {
+		return
+	}
+	mp.removeLocked(hash)
+	if !mtx.replaced {
+		mp.notifyEviction(hash, mtx, reason)
+	}
+}
+
+func (mp *PriorityMempool) notifyEviction(hash [sha256.Size]byte, mtx *mempoolTx, reason EvictionReason) {
+	telemetry.IncrCounterWithLabels([]string{"mempool", "evictions"}, 1, []metrics.Label{telemetry.NewLabel("reason", string(reason))})
+	eviction := MempoolEviction{TxHash: append([]byte(nil), hash[:]...), Reason: reason}
+	if mtx.signed {
+		eviction.Sender = sdk.AccAddress(mtx.sender.sender)
+	}
+	for ch := range mp.subscribers {
+		select {
+		case ch <- eviction:
+		default:
+		}
+	}
+}
+
+// insert adds a tx that passed CheckTx with the given priority, replacing the
+// pending tx with its sender and nonce, if any. It returns an error if the
+// mempool or the txs of its sender are full, or if the tx it replaces does not
+// have a lower priority.
+func (mp *PriorityMempool) insert(tx sdk.Tx, txBytes []byte, priority int64) error {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	hash := sha256.Sum256(txBytes)
+	if mtx, ok := mp.txs[hash]; ok {
+		if mtx.replaced {
+			return errReplacedTx
+		}
+		mtx.priority, mtx.generation = priority, mp.generation
+		return nil
+	}
+
+	sender, nonce, signed := txSenderNonce(tx)
+	key := senderNonce{sender, nonce}
+	var replaced *mempoolTx
+	if signed {
+		if replacedHash, ok := mp.pending[key]; ok {
+			replaced = mp.txs[replacedHash]
+		}
+	}
+
+	size := int64(len(txBytes))
+	bytes := mp.bytes
+	if replaced != nil {
+		if priority <= replaced.priority {
+			return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "replacing a tx of priority %d requires a higher priority, got %d", replaced.priority, priority)
+		}
+		bytes -= replaced.size
+	} else if mp.config.MaxTxsPerSender > 0 && signed && mp.senderTxs[sender] >= mp.config.MaxTxsPerSender {
+		return sdkerrors.Wrapf(sdkerrors.ErrMempoolIsFull, "sender %s already has %d txs in the mempool", sdk.AccAddress(sender), mp.senderTxs[sender])
+	}
+	if mp.config.MaxBytes > 0 && bytes+size > mp.config.MaxBytes {
+		return sdkerrors.Wrapf(sdkerrors.ErrMempoolIsFull, "the txs of the mempool would exceed %d bytes", mp.config.MaxBytes)
+	}
+	if mp.config.MaxBytesPerSender > 0 && signed {
+		senderBytes := mp.senderBytes[sender]
+		if replaced != nil {
+			senderBytes -= replaced.size
+		}
+		if senderBytes+size > mp.config.MaxBytesPerSender {
+			return sdkerrors.Wrapf(sdkerrors.ErrMempoolIsFull, "the txs of sender %s would exceed %d bytes", sdk.AccAddress(sender), mp.config.MaxBytesPerSender)
+		}
+	}
+
+	if replaced != nil {
+		mp.notifyEviction(mp.pending[key], replaced, EvictionReplaced)
+		mp.unpend(replaced)
+		replaced.replaced = true
+	}
+	mtx := &mempoolTx{
+		size: size, sender: key, signed: signed, priority: priority, generation: mp.generation,
+		insertedGeneration: mp.generation, insertedAt: mp.now(),
+	}
+	mp.txs[hash] = mtx
+	mp.bytes += size
+	if signed {
+		mp.pending[key] = hash
+		mp.senderTxs[sender]++
+		mp.senderBytes[sender] += size
+	}
+	return nil
+}
+
+// errExpiredTx is the error of the recheck of an expired tx.
+var errExpiredTx = sdkerrors.Wrap(sdkerrors.ErrTxTimeoutHeight, "tx stayed in the mempool for longer than its TTL")
+
+// expire evicts the tx with txBytes if it stayed in the mempool for longer than
+// the TTLs, reporting whether it did.
+func (mp *PriorityMempool) expire(txBytes []byte) bool {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	hash := sha256.Sum256(txBytes)
+	mtx, ok := mp.txs[hash]
+	if !ok {
+		return false
+	}
+	expired := (mp.config.TTLNumBlocks > 0 && mp.generation-mtx.insertedGeneration >= mp.config.TTLNumBlocks) ||
+		(mp.config.TTL > 0 && mp.now().Sub(mtx.insertedAt) >= mp.config.TTL)
+	if expired {
+		mp.evictLocked(hash, EvictionExpired)
+	}
+	return expired
+}
+
+// errReplacedTx is the error of the CheckTx of a replaced tx.
+var errReplacedTx = sdkerrors.Wrap(sdkerrors.ErrWrongSequence, "tx was replaced by a tx with the same sequence and a higher priority")
+
+// replaces reports whether tx has the sender and nonce of a pending tx, which
+// it replaces if its CheckTx passes.
+func (mp *PriorityMempool) replaces(tx sdk.Tx) bool {
+	sender, nonce, signed := txSenderNonce(tx)
+	if !signed {
+		return false
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	_, ok := mp.pending[senderNonce{sender, nonce}]
+	return ok
+}
+
+// isReplaced reports whether the tx with txBytes was replaced.
+func (mp *PriorityMempool) isReplaced(txBytes []byte) bool {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mtx, ok := mp.txs[sha256.Sum256(txBytes)]
+	return ok && mtx.replaced
+}
+
+// rechecked keeps the tx with txBytes if its recheck passed, with the priority
+// it was rechecked with, and removes it otherwise.
+func (mp *PriorityMempool) rechecked(txBytes []byte, ok bool, priority int64) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	hash := sha256.Sum256(txBytes)
+	if !ok {
+		mp.evictLocked(hash, EvictionRecheckFailed)
+	} else if mtx, found := mp.txs[hash]; found {
+		mtx.generation = mp.generation
+		mtx.priority = priority
+	}
+}
+
+// remove removes the tx with txBytes, if any.
+func (mp *PriorityMempool) remove(txBytes []byte) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.removeLocked(sha256.Sum256(txBytes))
+}
+
+func (mp *PriorityMempool) removeLocked(hash [sha256.Size]byte) {
+	mtx, ok := mp.txs[hash]
+	if !ok {
+		return
+	}
+	delete(mp.txs, hash)
+	if !mtx.replaced {
+		mp.unpend(mtx)
+	}
+}
+
+// unpend stops accounting for the pending tx mtx.
+func (mp *PriorityMempool) unpend(mtx *mempoolTx) {
+	mp.bytes -= mtx.size
+	if mtx.signed {
+		delete(mp.pending, mtx.sender)
+		if mp.senderTxs[mtx.sender.sender]--; mp.senderTxs[mtx.sender.sender] == 0 {
+			delete(mp.senderTxs, mtx.sender.sender)
+			delete(mp.senderBytes, mtx.sender.sender)
+		} else {
+			mp.senderBytes[mtx.sender.sender] -= mtx.size
+		}
+	}
+}
+
+// commit removes the txs that were not checked since the previous commit, which
+// Tendermint evicted.
+func (mp *PriorityMempool) commit() {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.generation++
+	for hash, mtx := range mp.txs {
+		if mtx.generation+1 < mp.generation {
+			mp.evictLocked(hash, EvictionDropped)
+		}
+	}
+}
+
+// CountTx returns the number of txs in the mempool, the replaced ones
+// included.
+func (mp *PriorityMempool) CountTx() int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return len(mp.txs)
+}
+
+// Bytes returns the total size of the pending txs in the mempool.
+func (mp *PriorityMempool) Bytes() int64 {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.bytes
+}
+
+// TxOrderer returns a TxOrderer proposing the txs by decreasing priority, each
+// sender's txs in nonce order, and dropping the replaced txs and the txs
+// following a gap in the nonces of their sender. The txs missing from the
+// mempool have the lowest priority, and the txs of equal priority keep their
+// order.
+func (mp *PriorityMempool) TxOrderer() TxOrderer {
+	return func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return mp.order(txs), nil
+	}
+}
+
+func (mp *PriorityMempool) order(proposable []ProposalTx) []ProposalTx {
+	mp.mtx.Lock()
+	txs := make([]ProposalTx, 0, len(proposable))
+	priorities := make([]int64, 0, len(proposable))
+	for _, tx := range proposable {
+		mtx, ok := mp.txs[sha256.Sum256(tx.Bytes)]
+		switch {
+		case !ok:
+			priorities = append(priorities, math.MinInt64)
+		case mtx.replaced:
+			continue
+		default:
+			priorities = append(priorities, mtx.priority)
+		}
+		txs = append(txs, tx)
+	}
+	mp.mtx.Unlock()
+
+	// the txs of each sender by nonce, the txs without a sender each forming
+	// their own chain
+	chains := make([]*nonceChain, 0, len(txs))
+	senderChains := make(map[string]*nonceChain)
+	for i, tx := range txs {
+		sender, nonce, ok := txSenderNonce(tx.Tx)
+		chain := senderChains[sender]
+		if chain == nil || !ok {
+			chain = &nonceChain{}
+			chains = append(chains, chain)
+			if ok {
+				senderChains[sender] = chain
+			}
+		}
+		chain.txs = append(chain.txs, i)
+		chain.nonces = append(chain.nonces, nonce)
+	}
+
+	queue := &chainQueue{priorities: priorities}
+	for _, chain := range chains {
+		sort.Stable(chain)
+		chain.truncateAtGap()
+		queue.chains = append(queue.chains, chain)
+	}
+	heap.Init(queue)
+
+	ordered := make([]ProposalTx, 0, len(txs))
+	for queue.Len() > 0 {
+		chain := queue.chains[0]
+		ordered = append(ordered, txs[chain.txs[chain.next]])
+		if chain.next++; chain.next < len(chain.txs) {
+			heap.Fix(queue, 0)
+		} else {
+			heap.Pop(queue)
+		}
+	}
+	return ordered
+}
+
+// nonceChain holds the indexes of the txs of a sender, sorted by nonce, the
+// next of which is to be proposed.
+type nonceChain struct {
+	txs    []int
+	nonces []uint64
+	next   int
+}
+
+// truncateAtGap drops the txs following a gap in the nonces, which a sequence
+// window lets the mempool hold, as they would fail until the missing txs are
+// delivered.
+func (c *nonceChain) truncateAtGap() {
+	for i := 1; i < len(c.nonces); i++ {
+		if c.nonces[i] > c.nonces[i-1]+1 {
+			c.txs, c.nonces = c.txs[:i], c.nonces[:i]
+			return
+		}
+	}
+}
+
+func (c *nonceChain) Len() int           { return len(c.txs) }
+func (c *nonceChain) Less(i, j int) bool { return c.nonces[i] < c.nonces[j] }
+func (c *nonceChain) Swap(i, j int) {
+	c.txs[i], c.txs[j] = c.txs[j], c.txs[i]
+	c.nonces[i], c.nonces[j] = c.nonces[j], c.nonces[i]
+}
+
+// chainQueue is a heap of nonce chains by the priority of their next tx, then
+// by its position in the block.
+type chainQueue struct {
+	chains     []*nonceChain
+	priorities []int64
+}
+
+func (q *chainQueue) Len() int { return len(q.chains) }
+func (q *chainQueue) Less(i, j int) bool {
+	ti, tj := q.chains[i].txs[q.chains[i].next], q.chains[j].txs[q.chains[j].next]
+	if q.priorities[ti] != q.priorities[tj] {
+		return q.priorities[ti] > q.priorities[tj]
+	}
+	return ti < tj
+}
+func (q *chainQueue) Swap(i, j int) { q.chains[i], q.chains[j] = q.chains[j], q.chains[i] }
+func (q *chainQueue) Push(x interface{}) {
+	q.chains = append(q.chains, x.(*nonceChain))
+}
+func (q *chainQueue) Pop() interface{} {
+	chain := q.chains[len(q.chains)-1]
+	q.chains = q.chains[:len(q.chains)-1]
+	return chain
+}
+
+// mempoolCheckContext returns the context of the CheckTx of txBytes, with the
+// sequence window of the mempool, and marked as replacing a pending tx if the
+// tx has its sender and nonce. A replaced tx fails its recheck instead.
+func (app *BaseApp) mempoolCheckContext(ctx sdk.Context, mode runTxMode, txBytes []byte) (sdk.Context, error) {
+	if app.mempool == nil {
+		return ctx, nil
+	}
+	switch mode {
+	case runTxModeReCheck:
+		if app.mempool.isReplaced(txBytes) {
+			return ctx, errReplacedTx
+		}
+		if app.mempool.expire(txBytes) {
+			return ctx, errExpiredTx
+		}
+		ctx = ctx.WithSequenceWindow(app.mempool.config.SequenceWindow)
+	case runTxModeCheck:
+		ctx = ctx.WithSequenceWindow(app.mempool.config.SequenceWindow)
+		// the txs of a sender are checked one at a time, so the pending tx
+		// cannot be replaced concurrently
+		if tx, err := app.decodeTx(ctx, txBytes); err == nil && app.mempool.replaces(tx) {
+			ctx = ctx.WithIsReplacementTx(true)
+		}
+	}
+	return ctx, nil
+}
+
+// signedTx is a tx whose signatures carry the sequences of their signers.
+type signedTx interface {
+	GetSigners() []sdk.AccAddress
+	GetSignaturesV2() ([]signing.SignatureV2, error)
+}
+
+// txSenderNonce returns the first signer of tx and the sequence it signed with,
+// which orders the txs of the signer.
+func txSenderNonce(tx sdk.Tx) (sender string, nonce uint64, ok bool) {
+	sigTx, isSigned := tx.(signedTx)
+	if !isSigned {
+		// the txs of alternative envelopes carry their nonce themselves
+		nonceTx, hasNonce := tx.(sdk.TxWithNonce)
+		if !hasNonce || len(nonceTx.GetSigners()) == 0 {
+			return "", 0, false
+		}
+		return string(nonceTx.GetSigners()[0]), nonceTx.GetNonce(), true
+	}
+	signers := sigTx.GetSigners()
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil || len(signers) == 0 || len(sigs) == 0 {
+		return "", 0, false
+	}
+	return string(signers[0]), sigs[0].Sequence, true
+}
@@ -0,0 +1,497 @@
+package baseapp
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// nonceTx is a tx of a sender signed with a nonce, encoded as
+// "sender/nonce/priority", where the priority is the one its CheckTx returns.
+type nonceTx struct {
+	senderMsg
+	nonce    uint64
+	priority int64
+}
+
+func (tx nonceTx) GetMsgs() []sdk.Msg { return []sdk.Msg{tx.senderMsg} }
+func (tx nonceTx) GetSignaturesV2() ([]signing.SignatureV2, error) {
+	return []signing.SignatureV2{{Sequence: tx.nonce}}, nil
+}
+
+func nonceTxDecoder(txBytes []byte) (sdk.Tx, error) {
+	var tx nonceTx
+	var sender string
+	if _, err := fmt.Sscanf(strings.ReplaceAll(string(txBytes), "/", " "), "%s %d %d", &sender, &tx.nonce, &tx.priority); err != nil {
+		return unsignedTx{}, nil
+	}
+	tx.sender = []byte(sender)
+	return tx, nil
+}
+
+// unsignedTx is a tx without signers.
+type unsignedTx struct{}
+
+func (unsignedTx) GetMsgs() []sdk.Msg   { return nil }
+func (unsignedTx) ValidateBasic() error { return nil }
+
+func TestPriorityMempoolOrder(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{})
+	var txs []ProposalTx
+	for _, bz := range []string{"a/1/10", "b/0/5", "a/0/1", "unsigned", "c/0/7", "c/1/7", "d/0/20"} {
+		tx, err := nonceTxDecoder([]byte(bz))
+		require.NoError(t, err)
+		txs = append(txs, ProposalTx{Bytes: []byte(bz), Tx: tx})
+		// d/0 is not in the mempool, e.g. after a restart
+		if bz != "d/0/20" {
+			priority := int64(3)
+			if ntx, ok := tx.(nonceTx); ok {
+				priority = ntx.priority
+			}
+			require.NoError(t, mempool.insert(tx, []byte(bz), priority))
+		}
+	}
+
+	var ordered []string
+	for _, tx := range mempool.order(txs) {
+		ordered = append(ordered, string(tx.Bytes))
+	}
+	// the high priority tx of a is proposed after its lower nonce, and the
+	// txs of equal priority keep their order
+	require.Equal(t, []string{"c/0/7", "c/1/7", "b/0/5", "unsigned", "a/0/1", "a/1/10", "d/0/20"}, ordered)
+}
+
+func TestPriorityMempoolBounds(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{MaxBytes: 30, MaxTxsPerSender: 2})
+	insert := func(bz string) error {
+		tx, err := nonceTxDecoder([]byte(bz))
+		require.NoError(t, err)
+		return mempool.insert(tx, []byte(bz), 0)
+	}
+
+	require.NoError(t, insert("a/0/0"))
+	require.NoError(t, insert("a/1/0"))
+	require.ErrorIs(t, insert("a/2/0"), sdkerrors.ErrMempoolIsFull)
+	// a tx already in the mempool is accepted again
+	require.NoError(t, insert("a/1/0"))
+	require.NoError(t, insert("b/0/0"))
+	require.NoError(t, insert("c/0/0"))
+	require.Equal(t, 4, mempool.CountTx())
+	require.Equal(t, int64(20), mempool.Bytes())
+	require.NoError(t, insert("d/10/0"))
+	require.ErrorIs(t, insert("d/11/0"), sdkerrors.ErrMempoolIsFull)
+
+	mempool.remove([]byte("a/0/0"))
+	require.NoError(t, insert("a/2/0"))
+	mempool.rechecked([]byte("a/2/0"), false, 0)
+	require.Equal(t, 4, mempool.CountTx())
+
+	// the txs that are not rechecked after a commit are removed at the next
+	mempool.commit()
+	for _, bz := range []string{"a/1/0", "b/0/0"} {
+		mempool.rechecked([]byte(bz), true, 0)
+	}
+	require.NoError(t, insert("e/0/0"))
+	mempool.commit()
+	require.Equal(t, 3, mempool.CountTx())
+	require.Equal(t, int64(15), mempool.Bytes())
+	require.Equal(t, map[string]int{"a": 1, "b": 1, "e": 1}, mempool.senderTxs)
+}
+
+func TestPriorityMempoolReplacement(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{MaxBytes: 15, MaxTxsPerSender: 1})
+	var txs []ProposalTx
+	insert := func(bz string) error {
+		tx, err := nonceTxDecoder([]byte(bz))
+		require.NoError(t, err)
+		if err := mempool.insert(tx, []byte(bz), tx.(nonceTx).priority); err != nil {
+			return err
+		}
+		txs = append(txs, ProposalTx{Bytes: []byte(bz), Tx: tx})
+		return nil
+	}
+
+	require.NoError(t, insert("a/0/5"))
+	require.NoError(t, insert("b/0/7"))
+	require.True(t, mempool.replaces(nonceTx{senderMsg{[]byte("a")}, 0, 0}))
+	require.False(t, mempool.replaces(nonceTx{senderMsg{[]byte("a")}, 1, 0}))
+
+	// the replacement needs a higher priority, but no room for another tx
+	require.ErrorIs(t, insert("a/0/4"), sdkerrors.ErrInsufficientFee)
+	require.ErrorIs(t, insert("a/00/5"), sdkerrors.ErrInsufficientFee)
+	require.NoError(t, insert("a/0/10"))
+	require.True(t, mempool.isReplaced([]byte("a/0/5")))
+	require.False(t, mempool.isReplaced([]byte("a/0/10")))
+	require.Equal(t, 3, mempool.CountTx())
+	require.Equal(t, int64(11), mempool.Bytes())
+	require.Equal(t, map[string]int{"a": 1, "b": 1}, mempool.senderTxs)
+
+	// the replaced tx is not proposed, nor accepted again
+	var ordered []string
+	for _, tx := range mempool.order(txs) {
+		ordered = append(ordered, string(tx.Bytes))
+	}
+	require.Equal(t, []string{"a/0/10", "b/0/7"}, ordered)
+	require.ErrorIs(t, mempool.insert(txs[0].Tx, txs[0].Bytes, 20), sdkerrors.ErrWrongSequence)
+
+	// removing the replaced tx leaves its replacement pending
+	mempool.rechecked([]byte("a/0/5"), false, 0)
+	require.Equal(t, 2, mempool.CountTx())
+	require.Equal(t, int64(11), mempool.Bytes())
+	require.True(t, mempool.replaces(nonceTx{senderMsg{[]byte("a")}, 0, 0}))
+}
+
+func TestPriorityMempoolEvictions(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{MaxBytesPerSender: 10, TTLNumBlocks: 2, TTL: time.Minute})
+	now := time.Unix(0, 0)
+	mempool.now = func() time.Time { return now }
+	evictions, unsubscribe := mempool.SubscribeEvictions(10)
+	insert := func(bz string) error {
+		tx, err := nonceTxDecoder([]byte(bz))
+		require.NoError(t, err)
+		return mempool.insert(tx, []byte(bz), tx.(nonceTx).priority)
+	}
+	evicted := func() []MempoolEviction {
+		var res []MempoolEviction
+		for {
+			select {
+			case e := <-evictions:
+				res = append(res, e)
+			default:
+				return res
+			}
+		}
+	}
+	eviction := func(bz string, reason EvictionReason) MempoolEviction {
+		hash := sha256.Sum256([]byte(bz))
+		return MempoolEviction{TxHash: hash[:], Sender: sdk.AccAddress(bz[:1]), Reason: reason}
+	}
+
+	// the byte quota of a sender leaves room for a replacement
+	require.NoError(t, insert("a/0/1"))
+	require.NoError(t, insert("a/1/1"))
+	require.ErrorIs(t, insert("a/2/1"), sdkerrors.ErrMempoolIsFull)
+	require.NoError(t, insert("a/1/2"))
+	require.Equal(t, map[string]int64{"a": 10}, mempool.senderBytes)
+	require.Equal(t, []MempoolEviction{eviction("a/1/1", EvictionReplaced)}, evicted())
+
+	// the replaced tx is not reported again when it fails its recheck
+	mempool.rechecked([]byte("a/1/1"), false, 0)
+	mempool.rechecked([]byte("a/0/1"), false, 0)
+	require.Equal(t, []MempoolEviction{eviction("a/0/1", EvictionRecheckFailed)}, evicted())
+	require.Equal(t, map[string]int64{"a": 5}, mempool.senderBytes)
+
+	// the txs expire after TTLNumBlocks blocks or TTL
+	require.NoError(t, insert("b/0/1"))
+	mempool.commit()
+	now = now.Add(30 * time.Second)
+	require.NoError(t, insert("c/0/1"))
+	require.False(t, mempool.expire([]byte("a/1/2")))
+	mempool.rechecked([]byte("a/1/2"), true, 2)
+	require.False(t, mempool.expire([]byte("b/0/1")))
+	mempool.rechecked([]byte("b/0/1"), true, 1)
+	mempool.commit()
+	require.True(t, mempool.expire([]byte("a/1/2")))
+	require.True(t, mempool.expire([]byte("b/0/1")))
+	// c/0/1 is a block old only, but a minute
+	now = now.Add(time.Minute)
+	require.True(t, mempool.expire([]byte("c/0/1")))
+	require.Equal(t, []MempoolEviction{
+		eviction("a/1/2", EvictionExpired), eviction("b/0/1", EvictionExpired), eviction("c/0/1", EvictionExpired),
+	}, evicted())
+	require.Zero(t, mempool.CountTx())
+	require.Empty(t, mempool.senderBytes)
+
+	// the txs that are not rechecked are dropped at the second commit
+	require.NoError(t, insert("d/0/1"))
+	mempool.commit()
+	mempool.commit()
+	require.Equal(t, []MempoolEviction{eviction("d/0/1", EvictionDropped)}, evicted())
+
+	unsubscribe()
+	_, ok := <-evictions
+	require.False(t, ok)
+	unsubscribe()
+}
+
+func TestMempoolRecheckTTL(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{TTL: time.Minute})
+	now := time.Unix(0, 0)
+	mempool.now = func() time.Time { return now }
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetMempool(mempool))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx.WithPriority(tx.(nonceTx).priority), nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	checkTx := func(tx string, typ abci.CheckTxType) error {
+		_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte(tx), Type: typ})
+		return err
+	}
+
+	require.NoError(t, checkTx("a/0/5", abci.CheckTxType_New))
+	require.NoError(t, checkTx("a/0/5", abci.CheckTxType_Recheck))
+	now = now.Add(time.Minute)
+	require.ErrorIs(t, checkTx("a/0/5", abci.CheckTxType_Recheck), sdkerrors.ErrTxTimeoutHeight)
+	require.Zero(t, mempool.CountTx())
+}
+
+func TestMempoolTxPriorityHandler(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{})
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetMempool(mempool))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx.WithPriority(tx.(nonceTx).priority), nil
+	})
+	// the txs of the allowlist come first, whatever their fee
+	allowlist := map[string]bool{"b": true}
+	app.SetTxPriorityHandler(func(ctx sdk.Context, tx sdk.Tx) (int64, error) {
+		sender := string(tx.(nonceTx).sender)
+		if sender == "banned" {
+			return 0, sdkerrors.ErrUnauthorized
+		}
+		if allowlist[sender] {
+			return ctx.Priority() + 100, nil
+		}
+		return ctx.Priority(), nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	checkTx := func(tx string, typ abci.CheckTxType) (int64, error) {
+		res, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte(tx), Type: typ})
+		return res.Priority, err
+	}
+	order := func() []string {
+		var txs []ProposalTx
+		for _, bz := range []string{"a/0/5", "b/0/1"} {
+			tx, err := nonceTxDecoder([]byte(bz))
+			require.NoError(t, err)
+			txs = append(txs, ProposalTx{Tx: tx, Bytes: []byte(bz)})
+		}
+		var ordered []string
+		for _, tx := range mempool.order(txs) {
+			ordered = append(ordered, string(tx.Bytes))
+		}
+		return ordered
+	}
+
+	priority, err := checkTx("a/0/5", abci.CheckTxType_New)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), priority)
+	priority, err = checkTx("b/0/1", abci.CheckTxType_New)
+	require.NoError(t, err)
+	require.Equal(t, int64(101), priority)
+	_, err = checkTx("banned/0/9", abci.CheckTxType_New)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.Equal(t, 2, mempool.CountTx())
+	require.Equal(t, []string{"b/0/1", "a/0/5"}, order())
+
+	// the priority of a rechecked tx follows the allowlist
+	delete(allowlist, "b")
+	priority, err = checkTx("b/0/1", abci.CheckTxType_Recheck)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), priority)
+	require.Equal(t, []string{"a/0/5", "b/0/1"}, order())
+}
+
+func TestMempoolCheckTx(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{MaxTxsPerSender: 1})
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetMempool(mempool))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		ntx := tx.(nonceTx)
+		store := ctx.KVStore(capKey1)
+		if seq := getIntFromStore(store, ntx.sender); uint64(seq) != ntx.nonce {
+			return ctx, fmt.Errorf("expected nonce %d", seq)
+		}
+		setIntOnStore(store, ntx.sender, int64(ntx.nonce)+1)
+		return ctx.WithPriority(ntx.priority), nil
+	})
+	app.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+		for _, tx := range req.Txs {
+			app.DeliverTx(ctx, abci.RequestDeliverTx{Tx: tx})
+		}
+		return &abci.ResponseFinalizeBlock{}, nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	res, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("a/0/5")})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), res.Priority)
+	require.Equal(t, 1, mempool.CountTx())
+
+	// the rejected tx does not advance the nonce of its sender
+	_, err = app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("a/1/5")})
+	require.ErrorIs(t, err, sdkerrors.ErrMempoolIsFull)
+	require.Equal(t, int64(1), getIntFromStore(app.checkState.ctx.KVStore(capKey1), []byte("a")))
+
+	// the delivered tx frees the room of its sender
+	_, err = app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 1, Txs: [][]byte{[]byte("a/0/5")}})
+	require.NoError(t, err)
+	app.SetDeliverStateToCommit()
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, mempool.CountTx())
+	_, err = app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("a/1/5")})
+	require.NoError(t, err)
+	require.Equal(t, 1, mempool.CountTx())
+}
+
+func TestMempoolCheckTxReplacement(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{})
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetMempool(mempool))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		ntx := tx.(nonceTx)
+		store := ctx.KVStore(capKey1)
+		seq := getIntFromStore(store, ntx.sender)
+		switch {
+		case ctx.IsReplacementTx() && int64(ntx.nonce) < seq:
+		case int64(ntx.nonce) == seq:
+			setIntOnStore(store, ntx.sender, seq+1)
+		default:
+			return ctx, sdkerrors.Wrapf(sdkerrors.ErrWrongSequence, "expected nonce %d", seq)
+		}
+		return ctx.WithPriority(ntx.priority), nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	checkTx := func(tx string, typ abci.CheckTxType) error {
+		_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte(tx), Type: typ})
+		return err
+	}
+
+	require.NoError(t, checkTx("a/0/5", abci.CheckTxType_New))
+	require.NoError(t, checkTx("a/1/5", abci.CheckTxType_New))
+	require.ErrorIs(t, checkTx("a/0/3", abci.CheckTxType_New), sdkerrors.ErrInsufficientFee)
+	require.ErrorIs(t, checkTx("a/3/9", abci.CheckTxType_New), sdkerrors.ErrWrongSequence)
+
+	// the replacement keeps the sequence of the sender
+	require.NoError(t, checkTx("a/0/9", abci.CheckTxType_New))
+	require.Equal(t, int64(2), getIntFromStore(app.checkState.ctx.KVStore(capKey1), []byte("a")))
+	require.NoError(t, checkTx("a/2/5", abci.CheckTxType_New))
+
+	// and the replaced tx fails its recheck
+	require.ErrorIs(t, checkTx("a/0/5", abci.CheckTxType_Recheck), sdkerrors.ErrWrongSequence)
+	require.False(t, mempool.isReplaced([]byte("a/0/5")))
+	require.Equal(t, 3, mempool.CountTx())
+}
+
+func TestPriorityMempoolOrderGap(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{SequenceWindow: 4})
+	var txs []ProposalTx
+	for _, bz := range []string{"a/3/5", "a/1/5", "b/0/5", "a/0/5", "b/2/5"} {
+		tx, err := nonceTxDecoder([]byte(bz))
+		require.NoError(t, err)
+		txs = append(txs, ProposalTx{Bytes: []byte(bz), Tx: tx})
+		require.NoError(t, mempool.insert(tx, []byte(bz), 5))
+	}
+
+	var ordered []string
+	for _, tx := range mempool.order(txs) {
+		ordered = append(ordered, string(tx.Bytes))
+	}
+	// the txs following a gap wait for the missing txs of their sender
+	require.Equal(t, []string{"b/0/5", "a/0/5", "a/1/5"}, ordered)
+}
+
+func TestMempoolCheckTxSequenceWindow(t *testing.T) {
+	mempool := NewPriorityMempool(MempoolConfig{SequenceWindow: 3})
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetMempool(mempool))
+	app.MountStores(capKey1)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	var windows []uint64
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		windows = append(windows, ctx.SequenceWindow())
+		return ctx, nil
+	})
+	app.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+		for _, tx := range req.Txs {
+			app.DeliverTx(ctx, abci.RequestDeliverTx{Tx: tx})
+		}
+		return &abci.ResponseFinalizeBlock{}, nil
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	// the sequence window only applies to CheckTx
+	_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("a/0/5"), Type: abci.CheckTxType_New})
+	require.NoError(t, err)
+	_, err = app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("a/0/5"), Type: abci.CheckTxType_Recheck})
+	require.NoError(t, err)
+	_, err = app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 1, Txs: [][]byte{[]byte("a/0/5")}})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 3, 0}, windows)
+}
+
+// envelopeTx is a tx of an alternative envelope, carrying its signer and nonce
+// rather than the signatures of the SDK.
+type envelopeTx struct {
+	unsignedTx
+	sender sdk.AccAddress
+	nonce  uint64
+}
+
+func (tx envelopeTx) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{tx.sender} }
+func (tx envelopeTx) GetNonce() uint64             { return tx.nonce }
+
+func TestTxSenderNonceEnvelope(t *testing.T) {
+	sender, nonce, ok := txSenderNonce(envelopeTx{sender: []byte("a"), nonce: 7})
+	require.True(t, ok)
+	require.Equal(t, "a", sender)
+	require.Equal(t, uint64(7), nonce)
+	// the signers of the tx, rather than of its msgs, change in CheckTx
+	require.Equal(t, []string{"a"}, txSenders(envelopeTx{sender: []byte("a")}))
+
+	_, _, ok = txSenderNonce(unsignedTx{})
+	require.False(t, ok)
+}
+
+func TestRegisterTxDecoder(t *testing.T) {
+	app := setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.RegisterTxDecoder(func(txBytes []byte) (sdk.Tx, error) {
+			if !strings.HasPrefix(string(txBytes), "envelope/") {
+				return nil, sdkerrors.ErrTxDecode
+			}
+			return envelopeTx{sender: txBytes[len("envelope/"):]}, nil
+		})
+	})
+	require.Panics(t, func() { app.RegisterTxDecoder(nonceTxDecoder) })
+
+	tx, err := app.txDecoder([]byte("envelope/a"))
+	require.NoError(t, err)
+	require.Equal(t, envelopeTx{sender: []byte("a")}, tx)
+
+	// the txs of the decoder of the app are decoded by it first
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	txBytes, err := codec.Marshal(newTxCounter(1, 1))
+	require.NoError(t, err)
+	tx, err = app.txDecoder(txBytes)
+	require.NoError(t, err)
+	require.IsType(t, txTest{}, tx)
+
+	// the error of the decoder of the app is returned
+	_, err = app.txDecoder(nil)
+	require.ErrorIs(t, err, sdkerrors.ErrTxDecode)
+	require.Contains(t, err.Error(), "tx bytes are empty")
+}
@@ -17,6 +17,13 @@ import (
 type MsgServiceRouter struct {
 	interfaceRegistry codectypes.InterfaceRegistry
 	routes            map[string]MsgServiceHandler
+	circuitBreaker    CircuitBreaker
+}
+
+// CircuitBreaker decides whether the messages of a type URL may be routed, so
+// that the messages of an exploited module can be disabled without a halt.
+type CircuitBreaker interface {
+	IsAllowed(ctx sdk.Context, typeURL string) bool
 }
 
 var _ gogogrpc.Server = &MsgServiceRouter{}
@@ -107,7 +114,10 @@ func (msr *MsgServiceRouter) RegisterService(sd *grpc.ServiceDesc, handler inter
 		}
 
 		msr.routes[requestTypeName] = func(ctx sdk.Context, req sdk.Msg) (*sdk.Result, error) {
-			ctx = ctx.WithEventManager(sdk.NewEventManager())
+			if err := msr.checkCircuitBreaker(ctx, requestTypeName); err != nil {
+				return nil, err
+			}
+			ctx = ctx.WithEventManager(sdk.NewEventManagerWithLimits(ctx.EventManager().Limits()))
 			interceptor := func(goCtx context.Context, _ interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 				goCtx = context.WithValue(goCtx, sdk.SdkContextKey, ctx)
 				return handler(goCtx, req)
@@ -143,6 +153,20 @@ func (msr *MsgServiceRouter) SetInterfaceRegistry(interfaceRegistry codectypes.I
 	msr.interfaceRegistry = interfaceRegistry
 }
 
+// SetCircuitBreaker sets the circuit breaker checked before routing a message.
+func (msr *MsgServiceRouter) SetCircuitBreaker(cb CircuitBreaker) {
+	msr.circuitBreaker = cb
+}
+
+// checkCircuitBreaker returns an error if the messages of the type URL are
+// disabled by the circuit breaker.
+func (msr *MsgServiceRouter) checkCircuitBreaker(ctx sdk.Context, typeURL string) error {
+	if msr.circuitBreaker == nil || msr.circuitBreaker.IsAllowed(ctx, typeURL) {
+		return nil
+	}
+	return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "message type %s is disabled by the circuit breaker", typeURL)
+}
+
 func noopDecoder(_ interface{}) error { return nil }
 func noopInterceptor(_ context.Context, _ interface{}, _ *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
 	return nil, nil
@@ -15,10 +15,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/testutil"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 )
 
+type disabledMsgs map[string]bool
+
+func (d disabledMsgs) IsAllowed(_ sdk.Context, typeURL string) bool { return !d[typeURL] }
+
 func TestRegisterMsgService(t *testing.T) {
 	db := dbm.NewMemDB()
 
@@ -68,6 +73,26 @@ func TestRegisterMsgServiceTwice(t *testing.T) {
 	})
 }
 
+func TestMsgServiceCircuitBreaker(t *testing.T) {
+	encCfg := simapp.MakeTestEncodingConfig()
+	testdata.RegisterInterfaces(encCfg.InterfaceRegistry)
+	app := baseapp.NewBaseApp("test", log.NewTestingLogger(t), dbm.NewMemDB(), encCfg.TxConfig.TxDecoder(), nil, &testutil.TestAppOpts{})
+	app.SetInterfaceRegistry(encCfg.InterfaceRegistry)
+	testdata.RegisterMsgServer(app.MsgServiceRouter(), testdata.MsgServerImpl{})
+	disabled := disabledMsgs{}
+	app.SetCircuitBreaker(disabled)
+
+	msg := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "Spot"}}
+	handler := app.MsgServiceRouter().Handler(msg)
+	ctx := sdk.Context{}.WithContext(context.Background())
+	_, err := handler(ctx, msg)
+	require.NoError(t, err)
+
+	disabled[sdk.MsgTypeURL(msg)] = true
+	_, err = handler(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+}
+
 func TestMsgService(t *testing.T) {
 	priv, _, _ := testdata.KeyTestPubAddr()
 	encCfg := simapp.MakeTestEncodingConfig()
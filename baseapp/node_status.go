@@ -0,0 +1,92 @@
+package baseapp
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+)
+
+const (
+	schedulerModeParallel   = "parallel"
+	schedulerModeSequential = "sequential"
+)
+
+// nodeService implements the node Service of a BaseApp, which RegisterGRPCServer
+// registers along the query services.
+type nodeService struct {
+	app *BaseApp
+}
+
+var _ node.ServiceServer = nodeService{}
+
+// Status implements node.ServiceServer.
+func (s nodeService) Status(_ context.Context, _ *node.StatusRequest) (*node.StatusResponse, error) {
+	app := s.app
+	commitID := app.LastCommitID()
+	pruning := app.cms.GetPruning()
+	app.knobsMtx.RLock()
+	workers, historicalStoreCacheSize := app.concurrencyWorkers, app.historicalStoreCacheSize
+	app.knobsMtx.RUnlock()
+	res := &node.StatusResponse{
+		LatestHeight:  commitID.Version,
+		LatestAppHash: commitID.Hash,
+		Pruning: &node.PruningStatus{
+			KeepRecent:      pruning.KeepRecent,
+			KeepEvery:       pruning.KeepEvery,
+			Interval:        pruning.Interval,
+			MinRetainBlocks: app.minRetainBlocks,
+		},
+		Snapshot: &node.SnapshotStatus{
+			Interval:   app.snapshotInterval,
+			KeepRecent: app.snapshotKeepRecent,
+		},
+		Scheduler: &node.SchedulerStatus{
+			Mode:    schedulerModeSequential,
+			Workers: int32(workers),
+		},
+		Cache: &node.CacheStatus{
+			HistoricalStoreCacheSize: int32(historicalStoreCacheSize),
+			InterBlockCache:          app.interBlockCache != nil,
+		},
+	}
+
+	if app.snapshotManager != nil {
+		snapshots, err := app.snapshotManager.List()
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) > 0 {
+			res.Snapshot.LatestHeight = snapshots[0].Height
+		}
+		progress := app.snapshotManager.Progress()
+		res.Snapshot.Operation = progress.Operation
+		res.Snapshot.OperationHeight = progress.Height
+		res.Snapshot.ChunksRestored = progress.ChunksRestored
+		res.Snapshot.Chunks = progress.Chunks
+	}
+
+	if commitID.Version > 0 {
+		ctx, err := app.CreateQueryContext(commitID.Version, false)
+		if err != nil {
+			return nil, err
+		}
+		res.Scheduler.SequentialFromHeight = app.GetExecutionParams(ctx).SequentialFromHeight
+	}
+	if app.scheduler != nil {
+		// the mode of the next block, which the execution params may switch
+		if from := res.Scheduler.SequentialFromHeight; from <= 0 || commitID.Version+1 < from {
+			res.Scheduler.Mode = schedulerModeParallel
+		}
+		res.Scheduler.LastBlockWorkers = int32(app.scheduler.Stats().Workers)
+	}
+
+	if app.sigCache != nil {
+		res.Cache.SignatureCacheSize = int32(app.sigCache.MaxEntries())
+		res.Cache.SignatureCacheEntries = int32(app.sigCache.Len())
+	}
+	if app.mempool != nil {
+		res.Cache.MempoolTxs = int32(app.mempool.CountTx())
+		res.Cache.MempoolBytes = app.mempool.Bytes()
+	}
+	return res, nil
+}
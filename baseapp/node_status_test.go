@@ -0,0 +1,73 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestNodeStatus(t *testing.T) {
+	app, teardown := setupBaseAppWithSnapshots(t, 4, 1, SetMinRetainBlocks(100), SetSignatureCacheSize(50), SetHistoricalStoreCacheSize(3))
+	defer teardown()
+
+	res, err := nodeService{app: app}.Status(context.Background(), &node.StatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), res.LatestHeight)
+	require.Equal(t, app.LastCommitID().Hash, res.LatestAppHash)
+	require.Equal(t, &node.PruningStatus{KeepEvery: 1, MinRetainBlocks: 100}, res.Pruning)
+	require.Equal(t, &node.SnapshotStatus{Interval: 2, LatestHeight: 4}, res.Snapshot)
+	require.Equal(t, &node.SchedulerStatus{Mode: "sequential"}, res.Scheduler)
+	require.Equal(t, &node.CacheStatus{SignatureCacheSize: 50, HistoricalStoreCacheSize: 3}, res.Cache)
+}
+
+func TestNodeStatusScheduler(t *testing.T) {
+	app := setupBaseApp(t, SetOccEnabled(true), SetConcurrencyWorkers(4))
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	commit := func(height int64, ep *ExecutionParams) {
+		app.setDeliverState(tmproto.Header{Height: height})
+		app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		if ep != nil {
+			app.paramStore.Set(app.deliverState.ctx, ParamStoreKeyExecutionParams, ep)
+		}
+		app.DeliverTxBatch(app.deliverState.ctx, sdk.DeliverTxBatchRequest{})
+		app.SetDeliverStateToCommit()
+		app.Commit(context.Background())
+	}
+	status := func() *node.SchedulerStatus {
+		res, err := nodeService{app: app}.Status(context.Background(), &node.StatusRequest{})
+		require.NoError(t, err)
+		return res.Scheduler
+	}
+
+	require.Equal(t, &node.SchedulerStatus{Mode: "parallel", Workers: 4}, status())
+	commit(1, &ExecutionParams{SequentialFromHeight: 3})
+	require.Equal(t, &node.SchedulerStatus{Mode: "parallel", Workers: 4, LastBlockWorkers: 4, SequentialFromHeight: 3}, status())
+	// the next block executes its txs sequentially
+	commit(2, nil)
+	require.Equal(t, "sequential", status().Mode)
+}
+
+func TestNodeStatusUpdatedKnobs(t *testing.T) {
+	app := setupBaseApp(t, SetOccEnabled(true), SetConcurrencyWorkers(4), SetHistoricalStoreCacheSize(3))
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	app.UpdateConcurrencyWorkers(2)
+	require.NoError(t, app.UpdateHistoricalStoreCacheSize(5))
+	require.Error(t, app.UpdateHistoricalStoreCacheSize(0))
+	res, err := nodeService{app: app}.Status(context.Background(), &node.StatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), res.Scheduler.Workers)
+	require.Equal(t, int32(5), res.Cache.HistoricalStoreCacheSize)
+
+	// the scheduler switches to the new workers with the next block
+	app.setDeliverState(tmproto.Header{Height: 1})
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+	app.DeliverTxBatch(app.deliverState.ctx, sdk.DeliverTxBatchRequest{})
+	require.Equal(t, 2, app.scheduler.Stats().Workers)
+}
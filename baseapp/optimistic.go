@@ -0,0 +1,125 @@
+package baseapp
+
+import (
+	"bytes"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// optimisticBlock is a proposed block executed by the finalize blocker while
+// consensus votes on it. It is executed on the deliver state, which is reset if
+// another block is finalized.
+type optimisticBlock struct {
+	height   int64
+	hash     []byte
+	gasMeter sdk.GasMeter
+
+	// done is closed once the finalize blocker returns, after which res and
+	// err are set
+	done chan struct{}
+	res  *abci.ResponseFinalizeBlock
+	err  error
+
+	// deliveredTxs are the txs delivered by the block, which are only passed
+	// to afterDeliverTx once the block is finalized
+	deliveredTxs []deliveredTx
+}
+
+type deliveredTx struct {
+	req abci.RequestDeliverTx
+	res abci.ResponseDeliverTx
+}
+
+// processOptimistically starts executing the block of an accepted proposal on
+// the deliver state, replacing the block processed optimistically so far. The
+// first block is not processed optimistically, as the deliver state already
+// holds the writes of InitChain.
+func (app *BaseApp) processOptimistically(req *abci.RequestProcessProposal) {
+	app.discardOptimisticBlock()
+	if app.finalizeBlocker == nil || app.deliverState != nil {
+		return
+	}
+
+	finalizeReq := &abci.RequestFinalizeBlock{
+		Txs:                   req.Txs,
+		DecidedLastCommit:     req.ProposedLastCommit,
+		ByzantineValidators:   req.ByzantineValidators,
+		Hash:                  req.Hash,
+		Height:                req.Height,
+		Time:                  req.Time,
+		NextValidatorsHash:    req.NextValidatorsHash,
+		ProposerAddress:       req.ProposerAddress,
+		AppHash:               req.AppHash,
+		ValidatorsHash:        req.ValidatorsHash,
+		ConsensusHash:         req.ConsensusHash,
+		DataHash:              req.DataHash,
+		EvidenceHash:          req.EvidenceHash,
+		LastBlockHash:         req.LastBlockHash,
+		LastBlockPartSetTotal: req.LastBlockPartSetTotal,
+		LastBlockPartSetHash:  req.LastBlockPartSetHash,
+		LastCommitHash:        req.LastCommitHash,
+		LastResultsHash:       req.LastResultsHash,
+	}
+	ob := &optimisticBlock{
+		height:   req.Height,
+		hash:     req.Hash,
+		gasMeter: app.prepareFinalizeBlockState(finalizeReq),
+		done:     make(chan struct{}),
+	}
+	ctx := app.deliverState.ctx
+	app.optimisticBlock = ob
+	go func() {
+		defer close(ob.done)
+		defer func() {
+			// the block is executed again once finalized, where the panic
+			// recurs if it is deterministic
+			if r := recover(); r != nil {
+				ob.err = fmt.Errorf("panic while processing the block optimistically: %v", r)
+			}
+		}()
+		ob.res, ob.err = app.finalizeBlocker(ctx, finalizeReq)
+	}()
+}
+
+// takeOptimisticBlock waits for the block processed optimistically, if any, and
+// returns its response if it is the block of req. Otherwise the block is
+// discarded and false is returned.
+func (app *BaseApp) takeOptimisticBlock(req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, sdk.GasMeter, bool) {
+	ob := app.optimisticBlock
+	if ob == nil {
+		return nil, nil, false
+	}
+	<-ob.done
+	if ob.err != nil || ob.height != req.Height || !bytes.Equal(ob.hash, req.Hash) {
+		if ob.err != nil {
+			app.logger.Error("failed to process the block optimistically", "height", ob.height, "err", ob.err)
+		}
+		app.discardOptimisticBlock()
+		return nil, nil, false
+	}
+
+	app.optimisticBlock = nil
+	telemetry.IncrCounter(1, "optimistic_processing", "finalized")
+	for _, tx := range ob.deliveredTxs {
+		app.afterDeliverTx(tx.req, tx.res)
+	}
+	return ob.res, ob.gasMeter, true
+}
+
+// discardOptimisticBlock waits for the block processed optimistically, if any,
+// and resets the deliver state it was executed on. The finalize blocker is not
+// interrupted.
+func (app *BaseApp) discardOptimisticBlock() {
+	ob := app.optimisticBlock
+	if ob == nil {
+		return
+	}
+	<-ob.done
+	app.optimisticBlock = nil
+	app.deliverState = nil
+	telemetry.IncrCounter(1, "optimistic_processing", "discarded")
+}
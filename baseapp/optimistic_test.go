@@ -0,0 +1,158 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestOptimisticProcessing(t *testing.T) {
+	codec := codec.NewLegacyAmino()
+	registerTestCodec(codec)
+	totalKey := []byte("total")
+
+	type testApp struct {
+		*BaseApp
+		recorder   *deliverTxRecorder
+		executions int
+	}
+	newApp := func(options ...func(*BaseApp)) *testApp {
+		tapp := &testApp{recorder: &deliverTxRecorder{}}
+		handlersOpt := func(bapp *BaseApp) {
+			bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil
+			})
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+				store := ctx.KVStore(capKey1)
+				total := getIntFromStore(store, totalKey)
+				setIntOnStore(store, totalKey, total+msg.(*msgCounter).Counter)
+				return &sdk.Result{Data: []byte(fmt.Sprint(total))}, nil
+			}))
+			bapp.SetProcessProposalHandler(func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+				return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
+			})
+			bapp.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+				tapp.executions++
+				batch := sdk.DeliverTxBatchRequest{}
+				for _, tx := range req.Txs {
+					batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: tx}})
+				}
+				res := &abci.ResponseFinalizeBlock{}
+				for _, result := range tapp.DeliverTxBatch(ctx, batch).Results {
+					res.TxResults = append(res.TxResults, &abci.ExecTxResult{Code: result.Response.Code, Data: result.Response.Data})
+				}
+				return res, nil
+			})
+		}
+		tapp.BaseApp = setupBaseApp(t, append(options, handlersOpt)...)
+		tapp.SetStreamingService(tapp.recorder)
+		tapp.InitChain(context.Background(), &abci.RequestInitChain{})
+		return tapp
+	}
+	newBlock := func(counters ...int64) [][]byte {
+		var txs [][]byte
+		for _, counter := range counters {
+			txs = append(txs, codec.MustMarshal(newTxCounter(counter, counter)))
+		}
+		return txs
+	}
+
+	seqApp := newApp()
+	optApp := newApp(SetOptimisticProcessing(true))
+	occApp := newApp(SetOptimisticProcessing(true), SetOccEnabled(true), SetConcurrencyWorkers(4))
+
+	var finalizedTxs [][]byte
+	for height, tc := range []struct {
+		// the blocks proposed in the rounds of the height, the last of
+		// which is finalized unless finalized is set
+		proposed  [][][]byte
+		finalized [][]byte
+		// the number of times the finalize blocker runs with optimistic
+		// processing
+		executions int
+	}{
+		// the first block is not processed optimistically
+		{proposed: [][][]byte{newBlock(1, 2)}, executions: 1},
+		{proposed: [][][]byte{newBlock(3, 4, 5)}, executions: 1},
+		{proposed: [][][]byte{newBlock(6, 7), newBlock(8)}, executions: 2},
+		{proposed: [][][]byte{newBlock(9, 10)}, finalized: newBlock(11), executions: 2},
+		{proposed: [][][]byte{newBlock()}, executions: 1},
+	} {
+		finalized := tc.finalized
+		if finalized == nil {
+			finalized = tc.proposed[len(tc.proposed)-1]
+		}
+		finalizeReq := &abci.RequestFinalizeBlock{Txs: finalized, Height: int64(height) + 1, Hash: []byte(fmt.Sprint(finalized))}
+		finalizedTxs = append(finalizedTxs, finalized...)
+
+		var results [][]*abci.ExecTxResult
+		for _, app := range []*testApp{seqApp, optApp, occApp} {
+			app.executions = 0
+			for _, txs := range tc.proposed {
+				res, err := app.ProcessProposal(context.Background(), &abci.RequestProcessProposal{
+					Txs: txs, Height: finalizeReq.Height, Hash: []byte(fmt.Sprint(txs)),
+				})
+				require.NoError(t, err)
+				require.Equal(t, abci.ResponseProcessProposal_ACCEPT, res.Status)
+			}
+			res, err := app.FinalizeBlock(context.Background(), finalizeReq)
+			require.NoError(t, err)
+			results = append(results, res.TxResults)
+			if app == seqApp {
+				require.Equal(t, 1, app.executions)
+			} else {
+				require.Equal(t, tc.executions, app.executions)
+			}
+			require.Nil(t, app.optimisticBlock)
+
+			app.SetDeliverStateToCommit()
+			_, err = app.Commit(context.Background())
+			require.NoError(t, err)
+		}
+
+		require.Len(t, results[0], len(finalized))
+		require.Equal(t, results[0], results[1])
+		require.Equal(t, results[0], results[2])
+		require.Equal(t, seqApp.LastCommitID(), optApp.LastCommitID())
+		require.Equal(t, seqApp.LastCommitID(), occApp.LastCommitID())
+	}
+
+	// the listeners only see the txs of the finalized blocks
+	require.Equal(t, finalizedTxs, seqApp.recorder.txs)
+	require.Equal(t, finalizedTxs, optApp.recorder.txs)
+	require.Equal(t, finalizedTxs, occApp.recorder.txs)
+}
+
+func TestOptimisticProcessingDiscardedOnCommit(t *testing.T) {
+	app := setupBaseApp(t, SetOptimisticProcessing(true), func(bapp *BaseApp) {
+		bapp.SetProcessProposalHandler(func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+			return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
+		})
+		bapp.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+			ctx.KVStore(capKey1).Set([]byte("optimistic"), []byte("value"))
+			return &abci.ResponseFinalizeBlock{}, nil
+		})
+	})
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	app.SetDeliverStateToCommit()
+	_, err := app.Commit(context.Background())
+	require.NoError(t, err)
+
+	// the state of the processed proposal is committed instead of a
+	// finalized block, which discards the optimistic execution
+	_, err = app.ProcessProposal(context.Background(), &abci.RequestProcessProposal{Height: 2, Hash: []byte("block")})
+	require.NoError(t, err)
+	require.NotNil(t, app.optimisticBlock)
+	app.SetProcessProposalStateToCommit()
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, app.optimisticBlock)
+	require.Nil(t, app.deliverState)
+	require.False(t, app.cms.GetKVStore(capKey1).Has([]byte("optimistic")))
+}
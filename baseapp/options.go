@@ -3,13 +3,18 @@ package baseapp
 import (
 	"fmt"
 	"io"
+	"time"
 
 	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
 
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	"github.com/cosmos/cosmos-sdk/store"
+	storeiavl "github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/tasks"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
 	"github.com/cosmos/iavl"
 )
 
@@ -56,11 +61,163 @@ func SetCompactionInterval(compactionInterval uint64) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.setCompactionInterval(compactionInterval) }
 }
 
+// SetAsyncCommitBuffer returns a BaseApp option function that makes commits
+// write to the application database in the background, with at most the given
+// number of versions waiting to be written. A value of 0 keeps commits
+// synchronous.
+func SetAsyncCommitBuffer(size int) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setAsyncCommitBuffer(size) }
+}
+
+// SetPipelinedCommit returns a BaseApp option function that makes Commit
+// reset the check state from the writes of the committed block on top of the
+// previous version, and write the block to the stores in the background. The
+// next block waits for the background commit to complete.
+func SetPipelinedCommit(enabled bool) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setPipelinedCommit(enabled) }
+}
+
+// SetBackgroundPruning returns a BaseApp option function that moves the
+// deletion of pruned heights out of Commit into a background pruner, running
+// every interval and deleting at most batchSize heights per run (0 for no
+// limit). An interval of 0 keeps pruning in the commit path.
+func SetBackgroundPruning(batchSize int, interval time.Duration) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setBackgroundPruning(batchSize, interval) }
+}
+
+// SetHistoricalStoreCacheSize returns a BaseApp option function that keeps the
+// stores of up to size past heights open once queried, the least recently
+// queried height being closed first. Queries at an open height neither load it
+// again nor contend with commits. A size of 0 loads the height for every query.
+func SetHistoricalStoreCacheSize(size int) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setHistoricalStoreCacheSize(size) }
+}
+
+// SetIAVLFastNodeMigration returns a BaseApp option function that sets how the
+// IAVL stores whose fast nodes are missing or out of date are migrated when
+// loaded, writing at most nodesPerSecond fast nodes per second (0 for no
+// limit). It has no effect if the fast nodes are disabled.
+func SetIAVLFastNodeMigration(mode storeiavl.FastNodeMigrationMode, nodesPerSecond int) func(*BaseApp) {
+	return func(bapp *BaseApp) {
+		bapp.setFastNodeMigration(storeiavl.FastNodeMigrationConfig{Mode: mode, NodesPerSecond: nodesPerSecond})
+	}
+}
+
 // SetTrace will turn on or off trace flag
 func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
 }
 
+// SetOccEnabled sets whether the txs of a block are executed concurrently.
+func SetOccEnabled(occEnabled bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setOccEnabled(occEnabled) }
+}
+
+// SetConcurrencyWorkers sets the number of workers executing the txs of a block
+// with OCC enabled, see tasks.NewScheduler.
+func SetConcurrencyWorkers(workers int) func(*BaseApp) {
+	return func(app *BaseApp) { app.setConcurrencyWorkers(workers) }
+}
+
+// SetAntePrepass sets whether DeliverTxBatch runs the ante stage of every tx of
+// a block, such as its fee deduction and sequence increment, before the msgs of
+// any tx, rather than each tx in turn. The ante stages rarely conflict with one
+// another, so running them as a pass of their own keeps their writes out of
+// the conflicts the scheduler validates the msgs against. It changes the state
+// transitions of a block, e.g. a tx cannot pay its fee with funds an earlier
+// tx of the block sends it, so every validator of a chain must agree on it.
+//
+// The msgs of a tx run with a gas meter of the gas limit its ante handler set,
+// or an infinite one if it set none, having consumed the gas of its ante stage.
+func SetAntePrepass(antePrepass bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setAntePrepass(antePrepass) }
+}
+
+// SetOptimisticProcessing sets whether the block of an accepted proposal is
+// executed while consensus votes on it, rather than once it is finalized.
+func SetOptimisticProcessing(optimisticProcessing bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setOptimisticProcessing(optimisticProcessing) }
+}
+
+// SetConcurrentCheckTx sets whether CheckTx runs concurrently for txs that do
+// not share a sender.
+func SetConcurrentCheckTx(concurrentCheckTx bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setConcurrentCheckTx(concurrentCheckTx) }
+}
+
+// SetMempool sets the mempool tracking the txs accepted by CheckTx, which may be
+// nil, see PriorityMempool.
+func SetMempool(mempool *PriorityMempool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setMempool(mempool) }
+}
+
+// SetSignatureCacheSize sets the number of signatures verified by CheckTx that
+// are kept for DeliverTx, see sdk.SignatureCache. 0 disables the cache.
+func SetSignatureCacheSize(size int) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSignatureCacheSize(size) }
+}
+
+// SetTxTracing sets whether TraceTx may re-execute committed txs, which it
+// refuses to by default.
+func SetTxTracing(txTracing bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setTxTracing(txTracing) }
+}
+
+// SetTxResourceEvents sets whether DeliverTx adds to the response of every tx
+// an event of type sdk.EventTypeTxResources with the store accesses of the tx
+// and the number of times it executed in its block.
+func SetTxResourceEvents(txResourceEvents bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.setTxResourceEvents(txResourceEvents) }
+}
+
+// SetEventLimits bounds the events of every tx to maxEvents events of at most
+// maxAttributes attributes, 0 meaning no limit. The events and attributes past
+// the limits are dropped as they are emitted, and the tx reports how many were
+// with an event of type sdk.EventTypeEventsTruncated.
+func SetEventLimits(maxEvents, maxAttributes int) func(*BaseApp) {
+	return func(app *BaseApp) {
+		app.setEventLimits(sdk.EventLimits{MaxEvents: maxEvents, MaxAttributes: maxAttributes})
+	}
+}
+
+// SetTxAccessHintsCacheSize sets the number of txs whose access operations,
+// declared by their senders with RegisterTxAccessOps, are kept for the scheduler
+// to place them, the least recently registered being dropped first. A size of 0
+// disables RegisterTxAccessOps.
+func SetTxAccessHintsCacheSize(size int) func(*BaseApp) {
+	return func(app *BaseApp) { app.setTxAccessHintsCacheSize(size) }
+}
+
+// SetSlowBlockProfiling sets the processing time past which a block has its CPU
+// and heap profiles written to dir, 0 disabling the profiles. The CPU profile
+// starts once the threshold is exceeded and stops at the end of Commit.
+func SetSlowBlockProfiling(threshold time.Duration, dir string) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSlowBlockProfiling(threshold, dir) }
+}
+
+// SetSchedulerOptions sets options of the scheduler executing the txs of a block
+// with OCC enabled.
+func SetSchedulerOptions(opts ...tasks.Option) func(*BaseApp) {
+	return func(app *BaseApp) { app.schedulerOptions = append(app.schedulerOptions, opts...) }
+}
+
+// SetQueryGasLimits sets the gas limit of the ABCI and gRPC queries, separate
+// from the gas of the txs, past which a query fails with ErrOutOfGas, or
+// codes.ResourceExhausted over gRPC. The queries to the modules of moduleLimits
+// are bounded by their own limit, those to the other modules by defaultLimit.
+// A limit of 0 leaves the queries unbounded. The module of a query is the route
+// of its legacy querier or the package of its gRPC service without its version,
+// e.g. bank for /cosmos.bank.v1beta1.Query/AllBalances.
+func SetQueryGasLimits(defaultLimit uint64, moduleLimits map[string]uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.setQueryGasLimits(defaultLimit, moduleLimits) }
+}
+
+// SetMaxQueryDepth sets the number of gRPC queries that may be nested while
+// handling one, see GRPCQueryRouter.SetMaxQueryDepth.
+func SetMaxQueryDepth(depth int) func(*BaseApp) {
+	return func(app *BaseApp) { app.grpcQueryRouter.SetMaxQueryDepth(depth) }
+}
+
 // SetIndexEvents provides a BaseApp option function that sets the events to index.
 func SetIndexEvents(ie []string) func(*BaseApp) {
 	return func(app *BaseApp) { app.setIndexEvents(ie) }
@@ -92,6 +249,17 @@ func SetSnapshotKeepRecent(keepRecent uint32) func(*BaseApp) {
 	return func(app *BaseApp) { app.SetSnapshotKeepRecent(keepRecent) }
 }
 
+// SetSnapshotCompression sets the compression of the snapshots taken, zlib or
+// zstd.
+func SetSnapshotCompression(compression string) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotCompression(compression) }
+}
+
+// SetSnapshotChunkSize sets the size of the chunks snapshots are split into.
+func SetSnapshotChunkSize(chunkSize uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotChunkSize(chunkSize) }
+}
+
 // SetSnapshotDirectory sets the snapshot directory.
 func SetSnapshotDirectory(dir string) func(*BaseApp) {
 	return func(app *BaseApp) { app.SetSnapshotDirectory(dir) }
@@ -180,6 +348,14 @@ func (app *BaseApp) SetEndBlocker(endBlocker sdk.EndBlocker) {
 	app.endBlocker = endBlocker
 }
 
+func (app *BaseApp) SetWarmUpper(warmUpper sdk.WarmUpper) {
+	if app.sealed {
+		panic("SetWarmUpper() on sealed BaseApp")
+	}
+
+	app.warmUpper = warmUpper
+}
+
 func (app *BaseApp) SetPrepareProposalHandler(prepareProposalHandler sdk.PrepareProposalHandler) {
 	if app.sealed {
 		panic("SetPrepareProposalHandler() on sealed BaseApp")
@@ -196,6 +372,27 @@ func (app *BaseApp) SetProcessProposalHandler(processProposalHandler sdk.Process
 	app.processProposalHandler = processProposalHandler
 }
 
+// SetExtendVoteHandler sets the handler returning the vote extensions of the
+// node, when vote extensions are enabled by the ABCI consensus params.
+func (app *BaseApp) SetExtendVoteHandler(extendVoteHandler sdk.ExtendVoteHandler) {
+	if app.sealed {
+		panic("SetExtendVoteHandler() on sealed BaseApp")
+	}
+
+	app.extendVoteHandler = extendVoteHandler
+}
+
+// SetVerifyVoteExtensionHandler sets the handler verifying the vote extensions
+// of the precommits of the validators. Every extension is accepted if it is not
+// set.
+func (app *BaseApp) SetVerifyVoteExtensionHandler(verifyVoteExtensionHandler sdk.VerifyVoteExtensionHandler) {
+	if app.sealed {
+		panic("SetVerifyVoteExtensionHandler() on sealed BaseApp")
+	}
+
+	app.verifyVoteExtensionHandler = verifyVoteExtensionHandler
+}
+
 func (app *BaseApp) SetFinalizeBlocker(finalizeBlocker sdk.FinalizeBlocker) {
 	if app.sealed {
 		panic("SetFinalizeBlocker() on sealed BaseApp")
@@ -220,6 +417,54 @@ func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
 
+// SetTxPriorityHandler sets the handler computing the priority of the txs that
+// pass the ante handler of CheckTx and ReCheckTx, in place of the priority set
+// by the ante handler. The priority is returned to Tendermint, and orders the
+// txs of the proposals with a PriorityMempool, e.g. to favor the txs of an
+// allowlist of senders. The handler may reject a tx by returning an error.
+func (app *BaseApp) SetTxPriorityHandler(txPriorityHandler sdk.TxPriorityHandler) {
+	if app.sealed {
+		panic("SetTxPriorityHandler() on sealed BaseApp")
+	}
+
+	app.txPriorityHandler = txPriorityHandler
+}
+
+// RegisterTxDecoder registers the decoder of an alternative tx envelope, such as
+// Ethereum-typed txs, tried on the txs that the decoder of NewBaseApp and the
+// decoders registered before fail to decode. The txs it decodes go through the
+// ante handler like any other, which can verify them through the fee and
+// signer interfaces they implement, see sdk.FeeTx and sdk.TxWithSigners.
+func (app *BaseApp) RegisterTxDecoder(decoder sdk.TxDecoder) {
+	if app.sealed {
+		panic("RegisterTxDecoder() on sealed BaseApp")
+	}
+
+	app.txDecoder = sdk.ChainTxDecoders(app.txDecoder, decoder)
+}
+
+// RegisterGRPCInterceptors registers interceptors of the unary and streaming
+// calls to the gRPC server of the node, e.g. to authenticate the callers, log
+// the requests or record custom metrics. They are chained after the ones of the
+// server, in the order they are registered, and run before the queries of the
+// app get their sdk.Context.
+func (app *BaseApp) RegisterGRPCInterceptors(unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) {
+	if app.sealed {
+		panic("RegisterGRPCInterceptors() on sealed BaseApp")
+	}
+
+	app.grpcUnaryInterceptors = append(app.grpcUnaryInterceptors, unary...)
+	app.grpcStreamInterceptors = append(app.grpcStreamInterceptors, stream...)
+}
+
+func (app *BaseApp) SetTxBatchVerifier(txBatchVerifier sdk.TxBatchVerifier) {
+	if app.sealed {
+		panic("SetTxBatchVerifier() on sealed BaseApp")
+	}
+
+	app.txBatchVerifier = txBatchVerifier
+}
+
 func (app *BaseApp) SetAnteDepGenerator(adg sdk.AnteDepGenerator) {
 	if app.sealed {
 		panic("SetAnteDepGenerator() on sealed BaseApp")
@@ -303,6 +548,24 @@ func (app *BaseApp) SetSnapshotKeepRecent(snapshotKeepRecent uint32) {
 	app.snapshotKeepRecent = snapshotKeepRecent
 }
 
+// SetSnapshotCompression sets the compression of the snapshots taken. An empty
+// compression uses the current snapshot format.
+func (app *BaseApp) SetSnapshotCompression(compression string) {
+	if app.sealed {
+		panic("SetSnapshotCompression() on sealed BaseApp")
+	}
+	app.snapshotCompression = compression
+}
+
+// SetSnapshotChunkSize sets the size of the chunks snapshots are split into, 0
+// keeping the default size.
+func (app *BaseApp) SetSnapshotChunkSize(chunkSize uint64) {
+	if app.sealed {
+		panic("SetSnapshotChunkSize() on sealed BaseApp")
+	}
+	app.snapshotChunkSize = chunkSize
+}
+
 // SetSnapshotDirectory sets the snapshot directory.
 func (app *BaseApp) SetSnapshotDirectory(dir string) {
 	if app.sealed {
@@ -318,6 +581,46 @@ func (app *BaseApp) SetInterfaceRegistry(registry types.InterfaceRegistry) {
 	app.msgServiceRouter.SetInterfaceRegistry(registry)
 }
 
+// SetCircuitBreaker sets the CircuitBreaker deciding which message types are
+// routed.
+func (app *BaseApp) SetCircuitBreaker(cb CircuitBreaker) {
+	if app.sealed {
+		panic("SetCircuitBreaker() on sealed BaseApp")
+	}
+	app.msgServiceRouter.SetCircuitBreaker(cb)
+}
+
+// SetSimulationTracer sets a function passed the trace of the execution of
+// every simulated tx, e.g. to infer the dependencies of its messages. It is
+// called concurrently by the simulations served concurrently.
+func (app *BaseApp) SetSimulationTracer(fn func(*sdk.TxTrace)) {
+	if app.sealed {
+		panic("SetSimulationTracer() on sealed BaseApp")
+	}
+	app.simulationTracer = fn
+}
+
+// SetDependencyMismatchHandler sets the handler called with the msgs whose store
+// accesses exceed the access operations declared for them by the MsgValidator
+// of their context.
+func (app *BaseApp) SetDependencyMismatchHandler(handler DependencyMismatchHandler) {
+	if app.sealed {
+		panic("SetDependencyMismatchHandler() on sealed BaseApp")
+	}
+	app.dependencyMismatchHandler = handler
+}
+
+// SetAccessOpsValidator sets the validator checking the access operations
+// declared for a tx with RegisterTxAccessOps against the store accesses of its
+// simulation. It defaults to one matching the resource types of
+// acltypes.DefaultStoreKeyToResourceTypePrefixMap.
+func (app *BaseApp) SetAccessOpsValidator(validator *acltypes.MsgValidator) {
+	if app.sealed {
+		panic("SetAccessOpsValidator() on sealed BaseApp")
+	}
+	app.accessOpsValidator = validator
+}
+
 // SetStreamingService is used to set a streaming service into the BaseApp hooks and load the listeners into the multistore
 func (app *BaseApp) SetStreamingService(s StreamingService) {
 	// add the listeners for each StoreKey
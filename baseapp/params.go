@@ -22,8 +22,20 @@ var (
 	ParamStoreKeySynchronyParams = []byte("SynchronyParams")
 	ParamStoreKeyTimeoutParams   = []byte("TimeoutParams")
 	ParamStoreKeyABCIParams      = []byte("ABCIParams")
+	ParamStoreKeyExecutionParams = []byte("ExecutionParams")
 )
 
+// ExecutionParams are the params of the execution of the txs of the blocks,
+// which are not passed to Tendermint.
+type ExecutionParams struct {
+	// SequentialFromHeight, if positive, is the height from which the txs of the
+	// blocks are executed sequentially, even by the nodes with OCC enabled. It
+	// lets the chain stop executing txs concurrently without a binary upgrade,
+	// for instance when a bug of the scheduler is suspected, and resume at
+	// another height by setting it back to 0.
+	SequentialFromHeight int64 `json:"sequential_from_height" yaml:"sequential_from_height"`
+}
+
 // ParamStore defines the interface the parameter store used by the BaseApp must
 // fulfill.
 type ParamStore interface {
@@ -157,6 +169,19 @@ func ValidateABCIParams(i interface{}) error {
 	return nil
 }
 
+func ValidateExecutionParams(i interface{}) error {
+	v, ok := i.(ExecutionParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.SequentialFromHeight < 0 {
+		return fmt.Errorf("sequential execution height must not be negative: %d", v.SequentialFromHeight)
+	}
+
+	return nil
+}
+
 func validateDurationPointer(i *time.Duration, name string) error {
 	if i == nil || *i < 0 {
 		return fmt.Errorf("invalid %s", name)
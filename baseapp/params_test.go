@@ -63,3 +63,20 @@ func TestValidateValidatorParams(t *testing.T) {
 		require.Equal(t, tc.expectErr, baseapp.ValidateValidatorParams(tc.arg) != nil)
 	}
 }
+
+func TestValidateExecutionParams(t *testing.T) {
+	testCases := []struct {
+		arg       interface{}
+		expectErr bool
+	}{
+		{nil, true},
+		{&baseapp.ExecutionParams{}, true},
+		{baseapp.ExecutionParams{SequentialFromHeight: -1}, true},
+		{baseapp.ExecutionParams{}, false},
+		{baseapp.ExecutionParams{SequentialFromHeight: 100}, false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expectErr, baseapp.ValidateExecutionParams(tc.arg) != nil)
+	}
+}
@@ -0,0 +1,68 @@
+package baseapp
+
+import (
+	"fmt"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// captureWriteSet copies the writes of the state to commit onto a branch of
+// the last committed version before they are written to the stores, so that
+// the check state of the next height can be set from it while the stores are
+// committed. It is a no-op unless pipelined commit is enabled, or if the
+// writes were already captured, e.g. by the finalize blocker getting the
+// working hash.
+//
+// The branch reads immutable versions of the IAVL stores, which commits do not
+// change, unlike the working trees.
+func (app *BaseApp) captureWriteSet() {
+	if !app.pipelinedCommit || app.commitWriteSet != nil {
+		return
+	}
+	writeSet, err := app.cms.CacheMultiStoreWithVersion(app.LastBlockHeight())
+	if err != nil {
+		// this should never happen
+		panic(fmt.Errorf("error when branching the last committed version: %s", err))
+	}
+	writer, ok := app.stateToCommit.ms.(interface{ WriteTo(sdk.MultiStore) })
+	if !ok {
+		panic(fmt.Sprintf("cannot capture the writes of a multistore of type %T", app.stateToCommit.ms))
+	}
+	writer.WriteTo(writeSet)
+
+	// the transient stores are emptied by the commit
+	writeSet.SetKVStores(func(key sdk.StoreKey, store sdk.KVStore) sdk.CacheWrap {
+		if _, ok := key.(*storetypes.TransientStoreKey); ok {
+			return cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, key, storetypes.DefaultCacheSizeLimit)
+		}
+		return store.(sdk.CacheWrap)
+	})
+	app.commitWriteSet = writeSet
+}
+
+// commitInBackground writes ms to the stores and commits them in the
+// background. The ABCI methods that read or write the stores, other than
+// CheckTx and queries, wait for the commit to complete.
+func (app *BaseApp) commitInBackground(ms sdk.CacheMultiStore) {
+	app.backgroundCommit.Lock()
+	go func() {
+		defer app.backgroundCommit.Unlock()
+		defer telemetry.MeasureSince(time.Now(), "abci", "commit", "background")
+		ms.Write()
+		app.cms.Commit(true)
+	}()
+}
+
+// waitForCommit waits until the block being committed in the background, if
+// any, is committed.
+func (app *BaseApp) waitForCommit() {
+	app.backgroundCommit.RLock()
+	defer app.backgroundCommit.RUnlock()
+}
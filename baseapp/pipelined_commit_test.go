@@ -0,0 +1,106 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// blockingWriter blocks the first write to the traced stores once armed until
+// it is released, which pauses the background commit while the block is
+// written.
+type blockingWriter struct {
+	armed   int32
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	if bytes.Contains(p, []byte(`"operation":"write"`)) && atomic.CompareAndSwapInt32(&w.armed, 1, 0) {
+		close(w.blocked)
+		<-w.release
+	}
+	return len(p), nil
+}
+
+func TestPipelinedCommit(t *testing.T) {
+	tkey := sdk.NewTransientStoreKey("transient")
+	app := NewBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nonceTxDecoder, nil, &testutil.TestAppOpts{}, SetPipelinedCommit(true))
+	app.MountStores(capKey1, tkey)
+	app.SetParamStore(&paramStore{db: dbm.NewMemDB()})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		ntx := tx.(nonceTx)
+		store := ctx.KVStore(capKey1)
+		if seq := getIntFromStore(store, ntx.sender); uint64(seq) != ntx.nonce {
+			return ctx, fmt.Errorf("expected nonce %d", seq)
+		}
+		setIntOnStore(store, ntx.sender, int64(ntx.nonce)+1)
+		return ctx, nil
+	})
+	app.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+		ctx.TransientStore(tkey).Set([]byte("block"), []byte{1})
+		for _, tx := range req.Txs {
+			app.DeliverTx(ctx, abci.RequestDeliverTx{Tx: tx})
+		}
+		return &abci.ResponseFinalizeBlock{}, nil
+	})
+	writer := &blockingWriter{blocked: make(chan struct{}), release: make(chan struct{})}
+	app.SetCommitMultiStoreTracer(writer)
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	checkTx := func(tx string) error {
+		_, err := app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte(tx)})
+		return err
+	}
+
+	_, err := app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 1, Txs: [][]byte{[]byte("a/0/0")}})
+	require.NoError(t, err)
+	app.SetDeliverStateToCommit()
+	atomic.StoreInt32(&writer.armed, 1)
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+
+	// the check state has the writes of the block while it is being committed
+	<-writer.blocked
+	require.Equal(t, int64(0), app.LastBlockHeight())
+	require.Error(t, checkTx("a/0/0"))
+	require.NoError(t, checkTx("a/1/0"))
+	require.Nil(t, app.checkState.ctx.TransientStore(tkey).Get([]byte("block")))
+
+	// and the next block waits for the commit
+	finalized := make(chan struct{})
+	go func() {
+		defer close(finalized)
+		_, err := app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 2, Txs: [][]byte{[]byte("a/1/0")}})
+		require.NoError(t, err)
+	}()
+	require.Never(t, func() bool {
+		select {
+		case <-finalized:
+			return true
+		default:
+			return false
+		}
+	}, 50*time.Millisecond, 5*time.Millisecond)
+	close(writer.release)
+	<-finalized
+	require.Equal(t, int64(1), app.LastBlockHeight())
+
+	app.SetDeliverStateToCommit()
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+	app.waitForCommit()
+	require.Equal(t, int64(2), app.LastBlockHeight())
+	require.Equal(t, int64(2), getIntFromStore(app.cms.GetKVStore(capKey1), []byte("a")))
+	require.NoError(t, checkTx("a/2/0"))
+}
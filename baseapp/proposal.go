@@ -0,0 +1,174 @@
+package baseapp
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProposalTx is a tx of a proposed block along with its decoding.
+type ProposalTx struct {
+	Bytes []byte
+	Tx    sdk.Tx
+}
+
+// TxOrderer reorders and filters the txs of a block being proposed, e.g. to
+// group the txs that do not conflict for the scheduler. It returns the txs to
+// propose in execution order, which must be taken from txs.
+type TxOrderer func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error)
+
+// ProposalValidator checks the txs of a proposed block in block order, and
+// returns an error for the block to be rejected.
+type ProposalValidator func(ctx sdk.Context, txs []ProposalTx) error
+
+// ProposalHandler builds the PrepareProposal and ProcessProposal handlers of an
+// application from its hooks.
+//
+// The proposer drops the txs that cannot be decoded, passes the others to the
+// TxOrderer, and proposes as many of them as fit in the size and gas limits of
+// the block. Validators reject the blocks with txs that cannot be decoded or
+// that exceed these limits, then pass the txs to the ProposalValidator.
+type ProposalHandler struct {
+	txDecoder sdk.TxDecoder
+	orderTxs  TxOrderer
+	validate  ProposalValidator
+}
+
+// NewProposalHandler returns a ProposalHandler that keeps the order of the txs
+// and accepts every well-formed block until hooks are set.
+func NewProposalHandler(txDecoder sdk.TxDecoder) *ProposalHandler {
+	return &ProposalHandler{txDecoder: txDecoder}
+}
+
+// SetTxOrderer sets the hook reordering and filtering the txs of the blocks
+// proposed.
+func (h *ProposalHandler) SetTxOrderer(orderTxs TxOrderer) {
+	h.orderTxs = orderTxs
+}
+
+// SetProposalValidator sets the hook checking the txs of the blocks proposed by
+// other validators.
+func (h *ProposalHandler) SetProposalValidator(validate ProposalValidator) {
+	h.validate = validate
+}
+
+// PrepareProposalHandler returns the handler proposing the txs returned by the
+// TxOrderer, within the limits of the block. The txs left out are removed from
+// the block.
+func (h *ProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+		txs, _ := h.decodeTxs(req.Txs)
+		if h.orderTxs != nil {
+			ordered, err := h.orderTxs(ctx, txs)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkSubset(ordered, txs); err != nil {
+				return nil, err
+			}
+			txs = ordered
+		}
+
+		maxGas := maxBlockGas(ctx)
+		var size int64
+		var gas uint64
+		records := make([]*abci.TxRecord, 0, len(txs))
+		for _, tx := range txs {
+			txGas := gasWanted(tx.Tx)
+			// a tx that does not fit is skipped rather than ending the block,
+			// so that smaller txs ordered after it are still proposed
+			if size+int64(len(tx.Bytes)) > req.MaxTxBytes || (maxGas > 0 && gas+txGas > maxGas) {
+				continue
+			}
+			size += int64(len(tx.Bytes))
+			gas += txGas
+			records = append(records, &abci.TxRecord{Action: abci.TxRecord_UNMODIFIED, Tx: tx.Bytes})
+		}
+		return &abci.ResponsePrepareProposal{TxRecords: records}, nil
+	}
+}
+
+// ProcessProposalHandler returns the handler rejecting the blocks with txs that
+// cannot be decoded, that exceed the gas limit of the block or that the
+// ProposalValidator rejects.
+func (h *ProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
+	return func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+		reject := func(err error) (*abci.ResponseProcessProposal, error) {
+			ctx.Logger().Info("rejecting proposal", "height", req.Height, "hash", fmt.Sprintf("%X", req.Hash), "err", err)
+			return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+		}
+
+		txs, err := h.decodeTxs(req.Txs)
+		if err != nil {
+			return reject(err)
+		}
+		if maxGas := maxBlockGas(ctx); maxGas > 0 {
+			var gas uint64
+			for _, tx := range txs {
+				gas += gasWanted(tx.Tx)
+			}
+			if gas > maxGas {
+				return reject(fmt.Errorf("block wants %d gas, more than the maximum of %d", gas, maxGas))
+			}
+		}
+		if h.validate != nil {
+			if err := h.validate(ctx, txs); err != nil {
+				return reject(err)
+			}
+		}
+		return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
+	}
+}
+
+// decodeTxs returns the txs of txsBytes that can be decoded, in order, along
+// with the error of the first one that cannot.
+func (h *ProposalHandler) decodeTxs(txsBytes [][]byte) ([]ProposalTx, error) {
+	var firstErr error
+	txs := make([]ProposalTx, 0, len(txsBytes))
+	for i, bz := range txsBytes {
+		tx, err := h.txDecoder(bz)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to decode tx %d: %w", i, err)
+			}
+			continue
+		}
+		txs = append(txs, ProposalTx{Bytes: bz, Tx: tx})
+	}
+	return txs, firstErr
+}
+
+// checkSubset returns an error if ordered has txs that are not in txs or that
+// it repeats, which Tendermint rejects.
+func checkSubset(ordered, txs []ProposalTx) error {
+	available := make(map[string]int, len(txs))
+	for _, tx := range txs {
+		available[string(tx.Bytes)]++
+	}
+	for _, tx := range ordered {
+		if available[string(tx.Bytes)] == 0 {
+			return fmt.Errorf("tx orderer returned a tx that is not proposable or is repeated: %X", tx.Bytes)
+		}
+		available[string(tx.Bytes)]--
+	}
+	return nil
+}
+
+// maxBlockGas returns the maximum gas of the block of ctx, or 0 if it is not
+// limited.
+func maxBlockGas(ctx sdk.Context) uint64 {
+	cp := ctx.ConsensusParams()
+	if cp == nil || cp.Block == nil || cp.Block.MaxGas <= 0 {
+		return 0
+	}
+	return uint64(cp.Block.MaxGas)
+}
+
+func gasWanted(tx sdk.Tx) uint64 {
+	if feeTx, ok := tx.(sdk.FeeTx); ok {
+		return feeTx.GetGas()
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package baseapp
+
+import (
+	"github.com/cosmos/cosmos-sdk/tasks"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// TxAccessOpsGetter returns the access operations declared by the msgs of a tx,
+// or nil if they are unknown, in which case the tx may access any state.
+type TxAccessOpsGetter func(tx sdk.Tx) []acltypes.AccessOperation
+
+// BatchingTxOrderer returns a TxOrderer grouping the txs ordered by next, or the
+// txs in mempool order if next is nil, into batches of txs that do not conflict
+// according to accessOps, and proposing the batches one after the other. The
+// scheduler of the validators executing the block then finds each batch in a
+// single wave, rather than the conflicting txs being spread across the block.
+//
+// A tx is placed in the batch after the last one with a tx ordered before it
+// that it conflicts with or that has the same sender, so that every pair of txs
+// keeps its order if their execution results depend on it. A tx with unknown
+// access operations gets a batch of its own, ordered after all the txs before
+// it and before all the txs after it. maxBatchSize, if positive, bounds the
+// number of txs of a batch, the txs that do not fit going in a later batch.
+// The batches only depend on the txs and their access operations.
+func BatchingTxOrderer(next TxOrderer, accessOps TxAccessOpsGetter, maxBatchSize int) TxOrderer {
+	return func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		if next != nil {
+			ordered, err := next(ctx, txs)
+			if err != nil {
+				return nil, err
+			}
+			txs = ordered
+		}
+
+		batches := batchTxs(txs, accessOps, maxBatchSize)
+		ctx.Logger().Debug("batched proposal txs", "txs", len(txs), "batches", len(batches))
+		batched := make([]ProposalTx, 0, len(txs))
+		for _, batch := range batches {
+			batched = append(batched, batch...)
+		}
+		return batched, nil
+	}
+}
+
+// batchTxs partitions txs into the batches of BatchingTxOrderer, keeping the
+// order of txs within each batch.
+func batchTxs(txs []ProposalTx, accessOps TxAccessOpsGetter, maxBatchSize int) [][]ProposalTx {
+	ops := make([][]acltypes.AccessOperation, len(txs))
+	batchOf := make([]int, len(txs))
+	lastBatchOfSender := make(map[string]int)
+	var batches [][]ProposalTx
+	// the first batch the txs can go in, after the last tx with unknown
+	// access operations
+	floor := 0
+	for i, tx := range txs {
+		ops[i] = accessOps(tx.Tx)
+		batch := floor
+		if len(ops[i]) == 0 {
+			batch = len(batches)
+		} else {
+			for j := 0; j < i; j++ {
+				if batchOf[j] >= batch && len(ops[j]) > 0 && tasks.AccessOpsConflict(ops[i], ops[j]) {
+					batch = batchOf[j] + 1
+				}
+			}
+		}
+		sender, _, hasSender := txSenderNonce(tx.Tx)
+		if last, ok := lastBatchOfSender[sender]; hasSender && ok && last >= batch {
+			batch = last + 1
+		}
+		for maxBatchSize > 0 && batch < len(batches) && len(batches[batch]) >= maxBatchSize {
+			batch++
+		}
+
+		if batch == len(batches) {
+			batches = append(batches, nil)
+		}
+		batches[batch] = append(batches[batch], tx)
+		batchOf[i] = batch
+		if hasSender {
+			lastBatchOfSender[sender] = batch
+		}
+		if len(ops[i]) == 0 {
+			floor = batch + 1
+		}
+	}
+	return batches
+}
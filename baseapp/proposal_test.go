@@ -0,0 +1,215 @@
+package baseapp
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// gasTx is a tx that only wants gas, encoded as its decimal gas.
+type gasTx struct {
+	gas uint64
+}
+
+func (tx gasTx) GetMsgs() []sdk.Msg         { return nil }
+func (tx gasTx) ValidateBasic() error       { return nil }
+func (tx gasTx) GetGas() uint64             { return tx.gas }
+func (tx gasTx) GetFee() sdk.Coins          { return nil }
+func (tx gasTx) FeePayer() sdk.AccAddress   { return nil }
+func (tx gasTx) FeeGranter() sdk.AccAddress { return nil }
+
+func gasTxDecoder(txBytes []byte) (sdk.Tx, error) {
+	gas, err := strconv.ParseUint(string(txBytes), 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrTxDecode, err.Error())
+	}
+	return gasTx{gas}, nil
+}
+
+func proposalContext(maxGas int64) sdk.Context {
+	return sdk.Context{}.WithLogger(log.NewNopLogger()).WithConsensusParams(&tmproto.ConsensusParams{
+		Block: &tmproto.BlockParams{MaxGas: maxGas},
+	})
+}
+
+func proposedTxs(res *abci.ResponsePrepareProposal) []string {
+	var txs []string
+	for _, record := range res.TxRecords {
+		txs = append(txs, string(record.Tx))
+	}
+	return txs
+}
+
+func TestPrepareProposal(t *testing.T) {
+	handler := NewProposalHandler(gasTxDecoder)
+	req := &abci.RequestPrepareProposal{
+		MaxTxBytes: 100,
+		Txs:        [][]byte{[]byte("10"), []byte("bad"), []byte("200"), []byte("30")},
+	}
+
+	// the undecodable tx is dropped
+	res, err := handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10", "200", "30"}, proposedTxs(res))
+
+	// the txs that do not fit in the block are skipped
+	res, err = handler.PrepareProposalHandler()(proposalContext(100), req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10", "30"}, proposedTxs(res))
+	req.MaxTxBytes = 4
+	res, err = handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10", "30"}, proposedTxs(res))
+	req.MaxTxBytes = 100
+
+	// the orderer reorders and filters the txs
+	handler.SetTxOrderer(func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		require.Len(t, txs, 3)
+		return []ProposalTx{txs[2], txs[0]}, nil
+	})
+	res, err = handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"30", "10"}, proposedTxs(res))
+
+	// but cannot propose other txs
+	handler.SetTxOrderer(func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return append(txs, txs[0]), nil
+	})
+	_, err = handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.ErrorContains(t, err, "is repeated")
+	handler.SetTxOrderer(func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return append(txs, ProposalTx{Bytes: []byte("bad")}), nil
+	})
+	_, err = handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.ErrorContains(t, err, "not proposable")
+
+	handler.SetTxOrderer(func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return nil, errors.New("orderer failed")
+	})
+	_, err = handler.PrepareProposalHandler()(proposalContext(-1), req)
+	require.EqualError(t, err, "orderer failed")
+}
+
+func TestProcessProposal(t *testing.T) {
+	handler := NewProposalHandler(gasTxDecoder)
+	process := func(maxGas int64, txs ...string) abci.ResponseProcessProposal_ProposalStatus {
+		req := &abci.RequestProcessProposal{}
+		for _, tx := range txs {
+			req.Txs = append(req.Txs, []byte(tx))
+		}
+		res, err := handler.ProcessProposalHandler()(proposalContext(maxGas), req)
+		require.NoError(t, err)
+		return res.Status
+	}
+
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, process(-1))
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, process(-1, "10", "200"))
+	require.Equal(t, abci.ResponseProcessProposal_REJECT, process(-1, "10", "bad"))
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, process(210, "10", "200"))
+	require.Equal(t, abci.ResponseProcessProposal_REJECT, process(209, "10", "200"))
+
+	var validated []ProposalTx
+	handler.SetProposalValidator(func(ctx sdk.Context, txs []ProposalTx) error {
+		validated = txs
+		for _, tx := range txs {
+			if tx.Tx.(gasTx).gas == 0 {
+				return errors.New("tx wants no gas")
+			}
+		}
+		return nil
+	})
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, process(-1, "10", "200"))
+	require.Equal(t, []ProposalTx{{Bytes: []byte("10"), Tx: gasTx{10}}, {Bytes: []byte("200"), Tx: gasTx{200}}}, validated)
+	require.Equal(t, abci.ResponseProcessProposal_REJECT, process(-1, "10", "0"))
+}
+
+// resourceTx is a tx of sender writing a balance, encoded as sender/nonce/key,
+// with an empty key if the balance it writes is unknown.
+type resourceTx struct {
+	sender string
+	nonce  uint64
+	key    string
+}
+
+func (tx resourceTx) GetMsgs() []sdk.Msg   { return nil }
+func (tx resourceTx) ValidateBasic() error { return nil }
+func (tx resourceTx) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(tx.sender)}
+}
+func (tx resourceTx) GetNonce() uint64 { return tx.nonce }
+
+func resourceTxs(t *testing.T, encoded ...string) []ProposalTx {
+	txs := make([]ProposalTx, len(encoded))
+	for i, bz := range encoded {
+		parts := strings.Split(bz, "/")
+		require.Len(t, parts, 3)
+		nonce, err := strconv.ParseUint(parts[1], 10, 64)
+		require.NoError(t, err)
+		txs[i] = ProposalTx{Bytes: []byte(bz), Tx: resourceTx{sender: parts[0], nonce: nonce, key: parts[2]}}
+	}
+	return txs
+}
+
+func resourceTxAccessOps(tx sdk.Tx) []acltypes.AccessOperation {
+	key := tx.(resourceTx).key
+	if key == "" {
+		return nil
+	}
+	return []acltypes.AccessOperation{{
+		AccessType:         acltypes.AccessType_WRITE,
+		ResourceType:       acltypes.ResourceType_KV_BANK_BALANCES,
+		IdentifierTemplate: key,
+	}}
+}
+
+func batchedTxs(t *testing.T, orderer TxOrderer, txs []ProposalTx) []string {
+	ordered, err := orderer(proposalContext(-1), txs)
+	require.NoError(t, err)
+	require.NoError(t, checkSubset(ordered, txs))
+	var encoded []string
+	for _, tx := range ordered {
+		encoded = append(encoded, string(tx.Bytes))
+	}
+	return encoded
+}
+
+func TestBatchingTxOrderer(t *testing.T) {
+	txs := resourceTxs(t, "a/0/x", "b/0/x", "c/0/y", "d/0/z", "a/1/w", "e/0/y")
+
+	// the txs writing x and y are split across two batches, the second tx of a
+	// following the first one
+	orderer := BatchingTxOrderer(nil, resourceTxAccessOps, 0)
+	require.Equal(t, []string{"a/0/x", "c/0/y", "d/0/z", "b/0/x", "a/1/w", "e/0/y"}, batchedTxs(t, orderer, txs))
+
+	// the batches are bounded
+	orderer = BatchingTxOrderer(nil, resourceTxAccessOps, 2)
+	require.Equal(t, []string{"a/0/x", "c/0/y", "b/0/x", "d/0/z", "a/1/w", "e/0/y"}, batchedTxs(t, orderer, txs))
+
+	// a tx with unknown accesses is ordered between the txs before and after it
+	txs = resourceTxs(t, "a/0/x", "b/0/y", "c/0/", "d/0/x", "e/0/z")
+	orderer = BatchingTxOrderer(nil, resourceTxAccessOps, 0)
+	require.Equal(t, []string{"a/0/x", "b/0/y", "c/0/", "d/0/x", "e/0/z"}, batchedTxs(t, orderer, txs))
+
+	// the txs are batched in the order of the next orderer, which may filter them
+	txs = resourceTxs(t, "a/0/x", "b/0/x", "c/0/y")
+	reverse := func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return []ProposalTx{txs[2], txs[1]}, nil
+	}
+	orderer = BatchingTxOrderer(reverse, resourceTxAccessOps, 0)
+	require.Equal(t, []string{"c/0/y", "b/0/x"}, batchedTxs(t, orderer, txs))
+	failing := func(ctx sdk.Context, txs []ProposalTx) ([]ProposalTx, error) {
+		return nil, errors.New("failed")
+	}
+	_, err := BatchingTxOrderer(failing, resourceTxAccessOps, 0)(proposalContext(-1), txs)
+	require.Error(t, err)
+}
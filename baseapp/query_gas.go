@@ -0,0 +1,90 @@
+package baseapp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// apiVersion matches the version of a protobuf package, e.g. v1beta1.
+var apiVersion = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// queryModule returns the module a query is addressed to, used to look up its
+// gas limit: the route of a legacy querier, e.g. bank for custom/bank/balance,
+// or the last component of the package of a gRPC service, its version aside,
+// e.g. bank for /cosmos.bank.v1beta1.Query/AllBalances.
+func queryModule(path string) string {
+	parts := splitPath(path)
+	switch {
+	case len(parts) >= 2 && parts[0] == "custom":
+		return parts[1]
+	case len(parts) != 2:
+		return ""
+	}
+	// the last component of the service is its name
+	components := strings.Split(parts[0], ".")
+	for i := len(components) - 2; i >= 0; i-- {
+		if !apiVersion.MatchString(components[i]) {
+			return components[i]
+		}
+	}
+	return ""
+}
+
+// ParseQueryGasLimits parses the per-module query gas limits of the app config,
+// each given as module=limit.
+func ParseQueryGasLimits(limits []string) (map[string]uint64, error) {
+	parsed := make(map[string]uint64, len(limits))
+	for _, limit := range limits {
+		i := strings.LastIndex(limit, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid query gas limit %q, expected module=limit", limit)
+		}
+		gas, err := strconv.ParseUint(limit[i+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query gas limit %q: %w", limit, err)
+		}
+		parsed[limit[:i]] = gas
+	}
+	return parsed, nil
+}
+
+// queryGasLimit returns the gas limit of the queries to module, 0 if they are
+// unbounded.
+func (app *BaseApp) queryGasLimit(module string) uint64 {
+	if limit, ok := app.moduleQueryGasLimits[module]; ok {
+		return limit
+	}
+	return app.defaultQueryGasLimit
+}
+
+// withQueryGasMeter returns ctx, a query context, metered with the gas limit of
+// the queries to the module path is addressed to.
+func (app *BaseApp) withQueryGasMeter(ctx sdk.Context, path string) sdk.Context {
+	limit := app.queryGasLimit(queryModule(path))
+	if limit == 0 {
+		return ctx
+	}
+	return ctx.WithGasMeter(sdk.NewGasMeter(limit))
+}
+
+// recoverQueryOutOfGas turns the out of gas panic of a query run with ctx into
+// an ErrOutOfGas error, to be deferred by the handlers of the queries.
+func recoverQueryOutOfGas(ctx sdk.Context, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	oog, ok := r.(sdk.ErrorOutOfGas)
+	if !ok {
+		panic(r)
+	}
+	*err = sdkerrors.Wrapf(
+		sdkerrors.ErrOutOfGas, "query out of gas in location: %v; gasLimit: %d, gasUsed: %d",
+		oog.Descriptor, ctx.GasMeter().Limit(), ctx.GasMeter().GasConsumed(),
+	)
+}
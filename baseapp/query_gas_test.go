@@ -0,0 +1,82 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestQueryModule(t *testing.T) {
+	require.Equal(t, "bank", queryModule("/cosmos.bank.v1beta1.Query/AllBalances"))
+	require.Equal(t, "dex", queryModule("/seiprotocol.seichain.dex.Query/GetPrice"))
+	require.Equal(t, "wasm", queryModule("/cosmwasm.wasm.v1.Query/SmartContractState"))
+	require.Equal(t, "gov", queryModule("custom/gov/proposal/1"))
+	require.Equal(t, "gov", queryModule("/custom/gov"))
+	require.Equal(t, "", queryModule("/store/bank/key"))
+}
+
+func TestParseQueryGasLimits(t *testing.T) {
+	limits, err := ParseQueryGasLimits([]string{"bank=1000", "wasm=0"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"bank": 1000, "wasm": 0}, limits)
+
+	_, err = ParseQueryGasLimits([]string{"bank"})
+	require.Error(t, err)
+	_, err = ParseQueryGasLimits([]string{"bank=-1"})
+	require.Error(t, err)
+}
+
+func TestQueryGasLimits(t *testing.T) {
+	app := setupBaseApp(t, SetQueryGasLimits(1000, map[string]uint64{"unbounded": 0, "bounded": 10}))
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	querier := func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		ctx.GasMeter().ConsumeGas(100, "scan")
+		return []byte("done"), nil
+	}
+	for _, route := range []string{"default", "unbounded", "bounded"} {
+		app.QueryRouter().AddRoute(route, querier)
+	}
+
+	res, err := app.Query(context.Background(), &abci.RequestQuery{Path: "custom/default/scan"})
+	require.NoError(t, err)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte("done"), res.Value)
+	res, err = app.Query(context.Background(), &abci.RequestQuery{Path: "custom/unbounded/scan"})
+	require.NoError(t, err)
+	require.True(t, res.IsOK(), res.Log)
+
+	res, err = app.Query(context.Background(), &abci.RequestQuery{Path: "custom/bounded/scan"})
+	require.NoError(t, err)
+	require.Equal(t, sdkerrors.ErrOutOfGas.ABCICode(), res.Code, res.Log)
+	require.Contains(t, res.Log, "scan")
+}
+
+func TestGRPCQueryRouterMaxQueryDepth(t *testing.T) {
+	qr := NewGRPCQueryRouter()
+	qr.SetInterfaceRegistry(testdata.NewTestInterfaceRegistry())
+	testdata.RegisterQueryServer(qr, testdata.QueryImpl{})
+	qr.SetMaxQueryDepth(2)
+	echo := qr.Route("/testdata.Query/Echo")
+	bz, err := (&testdata.EchoRequest{Message: "hello"}).Marshal()
+	require.NoError(t, err)
+	ctx := sdk.NewContext(nil, tmproto.Header{}, false, nil).WithContext(context.Background())
+
+	_, err = echo(ctx, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+	// a query handled while handling another one
+	nested := ctx.WithContext(context.WithValue(ctx.Context(), queryDepthKey{}, 1))
+	_, err = echo(nested, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+	tooDeep := ctx.WithContext(context.WithValue(ctx.Context(), queryDepthKey{}, 2))
+	_, err = echo(tooDeep, abci.RequestQuery{Data: bz})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -6,6 +6,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
 )
 
 // RecoveryHandler handles recovery() object.
@@ -63,6 +64,23 @@ func newOutOfGasRecoveryMiddleware(gasWanted uint64, ctx sdk.Context, next recov
 	return newRecoveryMiddleware(handler, next)
 }
 
+// newOCCAbortRecoveryMiddleware creates the recovery middleware for the aborts
+// of the concurrent executions of txs, raised by their version indexed stores,
+// so that the response of an aborted execution carries the stable code of its
+// cause rather than a panic and its stack.
+func newOCCAbortRecoveryMiddleware(next recoveryMiddleware) recoveryMiddleware {
+	handler := func(recoveryObj interface{}) error {
+		abort, ok := recoveryObj.(occ.Abort)
+		if !ok {
+			return nil
+		}
+
+		return occ.AbortError(abort)
+	}
+
+	return newRecoveryMiddleware(handler, next)
+}
+
 // newDefaultRecoveryMiddleware creates a default (last in chain) recovery middleware for app.runTx method.
 func newDefaultRecoveryMiddleware() recoveryMiddleware {
 	handler := func(recoveryObj interface{}) error {
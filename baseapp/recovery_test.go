@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
 )
 
 // Test that recovery chain produces expected error at specific middleware layer
@@ -62,3 +65,21 @@ func TestRecoveryChain(t *testing.T) {
 		require.Nil(t, receivedErr)
 	}
 }
+
+func TestOCCAbortRecovery(t *testing.T) {
+	mw := newOCCAbortRecoveryMiddleware(newDefaultRecoveryMiddleware())
+
+	// the aborts are delivered with the stable codes of their causes, and a
+	// log depending only on the abort
+	res := sdkerrors.ResponseDeliverTx(processRecovery(occ.NewEstimateAbort(3), mw), 0, 0, false)
+	require.Equal(t, occ.Codespace, res.Codespace)
+	require.Equal(t, occ.ErrEstimateReadTimeout.ABCICode(), res.Code)
+	require.Equal(t, "read an estimate of tx 3: estimate read timeout", res.Log)
+	res = sdkerrors.ResponseDeliverTx(processRecovery(occ.NewTimeoutAbort(), mw), 0, 0, false)
+	require.Equal(t, occ.Codespace, res.Codespace)
+	require.Equal(t, occ.ErrEstimateReadTimeout.ABCICode(), res.Code)
+	require.Equal(t, "execution timed out: estimate read timeout", res.Log)
+
+	// the other panics are left to the next middleware
+	require.ErrorIs(t, processRecovery("failed", mw), sdkerrors.ErrPanic)
+}
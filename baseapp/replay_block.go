@@ -0,0 +1,123 @@
+package baseapp
+
+import (
+	"bytes"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// BlockReplay is the outcome of the re-execution of a block by ReplayBlock.
+type BlockReplay struct {
+	Height int64
+	// AppHash is the app hash of the state resulting from the block
+	AppHash   []byte
+	TxResults []*abci.ExecTxResult
+	// Writes are the writes of the block that changed the committed stores,
+	// by store name, sorted by key
+	Writes map[string][]StoreWrite
+}
+
+// StoreWrite is the change of the value of a key by a block.
+type StoreWrite struct {
+	Key []byte
+	// OldValue is the value before the block, nil if the key did not exist
+	OldValue []byte
+	// NewValue is the value after the block, nil if the key was deleted
+	NewValue []byte
+}
+
+var _ storetypes.WriteListener = (*blockWriteRecorder)(nil)
+
+// blockWriteRecorder records the final value of every key written to the
+// committed stores, nil for deletes.
+type blockWriteRecorder struct {
+	writes map[storetypes.StoreKey]map[string][]byte
+}
+
+func (r *blockWriteRecorder) OnWrite(storeKey storetypes.StoreKey, key []byte, value []byte, delete bool) error {
+	writes, ok := r.writes[storeKey]
+	if !ok {
+		writes = make(map[string][]byte)
+		r.writes[storeKey] = writes
+	}
+	if delete {
+		value = nil
+	}
+	writes[string(key)] = value
+	return nil
+}
+
+// ReplayBlock loads the state committed at the height preceding the block of
+// req and executes the block on it, without committing it. It returns the app
+// hash the block results in and the writes it makes, which are meant to be
+// compared with the ones of other nodes to diagnose an app hash divergence.
+//
+// The app is left with the uncommitted state of the block and listening to
+// the writes to its stores, it must not be used afterwards.
+func (app *BaseApp) ReplayBlock(req *abci.RequestFinalizeBlock) (*BlockReplay, error) {
+	if app.finalizeBlocker == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "finalize block handler not set")
+	}
+	if req.Height < 2 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidHeight, "cannot replay block %d, the state before it is not committed", req.Height)
+	}
+	if err := app.cms.LoadVersion(req.Height - 1); err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to load state at height %d; %s", req.Height-1, err)
+	}
+	pre, err := app.cms.CacheMultiStoreWithVersion(req.Height - 1)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to load state at height %d; %s", req.Height-1, err)
+	}
+
+	// the listeners only see the writes reaching the committed stores, which
+	// are the final values of the block
+	recorder := &blockWriteRecorder{writes: make(map[storetypes.StoreKey]map[string][]byte)}
+	for _, key := range app.cms.StoreKeys() {
+		if app.cms.GetCommitKVStore(key).GetStoreType() != storetypes.StoreTypeIAVL {
+			continue
+		}
+		app.cms.AddListeners(key, []storetypes.WriteListener{recorder})
+	}
+
+	app.prepareFinalizeBlockState(req)
+	res, err := app.finalizeBlocker(app.deliverState.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.AppHash) == 0 {
+		// the finalize blocker left the writes of the block to Commit
+		app.SetDeliverStateToCommit()
+		res.AppHash = app.WriteStateToCommitAndGetWorkingHash()
+	}
+
+	replay := &BlockReplay{
+		Height:    req.Height,
+		AppHash:   res.AppHash,
+		TxResults: res.TxResults,
+		Writes:    make(map[string][]StoreWrite),
+	}
+	for storeKey, writes := range recorder.writes {
+		preStore := pre.GetKVStore(storeKey)
+		for key, value := range writes {
+			oldValue := preStore.Get([]byte(key))
+			if (oldValue == nil) == (value == nil) && bytes.Equal(oldValue, value) {
+				continue
+			}
+			replay.Writes[storeKey.Name()] = append(replay.Writes[storeKey.Name()], StoreWrite{
+				Key:      []byte(key),
+				OldValue: oldValue,
+				NewValue: value,
+			})
+		}
+	}
+	for _, writes := range replay.Writes {
+		sort.Slice(writes, func(i, j int) bool {
+			return bytes.Compare(writes[i].Key, writes[j].Key) < 0
+		})
+	}
+	return replay, nil
+}
@@ -0,0 +1,103 @@
+package baseapp
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestReplayBlock(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	newApp := func(options ...func(*BaseApp)) *BaseApp {
+		app := setupBaseApp(t, append(options,
+			func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+			func(bapp *BaseApp) {
+				bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+			},
+			func(bapp *BaseApp) {
+				bapp.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+					ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+					batch := sdk.DeliverTxBatchRequest{}
+					for _, tx := range req.Txs {
+						batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: tx}})
+					}
+					res := &abci.ResponseFinalizeBlock{}
+					for _, r := range bapp.DeliverTxBatch(ctx, batch).Results {
+						res.TxResults = append(res.TxResults, &abci.ExecTxResult{Code: r.Response.Code, GasUsed: r.Response.GasUsed})
+					}
+					return res, nil
+				})
+			},
+		)...)
+		app.InitChain(context.Background(), &abci.RequestInitChain{})
+		return app
+	}
+
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+	var reqs []*abci.RequestFinalizeBlock
+	for height, counter := int64(1), int64(0); height <= 3; height++ {
+		req := &abci.RequestFinalizeBlock{Height: height}
+		for i := 0; i < 3; i++ {
+			txBytes, err := cdc.Marshal(newTxCounter(counter, counter))
+			require.NoError(t, err)
+			req.Txs = append(req.Txs, txBytes)
+			counter++
+		}
+		reqs = append(reqs, req)
+	}
+
+	app := newApp()
+	var appHashes [][]byte
+	for _, req := range reqs {
+		_, err := app.FinalizeBlock(context.Background(), req)
+		require.NoError(t, err)
+		app.SetDeliverStateToCommit()
+		_, err = app.Commit(context.Background())
+		require.NoError(t, err)
+		appHashes = append(appHashes, app.LastCommitID().Hash)
+	}
+
+	// the replay of the second block, sequentially and with the scheduler,
+	// yields the committed app hash
+	for _, replayApp := range []*BaseApp{app, newApp(SetOccEnabled(true))} {
+		if replayApp != app {
+			for _, req := range reqs[:1] {
+				_, err := replayApp.FinalizeBlock(context.Background(), req)
+				require.NoError(t, err)
+				replayApp.SetDeliverStateToCommit()
+				_, err = replayApp.Commit(context.Background())
+				require.NoError(t, err)
+			}
+		}
+
+		replay, err := replayApp.ReplayBlock(reqs[1])
+		require.NoError(t, err)
+		require.Equal(t, int64(2), replay.Height)
+		require.Equal(t, appHashes[1], replay.AppHash)
+		require.Len(t, replay.TxResults, 3)
+		for _, res := range replay.TxResults {
+			require.Equal(t, uint32(0), res.Code)
+		}
+
+		// the counters went from 3 to 6 in the block
+		varint := func(i int64) []byte {
+			buf := make([]byte, binary.MaxVarintLen64)
+			return buf[:binary.PutVarint(buf, i)]
+		}
+		require.Equal(t, []StoreWrite{
+			{Key: anteKey, OldValue: varint(3), NewValue: varint(6)},
+			{Key: deliverKey, OldValue: varint(3), NewValue: varint(6)},
+		}, replay.Writes[capKey1.Name()])
+	}
+
+	// the first block has no committed state before it
+	_, err := newApp().ReplayBlock(reqs[0])
+	require.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package baseapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// slowBlockProfiler captures a CPU profile of the blocks whose processing, from
+// FinalizeBlock to the end of Commit, exceeds a threshold, starting once the
+// threshold is exceeded and stopping at the end of the block, and a heap
+// profile at the end of such blocks. The profiles are written to a directory,
+// named after the height of their block, so that the slow blocks can be
+// diagnosed after the fact.
+type slowBlockProfiler struct {
+	threshold time.Duration
+	dir       string
+	logger    log.Logger
+
+	mtx       sync.Mutex
+	height    int64
+	start     time.Time
+	timer     *time.Timer
+	cpuFile   *os.File
+	cpuHeight int64
+}
+
+func newSlowBlockProfiler(threshold time.Duration, dir string, logger log.Logger) *slowBlockProfiler {
+	return &slowBlockProfiler{
+		threshold: threshold,
+		dir:       dir,
+		logger:    logger.With("module", "slow-block-profiler"),
+	}
+}
+
+// startBlock starts timing the block at height, ending the block being timed
+// if any.
+func (p *slowBlockProfiler) startBlock(height int64) {
+	if p == nil {
+		return
+	}
+	p.endBlock()
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.height = height
+	p.start = time.Now()
+	p.timer = time.AfterFunc(p.threshold, func() { p.startCPUProfile(height) })
+}
+
+// startCPUProfile starts the CPU profile of the block at height, unless it
+// ended in the meantime.
+func (p *slowBlockProfiler) startCPUProfile(height int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.timer == nil || p.height != height {
+		return
+	}
+
+	f, err := p.createProfile(height, "cpu")
+	if err != nil {
+		p.logger.Error("failed to create the CPU profile of a slow block", "height", height, "err", err)
+		return
+	}
+	// only one CPU profile can run at once, e.g. the pprof server may be
+	// running one
+	if err := pprof.StartCPUProfile(f); err != nil {
+		p.logger.Error("failed to start the CPU profile of a slow block", "height", height, "err", err)
+		f.Close()
+		os.Remove(f.Name())
+		return
+	}
+	p.cpuFile = f
+	p.cpuHeight = height
+	p.logger.Info("block exceeded the slow block threshold, capturing its profile", "height", height, "threshold", p.threshold)
+}
+
+// endBlock ends the block being timed, stopping its CPU profile and writing
+// its heap profile if it was slow.
+func (p *slowBlockProfiler) endBlock() {
+	if p == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.timer == nil {
+		return
+	}
+	p.timer.Stop()
+	p.timer = nil
+	if p.cpuFile == nil {
+		return
+	}
+
+	pprof.StopCPUProfile()
+	cpuPath := p.cpuFile.Name()
+	if err := p.cpuFile.Close(); err != nil {
+		p.logger.Error("failed to write the CPU profile of a slow block", "height", p.cpuHeight, "err", err)
+	}
+	p.cpuFile = nil
+
+	heapPath, err := p.writeHeapProfile(p.cpuHeight)
+	if err != nil {
+		p.logger.Error("failed to write the heap profile of a slow block", "height", p.cpuHeight, "err", err)
+	}
+	p.logger.Info(
+		"captured the profile of a slow block", "height", p.cpuHeight, "duration", time.Since(p.start),
+		"cpu_profile", cpuPath, "heap_profile", heapPath,
+	)
+}
+
+func (p *slowBlockProfiler) writeHeapProfile(height int64) (string, error) {
+	f, err := p.createProfile(height, "heap")
+	if err != nil {
+		return "", err
+	}
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// createProfile creates the file of a profile of the block at height. The
+// files are named after the time they are created at too, as a block may be
+// processed more than once, e.g. after a restart.
+func (p *slowBlockProfiler) createProfile(height int64, kind string) (*os.File, error) {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("block-%d-%s-%d.pprof", height, kind, time.Now().Unix())
+	return os.Create(filepath.Join(p.dir, name))
+}
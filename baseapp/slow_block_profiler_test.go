@@ -0,0 +1,46 @@
+package baseapp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSlowBlockProfiler(t *testing.T) {
+	dir := t.TempDir()
+	p := newSlowBlockProfiler(10*time.Millisecond, dir, log.NewNopLogger())
+
+	// a block ending before the threshold has no profile
+	p.startBlock(1)
+	p.endBlock()
+	time.Sleep(20 * time.Millisecond)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	p.startBlock(2)
+	require.Eventually(t, func() bool {
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		return p.cpuFile != nil
+	}, time.Second, time.Millisecond)
+	p.endBlock()
+
+	for _, kind := range []string{"cpu", "heap"} {
+		files, err := filepath.Glob(filepath.Join(dir, "block-2-"+kind+"-*.pprof"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		info, err := os.Stat(files[0])
+		require.NoError(t, err)
+		require.NotZero(t, info.Size())
+	}
+
+	// the profiler is disabled by default
+	var disabled *slowBlockProfiler
+	disabled.startBlock(3)
+	disabled.endBlock()
+}
@@ -0,0 +1,47 @@
+package baseapp
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ExportState writes the state committed at the last height to w in the
+// binary format of snapshots.Manager.ExportState, which preserves the IAVL
+// trees of the stores and is much faster to export and import than a JSON
+// genesis. It requires a snapshot store, see SetSnapshotStore.
+func (app *BaseApp) ExportState(w io.Writer) (snapshots.StateHeader, error) {
+	lastCommitID := app.LastCommitID()
+	if lastCommitID.Version == 0 {
+		return snapshots.StateHeader{}, sdkerrors.Wrap(sdkerrors.ErrLogic, "no state committed")
+	}
+	header := snapshots.StateHeader{Height: uint64(lastCommitID.Version), AppHash: lastCommitID.Hash}
+	if err := app.snapshotManager.ExportState(header, w); err != nil {
+		return snapshots.StateHeader{}, err
+	}
+	return header, nil
+}
+
+// ImportState restores the state written by ExportState read from r, and
+// checks that it results in the app hash it was exported with. The app must
+// have no state committed, and must not be used afterwards: it is meant to be
+// restarted on the imported state, once the Tendermint stores are bootstrapped
+// at its height.
+func (app *BaseApp) ImportState(r io.Reader) (snapshots.StateHeader, error) {
+	if version := app.LastBlockHeight(); version != 0 {
+		return snapshots.StateHeader{}, sdkerrors.Wrapf(sdkerrors.ErrLogic, "cannot import state on the state committed at height %d", version)
+	}
+	header, err := app.snapshotManager.ImportState(r)
+	if err != nil {
+		return snapshots.StateHeader{}, err
+	}
+	lastCommitID := app.LastCommitID()
+	if uint64(lastCommitID.Version) != header.Height || !bytes.Equal(lastCommitID.Hash, header.AppHash) {
+		return snapshots.StateHeader{}, sdkerrors.Wrapf(sdkerrors.ErrLogic,
+			"imported state at height %d with app hash %X, expected height %d and app hash %X",
+			lastCommitID.Version, lastCommitID.Hash, header.Height, header.AppHash)
+	}
+	return header, nil
+}
@@ -0,0 +1,54 @@
+package baseapp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestExportImportState(t *testing.T) {
+	source, teardown := setupBaseAppWithSnapshots(t, 3, 2)
+	defer teardown()
+
+	var buf bytes.Buffer
+	header, err := source.ExportState(&buf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), header.Height)
+	require.Equal(t, source.LastCommitID().Hash, header.AppHash)
+	state := buf.Bytes()
+
+	target, teardown := setupBaseAppWithSnapshots(t, 0, 0)
+	defer teardown()
+	imported, err := target.ImportState(bytes.NewReader(state))
+	require.NoError(t, err)
+	require.Equal(t, header, imported)
+	require.Equal(t, source.LastCommitID(), target.LastCommitID())
+
+	// the state is only imported on an empty app
+	_, err = target.ImportState(bytes.NewReader(state))
+	require.ErrorIs(t, err, sdkerrors.ErrLogic)
+	_, err = source.ExportState(&buf)
+	require.NoError(t, err)
+}
+
+func TestImportStateAppHashMismatch(t *testing.T) {
+	source, teardown := setupBaseAppWithSnapshots(t, 2, 2)
+	defer teardown()
+	var buf bytes.Buffer
+	header, err := source.ExportState(&buf)
+	require.NoError(t, err)
+
+	// the app hash of the header follows its 8 bytes of magic, 4 of version,
+	// 8 of height and 4 of app hash size
+	state := buf.Bytes()
+	require.Equal(t, header.AppHash, state[24:24+len(header.AppHash)])
+	state[24] ^= 0xff
+
+	target, teardown := setupBaseAppWithSnapshots(t, 0, 0)
+	defer teardown()
+	_, err = target.ImportState(bytes.NewReader(state))
+	require.ErrorContains(t, err, "imported state at height 2")
+}
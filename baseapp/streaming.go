@@ -1,6 +1,7 @@
 package baseapp
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -20,6 +21,14 @@ type ABCIListener interface {
 	ListenDeliverTx(ctx types.Context, req abci.RequestDeliverTx, res abci.ResponseDeliverTx) error
 }
 
+// CommitListener is an optional interface of the StreamingServices notified once a
+// block is committed, by which point all of its state changes went through their
+// WriteListeners
+type CommitListener interface {
+	// ListenCommit updates the streaming service with the height of the committed block
+	ListenCommit(ctx context.Context, height int64) error
+}
+
 // StreamingService interface for registering WriteListeners with the BaseApp and updating the service with the ABCI messages using the hooks
 type StreamingService interface {
 	// Stream is the streaming service loop, awaits kv pairs and writes them to some destination stream or file
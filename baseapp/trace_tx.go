@@ -0,0 +1,83 @@
+package baseapp
+
+import (
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// TraceTx re-executes the tx at index of the txs of the committed block with
+// the given header and returns the trace of its execution, see sdk.TxTracer.
+// The tx is executed as in DeliverTx on the state the previous block committed,
+// after the txs preceding it in the block. The begin blockers of the block are
+// not run again, so the tx does not see their writes.
+func (app *BaseApp) TraceTx(header tmproto.Header, txs [][]byte, index int) (*sdk.TxTrace, error) {
+	if !app.txTracing {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "tx tracing is disabled")
+	}
+	if index < 0 || index >= len(txs) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "tx index %d out of range of %d txs", index, len(txs))
+	}
+	if header.Height < 1 || header.Height > app.LastBlockHeight() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidHeight, "cannot trace a tx at height %d; latest height: %d", header.Height, app.LastBlockHeight())
+	}
+
+	cacheMS, err := app.cms.CacheMultiStoreWithVersion(header.Height - 1)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to load state at height %d; %s", header.Height-1, err)
+	}
+	ctx := sdk.NewContext(cacheMS, header, false, app.logger).
+		WithBlockGasMeter(sdk.NewInfiniteGasMeter()).
+		WithTxCache(sdk.NewTxCache())
+	ctx = ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+
+	tracer := sdk.NewTxTracer()
+	if app.antePrepass {
+		// the ante stage of every tx of the block ran before the msgs of any;
+		// the scheduler may be processing a block, so it runs sequentially
+		prepass := &antePrepass{outcomes: make([]anteOutcome, len(txs))}
+		for i, txBytes := range txs {
+			txCtx := withAntePrepass(ctx, prepass).WithTxIndex(i).WithTxBytes(txBytes)
+			if i == index {
+				txCtx = txCtx.WithTxTracer(tracer)
+			}
+			_, _, _, _, _ = app.runTx(txCtx, runTxModeDeliver, txBytes)
+		}
+		ctx = withAntePrepass(ctx, prepass.msgStage())
+	}
+
+	for i, txBytes := range txs[:index] {
+		_, _, _, _, _ = app.runTx(ctx.WithTxIndex(i).WithTxBytes(txBytes), runTxModeDeliver, txBytes)
+	}
+
+	txBytes := txs[index]
+	gInfo, _, _, _, err := app.runTx(ctx.WithTxIndex(index).WithTxBytes(txBytes).WithTxTracer(tracer), runTxModeDeliver, txBytes)
+
+	trace := tracer.Trace()
+	trace.Height = header.Height
+	trace.GasInfo = gInfo
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	return trace, nil
+}
+
+// SimulateWithTrace simulates the tx as Simulate does, and returns the trace of
+// its execution along with its result. A tx failing is not an error: the trace
+// records it instead, so that the failure can be debugged.
+func (app *BaseApp) SimulateWithTrace(txBytes []byte) (*sdk.TxTrace, *sdk.Result, error) {
+	ctx := app.checkState.ctx.WithTxBytes(txBytes).WithVoteInfos(app.voteInfos).WithConsensusParams(app.GetConsensusParams(app.checkState.ctx))
+	ctx, _ = ctx.CacheContext()
+
+	tracer := sdk.NewTxTracer()
+	gInfo, result, _, _, err := app.runTx(ctx.WithTxTracer(tracer), runTxModeSimulate, txBytes)
+
+	trace := tracer.Trace()
+	trace.Height = ctx.BlockHeight()
+	trace.GasInfo = gInfo
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	return trace, result, nil
+}
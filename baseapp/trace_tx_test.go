@@ -0,0 +1,166 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestTraceTx(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		SetTxTracing(true),
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	failing := newTxCounter(3, 2, 3)
+	failing.setFailOnHandler(true)
+	blocks := [][]*txTest{
+		{newTxCounter(0, 0)},
+		{newTxCounter(1, 1), newTxCounter(2, 2), failing},
+	}
+	txs := make([][][]byte, len(blocks))
+	headers := make([]tmproto.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = tmproto.Header{Height: int64(i) + 1}
+		app.setDeliverState(headers[i])
+		app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		for _, tx := range block {
+			txBytes, err := cdc.Marshal(tx)
+			require.NoError(t, err)
+			txs[i] = append(txs[i], txBytes)
+			app.DeliverTx(app.deliverState.ctx, abci.RequestDeliverTx{Tx: txBytes})
+		}
+		app.SetDeliverStateToCommit()
+		_, err := app.Commit(context.Background())
+		require.NoError(t, err)
+	}
+
+	// the tx sees the writes of the tx preceding it in its block
+	trace, err := app.TraceTx(headers[1], txs[1], 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), trace.Height)
+	require.Empty(t, trace.Error)
+	require.Len(t, trace.Phases, 2)
+	ante, msg := trace.Phases[0], trace.Phases[1]
+	require.Equal(t, sdk.TxTraceAntePhase, ante.Name)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: anteKey, Value: []byte{4}},
+		{Store: "key1", Operation: "write", Key: anteKey, Value: []byte{6}},
+	}, ante.StoreAccesses)
+	require.Equal(t, counterEvent("ante_handler", 2).ToABCIEvents(), ante.Events)
+	require.Equal(t, sdk.MsgTypeURL(msgCounter{}), msg.Name)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: deliverKey, Value: []byte{4}},
+		{Store: "key1", Operation: "write", Key: deliverKey, Value: []byte{6}},
+	}, msg.StoreAccesses)
+	require.Equal(t, counterEvent(sdk.EventTypeMessage, 2).ToABCIEvents(), msg.Events)
+	require.Positive(t, msg.GasUsed)
+	require.Equal(t, trace.GasInfo.GasUsed, ante.GasUsed+msg.GasUsed)
+
+	// the failing msg is the last phase of its tx
+	trace, err = app.TraceTx(headers[1], txs[1], 2)
+	require.NoError(t, err)
+	require.Contains(t, trace.Error, "message handler failure")
+	require.Len(t, trace.Phases, 2)
+	require.Contains(t, trace.Phases[1].Error, "message handler failure")
+
+	_, err = app.TraceTx(tmproto.Header{Height: 3}, txs[1], 0)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidHeight)
+	_, err = app.TraceTx(headers[1], txs[1], 3)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	app.setTxTracing(false)
+	_, err = app.TraceTx(headers[1], txs[1], 0)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestSimulationTracer(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	var traces []*sdk.TxTrace
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		func(bapp *BaseApp) {
+			bapp.SetSimulationTracer(func(trace *sdk.TxTrace) { traces = append(traces, trace) })
+		},
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	txBytes := cdc.MustMarshal(newTxCounter(0, 0))
+	_, _, err := app.Simulate(txBytes)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	require.Len(t, traces[0].Phases, 2)
+	require.Equal(t, sdk.MsgTypeURL(msgCounter{}), traces[0].Phases[1].Name)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: deliverKey, Value: []byte{}},
+		{Store: "key1", Operation: "write", Key: deliverKey, Value: []byte{2}},
+	}, traces[0].Phases[1].StoreAccesses)
+
+	// the txs that are not simulated are not traced
+	_, err = app.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: txBytes})
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+}
+
+func TestSimulateWithTrace(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	var traces []*sdk.TxTrace
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		func(bapp *BaseApp) {
+			bapp.SetSimulationTracer(func(trace *sdk.TxTrace) { traces = append(traces, trace) })
+		},
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	trace, result, err := app.SimulateWithTrace(cdc.MustMarshal(newTxCounter(0, 0)))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Empty(t, trace.Error)
+	require.Len(t, trace.Phases, 2)
+	require.Equal(t, sdk.TxTraceAntePhase, trace.Phases[0].Name)
+	require.Equal(t, []sdk.StoreAccess{
+		{Store: "key1", Operation: "read", Key: deliverKey, Value: []byte{}},
+		{Store: "key1", Operation: "write", Key: deliverKey, Value: []byte{2}},
+	}, trace.Phases[1].StoreAccesses)
+	require.Equal(t, trace.GasInfo.GasUsed, trace.Phases[0].GasUsed+trace.Phases[1].GasUsed)
+	// the simulation tracer is passed the trace too
+	require.Equal(t, []*sdk.TxTrace{trace}, traces)
+
+	trace.SummarizeStoreAccesses()
+	require.Equal(t, []sdk.StoreAccessSummary{{Store: "key1", Reads: 1, Writes: 1}}, trace.Phases[1].StoreAccessSummaries)
+
+	// a failing tx is traced up to the phase it failed in; the simulations do
+	// not commit their writes
+	failing := newTxCounter(0, 0)
+	failing.setFailOnHandler(true)
+	trace, result, err = app.SimulateWithTrace(cdc.MustMarshal(failing))
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.Contains(t, trace.Error, "message handler failure")
+	require.Len(t, trace.Phases, 2)
+	require.Contains(t, trace.Phases[1].Error, "message handler failure")
+}
@@ -0,0 +1,75 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBlockSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, []byte("ante-key"))) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, []byte("deliver-key"))))
+		},
+		func(bapp *BaseApp) {
+			bapp.SetFinalizeBlocker(func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+				ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+				bapp.BeginBlock(ctx, abci.RequestBeginBlock{Header: tmproto.Header{Height: req.Height}})
+				for _, tx := range req.Txs {
+					bapp.DeliverTx(ctx, abci.RequestDeliverTx{Tx: tx})
+				}
+				bapp.EndBlock(ctx, abci.RequestEndBlock{Height: req.Height})
+				return &abci.ResponseFinalizeBlock{}, nil
+			})
+		},
+	)
+	app.TracingInfo.Tracer = &tracer
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+	txBytes, err := cdc.Marshal(newTxCounter(0, 0))
+	require.NoError(t, err)
+
+	_, err = app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 1, Txs: [][]byte{txBytes}})
+	require.NoError(t, err)
+	app.SetDeliverStateToCommit()
+	_, err = app.Commit(context.Background())
+	require.NoError(t, err)
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	require.Len(t, spans["Block"], 1)
+	block := spans["Block"][0]
+	require.Contains(t, block.Attributes(), attribute.Int64("blockHeight", 1))
+
+	// the stages of the block are children of its span
+	for _, name := range []string{"BeginBlock", "RunTx", "EndBlock", "Commit"} {
+		require.Len(t, spans[name], 1, name)
+		require.Equal(t, block.SpanContext().SpanID(), spans[name][0].Parent().SpanID(), name)
+		require.Contains(t, spans[name][0].Attributes(), attribute.Int64("blockHeight", 1), name)
+	}
+	runTx := spans["RunTx"][0].SpanContext().SpanID()
+	for _, name := range []string{"AnteHandler", "RunMsgs", "WriteTxCache"} {
+		require.Len(t, spans[name], 1, name)
+		require.Equal(t, runTx, spans[name][0].Parent().SpanID(), name)
+	}
+	require.Len(t, spans["RunMsg"], 1)
+	require.Equal(t, spans["RunMsgs"][0].SpanContext().SpanID(), spans["RunMsg"][0].Parent().SpanID())
+	require.Contains(t, spans["RunMsg"][0].Attributes(), attribute.String("msgType", sdk.MsgTypeURL(msgCounter{})))
+}
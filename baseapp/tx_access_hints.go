@@ -0,0 +1,99 @@
+package baseapp
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// maxReportedMissingAccesses bounds the store accesses listed by the error of
+// RegisterTxAccessOps for access operations that do not cover them.
+const maxReportedMissingAccesses = 5
+
+// RegisterTxAccessOps registers the access operations the sender of the tx
+// declares it performs, its ante handler and msgs included, for the scheduler
+// to place the tx when it executes the tx in a block, see
+// tasks.Scheduler.ProcessAllWithHints. The tx is first simulated as in
+// DeliverTx on the check state, and the access operations are rejected if the
+// tx fails or accesses state they do not cover, according to the validator set
+// by SetAccessOpsValidator.
+//
+// The access operations only help the scheduler: a tx accessing state they do
+// not cover in its block, e.g. because the state changed since its simulation,
+// is still validated and re-executed as any other. They are kept by this node
+// only, and thus only help the blocks it executes.
+func (app *BaseApp) RegisterTxAccessOps(txBytes []byte, accessOps []acltypes.AccessOperation) error {
+	if app.txAccessHints == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "tx access operations are disabled")
+	}
+	if len(accessOps) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no access operations declared")
+	}
+	for _, op := range accessOps {
+		if op.IdentifierTemplate == "" {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "access operation %s has no identifier", op.String())
+		}
+	}
+
+	missing, err := app.missingTxAccesses(txBytes, accessOps)
+	if err != nil {
+		return sdkerrors.Wrap(err, "failed to simulate tx")
+	}
+	if len(missing) > 0 {
+		telemetry.IncrCounter(1, "tx", "access_ops", "rejected")
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidConcurrencyExecution,
+			"the access operations miss %d store accesses of the tx: %s", len(missing), formatMissingAccesses(missing))
+	}
+
+	app.txAccessHints.Add(sha256.Sum256(txBytes), accessOps)
+	telemetry.IncrCounter(1, "tx", "access_ops", "registered")
+	return nil
+}
+
+// TxAccessOps returns the access operations registered for the tx with
+// RegisterTxAccessOps, or nil if there are none.
+func (app *BaseApp) TxAccessOps(txBytes []byte) []acltypes.AccessOperation {
+	if app.txAccessHints == nil {
+		return nil
+	}
+	accessOps, _ := app.txAccessHints.Peek(sha256.Sum256(txBytes))
+	return accessOps
+}
+
+// missingTxAccesses simulates the tx as in DeliverTx on a branch of the check
+// state and returns its store accesses that accessOps do not cover.
+func (app *BaseApp) missingTxAccesses(txBytes []byte, accessOps []acltypes.AccessOperation) (map[acltypes.Comparator]bool, error) {
+	ctx := app.checkState.ctx.WithTxBytes(txBytes).WithVoteInfos(app.voteInfos).WithConsensusParams(app.GetConsensusParams(app.checkState.ctx))
+	ctx, _ = ctx.CacheContext()
+	ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()).WithTxCache(sdk.NewTxCache())
+
+	// the accesses of the tx reach the branch as its reads miss the caches of
+	// the tx and as its writes are written to it
+	if _, _, _, _, err := app.runTx(ctx, runTxModeDeliver, txBytes); err != nil {
+		return nil, err
+	}
+
+	validator := app.accessOpsValidator
+	if validator == nil {
+		validator = acltypes.NewMsgValidator(acltypes.DefaultStoreKeyToResourceTypePrefixMap())
+	}
+	return validator.ValidateAccessOperations(accessOps, ctx.MultiStore().GetEvents()), nil
+}
+
+func formatMissingAccesses(missing map[acltypes.Comparator]bool) string {
+	accesses := make([]string, 0, len(missing))
+	for c := range missing {
+		accesses = append(accesses, fmt.Sprintf("%s %s %s", c.StoreKey, c.AccessType, c.Identifier))
+	}
+	sort.Strings(accesses)
+	if len(accesses) > maxReportedMissingAccesses {
+		accesses = append(accesses[:maxReportedMissingAccesses], "...")
+	}
+	return strings.Join(accesses, ", ")
+}
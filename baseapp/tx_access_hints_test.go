@@ -0,0 +1,74 @@
+package baseapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestRegisterTxAccessOps(t *testing.T) {
+	anteKey, deliverKey := []byte("ante-key"), []byte("deliver-key")
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(sdk.NewRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey)))
+		},
+		func(bapp *BaseApp) {
+			bapp.SetAccessOpsValidator(acltypes.NewMsgValidator(acltypes.StoreKeyToResourceTypePrefixMap{
+				capKey1.Name(): {acltypes.ResourceType_KV: {}},
+			}))
+		},
+		SetTxAccessHintsCacheSize(10),
+	)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	txBytes := cdc.MustMarshal(newTxCounter(0, 0))
+	reads := acltypes.AccessOperation{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV, IdentifierTemplate: "*"}
+	writes := acltypes.AccessOperation{AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV, IdentifierTemplate: "*"}
+
+	// the tx writes the counters it reads
+	err := app.RegisterTxAccessOps(txBytes, []acltypes.AccessOperation{reads})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidConcurrencyExecution)
+	require.Nil(t, app.TxAccessOps(txBytes))
+
+	err = app.RegisterTxAccessOps(txBytes, nil)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	err = app.RegisterTxAccessOps(txBytes, []acltypes.AccessOperation{reads, {AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV}})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	failing := newTxCounter(0, 0)
+	failing.setFailOnHandler(true)
+	err = app.RegisterTxAccessOps(cdc.MustMarshal(failing), []acltypes.AccessOperation{reads, writes})
+	require.Error(t, err)
+
+	accessOps := []acltypes.AccessOperation{reads, writes}
+	require.NoError(t, app.RegisterTxAccessOps(txBytes, accessOps))
+	require.Equal(t, accessOps, app.TxAccessOps(txBytes))
+
+	// the simulation does not change the check state
+	require.NoError(t, app.RegisterTxAccessOps(txBytes, accessOps))
+}
+
+func TestRegisterTxAccessOpsDisabled(t *testing.T) {
+	app := setupBaseApp(t)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+	cdc := codec.NewLegacyAmino()
+	registerTestCodec(cdc)
+
+	txBytes := cdc.MustMarshal(newTxCounter(0, 0))
+	err := app.RegisterTxAccessOps(txBytes, []acltypes.AccessOperation{
+		{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_ANY, IdentifierTemplate: "*"},
+	})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	require.Nil(t, app.TxAccessOps(txBytes))
+}
@@ -0,0 +1,104 @@
+package baseapp
+
+import (
+	"context"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Vote extensions let the validators attach data to their precommits, e.g. the
+// prices observed by their oracles, once the ABCI consensus params enable them.
+// The ExtendVoteHandler returns the extension of the node and the
+// VerifyVoteExtensionHandler checks the extensions of the other validators, a
+// rejected extension invalidating its precommit.
+//
+// Tendermint passes the extensions of the last commit to the proposer only, in
+// the LocalLastCommit of PrepareProposal, which the prepare proposal handler
+// reads from Context.VoteExtensions. The proposals cannot carry them to the
+// other validators, the version of Tendermint used only letting the proposals
+// keep or drop txs of the mempool, so the extensions are not available to the
+// execution of the next block.
+
+// voteExtensionsEnabled reports whether the ABCI consensus params enable vote
+// extensions at height.
+func (app *BaseApp) voteExtensionsEnabled(ctx sdk.Context, height int64) bool {
+	cp := app.GetConsensusParams(ctx)
+	if cp == nil || cp.Abci == nil {
+		return false
+	}
+	enableHeight := cp.Abci.VoteExtensionsEnableHeight
+	return enableHeight > 0 && height >= enableHeight
+}
+
+// voteExtensionContext returns the context the vote extensions of the block
+// with hash at height are handled with, on a branch of the last committed
+// state.
+func (app *BaseApp) voteExtensionContext(height int64, hash []byte) sdk.Context {
+	header := tmproto.Header{ChainID: app.ChainID, Height: height}
+	ctx := sdk.NewContext(app.cms.CacheMultiStore(), header, false, app.logger).
+		WithHeaderHash(hash).
+		WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+	return ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+}
+
+// withLocalLastCommit returns ctx with the vote extensions of the last commit,
+// if they were enabled at its height.
+func (app *BaseApp) withLocalLastCommit(ctx sdk.Context, req *abci.RequestPrepareProposal) sdk.Context {
+	if !app.voteExtensionsEnabled(ctx, req.Height-1) {
+		return ctx
+	}
+	extensions := req.LocalLastCommit
+	return ctx.WithVoteExtensions(&extensions)
+}
+
+// ExtendVote implements the ABCI interface. The precommit is sent without
+// extension if the handler fails, rather than not at all.
+func (app *BaseApp) ExtendVote(ctx context.Context, req *abci.RequestExtendVote) (res *abci.ResponseExtendVote, err error) {
+	app.waitForCommit()
+	if app.extendVoteHandler == nil {
+		return &abci.ResponseExtendVote{}, nil
+	}
+	sdkCtx := app.voteExtensionContext(req.Height, req.Hash)
+	if !app.voteExtensionsEnabled(sdkCtx, req.Height) {
+		return &abci.ResponseExtendVote{}, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			app.logger.Error("panic while extending the vote", "height", req.Height, "panic", r)
+			res, err = &abci.ResponseExtendVote{}, nil
+		}
+	}()
+	res, err = app.extendVoteHandler(sdkCtx, req)
+	if err != nil {
+		app.logger.Error("failed to extend the vote", "height", req.Height, "err", err)
+		return &abci.ResponseExtendVote{}, nil
+	}
+	return res, nil
+}
+
+// VerifyVoteExtension implements the ABCI interface. The extensions the handler
+// fails to verify are rejected.
+func (app *BaseApp) VerifyVoteExtension(ctx context.Context, req *abci.RequestVerifyVoteExtension) (res *abci.ResponseVerifyVoteExtension, err error) {
+	app.waitForCommit()
+	if app.verifyVoteExtensionHandler == nil {
+		return &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_ACCEPT}, nil
+	}
+	sdkCtx := app.voteExtensionContext(req.Height, req.Hash)
+
+	defer func() {
+		if r := recover(); r != nil {
+			app.logger.Error("panic while verifying the vote extension", "height", req.Height, "validator", req.ValidatorAddress, "panic", r)
+			res, err = &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_REJECT}, nil
+		}
+	}()
+	res, err = app.verifyVoteExtensionHandler(sdkCtx, req)
+	if err != nil {
+		app.logger.Error("failed to verify the vote extension", "height", req.Height, "validator", req.ValidatorAddress, "err", err)
+		return &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_REJECT}, nil
+	}
+	return res, nil
+}
@@ -0,0 +1,82 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestVoteExtensions(t *testing.T) {
+	var extensions *abci.ExtendedCommitInfo
+	handlers := func(app *BaseApp) {
+		app.SetExtendVoteHandler(func(ctx sdk.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
+			switch string(req.Hash) {
+			case "fail":
+				return nil, errors.New("no price")
+			case "panic":
+				panic("oracle down")
+			}
+			return &abci.ResponseExtendVote{VoteExtension: append([]byte("price@"), req.Hash...)}, nil
+		})
+		app.SetVerifyVoteExtensionHandler(func(ctx sdk.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
+			switch string(req.VoteExtension) {
+			case "fail":
+				return nil, errors.New("invalid price")
+			case "panic":
+				panic("invalid price")
+			}
+			return &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_ACCEPT}, nil
+		})
+		app.SetPrepareProposalHandler(func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+			extensions = ctx.VoteExtensions()
+			return &abci.ResponsePrepareProposal{}, nil
+		})
+	}
+	app := setupBaseApp(t, handlers)
+	app.InitChain(context.Background(), &abci.RequestInitChain{
+		ConsensusParams: &tmproto.ConsensusParams{Abci: &tmproto.ABCIParams{VoteExtensionsEnableHeight: 2}},
+	})
+
+	extendVote := func(height int64, hash string) []byte {
+		res, err := app.ExtendVote(context.Background(), &abci.RequestExtendVote{Height: height, Hash: []byte(hash)})
+		require.NoError(t, err)
+		return res.VoteExtension
+	}
+	// the votes are extended from the enable height
+	require.Empty(t, extendVote(1, "a"))
+	require.Equal(t, []byte("price@b"), extendVote(2, "b"))
+	// and sent without extension if the handler fails
+	require.Empty(t, extendVote(2, "fail"))
+	require.Empty(t, extendVote(2, "panic"))
+
+	verify := func(extension string) abci.ResponseVerifyVoteExtension_VerifyStatus {
+		res, err := app.VerifyVoteExtension(context.Background(), &abci.RequestVerifyVoteExtension{Height: 2, VoteExtension: []byte(extension)})
+		require.NoError(t, err)
+		return res.Status
+	}
+	require.Equal(t, abci.ResponseVerifyVoteExtension_ACCEPT, verify("price"))
+	require.Equal(t, abci.ResponseVerifyVoteExtension_REJECT, verify("fail"))
+	require.Equal(t, abci.ResponseVerifyVoteExtension_REJECT, verify("panic"))
+
+	// the proposer reads the extensions of the last commit once enabled
+	lastCommit := abci.ExtendedCommitInfo{Votes: []abci.ExtendedVoteInfo{{SignedLastBlock: true, VoteExtension: []byte("price@b")}}}
+	_, err := app.PrepareProposal(context.Background(), &abci.RequestPrepareProposal{Height: 2, LocalLastCommit: lastCommit})
+	require.NoError(t, err)
+	require.Nil(t, extensions)
+	_, err = app.PrepareProposal(context.Background(), &abci.RequestPrepareProposal{Height: 3, LocalLastCommit: lastCommit})
+	require.NoError(t, err)
+	require.Equal(t, &lastCommit, extensions)
+}
+
+func TestVerifyVoteExtensionWithoutHandler(t *testing.T) {
+	app := setupBaseApp(t)
+	res, err := app.VerifyVoteExtension(context.Background(), &abci.RequestVerifyVoteExtension{Height: 1, VoteExtension: []byte("price")})
+	require.NoError(t, err)
+	require.Equal(t, abci.ResponseVerifyVoteExtension_ACCEPT, res.Status)
+}
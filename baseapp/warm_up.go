@@ -0,0 +1,35 @@
+package baseapp
+
+import (
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WarmUp runs the warm-up logic set by SetWarmUpper on a branch of the state
+// committed at the last height, for the modules to load the state they use the
+// most in their caches, and the stores in theirs, before the node serves its
+// first txs and queries after a restart. The writes of the warm-up logic are
+// discarded. Nothing runs if no state is committed yet.
+func (app *BaseApp) WarmUp() error {
+	height := app.LastBlockHeight()
+	if app.warmUpper == nil || height == 0 {
+		return nil
+	}
+	defer telemetry.MeasureSince(time.Now(), "app", "warm_up")
+
+	header := tmproto.Header{ChainID: app.ChainID, Height: height}
+	ctx := sdk.NewContext(app.cms.CacheMultiStore(), header, false, app.logger).
+		WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+	ctx = ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+
+	start := time.Now()
+	if err := app.warmUpper(ctx); err != nil {
+		return err
+	}
+	app.logger.Info("warmed up the app", "height", height, "duration", time.Since(start))
+	return nil
+}
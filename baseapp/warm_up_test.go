@@ -0,0 +1,52 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestWarmUp(t *testing.T) {
+	key, value := []byte("key"), []byte("value")
+	var (
+		warmUps   int
+		warmUpErr error
+	)
+	warmUpOpt := func(bapp *BaseApp) {
+		bapp.SetWarmUpper(func(ctx sdk.Context) error {
+			warmUps++
+			require.Equal(t, bapp.LastBlockHeight(), ctx.BlockHeight())
+			store := ctx.KVStore(capKey1)
+			require.Equal(t, value, store.Get(key))
+			store.Set(key, []byte("overwritten"))
+			return warmUpErr
+		})
+	}
+	app := setupBaseApp(t, warmUpOpt)
+	app.InitChain(context.Background(), &abci.RequestInitChain{})
+
+	// nothing is warmed up before the first block is committed
+	require.NoError(t, app.WarmUp())
+	require.Zero(t, warmUps)
+
+	header := tmproto.Header{Height: 1}
+	app.setDeliverState(header)
+	app.deliverState.ctx.KVStore(capKey1).Set(key, value)
+	app.SetDeliverStateToCommit()
+	app.Commit(context.Background())
+
+	require.NoError(t, app.WarmUp())
+	require.Equal(t, 1, warmUps)
+	// the writes of the warm-up are discarded
+	require.Equal(t, value, app.NewUncachedContext(false, header).KVStore(capKey1).Get(key))
+
+	warmUpErr = errors.New("failed")
+	require.ErrorIs(t, app.WarmUp(), warmUpErr)
+	require.Equal(t, 2, warmUps)
+}
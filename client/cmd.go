@@ -103,6 +103,11 @@ func ReadPersistentCommandFlags(clientCtx Context, flagSet *pflag.FlagSet) (Cont
 		clientCtx = clientCtx.WithSimulation(dryRun)
 	}
 
+	if !clientCtx.SimulateTrace || flagSet.Changed(flags.FlagDryRunTrace) {
+		simulateTrace, _ := flagSet.GetBool(flags.FlagDryRunTrace)
+		clientCtx = clientCtx.WithSimulateTrace(simulateTrace)
+	}
+
 	if clientCtx.KeyringDir == "" || flagSet.Changed(flags.FlagKeyringDir) {
 		keyringDir, _ := flagSet.GetString(flags.FlagKeyringDir)
 
@@ -44,6 +44,7 @@ type Context struct {
 	SignModeStr       string
 	UseLedger         bool
 	Simulate          bool
+	SimulateTrace     bool
 	GenerateOnly      bool
 	Offline           bool
 	SkipConfirm       bool
@@ -178,6 +179,13 @@ func (ctx Context) WithSimulation(simulate bool) Context {
 	return ctx
 }
 
+// WithSimulateTrace returns a copy of the context with updated SimulateTrace
+// value.
+func (ctx Context) WithSimulateTrace(simulateTrace bool) Context {
+	ctx.SimulateTrace = simulateTrace
+	return ctx
+}
+
 // WithOffline returns a copy of the context with updated Offline value.
 func (ctx Context) WithOffline(offline bool) Context {
 	ctx.Offline = offline
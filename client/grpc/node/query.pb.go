@@ -0,0 +1,2119 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/base/node/v1beta1/query.proto
+
+package node
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// StatusRequest is the request type for the Service/Status RPC method.
+type StatusRequest struct {
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{0}
+}
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(m, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+// StatusResponse is the response type for the Service/Status RPC method.
+type StatusResponse struct {
+	// latest_height is the height of the last block committed.
+	LatestHeight int64 `protobuf:"varint,1,opt,name=latest_height,json=latestHeight,proto3" json:"latest_height,omitempty"`
+	// latest_app_hash is the app hash of the last block committed.
+	LatestAppHash []byte           `protobuf:"bytes,2,opt,name=latest_app_hash,json=latestAppHash,proto3" json:"latest_app_hash,omitempty"`
+	Pruning       *PruningStatus   `protobuf:"bytes,3,opt,name=pruning,proto3" json:"pruning,omitempty"`
+	Snapshot      *SnapshotStatus  `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Scheduler     *SchedulerStatus `protobuf:"bytes,5,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	Cache         *CacheStatus     `protobuf:"bytes,6,opt,name=cache,proto3" json:"cache,omitempty"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{1}
+}
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(m, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetLatestHeight() int64 {
+	if m != nil {
+		return m.LatestHeight
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetLatestAppHash() []byte {
+	if m != nil {
+		return m.LatestAppHash
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetPruning() *PruningStatus {
+	if m != nil {
+		return m.Pruning
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetSnapshot() *SnapshotStatus {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetScheduler() *SchedulerStatus {
+	if m != nil {
+		return m.Scheduler
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetCache() *CacheStatus {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+// PruningStatus is how the node prunes its state and blocks.
+type PruningStatus struct {
+	KeepRecent uint64 `protobuf:"varint,1,opt,name=keep_recent,json=keepRecent,proto3" json:"keep_recent,omitempty"`
+	KeepEvery  uint64 `protobuf:"varint,2,opt,name=keep_every,json=keepEvery,proto3" json:"keep_every,omitempty"`
+	Interval   uint64 `protobuf:"varint,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// min_retain_blocks is the number of blocks Tendermint is told to retain.
+	MinRetainBlocks uint64 `protobuf:"varint,4,opt,name=min_retain_blocks,json=minRetainBlocks,proto3" json:"min_retain_blocks,omitempty"`
+}
+
+func (m *PruningStatus) Reset()         { *m = PruningStatus{} }
+func (m *PruningStatus) String() string { return proto.CompactTextString(m) }
+func (*PruningStatus) ProtoMessage()    {}
+func (*PruningStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{2}
+}
+func (m *PruningStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PruningStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PruningStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PruningStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruningStatus.Merge(m, src)
+}
+func (m *PruningStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *PruningStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruningStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruningStatus proto.InternalMessageInfo
+
+func (m *PruningStatus) GetKeepRecent() uint64 {
+	if m != nil {
+		return m.KeepRecent
+	}
+	return 0
+}
+
+func (m *PruningStatus) GetKeepEvery() uint64 {
+	if m != nil {
+		return m.KeepEvery
+	}
+	return 0
+}
+
+func (m *PruningStatus) GetInterval() uint64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *PruningStatus) GetMinRetainBlocks() uint64 {
+	if m != nil {
+		return m.MinRetainBlocks
+	}
+	return 0
+}
+
+// SnapshotStatus is how the node takes state-sync snapshots, and the snapshot
+// operation in progress.
+type SnapshotStatus struct {
+	// interval is the number of blocks between snapshots, 0 if the node does not
+	// take any.
+	Interval   uint64 `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	KeepRecent uint32 `protobuf:"varint,2,opt,name=keep_recent,json=keepRecent,proto3" json:"keep_recent,omitempty"`
+	// latest_height is the height of the latest snapshot stored, 0 if none.
+	LatestHeight uint64 `protobuf:"varint,3,opt,name=latest_height,json=latestHeight,proto3" json:"latest_height,omitempty"`
+	// operation is the operation in progress: snapshot, prune, restore, or empty
+	// if none.
+	Operation string `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	// operation_height is the height of the snapshot being taken or restored.
+	OperationHeight uint64 `protobuf:"varint,5,opt,name=operation_height,json=operationHeight,proto3" json:"operation_height,omitempty"`
+	// chunks_restored and chunks are the number of chunks applied so far and the
+	// number of chunks of the snapshot being restored.
+	ChunksRestored uint32 `protobuf:"varint,6,opt,name=chunks_restored,json=chunksRestored,proto3" json:"chunks_restored,omitempty"`
+	Chunks         uint32 `protobuf:"varint,7,opt,name=chunks,proto3" json:"chunks,omitempty"`
+}
+
+func (m *SnapshotStatus) Reset()         { *m = SnapshotStatus{} }
+func (m *SnapshotStatus) String() string { return proto.CompactTextString(m) }
+func (*SnapshotStatus) ProtoMessage()    {}
+func (*SnapshotStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{3}
+}
+func (m *SnapshotStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotStatus.Merge(m, src)
+}
+func (m *SnapshotStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotStatus proto.InternalMessageInfo
+
+func (m *SnapshotStatus) GetInterval() uint64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *SnapshotStatus) GetKeepRecent() uint32 {
+	if m != nil {
+		return m.KeepRecent
+	}
+	return 0
+}
+
+func (m *SnapshotStatus) GetLatestHeight() uint64 {
+	if m != nil {
+		return m.LatestHeight
+	}
+	return 0
+}
+
+func (m *SnapshotStatus) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *SnapshotStatus) GetOperationHeight() uint64 {
+	if m != nil {
+		return m.OperationHeight
+	}
+	return 0
+}
+
+func (m *SnapshotStatus) GetChunksRestored() uint32 {
+	if m != nil {
+		return m.ChunksRestored
+	}
+	return 0
+}
+
+func (m *SnapshotStatus) GetChunks() uint32 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+// SchedulerStatus is how the node executes the txs of the blocks.
+type SchedulerStatus struct {
+	// mode is parallel if the next block executes its txs with the OCC
+	// scheduler, sequential otherwise.
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// workers is the number of workers configured, 0 letting the scheduler
+	// adapt them to the conflicts.
+	Workers int32 `protobuf:"varint,2,opt,name=workers,proto3" json:"workers,omitempty"`
+	// last_block_workers is the number of workers available to the last block
+	// executed by the scheduler.
+	LastBlockWorkers int32 `protobuf:"varint,3,opt,name=last_block_workers,json=lastBlockWorkers,proto3" json:"last_block_workers,omitempty"`
+	// sequential_from_height is the height from which governance makes the
+	// blocks execute their txs sequentially, 0 if none.
+	SequentialFromHeight int64 `protobuf:"varint,4,opt,name=sequential_from_height,json=sequentialFromHeight,proto3" json:"sequential_from_height,omitempty"`
+}
+
+func (m *SchedulerStatus) Reset()         { *m = SchedulerStatus{} }
+func (m *SchedulerStatus) String() string { return proto.CompactTextString(m) }
+func (*SchedulerStatus) ProtoMessage()    {}
+func (*SchedulerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{4}
+}
+func (m *SchedulerStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SchedulerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SchedulerStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SchedulerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulerStatus.Merge(m, src)
+}
+func (m *SchedulerStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *SchedulerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulerStatus proto.InternalMessageInfo
+
+func (m *SchedulerStatus) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *SchedulerStatus) GetWorkers() int32 {
+	if m != nil {
+		return m.Workers
+	}
+	return 0
+}
+
+func (m *SchedulerStatus) GetLastBlockWorkers() int32 {
+	if m != nil {
+		return m.LastBlockWorkers
+	}
+	return 0
+}
+
+func (m *SchedulerStatus) GetSequentialFromHeight() int64 {
+	if m != nil {
+		return m.SequentialFromHeight
+	}
+	return 0
+}
+
+// CacheStatus is the size of the caches of the node.
+type CacheStatus struct {
+	// signature_cache_size is the number of signatures the signature cache holds
+	// at most, and signature_cache_entries the number it holds.
+	SignatureCacheSize    int32 `protobuf:"varint,1,opt,name=signature_cache_size,json=signatureCacheSize,proto3" json:"signature_cache_size,omitempty"`
+	SignatureCacheEntries int32 `protobuf:"varint,2,opt,name=signature_cache_entries,json=signatureCacheEntries,proto3" json:"signature_cache_entries,omitempty"`
+	// historical_store_cache_size is the number of historical versions of the
+	// stores kept open for queries.
+	HistoricalStoreCacheSize int32 `protobuf:"varint,3,opt,name=historical_store_cache_size,json=historicalStoreCacheSize,proto3" json:"historical_store_cache_size,omitempty"`
+	InterBlockCache          bool  `protobuf:"varint,4,opt,name=inter_block_cache,json=interBlockCache,proto3" json:"inter_block_cache,omitempty"`
+	// mempool_txs and mempool_bytes are the number and the total size of the txs
+	// of the priority mempool, if enabled.
+	MempoolTxs   int32 `protobuf:"varint,5,opt,name=mempool_txs,json=mempoolTxs,proto3" json:"mempool_txs,omitempty"`
+	MempoolBytes int64 `protobuf:"varint,6,opt,name=mempool_bytes,json=mempoolBytes,proto3" json:"mempool_bytes,omitempty"`
+}
+
+func (m *CacheStatus) Reset()         { *m = CacheStatus{} }
+func (m *CacheStatus) String() string { return proto.CompactTextString(m) }
+func (*CacheStatus) ProtoMessage()    {}
+func (*CacheStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8324226a07064341, []int{5}
+}
+func (m *CacheStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CacheStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CacheStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CacheStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStatus.Merge(m, src)
+}
+func (m *CacheStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *CacheStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStatus proto.InternalMessageInfo
+
+func (m *CacheStatus) GetSignatureCacheSize() int32 {
+	if m != nil {
+		return m.SignatureCacheSize
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetSignatureCacheEntries() int32 {
+	if m != nil {
+		return m.SignatureCacheEntries
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetHistoricalStoreCacheSize() int32 {
+	if m != nil {
+		return m.HistoricalStoreCacheSize
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetInterBlockCache() bool {
+	if m != nil {
+		return m.InterBlockCache
+	}
+	return false
+}
+
+func (m *CacheStatus) GetMempoolTxs() int32 {
+	if m != nil {
+		return m.MempoolTxs
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetMempoolBytes() int64 {
+	if m != nil {
+		return m.MempoolBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*StatusRequest)(nil), "cosmos.base.node.v1beta1.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "cosmos.base.node.v1beta1.StatusResponse")
+	proto.RegisterType((*PruningStatus)(nil), "cosmos.base.node.v1beta1.PruningStatus")
+	proto.RegisterType((*SnapshotStatus)(nil), "cosmos.base.node.v1beta1.SnapshotStatus")
+	proto.RegisterType((*SchedulerStatus)(nil), "cosmos.base.node.v1beta1.SchedulerStatus")
+	proto.RegisterType((*CacheStatus)(nil), "cosmos.base.node.v1beta1.CacheStatus")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/base/node/v1beta1/query.proto", fileDescriptor_8324226a07064341)
+}
+
+var fileDescriptor_8324226a07064341 = []byte{
+	// 746 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xce, 0xc4, 0x7f, 0x71, 0x25, 0x8e, 0x77, 0x5b, 0xcb, 0x32, 0x5a, 0xc0, 0x44, 0xe6, 0x67,
+	0xbd, 0x2b, 0xd6, 0x66, 0x17, 0xc4, 0x05, 0x71, 0xd8, 0xc0, 0xb2, 0x39, 0xa2, 0x36, 0x12, 0x12,
+	0x1c, 0x46, 0xed, 0x71, 0xc5, 0xd3, 0xf2, 0x4c, 0xf7, 0xa4, 0xbb, 0xc7, 0x24, 0x79, 0x0a, 0x6e,
+	0x3c, 0x00, 0x17, 0x1e, 0x85, 0x63, 0x8e, 0x1c, 0x51, 0x72, 0xe5, 0x11, 0x38, 0xa0, 0xe9, 0xee,
+	0xb1, 0x19, 0xa3, 0x64, 0x4f, 0x76, 0x7f, 0xf5, 0xd5, 0x37, 0x55, 0x5f, 0x57, 0x17, 0x7c, 0x18,
+	0x4b, 0x9d, 0x49, 0x3d, 0x99, 0x31, 0x8d, 0x13, 0x21, 0xe7, 0x38, 0x59, 0x3d, 0x9f, 0xa1, 0x61,
+	0xcf, 0x27, 0x67, 0x05, 0xaa, 0x8b, 0x71, 0xae, 0xa4, 0x91, 0x24, 0x74, 0xac, 0x71, 0xc9, 0x1a,
+	0x97, 0xac, 0xb1, 0x67, 0x0d, 0xfb, 0xd0, 0x9b, 0x1a, 0x66, 0x0a, 0x4d, 0xf1, 0xac, 0x40, 0x6d,
+	0x86, 0x7f, 0xef, 0xc2, 0x61, 0x85, 0xe8, 0x5c, 0x0a, 0x8d, 0xe4, 0x03, 0xe8, 0xa5, 0xcc, 0xa0,
+	0x36, 0x51, 0x82, 0x7c, 0x91, 0x98, 0x30, 0x38, 0x0a, 0x46, 0x0d, 0x7a, 0xe0, 0xc0, 0x13, 0x8b,
+	0x91, 0x8f, 0xa1, 0xef, 0x49, 0x2c, 0xcf, 0xa3, 0x84, 0xe9, 0x24, 0xdc, 0x3d, 0x0a, 0x46, 0x07,
+	0xd4, 0xe7, 0xbe, 0xcc, 0xf3, 0x13, 0xa6, 0x13, 0xf2, 0x12, 0x3a, 0xb9, 0x2a, 0x04, 0x17, 0x8b,
+	0xb0, 0x71, 0x14, 0x8c, 0xf6, 0x5f, 0x3c, 0x1e, 0xdf, 0x56, 0xdc, 0xf8, 0x3b, 0x47, 0xf4, 0xe5,
+	0x54, 0x79, 0xe4, 0x1b, 0xd8, 0xd3, 0x82, 0xe5, 0x3a, 0x91, 0x26, 0x6c, 0x5a, 0x8d, 0xd1, 0xed,
+	0x1a, 0x53, 0xcf, 0xf4, 0x22, 0xeb, 0x4c, 0xf2, 0x1a, 0xba, 0x3a, 0x4e, 0x70, 0x5e, 0xa4, 0xa8,
+	0xc2, 0x96, 0x95, 0x79, 0x72, 0x87, 0x4c, 0x45, 0xf5, 0x3a, 0x9b, 0x5c, 0xf2, 0x25, 0xb4, 0x62,
+	0x16, 0x27, 0x18, 0xb6, 0xad, 0xc8, 0x47, 0xb7, 0x8b, 0x7c, 0x5d, 0xd2, 0xbc, 0x80, 0xcb, 0x19,
+	0xfe, 0x1a, 0x40, 0xaf, 0xd6, 0x26, 0x79, 0x1f, 0xf6, 0x97, 0x88, 0x79, 0xa4, 0x30, 0x46, 0xe1,
+	0xbc, 0x6e, 0x52, 0x28, 0x21, 0x6a, 0x11, 0xf2, 0x1e, 0xd8, 0x53, 0x84, 0x2b, 0x54, 0x17, 0xd6,
+	0xe4, 0x26, 0xed, 0x96, 0xc8, 0xab, 0x12, 0x20, 0x8f, 0x60, 0x8f, 0x0b, 0x83, 0x6a, 0xc5, 0x52,
+	0xeb, 0x70, 0x93, 0xae, 0xcf, 0xe4, 0x29, 0xdc, 0xcf, 0xb8, 0x88, 0x14, 0x1a, 0xc6, 0x45, 0x34,
+	0x4b, 0x65, 0xbc, 0xd4, 0xd6, 0xc2, 0x26, 0xed, 0x67, 0x5c, 0x50, 0x8b, 0x1f, 0x5b, 0x78, 0xf8,
+	0x4f, 0x00, 0x87, 0x75, 0xf3, 0x6a, 0xd2, 0xc1, 0x96, 0xf4, 0x56, 0xd9, 0x65, 0x59, 0xbd, 0x5a,
+	0xd9, 0xff, 0x9b, 0x22, 0x57, 0x5c, 0x7d, 0x8a, 0xde, 0x85, 0xae, 0xcc, 0x51, 0x31, 0xc3, 0xa5,
+	0xb0, 0x85, 0x75, 0xe9, 0x06, 0x20, 0x4f, 0xe0, 0xde, 0xfa, 0x50, 0xa9, 0xb4, 0x5c, 0xf5, 0x6b,
+	0xdc, 0x0b, 0x3d, 0x86, 0x7e, 0x9c, 0x14, 0x62, 0xa9, 0x23, 0x85, 0xda, 0x48, 0x85, 0x73, 0x7b,
+	0x3d, 0x3d, 0x7a, 0xe8, 0x60, 0xea, 0x51, 0xf2, 0x10, 0xda, 0x0e, 0x09, 0x3b, 0x36, 0xee, 0x4f,
+	0xc3, 0xdf, 0x02, 0xe8, 0x6f, 0x5d, 0x3a, 0x21, 0xd0, 0xcc, 0xe4, 0x1c, 0x6d, 0xef, 0x5d, 0x6a,
+	0xff, 0x93, 0x10, 0x3a, 0x3f, 0x4b, 0xb5, 0x44, 0xa5, 0x6d, 0xcf, 0x2d, 0x5a, 0x1d, 0xc9, 0x27,
+	0x40, 0x52, 0xa6, 0x8d, 0xb3, 0x39, 0xaa, 0x48, 0x0d, 0x4b, 0xba, 0x57, 0x46, 0xac, 0xd1, 0x3f,
+	0x78, 0xf6, 0xe7, 0xf0, 0x50, 0x97, 0x4f, 0x50, 0x18, 0xce, 0xd2, 0xe8, 0x54, 0xc9, 0xac, 0xea,
+	0xb0, 0x69, 0x5f, 0xdb, 0x83, 0x4d, 0xf4, 0x5b, 0x25, 0x33, 0xd7, 0xe6, 0xf0, 0xf7, 0x5d, 0xd8,
+	0xff, 0xcf, 0x54, 0x91, 0x4f, 0xe1, 0x81, 0xe6, 0x0b, 0xc1, 0x4c, 0xa1, 0x30, 0xb2, 0x13, 0x16,
+	0x69, 0x7e, 0xe9, 0x2a, 0x6e, 0x51, 0xb2, 0x8e, 0xb9, 0x1c, 0x7e, 0x89, 0xe4, 0x0b, 0x78, 0x7b,
+	0x3b, 0x03, 0x85, 0x51, 0x1c, 0xab, 0x7e, 0xde, 0xaa, 0x27, 0xbd, 0x72, 0x41, 0xf2, 0x15, 0xbc,
+	0x93, 0xf0, 0xd2, 0x43, 0x1e, 0xb3, 0x34, 0x2a, 0xff, 0xd4, 0x3e, 0xe8, 0xda, 0x0c, 0x37, 0x94,
+	0xa9, 0x91, 0x5e, 0xc1, 0x7e, 0xf6, 0x29, 0xdc, 0xb7, 0xa3, 0xe3, 0xdd, 0x71, 0x0f, 0xa8, 0xec,
+	0x74, 0x8f, 0xf6, 0x6d, 0xc0, 0x9a, 0x63, 0xf9, 0xe5, 0x68, 0x65, 0x98, 0xe5, 0x52, 0xa6, 0x91,
+	0x39, 0xd7, 0xf6, 0xc6, 0x5b, 0x14, 0x3c, 0xf4, 0xfd, 0xb9, 0x2e, 0x47, 0xab, 0x22, 0xcc, 0x2e,
+	0x0c, 0x6a, 0x7b, 0xd5, 0x0d, 0x7a, 0xe0, 0xc1, 0xe3, 0x12, 0x7b, 0x71, 0x0a, 0x9d, 0x29, 0xaa,
+	0x15, 0x8f, 0x91, 0xfc, 0x04, 0x6d, 0xef, 0xd7, 0x1d, 0xcb, 0xa7, 0xb6, 0x16, 0x1f, 0x8d, 0xde,
+	0x4c, 0x74, 0xdb, 0xf2, 0xf8, 0xf5, 0x1f, 0xd7, 0x83, 0xe0, 0xea, 0x7a, 0x10, 0xfc, 0x75, 0x3d,
+	0x08, 0x7e, 0xb9, 0x19, 0xec, 0x5c, 0xdd, 0x0c, 0x76, 0xfe, 0xbc, 0x19, 0xec, 0xfc, 0xf8, 0x6c,
+	0xc1, 0x4d, 0x52, 0xcc, 0xc6, 0xb1, 0xcc, 0x26, 0x7e, 0x6d, 0xbb, 0x9f, 0x67, 0x7a, 0xbe, 0x9c,
+	0xc4, 0x29, 0x47, 0x61, 0x26, 0x0b, 0x95, 0xc7, 0x76, 0x91, 0xcf, 0xda, 0x76, 0x77, 0x7f, 0xf6,
+	0xef, 0x00, 0x52, 0x25, 0xc9, 0xdc, 0xe3, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ServiceClient is the client API for Service service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ServiceClient interface {
+	// Status returns the committed height of the application and how the node
+	// prunes, snapshots and executes the blocks.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type serviceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewServiceClient(cc grpc1.ClientConn) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.node.v1beta1.Service/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServiceServer is the server API for Service service.
+type ServiceServer interface {
+	// Status returns the committed height of the application and how the node
+	// prunes, snapshots and executes the blocks.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterServiceServer(s grpc1.Server, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+func _Service_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.node.v1beta1.Service/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.base.node.v1beta1.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _Service_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/base/node/v1beta1/query.proto",
+}
+
+func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *StatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Cache != nil {
+		{
+			size, err := m.Cache.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Scheduler != nil {
+		{
+			size, err := m.Scheduler.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Snapshot != nil {
+		{
+			size, err := m.Snapshot.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Pruning != nil {
+		{
+			size, err := m.Pruning.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.LatestAppHash) > 0 {
+		i -= len(m.LatestAppHash)
+		copy(dAtA[i:], m.LatestAppHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.LatestAppHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LatestHeight != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.LatestHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PruningStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PruningStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PruningStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MinRetainBlocks != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.MinRetainBlocks))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Interval != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Interval))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.KeepEvery != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.KeepEvery))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.KeepRecent != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.KeepRecent))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Chunks != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Chunks))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ChunksRestored != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.ChunksRestored))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.OperationHeight != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.OperationHeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Operation) > 0 {
+		i -= len(m.Operation)
+		copy(dAtA[i:], m.Operation)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Operation)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.LatestHeight != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.LatestHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.KeepRecent != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.KeepRecent))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Interval != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Interval))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SchedulerStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchedulerStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SequentialFromHeight != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.SequentialFromHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LastBlockWorkers != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.LastBlockWorkers))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Workers != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Workers))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Mode) > 0 {
+		i -= len(m.Mode)
+		copy(dAtA[i:], m.Mode)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Mode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CacheStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CacheStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CacheStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MempoolBytes != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.MempoolBytes))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MempoolTxs != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.MempoolTxs))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.InterBlockCache {
+		i--
+		if m.InterBlockCache {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.HistoricalStoreCacheSize != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.HistoricalStoreCacheSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.SignatureCacheEntries != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.SignatureCacheEntries))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.SignatureCacheSize != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.SignatureCacheSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LatestHeight != 0 {
+		n += 1 + sovQuery(uint64(m.LatestHeight))
+	}
+	l = len(m.LatestAppHash)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Pruning != nil {
+		l = m.Pruning.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Scheduler != nil {
+		l = m.Scheduler.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Cache != nil {
+		l = m.Cache.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *PruningStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeepRecent != 0 {
+		n += 1 + sovQuery(uint64(m.KeepRecent))
+	}
+	if m.KeepEvery != 0 {
+		n += 1 + sovQuery(uint64(m.KeepEvery))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovQuery(uint64(m.Interval))
+	}
+	if m.MinRetainBlocks != 0 {
+		n += 1 + sovQuery(uint64(m.MinRetainBlocks))
+	}
+	return n
+}
+
+func (m *SnapshotStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Interval != 0 {
+		n += 1 + sovQuery(uint64(m.Interval))
+	}
+	if m.KeepRecent != 0 {
+		n += 1 + sovQuery(uint64(m.KeepRecent))
+	}
+	if m.LatestHeight != 0 {
+		n += 1 + sovQuery(uint64(m.LatestHeight))
+	}
+	l = len(m.Operation)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.OperationHeight != 0 {
+		n += 1 + sovQuery(uint64(m.OperationHeight))
+	}
+	if m.ChunksRestored != 0 {
+		n += 1 + sovQuery(uint64(m.ChunksRestored))
+	}
+	if m.Chunks != 0 {
+		n += 1 + sovQuery(uint64(m.Chunks))
+	}
+	return n
+}
+
+func (m *SchedulerStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Mode)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Workers != 0 {
+		n += 1 + sovQuery(uint64(m.Workers))
+	}
+	if m.LastBlockWorkers != 0 {
+		n += 1 + sovQuery(uint64(m.LastBlockWorkers))
+	}
+	if m.SequentialFromHeight != 0 {
+		n += 1 + sovQuery(uint64(m.SequentialFromHeight))
+	}
+	return n
+}
+
+func (m *CacheStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignatureCacheSize != 0 {
+		n += 1 + sovQuery(uint64(m.SignatureCacheSize))
+	}
+	if m.SignatureCacheEntries != 0 {
+		n += 1 + sovQuery(uint64(m.SignatureCacheEntries))
+	}
+	if m.HistoricalStoreCacheSize != 0 {
+		n += 1 + sovQuery(uint64(m.HistoricalStoreCacheSize))
+	}
+	if m.InterBlockCache {
+		n += 2
+	}
+	if m.MempoolTxs != 0 {
+		n += 1 + sovQuery(uint64(m.MempoolTxs))
+	}
+	if m.MempoolBytes != 0 {
+		n += 1 + sovQuery(uint64(m.MempoolBytes))
+	}
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestHeight", wireType)
+			}
+			m.LatestHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestAppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LatestAppHash = append(m.LatestAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LatestAppHash == nil {
+				m.LatestAppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pruning", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pruning == nil {
+				m.Pruning = &PruningStatus{}
+			}
+			if err := m.Pruning.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Snapshot == nil {
+				m.Snapshot = &SnapshotStatus{}
+			}
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduler", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Scheduler == nil {
+				m.Scheduler = &SchedulerStatus{}
+			}
+			if err := m.Scheduler.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cache", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cache == nil {
+				m.Cache = &CacheStatus{}
+			}
+			if err := m.Cache.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PruningStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PruningStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PruningStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepRecent", wireType)
+			}
+			m.KeepRecent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeepRecent |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepEvery", wireType)
+			}
+			m.KeepEvery = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeepEvery |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinRetainBlocks", wireType)
+			}
+			m.MinRetainBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinRetainBlocks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepRecent", wireType)
+			}
+			m.KeepRecent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeepRecent |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestHeight", wireType)
+			}
+			m.LatestHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationHeight", wireType)
+			}
+			m.OperationHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OperationHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunksRestored", wireType)
+			}
+			m.ChunksRestored = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunksRestored |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+			}
+			m.Chunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Chunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchedulerStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchedulerStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchedulerStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workers", wireType)
+			}
+			m.Workers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Workers |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockWorkers", wireType)
+			}
+			m.LastBlockWorkers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastBlockWorkers |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequentialFromHeight", wireType)
+			}
+			m.SequentialFromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequentialFromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CacheStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CacheStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CacheStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureCacheSize", wireType)
+			}
+			m.SignatureCacheSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignatureCacheSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureCacheEntries", wireType)
+			}
+			m.SignatureCacheEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignatureCacheEntries |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalStoreCacheSize", wireType)
+			}
+			m.HistoricalStoreCacheSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HistoricalStoreCacheSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InterBlockCache", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.InterBlockCache = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolTxs", wireType)
+			}
+			m.MempoolTxs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MempoolTxs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolBytes", wireType)
+			}
+			m.MempoolBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MempoolBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipQuery(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthQuery
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupQuery
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthQuery
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthQuery        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowQuery          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupQuery = fmt.Errorf("proto: unexpected end of group")
+)
@@ -6,13 +6,15 @@ package tmservice
 import (
 	context "context"
 	fmt "fmt"
-	types "github.com/cosmos/cosmos-sdk/codec/types"
+	_go "github.com/confio/ics23/go"
+	types1 "github.com/cosmos/cosmos-sdk/codec/types"
 	query "github.com/cosmos/cosmos-sdk/types/query"
 	_ "github.com/gogo/protobuf/gogoproto"
 	grpc1 "github.com/gogo/protobuf/grpc"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/tendermint/tendermint/abci/types"
 	p2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
-	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
+	types2 "github.com/tendermint/tendermint/proto/tendermint/types"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -33,6 +35,318 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// BatchQueryRequest is the request type for the Query/BatchQuery RPC method.
+type BatchQueryRequest struct {
+	// height is the height the queries are executed at, the latest committed one
+	// if it is 0.
+	Height  int64       `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Queries []ABCIQuery `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries"`
+}
+
+func (m *BatchQueryRequest) Reset()         { *m = BatchQueryRequest{} }
+func (m *BatchQueryRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchQueryRequest) ProtoMessage()    {}
+func (*BatchQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40c93fb3ef485c5d, []int{0}
+}
+func (m *BatchQueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchQueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchQueryRequest.Merge(m, src)
+}
+func (m *BatchQueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchQueryRequest proto.InternalMessageInfo
+
+func (m *BatchQueryRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BatchQueryRequest) GetQueries() []ABCIQuery {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+// GetProofRequest is the request type for the Query/GetProof RPC method.
+type GetProofRequest struct {
+	// store_key is the name of the store holding the key.
+	StoreKey string `protobuf:"bytes,1,opt,name=store_key,json=storeKey,proto3" json:"store_key,omitempty"`
+	Key      []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// height is the height of the state the key is proven in, the latest
+	// committed one if it is 0.
+	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetProofRequest) Reset()         { *m = GetProofRequest{} }
+func (m *GetProofRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProofRequest) ProtoMessage()    {}
+func (*GetProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40c93fb3ef485c5d, []int{1}
+}
+func (m *GetProofRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetProofRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProofRequest.Merge(m, src)
+}
+func (m *GetProofRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProofRequest proto.InternalMessageInfo
+
+func (m *GetProofRequest) GetStoreKey() string {
+	if m != nil {
+		return m.StoreKey
+	}
+	return ""
+}
+
+func (m *GetProofRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *GetProofRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// GetProofResponse is the response type for the Query/GetProof RPC method.
+type GetProofResponse struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// value is the value of the key, empty if the key is absent.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// proofs are the proof of the key in its store, followed by the proof of the
+	// store in the multistore, as in an IBC MerklePath.
+	Proofs []*_go.CommitmentProof `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	// app_hash is the root the proofs lead to, i.e. the app hash of the state at
+	// height, which the header of the next height commits to.
+	AppHash []byte `protobuf:"bytes,4,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+}
+
+func (m *GetProofResponse) Reset()         { *m = GetProofResponse{} }
+func (m *GetProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetProofResponse) ProtoMessage()    {}
+func (*GetProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40c93fb3ef485c5d, []int{2}
+}
+func (m *GetProofResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetProofResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProofResponse.Merge(m, src)
+}
+func (m *GetProofResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProofResponse proto.InternalMessageInfo
+
+func (m *GetProofResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetProofResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *GetProofResponse) GetProofs() []*_go.CommitmentProof {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+func (m *GetProofResponse) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+// ABCIQuery is a query of a BatchQueryRequest, as sent to the ABCI Query
+// method, e.g. a gRPC query path with its encoded request, or a "/store" path
+// with prove set to get the proof of the value.
+type ABCIQuery struct {
+	Path  string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Prove bool   `protobuf:"varint,3,opt,name=prove,proto3" json:"prove,omitempty"`
+}
+
+func (m *ABCIQuery) Reset()         { *m = ABCIQuery{} }
+func (m *ABCIQuery) String() string { return proto.CompactTextString(m) }
+func (*ABCIQuery) ProtoMessage()    {}
+func (*ABCIQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40c93fb3ef485c5d, []int{3}
+}
+func (m *ABCIQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ABCIQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ABCIQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ABCIQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ABCIQuery.Merge(m, src)
+}
+func (m *ABCIQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ABCIQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ABCIQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ABCIQuery proto.InternalMessageInfo
+
+func (m *ABCIQuery) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ABCIQuery) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ABCIQuery) GetProve() bool {
+	if m != nil {
+		return m.Prove
+	}
+	return false
+}
+
+// BatchQueryResponse is the response type for the Query/BatchQuery RPC method.
+type BatchQueryResponse struct {
+	// height is the height the queries were executed at.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// responses are the responses of the queries, in order. A failed query has
+	// a non-zero code without failing the others.
+	Responses []types.ResponseQuery `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses"`
+}
+
+func (m *BatchQueryResponse) Reset()         { *m = BatchQueryResponse{} }
+func (m *BatchQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchQueryResponse) ProtoMessage()    {}
+func (*BatchQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40c93fb3ef485c5d, []int{4}
+}
+func (m *BatchQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchQueryResponse.Merge(m, src)
+}
+func (m *BatchQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchQueryResponse proto.InternalMessageInfo
+
+func (m *BatchQueryResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BatchQueryResponse) GetResponses() []types.ResponseQuery {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
 // GetValidatorSetByHeightRequest is the request type for the Query/GetValidatorSetByHeight RPC method.
 type GetValidatorSetByHeightRequest struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -44,7 +358,7 @@ func (m *GetValidatorSetByHeightRequest) Reset()         { *m = GetValidatorSetB
 func (m *GetValidatorSetByHeightRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidatorSetByHeightRequest) ProtoMessage()    {}
 func (*GetValidatorSetByHeightRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{0}
+	return fileDescriptor_40c93fb3ef485c5d, []int{5}
 }
 func (m *GetValidatorSetByHeightRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -99,7 +413,7 @@ func (m *GetValidatorSetByHeightResponse) Reset()         { *m = GetValidatorSet
 func (m *GetValidatorSetByHeightResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidatorSetByHeightResponse) ProtoMessage()    {}
 func (*GetValidatorSetByHeightResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{1}
+	return fileDescriptor_40c93fb3ef485c5d, []int{6}
 }
 func (m *GetValidatorSetByHeightResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -159,7 +473,7 @@ func (m *GetLatestValidatorSetRequest) Reset()         { *m = GetLatestValidator
 func (m *GetLatestValidatorSetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetLatestValidatorSetRequest) ProtoMessage()    {}
 func (*GetLatestValidatorSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{2}
+	return fileDescriptor_40c93fb3ef485c5d, []int{7}
 }
 func (m *GetLatestValidatorSetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -207,7 +521,7 @@ func (m *GetLatestValidatorSetResponse) Reset()         { *m = GetLatestValidato
 func (m *GetLatestValidatorSetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetLatestValidatorSetResponse) ProtoMessage()    {}
 func (*GetLatestValidatorSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{3}
+	return fileDescriptor_40c93fb3ef485c5d, []int{8}
 }
 func (m *GetLatestValidatorSetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -259,17 +573,17 @@ func (m *GetLatestValidatorSetResponse) GetPagination() *query.PageResponse {
 
 // Validator is the type for the validator-set.
 type Validator struct {
-	Address          string     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	PubKey           *types.Any `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
-	VotingPower      int64      `protobuf:"varint,3,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
-	ProposerPriority int64      `protobuf:"varint,4,opt,name=proposer_priority,json=proposerPriority,proto3" json:"proposer_priority,omitempty"`
+	Address          string      `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PubKey           *types1.Any `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	VotingPower      int64       `protobuf:"varint,3,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	ProposerPriority int64       `protobuf:"varint,4,opt,name=proposer_priority,json=proposerPriority,proto3" json:"proposer_priority,omitempty"`
 }
 
 func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{4}
+	return fileDescriptor_40c93fb3ef485c5d, []int{9}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -305,7 +619,7 @@ func (m *Validator) GetAddress() string {
 	return ""
 }
 
-func (m *Validator) GetPubKey() *types.Any {
+func (m *Validator) GetPubKey() *types1.Any {
 	if m != nil {
 		return m.PubKey
 	}
@@ -335,7 +649,7 @@ func (m *GetBlockByHeightRequest) Reset()         { *m = GetBlockByHeightRequest
 func (m *GetBlockByHeightRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHeightRequest) ProtoMessage()    {}
 func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{5}
+	return fileDescriptor_40c93fb3ef485c5d, []int{10}
 }
 func (m *GetBlockByHeightRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -373,15 +687,15 @@ func (m *GetBlockByHeightRequest) GetHeight() int64 {
 
 // GetBlockByHeightResponse is the response type for the Query/GetBlockByHeight RPC method.
 type GetBlockByHeightResponse struct {
-	BlockId *types1.BlockID `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
-	Block   *types1.Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	BlockId *types2.BlockID `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Block   *types2.Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
 }
 
 func (m *GetBlockByHeightResponse) Reset()         { *m = GetBlockByHeightResponse{} }
 func (m *GetBlockByHeightResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHeightResponse) ProtoMessage()    {}
 func (*GetBlockByHeightResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{6}
+	return fileDescriptor_40c93fb3ef485c5d, []int{11}
 }
 func (m *GetBlockByHeightResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -410,14 +724,14 @@ func (m *GetBlockByHeightResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetBlockByHeightResponse proto.InternalMessageInfo
 
-func (m *GetBlockByHeightResponse) GetBlockId() *types1.BlockID {
+func (m *GetBlockByHeightResponse) GetBlockId() *types2.BlockID {
 	if m != nil {
 		return m.BlockId
 	}
 	return nil
 }
 
-func (m *GetBlockByHeightResponse) GetBlock() *types1.Block {
+func (m *GetBlockByHeightResponse) GetBlock() *types2.Block {
 	if m != nil {
 		return m.Block
 	}
@@ -432,7 +746,7 @@ func (m *GetLatestBlockRequest) Reset()         { *m = GetLatestBlockRequest{} }
 func (m *GetLatestBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*GetLatestBlockRequest) ProtoMessage()    {}
 func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{7}
+	return fileDescriptor_40c93fb3ef485c5d, []int{12}
 }
 func (m *GetLatestBlockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -463,15 +777,15 @@ var xxx_messageInfo_GetLatestBlockRequest proto.InternalMessageInfo
 
 // GetLatestBlockResponse is the response type for the Query/GetLatestBlock RPC method.
 type GetLatestBlockResponse struct {
-	BlockId *types1.BlockID `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
-	Block   *types1.Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	BlockId *types2.BlockID `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Block   *types2.Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
 }
 
 func (m *GetLatestBlockResponse) Reset()         { *m = GetLatestBlockResponse{} }
 func (m *GetLatestBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*GetLatestBlockResponse) ProtoMessage()    {}
 func (*GetLatestBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{8}
+	return fileDescriptor_40c93fb3ef485c5d, []int{13}
 }
 func (m *GetLatestBlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,14 +814,14 @@ func (m *GetLatestBlockResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLatestBlockResponse proto.InternalMessageInfo
 
-func (m *GetLatestBlockResponse) GetBlockId() *types1.BlockID {
+func (m *GetLatestBlockResponse) GetBlockId() *types2.BlockID {
 	if m != nil {
 		return m.BlockId
 	}
 	return nil
 }
 
-func (m *GetLatestBlockResponse) GetBlock() *types1.Block {
+func (m *GetLatestBlockResponse) GetBlock() *types2.Block {
 	if m != nil {
 		return m.Block
 	}
@@ -522,7 +836,7 @@ func (m *GetSyncingRequest) Reset()         { *m = GetSyncingRequest{} }
 func (m *GetSyncingRequest) String() string { return proto.CompactTextString(m) }
 func (*GetSyncingRequest) ProtoMessage()    {}
 func (*GetSyncingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{9}
+	return fileDescriptor_40c93fb3ef485c5d, []int{14}
 }
 func (m *GetSyncingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -560,7 +874,7 @@ func (m *GetSyncingResponse) Reset()         { *m = GetSyncingResponse{} }
 func (m *GetSyncingResponse) String() string { return proto.CompactTextString(m) }
 func (*GetSyncingResponse) ProtoMessage()    {}
 func (*GetSyncingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{10}
+	return fileDescriptor_40c93fb3ef485c5d, []int{15}
 }
 func (m *GetSyncingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -604,7 +918,7 @@ func (m *GetNodeInfoRequest) Reset()         { *m = GetNodeInfoRequest{} }
 func (m *GetNodeInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeInfoRequest) ProtoMessage()    {}
 func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{11}
+	return fileDescriptor_40c93fb3ef485c5d, []int{16}
 }
 func (m *GetNodeInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -643,7 +957,7 @@ func (m *GetNodeInfoResponse) Reset()         { *m = GetNodeInfoResponse{} }
 func (m *GetNodeInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNodeInfoResponse) ProtoMessage()    {}
 func (*GetNodeInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{12}
+	return fileDescriptor_40c93fb3ef485c5d, []int{17}
 }
 func (m *GetNodeInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -703,7 +1017,7 @@ func (m *VersionInfo) Reset()         { *m = VersionInfo{} }
 func (m *VersionInfo) String() string { return proto.CompactTextString(m) }
 func (*VersionInfo) ProtoMessage()    {}
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{13}
+	return fileDescriptor_40c93fb3ef485c5d, []int{18}
 }
 func (m *VersionInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -802,7 +1116,7 @@ func (m *Module) Reset()         { *m = Module{} }
 func (m *Module) String() string { return proto.CompactTextString(m) }
 func (*Module) ProtoMessage()    {}
 func (*Module) Descriptor() ([]byte, []int) {
-	return fileDescriptor_40c93fb3ef485c5d, []int{14}
+	return fileDescriptor_40c93fb3ef485c5d, []int{19}
 }
 func (m *Module) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -853,6 +1167,11 @@ func (m *Module) GetSum() string {
 }
 
 func init() {
+	proto.RegisterType((*BatchQueryRequest)(nil), "cosmos.base.tendermint.v1beta1.BatchQueryRequest")
+	proto.RegisterType((*GetProofRequest)(nil), "cosmos.base.tendermint.v1beta1.GetProofRequest")
+	proto.RegisterType((*GetProofResponse)(nil), "cosmos.base.tendermint.v1beta1.GetProofResponse")
+	proto.RegisterType((*ABCIQuery)(nil), "cosmos.base.tendermint.v1beta1.ABCIQuery")
+	proto.RegisterType((*BatchQueryResponse)(nil), "cosmos.base.tendermint.v1beta1.BatchQueryResponse")
 	proto.RegisterType((*GetValidatorSetByHeightRequest)(nil), "cosmos.base.tendermint.v1beta1.GetValidatorSetByHeightRequest")
 	proto.RegisterType((*GetValidatorSetByHeightResponse)(nil), "cosmos.base.tendermint.v1beta1.GetValidatorSetByHeightResponse")
 	proto.RegisterType((*GetLatestValidatorSetRequest)(nil), "cosmos.base.tendermint.v1beta1.GetLatestValidatorSetRequest")
@@ -875,75 +1194,92 @@ func init() {
 }
 
 var fileDescriptor_40c93fb3ef485c5d = []byte{
-	// 1081 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x41, 0x6f, 0x1b, 0xc5,
-	0x17, 0xcf, 0xda, 0x6d, 0x9c, 0x3c, 0xff, 0xf5, 0x27, 0x99, 0x84, 0x66, 0xb3, 0x4a, 0x4d, 0xf0,
-	0xa1, 0x4d, 0x08, 0xd9, 0x95, 0xdd, 0x36, 0xed, 0xa1, 0x14, 0x11, 0x02, 0x69, 0x44, 0xa9, 0xa2,
-	0x0d, 0xe2, 0x80, 0x90, 0x56, 0x6b, 0xef, 0x64, 0x33, 0x8a, 0xbd, 0x33, 0xdd, 0x99, 0x35, 0xb2,
-	0x50, 0x05, 0xe2, 0xc4, 0x11, 0x89, 0xaf, 0xd0, 0x03, 0x7c, 0x01, 0x24, 0x2e, 0x9c, 0x39, 0x56,
-	0x20, 0xa1, 0x8a, 0x13, 0x4a, 0xf8, 0x20, 0x68, 0x67, 0x66, 0xed, 0xdd, 0x26, 0xa9, 0xed, 0x1c,
-	0x90, 0x38, 0x79, 0xe7, 0xbd, 0xf7, 0x7b, 0xf3, 0x7b, 0xbf, 0x79, 0x6f, 0x3c, 0xf0, 0x56, 0x9b,
-	0xf2, 0x2e, 0xe5, 0x4e, 0xcb, 0xe7, 0xd8, 0x11, 0x38, 0x0a, 0x70, 0xdc, 0x25, 0x91, 0x70, 0x7a,
-	0x8d, 0x16, 0x16, 0x7e, 0xc3, 0x79, 0x92, 0xe0, 0xb8, 0x6f, 0xb3, 0x98, 0x0a, 0x8a, 0x6a, 0x2a,
-	0xd6, 0x4e, 0x63, 0xed, 0x61, 0xac, 0xad, 0x63, 0xad, 0xc5, 0x90, 0x86, 0x54, 0x86, 0x3a, 0xe9,
-	0x97, 0x42, 0x59, 0xcb, 0x21, 0xa5, 0x61, 0x07, 0x3b, 0x72, 0xd5, 0x4a, 0x0e, 0x1d, 0x3f, 0xd2,
-	0x09, 0xad, 0x15, 0xed, 0xf2, 0x19, 0x71, 0xfc, 0x28, 0xa2, 0xc2, 0x17, 0x84, 0x46, 0x5c, 0x7b,
-	0xad, 0x1c, 0x1d, 0xd6, 0x64, 0x8e, 0xe8, 0x33, 0x9c, 0xf9, 0x56, 0x72, 0x3e, 0x69, 0x77, 0x5a,
-	0x1d, 0xda, 0x3e, 0xbe, 0xd0, 0x9b, 0xc7, 0x16, 0x4a, 0x96, 0xf5, 0x0d, 0xaa, 0x65, 0x7e, 0x48,
-	0x22, 0x49, 0x42, 0xc5, 0xd6, 0xbf, 0x36, 0xa0, 0xb6, 0x8b, 0xc5, 0xa7, 0x7e, 0x87, 0x04, 0xbe,
-	0xa0, 0xf1, 0x01, 0x16, 0xdb, 0xfd, 0x87, 0x98, 0x84, 0x47, 0xc2, 0xc5, 0x4f, 0x12, 0xcc, 0x05,
-	0xba, 0x06, 0xd3, 0x47, 0xd2, 0x60, 0x1a, 0xab, 0xc6, 0x5a, 0xd9, 0xd5, 0x2b, 0xf4, 0x21, 0xc0,
-	0x30, 0x9d, 0x59, 0x5a, 0x35, 0xd6, 0xaa, 0xcd, 0x1b, 0x76, 0x5e, 0x42, 0xa5, 0xad, 0xde, 0xdb,
-	0xde, 0xf7, 0x43, 0xac, 0x73, 0xba, 0x39, 0x64, 0xfd, 0x85, 0x01, 0x6f, 0x5c, 0x48, 0x81, 0x33,
-	0x1a, 0x71, 0x8c, 0xde, 0x84, 0xff, 0xc9, 0xfa, 0xbd, 0x02, 0x93, 0xaa, 0xb4, 0xa9, 0x50, 0xb4,
-	0x07, 0xd0, 0xcb, 0x52, 0x70, 0xb3, 0xb4, 0x5a, 0x5e, 0xab, 0x36, 0xd7, 0xed, 0x57, 0x9f, 0xa8,
-	0x3d, 0xd8, 0xd4, 0xcd, 0x81, 0xd1, 0x6e, 0xa1, 0xb2, 0xb2, 0xac, 0xec, 0xe6, 0xc8, 0xca, 0x14,
-	0xd5, 0x42, 0x69, 0x87, 0xb0, 0xb2, 0x8b, 0xc5, 0x23, 0x5f, 0x60, 0x5e, 0xa8, 0x2f, 0x93, 0xb6,
-	0x28, 0xa1, 0x71, 0x69, 0x09, 0xff, 0x30, 0xe0, 0xfa, 0x05, 0x1b, 0xfd, 0xb7, 0x05, 0x7c, 0x66,
-	0xc0, 0xec, 0x60, 0x0b, 0x64, 0x42, 0xc5, 0x0f, 0x82, 0x18, 0x73, 0x2e, 0xf9, 0xcf, 0xba, 0xd9,
-	0x12, 0x6d, 0x42, 0x85, 0x25, 0x2d, 0xef, 0x18, 0xf7, 0x75, 0x23, 0x2e, 0xda, 0x6a, 0xf4, 0xec,
-	0x6c, 0x2a, 0xed, 0xf7, 0xa2, 0xbe, 0x3b, 0xcd, 0x92, 0xd6, 0x47, 0xb8, 0x9f, 0xaa, 0xd1, 0xa3,
-	0x82, 0x44, 0xa1, 0xc7, 0xe8, 0x17, 0x38, 0x96, 0x0c, 0xcb, 0x6e, 0x55, 0xd9, 0xf6, 0x53, 0x13,
-	0xda, 0x80, 0x79, 0x16, 0x53, 0x46, 0x39, 0x8e, 0x3d, 0x16, 0x13, 0x1a, 0x13, 0xd1, 0x37, 0xaf,
-	0xc8, 0xb8, 0xb9, 0xcc, 0xb1, 0xaf, 0xed, 0xf5, 0x06, 0x2c, 0xed, 0x62, 0xb1, 0x9d, 0x8a, 0x39,
-	0xe6, 0xf4, 0xd4, 0xbf, 0x02, 0xf3, 0x2c, 0x44, 0x1f, 0xd6, 0x6d, 0x98, 0x51, 0x87, 0x45, 0x02,
-	0xdd, 0x14, 0xcb, 0x79, 0xed, 0xd5, 0xac, 0x4b, 0xe8, 0xde, 0x8e, 0x5b, 0x91, 0xa1, 0x7b, 0x01,
-	0xda, 0x84, 0xab, 0xf2, 0x53, 0x2b, 0xb0, 0x74, 0x01, 0xc4, 0x55, 0x51, 0xf5, 0x25, 0x78, 0x7d,
-	0xd0, 0x32, 0xca, 0xa1, 0x18, 0xd7, 0x9f, 0xc2, 0xb5, 0x97, 0x1d, 0xff, 0x26, 0xaf, 0x05, 0x98,
-	0xdf, 0xc5, 0xe2, 0xa0, 0x1f, 0xb5, 0x49, 0x14, 0x66, 0x9c, 0x6c, 0x40, 0x79, 0xa3, 0xe6, 0x63,
-	0x42, 0x85, 0x2b, 0x93, 0xa4, 0x33, 0xe3, 0x66, 0xcb, 0xfa, 0xa2, 0x8c, 0x7f, 0x4c, 0x03, 0xbc,
-	0x17, 0x1d, 0xd2, 0x2c, 0xcb, 0xcf, 0x06, 0x2c, 0x14, 0xcc, 0x3a, 0xcf, 0x0e, 0xcc, 0x07, 0xf8,
-	0xd0, 0x4f, 0x3a, 0xc2, 0x8b, 0x68, 0x80, 0x3d, 0x12, 0x1d, 0x52, 0x5d, 0xa0, 0x99, 0x67, 0xcb,
-	0x9a, 0xcc, 0x1e, 0x80, 0x5f, 0xd3, 0x90, 0xcc, 0x80, 0x3e, 0x87, 0x05, 0x9f, 0xb1, 0x0e, 0x69,
-	0xcb, 0xd6, 0xf5, 0x7a, 0x38, 0xe6, 0xc3, 0x8b, 0x71, 0x63, 0xe4, 0x20, 0xa9, 0x70, 0x99, 0x1a,
-	0xe5, 0xf2, 0x68, 0x7b, 0xfd, 0x87, 0x12, 0x54, 0x73, 0x31, 0x08, 0xc1, 0x95, 0xc8, 0xef, 0x62,
-	0x3d, 0x08, 0xf2, 0x1b, 0x2d, 0xc3, 0x8c, 0xcf, 0x98, 0x27, 0xed, 0x25, 0x3d, 0x20, 0x8c, 0x3d,
-	0x4e, 0x5d, 0x26, 0x54, 0x32, 0x42, 0x65, 0xe5, 0xd1, 0x4b, 0x74, 0x1d, 0x20, 0x24, 0xc2, 0x6b,
-	0xd3, 0x6e, 0x97, 0x08, 0xd9, 0xe1, 0xb3, 0xee, 0x6c, 0x48, 0xc4, 0xfb, 0xd2, 0x90, 0xba, 0x5b,
-	0x09, 0xe9, 0x04, 0x9e, 0xf0, 0x43, 0x6e, 0x5e, 0x55, 0x6e, 0x69, 0xf9, 0xc4, 0x0f, 0xb9, 0x44,
-	0xd3, 0x41, 0xad, 0xd3, 0x1a, 0x4d, 0x35, 0x53, 0xf4, 0x41, 0x86, 0x0e, 0x30, 0xe3, 0x66, 0x45,
-	0xde, 0x29, 0x37, 0x46, 0x49, 0xf1, 0x31, 0x0d, 0x92, 0x0e, 0xd6, 0xbb, 0xec, 0x60, 0xc6, 0xd1,
-	0xdb, 0x80, 0x14, 0xc6, 0xe3, 0xc1, 0xf1, 0x60, 0xb7, 0x19, 0xb9, 0xdb, 0x9c, 0xf2, 0x1c, 0x04,
-	0xc7, 0x99, 0x54, 0x0f, 0x61, 0x5a, 0xa5, 0x48, 0x45, 0x62, 0xbe, 0x38, 0xca, 0x44, 0x4a, 0xbf,
-	0xf3, 0x4a, 0x94, 0x8a, 0x4a, 0xcc, 0x41, 0x99, 0x27, 0x5d, 0xad, 0x4f, 0xfa, 0xd9, 0xfc, 0x76,
-	0x16, 0x2a, 0x07, 0x38, 0xee, 0x91, 0x36, 0x46, 0x3f, 0x1a, 0x50, 0xcd, 0x35, 0x0f, 0x6a, 0x8e,
-	0x2a, 0xe3, 0x6c, 0x03, 0x5a, 0xb7, 0x26, 0xc2, 0xa8, 0xee, 0xac, 0x37, 0xbe, 0xf9, 0xfd, 0xef,
-	0xef, 0x4b, 0x1b, 0x68, 0xdd, 0x19, 0xf1, 0x94, 0x19, 0xf4, 0x2e, 0x7a, 0x66, 0x00, 0x0c, 0xe7,
-	0x05, 0x35, 0xc6, 0xd8, 0xb6, 0x38, 0x70, 0x56, 0x73, 0x12, 0x88, 0x26, 0xea, 0x48, 0xa2, 0xeb,
-	0xe8, 0xe6, 0x28, 0xa2, 0x7a, 0x4a, 0xd1, 0x4f, 0x06, 0xfc, 0xbf, 0x78, 0xd5, 0xa0, 0x3b, 0x63,
-	0xec, 0x7b, 0xf6, 0xce, 0xb2, 0xb6, 0x26, 0x85, 0x69, 0xca, 0x77, 0x24, 0x65, 0x07, 0x6d, 0x8e,
-	0xa2, 0x2c, 0xef, 0x26, 0xee, 0x74, 0x64, 0x0e, 0xf4, 0x8b, 0x01, 0x73, 0x2f, 0xdf, 0xde, 0xe8,
-	0xee, 0x18, 0x1c, 0xce, 0xfb, 0x8b, 0xb0, 0xee, 0x4d, 0x0e, 0xd4, 0xf4, 0xef, 0x4a, 0xfa, 0x0d,
-	0xe4, 0x8c, 0x49, 0xff, 0x4b, 0xf5, 0xe7, 0xf3, 0x14, 0xfd, 0x66, 0xe4, 0x6e, 0xff, 0xfc, 0x83,
-	0x01, 0xdd, 0x1f, 0x5b, 0xc9, 0x73, 0x1e, 0x34, 0xd6, 0x3b, 0x97, 0x44, 0xeb, 0x7a, 0xee, 0xcb,
-	0x7a, 0xb6, 0xd0, 0xed, 0x51, 0xf5, 0x0c, 0xdf, 0x1a, 0x58, 0x0c, 0x4e, 0xe5, 0x4f, 0x43, 0xfe,
-	0x0d, 0x9f, 0xf7, 0x90, 0x44, 0x0f, 0xc6, 0x20, 0xf6, 0x8a, 0x47, 0xb0, 0xf5, 0xee, 0xa5, 0xf1,
-	0xba, 0xb4, 0x07, 0xb2, 0xb4, 0x7b, 0x68, 0x6b, 0xb2, 0xd2, 0xb2, 0x13, 0xdb, 0x7e, 0xf4, 0xeb,
-	0x49, 0xcd, 0x78, 0x7e, 0x52, 0x33, 0xfe, 0x3a, 0xa9, 0x19, 0xdf, 0x9d, 0xd6, 0xa6, 0x9e, 0x9f,
-	0xd6, 0xa6, 0x5e, 0x9c, 0xd6, 0xa6, 0x3e, 0x6b, 0x86, 0x44, 0x1c, 0x25, 0x2d, 0xbb, 0x4d, 0xbb,
-	0x59, 0x6e, 0xf5, 0xb3, 0xc9, 0x83, 0x63, 0xa7, 0xdd, 0x21, 0x38, 0x12, 0x4e, 0x18, 0xb3, 0xb6,
-	0x23, 0xba, 0x5c, 0x5d, 0x66, 0xad, 0x69, 0xf9, 0x2c, 0xba, 0xf5, 0x4f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xd0, 0xfa, 0xea, 0x5b, 0x1e, 0x0d, 0x00, 0x00,
+	// 1360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xc6, 0x6d, 0x1c, 0x3f, 0x57, 0x34, 0x99, 0x84, 0xd6, 0x75, 0x5b, 0x37, 0xdd, 0x03,
+	0xfd, 0x13, 0xb2, 0xdb, 0xb8, 0x6d, 0x5a, 0x55, 0xa5, 0xa8, 0x6e, 0x21, 0x89, 0x28, 0x55, 0xd8,
+	0x20, 0x84, 0x10, 0xd2, 0x6a, 0xbc, 0x3b, 0x5e, 0xaf, 0x62, 0xef, 0x4c, 0x77, 0xc6, 0x46, 0x56,
+	0x55, 0x81, 0xb8, 0x72, 0x41, 0xe2, 0x2b, 0xf4, 0x00, 0xe2, 0x8e, 0xc4, 0x85, 0x73, 0x8f, 0x15,
+	0x48, 0xa8, 0xe2, 0x80, 0x50, 0xca, 0x07, 0x41, 0x3b, 0x33, 0x6b, 0xef, 0x92, 0xa4, 0x76, 0x7a,
+	0x40, 0xe2, 0xb4, 0x33, 0xef, 0xbd, 0xdf, 0x9b, 0xdf, 0x7b, 0xf3, 0xe6, 0xcd, 0x2c, 0x5c, 0xf6,
+	0x28, 0xef, 0x52, 0x6e, 0x37, 0x31, 0x27, 0xb6, 0x20, 0x91, 0x4f, 0xe2, 0x6e, 0x18, 0x09, 0xbb,
+	0xbf, 0xda, 0x24, 0x02, 0xaf, 0xda, 0x8f, 0x7a, 0x24, 0x1e, 0x58, 0x2c, 0xa6, 0x82, 0xa2, 0x9a,
+	0xb2, 0xb5, 0x12, 0x5b, 0x6b, 0x64, 0x6b, 0x69, 0xdb, 0xea, 0x82, 0x47, 0xa3, 0x56, 0x48, 0x6d,
+	0x16, 0x53, 0xda, 0xe2, 0x0a, 0x54, 0x5d, 0x0c, 0x68, 0x40, 0xe5, 0xd0, 0x4e, 0x46, 0x5a, 0x7a,
+	0x2a, 0xa0, 0x34, 0xe8, 0x10, 0x5b, 0xce, 0x9a, 0xbd, 0x96, 0x8d, 0x23, 0xbd, 0x4a, 0xf5, 0x8c,
+	0x56, 0x61, 0x16, 0xda, 0x38, 0x8a, 0xa8, 0xc0, 0x22, 0xa4, 0x51, 0xea, 0xee, 0x74, 0x86, 0x23,
+	0x6e, 0x7a, 0xa1, 0x2d, 0x06, 0x8c, 0xa4, 0xca, 0x6a, 0x46, 0xc9, 0xea, 0x2c, 0xa7, 0x3b, 0x93,
+	0xd1, 0x49, 0xb9, 0xdd, 0xec, 0x50, 0x6f, 0xe7, 0x40, 0x6d, 0x16, 0x9b, 0x4b, 0x92, 0xcc, 0xc8,
+	0x30, 0x3f, 0x0c, 0x07, 0x61, 0x24, 0x19, 0x2a, 0x5b, 0xb3, 0x0f, 0xf3, 0x0d, 0x2c, 0xbc, 0xf6,
+	0x47, 0x89, 0x99, 0x43, 0x1e, 0xf5, 0x08, 0x17, 0xe8, 0x04, 0xcc, 0xb4, 0x49, 0x18, 0xb4, 0x45,
+	0xc5, 0x58, 0x32, 0x2e, 0x16, 0x1c, 0x3d, 0x43, 0x9b, 0x50, 0x4c, 0xdc, 0x85, 0x84, 0x57, 0xa6,
+	0x97, 0x0a, 0x17, 0xcb, 0xf5, 0x4b, 0xd6, 0xab, 0x73, 0x6c, 0xdd, 0x6d, 0xdc, 0xdb, 0x94, 0xae,
+	0x1b, 0x47, 0x9e, 0xfd, 0x79, 0x6e, 0xca, 0x49, 0xf1, 0xe6, 0xa7, 0x70, 0x7c, 0x9d, 0x88, 0xad,
+	0x24, 0xf5, 0xe9, 0xaa, 0xa7, 0xa1, 0xc4, 0x05, 0x8d, 0x89, 0xbb, 0x43, 0x06, 0x72, 0xe1, 0x92,
+	0x33, 0x2b, 0x05, 0x1f, 0x90, 0x01, 0x9a, 0x83, 0x42, 0x22, 0x9e, 0x5e, 0x32, 0x2e, 0x1e, 0x73,
+	0x92, 0x61, 0x86, 0x64, 0x21, 0x4b, 0xd2, 0xfc, 0xc6, 0x80, 0xb9, 0x91, 0x6b, 0xce, 0x68, 0xc4,
+	0xc9, 0x81, 0x11, 0x2d, 0xc2, 0xd1, 0x3e, 0xee, 0xf4, 0x88, 0x76, 0xac, 0x26, 0xc8, 0x82, 0x19,
+	0x55, 0x14, 0x95, 0x82, 0x0c, 0xf3, 0x84, 0x15, 0x7a, 0xbc, 0x7e, 0xd5, 0xba, 0x47, 0xbb, 0xdd,
+	0x50, 0x74, 0x49, 0xa4, 0xbd, 0x6b, 0x2b, 0x74, 0x0a, 0x66, 0x31, 0x63, 0x6e, 0x1b, 0xf3, 0x76,
+	0xe5, 0x88, 0x74, 0x54, 0xc4, 0x8c, 0x6d, 0x60, 0xde, 0x36, 0x37, 0xa1, 0x34, 0xcc, 0x01, 0x42,
+	0x70, 0x84, 0x61, 0xd1, 0xd6, 0xc1, 0xc9, 0x71, 0x22, 0xf3, 0xb1, 0xc0, 0x9a, 0x80, 0x1c, 0x27,
+	0xac, 0x58, 0x4c, 0xfb, 0x44, 0x46, 0x36, 0xeb, 0xa8, 0x89, 0xc9, 0x00, 0x65, 0xb7, 0x6a, 0x4c,
+	0x64, 0x0d, 0x28, 0xc5, 0xda, 0x26, 0xdd, 0xad, 0x5a, 0x76, 0x87, 0x92, 0x6a, 0xb4, 0x52, 0x2f,
+	0xd9, 0x2d, 0x1a, 0xc1, 0xcc, 0xaf, 0x0c, 0xa8, 0xad, 0x13, 0xf1, 0x09, 0xee, 0x84, 0x3e, 0x16,
+	0x34, 0xde, 0x26, 0xa2, 0x31, 0xd8, 0x90, 0xfe, 0xc7, 0x95, 0xca, 0xfb, 0x00, 0xa3, 0x5a, 0x93,
+	0xc1, 0x95, 0xeb, 0x6f, 0xe5, 0xaa, 0x45, 0x1d, 0xd5, 0xb4, 0x50, 0xb6, 0x70, 0x40, 0xb4, 0x4f,
+	0x27, 0x83, 0x34, 0x5f, 0x18, 0x70, 0xee, 0x40, 0x0a, 0x3a, 0x05, 0xe7, 0xe1, 0x98, 0x3c, 0x1c,
+	0x6e, 0x8e, 0x49, 0x59, 0xca, 0x36, 0xd2, 0xca, 0x85, 0x7e, 0xea, 0x62, 0xe2, 0xe2, 0x1d, 0x2e,
+	0xea, 0x64, 0xc0, 0x68, 0x3d, 0x17, 0x59, 0x41, 0x46, 0x76, 0x61, 0x6c, 0x64, 0x8a, 0x6a, 0x2e,
+	0xb4, 0x16, 0x9c, 0x59, 0x27, 0xe2, 0x01, 0x16, 0x84, 0xe7, 0xe2, 0x4b, 0x53, 0x9b, 0x4f, 0xa1,
+	0xf1, 0xda, 0x29, 0xfc, 0xdd, 0x80, 0xb3, 0x07, 0x2c, 0xf4, 0xff, 0x4e, 0xe0, 0x53, 0x03, 0x4a,
+	0xc3, 0x25, 0x50, 0x05, 0x8a, 0xd8, 0xf7, 0x63, 0xc2, 0xb9, 0x3e, 0x5f, 0xe9, 0x14, 0xad, 0x40,
+	0x91, 0xf5, 0x9a, 0x6e, 0xda, 0x3f, 0xca, 0xf5, 0x45, 0x4b, 0x35, 0x6d, 0x2b, 0xed, 0xe7, 0xd6,
+	0xdd, 0x68, 0xe0, 0xcc, 0xb0, 0x5e, 0x33, 0x69, 0x35, 0xe7, 0xe1, 0x58, 0x9f, 0x8a, 0x30, 0x0a,
+	0x5c, 0x46, 0xbf, 0x20, 0xb1, 0x6e, 0x2f, 0x65, 0x25, 0xdb, 0x4a, 0x44, 0x68, 0x19, 0xe6, 0x59,
+	0x4c, 0x19, 0xe5, 0x24, 0x76, 0x59, 0x1c, 0xd2, 0x38, 0x14, 0x03, 0x79, 0xf2, 0x0b, 0xce, 0x5c,
+	0xaa, 0xd8, 0xd2, 0x72, 0x73, 0x15, 0x4e, 0xae, 0x13, 0xd1, 0x48, 0x92, 0x39, 0xe1, 0xe9, 0x31,
+	0xbf, 0x84, 0xca, 0x5e, 0x88, 0xde, 0xac, 0x6b, 0x30, 0xab, 0x36, 0x2b, 0xf4, 0x75, 0x51, 0x9c,
+	0xca, 0xe6, 0x5e, 0x5d, 0x04, 0x12, 0xba, 0x79, 0xdf, 0x29, 0x4a, 0xd3, 0x4d, 0x1f, 0xad, 0xc0,
+	0x51, 0x39, 0xd4, 0x19, 0x38, 0x79, 0x00, 0xc4, 0x51, 0x56, 0xe6, 0x49, 0x78, 0x73, 0x58, 0x32,
+	0x4a, 0xa1, 0x18, 0x9b, 0x4f, 0xe0, 0xc4, 0xbf, 0x15, 0xff, 0x25, 0xaf, 0x05, 0x98, 0x5f, 0x27,
+	0x62, 0x7b, 0x10, 0x79, 0x61, 0x14, 0xa4, 0x9c, 0x2c, 0x40, 0x59, 0xa1, 0xe6, 0x53, 0x81, 0x22,
+	0x57, 0x22, 0x49, 0x67, 0xd6, 0x49, 0xa7, 0xe6, 0xa2, 0xb4, 0x7f, 0x48, 0x7d, 0xb2, 0x19, 0xb5,
+	0x68, 0xea, 0xe5, 0x67, 0x03, 0x16, 0x72, 0x62, 0xed, 0xe7, 0x3e, 0xcc, 0xfb, 0xa4, 0x85, 0x7b,
+	0x1d, 0xe1, 0x46, 0xd4, 0x27, 0x6e, 0x18, 0xb5, 0xa8, 0x0e, 0xb0, 0x92, 0x65, 0xcb, 0xea, 0xcc,
+	0x1a, 0x82, 0x8f, 0x6b, 0x48, 0x2a, 0x40, 0x9f, 0xc3, 0x02, 0x66, 0xac, 0x13, 0x7a, 0xb2, 0x74,
+	0xdd, 0x3e, 0x89, 0xf9, 0xa8, 0x31, 0x2e, 0x8f, 0x3d, 0x48, 0xca, 0x5c, 0xba, 0x46, 0x19, 0x3f,
+	0x5a, 0x6e, 0x7e, 0x3f, 0x0d, 0xe5, 0x8c, 0x4d, 0x72, 0xa9, 0x44, 0xb8, 0x4b, 0xd2, 0x8b, 0x26,
+	0x19, 0xa7, 0x97, 0x94, 0x94, 0x4f, 0xeb, 0x03, 0xc2, 0xd8, 0xc3, 0x44, 0x55, 0x81, 0x62, 0x4a,
+	0xa8, 0xa0, 0x34, 0x7a, 0x8a, 0xce, 0x02, 0x04, 0xa1, 0x70, 0x3d, 0x79, 0xf1, 0xc9, 0x0a, 0x2f,
+	0x39, 0xa5, 0x20, 0x14, 0xea, 0x26, 0x4c, 0xd4, 0xcd, 0x5e, 0xd8, 0xf1, 0x5d, 0x81, 0x03, 0x5e,
+	0x39, 0xaa, 0xd4, 0x52, 0xf2, 0x31, 0x0e, 0xb8, 0x44, 0xd3, 0x61, 0xac, 0x33, 0x1a, 0x4d, 0x35,
+	0x53, 0xf4, 0x5e, 0x8a, 0xf6, 0x09, 0xe3, 0x95, 0xe2, 0x52, 0x61, 0x4f, 0x83, 0xdb, 0x27, 0x15,
+	0x1f, 0x52, 0xbf, 0xd7, 0x21, 0x7a, 0x95, 0xfb, 0x84, 0x71, 0xf4, 0x36, 0x20, 0x85, 0x71, 0xb9,
+	0xbf, 0x33, 0x5c, 0x6d, 0x56, 0xae, 0x36, 0xa7, 0x34, 0xdb, 0xfe, 0x4e, 0x9a, 0xaa, 0x0d, 0x98,
+	0x51, 0x2e, 0xf6, 0xbd, 0x8d, 0x33, 0x99, 0x98, 0xce, 0x67, 0x62, 0x0e, 0x0a, 0xbc, 0xd7, 0xd5,
+	0xf9, 0x49, 0x86, 0xf5, 0xdd, 0x32, 0x14, 0xb7, 0x49, 0xdc, 0x0f, 0x3d, 0x82, 0x7e, 0x30, 0xa0,
+	0x9c, 0x29, 0x1e, 0x54, 0x1f, 0x17, 0xc6, 0xde, 0x02, 0xac, 0x5e, 0x3d, 0x14, 0x46, 0x55, 0xa7,
+	0xb9, 0xfa, 0xf5, 0x6f, 0x7f, 0x7f, 0x37, 0xbd, 0x8c, 0x2e, 0xd9, 0x63, 0x5e, 0xc6, 0xc3, 0xda,
+	0x45, 0x4f, 0x0d, 0x80, 0xd1, 0x79, 0x41, 0xab, 0x13, 0x2c, 0x9b, 0x3f, 0x70, 0xd5, 0xfa, 0x61,
+	0x20, 0x9a, 0xa8, 0x2d, 0x89, 0x5e, 0x42, 0x17, 0xc6, 0x11, 0xd5, 0xa7, 0x14, 0xfd, 0x64, 0xc0,
+	0x1b, 0xf9, 0x56, 0x83, 0xae, 0x4f, 0xb0, 0xee, 0xde, 0x9e, 0x55, 0x5d, 0x3b, 0x2c, 0x4c, 0x53,
+	0xbe, 0x2e, 0x29, 0xdb, 0x68, 0x65, 0x1c, 0x65, 0xd9, 0x9b, 0xb8, 0xdd, 0x91, 0x3e, 0xd0, 0x2f,
+	0xea, 0x01, 0x9a, 0xeb, 0xde, 0xe8, 0xc6, 0x04, 0x1c, 0xf6, 0xbb, 0x22, 0xaa, 0x37, 0x0f, 0x0f,
+	0xd4, 0xf4, 0x6f, 0x48, 0xfa, 0xab, 0xc8, 0x9e, 0x90, 0xfe, 0x63, 0x75, 0xf9, 0x3c, 0x41, 0xbf,
+	0x1a, 0x99, 0xee, 0x9f, 0x7d, 0x30, 0xa0, 0xdb, 0x13, 0x67, 0x72, 0x9f, 0x07, 0x4d, 0xf5, 0x9d,
+	0xd7, 0x44, 0xeb, 0x78, 0x6e, 0xcb, 0x78, 0xd6, 0xd0, 0xb5, 0x71, 0xf1, 0x8c, 0xde, 0x1a, 0x44,
+	0x0c, 0x77, 0xe5, 0x0f, 0x43, 0x5e, 0xc3, 0xfb, 0x3d, 0x24, 0xd1, 0x9d, 0x09, 0x88, 0xbd, 0xe2,
+	0x11, 0x5c, 0x7d, 0xf7, 0xb5, 0xf1, 0x3a, 0xb4, 0x3b, 0x32, 0xb4, 0x9b, 0x68, 0xed, 0x70, 0xa1,
+	0x0d, 0x77, 0xec, 0x47, 0x03, 0x60, 0xf4, 0x6f, 0x30, 0xfe, 0x48, 0xef, 0xf9, 0xe5, 0xab, 0xd6,
+	0x0f, 0x03, 0xd1, 0xac, 0xd7, 0x24, 0xeb, 0x2b, 0xb7, 0x8c, 0xcb, 0xe6, 0xf2, 0xd8, 0x1a, 0x4b,
+	0xe0, 0xae, 0x7c, 0xd8, 0x25, 0xcd, 0x72, 0x36, 0xfd, 0x43, 0x43, 0xf6, 0x04, 0xb9, 0xcb, 0xfe,
+	0x26, 0x56, 0xaf, 0x4c, 0x0e, 0xd0, 0x3c, 0x6f, 0x49, 0x9e, 0xd7, 0x50, 0x7d, 0x1c, 0x49, 0xf5,
+	0x3b, 0x67, 0x3f, 0x1e, 0xfe, 0x86, 0x3e, 0x69, 0x3c, 0x78, 0xb6, 0x5b, 0x33, 0x9e, 0xef, 0xd6,
+	0x8c, 0xbf, 0x76, 0x6b, 0xc6, 0xb7, 0x2f, 0x6b, 0x53, 0xcf, 0x5f, 0xd6, 0xa6, 0x5e, 0xbc, 0xac,
+	0x4d, 0x7d, 0x56, 0x0f, 0x42, 0xd1, 0xee, 0x35, 0x2d, 0x8f, 0x76, 0x53, 0xbf, 0xea, 0xb3, 0xc2,
+	0xfd, 0x1d, 0xdb, 0xeb, 0x84, 0x24, 0x12, 0x76, 0x10, 0x33, 0xcf, 0x16, 0x5d, 0xae, 0xae, 0x89,
+	0xe6, 0x8c, 0x7c, 0x70, 0x5e, 0xfd, 0x67, 0x00, 0xe8, 0x71, 0xb6, 0x38, 0xc7, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -970,6 +1306,12 @@ type ServiceClient interface {
 	GetLatestValidatorSet(ctx context.Context, in *GetLatestValidatorSetRequest, opts ...grpc.CallOption) (*GetLatestValidatorSetResponse, error)
 	// GetValidatorSetByHeight queries validator-set at a given height.
 	GetValidatorSetByHeight(ctx context.Context, in *GetValidatorSetByHeightRequest, opts ...grpc.CallOption) (*GetValidatorSetByHeightResponse, error)
+	// BatchQuery executes a batch of ABCI queries against the same committed
+	// version of the state, returning a response per query.
+	BatchQuery(ctx context.Context, in *BatchQueryRequest, opts ...grpc.CallOption) (*BatchQueryResponse, error)
+	// GetProof returns the ICS-23 proofs of the value of a key of a store, or of
+	// its absence, at a committed height.
+	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error)
 }
 
 type serviceClient struct {
@@ -1034,6 +1376,24 @@ func (c *serviceClient) GetValidatorSetByHeight(ctx context.Context, in *GetVali
 	return out, nil
 }
 
+func (c *serviceClient) BatchQuery(ctx context.Context, in *BatchQueryRequest, opts ...grpc.CallOption) (*BatchQueryResponse, error) {
+	out := new(BatchQueryResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.tendermint.v1beta1.Service/BatchQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error) {
+	out := new(GetProofResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.tendermint.v1beta1.Service/GetProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
 	// GetNodeInfo queries the current node info.
@@ -1048,6 +1408,12 @@ type ServiceServer interface {
 	GetLatestValidatorSet(context.Context, *GetLatestValidatorSetRequest) (*GetLatestValidatorSetResponse, error)
 	// GetValidatorSetByHeight queries validator-set at a given height.
 	GetValidatorSetByHeight(context.Context, *GetValidatorSetByHeightRequest) (*GetValidatorSetByHeightResponse, error)
+	// BatchQuery executes a batch of ABCI queries against the same committed
+	// version of the state, returning a response per query.
+	BatchQuery(context.Context, *BatchQueryRequest) (*BatchQueryResponse, error)
+	// GetProof returns the ICS-23 proofs of the value of a key of a store, or of
+	// its absence, at a committed height.
+	GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error)
 }
 
 // UnimplementedServiceServer can be embedded to have forward compatible implementations.
@@ -1072,6 +1438,12 @@ func (*UnimplementedServiceServer) GetLatestValidatorSet(ctx context.Context, re
 func (*UnimplementedServiceServer) GetValidatorSetByHeight(ctx context.Context, req *GetValidatorSetByHeightRequest) (*GetValidatorSetByHeightResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorSetByHeight not implemented")
 }
+func (*UnimplementedServiceServer) BatchQuery(ctx context.Context, req *BatchQueryRequest) (*BatchQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchQuery not implemented")
+}
+func (*UnimplementedServiceServer) GetProof(ctx context.Context, req *GetProofRequest) (*GetProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
+}
 
 func RegisterServiceServer(s grpc1.Server, srv ServiceServer) {
 	s.RegisterService(&_Service_serviceDesc, srv)
@@ -1185,6 +1557,42 @@ func _Service_GetValidatorSetByHeight_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_BatchQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).BatchQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.tendermint.v1beta1.Service/BatchQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).BatchQuery(ctx, req.(*BatchQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.tendermint.v1beta1.Service/GetProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetProof(ctx, req.(*GetProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cosmos.base.tendermint.v1beta1.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1213,12 +1621,20 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetValidatorSetByHeight",
 			Handler:    _Service_GetValidatorSetByHeight_Handler,
 		},
+		{
+			MethodName: "BatchQuery",
+			Handler:    _Service_BatchQuery_Handler,
+		},
+		{
+			MethodName: "GetProof",
+			Handler:    _Service_GetProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cosmos/base/tendermint/v1beta1/query.proto",
 }
 
-func (m *GetValidatorSetByHeightRequest) Marshal() (dAtA []byte, err error) {
+func (m *BatchQueryRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1228,7 +1644,236 @@ func (m *GetValidatorSetByHeightRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetValidatorSetByHeightRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BatchQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Queries) > 0 {
+		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetProofRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetProofRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetProofRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.StoreKey) > 0 {
+		i -= len(m.StoreKey)
+		copy(dAtA[i:], m.StoreKey)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.StoreKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetProofResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetProofResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Proofs) > 0 {
+		for iNdEx := len(m.Proofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Proofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ABCIQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ABCIQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ABCIQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Prove {
+		i--
+		if m.Prove {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetValidatorSetByHeightRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetValidatorSetByHeightRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
@@ -1865,7 +2510,7 @@ func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
-func (m *GetValidatorSetByHeightRequest) Size() (n int) {
+func (m *BatchQueryRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1874,94 +2519,82 @@ func (m *GetValidatorSetByHeightRequest) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovQuery(uint64(m.Height))
 	}
-	if m.Pagination != nil {
-		l = m.Pagination.Size()
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
-}
-
-func (m *GetValidatorSetByHeightResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovQuery(uint64(m.BlockHeight))
-	}
-	if len(m.Validators) > 0 {
-		for _, e := range m.Validators {
+	if len(m.Queries) > 0 {
+		for _, e := range m.Queries {
 			l = e.Size()
 			n += 1 + l + sovQuery(uint64(l))
 		}
 	}
-	if m.Pagination != nil {
-		l = m.Pagination.Size()
-		n += 1 + l + sovQuery(uint64(l))
-	}
 	return n
 }
 
-func (m *GetLatestValidatorSetRequest) Size() (n int) {
+func (m *GetProofRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Pagination != nil {
-		l = m.Pagination.Size()
+	l = len(m.StoreKey)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
 		n += 1 + l + sovQuery(uint64(l))
 	}
+	if m.Height != 0 {
+		n += 1 + sovQuery(uint64(m.Height))
+	}
 	return n
 }
 
-func (m *GetLatestValidatorSetResponse) Size() (n int) {
+func (m *GetProofResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovQuery(uint64(m.BlockHeight))
+	if m.Height != 0 {
+		n += 1 + sovQuery(uint64(m.Height))
 	}
-	if len(m.Validators) > 0 {
-		for _, e := range m.Validators {
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.Proofs) > 0 {
+		for _, e := range m.Proofs {
 			l = e.Size()
 			n += 1 + l + sovQuery(uint64(l))
 		}
 	}
-	if m.Pagination != nil {
-		l = m.Pagination.Size()
+	l = len(m.AppHash)
+	if l > 0 {
 		n += 1 + l + sovQuery(uint64(l))
 	}
 	return n
 }
 
-func (m *Validator) Size() (n int) {
+func (m *ABCIQuery) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Address)
+	l = len(m.Path)
 	if l > 0 {
 		n += 1 + l + sovQuery(uint64(l))
 	}
-	if m.PubKey != nil {
-		l = m.PubKey.Size()
+	l = len(m.Data)
+	if l > 0 {
 		n += 1 + l + sovQuery(uint64(l))
 	}
-	if m.VotingPower != 0 {
-		n += 1 + sovQuery(uint64(m.VotingPower))
-	}
-	if m.ProposerPriority != 0 {
-		n += 1 + sovQuery(uint64(m.ProposerPriority))
+	if m.Prove {
+		n += 2
 	}
 	return n
 }
 
-func (m *GetBlockByHeightRequest) Size() (n int) {
+func (m *BatchQueryResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1970,17 +2603,131 @@ func (m *GetBlockByHeightRequest) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovQuery(uint64(m.Height))
 	}
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *GetBlockByHeightResponse) Size() (n int) {
+func (m *GetValidatorSetByHeightRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.BlockId != nil {
-		l = m.BlockId.Size()
+	if m.Height != 0 {
+		n += 1 + sovQuery(uint64(m.Height))
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *GetValidatorSetByHeightResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovQuery(uint64(m.BlockHeight))
+	}
+	if len(m.Validators) > 0 {
+		for _, e := range m.Validators {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLatestValidatorSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLatestValidatorSetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovQuery(uint64(m.BlockHeight))
+	}
+	if len(m.Validators) > 0 {
+		for _, e := range m.Validators {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *Validator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.VotingPower != 0 {
+		n += 1 + sovQuery(uint64(m.VotingPower))
+	}
+	if m.ProposerPriority != 0 {
+		n += 1 + sovQuery(uint64(m.ProposerPriority))
+	}
+	return n
+}
+
+func (m *GetBlockByHeightRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovQuery(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetBlockByHeightResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockId != nil {
+		l = m.BlockId.Size()
 		n += 1 + l + sovQuery(uint64(l))
 	}
 	if m.Block != nil {
@@ -2133,6 +2880,654 @@ func sovQuery(x uint64) (n int) {
 func sozQuery(x uint64) (n int) {
 	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
+func (m *BatchQueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchQueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queries = append(m.Queries, ABCIQuery{})
+			if err := m.Queries[len(m.Queries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetProofRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetProofRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetProofRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StoreKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetProofResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetProofResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proofs = append(m.Proofs, &_go.CommitmentProof{})
+			if err := m.Proofs[len(m.Proofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ABCIQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ABCIQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ABCIQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prove", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Prove = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchQueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchQueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, types.ResponseQuery{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *GetValidatorSetByHeightRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2693,7 +4088,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.PubKey == nil {
-				m.PubKey = &types.Any{}
+				m.PubKey = &types1.Any{}
 			}
 			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -2886,7 +4281,7 @@ func (m *GetBlockByHeightResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.BlockId == nil {
-				m.BlockId = &types1.BlockID{}
+				m.BlockId = &types2.BlockID{}
 			}
 			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -2922,7 +4317,7 @@ func (m *GetBlockByHeightResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Block == nil {
-				m.Block = &types1.Block{}
+				m.Block = &types2.Block{}
 			}
 			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3058,7 +4453,7 @@ func (m *GetLatestBlockResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.BlockId == nil {
-				m.BlockId = &types1.BlockID{}
+				m.BlockId = &types2.BlockID{}
 			}
 			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3094,7 +4489,7 @@ func (m *GetLatestBlockResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Block == nil {
-				m.Block = &types1.Block{}
+				m.Block = &types2.Block{}
 			}
 			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
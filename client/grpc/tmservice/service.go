@@ -2,9 +2,13 @@ package tmservice
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	ics23 "github.com/confio/ics23/go"
 	gogogrpc "github.com/gogo/protobuf/grpc"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -12,24 +16,36 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	qtypes "github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/version"
 )
 
+// MaxBatchQueries is the maximum number of queries of a BatchQuery request.
+const MaxBatchQueries = 100
+
+// ABCIQueryFn executes an ABCI query, e.g. BaseApp.Query.
+type ABCIQueryFn func(context.Context, *abci.RequestQuery) (*abci.ResponseQuery, error)
+
 // This is the struct that we will implement all the handlers on.
 type queryServer struct {
 	clientCtx         client.Context
 	interfaceRegistry codectypes.InterfaceRegistry
+	queryFn           ABCIQueryFn
 }
 
 var _ ServiceServer = queryServer{}
 var _ codectypes.UnpackInterfacesMessage = &GetLatestValidatorSetResponse{}
 
-// NewQueryServer creates a new tendermint query server.
-func NewQueryServer(clientCtx client.Context, interfaceRegistry codectypes.InterfaceRegistry) ServiceServer {
+// NewQueryServer creates a new tendermint query server, executing the batch
+// queries with queryFn.
+func NewQueryServer(clientCtx client.Context, interfaceRegistry codectypes.InterfaceRegistry, queryFn ABCIQueryFn) ServiceServer {
 	return queryServer{
 		clientCtx:         clientCtx,
 		interfaceRegistry: interfaceRegistry,
+		queryFn:           queryFn,
 	}
 }
 
@@ -193,15 +209,112 @@ func (s queryServer) GetNodeInfo(ctx context.Context, req *GetNodeInfoRequest) (
 	return &resp, nil
 }
 
-// RegisterTendermintService registers the tendermint queries on the gRPC router.
+// BatchQuery implements ServiceServer.BatchQuery
+func (s queryServer) BatchQuery(ctx context.Context, req *BatchQueryRequest) (*BatchQueryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if len(req.Queries) > MaxBatchQueries {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch holds at most %d queries, got %d", MaxBatchQueries, len(req.Queries))
+	}
+	height := req.Height
+	if height == 0 {
+		// the latest committed height is resolved once, so that a block
+		// committed while the queries execute does not split them over two
+		// versions
+		height = sdk.UnwrapSDKContext(ctx).BlockHeight()
+	}
+
+	responses := make([]abci.ResponseQuery, len(req.Queries))
+	for i, query := range req.Queries {
+		if query.Path == batchQueryPath {
+			return nil, status.Errorf(codes.InvalidArgument, "query %d: batch queries cannot be nested", i)
+		}
+		res, err := s.queryFn(ctx, &abci.RequestQuery{
+			Path:   query.Path,
+			Data:   query.Data,
+			Height: height,
+			Prove:  query.Prove,
+		})
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = *res
+	}
+	return &BatchQueryResponse{Height: height, Responses: responses}, nil
+}
+
+// GetProof implements ServiceServer.GetProof
+func (s queryServer) GetProof(ctx context.Context, req *GetProofRequest) (*GetProofResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.StoreKey == "" || strings.Contains(req.StoreKey, "/") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid store key %q", req.StoreKey)
+	}
+	if len(req.Key) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty key")
+	}
+	height := req.Height
+	if height == 0 {
+		height = sdk.UnwrapSDKContext(ctx).BlockHeight()
+	}
+
+	res, err := s.queryFn(ctx, &abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", req.StoreKey),
+		Data:   req.Key,
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !res.IsOK() {
+		return nil, sdkerrors.ABCIError(res.Codespace, res.Code, res.Log)
+	}
+	if len(res.ProofOps.GetOps()) == 0 {
+		return nil, status.Error(codes.Internal, "empty proof")
+	}
+
+	// the proofs are checked while computing the root they lead to, so that a
+	// response never holds proofs that do not verify
+	args := [][]byte{}
+	if len(res.Value) > 0 {
+		args = append(args, res.Value)
+	}
+	proofs := make([]*ics23.CommitmentProof, len(res.ProofOps.GetOps()))
+	for i, proofOp := range res.ProofOps.GetOps() {
+		op, err := storetypes.CommitmentOpDecoder(proofOp)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid proof: %s", err)
+		}
+		if args, err = op.Run(args); err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid proof: %s", err)
+		}
+		proofs[i] = op.(storetypes.CommitmentOp).Proof
+	}
+	return &GetProofResponse{
+		Height:  height,
+		Value:   res.Value,
+		Proofs:  proofs,
+		AppHash: args[0],
+	}, nil
+}
+
+// batchQueryPath is the ABCI query path of the BatchQuery method.
+const batchQueryPath = "/cosmos.base.tendermint.v1beta1.Service/BatchQuery"
+
+// RegisterTendermintService registers the tendermint queries on the gRPC router,
+// the batch queries being executed with queryFn.
 func RegisterTendermintService(
 	qrt gogogrpc.Server,
 	clientCtx client.Context,
 	interfaceRegistry codectypes.InterfaceRegistry,
+	queryFn ABCIQueryFn,
 ) {
 	RegisterServiceServer(
 		qrt,
-		NewQueryServer(clientCtx, interfaceRegistry),
+		NewQueryServer(clientCtx, interfaceRegistry, queryFn),
 	)
 }
 
@@ -11,9 +11,11 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/testutil/network"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	qtypes "github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/version"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
 type IntegrationTestSuite struct {
@@ -254,6 +256,117 @@ func (s IntegrationTestSuite) TestValidatorSetByHeight_GRPCGateway() {
 	}
 }
 
+func (s IntegrationTestSuite) TestBatchQuery() {
+	val := s.network.Validators[0]
+	balanceReq, err := val.ClientCtx.Codec.Marshal(&banktypes.QueryBalanceRequest{Address: val.Address.String(), Denom: s.cfg.BondDenom})
+	s.Require().NoError(err)
+	balanceKey := banktypes.CreatePrefixedAccountStoreKey(val.Address, []byte(s.cfg.BondDenom))
+
+	res, err := s.queryClient.BatchQuery(context.Background(), &tmservice.BatchQueryRequest{
+		Queries: []tmservice.ABCIQuery{
+			{Path: "/cosmos.bank.v1beta1.Query/Balance", Data: balanceReq},
+			{Path: "/store/bank/key", Data: balanceKey, Prove: true},
+			{Path: "/unknown"},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Positive(res.Height)
+	s.Require().Len(res.Responses, 3)
+	for _, r := range res.Responses[:2] {
+		s.Require().Equal(res.Height, r.Height)
+	}
+
+	s.Require().Zero(res.Responses[0].Code, res.Responses[0].Log)
+	var balanceRes banktypes.QueryBalanceResponse
+	s.Require().NoError(val.ClientCtx.Codec.Unmarshal(res.Responses[0].Value, &balanceRes))
+	// the store query returns the same balance, along with its proof
+	s.Require().Zero(res.Responses[1].Code, res.Responses[1].Log)
+	var balance sdk.Coin
+	s.Require().NoError(val.ClientCtx.Codec.Unmarshal(res.Responses[1].Value, &balance))
+	s.Require().Equal(*balanceRes.Balance, balance)
+	s.Require().NotNil(res.Responses[1].ProofOps)
+	// a failed query does not fail the others
+	s.Require().NotZero(res.Responses[2].Code)
+
+	// the queries are executed at the requested height
+	res, err = s.queryClient.BatchQuery(context.Background(), &tmservice.BatchQueryRequest{
+		Height:  1,
+		Queries: []tmservice.ABCIQuery{{Path: "/cosmos.bank.v1beta1.Query/Balance", Data: balanceReq}},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), res.Height)
+	s.Require().Equal(int64(1), res.Responses[0].Height)
+}
+
+func (s IntegrationTestSuite) TestBatchQueryInvalid() {
+	testCases := []struct {
+		name    string
+		queries []tmservice.ABCIQuery
+	}{
+		{"too many queries", make([]tmservice.ABCIQuery, tmservice.MaxBatchQueries+1)},
+		{"nested batch", []tmservice.ABCIQuery{{Path: "/cosmos.base.tendermint.v1beta1.Service/BatchQuery"}}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			_, err := s.queryClient.BatchQuery(context.Background(), &tmservice.BatchQueryRequest{Queries: tc.queries})
+			s.Require().Error(err)
+		})
+	}
+}
+
+func (s IntegrationTestSuite) TestGetProof() {
+	val := s.network.Validators[0]
+	balanceKey := banktypes.CreatePrefixedAccountStoreKey(val.Address, []byte(s.cfg.BondDenom))
+
+	res, err := s.queryClient.GetProof(context.Background(), &tmservice.GetProofRequest{StoreKey: banktypes.StoreKey, Key: balanceKey})
+	s.Require().NoError(err)
+	var balance sdk.Coin
+	s.Require().NoError(val.ClientCtx.Codec.Unmarshal(res.Value, &balance))
+	s.Require().Equal(s.cfg.BondDenom, balance.Denom)
+	s.Require().Len(res.Proofs, 2)
+	s.Require().NotNil(res.Proofs[0].GetExist())
+
+	// the app hash the proofs lead to is committed to by the next header
+	_, err = s.network.WaitForHeight(res.Height + 1)
+	s.Require().NoError(err)
+	block, err := s.queryClient.GetBlockByHeight(context.Background(), &tmservice.GetBlockByHeightRequest{Height: res.Height + 1})
+	s.Require().NoError(err)
+	s.Require().Equal(block.Block.Header.AppHash, res.AppHash)
+
+	// the absence of a key is proven at the requested height
+	absent, err := s.queryClient.GetProof(context.Background(), &tmservice.GetProofRequest{
+		StoreKey: banktypes.StoreKey,
+		Key:      banktypes.CreatePrefixedAccountStoreKey(val.Address, []byte("absent")),
+		Height:   res.Height,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(res.Height, absent.Height)
+	s.Require().Empty(absent.Value)
+	s.Require().Len(absent.Proofs, 2)
+	s.Require().NotNil(absent.Proofs[0].GetNonexist())
+	s.Require().Equal(res.AppHash, absent.AppHash)
+}
+
+func (s IntegrationTestSuite) TestGetProofInvalid() {
+	testCases := []struct {
+		name string
+		req  *tmservice.GetProofRequest
+	}{
+		{"unknown store", &tmservice.GetProofRequest{StoreKey: "unknown", Key: []byte{1}}},
+		{"invalid store key", &tmservice.GetProofRequest{StoreKey: "bank/key", Key: []byte{1}}},
+		{"empty key", &tmservice.GetProofRequest{StoreKey: banktypes.StoreKey}},
+		{"future height", &tmservice.GetProofRequest{StoreKey: banktypes.StoreKey, Key: []byte{1}, Height: 1000000}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			_, err := s.queryClient.GetProof(context.Background(), tc.req)
+			s.Require().Error(err)
+		})
+	}
+}
+
 func TestIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(IntegrationTestSuite))
 }
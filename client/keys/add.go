@@ -113,11 +113,31 @@ func runAddCmd(ctx client.Context, cmd *cobra.Command, args []string, inBuf *buf
 
 	keyringAlgos, _ := kb.SupportedAlgorithms()
 	algoStr, _ := cmd.Flags().GetString(flags.FlagKeyAlgorithm)
+	remoteKb, isRemote := kb.(keyring.RemoteKeyring)
+	if isRemote && !cmd.Flags().Changed(flags.FlagKeyAlgorithm) && len(keyringAlgos) > 0 {
+		// the default algorithm of the flag may not be supported by the
+		// remote signer
+		algoStr = string(keyringAlgos[0].Name())
+	}
 	algo, err := keyring.NewSigningAlgoFromString(algoStr, keyringAlgos)
 	if err != nil {
 		return err
 	}
 
+	// the keys of a remote signer are created by the signer, which keeps
+	// their private keys
+	if isRemote {
+		if dryRun, _ := cmd.Flags().GetBool(flags.FlagDryRun); dryRun {
+			return errors.New("keys of a remote signer cannot be created in dry-run mode")
+		}
+		info, err := remoteKb.CreateKey(name, algo.Name())
+		if err != nil {
+			return err
+		}
+
+		return printCreate(cmd, info, false, "", outputFormat)
+	}
+
 	if dryRun, _ := cmd.Flags().GetBool(flags.FlagDryRun); dryRun {
 		// use in memory keybase
 		kb = keyring.NewInMemory()
@@ -37,7 +37,7 @@ func PruningCmd(appCreator servertypes.AppCreator) *cobra.Command {
 		custom: allow pruning options to be manually specified through 'pruning-keep-recent'.
 		besides pruning options, database home directory and database backend type should also be specified via flags
 		'--home' and '--app-db-backend'.
-		valid app-db-backend type includes 'goleveldb', 'cleveldb', 'rocksdb', 'boltdb', and 'badgerdb'.
+		valid app-db-backend type includes 'goleveldb', 'cleveldb', 'rocksdb', 'boltdb', 'badgerdb', and 'pebbledb'.
 		`,
 		Example: `prune --home './' --app-db-backend 'goleveldb' --pruning 'custom' --pruning-keep-recent 100 --
 		pruning-keep-every 10, --pruning-interval 10`,
@@ -57,7 +57,7 @@ func PruningCmd(appCreator servertypes.AppCreator) *cobra.Command {
 			)
 
 			home := vp.GetString(flags.FlagHome)
-			db, err := openDB(home)
+			db, err := openDB(home, server.GetAppDBBackend(vp))
 			if err != nil {
 				return err
 			}
@@ -116,7 +116,7 @@ func PruningCmd(appCreator servertypes.AppCreator) *cobra.Command {
 	return cmd
 }
 
-func openDB(rootDir string) (dbm.DB, error) {
+func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
 	dataDir := filepath.Join(rootDir, "data")
-	return sdk.NewLevelDB("application", dataDir)
+	return sdk.NewDB("application", backendType, dataDir)
 }
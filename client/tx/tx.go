@@ -91,6 +91,17 @@ func BroadcastTx(clientCtx client.Context, txf Factory, msgs ...sdk.Msg) error {
 		return err
 	}
 
+	if clientCtx.SimulateTrace {
+		if !clientCtx.Simulate {
+			return fmt.Errorf("--%s requires --%s", flags.FlagDryRunTrace, flags.FlagDryRun)
+		}
+		res, err := SimulateWithTrace(clientCtx, txf, msgs...)
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintProto(res)
+	}
+
 	if txf.SimulateAndExecute() || clientCtx.Simulate {
 		_, adjusted, err := CalculateGas(clientCtx, txf, msgs...)
 		if err != nil {
@@ -251,6 +262,23 @@ func CalculateGas(
 	return simRes, uint64(txf.GasAdjustment() * float64(simRes.GasInfo.GasUsed)), nil
 }
 
+// SimulateWithTrace simulates the execution of a transaction and returns the
+// trace of the simulation, summarizing its store accesses. A transaction
+// failing is not an error: the error of the trace is set instead.
+func SimulateWithTrace(
+	clientCtx gogogrpc.ClientConn, txf Factory, msgs ...sdk.Msg,
+) (*tx.SimulateWithTraceResponse, error) {
+	txBytes, err := BuildSimTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	txSvcClient := tx.NewServiceClient(clientCtx)
+	return txSvcClient.SimulateWithTrace(context.Background(), &tx.SimulateWithTraceRequest{
+		TxBytes: txBytes,
+	})
+}
+
 // prepareFactory ensures the account defined by ctx.GetFromAddress() exists and
 // if the account number and/or the account sequence number are zero (not set),
 // they will be queried for and set on the provided Factory. A new Factory with
@@ -38,9 +38,20 @@ func (m mockContext) Invoke(grpcCtx gocontext.Context, method string, req, reply
 		return fmt.Errorf("mock err")
 	}
 
-	*(reply.(*txtypes.SimulateResponse)) = txtypes.SimulateResponse{
-		GasInfo: &sdk.GasInfo{GasUsed: m.gasUsed, GasWanted: m.gasUsed},
-		Result:  &sdk.Result{Data: []byte("tx data"), Log: "log"},
+	switch reply := reply.(type) {
+	case *txtypes.SimulateResponse:
+		*reply = txtypes.SimulateResponse{
+			GasInfo: &sdk.GasInfo{GasUsed: m.gasUsed, GasWanted: m.gasUsed},
+			Result:  &sdk.Result{Data: []byte("tx data"), Log: "log"},
+		}
+	case *txtypes.SimulateWithTraceResponse:
+		if len(req.(*txtypes.SimulateWithTraceRequest).TxBytes) == 0 {
+			return fmt.Errorf("empty tx bytes")
+		}
+		*reply = txtypes.SimulateWithTraceResponse{
+			Trace:  &sdk.TxTrace{GasInfo: sdk.GasInfo{GasUsed: m.gasUsed, GasWanted: m.gasUsed}},
+			Result: &sdk.Result{Data: []byte("tx data"), Log: "log"},
+		}
 	}
 
 	return nil
@@ -95,6 +106,21 @@ func TestCalculateGas(t *testing.T) {
 	}
 }
 
+func TestSimulateWithTrace(t *testing.T) {
+	txCfg := NewTestTxConfig()
+	txf := tx.Factory{}.
+		WithChainID("test-chain").
+		WithTxConfig(txCfg).WithSignMode(txCfg.SignModeHandler().DefaultMode())
+
+	res, err := tx.SimulateWithTrace(mockContext{gasUsed: 10}, txf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), res.Trace.GasInfo.GasUsed)
+	require.NotNil(t, res.Result)
+
+	_, err = tx.SimulateWithTrace(mockContext{wantErr: true}, txf)
+	require.Error(t, err)
+}
+
 func TestBuildSimTx(t *testing.T) {
 	txCfg := NewTestTxConfig()
 
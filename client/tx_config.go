@@ -45,3 +45,26 @@ type (
 		SetFeeGranter(feeGranter sdk.AccAddress)
 	}
 )
+
+// txConfigWithDecoders is a TxConfig whose decoder falls back to the decoders
+// of alternative tx envelopes.
+type txConfigWithDecoders struct {
+	TxConfig
+	decoder sdk.TxDecoder
+}
+
+// WithTxDecoders returns txConfig with a decoder trying the decoder of txConfig
+// first, then decoders in order, see sdk.ChainTxDecoders. It lets an app share
+// one decoder of all the tx envelopes it accepts between the BaseApp, the tx
+// service and the other users of its TxConfig. The txs of the alternative
+// envelopes are neither encoded nor built by the TxConfig.
+func WithTxDecoders(txConfig TxConfig, decoders ...sdk.TxDecoder) TxConfig {
+	return txConfigWithDecoders{
+		TxConfig: txConfig,
+		decoder:  sdk.ChainTxDecoders(append([]sdk.TxDecoder{txConfig.TxDecoder()}, decoders...)...),
+	}
+}
+
+func (c txConfigWithDecoders) TxDecoder() sdk.TxDecoder {
+	return c.decoder
+}
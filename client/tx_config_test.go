@@ -0,0 +1,50 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+)
+
+type envelopeTx struct{}
+
+func (envelopeTx) GetMsgs() []sdk.Msg   { return nil }
+func (envelopeTx) ValidateBasic() error { return nil }
+
+func TestWithTxDecoders(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	testdata.RegisterInterfaces(interfaceRegistry)
+	txConfig := authtx.NewTxConfig(codec.NewProtoCodec(interfaceRegistry), authtx.DefaultSignModes)
+
+	envelope := []byte("envelope")
+	txConfig = client.WithTxDecoders(txConfig, func(txBytes []byte) (sdk.Tx, error) {
+		if string(txBytes) != string(envelope) {
+			return nil, sdkerrors.ErrTxDecode
+		}
+		return envelopeTx{}, nil
+	})
+
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(testdata.NewTestMsg(sdk.AccAddress("addr"))))
+	txBytes, err := txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	tx, err := txConfig.TxDecoder()(txBytes)
+	require.NoError(t, err)
+	require.Len(t, tx.GetMsgs(), 1)
+
+	tx, err = txConfig.TxDecoder()(envelope)
+	require.NoError(t, err)
+	require.Equal(t, envelopeTx{}, tx)
+
+	_, err = txConfig.TxDecoder()([]byte("unknown"))
+	require.Error(t, err)
+}
@@ -22,6 +22,9 @@ const (
 	Ed25519Type = PubKeyType("ed25519")
 	// Sr25519Type represents the Sr25519Type signature system.
 	Sr25519Type = PubKeyType("sr25519")
+	// Secp256r1Type uses the NIST P-256 ECDSA parameters. Its keys cannot be
+	// derived from a mnemonic, they are held by a remote KMS.
+	Secp256r1Type = PubKeyType("secp256r1")
 )
 
 var (
@@ -36,6 +36,7 @@ const (
 	BackendPass    = "pass"
 	BackendTest    = "test"
 	BackendMemory  = "memory"
+	BackendKMS     = "kms"
 )
 
 const (
@@ -139,6 +140,9 @@ type Options struct {
 	SupportedAlgos SigningAlgoList
 	// supported signing algorithms for Ledger
 	SupportedAlgosLedger SigningAlgoList
+	// RemoteSigner is the signer of the kms backend, overriding the one of
+	// its configuration file, e.g. to sign with another KMS than Vault
+	RemoteSigner RemoteSigner
 }
 
 // NewInMemory creates a transient keyring useful for testing
@@ -156,7 +160,7 @@ func NewInMemoryWithKeyring(kr keyring.Keyring, opts ...Option) Keyring {
 
 // New creates a new instance of a keyring.
 // Keyring ptions can be applied when generating the new instance.
-// Available backends are "os", "file", "kwallet", "memory", "pass", "test".
+// Available backends are "os", "file", "kwallet", "memory", "pass", "test", "kms".
 func New(
 	appName, backend, rootDir string, userInput io.Reader, opts ...Option,
 ) (Keyring, error) {
@@ -178,6 +182,12 @@ func New(
 		db, err = keyring.Open(newKWalletBackendKeyringConfig(appName, rootDir, userInput))
 	case BackendPass:
 		db, err = keyring.Open(newPassBackendKeyringConfig(appName, rootDir, userInput))
+	case BackendKMS:
+		var options Options
+		for _, optionFn := range opts {
+			optionFn(&options)
+		}
+		return newKMSKeyring(rootDir, options)
 	default:
 		return nil, fmt.Errorf("unknown keyring backend %v", backend)
 	}
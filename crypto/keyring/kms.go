@@ -0,0 +1,310 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256r1"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// kmsConfigFileName is the name of the file configuring the kms backend,
+	// in the keyring directory.
+	kmsConfigFileName = "keyring-kms.toml"
+
+	// vaultTokenEnv is the environment variable holding the Vault token when
+	// the configuration sets no token file.
+	vaultTokenEnv = "VAULT_TOKEN"
+)
+
+// KMSConfig configures the kms keyring backend, which signs with the keys of
+// the transit secrets engine of a HashiCorp Vault server. It is read from the
+// keyring-kms.toml file of the keyring directory, e.g.
+//
+//	address = "https://vault.example.com:8200"
+//	mount = "transit"
+//	token-file = "/etc/vault/token"
+//	algorithms = ["secp256r1"]
+type KMSConfig struct {
+	// Address is the URL of the Vault server.
+	Address string `mapstructure:"address"`
+	// Mount is the path the transit engine is mounted at, "transit" by
+	// default.
+	Mount string `mapstructure:"mount"`
+	// Namespace is the Vault Enterprise namespace of the engine, if any.
+	Namespace string `mapstructure:"namespace"`
+	// TokenFile is the file holding the token authenticating the requests,
+	// read from the VAULT_TOKEN environment variable if empty.
+	TokenFile string `mapstructure:"token-file"`
+	// Algorithms are the signing algorithms of the keys listed and created,
+	// secp256r1 by default.
+	Algorithms []string `mapstructure:"algorithms"`
+	// Timeout is the timeout of the requests, 10s by default.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// vaultKeyTypes are the Vault transit key types of the supported algorithms.
+var vaultKeyTypes = map[hd.PubKeyType]string{
+	hd.Secp256r1Type: "ecdsa-p256",
+}
+
+// newKMSKeyring returns the keyring of the kms backend configured in rootDir,
+// or signing with the remote signer of the options if set.
+func newKMSKeyring(rootDir string, options Options) (Keyring, error) {
+	if options.RemoteSigner != nil {
+		return NewRemote(options.RemoteSigner, SigningAlgoList{Secp256r1}), nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(rootDir, kmsConfigFileName))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read the kms backend configuration: %w", err)
+	}
+	var cfg KMSConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the kms backend configuration: %w", err)
+	}
+	signer, err := NewVaultSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemote(signer, signer.algos), nil
+}
+
+var _ RemoteSigner = (*VaultSigner)(nil)
+
+// VaultSigner is a RemoteSigner signing with the keys of the transit secrets
+// engine of a HashiCorp Vault server.
+type VaultSigner struct {
+	baseURL   string
+	token     string
+	namespace string
+	algos     SigningAlgoList
+	client    *http.Client
+}
+
+// NewVaultSigner returns a signer of the keys of the Vault transit engine of
+// cfg.
+func NewVaultSigner(cfg KMSConfig) (*VaultSigner, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("kms backend: no Vault address")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "transit"
+	}
+	if len(cfg.Algorithms) == 0 {
+		cfg.Algorithms = []string{string(hd.Secp256r1Type)}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	var algos SigningAlgoList
+	for _, algo := range cfg.Algorithms {
+		if _, ok := vaultKeyTypes[hd.PubKeyType(algo)]; !ok {
+			return nil, fmt.Errorf("kms backend: %w: %s", ErrUnsupportedSigningAlgo, algo)
+		}
+		algos = append(algos, remoteAlgo(algo))
+	}
+
+	token := os.Getenv(vaultTokenEnv)
+	if cfg.TokenFile != "" {
+		bz, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("kms backend: failed to read the Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(bz))
+	}
+	if token == "" {
+		return nil, fmt.Errorf("kms backend: no Vault token, set token-file or %s", vaultTokenEnv)
+	}
+
+	return &VaultSigner{
+		baseURL:   strings.TrimSuffix(cfg.Address, "/") + "/v1/" + strings.Trim(cfg.Mount, "/"),
+		token:     token,
+		namespace: cfg.Namespace,
+		algos:     algos,
+		client:    &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Keys implements RemoteSigner.
+func (s *VaultSigner) Keys() ([]string, error) {
+	var res struct {
+		Keys []string `json:"keys"`
+	}
+	err := s.do(http.MethodGet, "/keys?list=true", nil, &res)
+	if errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		// the engine has no key
+		return nil, nil
+	}
+	return res.Keys, err
+}
+
+// PubKey implements RemoteSigner. Only the keys of the configured algorithms
+// are returned, the others being reported as unsupported.
+func (s *VaultSigner) PubKey(name string) (types.PubKey, error) {
+	var res struct {
+		Type          string `json:"type"`
+		LatestVersion int    `json:"latest_version"`
+		Keys          map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+	}
+	if err := s.do(http.MethodGet, "/keys/"+url.PathEscape(name), nil, &res); err != nil {
+		return nil, err
+	}
+	if !s.supports(res.Type) {
+		return nil, fmt.Errorf("%w: key %s is of type %s", ErrUnsupportedSigningAlgo, name, res.Type)
+	}
+
+	// the signatures are made with the latest version of the key
+	key, ok := res.Keys[fmt.Sprint(res.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("no public key for version %d of key %s", res.LatestVersion, name)
+	}
+	block, _ := pem.Decode([]byte(key.PublicKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key of key %s", name)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of key %s: %w", name, err)
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok || ecdsaPub.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("public key of key %s is not a P-256 key", name)
+	}
+	return secp256r1.NewPubKey(ecdsaPub)
+}
+
+// Sign implements RemoteSigner. The signature is the low-S concatenation of
+// R and S the secp256r1 public keys verify.
+func (s *VaultSigner) Sign(name string, msg []byte) ([]byte, error) {
+	req := map[string]string{
+		"input":                base64.StdEncoding.EncodeToString(msg),
+		"hash_algorithm":       "sha2-256",
+		"marshaling_algorithm": "jws",
+	}
+	var res struct {
+		Signature string `json:"signature"`
+	}
+	if err := s.do(http.MethodPost, "/sign/"+url.PathEscape(name), req, &res); err != nil {
+		return nil, err
+	}
+
+	// the signature is prefixed with "vault:v<key version>:"
+	parts := strings.Split(res.Signature, ":")
+	sig, err := base64.RawURLEncoding.DecodeString(parts[len(parts)-1])
+	if err != nil || len(sig) != 64 {
+		return nil, fmt.Errorf("invalid signature of key %s", name)
+	}
+	return lowS(sig), nil
+}
+
+// CreateKey implements RemoteSigner.
+func (s *VaultSigner) CreateKey(name string, algo hd.PubKeyType) (types.PubKey, error) {
+	keyType, ok := vaultKeyTypes[algo]
+	if !ok || !s.algos.Contains(remoteAlgo(algo)) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgo, algo)
+	}
+	if err := s.do(http.MethodPost, "/keys/"+url.PathEscape(name), map[string]string{"type": keyType}, nil); err != nil {
+		return nil, err
+	}
+	return s.PubKey(name)
+}
+
+func (s *VaultSigner) supports(keyType string) bool {
+	for _, algo := range s.algos {
+		if vaultKeyTypes[algo.Name()] == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+// do sends a request to the transit engine and decodes the data of its
+// response into res. A missing resource is reported as ErrKeyNotFound.
+func (s *VaultSigner) do(method, path string, body interface{}, res interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		bz, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bz)
+	}
+	req, err := http.NewRequest(method, s.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kms request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil && err != io.EOF {
+			return fmt.Errorf("invalid kms response: %w", err)
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, strings.TrimPrefix(path, "/"))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("kms request failed: %s: %s", resp.Status, strings.Join(envelope.Errors, "; "))
+	case res == nil || len(envelope.Data) == 0:
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, res)
+}
+
+// secp256r1HalfOrder is half the order of the secp256r1 curve.
+var secp256r1HalfOrder = new(big.Int).Rsh(elliptic.P256().Params().N, 1)
+
+// lowS returns the R || S signature sig with S in the lower half of the curve
+// order, the form the secp256r1 public keys accept.
+func lowS(sig []byte) []byte {
+	sVal := new(big.Int).SetBytes(sig[32:])
+	if sVal.Cmp(secp256r1HalfOrder) <= 0 {
+		return sig
+	}
+	sVal.Sub(elliptic.P256().Params().N, sVal)
+	out := make([]byte, 64)
+	copy(out, sig[:32])
+	sVal.FillBytes(out[32:])
+	return out
+}
@@ -0,0 +1,191 @@
+package keyring
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// fakeVault is a Vault transit engine holding P-256 keys in memory.
+type fakeVault struct {
+	t     *testing.T
+	mtx   sync.Mutex
+	keys  map[string]*ecdsa.PrivateKey
+	types map[string]string
+	// highS makes the signatures use the high S of the signature pair
+	highS bool
+}
+
+func newFakeVault(t *testing.T) *httptest.Server {
+	v := &fakeVault{t: t, keys: map[string]*ecdsa.PrivateKey{}, types: map[string]string{}}
+	return httptest.NewServer(v)
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if r.Header.Get("X-Vault-Token") != "token" {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/transit")
+	switch {
+	case path == "/keys" && r.URL.Query().Get("list") == "true":
+		if len(v.keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var names []string
+		for name := range v.keys {
+			names = append(names, name)
+		}
+		v.reply(w, map[string]interface{}{"keys": names})
+
+	case strings.HasPrefix(path, "/keys/") && r.Method == http.MethodPost:
+		var req struct{ Type string }
+		require.NoError(v.t, json.NewDecoder(r.Body).Decode(&req))
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(v.t, err)
+		name := strings.TrimPrefix(path, "/keys/")
+		v.keys[name] = priv
+		v.types[name] = req.Type
+		w.WriteHeader(http.StatusNoContent)
+
+	case strings.HasPrefix(path, "/keys/"):
+		name := strings.TrimPrefix(path, "/keys/")
+		priv, ok := v.keys[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+		require.NoError(v.t, err)
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+		v.reply(w, map[string]interface{}{
+			"type":           v.types[name],
+			"latest_version": 1,
+			"keys":           map[string]interface{}{"1": map[string]string{"public_key": string(pemKey)}},
+		})
+
+	case strings.HasPrefix(path, "/sign/"):
+		priv, ok := v.keys[strings.TrimPrefix(path, "/sign/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Input string `json:"input"`
+		}
+		require.NoError(v.t, json.NewDecoder(r.Body).Decode(&req))
+		msg, err := base64.StdEncoding.DecodeString(req.Input)
+		require.NoError(v.t, err)
+		hash := sha256.Sum256(msg)
+		rVal, sVal, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+		require.NoError(v.t, err)
+		if v.highS && sVal.Cmp(secp256r1HalfOrder) <= 0 {
+			sVal = new(big.Int).Sub(elliptic.P256().Params().N, sVal)
+		}
+		sig := make([]byte, 64)
+		rVal.FillBytes(sig[:32])
+		sVal.FillBytes(sig[32:])
+		v.reply(w, map[string]string{"signature": "vault:v1:" + base64.RawURLEncoding.EncodeToString(sig)})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (v *fakeVault) reply(w http.ResponseWriter, data interface{}) {
+	require.NoError(v.t, json.NewEncoder(w).Encode(map[string]interface{}{"data": data}))
+}
+
+func newKMSTestKeyring(t *testing.T, address string) Keyring {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+	cfg := "address = \"" + address + "\"\ntoken-file = \"" + tokenFile + "\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kmsConfigFileName), []byte(cfg), 0o600))
+
+	kr, err := New("keybasename", BackendKMS, dir, nil)
+	require.NoError(t, err)
+	return kr
+}
+
+func TestKMSKeyring(t *testing.T) {
+	srv := newFakeVault(t)
+	defer srv.Close()
+	kr := newKMSTestKeyring(t, srv.URL)
+
+	infos, err := kr.List()
+	require.NoError(t, err)
+	require.Empty(t, infos)
+
+	remote, ok := kr.(RemoteKeyring)
+	require.True(t, ok)
+	info, err := remote.CreateKey("validator", hd.Secp256r1Type)
+	require.NoError(t, err)
+	require.Equal(t, TypeRemote, info.GetType())
+	require.Equal(t, hd.Secp256r1Type, info.GetAlgo())
+	_, err = remote.CreateKey("validator", hd.Secp256r1Type)
+	require.EqualError(t, err, "cannot overwrite key: validator")
+	_, err = remote.CreateKey("other", hd.Secp256k1Type)
+	require.ErrorIs(t, err, ErrUnsupportedSigningAlgo)
+
+	infos, err = kr.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, info.GetAddress(), infos[0].GetAddress())
+
+	msg := []byte("message")
+	for _, highS := range []bool{false, true} {
+		srv.Config.Handler.(*fakeVault).highS = highS
+		sig, pub, err := kr.SignByAddress(info.GetAddress(), msg)
+		require.NoError(t, err)
+		require.True(t, pub.Equals(info.GetPubKey()))
+		require.True(t, pub.VerifySignature(msg, sig))
+	}
+
+	_, _, err = kr.Sign("missing", msg)
+	require.ErrorIs(t, err, sdkerrors.ErrKeyNotFound)
+	require.ErrorIs(t, kr.Delete("validator"), ErrRemoteKey)
+	_, err = kr.ExportPrivKeyArmor("validator", "passphrase")
+	require.ErrorIs(t, err, ErrRemoteKey)
+	_, _, err = kr.NewMnemonic("key", English, hd.CreateHDPath(sdk.CoinType, 0, 0).String(), DefaultBIP39Passphrase, hd.Secp256k1)
+	require.ErrorIs(t, err, ErrRemoteKey)
+}
+
+func TestKMSKeyringConfig(t *testing.T) {
+	_, err := New("keybasename", BackendKMS, t.TempDir(), nil)
+	require.ErrorContains(t, err, "failed to read the kms backend configuration")
+
+	t.Setenv(vaultTokenEnv, "")
+	_, err = NewVaultSigner(KMSConfig{Address: "http://localhost:8200"})
+	require.EqualError(t, err, "kms backend: no Vault token, set token-file or VAULT_TOKEN")
+	t.Setenv(vaultTokenEnv, "token")
+	_, err = NewVaultSigner(KMSConfig{Address: "http://localhost:8200", Algorithms: []string{"secp256k1"}})
+	require.ErrorIs(t, err, ErrUnsupportedSigningAlgo)
+	signer, err := NewVaultSigner(KMSConfig{Address: "http://localhost:8200/", Mount: "/eng/transit/"})
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8200/v1/eng/transit", signer.baseURL)
+}
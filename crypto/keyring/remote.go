@@ -0,0 +1,240 @@
+package keyring
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ErrRemoteKey is raised by the operations a remote keyring does not support,
+// as its private keys never leave the remote signer.
+var ErrRemoteKey = errors.New("not supported by the keys of a remote signer")
+
+// RemoteSigner is a remote service, such as a KMS, holding private keys and
+// signing with them, see NewRemote.
+type RemoteSigner interface {
+	// Keys returns the names of the keys of the signer.
+	Keys() ([]string, error)
+	// PubKey returns the public key of a key, or an error wrapping
+	// sdkerrors.ErrKeyNotFound if the signer has no key of that name.
+	PubKey(name string) (types.PubKey, error)
+	// Sign signs msg with a key, the signature being verifiable by its
+	// public key.
+	Sign(name string, msg []byte) ([]byte, error)
+	// CreateKey creates a key of algo in the signer.
+	CreateKey(name string, algo hd.PubKeyType) (types.PubKey, error)
+}
+
+// RemoteKeyring is a keyring whose keys are created by a remote signer rather
+// than derived from a mnemonic.
+type RemoteKeyring interface {
+	Keyring
+
+	// CreateKey creates a key of algo in the remote signer and returns its
+	// Info.
+	CreateKey(uid string, algo hd.PubKeyType) (Info, error)
+}
+
+var (
+	_ RemoteKeyring = remoteKeyring{}
+	_ Info          = remoteInfo{}
+)
+
+// remoteKeyring is a keyring listing and signing with the keys of a remote
+// signer. Nothing is stored locally: the keys, their names and their public
+// keys are the ones of the signer.
+type remoteKeyring struct {
+	signer RemoteSigner
+	algos  SigningAlgoList
+}
+
+// NewRemote returns a keyring delegating the signatures to signer, whose
+// keys never exist on disk. The keys of the signer are managed in the signer,
+// e.g. they cannot be imported, exported or deleted through the keyring.
+func NewRemote(signer RemoteSigner, algos SigningAlgoList) RemoteKeyring {
+	return remoteKeyring{signer: signer, algos: algos}
+}
+
+func (rk remoteKeyring) List() ([]Info, error) {
+	names, err := rk.signer.Keys()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	infos := make([]Info, 0, len(names))
+	for _, name := range names {
+		info, err := rk.Key(name)
+		if errors.Is(err, ErrUnsupportedSigningAlgo) {
+			// the signer may hold keys of other uses
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (rk remoteKeyring) SupportedAlgorithms() (SigningAlgoList, SigningAlgoList) {
+	return rk.algos, SigningAlgoList{}
+}
+
+func (rk remoteKeyring) Key(uid string) (Info, error) {
+	pub, err := rk.signer.PubKey(uid)
+	if err != nil {
+		return nil, err
+	}
+	return remoteInfo{Name: uid, PubKey: pub}, nil
+}
+
+func (rk remoteKeyring) KeyByAddress(address sdk.Address) (Info, error) {
+	infos, err := rk.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetAddress().Equals(address) {
+			return info, nil
+		}
+	}
+	return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprint("key with address ", address, " not found"))
+}
+
+func (rk remoteKeyring) CreateKey(uid string, algo hd.PubKeyType) (Info, error) {
+	if !rk.algos.Contains(remoteAlgo(algo)) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgo, algo)
+	}
+	if _, err := rk.signer.PubKey(uid); err == nil {
+		return nil, fmt.Errorf("cannot overwrite key: %s", uid)
+	}
+	pub, err := rk.signer.CreateKey(uid, algo)
+	if err != nil {
+		return nil, err
+	}
+	return remoteInfo{Name: uid, PubKey: pub}, nil
+}
+
+func (rk remoteKeyring) Sign(uid string, msg []byte) ([]byte, types.PubKey, error) {
+	pub, err := rk.signer.PubKey(uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err := rk.signer.Sign(uid, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, pub, nil
+}
+
+func (rk remoteKeyring) SignByAddress(address sdk.Address, msg []byte) ([]byte, types.PubKey, error) {
+	info, err := rk.KeyByAddress(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rk.Sign(info.GetName(), msg)
+}
+
+func (rk remoteKeyring) Delete(uid string) error {
+	return errors.Wrap(ErrRemoteKey, "delete the key in the remote signer")
+}
+
+func (rk remoteKeyring) DeleteByAddress(address sdk.Address) error {
+	return rk.Delete("")
+}
+
+func (rk remoteKeyring) NewMnemonic(string, Language, string, string, SignatureAlgo) (Info, string, error) {
+	return nil, "", errors.Wrap(ErrRemoteKey, "keys cannot be derived from a mnemonic")
+}
+
+func (rk remoteKeyring) NewAccount(string, string, string, string, SignatureAlgo) (Info, error) {
+	return nil, errors.Wrap(ErrRemoteKey, "keys cannot be derived from a mnemonic")
+}
+
+func (rk remoteKeyring) SaveLedgerKey(string, SignatureAlgo, string, uint32, uint32, uint32) (Info, error) {
+	return nil, errors.Wrap(ErrRemoteKey, "ledger keys cannot be saved")
+}
+
+func (rk remoteKeyring) SavePubKey(string, types.PubKey, hd.PubKeyType) (Info, error) {
+	return nil, errors.Wrap(ErrRemoteKey, "offline keys cannot be saved")
+}
+
+func (rk remoteKeyring) SaveMultisig(string, types.PubKey) (Info, error) {
+	return nil, errors.Wrap(ErrRemoteKey, "multisig keys cannot be saved")
+}
+
+func (rk remoteKeyring) ImportPrivKey(string, string, string) error {
+	return errors.Wrap(ErrRemoteKey, "private keys cannot be imported")
+}
+
+func (rk remoteKeyring) ExportPrivKeyArmor(string, string) (string, error) {
+	return "", errors.Wrap(ErrRemoteKey, "private keys cannot be exported")
+}
+
+func (rk remoteKeyring) ExportPrivKeyArmorByAddress(sdk.Address, string) (string, error) {
+	return "", errors.Wrap(ErrRemoteKey, "private keys cannot be exported")
+}
+
+// remoteAlgo is the algorithm of the keys of a remote signer, which are not
+// derived nor generated locally.
+type remoteAlgo hd.PubKeyType
+
+// Secp256r1 is the algorithm of the secp256r1 keys of a remote signer.
+var Secp256r1 = remoteAlgo(hd.Secp256r1Type)
+
+func (a remoteAlgo) Name() hd.PubKeyType {
+	return hd.PubKeyType(a)
+}
+
+func (a remoteAlgo) Derive() hd.DeriveFn {
+	return func(string, string, string) ([]byte, error) {
+		return nil, errors.Wrap(ErrRemoteKey, "keys cannot be derived")
+	}
+}
+
+func (a remoteAlgo) Generate() hd.GenerateFn {
+	return func([]byte) types.PrivKey { return nil }
+}
+
+// remoteInfo is the public information about a key of a remote signer.
+type remoteInfo struct {
+	Name   string       `json:"name"`
+	PubKey types.PubKey `json:"pubkey"`
+}
+
+// GetType implements Info interface
+func (i remoteInfo) GetType() KeyType {
+	return TypeRemote
+}
+
+// GetName implements Info interface
+func (i remoteInfo) GetName() string {
+	return i.Name
+}
+
+// GetPubKey implements Info interface
+func (i remoteInfo) GetPubKey() types.PubKey {
+	return i.PubKey
+}
+
+// GetAddress implements Info interface
+func (i remoteInfo) GetAddress() sdk.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
+// GetAlgo implements Info interface
+func (i remoteInfo) GetAlgo() hd.PubKeyType {
+	return hd.PubKeyType(i.PubKey.Type())
+}
+
+// GetPath implements Info interface
+func (i remoteInfo) GetPath() (*hd.BIP44Params, error) {
+	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
+}
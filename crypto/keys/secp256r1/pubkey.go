@@ -1,6 +1,9 @@
 package secp256r1
 
 import (
+	goecdsa "crypto/ecdsa"
+	"fmt"
+
 	"github.com/gogo/protobuf/proto"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 
@@ -8,6 +11,15 @@ import (
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 )
 
+// NewPubKey returns the public key of an ECDSA key of the secp256r1 curve, such
+// as a key held by a KMS.
+func NewPubKey(pub *goecdsa.PublicKey) (*PubKey, error) {
+	if pub.Curve != secp256r1 {
+		return nil, fmt.Errorf("public key is not of the %s curve", name)
+	}
+	return &PubKey{Key: &ecdsaPK{ecdsa.PubKey{PublicKey: *pub}}}, nil
+}
+
 // String implements proto.Message interface.
 func (m *PubKey) String() string {
 	return m.Key.String(name)
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// newLocalABCIClient returns the client through which the in-process
+// Tendermint node calls app.
+//
+// The calls are not serialized by default: the queries, i.e. the Info and
+// Query calls, are served from the committed versions of the stores, so heavy
+// query traffic never delays the execution of the blocks, while BaseApp guards
+// its own state for the other calls. With serialize set, every call holds a
+// single lock instead, the way the upstream local client does.
+func newLocalABCIClient(logger log.Logger, app abci.Application, serialize bool) abciclient.Client {
+	client := abciclient.NewLocalClient(logger, app)
+	if !serialize {
+		return client
+	}
+	return &serializedABCIClient{Client: client}
+}
+
+// serializedABCIClient is a local client making one ABCI call at a time.
+type serializedABCIClient struct {
+	abciclient.Client
+	mtx sync.Mutex
+}
+
+func (c *serializedABCIClient) Info(ctx context.Context, req *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.Info(ctx, req)
+}
+
+func (c *serializedABCIClient) Query(ctx context.Context, req *abci.RequestQuery) (*abci.ResponseQuery, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.Query(ctx, req)
+}
+
+func (c *serializedABCIClient) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.CheckTx(ctx, req)
+}
+
+func (c *serializedABCIClient) InitChain(ctx context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.InitChain(ctx, req)
+}
+
+func (c *serializedABCIClient) PrepareProposal(ctx context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.PrepareProposal(ctx, req)
+}
+
+func (c *serializedABCIClient) ProcessProposal(ctx context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.ProcessProposal(ctx, req)
+}
+
+func (c *serializedABCIClient) Commit(ctx context.Context) (*abci.ResponseCommit, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.Commit(ctx)
+}
+
+func (c *serializedABCIClient) ExtendVote(ctx context.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.ExtendVote(ctx, req)
+}
+
+func (c *serializedABCIClient) VerifyVoteExtension(ctx context.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.VerifyVoteExtension(ctx, req)
+}
+
+func (c *serializedABCIClient) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.FinalizeBlock(ctx, req)
+}
+
+func (c *serializedABCIClient) ListSnapshots(ctx context.Context, req *abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.ListSnapshots(ctx, req)
+}
+
+func (c *serializedABCIClient) OfferSnapshot(ctx context.Context, req *abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.OfferSnapshot(ctx, req)
+}
+
+func (c *serializedABCIClient) LoadSnapshotChunk(ctx context.Context, req *abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.LoadSnapshotChunk(ctx, req)
+}
+
+func (c *serializedABCIClient) ApplySnapshotChunk(ctx context.Context, req *abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.ApplySnapshotChunk(ctx, req)
+}
+
+func (c *serializedABCIClient) LoadLatest(ctx context.Context, req *abci.RequestLoadLatest) (*abci.ResponseLoadLatest, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Client.LoadLatest(ctx, req)
+}
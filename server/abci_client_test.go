@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// blockingApp is an application whose FinalizeBlock runs until unblock is
+// closed.
+type blockingApp struct {
+	abci.BaseApplication
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (app *blockingApp) FinalizeBlock(context.Context, *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	close(app.started)
+	<-app.unblock
+	return &abci.ResponseFinalizeBlock{}, nil
+}
+
+func TestLocalABCIClientQueries(t *testing.T) {
+	for _, serialize := range []bool{false, true} {
+		app := &blockingApp{started: make(chan struct{}), unblock: make(chan struct{})}
+		client := newLocalABCIClient(log.NewNopLogger(), app, serialize)
+		go client.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{}) //nolint:errcheck
+		<-app.started
+
+		queried := make(chan struct{})
+		go func() {
+			_, err := client.Query(context.Background(), &abci.RequestQuery{})
+			require.NoError(t, err)
+			close(queried)
+		}()
+		select {
+		case <-queried:
+			require.False(t, serialize, "the query did not wait for the block")
+		case <-time.After(100 * time.Millisecond):
+			require.True(t, serialize, "the query waited for the block")
+		}
+		close(app.unblock)
+		<-queried
+	}
+}
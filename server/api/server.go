@@ -18,6 +18,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/server/ratelimit"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/cosmos/cosmos-sdk/types/rest"
@@ -34,6 +35,7 @@ type Server struct {
 
 	logger  log.Logger
 	metrics *telemetry.Metrics
+	limiter *ratelimit.Limiter
 	// Start() is blocking and generally called from a separate goroutine.
 	// Close() can be called asynchronously and access shared memory
 	// via the listener. Therefore, we sync access to Start and Close with
@@ -85,6 +87,12 @@ func New(clientCtx client.Context, logger log.Logger) *Server {
 	}
 }
 
+// SetQueryLimiter makes the API server reject the requests over the limits of
+// limiter, which may be shared with the gRPC server.
+func (s *Server) SetQueryLimiter(limiter *ratelimit.Limiter) {
+	s.limiter = limiter
+}
+
 // Start starts the API server. Internally, the API server leverages Tendermint's
 // JSON RPC server. Configuration options are provided via config.APIConfig
 // and are delegated to the Tendermint JSON RPC server. The process is
@@ -112,6 +120,9 @@ func (s *Server) Start(cfg config.Config, apiMetrics *telemetry.Metrics) error {
 
 	s.listener = listener
 	var h http.Handler = s.Router
+	if s.limiter != nil {
+		h = s.limiter.Middleware(h)
+	}
 
 	if cfg.API.EnableUnsafeCORS {
 		allowAllCORS := handlers.CORS(handlers.AllowedHeaders([]string{"Content-Type"}))
@@ -121,7 +132,7 @@ func (s *Server) Start(cfg config.Config, apiMetrics *telemetry.Metrics) error {
 
 	s.logger.Info("starting API server...")
 	s.mtx.Unlock()
-	return tmrpcserver.Serve(context.Background(), s.listener, s.Router, s.logger, tmCfg)
+	return tmrpcserver.Serve(context.Background(), s.listener, h, s.logger, tmCfg)
 }
 
 // Close closes the API server.
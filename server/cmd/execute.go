@@ -28,7 +28,7 @@ func Execute(rootCmd *cobra.Command, defaultHome string) error {
 	ctx = context.WithValue(ctx, server.ServerContextKey, srvCtx)
 
 	// Config.toml not yet initialized so we can't use the config file to set
-	rootCmd.PersistentFlags().String(flags.FlagLogLevel, "", "The logging level (trace|debug|info|warn|error|fatal|panic)")
+	rootCmd.PersistentFlags().String(flags.FlagLogLevel, "", "The logging level (trace|debug|info|warn|error|fatal|panic), optionally with levels by module, e.g. info,x/bank:error,tasks:debug")
 	rootCmd.PersistentFlags().String(flags.FlagLogFormat, "", "The logging format (json|plain)")
 
 	executor := tmcli.PrepareBaseCmd(rootCmd, "", defaultHome)
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"io/fs"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+)
+
+const flagCompactDryRun = "dry-run"
+
+// compactableDB is implemented by the backends that can compact a key range,
+// namely goleveldb and pebbledb.
+type compactableDB interface {
+	ForceCompact(start, limit []byte) error
+}
+
+// CompactAppDBCmd returns a command that deletes the orphaned IAVL nodes of the
+// application database and compacts it.
+func CompactAppDBCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "compact-app-db",
+		Aliases: []string{"gc"},
+		Short:   "Delete the orphaned IAVL nodes of the application database and compact it",
+		Long: `Delete the IAVL nodes that no version of the application state references any
+more, along with their orphan records, then compact the application database so
+that the backend reclaims their space. Such nodes are left behind when pruning
+is interrupted or misses orphans, and nothing deletes them afterwards.
+
+The hashes of the nodes of every version kept are held in memory while the
+garbage is collected. Nothing is deleted from a store if one of its versions
+references a missing node.
+
+The node must be stopped while the database is compacted.
+`,
+		Example: "compact-app-db --home ~/.sei --dry-run",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := GetServerContextFromCmd(cmd)
+			if err := serverCtx.Viper.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			home := serverCtx.Viper.GetString(flags.FlagHome)
+			dryRun := serverCtx.Viper.GetBool(flagCompactDryRun)
+			dbDir := filepath.Join(home, "data", "application.db")
+
+			sizeBefore, err := dirSize(dbDir)
+			if err != nil {
+				return err
+			}
+			db, err := openDB(home, GetAppDBBackend(serverCtx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			stats, err := rootmulti.CollectGarbage(db, dryRun)
+			var orphaned, staleOrphans, garbageBytes int64
+			for _, s := range stats {
+				cmd.Printf("%s: %d versions, %d reachable nodes, %d orphaned nodes, %d stale orphan records, %d bytes\n",
+					s.Name, s.Versions, s.ReachableNodes, s.OrphanedNodes, s.StaleOrphans, s.Bytes)
+				orphaned += s.OrphanedNodes
+				staleOrphans += s.StaleOrphans
+				garbageBytes += s.Bytes
+			}
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				cmd.Printf("found %d orphaned nodes and %d stale orphan records (%d bytes), nothing was deleted\n", orphaned, staleOrphans, garbageBytes)
+				return nil
+			}
+			cmd.Printf("deleted %d orphaned nodes and %d stale orphan records (%d bytes)\n", orphaned, staleOrphans, garbageBytes)
+
+			compactable, ok := db.(compactableDB)
+			if !ok {
+				cmd.Printf("the %T backend cannot be compacted, its space is reclaimed by its own compactions\n", db)
+				return nil
+			}
+			if err := compactable.ForceCompact(nil, nil); err != nil {
+				return err
+			}
+			sizeAfter, err := dirSize(dbDir)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("compacted the database from %d to %d bytes, reclaiming %d bytes\n", sizeBefore, sizeAfter, sizeBefore-sizeAfter)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(FlagAppDBBackend, "", "The backend of the database to compact, defaults to the compile-time default")
+	cmd.Flags().Bool(flagCompactDryRun, false, "Only report the garbage found, without deleting it nor compacting the database")
+	return cmd
+}
+
+// dirSize returns the size of the files in dir and its subdirectories.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
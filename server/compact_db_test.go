@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestCompactAppDBCmd(t *testing.T) {
+	for _, backend := range []dbm.BackendType{dbm.GoLevelDBBackend, pebbledb.BackendType} {
+		t.Run(string(backend), func(t *testing.T) {
+			home := t.TempDir()
+			db, err := openDB(home, backend)
+			require.NoError(t, err)
+			ms := rootmulti.NewStore(db, log.NewNopLogger())
+			key := types.NewKVStoreKey("bank")
+			ms.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+			require.NoError(t, ms.LoadLatestVersion())
+			for i := 0; i < 3; i++ {
+				ms.GetKVStore(key).Set([]byte("key"), []byte{byte(i)})
+				ms.Commit(true)
+			}
+			// an unreachable node
+			require.NoError(t, db.Set(append([]byte("s/k:bank/n"), make([]byte, 32)...), []byte("garbage")))
+			require.NoError(t, db.Close())
+
+			ctx := context.WithValue(context.Background(), ServerContextKey, NewDefaultContext())
+			run := func(args ...string) string {
+				cmd := CompactAppDBCmd(home)
+				out := new(bytes.Buffer)
+				cmd.SetOut(out)
+				cmd.SetArgs(append(args, "--"+FlagAppDBBackend, string(backend)))
+				require.NoError(t, cmd.ExecuteContext(ctx))
+				return out.String()
+			}
+
+			out := run("--dry-run")
+			require.Contains(t, out, "bank: 3 versions")
+			require.Contains(t, out, "found 1 orphaned nodes")
+			require.NotContains(t, out, "compacted")
+
+			out = run()
+			require.Contains(t, out, "deleted 1 orphaned nodes")
+			require.Contains(t, out, "compacted the database")
+
+			out = run()
+			require.Contains(t, out, "deleted 0 orphaned nodes")
+		})
+	}
+}
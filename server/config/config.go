@@ -2,10 +2,13 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -35,6 +38,14 @@ type BaseConfig struct {
 	PruningKeepEvery  string `mapstructure:"pruning-keep-every"`
 	PruningInterval   string `mapstructure:"pruning-interval"`
 
+	// PruningBackgroundInterval sets (in seconds) the interval between runs of
+	// the background pruner. A value of 0 prunes in the commit path instead.
+	PruningBackgroundInterval uint64 `mapstructure:"pruning-background-interval"`
+
+	// PruningBatchSize is the maximum number of heights the background pruner
+	// deletes per run. A value of 0 means no limit.
+	PruningBatchSize int `mapstructure:"pruning-batch-size"`
+
 	// HaltHeight contains a non-zero block height at which a node will gracefully
 	// halt and shutdown that can be used to assist upgrades and testing.
 	//
@@ -67,6 +78,10 @@ type BaseConfig struct {
 	// InterBlockCache enables inter-block caching.
 	InterBlockCache bool `mapstructure:"inter-block-cache"`
 
+	// InterBlockCacheSize is the size in bytes of the inter-block cache of each
+	// store. A value of 0 uses the default size.
+	InterBlockCacheSize uint64 `mapstructure:"inter-block-cache-size"`
+
 	// IndexEvents defines the set of events in the form {eventType}.{attributeKey},
 	// which informs Tendermint what to index. If empty, all events will be indexed.
 	IndexEvents []string `mapstructure:"index-events"`
@@ -77,10 +92,141 @@ type BaseConfig struct {
 	// IAVLDisableFastNode enables or disables the fast sync node.
 	IAVLDisableFastNode bool `mapstructure:"iavl-disable-fastnode"`
 
+	// IAVLFastNodeMigration is auto to migrate the IAVL stores to fast nodes
+	// when they are loaded, defer to load the stores not migrated yet without
+	// their fast nodes, or force to rebuild the fast nodes of every store.
+	IAVLFastNodeMigration string `mapstructure:"iavl-fastnode-migration"`
+
+	// IAVLFastNodeMigrationRate bounds the number of fast nodes written per
+	// second by a migration, 0 leaving it unbounded.
+	IAVLFastNodeMigrationRate int `mapstructure:"iavl-fastnode-migration-rate"`
+
 	// CompactionInterval sets (in seconds) the interval between forced levelDB
 	// compaction. A value of 0 means no forced levelDB
 	CompactionInterval uint64 `mapstructure:"compaction-interval"`
 
+	// AppDBBackend defines the type of database for the application and
+	// snapshots databases. An empty string means the compile-time default.
+	AppDBBackend string `mapstructure:"app-db-backend"`
+
+	// AsyncCommitBuffer is the number of committed versions that may wait to be
+	// written to the application database. A value of 0 makes commits
+	// synchronous.
+	AsyncCommitBuffer int `mapstructure:"async-commit-buffer"`
+
+	// PipelinedCommit makes Commit reset the check state from the writes of the
+	// committed block and return, the block being written to the stores while
+	// CheckTx resumes.
+	PipelinedCommit bool `mapstructure:"pipelined-commit"`
+
+	// HistoricalStoreCacheSize is the number of past heights whose stores are
+	// kept open for queries once loaded. A value of 0 loads the stores of the
+	// queried height for every query.
+	HistoricalStoreCacheSize int `mapstructure:"historical-store-cache-size"`
+
+	// OccEnabled makes the txs of a block delivered by DeliverTxBatch execute
+	// concurrently, with optimistic concurrency control.
+	OccEnabled bool `mapstructure:"occ-enabled"`
+
+	// ConcurrencyWorkers is the number of workers executing the txs of a block
+	// when OccEnabled is set. A value of 0 uses one worker per CPU, adapting how
+	// many of them a block uses to its conflict rate.
+	ConcurrencyWorkers int `mapstructure:"concurrency-workers"`
+
+	// OptimisticProcessing makes the block of an accepted proposal execute
+	// while consensus votes on it, its results being discarded if another
+	// block is committed.
+	OptimisticProcessing bool `mapstructure:"optimistic-processing"`
+
+	// ConcurrentCheckTx makes CheckTx run concurrently for txs that do not
+	// share a sender, rather than one tx at a time.
+	ConcurrentCheckTx bool `mapstructure:"concurrent-check-tx"`
+
+	// SignatureCacheSize is the number of signatures verified by CheckTx that
+	// are kept for DeliverTx not to verify them again, 0 disabling the cache.
+	SignatureCacheSize int `mapstructure:"signature-cache-size"`
+
+	// PriorityMempool makes the proposals order the txs of the mempool by
+	// priority, keeping the txs of a sender in nonce order.
+	PriorityMempool bool `mapstructure:"priority-mempool"`
+
+	// MempoolMaxBytes bounds the total size of the txs of the priority
+	// mempool, 0 leaving it unbounded.
+	MempoolMaxBytes int64 `mapstructure:"mempool-max-bytes"`
+
+	// MempoolMaxTxsPerSender bounds the number of txs of a sender in the
+	// priority mempool, 0 leaving it unbounded.
+	MempoolMaxTxsPerSender int `mapstructure:"mempool-max-txs-per-sender"`
+
+	// MempoolMaxBytesPerSender bounds the total size of the txs of a sender in
+	// the priority mempool, 0 leaving it unbounded.
+	MempoolMaxBytesPerSender int64 `mapstructure:"mempool-max-bytes-per-sender"`
+
+	// MempoolTTLNumBlocks is the number of blocks after which a tx of the
+	// priority mempool fails its recheck, 0 disabling the expiration.
+	MempoolTTLNumBlocks uint64 `mapstructure:"mempool-ttl-num-blocks"`
+
+	// MempoolTTL is the time after which a tx of the priority mempool fails
+	// its recheck, 0 disabling the expiration.
+	MempoolTTL time.Duration `mapstructure:"mempool-ttl"`
+
+	// MempoolSequenceWindow lets CheckTx accept the txs of a sender signed
+	// with a sequence up to MempoolSequenceWindow-1 ahead of the sender's,
+	// with the priority mempool. 0 or 1 requires the exact sequence.
+	MempoolSequenceWindow uint64 `mapstructure:"mempool-sequence-window"`
+
+	// TxTracing enables the TraceTx endpoint of the tx service, which
+	// re-executes committed txs to trace them.
+	TxTracing bool `mapstructure:"tx-tracing"`
+
+	// TxAccessHintsCacheSize is the number of txs whose access operations,
+	// declared by their senders with the BroadcastTxWithAccessOps endpoint of
+	// the tx service, are kept for the scheduler. 0 disables the endpoint.
+	TxAccessHintsCacheSize int `mapstructure:"tx-access-hints-cache-size"`
+
+	// TxResourceEvents adds to the response of every delivered tx an event
+	// counting its store accesses and executions, see sdk.TxResources.
+	TxResourceEvents bool `mapstructure:"tx-resource-events"`
+
+	// MaxEventsPerTx and MaxEventAttributes bound the events of every tx and
+	// the attributes of every event, 0 meaning no limit.
+	MaxEventsPerTx     int `mapstructure:"max-events-per-tx"`
+	MaxEventAttributes int `mapstructure:"max-event-attributes"`
+
+	// SlowBlockProfileThreshold is the processing time, in milliseconds, past
+	// which the CPU and heap profiles of a block are written to
+	// SlowBlockProfileDir. 0 disables the profiles.
+	SlowBlockProfileThreshold uint64 `mapstructure:"slow-block-profile-threshold"`
+	SlowBlockProfileDir       string `mapstructure:"slow-block-profile-dir"`
+
+	// SerializeABCICalls makes the in-process Tendermint node make one ABCI
+	// call at a time, the queries waiting for the block being executed.
+	SerializeABCICalls bool `mapstructure:"serialize-abci-calls"`
+
+	// AnteDisabledDecorators names the decorators of the ante handler that
+	// CheckTx skips. The decorators verifying the signatures cannot be disabled.
+	AnteDisabledDecorators []string `mapstructure:"ante-disabled-decorators"`
+
+	// QueryGasLimit bounds the gas of the ABCI and gRPC queries, 0 leaving it
+	// unbounded, and QueryGasLimits overrides it per module, as module=limit.
+	QueryGasLimit  uint64   `mapstructure:"query-gas-limit"`
+	QueryGasLimits []string `mapstructure:"query-gas-limits"`
+	// MaxQueryDepth bounds the nesting of the queries made while handling a
+	// query, 0 leaving it unbounded.
+	MaxQueryDepth int `mapstructure:"max-query-depth"`
+
+	// ConfigReloadInterval is the interval at which the configuration files
+	// are checked for changes to the knobs that apply without a restart, 0
+	// only reloading them on SIGHUP.
+	ConfigReloadInterval time.Duration `mapstructure:"config-reload-interval"`
+
+	// ProposalTxBatching makes the proposer order the txs of its blocks in
+	// batches of txs with no conflicting declared resources, and
+	// ProposalMaxBatchSize bounds the size of the batches, 0 leaving it
+	// unbounded.
+	ProposalTxBatching   bool `mapstructure:"proposal-tx-batching"`
+	ProposalMaxBatchSize int  `mapstructure:"proposal-max-batch-size"`
+
 	// deprecated
 	NoVersioning bool `mapstructure:"no-versioning"`
 
@@ -151,6 +297,14 @@ type GRPCConfig struct {
 
 	// Address defines the API server to listen on
 	Address string `mapstructure:"address"`
+
+	// EnableReflection defines if the gRPC server reflection services should
+	// be registered.
+	EnableReflection bool `mapstructure:"enable-reflection"`
+
+	// EnableHealth defines if the grpc.health.v1 service should be registered,
+	// reporting the node as not serving while it catches up.
+	EnableHealth bool `mapstructure:"enable-health"`
 }
 
 // GRPCWebConfig defines configuration for the gRPC-web server.
@@ -165,6 +319,44 @@ type GRPCWebConfig struct {
 	EnableUnsafeCORS bool `mapstructure:"enable-unsafe-cors"`
 }
 
+// QueryLimitsConfig defines the limits on the calls served by the gRPC and API
+// servers, which keep a public node serving queries able to keep up with
+// consensus.
+type QueryLimitsConfig struct {
+	// MaxConcurrentQueries is the maximum number of calls the gRPC and API
+	// servers serve at once, together. 0 means no maximum.
+	MaxConcurrentQueries int `mapstructure:"max-concurrent-queries"`
+
+	// RateLimit is the number of calls per second each gRPC method and each
+	// API route accepts. 0 means no limit.
+	RateLimit float64 `mapstructure:"rate-limit"`
+
+	// RateBurst is the number of calls a method or route accepts at once. 0
+	// means its rate limit, rounded up.
+	RateBurst int `mapstructure:"rate-burst"`
+
+	// MethodRateLimits overrides the rate limit of gRPC methods, by full
+	// method name, and of API routes, by path prefix, as "<method>=<rate>".
+	MethodRateLimits []string `mapstructure:"method-rate-limits"`
+}
+
+// ParseMethodRateLimits returns the rate limits of MethodRateLimits by method.
+func (c QueryLimitsConfig) ParseMethodRateLimits() (map[string]float64, error) {
+	rates := make(map[string]float64, len(c.MethodRateLimits))
+	for _, methodRate := range c.MethodRateLimits {
+		i := strings.LastIndex(methodRate, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid method rate limit %q, expected <method>=<rate>", methodRate)
+		}
+		rate, err := strconv.ParseFloat(methodRate[i+1:], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate of method rate limit %q", methodRate)
+		}
+		rates[methodRate[:i]] = rate
+	}
+	return rates, nil
+}
+
 // StateSyncConfig defines the state sync snapshot configuration.
 type StateSyncConfig struct {
 	// SnapshotInterval sets the interval at which state sync snapshots are taken.
@@ -178,6 +370,83 @@ type StateSyncConfig struct {
 	// SnapshotDirectory sets the parent directory for where state sync snapshots are persisted.
 	// Default is emtpy which will then store under the app home directory.
 	SnapshotDirectory string `mapstructure:"snapshot-directory"`
+
+	// SnapshotCompression sets the compression of the snapshots taken, zlib or
+	// zstd. Snapshots of either compression can be restored.
+	SnapshotCompression string `mapstructure:"snapshot-compression"`
+
+	// SnapshotChunkSize sets the size in bytes of the chunks snapshots are
+	// split into. 0 uses the default size.
+	SnapshotChunkSize uint64 `mapstructure:"snapshot-chunk-size"`
+
+	// SnapshotProviders sets the URLs of the snapshots imported by the
+	// snapshot import command, in order of preference.
+	SnapshotProviders []string `mapstructure:"snapshot-providers"`
+}
+
+// StoreConfig defines the store configuration.
+type StoreConfig struct {
+	// Streamers lists the names of the streaming services writing out the state
+	// changes of every block, configured in the streamers section. Empty
+	// disables streaming.
+	Streamers []string `mapstructure:"streamers"`
+}
+
+// FileStreamerConfig defines the configuration of the file streaming service.
+type FileStreamerConfig struct {
+	// Keys lists the names of the stores to expose, "*" exposing all of them.
+	Keys     []string `mapstructure:"keys"`
+	WriteDir string   `mapstructure:"write_dir"`
+	Prefix   string   `mapstructure:"prefix"`
+}
+
+// GRPCStreamerConfig defines the configuration of the streaming service
+// delivering the change set of every block to a ChangeSetSink gRPC service.
+type GRPCStreamerConfig struct {
+	// Keys lists the names of the stores to expose, "*" exposing all of them.
+	Keys    []string `mapstructure:"keys"`
+	Address string   `mapstructure:"address"`
+	// Timeout is the time the service has to acknowledge a change set, e.g. "10s".
+	Timeout string `mapstructure:"timeout"`
+	// BufferSize is the number of committed blocks that may wait to be
+	// delivered before commits block.
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// KafkaStreamerConfig defines the configuration of the streaming service
+// publishing the change set of every block to a Kafka topic.
+type KafkaStreamerConfig struct {
+	// Keys lists the names of the stores to expose, "*" exposing all of them.
+	Keys    []string `mapstructure:"keys"`
+	Brokers []string `mapstructure:"brokers"`
+	Topic   string   `mapstructure:"topic"`
+	// BufferSize is the number of committed blocks that may wait to be
+	// published before commits block.
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// ParquetStreamerConfig defines the configuration of the streaming service
+// exporting the results of the blocks to Parquet files.
+type ParquetStreamerConfig struct {
+	// Keys lists the names of the stores whose changes are exported with
+	// StoreDeltas, "*" exposing all of them.
+	Keys     []string `mapstructure:"keys"`
+	WriteDir string   `mapstructure:"write_dir"`
+	// PartitionSize is the number of blocks exported to the same files.
+	PartitionSize int64 `mapstructure:"partition_size"`
+	// RowGroupSize is the number of rows of a table written at once.
+	RowGroupSize int `mapstructure:"row_group_size"`
+	// Compression is the codec of the files: none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+	StoreDeltas bool   `mapstructure:"store_deltas"`
+}
+
+// StreamersConfig defines the configuration of the streaming services.
+type StreamersConfig struct {
+	File    FileStreamerConfig    `mapstructure:"file"`
+	GRPC    GRPCStreamerConfig    `mapstructure:"grpc"`
+	Kafka   KafkaStreamerConfig   `mapstructure:"kafka"`
+	Parquet ParquetStreamerConfig `mapstructure:"parquet"`
 }
 
 // Config defines the server's top level configuration
@@ -185,12 +454,15 @@ type Config struct {
 	BaseConfig `mapstructure:",squash"`
 
 	// Telemetry defines the application telemetry configuration
-	Telemetry telemetry.Config `mapstructure:"telemetry"`
-	API       APIConfig        `mapstructure:"api"`
-	GRPC      GRPCConfig       `mapstructure:"grpc"`
-	Rosetta   RosettaConfig    `mapstructure:"rosetta"`
-	GRPCWeb   GRPCWebConfig    `mapstructure:"grpc-web"`
-	StateSync StateSyncConfig  `mapstructure:"state-sync"`
+	Telemetry   telemetry.Config  `mapstructure:"telemetry"`
+	API         APIConfig         `mapstructure:"api"`
+	GRPC        GRPCConfig        `mapstructure:"grpc"`
+	Rosetta     RosettaConfig     `mapstructure:"rosetta"`
+	GRPCWeb     GRPCWebConfig     `mapstructure:"grpc-web"`
+	QueryLimits QueryLimitsConfig `mapstructure:"query-limits"`
+	StateSync   StateSyncConfig   `mapstructure:"state-sync"`
+	Store       StoreConfig       `mapstructure:"store"`
+	Streamers   StreamersConfig   `mapstructure:"streamers"`
 }
 
 // SetMinGasPrices sets the validator's minimum gas prices.
@@ -224,18 +496,49 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
-			MinGasPrices:        defaultMinGasPrices,
-			InterBlockCache:     true,
-			Pruning:             storetypes.PruningOptionDefault,
-			PruningKeepRecent:   "0",
-			PruningKeepEvery:    "0",
-			PruningInterval:     "0",
-			MinRetainBlocks:     0,
-			IndexEvents:         make([]string, 0),
-			IAVLCacheSize:       781250, // 50 MB
-			IAVLDisableFastNode: true,
-			CompactionInterval:  0,
-			NoVersioning:        false,
+			MinGasPrices:              defaultMinGasPrices,
+			InterBlockCache:           true,
+			InterBlockCacheSize:       32 << 20, // 32 MB
+			Pruning:                   storetypes.PruningOptionDefault,
+			PruningKeepRecent:         "0",
+			PruningKeepEvery:          "0",
+			PruningInterval:           "0",
+			MinRetainBlocks:           0,
+			IndexEvents:               make([]string, 0),
+			IAVLCacheSize:             781250, // 50 MB
+			IAVLDisableFastNode:       true,
+			IAVLFastNodeMigration:     "auto",
+			IAVLFastNodeMigrationRate: 0,
+			CompactionInterval:        0,
+			NoVersioning:              false,
+			HistoricalStoreCacheSize:  10,
+			OccEnabled:                false,
+			ConcurrencyWorkers:        0,
+			OptimisticProcessing:      false,
+			ConcurrentCheckTx:         false,
+			SignatureCacheSize:        20000,
+			PriorityMempool:           false,
+			MempoolMaxBytes:           0,
+			MempoolMaxTxsPerSender:    0,
+			MempoolMaxBytesPerSender:  0,
+			MempoolTTLNumBlocks:       0,
+			MempoolTTL:                0,
+			MempoolSequenceWindow:     0,
+			TxTracing:                 false,
+			TxAccessHintsCacheSize:    0,
+			TxResourceEvents:          false,
+			MaxEventsPerTx:            0,
+			MaxEventAttributes:        0,
+			SlowBlockProfileThreshold: 0,
+			SlowBlockProfileDir:       "",
+			SerializeABCICalls:        false,
+			AnteDisabledDecorators:    make([]string, 0),
+			QueryGasLimit:             0,
+			QueryGasLimits:            make([]string, 0),
+			MaxQueryDepth:             10,
+			ConfigReloadInterval:      10 * time.Second,
+			ProposalTxBatching:        false,
+			ProposalMaxBatchSize:      0,
 		},
 		Telemetry: telemetry.Config{
 			Enabled:      false,
@@ -250,8 +553,10 @@ func DefaultConfig() *Config {
 			RPCMaxBodyBytes:    1000000,
 		},
 		GRPC: GRPCConfig{
-			Enable:  true,
-			Address: DefaultGRPCAddress,
+			Enable:           true,
+			Address:          DefaultGRPCAddress,
+			EnableReflection: true,
+			EnableHealth:     true,
 		},
 		Rosetta: RosettaConfig{
 			Enable:     false,
@@ -265,10 +570,49 @@ func DefaultConfig() *Config {
 			Enable:  true,
 			Address: DefaultGRPCWebAddress,
 		},
+		QueryLimits: QueryLimitsConfig{
+			MaxConcurrentQueries: 0,
+			RateLimit:            0,
+			RateBurst:            0,
+			MethodRateLimits:     []string{},
+		},
 		StateSync: StateSyncConfig{
-			SnapshotInterval:   0,
-			SnapshotKeepRecent: 2,
-			SnapshotDirectory:  "",
+			SnapshotInterval:    0,
+			SnapshotKeepRecent:  2,
+			SnapshotDirectory:   "",
+			SnapshotCompression: "zlib",
+			SnapshotChunkSize:   snapshottypes.DefaultChunkSize,
+			SnapshotProviders:   []string{},
+		},
+		Store: StoreConfig{
+			Streamers: []string{},
+		},
+		Streamers: StreamersConfig{
+			File: FileStreamerConfig{
+				Keys:     []string{"*"},
+				WriteDir: "",
+				Prefix:   "",
+			},
+			GRPC: GRPCStreamerConfig{
+				Keys:       []string{"*"},
+				Address:    "",
+				Timeout:    "10s",
+				BufferSize: 100,
+			},
+			Kafka: KafkaStreamerConfig{
+				Keys:       []string{"*"},
+				Brokers:    []string{},
+				Topic:      "",
+				BufferSize: 100,
+			},
+			Parquet: ParquetStreamerConfig{
+				Keys:          []string{"*"},
+				WriteDir:      "",
+				PartitionSize: 10000,
+				RowGroupSize:  100000,
+				Compression:   "snappy",
+				StoreDeltas:   false,
+			},
 		},
 	}
 }
@@ -295,16 +639,52 @@ func GetConfig(v *viper.Viper) (Config, error) {
 		BaseConfig: BaseConfig{
 			MinGasPrices:                 v.GetString("minimum-gas-prices"),
 			InterBlockCache:              v.GetBool("inter-block-cache"),
+			InterBlockCacheSize:          v.GetUint64("inter-block-cache-size"),
 			Pruning:                      v.GetString("pruning"),
 			PruningKeepRecent:            v.GetString("pruning-keep-recent"),
 			PruningInterval:              v.GetString("pruning-interval"),
+			PruningBackgroundInterval:    v.GetUint64("pruning-background-interval"),
+			PruningBatchSize:             v.GetInt("pruning-batch-size"),
 			HaltHeight:                   v.GetUint64("halt-height"),
 			HaltTime:                     v.GetUint64("halt-time"),
 			IndexEvents:                  v.GetStringSlice("index-events"),
 			MinRetainBlocks:              v.GetUint64("min-retain-blocks"),
 			IAVLCacheSize:                v.GetUint64("iavl-cache-size"),
 			IAVLDisableFastNode:          v.GetBool("iavl-disable-fastnode"),
+			IAVLFastNodeMigration:        v.GetString("iavl-fastnode-migration"),
+			IAVLFastNodeMigrationRate:    v.GetInt("iavl-fastnode-migration-rate"),
 			CompactionInterval:           v.GetUint64("compaction-interval"),
+			AppDBBackend:                 v.GetString("app-db-backend"),
+			AsyncCommitBuffer:            v.GetInt("async-commit-buffer"),
+			PipelinedCommit:              v.GetBool("pipelined-commit"),
+			HistoricalStoreCacheSize:     v.GetInt("historical-store-cache-size"),
+			OccEnabled:                   v.GetBool("occ-enabled"),
+			ConcurrencyWorkers:           v.GetInt("concurrency-workers"),
+			OptimisticProcessing:         v.GetBool("optimistic-processing"),
+			ConcurrentCheckTx:            v.GetBool("concurrent-check-tx"),
+			SignatureCacheSize:           v.GetInt("signature-cache-size"),
+			PriorityMempool:              v.GetBool("priority-mempool"),
+			MempoolMaxBytes:              v.GetInt64("mempool-max-bytes"),
+			MempoolMaxTxsPerSender:       v.GetInt("mempool-max-txs-per-sender"),
+			MempoolMaxBytesPerSender:     v.GetInt64("mempool-max-bytes-per-sender"),
+			MempoolTTLNumBlocks:          v.GetUint64("mempool-ttl-num-blocks"),
+			MempoolTTL:                   v.GetDuration("mempool-ttl"),
+			MempoolSequenceWindow:        v.GetUint64("mempool-sequence-window"),
+			TxTracing:                    v.GetBool("tx-tracing"),
+			TxAccessHintsCacheSize:       v.GetInt("tx-access-hints-cache-size"),
+			TxResourceEvents:             v.GetBool("tx-resource-events"),
+			MaxEventsPerTx:               v.GetInt("max-events-per-tx"),
+			MaxEventAttributes:           v.GetInt("max-event-attributes"),
+			SlowBlockProfileThreshold:    v.GetUint64("slow-block-profile-threshold"),
+			SlowBlockProfileDir:          v.GetString("slow-block-profile-dir"),
+			SerializeABCICalls:           v.GetBool("serialize-abci-calls"),
+			AnteDisabledDecorators:       v.GetStringSlice("ante-disabled-decorators"),
+			QueryGasLimit:                v.GetUint64("query-gas-limit"),
+			QueryGasLimits:               v.GetStringSlice("query-gas-limits"),
+			MaxQueryDepth:                v.GetInt("max-query-depth"),
+			ConfigReloadInterval:         v.GetDuration("config-reload-interval"),
+			ProposalTxBatching:           v.GetBool("proposal-tx-batching"),
+			ProposalMaxBatchSize:         v.GetInt("proposal-max-batch-size"),
 			NoVersioning:                 v.GetBool("no-versioning"),
 			SeparateOrphanStorage:        v.GetBool("separate-orphan-storage"),
 			SeparateOrphanVersionsToKeep: v.GetInt64("separate-orphan-versions-to-keep"),
@@ -341,20 +721,71 @@ func GetConfig(v *viper.Viper) (Config, error) {
 		GRPC: GRPCConfig{
 			Enable:  v.GetBool("grpc.enable"),
 			Address: v.GetString("grpc.address"),
+			// the services were registered before they could be disabled
+			EnableReflection: getBoolOrDefault(v, "grpc.enable-reflection", true),
+			EnableHealth:     getBoolOrDefault(v, "grpc.enable-health", true),
 		},
 		GRPCWeb: GRPCWebConfig{
 			Enable:           v.GetBool("grpc-web.enable"),
 			Address:          v.GetString("grpc-web.address"),
 			EnableUnsafeCORS: v.GetBool("grpc-web.enable-unsafe-cors"),
 		},
+		QueryLimits: QueryLimitsConfig{
+			MaxConcurrentQueries: v.GetInt("query-limits.max-concurrent-queries"),
+			RateLimit:            v.GetFloat64("query-limits.rate-limit"),
+			RateBurst:            v.GetInt("query-limits.rate-burst"),
+			MethodRateLimits:     v.GetStringSlice("query-limits.method-rate-limits"),
+		},
 		StateSync: StateSyncConfig{
-			SnapshotInterval:   v.GetUint64("state-sync.snapshot-interval"),
-			SnapshotKeepRecent: v.GetUint32("state-sync.snapshot-keep-recent"),
-			SnapshotDirectory:  v.GetString("state-sync.snapshot-directory"),
+			SnapshotInterval:    v.GetUint64("state-sync.snapshot-interval"),
+			SnapshotKeepRecent:  v.GetUint32("state-sync.snapshot-keep-recent"),
+			SnapshotDirectory:   v.GetString("state-sync.snapshot-directory"),
+			SnapshotCompression: v.GetString("state-sync.snapshot-compression"),
+			SnapshotChunkSize:   v.GetUint64("state-sync.snapshot-chunk-size"),
+			SnapshotProviders:   v.GetStringSlice("state-sync.snapshot-providers"),
+		},
+		Store: StoreConfig{
+			Streamers: v.GetStringSlice("store.streamers"),
+		},
+		Streamers: StreamersConfig{
+			File: FileStreamerConfig{
+				Keys:     v.GetStringSlice("streamers.file.keys"),
+				WriteDir: v.GetString("streamers.file.write_dir"),
+				Prefix:   v.GetString("streamers.file.prefix"),
+			},
+			GRPC: GRPCStreamerConfig{
+				Keys:       v.GetStringSlice("streamers.grpc.keys"),
+				Address:    v.GetString("streamers.grpc.address"),
+				Timeout:    v.GetString("streamers.grpc.timeout"),
+				BufferSize: v.GetInt("streamers.grpc.buffer_size"),
+			},
+			Kafka: KafkaStreamerConfig{
+				Keys:       v.GetStringSlice("streamers.kafka.keys"),
+				Brokers:    v.GetStringSlice("streamers.kafka.brokers"),
+				Topic:      v.GetString("streamers.kafka.topic"),
+				BufferSize: v.GetInt("streamers.kafka.buffer_size"),
+			},
+			Parquet: ParquetStreamerConfig{
+				Keys:          v.GetStringSlice("streamers.parquet.keys"),
+				WriteDir:      v.GetString("streamers.parquet.write_dir"),
+				PartitionSize: v.GetInt64("streamers.parquet.partition_size"),
+				RowGroupSize:  v.GetInt("streamers.parquet.row_group_size"),
+				Compression:   v.GetString("streamers.parquet.compression"),
+				StoreDeltas:   v.GetBool("streamers.parquet.store_deltas"),
+			},
 		},
 	}, nil
 }
 
+// getBoolOrDefault returns the boolean of key, or def if it is not set, e.g.
+// in an app.toml written before the option existed.
+func getBoolOrDefault(v *viper.Viper, key string, def bool) bool {
+	if !v.IsSet(key) {
+		return def
+	}
+	return v.GetBool(key)
+}
+
 // ValidateBasic returns an error if min-gas-prices field is empty in BaseConfig. Otherwise, it returns nil.
 func (c Config) ValidateBasic(tendermintConfig *tmcfg.Config) error {
 	if c.BaseConfig.MinGasPrices == "" {
@@ -365,6 +796,12 @@ func (c Config) ValidateBasic(tendermintConfig *tmcfg.Config) error {
 			"cannot enable state sync snapshots with '%s' pruning setting", storetypes.PruningOptionEverything,
 		)
 	}
+	if c.QueryLimits.MaxConcurrentQueries < 0 || c.QueryLimits.RateLimit < 0 || c.QueryLimits.RateBurst < 0 {
+		return sdkerrors.ErrAppConfig.Wrap("query limits cannot be negative")
+	}
+	if _, err := c.QueryLimits.ParseMethodRateLimits(); err != nil {
+		return sdkerrors.ErrAppConfig.Wrap(err.Error())
+	}
 
 	return nil
 }
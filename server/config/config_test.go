@@ -1,8 +1,10 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -23,3 +25,74 @@ func TestSetSnapshotDirectory(t *testing.T) {
 	cfg := DefaultConfig()
 	require.Equal(t, "", cfg.StateSync.SnapshotDirectory)
 }
+
+func TestStreamersConfigRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Store.Streamers = []string{"grpc", "kafka", "parquet"}
+	cfg.Streamers.GRPC.Address = "localhost:9095"
+	cfg.Streamers.Kafka.Brokers = []string{"broker1:9092", "broker2:9092"}
+	cfg.Streamers.Kafka.Topic = "changes"
+	cfg.Streamers.Parquet.WriteDir = "/data/parquet"
+	cfg.Streamers.Parquet.StoreDeltas = true
+
+	path := filepath.Join(t.TempDir(), "app.toml")
+	WriteConfigFile(path, cfg)
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+
+	parsed, err := GetConfig(v)
+	require.NoError(t, err)
+	require.Equal(t, cfg.Store, parsed.Store)
+	require.Equal(t, cfg.Streamers, parsed.Streamers)
+}
+
+func TestQueryLimitsConfigRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.QueryLimits = QueryLimitsConfig{
+		MaxConcurrentQueries: 64,
+		RateLimit:            2.5,
+		RateBurst:            10,
+		MethodRateLimits:     []string{"/cosmos.bank.v1beta1.Query/AllBalances=1", "/cosmos/bank/v1beta1/balances=0"},
+	}
+
+	path := filepath.Join(t.TempDir(), "app.toml")
+	WriteConfigFile(path, cfg)
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+
+	parsed, err := GetConfig(v)
+	require.NoError(t, err)
+	require.Equal(t, cfg.QueryLimits, parsed.QueryLimits)
+	rates, err := parsed.QueryLimits.ParseMethodRateLimits()
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{
+		"/cosmos.bank.v1beta1.Query/AllBalances": 1,
+		"/cosmos/bank/v1beta1/balances":          0,
+	}, rates)
+}
+
+func TestParseMethodRateLimitsInvalid(t *testing.T) {
+	for _, methodRate := range []string{"/cosmos/bank", "=1", "/cosmos/bank=fast", "/cosmos/bank=-1"} {
+		_, err := QueryLimitsConfig{MethodRateLimits: []string{methodRate}}.ParseMethodRateLimits()
+		require.Error(t, err, methodRate)
+	}
+}
+
+func TestGRPCServicesEnabledByDefault(t *testing.T) {
+	// an app.toml written before the services could be disabled
+	v := viper.New()
+	v.Set("telemetry.global-labels", []interface{}{})
+	cfg, err := GetConfig(v)
+	require.NoError(t, err)
+	require.True(t, cfg.GRPC.EnableReflection)
+	require.True(t, cfg.GRPC.EnableHealth)
+
+	v.Set("grpc.enable-reflection", false)
+	v.Set("grpc.enable-health", false)
+	cfg, err = GetConfig(v)
+	require.NoError(t, err)
+	require.False(t, cfg.GRPC.EnableReflection)
+	require.False(t, cfg.GRPC.EnableHealth)
+}
@@ -33,6 +33,16 @@ pruning-keep-recent = "{{ .BaseConfig.PruningKeepRecent }}"
 pruning-keep-every = "{{ .BaseConfig.PruningKeepEvery }}"
 pruning-interval = "{{ .BaseConfig.PruningInterval }}"
 
+# PruningBackgroundInterval sets (in seconds) the interval between runs of a
+# background pruner deleting the pruned heights, instead of deleting them during
+# commit which delays the next block. Heights being exported to a state sync
+# snapshot are left for a later run. Default is 0, which prunes during commit.
+pruning-background-interval = {{ .BaseConfig.PruningBackgroundInterval }}
+
+# PruningBatchSize is the maximum number of heights the background pruner
+# deletes per run, 0 meaning no limit.
+pruning-batch-size = {{ .BaseConfig.PruningBatchSize }}
+
 # HaltHeight contains a non-zero block height at which a node will gracefully
 # halt and shutdown that can be used to assist upgrades and testing.
 #
@@ -65,6 +75,11 @@ min-retain-blocks = {{ .BaseConfig.MinRetainBlocks }}
 # InterBlockCache enables inter-block caching.
 inter-block-cache = {{ .BaseConfig.InterBlockCache }}
 
+# InterBlockCacheSize is the size in bytes of the inter-block cache of each store, the
+# least recently read keys being evicted first. Its hit rate is reported per store by
+# the store_inter_block_cache_hit_rate metric. Default is 32 MB, 0 uses the default.
+inter-block-cache-size = {{ .BaseConfig.InterBlockCacheSize }}
+
 # IndexEvents defines the set of events in the form {eventType}.{attributeKey},
 # which informs Tendermint what to index. If empty, all events will be indexed.
 #
@@ -80,11 +95,193 @@ iavl-cache-size = {{ .BaseConfig.IAVLCacheSize }}
 # Default is true.
 iavl-disable-fastnode = {{ .BaseConfig.IAVLDisableFastNode }}
 
+# IAVLFastNodeMigration sets how the IAVL stores whose fast nodes are missing or out of date,
+# e.g. after enabling them or running a binary with them disabled, are migrated on startup:
+# - auto: migrate them, logging the progress and resuming an interrupted migration
+# - defer: run the stores not migrated yet without their fast nodes, e.g. to upgrade first
+# - force: rebuild the fast nodes of every store, even up to date ones
+# The migration of a large store may take hours, it has no effect if the fast nodes are disabled.
+iavl-fastnode-migration = "{{ .BaseConfig.IAVLFastNodeMigration }}"
+
+# IAVLFastNodeMigrationRate bounds the number of fast nodes written per second by a migration,
+# so that it leaves disk bandwidth to the other processes of the host. 0 leaves it unbounded.
+iavl-fastnode-migration-rate = {{ .BaseConfig.IAVLFastNodeMigrationRate }}
+
 # CompactionInterval sets (in seconds) the interval between forced levelDB
 # compaction. A value of 0 means no forced levelDB.
 # Default is 0.
 compaction-interval = {{ .BaseConfig.CompactionInterval }}
 
+# AppDBBackend defines the database backend type to use for the application and
+# snapshots DBs. An empty string indicates that the default backend should be
+# used, goleveldb unless another one was set at compile time.
+# Valid options: goleveldb, cleveldb, rocksdb, boltdb, badgerdb, pebbledb.
+# Changing the backend of an existing node requires migrating its application
+# database first, see the migrate-app-db command.
+app-db-backend = "{{ .BaseConfig.AppDBBackend }}"
+
+# AsyncCommitBuffer is the number of committed versions that may wait to be
+# written to the application database. Commits return as soon as the version is
+# in memory, and block once this many versions are waiting. After a crash, the
+# blocks whose versions were not written yet are replayed on restart.
+# Default is 0, which makes commits write to the database synchronously.
+async-commit-buffer = {{ .BaseConfig.AsyncCommitBuffer }}
+
+# PipelinedCommit makes commits return once the mempool state is reset from the writes
+# of the committed block, the app hash of the block being computed and the block being
+# written to disk while CheckTx resumes. The next block waits for the commit to complete.
+# The mempool state reads the previous version, which the pruning and orphan settings
+# must keep, i.e. keep at least one version besides the latest.
+pipelined-commit = {{ .BaseConfig.PipelinedCommit }}
+
+# HistoricalStoreCacheSize is the number of past heights whose stores are kept open
+# once queried, e.g. by gRPC queries with a height header, the least recently queried
+# height being closed to open another one. Queries at an open height share its stores
+# rather than loading them again while contending with commits.
+# Default is 10, 0 loads the stores of the queried height for every query.
+historical-store-cache-size = {{ .BaseConfig.HistoricalStoreCacheSize }}
+
+# OccEnabled makes the txs of a block execute concurrently, with optimistic concurrency
+# control: txs whose reads were invalidated by lower-indexed txs are re-executed, so that
+# the block yields the same state as sequential execution. If the concurrent execution
+# of a block fails, its txs are executed sequentially instead.
+occ-enabled = {{ .BaseConfig.OccEnabled }}
+
+# ConcurrencyWorkers is the number of workers executing the txs of a block when
+# occ-enabled is set. Default is 0, which uses one worker per CPU and adapts how many
+# of them a block uses to its conflict rate.
+concurrency-workers = {{ .BaseConfig.ConcurrencyWorkers }}
+
+# OptimisticProcessing makes the block of an accepted proposal execute while consensus
+# votes on it, so that it is mostly executed by the time it is finalized. The results are
+# discarded if another block is finalized, which is then executed from scratch.
+optimistic-processing = {{ .BaseConfig.OptimisticProcessing }}
+
+# ConcurrentCheckTx makes CheckTx run concurrently for txs that do not share a signer or
+# fee payer, rather than one tx at a time. The txs of the same sender are still checked
+# one at a time, so that their sequences are checked in order.
+concurrent-check-tx = {{ .BaseConfig.ConcurrentCheckTx }}
+
+# SignatureCacheSize is the number of signatures verified by CheckTx that are kept, so that
+# the txs of the local mempool do not have their signatures verified again once they are
+# delivered. The signatures are evicted once their txs are committed, or oldest first when
+# the cache is full. 0 disables the cache.
+signature-cache-size = {{ .BaseConfig.SignatureCacheSize }}
+
+# PriorityMempool makes the proposals order the txs of the mempool by priority, i.e. by fee
+# unless the ante handler sets another priority, rather than in arrival order. The txs of a
+# sender are still proposed in nonce order, and a tx signed with the sequence of a pending tx
+# of its sender replaces it if its priority is higher. It relies on Tendermint rechecking
+# its mempool.
+priority-mempool = {{ .BaseConfig.PriorityMempool }}
+
+# MempoolMaxBytes bounds the total size of the txs of the priority mempool, CheckTx rejecting
+# the txs that would exceed it. 0 leaves it unbounded.
+mempool-max-bytes = {{ .BaseConfig.MempoolMaxBytes }}
+
+# MempoolMaxTxsPerSender bounds the number of txs of a sender in the priority mempool,
+# CheckTx rejecting the txs that would exceed it. 0 leaves it unbounded.
+mempool-max-txs-per-sender = {{ .BaseConfig.MempoolMaxTxsPerSender }}
+
+# MempoolMaxBytesPerSender bounds the total size of the txs of a sender in the priority
+# mempool, CheckTx rejecting the txs that would exceed it, so that a single account cannot
+# fill the mempool. 0 leaves it unbounded.
+mempool-max-bytes-per-sender = {{ .BaseConfig.MempoolMaxBytesPerSender }}
+
+# MempoolTTLNumBlocks is the number of blocks after which a tx of the priority mempool fails
+# its recheck and is evicted. 0 keeps the txs however many blocks are committed.
+mempool-ttl-num-blocks = {{ .BaseConfig.MempoolTTLNumBlocks }}
+
+# MempoolTTL is the time, e.g. "10m", after which a tx of the priority mempool fails its
+# recheck and is evicted. "0s" keeps the txs however long they wait.
+mempool-ttl = "{{ .BaseConfig.MempoolTTL }}"
+
+# MempoolSequenceWindow lets CheckTx accept the txs of a sender signed with a sequence up to
+# MempoolSequenceWindow-1 ahead of the sequence of the sender, with the priority mempool, so
+# that the consecutive txs of a sender may reach the node out of order. Such a tx waits in
+# the mempool until the txs preceding it arrive, and is then proposed after them in the same
+# block. 0 or 1 requires the exact sequence.
+mempool-sequence-window = {{ .BaseConfig.MempoolSequenceWindow }}
+
+# TxTracing enables the TraceTx endpoint of the tx service, which re-executes a committed tx
+# on the state of its block to report the gas, store accesses and events of its ante handler
+# and of each of its messages. Every request replays the txs preceding the traced one in its
+# block, so it should not be enabled on public nodes. The state of the height before the tx
+# must not be pruned.
+tx-tracing = {{ .BaseConfig.TxTracing }}
+
+# TxAccessHintsCacheSize enables the BroadcastTxWithAccessOps endpoint of the tx service, which
+# broadcasts a tx along with the access operations its sender declares, once its simulation
+# shows they cover the state it accesses. The scheduler of the node then places the tx in its
+# block from them. Every request simulates its tx, so it should not be enabled on public nodes.
+# It is the number of txs whose access operations are kept. 0 disables the endpoint.
+tx-access-hints-cache-size = {{ .BaseConfig.TxAccessHintsCacheSize }}
+
+# TxResourceEvents adds to the response of every delivered tx a tx_resources event with the
+# number of keys it read and wrote, the bytes it wrote, and the number of times the scheduler
+# executed it in its block. The counts are those of the execution of the tx that the block
+# kept. The events are not part of consensus, and the incarnations of a tx may differ from a
+# node to another.
+tx-resource-events = {{ .BaseConfig.TxResourceEvents }}
+
+# MaxEventsPerTx and MaxEventAttributes bound the events a tx keeps and the attributes kept per
+# event (0 for no limit), so that a tx emitting a huge number of events cannot exhaust the memory
+# of the node. The events and attributes past the limits are dropped as they are emitted, and the
+# tx reports how many were with an events_truncated event. The events are not part of consensus.
+max-events-per-tx = {{ .BaseConfig.MaxEventsPerTx }}
+max-event-attributes = {{ .BaseConfig.MaxEventAttributes }}
+
+# SlowBlockProfileThreshold is the processing time, in milliseconds, from FinalizeBlock to the
+# end of Commit, past which a block has its CPU profile captured until its end and its heap
+# profile captured at its end (0 to disable). The CPU profile cannot be captured while another
+# one, e.g. of the pprof server, is running.
+slow-block-profile-threshold = {{ .BaseConfig.SlowBlockProfileThreshold }}
+
+# SlowBlockProfileDir is the directory the profiles of the slow blocks are written to, named
+# after the height of their block, defaulting to <home>/data/profiles.
+slow-block-profile-dir = "{{ .BaseConfig.SlowBlockProfileDir }}"
+
+# SerializeABCICalls makes the in-process Tendermint node make one ABCI call at a time, as the
+# upstream local ABCI client does. By default the queries are served from the committed state
+# concurrently with the other calls, so that heavy query traffic cannot delay the blocks.
+serialize-abci-calls = {{ .BaseConfig.SerializeABCICalls }}
+
+# AnteDisabledDecorators names the decorators of the ante handler, e.g. "validate_memo", that
+# CheckTx skips, trading the checks of the mempool for its latency. DeliverTx runs all of them.
+# The ante.decorator.time and ante.decorator.gas telemetry samples, labeled by decorator, tell
+# which ones dominate CheckTx. The decorators verifying the signatures cannot be disabled.
+ante-disabled-decorators = [{{ range $i, $v := .BaseConfig.AnteDisabledDecorators }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+
+# QueryGasLimit bounds the gas consumed by an ABCI or gRPC query, separately from the gas of the
+# txs, so that a query scanning a large range fails with an out of gas error, or ResourceExhausted
+# over gRPC, instead of holding the node. 0 leaves the queries unbounded.
+query-gas-limit = {{ .BaseConfig.QueryGasLimit }}
+
+# QueryGasLimits overrides the gas limit of the queries to some modules, as module=limit, e.g.
+# "wasm=10000000". The module of a gRPC query is the package of its service without its version.
+query-gas-limits = [{{ range $i, $v := .BaseConfig.QueryGasLimits }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+
+# MaxQueryDepth bounds the number of queries nested while handling a query, e.g. by contracts
+# querying each other. 0 leaves the nesting unbounded.
+max-query-depth = {{ .BaseConfig.MaxQueryDepth }}
+
+# ConfigReloadInterval is the interval at which config.toml and app.toml are checked for changes,
+# which are applied without restarting the node to the knobs that allow it: the log level of
+# config.toml, the query-limits, concurrency-workers and historical-store-cache-size (if it was
+# not 0 at start). The files are also reloaded on SIGHUP. 0 only reloads them on SIGHUP.
+config-reload-interval = "{{ .BaseConfig.ConfigReloadInterval }}"
+
+# ProposalTxBatching makes the node, when proposing a block, group its txs into batches of txs
+# whose resources declared in the accesscontrol module do not conflict, and propose the batches
+# one after the other, so that the validators executing the block with the scheduler run each
+# batch in parallel. The txs of a sender keep their order, and the txs with undeclared resources
+# are ordered between the txs before and after them.
+proposal-tx-batching = {{ .BaseConfig.ProposalTxBatching }}
+
+# ProposalMaxBatchSize bounds the number of txs of a batch when ProposalTxBatching is enabled,
+# the txs in excess going to later batches. 0 leaves the batches unbounded.
+proposal-max-batch-size = {{ .BaseConfig.ProposalMaxBatchSize }}
+
 # deprecated
 no-versioning = {{ .BaseConfig.NoVersioning }}
 
@@ -202,6 +399,14 @@ enable = {{ .GRPC.Enable }}
 # Address defines the gRPC server address to bind to.
 address = "{{ .GRPC.Address }}"
 
+# EnableReflection defines if the gRPC server reflection services should be registered,
+# letting clients discover the services and methods of the node.
+enable-reflection = {{ .GRPC.EnableReflection }}
+
+# EnableHealth defines if the grpc.health.v1 service should be registered. The node is
+# reported as NOT_SERVING while it catches up, or cannot reach its Tendermint node.
+enable-health = {{ .GRPC.EnableHealth }}
+
 ###############################################################################
 ###                        gRPC Web Configuration                           ###
 ###############################################################################
@@ -218,6 +423,32 @@ address = "{{ .GRPCWeb.Address }}"
 # EnableUnsafeCORS defines if CORS should be enabled (unsafe - use it at your own risk).
 enable-unsafe-cors = {{ .GRPCWeb.EnableUnsafeCORS }}
 
+###############################################################################
+###                        Query Limits Configuration                       ###
+###############################################################################
+
+# The query limits protect a node serving public queries from query storms. The calls
+# over the limits are rejected with a RESOURCE_EXHAUSTED gRPC status or a 429 HTTP status.
+[query-limits]
+
+# MaxConcurrentQueries is the maximum number of calls the gRPC and API servers serve
+# at once, together (0 for no maximum).
+max-concurrent-queries = {{ .QueryLimits.MaxConcurrentQueries }}
+
+# RateLimit is the number of calls per second each gRPC method and each API route
+# accepts (0 for no limit). An API route is identified by the first four segments of
+# its path, e.g. /cosmos/bank/v1beta1/balances.
+rate-limit = {{ .QueryLimits.RateLimit }}
+
+# RateBurst is the number of calls a method or route accepts at once (0 for its rate
+# limit, rounded up).
+rate-burst = {{ .QueryLimits.RateBurst }}
+
+# MethodRateLimits overrides the rate limit of gRPC methods, by full method name, and
+# of API routes, by path prefix, 0 removing the limit, e.g.
+# ["/cosmos.bank.v1beta1.Query/AllBalances=5", "/cosmos/bank/v1beta1/balances=5"].
+method-rate-limits = [{{ range $i, $v := .QueryLimits.MethodRateLimits }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+
 ###############################################################################
 ###                        State Sync Configuration                         ###
 ###############################################################################
@@ -237,6 +468,73 @@ snapshot-keep-recent = {{ .StateSync.SnapshotKeepRecent }}
 # default is emtpy which will then store under the app home directory same as before.
 snapshot-directory = "{{ .StateSync.SnapshotDirectory }}"
 
+# snapshot-compression sets the compression of the snapshots taken, zlib or zstd. zstd
+# compresses and decompresses several times faster at a similar ratio, and nodes restore
+# snapshots of either compression.
+snapshot-compression = "{{ .StateSync.SnapshotCompression }}"
+
+# snapshot-chunk-size sets the size in bytes of the chunks snapshots are split into and
+# served by (0 for the default of 10 MB). Snapshots are only fetched from peers serving the
+# same chunks, so nodes of a network should agree on the chunk size and compression.
+snapshot-chunk-size = {{ .StateSync.SnapshotChunkSize }}
+
+# snapshot-providers sets the URLs the snapshot import command imports a snapshot from when
+# given none, http(s) URLs or s3://<bucket>/<prefix>/<height>/<format> URLs, in order of
+# preference. The chunks a provider fails to serve are fetched from the next one.
+snapshot-providers = [{{ range $i, $v := .StateSync.SnapshotProviders }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+
+###############################################################################
+###                         State Streaming Configuration                   ###
+###############################################################################
+
+[store]
+
+# streamers lists the streaming services writing out the state changes of every block:
+# "file", "grpc", "kafka" and "parquet", configured below. Leave empty to disable streaming.
+streamers = [{{ range $i, $v := .Store.Streamers }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+
+[streamers]
+
+# keys lists the stores whose changes each streaming service exposes, "*" exposing all of them.
+
+[streamers.file]
+keys = [{{ range $i, $v := .Streamers.File.Keys }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+write_dir = "{{ .Streamers.File.WriteDir }}"
+prefix = "{{ .Streamers.File.Prefix }}"
+
+# The grpc streamer delivers the changes of every block, in height order, to a
+# cosmos.base.store.streaming.v1beta1.ChangeSetSink service, retrying each block until the
+# service acknowledges its height.
+[streamers.grpc]
+keys = [{{ range $i, $v := .Streamers.GRPC.Keys }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+address = "{{ .Streamers.GRPC.Address }}"
+timeout = "{{ .Streamers.GRPC.Timeout }}"
+
+# buffer_size is the number of committed blocks that may wait to be delivered, commits
+# block once it is reached until the service catches up.
+buffer_size = {{ .Streamers.GRPC.BufferSize }}
+
+# The kafka streamer publishes the changes of every block, in height order, to a single
+# partition of topic, retrying each block until all the in-sync replicas stored it.
+[streamers.kafka]
+keys = [{{ range $i, $v := .Streamers.Kafka.Keys }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+brokers = [{{ range $i, $v := .Streamers.Kafka.Brokers }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+topic = "{{ .Streamers.Kafka.Topic }}"
+buffer_size = {{ .Streamers.Kafka.BufferSize }}
+
+# The parquet streamer exports the tx results and events of every committed block, and the
+# changes of the stores of keys if store_deltas is set, to Parquet files under write_dir,
+# in a directory per table and a file per table and range of partition_size blocks.
+[streamers.parquet]
+keys = [{{ range $i, $v := .Streamers.Parquet.Keys }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}]
+write_dir = "{{ .Streamers.Parquet.WriteDir }}"
+partition_size = {{ .Streamers.Parquet.PartitionSize }}
+row_group_size = {{ .Streamers.Parquet.RowGroupSize }}
+
+# compression is the codec of the files: "none", "snappy", "gzip" or "zstd".
+compression = "{{ .Streamers.Parquet.Compression }}"
+store_deltas = {{ .Streamers.Parquet.StoreDeltas }}
+
 `
 
 var configTemplate *template.Template
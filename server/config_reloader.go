@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/server/ratelimit"
+	"github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// configReloader reads the configuration files of a running node again on
+// SIGHUP, or once they are modified, and applies the knobs that can change
+// without a restart: the log levels of config.toml, the query limits of
+// app.toml and, if the app is a types.ConfigReloader, the knobs of app.toml it
+// applies. The other settings keep the values the node started with.
+type configReloader struct {
+	configDir string
+	logger    tmlog.Logger
+	limiter   *ratelimit.Limiter
+	app       types.Application
+
+	// modTime is the latest modification time of the files when last read
+	modTime time.Time
+	// logLevel is the log level of config.toml when last read, which is only
+	// applied once it changes so that the --log_level flag is kept until then
+	logLevel    string
+	queryLimits config.QueryLimitsConfig
+}
+
+func newConfigReloader(ctx *Context, cfg config.Config, limiter *ratelimit.Limiter, app types.Application) *configReloader {
+	r := &configReloader{
+		configDir:   filepath.Join(ctx.Config.RootDir, "config"),
+		logger:      ctx.Logger.With("module", "config-reloader"),
+		limiter:     limiter,
+		app:         app,
+		logLevel:    ctx.Config.LogLevel,
+		queryLimits: cfg.QueryLimits,
+	}
+	r.modTime = r.latestModTime()
+	return r
+}
+
+// run reloads the configuration on SIGHUP and, if interval is positive, when
+// the files are found modified at the end of an interval, until goCtx is done.
+func (r *configReloader) run(goCtx context.Context, interval time.Duration) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-goCtx.Done():
+			return
+		case <-sighup:
+			r.reload()
+		case <-tick:
+			if r.latestModTime().After(r.modTime) {
+				r.reload()
+			}
+		}
+	}
+}
+
+// latestModTime returns the latest modification time of config.toml and
+// app.toml.
+func (r *configReloader) latestModTime() time.Time {
+	var latest time.Time
+	for _, name := range []string{"config.toml", "app.toml"} {
+		info, err := os.Stat(filepath.Join(r.configDir, name))
+		if err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// reload reads the configuration files and applies their runtime knobs. A
+// configuration that cannot be read or applied is logged, the knobs keeping
+// their values.
+func (r *configReloader) reload() {
+	r.modTime = r.latestModTime()
+	v := viper.New()
+	v.SetConfigType("toml")
+	v.SetConfigFile(filepath.Join(r.configDir, "config.toml"))
+	if err := v.ReadInConfig(); err != nil {
+		r.logger.Error("failed to reload config.toml", "err", err)
+		return
+	}
+	v.SetConfigFile(filepath.Join(r.configDir, "app.toml"))
+	if err := v.MergeInConfig(); err != nil {
+		r.logger.Error("failed to reload app.toml", "err", err)
+		return
+	}
+	cfg, err := config.GetConfig(v)
+	if err != nil {
+		r.logger.Error("failed to reload app.toml", "err", err)
+		return
+	}
+	r.apply(v.GetString("log-level"), cfg)
+}
+
+// apply applies the runtime knobs of the log level of config.toml and of the
+// configuration of app.toml.
+func (r *configReloader) apply(logLevel string, cfg config.Config) {
+	if logLevel != r.logLevel {
+		logger, ok := r.logger.(ZeroLogWrapper)
+		if !ok {
+			r.logger.Error("the log level cannot be changed at runtime")
+		} else if err := logger.SetLevel(logLevel); err != nil {
+			r.logger.Error("failed to apply log level", "err", err)
+		} else {
+			r.logLevel = logLevel
+			r.logger.Info("applied log level", "level", logLevel)
+		}
+	}
+
+	if r.limiter != nil && !reflect.DeepEqual(cfg.QueryLimits, r.queryLimits) {
+		if err := r.limiter.SetLimits(cfg.QueryLimits); err != nil {
+			r.logger.Error("failed to apply query limits", "err", err)
+		} else {
+			r.queryLimits = cfg.QueryLimits
+			r.logger.Info("applied query limits")
+		}
+	}
+
+	if reloader, ok := r.app.(types.ConfigReloader); ok {
+		if err := reloader.ReloadConfig(cfg); err != nil {
+			r.logger.Error("failed to apply app config", "err", err)
+		}
+	}
+	r.logger.Info("reloaded config")
+}
@@ -5,12 +5,19 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
 )
 
 func Test_openDB(t *testing.T) {
 	t.Parallel()
-	_, err := openDB(t.TempDir())
+	_, err := openDB(t.TempDir(), "")
+	require.NoError(t, err)
+
+	db, err := openDB(t.TempDir(), pebbledb.BackendType)
 	require.NoError(t, err)
+	require.IsType(t, &pebbledb.PebbleDB{}, db)
+	require.NoError(t, db.Close())
 }
 
 func Test_openTraceWriter(t *testing.T) {
@@ -20,6 +20,7 @@ const (
 	FlagHeight           = "height"
 	FlagForZeroHeight    = "for-zero-height"
 	FlagJailAllowedAddrs = "jail-allowed-addrs"
+	FlagAppStateFile     = "app-state-file"
 )
 
 // ExportCmd dumps app state to JSON.
@@ -38,7 +39,7 @@ func ExportCmd(appExporter types.AppExporter, defaultNodeHome string) *cobra.Com
 				return err
 			}
 
-			db, err := openDB(config.RootDir)
+			db, err := openDB(config.RootDir, GetAppDBBackend(serverCtx.Viper))
 			if err != nil {
 				return err
 			}
@@ -66,6 +67,7 @@ func ExportCmd(appExporter types.AppExporter, defaultNodeHome string) *cobra.Com
 			height, _ := cmd.Flags().GetInt64(FlagHeight)
 			forZeroHeight, _ := cmd.Flags().GetBool(FlagForZeroHeight)
 			jailAllowedAddrs, _ := cmd.Flags().GetStringSlice(FlagJailAllowedAddrs)
+			appStateFile, _ := cmd.Flags().GetString(FlagAppStateFile)
 
 			exported, err := appExporter(serverCtx.Logger, db, traceWriter, height, forZeroHeight, jailAllowedAddrs, serverCtx.Viper)
 			if err != nil {
@@ -78,6 +80,12 @@ func ExportCmd(appExporter types.AppExporter, defaultNodeHome string) *cobra.Com
 			}
 
 			doc.AppState = exported.AppState
+			if appStateFile != "" {
+				if err := writeAppStateFile(appStateFile, exported); err != nil {
+					return err
+				}
+				doc.AppState = nil
+			}
 			doc.Validators = exported.Validators
 			doc.InitialHeight = exported.Height
 			doc.ConsensusParams = &tmtypes.ConsensusParams{
@@ -113,6 +121,24 @@ func ExportCmd(appExporter types.AppExporter, defaultNodeHome string) *cobra.Com
 	cmd.Flags().Bool(FlagForZeroHeight, false, "Export state to start at height zero (perform preproccessing)")
 	cmd.Flags().StringSlice(FlagJailAllowedAddrs, []string{}, "Comma-separated list of operator addresses of jailed validators to unjail")
 	cmd.Flags().String(FlagChainID, "", "Chain ID")
+	cmd.Flags().String(FlagAppStateFile, "", "Write the app state to this file, one module at a time, instead of the exported genesis")
 
 	return cmd
 }
+
+// writeAppStateFile writes the exported app state to path.
+func writeAppStateFile(path string, exported types.ExportedApp) error {
+	if exported.WriteAppState == nil {
+		return fmt.Errorf("the app does not support exporting its state to a file")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := exported.WriteAppState(f); err != nil {
+		f.Close()
+		return fmt.Errorf("error exporting state: %v", err)
+	}
+	return f.Close()
+}
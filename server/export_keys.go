@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/store/export"
+)
+
+const (
+	flagExportFormat = "format"
+	flagExportOutput = "output"
+)
+
+// ExportKeysCmd returns a command that exports the keys of a store under a
+// prefix, at a given height, without exporting the whole genesis.
+func ExportKeysCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-keys [store-name] [hex-prefix]",
+		Short: "Export the keys of a store under a prefix at a given height",
+		Long: `Stream every key-value pair of the store named store-name whose key starts with
+the hex encoded prefix, in key order, as stored at --height. Without a prefix,
+the whole store is exported.
+
+With --format jsonl, each line is a cosmos.base.store.v1beta1.StoreKVPair in its
+protobuf JSON encoding, keys and values being base64 encoded. With --format proto,
+the pairs are written as protobuf messages, each prefixed with its uvarint
+encoded length.
+
+The node must be stopped, or the command pointed at a copy of its data.
+`,
+		Example: "export-keys bank 02 --height 1000 --output balances.jsonl",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var prefix []byte
+			if len(args) > 1 {
+				var err error
+				if prefix, err = hex.DecodeString(args[1]); err != nil {
+					return fmt.Errorf("invalid hex prefix %q: %w", args[1], err)
+				}
+			}
+			height, err := cmd.Flags().GetInt64(FlagHeight)
+			if err != nil {
+				return err
+			}
+			if height < 0 {
+				return fmt.Errorf("invalid height %d", height)
+			}
+			formatName, err := cmd.Flags().GetString(flagExportFormat)
+			if err != nil {
+				return err
+			}
+			format, err := export.ParseFormat(formatName)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(flagExportOutput)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			w, err := export.NewWriter(out, format)
+			if err != nil {
+				return err
+			}
+
+			ctx := GetServerContextFromCmd(cmd)
+			db, err := openDB(ctx.Config.RootDir, GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			app := appCreator(ctx.Logger, db, nil, ctx.Config, ctx.Viper)
+			defer app.Close()
+
+			count, err := export.Prefix(app.CommitMultiStore(), height, args[0], prefix, w)
+			if err != nil {
+				return err
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			if output != "" {
+				cmd.Printf("exported %d keys to %s\n", count, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flags.FlagChainID, "sei-chain", "genesis file chain-id, if left blank will use sei")
+	cmd.Flags().Int64(FlagHeight, 0, "Height to export the keys at, 0 for the latest height")
+	cmd.Flags().String(flagExportFormat, string(export.FormatJSONL), "Output format (jsonl|proto)")
+	cmd.Flags().String(flagExportOutput, "", "File to write the keys to, defaults to stdout")
+	return cmd
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportKeysCmdArgs(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ServerContextKey, NewDefaultContext())
+	testCases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"bank", "0g"}, `invalid hex prefix "0g": encoding/hex: invalid byte: U+0067 'g'`},
+		{[]string{"bank", "--height", "-1"}, "invalid height -1"},
+		{[]string{"bank", "--format", "csv"}, `unknown export format "csv", expected jsonl or proto`},
+	}
+	for _, tc := range testCases {
+		cmd := ExportKeysCmd(nil, t.TempDir())
+		cmd.SetArgs(tc.args)
+		require.EqualError(t, cmd.ExecuteContext(ctx), tc.err)
+	}
+}
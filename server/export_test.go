@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -53,6 +54,46 @@ func TestExportCmd_ConsensusParams(t *testing.T) {
 	require.Equal(t, simapp.DefaultConsensusParams.Validator.PubKeyTypes, exportedGenDoc.ConsensusParams.Validator.PubKeyTypes)
 }
 
+func TestExportCmd_AppStateFile(t *testing.T) {
+	tempDir := t.TempDir()
+	app, ctx, _, cmd := setupApp(t, tempDir)
+
+	exported, err := app.ExportAppStateAndValidators(false, nil)
+	require.NoError(t, err)
+
+	// the exporter of the app is told by the app options to write its state
+	appStateFile := path.Join(tempDir, "app_state.json")
+	serverCtx := ctx.Value(server.ServerContextKey).(*server.Context)
+	serverCtx.Viper.Set(server.FlagAppStateFile, appStateFile)
+
+	output := &bytes.Buffer{}
+	cmd.SetOut(output)
+	cmd.SetArgs([]string{fmt.Sprintf("--%s=%s", flags.FlagHome, tempDir), fmt.Sprintf("--%s=%s", server.FlagAppStateFile, appStateFile)})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	var streamedGenDoc tmtypes.GenesisDoc
+	require.NoError(t, json.Unmarshal(output.Bytes(), &streamedGenDoc))
+	require.Empty(t, streamedGenDoc.AppState)
+	require.Equal(t, exported.Height, streamedGenDoc.InitialHeight)
+
+	appState, err := os.ReadFile(appStateFile)
+	require.NoError(t, err)
+	require.JSONEq(t, string(exported.AppState), string(appState))
+
+	// a chain initialized from the app state file exports the same app state
+	appOpts := viper.New()
+	appOpts.Set(flags.FlagChainID, "test-chain")
+	appOpts.Set(genutil.FlagAppStateFile, appStateFile)
+	newApp := simapp.NewSimApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, tempDir, 0, nil, simapp.MakeTestEncodingConfig(), appOpts)
+	newApp.InitChain(context.Background(), &abci.RequestInitChain{ConsensusParams: simapp.DefaultConsensusParams})
+	newApp.SetDeliverStateToCommit()
+	newApp.Commit(context.Background())
+
+	reexported, err := newApp.ExportAppStateAndValidators(false, nil)
+	require.NoError(t, err)
+	require.JSONEq(t, string(exported.AppState), string(reexported.AppState))
+}
+
 func TestExportCmd_HomeDir(t *testing.T) {
 	_, ctx, _, cmd := setupApp(t, t.TempDir())
 
@@ -161,6 +202,9 @@ func setupApp(t *testing.T, tempDir string) (*simapp.SimApp, context.Context, *t
 				simApp = simapp.NewSimApp(logger, db, nil, true, map[int64]bool{}, "", 0, nil, encCfg, &simapp.EmptyAppOptions{})
 			}
 
+			if appOptons.Get(server.FlagAppStateFile) != nil {
+				return simApp.ExportAppStateAndValidatorsToWriter(forZeroHeight, jailAllowedAddrs)
+			}
 			return simApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs)
 		}, tempDir)
 
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+// healthCheckInterval is the interval at which the health of the node is
+// checked.
+const healthCheckInterval = 5 * time.Second
+
+// nodeServingStatus returns the health of the node of clientCtx: a node
+// catching up, or whose status cannot be fetched, is not serving. A context
+// without a node, e.g. in gRPC only mode, is always serving.
+func nodeServingStatus(ctx context.Context, clientCtx client.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if clientCtx.Client == nil {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	status, err := clientCtx.Client.Status(ctx)
+	if err != nil || status.SyncInfo.CatchingUp {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// reportHealth sets the status of the server, i.e. of the empty service name,
+// and of each of its services to the health of the node of clientCtx every
+// interval, until done is closed.
+func reportHealth(healthSrv *health.Server, services []string, clientCtx client.Context, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		servingStatus := nodeServingStatus(ctx, clientCtx)
+		cancel()
+		healthSrv.SetServingStatus("", servingStatus)
+		for _, service := range services {
+			healthSrv.SetServingStatus(service, servingStatus)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-done:
+			healthSrv.Shutdown()
+			return
+		}
+	}
+}
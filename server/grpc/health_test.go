@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func TestReportHealth(t *testing.T) {
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := healthSrv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return res.Status
+	}
+
+	// a context without a node is serving
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		reportHealth(healthSrv, []string{"cosmos.bank.v1beta1.Query"}, client.Context{}, time.Hour, done)
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool {
+		return check("") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, check("cosmos.bank.v1beta1.Query"))
+
+	// the server stopping shuts the health service down
+	close(done)
+	<-stopped
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("cosmos.bank.v1beta1.Query"))
+}
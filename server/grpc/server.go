@@ -6,50 +6,79 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/cosmos/cosmos-sdk/server/grpc/gogoreflection"
 	reflection "github.com/cosmos/cosmos-sdk/server/grpc/reflection/v2alpha1"
 	"github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// StartGRPCServer starts a gRPC server on the given address.
-func StartGRPCServer(clientCtx client.Context, app types.Application, address string) (*grpc.Server, error) {
-	grpcSrv := grpc.NewServer()
+// StartGRPCServer starts a gRPC server on the configured address, created with
+// the provided options, e.g. the interceptors of a ratelimit.Limiter, followed
+// by the interceptors of the app if it is a types.GRPCInterceptorsProvider.
+func StartGRPCServer(clientCtx client.Context, app types.Application, cfg config.GRPCConfig, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if provider, ok := app.(types.GRPCInterceptorsProvider); ok {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(provider.GRPCUnaryInterceptors()...),
+			grpc.ChainStreamInterceptor(provider.GRPCStreamInterceptors()...),
+		)
+	}
+	grpcSrv := grpc.NewServer(opts...)
 	app.RegisterGRPCServer(grpcSrv)
-	// reflection allows consumers to build dynamic clients that can write
-	// to any cosmos-sdk application without relying on application packages at compile time
-	err := reflection.Register(grpcSrv, reflection.Config{
-		SigningModes: func() map[string]int32 {
-			modes := make(map[string]int32, len(clientCtx.TxConfig.SignModeHandler().Modes()))
-			for _, m := range clientCtx.TxConfig.SignModeHandler().Modes() {
-				modes[m.String()] = (int32)(m)
-			}
-			return modes
-		}(),
-		ChainID:           clientCtx.ChainID,
-		SdkConfig:         sdk.GetConfig(),
-		InterfaceRegistry: clientCtx.InterfaceRegistry,
-	})
-	if err != nil {
-		return nil, err
+	if cfg.EnableReflection {
+		// reflection allows consumers to build dynamic clients that can write
+		// to any cosmos-sdk application without relying on application packages at compile time
+		err := reflection.Register(grpcSrv, reflection.Config{
+			SigningModes: func() map[string]int32 {
+				modes := make(map[string]int32, len(clientCtx.TxConfig.SignModeHandler().Modes()))
+				for _, m := range clientCtx.TxConfig.SignModeHandler().Modes() {
+					modes[m.String()] = (int32)(m)
+				}
+				return modes
+			}(),
+			ChainID:           clientCtx.ChainID,
+			SdkConfig:         sdk.GetConfig(),
+			InterfaceRegistry: clientCtx.InterfaceRegistry,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Reflection allows external clients to see what services and methods
+		// the gRPC server exposes.
+		gogoreflection.Register(grpcSrv)
+	}
+	var healthSrv *health.Server
+	if cfg.EnableHealth {
+		healthSrv = health.NewServer()
+		// the node is not known to be serving until it is checked
+		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthpb.RegisterHealthServer(grpcSrv, healthSrv)
 	}
-	// Reflection allows external clients to see what services and methods
-	// the gRPC server exposes.
-	gogoreflection.Register(grpcSrv)
-	listener, err := net.Listen("tcp", address)
+	listener, err := net.Listen("tcp", cfg.Address)
 	if err != nil {
 		return nil, err
 	}
 
 	errCh := make(chan error)
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		err = grpcSrv.Serve(listener)
 		if err != nil {
 			errCh <- fmt.Errorf("failed to serve: %w", err)
 		}
 	}()
+	if healthSrv != nil {
+		services := make([]string, 0, len(grpcSrv.GetServiceInfo()))
+		for service := range grpcSrv.GetServiceInfo() {
+			services = append(services, service)
+		}
+		go reportHealth(healthSrv, services, clientCtx, healthCheckInterval, done)
+	}
 
 	select {
 	case err := <-errCh:
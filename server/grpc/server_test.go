@@ -14,12 +14,18 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	reflectionv1 "github.com/cosmos/cosmos-sdk/client/grpc/reflection"
 	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/server"
+	srvconfig "github.com/cosmos/cosmos-sdk/server/config"
+	servergrpc "github.com/cosmos/cosmos-sdk/server/grpc"
 	reflectionv2 "github.com/cosmos/cosmos-sdk/server/grpc/reflection/v2alpha1"
 	"github.com/cosmos/cosmos-sdk/simapp"
 	"github.com/cosmos/cosmos-sdk/testutil/network"
@@ -130,6 +136,44 @@ func (s *IntegrationTestSuite) TestGRPCServer_Reflection() {
 	}
 }
 
+func (s *IntegrationTestSuite) TestGRPCServer_Health() {
+	healthClient := healthpb.NewHealthClient(s.conn)
+	// the node is reported serving once it is checked not to be catching up
+	s.Require().Eventually(func() bool {
+		res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && res.Status == healthpb.HealthCheckResponse_SERVING
+	}, 15*time.Second, 100*time.Millisecond)
+	res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "cosmos.bank.v1beta1.Query"})
+	s.Require().NoError(err)
+	s.Require().Equal(healthpb.HealthCheckResponse_SERVING, res.Status)
+	_, err = healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *IntegrationTestSuite) TestGRPCServer_Disabled() {
+	val0 := s.network.Validators[0]
+	_, port, err := server.FreeTCPAddr()
+	s.Require().NoError(err)
+	address := fmt.Sprintf("127.0.0.1:%s", port)
+	grpcSrv, err := servergrpc.StartGRPCServer(val0.ClientCtx, s.app, srvconfig.GRPCConfig{Address: address})
+	s.Require().NoError(err)
+	defer grpcSrv.Stop()
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	// the queries are served without the reflection and health services
+	_, err = testdata.NewQueryClient(conn).Echo(context.Background(), &testdata.EchoRequest{Message: "hello"})
+	s.Require().NoError(err)
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}}))
+	_, err = stream.Recv()
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+}
+
 func (s *IntegrationTestSuite) TestGRPCServer_InterfaceReflection() {
 	// this tests the application reflection capabilities and compatibility between v1 and v2
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -1,44 +1,183 @@
 package server
 
 import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
 	"github.com/rs/zerolog"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
 var _ tmlog.Logger = (*ZeroLogWrapper)(nil)
 
+// Field names of the JSON logs. They are set explicitly, rather than left to
+// the zerolog defaults, so that log pipelines can rely on them.
+const (
+	LogFieldTime    = "time"
+	LogFieldLevel   = "level"
+	LogFieldMessage = "message"
+	LogFieldError   = "error"
+	// LogFieldModule is the field of the module a logger was created for with
+	// With("module", ...), which selects its level override.
+	LogFieldModule = "module"
+)
+
+// defaultLogModule selects the default level in a log level string.
+const defaultLogModule = "*"
+
 // ZeroLogWrapper provides a wrapper around a zerolog.Logger instance. It implements
 // Tendermint's Logger interface.
 type ZeroLogWrapper struct {
 	zerolog.Logger
+
+	// levels, if set, are the levels shared by the loggers derived from the
+	// logger created by NewZeroLogger, which SetLevel changes at runtime
+	levels *logLevels
+	// module is the module the logger was created for with With
+	module string
+}
+
+// logLevels holds the default level of the loggers and the levels overriding
+// it for some modules.
+type logLevels struct {
+	v atomic.Value // moduleLevels
+}
+
+type moduleLevels struct {
+	defaultLvl zerolog.Level
+	modules    map[string]zerolog.Level
+}
+
+func (l *logLevels) level(module string) zerolog.Level {
+	levels := l.v.Load().(moduleLevels)
+	if lvl, ok := levels.modules[module]; ok {
+		return lvl
+	}
+	return levels.defaultLvl
+}
+
+// NewZeroLogger returns a logger writing to out in the given format, plain or
+// json. The level is either a single level, e.g. "info", or a comma separated
+// list of levels by module, e.g. "info,x/bank:error,tasks:debug", where the
+// level without module, or of the module "*", is the default level.
+func NewZeroLogger(format string, level string, out io.Writer) (ZeroLogWrapper, error) {
+	defaultLvl, modules, err := ParseLogLevels(level)
+	if err != nil {
+		return ZeroLogWrapper{}, err
+	}
+	levels := &logLevels{}
+	levels.v.Store(moduleLevels{defaultLvl: defaultLvl, modules: modules})
+
+	if strings.ToLower(format) == tmlog.LogFormatPlain {
+		out = zerolog.ConsoleWriter{Out: out}
+	} else {
+		zerolog.TimestampFieldName = LogFieldTime
+		zerolog.LevelFieldName = LogFieldLevel
+		zerolog.MessageFieldName = LogFieldMessage
+		zerolog.ErrorFieldName = LogFieldError
+		zerolog.TimeFieldFormat = time.RFC3339Nano
+	}
+
+	return ZeroLogWrapper{
+		Logger: zerolog.New(out).With().Timestamp().Logger(),
+		levels: levels,
+	}, nil
+}
+
+// SetLevel changes the levels, given as to NewZeroLogger, of the logger and of
+// every other logger derived from the same NewZeroLogger logger, e.g. once the
+// node configuration is reloaded.
+func (z ZeroLogWrapper) SetLevel(level string) error {
+	if z.levels == nil {
+		return fmt.Errorf("the level of the logger cannot be changed")
+	}
+	defaultLvl, modules, err := ParseLogLevels(level)
+	if err != nil {
+		return err
+	}
+	z.levels.v.Store(moduleLevels{defaultLvl: defaultLvl, modules: modules})
+	return nil
+}
+
+// logger returns the zerolog logger at the current level of z.
+func (z ZeroLogWrapper) logger() *zerolog.Logger {
+	if z.levels == nil {
+		return &z.Logger
+	}
+	logger := z.Logger.Level(z.levels.level(z.module))
+	return &logger
+}
+
+// ParseLogLevels parses a log level string, see NewZeroLogger, into its default
+// level, info if it has none, and its levels by module.
+func ParseLogLevels(s string) (zerolog.Level, map[string]zerolog.Level, error) {
+	defaultLvl := zerolog.InfoLevel
+	var moduleLevels map[string]zerolog.Level
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		module, lvlStr := defaultLogModule, entry
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			module, lvlStr = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		lvl, err := zerolog.ParseLevel(lvlStr)
+		if err != nil || lvlStr == "" || module == "" {
+			return 0, nil, fmt.Errorf("failed to parse log level (%s): invalid entry %q", s, entry)
+		}
+
+		if module == defaultLogModule {
+			defaultLvl = lvl
+			continue
+		}
+		if moduleLevels == nil {
+			moduleLevels = make(map[string]zerolog.Level)
+		}
+		moduleLevels[module] = lvl
+	}
+	return defaultLvl, moduleLevels, nil
 }
 
 // Info implements Tendermint's Logger interface and logs with level INFO. A set
 // of key/value tuples may be provided to add context to the log. The number of
 // tuples must be even and the key of the tuple must be a string.
 func (z ZeroLogWrapper) Info(msg string, keyVals ...interface{}) {
-	z.Logger.Info().Fields(getLogFields(keyVals...)).Msg(msg)
+	z.logger().Info().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
 // Error implements Tendermint's Logger interface and logs with level ERR. A set
 // of key/value tuples may be provided to add context to the log. The number of
 // tuples must be even and the key of the tuple must be a string.
 func (z ZeroLogWrapper) Error(msg string, keyVals ...interface{}) {
-	z.Logger.Error().Fields(getLogFields(keyVals...)).Msg(msg)
+	z.logger().Error().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
 // Debug implements Tendermint's Logger interface and logs with level DEBUG. A set
 // of key/value tuples may be provided to add context to the log. The number of
 // tuples must be even and the key of the tuple must be a string.
 func (z ZeroLogWrapper) Debug(msg string, keyVals ...interface{}) {
-	z.Logger.Debug().Fields(getLogFields(keyVals...)).Msg(msg)
+	z.logger().Debug().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
 // With returns a new wrapped logger with additional context provided by a set
 // of key/value tuples. The number of tuples must be even and the key of the
 // tuple must be a string.
 func (z ZeroLogWrapper) With(keyVals ...interface{}) tmlog.Logger {
-	return ZeroLogWrapper{z.Logger.With().Fields(getLogFields(keyVals...)).Logger()}
+	fields := getLogFields(keyVals...)
+	wrapper := ZeroLogWrapper{
+		Logger: z.Logger.With().Fields(fields).Logger(),
+		levels: z.levels,
+		module: z.module,
+	}
+	if module, ok := fields[LogFieldModule].(string); ok {
+		wrapper.module = module
+	}
+	return wrapper
 }
 
 func getLogFields(keyVals ...interface{}) map[string]interface{} {
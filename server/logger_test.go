@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevels(t *testing.T) {
+	testCases := []struct {
+		level        string
+		defaultLvl   zerolog.Level
+		moduleLevels map[string]zerolog.Level
+		expErr       bool
+	}{
+		{"", zerolog.InfoLevel, nil, false},
+		{"error", zerolog.ErrorLevel, nil, false},
+		{"x/bank:error,tasks:debug", zerolog.InfoLevel, map[string]zerolog.Level{"x/bank": zerolog.ErrorLevel, "tasks": zerolog.DebugLevel}, false},
+		{"warn, consensus:error", zerolog.WarnLevel, map[string]zerolog.Level{"consensus": zerolog.ErrorLevel}, false},
+		{"*:debug,p2p:error", zerolog.DebugLevel, map[string]zerolog.Level{"p2p": zerolog.ErrorLevel}, false},
+		{"verbose", 0, nil, true},
+		{"x/bank:", 0, nil, true},
+		{":debug", 0, nil, true},
+	}
+
+	for _, tc := range testCases {
+		defaultLvl, moduleLevels, err := ParseLogLevels(tc.level)
+		if tc.expErr {
+			require.Error(t, err, tc.level)
+			continue
+		}
+		require.NoError(t, err, tc.level)
+		require.Equal(t, tc.defaultLvl, defaultLvl, tc.level)
+		require.Equal(t, tc.moduleLevels, moduleLevels, tc.level)
+	}
+}
+
+func TestZeroLoggerModuleLevels(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := NewZeroLogger("json", "info,consensus:error,tasks:debug", out)
+	require.NoError(t, err)
+
+	logger.Debug("dropped")
+	logger.Info("kept", "height", 3)
+	consensus := logger.With("module", "consensus")
+	consensus.Info("dropped")
+	consensus.Error("kept")
+	tasks := logger.With("module", "tasks")
+	tasks.Debug("kept")
+	// the override still applies below the module logger
+	tasks.With("height", 4).Debug("kept")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		require.Equal(t, "kept", entry[LogFieldMessage])
+		require.Contains(t, entry, LogFieldTime)
+		require.Contains(t, entry, LogFieldLevel)
+	}
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	require.Equal(t, "consensus", entry[LogFieldModule])
+	require.Equal(t, "error", entry[LogFieldLevel])
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &entry))
+	require.Equal(t, "tasks", entry[LogFieldModule])
+	require.Equal(t, float64(4), entry["height"])
+
+	_, err = NewZeroLogger("json", "tasks:loud", out)
+	require.Error(t, err)
+}
+
+func TestZeroLoggerSetLevel(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := NewZeroLogger("json", "info", out)
+	require.NoError(t, err)
+	tasks := logger.With("module", "tasks")
+	tasks.Debug("dropped")
+
+	// the loggers derived before the change follow it
+	require.NoError(t, logger.SetLevel("error,tasks:debug"))
+	logger.Info("dropped")
+	tasks.Debug("kept")
+	tasks.With("height", 4).Debug("kept")
+
+	require.Error(t, logger.SetLevel("tasks:loud"))
+	tasks.Debug("kept")
+	require.Error(t, ZeroLogWrapper{}.SetLevel("debug"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		require.Equal(t, "kept", entry[LogFieldMessage])
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagMigrateBatchSize = "batch-size"
+	flagMigrateVerify    = "verify"
+)
+
+// MigrateAppDBCmd returns a command that migrates the application database to
+// another backend, for instance from goleveldb to pebbledb.
+func MigrateAppDBCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-app-db [target-backend]",
+		Short: "Migrate the application database to another backend",
+		Long: `Copy every key of the application database, opened with the backend set in
+app-db-backend, into a new database of the target backend. Once the copy is
+complete and verified, the new database replaces the old one, which is kept as
+data/application.db.bak.
+
+The node must be stopped while the database is migrated, and app-db-backend
+must be set to the target backend before it is restarted.
+`,
+		Example: "migrate-app-db pebbledb --home ~/.sei --app-db-backend goleveldb",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := GetServerContextFromCmd(cmd)
+			if err := serverCtx.Viper.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			home := serverCtx.Viper.GetString(flags.FlagHome)
+			sourceBackend := GetAppDBBackend(serverCtx.Viper)
+			targetBackend := dbm.BackendType(args[0])
+			if targetBackend == sourceBackend {
+				return fmt.Errorf("the application database already uses %s", targetBackend)
+			}
+			batchSize := serverCtx.Viper.GetInt(flagMigrateBatchSize)
+			if batchSize <= 0 {
+				return fmt.Errorf("batch size must be positive, got %d", batchSize)
+			}
+
+			dataDir := filepath.Join(home, "data")
+			backupDir := filepath.Join(dataDir, "application.db.bak")
+			if _, err := os.Stat(backupDir); err == nil {
+				return fmt.Errorf("%s already exists, remove it before migrating again", backupDir)
+			}
+			stagingDir := filepath.Join(dataDir, "migrate-"+string(targetBackend))
+			if _, err := os.Stat(stagingDir); err == nil {
+				return fmt.Errorf("%s already exists, it is likely left over from an interrupted migration and must be removed", stagingDir)
+			}
+
+			src, err := openDB(home, sourceBackend)
+			if err != nil {
+				return err
+			}
+			dst, err := sdk.NewDB("application", targetBackend, stagingDir)
+			if err != nil {
+				src.Close()
+				return err
+			}
+			copied, err := migrateDB(src, dst, batchSize, serverCtx.Viper.GetBool(flagMigrateVerify))
+			if closeErr := dst.Close(); err == nil {
+				err = closeErr
+			}
+			if closeErr := src.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := os.Rename(filepath.Join(dataDir, "application.db"), backupDir); err != nil {
+				return err
+			}
+			if err := os.Rename(filepath.Join(stagingDir, "application.db"), filepath.Join(dataDir, "application.db")); err != nil {
+				return err
+			}
+			if err := os.Remove(stagingDir); err != nil {
+				return err
+			}
+			cmd.Printf("migrated %d keys to %s, the previous database is kept in %s\n", copied, targetBackend, backupDir)
+			cmd.Printf("set app-db-backend = %q in app.toml before restarting the node\n", targetBackend)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(FlagAppDBBackend, "", "The backend of the database to migrate, defaults to the compile-time default")
+	cmd.Flags().Int(flagMigrateBatchSize, 10000, "Number of keys written to the target database per batch")
+	cmd.Flags().Bool(flagMigrateVerify, true, "Compare the target database with the source once the copy is complete")
+	return cmd
+}
+
+// migrateDB copies every key of src into dst, writing batchSize keys at a time,
+// and returns the number of keys copied. If verify is set, dst is then read
+// back and compared with src.
+func migrateDB(src, dst dbm.DB, batchSize int, verify bool) (int64, error) {
+	itr, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+
+	var copied int64
+	batch := dst.NewBatch()
+	defer func() { batch.Close() }()
+	for ; itr.Valid(); itr.Next() {
+		if err := batch.Set(itr.Key(), itr.Value()); err != nil {
+			return copied, err
+		}
+		copied++
+		if copied%int64(batchSize) == 0 {
+			if err := batch.Write(); err != nil {
+				return copied, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return copied, err
+	}
+	// the last batch is synced, which also persists the unsynced ones before it
+	if err := batch.WriteSync(); err != nil {
+		return copied, err
+	}
+
+	if verify {
+		if err := compareDBs(src, dst); err != nil {
+			return copied, fmt.Errorf("migrated database does not match the source: %w", err)
+		}
+	}
+	return copied, nil
+}
+
+// compareDBs returns an error if the keys or values of a and b differ.
+func compareDBs(a, b dbm.DB) error {
+	itrA, err := a.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itrA.Close()
+	itrB, err := b.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itrB.Close()
+
+	for ; itrA.Valid(); itrA.Next() {
+		if !itrB.Valid() {
+			return fmt.Errorf("key %X is missing", itrA.Key())
+		}
+		if !bytes.Equal(itrA.Key(), itrB.Key()) {
+			return fmt.Errorf("expected key %X, found %X", itrA.Key(), itrB.Key())
+		}
+		if !bytes.Equal(itrA.Value(), itrB.Value()) {
+			return fmt.Errorf("value of key %X differs", itrA.Key())
+		}
+		itrB.Next()
+	}
+	if itrB.Valid() {
+		return fmt.Errorf("unexpected key %X", itrB.Key())
+	}
+	if err := itrA.Error(); err != nil {
+		return err
+	}
+	return itrB.Error()
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
+)
+
+func Test_migrateDB(t *testing.T) {
+	src, err := dbm.NewGoLevelDB("application", t.TempDir())
+	require.NoError(t, err)
+	defer src.Close()
+	for i := 0; i < 25; i++ {
+		require.NoError(t, src.Set([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	dst, err := pebbledb.NewDB("application", t.TempDir())
+	require.NoError(t, err)
+	defer dst.Close()
+
+	copied, err := migrateDB(src, dst, 10, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 25, copied)
+	for i := 0; i < 25; i++ {
+		value, err := dst.Get([]byte(fmt.Sprintf("key%03d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+	}
+
+	// an extra key in the target fails the verification
+	require.NoError(t, dst.Set([]byte("key999"), []byte("stray")))
+	_, err = migrateDB(src, dst, 10, true)
+	require.ErrorContains(t, err, "unexpected key")
+}
+
+func TestMigrateAppDBCmd(t *testing.T) {
+	home := t.TempDir()
+	src, err := openDB(home, dbm.GoLevelDBBackend)
+	require.NoError(t, err)
+	require.NoError(t, src.Set([]byte("key"), []byte("value")))
+	require.NoError(t, src.Close())
+
+	cmd := MigrateAppDBCmd(home)
+	cmd.SetArgs([]string{string(pebbledb.BackendType), "--" + FlagAppDBBackend, string(dbm.GoLevelDBBackend)})
+	ctx := context.WithValue(context.Background(), ServerContextKey, NewDefaultContext())
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	_, err = os.Stat(filepath.Join(home, "data", "application.db.bak"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(home, "data", "migrate-"+string(pebbledb.BackendType)))
+	require.True(t, os.IsNotExist(err))
+
+	db, err := openDB(home, pebbledb.BackendType)
+	require.NoError(t, err)
+	defer db.Close()
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// a second migration does not overwrite the backup
+	cmd = MigrateAppDBCmd(home)
+	cmd.SetArgs([]string{string(dbm.GoLevelDBBackend), "--" + flags.FlagHome, home, "--" + FlagAppDBBackend, string(pebbledb.BackendType)})
+	require.ErrorContains(t, cmd.ExecuteContext(ctx), "already exists")
+}
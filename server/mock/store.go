@@ -113,6 +113,14 @@ func (ms multiStore) GetStore(key sdk.StoreKey) sdk.Store {
 	panic("not implemented")
 }
 
+func (ms multiStore) StoreKeys() []sdk.StoreKey {
+	panic("not implemented")
+}
+
+func (ms multiStore) SetKVStores(handler func(key store.StoreKey, s sdk.KVStore) store.CacheWrap) sdk.MultiStore {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetEvents() []abci.Event {
 	panic("not implemented")
 }
@@ -0,0 +1,242 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+// maxBuckets bounds the number of API routes tracked separately, as the paths
+// of the requests are chosen by the clients. The routes past it share a
+// bucket.
+const maxBuckets = 10000
+
+// overflowKey is the key of the bucket shared by the routes past maxBuckets.
+const overflowKey = ""
+
+// Limiter limits the calls served by the gRPC and API servers: a semaphore
+// caps the calls in flight across both servers, while a token bucket per gRPC
+// method or API route caps the rate of its calls.
+type Limiter struct {
+	now func() time.Time
+
+	mtx         sync.Mutex
+	sem         chan struct{}
+	rate        float64
+	burst       int
+	methodRates map[string]float64
+	buckets     map[string]*bucket
+}
+
+// NewLimiter creates a Limiter enforcing cfg.
+func NewLimiter(cfg config.QueryLimitsConfig) (*Limiter, error) {
+	l := &Limiter{now: time.Now}
+	if err := l.SetLimits(cfg); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetLimits makes l enforce cfg from then on, e.g. once app.toml is reloaded.
+// The calls being served are not counted against the new concurrency limit,
+// and every method or route starts again with a full bucket.
+func (l *Limiter) SetLimits(cfg config.QueryLimitsConfig) error {
+	methodRates, err := cfg.ParseMethodRateLimits()
+	if err != nil {
+		return err
+	}
+	var sem chan struct{}
+	if cfg.MaxConcurrentQueries > 0 {
+		sem = make(chan struct{}, cfg.MaxConcurrentQueries)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.sem = sem
+	l.rate = cfg.RateLimit
+	l.burst = cfg.RateBurst
+	l.methodRates = methodRates
+	l.buckets = make(map[string]*bucket)
+	return nil
+}
+
+// rateLimitedError is returned for a call over the rate limit of its method,
+// which accepts a call again after retryAfter.
+type rateLimitedError struct {
+	method     string
+	retryAfter time.Duration
+}
+
+func (e rateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit of %s exceeded, retry in %s", e.method, e.retryAfter)
+}
+
+// errTooManyQueries is returned for a call while the maximum number of calls
+// are served.
+var errTooManyQueries = fmt.Errorf("too many concurrent queries")
+
+// acquire admits a call to the method or route of key, limited to rate calls
+// per second, returning the function to call once it is served, or the reason
+// it is rejected.
+func (l *Limiter) acquire(key string, rate float64) (func(), error) {
+	if err := l.allow(key, rate); err != nil {
+		return nil, err
+	}
+	l.mtx.Lock()
+	sem := l.sem
+	l.mtx.Unlock()
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	default:
+		telemetry.IncrCounter(1, "server", "queries", "concurrency_limited")
+		return nil, errTooManyQueries
+	}
+}
+
+// allow takes a token from the bucket of key, which is refilled at rate.
+func (l *Limiter) allow(key string, rate float64) error {
+	if rate == 0 {
+		return nil
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			key = overflowKey
+			b = l.buckets[key]
+		}
+		if b == nil {
+			burst := l.burst
+			if burst == 0 {
+				burst = int(math.Ceil(rate))
+			}
+			b = &bucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: l.now()}
+			l.buckets[key] = b
+		}
+	}
+	if retryAfter := b.take(l.now()); retryAfter > 0 {
+		telemetry.IncrCounter(1, "server", "queries", "rate_limited")
+		return rateLimitedError{method: key, retryAfter: retryAfter}
+	}
+	return nil
+}
+
+// methodRate returns the rate limit of a gRPC method.
+func (l *Limiter) methodRate(method string) float64 {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if rate, ok := l.methodRates[method]; ok {
+		return rate
+	}
+	return l.rate
+}
+
+// routeRate returns the key and the rate limit of the API route of path: the
+// longest path prefix of MethodRateLimits matching it, or else the first four
+// segments of the path, e.g. /cosmos/bank/v1beta1/balances.
+func (l *Limiter) routeRate(path string) (string, float64) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	route, rate, found := "", 0.0, false
+	for prefix, prefixRate := range l.methodRates {
+		if len(prefix) > len(route) && hasPathPrefix(path, prefix) {
+			route, rate, found = prefix, prefixRate, true
+		}
+	}
+	if found {
+		return route, rate
+	}
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 5)
+	if len(segments) > 4 {
+		segments = segments[:4]
+	}
+	return "/" + strings.Join(segments, "/"), l.rate
+}
+
+// hasPathPrefix returns whether prefix is made of the first segments of path.
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// UnaryServerInterceptor returns the gRPC interceptor limiting the unary calls.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := l.acquire(info.FullMethod, l.methodRate(info.FullMethod))
+		if err != nil {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the gRPC interceptor limiting the rate of the
+// streaming calls. They are not counted as served concurrently, since a
+// subscription lasts as long as its client wants.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(info.FullMethod, l.methodRate(info.FullMethod)); err != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Middleware returns the HTTP handler limiting the requests to next, the ones
+// over the limits being answered with a 429 status.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release, err := l.acquire(l.routeRate(r.URL.Path))
+		if err != nil {
+			if rateLimited, ok := err.(rateLimitedError); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.retryAfter.Seconds()))))
+			}
+			rest.WriteErrorResponse(w, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bucket is a token bucket holding up to burst tokens, refilled at rate tokens
+// per second.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take takes a token from the bucket, returning the time until one is
+// available if it is empty.
+func (b *bucket) take(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	b.tokens--
+	return 0
+}
@@ -0,0 +1,155 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+)
+
+// newTestLimiter returns a Limiter enforcing cfg whose clock is advanced by
+// the returned function.
+func newTestLimiter(t *testing.T, cfg config.QueryLimitsConfig) (*Limiter, func(time.Duration)) {
+	l, err := NewLimiter(cfg)
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func callUnary(l *Limiter, method string, handler grpc.UnaryHandler) error {
+	_, err := l.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+func okHandler(context.Context, interface{}) (interface{}, error) { return nil, nil }
+
+func TestRateLimit(t *testing.T) {
+	l, advance := newTestLimiter(t, config.QueryLimitsConfig{
+		RateLimit:        2,
+		MethodRateLimits: []string{"/test.Query/Slow=0.5", "/test.Query/Free=0"},
+	})
+
+	// each method has its own bucket, holding its rate of calls
+	require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	err := callUnary(l, "/test.Query/A", okHandler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.NoError(t, callUnary(l, "/test.Query/B", okHandler))
+
+	// the buckets refill at their rate
+	advance(500 * time.Millisecond)
+	require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+
+	// the methods overriding the rate limit
+	require.NoError(t, callUnary(l, "/test.Query/Slow", okHandler))
+	advance(time.Second)
+	require.Error(t, callUnary(l, "/test.Query/Slow", okHandler))
+	advance(time.Second)
+	require.NoError(t, callUnary(l, "/test.Query/Slow", okHandler))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, callUnary(l, "/test.Query/Free", okHandler))
+	}
+}
+
+func TestRateBurst(t *testing.T) {
+	l, advance := newTestLimiter(t, config.QueryLimitsConfig{RateLimit: 1, RateBurst: 3})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	}
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+	// the bucket does not hold more than the burst
+	advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	}
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+}
+
+func TestMaxConcurrentQueries(t *testing.T) {
+	l, _ := newTestLimiter(t, config.QueryLimitsConfig{MaxConcurrentQueries: 1})
+
+	// a call served holds the only slot until it returns
+	err := callUnary(l, "/test.Query/A", func(context.Context, interface{}) (interface{}, error) {
+		err := callUnary(l, "/test.Query/B", okHandler)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		rec := httptest.NewRecorder()
+		l.Middleware(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cosmos/bank/v1beta1/balances/addr", nil))
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+		// the streams are not counted as served concurrently
+		return nil, l.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/test.Stream/Subscribe"}, func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, callUnary(l, "/test.Query/B", okHandler))
+}
+
+func TestSetLimits(t *testing.T) {
+	l, _ := newTestLimiter(t, config.QueryLimitsConfig{RateLimit: 1, MaxConcurrentQueries: 1})
+	require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+
+	err := callUnary(l, "/test.Query/B", func(context.Context, interface{}) (interface{}, error) {
+		require.NoError(t, l.SetLimits(config.QueryLimitsConfig{RateLimit: 2, MaxConcurrentQueries: 1}))
+		// the call being served does not hold the slot of the new limit
+		require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+		return nil, nil
+	})
+	require.NoError(t, err)
+	// the buckets start again from the new rate
+	require.NoError(t, callUnary(l, "/test.Query/A", okHandler))
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+
+	// invalid limits leave the limits in place
+	require.Error(t, l.SetLimits(config.QueryLimitsConfig{MethodRateLimits: []string{"/test.Query/A"}}))
+	require.Error(t, callUnary(l, "/test.Query/A", okHandler))
+}
+
+func TestMiddleware(t *testing.T) {
+	l, _ := newTestLimiter(t, config.QueryLimitsConfig{
+		RateLimit:        1,
+		MethodRateLimits: []string{"/cosmos/bank/v1beta1/supply=0", "/cosmos/tx/v1beta1=0.1"},
+	})
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		l.Middleware(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// the routes are identified by the first four segments of their path
+	require.Equal(t, http.StatusNotFound, serve("/cosmos/bank/v1beta1/balances/addr1").Code)
+	rec := serve("/cosmos/bank/v1beta1/balances/addr2")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusNotFound, serve("/cosmos/bank/v1beta1/params").Code)
+
+	// or by the longest prefix overriding their rate limit
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusNotFound, serve("/cosmos/bank/v1beta1/supply/usei").Code)
+	}
+	require.Equal(t, http.StatusNotFound, serve("/cosmos/tx/v1beta1/txs/hash1").Code)
+	rec = serve("/cosmos/tx/v1beta1/simulate")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "10", rec.Header().Get("Retry-After"))
+}
+
+func TestRouteBucketsBounded(t *testing.T) {
+	l, _ := newTestLimiter(t, config.QueryLimitsConfig{RateLimit: 1})
+	for i := 0; i < maxBuckets+10; i++ {
+		key, rate := l.routeRate("/route/" + time.Duration(i).String())
+		_ = l.allow(key, rate)
+	}
+	require.Len(t, l.buckets, maxBuckets+1)
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server/types"
+)
+
+const (
+	flagReplayConcurrent = "concurrent"
+
+	replayValidatorsPerPage = 100
+)
+
+// BlockReplayer is implemented by the apps whose blocks can be replayed by
+// ReplayBlockCmd, such as the ones embedding a BaseApp.
+type BlockReplayer interface {
+	ReplayBlock(req *abci.RequestFinalizeBlock) (*baseapp.BlockReplay, error)
+}
+
+// blockReplayOutput is the JSON output of ReplayBlockCmd.
+type blockReplayOutput struct {
+	Height     int64            `json:"height"`
+	Concurrent bool             `json:"concurrent"`
+	AppHash    tmbytes.HexBytes `json:"app_hash"`
+	// ExpectedAppHash is the app hash the network agreed on for the block,
+	// empty if the next block is not available yet
+	ExpectedAppHash tmbytes.HexBytes              `json:"expected_app_hash,omitempty"`
+	TxResults       []txReplayOutput              `json:"tx_results"`
+	Writes          map[string][]storeWriteOutput `json:"writes"`
+}
+
+type txReplayOutput struct {
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace,omitempty"`
+	Log       string `json:"log,omitempty"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+}
+
+type storeWriteOutput struct {
+	Key      tmbytes.HexBytes `json:"key"`
+	OldValue tmbytes.HexBytes `json:"old_value,omitempty"`
+	NewValue tmbytes.HexBytes `json:"new_value,omitempty"`
+	Deleted  bool             `json:"deleted,omitempty"`
+}
+
+// ReplayBlockCmd returns a command re-executing a block on the state of the
+// local node and printing the app hash and writes it results in.
+func ReplayBlockCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-block [height]",
+		Short: "Re-execute a block on the local application state and print its app hash and writes",
+		Long: `Re-execute the block at the given height on the application state committed at
+the previous height, without committing it, and print as JSON the resulting app
+hash, tx results and the changes of every store, by key.
+
+The block is fetched from the Tendermint RPC of --node, while the state is read
+from the application database of --home, which must not be used by a running
+node. Comparing the output of nodes disagreeing on the app hash of a block shows
+the keys they disagree on. With --concurrent, the txs of the block are executed
+by the concurrent scheduler rather than sequentially.
+`,
+		Example: "debug replay-block 1234 --home ~/.sei --node tcp://localhost:26657 --concurrent",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %s: %w", args[0], err)
+			}
+			node, err := cmd.Flags().GetString(flags.FlagNode)
+			if err != nil {
+				return err
+			}
+			concurrent, err := cmd.Flags().GetBool(flagReplayConcurrent)
+			if err != nil {
+				return err
+			}
+
+			rpc, err := client.NewClientFromNode(node)
+			if err != nil {
+				return err
+			}
+			req, err := fetchFinalizeBlockRequest(cmd.Context(), rpc, height)
+			if err != nil {
+				return err
+			}
+			out := blockReplayOutput{Height: height, Concurrent: concurrent}
+			next := height + 1
+			if res, err := rpc.Block(cmd.Context(), &next); err == nil && res.Block != nil {
+				out.ExpectedAppHash = res.Block.AppHash
+			}
+
+			ctx := GetServerContextFromCmd(cmd)
+			db, err := openDB(ctx.Config.RootDir, GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			// the execution mode is the one of the command, not of app.toml
+			ctx.Viper.Set(FlagOccEnabled, concurrent)
+			app := appCreator(ctx.Logger, db, nil, nil, ctx.Viper)
+			replayer, ok := app.(BlockReplayer)
+			if !ok {
+				return fmt.Errorf("the application does not support replaying blocks")
+			}
+
+			replay, err := replayer.ReplayBlock(req)
+			if err != nil {
+				return fmt.Errorf("failed to replay block %d: %w", height, err)
+			}
+			out.AppHash = replay.AppHash
+			for _, res := range replay.TxResults {
+				out.TxResults = append(out.TxResults, txReplayOutput{
+					Code:      res.Code,
+					Codespace: res.Codespace,
+					Log:       res.Log,
+					GasWanted: res.GasWanted,
+					GasUsed:   res.GasUsed,
+				})
+			}
+			out.Writes = storeWritesOutput(replay.Writes)
+
+			bz, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to Tendermint RPC interface the block is fetched from")
+	cmd.Flags().Bool(flagReplayConcurrent, false, "Execute the txs of the block with the concurrent scheduler")
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	return cmd
+}
+
+// fetchFinalizeBlockRequest returns the request Tendermint finalizes the block
+// at height with, from the block and the validators of the previous height.
+func fetchFinalizeBlockRequest(ctx context.Context, rpc rpcclient.Client, height int64) (*abci.RequestFinalizeBlock, error) {
+	res, err := rpc.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %d: %w", height, err)
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+
+	var lastVals []*tmtypes.Validator
+	if res.Block.LastCommit != nil && res.Block.LastCommit.Size() > 0 {
+		lastHeight := height - 1
+		for page := 1; ; page++ {
+			page, perPage := page, replayValidatorsPerPage
+			vals, err := rpc.Validators(ctx, &lastHeight, &page, &perPage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch the validators of height %d: %w", lastHeight, err)
+			}
+			lastVals = append(lastVals, vals.Validators...)
+			if len(vals.Validators) == 0 || len(lastVals) >= vals.Total {
+				break
+			}
+		}
+	}
+	return finalizeBlockRequest(res.Block, lastVals)
+}
+
+// finalizeBlockRequest builds the request of the block the way Tendermint does,
+// lastVals being the validators that signed its last commit, in order.
+func finalizeBlockRequest(block *tmtypes.Block, lastVals []*tmtypes.Validator) (*abci.RequestFinalizeBlock, error) {
+	var lastCommit abci.CommitInfo
+	if block.LastCommit != nil && block.LastCommit.Size() > 0 {
+		if block.LastCommit.Size() != len(lastVals) {
+			return nil, fmt.Errorf("commit size (%d) doesn't match validator set length (%d) at height %d",
+				block.LastCommit.Size(), len(lastVals), block.Height)
+		}
+		votes := make([]abci.VoteInfo, len(lastVals))
+		for i, val := range lastVals {
+			votes[i] = abci.VoteInfo{
+				Validator:       tmtypes.TM2PB.Validator(val),
+				SignedLastBlock: block.LastCommit.Signatures[i].BlockIDFlag != tmtypes.BlockIDFlagAbsent,
+			}
+		}
+		lastCommit = abci.CommitInfo{Round: block.LastCommit.Round, Votes: votes}
+	}
+
+	return &abci.RequestFinalizeBlock{
+		Hash:                  block.Hash(),
+		Height:                block.Height,
+		Time:                  block.Time,
+		Txs:                   block.Txs.ToSliceOfBytes(),
+		DecidedLastCommit:     lastCommit,
+		ByzantineValidators:   block.Evidence.ToABCI(),
+		ProposerAddress:       block.ProposerAddress,
+		NextValidatorsHash:    block.NextValidatorsHash,
+		AppHash:               block.AppHash,
+		ValidatorsHash:        block.ValidatorsHash,
+		ConsensusHash:         block.ConsensusHash,
+		DataHash:              block.DataHash,
+		EvidenceHash:          block.EvidenceHash,
+		LastBlockHash:         block.LastBlockID.Hash,
+		LastBlockPartSetTotal: int64(block.LastBlockID.PartSetHeader.Total),
+		// as set by Tendermint
+		LastBlockPartSetHash: block.LastBlockID.Hash,
+		LastCommitHash:       block.LastCommitHash,
+		LastResultsHash:      block.LastResultsHash,
+	}, nil
+}
+
+func storeWritesOutput(writes map[string][]baseapp.StoreWrite) map[string][]storeWriteOutput {
+	out := make(map[string][]storeWriteOutput, len(writes))
+	for name, storeWrites := range writes {
+		for _, w := range storeWrites {
+			out[name] = append(out[name], storeWriteOutput{
+				Key:      w.Key,
+				OldValue: w.OldValue,
+				NewValue: w.NewValue,
+				Deleted:  w.NewValue == nil,
+			})
+		}
+	}
+	return out
+}
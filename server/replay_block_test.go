@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestFinalizeBlockRequest(t *testing.T) {
+	vals := []*tmtypes.Validator{
+		tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+		tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 20),
+	}
+	block := &tmtypes.Block{
+		Header: tmtypes.Header{Height: 5, AppHash: []byte("apphash")},
+		Data:   tmtypes.Data{Txs: tmtypes.Txs{[]byte("tx1"), []byte("tx2")}},
+		LastCommit: &tmtypes.Commit{
+			Height: 4,
+			Round:  1,
+			Signatures: []tmtypes.CommitSig{
+				{BlockIDFlag: tmtypes.BlockIDFlagCommit},
+				{BlockIDFlag: tmtypes.BlockIDFlagAbsent},
+			},
+		},
+	}
+
+	req, err := finalizeBlockRequest(block, vals)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), req.Height)
+	require.Equal(t, []byte("apphash"), req.AppHash)
+	require.Equal(t, [][]byte{[]byte("tx1"), []byte("tx2")}, req.Txs)
+	require.Equal(t, block.Hash().Bytes(), req.Hash)
+	require.Equal(t, int32(1), req.DecidedLastCommit.Round)
+	require.Len(t, req.DecidedLastCommit.Votes, 2)
+	require.True(t, req.DecidedLastCommit.Votes[0].SignedLastBlock)
+	require.Equal(t, int64(10), req.DecidedLastCommit.Votes[0].Validator.Power)
+	require.False(t, req.DecidedLastCommit.Votes[1].SignedLastBlock)
+
+	_, err = finalizeBlockRequest(block, vals[:1])
+	require.EqualError(t, err, "commit size (2) doesn't match validator set length (1) at height 5")
+
+	// the initial height has no last commit
+	block.LastCommit = &tmtypes.Commit{}
+	req, err = finalizeBlockRequest(block, nil)
+	require.NoError(t, err)
+	require.Empty(t, req.DecidedLastCommit.Votes)
+}
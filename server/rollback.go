@@ -9,13 +9,16 @@ import (
 	tmcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 )
 
+const flagRollbackHeights = "heights"
+
 var removeBlock = false
 
-// NewRollbackCmd creates a command to rollback tendermint and multistore state by one height.
+// NewRollbackCmd creates a command to rollback tendermint and multistore state by one
+// or more heights.
 func NewRollbackCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rollback",
-		Short: "rollback cosmos-sdk and tendermint state by one height",
+		Short: "rollback cosmos-sdk and tendermint state by one or more heights",
 		Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when Tendermint has persisted an incorrect app hash and is thus unable to make
@@ -23,12 +26,29 @@ progress. Rollback overwrites a state at height n with the state at height n - 1
 The application also roll back to height n - 1. No blocks are removed, so upon
 restarting Tendermint the transactions in block n will be re-executed against the
 application.
+
+With --hard, the blocks are removed as well and --heights rolls back the last N
+heights at once: the state at height n is overwritten with the state at height n - N,
+and the application versions above n - N are deleted.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			heights, err := cmd.Flags().GetInt64(flagRollbackHeights)
+			if err != nil {
+				return err
+			}
+			if heights < 1 {
+				return fmt.Errorf("invalid number of heights to rollback: %d", heights)
+			}
+			if heights > 1 && !removeBlock {
+				// without removing the block, tendermint only rolls back its
+				// state once until the block is executed again
+				return fmt.Errorf("rolling back more than one height requires --hard")
+			}
+
 			ctx := GetServerContextFromCmd(cmd)
 			cfg := ctx.Config
 			home := cfg.RootDir
-			db, err := openDB(home)
+			db, err := openDB(home, GetAppDBBackend(ctx.Viper))
 			if err != nil {
 				return err
 			}
@@ -45,12 +65,16 @@ application.
 			lastCommit := app.CommitMultiStore().LastCommitID()
 			fmt.Printf("Initial App state height=%d and hash=%X\n", lastCommit.GetVersion(), lastCommit.GetHash())
 
-			// rollback tendermint state
-			tmHeight, hash, err := tmcmd.RollbackState(ctx.Config, removeBlock)
-			if err != nil {
-				return fmt.Errorf("failed to rollback tendermint state: %w", err)
+			// rollback tendermint state, one height at a time
+			var tmHeight int64
+			var hash []byte
+			for i := int64(0); i < heights; i++ {
+				tmHeight, hash, err = tmcmd.RollbackState(ctx.Config, removeBlock)
+				if err != nil {
+					return fmt.Errorf("failed to rollback tendermint state: %w", err)
+				}
+				fmt.Printf("Rolled back tendermint state to height %d and hash %X\n\n", tmHeight, hash)
 			}
-			fmt.Printf("Rolled back tendermint state to height %d and hash %X\n\n", tmHeight, hash)
 
 			// rollback the app state
 			lastCommit = app.CommitMultiStore().LastCommitID()
@@ -79,6 +103,7 @@ application.
 
 	cmd.Flags().String(flags.FlagChainID, "sei-chain", "genesis file chain-id, if left blank will use sei")
 	cmd.Flags().BoolVar(&removeBlock, "hard", false, "remove last block as well as state")
+	cmd.Flags().Int64(flagRollbackHeights, 1, "number of heights to rollback, more than one requires --hard")
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
 	return cmd
 }
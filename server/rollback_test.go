@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackCmdHeights(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ServerContextKey, NewDefaultContext())
+	testCases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"--heights", "0"}, "invalid number of heights to rollback: 0"},
+		{[]string{"--heights", "-1", "--hard"}, "invalid number of heights to rollback: -1"},
+		{[]string{"--heights", "2"}, "rolling back more than one height requires --hard"},
+	}
+	for _, tc := range testCases {
+		cmd := NewRollbackCmd(nil, t.TempDir())
+		cmd.SetArgs(tc.args)
+		require.EqualError(t, cmd.ExecuteContext(ctx), tc.err)
+	}
+}
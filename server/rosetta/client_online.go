@@ -275,6 +275,13 @@ func (c *Client) GetTx(ctx context.Context, hash string) (*rosettatypes.Transact
 		}
 
 		return fullBlock.Transactions[0], nil
+	// handle deliver tx hash
+	case DeliverTxTx:
+		rawTx, err := c.tmRPC.Tx(ctx, hashBytes, true)
+		if err != nil {
+			return nil, crgerrs.WrapError(crgerrs.ErrUnknown, err.Error())
+		}
+		return c.converter.ToRosetta().Tx(rawTx.Tx, &rawTx.TxResult)
 	// handle end block hash
 	case EndBlockTx:
 		// get block height by hash
@@ -432,8 +439,10 @@ func (c *Client) blockTxs(ctx context.Context, height *int64) (crgtypes.BlockTra
 	}
 
 	if len(blockResults.TxsResults) != len(blockInfo.Block.Txs) {
-		// wtf?
-		panic("block results transactions do now match block transactions")
+		return crgtypes.BlockTransactionsResponse{}, crgerrs.WrapError(
+			crgerrs.ErrUnknown,
+			fmt.Sprintf("block %d has %d txs but %d tx results", blockInfo.Block.Height, len(blockInfo.Block.Txs), len(blockResults.TxsResults)),
+		)
 	}
 	// process begin and end block txs
 	beginBlockTx := &rosettatypes.Transaction{
@@ -455,7 +464,7 @@ func (c *Client) blockTxs(ctx context.Context, height *int64) (crgtypes.BlockTra
 	deliverTx := make([]*rosettatypes.Transaction, len(blockInfo.Block.Txs))
 	// process normal txs
 	for i, tx := range blockInfo.Block.Txs {
-		rosTx, err := c.converter.ToRosetta().Tx(tx, &abcitypes.ResponseDeliverTx{})
+		rosTx, err := c.converter.ToRosetta().Tx(tx, blockResults.TxsResults[i])
 		if err != nil {
 			return crgtypes.BlockTransactionsResponse{}, err
 		}
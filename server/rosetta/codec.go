@@ -6,6 +6,7 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	authcodec "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankcodec "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingcodec "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // MakeCodec generates the codec required to interact
@@ -16,6 +17,7 @@ func MakeCodec() (*codec.ProtoCodec, codectypes.InterfaceRegistry) {
 
 	authcodec.RegisterInterfaces(ir)
 	bankcodec.RegisterInterfaces(ir)
+	stakingcodec.RegisterInterfaces(ir)
 	cryptocodec.RegisterInterfaces(ir)
 
 	return cdc, ir
@@ -186,6 +186,59 @@ func (s *ConverterTestSuite) TestOpsAndSigners() {
 	})
 }
 
+func (s *ConverterTestSuite) TestTxWithResult() {
+	addr1 := sdk.AccAddress("address1").String()
+	addr2 := sdk.AccAddress("address2").String()
+
+	builder := s.txConf.NewTxBuilder()
+	s.Require().NoError(builder.SetMsgs(&bank.MsgSend{
+		FromAddress: addr1,
+		ToAddress:   addr2,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("test", 10)),
+	}))
+	txBytes, err := s.txConf.TxEncoder()(builder.GetTx())
+	s.Require().NoError(err)
+
+	events := []abci.Event{
+		{Type: bank.EventTypeCoinSpent, Attributes: []abci.EventAttribute{
+			{Key: []byte(bank.AttributeKeySpender), Value: []byte(addr1)},
+			{Key: []byte(sdk.AttributeKeyAmount), Value: []byte("10test")},
+		}},
+		{Type: bank.EventTypeCoinReceived, Attributes: []abci.EventAttribute{
+			{Key: []byte(bank.AttributeKeyReceiver), Value: []byte(addr2)},
+			{Key: []byte(sdk.AttributeKeyAmount), Value: []byte("10test")},
+		}},
+	}
+
+	s.Run("success", func() {
+		tx, err := s.c.ToRosetta().Tx(txBytes, &abci.ExecTxResult{Events: events})
+		s.Require().NoError(err)
+		// the msg operation, then the balance operations of the events
+		s.Require().Len(tx.Operations, 3)
+		for _, op := range tx.Operations {
+			s.Require().Equal(rosetta.StatusTxSuccess, *op.Status)
+		}
+		s.Require().Equal("-10", tx.Operations[1].Amount.Value)
+		s.Require().Equal(addr1, tx.Operations[1].Account.Address)
+		s.Require().Equal("10", tx.Operations[2].Amount.Value)
+		s.Require().Equal(addr2, tx.Operations[2].Account.Address)
+	})
+
+	s.Run("reverted", func() {
+		tx, err := s.c.ToRosetta().Tx(txBytes, &abci.ExecTxResult{Code: 5})
+		s.Require().NoError(err)
+		s.Require().Len(tx.Operations, 1)
+		s.Require().Equal(rosetta.StatusTxReverted, *tx.Operations[0].Status)
+	})
+
+	s.Run("unconfirmed", func() {
+		tx, err := s.c.ToRosetta().Tx(txBytes, nil)
+		s.Require().NoError(err)
+		s.Require().Len(tx.Operations, 1)
+		s.Require().Empty(*tx.Operations[0].Status)
+	})
+}
+
 func (s *ConverterTestSuite) TestBeginEndBlockAndHashToTxType() {
 	const deliverTxHex = "5229A67AA008B5C5F1A0AEA77D4DEBE146297A30AAEF01777AF10FAD62DD36AB"
 
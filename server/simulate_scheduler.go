@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/tasks/simulator"
+)
+
+const (
+	flagSimBlocks          = "blocks"
+	flagSimTxs             = "txs"
+	flagSimConflictRate    = "conflict-rate"
+	flagSimHotKeys         = "hot-keys"
+	flagSimGas             = "gas"
+	flagSimGasDistribution = "gas-distribution"
+	flagSimWorkers         = "workers"
+	flagSimSeed            = "seed"
+)
+
+// SimulateSchedulerCmd returns a command that runs synthetic blocks through the
+// OCC scheduler and reports its speedup over sequential execution.
+func SimulateSchedulerCmd() *cobra.Command {
+	defaults := simulator.DefaultConfig()
+	cmd := &cobra.Command{
+		Use:   "simulate-scheduler",
+		Short: "Measure the speedup of the OCC scheduler on synthetic blocks",
+		Long: `Generate synthetic blocks, execute them sequentially and then with the OCC
+scheduler for every number of workers given, and report the speedup of each.
+
+Every tx writes a key of its own and, with the probability given by the conflict
+rate, increments one of the hot keys, the conflicts between txs coming from
+those. The work of a tx is a SHA-256 hash per 100 gas it uses. The results of
+the scheduler are checked against the sequential execution.
+
+A number of workers of 0 lets the scheduler adapt the workers to the conflicts,
+a negative one runs every tx in its own goroutine.
+`,
+		Example: "simulate-scheduler --txs 5000 --conflict-rate 0.3 --gas-distribution exponential --workers 4,8,16",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg := simulator.Config{}
+			var err error
+			if cfg.Blocks, err = cmd.Flags().GetInt(flagSimBlocks); err != nil {
+				return err
+			}
+			if cfg.Txs, err = cmd.Flags().GetInt(flagSimTxs); err != nil {
+				return err
+			}
+			if cfg.ConflictRate, err = cmd.Flags().GetFloat64(flagSimConflictRate); err != nil {
+				return err
+			}
+			if cfg.HotKeys, err = cmd.Flags().GetInt(flagSimHotKeys); err != nil {
+				return err
+			}
+			if cfg.GasMean, err = cmd.Flags().GetUint64(flagSimGas); err != nil {
+				return err
+			}
+			dist, err := cmd.Flags().GetString(flagSimGasDistribution)
+			if err != nil {
+				return err
+			}
+			cfg.GasDistribution = simulator.GasDistribution(dist)
+			if cfg.Workers, err = cmd.Flags().GetIntSlice(flagSimWorkers); err != nil {
+				return err
+			}
+			if cfg.Seed, err = cmd.Flags().GetInt64(flagSimSeed); err != nil {
+				return err
+			}
+
+			report, err := simulator.Run(cfg)
+			if err != nil {
+				return err
+			}
+			printSimulationReport(cmd, report)
+			return nil
+		},
+	}
+	cmd.Flags().Int(flagSimBlocks, defaults.Blocks, "Number of blocks to simulate")
+	cmd.Flags().Int(flagSimTxs, defaults.Txs, "Number of txs per block")
+	cmd.Flags().Float64(flagSimConflictRate, defaults.ConflictRate, "Probability of a tx writing a hot key, between 0 and 1")
+	cmd.Flags().Int(flagSimHotKeys, defaults.HotKeys, "Number of hot keys the conflicting txs write")
+	cmd.Flags().Uint64(flagSimGas, defaults.GasMean, "Mean gas used by a tx")
+	cmd.Flags().String(flagSimGasDistribution, string(defaults.GasDistribution), "Distribution of the gas used by the txs: constant, uniform or exponential")
+	cmd.Flags().IntSlice(flagSimWorkers, defaults.Workers, "Numbers of workers to run the scheduler with")
+	cmd.Flags().Int64(flagSimSeed, defaults.Seed, "Seed of the generation of the blocks")
+	return cmd
+}
+
+func printSimulationReport(cmd *cobra.Command, report simulator.Report) {
+	cfg := report.Config
+	cmd.Printf("%d blocks of %d txs, conflict rate %v over %d hot keys, %s gas of mean %d\n",
+		cfg.Blocks, cfg.Txs, cfg.ConflictRate, cfg.HotKeys, cfg.GasDistribution, cfg.GasMean)
+	cmd.Printf("sequential: %s\n", report.Sequential)
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKERS\tDURATION\tSPEEDUP\tINCARNATIONS\tVALIDATION FAILURES\tABORTS")
+	for _, res := range report.Results {
+		fmt.Fprintf(w, "%d\t%s\t%.2fx\t%d\t%d\t%d\n",
+			res.Workers, res.Duration, res.Speedup, res.Incarnations, res.ValidationFailures, res.Aborts)
+	}
+	w.Flush()
+}
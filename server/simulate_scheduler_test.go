@@ -0,0 +1,25 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateSchedulerCmd(t *testing.T) {
+	cmd := SimulateSchedulerCmd()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--blocks", "2", "--txs", "20", "--gas", "500", "--gas-distribution", "uniform", "--workers", "1,2"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "2 blocks of 20 txs, conflict rate 0.1 over 10 hot keys, uniform gas of mean 500")
+	require.Contains(t, out.String(), "WORKERS")
+	require.Regexp(t, `(?m)^2 +\S+ +[0-9.]+x`, out.String())
+
+	cmd = SimulateSchedulerCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--gas-distribution", "normal"})
+	require.EqualError(t, cmd.Execute(), "unknown gas distribution normal")
+}
@@ -0,0 +1,370 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/light"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagSnapshotFormat      = "format"
+	flagSnapshotS3Endpoint  = "s3-endpoint"
+	flagSnapshotS3Region    = "s3-region"
+	flagSnapshotTrustedHash = "trusted-hash"
+	flagSnapshotRestore     = "restore"
+)
+
+// SnapshotCmd returns the command exporting the state sync snapshots of the
+// node to S3-compatible object storage, and importing the exported snapshots.
+func SnapshotCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export and import state sync snapshots to and from object storage",
+	}
+	cmd.AddCommand(
+		exportSnapshotCmd(),
+		importSnapshotCmd(appCreator),
+	)
+	cmd.PersistentFlags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.PersistentFlags().String(flagSnapshotS3Endpoint, "", "Endpoint of the S3-compatible object storage, https://s3.<region>.amazonaws.com by default")
+	cmd.PersistentFlags().String(flagSnapshotS3Region, "us-east-1", "Region of the S3 bucket")
+	return cmd
+}
+
+func exportSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <s3-url> [height]",
+		Short: "Export a state sync snapshot of the node to an S3 bucket",
+		Long: `Export the state sync snapshot of the node at the given height, the latest one by
+default, to the S3 bucket and key prefix of the s3://<bucket>/<prefix> URL, under
+<prefix>/<height>/<format>. The snapshot is written as a snapshot.json object with
+its metadata, including the hash of every chunk, and an object per chunk.
+
+The credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+AWS_SESSION_TOKEN environment variables. The snapshots are read from the snapshot
+directory of --home, which must not be used by a running node.
+`,
+		Example: "snapshot export s3://snapshots/pacific-1 --s3-region eu-west-1",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var height uint64
+			if len(args) > 1 {
+				var err error
+				if height, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+					return fmt.Errorf("invalid height %s: %w", args[1], err)
+				}
+			}
+			format, err := cmd.Flags().GetUint32(flagSnapshotFormat)
+			if err != nil {
+				return err
+			}
+			client, err := s3ClientFromURL(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx := GetServerContextFromCmd(cmd)
+			store, closeStore, err := openSnapshotStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeStore()
+			snapshot, err := findSnapshot(store, height, format)
+			if err != nil {
+				return err
+			}
+
+			client = client.WithPrefix(fmt.Sprintf("%d/%d", snapshot.Height, snapshot.Format))
+			if _, err := snapshots.ExportSnapshot(cmd.Context(), store, snapshot.Height, snapshot.Format, client); err != nil {
+				return err
+			}
+			cmd.Printf("exported snapshot at height %d, format %d, of hash %X to %s\n",
+				snapshot.Height, snapshot.Format, snapshot.Hash, client.URL())
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(flagSnapshotFormat, 0, "Format of the snapshot, any format by default")
+	return cmd
+}
+
+func importSnapshotCmd(appCreator types.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [url...]",
+		Short: "Import a state sync snapshot from URLs and restore the application state from it",
+		Long: `Import the state sync snapshot exported under the given URLs, http(s) URLs or the
+s3://<bucket>/<prefix>/<height>/<format> URLs of private buckets, into the snapshot
+directory of the node, then restore the application state from it. The URLs are the
+snapshot-providers of the state-sync configuration of app.toml by default. The
+snapshot is the one of the first URL serving one, and every chunk is fetched from
+the next URL serving the same snapshot when a URL fails to serve it. Every chunk is
+checked against its hash in the snapshot metadata, and the snapshot hash against
+--trusted-hash if set, before being saved.
+
+With --restore=false, the snapshot is only imported, e.g. to be served to the peers
+state syncing. Otherwise, the application state of --home must be empty, and the
+Tendermint state and block stores must then be bootstrapped at the snapshot height
+for the node to start from it. The node must not be running.
+
+When the statesync section of config.toml sets rpc-servers, the app hash of the
+snapshot height is verified by a light client against the trusted header of its
+trust-height and trust-hash before the snapshot is restored, and the restored state
+must have that app hash, as in state sync.
+`,
+		Example: "snapshot import https://snapshots.s3.amazonaws.com/pacific-1/1000/3 https://mirror.example.com/pacific-1/1000/3 --trusted-hash <hash>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			trustedHash, err := getTrustedHash(cmd)
+			if err != nil {
+				return err
+			}
+			restore, err := cmd.Flags().GetBool(flagSnapshotRestore)
+			if err != nil {
+				return err
+			}
+			ctx := GetServerContextFromCmd(cmd)
+			urls := args
+			if len(urls) == 0 {
+				urls = ctx.Viper.GetStringSlice(FlagStateSyncSnapshotProviders)
+			}
+			if len(urls) == 0 {
+				return fmt.Errorf("no snapshot URL given nor snapshot providers configured")
+			}
+			srcs := make([]snapshots.ObjectReader, 0, len(urls))
+			for _, rawURL := range urls {
+				src, err := objectReaderFromURL(cmd, rawURL)
+				if err != nil {
+					return err
+				}
+				srcs = append(srcs, src)
+			}
+
+			store, closeStore, err := openSnapshotStore(ctx)
+			if err != nil {
+				return err
+			}
+			snapshot, err := snapshots.ImportSnapshotWithFailover(cmd.Context(), store, srcs, trustedHash)
+			// the app opens the snapshot store too
+			closeStore()
+			if err != nil {
+				return err
+			}
+			cmd.Printf("imported snapshot at height %d, format %d, of hash %X\n", snapshot.Height, snapshot.Format, snapshot.Hash)
+			if !restore {
+				return nil
+			}
+
+			appHash, err := verifiedAppHash(cmd.Context(), ctx, snapshot.Height)
+			if err != nil {
+				return err
+			}
+			if appHash == nil {
+				cmd.Printf("not verifying the app hash of the snapshot, no statesync rpc-servers are configured\n")
+			} else {
+				cmd.Printf("verified app hash %X at height %d\n", appHash, snapshot.Height)
+			}
+
+			db, err := openDB(ctx.Config.RootDir, GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			app := appCreator(ctx.Logger, db, nil, nil, ctx.Viper)
+			defer app.Close()
+			if version := app.CommitMultiStore().LastCommitID().Version; version != 0 {
+				return fmt.Errorf("cannot restore the snapshot on the application state at height %d", version)
+			}
+			if err := restoreSnapshot(cmd.Context(), app, snapshot, appHash); err != nil {
+				return err
+			}
+			cmd.Printf("restored the application state at height %d\n", snapshot.Height)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagSnapshotTrustedHash, "", "Hex hash the snapshot must have")
+	cmd.Flags().Bool(flagSnapshotRestore, true, "Restore the application state from the imported snapshot")
+	return cmd
+}
+
+// objectReaderFromURL returns the reader of the objects under the http(s) URL
+// or the s3://<bucket>/<prefix> URL.
+func objectReaderFromURL(cmd *cobra.Command, rawURL string) (snapshots.ObjectReader, error) {
+	if strings.HasPrefix(rawURL, "s3://") {
+		return s3ClientFromURL(cmd, rawURL)
+	}
+	return snapshots.NewURLObjectReader(rawURL, nil), nil
+}
+
+// verifiedAppHash returns the app hash of the state at height, verified by a
+// light client against the trusted header of the statesync section of the
+// Tendermint configuration, or nil if it sets no RPC servers.
+func verifiedAppHash(ctx context.Context, serverCtx *Context, height uint64) ([]byte, error) {
+	cfg := serverCtx.Config.StateSync
+	if len(cfg.RPCServers) == 0 {
+		return nil, nil
+	}
+	trustHash, err := hex.DecodeString(cfg.TrustHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid statesync trust-hash %s: %w", cfg.TrustHash, err)
+	}
+	trustOptions := light.TrustOptions{Period: cfg.TrustPeriod, Height: cfg.TrustHeight, Hash: trustHash}
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid statesync trust options: %w", err)
+	}
+	genDoc, err := tmtypes.GenesisDocFromFile(serverCtx.Config.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	stateProvider, err := light.NewRPCStateProvider(ctx, genDoc.ChainID, genDoc.InitialHeight,
+		cfg.VerifyLightBlockTimeout, cfg.RPCServers, trustOptions, serverCtx.Logger, cfg.BlacklistTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up the light client: %w", err)
+	}
+	// the app hash of the state at height is the one of the header at height+1
+	appHash, err := stateProvider.AppHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the app hash at height %d: %w", height, err)
+	}
+	return appHash, nil
+}
+
+func getTrustedHash(cmd *cobra.Command) ([]byte, error) {
+	trustedHash, err := cmd.Flags().GetString(flagSnapshotTrustedHash)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := hex.DecodeString(trustedHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted hash %s: %w", trustedHash, err)
+	}
+	return hash, nil
+}
+
+// s3ClientFromURL returns the client of the bucket and key prefix of the
+// s3://<bucket>/<prefix> URL.
+func s3ClientFromURL(cmd *cobra.Command, rawURL string) (*snapshots.S3Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 URL %s, expected s3://<bucket>/<prefix>", rawURL)
+	}
+	endpoint, err := cmd.Flags().GetString(flagSnapshotS3Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	region, err := cmd.Flags().GetString(flagSnapshotS3Region)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	return snapshots.NewS3Client(snapshots.S3Config{
+		Endpoint:        endpoint,
+		Region:          region,
+		Bucket:          u.Host,
+		Prefix:          u.Path,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil)
+}
+
+// openSnapshotStore opens the snapshot store of the node, which must be
+// closed with the returned function.
+func openSnapshotStore(ctx *Context) (*snapshots.Store, func(), error) {
+	dir := ctx.Viper.GetString(FlagStateSyncSnapshotDir)
+	if dir == "" {
+		dir = filepath.Join(ctx.Config.RootDir, "data", "snapshots")
+	}
+	db, err := sdk.NewDB("metadata", GetAppDBBackend(ctx.Viper), dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := snapshots.NewStore(db, dir)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return store, func() { db.Close() }, nil
+}
+
+// findSnapshot returns the snapshot of the store at height, the latest one if
+// 0, and of format, any format if 0.
+func findSnapshot(store *snapshots.Store, height uint64, format uint32) (*snapshottypes.Snapshot, error) {
+	list, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	// the snapshots are listed newest first
+	for _, snapshot := range list {
+		if (height == 0 || snapshot.Height == height) && (format == 0 || snapshot.Format == format) {
+			return snapshot, nil
+		}
+	}
+	if height == 0 {
+		return nil, fmt.Errorf("no snapshot found")
+	}
+	return nil, fmt.Errorf("no snapshot found at height %d", height)
+}
+
+// restoreSnapshot restores the state of app from its snapshot, the way state
+// sync does, and checks that the restored state has appHash unless it is nil.
+func restoreSnapshot(ctx context.Context, app abci.Application, snapshot *snapshottypes.Snapshot, appHash []byte) error {
+	abciSnapshot, err := snapshot.ToABCI()
+	if err != nil {
+		return err
+	}
+	offer, err := app.OfferSnapshot(ctx, &abci.RequestOfferSnapshot{Snapshot: &abciSnapshot, AppHash: appHash})
+	if err != nil {
+		return err
+	}
+	if offer.Result != abci.ResponseOfferSnapshot_ACCEPT {
+		return fmt.Errorf("the application did not accept the snapshot: %s", offer.Result)
+	}
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		chunk, err := app.LoadSnapshotChunk(ctx, &abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  index,
+		})
+		if err != nil {
+			return err
+		}
+		if chunk.Chunk == nil {
+			return fmt.Errorf("failed to load snapshot chunk %d", index)
+		}
+		res, err := app.ApplySnapshotChunk(ctx, &abci.RequestApplySnapshotChunk{Index: index, Chunk: chunk.Chunk})
+		if err != nil {
+			return err
+		}
+		if res.Result != abci.ResponseApplySnapshotChunk_ACCEPT {
+			return fmt.Errorf("the application did not accept snapshot chunk %d: %s", index, res.Result)
+		}
+	}
+	if appHash == nil {
+		return nil
+	}
+	info, err := app.Info(ctx, &abci.RequestInfo{})
+	if err != nil {
+		return err
+	}
+	if uint64(info.LastBlockHeight) != snapshot.Height || !bytes.Equal(info.LastBlockAppHash, appHash) {
+		return fmt.Errorf("restored state at height %d with app hash %X, expected height %d and app hash %X",
+			info.LastBlockHeight, info.LastBlockAppHash, snapshot.Height, appHash)
+	}
+	return nil
+}
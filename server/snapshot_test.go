@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+)
+
+func TestFindSnapshot(t *testing.T) {
+	store, err := snapshots.NewStore(db.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	for _, s := range []struct {
+		height uint64
+		format uint32
+	}{{1, 1}, {2, 1}, {2, 2}} {
+		ch := make(chan io.ReadCloser, 1)
+		ch <- ioutil.NopCloser(bytes.NewReader([]byte{1}))
+		close(ch)
+		_, err := store.Save(s.height, s.format, ch)
+		require.NoError(t, err)
+	}
+
+	snapshot, err := findSnapshot(store, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), snapshot.Height)
+	require.Equal(t, uint32(2), snapshot.Format)
+	snapshot, err = findSnapshot(store, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), snapshot.Format)
+	snapshot, err = findSnapshot(store, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), snapshot.Height)
+	_, err = findSnapshot(store, 3, 0)
+	require.EqualError(t, err, "no snapshot found at height 3")
+}
+
+func TestS3ClientFromURL(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	cmd := SnapshotCmd(nil, t.TempDir())
+	export, _, err := cmd.Find([]string{"export"})
+	require.NoError(t, err)
+	require.NoError(t, export.ParseFlags([]string{"--" + flagSnapshotS3Region, "eu-west-1"}))
+
+	client, err := s3ClientFromURL(export, "s3://bucket/chain/")
+	require.NoError(t, err)
+	require.Equal(t, "https://s3.eu-west-1.amazonaws.com/bucket/chain", client.URL())
+
+	for _, rawURL := range []string{"https://bucket/chain", "s3:///chain"} {
+		_, err = s3ClientFromURL(export, rawURL)
+		require.Error(t, err)
+	}
+	_, err = s3ClientFromURL(&cobra.Command{}, "s3://bucket")
+	require.Error(t, err)
+}
+
+// restoringApp is an application accepting any snapshot, whose state is then
+// at the snapshot height with appHash.
+type restoringApp struct {
+	abci.BaseApplication
+	offered *abci.RequestOfferSnapshot
+	applied []uint32
+	appHash []byte
+}
+
+func (app *restoringApp) OfferSnapshot(_ context.Context, req *abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	app.offered = req
+	return &abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil
+}
+
+func (app *restoringApp) LoadSnapshotChunk(_ context.Context, req *abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	return &abci.ResponseLoadSnapshotChunk{Chunk: []byte{byte(req.Chunk)}}, nil
+}
+
+func (app *restoringApp) ApplySnapshotChunk(_ context.Context, req *abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	app.applied = append(app.applied, req.Index)
+	return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil
+}
+
+func (app *restoringApp) Info(context.Context, *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	return &abci.ResponseInfo{LastBlockHeight: int64(app.offered.Snapshot.Height), LastBlockAppHash: app.appHash}, nil
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	ctx := context.Background()
+	snapshot := &snapshottypes.Snapshot{Height: 3, Format: 1, Chunks: 2, Hash: []byte{1}}
+	app := &restoringApp{appHash: []byte{2}}
+
+	// without a verified app hash, the restored state is not checked
+	require.NoError(t, restoreSnapshot(ctx, app, snapshot, nil))
+	require.Equal(t, []uint32{0, 1}, app.applied)
+	require.Nil(t, app.offered.AppHash)
+
+	require.NoError(t, restoreSnapshot(ctx, app, snapshot, []byte{2}))
+	require.Equal(t, []byte{2}, app.offered.AppHash)
+	err := restoreSnapshot(ctx, app, snapshot, []byte{3})
+	require.EqualError(t, err, "restored state at height 3 with app hash 02, expected height 3 and app hash 03")
+}
+
+func TestVerifiedAppHash(t *testing.T) {
+	serverCtx := NewDefaultContext()
+	appHash, err := verifiedAppHash(context.Background(), serverCtx, 3)
+	require.NoError(t, err)
+	require.Nil(t, appHash)
+
+	// the trust options are checked before the light client is set up
+	serverCtx.Config.StateSync.RPCServers = []string{"localhost:26657", "localhost:36657"}
+	serverCtx.Config.StateSync.TrustHash = "zz"
+	_, err = verifiedAppHash(context.Background(), serverCtx, 3)
+	require.ErrorContains(t, err, "invalid statesync trust-hash")
+}
@@ -14,7 +14,6 @@ import (
 	clientconfig "github.com/cosmos/cosmos-sdk/client/config"
 
 	"github.com/spf13/cobra"
-	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/server"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	"github.com/tendermint/tendermint/libs/service"
@@ -33,6 +32,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/server/api"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	servergrpc "github.com/cosmos/cosmos-sdk/server/grpc"
+	"github.com/cosmos/cosmos-sdk/server/ratelimit"
 	"github.com/cosmos/cosmos-sdk/server/rosetta"
 	crgserver "github.com/cosmos/cosmos-sdk/server/rosetta/lib/server"
 	"github.com/cosmos/cosmos-sdk/server/types"
@@ -43,38 +43,77 @@ import (
 
 const (
 	// Tendermint full-node start flags
-	flagWithTendermint     = "with-tendermint"
-	flagAddress            = "address"
-	flagTransport          = "transport"
-	flagTraceStore         = "trace-store"
-	flagCPUProfile         = "cpu-profile"
-	FlagMinGasPrices       = "minimum-gas-prices"
-	FlagHaltHeight         = "halt-height"
-	FlagHaltTime           = "halt-time"
-	FlagInterBlockCache    = "inter-block-cache"
-	FlagUnsafeSkipUpgrades = "unsafe-skip-upgrades"
-	FlagTrace              = "trace"
-	FlagProfile            = "profile"
-	FlagInvCheckPeriod     = "inv-check-period"
+	flagWithTendermint      = "with-tendermint"
+	flagAddress             = "address"
+	flagTransport           = "transport"
+	flagTraceStore          = "trace-store"
+	flagCPUProfile          = "cpu-profile"
+	FlagMinGasPrices        = "minimum-gas-prices"
+	FlagHaltHeight          = "halt-height"
+	FlagHaltTime            = "halt-time"
+	FlagInterBlockCache     = "inter-block-cache"
+	FlagInterBlockCacheSize = "inter-block-cache-size"
+	FlagUnsafeSkipUpgrades  = "unsafe-skip-upgrades"
+	FlagTrace               = "trace"
+	FlagProfile             = "profile"
+	FlagInvCheckPeriod      = "inv-check-period"
 
 	FlagPruning                      = "pruning"
 	FlagPruningKeepRecent            = "pruning-keep-recent"
 	FlagPruningKeepEvery             = "pruning-keep-every"
 	FlagPruningInterval              = "pruning-interval"
+	FlagPruningBackgroundInterval    = "pruning-background-interval"
+	FlagPruningBatchSize             = "pruning-batch-size"
 	FlagIndexEvents                  = "index-events"
 	FlagMinRetainBlocks              = "min-retain-blocks"
 	FlagIAVLCacheSize                = "iavl-cache-size"
 	FlagIAVLFastNode                 = "iavl-disable-fastnode"
+	FlagIAVLFastNodeMigration        = "iavl-fastnode-migration"
+	FlagIAVLFastNodeMigrationRate    = "iavl-fastnode-migration-rate"
 	FlagCompactionInterval           = "compaction-interval"
+	FlagAppDBBackend                 = "app-db-backend"
+	FlagAsyncCommitBuffer            = "async-commit-buffer"
+	FlagPipelinedCommit              = "pipelined-commit"
+	FlagHistoricalStoreCacheSize     = "historical-store-cache-size"
+	FlagOccEnabled                   = "occ-enabled"
+	FlagConcurrencyWorkers           = "concurrency-workers"
+	FlagOptimisticProcessing         = "optimistic-processing"
+	FlagConcurrentCheckTx            = "concurrent-check-tx"
+	FlagSignatureCacheSize           = "signature-cache-size"
+	FlagPriorityMempool              = "priority-mempool"
+	FlagMempoolMaxBytes              = "mempool-max-bytes"
+	FlagMempoolMaxTxsPerSender       = "mempool-max-txs-per-sender"
+	FlagMempoolMaxBytesPerSender     = "mempool-max-bytes-per-sender"
+	FlagMempoolTTLNumBlocks          = "mempool-ttl-num-blocks"
+	FlagMempoolTTL                   = "mempool-ttl"
+	FlagMempoolSequenceWindow        = "mempool-sequence-window"
+	FlagTxTracing                    = "tx-tracing"
+	FlagTxAccessHintsCacheSize       = "tx-access-hints-cache-size"
+	FlagTxResourceEvents             = "tx-resource-events"
+	FlagMaxEventsPerTx               = "max-events-per-tx"
+	FlagMaxEventAttributes           = "max-event-attributes"
+	FlagSlowBlockProfileThreshold    = "slow-block-profile-threshold"
+	FlagSlowBlockProfileDir          = "slow-block-profile-dir"
+	FlagSerializeABCICalls           = "serialize-abci-calls"
+	FlagAnteDisabledDecorators       = "ante-disabled-decorators"
+	FlagQueryGasLimit                = "query-gas-limit"
+	FlagQueryGasLimits               = "query-gas-limits"
+	FlagMaxQueryDepth                = "max-query-depth"
+	FlagConfigReloadInterval         = "config-reload-interval"
+	FlagProposalTxBatching           = "proposal-tx-batching"
+	FlagProposalMaxBatchSize         = "proposal-max-batch-size"
 	FlagSeparateOrphanStorage        = "separate-orphan-storage"
 	FlagSeparateOrphanVersionsToKeep = "separate-orphan-versions-to-keep"
 	FlagNumOrphanPerFile             = "num-orphan-per-file"
 	FlagOrphanDirectory              = "orphan-dir"
 
 	// state sync-related flags
-	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
-	FlagStateSyncSnapshotKeepRecent = "state-sync.snapshot-keep-recent"
-	FlagStateSyncSnapshotDir        = "state-sync.snapshot-directory"
+	FlagStateSyncSnapshotInterval    = "state-sync.snapshot-interval"
+	FlagStateSyncSnapshotKeepRecent  = "state-sync.snapshot-keep-recent"
+	FlagStateSyncSnapshotDir         = "state-sync.snapshot-directory"
+	FlagStateSyncSnapshotCompression = "state-sync.snapshot-compression"
+	FlagStateSyncSnapshotChunkSize   = "state-sync.snapshot-chunk-size"
+	FlagStateSyncSnapshotProviders   = "state-sync.snapshot-providers"
 
 	// gRPC-related flags
 	flagGRPCOnly       = "grpc-only"
@@ -237,17 +276,53 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Block height at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Uint64(FlagHaltTime, 0, "Minimum block time (in Unix seconds) at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Bool(FlagInterBlockCache, true, "Enable inter-block caching")
+	cmd.Flags().Uint64(FlagInterBlockCacheSize, 32<<20, "Size in bytes of the inter-block cache of each store, 0 for the default")
 	cmd.Flags().String(flagCPUProfile, "", "Enable CPU profiling and write to the provided file")
 	cmd.Flags().Bool(FlagTrace, false, "Provide full stack traces for errors in ABCI Log")
 	cmd.Flags().Bool(tracing.FlagTracing, false, "Enable Tracing for the app")
+	cmd.Flags().String(tracing.FlagTracingExporter, tracing.ExporterJaeger, "Exporter of the app spans when tracing is enabled (jaeger|otlp)")
+	cmd.Flags().String(tracing.FlagTracingEndpoint, "", "Collector endpoint the app spans are exported to, the default endpoint of the exporter if empty")
 	cmd.Flags().Bool(FlagProfile, false, "Enable Profiling in the application")
 	cmd.Flags().String(FlagPruning, storetypes.PruningOptionDefault, "Pruning strategy (default|nothing|everything|custom)")
 	cmd.Flags().Uint64(FlagPruningKeepRecent, 0, "Number of recent heights to keep on disk (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningKeepEvery, 0, "Offset heights to keep on disk after 'keep-every' (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningInterval, 0, "Height interval at which pruned heights are removed from disk (ignored if pruning is not 'custom')")
+	cmd.Flags().Uint64(FlagPruningBackgroundInterval, 0, "Time interval in seconds between runs of the background pruner, 0 means pruning during commit")
+	cmd.Flags().Int(FlagPruningBatchSize, 0, "Maximum number of heights deleted per background pruning run, 0 means no limit")
 	cmd.Flags().Uint(FlagInvCheckPeriod, 0, "Assert registered invariants every N blocks")
 	cmd.Flags().Uint64(FlagMinRetainBlocks, 0, "Minimum block height offset during ABCI commit to prune Tendermint blocks")
 	cmd.Flags().Uint64(FlagCompactionInterval, 0, "Time interval in between forced levelDB compaction. 0 means no forced compaction.")
+	cmd.Flags().String(FlagAppDBBackend, "", "The type of database for application and snapshots databases (goleveldb|cleveldb|rocksdb|boltdb|badgerdb|pebbledb)")
+	cmd.Flags().Int(FlagAsyncCommitBuffer, 0, "Number of committed versions that may wait to be written to the application database, 0 means synchronous commits")
+	cmd.Flags().Bool(FlagPipelinedCommit, false, "Resume CheckTx while the committed block is written to the stores in the background")
+	cmd.Flags().Int(FlagHistoricalStoreCacheSize, 10, "Number of past heights whose stores are kept open for queries, 0 loads them for every query")
+	cmd.Flags().Bool(FlagOccEnabled, false, "Execute the txs of a block concurrently with optimistic concurrency control")
+	cmd.Flags().Int(FlagConcurrencyWorkers, 0, "Number of workers executing the txs of a block with OCC enabled, 0 uses one per CPU")
+	cmd.Flags().Bool(FlagOptimisticProcessing, false, "Execute the block of an accepted proposal before it is finalized")
+	cmd.Flags().Bool(FlagConcurrentCheckTx, false, "Run CheckTx concurrently for txs that do not share a sender")
+	cmd.Flags().Int(FlagSignatureCacheSize, 20000, "Number of signatures verified by CheckTx kept for DeliverTx, 0 disables the cache")
+	cmd.Flags().Bool(FlagPriorityMempool, false, "Propose the txs of the mempool by priority, keeping the txs of a sender in nonce order")
+	cmd.Flags().Int64(FlagMempoolMaxBytes, 0, "Maximum total size of the txs of the priority mempool, 0 for no limit")
+	cmd.Flags().Int(FlagMempoolMaxTxsPerSender, 0, "Maximum number of txs of a sender in the priority mempool, 0 for no limit")
+	cmd.Flags().Int64(FlagMempoolMaxBytesPerSender, 0, "Maximum total size of the txs of a sender in the priority mempool, 0 for no limit")
+	cmd.Flags().Uint64(FlagMempoolTTLNumBlocks, 0, "Number of blocks after which a tx of the priority mempool is evicted, 0 to keep the txs")
+	cmd.Flags().Duration(FlagMempoolTTL, 0, "Time after which a tx of the priority mempool is evicted, 0 to keep the txs")
+	cmd.Flags().Uint64(FlagMempoolSequenceWindow, 0, "Number of sequences from the sequence of a sender that the txs of the priority mempool may be signed with, 0 requiring the exact sequence")
+	cmd.Flags().Bool(FlagTxTracing, false, "Enable the endpoint re-executing committed txs to trace them")
+	cmd.Flags().Bool(FlagTxResourceEvents, false, "Add to the response of every delivered tx an event with its store accesses and executions")
+	cmd.Flags().Int(FlagMaxEventsPerTx, 0, "Maximum number of events kept per tx, 0 for no limit")
+	cmd.Flags().Int(FlagMaxEventAttributes, 0, "Maximum number of attributes kept per event of a tx, 0 for no limit")
+	cmd.Flags().Int(FlagTxAccessHintsCacheSize, 0, "Number of txs whose access operations declared by their senders are kept for the scheduler, 0 disables the endpoint declaring them")
+	cmd.Flags().Uint64(FlagSlowBlockProfileThreshold, 0, "Processing time in milliseconds past which the CPU and heap profiles of a block are captured, 0 disables the profiles")
+	cmd.Flags().String(FlagSlowBlockProfileDir, "", "Directory the profiles of the slow blocks are written to, defaults to <home>/data/profiles")
+	cmd.Flags().Bool(FlagSerializeABCICalls, false, "Make one ABCI call at a time, the queries waiting for the block being executed")
+	cmd.Flags().StringSlice(FlagAnteDisabledDecorators, []string{}, "Names of the ante decorators skipped by CheckTx")
+	cmd.Flags().Uint64(FlagQueryGasLimit, 0, "Gas limit of the ABCI and gRPC queries, 0 for no limit")
+	cmd.Flags().StringSlice(FlagQueryGasLimits, []string{}, "Gas limits of the queries to some modules, as module=limit")
+	cmd.Flags().Int(FlagMaxQueryDepth, 10, "Maximum number of queries nested while handling a query, 0 for no limit")
+	cmd.Flags().Duration(FlagConfigReloadInterval, 10*time.Second, "Interval at which the config files are checked for changes to the knobs applied without a restart, 0 to only reload them on SIGHUP")
+	cmd.Flags().Bool(FlagProposalTxBatching, false, "Order the txs of the proposed blocks in batches of txs with no conflicting declared resources")
+	cmd.Flags().Int(FlagProposalMaxBatchSize, 0, "Maximum number of txs of a batch of a proposed block, 0 for no limit")
 	cmd.Flags().Bool(FlagSeparateOrphanStorage, false, "Whether to store orphans outside main application levelDB")
 	cmd.Flags().Int64(FlagSeparateOrphanVersionsToKeep, 2, "Number of versions to keep if storing orphans separately")
 	cmd.Flags().Int(FlagNumOrphanPerFile, 100000, "Number of orphans to store on each file if storing orphans separately")
@@ -262,12 +337,16 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 
 	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval")
 	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "State sync snapshot to keep")
+	cmd.Flags().String(FlagStateSyncSnapshotCompression, "zlib", "State sync snapshot compression (zlib|zstd)")
+	cmd.Flags().Uint64(FlagStateSyncSnapshotChunkSize, 0, "State sync snapshot chunk size in bytes, 0 uses the default of 10 MB")
 
 	cmd.Flags().Int64(FlagArchivalVersion, 0, "Application data before this version is stored in archival DB")
 	cmd.Flags().String(FlagArchivalDBType, "", "Archival DB type. Valid options: arweave")
 	cmd.Flags().String(FlagArchivalArweaveIndexDBFullPath, "", "Full local path to the levelDB used for indexing arweave data")
 	cmd.Flags().String(FlagArchivalArweaveNodeURL, "", "Arweave Node URL that stores archived data")
 	cmd.Flags().Bool(FlagIAVLFastNode, true, "Enable fast node for IAVL tree")
+	cmd.Flags().String(FlagIAVLFastNodeMigration, "auto", "Migration of the IAVL stores to fast nodes on startup: auto, defer or force")
+	cmd.Flags().Int(FlagIAVLFastNodeMigrationRate, 0, "Maximum number of fast nodes written per second by a migration, 0 for no limit")
 
 	cmd.Flags().String(FlagChainID, "", "Chain ID")
 
@@ -281,7 +360,7 @@ func startStandAlone(ctx *Context, appCreator types.AppCreator) error {
 	transport := ctx.Viper.GetString(flagTransport)
 	home := ctx.Viper.GetString(flags.FlagHome)
 
-	db, err := openDB(home)
+	db, err := openDB(home, GetAppDBBackend(ctx.Viper))
 	if err != nil {
 		return err
 	}
@@ -293,6 +372,7 @@ func startStandAlone(ctx *Context, appCreator types.AppCreator) error {
 	}
 
 	app := appCreator(ctx.Logger, db, traceWriter, nil, ctx.Viper)
+	warmUpApp(ctx, app)
 
 	svr, err := server.NewServer(ctx.Logger.With("module", "abci-server"), addr, transport, app)
 	if err != nil {
@@ -349,7 +429,7 @@ func startInProcess(
 	}
 
 	traceWriterFile := ctx.Viper.GetString(flagTraceStore)
-	db, err := openDB(home)
+	db, err := openDB(home, GetAppDBBackend(ctx.Viper))
 	if err != nil {
 		return err
 	}
@@ -370,6 +450,7 @@ func startInProcess(
 			"(SDK v0.45). Please explicitly put the desired minimum-gas-prices in your app.toml.")
 	}
 	app := appCreator(ctx.Logger, db, traceWriter, ctx.Config, ctx.Viper)
+	warmUpApp(ctx, app)
 
 	var (
 		tmNode    service.Service
@@ -389,7 +470,7 @@ func startInProcess(
 			ctx.Config,
 			ctx.Logger,
 			restartCh,
-			abciclient.NewLocalClient(ctx.Logger, app),
+			newLocalABCIClient(ctx.Logger, app, ctx.Viper.GetBool(FlagSerializeABCICalls)),
 			nil,
 			tracerProviderOptions,
 			nodeMetricsProvider,
@@ -416,10 +497,19 @@ func startInProcess(
 		app.RegisterTendermintService(clientCtx)
 	}
 
+	// the API and gRPC servers share the cap on the queries served at once
+	queryLimiter, err := ratelimit.NewLimiter(config.QueryLimits)
+	if err != nil {
+		return err
+	}
+
+	go newConfigReloader(ctx, config, queryLimiter, app).run(goCtx, config.ConfigReloadInterval)
+
 	var apiSrv *api.Server
 	if config.API.Enable {
 		clientCtx := clientCtx.WithHomeDir(home).WithChainID(clientCtx.ChainID)
 		apiSrv = api.New(clientCtx, ctx.Logger.With("module", "api-server"))
+		apiSrv.SetQueryLimiter(queryLimiter)
 		app.RegisterAPIRoutes(apiSrv, config.API)
 		errCh := make(chan error)
 
@@ -443,7 +533,11 @@ func startInProcess(
 	)
 
 	if config.GRPC.Enable {
-		grpcSrv, err = servergrpc.StartGRPCServer(clientCtx, app, config.GRPC.Address)
+		grpcSrv, err = servergrpc.StartGRPCServer(
+			clientCtx, app, config.GRPC,
+			grpc.ChainUnaryInterceptor(queryLimiter.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(queryLimiter.StreamServerInterceptor()),
+		)
 		if err != nil {
 			return err
 		}
@@ -536,3 +630,22 @@ func startInProcess(
 	// wait for signal capture and gracefully return
 	return WaitForQuitSignals(ctx, restartCh, canRestartAfter)
 }
+
+// WarmUpper is implemented by the apps loading the state they use the most in
+// their caches before the node serves its first requests, such as the ones
+// embedding a BaseApp, see baseapp.BaseApp.WarmUp.
+type WarmUpper interface {
+	WarmUp() error
+}
+
+// warmUpApp warms up the app if it supports it. A failure to warm up is only
+// logged, as the caches then fill up as the node serves its requests.
+func warmUpApp(ctx *Context, app types.Application) {
+	warmUpper, ok := app.(WarmUpper)
+	if !ok {
+		return
+	}
+	if err := warmUpper.WarmUp(); err != nil {
+		ctx.Logger.Error("failed to warm up the app", "err", err)
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/snapshots"
+)
+
+// StateExporter is implemented by the apps whose state can be exported and
+// imported by StateCmd, such as the ones embedding a BaseApp.
+type StateExporter interface {
+	ExportState(w io.Writer) (snapshots.StateHeader, error)
+	ImportState(r io.Reader) (snapshots.StateHeader, error)
+}
+
+// StateCmd returns the command exporting the application state to a file in
+// a binary format preserving the IAVL trees of the stores, and importing it.
+func StateCmd(appCreator types.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Export and import the application state in a binary format",
+		Long: `Export and import the application state in a binary format preserving the IAVL
+trees of the stores, much faster to export and import than a JSON genesis, e.g.
+to spin up a devnet from the state of a network or to recover a node.`,
+	}
+	cmd.AddCommand(
+		exportStateCmd(appCreator),
+		importStateCmd(appCreator),
+	)
+	cmd.PersistentFlags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	return cmd
+}
+
+func exportStateCmd(appCreator types.AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <file>",
+		Short: "Export the application state committed at the last height to a file",
+		Long: `Export the application state committed at the last height to the given file,
+with the height and app hash of the state. The state is read from the application
+database of --home, which must not be used by a running node.
+`,
+		Example: "state export state.bin --home ~/.simapp",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := GetServerContextFromCmd(cmd)
+			exporter, closeApp, err := openStateExporter(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			defer closeApp()
+
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			header, err := exporter.ExportState(f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(args[0])
+				return fmt.Errorf("failed to export state: %w", err)
+			}
+			cmd.Printf("exported the state at height %d, of app hash %X, to %s\n", header.Height, header.AppHash, args[0])
+			return nil
+		},
+	}
+}
+
+func importStateCmd(appCreator types.AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import the application state from a file exported by state export",
+		Long: `Import the application state from the given file, exported by state export, and
+check that it results in the app hash it was exported with. The application state
+of --home must be empty, and the Tendermint state and block stores must then be
+bootstrapped at the height of the state for the node to start from it. The node
+must not be running.
+`,
+		Example: "state import state.bin --home ~/.simapp",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			ctx := GetServerContextFromCmd(cmd)
+			exporter, closeApp, err := openStateExporter(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			defer closeApp()
+
+			header, err := exporter.ImportState(f)
+			if err != nil {
+				return fmt.Errorf("failed to import state: %w", err)
+			}
+			cmd.Printf("imported the state at height %d, of app hash %X\n", header.Height, header.AppHash)
+			return nil
+		},
+	}
+}
+
+// openStateExporter creates the app on the application database of the node,
+// which must be closed with the returned function.
+func openStateExporter(ctx *Context, appCreator types.AppCreator) (StateExporter, func(), error) {
+	db, err := openDB(ctx.Config.RootDir, GetAppDBBackend(ctx.Viper))
+	if err != nil {
+		return nil, nil, err
+	}
+	app := appCreator(ctx.Logger, db, nil, nil, ctx.Viper)
+	exporter, ok := app.(StateExporter)
+	if !ok {
+		app.Close()
+		return nil, nil, fmt.Errorf("the application does not support exporting its state")
+	}
+	return exporter, func() { app.Close() }, nil
+}
@@ -14,6 +14,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
+	googlegrpc "google.golang.org/grpc"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server/api"
@@ -62,6 +63,22 @@ type (
 		Close() error
 	}
 
+	// GRPCInterceptorsProvider is implemented by the applications registering
+	// interceptors of the calls to the gRPC server, such as the ones embedding
+	// a BaseApp. They are chained after the interceptors of the server.
+	GRPCInterceptorsProvider interface {
+		GRPCUnaryInterceptors() []googlegrpc.UnaryServerInterceptor
+		GRPCStreamInterceptors() []googlegrpc.StreamServerInterceptor
+	}
+
+	// ConfigReloader is implemented by the applications applying some knobs of
+	// app.toml while they run, such as the number of workers of the scheduler.
+	// ReloadConfig is called with the configuration each time the server
+	// reloads it, and ignores the knobs that need a restart.
+	ConfigReloader interface {
+		ReloadConfig(cfg config.Config) error
+	}
+
 	// AppCreator is a function that allows us to lazily initialize an
 	// application using various configurations.
 	AppCreator func(log.Logger, dbm.DB, io.Writer, *tmcfg.Config, AppOptions) Application
@@ -74,6 +91,9 @@ type (
 	ExportedApp struct {
 		// AppState is the application state as JSON.
 		AppState json.RawMessage
+		// WriteAppState, if set instead of AppState, writes the application
+		// state as JSON to the given writer, one module at a time.
+		WriteAppState func(io.Writer) error
 		// Validators is the exported validator set.
 		Validators []tmtypes.GenesisValidator
 		// Height is the app's latest block height.
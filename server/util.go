@@ -14,8 +14,8 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -62,7 +62,7 @@ func NewDefaultContext() *Context {
 	return NewContext(
 		viper.New(),
 		tmcfg.DefaultConfig(),
-		ZeroLogWrapper{log.Logger},
+		ZeroLogWrapper{Logger: log.Logger},
 	)
 }
 
@@ -167,28 +167,19 @@ func InterceptConfigsPreRunHandler(cmd *cobra.Command, customAppConfigTemplate s
 		return err
 	}
 
-	var logWriter io.Writer
-	logLvlFormat := serverCtx.Viper.GetString(flags.FlagLogFormat)
-	if logLvlFormat == "" {
-		logLvlFormat = serverCtx.Config.LogFormat
+	logFormat := serverCtx.Viper.GetString(flags.FlagLogFormat)
+	if logFormat == "" {
+		logFormat = serverCtx.Config.LogFormat
 	}
-	if strings.ToLower(logLvlFormat) == tmlog.LogFormatPlain {
-		logWriter = zerolog.ConsoleWriter{Out: os.Stderr}
-	} else {
-		logWriter = os.Stderr
-	}
-
 	logLvlStr := serverCtx.Viper.GetString(flags.FlagLogLevel)
 	if logLvlStr == "" {
 		logLvlStr = serverCtx.Config.LogLevel
 	}
-	logLvl, err := zerolog.ParseLevel(logLvlStr)
+	serverCtx.Logger, err = NewZeroLogger(logFormat, logLvlStr, os.Stderr)
 	if err != nil {
-		return fmt.Errorf("failed to parse log level (%s): %w", logLvlStr, err)
+		return err
 	}
 
-	serverCtx.Logger = ZeroLogWrapper{zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger()}
-
 	return SetCmdServerContext(cmd, serverCtx)
 }
 
@@ -351,6 +342,10 @@ func AddCommands(
 		version.NewVersionCommand(),
 		NewRollbackCmd(appCreator, defaultNodeHome),
 		LatestVersionCmd(defaultNodeHome),
+		MigrateAppDBCmd(defaultNodeHome),
+		CompactAppDBCmd(defaultNodeHome),
+		ExportKeysCmd(appCreator, defaultNodeHome),
+		SimulateSchedulerCmd(),
 	)
 }
 
@@ -458,13 +453,19 @@ func addrToIP(addr net.Addr) net.IP {
 	return ip
 }
 
-func OpenDB(rootDir string) (dbm.DB, error) {
-	return openDB(rootDir)
+func OpenDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	return openDB(rootDir, backendType)
 }
 
-func openDB(rootDir string) (dbm.DB, error) {
+func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
 	dataDir := filepath.Join(rootDir, "data")
-	return sdk.NewLevelDB("application", dataDir)
+	return sdk.NewDB("application", backendType, dataDir)
+}
+
+// GetAppDBBackend returns the app-db-backend set in opts. It is empty if it is
+// not set, which makes sdk.NewDB use the compile-time default backend.
+func GetAppDBBackend(opts types.AppOptions) dbm.BackendType {
+	return dbm.BackendType(cast.ToString(opts.Get(FlagAppDBBackend)))
 }
 
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
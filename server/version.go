@@ -28,7 +28,7 @@ func LatestVersionCmd(defaultNodeHome string) *cobra.Command {
 				return err
 			}
 
-			db, err := openDB(config.RootDir)
+			db, err := openDB(config.RootDir, GetAppDBBackend(serverCtx.Viper))
 			if err != nil {
 				return err
 			}
@@ -1,7 +1,6 @@
 package simapp
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	gogogrpc "github.com/gogo/protobuf/grpc"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cast"
@@ -24,17 +24,20 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/server/api"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	"github.com/cosmos/cosmos-sdk/store/streaming"
+	"github.com/cosmos/cosmos-sdk/store/streaming/blockresults"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/utils"
 	"github.com/cosmos/cosmos-sdk/version"
 	aclmodule "github.com/cosmos/cosmos-sdk/x/accesscontrol"
+	aclclient "github.com/cosmos/cosmos-sdk/x/accesscontrol/client"
 	aclkeeper "github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
 	acltestutil "github.com/cosmos/cosmos-sdk/x/accesscontrol/testutil"
 	acltypes "github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
@@ -51,11 +54,17 @@ import (
 	authzkeeper "github.com/cosmos/cosmos-sdk/x/authz/keeper"
 	authzmodule "github.com/cosmos/cosmos-sdk/x/authz/module"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	bankclient "github.com/cosmos/cosmos-sdk/x/bank/client"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	bankstreaming "github.com/cosmos/cosmos-sdk/x/bank/streaming"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/capability"
 	capabilitykeeper "github.com/cosmos/cosmos-sdk/x/capability/keeper"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/cosmos/cosmos-sdk/x/circuit"
+	circuitclient "github.com/cosmos/cosmos-sdk/x/circuit/client"
+	circuitkeeper "github.com/cosmos/cosmos-sdk/x/circuit/keeper"
+	circuittypes "github.com/cosmos/cosmos-sdk/x/circuit/types"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	crisiskeeper "github.com/cosmos/cosmos-sdk/x/crisis/keeper"
 	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
@@ -63,12 +72,18 @@ import (
 	distrclient "github.com/cosmos/cosmos-sdk/x/distribution/client"
 	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/epochs"
+	epochskeeper "github.com/cosmos/cosmos-sdk/x/epochs/keeper"
+	epochstypes "github.com/cosmos/cosmos-sdk/x/epochs/types"
 	"github.com/cosmos/cosmos-sdk/x/evidence"
 	evidencekeeper "github.com/cosmos/cosmos-sdk/x/evidence/keeper"
 	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	feegrantkeeper "github.com/cosmos/cosmos-sdk/x/feegrant/keeper"
 	feegrantmodule "github.com/cosmos/cosmos-sdk/x/feegrant/module"
+	"github.com/cosmos/cosmos-sdk/x/feemarket"
+	feemarketkeeper "github.com/cosmos/cosmos-sdk/x/feemarket/keeper"
+	feemarkettypes "github.com/cosmos/cosmos-sdk/x/feemarket/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -113,9 +128,14 @@ var (
 		capability.AppModuleBasic{},
 		staking.AppModuleBasic{},
 		mint.AppModuleBasic{},
+		feemarket.AppModuleBasic{},
+		epochs.AppModuleBasic{},
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distrclient.ProposalHandler, upgradeclient.ProposalHandler, upgradeclient.CancelProposalHandler,
+			circuitclient.TripProposalHandler, circuitclient.ResetProposalHandler,
+			bankclient.UpdateDenomMetadataProposalHandler,
+			aclclient.ResourceDependencyProposalHandler, aclclient.WasmDependencyProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -124,6 +144,7 @@ var (
 		upgrade.AppModuleBasic{},
 		evidence.AppModuleBasic{},
 		authzmodule.AppModuleBasic{},
+		circuit.AppModuleBasic{},
 		vesting.AppModuleBasic{},
 		aclmodule.AppModuleBasic{},
 		params.AppModuleBasic{},
@@ -141,8 +162,9 @@ var (
 )
 
 var (
-	_ App                     = (*SimApp)(nil)
-	_ servertypes.Application = (*SimApp)(nil)
+	_ App                        = (*SimApp)(nil)
+	_ servertypes.Application    = (*SimApp)(nil)
+	_ servertypes.ConfigReloader = (*SimApp)(nil)
 )
 
 // SimApp extends an ABCI application, but with most of its parameters exported.
@@ -155,6 +177,11 @@ type SimApp struct {
 	interfaceRegistry types.InterfaceRegistry
 
 	invCheckPeriod uint
+	// appStateFile is the file the app state of the genesis is read from
+	// instead of the genesis file, if set
+	appStateFile string
+
+	proposalHandler *baseapp.ProposalHandler
 
 	// keys to access the substores
 	keys    map[string]*sdk.KVStoreKey
@@ -177,6 +204,21 @@ type SimApp struct {
 	AuthzKeeper         authzkeeper.Keeper
 	EvidenceKeeper      evidencekeeper.Keeper
 	FeeGrantKeeper      feegrantkeeper.Keeper
+	FeeMarketKeeper     feemarketkeeper.Keeper
+	EpochsKeeper        epochskeeper.Keeper
+	CircuitKeeper       circuitkeeper.Keeper
+
+	// DependencyInference infers the dependencies of the msgs from their
+	// simulations, which hint the scheduler of the txs of the blocks
+	DependencyInference *aclkeeper.DependencyInferenceRegistry
+
+	// BlockResults streams the results of the committed blocks to the
+	// subscribers of its gRPC service
+	BlockResults *blockresults.Service
+
+	// BalanceChanges streams the balance changes of the committed blocks to
+	// the subscribers of its gRPC service
+	BalanceChanges *bankstreaming.Service
 
 	// the module manager
 	mm *module.Manager
@@ -220,7 +262,8 @@ func NewSimApp(
 		minttypes.StoreKey, distrtypes.StoreKey, slashingtypes.StoreKey,
 		govtypes.StoreKey, paramstypes.StoreKey, upgradetypes.StoreKey, feegrant.StoreKey,
 		evidencetypes.StoreKey, capabilitytypes.StoreKey,
-		authzkeeper.StoreKey, acltypes.StoreKey,
+		authzkeeper.StoreKey, acltypes.StoreKey, feemarkettypes.StoreKey,
+		circuittypes.StoreKey, epochstypes.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
 	// NOTE: The testingkey is just mounted for testing purposes. Actual applications should
@@ -232,6 +275,10 @@ func NewSimApp(
 	if _, _, err := streaming.LoadStreamingServices(bApp, appOpts, appCodec, keys); err != nil {
 		os.Exit(1)
 	}
+	blockResults := blockresults.NewService(blockresults.DefaultBufferSize)
+	bApp.SetStreamingService(blockResults)
+	balanceChanges := bankstreaming.NewService(bApp.CommitMultiStore(), keys[banktypes.StoreKey], bankstreaming.DefaultBufferSize)
+	bApp.SetStreamingService(balanceChanges)
 
 	app := &SimApp{
 		BaseApp:           bApp,
@@ -239,10 +286,13 @@ func NewSimApp(
 		appCodec:          appCodec,
 		interfaceRegistry: interfaceRegistry,
 		invCheckPeriod:    invCheckPeriod,
+		appStateFile:      cast.ToString(appOpts.Get(genutil.FlagAppStateFile)),
 		keys:              keys,
 		tkeys:             tkeys,
 		memKeys:           memKeys,
 		txDecoder:         encodingConfig.TxConfig.TxDecoder(),
+		BlockResults:      blockResults,
+		BalanceChanges:    balanceChanges,
 	}
 
 	app.ParamsKeeper = initParamsKeeper(appCodec, legacyAmino, keys[paramstypes.StoreKey], tkeys[paramstypes.TStoreKey])
@@ -269,6 +319,8 @@ func NewSimApp(
 		appCodec, keys[minttypes.StoreKey], app.GetSubspace(minttypes.ModuleName), &stakingKeeper,
 		app.AccountKeeper, app.BankKeeper, authtypes.FeeCollectorName,
 	)
+	app.FeeMarketKeeper = feemarketkeeper.NewKeeper(keys[feemarkettypes.StoreKey], app.GetSubspace(feemarkettypes.ModuleName))
+	app.EpochsKeeper = epochskeeper.NewKeeper(appCodec, keys[epochstypes.StoreKey])
 	app.DistrKeeper = distrkeeper.NewKeeper(
 		appCodec, keys[distrtypes.StoreKey], app.GetSubspace(distrtypes.ModuleName), app.AccountKeeper, app.BankKeeper,
 		&stakingKeeper, authtypes.FeeCollectorName, app.ModuleAccountAddrs(),
@@ -282,6 +334,9 @@ func NewSimApp(
 
 	app.FeeGrantKeeper = feegrantkeeper.NewKeeper(appCodec, keys[feegrant.StoreKey], app.AccountKeeper)
 	app.UpgradeKeeper = upgradekeeper.NewKeeper(skipUpgradeHeights, keys[upgradetypes.StoreKey], appCodec, homePath, app.BaseApp)
+	app.UpgradeKeeper.SetBinaryChecksumVerification(cast.ToBool(appOpts.Get(upgrade.FlagVerifyBinaryChecksum)))
+	app.CircuitKeeper = circuitkeeper.NewKeeper(keys[circuittypes.StoreKey], app.GetSubspace(circuittypes.ModuleName))
+	app.SetCircuitBreaker(app.CircuitKeeper)
 
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
@@ -290,6 +345,25 @@ func NewSimApp(
 	)
 
 	app.AuthzKeeper = authzkeeper.NewKeeper(keys[authzkeeper.StoreKey], appCodec, app.BaseApp.MsgServiceRouter())
+
+	signModeHandler := encodingConfig.TxConfig.SignModeHandler()
+	anteHandler, anteDepGenerator, err := ante.NewAnteHandler(
+		ante.HandlerOptions{
+			AccountKeeper:   app.AccountKeeper,
+			BankKeeper:      app.BankKeeper,
+			SignModeHandler: signModeHandler,
+			FeegrantKeeper:  app.FeeGrantKeeper,
+			ParamsKeeper:    app.ParamsKeeper,
+			SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
+			TxFeeChecker:    app.FeeMarketKeeper.TxFeeChecker(ante.CheckTxFeeWithValidatorMinGasPrices),
+
+			DisabledDecorators: cast.ToStringSlice(appOpts.Get(server.FlagAnteDisabledDecorators)),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	app.AccessControlKeeper = aclkeeper.NewKeeper(
 		appCodec,
 		keys[acltypes.StoreKey],
@@ -297,14 +371,22 @@ func NewSimApp(
 		app.AccountKeeper,
 		app.StakingKeeper,
 		aclkeeper.WithDependencyMappingGenerator(acltestutil.MessageDependencyGeneratorTestHelper()),
+		aclkeeper.WithTxDecoder(encodingConfig.TxConfig.TxDecoder()),
+		aclkeeper.WithAnteDepGenerator(anteDepGenerator),
 	)
+	app.DependencyInference = aclkeeper.NewDependencyInferenceRegistry(acltestutil.TestingStoreKeyToResourceTypePrefixMap)
+	app.SetSimulationTracer(app.DependencyInference.ObserveTrace)
+	app.SetDependencyMismatchHandler(app.AccessControlKeeper.HandleDependencyMismatch)
 
 	// register the proposal types
 	govRouter := govtypes.NewRouter()
 	govRouter.AddRoute(govtypes.RouterKey, govtypes.ProposalHandler).
 		AddRoute(paramproposal.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
 		AddRoute(distrtypes.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
-		AddRoute(upgradetypes.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper))
+		AddRoute(upgradetypes.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(circuittypes.RouterKey, circuit.NewCircuitBreakerProposalHandler(app.CircuitKeeper)).
+		AddRoute(banktypes.RouterKey, bank.NewUpdateDenomMetadataProposalHandler(app.BankKeeper)).
+		AddRoute(acltypes.RouterKey, aclmodule.NewProposalHandler(app.AccessControlKeeper))
 	//TODO: we may need to add acl gov proposal types here
 	govKeeper := govkeeper.NewKeeper(
 		appCodec, keys[govtypes.StoreKey], app.GetSubspace(govtypes.ModuleName), app.AccountKeeper, app.BankKeeper,
@@ -329,6 +411,9 @@ func NewSimApp(
 	// NOTE: we may consider parsing `appOpts` inside module constructors. For the moment
 	// we prefer to be more strict in what arguments the modules expect.
 	var skipGenesisInvariants = cast.ToBool(appOpts.Get(crisis.FlagSkipGenesisInvariants))
+	if cast.ToBool(appOpts.Get(crisis.FlagAsyncInvariants)) {
+		app.CrisisKeeper.SetAsyncInvariantChecks(app.CommitMultiStore().CacheMultiStoreWithVersion)
+	}
 
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
@@ -346,6 +431,8 @@ func NewSimApp(
 		feegrantmodule.NewAppModule(appCodec, app.AccountKeeper, app.BankKeeper, app.FeeGrantKeeper, app.interfaceRegistry),
 		gov.NewAppModule(appCodec, app.GovKeeper, app.AccountKeeper, app.BankKeeper),
 		mint.NewAppModule(appCodec, app.MintKeeper, app.AccountKeeper),
+		feemarket.NewAppModule(app.FeeMarketKeeper),
+		epochs.NewAppModule(app.EpochsKeeper),
 		slashing.NewAppModule(appCodec, app.SlashingKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper),
 		distr.NewAppModule(appCodec, app.DistrKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper),
 		staking.NewAppModule(appCodec, app.StakingKeeper, app.AccountKeeper, app.BankKeeper),
@@ -353,6 +440,7 @@ func NewSimApp(
 		evidence.NewAppModule(app.EvidenceKeeper),
 		params.NewAppModule(app.ParamsKeeper),
 		authzmodule.NewAppModule(appCodec, app.AuthzKeeper, app.AccountKeeper, app.BankKeeper, app.interfaceRegistry),
+		circuit.NewAppModule(app.CircuitKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -361,19 +449,19 @@ func NewSimApp(
 	// NOTE: staking module is required if HistoricalEntries param > 0
 	// NOTE: capability module's beginblocker must come before any modules using capabilities (e.g. IBC)
 	app.mm.SetOrderBeginBlockers(
-		upgradetypes.ModuleName, capabilitytypes.ModuleName, minttypes.ModuleName, distrtypes.ModuleName, slashingtypes.ModuleName,
+		upgradetypes.ModuleName, capabilitytypes.ModuleName, epochstypes.ModuleName, minttypes.ModuleName, distrtypes.ModuleName, slashingtypes.ModuleName,
 		evidencetypes.ModuleName, stakingtypes.ModuleName,
 		authtypes.ModuleName, banktypes.ModuleName, govtypes.ModuleName, crisistypes.ModuleName, genutiltypes.ModuleName,
-		authz.ModuleName, feegrant.ModuleName,
-		paramstypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName,
+		authz.ModuleName, feegrant.ModuleName, feemarkettypes.ModuleName,
+		paramstypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName, circuittypes.ModuleName,
 	)
 	app.mm.SetOrderEndBlockers(
 		crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName,
 		capabilitytypes.ModuleName, authtypes.ModuleName, banktypes.ModuleName, distrtypes.ModuleName,
 		slashingtypes.ModuleName, minttypes.ModuleName,
 		genutiltypes.ModuleName, evidencetypes.ModuleName, authz.ModuleName,
-		feegrant.ModuleName,
-		paramstypes.ModuleName, upgradetypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName,
+		feegrant.ModuleName, feemarkettypes.ModuleName, epochstypes.ModuleName,
+		paramstypes.ModuleName, upgradetypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName, circuittypes.ModuleName,
 	)
 
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -383,10 +471,10 @@ func NewSimApp(
 	// can do so safely.
 	app.mm.SetOrderInitGenesis(
 		capabilitytypes.ModuleName, authtypes.ModuleName, banktypes.ModuleName, distrtypes.ModuleName, stakingtypes.ModuleName,
-		slashingtypes.ModuleName, govtypes.ModuleName, minttypes.ModuleName, crisistypes.ModuleName,
+		slashingtypes.ModuleName, govtypes.ModuleName, minttypes.ModuleName, feemarkettypes.ModuleName, crisistypes.ModuleName,
 		genutiltypes.ModuleName, evidencetypes.ModuleName, authz.ModuleName,
-		feegrant.ModuleName,
-		paramstypes.ModuleName, upgradetypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName,
+		feegrant.ModuleName, epochstypes.ModuleName,
+		paramstypes.ModuleName, upgradetypes.ModuleName, vestingtypes.ModuleName, acltypes.ModuleName, circuittypes.ModuleName,
 	)
 
 	// Uncomment if you want to set a custom migration order here.
@@ -420,26 +508,20 @@ func NewSimApp(
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 
-	signModeHandler := encodingConfig.TxConfig.SignModeHandler()
-	anteHandler, anteDepGenerator, err := ante.NewAnteHandler(
-		ante.HandlerOptions{
-			AccountKeeper:   app.AccountKeeper,
-			BankKeeper:      app.BankKeeper,
-			SignModeHandler: signModeHandler,
-			FeegrantKeeper:  app.FeeGrantKeeper,
-			ParamsKeeper:    app.ParamsKeeper,
-			SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
-			TxFeeChecker:    ante.CheckTxFeeWithValidatorMinGasPrices,
-		},
-	)
-
-	if err != nil {
-		panic(err)
-	}
-
 	app.SetAnteHandler(anteHandler)
 	app.SetAnteDepGenerator(anteDepGenerator)
+	app.SetTxBatchVerifier(ante.NewBatchSigVerifier(app.AccountKeeper, signModeHandler).VerifyTxs)
 	app.SetEndBlocker(app.EndBlocker)
+	app.SetWarmUpper(app.mm.WarmUp)
+	app.proposalHandler = baseapp.NewProposalHandler(encodingConfig.TxConfig.TxDecoder())
+	var orderTxs baseapp.TxOrderer
+	if mempool := app.Mempool(); mempool != nil {
+		orderTxs = mempool.TxOrderer()
+	}
+	if cast.ToBool(appOpts.Get(server.FlagProposalTxBatching)) {
+		orderTxs = baseapp.BatchingTxOrderer(orderTxs, app.DependencyInference.TxAccessOps, cast.ToInt(appOpts.Get(server.FlagProposalMaxBatchSize)))
+	}
+	app.proposalHandler.SetTxOrderer(orderTxs)
 	app.SetPrepareProposalHandler(app.PrepareProposalHandler)
 	app.SetProcessProposalHandler(app.ProcessProposalHandler)
 	app.SetFinalizeBlocker(app.FinalizeBlocker)
@@ -458,17 +540,11 @@ func NewSimApp(
 func (app *SimApp) Name() string { return app.BaseApp.Name() }
 
 func (app *SimApp) PrepareProposalHandler(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
-	return &abci.ResponsePrepareProposal{
-		TxRecords: utils.Map(req.Txs, func(tx []byte) *abci.TxRecord {
-			return &abci.TxRecord{Action: abci.TxRecord_UNMODIFIED, Tx: tx}
-		}),
-	}, nil
+	return app.proposalHandler.PrepareProposalHandler()(ctx, req)
 }
 
 func (app *SimApp) ProcessProposalHandler(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
-	return &abci.ResponseProcessProposal{
-		Status: abci.ResponseProcessProposal_ACCEPT,
-	}, nil
+	return app.proposalHandler.ProcessProposalHandler()(ctx, req)
 }
 
 func (app *SimApp) FinalizeBlocker(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
@@ -512,12 +588,16 @@ func (app *SimApp) FinalizeBlocker(ctx sdk.Context, req *abci.RequestFinalizeBlo
 		}
 	}
 
-	txResults := []*abci.ExecTxResult{}
+	batch := sdk.DeliverTxBatchRequest{TxEntries: make([]*sdk.DeliverTxEntry, len(req.Txs))}
 	for i, tx := range req.Txs {
-		ctx = ctx.WithContext(context.WithValue(ctx.Context(), ante.ContextKeyTxIndexKey, i))
-		deliverTxResp := app.DeliverTx(ctx, abci.RequestDeliverTx{
-			Tx: tx,
-		})
+		batch.TxEntries[i] = &sdk.DeliverTxEntry{Request: abci.RequestDeliverTx{Tx: tx}}
+		if typedTxs[i] != nil {
+			batch.TxEntries[i].AccessOperations = app.DependencyInference.TxAccessOps(typedTxs[i])
+		}
+	}
+	txResults := []*abci.ExecTxResult{}
+	for _, result := range app.DeliverTxBatch(ctx, batch).Results {
+		deliverTxResp := result.Response
 		txResults = append(txResults, &abci.ExecTxResult{
 			Code:      deliverTxResp.Code,
 			Data:      deliverTxResp.Data,
@@ -583,6 +663,10 @@ func (app *SimApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.Re
 
 // InitChainer application update at chain initialization
 func (app *SimApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+	if app.appStateFile != "" {
+		return app.initChainerFromFile(ctx)
+	}
+
 	var genesisState GenesisState
 	if err := json.Unmarshal(req.AppStateBytes, &genesisState); err != nil {
 		panic(err)
@@ -591,6 +675,20 @@ func (app *SimApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.
 	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
 }
 
+// initChainerFromFile initializes the chain from the app state file, loading
+// the genesis state of one module at a time.
+func (app *SimApp) initChainerFromFile(ctx sdk.Context) (res abci.ResponseInitChain) {
+	app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
+	err := genutil.ReadAppStateFile(app.appStateFile, func(ar *genutil.AppStateReader) error {
+		res = app.mm.InitGenesisFrom(ctx, app.appCodec, ar.ModuleState)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // LoadHeight loads a particular height
 func (app *SimApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
@@ -683,14 +781,30 @@ func (app *SimApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICon
 	}
 }
 
+// RegisterGRPCServer implements the Application.RegisterGRPCServer method,
+// registering the BlockResults and BalanceChanges streaming services along
+// with the queries.
+func (app *SimApp) RegisterGRPCServer(server gogogrpc.Server) {
+	app.BaseApp.RegisterGRPCServer(server)
+	blockresults.RegisterBlockResultsServer(server, app.BlockResults)
+	bankstreaming.RegisterBalanceChangesServer(server, app.BalanceChanges)
+}
+
 // RegisterTxService implements the Application.RegisterTxService method.
 func (app *SimApp) RegisterTxService(clientCtx client.Context) {
-	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.interfaceRegistry)
+	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.BaseApp.TraceTx, app.BaseApp.SimulateWithTrace, app.BaseApp.RegisterTxAccessOps, app.interfaceRegistry)
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
 func (app *SimApp) RegisterTendermintService(clientCtx client.Context) {
-	tmservice.RegisterTendermintService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.interfaceRegistry)
+	tmservice.RegisterTendermintService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.interfaceRegistry, app.Query)
+}
+
+// ReloadConfig implements the ConfigReloader interface, applying the number of
+// workers of the scheduler and the size of the historical store cache.
+func (app *SimApp) ReloadConfig(cfg config.Config) error {
+	app.UpdateConcurrencyWorkers(cfg.ConcurrencyWorkers)
+	return app.UpdateHistoricalStoreCacheSize(cfg.HistoricalStoreCacheSize)
 }
 
 // RegisterSwaggerAPI registers swagger route with API Server
@@ -721,10 +835,19 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	paramsKeeper.Subspace(banktypes.ModuleName)
 	paramsKeeper.Subspace(stakingtypes.ModuleName)
 	paramsKeeper.Subspace(minttypes.ModuleName)
+	paramsKeeper.Subspace(feemarkettypes.ModuleName)
+	paramsKeeper.Subspace(circuittypes.ModuleName)
 	paramsKeeper.Subspace(distrtypes.ModuleName)
 	paramsKeeper.Subspace(slashingtypes.ModuleName)
 	paramsKeeper.Subspace(govtypes.ModuleName).WithKeyTable(govtypes.ParamKeyTable())
 	paramsKeeper.Subspace(crisistypes.ModuleName)
 
+	// validate the parameter sets changed by proposals as a whole
+	paramsKeeper.RegisterParamChangeValidator(authtypes.ModuleName, paramstypes.ValidateParamSet(func() paramstypes.ValidatedParamSet { return &authtypes.Params{} }))
+	paramsKeeper.RegisterParamChangeValidator(banktypes.ModuleName, paramstypes.ValidateParamSet(func() paramstypes.ValidatedParamSet { return &banktypes.Params{} }))
+	paramsKeeper.RegisterParamChangeValidator(stakingtypes.ModuleName, paramstypes.ValidateParamSet(func() paramstypes.ValidatedParamSet { return &stakingtypes.Params{} }))
+	paramsKeeper.RegisterParamChangeValidator(minttypes.ModuleName, paramstypes.ValidateParamSet(func() paramstypes.ValidatedParamSet { return &minttypes.Params{} }))
+	paramsKeeper.RegisterParamChangeValidator(feemarkettypes.ModuleName, paramstypes.ValidateParamSet(func() paramstypes.ValidatedParamSet { return &feemarkettypes.Params{} }))
+
 	return paramsKeeper
 }
@@ -3,6 +3,7 @@ package simapp
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"testing"
 
 	aclmodule "github.com/cosmos/cosmos-sdk/x/accesscontrol"
@@ -13,8 +14,10 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store/streaming/blockresults"
 	"github.com/cosmos/cosmos-sdk/tests/mocks"
 	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -25,10 +28,13 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/capability"
+	"github.com/cosmos/cosmos-sdk/x/circuit"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/epochs"
 	"github.com/cosmos/cosmos-sdk/x/evidence"
 	feegrantmodule "github.com/cosmos/cosmos-sdk/x/feegrant/module"
+	"github.com/cosmos/cosmos-sdk/x/feemarket"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mint"
@@ -176,6 +182,9 @@ func TestRunMigrations(t *testing.T) {
 					"authz":         authzmodule.AppModule{}.ConsensusVersion(),
 					"staking":       staking.AppModule{}.ConsensusVersion(),
 					"mint":          mint.AppModule{}.ConsensusVersion(),
+					"feemarket":     feemarket.AppModule{}.ConsensusVersion(),
+					"circuit":       circuit.AppModule{}.ConsensusVersion(),
+					"epochs":        epochs.AppModule{}.ConsensusVersion(),
 					"distribution":  distribution.AppModule{}.ConsensusVersion(),
 					"slashing":      slashing.AppModule{}.ConsensusVersion(),
 					"gov":           gov.AppModule{}.ConsensusVersion(),
@@ -228,6 +237,9 @@ func TestInitGenesisOnMigration(t *testing.T) {
 			"authz":        authzmodule.AppModule{}.ConsensusVersion(),
 			"staking":      staking.AppModule{}.ConsensusVersion(),
 			"mint":         mint.AppModule{}.ConsensusVersion(),
+			"feemarket":    feemarket.AppModule{}.ConsensusVersion(),
+			"circuit":      circuit.AppModule{}.ConsensusVersion(),
+			"epochs":       epochs.AppModule{}.ConsensusVersion(),
 			"distribution": distribution.AppModule{}.ConsensusVersion(),
 			"slashing":     slashing.AppModule{}.ConsensusVersion(),
 			"gov":          gov.AppModule{}.ConsensusVersion(),
@@ -267,3 +279,34 @@ func TestUpgradeStateOnGenesis(t *testing.T) {
 		require.Equal(t, vm[v], i.ConsensusVersion())
 	}
 }
+
+func TestBlockResultsStreaming(t *testing.T) {
+	app := Setup(false)
+	app.Commit(context.Background())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcSrv := grpc.NewServer()
+	app.RegisterGRPCServer(grpcSrv)
+	go grpcSrv.Serve(lis) //nolint:errcheck
+	defer grpcSrv.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := blockresults.NewBlockResultsClient(conn).Subscribe(context.Background(), &blockresults.SubscribeRequest{})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	res, err := app.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: 2, Txs: [][]byte{[]byte("invalid")}})
+	require.NoError(t, err)
+	app.Commit(context.Background())
+
+	result, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Height)
+	require.Len(t, result.TxResults, 1)
+	require.Equal(t, res.TxResults[0].Code, result.TxResults[0].Code)
+	require.NotEmpty(t, result.BeginBlockEvents)
+}
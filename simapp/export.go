@@ -2,12 +2,14 @@ package simapp
 
 import (
 	"encoding/json"
+	"io"
 	"log"
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -18,16 +20,7 @@ import (
 func (app *SimApp) ExportAppStateAndValidators(
 	forZeroHeight bool, jailAllowedAddrs []string,
 ) (servertypes.ExportedApp, error) {
-	// as if they could withdraw from the start of the next block
-	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
-
-	// We export at last height + 1, because that's the height at which
-	// Tendermint will start InitChain.
-	height := app.LastBlockHeight() + 1
-	if forZeroHeight {
-		height = 0
-		app.prepForZeroHeightGenesis(ctx, jailAllowedAddrs)
-	}
+	ctx, height := app.exportContext(forZeroHeight, jailAllowedAddrs)
 
 	genState := app.mm.ExportGenesis(ctx, app.appCodec)
 	appState, err := json.MarshalIndent(genState, "", "  ")
@@ -44,6 +37,45 @@ func (app *SimApp) ExportAppStateAndValidators(
 	}, err
 }
 
+// ExportAppStateAndValidatorsToWriter is like ExportAppStateAndValidators,
+// except that the app state is written by the returned WriteAppState one
+// module at a time, instead of being held in memory.
+func (app *SimApp) ExportAppStateAndValidatorsToWriter(
+	forZeroHeight bool, jailAllowedAddrs []string,
+) (servertypes.ExportedApp, error) {
+	ctx, height := app.exportContext(forZeroHeight, jailAllowedAddrs)
+
+	validators, err := staking.WriteValidators(ctx, app.StakingKeeper)
+	return servertypes.ExportedApp{
+		WriteAppState: func(w io.Writer) error {
+			aw := genutil.NewAppStateWriter(w)
+			if err := app.mm.ExportGenesisTo(ctx, app.appCodec, aw.WriteModule); err != nil {
+				return err
+			}
+			return aw.Close()
+		},
+		Validators:      validators,
+		Height:          height,
+		ConsensusParams: app.BaseApp.GetConsensusParams(ctx),
+	}, err
+}
+
+// exportContext returns the context the state is exported with and the height
+// of the exported genesis.
+func (app *SimApp) exportContext(forZeroHeight bool, jailAllowedAddrs []string) (sdk.Context, int64) {
+	// as if they could withdraw from the start of the next block
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+
+	// We export at last height + 1, because that's the height at which
+	// Tendermint will start InitChain.
+	height := app.LastBlockHeight() + 1
+	if forZeroHeight {
+		height = 0
+		app.prepForZeroHeightGenesis(ctx, jailAllowedAddrs)
+	}
+	return ctx, height
+}
+
 // prepare for fresh start at zero height
 // NOTE zero height genesis is a temporary feature which will be deprecated
 //      in favour of export at a block height
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/cosmos/iavl"
@@ -33,12 +34,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/simapp/params"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	"github.com/cosmos/cosmos-sdk/store"
+	storeiavl "github.com/cosmos/cosmos-sdk/store/iavl"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutilcli "github.com/cosmos/cosmos-sdk/x/genutil/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const DefaultTracingURL = "http://localhost:14268/api/traces"
@@ -161,9 +165,12 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 		AddGenesisAccountCmd(simapp.DefaultNodeHome),
 		tmmain.NewCompletionCmd(rootCmd, true),
 		testnetCmd(simapp.ModuleBasics, banktypes.GenesisBalancesIterator{}),
-		debug.Cmd(),
+		debugCmd(a),
 		config.Cmd(),
 		pruning.PruningCmd(a.newApp),
+		server.SnapshotCmd(a.newApp, simapp.DefaultNodeHome),
+		server.StateCmd(a.newApp, simapp.DefaultNodeHome),
+		genutilcli.BalancesSnapshotCmd(a.newApp, simapp.DefaultNodeHome),
 	)
 
 	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(DefaultTracingURL)))
@@ -196,6 +203,8 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
+	genutil.AddModuleInitFlags(startCmd)
+	upgrade.AddModuleInitFlags(startCmd)
 }
 
 func queryCommand() *cobra.Command {
@@ -257,7 +266,19 @@ func (a appCreator) newApp(logger log.Logger, db dbm.DB, traceStore io.Writer, t
 	var cache sdk.MultiStorePersistentCache
 
 	if cast.ToBool(appOpts.Get(server.FlagInterBlockCache)) {
-		cache = store.NewCommitKVStoreCacheManager()
+		cache = store.NewCommitKVStoreCacheManagerWithSize(cast.ToUint(appOpts.Get(server.FlagInterBlockCacheSize)))
+	}
+
+	var mempool *baseapp.PriorityMempool
+	if cast.ToBool(appOpts.Get(server.FlagPriorityMempool)) {
+		mempool = baseapp.NewPriorityMempool(baseapp.MempoolConfig{
+			MaxBytes:          cast.ToInt64(appOpts.Get(server.FlagMempoolMaxBytes)),
+			MaxTxsPerSender:   cast.ToInt(appOpts.Get(server.FlagMempoolMaxTxsPerSender)),
+			MaxBytesPerSender: cast.ToInt64(appOpts.Get(server.FlagMempoolMaxBytesPerSender)),
+			TTLNumBlocks:      cast.ToUint64(appOpts.Get(server.FlagMempoolTTLNumBlocks)),
+			TTL:               cast.ToDuration(appOpts.Get(server.FlagMempoolTTL)),
+			SequenceWindow:    cast.ToUint64(appOpts.Get(server.FlagMempoolSequenceWindow)),
+		})
 	}
 
 	skipUpgradeHeights := make(map[int64]bool)
@@ -275,7 +296,22 @@ func (a appCreator) newApp(logger log.Logger, db dbm.DB, traceStore io.Writer, t
 		snapshotDirectory = filepath.Join(cast.ToString(appOpts.Get(flags.FlagHome)), "data", "snapshots")
 	}
 
-	snapshotDB, err := sdk.NewLevelDB("metadata", snapshotDirectory)
+	fastNodeMigration, err := storeiavl.ParseFastNodeMigrationMode(cast.ToString(appOpts.Get(server.FlagIAVLFastNodeMigration)))
+	if err != nil {
+		panic(err)
+	}
+
+	queryGasLimits, err := baseapp.ParseQueryGasLimits(cast.ToStringSlice(appOpts.Get(server.FlagQueryGasLimits)))
+	if err != nil {
+		panic(err)
+	}
+
+	profileDirectory := cast.ToString(appOpts.Get(server.FlagSlowBlockProfileDir))
+	if profileDirectory == "" {
+		profileDirectory = filepath.Join(cast.ToString(appOpts.Get(flags.FlagHome)), "data", "profiles")
+	}
+
+	snapshotDB, err := sdk.NewDB("metadata", server.GetAppDBBackend(appOpts), snapshotDirectory)
 	if err != nil {
 		panic(err)
 	}
@@ -292,6 +328,10 @@ func (a appCreator) newApp(logger log.Logger, db dbm.DB, traceStore io.Writer, t
 		a.encCfg,
 		appOpts,
 		baseapp.SetPruning(pruningOpts),
+		baseapp.SetBackgroundPruning(
+			cast.ToInt(appOpts.Get(server.FlagPruningBatchSize)),
+			time.Duration(cast.ToUint64(appOpts.Get(server.FlagPruningBackgroundInterval)))*time.Second,
+		),
 		baseapp.SetMinGasPrices(cast.ToString(appOpts.Get(server.FlagMinGasPrices))),
 		baseapp.SetHaltHeight(cast.ToUint64(appOpts.Get(server.FlagHaltHeight))),
 		baseapp.SetHaltTime(cast.ToUint64(appOpts.Get(server.FlagHaltTime))),
@@ -302,10 +342,32 @@ func (a appCreator) newApp(logger log.Logger, db dbm.DB, traceStore io.Writer, t
 		baseapp.SetSnapshotStore(snapshotStore),
 		baseapp.SetSnapshotInterval(cast.ToUint64(appOpts.Get(server.FlagStateSyncSnapshotInterval))),
 		baseapp.SetSnapshotKeepRecent(cast.ToUint32(appOpts.Get(server.FlagStateSyncSnapshotKeepRecent))),
+		baseapp.SetSnapshotCompression(cast.ToString(appOpts.Get(server.FlagStateSyncSnapshotCompression))),
+		baseapp.SetSnapshotChunkSize(cast.ToUint64(appOpts.Get(server.FlagStateSyncSnapshotChunkSize))),
 		baseapp.SetSnapshotDirectory(snapshotDirectory),
 		baseapp.SetIAVLCacheSize(cast.ToInt(appOpts.Get(server.FlagIAVLCacheSize))),
 		baseapp.SetIAVLDisableFastNode(cast.ToBool(appOpts.Get(server.FlagIAVLFastNode))),
+		baseapp.SetIAVLFastNodeMigration(fastNodeMigration, cast.ToInt(appOpts.Get(server.FlagIAVLFastNodeMigrationRate))),
 		baseapp.SetCompactionInterval(cast.ToUint64(appOpts.Get(server.FlagCompactionInterval))),
+		baseapp.SetAsyncCommitBuffer(cast.ToInt(appOpts.Get(server.FlagAsyncCommitBuffer))),
+		baseapp.SetPipelinedCommit(cast.ToBool(appOpts.Get(server.FlagPipelinedCommit))),
+		baseapp.SetHistoricalStoreCacheSize(cast.ToInt(appOpts.Get(server.FlagHistoricalStoreCacheSize))),
+		baseapp.SetOccEnabled(cast.ToBool(appOpts.Get(server.FlagOccEnabled))),
+		baseapp.SetConcurrencyWorkers(cast.ToInt(appOpts.Get(server.FlagConcurrencyWorkers))),
+		baseapp.SetOptimisticProcessing(cast.ToBool(appOpts.Get(server.FlagOptimisticProcessing))),
+		baseapp.SetConcurrentCheckTx(cast.ToBool(appOpts.Get(server.FlagConcurrentCheckTx))),
+		baseapp.SetSignatureCacheSize(cast.ToInt(appOpts.Get(server.FlagSignatureCacheSize))),
+		baseapp.SetMempool(mempool),
+		baseapp.SetTxTracing(cast.ToBool(appOpts.Get(server.FlagTxTracing))),
+		baseapp.SetTxResourceEvents(cast.ToBool(appOpts.Get(server.FlagTxResourceEvents))),
+		baseapp.SetEventLimits(cast.ToInt(appOpts.Get(server.FlagMaxEventsPerTx)), cast.ToInt(appOpts.Get(server.FlagMaxEventAttributes))),
+		baseapp.SetTxAccessHintsCacheSize(cast.ToInt(appOpts.Get(server.FlagTxAccessHintsCacheSize))),
+		baseapp.SetQueryGasLimits(cast.ToUint64(appOpts.Get(server.FlagQueryGasLimit)), queryGasLimits),
+		baseapp.SetMaxQueryDepth(cast.ToInt(appOpts.Get(server.FlagMaxQueryDepth))),
+		baseapp.SetSlowBlockProfiling(
+			time.Duration(cast.ToUint64(appOpts.Get(server.FlagSlowBlockProfileThreshold)))*time.Millisecond,
+			profileDirectory,
+		),
 		baseapp.SetOrphanConfig(&iavl.Options{
 			SeparateOrphanStorage:       cast.ToBool(appOpts.Get(server.FlagSeparateOrphanStorage)),
 			SeparateOphanVersionsToKeep: cast.ToInt64(appOpts.Get(server.FlagSeparateOrphanVersionsToKeep)),
@@ -337,5 +399,15 @@ func (a appCreator) appExport(
 		simApp = simapp.NewSimApp(logger, db, traceStore, true, map[int64]bool{}, homePath, uint(1), nil, a.encCfg, appOpts)
 	}
 
+	if cast.ToString(appOpts.Get(server.FlagAppStateFile)) != "" {
+		return simApp.ExportAppStateAndValidatorsToWriter(forZeroHeight, jailAllowedAddrs)
+	}
 	return simApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs)
 }
+
+// debugCmd returns the debug command with the subcommands that need the app.
+func debugCmd(a appCreator) *cobra.Command {
+	cmd := debug.Cmd()
+	cmd.AddCommand(server.ReplayBlockCmd(a.newApp, simapp.DefaultNodeHome))
+	return cmd
+}
@@ -10,6 +10,8 @@ import (
 	"sort"
 	"sync"
 
+	protoio "github.com/gogo/protobuf/io"
+
 	"github.com/cosmos/cosmos-sdk/snapshots/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
@@ -51,6 +53,8 @@ type Manager struct {
 	store      *Store
 	multistore types.Snapshotter
 	extensions map[string]types.ExtensionSnapshotter
+	format     uint32
+	chunkSize  uint64
 
 	mtx                sync.Mutex
 	operation          operation
@@ -58,6 +62,23 @@ type Manager struct {
 	chRestoreDone      <-chan restoreDone
 	restoreChunkHashes [][]byte
 	restoreChunkIndex  uint32
+
+	// progress mirrors the operation in progress under its own mutex, mtx
+	// being held for the whole restore of the last chunk
+	progressMtx sync.Mutex
+	progress    Progress
+}
+
+// Progress is the operation in progress of a Manager.
+type Progress struct {
+	// Operation is the operation in progress, empty if none
+	Operation string
+	// Height is the height of the snapshot being taken or restored
+	Height uint64
+	// ChunksRestored and Chunks are the number of chunks applied so far and
+	// the number of chunks of the snapshot being restored
+	ChunksRestored uint32
+	Chunks         uint32
 }
 
 // NewManager creates a new manager.
@@ -66,6 +87,8 @@ func NewManager(store *Store, multistore types.Snapshotter) *Manager {
 		store:      store,
 		multistore: multistore,
 		extensions: make(map[string]types.ExtensionSnapshotter),
+		format:     types.CurrentFormat,
+		chunkSize:  types.DefaultChunkSize,
 	}
 }
 
@@ -75,6 +98,8 @@ func NewManagerWithExtensions(store *Store, multistore types.Snapshotter, extens
 		store:      store,
 		multistore: multistore,
 		extensions: extensions,
+		format:     types.CurrentFormat,
+		chunkSize:  types.DefaultChunkSize,
 	}
 }
 
@@ -82,6 +107,22 @@ func (m *Manager) SetMultiStore(s types.Snapshotter) {
 	m.multistore = s
 }
 
+// SetFormat sets the format new snapshots are created in, which determines
+// their compression. Snapshots of any supported format can be restored.
+func (m *Manager) SetFormat(format uint32) error {
+	if !types.IsSupportedFormat(format) {
+		return sdkerrors.Wrapf(types.ErrUnknownFormat, "format %v", format)
+	}
+	m.format = format
+	return nil
+}
+
+// SetChunkSize sets the size of the chunks new snapshots are split into, 0
+// meaning a single chunk.
+func (m *Manager) SetChunkSize(chunkSize uint64) {
+	m.chunkSize = chunkSize
+}
+
 func (m *Manager) Close() error {
 	return m.store.db.Close()
 }
@@ -117,9 +158,24 @@ func (m *Manager) beginLocked(op operation) error {
 		return sdkerrors.Wrapf(sdkerrors.ErrConflict, "a %v operation is in progress", m.operation)
 	}
 	m.operation = op
+	m.setProgress(Progress{Operation: string(op)})
 	return nil
 }
 
+func (m *Manager) setProgress(progress Progress) {
+	m.progressMtx.Lock()
+	defer m.progressMtx.Unlock()
+	m.progress = progress
+}
+
+// Progress returns the operation in progress. Unlike the other methods, it
+// does not wait for the operation to give way.
+func (m *Manager) Progress() Progress {
+	m.progressMtx.Lock()
+	defer m.progressMtx.Unlock()
+	return m.progress
+}
+
 // end ends the current operation.
 func (m *Manager) end() {
 	m.mtx.Lock()
@@ -130,6 +186,7 @@ func (m *Manager) end() {
 // endLocked ends the current operation while already holding the mutex.
 func (m *Manager) endLocked() {
 	m.operation = opNone
+	m.setProgress(Progress{})
 	if m.chRestore != nil {
 		close(m.chRestore)
 		m.chRestore = nil
@@ -160,6 +217,7 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 		return nil, err
 	}
 	defer m.end()
+	m.setProgress(Progress{Operation: string(opSnapshot), Height: height})
 
 	latest, err := m.store.GetLatest()
 	if err != nil {
@@ -174,25 +232,32 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 	ch := make(chan io.ReadCloser)
 	go m.createSnapshot(height, ch)
 
-	return m.store.Save(height, types.CurrentFormat, ch)
+	return m.store.Save(height, m.format, ch)
 }
 
 // createSnapshot do the heavy work of snapshotting after the validations of request are done
 // the produced chunks are written to the channel.
 func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
-	streamWriter := NewStreamWriter(ch)
+	streamWriter := NewStreamWriterWithFormat(ch, m.format, m.chunkSize)
 	if streamWriter == nil {
 		return
 	}
 	defer streamWriter.Close()
-	if err := m.multistore.Snapshot(height, streamWriter); err != nil {
+	if err := m.writeItems(height, streamWriter); err != nil {
 		streamWriter.CloseWithError(err)
-		return
+	}
+}
+
+// writeItems writes the snapshot items of the multistore and extensions at
+// height.
+func (m *Manager) writeItems(height uint64, protoWriter protoio.Writer) error {
+	if err := m.multistore.Snapshot(height, protoWriter); err != nil {
+		return err
 	}
 	for _, name := range m.sortedExtensionNames() {
 		extension := m.extensions[name]
 		// write extension metadata
-		err := streamWriter.WriteMsg(&types.SnapshotItem{
+		err := protoWriter.WriteMsg(&types.SnapshotItem{
 			Item: &types.SnapshotItem_Extension{
 				Extension: &types.SnapshotExtensionMeta{
 					Name:   name,
@@ -201,14 +266,13 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 			},
 		})
 		if err != nil {
-			streamWriter.CloseWithError(err)
-			return
+			return err
 		}
-		if err := extension.Snapshot(height, streamWriter); err != nil {
-			streamWriter.CloseWithError(err)
-			return
+		if err := extension.Snapshot(height, protoWriter); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 // List lists snapshots, mirroring ABCI ListSnapshots. It can be concurrent with other operations.
@@ -256,7 +320,7 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	defer m.mtx.Unlock()
 
 	// check multistore supported format preemptive
-	if snapshot.Format != types.CurrentFormat {
+	if !types.IsSupportedFormat(snapshot.Format) {
 		return sdkerrors.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
 	}
 	if snapshot.Height == 0 {
@@ -289,18 +353,24 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	m.chRestoreDone = chDone
 	m.restoreChunkHashes = snapshot.Metadata.ChunkHashes
 	m.restoreChunkIndex = 0
+	m.setProgress(Progress{Operation: string(opRestore), Height: snapshot.Height, Chunks: snapshot.Chunks})
 	return nil
 }
 
 // restoreSnapshot do the heavy work of snapshot restoration after preliminary checks on request have passed.
 func (m *Manager) restoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser) error {
-	streamReader, err := NewStreamReader(chChunks)
+	streamReader, err := NewStreamReaderWithFormat(chChunks, snapshot.Format)
 	if err != nil {
 		return err
 	}
 	defer streamReader.Close()
+	return m.restoreItems(snapshot.Height, snapshot.Format, streamReader)
+}
 
-	next, err := m.multistore.Restore(snapshot.Height, snapshot.Format, streamReader)
+// restoreItems restores the multistore and extensions at height from the
+// snapshot items of the given format.
+func (m *Manager) restoreItems(height uint64, format uint32, protoReader protoio.Reader) error {
+	next, err := m.multistore.Restore(height, format, protoReader)
 	if err != nil {
 		return sdkerrors.Wrap(err, "multistore restore")
 	}
@@ -320,7 +390,7 @@ func (m *Manager) restoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.Re
 		if !IsFormatSupported(extension, metadata.Format) {
 			return sdkerrors.Wrapf(types.ErrUnknownFormat, "format %v for extension %s", metadata.Format, metadata.Name)
 		}
-		next, err = extension.Restore(snapshot.Height, metadata.Format, streamReader)
+		next, err = extension.Restore(height, metadata.Format, protoReader)
 		if err != nil {
 			return sdkerrors.Wrapf(err, "extension %s restore", metadata.Name)
 		}
@@ -363,6 +433,9 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 	// Pass the chunk to the restore, and wait for completion if it was the final one.
 	m.chRestore <- ioutil.NopCloser(bytes.NewReader(chunk))
 	m.restoreChunkIndex++
+	m.progressMtx.Lock()
+	m.progress.ChunksRestored = m.restoreChunkIndex
+	m.progressMtx.Unlock()
 
 	if int(m.restoreChunkIndex) >= len(m.restoreChunkHashes) {
 		close(m.chRestore)
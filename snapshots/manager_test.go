@@ -1,6 +1,7 @@
 package snapshots_test
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
@@ -161,6 +162,7 @@ func TestManager_Restore(t *testing.T) {
 		Metadata: types.Metadata{ChunkHashes: checksums(chunks)},
 	})
 	require.NoError(t, err)
+	require.Equal(t, snapshots.Progress{Operation: "restore", Height: 3, Chunks: 1}, manager.Progress())
 
 	// While the restore is in progress, any other operations fail
 	_, err = manager.Create(4)
@@ -186,6 +188,7 @@ func TestManager_Restore(t *testing.T) {
 	}
 
 	assert.Equal(t, expectItems, target.items)
+	require.Equal(t, snapshots.Progress{}, manager.Progress())
 
 	// Starting a new restore should fail now, because the target already has contents.
 	err = manager.Restore(types.Snapshot{
@@ -210,3 +213,37 @@ func TestManager_Restore(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestManager_TakeAndRestoreWithFormat(t *testing.T) {
+	items := [][]byte{
+		bytes.Repeat([]byte{1}, 100),
+		bytes.Repeat([]byte{2}, 100),
+		bytes.Repeat([]byte{3}, 100),
+	}
+	source := snapshots.NewManager(setupStore(t), &mockSnapshotter{items: items})
+	require.ErrorIs(t, source.SetFormat(99), types.ErrUnknownFormat)
+	require.NoError(t, source.SetFormat(types.FormatZstd))
+	source.SetChunkSize(8)
+
+	snapshot, err := source.Create(5)
+	require.NoError(t, err)
+	require.Equal(t, types.FormatZstd, snapshot.Format)
+	require.Greater(t, snapshot.Chunks, uint32(1))
+	chunks := make([][]byte, snapshot.Chunks)
+	for i := range chunks {
+		chunks[i], err = source.LoadChunk(snapshot.Height, snapshot.Format, uint32(i))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(chunks[i]), 8)
+	}
+
+	// the restoring node does not need to be configured with the same format
+	target := &mockSnapshotter{}
+	manager := snapshots.NewManager(setupStore(t), target)
+	require.NoError(t, manager.Restore(*snapshot))
+	for i, chunk := range chunks {
+		done, err := manager.RestoreChunk(chunk)
+		require.NoError(t, err)
+		require.Equal(t, i == len(chunks)-1, done)
+	}
+	require.Equal(t, items, target.items)
+}
@@ -0,0 +1,285 @@
+package snapshots
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SnapshotObjectName is the name of the object holding the snapshot metadata,
+// as JSON, among the objects of an exported snapshot. The chunks are the
+// objects named after their index, e.g. 0, 1, 2...
+const SnapshotObjectName = "snapshot.json"
+
+// ObjectReader reads the objects of an object storage, such as a bucket or a
+// web server, snapshots are imported from.
+type ObjectReader interface {
+	// GetObject returns the content of the object of key, which must be closed.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ObjectWriter writes the objects of an object storage snapshots are exported
+// to.
+type ObjectWriter interface {
+	// PutObject creates or replaces the object of key.
+	PutObject(ctx context.Context, key string, body []byte) error
+}
+
+// ExportSnapshot exports the snapshot of the store at height and format to dst,
+// as a snapshot.json object with its metadata and an object per chunk. The
+// chunks are checked against their hashes before being exported, and the
+// metadata is written last, so that an interrupted export is not imported.
+func ExportSnapshot(ctx context.Context, store *Store, height uint64, format uint32, dst ObjectWriter) (*types.Snapshot, error) {
+	snapshot, chunks, err := store.Load(height, format)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "snapshot for height %v format %v", height, format)
+	}
+	defer DrainChunks(chunks)
+
+	index := uint32(0)
+	for chunk := range chunks {
+		body, err := ioutil.ReadAll(chunk)
+		chunk.Close()
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to read snapshot chunk %v", index)
+		}
+		if err := verifyChunk(snapshot, index, body); err != nil {
+			return nil, err
+		}
+		if err := dst.PutObject(ctx, strconv.FormatUint(uint64(index), 10), body); err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to export snapshot chunk %v", index)
+		}
+		index++
+	}
+	if index != snapshot.Chunks {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidMetadata, "snapshot has %v chunks, but %v were loaded", snapshot.Chunks, index)
+	}
+
+	metadata, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to encode snapshot metadata")
+	}
+	if err := dst.PutObject(ctx, SnapshotObjectName, metadata); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to export snapshot metadata")
+	}
+	return snapshot, nil
+}
+
+// ImportSnapshot imports the snapshot exported to src by ExportSnapshot into
+// the store, from which it can then be restored or served to the peers
+// state syncing. Every chunk is checked against the hash of the metadata
+// before being saved, as is the hash of the whole snapshot, which must be
+// trustedHash unless it is empty. Nothing is saved if any check fails.
+func ImportSnapshot(ctx context.Context, store *Store, src ObjectReader, trustedHash []byte) (*types.Snapshot, error) {
+	return ImportSnapshotWithFailover(ctx, store, []ObjectReader{src}, trustedHash)
+}
+
+// ImportSnapshotWithFailover imports the snapshot exported by ExportSnapshot
+// to several providers, e.g. mirrors of the same bucket, as ImportSnapshot
+// does from one. The snapshot is the one of the first provider serving valid
+// metadata, of trustedHash unless it is empty, and its chunks are fetched from
+// the providers serving the same metadata: when a provider fails to serve a
+// chunk, or serves one not matching its hash, the chunk is fetched from the
+// next one. The import only fails once every provider failed.
+func ImportSnapshotWithFailover(ctx context.Context, store *Store, srcs []ObjectReader, trustedHash []byte) (*types.Snapshot, error) {
+	if len(srcs) == 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no snapshot providers")
+	}
+	snapshot, srcs, err := selectSnapshotProviders(ctx, srcs, trustedHash)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan io.ReadCloser)
+	errCh := make(chan error, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer close(ch)
+		// the chunks are fetched from the last provider which served one
+		current := 0
+		for index := uint32(0); index < snapshot.Chunks; index++ {
+			var (
+				body []byte
+				err  error
+			)
+			for i := range srcs {
+				src := (current + i) % len(srcs)
+				if body, err = getChunk(ctx, srcs[src], snapshot, index); err == nil {
+					current = src
+					break
+				}
+			}
+			if err != nil {
+				errCh <- failedProvidersError(err, len(srcs))
+				return
+			}
+			select {
+			case ch <- ioutil.NopCloser(bytes.NewReader(body)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	saved, err := store.Save(snapshot.Height, snapshot.Format, ch)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case err = <-errCh:
+	default:
+		// the chunks were saved as exported, so the hash of the snapshot is
+		// the exported one unless the metadata is inconsistent
+		if !bytes.Equal(saved.Hash, snapshot.Hash) {
+			err = sdkerrors.Wrapf(types.ErrInvalidMetadata, "snapshot hash %X does not match its chunks, of hash %X",
+				snapshot.Hash, saved.Hash)
+		}
+	}
+	if err != nil {
+		if delErr := store.Delete(snapshot.Height, snapshot.Format); delErr != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to delete the partially imported snapshot: %v", delErr)
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+// selectSnapshotProviders returns the snapshot of the first of srcs serving
+// valid metadata, of trustedHash unless it is empty, along with the providers
+// serving the same metadata, in the order of srcs.
+func selectSnapshotProviders(ctx context.Context, srcs []ObjectReader, trustedHash []byte) (*types.Snapshot, []ObjectReader, error) {
+	var (
+		snapshot  *types.Snapshot
+		providers []ObjectReader
+		lastErr   error
+	)
+	for _, src := range srcs {
+		metadata, err := getSnapshotMetadata(ctx, src)
+		switch {
+		case err != nil:
+			lastErr = err
+		case len(trustedHash) > 0 && !bytes.Equal(metadata.Hash, trustedHash):
+			lastErr = sdkerrors.Wrapf(types.ErrInvalidMetadata, "snapshot hash %X does not match trusted hash %X",
+				metadata.Hash, trustedHash)
+		case snapshot == nil:
+			snapshot = metadata
+			providers = append(providers, src)
+		case metadata.Height == snapshot.Height && metadata.Format == snapshot.Format && bytes.Equal(metadata.Hash, snapshot.Hash):
+			providers = append(providers, src)
+		}
+	}
+	if snapshot == nil {
+		return nil, nil, failedProvidersError(lastErr, len(srcs))
+	}
+	return snapshot, providers, nil
+}
+
+// failedProvidersError returns err, the error of the last of n providers,
+// once they all failed.
+func failedProvidersError(err error, n int) error {
+	if n == 1 {
+		return err
+	}
+	return sdkerrors.Wrapf(err, "all %d snapshot providers failed", n)
+}
+
+// getSnapshotMetadata reads and validates the metadata of the snapshot of src.
+func getSnapshotMetadata(ctx context.Context, src ObjectReader) (*types.Snapshot, error) {
+	body, err := getObject(ctx, src, SnapshotObjectName)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to fetch snapshot metadata")
+	}
+	snapshot := &types.Snapshot{}
+	if err := json.Unmarshal(body, snapshot); err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidMetadata, "failed to decode snapshot metadata: %v", err)
+	}
+	switch {
+	case snapshot.Height == 0:
+		return nil, sdkerrors.Wrap(types.ErrInvalidMetadata, "snapshot height cannot be 0")
+	case snapshot.Chunks == 0:
+		return nil, sdkerrors.Wrap(types.ErrInvalidMetadata, "no chunks")
+	case uint32(len(snapshot.Metadata.ChunkHashes)) != snapshot.Chunks:
+		return nil, sdkerrors.Wrapf(types.ErrInvalidMetadata, "snapshot has %v chunk hashes, but %v chunks",
+			len(snapshot.Metadata.ChunkHashes), snapshot.Chunks)
+	}
+	return snapshot, nil
+}
+
+func getChunk(ctx context.Context, src ObjectReader, snapshot *types.Snapshot, index uint32) ([]byte, error) {
+	body, err := getObject(ctx, src, strconv.FormatUint(uint64(index), 10))
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "failed to fetch snapshot chunk %v", index)
+	}
+	return body, verifyChunk(snapshot, index, body)
+}
+
+func getObject(ctx context.Context, src ObjectReader, key string) ([]byte, error) {
+	r, err := src.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// verifyChunk checks the chunk at index against its hash in the snapshot
+// metadata.
+func verifyChunk(snapshot *types.Snapshot, index uint32, chunk []byte) error {
+	if int(index) >= len(snapshot.Metadata.ChunkHashes) {
+		return sdkerrors.Wrapf(types.ErrInvalidMetadata, "no hash for snapshot chunk %v", index)
+	}
+	hash := sha256.Sum256(chunk)
+	if expected := snapshot.Metadata.ChunkHashes[index]; !bytes.Equal(hash[:], expected) {
+		return sdkerrors.Wrapf(types.ErrChunkHashMismatch, "chunk %v: expected %x, got %x", index, expected, hash)
+	}
+	return nil
+}
+
+// URLObjectReader reads the objects under a base URL with HTTP GET requests,
+// e.g. the objects of a public bucket or of a web server.
+type URLObjectReader struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ ObjectReader = URLObjectReader{}
+
+// NewURLObjectReader returns a reader of the objects under baseURL.
+func NewURLObjectReader(baseURL string, client *http.Client) URLObjectReader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return URLObjectReader{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+// GetObject implements ObjectReader.
+func (r URLObjectReader) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"/"+key, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", req.URL, res.Status)
+	}
+	return res.Body, nil
+}
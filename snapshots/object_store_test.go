@@ -0,0 +1,206 @@
+package snapshots_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	"github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// objectServer is an object storage serving its objects over HTTP, the
+// objects being put with PUT requests or PutObject.
+type objectServer struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+}
+
+func newObjectServer() *objectServer {
+	return &objectServer{objects: map[string][]byte{}}
+}
+
+func (s *objectServer) PutObject(_ context.Context, key string, body []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.objects[key] = body
+	return nil
+}
+
+func (s *objectServer) GetObject(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	body, ok := s.objects[key]
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrNotFound, key)
+	}
+	return ioutil.NopCloser(bytes.NewReader(body)), nil
+}
+
+func (s *objectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		s.PutObject(r.Context(), key, body)
+	case http.MethodGet:
+		body, err := s.GetObject(r.Context(), key)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.Copy(w, body)
+	}
+}
+
+func newEmptyStore(t *testing.T) *snapshots.Store {
+	tempdir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(tempdir) })
+
+	store, err := snapshots.NewStore(db.NewMemDB(), tempdir)
+	require.NoError(t, err)
+	return store
+}
+
+func TestExportImportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(t)
+	objects := newObjectServer()
+
+	snapshot, err := snapshots.ExportSnapshot(ctx, store, 2, 2, objects)
+	require.NoError(t, err)
+	require.Len(t, objects.objects, 4)
+	require.Equal(t, []byte{2, 2, 1}, objects.objects["1"])
+
+	_, err = snapshots.ExportSnapshot(ctx, store, 9, 1, objects)
+	require.ErrorIs(t, err, sdkerrors.ErrNotFound)
+
+	target := newEmptyStore(t)
+	_, err = snapshots.ImportSnapshot(ctx, target, objects, []byte{1, 2, 3})
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+
+	imported, err := snapshots.ImportSnapshot(ctx, target, objects, snapshot.Hash)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, imported)
+	_, chunks, err := target.Load(2, 2)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{2, 2, 0}, {2, 2, 1}, {2, 2, 2}}, readChunks(chunks))
+
+	// importing a snapshot twice is a conflict
+	_, err = snapshots.ImportSnapshot(ctx, target, objects, nil)
+	require.ErrorIs(t, err, sdkerrors.ErrConflict)
+}
+
+func TestImportSnapshot_Tampered(t *testing.T) {
+	ctx := context.Background()
+	objects := newObjectServer()
+	_, err := snapshots.ExportSnapshot(ctx, setupStore(t), 2, 2, objects)
+	require.NoError(t, err)
+	objects.objects["2"] = []byte{2, 2, 9}
+
+	target := newEmptyStore(t)
+	_, err = snapshots.ImportSnapshot(ctx, target, objects, nil)
+	require.ErrorIs(t, err, types.ErrChunkHashMismatch)
+
+	// nothing of the snapshot is kept
+	snapshot, err := target.Get(2, 2)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+	chunk, err := target.LoadChunk(2, 2, 0)
+	require.NoError(t, err)
+	require.Nil(t, chunk)
+
+	delete(objects.objects, "2")
+	_, err = snapshots.ImportSnapshot(ctx, target, objects, nil)
+	require.ErrorIs(t, err, sdkerrors.ErrNotFound)
+	snapshot, err = target.Get(2, 2)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+}
+
+func TestImportSnapshotWithFailover(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(t)
+	// a provider without the snapshot, one serving a tampered chunk, one
+	// serving another snapshot and one serving the snapshot
+	missing, tampered, other, good := newObjectServer(), newObjectServer(), newObjectServer(), newObjectServer()
+	snapshot, err := snapshots.ExportSnapshot(ctx, store, 2, 2, tampered)
+	require.NoError(t, err)
+	tampered.objects["1"] = []byte{2, 2, 9}
+	otherSnapshot, err := snapshots.ExportSnapshot(ctx, store, 3, 2, other)
+	require.NoError(t, err)
+	_, err = snapshots.ExportSnapshot(ctx, store, 2, 2, good)
+	require.NoError(t, err)
+	delete(good.objects, "0")
+
+	// the chunks missing from a provider are fetched from the next one
+	target := newEmptyStore(t)
+	imported, err := snapshots.ImportSnapshotWithFailover(ctx, target, []snapshots.ObjectReader{missing, tampered, other, good}, nil)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, imported)
+	_, chunks, err := target.Load(2, 2)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{2, 2, 0}, {2, 2, 1}, {2, 2, 2}}, readChunks(chunks))
+
+	// the providers of another snapshot are not used
+	_, err = snapshots.ImportSnapshotWithFailover(ctx, newEmptyStore(t), []snapshots.ObjectReader{tampered, other}, nil)
+	require.ErrorIs(t, err, types.ErrChunkHashMismatch)
+	imported, err = snapshots.ImportSnapshotWithFailover(ctx, newEmptyStore(t), []snapshots.ObjectReader{tampered, other}, otherSnapshot.Hash)
+	require.NoError(t, err)
+	require.Equal(t, otherSnapshot, imported)
+
+	_, err = snapshots.ImportSnapshotWithFailover(ctx, newEmptyStore(t), []snapshots.ObjectReader{missing, other}, snapshot.Hash)
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+	require.ErrorContains(t, err, "all 2 snapshot providers failed")
+	_, err = snapshots.ImportSnapshotWithFailover(ctx, newEmptyStore(t), nil, nil)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestExportImportSnapshot_S3(t *testing.T) {
+	ctx := context.Background()
+	objects := newObjectServer()
+	srv := httptest.NewServer(objects)
+	defer srv.Close()
+
+	client, err := snapshots.NewS3Client(snapshots.S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "bucket",
+		Prefix:          "/snapshots/",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	}, nil)
+	require.NoError(t, err)
+	client = client.WithPrefix("2/2")
+	require.Equal(t, srv.URL+"/bucket/snapshots/2/2", client.URL())
+
+	snapshot, err := snapshots.ExportSnapshot(ctx, setupStore(t), 2, 2, client)
+	require.NoError(t, err)
+	require.Contains(t, objects.objects, "bucket/snapshots/2/2/snapshot.json")
+
+	// the exported snapshot can be imported from the bucket or its URL
+	imported, err := snapshots.ImportSnapshot(ctx, newEmptyStore(t), client, snapshot.Hash)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, imported)
+	imported, err = snapshots.ImportSnapshot(ctx, newEmptyStore(t), snapshots.NewURLObjectReader(client.URL(), nil), snapshot.Hash)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, imported)
+
+	_, err = snapshots.ImportSnapshot(ctx, newEmptyStore(t), snapshots.NewURLObjectReader(srv.URL+"/missing", nil), nil)
+	require.ErrorContains(t, err, "404 Not Found")
+}
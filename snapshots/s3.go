@@ -0,0 +1,207 @@
+package snapshots
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3SignAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat    = "20060102T150405Z"
+)
+
+// S3Config configures the S3-compatible object storage bucket of an S3Client.
+type S3Config struct {
+	// Endpoint is the URL of the storage, https://s3.<region>.amazonaws.com
+	// for AWS.
+	Endpoint string
+	// Region is the region of the bucket, us-east-1 if empty.
+	Region string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Prefix is the prefix of the keys of the objects, if any.
+	Prefix string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is the token of temporary credentials, if any.
+	SessionToken string
+}
+
+// S3Client reads and writes the objects of an S3-compatible object storage
+// bucket, addressed by path, authenticating its requests with AWS signature
+// version 4.
+type S3Client struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+var (
+	_ ObjectReader = (*S3Client)(nil)
+	_ ObjectWriter = (*S3Client)(nil)
+)
+
+// NewS3Client returns a client of the bucket of cfg.
+func NewS3Client(cfg S3Config, client *http.Client) (*S3Client, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 endpoint and bucket are required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 credentials are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &S3Client{cfg: cfg, client: client, now: time.Now}, nil
+}
+
+// WithPrefix returns a client of the objects under prefix, relative to the
+// prefix of c.
+func (c *S3Client) WithPrefix(prefix string) *S3Client {
+	cfg := c.cfg
+	cfg.Prefix = strings.Trim(strings.Trim(cfg.Prefix, "/")+"/"+strings.Trim(prefix, "/"), "/")
+	return &S3Client{cfg: cfg, client: c.client, now: c.now}
+}
+
+// URL returns the URL of the objects of the client, under which they can be
+// read with NewURLObjectReader if they are public.
+func (c *S3Client) URL() string {
+	return c.objectURL("")
+}
+
+// PutObject implements ObjectWriter.
+func (c *S3Client) PutObject(ctx context.Context, key string, body []byte) error {
+	res, err := c.do(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// GetObject implements ObjectReader.
+func (c *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := c.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (c *S3Client) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, body)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s: %s", method, req.URL, res.Status, bytes.TrimSpace(msg))
+	}
+	return res, nil
+}
+
+func (c *S3Client) objectURL(key string) string {
+	path := c.cfg.Bucket
+	if c.cfg.Prefix != "" {
+		path += "/" + c.cfg.Prefix
+	}
+	if key != "" {
+		path += "/" + key
+	}
+	return c.cfg.Endpoint + "/" + s3EscapePath(path)
+}
+
+// sign adds the headers of the AWS signature version 4 of req, whose payload
+// is body, signing all of its headers.
+func (c *S3Client) sign(req *http.Request, body []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format(s3DateFormat)
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	if c.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		"", // the requests have no query
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := strings.Join([]string{now.Format("20060102"), c.cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3SignAlgorithm, amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte("AWS4" + c.cfg.SecretAccessKey)
+	for _, part := range strings.Split(scope, "/") {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SignAlgorithm, c.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath escapes every byte of path but the unreserved characters and
+// the slashes, the way signature version 4 expects.
+func s3EscapePath(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		b := path[i]
+		if 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || strings.IndexByte("-_.~/", b) >= 0 {
+			sb.WriteByte(b)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+package snapshots
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// StateFileVersion is the version of the state files written by
+	// ExportState, to be bumped when their layout changes.
+	StateFileVersion uint32 = 1
+
+	// maxStateAppHashSize bounds the app hash read from a state file header.
+	maxStateAppHashSize = 1024
+)
+
+// stateFileMagic starts the state files written by ExportState.
+var stateFileMagic = []byte("SDKSTATE")
+
+// StateHeader is the header of a state file, recording the state it holds.
+type StateHeader struct {
+	Height  uint64
+	AppHash []byte
+}
+
+// ExportState writes the state of the multistore and extensions at the height
+// of header to w, for ImportState to restore it on another node. The state
+// file is the header followed by the items of a snapshot in FormatZstd, in a
+// single stream: the IAVL nodes are exported with their heights and versions,
+// so the imported trees are identical to the exported ones, app hash included,
+// without being rebuilt from the leaves.
+//
+// The header is written as the magic bytes, then StateFileVersion, the height
+// and the length of the app hash as big-endian integers, then the app hash.
+func (m *Manager) ExportState(header StateHeader, w io.Writer) error {
+	if m == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrLogic, "no snapshot store configured")
+	}
+	if err := m.begin(opSnapshot); err != nil {
+		return err
+	}
+	defer m.end()
+	m.setProgress(Progress{Operation: string(opSnapshot), Height: header.Height})
+
+	bufWriter := bufio.NewWriter(w)
+	if err := writeStateHeader(bufWriter, header); err != nil {
+		return err
+	}
+	zWriter, err := zstd.NewWriter(bufWriter, zstd.WithEncoderLevel(snapshotZstdCompressionLevel))
+	if err != nil {
+		return sdkerrors.Wrap(err, "zstd failure")
+	}
+	defer zWriter.Close()
+	if err := m.writeItems(header.Height, protoio.NewDelimitedWriter(zWriter)); err != nil {
+		return err
+	}
+	if err := zWriter.Close(); err != nil {
+		return err
+	}
+	return bufWriter.Flush()
+}
+
+// ImportState restores the multistore and extensions from the state file
+// written by ExportState read from r, and returns its header. The multistore
+// must be empty, and its app hash is left to be checked against the one of the
+// header by the caller.
+func (m *Manager) ImportState(r io.Reader) (StateHeader, error) {
+	if m == nil {
+		return StateHeader{}, sdkerrors.Wrap(sdkerrors.ErrLogic, "no snapshot store configured")
+	}
+	bufReader := bufio.NewReader(r)
+	header, err := readStateHeader(bufReader)
+	if err != nil {
+		return StateHeader{}, err
+	}
+	if header.Height == 0 {
+		return StateHeader{}, sdkerrors.Wrap(sdkerrors.ErrLogic, "cannot import state at height 0")
+	}
+	if header.Height > uint64(math.MaxInt64) {
+		return StateHeader{}, sdkerrors.Wrapf(types.ErrInvalidMetadata,
+			"state height %v cannot exceed %v", header.Height, int64(math.MaxInt64))
+	}
+
+	if err := m.begin(opRestore); err != nil {
+		return StateHeader{}, err
+	}
+	defer m.end()
+	m.setProgress(Progress{Operation: string(opRestore), Height: header.Height})
+
+	decoder, err := zstd.NewReader(bufReader)
+	if err != nil {
+		return StateHeader{}, sdkerrors.Wrap(err, "zstd failure")
+	}
+	defer decoder.Close()
+	protoReader := protoio.NewDelimitedReader(decoder, snapshotMaxItemSize)
+	if err := m.restoreItems(header.Height, types.FormatZstd, protoReader); err != nil {
+		return StateHeader{}, err
+	}
+	return header, nil
+}
+
+func writeStateHeader(w io.Writer, header StateHeader) error {
+	buf := bytes.NewBuffer(append([]byte{}, stateFileMagic...))
+	_ = binary.Write(buf, binary.BigEndian, StateFileVersion)
+	_ = binary.Write(buf, binary.BigEndian, header.Height)
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(header.AppHash)))
+	buf.Write(header.AppHash)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func readStateHeader(r io.Reader) (StateHeader, error) {
+	magic := make([]byte, len(stateFileMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return StateHeader{}, sdkerrors.Wrap(err, "failed to read state header")
+	}
+	if !bytes.Equal(magic, stateFileMagic) {
+		return StateHeader{}, sdkerrors.Wrap(types.ErrInvalidMetadata, "not a state file")
+	}
+	var fields struct {
+		Version     uint32
+		Height      uint64
+		AppHashSize uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &fields); err != nil {
+		return StateHeader{}, sdkerrors.Wrap(err, "failed to read state header")
+	}
+	if fields.Version != StateFileVersion {
+		return StateHeader{}, sdkerrors.Wrapf(types.ErrUnknownFormat, "state file version %v", fields.Version)
+	}
+	if fields.AppHashSize > maxStateAppHashSize {
+		return StateHeader{}, sdkerrors.Wrapf(types.ErrInvalidMetadata, "app hash of %d bytes", fields.AppHashSize)
+	}
+	header := StateHeader{Height: fields.Height, AppHash: make([]byte, fields.AppHashSize)}
+	if _, err := io.ReadFull(r, header.AppHash); err != nil {
+		return StateHeader{}, sdkerrors.Wrap(err, "failed to read state header")
+	}
+	return header, nil
+}
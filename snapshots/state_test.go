@@ -0,0 +1,53 @@
+package snapshots_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/snapshots"
+	"github.com/cosmos/cosmos-sdk/snapshots/types"
+)
+
+func TestManager_ExportImportState(t *testing.T) {
+	store := setupStore(t)
+	snapshotsBefore, err := store.List()
+	require.NoError(t, err)
+	items := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	source := snapshots.NewManager(store, &mockSnapshotter{items: items})
+	header := snapshots.StateHeader{Height: 5, AppHash: []byte{0xab, 0xcd}}
+
+	var buf bytes.Buffer
+	require.NoError(t, source.ExportState(header, &buf))
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("SDKSTATE")))
+
+	target := &mockSnapshotter{}
+	imported, err := snapshots.NewManager(setupStore(t), target).ImportState(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, header, imported)
+	require.Equal(t, items, target.items)
+
+	// the state is not saved as a snapshot
+	snapshotsAfter, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, snapshotsBefore, snapshotsAfter)
+}
+
+func TestManager_ImportStateInvalidHeader(t *testing.T) {
+	manager := snapshots.NewManager(setupStore(t), &mockSnapshotter{})
+	_, err := manager.ImportState(bytes.NewReader([]byte("not a state file")))
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshots.NewManager(setupStore(t), &mockSnapshotter{items: [][]byte{{1}}}).
+		ExportState(snapshots.StateHeader{Height: 1}, &buf))
+	state := buf.Bytes()
+	// bump the version following the magic bytes
+	state[11]++
+	_, err = manager.ImportState(bytes.NewReader(state))
+	require.ErrorIs(t, err, types.ErrUnknownFormat)
+
+	_, err = manager.ImportState(bytes.NewReader(state[:10]))
+	require.Error(t, err)
+}
@@ -7,34 +7,54 @@ import (
 
 	protoio "github.com/gogo/protobuf/io"
 	"github.com/gogo/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
 
+	"github.com/cosmos/cosmos-sdk/snapshots/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 const (
-	// Do not change chunk size without new snapshot format (must be uniform across nodes)
-	snapshotChunkSize  = uint64(10e6)
-	snapshotBufferSize = int(snapshotChunkSize)
-	// Do not change compression level without new snapshot format (must be uniform across nodes)
-	snapshotCompressionLevel = 7
+	// Do not change compression levels without new snapshot format (must be uniform across nodes)
+	snapshotCompressionLevel     = 7
+	snapshotZstdCompressionLevel = zstd.SpeedDefault
 )
 
 // StreamWriter set up a stream pipeline to serialize snapshot nodes:
-// Exported Items -> delimited Protobuf -> zlib -> buffer -> chunkWriter -> chan io.ReadCloser
+// Exported Items -> delimited Protobuf -> zlib/zstd -> buffer -> chunkWriter -> chan io.ReadCloser
 type StreamWriter struct {
 	chunkWriter *ChunkWriter
 	bufWriter   *bufio.Writer
-	zWriter     *zlib.Writer
+	zWriter     io.WriteCloser
 	protoWriter protoio.WriteCloser
 }
 
-// NewStreamWriter set up a stream pipeline to serialize snapshot DB records.
+// NewStreamWriter set up a stream pipeline to serialize snapshot DB records
+// in the current format, with the default chunk size.
 func NewStreamWriter(ch chan<- io.ReadCloser) *StreamWriter {
-	chunkWriter := NewChunkWriter(ch, snapshotChunkSize)
-	bufWriter := bufio.NewWriterSize(chunkWriter, snapshotBufferSize)
-	zWriter, err := zlib.NewWriterLevel(bufWriter, snapshotCompressionLevel)
+	return NewStreamWriterWithFormat(ch, types.CurrentFormat, types.DefaultChunkSize)
+}
+
+// NewStreamWriterWithFormat set up a stream pipeline to serialize snapshot DB
+// records in the given format, split into chunks of chunkSize bytes. Nodes
+// with different chunk sizes produce snapshots with different hashes, which
+// are fetched from different sets of peers during state sync.
+func NewStreamWriterWithFormat(ch chan<- io.ReadCloser, format uint32, chunkSize uint64) *StreamWriter {
+	chunkWriter := NewChunkWriter(ch, chunkSize)
+	bufWriter := bufio.NewWriterSize(chunkWriter, int(chunkSize))
+	var zWriter io.WriteCloser
+	var err error
+	switch format {
+	case types.FormatZlib:
+		zWriter, err = zlib.NewWriterLevel(bufWriter, snapshotCompressionLevel)
+		err = sdkerrors.Wrap(err, "zlib failure")
+	case types.FormatZstd:
+		zWriter, err = zstd.NewWriter(bufWriter, zstd.WithEncoderLevel(snapshotZstdCompressionLevel))
+		err = sdkerrors.Wrap(err, "zstd failure")
+	default:
+		err = sdkerrors.Wrapf(types.ErrUnknownFormat, "format %v", format)
+	}
 	if err != nil {
-		chunkWriter.CloseWithError(sdkerrors.Wrap(err, "zlib failure"))
+		chunkWriter.CloseWithError(err)
 		return nil
 	}
 	protoWriter := protoio.NewDelimitedWriter(zWriter)
@@ -74,19 +94,38 @@ func (sw *StreamWriter) CloseWithError(err error) {
 }
 
 // StreamReader set up a restore stream pipeline
-// chan io.ReadCloser -> chunkReader -> zlib -> delimited Protobuf -> ExportNode
+// chan io.ReadCloser -> chunkReader -> zlib/zstd -> delimited Protobuf -> ExportNode
 type StreamReader struct {
 	chunkReader *ChunkReader
 	zReader     io.ReadCloser
 	protoReader protoio.ReadCloser
 }
 
-// NewStreamReader set up a restore stream pipeline.
+// NewStreamReader set up a restore stream pipeline for the current format.
 func NewStreamReader(chunks <-chan io.ReadCloser) (*StreamReader, error) {
+	return NewStreamReaderWithFormat(chunks, types.CurrentFormat)
+}
+
+// NewStreamReaderWithFormat set up a restore stream pipeline for the given
+// format.
+func NewStreamReaderWithFormat(chunks <-chan io.ReadCloser, format uint32) (*StreamReader, error) {
 	chunkReader := NewChunkReader(chunks)
-	zReader, err := zlib.NewReader(chunkReader)
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "zlib failure")
+	var zReader io.ReadCloser
+	switch format {
+	case types.FormatZlib:
+		var err error
+		zReader, err = zlib.NewReader(chunkReader)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "zlib failure")
+		}
+	case types.FormatZstd:
+		decoder, err := zstd.NewReader(chunkReader)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "zstd failure")
+		}
+		zReader = decoder.IOReadCloser()
+	default:
+		return nil, sdkerrors.Wrapf(types.ErrUnknownFormat, "format %v", format)
 	}
 	protoReader := protoio.NewDelimitedReader(zReader, snapshotMaxItemSize)
 	return &StreamReader{
@@ -1,6 +1,48 @@
 package types
 
-// CurrentFormat is the currently used format for snapshots. Snapshots using the same format
-// must be identical across all nodes for a given height, so this must be bumped when the binary
-// snapshot output changes.
-const CurrentFormat uint32 = 1
+import "fmt"
+
+const (
+	// FormatZlib is a zlib-compressed stream of snapshot items.
+	FormatZlib uint32 = 1
+	// FormatZstd is the stream of FormatZlib compressed with zstd, which is
+	// faster to compress and decompress at a similar ratio.
+	FormatZstd uint32 = 2
+)
+
+// CurrentFormat is the format snapshots are created in by default. Snapshots using the same
+// format must be identical across all nodes for a given height, so a new format must be added
+// when the binary snapshot output changes.
+const CurrentFormat uint32 = FormatZlib
+
+// SupportedFormats are the formats snapshots can be created in and restored from.
+var SupportedFormats = []uint32{FormatZlib, FormatZstd}
+
+// DefaultChunkSize is the size of the chunks snapshots are split into by default.
+const DefaultChunkSize = uint64(10e6)
+
+// FormatFromCompression returns the snapshot format using the given
+// compression, "zlib" or "zstd". An empty compression is the current format.
+func FormatFromCompression(compression string) (uint32, error) {
+	switch compression {
+	case "":
+		return CurrentFormat, nil
+	case "zlib":
+		return FormatZlib, nil
+	case "zstd":
+		return FormatZstd, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown snapshot compression %q", ErrUnknownFormat, compression)
+	}
+}
+
+// IsSupportedFormat returns whether snapshots can be created in and restored
+// from format.
+func IsSupportedFormat(format uint32) bool {
+	for _, f := range SupportedFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
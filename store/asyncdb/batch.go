@@ -0,0 +1,66 @@
+package asyncdb
+
+import (
+	"errors"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+var errBatchClosed = errors.New("batch has been written or closed")
+
+// batch collects writes that are applied to the open layer of db at once.
+type batch struct {
+	db  *DB
+	ops layer
+}
+
+var _ dbm.Batch = (*batch)(nil)
+
+// Set implements Batch.
+func (b *batch) Set(key, value []byte) error {
+	if err := validateSet(key, value); err != nil {
+		return err
+	}
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	b.ops[string(key)] = cp(value)
+	return nil
+}
+
+// Delete implements Batch.
+func (b *batch) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	b.ops[string(key)] = nil
+	return nil
+}
+
+// Write implements Batch.
+func (b *batch) Write() error {
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	b.db.mtx.Lock()
+	for key, value := range b.ops {
+		b.db.current[key] = value
+	}
+	b.db.mtx.Unlock()
+	return b.Close()
+}
+
+// WriteSync implements Batch. The writes are only durable once their layer is
+// flushed.
+func (b *batch) WriteSync() error {
+	return b.Write()
+}
+
+// Close implements Batch.
+func (b *batch) Close() error {
+	b.ops = nil
+	return nil
+}
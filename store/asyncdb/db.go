@@ -0,0 +1,299 @@
+// Package asyncdb implements a write-behind tm-db DB. Writes are kept in memory
+// and handed over to a background writer one version at a time, so that a
+// commit does not wait for its data to reach the disk.
+package asyncdb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+var (
+	errKeyEmpty = errors.New("key cannot be empty")
+	errValueNil = errors.New("value cannot be nil")
+	errClosed   = errors.New("asyncdb: db is closed")
+)
+
+// layer holds the writes of a version. A nil value is a deletion.
+type layer map[string][]byte
+
+// DB buffers writes on top of a parent DB. Writes go to the open layer, which
+// Seal closes and queues for the background writer. The writer applies each
+// sealed layer to the parent in a single synced batch, in the order they were
+// sealed, so the parent always holds the data of a whole number of layers: if
+// the process stops before the queue is drained, the parent is left at the
+// last layer that was written, as if the later ones had never been made.
+//
+// Reads see every write, whether it was flushed or not. At most maxPending
+// layers wait for the writer, Seal blocks when the queue is full.
+type DB struct {
+	parent dbm.DB
+
+	mtx     sync.RWMutex
+	flushed *sync.Cond
+	current layer
+	// pending are the sealed layers that are not written yet, oldest first.
+	pending []layer
+	err     error
+	closed  bool
+
+	sealMtx sync.Mutex
+	queue   chan layer
+	done    chan struct{}
+}
+
+var _ dbm.DB = (*DB)(nil)
+
+// New returns a DB writing to parent in the background, with at most
+// maxPending sealed layers waiting to be written.
+func New(parent dbm.DB, maxPending int) *DB {
+	if maxPending < 1 {
+		panic(fmt.Sprintf("maxPending must be positive, got %d", maxPending))
+	}
+	db := &DB{
+		parent:  parent,
+		current: layer{},
+		queue:   make(chan layer, maxPending),
+		done:    make(chan struct{}),
+	}
+	db.flushed = sync.NewCond(&db.mtx)
+	go db.run()
+	return db
+}
+
+// run writes the sealed layers to the parent until the queue is closed. After
+// a failed write, later layers are not written so that the parent stays at a
+// layer boundary; they remain readable from memory.
+func (db *DB) run() {
+	defer close(db.done)
+	for l := range db.queue {
+		db.mtx.RLock()
+		failed := db.err != nil
+		db.mtx.RUnlock()
+		if failed {
+			continue
+		}
+
+		err := db.write(l)
+		db.mtx.Lock()
+		if err != nil {
+			db.err = fmt.Errorf("asyncdb: background write failed: %w", err)
+		} else {
+			db.pending = db.pending[1:]
+		}
+		db.flushed.Broadcast()
+		db.mtx.Unlock()
+	}
+}
+
+func (db *DB) write(l layer) error {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	batch := db.parent.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		var err error
+		if value := l[key]; value == nil {
+			err = batch.Delete([]byte(key))
+		} else {
+			err = batch.Set([]byte(key), value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// Seal closes the open layer and queues it for the background writer. It
+// blocks while the queue is full, and returns the error of a previous failed
+// write, if any.
+func (db *DB) Seal() error {
+	db.sealMtx.Lock()
+	defer db.sealMtx.Unlock()
+
+	db.mtx.Lock()
+	if db.err != nil || db.closed {
+		defer db.mtx.Unlock()
+		if db.closed {
+			return errClosed
+		}
+		return db.err
+	}
+	if len(db.current) == 0 {
+		db.mtx.Unlock()
+		return nil
+	}
+	sealed := db.current
+	db.current = layer{}
+	db.pending = append(db.pending, sealed)
+	db.mtx.Unlock()
+
+	db.queue <- sealed
+	return nil
+}
+
+// Flush seals the open layer and waits until every sealed layer is written.
+func (db *DB) Flush() error {
+	if err := db.Seal(); err != nil {
+		return err
+	}
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	for len(db.pending) > 0 && db.err == nil {
+		db.flushed.Wait()
+	}
+	return db.err
+}
+
+// Get implements DB.
+func (db *DB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	if value, ok := db.getBuffered(key); ok {
+		return value, nil
+	}
+	// a layer written since getBuffered is already in the parent
+	return db.parent.Get(key)
+}
+
+// getBuffered returns the latest unwritten value of key, ok being false if
+// key was not written since the last flushed layer.
+func (db *DB) getBuffered(key []byte) (value []byte, ok bool) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	if value, ok := db.current[string(key)]; ok {
+		return value, true
+	}
+	for i := len(db.pending) - 1; i >= 0; i-- {
+		if value, ok := db.pending[i][string(key)]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Has implements DB.
+func (db *DB) Has(key []byte) (bool, error) {
+	value, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return value != nil, nil
+}
+
+// Set implements DB.
+func (db *DB) Set(key []byte, value []byte) error {
+	if err := validateSet(key, value); err != nil {
+		return err
+	}
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	db.current[string(key)] = cp(value)
+	return nil
+}
+
+// SetSync implements DB. The write is only durable once its layer is flushed.
+func (db *DB) SetSync(key []byte, value []byte) error {
+	return db.Set(key, value)
+}
+
+// Delete implements DB.
+func (db *DB) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	db.current[string(key)] = nil
+	return nil
+}
+
+// DeleteSync implements DB. The deletion is only durable once its layer is
+// flushed.
+func (db *DB) DeleteSync(key []byte) error {
+	return db.Delete(key)
+}
+
+// Iterator implements DB.
+func (db *DB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	return db.newIterator(start, end, true)
+}
+
+// ReverseIterator implements DB.
+func (db *DB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	return db.newIterator(start, end, false)
+}
+
+// Close flushes the sealed and open layers, stops the background writer and
+// closes the parent.
+func (db *DB) Close() error {
+	err := db.Flush()
+	db.sealMtx.Lock()
+	db.mtx.Lock()
+	if !db.closed {
+		db.closed = true
+		close(db.queue)
+	}
+	db.mtx.Unlock()
+	db.sealMtx.Unlock()
+	<-db.done
+	if closeErr := db.parent.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// NewBatch implements DB.
+func (db *DB) NewBatch() dbm.Batch {
+	return &batch{db: db, ops: layer{}}
+}
+
+// Print implements DB.
+func (db *DB) Print() error {
+	return db.parent.Print()
+}
+
+// Stats implements DB.
+func (db *DB) Stats() map[string]string {
+	stats := db.parent.Stats()
+	if stats == nil {
+		stats = map[string]string{}
+	}
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	stats["asyncdb.pending"] = strconv.Itoa(len(db.pending))
+	return stats
+}
+
+func validateSet(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return nil
+}
+
+// cp copies bz, returning a non-nil slice for empty values since nil marks a
+// deletion in a layer.
+func cp(bz []byte) []byte {
+	return append([]byte{}, bz...)
+}
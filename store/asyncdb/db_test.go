@@ -0,0 +1,253 @@
+package asyncdb
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// blockingDB is a MemDB whose batch writes wait on unblock, and fail with
+// fail if it is set.
+type blockingDB struct {
+	*dbm.MemDB
+	unblock chan struct{}
+	fail    error
+}
+
+type blockingBatch struct {
+	dbm.Batch
+	db *blockingDB
+}
+
+func (db *blockingDB) NewBatch() dbm.Batch {
+	return blockingBatch{Batch: db.MemDB.NewBatch(), db: db}
+}
+
+func (b blockingBatch) WriteSync() error {
+	<-b.db.unblock
+	if b.db.fail != nil {
+		return b.db.fail
+	}
+	return b.Batch.WriteSync()
+}
+
+func TestDB(t *testing.T) {
+	parent := dbm.NewMemDB()
+	db := New(parent, 2)
+
+	require.NoError(t, parent.Set([]byte("a"), []byte("parent")))
+	require.NoError(t, parent.Set([]byte("b"), []byte("parent")))
+	require.NoError(t, db.Set([]byte("a"), []byte("1")))
+	require.NoError(t, db.Delete([]byte("b")))
+	require.NoError(t, db.Set([]byte("c"), []byte{}))
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	has, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, has)
+	value, err = db.Get([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, value)
+
+	// nothing reaches the parent until the layer is flushed
+	value, err = parent.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("parent"), value)
+
+	require.NoError(t, db.Flush())
+	value, err = parent.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	has, err = parent.Has([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, has)
+	require.Equal(t, "0", db.Stats()["asyncdb.pending"])
+
+	require.Error(t, db.Set(nil, []byte("value")))
+	require.Error(t, db.Set([]byte("key"), nil))
+	require.Error(t, db.Delete([]byte{}))
+	_, err = db.Get(nil)
+	require.Error(t, err)
+}
+
+func TestDBBatch(t *testing.T) {
+	db := New(dbm.NewMemDB(), 1)
+	require.NoError(t, db.Set([]byte("b"), []byte("1")))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("a"), []byte("2")))
+	require.NoError(t, batch.Delete([]byte("b")))
+	// the batch is not visible until it is written
+	has, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, batch.WriteSync())
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("2"), value)
+	has, err = db.Has([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.ErrorIs(t, batch.Set([]byte("c"), []byte("3")), errBatchClosed)
+	require.ErrorIs(t, batch.Write(), errBatchClosed)
+	require.NoError(t, batch.Close())
+}
+
+func TestDBIterator(t *testing.T) {
+	parent := &blockingDB{MemDB: dbm.NewMemDB(), unblock: make(chan struct{})}
+	db := New(parent, 1000)
+	truth := dbm.NewMemDB()
+	r := rand.New(rand.NewSource(1))
+
+	key := func() []byte { return []byte(fmt.Sprintf("key%02d", r.Intn(50))) }
+	for i := 0; i < 500; i++ {
+		if r.Intn(3) == 0 {
+			k := key()
+			require.NoError(t, db.Delete(k))
+			require.NoError(t, truth.Delete(k))
+		} else {
+			k, v := key(), []byte(fmt.Sprint(i))
+			require.NoError(t, db.Set(k, v))
+			require.NoError(t, truth.Set(k, v))
+		}
+		switch r.Intn(20) {
+		case 0:
+			require.NoError(t, db.Seal())
+		case 1:
+			// let the writer catch up with some of the sealed layers
+			for j := r.Intn(3); j > 0; j-- {
+				select {
+				case parent.unblock <- struct{}{}:
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		}
+
+		domains := [][2][]byte{
+			{nil, nil},
+			{[]byte("key10"), nil},
+			{nil, []byte("key30")},
+			{[]byte("key10"), []byte("key30")},
+			{[]byte("key10"), []byte("key10")},
+		}
+		for _, domain := range domains {
+			for _, ascending := range []bool{true, false} {
+				var itr, expected dbm.Iterator
+				var err error
+				if ascending {
+					itr, err = db.Iterator(domain[0], domain[1])
+					require.NoError(t, err)
+					expected, err = truth.Iterator(domain[0], domain[1])
+				} else {
+					itr, err = db.ReverseIterator(domain[0], domain[1])
+					require.NoError(t, err)
+					expected, err = truth.ReverseIterator(domain[0], domain[1])
+				}
+				require.NoError(t, err)
+				for ; expected.Valid(); expected.Next() {
+					require.True(t, itr.Valid())
+					require.Equal(t, expected.Key(), itr.Key())
+					require.Equal(t, expected.Value(), itr.Value())
+					itr.Next()
+				}
+				require.False(t, itr.Valid())
+				require.Panics(t, func() { itr.Next() })
+				require.NoError(t, itr.Close())
+				require.NoError(t, expected.Close())
+			}
+		}
+	}
+
+	close(parent.unblock)
+	require.NoError(t, db.Flush())
+	itr, err := parent.Iterator(nil, nil)
+	require.NoError(t, err)
+	expected, err := truth.Iterator(nil, nil)
+	require.NoError(t, err)
+	for ; expected.Valid(); expected.Next() {
+		require.True(t, itr.Valid())
+		require.Equal(t, expected.Key(), itr.Key())
+		require.Equal(t, expected.Value(), itr.Value())
+		itr.Next()
+	}
+	require.False(t, itr.Valid())
+}
+
+func TestDBWritesWholeLayers(t *testing.T) {
+	parent := &blockingDB{MemDB: dbm.NewMemDB(), unblock: make(chan struct{})}
+	db := New(parent, 3)
+
+	for version := 1; version <= 3; version++ {
+		require.NoError(t, db.Set([]byte("version"), []byte(fmt.Sprint(version))))
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%d", version)), []byte("value")))
+		require.NoError(t, db.Seal())
+	}
+	parent.unblock <- struct{}{}
+
+	// the parent only moves from one layer to the next, the later layers are
+	// still readable through db
+	require.Eventually(t, func() bool {
+		return db.Stats()["asyncdb.pending"] == "2"
+	}, time.Second, time.Millisecond)
+	value, err := parent.Get([]byte("version"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	has, err := parent.Has([]byte("key2"))
+	require.NoError(t, err)
+	require.False(t, has)
+	value, err = db.Get([]byte("version"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), value)
+}
+
+func TestDBBackpressure(t *testing.T) {
+	parent := &blockingDB{MemDB: dbm.NewMemDB(), unblock: make(chan struct{})}
+	db := New(parent, 1)
+
+	// the first layer is taken by the writer, the second one fills the queue
+	require.NoError(t, db.Set([]byte("key"), []byte("0")))
+	require.NoError(t, db.Seal())
+	require.Eventually(t, func() bool { return len(db.queue) == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, db.Set([]byte("key"), []byte("1")))
+	require.NoError(t, db.Seal())
+
+	sealed := make(chan error)
+	go func() {
+		require.NoError(t, db.Set([]byte("key"), []byte("2")))
+		sealed <- db.Seal()
+	}()
+	select {
+	case <-sealed:
+		t.Fatal("seal did not wait for the writer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	parent.unblock <- struct{}{}
+	require.NoError(t, <-sealed)
+	close(parent.unblock)
+	require.NoError(t, db.Close())
+}
+
+func TestDBWriteFailure(t *testing.T) {
+	parent := &blockingDB{MemDB: dbm.NewMemDB(), unblock: make(chan struct{}), fail: errors.New("disk full")}
+	close(parent.unblock)
+	db := New(parent, 2)
+
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.ErrorContains(t, db.Flush(), "disk full")
+	require.ErrorContains(t, db.Seal(), "disk full")
+
+	// the unwritten data is still readable
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
@@ -0,0 +1,158 @@
+package asyncdb
+
+import (
+	"bytes"
+	"sort"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// iterator merges a snapshot of the unwritten keys in its domain with an
+// iterator of the parent, the unwritten values taking precedence.
+type iterator struct {
+	parent    dbm.Iterator
+	keys      []string
+	values    [][]byte
+	ascending bool
+
+	key   []byte
+	value []byte
+	valid bool
+}
+
+var _ dbm.Iterator = (*iterator)(nil)
+
+func (db *DB) newIterator(start, end []byte, ascending bool) (*iterator, error) {
+	db.mtx.RLock()
+	buffered := layer{}
+	for i := range db.pending {
+		for key, value := range db.pending[i] {
+			if dbm.IsKeyInDomain([]byte(key), start, end) {
+				buffered[key] = value
+			}
+		}
+	}
+	for key, value := range db.current {
+		if dbm.IsKeyInDomain([]byte(key), start, end) {
+			buffered[key] = value
+		}
+	}
+	// the parent is opened under the lock, so a layer is either in the
+	// snapshot or already written when the parent iterator is created
+	var parent dbm.Iterator
+	var err error
+	if ascending {
+		parent, err = db.parent.Iterator(start, end)
+	} else {
+		parent, err = db.parent.ReverseIterator(start, end)
+	}
+	db.mtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(buffered))
+	for key := range buffered {
+		keys = append(keys, key)
+	}
+	if ascending {
+		sort.Strings(keys)
+	} else {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	}
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = buffered[key]
+	}
+
+	itr := &iterator{
+		parent:    parent,
+		keys:      keys,
+		values:    values,
+		ascending: ascending,
+	}
+	itr.advance()
+	return itr, nil
+}
+
+// advance moves to the next key that is not deleted, skipping the parent's
+// entry of a key that has an unwritten value.
+func (itr *iterator) advance() {
+	for {
+		hasBuffered := len(itr.keys) > 0
+		hasParent := itr.parent.Valid()
+		if !hasBuffered && !hasParent {
+			itr.valid = false
+			return
+		}
+
+		if hasParent {
+			cmp := -1
+			if hasBuffered {
+				cmp = bytes.Compare(itr.parent.Key(), []byte(itr.keys[0]))
+				if !itr.ascending {
+					cmp = -cmp
+				}
+			}
+			if cmp < 0 {
+				itr.key, itr.value, itr.valid = itr.parent.Key(), itr.parent.Value(), true
+				itr.parent.Next()
+				return
+			}
+			if cmp == 0 {
+				itr.parent.Next()
+			}
+		}
+
+		key, value := itr.keys[0], itr.values[0]
+		itr.keys, itr.values = itr.keys[1:], itr.values[1:]
+		if value != nil {
+			itr.key, itr.value, itr.valid = []byte(key), value, true
+			return
+		}
+	}
+}
+
+// Domain implements Iterator.
+func (itr *iterator) Domain() ([]byte, []byte) {
+	return itr.parent.Domain()
+}
+
+// Valid implements Iterator.
+func (itr *iterator) Valid() bool {
+	return itr.valid
+}
+
+// Next implements Iterator.
+func (itr *iterator) Next() {
+	itr.assertIsValid()
+	itr.advance()
+}
+
+// Key implements Iterator.
+func (itr *iterator) Key() []byte {
+	itr.assertIsValid()
+	return itr.key
+}
+
+// Value implements Iterator.
+func (itr *iterator) Value() []byte {
+	itr.assertIsValid()
+	return itr.value
+}
+
+// Error implements Iterator.
+func (itr *iterator) Error() error {
+	return itr.parent.Error()
+}
+
+// Close implements Iterator.
+func (itr *iterator) Close() error {
+	return itr.parent.Close()
+}
+
+func (itr *iterator) assertIsValid() {
+	if !itr.valid {
+		panic("iterator is invalid")
+	}
+}
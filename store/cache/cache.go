@@ -1,36 +1,43 @@
 package cache
 
 import (
-	"fmt"
 	"sync"
 
+	metrics "github.com/armon/go-metrics"
+
 	"github.com/cosmos/cosmos-sdk/store/cachekv"
 	"github.com/cosmos/cosmos-sdk/store/types"
-
-	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 )
 
 var (
 	_ types.CommitKVStore             = (*CommitKVStoreCache)(nil)
 	_ types.MultiStorePersistentCache = (*CommitKVStoreCacheManager)(nil)
 
-	// DefaultCommitKVStoreCacheSize defines the persistent ARC cache size for a
-	// CommitKVStoreCache.
-	DefaultCommitKVStoreCacheSize uint = 100000
+	// DefaultCommitKVStoreCacheSize defines the size in bytes of the persistent
+	// LRU cache of a CommitKVStoreCache.
+	DefaultCommitKVStoreCacheSize uint = 32 << 20
 )
 
 type (
 	// CommitKVStoreCache implements an inter-block (persistent) cache that wraps a
-	// CommitKVStore. Reads first hit the internal ARC (Adaptive Replacement Cache).
-	// During a cache miss, the read is delegated to the underlying CommitKVStore
-	// and cached. Deletes and writes always happen to both the cache and the
-	// CommitKVStore in a write-through manner. Caching performed in the
-	// CommitKVStore and below is completely irrelevant to this layer.
+	// CommitKVStore. Reads first hit the internal LRU cache, bounded by the size
+	// in bytes of its keys and values. During a cache miss, the read is delegated
+	// to the underlying CommitKVStore and cached. Deletes and writes always happen
+	// to both the cache and the CommitKVStore in a write-through manner. Caching
+	// performed in the CommitKVStore and below is completely irrelevant to this
+	// layer.
+	//
+	// The hits and misses of the reads are emitted on every commit, labelled with
+	// the store name.
 	CommitKVStoreCache struct {
 		types.CommitKVStore
-		cache       *lru.TwoQueueCache[string, []byte]
+		cache       *byteLRU
 		cacheKVSize int
 
+		labels       []metrics.Label
+		hits, misses int64
+
 		// the same CommitKVStoreCache may be accessed concurrently by multiple
 		// goroutines due to transaction parallelization
 		mtx sync.Mutex
@@ -47,15 +54,12 @@ type (
 	}
 )
 
+// NewCommitKVStoreCache returns a CommitKVStoreCache of size bytes wrapping
+// store.
 func NewCommitKVStoreCache(store types.CommitKVStore, size uint, cacheKVSize int) *CommitKVStoreCache {
-	cache, err := lru.New2Q[string, []byte](int(size))
-	if err != nil {
-		panic(fmt.Errorf("failed to create KVStore cache: %s", err))
-	}
-
 	return &CommitKVStoreCache{
 		CommitKVStore: store,
-		cache:         cache,
+		cache:         newByteLRU(int(size)),
 		cacheKVSize:   cacheKVSize,
 	}
 }
@@ -73,7 +77,9 @@ func NewCommitKVStoreCacheManager(size uint, cacheKVSize int) *CommitKVStoreCach
 // The returned Cache is meant to be used in a persistent manner.
 func (cmgr *CommitKVStoreCacheManager) GetStoreCache(key types.StoreKey, store types.CommitKVStore) types.CommitKVStore {
 	if cmgr.caches[key.Name()] == nil {
-		cmgr.caches[key.Name()] = NewCommitKVStoreCache(store, cmgr.cacheSize, cmgr.cacheKVSize)
+		ckv := NewCommitKVStoreCache(store, cmgr.cacheSize, cmgr.cacheKVSize)
+		ckv.labels = []metrics.Label{telemetry.NewLabel("store_name", key.Name())}
+		cmgr.caches[key.Name()] = ckv
 	}
 
 	return cmgr.caches[key.Name()]
@@ -115,10 +121,12 @@ func (ckv *CommitKVStoreCache) Get(key []byte) []byte {
 	value, ok := ckv.cache.Get(keyStr)
 	if ok {
 		// cache hit
+		ckv.hits++
 		return value
 	}
 
 	// cache miss; write to cache
+	ckv.misses++
 	value = ckv.CommitKVStore.Get(key)
 	ckv.cache.Add(keyStr, value)
 
@@ -154,3 +162,33 @@ func (ckv *CommitKVStoreCache) Reset() {
 
 	ckv.cache.Purge()
 }
+
+// Commit emits the cache metrics of the block before committing the underlying
+// CommitKVStore.
+func (ckv *CommitKVStoreCache) Commit(bumpVersion bool) types.CommitID {
+	ckv.emitMetrics()
+	return ckv.CommitKVStore.Commit(bumpVersion)
+}
+
+// emitMetrics reports the reads since the previous call and resets them:
+//   - store_inter_block_cache_hits and store_inter_block_cache_misses: counters
+//     of the reads served by the cache and by the underlying store
+//   - store_inter_block_cache_hit_rate: the ratio of the reads of the block
+//     served by the cache
+//   - store_inter_block_cache_bytes and store_inter_block_cache_entries: the
+//     size of the cache
+func (ckv *CommitKVStoreCache) emitMetrics() {
+	ckv.mtx.Lock()
+	hits, misses := ckv.hits, ckv.misses
+	ckv.hits, ckv.misses = 0, 0
+	size, entries := ckv.cache.Bytes(), ckv.cache.Len()
+	ckv.mtx.Unlock()
+
+	telemetry.IncrCounterWithLabels([]string{"store", "inter_block_cache", "hits"}, float32(hits), ckv.labels)
+	telemetry.IncrCounterWithLabels([]string{"store", "inter_block_cache", "misses"}, float32(misses), ckv.labels)
+	if hits+misses > 0 {
+		telemetry.SetGaugeWithLabels([]string{"store", "inter_block_cache", "hit_rate"}, float32(hits)/float32(hits+misses), ckv.labels)
+	}
+	telemetry.SetGaugeWithLabels([]string{"store", "inter_block_cache", "bytes"}, float32(size), ckv.labels)
+	telemetry.SetGaugeWithLabels([]string{"store", "inter_block_cache", "entries"}, float32(entries), ckv.labels)
+}
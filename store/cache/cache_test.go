@@ -3,7 +3,9 @@ package cache_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	metrics "github.com/armon/go-metrics"
 	"github.com/cosmos/iavl"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
@@ -43,7 +45,7 @@ func TestUnwrap(t *testing.T) {
 
 func TestStoreCache(t *testing.T) {
 	db := dbm.NewMemDB()
-	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize, types.DefaultCacheSizeLimit)
+	mngr := cache.NewCommitKVStoreCacheManager(4096, types.DefaultCacheSizeLimit)
 
 	sKey := types.NewKVStoreKey("test")
 	tree, err := iavl.NewMutableTree(db, 100, false)
@@ -51,7 +53,8 @@ func TestStoreCache(t *testing.T) {
 	store := iavlstore.UnsafeNewStore(tree)
 	kvStore := mngr.GetStoreCache(sKey, store)
 
-	for i := uint(0); i < cache.DefaultCommitKVStoreCacheSize*2; i++ {
+	// write more than the cache holds so that entries are evicted
+	for i := 0; i < 1000; i++ {
 		key := []byte(fmt.Sprintf("key_%d", i))
 		value := []byte(fmt.Sprintf("value_%d", i))
 
@@ -67,3 +70,42 @@ func TestStoreCache(t *testing.T) {
 		require.Nil(t, store.Get(key))
 	}
 }
+
+func TestStoreCacheMetrics(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, sink)
+	require.NoError(t, err)
+	defer metrics.NewGlobal(cfg, &metrics.BlackholeSink{}) //nolint:errcheck
+
+	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize, types.DefaultCacheSizeLimit)
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100, false)
+	require.NoError(t, err)
+	store := iavlstore.UnsafeNewStore(tree)
+	store.Set([]byte("a"), []byte("1"))
+	kvStore := mngr.GetStoreCache(types.NewKVStoreKey("bank"), store)
+
+	// one miss loading each key, then hits
+	require.Equal(t, []byte("1"), kvStore.Get([]byte("a")))
+	require.Equal(t, []byte("1"), kvStore.Get([]byte("a")))
+	require.Equal(t, []byte("1"), kvStore.Get([]byte("a")))
+	require.Nil(t, kvStore.Get([]byte("b")))
+	kvStore.(types.CommitStore).Commit(true)
+
+	data := sink.Data()
+	require.NotEmpty(t, data)
+	counters, gauges := data[0].Counters, data[0].Gauges
+	require.Equal(t, float64(2), counters["store.inter_block_cache.hits;store_name=bank"].Sum)
+	require.Equal(t, float64(2), counters["store.inter_block_cache.misses;store_name=bank"].Sum)
+	require.Equal(t, float32(0.5), gauges["store.inter_block_cache.hit_rate;store_name=bank"].Value)
+	require.Equal(t, float32(2), gauges["store.inter_block_cache.entries;store_name=bank"].Value)
+	require.Positive(t, gauges["store.inter_block_cache.bytes;store_name=bank"].Value)
+
+	// the reads are counted per block
+	require.Equal(t, []byte("1"), kvStore.Get([]byte("a")))
+	kvStore.(types.CommitStore).Commit(true)
+	require.Equal(t, float64(3), sink.Data()[0].Counters["store.inter_block_cache.hits;store_name=bank"].Sum)
+	require.Equal(t, float32(1), sink.Data()[0].Gauges["store.inter_block_cache.hit_rate;store_name=bank"].Value)
+}
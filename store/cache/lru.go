@@ -0,0 +1,89 @@
+package cache
+
+import "container/list"
+
+// entryOverhead approximates the memory an entry takes besides its key and
+// value: the list element, the map entry and the value slice header.
+const entryOverhead = 96
+
+// byteLRU is a least recently used cache bounded by the size in bytes of its
+// entries rather than their number, as the values of a store range from a few
+// bytes to contract code. It is not safe for concurrent use.
+type byteLRU struct {
+	maxBytes int
+	bytes    int
+	order    *list.List // front is the most recently used
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+func newByteLRU(maxBytes int) *byteLRU {
+	return &byteLRU{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func entrySize(key string, value []byte) int {
+	return len(key) + len(value) + entryOverhead
+}
+
+// Get returns the value of key, marking it as the most recently used.
+func (c *byteLRU) Get(key string) ([]byte, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+// Add sets the value of key and evicts the least recently used entries until
+// the cache fits in its size. A value larger than the whole cache is not kept.
+func (c *byteLRU) Add(key string, value []byte) {
+	c.Remove(key)
+	size := entrySize(key, value)
+	if size > c.maxBytes {
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	c.bytes += size
+	for c.bytes > c.maxBytes {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Remove deletes key from the cache.
+func (c *byteLRU) Remove(key string) {
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *byteLRU) removeElement(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entrySize(entry.key, entry.value)
+}
+
+// Purge deletes all the entries.
+func (c *byteLRU) Purge() {
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+// Len returns the number of entries.
+func (c *byteLRU) Len() int {
+	return len(c.entries)
+}
+
+// Bytes returns the size of the entries.
+func (c *byteLRU) Bytes() int {
+	return c.bytes
+}
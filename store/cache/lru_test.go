@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteLRUEvictsBySize(t *testing.T) {
+	c := newByteLRU(3 * entrySize("k0", make([]byte, 10)))
+
+	for _, key := range []string{"k0", "k1", "k2"} {
+		c.Add(key, make([]byte, 10))
+	}
+	require.Equal(t, 3, c.Len())
+	require.Equal(t, c.maxBytes, c.Bytes())
+
+	// reading k0 makes k1 the least recently used
+	_, ok := c.Get("k0")
+	require.True(t, ok)
+	c.Add("k3", make([]byte, 10))
+	_, ok = c.Get("k1")
+	require.False(t, ok)
+
+	// a large value evicts as many entries as needed
+	c.Add("k4", make([]byte, 10+entrySize("k0", make([]byte, 10))))
+	require.Equal(t, 2, c.Len())
+	_, ok = c.Get("k4")
+	require.True(t, ok)
+	_, ok = c.Get("k3")
+	require.True(t, ok)
+	require.LessOrEqual(t, c.Bytes(), c.maxBytes)
+}
+
+func TestByteLRUOversizedValue(t *testing.T) {
+	c := newByteLRU(1024)
+	c.Add("small", []byte("v"))
+
+	// a value larger than the cache is not kept and evicts nothing
+	c.Add("large", make([]byte, 2048))
+	_, ok := c.Get("large")
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+
+	// nor does it keep the previous value of the key
+	c.Add("small", make([]byte, 2048))
+	require.Zero(t, c.Len())
+	require.Zero(t, c.Bytes())
+}
+
+func TestByteLRUAccounting(t *testing.T) {
+	c := newByteLRU(1024)
+	c.Add("a", []byte("12345"))
+	c.Add("b", nil)
+	require.Equal(t, entrySize("a", []byte("12345"))+entrySize("b", nil), c.Bytes())
+
+	// replacing a value accounts for its new size
+	c.Add("a", []byte("1"))
+	require.Equal(t, entrySize("a", []byte("1"))+entrySize("b", nil), c.Bytes())
+
+	c.Remove("a")
+	c.Remove("missing")
+	require.Equal(t, entrySize("b", nil), c.Bytes())
+	require.Equal(t, 1, c.Len())
+
+	c.Purge()
+	require.Zero(t, c.Len())
+	require.Zero(t, c.Bytes())
+	_, ok := c.Get("b")
+	require.False(t, ok)
+}
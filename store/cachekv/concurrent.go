@@ -0,0 +1,311 @@
+package cachekv
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/internal/conv"
+	"github.com/cosmos/cosmos-sdk/store/listenkv"
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// concurrentShards is the number of shards of a ConcurrentStore, a power of two.
+const concurrentShards = 64
+
+// concurrentShard holds the cached values of the keys hashed to it. A nil
+// value in a dirty entry is a deletion, since Set does not accept nil values.
+type concurrentShard struct {
+	mtx     sync.RWMutex
+	entries map[string]*types.CValue
+	dirty   map[string]struct{}
+}
+
+// ConcurrentStore is a CacheKVStore that is safe for concurrent use. Store
+// serializes every access behind a single mutex, including cache hits, and
+// prepares iterators by mutating shared state, so concurrent txs accessing the
+// same branch contend on it. ConcurrentStore spreads keys over shards guarded
+// by read-write locks, so that reads only contend with writes to keys of the
+// same shard, and iterators work on a snapshot of the dirty keys in their
+// range.
+//
+// The parent must be safe for concurrent reads. Iterators see the writes made
+// before they were created, and deletions made while they are open.
+type ConcurrentStore struct {
+	shards    [concurrentShards]concurrentShard
+	deleted   *sync.Map
+	parent    types.KVStore
+	storeKey  types.StoreKey
+	cacheSize int
+
+	emMtx        sync.RWMutex
+	eventManager *sdktypes.EventManager
+}
+
+var _ types.CacheKVStore = (*ConcurrentStore)(nil)
+
+// NewConcurrentStore creates a new ConcurrentStore. Like Store, it keeps at
+// most cacheSize values read from the parent, but writes are always kept.
+func NewConcurrentStore(parent types.KVStore, storeKey types.StoreKey, cacheSize int) *ConcurrentStore {
+	if cacheSize == 0 {
+		panic("cache limit must be at least 1")
+	}
+	store := &ConcurrentStore{
+		deleted:      &sync.Map{},
+		parent:       parent,
+		storeKey:     storeKey,
+		cacheSize:    cacheSize,
+		eventManager: sdktypes.NewEventManager(),
+	}
+	for i := range store.shards {
+		store.shards[i].entries = make(map[string]*types.CValue)
+		store.shards[i].dirty = make(map[string]struct{})
+	}
+	return store
+}
+
+// shard returns the shard of key, picked by its FNV-1a hash.
+func (store *ConcurrentStore) shard(key []byte) *concurrentShard {
+	hash := uint32(2166136261)
+	for _, b := range key {
+		hash ^= uint32(b)
+		hash *= 16777619
+	}
+	return &store.shards[hash&(concurrentShards-1)]
+}
+
+func (store *ConcurrentStore) events() *sdktypes.EventManager {
+	store.emMtx.RLock()
+	defer store.emMtx.RUnlock()
+	return store.eventManager
+}
+
+func (store *ConcurrentStore) GetWorkingHash() ([]byte, error) {
+	panic("should never attempt to get working hash from cache kv store")
+}
+
+// GetEvents implements CacheKVStore.
+func (store *ConcurrentStore) GetEvents() []abci.Event {
+	return store.events().ABCIEvents()
+}
+
+// ResetEvents implements CacheKVStore.
+func (store *ConcurrentStore) ResetEvents() {
+	store.emMtx.Lock()
+	defer store.emMtx.Unlock()
+	store.eventManager = sdktypes.NewEventManager()
+}
+
+// GetStoreType implements Store.
+func (store *ConcurrentStore) GetStoreType() types.StoreType {
+	return store.parent.GetStoreType()
+}
+
+// Get implements types.KVStore.
+func (store *ConcurrentStore) Get(key []byte) []byte {
+	types.AssertValidKey(key)
+	value := store.get(key)
+	store.events().EmitResourceAccessReadEvent("get", store.storeKey, key, value)
+	return value
+}
+
+func (store *ConcurrentStore) get(key []byte) []byte {
+	shard := store.shard(key)
+	shard.mtx.RLock()
+	cacheValue, ok := shard.entries[conv.UnsafeBytesToStr(key)]
+	shard.mtx.RUnlock()
+	if ok {
+		return cacheValue.Value()
+	}
+
+	// the parent is read without holding the lock, so a write may have raced it
+	value := store.parent.Get(key)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	if cacheValue, ok := shard.entries[string(key)]; ok {
+		return cacheValue.Value()
+	}
+	if len(shard.entries) < store.cacheSize/concurrentShards+1 {
+		shard.entries[string(key)] = types.NewCValue(value, false)
+	}
+	return value
+}
+
+// Set implements types.KVStore.
+func (store *ConcurrentStore) Set(key []byte, value []byte) {
+	types.AssertValidKey(key)
+	types.AssertValidValue(value)
+
+	store.setCacheValue(key, value)
+	store.events().EmitResourceAccessWriteEvent("set", store.storeKey, key, value)
+}
+
+// Has implements types.KVStore.
+func (store *ConcurrentStore) Has(key []byte) bool {
+	types.AssertValidKey(key)
+	value := store.get(key)
+	store.events().EmitResourceAccessReadEvent("has", store.storeKey, key, value)
+	return value != nil
+}
+
+// Delete implements types.KVStore.
+func (store *ConcurrentStore) Delete(key []byte) {
+	defer telemetry.MeasureSince(time.Now(), "store", "cachekv", "delete")
+
+	types.AssertValidKey(key)
+	store.setCacheValue(key, nil)
+	store.events().EmitResourceAccessWriteEvent("delete", store.storeKey, key, []byte{})
+}
+
+// setCacheValue writes value to the cache, a nil value deleting the key.
+func (store *ConcurrentStore) setCacheValue(key, value []byte) {
+	keyStr := string(key)
+	shard := store.shard(key)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	shard.entries[keyStr] = types.NewCValue(value, true)
+	shard.dirty[keyStr] = struct{}{}
+	if value == nil {
+		store.deleted.Store(keyStr, struct{}{})
+	} else {
+		store.deleted.Delete(keyStr)
+	}
+}
+
+// Write implements CacheKVStore. It must not be called concurrently with other
+// accesses to the store.
+func (store *ConcurrentStore) Write() {
+	defer telemetry.MeasureSince(time.Now(), "store", "cachekv", "write")
+	for i := range store.shards {
+		store.shards[i].mtx.Lock()
+		defer store.shards[i].mtx.Unlock()
+	}
+
+	store.writeTo(store.parent)
+
+	for i := range store.shards {
+		shard := &store.shards[i]
+		for key := range shard.entries {
+			delete(shard.entries, key)
+		}
+		for key := range shard.dirty {
+			delete(shard.dirty, key)
+		}
+	}
+	store.deleted.Range(func(key, _ any) bool {
+		store.deleted.Delete(key)
+		return true
+	})
+}
+
+// WriteTo writes the dirty entries of the store to dst. Unlike Write, it
+// keeps the entries, which can still be written to the parent afterwards.
+func (store *ConcurrentStore) WriteTo(dst types.KVStore) {
+	for i := range store.shards {
+		store.shards[i].mtx.RLock()
+		defer store.shards[i].mtx.RUnlock()
+	}
+	store.writeTo(dst)
+}
+
+// writeTo writes the dirty entries of the store to dst in key order. The
+// caller must hold the locks of every shard.
+func (store *ConcurrentStore) writeTo(dst types.KVStore) {
+	keys := []string{}
+	for i := range store.shards {
+		for key := range store.shards[i].dirty {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := store.shard([]byte(key)).entries[key].Value()
+		if value == nil {
+			dst.Delete([]byte(key))
+			continue
+		}
+		dst.Set([]byte(key), value)
+	}
+}
+
+// CacheWrap implements CacheWrapper.
+func (store *ConcurrentStore) CacheWrap(storeKey types.StoreKey) types.CacheWrap {
+	return NewStore(store, storeKey, store.cacheSize)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (store *ConcurrentStore) CacheWrapWithTrace(storeKey types.StoreKey, w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return NewStore(tracekv.NewStore(store, w, tc), storeKey, store.cacheSize)
+}
+
+// CacheWrapWithListeners implements the CacheWrapper interface.
+func (store *ConcurrentStore) CacheWrapWithListeners(storeKey types.StoreKey, listeners []types.WriteListener) types.CacheWrap {
+	return NewStore(listenkv.NewStore(store, storeKey, listeners), storeKey, store.cacheSize)
+}
+
+//----------------------------------------
+// Iteration
+
+// Iterator implements types.KVStore.
+func (store *ConcurrentStore) Iterator(start, end []byte) types.Iterator {
+	return store.iterator(start, end, true)
+}
+
+// ReverseIterator implements types.KVStore.
+func (store *ConcurrentStore) ReverseIterator(start, end []byte) types.Iterator {
+	return store.iterator(start, end, false)
+}
+
+func (store *ConcurrentStore) iterator(start, end []byte, ascending bool) types.Iterator {
+	var parent types.Iterator
+	if ascending {
+		parent = store.parent.Iterator(start, end)
+	} else {
+		parent = store.parent.ReverseIterator(start, end)
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			// close out parent iterator, then reraise panic
+			parent.Close()
+			panic(err)
+		}
+	}()
+
+	eventManager := store.events()
+	cache := newMemIterator(start, end, store.dirtyItems(start, end), store.deleted, ascending, eventManager, store.storeKey)
+	return NewCacheMergeIterator(parent, cache, ascending, eventManager, store.storeKey)
+}
+
+// dirtyItems returns a snapshot of the keys written in [start, end). Deleted
+// keys are given an empty value and are filtered out through store.deleted.
+func (store *ConcurrentStore) dirtyItems(start, end []byte) *dbm.MemDB {
+	items := dbm.NewMemDB()
+	for i := range store.shards {
+		shard := &store.shards[i]
+		shard.mtx.RLock()
+		for key := range shard.dirty {
+			if !dbm.IsKeyInDomain(conv.UnsafeStrToBytes(key), start, end) {
+				continue
+			}
+			value := shard.entries[key].Value()
+			if value == nil {
+				value = []byte{}
+			}
+			if err := items.Set([]byte(key), value); err != nil {
+				shard.mtx.RUnlock()
+				panic(err)
+			}
+		}
+		shard.mtx.RUnlock()
+	}
+	return items
+}
@@ -0,0 +1,212 @@
+package cachekv_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func newConcurrentStore() (*cachekv.ConcurrentStore, dbadapter.Store) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	return cachekv.NewConcurrentStore(mem, types.NewKVStoreKey("CacheKvTest"), types.DefaultCacheSizeLimit), mem
+}
+
+func TestConcurrentStore(t *testing.T) {
+	st, mem := newConcurrentStore()
+
+	require.Empty(t, st.Get(keyFmt(1)))
+	// a read is cached, it does not see later writes to the parent
+	mem.Set(keyFmt(1), valFmt(1))
+	require.Empty(t, st.Get(keyFmt(1)))
+
+	st.Set(keyFmt(1), valFmt(2))
+	st.Set(keyFmt(2), valFmt(2))
+	require.Equal(t, valFmt(2), st.Get(keyFmt(1)))
+	require.True(t, st.Has(keyFmt(2)))
+	require.Equal(t, valFmt(1), mem.Get(keyFmt(1)))
+
+	st.Delete(keyFmt(1))
+	require.Nil(t, st.Get(keyFmt(1)))
+	require.False(t, st.Has(keyFmt(1)))
+
+	st.Write()
+	require.Nil(t, mem.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), mem.Get(keyFmt(2)))
+	require.Equal(t, valFmt(2), st.Get(keyFmt(2)))
+
+	require.Panics(t, func() { st.Set([]byte("key"), nil) })
+	require.Panics(t, func() { st.Set(nil, []byte("value")) })
+}
+
+func TestConcurrentStoreNested(t *testing.T) {
+	st, mem := newConcurrentStore()
+
+	st.Set(keyFmt(1), valFmt(1))
+	st2 := st.CacheWrap(types.NewKVStoreKey("CacheKvTest")).(types.CacheKVStore)
+	require.Equal(t, valFmt(1), st2.Get(keyFmt(1)))
+
+	st2.Set(keyFmt(1), valFmt(3))
+	require.Equal(t, valFmt(1), st.Get(keyFmt(1)))
+
+	st2.Write()
+	require.Equal(t, valFmt(3), st.Get(keyFmt(1)))
+	require.Nil(t, mem.Get(keyFmt(1)))
+}
+
+func TestConcurrentStoreIterator(t *testing.T) {
+	st, mem := newConcurrentStore()
+
+	for i := 0; i < 10; i++ {
+		mem.Set(keyFmt(i), valFmt(i))
+	}
+	st.Set(keyFmt(10), valFmt(10))
+	st.Set(keyFmt(3), valFmt(30))
+	st.Delete(keyFmt(5))
+
+	var keys, values [][]byte
+	itr := st.Iterator(keyFmt(2), keyFmt(11))
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, itr.Key())
+		values = append(values, itr.Value())
+		// deleting the current key does not affect the iteration
+		st.Delete(itr.Key())
+	}
+	require.NoError(t, itr.Close())
+	require.Equal(t, [][]byte{
+		keyFmt(2), keyFmt(3), keyFmt(4), keyFmt(6), keyFmt(7), keyFmt(8), keyFmt(9), keyFmt(10),
+	}, keys)
+	require.Equal(t, valFmt(30), values[1])
+
+	itr = st.ReverseIterator(nil, nil)
+	keys = nil
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, itr.Key())
+	}
+	require.NoError(t, itr.Close())
+	require.Equal(t, [][]byte{keyFmt(1), keyFmt(0)}, keys)
+}
+
+func TestConcurrentStoreRandom(t *testing.T) {
+	st, _ := newConcurrentStore()
+	truth := dbm.NewMemDB()
+
+	setRange(t, st, truth, 25, 975)
+	for i := 0; i < 2000; i++ {
+		doRandomOp(t, st, truth, 1000)
+		assertIterateDomainCompare(t, st, truth)
+	}
+}
+
+func TestConcurrentStoreConcurrentAccess(t *testing.T) {
+	st, mem := newConcurrentStore()
+	for i := 0; i < 100; i++ {
+		mem.Set(keyFmt(i), valFmt(i))
+	}
+
+	// each goroutine owns a range of keys, but they all read and iterate the
+	// whole store
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				require.NotNil(t, st.Get(keyFmt(i)))
+				st.Set(keyFmt(100+g*100+i), valFmt(g))
+				if i%10 == 0 {
+					st.Delete(keyFmt(100 + g*100 + i))
+					itr := st.Iterator(nil, nil)
+					for ; itr.Valid(); itr.Next() {
+						require.NotNil(t, itr.Value())
+					}
+					itr.Close()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	st.Write()
+	for g := 0; g < 8; g++ {
+		for i := 0; i < 100; i++ {
+			if i%10 == 0 {
+				require.Nil(t, mem.Get(keyFmt(100+g*100+i)))
+			} else {
+				require.Equal(t, valFmt(g), mem.Get(keyFmt(100+g*100+i)))
+			}
+		}
+	}
+}
+
+func BenchmarkConcurrentStore(b *testing.B) {
+	keys := make([][]byte, 1<<12)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key%0.8d", i))
+	}
+	stores := map[string]func(parent types.KVStore) types.CacheKVStore{
+		"Store": func(parent types.KVStore) types.CacheKVStore {
+			return cachekv.NewStore(parent, types.NewKVStoreKey("bench"), types.DefaultCacheSizeLimit)
+		},
+		"ConcurrentStore": func(parent types.KVStore) types.CacheKVStore {
+			return cachekv.NewConcurrentStore(parent, types.NewKVStoreKey("bench"), types.DefaultCacheSizeLimit)
+		},
+	}
+	for _, name := range []string{"Store", "ConcurrentStore"} {
+		newStore := stores[name]
+		b.Run(name+"/Set", func(b *testing.B) {
+			st := newStore(dbadapter.Store{DB: dbm.NewMemDB()})
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := keys[i%len(keys)]
+				st.Set(key, key)
+			}
+		})
+		b.Run(name+"/Get", func(b *testing.B) {
+			st := newStore(dbadapter.Store{DB: dbm.NewMemDB()})
+			for _, key := range keys {
+				st.Set(key, key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sink = st.Get(keys[i%len(keys)])
+			}
+		})
+		b.Run(name+"/ParallelGet", func(b *testing.B) {
+			st := newStore(dbadapter.Store{DB: dbm.NewMemDB()})
+			for _, key := range keys {
+				st.Set(key, key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					st.Get(keys[i%len(keys)])
+				}
+			})
+		})
+		b.Run(name+"/Iterate", func(b *testing.B) {
+			st := newStore(dbadapter.Store{DB: dbm.NewMemDB()})
+			for _, key := range keys[:100] {
+				st.Set(key, key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				itr := st.Iterator(nil, nil)
+				for ; itr.Valid(); itr.Next() {
+					sink = itr.Value()
+				}
+				itr.Close()
+			}
+		})
+	}
+}
@@ -161,6 +161,33 @@ func (store *Store) Write() {
 	defer store.mtx.Unlock()
 	defer telemetry.MeasureSince(time.Now(), "store", "cachekv", "write")
 
+	store.writeTo(store.parent)
+
+	// Clear the cache using the map clearing idiom
+	// and not allocating fresh objects.
+	// Please see https://bencher.orijtech.com/perfclinic/mapclearing/
+	store.cache.DeleteAll()
+	store.deleted.Range(func(key, value any) bool {
+		store.deleted.Delete(key)
+		return true
+	})
+	for key := range store.unsortedCache {
+		delete(store.unsortedCache, key)
+	}
+	store.sortedCache = dbm.NewMemDB()
+}
+
+// WriteTo writes the dirty entries of the store to dst. Unlike Write, it
+// keeps the entries, which can still be written to the parent afterwards.
+func (store *Store) WriteTo(dst types.KVStore) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	store.writeTo(dst)
+}
+
+// writeTo writes the dirty entries of the store to dst in key order. The
+// caller must hold the lock of the store.
+func (store *Store) writeTo(dst types.KVStore) {
 	// We need a copy of all of the keys.
 	// Not the best, but probably not a bottleneck depending.
 	keys := make([]string, 0, store.cache.Len())
@@ -182,29 +209,16 @@ func (store *Store) Write() {
 			// be sure if the underlying store might do a save with the byteslice or
 			// not. Once we get confirmation that .Delete is guaranteed not to
 			// save the byteslice, then we can assume only a read-only copy is sufficient.
-			store.parent.Delete([]byte(key))
+			dst.Delete([]byte(key))
 			continue
 		}
 
 		cacheValue, _ := store.cache.Get(key)
 		if cacheValue.Value() != nil {
 			// It already exists in the parent, hence delete it.
-			store.parent.Set([]byte(key), cacheValue.Value())
+			dst.Set([]byte(key), cacheValue.Value())
 		}
 	}
-
-	// Clear the cache using the map clearing idiom
-	// and not allocating fresh objects.
-	// Please see https://bencher.orijtech.com/perfclinic/mapclearing/
-	store.cache.DeleteAll()
-	store.deleted.Range(func(key, value any) bool {
-		store.deleted.Delete(key)
-		return true
-	})
-	for key := range store.unsortedCache {
-		delete(store.unsortedCache, key)
-	}
-	store.sortedCache = dbm.NewMemDB()
 }
 
 // CacheWrap implements CacheWrapper.
@@ -0,0 +1,243 @@
+package cachemulti
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// branchMaps recycles the substore maps of discarded branches.
+var branchMaps = sync.Pool{
+	New: func() interface{} {
+		return make(map[types.StoreKey]types.CacheWrap)
+	},
+}
+
+// BranchFunc returns the branch of the substore parent mounted under key.
+type BranchFunc func(key types.StoreKey, parent types.KVStore) types.CacheWrap
+
+// BranchStore is a copy-on-write branch of a MultiStore. Unlike Store, which
+// branches every substore when it is created, a BranchStore only branches a
+// substore the first time it is accessed, which makes it cheap to create for
+// txs that touch a few of the mounted stores. It is meant to be created for
+// every execution of a tx and discarded afterwards.
+//
+// Tracing and listening are configured on the parent, and are not supported
+// on the branch itself.
+type BranchStore struct {
+	mtx    sync.Mutex
+	parent types.MultiStore
+	branch BranchFunc
+	stores map[types.StoreKey]types.CacheWrap
+}
+
+var _ types.CacheMultiStore = (*BranchStore)(nil)
+
+// NewBranchStore returns a branch of parent whose substores are created with
+// branch, or wrapped in a cachekv.Store if branch is nil.
+func NewBranchStore(parent types.MultiStore, branch BranchFunc) *BranchStore {
+	if branch == nil {
+		branch = func(key types.StoreKey, parent types.KVStore) types.CacheWrap {
+			return cachekv.NewStore(parent, key, types.DefaultCacheSizeLimit)
+		}
+	}
+	return &BranchStore{
+		parent: parent,
+		branch: branch,
+		stores: branchMaps.Get().(map[types.StoreKey]types.CacheWrap),
+	}
+}
+
+// getStore returns the branch of the substore mounted under key, creating it
+// if this is the first access.
+func (bs *BranchStore) getStore(key types.StoreKey) types.CacheWrap {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	if bs.stores == nil {
+		panic("cannot use a discarded branch store")
+	}
+	if store, ok := bs.stores[key]; ok {
+		return store
+	}
+	if key == nil {
+		panic("kv store with nil key has not been registered in stores")
+	}
+	store := bs.branch(key, bs.parent.GetKVStore(key))
+	bs.stores[key] = store
+	return store
+}
+
+// materialized returns the substores accessed so far, sorted by key name.
+func (bs *BranchStore) materialized() []types.CacheWrap {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	keys := make([]types.StoreKey, 0, len(bs.stores))
+	for key := range bs.stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+	stores := make([]types.CacheWrap, len(keys))
+	for i, key := range keys {
+		stores[i] = bs.stores[key]
+	}
+	return stores
+}
+
+// Discard drops the branch without writing it and recycles its buffers. The
+// branch must not be used afterwards.
+func (bs *BranchStore) Discard() {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	if bs.stores == nil {
+		return
+	}
+	for key := range bs.stores {
+		delete(bs.stores, key)
+	}
+	branchMaps.Put(bs.stores)
+	bs.stores = nil
+}
+
+// GetStoreType implements Store.
+func (bs *BranchStore) GetStoreType() types.StoreType {
+	return types.StoreTypeMulti
+}
+
+// Write writes the substores accessed so far to the parent.
+func (bs *BranchStore) Write() {
+	for _, store := range bs.materialized() {
+		store.Write()
+	}
+}
+
+// WriteTo writes the dirty entries of the substores accessed so far to the
+// substores of dst mounted under the same keys, leaving the branch unchanged.
+func (bs *BranchStore) WriteTo(dst types.MultiStore) {
+	bs.mtx.Lock()
+	stores := make(map[types.StoreKey]types.CacheWrap, len(bs.stores))
+	for key, store := range bs.stores {
+		stores[key] = store
+	}
+	bs.mtx.Unlock()
+
+	for key, store := range stores {
+		writeTo(key, store, dst)
+	}
+}
+
+// GetEvents implements MultiStore.
+func (bs *BranchStore) GetEvents() []abci.Event {
+	events := []abci.Event{}
+	for _, store := range bs.materialized() {
+		events = append(events, store.GetEvents()...)
+	}
+	return events
+}
+
+// ResetEvents implements MultiStore.
+func (bs *BranchStore) ResetEvents() {
+	for _, store := range bs.materialized() {
+		store.ResetEvents()
+	}
+}
+
+// CacheWrap implements CacheWrapper.
+func (bs *BranchStore) CacheWrap(_ types.StoreKey) types.CacheWrap {
+	return bs.CacheMultiStore().(types.CacheWrap)
+}
+
+// CacheWrapWithTrace implements CacheWrapper.
+func (bs *BranchStore) CacheWrapWithTrace(storeKey types.StoreKey, _ io.Writer, _ types.TraceContext) types.CacheWrap {
+	return bs.CacheWrap(storeKey)
+}
+
+// CacheWrapWithListeners implements CacheWrapper.
+func (bs *BranchStore) CacheWrapWithListeners(storeKey types.StoreKey, _ []types.WriteListener) types.CacheWrap {
+	return bs.CacheWrap(storeKey)
+}
+
+// CacheMultiStore returns a branch of this branch.
+func (bs *BranchStore) CacheMultiStore() types.CacheMultiStore {
+	return NewBranchStore(bs, nil)
+}
+
+// CacheMultiStoreWithVersion implements MultiStore. It panics as a branch
+// cannot load previous versions.
+func (bs *BranchStore) CacheMultiStoreWithVersion(_ int64) (types.CacheMultiStore, error) {
+	panic("cannot branch a branch store with a version")
+}
+
+// GetStore implements MultiStore.
+func (bs *BranchStore) GetStore(key types.StoreKey) types.Store {
+	return bs.getStore(key).(types.Store)
+}
+
+// GetKVStore implements MultiStore.
+func (bs *BranchStore) GetKVStore(key types.StoreKey) types.KVStore {
+	return bs.getStore(key).(types.KVStore)
+}
+
+// StoreKeys implements MultiStore.
+func (bs *BranchStore) StoreKeys() []types.StoreKey {
+	return bs.parent.StoreKeys()
+}
+
+// SetKVStores implements MultiStore. The substores accessed so far are
+// replaced right away, the others are wrapped when they are first accessed.
+func (bs *BranchStore) SetKVStores(handler func(key types.StoreKey, s types.KVStore) types.CacheWrap) types.MultiStore {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	for key, store := range bs.stores {
+		bs.stores[key] = handler(key, store.(types.KVStore))
+	}
+	branch := bs.branch
+	bs.branch = func(key types.StoreKey, parent types.KVStore) types.CacheWrap {
+		return handler(key, branch(key, parent).(types.KVStore))
+	}
+	return bs
+}
+
+// TracingEnabled implements MultiStore.
+func (bs *BranchStore) TracingEnabled() bool {
+	return false
+}
+
+// SetTracer implements MultiStore. It panics as tracing is configured on the
+// parent.
+func (bs *BranchStore) SetTracer(_ io.Writer) types.MultiStore {
+	panic("cannot set a tracer on a branch store")
+}
+
+// SetTracingContext implements MultiStore. It panics as tracing is configured
+// on the parent.
+func (bs *BranchStore) SetTracingContext(_ types.TraceContext) types.MultiStore {
+	panic("cannot set a tracing context on a branch store")
+}
+
+// ListeningEnabled implements MultiStore.
+func (bs *BranchStore) ListeningEnabled(_ types.StoreKey) bool {
+	return false
+}
+
+// AddListeners implements MultiStore. It panics as listeners are configured
+// on the parent.
+func (bs *BranchStore) AddListeners(key types.StoreKey, _ []types.WriteListener) {
+	panic(fmt.Sprintf("cannot add listeners for %s on a branch store", key.Name()))
+}
+
+// GetWorkingHash implements MultiStore.
+func (bs *BranchStore) GetWorkingHash() ([]byte, error) {
+	panic("should never attempt to get working hash from branch store")
+}
@@ -0,0 +1,148 @@
+package cachemulti
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func newTestMultiStore(n int) (Store, []types.StoreKey) {
+	stores := make(map[types.StoreKey]types.CacheWrapper, n)
+	keys := make([]types.StoreKey, n)
+	for i := range keys {
+		keys[i] = types.NewKVStoreKey(fmt.Sprintf("store%02d", i))
+		stores[keys[i]] = dbadapter.Store{DB: dbm.NewMemDB()}
+	}
+	return NewStore(dbm.NewMemDB(), stores, nil, nil, nil, nil), keys
+}
+
+func TestBranchStore(t *testing.T) {
+	parent, keys := newTestMultiStore(3)
+	parent.GetKVStore(keys[0]).Set([]byte("key"), []byte("parent"))
+
+	branched := 0
+	bs := NewBranchStore(parent, func(key types.StoreKey, parent types.KVStore) types.CacheWrap {
+		branched++
+		return parent.CacheWrap(key)
+	})
+	require.Equal(t, parent.StoreKeys(), bs.StoreKeys())
+
+	// substores are only branched when first accessed
+	require.Equal(t, 0, branched)
+	kv := bs.GetKVStore(keys[0])
+	require.Equal(t, []byte("parent"), kv.Get([]byte("key")))
+	kv.Set([]byte("key"), []byte("branch"))
+	require.Same(t, kv, bs.GetKVStore(keys[0]))
+	require.Equal(t, 1, branched)
+
+	// writes stay in the branch until it is written
+	require.Equal(t, []byte("parent"), parent.GetKVStore(keys[0]).Get([]byte("key")))
+	bs.Write()
+	require.Equal(t, []byte("branch"), parent.GetKVStore(keys[0]).Get([]byte("key")))
+	require.Equal(t, 1, branched)
+
+	require.Panics(t, func() { bs.GetKVStore(types.NewKVStoreKey("unknown")) })
+}
+
+func TestBranchStoreNested(t *testing.T) {
+	parent, keys := newTestMultiStore(2)
+	bs := NewBranchStore(parent, nil)
+	nested := bs.CacheMultiStore()
+
+	nested.GetKVStore(keys[1]).Set([]byte("key"), []byte("nested"))
+	require.Nil(t, bs.GetKVStore(keys[1]).Get([]byte("key")))
+	nested.Write()
+	require.Equal(t, []byte("nested"), bs.GetKVStore(keys[1]).Get([]byte("key")))
+	require.Nil(t, parent.GetKVStore(keys[1]).Get([]byte("key")))
+}
+
+func TestBranchStoreSetKVStores(t *testing.T) {
+	parent, keys := newTestMultiStore(2)
+	bs := NewBranchStore(parent, nil)
+	accessed := bs.GetKVStore(keys[0])
+
+	wrapped := make(map[types.StoreKey]types.KVStore)
+	bs.SetKVStores(func(key types.StoreKey, s types.KVStore) types.CacheWrap {
+		wrapped[key] = s
+		return s.CacheWrap(key)
+	})
+	// the accessed substore is wrapped right away, the other on first access
+	require.Equal(t, map[types.StoreKey]types.KVStore{keys[0]: accessed}, wrapped)
+	bs.GetKVStore(keys[1])
+	require.Len(t, wrapped, 2)
+}
+
+func TestBranchStoreDiscard(t *testing.T) {
+	parent, keys := newTestMultiStore(1)
+	bs := NewBranchStore(parent, nil)
+	bs.GetKVStore(keys[0]).Set([]byte("key"), []byte("branch"))
+
+	bs.Discard()
+	bs.Discard()
+	require.Nil(t, parent.GetKVStore(keys[0]).Get([]byte("key")))
+	require.Panics(t, func() { bs.GetKVStore(keys[0]) })
+}
+
+// Branching a multi-store with many substores for a tx that touches two.
+func BenchmarkBranch(b *testing.B) {
+	parent, keys := newTestMultiStore(20)
+	touch := func(ms types.MultiStore) {
+		for _, key := range keys[:2] {
+			ms.GetKVStore(key).Set([]byte("key"), []byte("value"))
+		}
+	}
+
+	b.Run("cachemulti", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			touch(parent.CacheMultiStore())
+		}
+	})
+	b.Run("branch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bs := NewBranchStore(parent, nil)
+			touch(bs)
+			bs.Discard()
+		}
+	})
+}
+
+func TestWriteTo(t *testing.T) {
+	parent, keys := newTestMultiStore(2)
+	parent.GetKVStore(keys[0]).Set([]byte("deleted"), []byte("parent"))
+	branches := map[string]types.CacheMultiStore{
+		"store": parent.CacheMultiStore(),
+		"branch": NewBranchStore(parent, func(key types.StoreKey, parent types.KVStore) types.CacheWrap {
+			return cachekv.NewConcurrentStore(parent, key, types.DefaultCacheSizeLimit)
+		}),
+	}
+	for name, branch := range branches {
+		t.Run(name, func(t *testing.T) {
+			branch.GetKVStore(keys[0]).Delete([]byte("deleted"))
+			branch.GetKVStore(keys[1]).Set([]byte("key"), []byte("branch"))
+
+			dst := NewStore(dbm.NewMemDB(), map[types.StoreKey]types.CacheWrapper{
+				keys[0]: dbadapter.Store{DB: dbm.NewMemDB()},
+				keys[1]: dbadapter.Store{DB: dbm.NewMemDB()},
+			}, nil, nil, nil, nil)
+			dst.GetKVStore(keys[0]).Set([]byte("deleted"), []byte("dst"))
+			branch.(interface{ WriteTo(types.MultiStore) }).WriteTo(dst)
+			require.Nil(t, dst.GetKVStore(keys[0]).Get([]byte("deleted")))
+			require.Equal(t, []byte("branch"), dst.GetKVStore(keys[1]).Get([]byte("key")))
+
+			// the writes are kept, to be written to the parent as well
+			require.Equal(t, []byte("parent"), parent.GetKVStore(keys[0]).Get([]byte("deleted")))
+			branch.Write()
+			require.Nil(t, parent.GetKVStore(keys[0]).Get([]byte("deleted")))
+			require.Equal(t, []byte("branch"), parent.GetKVStore(keys[1]).Get([]byte("key")))
+			parent.GetKVStore(keys[0]).Set([]byte("deleted"), []byte("parent"))
+		})
+	}
+}
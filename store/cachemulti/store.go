@@ -3,6 +3,7 @@ package cachemulti
 import (
 	"fmt"
 	"io"
+	"sort"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tm-db"
@@ -144,6 +145,25 @@ func (cms Store) Write() {
 	}
 }
 
+// WriteTo writes the dirty entries of each substore to the substore of dst
+// mounted under the same key, leaving cms unchanged so that it can still be
+// written to its parent.
+func (cms Store) WriteTo(dst types.MultiStore) {
+	for key, store := range cms.stores {
+		writeTo(key, store, dst)
+	}
+}
+
+// writeTo writes the dirty entries of the branched substore mounted under key
+// to the substore of dst.
+func writeTo(key types.StoreKey, store types.CacheWrap, dst types.MultiStore) {
+	writer, ok := store.(interface{ WriteTo(types.KVStore) })
+	if !ok {
+		panic(fmt.Sprintf("cannot copy the writes of store %s of type %T", key.Name(), store))
+	}
+	writer.WriteTo(dst.GetKVStore(key))
+}
+
 func (cms Store) GetEvents() []abci.Event {
 	events := []abci.Event{}
 	for _, store := range cms.stores {
@@ -205,6 +225,26 @@ func (cms Store) GetKVStore(key types.StoreKey) types.KVStore {
 	return store.(types.KVStore)
 }
 
+// StoreKeys returns the keys of all branched stores, sorted by name.
+func (cms Store) StoreKeys() []types.StoreKey {
+	keys := make([]types.StoreKey, 0, len(cms.stores))
+	for key := range cms.stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+	return keys
+}
+
+// SetKVStores replaces each branched store with the store returned by handler.
+func (cms Store) SetKVStores(handler func(key types.StoreKey, s types.KVStore) types.CacheWrap) types.MultiStore {
+	for key, store := range cms.stores {
+		cms.stores[key] = handler(key, store.(types.KVStore))
+	}
+	return cms
+}
+
 func (cms Store) GetWorkingHash() ([]byte, error) {
 	panic("should never attempt to get working hash from cache multi store")
 }
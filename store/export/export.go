@@ -0,0 +1,158 @@
+// Package export extracts the key-value pairs stored under a prefix of a store,
+// at a given height, into a portable format. Unlike a genesis export, it reads
+// the raw store and does not require the modules to be initialized.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// Format is the encoding of the exported key-value pairs.
+type Format string
+
+const (
+	// FormatJSONL writes a cosmos.base.store.v1beta1.StoreKVPair per line in
+	// its protobuf JSON encoding, the keys and values being base64 encoded.
+	FormatJSONL Format = "jsonl"
+	// FormatProto writes a stream of cosmos.base.store.v1beta1.StoreKVPair
+	// messages, each prefixed with its uvarint encoded length.
+	FormatProto Format = "proto"
+)
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatJSONL, FormatProto:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q, expected %s or %s", s, FormatJSONL, FormatProto)
+	}
+}
+
+// Writer writes exported key-value pairs. Close must be called once all of them
+// are written, and does not close the underlying io.Writer.
+type Writer interface {
+	Write(pair *types.StoreKVPair) error
+	Close() error
+}
+
+// NewWriter returns a Writer encoding the pairs written to w in format.
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(w)
+	if format == FormatProto {
+		return &protoWriter{buf: buf, w: protoio.NewDelimitedWriter(buf)}, nil
+	}
+	return &jsonlWriter{w: buf, marshaler: &jsonpb.Marshaler{OrigName: true}}, nil
+}
+
+type jsonlWriter struct {
+	w         *bufio.Writer
+	marshaler *jsonpb.Marshaler
+}
+
+func (jw *jsonlWriter) Write(pair *types.StoreKVPair) error {
+	if err := jw.marshaler.Marshal(jw.w, pair); err != nil {
+		return err
+	}
+	return jw.w.WriteByte('\n')
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.w.Flush()
+}
+
+type protoWriter struct {
+	buf *bufio.Writer
+	w   protoio.Writer
+}
+
+func (pw *protoWriter) Write(pair *types.StoreKVPair) error {
+	return pw.w.WriteMsg(pair)
+}
+
+func (pw *protoWriter) Close() error {
+	return pw.buf.Flush()
+}
+
+// versionedStore is implemented by the stores keeping past versions, such as
+// the IAVL stores.
+type versionedStore interface {
+	VersionExists(version int64) bool
+}
+
+// Prefix writes every key-value pair of the store named storeName starting
+// with prefix to w, in key order, as stored at height. A height of 0 exports
+// the latest committed height. It returns the number of pairs written.
+func Prefix(ms types.CommitMultiStore, height int64, storeName string, prefix []byte, w Writer) (int64, error) {
+	store, err := KVStoreAt(ms, height, storeName)
+	if err != nil {
+		return 0, err
+	}
+	return KVStorePrefix(store, storeName, prefix, w)
+}
+
+// KVStoreAt returns a read-only view of the store named storeName as stored at
+// height, 0 being the latest committed height. It fails if the height has been
+// pruned.
+func KVStoreAt(ms types.CommitMultiStore, height int64, storeName string) (types.KVStore, error) {
+	latest := ms.LastCommitID().Version
+	if height == 0 {
+		height = latest
+	}
+	if height <= 0 || height > latest {
+		return nil, fmt.Errorf("invalid height %d, the latest height is %d", height, latest)
+	}
+
+	var key types.StoreKey
+	for _, k := range ms.StoreKeys() {
+		if k.Name() == storeName {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no store named %q", storeName)
+	}
+	// the stores of a pruned height read as empty, rather than failing
+	if vs, ok := ms.GetStore(key).(versionedStore); ok && !vs.VersionExists(height) {
+		return nil, fmt.Errorf("height %d of store %s does not exist, it may have been pruned", height, storeName)
+	}
+
+	cms, err := ms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return nil, err
+	}
+	return cms.GetKVStore(key), nil
+}
+
+// KVStorePrefix writes every key-value pair of store starting with prefix to
+// w, in key order, labelled with storeName. It returns the number of pairs
+// written.
+func KVStorePrefix(store types.KVStore, storeName string, prefix []byte, w Writer) (int64, error) {
+	itr := types.KVStorePrefixIterator(store, prefix)
+	defer itr.Close()
+
+	var count int64
+	for ; itr.Valid(); itr.Next() {
+		err := w.Write(&types.StoreKVPair{
+			StoreKey: storeName,
+			Key:      itr.Key(),
+			Value:    itr.Value(),
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
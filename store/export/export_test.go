@@ -0,0 +1,120 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var (
+	bankKey    = types.NewKVStoreKey("bank")
+	stakingKey = types.NewKVStoreKey("staking")
+)
+
+// newStore returns a multistore where height 1 holds two balances and height
+// 2 a third one, with an unrelated key in each store.
+func newStore(t *testing.T, pruning types.PruningOptions) *rootmulti.Store {
+	ms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+	ms.SetPruning(pruning)
+	ms.MountStoreWithDB(bankKey, types.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(stakingKey, types.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	bank := ms.GetKVStore(bankKey)
+	bank.Set([]byte{0x02, 'a'}, []byte("10"))
+	bank.Set([]byte{0x02, 'b'}, []byte("20"))
+	bank.Set([]byte{0x01}, []byte("supply"))
+	ms.GetKVStore(stakingKey).Set([]byte{0x02, 'c'}, []byte("validator"))
+	ms.Commit(true)
+	bank.Set([]byte{0x02, 'c'}, []byte("30"))
+	ms.Commit(true)
+	return ms
+}
+
+func readJSONL(t *testing.T, data []byte) []*types.StoreKVPair {
+	var pairs []*types.StoreKVPair
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		pair := &types.StoreKVPair{}
+		require.NoError(t, jsonpb.UnmarshalString(scanner.Text(), pair))
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+func TestPrefixJSONL(t *testing.T) {
+	ms := newStore(t, types.PruneNothing)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatJSONL)
+	require.NoError(t, err)
+	count, err := Prefix(ms, 1, "bank", []byte{0x02}, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.EqualValues(t, 2, count)
+
+	require.Equal(t, `{"store_key":"bank","key":"AmE=","value":"MTA="}`, strings.Split(buf.String(), "\n")[0])
+	require.Equal(t, []*types.StoreKVPair{
+		{StoreKey: "bank", Key: []byte{0x02, 'a'}, Value: []byte("10")},
+		{StoreKey: "bank", Key: []byte{0x02, 'b'}, Value: []byte("20")},
+	}, readJSONL(t, buf.Bytes()))
+
+	// height 0 exports the latest height, and no prefix the whole store
+	buf.Reset()
+	w, err = NewWriter(&buf, FormatJSONL)
+	require.NoError(t, err)
+	count, err = Prefix(ms, 0, "bank", nil, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.EqualValues(t, 4, count)
+	pairs := readJSONL(t, buf.Bytes())
+	require.Equal(t, []byte{0x01}, pairs[0].Key)
+	require.Equal(t, []byte("30"), pairs[3].Value)
+}
+
+func TestPrefixProto(t *testing.T) {
+	ms := newStore(t, types.PruneNothing)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatProto)
+	require.NoError(t, err)
+	count, err := Prefix(ms, 2, "bank", []byte{0x02}, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.EqualValues(t, 3, count)
+
+	reader := protoio.NewDelimitedReader(&buf, 1<<20)
+	for _, key := range []byte{'a', 'b', 'c'} {
+		pair := &types.StoreKVPair{}
+		require.NoError(t, reader.ReadMsg(pair))
+		require.Equal(t, "bank", pair.StoreKey)
+		require.Equal(t, []byte{0x02, key}, pair.Key)
+	}
+	require.Error(t, reader.ReadMsg(&types.StoreKVPair{}))
+}
+
+func TestPrefixErrors(t *testing.T) {
+	ms := newStore(t, types.NewPruningOptions(0, 0, 1))
+	w, err := NewWriter(&bytes.Buffer{}, FormatJSONL)
+	require.NoError(t, err)
+
+	_, err = Prefix(ms, 3, "bank", nil, w)
+	require.EqualError(t, err, "invalid height 3, the latest height is 2")
+	_, err = Prefix(ms, 2, "gov", nil, w)
+	require.EqualError(t, err, `no store named "gov"`)
+	_, err = Prefix(ms, 1, "bank", nil, w)
+	require.EqualError(t, err, "height 1 of store bank does not exist, it may have been pruned")
+
+	_, err = NewWriter(&bytes.Buffer{}, "csv")
+	require.EqualError(t, err, `unknown export format "csv", expected jsonl or proto`)
+}
@@ -0,0 +1,338 @@
+package iavl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/cosmos/iavl"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// FastNodeMigrationMode tells what to do with an IAVL store whose fast nodes,
+// the index of its latest version by key, are missing or out of date.
+type FastNodeMigrationMode string
+
+const (
+	// FastNodeMigrationAuto migrates the store when it is loaded, resuming an
+	// interrupted migration of the same version.
+	FastNodeMigrationAuto FastNodeMigrationMode = "auto"
+	// FastNodeMigrationDefer loads the store without its fast nodes until it is
+	// loaded with another mode, the stores already migrated using theirs.
+	FastNodeMigrationDefer FastNodeMigrationMode = "defer"
+	// FastNodeMigrationForce rebuilds the fast nodes of every store, even up to
+	// date ones, e.g. after a disk failure.
+	FastNodeMigrationForce FastNodeMigrationMode = "force"
+)
+
+// ParseFastNodeMigrationMode returns the FastNodeMigrationMode named s, an
+// empty s being FastNodeMigrationAuto.
+func ParseFastNodeMigrationMode(s string) (FastNodeMigrationMode, error) {
+	switch mode := FastNodeMigrationMode(s); mode {
+	case "":
+		return FastNodeMigrationAuto, nil
+	case FastNodeMigrationAuto, FastNodeMigrationDefer, FastNodeMigrationForce:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown fast node migration mode %q, expected %s, %s or %s", s, FastNodeMigrationAuto, FastNodeMigrationDefer, FastNodeMigrationForce)
+	}
+}
+
+// FastNodeMigrationConfig configures the migration of the IAVL stores to fast
+// nodes.
+type FastNodeMigrationConfig struct {
+	Mode FastNodeMigrationMode
+	// NodesPerSecond bounds the rate at which the fast nodes are written, so
+	// that a migration does not starve the other users of the disk. 0 leaves
+	// it unbounded.
+	NodesPerSecond int
+}
+
+// The layout of the fast nodes and of the storage version in the DB of a tree,
+// as written by the iavl package.
+const (
+	fastNodePrefix             = 'f'
+	rootPrefix                 = 'r'
+	storageVersionKey          = "storage_version"
+	defaultStorageVersionValue = "1.0.0"
+	fastStorageVersionValue    = "1.1.0"
+)
+
+var (
+	storageVersionDBKey = append([]byte{'m'}, storageVersionKey...)
+	// migrationCheckpointKey holds the version being migrated followed by the
+	// last key whose fast node is written, along with the fast nodes
+	migrationCheckpointKey = []byte("mfast_node_migration")
+)
+
+const (
+	// fastNodeMigrationBatchSize is the number of fast nodes written per
+	// batch, and so the work lost by an interrupted migration
+	fastNodeMigrationBatchSize = 100000
+	fastNodeMigrationLogPeriod = 10 * time.Second
+	fastNodeMigrationCacheSize = 10000
+)
+
+// MigrateFastNodes writes the fast nodes of the latest version of the IAVL tree
+// stored in db if they are missing or out of date, or if cfg forces it, as the
+// iavl package does when loading a tree. Unlike it, MigrateFastNodes reports
+// its progress, bounds its rate and resumes from the last batch written after
+// an interruption.
+//
+// It returns whether the fast nodes are up to date, which they are not if the
+// migration is deferred, in which case the tree must be loaded without fast
+// nodes so that the iavl package does not migrate it either.
+func MigrateFastNodes(db dbm.DB, logger log.Logger, key types.StoreKey, cfg FastNodeMigrationConfig) (bool, error) {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	latest, err := latestTreeVersion(db)
+	if err != nil || latest == 0 {
+		// an empty tree is migrated by the iavl package at no cost
+		return true, err
+	}
+	storageVersion, err := db.Get(storageVersionDBKey)
+	if err != nil {
+		return false, err
+	}
+	if fastNodesUpToDate(string(storageVersion), latest) && cfg.Mode != FastNodeMigrationForce {
+		return true, nil
+	}
+	if cfg.Mode == FastNodeMigrationDefer {
+		logger.Info("deferring the migration of the IAVL store to fast nodes", "store", key.Name(), "version", latest)
+		return false, nil
+	}
+
+	m := &fastNodeMigration{
+		db:      db,
+		logger:  logger.With("store", key.Name(), "version", latest),
+		version: latest,
+		rate:    cfg.NodesPerSecond,
+		labels:  []metrics.Label{telemetry.NewLabel("store_name", key.Name())},
+	}
+	if err := m.run(cfg.Mode == FastNodeMigrationForce); err != nil {
+		return false, fmt.Errorf("failed to migrate the IAVL store %s to fast nodes: %w", key.Name(), err)
+	}
+	return true, nil
+}
+
+// latestTreeVersion returns the latest version of the tree stored in db, 0 if
+// it is empty.
+func latestTreeVersion(db dbm.DB) (int64, error) {
+	itr, err := db.ReverseIterator([]byte{rootPrefix}, []byte{rootPrefix + 1})
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+	if !itr.Valid() {
+		return 0, itr.Error()
+	}
+	k := itr.Key()
+	if len(k) != 9 {
+		return 0, fmt.Errorf("invalid root key %X", k)
+	}
+	return int64(binary.BigEndian.Uint64(k[1:])), nil
+}
+
+type fastNodeMigration struct {
+	db      dbm.DB
+	logger  log.Logger
+	version int64
+	rate    int
+	labels  []metrics.Label
+
+	written int64
+	total   int64
+	// resumed is the number of fast nodes written before a resumption
+	resumed int64
+	start   time.Time
+	lastLog time.Time
+}
+
+func (m *fastNodeMigration) run(force bool) error {
+	tree, err := iavl.NewMutableTree(m.db, fastNodeMigrationCacheSize, true)
+	if err != nil {
+		return err
+	}
+	itree, err := tree.GetImmutable(m.version)
+	if err != nil {
+		return err
+	}
+	m.total = itree.Size()
+	m.start = time.Now()
+
+	var startKey []byte
+	checkpoint, err := m.db.Get(migrationCheckpointKey)
+	if err != nil {
+		return err
+	}
+	if !force && len(checkpoint) >= 8 && int64(binary.BigEndian.Uint64(checkpoint)) == m.version {
+		// the fast nodes up to the last key written are those of this version
+		lastKey := checkpoint[8:]
+		startKey = append(append([]byte{}, lastKey...), 0)
+		if m.written, _, err = itree.GetWithIndex(startKey); err != nil {
+			return err
+		}
+		m.resumed = m.written
+		m.logger.Info("resuming the migration of the IAVL store to fast nodes", "migrated", m.written, "total", m.total)
+	} else {
+		m.logger.Info("migrating the IAVL store to fast nodes, this may take a while", "total", m.total)
+		if err := m.deleteFastNodes(); err != nil {
+			return err
+		}
+	}
+
+	itr, err := itree.Iterator(startKey, nil, true)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	batch := m.db.NewBatch()
+	defer func() { batch.Close() }()
+	var value bytes.Buffer
+	inBatch := 0
+	for ; itr.Valid(); itr.Next() {
+		value.Reset()
+		encodeFastNode(&value, m.version, itr.Value())
+		if err := batch.Set(append([]byte{fastNodePrefix}, itr.Key()...), append([]byte{}, value.Bytes()...)); err != nil {
+			return err
+		}
+		m.written++
+		inBatch++
+		if inBatch == fastNodeMigrationBatchSize {
+			if err := m.writeCheckpoint(batch, itr.Key()); err != nil {
+				return err
+			}
+			batch.Close()
+			batch = m.db.NewBatch()
+			inBatch = 0
+			m.report(false)
+		}
+		m.throttle()
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+
+	version := fastStorageVersionValue + "-" + strconv.FormatInt(m.version, 10)
+	if err := batch.Set(storageVersionDBKey, []byte(version)); err != nil {
+		return err
+	}
+	if err := batch.Delete(migrationCheckpointKey); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+	m.report(true)
+	return nil
+}
+
+// deleteFastNodes deletes the fast nodes of the previous versions, marking the
+// store as not migrated first so that an interruption leaves it migrating.
+func (m *fastNodeMigration) deleteFastNodes() error {
+	if err := m.db.SetSync(storageVersionDBKey, []byte(defaultStorageVersionValue)); err != nil {
+		return err
+	}
+	if err := m.db.Delete(migrationCheckpointKey); err != nil {
+		return err
+	}
+
+	// the keys are collected before being deleted, as deleting the keys of an
+	// open iterator is not supported by every backend
+	for {
+		itr, err := m.db.Iterator([]byte{fastNodePrefix}, []byte{fastNodePrefix + 1})
+		if err != nil {
+			return err
+		}
+		var keys [][]byte
+		for ; itr.Valid() && len(keys) < fastNodeMigrationBatchSize; itr.Next() {
+			keys = append(keys, append([]byte{}, itr.Key()...))
+		}
+		err = itr.Error()
+		itr.Close()
+		if err != nil || len(keys) == 0 {
+			return err
+		}
+
+		batch := m.db.NewBatch()
+		for _, k := range keys {
+			if err := batch.Delete(k); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		err = batch.Write()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (m *fastNodeMigration) writeCheckpoint(batch dbm.Batch, lastKey []byte) error {
+	checkpoint := make([]byte, 8, 8+len(lastKey))
+	binary.BigEndian.PutUint64(checkpoint, uint64(m.version))
+	if err := batch.Set(migrationCheckpointKey, append(checkpoint, lastKey...)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// throttle sleeps while the fast nodes are written faster than the rate.
+func (m *fastNodeMigration) throttle() {
+	if m.rate <= 0 {
+		return
+	}
+	if ahead := time.Duration(m.written-m.resumed)*time.Second/time.Duration(m.rate) - time.Since(m.start); ahead > 0 {
+		time.Sleep(ahead)
+	}
+}
+
+// report logs the progress of the migration, at most every
+// fastNodeMigrationLogPeriod unless done, and exports it as gauges.
+func (m *fastNodeMigration) report(done bool) {
+	telemetry.SetGaugeWithLabels([]string{"store", "iavl", "fast_node_migration", "migrated"}, float32(m.written), m.labels)
+	telemetry.SetGaugeWithLabels([]string{"store", "iavl", "fast_node_migration", "total"}, float32(m.total), m.labels)
+	if done {
+		m.logger.Info("migrated the IAVL store to fast nodes", "migrated", m.written, "duration", time.Since(m.start))
+		return
+	}
+	if time.Since(m.lastLog) < fastNodeMigrationLogPeriod {
+		return
+	}
+	m.lastLog = time.Now()
+	progress := "100"
+	if m.total > 0 {
+		progress = strconv.FormatFloat(float64(m.written)*100/float64(m.total), 'f', 1, 64)
+	}
+	m.logger.Info("migrating the IAVL store to fast nodes", "migrated", m.written, "total", m.total, "progress", progress+"%")
+}
+
+// encodeFastNode writes the fast node of value, as of version, in the encoding
+// of the iavl package.
+func encodeFastNode(buf *bytes.Buffer, version int64, value []byte) {
+	var varint [binary.MaxVarintLen64]byte
+	buf.Write(varint[:binary.PutVarint(varint[:], version)])
+	buf.Write(varint[:binary.PutUvarint(varint[:], uint64(len(value)))])
+	buf.Write(value)
+}
+
+// fastNodesUpToDate tells whether the storage version of a tree marks its fast
+// nodes as those of its latest version, as the iavl package checks it.
+func fastNodesUpToDate(storageVersion string, latest int64) bool {
+	versions := strings.Split(storageVersion, "-")
+	if versions[0] < fastStorageVersionValue {
+		return false
+	}
+	return len(versions) != 2 || versions[1] == strconv.FormatInt(latest, 10)
+}
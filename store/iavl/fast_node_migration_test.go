@@ -0,0 +1,140 @@
+package iavl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// newTreeWithoutFastNodes saves two versions of a tree of 100 keys without
+// writing their fast nodes.
+func newTreeWithoutFastNodes(t *testing.T) dbm.DB {
+	db := dbm.NewMemDB()
+	tree, err := iavl.NewMutableTree(db, cacheSize, true)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		tree.Set([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	tree.Set([]byte("key000"), []byte("updated"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	return db
+}
+
+func fastNodes(t *testing.T, db dbm.DB) map[string][]byte {
+	itr, err := db.Iterator([]byte{fastNodePrefix}, []byte{fastNodePrefix + 1})
+	require.NoError(t, err)
+	defer itr.Close()
+	nodes := make(map[string][]byte)
+	for ; itr.Valid(); itr.Next() {
+		nodes[string(itr.Key()[1:])] = itr.Value()
+	}
+	return nodes
+}
+
+func fastNodeValue(t *testing.T, db dbm.DB, key string) string {
+	bz, err := db.Get(append([]byte{fastNodePrefix}, key...))
+	require.NoError(t, err)
+	version, n := binary.Varint(bz)
+	require.Equal(t, int64(2), version)
+	size, m := binary.Uvarint(bz[n:])
+	require.Len(t, bz, n+m+int(size))
+	return string(bz[n+m:])
+}
+
+func TestMigrateFastNodes(t *testing.T) {
+	db := newTreeWithoutFastNodes(t)
+	key := types.NewKVStoreKey("bank")
+	// a fast node left by a previous migration, whose key has been deleted since
+	require.NoError(t, db.Set(append([]byte{fastNodePrefix}, "stale"...), []byte{}))
+
+	upToDate, err := MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{Mode: FastNodeMigrationDefer})
+	require.NoError(t, err)
+	require.False(t, upToDate)
+	require.Len(t, fastNodes(t, db), 1)
+
+	upToDate, err = MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{})
+	require.NoError(t, err)
+	require.True(t, upToDate)
+	require.Len(t, fastNodes(t, db), 100)
+	require.Equal(t, "updated", fastNodeValue(t, db, "key000"))
+	require.Equal(t, "value99", fastNodeValue(t, db, "key099"))
+
+	// the iavl package loads the tree with the fast nodes written
+	tree, err := iavl.NewMutableTree(db, cacheSize, false)
+	require.NoError(t, err)
+	upgradeable, err := tree.IsUpgradeable()
+	require.NoError(t, err)
+	require.False(t, upgradeable)
+	_, err = tree.LoadVersion(0)
+	require.NoError(t, err)
+	enabled, err := tree.ImmutableTree().IsFastCacheEnabled()
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	// an up to date store is left as is, unless the migration is forced
+	require.NoError(t, db.Set(append([]byte{fastNodePrefix}, "key050"...), []byte{4, 3, 'b', 'a', 'd'}))
+	upToDate, err = MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{Mode: FastNodeMigrationDefer})
+	require.NoError(t, err)
+	require.True(t, upToDate)
+	_, err = MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "bad", fastNodeValue(t, db, "key050"))
+	_, err = MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{Mode: FastNodeMigrationForce, NodesPerSecond: 100000})
+	require.NoError(t, err)
+	require.Equal(t, "value50", fastNodeValue(t, db, "key050"))
+}
+
+func TestMigrateFastNodesResume(t *testing.T) {
+	db := newTreeWithoutFastNodes(t)
+	key := types.NewKVStoreKey("bank")
+	_, err := MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{})
+	require.NoError(t, err)
+
+	// interrupt the migration after key049, whose fast node is marked to tell
+	// whether it is written again
+	require.NoError(t, db.Set(storageVersionDBKey, []byte(defaultStorageVersionValue)))
+	checkpoint := make([]byte, 8)
+	binary.BigEndian.PutUint64(checkpoint, 2)
+	require.NoError(t, db.Set(migrationCheckpointKey, append(checkpoint, "key049"...)))
+	require.NoError(t, db.Set(append([]byte{fastNodePrefix}, "key049"...), []byte{4, 6, 'r', 'e', 's', 'u', 'm', 'e'}))
+	for i := 50; i < 100; i++ {
+		require.NoError(t, db.Delete(append([]byte{fastNodePrefix}, fmt.Sprintf("key%03d", i)...)))
+	}
+
+	upToDate, err := MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{})
+	require.NoError(t, err)
+	require.True(t, upToDate)
+	require.Len(t, fastNodes(t, db), 100)
+	require.Equal(t, "resume", fastNodeValue(t, db, "key049"))
+	require.Equal(t, "value50", fastNodeValue(t, db, "key050"))
+	checkpoint, err = db.Get(migrationCheckpointKey)
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	// a checkpoint of another version is ignored
+	require.NoError(t, db.Set(storageVersionDBKey, []byte(defaultStorageVersionValue)))
+	require.NoError(t, db.Set(migrationCheckpointKey, append([]byte{0, 0, 0, 0, 0, 0, 0, 1}, "key049"...)))
+	_, err = MigrateFastNodes(db, nil, key, FastNodeMigrationConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "value49", fastNodeValue(t, db, "key049"))
+}
+
+func TestParseFastNodeMigrationMode(t *testing.T) {
+	mode, err := ParseFastNodeMigrationMode("")
+	require.NoError(t, err)
+	require.Equal(t, FastNodeMigrationAuto, mode)
+	mode, err = ParseFastNodeMigrationMode("defer")
+	require.NoError(t, err)
+	require.Equal(t, FastNodeMigrationDefer, mode)
+	_, err = ParseFastNodeMigrationMode("later")
+	require.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package iavl
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/iavl"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// The prefixes of the IAVL keys, which are not exported by the iavl package.
+const (
+	nodeKeyPrefix   = 'n' // n<hash>
+	orphanKeyPrefix = 'o' // o<last-version><first-version><hash>
+	rootKeyPrefix   = 'r' // r<version>
+
+	// iavlHashSize is the size of the node hashes, which are SHA-256 sums.
+	iavlHashSize = 32
+	gcBatchSize  = 10000
+)
+
+// GCStats reports the garbage found by CollectGarbage in a tree.
+type GCStats struct {
+	// Versions is the number of versions of the tree.
+	Versions int
+	// ReachableNodes is the number of nodes of these versions, which are kept.
+	ReachableNodes int64
+	// OrphanedNodes is the number of nodes that no version references.
+	OrphanedNodes int64
+	// StaleOrphans is the number of orphan records of nodes that no version
+	// references, which pruning would never delete either.
+	StaleOrphans int64
+	// Bytes is the size of the keys and values of the garbage.
+	Bytes int64
+}
+
+// HasVersion returns whether the tree stored in db has version.
+func HasVersion(db dbm.DB, version int64) (bool, error) {
+	key := make([]byte, 9)
+	key[0] = rootKeyPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+	return db.Has(key)
+}
+
+// CollectGarbage deletes the nodes of the tree stored in db that are not
+// reachable from the root of any of its versions, along with their orphan
+// records. Such nodes are left behind when the deletion of a version is
+// interrupted or misses orphans, and nothing deletes them afterwards. If dryRun
+// is set, the garbage is only counted.
+//
+// The hashes of the reachable nodes are held in memory, and db must not be
+// written to while the garbage is collected. Nothing is deleted if a version
+// references a missing node, nor if the tree has no versions.
+func CollectGarbage(db dbm.DB, dryRun bool) (GCStats, error) {
+	var stats GCStats
+	roots, err := readRoots(db)
+	if err != nil {
+		return stats, err
+	}
+	stats.Versions = len(roots)
+	if len(roots) == 0 {
+		return stats, nil
+	}
+
+	reachable, err := markReachable(db, roots)
+	if err != nil {
+		return stats, err
+	}
+	stats.ReachableNodes = int64(len(reachable))
+
+	isGarbage := func(key []byte) bool {
+		// nodes and orphan records both end with the node hash
+		_, ok := reachable[string(key[len(key)-iavlHashSize:])]
+		return !ok
+	}
+	stats.OrphanedNodes, err = sweep(db, nodeKeyPrefix, isGarbage, dryRun, &stats.Bytes)
+	if err != nil {
+		return stats, err
+	}
+	stats.StaleOrphans, err = sweep(db, orphanKeyPrefix, isGarbage, dryRun, &stats.Bytes)
+	return stats, err
+}
+
+// readRoots returns the root hashes of the versions of the tree by version. The
+// root of an empty version is nil.
+func readRoots(db dbm.DB) (map[int64][]byte, error) {
+	itr, err := dbm.IteratePrefix(db, []byte{rootKeyPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	roots := make(map[int64][]byte)
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()
+		if len(key) != 9 {
+			return nil, fmt.Errorf("invalid root key %X", key)
+		}
+		roots[int64(binary.BigEndian.Uint64(key[1:]))] = append([]byte(nil), itr.Value()...)
+	}
+	return roots, itr.Error()
+}
+
+// markReachable returns the hashes of the nodes of the given roots.
+func markReachable(db dbm.DB, roots map[int64][]byte) (map[string]struct{}, error) {
+	reachable := make(map[string]struct{})
+	nodeKey := func(hash []byte) []byte {
+		return append([]byte{nodeKeyPrefix}, hash...)
+	}
+	for version, root := range roots {
+		if len(root) == 0 {
+			continue
+		}
+		stack := [][]byte{root}
+		for len(stack) > 0 {
+			hash := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			// the subtree of a node is reachable as soon as the node is, as
+			// the hash of a node covers its children
+			if _, ok := reachable[string(hash)]; ok {
+				continue
+			}
+			bz, err := db.Get(nodeKey(hash))
+			if err != nil {
+				return nil, err
+			}
+			if bz == nil {
+				return nil, fmt.Errorf("node %X of version %d is missing", hash, version)
+			}
+			node, err := iavl.MakeNode(bz)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode node %X of version %d: %w", hash, version, err)
+			}
+			reachable[string(hash)] = struct{}{}
+			if node.GetHeight() > 0 {
+				stack = append(stack, node.GetLeftHash(), node.GetRightHash())
+			}
+		}
+	}
+	return reachable, nil
+}
+
+// sweep deletes the keys with prefix for which isGarbage returns true, unless
+// dryRun is set, and returns their number. The size of their keys and values is
+// added to bytes.
+func sweep(db dbm.DB, prefix byte, isGarbage func(key []byte) bool, dryRun bool, bytes *int64) (int64, error) {
+	var swept int64
+	start, end := []byte{prefix}, []byte{prefix + 1}
+	for {
+		// the keys are deleted once the iterator is closed, as not every
+		// backend allows writing while iterating
+		garbage, next, err := scanGarbage(db, start, end, isGarbage, bytes)
+		if err != nil {
+			return swept, err
+		}
+		swept += int64(len(garbage))
+		if !dryRun && len(garbage) > 0 {
+			if err := deleteKeys(db, garbage); err != nil {
+				return swept, err
+			}
+		}
+		if next == nil {
+			return swept, nil
+		}
+		start = next
+	}
+}
+
+// scanGarbage returns up to gcBatchSize keys of [start, end) for which
+// isGarbage returns true, and the key to resume the scan from, which is nil
+// once the range is exhausted.
+func scanGarbage(db dbm.DB, start, end []byte, isGarbage func(key []byte) bool, bytes *int64) (garbage [][]byte, next []byte, err error) {
+	itr, err := db.Iterator(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()
+		if len(key) <= iavlHashSize {
+			return nil, nil, fmt.Errorf("invalid key %X", key)
+		}
+		if !isGarbage(key) {
+			continue
+		}
+		garbage = append(garbage, append([]byte(nil), key...))
+		*bytes += int64(len(key) + len(itr.Value()))
+		if len(garbage) == gcBatchSize {
+			return garbage, append(append([]byte(nil), key...), 0), itr.Error()
+		}
+	}
+	return garbage, nil, itr.Error()
+}
+
+func deleteKeys(db dbm.DB, keys [][]byte) error {
+	batch := db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
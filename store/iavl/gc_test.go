@@ -0,0 +1,132 @@
+package iavl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func countPrefix(t *testing.T, db dbm.DB, prefix byte) int {
+	itr, err := dbm.IteratePrefix(db, []byte{prefix})
+	require.NoError(t, err)
+	defer itr.Close()
+	var count int
+	for ; itr.Valid(); itr.Next() {
+		count++
+	}
+	return count
+}
+
+// newGarbageTree saves 3 versions of a tree, then deletes the root of version 1
+// without deleting its nodes, like an interrupted pruning.
+func newGarbageTree(t *testing.T) dbm.DB {
+	db := dbm.NewMemDB()
+	tree, err := iavl.NewMutableTree(db, 100, false)
+	require.NoError(t, err)
+	for version := 1; version <= 3; version++ {
+		for i := 0; i < 20; i++ {
+			tree.Set([]byte(fmt.Sprintf("key%02d", i)), []byte(fmt.Sprintf("value%d-%d", i, version)))
+		}
+		_, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	rootKey := make([]byte, 9)
+	rootKey[0] = rootKeyPrefix
+	binary.BigEndian.PutUint64(rootKey[1:], 1)
+	require.NoError(t, db.Delete(rootKey))
+	return db
+}
+
+func TestCollectGarbage(t *testing.T) {
+	db := newGarbageTree(t)
+	nodes, orphans := countPrefix(t, db, nodeKeyPrefix), countPrefix(t, db, orphanKeyPrefix)
+
+	ok, err := HasVersion(db, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = HasVersion(db, 3)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a dry run only counts the garbage
+	stats, err := CollectGarbage(db, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Versions)
+	require.Positive(t, stats.OrphanedNodes)
+	require.Positive(t, stats.StaleOrphans)
+	require.Positive(t, stats.Bytes)
+	require.Equal(t, int64(nodes), stats.ReachableNodes+stats.OrphanedNodes)
+	require.Equal(t, nodes, countPrefix(t, db, nodeKeyPrefix))
+
+	collected, err := CollectGarbage(db, false)
+	require.NoError(t, err)
+	require.Equal(t, stats, collected)
+	require.Equal(t, int(stats.ReachableNodes), countPrefix(t, db, nodeKeyPrefix))
+	require.Equal(t, orphans-int(stats.StaleOrphans), countPrefix(t, db, orphanKeyPrefix))
+
+	// the remaining versions are intact and there is no garbage left
+	tree, err := iavl.NewMutableTree(db, 100, false)
+	require.NoError(t, err)
+	_, err = tree.LoadVersion(3)
+	require.NoError(t, err)
+	for _, version := range []int64{2, 3} {
+		value, err := tree.GetVersioned([]byte("key07"), version)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value7-%d", version)), value)
+	}
+	stats, err = CollectGarbage(db, false)
+	require.NoError(t, err)
+	require.Zero(t, stats.OrphanedNodes)
+	require.Zero(t, stats.StaleOrphans)
+
+	// the orphans of the remaining versions are still pruned
+	require.NoError(t, tree.DeleteVersion(2))
+	stats, err = CollectGarbage(db, true)
+	require.NoError(t, err)
+	require.Zero(t, stats.OrphanedNodes)
+}
+
+func TestCollectGarbageBatches(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, err := iavl.NewMutableTree(db, 100, false)
+	require.NoError(t, err)
+	tree.Set([]byte("key"), []byte("value"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	// more unreachable nodes than are deleted per batch
+	for i := 0; i < gcBatchSize+5; i++ {
+		hash := make([]byte, iavlHashSize)
+		binary.BigEndian.PutUint64(hash, uint64(i))
+		require.NoError(t, db.Set(append([]byte{nodeKeyPrefix}, hash...), []byte("garbage")))
+	}
+	stats, err := CollectGarbage(db, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(gcBatchSize+5), stats.OrphanedNodes)
+	require.Equal(t, 1, countPrefix(t, db, nodeKeyPrefix))
+}
+
+func TestCollectGarbageMissingNode(t *testing.T) {
+	db := newGarbageTree(t)
+	roots, err := readRoots(db)
+	require.NoError(t, err)
+	require.NoError(t, db.Delete(append([]byte{nodeKeyPrefix}, roots[3]...)))
+	nodes := countPrefix(t, db, nodeKeyPrefix)
+
+	// nothing is deleted from a corrupted tree
+	_, err = CollectGarbage(db, false)
+	require.ErrorContains(t, err, "of version 3 is missing")
+	require.Equal(t, nodes, countPrefix(t, db, nodeKeyPrefix))
+
+	// nor from a store without versions
+	raw := dbm.NewMemDB()
+	require.NoError(t, raw.Set([]byte("n-raw-key"), []byte("value")))
+	stats, err := CollectGarbage(raw, false)
+	require.NoError(t, err)
+	require.Zero(t, stats.Versions)
+	require.Equal(t, 1, countPrefix(t, raw, nodeKeyPrefix))
+}
@@ -0,0 +1,73 @@
+package iavl
+
+import (
+	"sync/atomic"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// The store operations counted by storeMetrics.
+const (
+	opRead = iota
+	opWrite
+	opDelete
+	opIterate
+	numOps
+)
+
+var opNames = [numOps]string{"read", "write", "delete", "iterate"}
+
+// storeMetrics accumulates the number and the total duration of the operations
+// on a store between two commits. They are emitted at commit, labelled with the
+// store name, so that the cost of a block can be broken down per module store.
+type storeMetrics struct {
+	labels [numOps][]metrics.Label
+
+	counts    [numOps]int64
+	durations [numOps]int64 // nanoseconds
+}
+
+func newStoreMetrics(storeName string) *storeMetrics {
+	m := &storeMetrics{}
+	for op, name := range opNames {
+		m.labels[op] = []metrics.Label{
+			telemetry.NewLabel("store_name", storeName),
+			telemetry.NewLabel("operation", name),
+		}
+	}
+	return m
+}
+
+// record adds an operation started at start. It is safe to call concurrently
+// and a no-op on a nil storeMetrics, as used by the stores of past versions.
+func (m *storeMetrics) record(op int, start time.Time) {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.counts[op], 1)
+	atomic.AddInt64(&m.durations[op], int64(time.Since(start)))
+}
+
+// emit reports the operations recorded since the previous emit as the
+// operations of the committed block and resets them:
+//   - store_iavl_block_ops: the number of operations of the block
+//   - store_iavl_block_ops_time: a histogram of the time in milliseconds the
+//     operations took in each block
+//   - store_iavl_ops: a counter of all the operations
+func (m *storeMetrics) emit() {
+	if m == nil {
+		return
+	}
+	for op := range opNames {
+		count := atomic.SwapInt64(&m.counts[op], 0)
+		duration := time.Duration(atomic.SwapInt64(&m.durations[op], 0))
+		telemetry.SetGaugeWithLabels([]string{"store", "iavl", "block_ops"}, float32(count), m.labels[op])
+		telemetry.AddSampleWithLabels([]string{"store", "iavl", "block_ops_time"}, float32(duration.Seconds()*1000), m.labels[op])
+		if count > 0 {
+			telemetry.IncrCounterWithLabels([]string{"store", "iavl", "ops"}, float32(count), m.labels[op])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package iavl
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func opCounts(m *storeMetrics) [numOps]int64 {
+	var counts [numOps]int64
+	for op := range counts {
+		counts[op] = atomic.LoadInt64(&m.counts[op])
+	}
+	return counts
+}
+
+func TestStoreMetrics(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, sink)
+	require.NoError(t, err)
+	defer metrics.NewGlobal(cfg, &metrics.BlackholeSink{}) //nolint:errcheck
+
+	cs, err := LoadStore(dbm.NewMemDB(), log.NewNopLogger(), types.NewKVStoreKey("bank"), types.CommitID{}, false, DefaultIAVLCacheSize, false, nil)
+	require.NoError(t, err)
+	st := cs.(*Store)
+
+	st.Set([]byte("a"), []byte("1"))
+	st.Set([]byte("b"), []byte("2"))
+	require.Equal(t, []byte("1"), st.Get([]byte("a")))
+	require.True(t, st.Has([]byte("b")))
+	st.Delete([]byte("b"))
+	st.Iterator(nil, nil).Close()
+	require.Equal(t, [numOps]int64{opRead: 2, opWrite: 2, opDelete: 1, opIterate: 1}, opCounts(st.metrics))
+
+	// the operations are emitted per store at commit
+	st.Commit(true)
+	require.Equal(t, [numOps]int64{}, opCounts(st.metrics))
+
+	data := sink.Data()
+	require.NotEmpty(t, data)
+	gauges, counters, samples := data[0].Gauges, data[0].Counters, data[0].Samples
+	for op, count := range map[string]float32{"read": 2, "write": 2, "delete": 1, "iterate": 1} {
+		key := "store.iavl.block_ops;store_name=bank;operation=" + op
+		require.Equal(t, count, gauges[key].Value, key)
+		require.Equal(t, float64(count), counters["store.iavl.ops;store_name=bank;operation="+op].Sum)
+		require.Equal(t, 1, samples["store.iavl.block_ops_time;store_name=bank;operation="+op].Count)
+	}
+
+	// a block without operations resets the gauges
+	st.Commit(true)
+	require.Equal(t, float32(0), sink.Data()[0].Gauges["store.iavl.block_ops;store_name=bank;operation=read"].Value)
+}
+
+func TestStoreMetricsOfPastVersions(t *testing.T) {
+	cs, err := LoadStore(dbm.NewMemDB(), log.NewNopLogger(), types.NewKVStoreKey("bank"), types.CommitID{}, false, DefaultIAVLCacheSize, false, nil)
+	require.NoError(t, err)
+	st := cs.(*Store)
+	st.Set([]byte("a"), []byte("1"))
+	st.Commit(true)
+
+	// reads of the stores of past versions, served to queries, are not
+	// counted as operations of a block
+	immutable, err := st.GetImmutable(1)
+	require.NoError(t, err)
+	require.Nil(t, immutable.metrics)
+	require.Equal(t, []byte("1"), immutable.Get([]byte("a")))
+	require.Equal(t, [numOps]int64{}, opCounts(st.metrics))
+}
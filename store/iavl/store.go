@@ -39,6 +39,7 @@ var (
 type Store struct {
 	tree    Tree
 	treeMtx *sync.RWMutex
+	metrics *storeMetrics
 }
 
 // LoadStore returns an IAVL Store as a CommitKVStore. Internally, it will load the
@@ -99,6 +100,7 @@ func LoadStoreWithInitialVersion(db dbm.DB, logger log.Logger, key types.StoreKe
 	return &Store{
 		tree:    tree,
 		treeMtx: &sync.RWMutex{},
+		metrics: newStoreMetrics(key.Name()),
 	}, nil
 }
 
@@ -154,6 +156,7 @@ func (st *Store) Commit(bumpVersion bool) types.CommitID {
 	st.treeMtx.Lock()
 	defer st.treeMtx.Unlock()
 	defer telemetry.MeasureSince(time.Now(), "store", "iavl", "commit")
+	defer st.metrics.emit()
 
 	var hash []byte
 	var version int64
@@ -232,12 +235,15 @@ func (st *Store) CacheWrapWithListeners(storeKey types.StoreKey, listeners []typ
 func (st *Store) Set(key, value []byte) {
 	types.AssertValidKey(key)
 	types.AssertValidValue(value)
+	defer st.metrics.record(opWrite, time.Now())
 	st.tree.Set(key, value)
 }
 
 // Implements types.KVStore.
 func (st *Store) Get(key []byte) []byte {
-	defer telemetry.MeasureSince(time.Now(), "store", "iavl", "get")
+	start := time.Now()
+	defer telemetry.MeasureSince(start, "store", "iavl", "get")
+	defer st.metrics.record(opRead, start)
 	value, err := st.tree.Get(key)
 	if err != nil {
 		panic(err)
@@ -247,7 +253,9 @@ func (st *Store) Get(key []byte) []byte {
 
 // Implements types.KVStore.
 func (st *Store) Has(key []byte) (exists bool) {
-	defer telemetry.MeasureSince(time.Now(), "store", "iavl", "has")
+	start := time.Now()
+	defer telemetry.MeasureSince(start, "store", "iavl", "has")
+	defer st.metrics.record(opRead, start)
 	has, err := st.tree.Has(key)
 	if err != nil {
 		panic(err)
@@ -257,7 +265,9 @@ func (st *Store) Has(key []byte) (exists bool) {
 
 // Implements types.KVStore.
 func (st *Store) Delete(key []byte) {
-	defer telemetry.MeasureSince(time.Now(), "store", "iavl", "delete")
+	start := time.Now()
+	defer telemetry.MeasureSince(start, "store", "iavl", "delete")
+	defer st.metrics.record(opDelete, start)
 	st.tree.Remove(key)
 }
 
@@ -276,6 +286,7 @@ func (st *Store) LoadVersionForOverwriting(targetVersion int64) (int64, error) {
 
 // Implements types.KVStore.
 func (st *Store) Iterator(start, end []byte) types.Iterator {
+	defer st.metrics.record(opIterate, time.Now())
 	iterator, err := st.tree.Iterator(start, end, true)
 	if err != nil {
 		if iterator != nil {
@@ -288,6 +299,7 @@ func (st *Store) Iterator(start, end []byte) types.Iterator {
 
 // Implements types.KVStore.
 func (st *Store) ReverseIterator(start, end []byte) types.Iterator {
+	defer st.metrics.record(opIterate, time.Now())
 	iterator, err := st.tree.Iterator(start, end, false)
 	if err != nil {
 		if iterator != nil {
@@ -0,0 +1,34 @@
+package multiversion
+
+import (
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// WriteAll commits the writesets of every multi-version store of a block to
+// their parent stores. The writesets are all collected before anything is
+// written, so an error, for instance a transaction that was left invalidated,
+// leaves every parent store untouched.
+func WriteAll(stores map[types.StoreKey]MultiVersionStore) error {
+	keys := make([]types.StoreKey, 0, len(stores))
+	for key := range stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+
+	writesets := make([]map[int]WriteSet, len(keys))
+	for i, key := range keys {
+		ws, err := stores[key].FinalWritesets()
+		if err != nil {
+			return err
+		}
+		writesets[i] = ws
+	}
+	for i, key := range keys {
+		stores[key].CommitWritesets(writesets[i])
+	}
+	return nil
+}
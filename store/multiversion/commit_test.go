@@ -0,0 +1,92 @@
+package multiversion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// recordingStore records the writes made to the store it wraps.
+type recordingStore struct {
+	types.KVStore
+	writes []string
+}
+
+func (rs *recordingStore) Set(key, value []byte) {
+	rs.writes = append(rs.writes, "set "+string(key)+"="+string(value))
+	rs.KVStore.Set(key, value)
+}
+
+func (rs *recordingStore) Delete(key []byte) {
+	rs.writes = append(rs.writes, "delete "+string(key))
+	rs.KVStore.Delete(key)
+}
+
+func TestMultiVersionStoreFinalWritesets(t *testing.T) {
+	mvs := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	mvs.SetWriteset(1, 1, map[string][]byte{"key1": []byte("value1"), "key2": nil})
+	mvs.SetWriteset(2, 1, map[string][]byte{"key1": []byte("value2")})
+
+	writesets, err := mvs.FinalWritesets()
+	require.NoError(t, err)
+	require.Equal(t, map[int]multiversion.WriteSet{
+		1: {"key1": []byte("value1"), "key2": nil},
+		2: {"key1": []byte("value2")},
+	}, writesets)
+
+	// an invalidated tx has no final writeset yet
+	mvs.InvalidateWriteset(2, 1)
+	_, err = mvs.FinalWritesets()
+	require.Error(t, err)
+}
+
+func TestMultiVersionStoreCommitWritesets(t *testing.T) {
+	parent := &recordingStore{KVStore: dbadapter.Store{DB: dbm.NewMemDB()}}
+	mvs := multiversion.NewMultiVersionStore(parent, testStoreKey)
+
+	mvs.SetWriteset(2, 1, map[string][]byte{"key1": []byte("value3"), "key0": []byte("value2")})
+	mvs.SetWriteset(1, 1, map[string][]byte{"key2": nil, "key1": []byte("value1")})
+
+	writesets, err := mvs.FinalWritesets()
+	require.NoError(t, err)
+	mvs.CommitWritesets(writesets)
+
+	// the writes are replayed tx by tx, as a sequential execution would make them
+	require.Equal(t, []string{
+		"set key1=value1",
+		"delete key2",
+		"set key0=value2",
+		"set key1=value3",
+	}, parent.writes)
+	require.Equal(t, []byte("value3"), parent.Get([]byte("key1")))
+}
+
+func TestWriteAll(t *testing.T) {
+	parent1 := dbadapter.Store{DB: dbm.NewMemDB()}
+	parent2 := dbadapter.Store{DB: dbm.NewMemDB()}
+	key1, key2 := types.NewKVStoreKey("store1"), types.NewKVStoreKey("store2")
+	stores := map[types.StoreKey]multiversion.MultiVersionStore{
+		key1: multiversion.NewMultiVersionStore(parent1, key1),
+		key2: multiversion.NewMultiVersionStore(parent2, key2),
+	}
+
+	stores[key1].SetWriteset(0, 1, map[string][]byte{"key1": []byte("value1")})
+	stores[key2].SetWriteset(1, 1, map[string][]byte{"key2": []byte("value2")})
+	stores[key2].InvalidateWriteset(1, 1)
+
+	// a single tx left invalidated prevents every store from being written
+	require.Error(t, multiversion.WriteAll(stores))
+	require.Nil(t, parent1.Get([]byte("key1")))
+	require.Nil(t, parent2.Get([]byte("key2")))
+
+	stores[key2].SetWriteset(1, 2, map[string][]byte{"key2": []byte("value2")})
+	require.NoError(t, multiversion.WriteAll(stores))
+	require.Equal(t, []byte("value1"), parent1.Get([]byte("key1")))
+	require.Equal(t, []byte("value2"), parent2.Get([]byte("key2")))
+}
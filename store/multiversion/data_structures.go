@@ -0,0 +1,211 @@
+package multiversion
+
+import (
+	"sync"
+
+	"github.com/google/btree"
+)
+
+const (
+	// The approximate number of items and children per B-tree node. Tuned with benchmarks.
+	multiVersionBTreeDegree = 2 // should be equivalent to a binary search tree
+)
+
+// MultiVersionValue holds every version of a single key written during a block,
+// ordered by the index of the transaction that wrote it.
+type MultiVersionValue interface {
+	GetLatest() (value MultiVersionValueItem, found bool)
+	GetLatestBeforeIndex(index int) (value MultiVersionValueItem, found bool)
+	Set(index int, incarnation int, value []byte)
+	SetEstimate(index int, incarnation int)
+	Delete(index int, incarnation int)
+	Remove(index int)
+	RemoveBefore(index int) (value MultiVersionValueItem, found bool)
+	Size() (versions int, valueBytes int)
+}
+
+// MultiVersionValueItem is a single version of a key, as written by the
+// transaction at Index() during its Incarnation().
+type MultiVersionValueItem interface {
+	IsDeleted() bool
+	IsEstimate() bool
+	Value() []byte
+	Incarnation() int
+	Index() int
+}
+
+type multiVersionItem struct {
+	valueTree *btree.BTreeG[*valueItem] // contains versions values written to this key
+	mtx       sync.RWMutex              // manages read + write accesses
+}
+
+var _ MultiVersionValue = (*multiVersionItem)(nil)
+
+// NewMultiVersionItem returns a new, empty MultiVersionValue.
+func NewMultiVersionItem() *multiVersionItem {
+	return &multiVersionItem{
+		valueTree: btree.NewG(multiVersionBTreeDegree, func(a, b *valueItem) bool {
+			return a.index < b.index
+		}),
+	}
+}
+
+// GetLatest returns the latest written value to the btree, and returns a boolean indicating whether it was found.
+func (item *multiVersionItem) GetLatest() (MultiVersionValueItem, bool) {
+	item.mtx.RLock()
+	defer item.mtx.RUnlock()
+
+	valueItem, found := item.valueTree.Max()
+	if !found {
+		return nil, false
+	}
+	return valueItem, true
+}
+
+// GetLatestBeforeIndex returns the latest written value to the btree prior to the index passed in.
+func (item *multiVersionItem) GetLatestBeforeIndex(index int) (MultiVersionValueItem, bool) {
+	item.mtx.RLock()
+	defer item.mtx.RUnlock()
+
+	// we want to find the value at the index that is LESS than the current index
+	pivot := &valueItem{index: index - 1}
+
+	var vItem *valueItem
+	var found bool
+	// start from pivot which contains our current index, and return on first item we hit.
+	// This will ensure we get the latest indexed value relative to our current index
+	item.valueTree.DescendLessOrEqual(pivot, func(bTreeItem *valueItem) bool {
+		vItem = bTreeItem
+		found = true
+		return false
+	})
+	if !found {
+		return nil, false
+	}
+	return vItem, true
+}
+
+// Set records value as written by the transaction at index during incarnation.
+func (item *multiVersionItem) Set(index int, incarnation int, value []byte) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	item.valueTree.ReplaceOrInsert(newValueItem(index, incarnation, value))
+}
+
+// SetEstimate marks the version written by the transaction at index as an
+// ESTIMATE: the transaction is being re-executed and will likely write this
+// key again, so readers must wait for it rather than consume a stale value.
+func (item *multiVersionItem) SetEstimate(index int, incarnation int) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	item.valueTree.ReplaceOrInsert(newEstimatedItem(index, incarnation))
+}
+
+// Delete records a deletion of the key by the transaction at index during incarnation.
+func (item *multiVersionItem) Delete(index int, incarnation int) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	item.valueTree.ReplaceOrInsert(newDeletedItem(index, incarnation))
+}
+
+// Remove drops any version written by the transaction at index.
+func (item *multiVersionItem) Remove(index int) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	item.valueTree.Delete(&valueItem{index: index})
+}
+
+// RemoveBefore drops every version written by a transaction below index and
+// returns the latest of them.
+func (item *multiVersionItem) RemoveBefore(index int) (MultiVersionValueItem, bool) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	var latest *valueItem
+	for {
+		min, found := item.valueTree.Min()
+		if !found || min.index >= index {
+			break
+		}
+		latest, _ = item.valueTree.DeleteMin()
+	}
+	if latest == nil {
+		return nil, false
+	}
+	return latest, true
+}
+
+// Size returns the number of versions of the key and the bytes of their values.
+func (item *multiVersionItem) Size() (versions int, valueBytes int) {
+	item.mtx.RLock()
+	defer item.mtx.RUnlock()
+
+	item.valueTree.Ascend(func(v *valueItem) bool {
+		valueBytes += len(v.value)
+		return true
+	})
+	return item.valueTree.Len(), valueBytes
+}
+
+type valueItem struct {
+	index       int
+	incarnation int
+	value       []byte
+	estimate    bool
+	deleted     bool
+}
+
+var _ MultiVersionValueItem = (*valueItem)(nil)
+
+// Index implements MultiVersionValueItem.
+func (v *valueItem) Index() int {
+	return v.index
+}
+
+// Incarnation implements MultiVersionValueItem.
+func (v *valueItem) Incarnation() int {
+	return v.incarnation
+}
+
+// IsDeleted implements MultiVersionValueItem.
+func (v *valueItem) IsDeleted() bool {
+	return v.deleted
+}
+
+// IsEstimate implements MultiVersionValueItem.
+func (v *valueItem) IsEstimate() bool {
+	return v.estimate
+}
+
+// Value implements MultiVersionValueItem.
+func (v *valueItem) Value() []byte {
+	return v.value
+}
+
+func newValueItem(index int, incarnation int, value []byte) *valueItem {
+	return &valueItem{
+		index:       index,
+		incarnation: incarnation,
+		value:       value,
+	}
+}
+
+func newDeletedItem(index int, incarnation int) *valueItem {
+	return &valueItem{
+		index:       index,
+		incarnation: incarnation,
+		deleted:     true,
+	}
+}
+
+func newEstimatedItem(index int, incarnation int) *valueItem {
+	return &valueItem{
+		index:       index,
+		incarnation: incarnation,
+		estimate:    true,
+	}
+}
@@ -0,0 +1,47 @@
+package multiversion
+
+import (
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// memIterator iterates over the keys visible to a transaction that are not
+// served by the parent store. Deleted keys are reported with a nil value so
+// that the merge iterator skips them in the parent.
+// Implements Iterator.
+type memIterator struct {
+	types.Iterator
+
+	deleted map[string]struct{}
+}
+
+func newMemIterator(start, end []byte, items *dbm.MemDB, deleted map[string]struct{}, ascending bool) *memIterator {
+	var iter types.Iterator
+	var err error
+
+	if ascending {
+		iter, err = items.Iterator(start, end)
+	} else {
+		iter, err = items.ReverseIterator(start, end)
+	}
+
+	if err != nil {
+		if iter != nil {
+			iter.Close()
+		}
+		panic(err)
+	}
+
+	return &memIterator{
+		Iterator: iter,
+		deleted:  deleted,
+	}
+}
+
+func (mi *memIterator) Value() []byte {
+	if _, ok := mi.deleted[string(mi.Iterator.Key())]; ok {
+		return nil
+	}
+	return mi.Iterator.Value()
+}
@@ -0,0 +1,320 @@
+package multiversion
+
+import (
+	"io"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// VersionIndexedStore is a KVStore for a single incarnation of a single
+// transaction. Writes are buffered in a local writeset, and reads resolve, in
+// order, against the writeset, the versions written by lower-indexed
+// transactions in the multi-version store, and finally the parent store.
+//
+// The store does not charge gas itself: a transaction reaches it through
+// ctx.KVStore, which wraps it in a gaskv store like any other KVStore. Since
+// it returns the values the sequential execution would read, the gas consumed
+// is the same.
+type VersionIndexedStore struct {
+	mtx sync.Mutex
+	// contains the key -> value mapping for all keys written to the store, a nil value is a delete
+	writeset WriteSet
+	// contains the key -> value mapping for all keys read from the multiversion or parent store, a nil value means the key was absent
+	readset ReadSet
+	// contains the ranges iterated over, with the multiversion store values observed in them
+	iterateset IterateSet
+	// parent stores (both multiversion and underlying parent store)
+	multiVersionStore MultiVersionStore
+	parent            types.KVStore
+	// transaction metadata for versioned operations
+	transactionIndex int
+	incarnation      int
+	// signals that a read hit an ESTIMATE and the incarnation must be aborted
+	abortChannel chan occ.Abort
+	// interrupt, if set, aborts the incarnation on its next access to the store
+	interrupt *occ.Abort
+
+	eventManager *sdktypes.EventManager
+	storeKey     types.StoreKey
+}
+
+var _ types.KVStore = (*VersionIndexedStore)(nil)
+
+// NewVersionIndexedStore returns a store for the transaction at index during
+// the given incarnation.
+func NewVersionIndexedStore(parent types.KVStore, multiVersionStore MultiVersionStore, storeKey types.StoreKey, transactionIndex, incarnation int, abortChannel chan occ.Abort) *VersionIndexedStore {
+	return &VersionIndexedStore{
+		writeset:          make(WriteSet),
+		readset:           make(ReadSet),
+		multiVersionStore: multiVersionStore,
+		parent:            parent,
+		transactionIndex:  transactionIndex,
+		incarnation:       incarnation,
+		abortChannel:      abortChannel,
+		eventManager:      sdktypes.NewEventManager(),
+		storeKey:          storeKey,
+	}
+}
+
+// Get implements types.KVStore.
+func (store *VersionIndexedStore) Get(key []byte) []byte {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.checkInterrupt()
+	types.AssertValidKey(key)
+	return store.get(key)
+}
+
+// get resolves a key without acquiring the store lock. Values that do not come
+// from the local writeset are recorded in the readset.
+func (store *VersionIndexedStore) get(key []byte) []byte {
+	// first check the local writeset, and return that value if present
+	if value, ok := store.writeset[string(key)]; ok {
+		return value
+	}
+	// then the latest value written by a lower-indexed transaction
+	if mvsValue := store.multiVersionStore.GetLatestBeforeIndex(store.transactionIndex, key); mvsValue != nil {
+		if mvsValue.IsEstimate() {
+			store.abortOnEstimate(mvsValue.Index())
+		}
+		var value []byte
+		if !mvsValue.IsDeleted() {
+			value = mvsValue.Value()
+		}
+		store.readset[string(key)] = value
+		return value
+	}
+	// nothing written earlier in the block, read from the parent store
+	value := store.parent.Get(key)
+	store.readset[string(key)] = value
+	return value
+}
+
+// Has implements types.KVStore.
+func (store *VersionIndexedStore) Has(key []byte) bool {
+	return store.Get(key) != nil
+}
+
+// Set implements types.KVStore.
+func (store *VersionIndexedStore) Set(key []byte, value []byte) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.checkInterrupt()
+	types.AssertValidKey(key)
+	types.AssertValidValue(value)
+	store.writeset[string(key)] = value
+}
+
+// Delete implements types.KVStore.
+func (store *VersionIndexedStore) Delete(key []byte) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.checkInterrupt()
+	types.AssertValidKey(key)
+	store.writeset[string(key)] = nil
+}
+
+// Iterator implements types.KVStore.
+func (store *VersionIndexedStore) Iterator(start, end []byte) types.Iterator {
+	return store.iterator(start, end, true)
+}
+
+// ReverseIterator implements types.KVStore.
+func (store *VersionIndexedStore) ReverseIterator(start, end []byte) types.Iterator {
+	return store.iterator(start, end, false)
+}
+
+// iterator merges the parent store with the versions visible to this
+// transaction: writes from lower-indexed transactions overlaid with the local
+// writeset.
+func (store *VersionIndexedStore) iterator(start, end []byte, ascending bool) types.Iterator {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.checkInterrupt()
+	items := dbm.NewMemDB()
+	deleted := make(map[string]struct{})
+	setItem := func(key string, value []byte, isDeleted bool) {
+		if isDeleted {
+			value = []byte{}
+			deleted[key] = struct{}{}
+		} else {
+			delete(deleted, key)
+		}
+		if err := items.Set([]byte(key), value); err != nil {
+			panic(err)
+		}
+	}
+	observed := make(map[string][]byte)
+	for key, mvValue := range store.multiVersionStore.GetLatestBeforeIndexInDomain(store.transactionIndex, start, end) {
+		if mvValue.IsEstimate() {
+			store.abortOnEstimate(mvValue.Index())
+		}
+		setItem(key, mvValue.Value(), mvValue.IsDeleted())
+		if mvValue.IsDeleted() {
+			observed[key] = nil
+		} else {
+			observed[key] = mvValue.Value()
+		}
+	}
+	store.iterateset = append(store.iterateset, Iteration{Start: start, End: end, Observed: observed})
+	for key, value := range store.writeset {
+		if dbm.IsKeyInDomain([]byte(key), start, end) {
+			setItem(key, value, value == nil)
+		}
+	}
+
+	var parent types.Iterator
+	if ascending {
+		parent = store.parent.Iterator(start, end)
+	} else {
+		parent = store.parent.ReverseIterator(start, end)
+	}
+	cache := newMemIterator(start, end, items, deleted, ascending)
+	return cachekv.NewCacheMergeIterator(parent, cache, ascending, store.eventManager, store.storeKey)
+}
+
+// GetStoreType implements types.KVStore.
+func (store *VersionIndexedStore) GetStoreType() types.StoreType {
+	return store.parent.GetStoreType()
+}
+
+// GetWorkingHash implements types.KVStore.
+func (store *VersionIndexedStore) GetWorkingHash() ([]byte, error) {
+	panic("should never attempt to get working hash from version indexed store")
+}
+
+// CacheWrap implements types.KVStore.
+func (store *VersionIndexedStore) CacheWrap(storeKey types.StoreKey) types.CacheWrap {
+	return cachekv.NewStore(store, storeKey, types.DefaultCacheSizeLimit)
+}
+
+// CacheWrapWithTrace implements types.KVStore.
+func (store *VersionIndexedStore) CacheWrapWithTrace(storeKey types.StoreKey, w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(store, storeKey, types.DefaultCacheSizeLimit)
+}
+
+// CacheWrapWithListeners implements types.KVStore.
+func (store *VersionIndexedStore) CacheWrapWithListeners(storeKey types.StoreKey, listeners []types.WriteListener) types.CacheWrap {
+	return cachekv.NewStore(store, storeKey, types.DefaultCacheSizeLimit)
+}
+
+// GetEvents implements types.CacheWrap.
+func (store *VersionIndexedStore) GetEvents() []abci.Event {
+	return store.eventManager.ABCIEvents()
+}
+
+// ResetEvents implements types.CacheWrap.
+func (store *VersionIndexedStore) ResetEvents() {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	store.eventManager = sdktypes.NewEventManager()
+}
+
+// Write implements types.CacheWrap. The underlying store of a
+// VersionIndexedStore is the multi-version store, so this is equivalent to
+// WriteToMultiVersionStore.
+func (store *VersionIndexedStore) Write() {
+	store.WriteToMultiVersionStore()
+}
+
+// WriteToMultiVersionStore publishes the writeset to the multi-version store
+// at this store's transaction index and incarnation, making the writes
+// visible to higher-indexed transactions.
+func (store *VersionIndexedStore) WriteToMultiVersionStore() {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.multiVersionStore.SetWriteset(store.transactionIndex, store.incarnation, store.writeset)
+}
+
+// WriteEstimatesToMultiVersionStore publishes the keys of the writeset as
+// ESTIMATEs. It is used when the incarnation aborts, so that higher-indexed
+// transactions reading those keys abort instead of consuming stale values.
+func (store *VersionIndexedStore) WriteEstimatesToMultiVersionStore() {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.multiVersionStore.SetEstimatedWriteset(store.transactionIndex, store.incarnation, store.writeset)
+}
+
+// WriteAbort pushes an abort onto the abort channel without blocking. A single
+// abort is enough for the scheduler to re-execute the incarnation, so extra
+// aborts are discarded when the channel is full.
+func (store *VersionIndexedStore) WriteAbort(abort occ.Abort) {
+	select {
+	case store.abortChannel <- abort:
+	default:
+	}
+}
+
+// abortOnEstimate signals an abort for a read of an ESTIMATE written by the
+// transaction at dependentTxIdx and stops execution of the incarnation.
+func (store *VersionIndexedStore) abortOnEstimate(dependentTxIdx int) {
+	abort := occ.NewEstimateAbort(dependentTxIdx)
+	store.WriteAbort(abort)
+	panic(abort)
+}
+
+// Interrupt makes the next access to the store abort the incarnation with
+// abort. It may be called from any goroutine, and cannot stop an incarnation
+// that no longer accesses the store.
+func (store *VersionIndexedStore) Interrupt(abort occ.Abort) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.interrupt = &abort
+}
+
+// checkInterrupt aborts the incarnation if it has been interrupted. The store
+// lock must be held.
+func (store *VersionIndexedStore) checkInterrupt() {
+	if store.interrupt != nil {
+		store.WriteAbort(*store.interrupt)
+		panic(*store.interrupt)
+	}
+}
+
+// GetWriteset returns the writes buffered by this store.
+func (store *VersionIndexedStore) GetWriteset() WriteSet {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	return store.writeset
+}
+
+// ResetWriteset discards the writes buffered by this store, as if the
+// transaction had not written anything.
+func (store *VersionIndexedStore) ResetWriteset() {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	store.writeset = make(WriteSet)
+}
+
+// GetIterateset returns the ranges iterated over through this store.
+func (store *VersionIndexedStore) GetIterateset() IterateSet {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	return store.iterateset
+}
+
+// GetReadset returns the values this store observed outside of its own
+// writeset, keyed by the key that was read.
+func (store *VersionIndexedStore) GetReadset() ReadSet {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	return store.readset
+}
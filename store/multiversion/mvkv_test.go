@@ -0,0 +1,280 @@
+package multiversion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/gaskv"
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+func TestVersionIndexedStoreGetters(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	parentKVStore := cachekv.NewStore(mem, types.NewKVStoreKey("mock"), 1000)
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	// mock a value in the parent store
+	parentKVStore.Set([]byte("key1"), []byte("value1"))
+
+	vis := mvs.VersionedIndexedStore(2, 1, make(chan occ.Abort, 1))
+
+	// should get value from parent store
+	require.Equal(t, []byte("value1"), vis.Get([]byte("key1")))
+	require.True(t, vis.Has([]byte("key1")))
+
+	// write to a lower index, it must shadow the parent store
+	mvs.SetWriteset(1, 1, map[string][]byte{"key1": []byte("value2")})
+	require.Equal(t, []byte("value2"), vis.Get([]byte("key1")))
+
+	// write to a higher index, it must not be visible
+	mvs.SetWriteset(3, 1, map[string][]byte{"key1": []byte("value3"), "key2": []byte("value3")})
+	require.Equal(t, []byte("value2"), vis.Get([]byte("key1")))
+	require.Nil(t, vis.Get([]byte("key2")))
+	require.False(t, vis.Has([]byte("key2")))
+
+	// a delete at a lower index hides the parent store value
+	mvs.SetWriteset(0, 1, map[string][]byte{"key4": nil})
+	parentKVStore.Set([]byte("key4"), []byte("value4"))
+	require.Nil(t, vis.Get([]byte("key4")))
+
+	// the local writeset has the highest priority
+	vis.Set([]byte("key1"), []byte("value5"))
+	require.Equal(t, []byte("value5"), vis.Get([]byte("key1")))
+	vis.Delete([]byte("key1"))
+	require.Nil(t, vis.Get([]byte("key1")))
+}
+
+func TestVersionIndexedStoreWrite(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	parentKVStore := cachekv.NewStore(mem, types.NewKVStoreKey("mock"), 1000)
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	vis := mvs.VersionedIndexedStore(1, 2, make(chan occ.Abort, 1))
+	vis.Set([]byte("key1"), []byte("value1"))
+	vis.Delete([]byte("key2"))
+
+	// nothing is visible to other transactions before the writeset is published
+	require.Nil(t, mvs.GetLatest([]byte("key1")))
+
+	vis.WriteToMultiVersionStore()
+
+	require.Equal(t, []byte("value1"), mvs.GetLatest([]byte("key1")).Value())
+	require.Equal(t, 1, mvs.GetLatest([]byte("key1")).Index())
+	require.Equal(t, 2, mvs.GetLatest([]byte("key1")).Incarnation())
+	require.True(t, mvs.GetLatest([]byte("key2")).IsDeleted())
+
+	// the parent store is untouched until the multiversion store is flushed
+	require.Nil(t, parentKVStore.Get([]byte("key1")))
+	mvs.WriteLatestToStore()
+	require.Equal(t, []byte("value1"), parentKVStore.Get([]byte("key1")))
+}
+
+func TestVersionIndexedStoreIterator(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	parentKVStore := cachekv.NewStore(mem, types.NewKVStoreKey("mock"), 1000)
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	parentKVStore.Set([]byte("key1"), []byte("parent1"))
+	parentKVStore.Set([]byte("key2"), []byte("parent2"))
+	parentKVStore.Set([]byte("key3"), []byte("parent3"))
+
+	mvs.SetWriteset(0, 1, map[string][]byte{
+		"key2": nil,
+		"key4": []byte("mvs4"),
+	})
+	// invisible to index 2
+	mvs.SetWriteset(3, 1, map[string][]byte{
+		"key5": []byte("mvs5"),
+	})
+
+	vis := mvs.VersionedIndexedStore(2, 1, make(chan occ.Abort, 1))
+	vis.Set([]byte("key3"), []byte("local3"))
+	vis.Set([]byte("key0"), []byte("local0"))
+	vis.Delete([]byte("key4"))
+
+	collect := func(iter types.Iterator) (keys, values []string) {
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, string(iter.Key()))
+			values = append(values, string(iter.Value()))
+		}
+		return keys, values
+	}
+
+	keys, values := collect(vis.Iterator(nil, nil))
+	require.Equal(t, []string{"key0", "key1", "key3"}, keys)
+	require.Equal(t, []string{"local0", "parent1", "local3"}, values)
+
+	keys, _ = collect(vis.ReverseIterator(nil, nil))
+	require.Equal(t, []string{"key3", "key1", "key0"}, keys)
+
+	keys, _ = collect(vis.Iterator([]byte("key1"), []byte("key3")))
+	require.Equal(t, []string{"key1"}, keys)
+
+	// every range is recorded with the versions of lower-indexed txs in it
+	iterateset := vis.GetIterateset()
+	require.Len(t, iterateset, 3)
+	require.Equal(t, map[string][]byte{"key2": nil, "key4": []byte("mvs4")}, iterateset[0].Observed)
+	require.Equal(t, multiversion.Iteration{
+		Start:    []byte("key1"),
+		End:      []byte("key3"),
+		Observed: map[string][]byte{"key2": nil},
+	}, iterateset[2])
+}
+
+func TestVersionIndexedStoreAbortOnEstimate(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	abortCh := make(chan occ.Abort, 1)
+	vis := mvs.VersionedIndexedStore(2, 1, abortCh)
+
+	mvs.SetEstimatedWriteset(1, 1, map[string][]byte{"key1": []byte("value1")})
+
+	// reading an estimate panics with the abort and signals it on the channel
+	require.PanicsWithValue(t, occ.NewEstimateAbort(1), func() {
+		vis.Get([]byte("key1"))
+	})
+	require.Equal(t, occ.NewEstimateAbort(1), <-abortCh)
+
+	// so does iterating over it
+	require.PanicsWithValue(t, occ.NewEstimateAbort(1), func() {
+		vis.Iterator(nil, nil)
+	})
+	require.Equal(t, occ.NewEstimateAbort(1), <-abortCh)
+
+	// an estimate at a higher index is not visible
+	mvs.SetEstimatedWriteset(3, 1, map[string][]byte{"key2": []byte("value2")})
+	require.Nil(t, vis.Get([]byte("key2")))
+}
+
+func TestVersionIndexedStoreInterrupt(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	abortCh := make(chan occ.Abort, 1)
+	vis := mvs.VersionedIndexedStore(1, 1, abortCh)
+
+	vis.Set([]byte("key1"), []byte("value1"))
+	vis.Interrupt(occ.NewTimeoutAbort())
+
+	// every access after the interrupt aborts the incarnation
+	require.PanicsWithValue(t, occ.NewTimeoutAbort(), func() {
+		vis.Get([]byte("key1"))
+	})
+	require.Equal(t, occ.NewTimeoutAbort(), <-abortCh)
+	require.PanicsWithValue(t, occ.NewTimeoutAbort(), func() {
+		vis.Set([]byte("key2"), []byte("value2"))
+	})
+	require.PanicsWithValue(t, occ.NewTimeoutAbort(), func() {
+		vis.Iterator(nil, nil)
+	})
+	require.Equal(t, multiversion.WriteSet{"key1": []byte("value1")}, vis.GetWriteset())
+}
+
+func TestVersionIndexedStoreWriteEstimates(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	vis := mvs.VersionedIndexedStore(1, 1, make(chan occ.Abort, 1))
+
+	vis.Set([]byte("key1"), []byte("value1"))
+	vis.Delete([]byte("key2"))
+	vis.WriteEstimatesToMultiVersionStore()
+
+	require.True(t, mvs.GetLatest([]byte("key1")).IsEstimate())
+	require.True(t, mvs.GetLatest([]byte("key2")).IsEstimate())
+}
+
+func TestVersionIndexedStoreReadset(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	vis := mvs.VersionedIndexedStore(2, 1, make(chan occ.Abort, 1))
+
+	parentKVStore.Set([]byte("key1"), []byte("value1"))
+	mvs.SetWriteset(1, 1, map[string][]byte{
+		"key2": []byte("value2"),
+		"key3": nil,
+	})
+
+	vis.Get([]byte("key1"))
+	vis.Get([]byte("key2"))
+	vis.Has([]byte("key3"))
+	vis.Get([]byte("key4"))
+	// reads served from the writeset are not recorded
+	vis.Set([]byte("key5"), []byte("value5"))
+	vis.Get([]byte("key5"))
+
+	require.Equal(t, multiversion.ReadSet{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+		"key3": nil,
+		"key4": nil,
+	}, vis.GetReadset())
+	require.Equal(t, multiversion.WriteSet{"key5": []byte("value5")}, vis.GetWriteset())
+	require.True(t, mvs.ValidateReadset(2, vis.GetReadset()))
+}
+
+func TestVersionIndexedStoreResetWriteset(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	vis := mvs.VersionedIndexedStore(1, 1, make(chan occ.Abort, 1))
+
+	vis.Set([]byte("key1"), []byte("value1"))
+	vis.Get([]byte("key2"))
+	vis.ResetWriteset()
+
+	require.Empty(t, vis.GetWriteset())
+	require.Equal(t, multiversion.ReadSet{"key2": nil}, vis.GetReadset())
+	require.Nil(t, vis.Get([]byte("key1")))
+}
+
+func TestVersionIndexedStoreGas(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	parentKVStore := cachekv.NewStore(mem, types.NewKVStoreKey("mock"), 1000)
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+	parentKVStore.Set([]byte("key1"), []byte("parent1"))
+	parentKVStore.Set([]byte("key2"), []byte("parent2"))
+	parentKVStore.Set([]byte("key3"), []byte("parent3"))
+	mvs.SetWriteset(0, 1, map[string][]byte{
+		"key2": nil,
+		"key4": []byte("a longer value written by tx 0"),
+	})
+
+	// the same state, as the sequential execution sees it after tx 0
+	sequential := cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, types.NewKVStoreKey("mock"), 1000)
+	sequential.Set([]byte("key1"), []byte("parent1"))
+	sequential.Set([]byte("key3"), []byte("parent3"))
+	sequential.Set([]byte("key4"), []byte("a longer value written by tx 0"))
+
+	versionedMeter, sequentialMeter := types.NewInfiniteGasMeter(), types.NewInfiniteGasMeter()
+	versioned := gaskv.NewStore(mvs.VersionedIndexedStore(1, 1, make(chan occ.Abort, 1)), versionedMeter, types.KVGasConfig())
+	plain := gaskv.NewStore(sequential, sequentialMeter, types.KVGasConfig())
+
+	iterate := func(iter types.Iterator) {
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			iter.Key()
+			iter.Value()
+		}
+	}
+	for _, access := range []func(kv types.KVStore){
+		func(kv types.KVStore) { kv.Get([]byte("key1")) },
+		func(kv types.KVStore) { kv.Get([]byte("key2")) },
+		func(kv types.KVStore) { kv.Get([]byte("key4")) },
+		func(kv types.KVStore) { kv.Has([]byte("key5")) },
+		func(kv types.KVStore) { kv.Set([]byte("key5"), []byte("value5")) },
+		func(kv types.KVStore) { kv.Get([]byte("key5")) },
+		func(kv types.KVStore) { kv.Delete([]byte("key1")) },
+		func(kv types.KVStore) { iterate(kv.Iterator(nil, nil)) },
+		func(kv types.KVStore) { iterate(kv.ReverseIterator([]byte("key2"), []byte("key5"))) },
+	} {
+		access(versioned)
+		access(plain)
+		// every access costs the same as in the sequential execution
+		require.Equal(t, sequentialMeter.GasConsumed(), versionedMeter.GasConsumed())
+	}
+	require.NotZero(t, versionedMeter.GasConsumed())
+}
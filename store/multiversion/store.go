@@ -0,0 +1,460 @@
+package multiversion
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// MultiVersionStore keeps, for every key written during a block, the value
+// written by each transaction index. A transaction at index i only observes
+// versions written by transactions with a lower index, so concurrently
+// executing transactions see the state they would have seen sequentially.
+type MultiVersionStore interface {
+	GetLatest(key []byte) (value MultiVersionValueItem)
+	GetLatestBeforeIndex(index int, key []byte) (value MultiVersionValueItem)
+	GetLatestBeforeIndexInDomain(index int, start, end []byte) map[string]MultiVersionValueItem
+	Has(index int, key []byte) bool
+	WriteLatestToStore()
+	FinalWritesets() (map[int]WriteSet, error)
+	CommitWritesets(writesets map[int]WriteSet)
+	SetWriteset(index int, incarnation int, writeset WriteSet)
+	InvalidateWriteset(index int, incarnation int)
+	SetEstimatedWriteset(index int, incarnation int, writeset WriteSet)
+	GetAllWritesetKeys() map[int][]string
+	ValidateReadset(index int, readset ReadSet) bool
+	ValidateIterateset(index int, iterateset IterateSet) bool
+	VersionedIndexedStore(index int, incarnation int, abortChannel chan occ.Abort) *VersionIndexedStore
+	Size() int
+	FlushBefore(index int) WriteSet
+}
+
+// WriteSet maps a key to the value written by a transaction. A nil value
+// represents a deletion.
+type WriteSet map[string][]byte
+
+// ReadSet maps a key to the value a transaction observed for it. A nil value
+// means the key did not exist.
+type ReadSet map[string][]byte
+
+// Iteration is a range scanned by a transaction. Observed holds the versions
+// written by lower-indexed transactions in [Start, End) when the iterator was
+// created, a nil value being a deletion. The parent store does not change
+// during a block, so any difference in those versions means the scan could
+// now return other keys or values.
+type Iteration struct {
+	Start    []byte
+	End      []byte
+	Observed map[string][]byte
+}
+
+// IterateSet holds the ranges a transaction scanned, in creation order.
+type IterateSet []Iteration
+
+var _ MultiVersionStore = (*Store)(nil)
+
+// Store implements MultiVersionStore on top of a parent KVStore, which holds
+// the state as of the start of the block.
+type Store struct {
+	mtx sync.RWMutex
+	// map that stores the key -> MultiVersionValue mapping for accessing from a given key
+	multiVersionMap map[string]MultiVersionValue
+	// map of tx index -> writeset keys, used to clean up stale versions on re-execution
+	txWritesetKeys map[int][]string
+
+	parentStore types.KVStore
+	storeKey    types.StoreKey
+}
+
+// NewMultiVersionStore returns a new, empty multi-version store for the parent
+// store mounted under storeKey.
+func NewMultiVersionStore(parentStore types.KVStore, storeKey types.StoreKey) *Store {
+	return &Store{
+		multiVersionMap: make(map[string]MultiVersionValue),
+		txWritesetKeys:  make(map[int][]string),
+		parentStore:     parentStore,
+		storeKey:        storeKey,
+	}
+}
+
+// VersionedIndexedStore creates a new versioned index store for a given incarnation and transaction index
+func (s *Store) VersionedIndexedStore(index int, incarnation int, abortChannel chan occ.Abort) *VersionIndexedStore {
+	return NewVersionIndexedStore(s.parentStore, s, s.storeKey, index, incarnation, abortChannel)
+}
+
+// GetLatest implements MultiVersionStore.
+func (s *Store) GetLatest(key []byte) (value MultiVersionValueItem) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	keyString := string(key)
+	// if the key doesn't exist in the overall map, return nil
+	if _, ok := s.multiVersionMap[keyString]; !ok {
+		return nil
+	}
+	val, found := s.multiVersionMap[keyString].GetLatest()
+	if !found {
+		return nil // this shouldn't be possible
+	}
+	return val
+}
+
+// GetLatestBeforeIndex implements MultiVersionStore.
+func (s *Store) GetLatestBeforeIndex(index int, key []byte) (value MultiVersionValueItem) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	keyString := string(key)
+	// if the key doesn't exist in the overall map, return nil
+	if _, ok := s.multiVersionMap[keyString]; !ok {
+		return nil
+	}
+	val, found := s.multiVersionMap[keyString].GetLatestBeforeIndex(index)
+	// otherwise, we may have found a value for that key, but its not written before the index passed in
+	if !found {
+		return nil
+	}
+	return val
+}
+
+// GetLatestBeforeIndexInDomain returns, for every key in [start, end) that has
+// been written by a transaction below index, the latest such version.
+func (s *Store) GetLatestBeforeIndexInDomain(index int, start, end []byte) map[string]MultiVersionValueItem {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	items := make(map[string]MultiVersionValueItem)
+	for key, mvVal := range s.multiVersionMap {
+		if !dbm.IsKeyInDomain([]byte(key), start, end) {
+			continue
+		}
+		if val, found := mvVal.GetLatestBeforeIndex(index); found {
+			items[key] = val
+		}
+	}
+	return items
+}
+
+// Has implements MultiVersionStore. It checks if the key exists in the multiversion store at or before the specified index.
+func (s *Store) Has(index int, key []byte) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	keyString := string(key)
+	if _, ok := s.multiVersionMap[keyString]; !ok {
+		return false // this is okay because the caller of this will THEN need to access the parent store to verify that the key doesnt exist there
+	}
+	_, found := s.multiVersionMap[keyString].GetLatestBeforeIndex(index)
+	return found
+}
+
+// This function will try to intialize the multiversion item if it doesn't exist for a key specified by byte array
+// NOTE: this should be used within an acquired mutex lock
+func (s *Store) tryInitMultiVersionItem(keyString string) {
+	if _, ok := s.multiVersionMap[keyString]; !ok {
+		multiVersionValue := NewMultiVersionItem()
+		s.multiVersionMap[keyString] = multiVersionValue
+	}
+}
+
+// removeOldWriteset drops versions written by a previous incarnation of the
+// transaction at index for keys that the new writeset no longer touches.
+// NOTE: this should be used within an acquired mutex lock
+func (s *Store) removeOldWriteset(index int, newWriteSet WriteSet) {
+	writeset := make(map[string][]byte)
+	if newWriteSet != nil {
+		// if non-nil writeset passed in, we can use that to optimize removals
+		writeset = newWriteSet
+	}
+	// if there is already a writeset existing, we should remove that fully
+	if keys, ok := s.txWritesetKeys[index]; ok {
+		// we need to delete all of the keys in the writeset from the multiversion store
+		for _, key := range keys {
+			// small optimization to check if the new writeset is going to write this key, if so, we can leave it behind
+			if _, ok := writeset[key]; ok {
+				// we don't need to remove this key because it will be overwritten anyways - saves the operation of removing + rebalancing underlying btree
+				continue
+			}
+			// remove from the appropriate item if present in multiVersionMap
+			if val, ok := s.multiVersionMap[key]; ok {
+				val.Remove(index)
+			}
+		}
+	}
+	// unset the writesetKeys for this index
+	delete(s.txWritesetKeys, index)
+}
+
+// SetWriteset sets a writeset for a transaction index, and also writes all of the multiversion items in the writeset to the multiversion store.
+func (s *Store) SetWriteset(index int, incarnation int, writeset WriteSet) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// remove old writeset if it exists
+	s.removeOldWriteset(index, writeset)
+
+	writeSetKeys := make([]string, 0, len(writeset))
+	for key, value := range writeset {
+		writeSetKeys = append(writeSetKeys, key)
+		s.tryInitMultiVersionItem(key)
+		if value == nil {
+			// delete if nil value
+			s.multiVersionMap[key].Delete(index, incarnation)
+		} else {
+			s.multiVersionMap[key].Set(index, incarnation, value)
+		}
+	}
+	sort.Strings(writeSetKeys)
+	s.txWritesetKeys[index] = writeSetKeys
+}
+
+// InvalidateWriteset iterates over the keys for the given index and incarnation writeset and replaces with ESTIMATEs
+func (s *Store) InvalidateWriteset(index int, incarnation int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if keys, ok := s.txWritesetKeys[index]; ok {
+		for _, key := range keys {
+			// invalidate all of the writeset items - is this suboptimal? - we could potentially do concurrently if slow because locking is on an item specific level
+			s.tryInitMultiVersionItem(key) // this SHOULD no-op because we're invalidating existing keys
+			s.multiVersionMap[key].SetEstimate(index, incarnation)
+		}
+	}
+	// we leave the writeset in place because we'll need it for key removal later if/when we replace with a new writeset
+}
+
+// SetEstimatedWriteset is used to directly write estimates instead of writing a writeset and later invalidating
+func (s *Store) SetEstimatedWriteset(index int, incarnation int, writeset WriteSet) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// remove old writeset if it exists
+	s.removeOldWriteset(index, writeset)
+
+	writeSetKeys := make([]string, 0, len(writeset))
+	// still need to save the writeset so we can remove the elements later:
+	for key := range writeset {
+		writeSetKeys = append(writeSetKeys, key)
+		s.tryInitMultiVersionItem(key)
+		s.multiVersionMap[key].SetEstimate(index, incarnation)
+	}
+	sort.Strings(writeSetKeys)
+	s.txWritesetKeys[index] = writeSetKeys
+}
+
+// GetAllWritesetKeys implements MultiVersionStore.
+func (s *Store) GetAllWritesetKeys() map[int][]string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.txWritesetKeys
+}
+
+// ValidateReadset reports whether every value in readset is still the value a
+// transaction at index would read now. A read is stale if a lower-indexed
+// transaction has since written a different value, or has an ESTIMATE pending
+// for the key.
+func (s *Store) ValidateReadset(index int, readset ReadSet) bool {
+	for key, value := range readset {
+		latest := s.GetLatestBeforeIndex(index, []byte(key))
+		if latest == nil {
+			// no earlier transaction writes the key, so the read came from the parent store
+			if !bytes.Equal(s.parentStore.Get([]byte(key)), value) {
+				return false
+			}
+			continue
+		}
+		if latest.IsEstimate() {
+			return false
+		}
+		if latest.IsDeleted() {
+			if value != nil {
+				return false
+			}
+			continue
+		}
+		if value == nil || !bytes.Equal(latest.Value(), value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateIterateset reports whether every range in iterateset still contains
+// the versions the transaction at index observed. A key written in the range
+// by a lower-indexed transaction since then is a phantom read and makes the
+// iterateset invalid, as does any pending ESTIMATE in the range.
+func (s *Store) ValidateIterateset(index int, iterateset IterateSet) bool {
+	for _, iteration := range iterateset {
+		latest := s.GetLatestBeforeIndexInDomain(index, iteration.Start, iteration.End)
+		if len(latest) != len(iteration.Observed) {
+			return false
+		}
+		for key, item := range latest {
+			observed, ok := iteration.Observed[key]
+			if !ok || item.IsEstimate() {
+				return false
+			}
+			if item.IsDeleted() != (observed == nil) || !bytes.Equal(item.Value(), observed) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FinalWritesets returns the writeset of every transaction as currently held by
+// the store, keyed by transaction index. It fails if any transaction still has
+// ESTIMATEs, meaning it has not been re-executed since it was invalidated.
+func (s *Store) FinalWritesets() (map[int]WriteSet, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	writesets := make(map[int]WriteSet, len(s.txWritesetKeys))
+	for index, keys := range s.txWritesetKeys {
+		writeset := make(WriteSet, len(keys))
+		for _, key := range keys {
+			item, found := s.multiVersionMap[key].GetLatestBeforeIndex(index + 1)
+			if !found || item.Index() != index {
+				return nil, fmt.Errorf("missing version of key %X written by tx %d", key, index)
+			}
+			if item.IsEstimate() {
+				return nil, fmt.Errorf("estimate for key %X written by tx %d", key, index)
+			}
+			writeset[key] = nil
+			if !item.IsDeleted() {
+				writeset[key] = item.Value()
+			}
+		}
+		writesets[index] = writeset
+	}
+	return writesets, nil
+}
+
+// CommitWritesets applies writesets to the parent store one transaction at a
+// time, in index order, and each writeset in key order, which is how the
+// transactions would have written to the parent if executed sequentially.
+func (s *Store) CommitWritesets(writesets map[int]WriteSet) {
+	indexes := make([]int, 0, len(writesets))
+	for index := range writesets {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		writeset := writesets[index]
+		keys := make([]string, 0, len(writeset))
+		for key := range writeset {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if value := writeset[key]; value == nil {
+				s.parentStore.Delete([]byte(key))
+			} else {
+				s.parentStore.Set([]byte(key), value)
+			}
+		}
+	}
+}
+
+// WriteLatestToStore flushes the latest version of every key into the parent
+// store. Applying the latest version per key is equivalent to applying every
+// writeset in index order.
+func (s *Store) WriteLatestToStore() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// sort the keys so that the writes to the parent store are deterministic
+	keys := make([]string, 0, len(s.multiVersionMap))
+	for key := range s.multiVersionMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		mvValue, found := s.multiVersionMap[key].GetLatest()
+		if !found {
+			// this means that at some point, there was a value but it was removed by a later incarnation
+			continue
+		}
+		if mvValue.IsEstimate() {
+			panic(fmt.Sprintf("invalid multiversion store state: estimate for key %X written by tx %d", key, mvValue.Index()))
+		}
+		if mvValue.IsDeleted() {
+			// We use []byte(key) instead of conv.UnsafeStrToBytes because we cannot
+			// be sure if the underlying store might do a save with the byteslice or
+			// not. Once we get confirmation that .Delete is guaranteed not to
+			// save the byteslice, then we can assume only a read-only copy is sufficient.
+			s.parentStore.Delete([]byte(key))
+			continue
+		}
+		if mvValue.Value() != nil {
+			s.parentStore.Set([]byte(key), mvValue.Value())
+		}
+	}
+}
+
+// Size returns the approximate memory held by the versions of the store, the
+// bytes of their keys and values.
+func (s *Store) Size() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	size := 0
+	for key, mvValue := range s.multiVersionMap {
+		versions, valueBytes := mvValue.Size()
+		size += versions*len(key) + valueBytes
+	}
+	return size
+}
+
+// FlushBefore writes the latest version written by the transactions below
+// index to the parent store, drops every version they wrote, and returns the
+// writes applied to the parent. Those transactions must be final: a later
+// read of a key they wrote gets the value from the parent store. The versions
+// they wrote are no longer observed by the iterations validated afterwards,
+// which then fail validation if they saw any.
+func (s *Store) FlushBefore(index int) WriteSet {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	keys := make([]string, 0, len(s.multiVersionMap))
+	for key := range s.multiVersionMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	flushed := make(WriteSet)
+	for _, key := range keys {
+		latest, found := s.multiVersionMap[key].RemoveBefore(index)
+		if versions, _ := s.multiVersionMap[key].Size(); versions == 0 {
+			delete(s.multiVersionMap, key)
+		}
+		if !found {
+			continue
+		}
+		if latest.IsEstimate() {
+			panic(fmt.Sprintf("invalid multiversion store state: estimate for key %X written by tx %d", key, latest.Index()))
+		}
+		if latest.IsDeleted() {
+			s.parentStore.Delete([]byte(key))
+			flushed[key] = nil
+		} else {
+			s.parentStore.Set([]byte(key), latest.Value())
+			flushed[key] = latest.Value()
+		}
+	}
+	for txIndex := range s.txWritesetKeys {
+		if txIndex < index {
+			delete(s.txWritesetKeys, txIndex)
+		}
+	}
+	return flushed
+}
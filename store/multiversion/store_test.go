@@ -0,0 +1,246 @@
+package multiversion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+var testStoreKey = types.NewKVStoreKey("mock")
+
+func TestMultiVersionStore(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	// Test Set and GetLatest
+	store.SetWriteset(1, 1, map[string][]byte{"key1": []byte("value1")})
+	store.SetWriteset(2, 1, map[string][]byte{"key1": []byte("value2")})
+	store.SetWriteset(3, 1, map[string][]byte{"key2": []byte("value3")})
+	require.Equal(t, []byte("value2"), store.GetLatest([]byte("key1")).Value())
+	require.Equal(t, []byte("value3"), store.GetLatest([]byte("key2")).Value())
+
+	// Test SetWriteset with a deletion
+	store.SetWriteset(4, 1, map[string][]byte{"key1": nil})
+	require.True(t, store.GetLatest([]byte("key1")).IsDeleted())
+
+	// Test GetLatestBeforeIndex
+	store.SetWriteset(5, 1, map[string][]byte{"key1": []byte("value4")})
+	require.Equal(t, []byte("value4"), store.GetLatestBeforeIndex(6, []byte("key1")).Value())
+	require.True(t, store.GetLatestBeforeIndex(5, []byte("key1")).IsDeleted())
+	require.Equal(t, []byte("value2"), store.GetLatestBeforeIndex(4, []byte("key1")).Value())
+	require.Nil(t, store.GetLatestBeforeIndex(1, []byte("key1")))
+
+	// Test Has
+	require.True(t, store.Has(2, []byte("key1")))
+	require.False(t, store.Has(0, []byte("key1")))
+	require.False(t, store.Has(5, []byte("key4")))
+}
+
+func TestMultiVersionStoreHasLaterValue(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	store.SetWriteset(5, 1, map[string][]byte{"key1": []byte("value2")})
+
+	require.Nil(t, store.GetLatestBeforeIndex(4, []byte("key1")))
+	require.Equal(t, []byte("value2"), store.GetLatestBeforeIndex(6, []byte("key1")).Value())
+}
+
+func TestMultiVersionStoreKeyDNE(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	require.Nil(t, store.GetLatest([]byte("key1")))
+	require.Nil(t, store.GetLatestBeforeIndex(0, []byte("key1")))
+	require.False(t, store.Has(0, []byte("key1")))
+}
+
+func TestMultiVersionStoreSetWritesetRemovesOldKeys(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	store.SetWriteset(2, 1, map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	})
+	require.Equal(t, map[int][]string{2: {"key1", "key2"}}, store.GetAllWritesetKeys())
+
+	// the next incarnation no longer writes key2, so its version must disappear
+	store.SetWriteset(2, 2, map[string][]byte{"key1": []byte("value3")})
+	require.Equal(t, []byte("value3"), store.GetLatest([]byte("key1")).Value())
+	require.Equal(t, 2, store.GetLatest([]byte("key1")).Incarnation())
+	require.Nil(t, store.GetLatest([]byte("key2")))
+	require.Equal(t, map[int][]string{2: {"key1"}}, store.GetAllWritesetKeys())
+}
+
+func TestMultiVersionStoreWriteLatestToStore(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	parentKVStore.Set([]byte("key2"), []byte("value0"))
+	parentKVStore.Set([]byte("key4"), []byte("value4"))
+
+	mvs.SetWriteset(1, 1, map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": nil,
+		"key3": []byte("value3"),
+	})
+	mvs.SetWriteset(2, 1, map[string][]byte{
+		"key1": []byte("value5"),
+	})
+
+	mvs.WriteLatestToStore()
+
+	require.Equal(t, []byte("value5"), parentKVStore.Get([]byte("key1")))
+	require.Nil(t, parentKVStore.Get([]byte("key2")))
+	require.Equal(t, []byte("value3"), parentKVStore.Get([]byte("key3")))
+	require.Equal(t, []byte("value4"), parentKVStore.Get([]byte("key4")))
+}
+
+func TestMultiVersionStoreInvalidateWriteset(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	store.SetWriteset(1, 1, map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": nil,
+	})
+	store.InvalidateWriteset(1, 1)
+
+	require.True(t, store.GetLatest([]byte("key1")).IsEstimate())
+	require.True(t, store.GetLatest([]byte("key2")).IsEstimate())
+	require.Equal(t, 1, store.GetLatestBeforeIndex(2, []byte("key1")).Index())
+	// the writeset keys are kept so the next incarnation can clean them up
+	require.Equal(t, map[int][]string{1: {"key1", "key2"}}, store.GetAllWritesetKeys())
+
+	store.SetWriteset(1, 2, map[string][]byte{"key1": []byte("value2")})
+	require.False(t, store.GetLatest([]byte("key1")).IsEstimate())
+	require.Equal(t, []byte("value2"), store.GetLatest([]byte("key1")).Value())
+	require.Nil(t, store.GetLatest([]byte("key2")))
+}
+
+func TestMultiVersionStoreSetEstimatedWriteset(t *testing.T) {
+	store := multiversion.NewMultiVersionStore(nil, testStoreKey)
+
+	store.SetWriteset(1, 1, map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	})
+	store.SetEstimatedWriteset(1, 2, map[string][]byte{"key1": []byte("value3")})
+
+	val := store.GetLatest([]byte("key1"))
+	require.True(t, val.IsEstimate())
+	require.Nil(t, val.Value())
+	require.Equal(t, 2, val.Incarnation())
+	// keys outside of the estimated writeset are removed
+	require.Nil(t, store.GetLatest([]byte("key2")))
+	require.Equal(t, map[int][]string{1: {"key1"}}, store.GetAllWritesetKeys())
+}
+
+func TestMultiVersionStoreValidateReadset(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	parentKVStore.Set([]byte("key1"), []byte("value1"))
+	mvs.SetWriteset(1, 1, map[string][]byte{
+		"key2": []byte("value2"),
+		"key3": nil,
+	})
+
+	// reads that match the parent store and earlier writes are valid
+	require.True(t, mvs.ValidateReadset(2, multiversion.ReadSet{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+		"key3": nil,
+		"key4": nil,
+	}))
+	// writes at or above the index are not visible
+	require.True(t, mvs.ValidateReadset(1, multiversion.ReadSet{"key2": nil}))
+
+	// a stale value is invalid, whether it came from the parent or an earlier write
+	require.False(t, mvs.ValidateReadset(2, multiversion.ReadSet{"key1": []byte("value0")}))
+	require.False(t, mvs.ValidateReadset(2, multiversion.ReadSet{"key2": []byte("value0")}))
+	require.False(t, mvs.ValidateReadset(2, multiversion.ReadSet{"key2": nil}))
+	require.False(t, mvs.ValidateReadset(2, multiversion.ReadSet{"key3": []byte("value3")}))
+
+	// an estimate invalidates any read of the key
+	mvs.InvalidateWriteset(1, 1)
+	require.False(t, mvs.ValidateReadset(2, multiversion.ReadSet{"key2": []byte("value2")}))
+}
+
+func TestMultiVersionStoreValidateIterateset(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	parentKVStore.Set([]byte("key1"), []byte("value1"))
+	mvs.SetWriteset(1, 1, map[string][]byte{
+		"key2": []byte("value2"),
+		"key3": nil,
+	})
+	iterateset := multiversion.IterateSet{{
+		Start:    []byte("key1"),
+		End:      []byte("key5"),
+		Observed: map[string][]byte{"key2": []byte("value2"), "key3": nil},
+	}}
+	require.True(t, mvs.ValidateIterateset(2, iterateset))
+	// writes at or above the index are not visible
+	mvs.SetWriteset(2, 1, map[string][]byte{"key4": []byte("value4")})
+	require.True(t, mvs.ValidateIterateset(2, iterateset))
+	// and writes outside of the range are ignored
+	mvs.SetWriteset(0, 1, map[string][]byte{"key6": []byte("value6")})
+	require.True(t, mvs.ValidateIterateset(2, iterateset))
+
+	// a key inserted in the range by a lower-indexed tx is a phantom read
+	mvs.SetWriteset(0, 1, map[string][]byte{"key4": []byte("value4")})
+	require.False(t, mvs.ValidateIterateset(2, iterateset))
+	mvs.SetWriteset(0, 1, nil)
+	require.True(t, mvs.ValidateIterateset(2, iterateset))
+
+	// so are changed values and deletions
+	mvs.SetWriteset(1, 2, map[string][]byte{"key2": []byte("value0"), "key3": nil})
+	require.False(t, mvs.ValidateIterateset(2, iterateset))
+	mvs.SetWriteset(1, 2, map[string][]byte{"key2": nil, "key3": nil})
+	require.False(t, mvs.ValidateIterateset(2, iterateset))
+
+	// and estimates in the range
+	mvs.SetWriteset(1, 2, map[string][]byte{"key2": []byte("value2"), "key3": nil})
+	require.True(t, mvs.ValidateIterateset(2, iterateset))
+	mvs.InvalidateWriteset(1, 2)
+	require.False(t, mvs.ValidateIterateset(2, iterateset))
+}
+
+func TestMultiVersionStoreSize(t *testing.T) {
+	mvs := multiversion.NewMultiVersionStore(nil, testStoreKey)
+	require.Zero(t, mvs.Size())
+
+	mvs.SetWriteset(1, 1, map[string][]byte{"key1": []byte("value1"), "key2": nil})
+	mvs.SetWriteset(2, 1, map[string][]byte{"key1": []byte("v2")})
+	// two versions of key1 and a deletion of key2
+	require.Equal(t, 2*4+6+2+4, mvs.Size())
+}
+
+func TestMultiVersionStoreFlushBefore(t *testing.T) {
+	parentKVStore := dbadapter.Store{DB: dbm.NewMemDB()}
+	mvs := multiversion.NewMultiVersionStore(parentKVStore, testStoreKey)
+
+	parentKVStore.Set([]byte("key2"), []byte("value0"))
+	mvs.SetWriteset(1, 1, map[string][]byte{"key1": []byte("value1"), "key2": nil})
+	mvs.SetWriteset(2, 1, map[string][]byte{"key1": []byte("value2")})
+	mvs.SetWriteset(3, 1, map[string][]byte{"key1": []byte("value3"), "key3": []byte("value3")})
+
+	flushed := mvs.FlushBefore(3)
+	require.Equal(t, multiversion.WriteSet{"key1": []byte("value2"), "key2": nil}, flushed)
+	require.Equal(t, []byte("value2"), parentKVStore.Get([]byte("key1")))
+	require.Nil(t, parentKVStore.Get([]byte("key2")))
+	require.Nil(t, parentKVStore.Get([]byte("key3")))
+
+	// the versions of tx 3 are left, and it reads the flushed values from the parent
+	require.Equal(t, map[int][]string{3: {"key1", "key3"}}, mvs.GetAllWritesetKeys())
+	require.Nil(t, mvs.GetLatestBeforeIndex(3, []byte("key1")))
+	require.True(t, mvs.ValidateReadset(3, multiversion.ReadSet{"key1": []byte("value2"), "key2": nil}))
+	require.Equal(t, 2*4+2*6, mvs.Size())
+
+	mvs.WriteLatestToStore()
+	require.Equal(t, []byte("value3"), parentKVStore.Get([]byte("key1")))
+	require.Equal(t, []byte("value3"), parentKVStore.Get([]byte("key3")))
+}
@@ -0,0 +1,195 @@
+// Package pebbledb implements the tm-db DB interface on top of PebbleDB, a
+// LevelDB-compatible LSM store whose compactions do not stall writes the way
+// goleveldb's do under sustained write throughput.
+package pebbledb
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// BackendType is the name of the PebbleDB backend, as set in app-db-backend.
+const BackendType dbm.BackendType = "pebbledb"
+
+var (
+	errKeyEmpty    = errors.New("key cannot be empty")
+	errValueNil    = errors.New("value cannot be nil")
+	errBatchClosed = errors.New("batch has been written or closed")
+)
+
+// PebbleDB is a dbm.DB backed by PebbleDB.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ dbm.DB = (*PebbleDB)(nil)
+
+// NewDB opens the database name in dir with the default options, creating it
+// if it does not exist. Like the tm-db backends, it is stored in dir/name.db.
+func NewDB(name string, dir string) (*PebbleDB, error) {
+	opts := &pebble.Options{}
+	opts.EnsureDefaults()
+	return NewDBWithOpts(name, dir, opts)
+}
+
+// NewDBWithOpts opens the database name in dir with opts.
+func NewDBWithOpts(name string, dir string, opts *pebble.Options) (*PebbleDB, error) {
+	db, err := pebble.Open(filepath.Join(dir, name+".db"), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// DB returns the underlying PebbleDB.
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	// the value is only valid until the closer is closed
+	return cp(res), nil
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bytes, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bytes != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key []byte, value []byte) error {
+	if err := validateSet(key, value); err != nil {
+		return err
+	}
+	return db.db.Set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key []byte, value []byte) error {
+	if err := validateSet(key, value); err != nil {
+		return err
+	}
+	return db.db.Set(key, value, pebble.Sync)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, pebble.Sync)
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	fmt.Printf("%v\n", db.db.Metrics())
+
+	itr := db.db.NewIter(nil)
+	defer itr.Close()
+	for itr.First(); itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return nil
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	return map[string]string{
+		"pebble.metrics": db.db.Metrics().String(),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() dbm.Batch {
+	return newPebbleDBBatch(db)
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(itr, start, end, false), nil
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(itr, start, end, true), nil
+}
+
+// ForceCompact compacts the keys of [start, limit), like GoLevelDB's, a nil
+// bound extending the range to the first or the last key.
+func (db *PebbleDB) ForceCompact(start, limit []byte) error {
+	if limit == nil {
+		// pebble requires an upper bound, which is exclusive
+		itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start})
+		if !itr.Last() {
+			return itr.Close()
+		}
+		limit = append(cp(itr.Key()), 0)
+		if err := itr.Close(); err != nil {
+			return err
+		}
+	}
+	if start == nil {
+		start = []byte{}
+	}
+	return db.db.Compact(start, limit, true)
+}
+
+func validateSet(key, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return nil
+}
+
+func cp(bz []byte) []byte {
+	if bz == nil {
+		return nil
+	}
+	ret := make([]byte, len(bz))
+	copy(ret, bz)
+	return ret
+}
@@ -0,0 +1,179 @@
+package pebbledb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
+)
+
+func newDB(t *testing.T) *pebbledb.PebbleDB {
+	db, err := pebbledb.NewDB("test", t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func collect(t *testing.T, itr dbm.Iterator) []string {
+	defer itr.Close()
+	var keys []string
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, string(itr.Key()))
+		require.Equal(t, "v"+string(itr.Key()), string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+	return keys
+}
+
+func TestPebbleDB(t *testing.T) {
+	db := newDB(t)
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, db.Set([]byte("a"), []byte("1")))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{}))
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	// an empty value is not a missing key
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, value)
+	has, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	require.True(t, has)
+
+	require.NoError(t, db.Delete([]byte("a")))
+	require.NoError(t, db.DeleteSync([]byte("b")))
+	has, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, has)
+	// deleting a missing key is not an error
+	require.NoError(t, db.Delete([]byte("c")))
+
+	_, err = db.Get(nil)
+	require.Error(t, err)
+	require.Error(t, db.Set([]byte{}, []byte("1")))
+	require.Error(t, db.Set([]byte("a"), nil))
+	require.Error(t, db.Delete(nil))
+}
+
+// TestPebbleDBIterator checks that iterators follow the goleveldb semantics:
+// [start, end) domains with nil meaning unbounded, in both directions.
+func TestPebbleDBIterator(t *testing.T) {
+	db := newDB(t)
+	leveldb, err := dbm.NewGoLevelDB("test", t.TempDir())
+	require.NoError(t, err)
+	defer leveldb.Close()
+
+	for _, key := range []string{"a", "aa", "b", "c", "c\x00", "d", "\xff"} {
+		require.NoError(t, db.Set([]byte(key), []byte("v"+key)))
+		require.NoError(t, leveldb.Set([]byte(key), []byte("v"+key)))
+	}
+
+	domains := [][2][]byte{
+		{nil, nil},
+		{[]byte("a"), nil},
+		{nil, []byte("c")},
+		{[]byte("aa"), []byte("c\x00")},
+		{[]byte("ab"), []byte("cc")},
+		{[]byte("b"), []byte("b")},
+		{[]byte("c"), []byte("b")},
+		{[]byte("e"), nil},
+		{[]byte("\xff"), nil},
+	}
+	for _, domain := range domains {
+		start, end := domain[0], domain[1]
+		name := fmt.Sprintf("%q-%q", start, end)
+
+		itr, err := db.Iterator(start, end)
+		require.NoError(t, err)
+		expectedItr, err := leveldb.Iterator(start, end)
+		require.NoError(t, err)
+		require.Equal(t, collect(t, expectedItr), collect(t, itr), name)
+
+		itr, err = db.ReverseIterator(start, end)
+		require.NoError(t, err)
+		expectedItr, err = leveldb.ReverseIterator(start, end)
+		require.NoError(t, err)
+		require.Equal(t, collect(t, expectedItr), collect(t, itr), "reverse "+name)
+	}
+
+	itr, err := db.Iterator([]byte("b"), []byte("d"))
+	require.NoError(t, err)
+	start, end := itr.Domain()
+	require.Equal(t, []byte("b"), start)
+	require.Equal(t, []byte("d"), end)
+	require.NoError(t, itr.Close())
+
+	_, err = db.Iterator([]byte{}, nil)
+	require.Error(t, err)
+	_, err = db.ReverseIterator(nil, []byte{})
+	require.Error(t, err)
+}
+
+func TestPebbleDBIteratorSnapshot(t *testing.T) {
+	db := newDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte("va")))
+	require.NoError(t, db.Set([]byte("b"), []byte("vb")))
+
+	// like goleveldb iterators, writes made after creation are not visible
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("c"), []byte("vc")))
+	require.NoError(t, db.Delete([]byte("b")))
+	require.Equal(t, []string{"a", "b"}, collect(t, itr))
+
+	// the returned key and value do not change when the iterator moves
+	itr, err = db.Iterator(nil, nil)
+	require.NoError(t, err)
+	key, value := itr.Key(), itr.Value()
+	itr.Next()
+	require.Equal(t, []byte("a"), key)
+	require.Equal(t, []byte("va"), value)
+	itr.Next()
+	require.False(t, itr.Valid())
+	require.Panics(t, func() { itr.Key() })
+	require.Panics(t, func() { itr.Next() })
+	require.NoError(t, itr.Close())
+}
+
+func TestPebbleDBBatch(t *testing.T) {
+	db := newDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte("va")))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("b"), []byte("vb")))
+	require.NoError(t, batch.Delete([]byte("a")))
+	require.Error(t, batch.Set(nil, []byte("v")))
+	require.Error(t, batch.Set([]byte("c"), nil))
+
+	// nothing is visible before the batch is written
+	has, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, batch.Write())
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, collect(t, itr))
+
+	// a written batch cannot be reused, but can be closed again
+	require.Error(t, batch.Set([]byte("c"), []byte("vc")))
+	require.Error(t, batch.WriteSync())
+	require.NoError(t, batch.Close())
+
+	// a closed batch is discarded
+	batch = db.NewBatch()
+	require.NoError(t, batch.Set([]byte("c"), []byte("vc")))
+	require.NoError(t, batch.Close())
+	require.Error(t, batch.Write())
+	has, err = db.Has([]byte("c"))
+	require.NoError(t, err)
+	require.False(t, has)
+}
@@ -0,0 +1,194 @@
+package pruning
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// PruneFunc deletes the given heights from the stores. The heights are sorted
+// in ascending order.
+type PruneFunc func(heights []int64) error
+
+// Manager deletes pruned heights in the background so that the commit path
+// only has to record them. Every interval it deletes up to batchSize of the
+// pending heights, oldest first. A height that fails to be deleted stays
+// pending and is retried on the next run.
+//
+// Heights can be protected while they are being read, e.g. to export a
+// state-sync snapshot, in which case they are skipped until released.
+type Manager struct {
+	logger    log.Logger
+	prune     PruneFunc
+	batchSize int
+	interval  time.Duration
+
+	mtx       sync.Mutex
+	pending   map[int64]struct{}
+	protected map[int64]int
+
+	// pruneMtx is held while a batch is deleted, so that protecting a height
+	// waits for a batch that may contain it.
+	pruneMtx sync.Mutex
+
+	runMtx sync.Mutex
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewManager returns a Manager deleting heights with prune. A batchSize of 0
+// deletes all the pending heights in a single run.
+func NewManager(logger log.Logger, prune PruneFunc, batchSize int, interval time.Duration) *Manager {
+	return &Manager{
+		logger:    logger,
+		prune:     prune,
+		batchSize: batchSize,
+		interval:  interval,
+		pending:   make(map[int64]struct{}),
+		protected: make(map[int64]int),
+	}
+}
+
+// Add marks heights to be pruned on a later run.
+func (m *Manager) Add(heights ...int64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, height := range heights {
+		m.pending[height] = struct{}{}
+	}
+}
+
+// DiscardAbove drops the pending heights above height, which no longer exist
+// once the store is rolled back to it.
+func (m *Manager) DiscardAbove(height int64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for pending := range m.pending {
+		if pending > height {
+			delete(m.pending, pending)
+		}
+	}
+}
+
+// PendingHeights returns the heights that are not pruned yet, in ascending
+// order.
+func (m *Manager) PendingHeights() []int64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	heights := make([]int64, 0, len(m.pending))
+	for height := range m.pending {
+		heights = append(heights, height)
+	}
+	sortHeights(heights)
+	return heights
+}
+
+// Protect keeps height from being pruned until the returned function is
+// called. If a batch is being deleted, it waits for it to complete, so the
+// height may already be pruned when Protect returns.
+func (m *Manager) Protect(height int64) (release func()) {
+	m.pruneMtx.Lock()
+	m.mtx.Lock()
+	m.protected[height]++
+	m.mtx.Unlock()
+	m.pruneMtx.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			m.protected[height]--
+			if m.protected[height] <= 0 {
+				delete(m.protected, height)
+			}
+		})
+	}
+}
+
+// Prune deletes the next batch of pending heights, skipping protected ones.
+func (m *Manager) Prune() error {
+	m.pruneMtx.Lock()
+	defer m.pruneMtx.Unlock()
+
+	heights := m.nextBatch()
+	if len(heights) == 0 {
+		return nil
+	}
+	if err := m.prune(heights); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, height := range heights {
+		delete(m.pending, height)
+	}
+	return nil
+}
+
+func (m *Manager) nextBatch() []int64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	heights := make([]int64, 0, len(m.pending))
+	for height := range m.pending {
+		if m.protected[height] == 0 {
+			heights = append(heights, height)
+		}
+	}
+	sortHeights(heights)
+	if m.batchSize > 0 && len(heights) > m.batchSize {
+		heights = heights[:m.batchSize]
+	}
+	return heights
+}
+
+// Start runs Prune every interval in a background goroutine. It is a no-op if
+// the manager is already running.
+func (m *Manager) Start() {
+	m.runMtx.Lock()
+	defer m.runMtx.Unlock()
+	if m.stop != nil {
+		return
+	}
+	m.stop, m.done = make(chan struct{}), make(chan struct{})
+	go m.run(m.stop, m.done)
+}
+
+// Stop stops the background goroutine, waiting for a running batch to
+// complete. The pending heights are kept.
+func (m *Manager) Stop() {
+	m.runMtx.Lock()
+	defer m.runMtx.Unlock()
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+	m.stop, m.done = nil, nil
+}
+
+func (m *Manager) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := m.Prune(); err != nil {
+				m.logger.Error("failed to prune heights, retrying on the next run", "err", err)
+				continue
+			}
+			m.logger.Debug("pruned heights", "duration", time.Since(start))
+		}
+	}
+}
+
+func sortHeights(heights []int64) {
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+}
@@ -0,0 +1,155 @@
+package pruning
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// recorder records the batches it is asked to prune and fails while err is
+// set.
+type recorder struct {
+	mtx     sync.Mutex
+	batches [][]int64
+	err     error
+}
+
+func (r *recorder) prune(heights []int64) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.batches = append(r.batches, append([]int64{}, heights...))
+	return nil
+}
+
+func (r *recorder) pruned() [][]int64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.batches
+}
+
+func TestManagerBatches(t *testing.T) {
+	r := &recorder{}
+	m := NewManager(log.NewNopLogger(), r.prune, 2, time.Hour)
+
+	m.Add(5, 1, 3)
+	m.Add(2, 3)
+	require.Equal(t, []int64{1, 2, 3, 5}, m.PendingHeights())
+
+	require.NoError(t, m.Prune())
+	require.NoError(t, m.Prune())
+	require.NoError(t, m.Prune())
+	require.Equal(t, [][]int64{{1, 2}, {3, 5}}, r.pruned())
+	require.Empty(t, m.PendingHeights())
+
+	// a batch size of 0 prunes everything at once
+	m = NewManager(log.NewNopLogger(), r.prune, 0, time.Hour)
+	m.Add(7, 6, 8)
+	require.NoError(t, m.Prune())
+	require.Equal(t, []int64{6, 7, 8}, r.pruned()[2])
+}
+
+func TestManagerDiscardAbove(t *testing.T) {
+	r := &recorder{}
+	m := NewManager(log.NewNopLogger(), r.prune, 0, time.Hour)
+
+	m.Add(1, 2, 3, 4)
+	m.DiscardAbove(2)
+	require.Equal(t, []int64{1, 2}, m.PendingHeights())
+	require.NoError(t, m.Prune())
+	require.Equal(t, [][]int64{{1, 2}}, r.pruned())
+}
+
+func TestManagerFailedPruneIsRetried(t *testing.T) {
+	r := &recorder{err: errors.New("active readers")}
+	m := NewManager(log.NewNopLogger(), r.prune, 0, time.Hour)
+
+	m.Add(1, 2)
+	require.ErrorContains(t, m.Prune(), "active readers")
+	require.Equal(t, []int64{1, 2}, m.PendingHeights())
+
+	r.err = nil
+	require.NoError(t, m.Prune())
+	require.Equal(t, [][]int64{{1, 2}}, r.pruned())
+	require.Empty(t, m.PendingHeights())
+}
+
+func TestManagerProtect(t *testing.T) {
+	r := &recorder{}
+	m := NewManager(log.NewNopLogger(), r.prune, 0, time.Hour)
+
+	m.Add(1, 2, 3)
+	release := m.Protect(2)
+	release2 := m.Protect(2)
+	require.NoError(t, m.Prune())
+	require.Equal(t, [][]int64{{1, 3}}, r.pruned())
+	require.Equal(t, []int64{2}, m.PendingHeights())
+
+	// the height is protected until every reader released it
+	release()
+	release()
+	require.NoError(t, m.Prune())
+	require.Len(t, r.pruned(), 1)
+
+	release2()
+	require.NoError(t, m.Prune())
+	require.Equal(t, [][]int64{{1, 3}, {2}}, r.pruned())
+}
+
+func TestManagerProtectWaitsForBatch(t *testing.T) {
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	var pruned []int64
+	m := NewManager(log.NewNopLogger(), func(heights []int64) error {
+		close(started)
+		<-unblock
+		pruned = heights
+		return nil
+	}, 0, time.Hour)
+	m.Add(1)
+
+	go func() { require.NoError(t, m.Prune()) }()
+	<-started
+	protected := make(chan struct{})
+	go func() {
+		m.Protect(1)
+		close(protected)
+	}()
+	select {
+	case <-protected:
+		t.Fatal("protect did not wait for the running batch")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(unblock)
+	<-protected
+	require.Equal(t, []int64{1}, pruned)
+}
+
+func TestManagerStartStop(t *testing.T) {
+	r := &recorder{}
+	m := NewManager(log.NewNopLogger(), r.prune, 1, time.Millisecond)
+	m.Add(1, 2, 3)
+
+	m.Start()
+	m.Start()
+	require.Eventually(t, func() bool { return len(m.PendingHeights()) == 0 }, time.Second, time.Millisecond)
+	m.Stop()
+	m.Stop()
+	require.Equal(t, [][]int64{{1}, {2}, {3}}, r.pruned())
+
+	// nothing is pruned once stopped
+	m.Add(4)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, []int64{4}, m.PendingHeights())
+
+	m.Start()
+	require.Eventually(t, func() bool { return len(m.PendingHeights()) == 0 }, time.Second, time.Millisecond)
+	m.Stop()
+}
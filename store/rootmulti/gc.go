@@ -0,0 +1,57 @@
+package rootmulti
+
+import (
+	"fmt"
+	"sort"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+)
+
+// StoreGCStats reports the garbage collected from the tree of a store.
+type StoreGCStats struct {
+	Name string
+	iavl.GCStats
+}
+
+// CollectGarbage deletes the IAVL nodes that no version references from the
+// stores of the latest version committed to db, unless dryRun is set, and
+// returns the stats of each store in name order. The stores that are not IAVL
+// trees are left untouched. It must only be called while the application is
+// stopped.
+func CollectGarbage(db dbm.DB, dryRun bool) ([]StoreGCStats, error) {
+	version := GetLatestVersion(db)
+	if version == 0 {
+		return nil, fmt.Errorf("no version committed")
+	}
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(cInfo.StoreInfos))
+	for i, storeInfo := range cInfo.StoreInfos {
+		names[i] = storeInfo.Name
+	}
+	sort.Strings(names)
+
+	var stats []StoreGCStats
+	for _, name := range names {
+		storeDB := dbm.NewPrefixDB(db, []byte("s/k:"+name+"/"))
+		// every version of an IAVL store has a root, which a raw store
+		// could not be mistaken for
+		ok, err := iavl.HasVersion(storeDB, version)
+		if err != nil {
+			return stats, err
+		}
+		if !ok {
+			continue
+		}
+		storeStats, err := iavl.CollectGarbage(storeDB, dryRun)
+		if err != nil {
+			return stats, fmt.Errorf("failed to collect the garbage of store %s: %w", name, err)
+		}
+		stats = append(stats, StoreGCStats{Name: name, GCStats: storeStats})
+	}
+	return stats, nil
+}
@@ -0,0 +1,42 @@
+package rootmulti
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	db := dbm.NewMemDB()
+	_, err := CollectGarbage(db, false)
+	require.ErrorContains(t, err, "no version committed")
+
+	ms := newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(t, ms, 1, 3)
+
+	// drop the root of version 1 of store1, leaving its nodes behind
+	rootKey := make([]byte, 9)
+	rootKey[0] = 'r'
+	binary.BigEndian.PutUint64(rootKey[1:], 1)
+	require.NoError(t, db.Delete(append([]byte("s/k:store1/"), rootKey...)))
+
+	stats, err := CollectGarbage(db, false)
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+	for i, name := range []string{"store1", "store2", "store3"} {
+		require.Equal(t, name, stats[i].Name)
+	}
+	require.Positive(t, stats[0].OrphanedNodes)
+	require.Zero(t, stats[1].OrphanedNodes)
+	require.Zero(t, stats[2].OrphanedNodes)
+
+	ms = newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, "2", latestAt(t, ms, 2))
+	require.Equal(t, "3", latestAt(t, ms, 3))
+}
@@ -0,0 +1,95 @@
+package rootmulti
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// historicalStores keeps the IAVL stores loaded for past versions by
+// CacheMultiStoreWithVersion, so that the queries at a height share them rather
+// than each one loading the version from every tree, which contends with
+// commits for the tree locks. At most size versions are kept open, the least
+// recently queried one being closed to open another.
+type historicalStores struct {
+	cache *lru.Cache[int64, map[types.StoreKey]*iavl.Store]
+	loads singleflight.Group
+
+	// generation is bumped whenever versions are invalidated, so that a load
+	// racing with the invalidation does not cache stores of a deleted version.
+	mtx        sync.Mutex
+	generation uint64
+}
+
+func newHistoricalStores(size int) *historicalStores {
+	cache, err := lru.New[int64, map[types.StoreKey]*iavl.Store](size)
+	if err != nil {
+		panic(fmt.Errorf("failed to create historical stores cache: %w", err))
+	}
+	return &historicalStores{cache: cache}
+}
+
+// get returns the stores of version, calling load if they are not open yet.
+// Concurrent calls for the same version share a single load.
+func (h *historicalStores) get(version int64, load func() (map[types.StoreKey]*iavl.Store, error)) (map[types.StoreKey]*iavl.Store, error) {
+	if stores, ok := h.cache.Get(version); ok {
+		return stores, nil
+	}
+	res, err, _ := h.loads.Do(strconv.FormatInt(version, 10), func() (interface{}, error) {
+		if stores, ok := h.cache.Get(version); ok {
+			return stores, nil
+		}
+		generation := h.currentGeneration()
+		stores, err := load()
+		if err != nil {
+			return nil, err
+		}
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		if generation == h.generation {
+			h.cache.Add(version, stores)
+		}
+		return stores, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(map[types.StoreKey]*iavl.Store), nil
+}
+
+func (h *historicalStores) currentGeneration() uint64 {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.generation
+}
+
+// remove closes the given versions, which must be called once they are
+// deleted from the trees.
+func (h *historicalStores) remove(versions ...int64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.generation++
+	for _, version := range versions {
+		h.cache.Remove(version)
+	}
+}
+
+// resize changes the number of versions kept open, closing the least recently
+// queried ones in excess.
+func (h *historicalStores) resize(size int) {
+	h.cache.Resize(size)
+}
+
+// purge closes all the versions.
+func (h *historicalStores) purge() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.generation++
+	h.cache.Purge()
+}
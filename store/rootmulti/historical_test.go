@@ -0,0 +1,161 @@
+package rootmulti
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func latestAt(t *testing.T, ms *Store, version int64) string {
+	cms, err := ms.CacheMultiStoreWithVersion(version)
+	require.NoError(t, err)
+	return string(cms.GetKVStore(testStoreKey1).Get([]byte("latest")))
+}
+
+func TestCacheMultiStoreWithVersionHistoricalStores(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	ms.SetHistoricalStoreCacheSize(2)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(t, ms, 1, 3)
+
+	require.Equal(t, "1", latestAt(t, ms, 1))
+	require.Equal(t, "2", latestAt(t, ms, 2))
+	require.ElementsMatch(t, []int64{1, 2}, ms.historicalStores.cache.Keys())
+
+	// the queries at an open version share its stores
+	open, _ := ms.historicalStores.cache.Get(1)
+	require.Equal(t, "1", latestAt(t, ms, 1))
+	reopened, _ := ms.historicalStores.cache.Get(1)
+	require.Equal(t, open, reopened)
+
+	// opening a third version closes the least recently queried one
+	require.Equal(t, "3", latestAt(t, ms, 3))
+	require.ElementsMatch(t, []int64{1, 3}, ms.historicalStores.cache.Keys())
+
+	// versions that are not committed yet are not kept open
+	require.Equal(t, "", latestAt(t, ms, 4))
+	commitVersions(t, ms, 4, 4)
+	require.Equal(t, "4", latestAt(t, ms, 4))
+
+	// the versions are closed when the stores are loaded again
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Zero(t, ms.historicalStores.cache.Len())
+	require.Equal(t, "2", latestAt(t, ms, 2))
+}
+
+func TestResizeHistoricalStoreCache(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.Error(t, ms.ResizeHistoricalStoreCache(2))
+	ms.SetHistoricalStoreCacheSize(3)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(t, ms, 1, 3)
+	for version := int64(1); version <= 3; version++ {
+		require.Equal(t, fmt.Sprint(version), latestAt(t, ms, version))
+	}
+
+	// the least recently queried versions are closed
+	require.NoError(t, ms.ResizeHistoricalStoreCache(1))
+	require.ElementsMatch(t, []int64{3}, ms.historicalStores.cache.Keys())
+	require.NoError(t, ms.ResizeHistoricalStoreCache(2))
+	require.Equal(t, "1", latestAt(t, ms, 1))
+	require.ElementsMatch(t, []int64{1, 3}, ms.historicalStores.cache.Keys())
+	require.Error(t, ms.ResizeHistoricalStoreCache(0))
+}
+
+func TestHistoricalStoresClosedWhenPruned(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	ms.SetHistoricalStoreCacheSize(10)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(t, ms, 1, 3)
+
+	require.Equal(t, "1", latestAt(t, ms, 1))
+	require.Equal(t, "2", latestAt(t, ms, 2))
+	require.NoError(t, ms.deleteVersions([]int64{1}))
+	require.ElementsMatch(t, []int64{2}, ms.historicalStores.cache.Keys())
+	require.Equal(t, "", latestAt(t, ms, 1))
+}
+
+func TestHistoricalStoresLoadRacingRemove(t *testing.T) {
+	h := newHistoricalStores(10)
+
+	// a version deleted while it is being loaded is not kept open
+	stores, err := h.get(1, func() (map[types.StoreKey]*iavl.Store, error) {
+		h.remove(1)
+		return map[types.StoreKey]*iavl.Store{}, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, stores)
+	require.Zero(t, h.cache.Len())
+
+	_, err = h.get(2, func() (map[types.StoreKey]*iavl.Store, error) {
+		return nil, fmt.Errorf("load failed")
+	})
+	require.EqualError(t, err, "load failed")
+	require.Zero(t, h.cache.Len())
+}
+
+func TestHistoricalStoresSharedLoad(t *testing.T) {
+	h := newHistoricalStores(10)
+	release := make(chan struct{})
+	var loads int
+	load := func() (map[types.StoreKey]*iavl.Store, error) {
+		loads++
+		<-release
+		return map[types.StoreKey]*iavl.Store{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := h.get(1, load)
+			require.NoError(t, err)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	// the callers waiting on a running load share it
+	require.LessOrEqual(t, loads, 5)
+	require.Equal(t, 1, h.cache.Len())
+
+	// the open version is not loaded again
+	loaded := loads
+	_, err := h.get(1, load)
+	require.NoError(t, err)
+	require.Equal(t, loaded, loads)
+}
+
+func TestHistoricalQueriesConcurrentWithCommits(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	ms.SetHistoricalStoreCacheSize(3)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(t, ms, 1, 2)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				version := int64(i%2 + 1)
+				require.Equal(t, fmt.Sprint(version), latestAt(t, ms, version))
+			}
+		}(i)
+	}
+	commitVersions(t, ms, 3, 20)
+	close(done)
+	wg.Wait()
+}
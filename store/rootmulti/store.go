@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/cosmos/cosmos-sdk/telemetry"
@@ -21,11 +23,13 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+	"github.com/cosmos/cosmos-sdk/store/asyncdb"
 	"github.com/cosmos/cosmos-sdk/store/cachemulti"
 	"github.com/cosmos/cosmos-sdk/store/dbadapter"
 	"github.com/cosmos/cosmos-sdk/store/iavl"
 	"github.com/cosmos/cosmos-sdk/store/listenkv"
 	"github.com/cosmos/cosmos-sdk/store/mem"
+	"github.com/cosmos/cosmos-sdk/store/pruning"
 	"github.com/cosmos/cosmos-sdk/store/tracekv"
 	"github.com/cosmos/cosmos-sdk/store/transient"
 	"github.com/cosmos/cosmos-sdk/store/types"
@@ -42,11 +46,16 @@ const (
 
 const iavlDisablefastNodeDefault = true
 
+// restoreSealInterval is the number of snapshot nodes restored between seals
+// of the async DB, so that a restore does not buffer the whole state.
+const restoreSealInterval = 100000
+
 // Store is composed of many CommitStores. Name contrasts with
 // cacheMultiStore which is used for branching other MultiStores. It implements
 // the CommitMultiStore interface.
 type Store struct {
 	db                  dbm.DB
+	asyncDB             *asyncdb.DB
 	logger              log.Logger
 	archivalDb          dbm.DB
 	lastCommitInfo      *types.CommitInfo
@@ -54,11 +63,14 @@ type Store struct {
 	pruningOpts         types.PruningOptions
 	iavlCacheSize       int
 	iavlDisableFastNode bool
+	fastNodeMigration   iavl.FastNodeMigrationConfig
 	storesParams        map[types.StoreKey]storeParams
 	stores              map[types.StoreKey]types.CommitKVStore
 	keysByName          map[string]types.StoreKey
 	lazyLoading         bool
 	pruneHeights        []int64
+	pruningManager      *pruning.Manager
+	historicalStores    *historicalStores
 	initialVersion      int64
 	archivalVersion     int64
 	orphanOpts          *iavltree.Options
@@ -140,6 +152,92 @@ func (rs *Store) SetIAVLDisableFastNode(disableFastNode bool) {
 	rs.iavlDisableFastNode = disableFastNode
 }
 
+// SetFastNodeMigration configures the migration of the IAVL stores to fast
+// nodes, see iavl.MigrateFastNodes. It has no effect if the fast nodes are
+// disabled, and must be called before the store is loaded.
+func (rs *Store) SetFastNodeMigration(cfg iavl.FastNodeMigrationConfig) {
+	rs.fastNodeMigration = cfg
+}
+
+// SetAsyncCommit makes Commit return once the new version is written to
+// memory, the writes to the DB being done by a background writer with at most
+// maxPendingVersions versions waiting for it. Each version is written
+// atomically, so after a crash the DB holds the last version the writer
+// completed, and the blocks committed after it are replayed by Tendermint on
+// the handshake. Stores mounted with their own DB are written synchronously.
+// It must be called before the store is loaded.
+func (rs *Store) SetAsyncCommit(maxPendingVersions int) {
+	if rs.asyncDB != nil {
+		return
+	}
+	rs.asyncDB = asyncdb.New(rs.db, maxPendingVersions)
+	rs.db = rs.asyncDB
+}
+
+// FlushCommits waits until the versions committed so far are written to the
+// DB. It is a no-op if async commit is disabled.
+func (rs *Store) FlushCommits() error {
+	if rs.asyncDB == nil {
+		return nil
+	}
+	return rs.asyncDB.Flush()
+}
+
+// SetBackgroundPruning makes Commit only record the heights to prune, which
+// are then deleted by a background pruner every interval, at most batchSize
+// heights at a time (0 for no limit). Heights being exported by Snapshot are
+// not pruned until the export completes. It must be called before the store
+// is loaded, and StopPruning must be called before the DB is closed.
+func (rs *Store) SetBackgroundPruning(batchSize int, interval time.Duration) {
+	if rs.pruningManager != nil {
+		return
+	}
+	rs.pruningManager = pruning.NewManager(rs.logger, rs.deleteVersions, batchSize, interval)
+}
+
+// SetHistoricalStoreCacheSize keeps the IAVL stores of up to size past
+// versions open once loaded by CacheMultiStoreWithVersion, to be shared by the
+// queries at these heights. A size of 0 loads the stores for every query.
+func (rs *Store) SetHistoricalStoreCacheSize(size int) {
+	if size <= 0 {
+		rs.historicalStores = nil
+		return
+	}
+	rs.historicalStores = newHistoricalStores(size)
+}
+
+// ResizeHistoricalStoreCache changes the number of past versions kept open by
+// the cache enabled with SetHistoricalStoreCacheSize. Unlike the latter, it may
+// be called while queries are served, but cannot enable or disable the cache.
+func (rs *Store) ResizeHistoricalStoreCache(size int) error {
+	if rs.historicalStores == nil {
+		return errors.New("the historical store cache is disabled")
+	}
+	if size <= 0 {
+		return fmt.Errorf("invalid historical store cache size %d", size)
+	}
+	rs.historicalStores.resize(size)
+	return nil
+}
+
+// StopPruning stops the background pruner, waiting for a running batch to
+// complete. The heights left to prune are persisted on the next commit and
+// pruned once the store is loaded again. It is a no-op if background pruning
+// is disabled.
+func (rs *Store) StopPruning() {
+	if rs.pruningManager != nil {
+		rs.pruningManager.Stop()
+	}
+}
+
+// sealCommit hands the writes made since the last commit over to the
+// background writer.
+func (rs *Store) sealCommit() {
+	if err := rs.asyncDB.Seal(); err != nil {
+		panic(fmt.Errorf("error on async commit %w", err))
+	}
+}
+
 // SetLazyLoading sets if the iavl store should be loaded lazily or not
 func (rs *Store) SetLazyLoading(lazyLoading bool) {
 	rs.lazyLoading = lazyLoading
@@ -228,6 +326,13 @@ func (rs *Store) LoadVersion(ver int64) error {
 }
 
 func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
+	// the background pruner is restarted once the new stores are loaded
+	rs.StopPruning()
+	// the stores of past versions are loaded again from the new stores
+	if rs.historicalStores != nil {
+		rs.historicalStores.purge()
+	}
+
 	infos := make(map[string]types.StoreInfo)
 
 	cInfo := &types.CommitInfo{}
@@ -308,6 +413,11 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 	if err == nil && len(ph) > 0 {
 		rs.pruneHeights = ph
 	}
+	if rs.pruningManager != nil {
+		rs.pruningManager.Add(rs.pruneHeights...)
+		rs.pruneHeights = make([]int64, 0)
+		rs.pruningManager.Start()
+	}
 
 	return nil
 }
@@ -473,6 +583,11 @@ func (rs *Store) Commit(bumpVersion bool) types.CommitID {
 		version = c.GetVersion()
 	}
 
+	if rs.asyncDB != nil {
+		// deferred first so that it runs last, sealing the version with its
+		// metadata and pruning
+		defer rs.sealCommit()
+	}
 	rs.SetLastCommitInfo(commitStores(version, rs.stores, bumpVersion))
 	defer rs.flushMetadata(rs.db, version, rs.LastCommitInfo())
 
@@ -486,12 +601,17 @@ func (rs *Store) Commit(bumpVersion bool) types.CommitID {
 		// - KeepEvery % (height - KeepRecent) != 0 as that means the height is not
 		// a 'snapshot' height.
 		if rs.pruningOpts.KeepEvery == 0 || pruneHeight%int64(rs.pruningOpts.KeepEvery) != 0 {
-			rs.pruneHeights = append(rs.pruneHeights, pruneHeight)
+			if rs.pruningManager != nil {
+				rs.pruningManager.Add(pruneHeight)
+			} else {
+				rs.pruneHeights = append(rs.pruneHeights, pruneHeight)
+			}
 		}
 	}
 
-	// batch prune if the current height is a pruning interval height
-	if rs.pruningOpts.Interval > 0 && version%int64(rs.pruningOpts.Interval) == 0 {
+	// batch prune if the current height is a pruning interval height, unless
+	// the background pruner takes care of it
+	if rs.pruningManager == nil && rs.pruningOpts.Interval > 0 && version%int64(rs.pruningOpts.Interval) == 0 {
 		rs.PruneStores(true, nil)
 	}
 
@@ -513,23 +633,35 @@ func (rs *Store) PruneStores(clearStorePruningHeights bool, pruningHeights []int
 		return
 	}
 
+	if err := rs.deleteVersions(pruningHeights); err != nil {
+		panic(err)
+	}
+
+	if clearStorePruningHeights {
+		rs.pruneHeights = make([]int64, 0)
+	}
+}
+
+// deleteVersions deletes heights from each mounted IAVL sub-store, ignoring
+// the ones that do not exist anymore.
+func (rs *Store) deleteVersions(heights []int64) error {
 	for key, store := range rs.stores {
 		if store.GetStoreType() == types.StoreTypeIAVL {
 			// If the store is wrapped with an inter-block cache, we must first unwrap
 			// it to get the underlying IAVL store.
 			store = rs.GetCommitKVStore(key)
 
-			if err := store.(*iavl.Store).DeleteVersions(pruningHeights...); err != nil {
+			if err := store.(*iavl.Store).DeleteVersions(heights...); err != nil {
 				if errCause := errors.Cause(err); errCause != nil && errCause != iavltree.ErrVersionDoesNotExist {
-					panic(err)
+					return err
 				}
 			}
 		}
 	}
-
-	if clearStorePruningHeights {
-		rs.pruneHeights = make([]int64, 0)
+	if rs.historicalStores != nil {
+		rs.historicalStores.remove(heights...)
 	}
+	return nil
 }
 
 // CacheWrap implements CacheWrapper/Store/CommitStore.
@@ -562,24 +694,28 @@ func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 // any store cannot be loaded. This should only be used for querying and
 // iterating at past heights.
 func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStore, error) {
+	var (
+		iavlStores map[types.StoreKey]*iavl.Store
+		err        error
+	)
+	// the stores of versions that are not committed yet are empty, and are not
+	// kept open
+	if rs.historicalStores != nil && version <= rs.LastCommitInfo().GetVersion() {
+		iavlStores, err = rs.historicalStores.get(version, func() (map[types.StoreKey]*iavl.Store, error) {
+			return rs.loadIAVLStoresAt(version)
+		})
+	} else {
+		iavlStores, err = rs.loadIAVLStoresAt(version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	cachedStores := make(map[types.StoreKey]types.CacheWrapper)
 	for key, store := range rs.stores {
-		switch store.GetStoreType() {
-		case types.StoreTypeIAVL:
-			// If the store is wrapped with an inter-block cache, we must first unwrap
-			// it to get the underlying IAVL store.
-			store = rs.GetCommitKVStore(key)
-
-			// Attempt to lazy-load an already saved IAVL store version. If the
-			// version does not exist or is pruned, an error should be returned.
-			iavlStore, err := store.(*iavl.Store).GetImmutable(version)
-			if err != nil {
-				return nil, err
-			}
-
+		if iavlStore, ok := iavlStores[key]; ok {
 			cachedStores[key] = iavlStore
-
-		default:
+		} else {
 			cachedStores[key] = store
 		}
 	}
@@ -587,6 +723,28 @@ func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStor
 	return cachemulti.NewStore(rs.db, cachedStores, rs.keysByName, rs.traceWriter, rs.getTracingContext(), rs.listeners), nil
 }
 
+// loadIAVLStoresAt returns the immutable IAVL stores of version.
+func (rs *Store) loadIAVLStoresAt(version int64) (map[types.StoreKey]*iavl.Store, error) {
+	iavlStores := make(map[types.StoreKey]*iavl.Store)
+	for key, store := range rs.stores {
+		if store.GetStoreType() != types.StoreTypeIAVL {
+			continue
+		}
+		// If the store is wrapped with an inter-block cache, we must first unwrap
+		// it to get the underlying IAVL store.
+		store = rs.GetCommitKVStore(key)
+
+		// Attempt to lazy-load an already saved IAVL store version. If the
+		// version does not exist or is pruned, an error should be returned.
+		iavlStore, err := store.(*iavl.Store).GetImmutable(version)
+		if err != nil {
+			return nil, err
+		}
+		iavlStores[key] = iavlStore
+	}
+	return iavlStores, nil
+}
+
 // GetStore returns a mounted Store for a given StoreKey. If the StoreKey does
 // not exist, it will panic. If the Store is wrapped in an inter-block cache, it
 // will be unwrapped prior to being returned.
@@ -625,6 +783,17 @@ func (rs *Store) GetKVStore(key types.StoreKey) types.KVStore {
 	return store
 }
 
+// StoreKeys returns the keys of all mounted stores, sorted by name.
+func (rs *Store) StoreKeys() []types.StoreKey {
+	return keysForStoreKeyMap(rs.stores)
+}
+
+// SetKVStores is not supported on the root multi-store; branch it with
+// CacheMultiStore first.
+func (rs *Store) SetKVStores(handler func(key types.StoreKey, s types.KVStore) types.CacheWrap) types.MultiStore {
+	panic("SetKVStores is not implemented for rootmulti")
+}
+
 // GetStoreByName performs a lookup of a StoreKey given a store name typically
 // provided in a path. The StoreKey is then used to perform a lookup and return
 // a Store. If the Store is wrapped in an inter-block cache, it will be unwrapped
@@ -749,6 +918,11 @@ func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
 	if height > uint64(rs.LastCommitID().Version) {
 		return sdkerrors.Wrapf(sdkerrors.ErrLogic, "cannot snapshot future height %v", height)
 	}
+	if rs.pruningManager != nil {
+		// the export fails if the height is pruned while it is read
+		release := rs.pruningManager.Protect(int64(height))
+		defer release()
+	}
 
 	// Collect stores to snapshot (only IAVL stores are supported)
 	type namedStore struct {
@@ -853,6 +1027,7 @@ func (rs *Store) Restore(
 	// SnapshotNodeItem (i.e. ExportNode) until we reach the next SnapshotStoreItem or EOF.
 	var importer *iavltree.Importer
 	var snapshotItem snapshottypes.SnapshotItem
+	var restoredNodes int
 loop:
 	for {
 		snapshotItem = snapshottypes.SnapshotItem{}
@@ -908,6 +1083,12 @@ loop:
 			if err != nil {
 				return snapshottypes.SnapshotItem{}, sdkerrors.Wrap(err, "IAVL node import failed")
 			}
+			restoredNodes++
+			if rs.asyncDB != nil && restoredNodes%restoreSealInterval == 0 {
+				if err := rs.asyncDB.Seal(); err != nil {
+					return snapshottypes.SnapshotItem{}, err
+				}
+			}
 
 		default:
 			break loop
@@ -923,6 +1104,9 @@ loop:
 	}
 
 	rs.flushMetadata(rs.db, int64(height), rs.buildCommitInfo(int64(height)))
+	if err := rs.FlushCommits(); err != nil {
+		return snapshottypes.SnapshotItem{}, err
+	}
 	return snapshotItem, rs.LoadLatestVersion()
 }
 
@@ -949,10 +1133,20 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 		var store types.CommitKVStore
 		var err error
 
+		disableFastNode := rs.iavlDisableFastNode
+		if !disableFastNode {
+			upToDate, err := iavl.MigrateFastNodes(db, rs.logger, key, rs.fastNodeMigration)
+			if err != nil {
+				return nil, err
+			}
+			// a deferred migration must not be run by the iavl package either
+			disableFastNode = !upToDate
+		}
+
 		if params.initialVersion == 0 {
-			store, err = iavl.LoadStore(db, rs.logger, key, id, rs.lazyLoading, rs.iavlCacheSize, rs.iavlDisableFastNode, rs.orphanOpts)
+			store, err = iavl.LoadStore(db, rs.logger, key, id, rs.lazyLoading, rs.iavlCacheSize, disableFastNode, rs.orphanOpts)
 		} else {
-			store, err = iavl.LoadStoreWithInitialVersion(db, rs.logger, key, id, rs.lazyLoading, params.initialVersion, rs.iavlCacheSize, rs.iavlDisableFastNode, rs.orphanOpts)
+			store, err = iavl.LoadStoreWithInitialVersion(db, rs.logger, key, id, rs.lazyLoading, params.initialVersion, rs.iavlCacheSize, disableFastNode, rs.orphanOpts)
 		}
 
 		if err != nil {
@@ -1016,6 +1210,7 @@ func (rs *Store) RollbackToVersion(target int64) error {
 		return fmt.Errorf("invalid rollback height target: %d", target)
 	}
 
+	rs.StopPruning()
 	fmt.Printf("Target Version=%d\n", target)
 	for key, store := range rs.stores {
 		if store.GetStoreType() == types.StoreTypeIAVL {
@@ -1029,8 +1224,23 @@ func (rs *Store) RollbackToVersion(target int64) error {
 			fmt.Printf("Reset key=%s to height=%d\n", key.Name(), latestVersion)
 		}
 	}
+	// the heights above target do not exist anymore, and deleting them fails
+	// while they are above the latest version
+	pruneHeights := make([]int64, 0, len(rs.pruneHeights))
+	for _, height := range rs.pruneHeights {
+		if height <= target {
+			pruneHeights = append(pruneHeights, height)
+		}
+	}
+	rs.pruneHeights = pruneHeights
+	if rs.pruningManager != nil {
+		rs.pruningManager.DiscardAbove(target)
+	}
 	rs.SetLastCommitInfo(commitStores(target, rs.stores, false))
 	rs.flushMetadata(rs.db, target, rs.LastCommitInfo())
+	if err := rs.FlushCommits(); err != nil {
+		return err
+	}
 	return rs.LoadLatestVersion()
 }
 
@@ -1041,7 +1251,11 @@ func (rs *Store) flushMetadata(db dbm.DB, version int64, cInfo *types.CommitInfo
 		flushCommitInfo(batch, version, cInfo)
 	}
 	flushLatestVersion(batch, version)
-	flushPruningHeights(batch, rs.pruneHeights)
+	pruneHeights := rs.pruneHeights
+	if rs.pruningManager != nil {
+		pruneHeights = rs.pruningManager.PendingHeights()
+	}
+	flushPruningHeights(batch, pruneHeights)
 	if err := batch.WriteSync(); err != nil {
 		panic(fmt.Errorf("error on batch write %w", err))
 	}
@@ -1090,18 +1304,43 @@ func GetLatestVersion(db dbm.DB) int64 {
 
 // Commits each store and returns a new commitInfo.
 func commitStores(version int64, storeMap map[types.StoreKey]types.CommitKVStore, bumpVersion bool) *types.CommitInfo {
-	storeInfos := make([]types.StoreInfo, 0, len(storeMap))
-
-	for key, store := range storeMap {
-		commitID := store.Commit(bumpVersion)
+	// the stores are committed concurrently, each hashing its own tree, and
+	// their commit IDs are then collected in the order of their names
+	keys := keysForStoreKeyMap(storeMap)
+	commitIDs := make([]types.CommitID, len(keys))
+	panics := make([]interface{}, len(keys))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, store types.CommitKVStore) {
+			defer func() {
+				// a store failing to commit panics, which is raised again
+				// by the calling goroutine so that it can be recovered from
+				panics[i] = recover()
+				<-sem
+				wg.Done()
+			}()
+			commitIDs[i] = store.Commit(bumpVersion)
+		}(i, storeMap[key])
+	}
+	wg.Wait()
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
 
-		if store.GetStoreType() == types.StoreTypeTransient {
+	storeInfos := make([]types.StoreInfo, 0, len(storeMap))
+	for i, key := range keys {
+		if storeMap[key].GetStoreType() == types.StoreTypeTransient {
 			continue
 		}
 
 		si := types.StoreInfo{}
 		si.Name = key.Name()
-		si.CommitId = commitID
+		si.CommitId = commitIDs[i]
 		storeInfos = append(storeInfos, si)
 	}
 
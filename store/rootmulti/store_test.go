@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -134,6 +135,40 @@ func TestHashStableWithEmptyCommit(t *testing.T) {
 	require.Equal(t, hash, cID.Hash)
 }
 
+func TestCommitStoresDeterministic(t *testing.T) {
+	newStore := func() *Store {
+		store := NewStore(dbm.NewMemDB(), log.NewNopLogger())
+		for i := 0; i < 30; i++ {
+			store.MountStoreWithDB(types.NewKVStoreKey(fmt.Sprintf("store%02d", i)), types.StoreTypeIAVL, nil)
+		}
+		store.MountStoreWithDB(types.NewTransientStoreKey("transient"), types.StoreTypeTransient, nil)
+		require.NoError(t, store.LoadLatestVersion())
+		return store
+	}
+	store1, store2 := newStore(), newStore()
+
+	for version := 1; version <= 3; version++ {
+		for _, store := range []*Store{store1, store2} {
+			for i := 0; i < 30; i += version {
+				kv := store.GetStoreByName(fmt.Sprintf("store%02d", i)).(types.KVStore)
+				kv.Set([]byte(fmt.Sprintf("key%d", version)), []byte(fmt.Sprintf("value%d", i)))
+			}
+		}
+		cID1, cID2 := store1.Commit(true), store2.Commit(true)
+		require.Equal(t, cID1, cID2)
+
+		// the commit IDs of the stores, transient ones aside, are those they
+		// committed, in the order of their names
+		storeInfos := store1.LastCommitInfo().StoreInfos
+		require.Len(t, storeInfos, 30)
+		for i, si := range storeInfos {
+			require.Equal(t, fmt.Sprintf("store%02d", i), si.Name)
+			require.Equal(t, store1.GetStoreByName(si.Name).(types.CommitKVStore).LastCommitID(), si.CommitId)
+		}
+		require.Equal(t, storeInfos, store2.LastCommitInfo().StoreInfos)
+	}
+}
+
 func TestMultistoreCommitLoad(t *testing.T) {
 	var db dbm.DB = dbm.NewMemDB()
 	store := newMultiStoreWithMounts(db, types.PruneNothing)
@@ -757,6 +792,120 @@ func TestTraceConcurrency(t *testing.T) {
 	stopW <- struct{}{}
 }
 
+func TestMultiStoreBackgroundPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, types.PruneEverything)
+	ms.SetBackgroundPruning(3, time.Hour)
+	require.NoError(t, ms.LoadLatestVersion())
+	for i := int64(0); i < 10; i++ {
+		ms.Commit(true)
+	}
+
+	// the commits only record the heights, which are persisted until pruned
+	pruneHeights := []int64{1, 2, 3, 4, 5, 6, 7}
+	require.Equal(t, pruneHeights, ms.pruningManager.PendingHeights())
+	ph, err := getPruningHeights(ms.db)
+	require.NoError(t, err)
+	require.Equal(t, pruneHeights, ph)
+	for _, v := range pruneHeights {
+		require.True(t, versionExists(ms, v), "expected height to exist: %d", v)
+	}
+
+	// each run prunes a batch, oldest heights first
+	require.NoError(t, ms.pruningManager.Prune())
+	require.Equal(t, pruneHeights[3:], ms.pruningManager.PendingHeights())
+	for _, v := range pruneHeights[:3] {
+		require.False(t, versionExists(ms, v), "expected height to be pruned: %d", v)
+	}
+
+	// "restart", the heights left are pruned by the new store in the background
+	ms.StopPruning()
+	ms = newMultiStoreWithMounts(db, types.PruneEverything)
+	ms.SetBackgroundPruning(0, time.Millisecond)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Eventually(t, func() bool {
+		return len(ms.pruningManager.PendingHeights()) == 0
+	}, time.Second, time.Millisecond)
+	ms.StopPruning()
+	for _, v := range pruneHeights {
+		require.False(t, versionExists(ms, v), "expected height to be pruned: %d", v)
+	}
+	for _, v := range []int64{8, 9, 10} {
+		require.True(t, versionExists(ms, v), "expected height to exist: %d", v)
+	}
+}
+
+func versionExists(ms *Store, version int64) bool {
+	return ms.GetCommitKVStore(testStoreKey1).(*iavl.Store).VersionExists(version)
+}
+
+// pruningWriter runs the background pruner in the middle of a snapshot.
+type pruningWriter struct {
+	ms     *Store
+	pruned bool
+}
+
+func (w *pruningWriter) WriteMsg(proto.Message) error {
+	if !w.pruned {
+		w.pruned = true
+		return w.ms.pruningManager.Prune()
+	}
+	return nil
+}
+
+func (w *pruningWriter) Close() error {
+	return nil
+}
+
+func TestMultiStoreBackgroundPruningDuringSnapshot(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneEverything)
+	ms.SetBackgroundPruning(0, time.Hour)
+	require.NoError(t, ms.LoadLatestVersion())
+	store := ms.GetStoreByName("store1").(types.KVStore)
+	for i := 0; i < 6; i++ {
+		store.Set([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		ms.Commit(true)
+	}
+	require.Equal(t, []int64{1, 2, 3}, ms.pruningManager.PendingHeights())
+
+	// the height being exported is left for a later run
+	require.NoError(t, ms.Snapshot(3, &pruningWriter{ms: ms}))
+	require.Equal(t, []int64{3}, ms.pruningManager.PendingHeights())
+
+	require.NoError(t, ms.pruningManager.Prune())
+	require.Empty(t, ms.pruningManager.PendingHeights())
+	require.False(t, versionExists(ms, 3))
+}
+
+func TestRollbackToVersionPruneHeights(t *testing.T) {
+	for _, background := range []bool{false, true} {
+		db := dbm.NewMemDB()
+		ms := newMultiStoreWithMounts(db, types.NewPruningOptions(2, 0, 100))
+		if background {
+			ms.SetBackgroundPruning(0, time.Hour)
+		}
+		require.NoError(t, ms.LoadLatestVersion())
+		commitVersions(t, ms, 1, 10)
+
+		// the heights above the target are not pruned anymore
+		require.NoError(t, ms.RollbackToVersion(5))
+		require.Equal(t, int64(5), ms.LastCommitID().Version)
+		for v := int64(6); v <= 10; v++ {
+			require.False(t, versionExists(ms, v), "expected height to be deleted: %d", v)
+		}
+		pruneHeights := []int64{1, 2, 3, 4, 5}
+		if background {
+			require.Equal(t, pruneHeights, ms.pruningManager.PendingHeights())
+			ms.StopPruning()
+		} else {
+			require.Equal(t, pruneHeights, ms.pruneHeights)
+		}
+		ph, err := getPruningHeights(ms.db)
+		require.NoError(t, err)
+		require.Equal(t, pruneHeights, ph)
+	}
+}
+
 //-----------------------------------------------------------------------
 // utils
 
@@ -888,3 +1037,88 @@ func TestStateListeners(t *testing.T) {
 	cacheMulti.Write()
 	require.Equal(t, 1, len(listener.stateCache))
 }
+
+// stalledDB is a MemDB whose synced batch writes wait on unblock, standing in
+// for a disk that falls behind the async writer.
+type stalledDB struct {
+	*dbm.MemDB
+	unblock chan struct{}
+}
+
+type stalledBatch struct {
+	dbm.Batch
+	unblock chan struct{}
+}
+
+func (db stalledDB) NewBatch() dbm.Batch {
+	return stalledBatch{Batch: db.MemDB.NewBatch(), unblock: db.unblock}
+}
+
+func (b stalledBatch) WriteSync() error {
+	<-b.unblock
+	return b.Batch.WriteSync()
+}
+
+// commitVersions writes a few keys to every store of ms and commits, once per
+// version in [from, to].
+func commitVersions(t *testing.T, ms *Store, from, to int64) []types.CommitID {
+	var ids []types.CommitID
+	for version := from; version <= to; version++ {
+		for _, key := range []types.StoreKey{testStoreKey1, testStoreKey2, testStoreKey3} {
+			kv := ms.GetStoreByName(key.Name()).(types.KVStore)
+			kv.Set([]byte(fmt.Sprintf("key%d", version)), []byte(key.Name()))
+			kv.Set([]byte("latest"), []byte(fmt.Sprint(version)))
+			kv.Delete([]byte(fmt.Sprintf("key%d", version-2)))
+		}
+		id := ms.Commit(true)
+		require.Equal(t, version, id.Version)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestMultiStoreAsyncCommit(t *testing.T) {
+	syncStore := newMultiStoreWithMounts(dbm.NewMemDB(), types.NewPruningOptions(2, 3, 1))
+	require.NoError(t, syncStore.LoadLatestVersion())
+	expected := commitVersions(t, syncStore, 1, 10)
+
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, types.NewPruningOptions(2, 3, 1))
+	ms.SetAsyncCommit(2)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, expected, commitVersions(t, ms, 1, 10))
+	require.NoError(t, ms.FlushCommits())
+
+	// "restart" on the DB the versions were flushed to
+	ms = newMultiStoreWithMounts(db, types.NewPruningOptions(2, 3, 1))
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, expected[9], ms.LastCommitID())
+	_, err := ms.CacheMultiStoreWithVersion(9)
+	require.NoError(t, err)
+}
+
+func TestMultiStoreAsyncCommitCrashRecovery(t *testing.T) {
+	syncStore := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, syncStore.LoadLatestVersion())
+	expected := commitVersions(t, syncStore, 1, 5)
+
+	db := stalledDB{MemDB: dbm.NewMemDB(), unblock: make(chan struct{})}
+	ms := newMultiStoreWithMounts(db, types.PruneNothing)
+	ms.SetAsyncCommit(5)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, expected, commitVersions(t, ms, 1, 5))
+
+	// the node crashes once the first two versions are on disk
+	db.unblock <- struct{}{}
+	db.unblock <- struct{}{}
+	require.Eventually(t, func() bool {
+		return ms.asyncDB.Stats()["asyncdb.pending"] == "3"
+	}, time.Second, time.Millisecond)
+
+	// the restarted store is at the last flushed version, and replaying the
+	// later blocks gives back the same hashes
+	restarted := newMultiStoreWithMounts(db.MemDB, types.PruneNothing)
+	require.NoError(t, restarted.LoadLatestVersion())
+	require.Equal(t, expected[1], restarted.LastCommitID())
+	require.Equal(t, expected[2:], commitVersions(t, restarted, 3, 5))
+}
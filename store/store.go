@@ -20,3 +20,12 @@ func NewCommitMultiStoreWithArchival(db dbm.DB, archivalDb dbm.DB, archivalVersi
 func NewCommitKVStoreCacheManager() types.MultiStorePersistentCache {
 	return cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize, types.DefaultCacheSizeLimit)
 }
+
+// NewCommitKVStoreCacheManagerWithSize returns an inter-block cache keeping up
+// to size bytes of keys and values per store. A size of 0 uses the default.
+func NewCommitKVStoreCacheManagerWithSize(size uint) types.MultiStorePersistentCache {
+	if size == 0 {
+		size = cache.DefaultCommitKVStoreCacheSize
+	}
+	return cache.NewCommitKVStoreCacheManager(size, types.DefaultCacheSizeLimit)
+}
@@ -0,0 +1,748 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/base/streaming/v1beta1/block_results.proto
+
+package blockresults
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	types "github.com/tendermint/tendermint/abci/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SubscribeRequest is the request type for the BlockResults/Subscribe RPC method.
+type SubscribeRequest struct {
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cede792737c3525, []int{0}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+// BlockResult is the execution result of a committed block.
+type BlockResult struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// begin_block_events are the events emitted by BeginBlock.
+	BeginBlockEvents []types.Event `protobuf:"bytes,2,rep,name=begin_block_events,json=beginBlockEvents,proto3" json:"begin_block_events"`
+	// tx_results are the results of the txs of the block, in order.
+	TxResults []*types.ResponseDeliverTx `protobuf:"bytes,3,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+	// end_block_events are the events emitted by EndBlock.
+	EndBlockEvents []types.Event `protobuf:"bytes,4,rep,name=end_block_events,json=endBlockEvents,proto3" json:"end_block_events"`
+}
+
+func (m *BlockResult) Reset()         { *m = BlockResult{} }
+func (m *BlockResult) String() string { return proto.CompactTextString(m) }
+func (*BlockResult) ProtoMessage()    {}
+func (*BlockResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cede792737c3525, []int{1}
+}
+func (m *BlockResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResult.Merge(m, src)
+}
+func (m *BlockResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResult proto.InternalMessageInfo
+
+func (m *BlockResult) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockResult) GetBeginBlockEvents() []types.Event {
+	if m != nil {
+		return m.BeginBlockEvents
+	}
+	return nil
+}
+
+func (m *BlockResult) GetTxResults() []*types.ResponseDeliverTx {
+	if m != nil {
+		return m.TxResults
+	}
+	return nil
+}
+
+func (m *BlockResult) GetEndBlockEvents() []types.Event {
+	if m != nil {
+		return m.EndBlockEvents
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SubscribeRequest)(nil), "cosmos.base.streaming.v1beta1.SubscribeRequest")
+	proto.RegisterType((*BlockResult)(nil), "cosmos.base.streaming.v1beta1.BlockResult")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/base/streaming/v1beta1/block_results.proto", fileDescriptor_2cede792737c3525)
+}
+
+var fileDescriptor_2cede792737c3525 = []byte{
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcf, 0x6e, 0xda, 0x40,
+	0x10, 0xc6, 0xed, 0x82, 0x90, 0x58, 0xaa, 0x0a, 0xad, 0x2a, 0x84, 0xa8, 0xea, 0x22, 0x4e, 0xa8,
+	0x52, 0x77, 0x0b, 0x3d, 0xf5, 0x58, 0xd4, 0xf6, 0xd0, 0xa3, 0xe9, 0x29, 0x17, 0xe4, 0xb5, 0x47,
+	0x66, 0x03, 0xde, 0x25, 0x9e, 0x35, 0x22, 0x79, 0x8a, 0x3c, 0x16, 0x47, 0x8e, 0x39, 0x45, 0x11,
+	0xbc, 0x46, 0x0e, 0x91, 0xd7, 0x0e, 0x71, 0x12, 0x29, 0xc9, 0xc9, 0x7f, 0x76, 0x7f, 0xf3, 0x7d,
+	0xf3, 0xcd, 0x90, 0x51, 0xa8, 0x31, 0xd1, 0xc8, 0x45, 0x80, 0xc0, 0xd1, 0xa4, 0x10, 0x24, 0x52,
+	0xc5, 0x7c, 0x3d, 0x12, 0x60, 0x82, 0x11, 0x17, 0x4b, 0x1d, 0x2e, 0x66, 0x29, 0x60, 0xb6, 0x34,
+	0xc8, 0x56, 0xa9, 0x36, 0x9a, 0x7e, 0x2e, 0x10, 0x96, 0x23, 0xec, 0x88, 0xb0, 0x12, 0xe9, 0x7d,
+	0x8c, 0x75, 0xac, 0xed, 0x4d, 0x9e, 0xbf, 0x15, 0x50, 0xef, 0x93, 0x01, 0x15, 0x41, 0x9a, 0x48,
+	0x65, 0x78, 0x20, 0x42, 0xc9, 0xcd, 0xf9, 0x0a, 0xca, 0x8a, 0x03, 0x4a, 0xda, 0xd3, 0x4c, 0x60,
+	0x98, 0x4a, 0x01, 0x3e, 0x9c, 0x65, 0x80, 0x66, 0x70, 0xeb, 0x92, 0xd6, 0x24, 0x57, 0xf7, 0xad,
+	0x38, 0xed, 0x90, 0xc6, 0x1c, 0x64, 0x3c, 0x37, 0x5d, 0xb7, 0xef, 0x0e, 0x6b, 0x7e, 0xf9, 0x45,
+	0xff, 0x11, 0x2a, 0x20, 0x96, 0x6a, 0x56, 0x58, 0x85, 0x35, 0x28, 0x83, 0xdd, 0x77, 0xfd, 0xda,
+	0xb0, 0x35, 0xee, 0xb0, 0x07, 0x55, 0x96, 0xab, 0xb2, 0x3f, 0xf9, 0xf1, 0xa4, 0xbe, 0xbd, 0xfe,
+	0xe2, 0xf8, 0x6d, 0xcb, 0x59, 0x0d, 0xfb, 0x1b, 0xe9, 0x2f, 0x42, 0xcc, 0xe6, 0xbe, 0xdb, 0x6e,
+	0xcd, 0xd6, 0x18, 0x3c, 0xab, 0xe1, 0x03, 0xae, 0xb4, 0x42, 0xf8, 0x0d, 0x4b, 0xb9, 0x86, 0xf4,
+	0xff, 0xc6, 0x6f, 0x9a, 0x4d, 0xe1, 0x12, 0xe9, 0x5f, 0xd2, 0x06, 0x15, 0x3d, 0x36, 0x53, 0x7f,
+	0x83, 0x99, 0x0f, 0xa0, 0xa2, 0x8a, 0x95, 0xf1, 0x05, 0x79, 0x5f, 0xe9, 0x1e, 0xe9, 0x29, 0x69,
+	0x1e, 0x23, 0xa2, 0x9c, 0xbd, 0x38, 0x02, 0xf6, 0x34, 0xcc, 0xde, 0xd7, 0x57, 0x80, 0x8a, 0xd4,
+	0x77, 0x77, 0x32, 0xdd, 0xee, 0x3d, 0x77, 0xb7, 0xf7, 0xdc, 0x9b, 0xbd, 0xe7, 0x5e, 0x1e, 0x3c,
+	0x67, 0x77, 0xf0, 0x9c, 0xab, 0x83, 0xe7, 0x9c, 0xfc, 0x8c, 0xa5, 0x99, 0x67, 0x82, 0x85, 0x3a,
+	0xe1, 0xe5, 0xe2, 0x14, 0x8f, 0x6f, 0x18, 0x2d, 0x38, 0x1a, 0x9d, 0x56, 0x97, 0xc8, 0x86, 0x50,
+	0xa6, 0x29, 0x1a, 0x76, 0xd4, 0x3f, 0xee, 0x06, 0x00, 0x7c, 0xc9, 0x95, 0x19, 0x71, 0x02, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BlockResultsClient is the client API for BlockResults service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockResultsClient interface {
+	// Subscribe streams the result of each block committed after the
+	// subscription, in height order, the response headers being sent once the
+	// subscription is active. A subscriber falling behind the node is
+	// disconnected with a RESOURCE_EXHAUSTED status, and should query the blocks
+	// it missed before subscribing again.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (BlockResults_SubscribeClient, error)
+}
+
+type blockResultsClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewBlockResultsClient(cc grpc1.ClientConn) BlockResultsClient {
+	return &blockResultsClient{cc}
+}
+
+func (c *blockResultsClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (BlockResults_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockResults_serviceDesc.Streams[0], "/cosmos.base.streaming.v1beta1.BlockResults/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockResultsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockResults_SubscribeClient interface {
+	Recv() (*BlockResult, error)
+	grpc.ClientStream
+}
+
+type blockResultsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockResultsSubscribeClient) Recv() (*BlockResult, error) {
+	m := new(BlockResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockResultsServer is the server API for BlockResults service.
+type BlockResultsServer interface {
+	// Subscribe streams the result of each block committed after the
+	// subscription, in height order, the response headers being sent once the
+	// subscription is active. A subscriber falling behind the node is
+	// disconnected with a RESOURCE_EXHAUSTED status, and should query the blocks
+	// it missed before subscribing again.
+	Subscribe(*SubscribeRequest, BlockResults_SubscribeServer) error
+}
+
+// UnimplementedBlockResultsServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockResultsServer struct {
+}
+
+func (*UnimplementedBlockResultsServer) Subscribe(req *SubscribeRequest, srv BlockResults_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterBlockResultsServer(s grpc1.Server, srv BlockResultsServer) {
+	s.RegisterService(&_BlockResults_serviceDesc, srv)
+}
+
+func _BlockResults_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockResultsServer).Subscribe(m, &blockResultsSubscribeServer{stream})
+}
+
+type BlockResults_SubscribeServer interface {
+	Send(*BlockResult) error
+	grpc.ServerStream
+}
+
+type blockResultsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockResultsSubscribeServer) Send(m *BlockResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlockResults_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.base.streaming.v1beta1.BlockResults",
+	HandlerType: (*BlockResultsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _BlockResults_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cosmos/base/streaming/v1beta1/block_results.proto",
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EndBlockEvents) > 0 {
+		for iNdEx := len(m.EndBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EndBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlockResults(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.TxResults) > 0 {
+		for iNdEx := len(m.TxResults) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxResults[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlockResults(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.BeginBlockEvents) > 0 {
+		for iNdEx := len(m.BeginBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BeginBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBlockResults(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockResults(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintBlockResults(dAtA []byte, offset int, v uint64) int {
+	offset -= sovBlockResults(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *BlockResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockResults(uint64(m.Height))
+	}
+	if len(m.BeginBlockEvents) > 0 {
+		for _, e := range m.BeginBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovBlockResults(uint64(l))
+		}
+	}
+	if len(m.TxResults) > 0 {
+		for _, e := range m.TxResults {
+			l = e.Size()
+			n += 1 + l + sovBlockResults(uint64(l))
+		}
+	}
+	if len(m.EndBlockEvents) > 0 {
+		for _, e := range m.EndBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovBlockResults(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovBlockResults(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozBlockResults(x uint64) (n int) {
+	return sovBlockResults(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockResults
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockResults(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockResults
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginBlockEvents = append(m.BeginBlockEvents, types.Event{})
+			if err := m.BeginBlockEvents[len(m.BeginBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxResults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxResults = append(m.TxResults, &types.ResponseDeliverTx{})
+			if err := m.TxResults[len(m.TxResults)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndBlockEvents = append(m.EndBlockEvents, types.Event{})
+			if err := m.EndBlockEvents[len(m.EndBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockResults(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlockResults
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipBlockResults(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowBlockResults
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowBlockResults
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthBlockResults
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupBlockResults
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthBlockResults
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthBlockResults        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowBlockResults          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupBlockResults = fmt.Errorf("proto: unexpected end of group")
+)
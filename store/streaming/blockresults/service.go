@@ -0,0 +1,188 @@
+package blockresults
+
+import (
+	"context"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultBufferSize is the default number of block results that may wait to be
+// sent to a subscriber before it is disconnected.
+const DefaultBufferSize = 100
+
+var (
+	_ baseapp.StreamingService = (*Service)(nil)
+	_ baseapp.CommitListener   = (*Service)(nil)
+	_ BlockResultsServer       = (*Service)(nil)
+)
+
+// Service is a StreamingService collecting the results of the blocks executed
+// by the BaseApp, which it serves to the subscribers of the BlockResults gRPC
+// service once the blocks are committed. It does not listen to any store.
+//
+// The results are pushed to each subscriber through a buffer of bufferSize
+// blocks, so that a slow subscriber never delays the commits of the node: one
+// whose buffer is full is disconnected instead.
+type Service struct {
+	bufferSize int
+
+	mtx         sync.Mutex
+	pending     *BlockResult
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// subscriber receives the committed block results until results is closed, at
+// which point err tells why.
+type subscriber struct {
+	results chan *BlockResult
+	err     error
+}
+
+// NewService creates a Service buffering up to bufferSize block results for
+// each subscriber. A bufferSize of 0 means DefaultBufferSize.
+func NewService(bufferSize int) *Service {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Service{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Stream satisfies the baseapp.StreamingService interface. The results are
+// pushed to the subscribers by ListenCommit, so there is no loop to run.
+func (s *Service) Stream(_ *sync.WaitGroup) error {
+	return nil
+}
+
+// Listeners satisfies the baseapp.StreamingService interface.
+func (s *Service) Listeners() map[types.StoreKey][]types.WriteListener {
+	return nil
+}
+
+// ListenBeginBlock starts the result of a block with its BeginBlock events. A
+// block whose execution is started over, e.g. after an optimistic execution
+// was discarded, starts over its result too.
+func (s *Service) ListenBeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock, res abci.ResponseBeginBlock) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pending = &BlockResult{Height: ctx.BlockHeight(), BeginBlockEvents: res.Events}
+	return nil
+}
+
+// ListenDeliverTx adds the result of a tx to the result of its block.
+func (s *Service) ListenDeliverTx(ctx sdk.Context, _ abci.RequestDeliverTx, res abci.ResponseDeliverTx) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	result := s.pendingResult(ctx.BlockHeight())
+	result.TxResults = append(result.TxResults, &res)
+	return nil
+}
+
+// ListenEndBlock completes the result of a block with its EndBlock events.
+func (s *Service) ListenEndBlock(ctx sdk.Context, _ abci.RequestEndBlock, res abci.ResponseEndBlock) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pendingResult(ctx.BlockHeight()).EndBlockEvents = res.Events
+	return nil
+}
+
+// pendingResult returns the result of the block being executed at height.
+func (s *Service) pendingResult(height int64) *BlockResult {
+	if s.pending == nil || s.pending.Height != height {
+		s.pending = &BlockResult{Height: height}
+	}
+	return s.pending
+}
+
+// ListenCommit pushes the result of the committed block to the subscribers,
+// disconnecting the ones that fell behind.
+func (s *Service) ListenCommit(_ context.Context, height int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	result := s.pendingResult(height)
+	s.pending = nil
+	for sub := range s.subscribers {
+		select {
+		case sub.results <- result:
+		default:
+			telemetry.IncrCounter(1, "streaming", "block_results", "dropped_subscribers")
+			s.removeLocked(sub, status.Errorf(codes.ResourceExhausted, "subscriber fell more than %d blocks behind", s.bufferSize))
+		}
+	}
+	return nil
+}
+
+// Subscribe implements the BlockResults/Subscribe RPC method.
+func (s *Service) Subscribe(_ *SubscribeRequest, stream BlockResults_SubscribeServer) error {
+	sub, err := s.subscribe()
+	if err != nil {
+		return err
+	}
+	defer s.unsubscribe(sub)
+	// the headers tell the subscriber that the blocks committed from now on
+	// are streamed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case result, ok := <-sub.results:
+			if !ok {
+				return sub.err
+			}
+			if err := stream.Send(result); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *Service) subscribe() (*subscriber, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return nil, status.Error(codes.Unavailable, "block results service is closed")
+	}
+	sub := &subscriber{results: make(chan *BlockResult, s.bufferSize)}
+	s.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+func (s *Service) unsubscribe(sub *subscriber) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	// a subscriber removed by the service has its results closed already
+	delete(s.subscribers, sub)
+}
+
+// removeLocked disconnects sub with err once it sent the results it holds.
+func (s *Service) removeLocked(sub *subscriber, err error) {
+	sub.err = err
+	close(sub.results)
+	delete(s.subscribers, sub)
+}
+
+// Close disconnects the subscribers once they sent the results they hold.
+func (s *Service) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	for sub := range s.subscribers {
+		s.removeLocked(sub, status.Error(codes.Unavailable, "block results service is closed"))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package blockresults
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func startServer(t *testing.T, s *Service) BlockResultsClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcSrv := grpc.NewServer()
+	RegisterBlockResultsServer(grpcSrv, s)
+	go grpcSrv.Serve(lis) //nolint:errcheck
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewBlockResultsClient(conn)
+}
+
+func (s *Service) numSubscribers() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.subscribers)
+}
+
+// executeBlock passes the execution of a block with a tx per result in
+// txResults to s, and commits it.
+func executeBlock(t *testing.T, s *Service, height int64, txResults ...abci.ResponseDeliverTx) {
+	ctx := sdk.Context{}.WithBlockHeader(tmproto.Header{Height: height})
+	require.NoError(t, s.ListenBeginBlock(ctx, abci.RequestBeginBlock{}, abci.ResponseBeginBlock{
+		Events: []abci.Event{{Type: "begin"}},
+	}))
+	for _, res := range txResults {
+		require.NoError(t, s.ListenDeliverTx(ctx, abci.RequestDeliverTx{}, res))
+	}
+	require.NoError(t, s.ListenEndBlock(ctx, abci.RequestEndBlock{}, abci.ResponseEndBlock{
+		Events: []abci.Event{{Type: "end"}},
+	}))
+	require.NoError(t, s.ListenCommit(context.Background(), height))
+}
+
+func TestSubscribe(t *testing.T) {
+	s := NewService(0)
+	client := startServer(t, s)
+
+	// the blocks committed before the subscription are not streamed
+	executeBlock(t, s, 1, abci.ResponseDeliverTx{Code: 1})
+
+	stream, err := client.Subscribe(context.Background(), &SubscribeRequest{})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+	require.Equal(t, 1, s.numSubscribers())
+
+	executeBlock(t, s, 2, abci.ResponseDeliverTx{GasUsed: 1}, abci.ResponseDeliverTx{Code: 2, Log: "failed"})
+	// a block whose execution started over only streams its last execution
+	executeBlock(t, s, 3, abci.ResponseDeliverTx{GasUsed: 2})
+	require.NoError(t, s.ListenBeginBlock(sdk.Context{}.WithBlockHeader(tmproto.Header{Height: 4}), abci.RequestBeginBlock{}, abci.ResponseBeginBlock{}))
+	executeBlock(t, s, 4)
+
+	result, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, &BlockResult{
+		Height:           2,
+		BeginBlockEvents: []abci.Event{{Type: "begin"}},
+		TxResults:        []*abci.ResponseDeliverTx{{GasUsed: 1}, {Code: 2, Log: "failed"}},
+		EndBlockEvents:   []abci.Event{{Type: "end"}},
+	}, result)
+	result, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Height)
+	require.Equal(t, []*abci.ResponseDeliverTx{{GasUsed: 2}}, result.TxResults)
+	result, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), result.Height)
+	require.Empty(t, result.TxResults)
+
+	// closing the service ends the subscriptions
+	require.NoError(t, s.Close())
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	stream, err = client.Subscribe(context.Background(), &SubscribeRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSubscriberFallingBehind(t *testing.T) {
+	s := NewService(1)
+	sub, err := s.subscribe()
+	require.NoError(t, err)
+
+	executeBlock(t, s, 1)
+	executeBlock(t, s, 2)
+	require.Zero(t, s.numSubscribers())
+
+	// the buffered result is still sent before the subscriber is disconnected
+	result, ok := <-sub.results
+	require.True(t, ok)
+	require.Equal(t, int64(1), result.Height)
+	_, ok = <-sub.results
+	require.False(t, ok)
+	require.Equal(t, codes.ResourceExhausted, status.Code(sub.err))
+	// the subscriber ending its subscription afterwards is a no-op
+	s.unsubscribe(sub)
+}
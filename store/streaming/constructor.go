@@ -9,6 +9,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	serverTypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/store/streaming/file"
+	"github.com/cosmos/cosmos-sdk/store/streaming/grpcsink"
+	"github.com/cosmos/cosmos-sdk/store/streaming/kafkasink"
+	"github.com/cosmos/cosmos-sdk/store/streaming/parquet"
 	"github.com/cosmos/cosmos-sdk/store/types"
 
 	"github.com/spf13/cast"
@@ -23,6 +26,9 @@ type ServiceType int
 const (
 	Unknown ServiceType = iota
 	File
+	GRPC
+	Kafka
+	Parquet
 	// add more in the future
 )
 
@@ -31,6 +37,12 @@ func ServiceTypeFromString(name string) ServiceType {
 	switch strings.ToLower(name) {
 	case "file", "f":
 		return File
+	case "grpc":
+		return GRPC
+	case "kafka":
+		return Kafka
+	case "parquet":
+		return Parquet
 	default:
 		return Unknown
 	}
@@ -41,6 +53,12 @@ func (sst ServiceType) String() string {
 	switch sst {
 	case File:
 		return "file"
+	case GRPC:
+		return "grpc"
+	case Kafka:
+		return "kafka"
+	case Parquet:
+		return "parquet"
 	default:
 		return "unknown"
 	}
@@ -48,13 +66,30 @@ func (sst ServiceType) String() string {
 
 // ServiceConstructorLookupTable is a mapping of streaming.ServiceTypes to streaming.ServiceConstructors
 var ServiceConstructorLookupTable = map[ServiceType]ServiceConstructor{
-	File: NewFileStreamingService,
+	File:    NewFileStreamingService,
+	GRPC:    NewSinkServiceConstructor("grpc", newGRPCSink),
+	Kafka:   NewSinkServiceConstructor("kafka", newKafkaSink),
+	Parquet: NewParquetStreamingService,
+}
+
+// customServiceConstructors holds the constructors registered by the App for
+// the names that are not a streaming.ServiceType
+var customServiceConstructors = map[string]ServiceConstructor{}
+
+// RegisterServiceConstructor registers the streaming.ServiceConstructor of a custom
+// streaming service, e.g. one built with NewSinkServiceConstructor, which can then be
+// enabled under the provided name in "store.streamers"
+func RegisterServiceConstructor(name string, constructor ServiceConstructor) {
+	customServiceConstructors[strings.ToLower(name)] = constructor
 }
 
 // NewServiceConstructor returns the streaming.ServiceConstructor corresponding to the provided name
 func NewServiceConstructor(name string) (ServiceConstructor, error) {
 	ssType := ServiceTypeFromString(name)
 	if ssType == Unknown {
+		if constructor, ok := customServiceConstructors[strings.ToLower(name)]; ok {
+			return constructor, nil
+		}
 		return nil, fmt.Errorf("unrecognized streaming service name %s", name)
 	}
 	if constructor, ok := ServiceConstructorLookupTable[ssType]; ok && constructor != nil {
@@ -70,6 +105,61 @@ func NewFileStreamingService(opts serverTypes.AppOptions, keys []types.StoreKey,
 	return file.NewStreamingService(fileDir, filePrefix, keys, marshaller)
 }
 
+// NewParquetStreamingService is the streaming.ServiceConstructor function for creating a
+// parquet.Service exporting the results of the blocks, and the changes of the provided keys
+// if "streamers.parquet.store_deltas" is set, to Parquet files
+func NewParquetStreamingService(opts serverTypes.AppOptions, keys []types.StoreKey, _ codec.BinaryCodec) (baseapp.StreamingService, error) {
+	compression, err := parquet.ParseCodec(cast.ToString(opts.Get("streamers.parquet.compression")))
+	if err != nil {
+		return nil, err
+	}
+	return parquet.NewService(parquet.Config{
+		WriteDir:      cast.ToString(opts.Get("streamers.parquet.write_dir")),
+		PartitionSize: cast.ToInt64(opts.Get("streamers.parquet.partition_size")),
+		RowGroupSize:  cast.ToInt(opts.Get("streamers.parquet.row_group_size")),
+		Codec:         compression,
+		StoreDeltas:   cast.ToBool(opts.Get("streamers.parquet.store_deltas")),
+	}, keys)
+}
+
+// SinkConstructor is used to construct a Sink from the "streamers.<name>" options
+type SinkConstructor func(opts serverTypes.AppOptions, name string) (Sink, error)
+
+// NewSinkServiceConstructor returns the streaming.ServiceConstructor creating a SinkService
+// for the Sink built by newSink, with the "streamers.<name>.buffer_size" option setting
+// the number of blocks that may wait to be delivered
+func NewSinkServiceConstructor(name string, newSink SinkConstructor) ServiceConstructor {
+	return func(opts serverTypes.AppOptions, keys []types.StoreKey, _ codec.BinaryCodec) (baseapp.StreamingService, error) {
+		sink, err := newSink(opts, name)
+		if err != nil {
+			return nil, err
+		}
+		bufferSize := cast.ToInt(opts.Get(fmt.Sprintf("streamers.%s.buffer_size", name)))
+		return NewSinkService(sink, keys, bufferSize), nil
+	}
+}
+
+func newGRPCSink(opts serverTypes.AppOptions, name string) (Sink, error) {
+	address := cast.ToString(opts.Get(fmt.Sprintf("streamers.%s.address", name)))
+	if address == "" {
+		return nil, fmt.Errorf("streamers.%s.address is required", name)
+	}
+	timeout, err := cast.ToDurationE(opts.Get(fmt.Sprintf("streamers.%s.timeout", name)))
+	if err != nil {
+		return nil, err
+	}
+	return grpcsink.NewSink(address, timeout)
+}
+
+func newKafkaSink(opts serverTypes.AppOptions, name string) (Sink, error) {
+	brokers := cast.ToStringSlice(opts.Get(fmt.Sprintf("streamers.%s.brokers", name)))
+	topic := cast.ToString(opts.Get(fmt.Sprintf("streamers.%s.topic", name)))
+	if len(brokers) == 0 || topic == "" {
+		return nil, fmt.Errorf("streamers.%s.brokers and streamers.%s.topic are required", name, name)
+	}
+	return kafkasink.NewSink(brokers, topic), nil
+}
+
 // LoadStreamingServices is a function for loading StreamingServices onto the BaseApp using the provided AppOptions, codec, and keys
 // It returns the WaitGroup and quit channel used to synchronize with the streaming services and any error that occurs during the setup
 func LoadStreamingServices(bApp *baseapp.BaseApp, appOpts serverTypes.AppOptions, appCodec codec.BinaryCodec, keys map[string]*types.KVStoreKey) ([]baseapp.StreamingService, *sync.WaitGroup, error) {
@@ -116,6 +206,9 @@ func LoadStreamingServices(bApp *baseapp.BaseApp, appOpts serverTypes.AppOptions
 			}
 			return nil, nil, err
 		}
+		if sinkService, ok := streamingService.(*SinkService); ok {
+			sinkService.SetLogger(bApp.Logger().With("module", "streaming", "streamer", streamerName))
+		}
 		// register the streaming service with the BaseApp
 		bApp.SetStreamingService(streamingService)
 		// kick off the background streaming service loop
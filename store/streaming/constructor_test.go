@@ -5,7 +5,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	serverTypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/store/streaming/file"
+	"github.com/cosmos/cosmos-sdk/store/streaming/parquet"
 	"github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -41,3 +43,76 @@ func TestStreamingServiceConstructor(t *testing.T) {
 		require.True(t, ok)
 	}
 }
+
+type mapOptions map[string]interface{}
+
+func (m mapOptions) Get(key string) interface{} { return m[key] }
+
+func TestSinkServiceConstructors(t *testing.T) {
+	constructor, err := NewServiceConstructor("grpc")
+	require.Nil(t, err)
+	_, err = constructor(mockOptions, mockKeys, testMarshaller)
+	require.ErrorContains(t, err, "streamers.grpc.address is required")
+
+	serv, err := constructor(mapOptions{
+		"streamers.grpc.address": "localhost:9095",
+		"streamers.grpc.timeout": "5s",
+	}, mockKeys, testMarshaller)
+	require.Nil(t, err)
+	require.IsType(t, &SinkService{}, serv)
+	require.Len(t, serv.Listeners(), len(mockKeys))
+	require.Nil(t, serv.Close())
+
+	constructor, err = NewServiceConstructor("kafka")
+	require.Nil(t, err)
+	_, err = constructor(mapOptions{"streamers.kafka.topic": "changes"}, mockKeys, testMarshaller)
+	require.ErrorContains(t, err, "streamers.kafka.brokers and streamers.kafka.topic are required")
+
+	serv, err = constructor(mapOptions{
+		"streamers.kafka.brokers":     []string{"localhost:9092"},
+		"streamers.kafka.topic":       "changes",
+		"streamers.kafka.buffer_size": 10,
+	}, mockKeys, testMarshaller)
+	require.Nil(t, err)
+	require.IsType(t, &SinkService{}, serv)
+	require.Nil(t, serv.Close())
+}
+
+func TestParquetServiceConstructor(t *testing.T) {
+	constructor, err := NewServiceConstructor("parquet")
+	require.Nil(t, err)
+	_, err = constructor(mockOptions, mockKeys, testMarshaller)
+	require.ErrorContains(t, err, "parquet export directory is required")
+	_, err = constructor(mapOptions{
+		"streamers.parquet.write_dir":   t.TempDir(),
+		"streamers.parquet.compression": "lzo",
+	}, mockKeys, testMarshaller)
+	require.ErrorContains(t, err, "unknown parquet compression codec lzo")
+
+	serv, err := constructor(mapOptions{
+		"streamers.parquet.write_dir":    t.TempDir(),
+		"streamers.parquet.store_deltas": true,
+	}, mockKeys, testMarshaller)
+	require.Nil(t, err)
+	require.IsType(t, &parquet.Service{}, serv)
+	require.Len(t, serv.Listeners(), len(mockKeys))
+	require.Nil(t, serv.Close())
+}
+
+func TestRegisterServiceConstructor(t *testing.T) {
+	_, err := NewServiceConstructor("custom")
+	require.NotNil(t, err)
+
+	sink := newFakeSink()
+	RegisterServiceConstructor("Custom", NewSinkServiceConstructor("custom", func(serverTypes.AppOptions, string) (Sink, error) {
+		return sink, nil
+	}))
+	defer delete(customServiceConstructors, "custom")
+
+	constructor, err := NewServiceConstructor("custom")
+	require.Nil(t, err)
+	serv, err := constructor(mockOptions, mockKeys, testMarshaller)
+	require.Nil(t, err)
+	require.Nil(t, serv.Close())
+	require.True(t, sink.closed)
+}
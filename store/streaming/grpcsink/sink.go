@@ -0,0 +1,61 @@
+package grpcsink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// DefaultTimeout is the default time a ChangeSetSink service has to acknowledge
+// a change set.
+const DefaultTimeout = 10 * time.Second
+
+// Sink delivers the change sets of the committed blocks to a ChangeSetSink
+// gRPC service, a change set being acknowledged once the service returns its
+// height.
+type Sink struct {
+	conn    *grpc.ClientConn
+	client  ChangeSetSinkClient
+	timeout time.Duration
+}
+
+// NewSink creates a Sink connected to the ChangeSetSink service at address.
+// A timeout of 0 means DefaultTimeout.
+func NewSink(address string, timeout time.Duration) (*Sink, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	// the connection is established lazily, and re-established after failures
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &Sink{
+		conn:    conn,
+		client:  NewChangeSetSinkClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Deliver sends changeSet to the service and waits for its acknowledgement.
+func (s *Sink) Deliver(ctx context.Context, changeSet *types.BlockChangeSet) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	res, err := s.client.DeliverChangeSet(ctx, &DeliverChangeSetRequest{ChangeSet: changeSet})
+	if err != nil {
+		return err
+	}
+	if res.AcknowledgedHeight != changeSet.Height {
+		return fmt.Errorf("change set of height %d acknowledged as height %d", changeSet.Height, res.AcknowledgedHeight)
+	}
+	return nil
+}
+
+// Close closes the connection to the service.
+func (s *Sink) Close() error {
+	return s.conn.Close()
+}
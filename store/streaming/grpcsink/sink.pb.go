@@ -0,0 +1,582 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/base/store/streaming/v1beta1/sink.proto
+
+package grpcsink
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/store/types"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// DeliverChangeSetRequest is the request type for the ChangeSetSink/DeliverChangeSet RPC method.
+type DeliverChangeSetRequest struct {
+	ChangeSet *types.BlockChangeSet `protobuf:"bytes,1,opt,name=change_set,json=changeSet,proto3" json:"change_set,omitempty"`
+}
+
+func (m *DeliverChangeSetRequest) Reset()         { *m = DeliverChangeSetRequest{} }
+func (m *DeliverChangeSetRequest) String() string { return proto.CompactTextString(m) }
+func (*DeliverChangeSetRequest) ProtoMessage()    {}
+func (*DeliverChangeSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65305dbfe4771c41, []int{0}
+}
+func (m *DeliverChangeSetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeliverChangeSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeliverChangeSetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeliverChangeSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverChangeSetRequest.Merge(m, src)
+}
+func (m *DeliverChangeSetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeliverChangeSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverChangeSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverChangeSetRequest proto.InternalMessageInfo
+
+func (m *DeliverChangeSetRequest) GetChangeSet() *types.BlockChangeSet {
+	if m != nil {
+		return m.ChangeSet
+	}
+	return nil
+}
+
+// DeliverChangeSetResponse is the response type for the ChangeSetSink/DeliverChangeSet RPC method.
+type DeliverChangeSetResponse struct {
+	// acknowledged_height is the height of the change set the sink processed,
+	// which must match the delivered one.
+	AcknowledgedHeight int64 `protobuf:"varint,1,opt,name=acknowledged_height,json=acknowledgedHeight,proto3" json:"acknowledged_height,omitempty"`
+}
+
+func (m *DeliverChangeSetResponse) Reset()         { *m = DeliverChangeSetResponse{} }
+func (m *DeliverChangeSetResponse) String() string { return proto.CompactTextString(m) }
+func (*DeliverChangeSetResponse) ProtoMessage()    {}
+func (*DeliverChangeSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65305dbfe4771c41, []int{1}
+}
+func (m *DeliverChangeSetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeliverChangeSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeliverChangeSetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeliverChangeSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverChangeSetResponse.Merge(m, src)
+}
+func (m *DeliverChangeSetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeliverChangeSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverChangeSetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverChangeSetResponse proto.InternalMessageInfo
+
+func (m *DeliverChangeSetResponse) GetAcknowledgedHeight() int64 {
+	if m != nil {
+		return m.AcknowledgedHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*DeliverChangeSetRequest)(nil), "cosmos.base.store.streaming.v1beta1.DeliverChangeSetRequest")
+	proto.RegisterType((*DeliverChangeSetResponse)(nil), "cosmos.base.store.streaming.v1beta1.DeliverChangeSetResponse")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/base/store/streaming/v1beta1/sink.proto", fileDescriptor_65305dbfe4771c41)
+}
+
+var fileDescriptor_65305dbfe4771c41 = []byte{
+	// 303 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x4f, 0x4b, 0xc3, 0x30,
+	0x18, 0x87, 0x1b, 0x04, 0xc1, 0x88, 0x20, 0xf1, 0xe0, 0xd8, 0x21, 0xc8, 0xbc, 0xb8, 0x83, 0x09,
+	0x9b, 0x78, 0xd3, 0xcb, 0xf4, 0x30, 0xf0, 0x20, 0x6c, 0x37, 0x2f, 0xa3, 0x4d, 0x5f, 0xd2, 0xd0,
+	0x36, 0xa9, 0x4d, 0x36, 0x3f, 0x86, 0x7e, 0x05, 0xbf, 0x8d, 0xc7, 0x1d, 0x3d, 0x4a, 0xfb, 0x45,
+	0xa4, 0x7f, 0x36, 0xc5, 0x22, 0x88, 0x97, 0x36, 0xe4, 0x7d, 0xf2, 0xbc, 0xbc, 0xf9, 0x05, 0x33,
+	0x61, 0x6c, 0x6a, 0x2c, 0x0f, 0x7c, 0x0b, 0xdc, 0x3a, 0x93, 0x57, 0xdf, 0x1c, 0xfc, 0x54, 0x69,
+	0xc9, 0x57, 0xa3, 0x00, 0x9c, 0x3f, 0xe2, 0x56, 0xe9, 0x98, 0x65, 0xb9, 0x71, 0x86, 0x9c, 0x36,
+	0x3c, 0xab, 0x78, 0x56, 0xf3, 0x6c, 0xcb, 0xb3, 0x96, 0xef, 0x0f, 0xbb, 0xd2, 0x8d, 0x2a, 0x51,
+	0xd6, 0x81, 0xae, 0xe0, 0xda, 0x37, 0x10, 0xf8, 0xf8, 0x16, 0x12, 0xb5, 0x82, 0xfc, 0x26, 0xf2,
+	0xb5, 0x84, 0x39, 0xb8, 0x19, 0x3c, 0x2e, 0xc1, 0x3a, 0x32, 0xc5, 0x58, 0xd4, 0x7b, 0x0b, 0x0b,
+	0xae, 0x87, 0x4e, 0xd0, 0xd9, 0xfe, 0x78, 0xc8, 0xba, 0xfd, 0x5b, 0x35, 0x9b, 0x24, 0x46, 0xc4,
+	0x5f, 0x96, 0x3d, 0xb1, 0x59, 0x0e, 0xee, 0x70, 0xaf, 0xdb, 0xc4, 0x66, 0x46, 0x5b, 0x20, 0x1c,
+	0x1f, 0xf9, 0x22, 0xd6, 0xe6, 0x29, 0x81, 0x50, 0x42, 0xb8, 0x88, 0x40, 0xc9, 0xa8, 0x69, 0xb7,
+	0x33, 0x23, 0xdf, 0x4b, 0xd3, 0xba, 0x32, 0x7e, 0x45, 0xf8, 0x60, 0xab, 0x99, 0x2b, 0x1d, 0x93,
+	0x67, 0x84, 0x0f, 0x7f, 0xfa, 0xc9, 0x15, 0xfb, 0xc3, 0x4d, 0xb1, 0x5f, 0x66, 0xef, 0x5f, 0xff,
+	0xf3, 0x74, 0x33, 0xd4, 0xe4, 0xfe, 0xad, 0xa0, 0x68, 0x5d, 0x50, 0xf4, 0x51, 0x50, 0xf4, 0x52,
+	0x52, 0x6f, 0x5d, 0x52, 0xef, 0xbd, 0xa4, 0xde, 0xc3, 0xa5, 0x54, 0x2e, 0x5a, 0x06, 0x4c, 0x98,
+	0x94, 0xb7, 0x29, 0x35, 0xbf, 0x73, 0x1b, 0xc6, 0x9d, 0x07, 0x20, 0xf3, 0x4c, 0x54, 0xe1, 0x07,
+	0xbb, 0x75, 0x5a, 0x17, 0x9f, 0x03, 0x00, 0x2f, 0xf4, 0x16, 0xda, 0x2f, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ChangeSetSinkClient is the client API for ChangeSetSink service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChangeSetSinkClient interface {
+	// DeliverChangeSet sends the change set of a block. The blocks are delivered
+	// in height order, the next one being sent once this one is acknowledged.
+	DeliverChangeSet(ctx context.Context, in *DeliverChangeSetRequest, opts ...grpc.CallOption) (*DeliverChangeSetResponse, error)
+}
+
+type changeSetSinkClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewChangeSetSinkClient(cc grpc1.ClientConn) ChangeSetSinkClient {
+	return &changeSetSinkClient{cc}
+}
+
+func (c *changeSetSinkClient) DeliverChangeSet(ctx context.Context, in *DeliverChangeSetRequest, opts ...grpc.CallOption) (*DeliverChangeSetResponse, error) {
+	out := new(DeliverChangeSetResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.store.streaming.v1beta1.ChangeSetSink/DeliverChangeSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangeSetSinkServer is the server API for ChangeSetSink service.
+type ChangeSetSinkServer interface {
+	// DeliverChangeSet sends the change set of a block. The blocks are delivered
+	// in height order, the next one being sent once this one is acknowledged.
+	DeliverChangeSet(context.Context, *DeliverChangeSetRequest) (*DeliverChangeSetResponse, error)
+}
+
+// UnimplementedChangeSetSinkServer can be embedded to have forward compatible implementations.
+type UnimplementedChangeSetSinkServer struct {
+}
+
+func (*UnimplementedChangeSetSinkServer) DeliverChangeSet(ctx context.Context, req *DeliverChangeSetRequest) (*DeliverChangeSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverChangeSet not implemented")
+}
+
+func RegisterChangeSetSinkServer(s grpc1.Server, srv ChangeSetSinkServer) {
+	s.RegisterService(&_ChangeSetSink_serviceDesc, srv)
+}
+
+func _ChangeSetSink_DeliverChangeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliverChangeSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeSetSinkServer).DeliverChangeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.store.streaming.v1beta1.ChangeSetSink/DeliverChangeSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeSetSinkServer).DeliverChangeSet(ctx, req.(*DeliverChangeSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ChangeSetSink_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.base.store.streaming.v1beta1.ChangeSetSink",
+	HandlerType: (*ChangeSetSinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeliverChangeSet",
+			Handler:    _ChangeSetSink_DeliverChangeSet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/base/store/streaming/v1beta1/sink.proto",
+}
+
+func (m *DeliverChangeSetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverChangeSetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeliverChangeSetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ChangeSet != nil {
+		{
+			size, err := m.ChangeSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSink(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeliverChangeSetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverChangeSetResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeliverChangeSetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AcknowledgedHeight != 0 {
+		i = encodeVarintSink(dAtA, i, uint64(m.AcknowledgedHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSink(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSink(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DeliverChangeSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangeSet != nil {
+		l = m.ChangeSet.Size()
+		n += 1 + l + sovSink(uint64(l))
+	}
+	return n
+}
+
+func (m *DeliverChangeSetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AcknowledgedHeight != 0 {
+		n += 1 + sovSink(uint64(m.AcknowledgedHeight))
+	}
+	return n
+}
+
+func sovSink(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSink(x uint64) (n int) {
+	return sovSink(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeliverChangeSetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSink
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverChangeSetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverChangeSetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSink
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSink
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSink
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangeSet == nil {
+				m.ChangeSet = &types.BlockChangeSet{}
+			}
+			if err := m.ChangeSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSink(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSink
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverChangeSetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSink
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverChangeSetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverChangeSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcknowledgedHeight", wireType)
+			}
+			m.AcknowledgedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSink
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AcknowledgedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSink(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSink
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSink(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSink
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSink
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSink
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSink
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSink
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSink
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSink        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSink          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSink = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,66 @@
+package grpcsink
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// server acknowledges the change sets it receives with ackOffset added to their
+// height.
+type server struct {
+	received  []*types.BlockChangeSet
+	ackOffset int64
+}
+
+func (s *server) DeliverChangeSet(_ context.Context, req *DeliverChangeSetRequest) (*DeliverChangeSetResponse, error) {
+	s.received = append(s.received, req.ChangeSet)
+	return &DeliverChangeSetResponse{AcknowledgedHeight: req.ChangeSet.Height + s.ackOffset}, nil
+}
+
+func startServer(t *testing.T, srv *server) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcSrv := grpc.NewServer()
+	RegisterChangeSetSinkServer(grpcSrv, srv)
+	go grpcSrv.Serve(lis) //nolint:errcheck
+	t.Cleanup(grpcSrv.Stop)
+	return lis.Addr().String()
+}
+
+func TestSinkDeliver(t *testing.T) {
+	srv := &server{}
+	sink, err := NewSink(startServer(t, srv), time.Second)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	changeSet := &types.BlockChangeSet{
+		Height:  3,
+		Changes: []*types.StoreKVPair{{StoreKey: "bank", Key: []byte("k"), Value: []byte("v")}},
+	}
+	require.NoError(t, sink.Deliver(context.Background(), changeSet))
+	require.Len(t, srv.received, 1)
+	require.Equal(t, changeSet, srv.received[0])
+
+	// an acknowledgement of another height is a failed delivery
+	srv.ackOffset = -1
+	require.ErrorContains(t, sink.Deliver(context.Background(), changeSet), "change set of height 3 acknowledged as height 2")
+}
+
+func TestSinkDeliverUnavailable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	sink, err := NewSink(address, 100*time.Millisecond)
+	require.NoError(t, err)
+	defer sink.Close()
+	require.Error(t, sink.Deliver(context.Background(), &types.BlockChangeSet{Height: 1}))
+}
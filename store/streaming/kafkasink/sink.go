@@ -0,0 +1,73 @@
+package kafkasink
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+const (
+	// messageKey is the key of every message, which makes them all go to the
+	// same partition where Kafka keeps them in order.
+	messageKey = "block_change_set"
+	// heightHeader is the message header holding the height of the change set.
+	heightHeader = "height"
+	// maxMessageBytes bounds the size of a change set message, the brokers
+	// must accept messages this large for the largest blocks to be delivered.
+	maxMessageBytes = 64 << 20
+	writeTimeout    = 10 * time.Second
+)
+
+// writer is the subset of kafka.Writer used by the Sink.
+type writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Sink publishes the change sets of the committed blocks to a Kafka topic, a
+// change set being acknowledged once all the in-sync replicas stored it.
+type Sink struct {
+	writer writer
+}
+
+// NewSink creates a Sink publishing to topic on the given brokers.
+func NewSink(brokers []string, topic string) *Sink {
+	return &Sink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// change sets are written one at a time, waiting for the previous
+			// one to be acknowledged
+			BatchSize:    1,
+			BatchBytes:   maxMessageBytes,
+			WriteTimeout: writeTimeout,
+		},
+	}
+}
+
+// Deliver publishes changeSet as a protobuf encoded message and waits for the
+// brokers to acknowledge it.
+func (s *Sink) Deliver(ctx context.Context, changeSet *types.BlockChangeSet) error {
+	value, err := changeSet.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(messageKey),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: heightHeader, Value: []byte(strconv.FormatInt(changeSet.Height, 10))},
+		},
+	})
+}
+
+// Close flushes and closes the Kafka writer.
+func (s *Sink) Close() error {
+	return s.writer.Close()
+}
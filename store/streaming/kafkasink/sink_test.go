@@ -0,0 +1,52 @@
+package kafkasink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+)
+
+type fakeWriter struct {
+	messages []kafka.Message
+	closed   bool
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestSinkDeliver(t *testing.T) {
+	w := &fakeWriter{}
+	sink := &Sink{writer: w}
+
+	for height := int64(1); height <= 2; height++ {
+		require.NoError(t, sink.Deliver(context.Background(), &types.BlockChangeSet{
+			Height:  height,
+			Changes: []*types.StoreKVPair{{StoreKey: "bank", Key: []byte("k"), Delete: true}},
+		}))
+	}
+	require.NoError(t, sink.Close())
+	require.True(t, w.closed)
+
+	require.Len(t, w.messages, 2)
+	for i, msg := range w.messages {
+		// all the messages share a key to be kept in order on a single partition
+		require.Equal(t, messageKey, string(msg.Key))
+		require.Equal(t, []kafka.Header{{Key: heightHeader, Value: []byte{'1' + byte(i)}}}, msg.Headers)
+
+		var changeSet types.BlockChangeSet
+		require.NoError(t, changeSet.Unmarshal(msg.Value))
+		require.Equal(t, int64(i+1), changeSet.Height)
+		require.Equal(t, "bank", changeSet.Changes[0].StoreKey)
+	}
+}
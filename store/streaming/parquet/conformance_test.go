@@ -0,0 +1,394 @@
+package parquet_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store/streaming/parquet"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// This file reads the files of the exporter as any Parquet reader would, from
+// the Parquet and Thrift specifications alone: it does not use the code of the
+// package, and the numbers below are those of parquet.thrift.
+
+// physical types
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetByteArray = 6
+)
+
+// converted types, encodings, page types and repetitions
+const (
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+	parquetPlain           = 0
+	parquetDataPage        = 0
+	parquetRequired        = 0
+)
+
+// thriftStruct holds the fields of a struct by id.
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) i64(t *testing.T, id int16) int64 {
+	v, ok := s[id].(int64)
+	require.True(t, ok, "field %d is not an integer: %v", id, s[id])
+	return v
+}
+
+func (s thriftStruct) str(t *testing.T, id int16) string {
+	v, ok := s[id].([]byte)
+	require.True(t, ok, "field %d is not a binary: %v", id, s[id])
+	return string(v)
+}
+
+func (s thriftStruct) list(t *testing.T, id int16) []interface{} {
+	v, ok := s[id].([]interface{})
+	require.True(t, ok, "field %d is not a list: %v", id, s[id])
+	return v
+}
+
+func (s thriftStruct) strct(t *testing.T, id int16) thriftStruct {
+	v, ok := s[id].(thriftStruct)
+	require.True(t, ok, "field %d is not a struct: %v", id, s[id])
+	return v
+}
+
+// compactDecoder decodes values of the Thrift compact protocol.
+type compactDecoder struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (d *compactDecoder) byte() byte {
+	require.Less(d.t, d.pos, len(d.data), "unexpected end of thrift data")
+	b := d.data[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *compactDecoder) uvarint() uint64 {
+	var v uint64
+	for shift := 0; ; shift += 7 {
+		require.Less(d.t, shift, 64, "varint overflow")
+		b := d.byte()
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v
+		}
+	}
+}
+
+// zigzag decodes the i16, i32 and i64 values.
+func (d *compactDecoder) zigzag() int64 {
+	v := d.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (d *compactDecoder) binary() []byte {
+	size := int(d.uvarint())
+	require.LessOrEqual(d.t, d.pos+size, len(d.data), "binary past the end of thrift data")
+	v := d.data[d.pos : d.pos+size]
+	d.pos += size
+	return v
+}
+
+func (d *compactDecoder) value(typ byte) interface{} {
+	switch typ {
+	case 1, 2: // booleans, in a field header
+		return typ == 1
+	case 3: // byte
+		return int64(int8(d.byte()))
+	case 4, 5, 6: // i16, i32, i64
+		return d.zigzag()
+	case 7: // double
+		require.LessOrEqual(d.t, d.pos+8, len(d.data))
+		d.pos += 8
+		return nil
+	case 8:
+		return d.binary()
+	case 9, 10: // list, set
+		header := d.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(d.uvarint())
+		}
+		elemType := header & 0x0f
+		list := make([]interface{}, size)
+		for i := range list {
+			if elemType == 1 || elemType == 2 {
+				// booleans in a list are a byte each
+				list[i] = d.byte() == 1
+				continue
+			}
+			list[i] = d.value(elemType)
+		}
+		return list
+	case 11: // map, skipped
+		size := int(d.uvarint())
+		if size > 0 {
+			types := d.byte()
+			for i := 0; i < size; i++ {
+				d.value(types >> 4)
+				d.value(types & 0x0f)
+			}
+		}
+		return nil
+	case 12:
+		return d.structValue()
+	default:
+		d.t.Fatalf("invalid thrift compact type %d", typ)
+		return nil
+	}
+}
+
+func (d *compactDecoder) structValue() thriftStruct {
+	s := thriftStruct{}
+	var id int16
+	for {
+		header := d.byte()
+		if header == 0 {
+			return s
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(d.zigzag())
+		}
+		s[id] = d.value(header & 0x0f)
+	}
+}
+
+// parquetColumn is a leaf of the schema of a file.
+type parquetColumn struct {
+	name      string
+	typ       int64
+	converted int64
+}
+
+// readParquet reads the Parquet file at path, checking its structure, and
+// returns its columns and its rows. The UTF8 columns are read as strings, the
+// other byte arrays as byte slices.
+func readParquet(t *testing.T, path string) ([]parquetColumn, [][]interface{}) {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(data), 12)
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLen
+	require.GreaterOrEqual(t, footerStart, 4)
+	footer := &compactDecoder{t: t, data: data[footerStart : len(data)-8]}
+	meta := footer.structValue()
+	require.Equal(t, footerLen, footer.pos, "the footer length is the length of the metadata")
+
+	// FileMetaData
+	require.Equal(t, int64(1), meta.i64(t, 1), "version")
+	require.NotEmpty(t, meta.str(t, 6), "created_by")
+	schema := meta.list(t, 2)
+	require.NotEmpty(t, schema)
+	root := schema[0].(thriftStruct)
+	require.Equal(t, int64(len(schema)-1), root.i64(t, 5), "num_children of the root")
+	var columns []parquetColumn
+	for _, elem := range schema[1:] {
+		leaf := elem.(thriftStruct)
+		require.Equal(t, int64(parquetRequired), leaf.i64(t, 3), "repetition_type")
+		_, hasChildren := leaf[5]
+		require.False(t, hasChildren)
+		col := parquetColumn{name: leaf.str(t, 4), typ: leaf.i64(t, 1), converted: -1}
+		if _, ok := leaf[6]; ok {
+			col.converted = leaf.i64(t, 6)
+		}
+		columns = append(columns, col)
+	}
+
+	var rows [][]interface{}
+	for _, g := range meta.list(t, 4) {
+		// RowGroup
+		group := g.(thriftStruct)
+		numRows := int(group.i64(t, 3))
+		chunks := group.list(t, 1)
+		require.Len(t, chunks, len(columns))
+		groupRows := make([][]interface{}, numRows)
+		var totalSize int64
+		for i, c := range chunks {
+			// ColumnChunk and its ColumnMetaData
+			chunk := c.(thriftStruct)
+			_, inOtherFile := chunk[1]
+			require.False(t, inOtherFile, "file_path")
+			chunkMeta := chunk.strct(t, 3)
+			require.Equal(t, columns[i].typ, chunkMeta.i64(t, 1))
+			require.Contains(t, chunkMeta.list(t, 2), int64(parquetPlain))
+			require.Equal(t, []interface{}{[]byte(columns[i].name)}, chunkMeta.list(t, 3), "path_in_schema")
+			require.Equal(t, int64(numRows), chunkMeta.i64(t, 5), "num_values")
+			totalSize += chunkMeta.i64(t, 6)
+
+			offset := int(chunkMeta.i64(t, 9))
+			require.GreaterOrEqual(t, offset, 4)
+			require.Less(t, offset, footerStart)
+			pageDecoder := &compactDecoder{t: t, data: data[offset:footerStart]}
+			// PageHeader and its DataPageHeader
+			header := pageDecoder.structValue()
+			require.Equal(t, int64(parquetDataPage), header.i64(t, 1))
+			dataHeader := header.strct(t, 5)
+			require.Equal(t, int64(numRows), dataHeader.i64(t, 1), "num_values")
+			require.Equal(t, int64(parquetPlain), dataHeader.i64(t, 2), "encoding")
+			compressedSize := int(header.i64(t, 3))
+			require.Equal(t, int64(pageDecoder.pos+compressedSize), chunkMeta.i64(t, 7), "total_compressed_size")
+			require.Equal(t, int64(pageDecoder.pos)+header.i64(t, 2), chunkMeta.i64(t, 6), "total_uncompressed_size")
+			require.LessOrEqual(t, pageDecoder.pos+compressedSize, len(pageDecoder.data))
+			page := decompressPage(t, chunkMeta.i64(t, 4), pageDecoder.data[pageDecoder.pos:pageDecoder.pos+compressedSize])
+			require.Len(t, page, int(header.i64(t, 2)), "uncompressed_page_size")
+
+			// a required column has no levels, the page only holds the values
+			for j, v := range decodePlainValues(t, columns[i], page, numRows) {
+				groupRows[j] = append(groupRows[j], v)
+			}
+		}
+		require.Equal(t, totalSize, group.i64(t, 2), "total_byte_size")
+		rows = append(rows, groupRows...)
+	}
+	require.Equal(t, int64(len(rows)), meta.i64(t, 3), "num_rows")
+	return columns, rows
+}
+
+func decompressPage(t *testing.T, codec int64, page []byte) []byte {
+	var err error
+	switch codec {
+	case 0:
+	case 1:
+		page, err = snappy.Decode(nil, page)
+	case 2:
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(bytes.NewReader(page))
+		require.NoError(t, err)
+		page, err = ioutil.ReadAll(zr)
+	case 6:
+		var dec *zstd.Decoder
+		dec, err = zstd.NewReader(nil)
+		require.NoError(t, err)
+		defer dec.Close()
+		page, err = dec.DecodeAll(page, nil)
+	default:
+		t.Fatalf("unexpected codec %d", codec)
+	}
+	require.NoError(t, err)
+	return page
+}
+
+// decodePlainValues decodes numValues PLAIN encoded values of col, all of
+// page.
+func decodePlainValues(t *testing.T, col parquetColumn, page []byte, numValues int) []interface{} {
+	values := make([]interface{}, numValues)
+	pos := 0
+	next := func(n int) []byte {
+		require.LessOrEqual(t, pos+n, len(page), "value past the end of the page of column %s", col.name)
+		v := page[pos : pos+n]
+		pos += n
+		return v
+	}
+	for i := range values {
+		switch col.typ {
+		case parquetBoolean:
+			values[i] = page[i/8]>>(i%8)&1 == 1
+		case parquetInt32:
+			values[i] = int32(binary.LittleEndian.Uint32(next(4)))
+		case parquetInt64:
+			v := int64(binary.LittleEndian.Uint64(next(8)))
+			values[i] = v
+			if col.converted == parquetTimestampMillis {
+				values[i] = time.UnixMilli(v).UTC()
+			}
+		case parquetByteArray:
+			v := next(int(binary.LittleEndian.Uint32(next(4))))
+			values[i] = append([]byte{}, v...)
+			if col.converted == parquetUTF8 {
+				values[i] = string(v)
+			}
+		default:
+			t.Fatalf("unexpected physical type %d of column %s", col.typ, col.name)
+		}
+	}
+	if col.typ == parquetBoolean {
+		pos = (numValues + 7) / 8
+	}
+	require.Equal(t, len(page), pos, "bytes left in the page of column %s", col.name)
+	return values
+}
+
+func TestFilesDecodeWithSpecReader(t *testing.T) {
+	// the row groups of a single row make lists of more than 14 row groups,
+	// and those of 10 rows pack the booleans of a page in more than a byte
+	for _, rowGroupSize := range []int{1, 10} {
+		for _, codec := range []parquet.Codec{parquet.CodecNone, parquet.CodecSnappy, parquet.CodecGzip, parquet.CodecZstd} {
+			testFilesDecodeWithSpecReader(t, rowGroupSize, codec)
+		}
+	}
+}
+
+func testFilesDecodeWithSpecReader(t *testing.T, rowGroupSize int, codec parquet.Codec) {
+	blockTime := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	key := sdk.NewKVStoreKey("bank")
+	s, err := parquet.NewService(parquet.Config{WriteDir: dir, PartitionSize: 100, RowGroupSize: rowGroupSize, Codec: codec, StoreDeltas: true}, []types.StoreKey{key})
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 20; height++ {
+		ctx := sdk.Context{}.WithBlockHeader(tmproto.Header{Height: height, Time: blockTime})
+		require.NoError(t, s.ListenBeginBlock(ctx, abci.RequestBeginBlock{}, abci.ResponseBeginBlock{}))
+		require.NoError(t, s.OnWrite(key, []byte{0xff, byte(height)}, []byte{0, byte(height)}, false))
+		require.NoError(t, s.OnWrite(key, []byte{0}, nil, height%3 == 0))
+		require.NoError(t, s.ListenDeliverTx(ctx, abci.RequestDeliverTx{Tx: []byte{byte(height)}}, abci.ResponseDeliverTx{
+			Code: uint32(height), Codespace: "sdk", GasWanted: -1, GasUsed: height << 40, Log: "é",
+		}))
+		require.NoError(t, s.ListenEndBlock(ctx, abci.RequestEndBlock{}, abci.ResponseEndBlock{}))
+		require.NoError(t, s.ListenCommit(context.Background(), height))
+	}
+	require.NoError(t, s.Close())
+
+	columns, rows := readParquet(t, filepath.Join(dir, parquet.TxResultsTable, "000000000001-000000000020.parquet"))
+	require.Equal(t, []parquetColumn{
+		{"height", parquetInt64, -1}, {"block_time", parquetInt64, parquetTimestampMillis}, {"tx_index", parquetInt32, -1},
+		{"tx_hash", parquetByteArray, parquetUTF8}, {"code", parquetInt64, -1}, {"codespace", parquetByteArray, parquetUTF8},
+		{"gas_wanted", parquetInt64, -1}, {"gas_used", parquetInt64, -1}, {"log", parquetByteArray, parquetUTF8},
+	}, columns)
+	require.Len(t, rows, 20)
+	for i, row := range rows {
+		height := int64(i + 1)
+		require.Equal(t, []interface{}{height, blockTime, int32(0)}, row[:3])
+		require.Len(t, row[3], 64)
+		require.Equal(t, []interface{}{height, "sdk", int64(-1), height << 40, "é"}, row[4:])
+	}
+
+	columns, rows = readParquet(t, filepath.Join(dir, parquet.StoreDeltasTable, "000000000001-000000000020.parquet"))
+	require.Equal(t, []parquetColumn{
+		{"height", parquetInt64, -1}, {"block_time", parquetInt64, parquetTimestampMillis}, {"store", parquetByteArray, parquetUTF8},
+		{"key", parquetByteArray, -1}, {"value", parquetByteArray, -1}, {"delete", parquetBoolean, -1},
+	}, columns)
+	require.Len(t, rows, 40)
+	for i := 0; i < len(rows); i += 2 {
+		height := int64(i/2 + 1)
+		require.Equal(t, []interface{}{height, blockTime, "bank", []byte{0xff, byte(height)}, []byte{0, byte(height)}, false}, rows[i])
+		require.Equal(t, []interface{}{height, blockTime, "bank", []byte{0}, []byte{}, height%3 == 0}, rows[i+1])
+	}
+
+	// a file without rows has no row groups
+	_, rows = readParquet(t, filepath.Join(dir, parquet.EventsTable, "000000000001-000000000020.parquet"))
+	require.Empty(t, rows)
+}
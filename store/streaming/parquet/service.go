@@ -0,0 +1,433 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultPartitionSize is the default number of blocks whose rows are
+	// exported to the same files.
+	DefaultPartitionSize = 10000
+	// DefaultRowGroupSize is the default number of rows of a table buffered
+	// before they are written as a row group.
+	DefaultRowGroupSize = 100000
+
+	// maxRowGroupBytes bounds the size of the buffered rows of a table, whose
+	// pages must be smaller than 2 GB
+	maxRowGroupBytes = 128 << 20
+)
+
+// The names of the exported tables, which are the names of their directories.
+const (
+	TxResultsTable   = "tx_results"
+	EventsTable      = "events"
+	StoreDeltasTable = "store_deltas"
+)
+
+// The stages of a block the exported events are emitted in.
+const (
+	StageBeginBlock = "begin_block"
+	StageTx         = "tx"
+	StageEndBlock   = "end_block"
+)
+
+var (
+	txResultsColumns = []column{
+		int64Column("height"),
+		timestampColumn("block_time"),
+		int32Column("tx_index"),
+		stringColumn("tx_hash"),
+		int64Column("code"),
+		stringColumn("codespace"),
+		int64Column("gas_wanted"),
+		int64Column("gas_used"),
+		stringColumn("log"),
+	}
+	eventsColumns = []column{
+		int64Column("height"),
+		timestampColumn("block_time"),
+		stringColumn("stage"),
+		int32Column("tx_index"),
+		int32Column("event_index"),
+		stringColumn("type"),
+		int32Column("attribute_index"),
+		stringColumn("key"),
+		stringColumn("value"),
+	}
+	storeDeltasColumns = []column{
+		int64Column("height"),
+		timestampColumn("block_time"),
+		stringColumn("store"),
+		bytesColumn("key"),
+		bytesColumn("value"),
+		boolColumn("delete"),
+	}
+)
+
+var (
+	_ baseapp.StreamingService = (*Service)(nil)
+	_ baseapp.CommitListener   = (*Service)(nil)
+	_ types.WriteListener      = (*Service)(nil)
+)
+
+// Config configures the exported files of a Service.
+type Config struct {
+	// WriteDir is the directory the tables are exported to, in a
+	// subdirectory per table.
+	WriteDir string
+	// PartitionSize is the number of blocks whose rows are exported to the
+	// same file of a table, DefaultPartitionSize if 0.
+	PartitionSize int64
+	// RowGroupSize is the number of rows of a table buffered before they are
+	// written as a row group, DefaultRowGroupSize if 0.
+	RowGroupSize int
+	// Codec compresses the pages of the files, CodecNone leaving them
+	// uncompressed.
+	Codec Codec
+	// StoreDeltas exports the changes written to the listened stores.
+	StoreDeltas bool
+}
+
+// Service is a StreamingService exporting the results of the committed blocks
+// to Parquet files, for analytics: a row per tx result in the tx_results
+// table, a row per event attribute in the events table and, optionally, a row
+// per store write in the store_deltas table.
+//
+// The blocks are partitioned by height range, the rows of the blocks from
+// n*PartitionSize to (n+1)*PartitionSize-1 going to the same file of each
+// table, named after the first and last heights it holds, e.g.
+// tx_results/000000010000-000000019999.parquet. The file of a partition is
+// written to a .tmp file until the partition or the service is closed, so a
+// node stopped without closing the service leaves the .tmp files of the
+// unfinished partition, which are not valid Parquet files. A node restarted
+// mid-partition exports the rest of it to new files.
+//
+// The rows are written at commit, which a failed write does not fail: the
+// service stops exporting instead, returning the error at every commit.
+type Service struct {
+	cfg       Config
+	listeners map[types.StoreKey][]types.WriteListener
+
+	mtx     sync.Mutex
+	pending *block
+	deltas  []*types.StoreKVPair
+
+	tables         []*table
+	partitionStart int64
+	err            error
+	closed         bool
+}
+
+// block holds the results of the block being executed.
+type block struct {
+	height           int64
+	time             time.Time
+	beginBlockEvents []abci.Event
+	txResults        []abci.ResponseDeliverTx
+	txHashes         []string
+	endBlockEvents   []abci.Event
+}
+
+// NewService creates a Service exporting the tables configured by cfg, the
+// store deltas being the changes of the provided storeKeys.
+func NewService(cfg Config, storeKeys []types.StoreKey) (*Service, error) {
+	if cfg.WriteDir == "" {
+		return nil, errors.New("parquet export directory is required")
+	}
+	if cfg.PartitionSize <= 0 {
+		cfg.PartitionSize = DefaultPartitionSize
+	}
+	if cfg.RowGroupSize <= 0 {
+		cfg.RowGroupSize = DefaultRowGroupSize
+	}
+	s := &Service{
+		cfg: cfg,
+		tables: []*table{
+			newTable(TxResultsTable, txResultsColumns),
+			newTable(EventsTable, eventsColumns),
+		},
+		partitionStart: -1,
+	}
+	if cfg.StoreDeltas {
+		s.tables = append(s.tables, newTable(StoreDeltasTable, storeDeltasColumns))
+		s.listeners = make(map[types.StoreKey][]types.WriteListener, len(storeKeys))
+		for _, key := range storeKeys {
+			s.listeners[key] = append(s.listeners[key], s)
+		}
+	}
+	for _, t := range s.tables {
+		if err := os.MkdirAll(filepath.Join(cfg.WriteDir, t.name), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Stream satisfies the baseapp.StreamingService interface. The rows are
+// written at commit, so there is no loop to run.
+func (s *Service) Stream(_ *sync.WaitGroup) error {
+	return nil
+}
+
+// Listeners satisfies the baseapp.StreamingService interface, listening to
+// the stores only if the store deltas are exported.
+func (s *Service) Listeners() map[types.StoreKey][]types.WriteListener {
+	return s.listeners
+}
+
+// OnWrite satisfies the types.WriteListener interface by buffering the change
+// until the block is committed.
+func (s *Service) OnWrite(storeKey types.StoreKey, key []byte, value []byte, delete bool) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.deltas = append(s.deltas, &types.StoreKVPair{
+		StoreKey: storeKey.Name(),
+		Delete:   delete,
+		Key:      key,
+		Value:    value,
+	})
+	return nil
+}
+
+// ListenBeginBlock starts the results of a block with its BeginBlock events.
+// A block whose execution is started over starts over its results too.
+func (s *Service) ListenBeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock, res abci.ResponseBeginBlock) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pending = &block{height: ctx.BlockHeight(), time: ctx.BlockTime(), beginBlockEvents: res.Events}
+	return nil
+}
+
+// ListenDeliverTx adds the result of a tx to the results of its block.
+func (s *Service) ListenDeliverTx(ctx sdk.Context, req abci.RequestDeliverTx, res abci.ResponseDeliverTx) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	b := s.pendingBlock(ctx)
+	b.txResults = append(b.txResults, res)
+	b.txHashes = append(b.txHashes, fmt.Sprintf("%X", tmhash.Sum(req.Tx)))
+	return nil
+}
+
+// ListenEndBlock completes the results of a block with its EndBlock events.
+func (s *Service) ListenEndBlock(ctx sdk.Context, _ abci.RequestEndBlock, res abci.ResponseEndBlock) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.pendingBlock(ctx).endBlockEvents = res.Events
+	return nil
+}
+
+func (s *Service) pendingBlock(ctx sdk.Context) *block {
+	if s.pending == nil || s.pending.height != ctx.BlockHeight() {
+		s.pending = &block{height: ctx.BlockHeight(), time: ctx.BlockTime()}
+	}
+	return s.pending
+}
+
+// ListenCommit satisfies the baseapp.CommitListener interface. It adds the rows
+// of the committed block to the tables, writing the row groups that are full,
+// and closes the files of the partition once its last block is committed.
+func (s *Service) ListenCommit(_ context.Context, height int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	b := s.pending
+	if b == nil || b.height != height {
+		b = &block{height: height}
+	}
+	deltas := s.deltas
+	s.pending, s.deltas = nil, nil
+	if s.err != nil {
+		return s.err
+	}
+	if s.closed {
+		return errors.New("parquet export service is closed")
+	}
+
+	start := height - height%s.cfg.PartitionSize
+	if s.partitionStart >= 0 && s.partitionStart != start {
+		if s.err = s.closePartition(); s.err != nil {
+			return s.err
+		}
+	}
+	if s.partitionStart < 0 {
+		if s.err = s.openPartition(start, height); s.err != nil {
+			return s.err
+		}
+	}
+	s.addRows(b, deltas)
+	for _, t := range s.tables {
+		t.lastHeight = height
+		if t.rows >= s.cfg.RowGroupSize || t.size() >= maxRowGroupBytes {
+			if s.err = t.flush(); s.err != nil {
+				return s.err
+			}
+		}
+	}
+	if height == start+s.cfg.PartitionSize-1 {
+		s.err = s.closePartition()
+	}
+	return s.err
+}
+
+func (s *Service) addRows(b *block, deltas []*types.StoreKVPair) {
+	txResults, events := s.tables[0], s.tables[1]
+	addEvents := func(stage string, txIndex int32, evts []abci.Event) {
+		for i, event := range evts {
+			if len(event.Attributes) == 0 {
+				events.add(b.height, b.time, stage, txIndex, int32(i), event.Type, int32(0), "", "")
+			}
+			for j, attr := range event.Attributes {
+				events.add(b.height, b.time, stage, txIndex, int32(i), event.Type, int32(j), string(attr.Key), string(attr.Value))
+			}
+		}
+	}
+
+	addEvents(StageBeginBlock, -1, b.beginBlockEvents)
+	for i, res := range b.txResults {
+		txResults.add(b.height, b.time, int32(i), b.txHashes[i], int64(res.Code), res.Codespace, res.GasWanted, res.GasUsed, res.Log)
+		addEvents(StageTx, int32(i), res.Events)
+	}
+	addEvents(StageEndBlock, -1, b.endBlockEvents)
+
+	if s.cfg.StoreDeltas {
+		// the stores are written in no particular order, while each of them
+		// writes its keys in order
+		sort.SliceStable(deltas, func(i, j int) bool {
+			return deltas[i].StoreKey < deltas[j].StoreKey
+		})
+		for _, delta := range deltas {
+			s.tables[2].add(b.height, b.time, delta.StoreKey, delta.Key, delta.Value, delta.Delete)
+		}
+	}
+}
+
+// openPartition creates the files of the partition starting at start, whose
+// first exported block is at height.
+func (s *Service) openPartition(start, height int64) error {
+	for _, t := range s.tables {
+		if err := t.open(s.cfg.WriteDir, height, s.cfg.Codec); err != nil {
+			return err
+		}
+	}
+	s.partitionStart = start
+	return nil
+}
+
+// closePartition writes the rows left of the open partition and closes its
+// files.
+func (s *Service) closePartition() error {
+	for _, t := range s.tables {
+		if err := t.close(s.cfg.WriteDir); err != nil {
+			return err
+		}
+	}
+	s.partitionStart = -1
+	return nil
+}
+
+// Close satisfies the io.Closer interface, closing the files of the open
+// partition, which then hold the blocks committed so far.
+func (s *Service) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.err != nil || s.partitionStart < 0 {
+		return s.err
+	}
+	s.err = s.closePartition()
+	return s.err
+}
+
+// table buffers the rows of a table and writes them to the file of the open
+// partition.
+type table struct {
+	name    string
+	columns []column
+	buffers []*columnBuffer
+	rows    int
+
+	file        *fileWriter
+	tmpPath     string
+	firstHeight int64
+	lastHeight  int64
+}
+
+func newTable(name string, columns []column) *table {
+	t := &table{name: name, columns: columns}
+	for range columns {
+		t.buffers = append(t.buffers, &columnBuffer{})
+	}
+	return t
+}
+
+// add buffers a row, holding a value per column.
+func (t *table) add(values ...interface{}) {
+	for i, v := range values {
+		t.buffers[i].add(t.columns[i], v)
+	}
+	t.rows++
+}
+
+func (t *table) size() int {
+	size := 0
+	for _, buf := range t.buffers {
+		size += buf.size()
+	}
+	return size
+}
+
+func (t *table) open(dir string, height int64, codec Codec) error {
+	t.tmpPath = filepath.Join(dir, t.name, fmt.Sprintf("%012d.parquet.tmp", height))
+	file, err := createFileWriter(t.tmpPath, t.columns, codec)
+	if err != nil {
+		return err
+	}
+	t.file = file
+	t.firstHeight, t.lastHeight = height, height
+	return nil
+}
+
+// flush writes the buffered rows as a row group.
+func (t *table) flush() error {
+	if t.rows == 0 {
+		return nil
+	}
+	if err := t.file.writeRowGroup(t.buffers, t.rows); err != nil {
+		return err
+	}
+	for _, buf := range t.buffers {
+		buf.reset()
+	}
+	t.rows = 0
+	return nil
+}
+
+// close writes the buffered rows and the metadata of the file, which is then
+// renamed after the heights it holds.
+func (t *table) close(dir string) error {
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if err := t.file.close(); err != nil {
+		return err
+	}
+	t.file = nil
+	name := fmt.Sprintf("%012d-%012d.parquet", t.firstHeight, t.lastHeight)
+	return os.Rename(t.tmpPath, filepath.Join(dir, t.name, name))
+}
@@ -0,0 +1,285 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// thriftReader decodes the Thrift compact protocol, the structs into maps of
+// their field values by id.
+type thriftReader struct {
+	buf *bytes.Reader
+}
+
+func (r *thriftReader) readStruct(t *testing.T) map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var lastID int16
+	for {
+		header, err := r.buf.ReadByte()
+		require.NoError(t, err)
+		if header == 0 {
+			return fields
+		}
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.readVarint(t))
+		}
+		lastID = id
+		fields[id] = r.readValue(t, header&0x0f)
+	}
+}
+
+func (r *thriftReader) readValue(t *testing.T, typ byte) interface{} {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case compactI32, compactI64:
+		return r.readVarint(t)
+	case compactBinary:
+		size, err := binary.ReadUvarint(r.buf)
+		require.NoError(t, err)
+		bz := make([]byte, size)
+		_, err = r.buf.Read(bz)
+		require.NoError(t, err)
+		return string(bz)
+	case compactList:
+		header, err := r.buf.ReadByte()
+		require.NoError(t, err)
+		size := uint64(header >> 4)
+		if size == 15 {
+			size, err = binary.ReadUvarint(r.buf)
+			require.NoError(t, err)
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.readValue(t, header&0x0f)
+		}
+		return list
+	case compactStruct:
+		return r.readStruct(t)
+	default:
+		t.Fatalf("unexpected thrift type %d", typ)
+		return nil
+	}
+}
+
+func (r *thriftReader) readVarint(t *testing.T) int64 {
+	v, err := binary.ReadVarint(r.buf)
+	require.NoError(t, err)
+	return v
+}
+
+// readFile reads the Parquet file at path, returning the names of its columns
+// and its rows.
+func readFile(t *testing.T, path string) ([]string, [][]interface{}) {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, magic, string(data[:4]))
+	require.Equal(t, magic, string(data[len(data)-4:]))
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta := (&thriftReader{bytes.NewReader(data[len(data)-8-metaLen : len(data)-8])}).readStruct(t)
+	require.Equal(t, int64(1), meta[1])
+
+	schema := meta[2].([]interface{})
+	root := schema[0].(map[int16]interface{})
+	require.Equal(t, int64(len(schema)-1), root[5])
+	var names []string
+	var columns []column
+	for _, elem := range schema[1:] {
+		field := elem.(map[int16]interface{})
+		names = append(names, field[4].(string))
+		col := column{name: field[4].(string), typ: int32(field[1].(int64)), converted: noConvertedType}
+		if converted, ok := field[6]; ok {
+			col.converted = int32(converted.(int64))
+		}
+		columns = append(columns, col)
+	}
+
+	var rows [][]interface{}
+	for _, g := range meta[4].([]interface{}) {
+		group := g.(map[int16]interface{})
+		numRows := int(group[3].(int64))
+		groupRows := make([][]interface{}, numRows)
+		for i, c := range group[1].([]interface{}) {
+			chunk := c.(map[int16]interface{})[3].(map[int16]interface{})
+			require.Equal(t, []interface{}{names[i]}, chunk[3])
+			offset := chunk[9].(int64)
+			pageReader := &thriftReader{bytes.NewReader(data[offset:])}
+			header := pageReader.readStruct(t)
+			require.Equal(t, int64(numRows), header[5].(map[int16]interface{})[1])
+			headerLen := int64(len(data[offset:]) - pageReader.buf.Len())
+			require.Equal(t, chunk[7], headerLen+header[3].(int64))
+			page := data[offset+headerLen : offset+headerLen+header[3].(int64)]
+			page = decompress(t, Codec(chunk[4].(int64)), page)
+			require.Len(t, page, int(header[2].(int64)))
+			for j, v := range decodePlain(t, columns[i], page, numRows) {
+				groupRows[j] = append(groupRows[j], v)
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	require.Equal(t, int64(len(rows)), meta[3])
+	return names, rows
+}
+
+func decompress(t *testing.T, codec Codec, page []byte) []byte {
+	var err error
+	switch codec {
+	case CodecSnappy:
+		page, err = s2.Decode(nil, page)
+	case CodecGzip:
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(bytes.NewReader(page))
+		require.NoError(t, err)
+		page, err = ioutil.ReadAll(zr)
+	case CodecZstd:
+		var dec *zstd.Decoder
+		dec, err = zstd.NewReader(nil)
+		require.NoError(t, err)
+		page, err = dec.DecodeAll(page, nil)
+	}
+	require.NoError(t, err)
+	return page
+}
+
+func decodePlain(t *testing.T, col column, page []byte, numRows int) []interface{} {
+	values := make([]interface{}, numRows)
+	for i := range values {
+		switch col.typ {
+		case typeBoolean:
+			values[i] = page[i/8]&(1<<(i%8)) != 0
+		case typeInt32:
+			values[i] = int32(binary.LittleEndian.Uint32(page))
+			page = page[4:]
+		case typeInt64:
+			v := int64(binary.LittleEndian.Uint64(page))
+			values[i] = v
+			if col.converted == convertedTimestampMil {
+				values[i] = time.UnixMilli(v).UTC()
+			}
+			page = page[8:]
+		case typeByteArray:
+			size := binary.LittleEndian.Uint32(page)
+			values[i] = string(page[4 : 4+size])
+			page = page[4+size:]
+		default:
+			t.Fatalf("unexpected parquet type %d", col.typ)
+		}
+	}
+	return values
+}
+
+// listFiles returns the names of the files of table.
+func listFiles(t *testing.T, dir, table string) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, table))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+var blockTime = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// executeBlock passes the execution of a block with a tx per result in
+// txResults to s, and commits it.
+func executeBlock(t *testing.T, s *Service, height int64, txResults ...abci.ResponseDeliverTx) {
+	ctx := sdk.Context{}.WithBlockHeader(tmproto.Header{Height: height, Time: blockTime})
+	require.NoError(t, s.ListenBeginBlock(ctx, abci.RequestBeginBlock{}, abci.ResponseBeginBlock{
+		Events: []abci.Event{{Type: "begin"}},
+	}))
+	for i, res := range txResults {
+		require.NoError(t, s.ListenDeliverTx(ctx, abci.RequestDeliverTx{Tx: []byte{byte(i)}}, res))
+	}
+	require.NoError(t, s.ListenEndBlock(ctx, abci.RequestEndBlock{}, abci.ResponseEndBlock{
+		Events: []abci.Event{{Type: "end", Attributes: []abci.EventAttribute{{Key: []byte("k"), Value: []byte("v")}}}},
+	}))
+	require.NoError(t, s.ListenCommit(context.Background(), height))
+}
+
+func TestService(t *testing.T) {
+	for _, codec := range []Codec{CodecNone, CodecSnappy, CodecGzip, CodecZstd} {
+		dir := t.TempDir()
+		key := sdk.NewKVStoreKey("bank")
+		s, err := NewService(Config{WriteDir: dir, PartitionSize: 10, RowGroupSize: 3, Codec: codec, StoreDeltas: true}, []types.StoreKey{key})
+		require.NoError(t, err)
+		require.Len(t, s.Listeners()[key], 1)
+
+		for height := int64(8); height <= 12; height++ {
+			require.NoError(t, s.OnWrite(key, []byte{byte(height)}, []byte("value"), false))
+			require.NoError(t, s.OnWrite(key, []byte{0}, nil, true))
+			executeBlock(t, s, height, abci.ResponseDeliverTx{
+				Code:      uint32(height),
+				Codespace: "sdk",
+				GasWanted: 200,
+				GasUsed:   100,
+				Log:       "log",
+				Events:    []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("1usei")}}}},
+			})
+		}
+
+		// the first partition is closed once its last block is committed
+		require.Equal(t, []string{"000000000008-000000000009.parquet", "000000000010.parquet.tmp"}, listFiles(t, dir, TxResultsTable))
+		require.NoError(t, s.Close())
+		require.ErrorContains(t, s.ListenCommit(context.Background(), 13), "closed")
+		for _, table := range []string{TxResultsTable, EventsTable, StoreDeltasTable} {
+			require.Equal(t, []string{"000000000008-000000000009.parquet", "000000000010-000000000012.parquet"}, listFiles(t, dir, table))
+		}
+
+		columns, rows := readFile(t, filepath.Join(dir, TxResultsTable, "000000000010-000000000012.parquet"))
+		require.Equal(t, []string{"height", "block_time", "tx_index", "tx_hash", "code", "codespace", "gas_wanted", "gas_used", "log"}, columns)
+		require.Len(t, rows, 3)
+		require.Equal(t, []interface{}{
+			int64(10), blockTime, int32(0), "6E340B9CFFB37A989CA544E6BB780A2C78901D3FB33738768511A30617AFA01D",
+			int64(10), "sdk", int64(200), int64(100), "log",
+		}, rows[0])
+
+		_, rows = readFile(t, filepath.Join(dir, EventsTable, "000000000008-000000000009.parquet"))
+		require.Len(t, rows, 6)
+		require.Equal(t, []interface{}{int64(8), blockTime, StageBeginBlock, int32(-1), int32(0), "begin", int32(0), "", ""}, rows[0])
+		require.Equal(t, []interface{}{int64(8), blockTime, StageTx, int32(0), int32(0), "transfer", int32(0), "amount", "1usei"}, rows[1])
+		require.Equal(t, []interface{}{int64(8), blockTime, StageEndBlock, int32(-1), int32(0), "end", int32(0), "k", "v"}, rows[2])
+
+		_, rows = readFile(t, filepath.Join(dir, StoreDeltasTable, "000000000010-000000000012.parquet"))
+		require.Len(t, rows, 6)
+		require.Equal(t, []interface{}{int64(11), blockTime, "bank", string([]byte{11}), "value", false}, rows[2])
+		require.Equal(t, []interface{}{int64(11), blockTime, "bank", string([]byte{0}), "", true}, rows[3])
+	}
+}
+
+func TestServiceWithoutStoreDeltas(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewService(Config{WriteDir: dir}, []types.StoreKey{sdk.NewKVStoreKey("bank")})
+	require.NoError(t, err)
+	require.Empty(t, s.Listeners())
+
+	// a block without txs gives an empty tx_results file
+	executeBlock(t, s, 1)
+	require.NoError(t, s.Close())
+	_, rows := readFile(t, filepath.Join(dir, TxResultsTable, "000000000001-000000000001.parquet"))
+	require.Empty(t, rows)
+	_, err = os.Stat(filepath.Join(dir, StoreDeltasTable))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = NewService(Config{}, nil)
+	require.EqualError(t, err, "parquet export directory is required")
+}
@@ -0,0 +1,97 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// types of the Thrift compact protocol used by the Parquet metadata
+const (
+	compactI32    byte = 5
+	compactI64    byte = 6
+	compactBinary byte = 8
+	compactList   byte = 9
+	compactStruct byte = 12
+)
+
+// thriftWriter encodes the Parquet metadata structures with the Thrift compact
+// protocol. The fields of a struct must be written in increasing id order,
+// between beginStruct and endStruct.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// lastIDs holds the id of the last field written in each open struct
+	lastIDs []int16
+}
+
+func (w *thriftWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func (w *thriftWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0) // stop field
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.writeVarint(int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, compactI32)
+	w.writeVarint(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, compactI64)
+	w.writeVarint(v)
+}
+
+func (w *thriftWriter) stringField(id int16, v string) {
+	w.fieldHeader(id, compactBinary)
+	w.writeString(v)
+}
+
+// structField starts a struct field, which must be ended with endStruct.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, compactStruct)
+	w.beginStruct()
+}
+
+// listField starts a list field of size elements of type elemType, which are
+// written next, each struct element between beginStruct and endStruct.
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, compactList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.writeUvarint(uint64(size))
+	}
+}
+
+func (w *thriftWriter) writeString(v string) {
+	w.writeUvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+// writeVarint writes the zigzag varint of the i16, i32 and i64 values.
+func (w *thriftWriter) writeVarint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (w *thriftWriter) writeUvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
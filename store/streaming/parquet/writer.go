@@ -0,0 +1,338 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// createdBy is recorded in the metadata of the written files
+const createdBy = "cosmos-sdk parquet exporter"
+
+// physical types of the Parquet columns
+const (
+	typeBoolean   int32 = 0
+	typeInt32     int32 = 1
+	typeInt64     int32 = 2
+	typeByteArray int32 = 6
+)
+
+// converted types telling how to interpret the physical values
+const (
+	noConvertedType       int32 = -1
+	convertedUTF8         int32 = 0
+	convertedTimestampMil int32 = 9
+)
+
+const (
+	encodingPlain      int32 = 0
+	encodingRLE        int32 = 3
+	pageTypeData       int32 = 0
+	repetitionRequired int32 = 0
+)
+
+// Codec is the compression codec of the pages of the written files.
+type Codec int32
+
+// the codecs supported by the exporter, among the Parquet ones
+const (
+	CodecNone   Codec = 0
+	CodecSnappy Codec = 1
+	CodecGzip   Codec = 2
+	CodecZstd   Codec = 6
+)
+
+// ParseCodec returns the Codec of name: none, snappy, gzip or zstd.
+func ParseCodec(name string) (Codec, error) {
+	switch strings.ToLower(name) {
+	case "none", "uncompressed":
+		return CodecNone, nil
+	case "", "snappy":
+		return CodecSnappy, nil
+	case "gzip":
+		return CodecGzip, nil
+	case "zstd":
+		return CodecZstd, nil
+	default:
+		return 0, fmt.Errorf("unknown parquet compression codec %s", name)
+	}
+}
+
+func (c Codec) compress(data []byte) ([]byte, error) {
+	switch c {
+	case CodecNone:
+		return data, nil
+	case CodecSnappy:
+		return s2.EncodeSnappy(nil, data), nil
+	case CodecGzip:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CodecZstd:
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown parquet compression codec %d", c)
+	}
+}
+
+// column is a required, non-nested column of a table.
+type column struct {
+	name      string
+	typ       int32
+	converted int32
+}
+
+func int32Column(name string) column  { return column{name, typeInt32, noConvertedType} }
+func int64Column(name string) column  { return column{name, typeInt64, noConvertedType} }
+func boolColumn(name string) column   { return column{name, typeBoolean, noConvertedType} }
+func bytesColumn(name string) column  { return column{name, typeByteArray, noConvertedType} }
+func stringColumn(name string) column { return column{name, typeByteArray, convertedUTF8} }
+func timestampColumn(name string) column {
+	return column{name, typeInt64, convertedTimestampMil}
+}
+
+// columnBuffer holds the PLAIN encoded values of a column not written yet.
+type columnBuffer struct {
+	data  []byte
+	bools []bool
+}
+
+// add appends v, whose Go type must match the type of col.
+func (b *columnBuffer) add(col column, v interface{}) {
+	switch col.typ {
+	case typeBoolean:
+		b.bools = append(b.bools, v.(bool))
+	case typeInt32:
+		b.data = appendUint32(b.data, uint32(v.(int32)))
+	case typeInt64:
+		if col.converted == convertedTimestampMil {
+			v = v.(time.Time).UnixMilli()
+		}
+		b.data = appendUint64(b.data, uint64(v.(int64)))
+	case typeByteArray:
+		var bz []byte
+		if s, ok := v.(string); ok {
+			bz = []byte(s)
+		} else {
+			bz = v.([]byte)
+		}
+		b.data = appendUint32(b.data, uint32(len(bz)))
+		b.data = append(b.data, bz...)
+	default:
+		panic(fmt.Sprintf("unsupported parquet type %d", col.typ))
+	}
+}
+
+// encode returns the PLAIN encoded values, the booleans being bit-packed.
+func (b *columnBuffer) encode(col column) []byte {
+	if col.typ != typeBoolean {
+		return b.data
+	}
+	data := make([]byte, (len(b.bools)+7)/8)
+	for i, v := range b.bools {
+		if v {
+			data[i/8] |= 1 << (i % 8)
+		}
+	}
+	return data
+}
+
+func (b *columnBuffer) size() int {
+	return len(b.data) + len(b.bools)/8
+}
+
+func (b *columnBuffer) reset() {
+	b.data = b.data[:0]
+	b.bools = b.bools[:0]
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// columnChunk is the metadata of the values of a column in a row group.
+type columnChunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+type rowGroup struct {
+	chunks  []columnChunk
+	numRows int64
+}
+
+// fileWriter writes a Parquet file, a row group at a time, each column chunk
+// being a single PLAIN encoded data page. The file is only valid once closed,
+// which writes its metadata.
+type fileWriter struct {
+	file      *os.File
+	out       *bufio.Writer
+	offset    int64
+	columns   []column
+	codec     Codec
+	rowGroups []rowGroup
+	numRows   int64
+}
+
+func createFileWriter(path string, columns []column, codec Codec) (*fileWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &fileWriter{file: file, out: bufio.NewWriter(file), columns: columns, codec: codec}
+	if err := w.write([]byte(magic)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *fileWriter) write(data []byte) error {
+	n, err := w.out.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+// writeRowGroup writes the numRows rows of buffers, a buffer per column.
+func (w *fileWriter) writeRowGroup(buffers []*columnBuffer, numRows int) error {
+	group := rowGroup{numRows: int64(numRows)}
+	for i, buf := range buffers {
+		data := buf.encode(w.columns[i])
+		page, err := w.codec.compress(data)
+		if err != nil {
+			return err
+		}
+
+		header := &thriftWriter{}
+		header.beginStruct()
+		header.i32Field(1, pageTypeData)
+		header.i32Field(2, int32(len(data)))
+		header.i32Field(3, int32(len(page)))
+		header.structField(5)
+		header.i32Field(1, int32(numRows))
+		header.i32Field(2, encodingPlain)
+		header.i32Field(3, encodingRLE)
+		header.i32Field(4, encodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		chunk := columnChunk{
+			offset:           w.offset,
+			uncompressedSize: int64(len(header.Bytes()) + len(data)),
+			compressedSize:   int64(len(header.Bytes()) + len(page)),
+		}
+		if err := w.write(header.Bytes()); err != nil {
+			return err
+		}
+		if err := w.write(page); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+	}
+	w.rowGroups = append(w.rowGroups, group)
+	w.numRows += int64(numRows)
+	return nil
+}
+
+// close writes the metadata of the file and closes it.
+func (w *fileWriter) close() error {
+	meta := w.metadata()
+	footer := appendUint32(meta, uint32(len(meta)))
+	footer = append(footer, magic...)
+	err := w.write(footer)
+	if err == nil {
+		err = w.out.Flush()
+	}
+	if err == nil {
+		err = w.file.Sync()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// metadata returns the Thrift encoded FileMetaData of the file.
+func (w *fileWriter) metadata() []byte {
+	t := &thriftWriter{}
+	t.beginStruct()
+	t.i32Field(1, 1) // version
+	t.listField(2, compactStruct, len(w.columns)+1)
+	t.beginStruct()
+	t.stringField(4, "schema")
+	t.i32Field(5, int32(len(w.columns)))
+	t.endStruct()
+	for _, col := range w.columns {
+		t.beginStruct()
+		t.i32Field(1, col.typ)
+		t.i32Field(3, repetitionRequired)
+		t.stringField(4, col.name)
+		if col.converted != noConvertedType {
+			t.i32Field(6, col.converted)
+		}
+		t.endStruct()
+	}
+	t.i64Field(3, w.numRows)
+	t.listField(4, compactStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		var totalSize int64
+		t.beginStruct()
+		t.listField(1, compactStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			totalSize += chunk.uncompressedSize
+			t.beginStruct()
+			t.i64Field(2, chunk.offset)
+			t.structField(3)
+			t.i32Field(1, w.columns[i].typ)
+			t.listField(2, compactI32, 2)
+			t.writeVarint(int64(encodingPlain))
+			t.writeVarint(int64(encodingRLE))
+			t.listField(3, compactBinary, 1)
+			t.writeString(w.columns[i].name)
+			t.i32Field(4, int32(w.codec))
+			t.i64Field(5, group.numRows)
+			t.i64Field(6, chunk.uncompressedSize)
+			t.i64Field(7, chunk.compressedSize)
+			t.i64Field(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64Field(2, totalSize)
+		t.i64Field(3, group.numRows)
+		t.endStruct()
+	}
+	t.stringField(6, createdBy)
+	t.endStruct()
+	return t.Bytes()
+}
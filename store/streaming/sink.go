@@ -0,0 +1,233 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultSinkBufferSize is the default number of committed blocks that may
+	// wait to be delivered to a sink before commits block.
+	DefaultSinkBufferSize = 100
+
+	minRetryInterval = 100 * time.Millisecond
+	maxRetryInterval = 10 * time.Second
+)
+
+// Sink is an external destination for the state changes of the committed blocks.
+type Sink interface {
+	// Deliver sends the change set of a block. The change sets are delivered in
+	// height order, and the next one is only delivered once Deliver returned nil
+	// for this one, which acknowledges it. A failed delivery is retried.
+	Deliver(ctx context.Context, changeSet *types.BlockChangeSet) error
+	io.Closer
+}
+
+var (
+	_ baseapp.StreamingService = (*SinkService)(nil)
+	_ baseapp.CommitListener   = (*SinkService)(nil)
+	_ types.WriteListener      = (*SinkService)(nil)
+)
+
+// SinkService is a StreamingService delivering the state changes of each
+// committed block to a Sink. The change sets are queued at commit and delivered
+// by a background loop, in height order and retrying until the sink
+// acknowledges them. Once bufferSize blocks are waiting, commits block until the
+// sink catches up, so no block is skipped.
+type SinkService struct {
+	sink      Sink
+	logger    log.Logger
+	listeners map[types.StoreKey][]types.WriteListener
+
+	mtx     sync.Mutex
+	changes []*types.StoreKVPair
+
+	queue     chan *types.BlockChangeSet
+	quitChan  chan struct{}
+	doneChan  chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewSinkService creates a SinkService delivering the state changes of the
+// provided storeKeys to sink.
+func NewSinkService(sink Sink, storeKeys []types.StoreKey, bufferSize int) *SinkService {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSinkBufferSize
+	}
+	ss := &SinkService{
+		sink:      sink,
+		logger:    log.NewNopLogger(),
+		listeners: make(map[types.StoreKey][]types.WriteListener, len(storeKeys)),
+		queue:     make(chan *types.BlockChangeSet, bufferSize),
+	}
+	for _, key := range storeKeys {
+		ss.listeners[key] = append(ss.listeners[key], ss)
+	}
+	return ss
+}
+
+// SetLogger sets the logger used to report failed deliveries.
+func (ss *SinkService) SetLogger(logger log.Logger) {
+	ss.logger = logger
+}
+
+// Listeners satisfies the baseapp.StreamingService interface
+func (ss *SinkService) Listeners() map[types.StoreKey][]types.WriteListener {
+	return ss.listeners
+}
+
+// OnWrite satisfies the types.WriteListener interface by buffering the change
+// until the block is committed
+func (ss *SinkService) OnWrite(storeKey types.StoreKey, key []byte, value []byte, delete bool) error {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.changes = append(ss.changes, &types.StoreKVPair{
+		StoreKey: storeKey.Name(),
+		Delete:   delete,
+		Key:      key,
+		Value:    value,
+	})
+	return nil
+}
+
+// ListenBeginBlock satisfies the baseapp.ABCIListener interface
+func (ss *SinkService) ListenBeginBlock(sdk.Context, abci.RequestBeginBlock, abci.ResponseBeginBlock) error {
+	return nil
+}
+
+// ListenEndBlock satisfies the baseapp.ABCIListener interface
+func (ss *SinkService) ListenEndBlock(sdk.Context, abci.RequestEndBlock, abci.ResponseEndBlock) error {
+	return nil
+}
+
+// ListenDeliverTx satisfies the baseapp.ABCIListener interface
+func (ss *SinkService) ListenDeliverTx(sdk.Context, abci.RequestDeliverTx, abci.ResponseDeliverTx) error {
+	return nil
+}
+
+// ListenCommit satisfies the baseapp.CommitListener interface
+// It queues the changes written since the previous commit as the change set of
+// the block at height, waiting for room in the queue if it is full
+func (ss *SinkService) ListenCommit(_ context.Context, height int64) error {
+	ss.mtx.Lock()
+	changes := ss.changes
+	ss.changes = nil
+	ss.mtx.Unlock()
+
+	// the stores are written in no particular order, while each of them writes
+	// its keys in order
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].StoreKey < changes[j].StoreKey
+	})
+
+	changeSet := &types.BlockChangeSet{Height: height, Changes: changes}
+	// a queue with room would otherwise accept the change set of a closed
+	// service, which is never delivered
+	select {
+	case <-ss.doneChan:
+		return errors.New("sink service is closed")
+	default:
+	}
+	select {
+	case ss.queue <- changeSet:
+		return nil
+	case <-ss.doneChan:
+		return errors.New("sink service is closed")
+	}
+}
+
+// Stream satisfies the baseapp.StreamingService interface
+// It spins up the goroutine delivering the queued change sets to the sink
+// returns an error if it is called twice
+func (ss *SinkService) Stream(wg *sync.WaitGroup) error {
+	if ss.quitChan != nil {
+		return errors.New("`Stream` has already been called. The stream needs to be closed before it can be started again")
+	}
+	ss.quitChan = make(chan struct{})
+	ss.doneChan = make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ss.doneChan)
+		ss.deliverLoop()
+	}()
+	return nil
+}
+
+func (ss *SinkService) deliverLoop() {
+	for {
+		select {
+		case changeSet := <-ss.queue:
+			if !ss.deliver(changeSet) {
+				return
+			}
+		case <-ss.quitChan:
+			// deliver what was committed before closing, as long as the sink
+			// accepts it
+			for {
+				select {
+				case changeSet := <-ss.queue:
+					if !ss.deliver(changeSet) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver retries delivering changeSet until it succeeds, backing off between
+// attempts. Once the service is closed, a failed attempt is not retried and
+// false is returned.
+func (ss *SinkService) deliver(changeSet *types.BlockChangeSet) bool {
+	retryInterval := minRetryInterval
+	for {
+		err := ss.sink.Deliver(context.Background(), changeSet)
+		if err == nil {
+			return true
+		}
+		select {
+		case <-ss.quitChan:
+			ss.logger.Error("failed to deliver change set on close, later blocks are not delivered", "height", changeSet.Height, "err", err)
+			return false
+		default:
+		}
+		ss.logger.Error("failed to deliver change set, retrying", "height", changeSet.Height, "err", err)
+		select {
+		case <-time.After(retryInterval):
+		case <-ss.quitChan:
+		}
+		retryInterval *= 2
+		if retryInterval > maxRetryInterval {
+			retryInterval = maxRetryInterval
+		}
+	}
+}
+
+// Close satisfies the io.Closer interface, which satisfies the baseapp.StreamingService interface
+// It stops the delivery loop once the queued change sets are delivered or a
+// delivery fails, then closes the sink. Later calls are no-ops
+func (ss *SinkService) Close() error {
+	ss.closeOnce.Do(func() {
+		if ss.quitChan != nil {
+			close(ss.quitChan)
+			<-ss.doneChan
+		}
+		ss.closeErr = ss.sink.Close()
+	})
+	return ss.closeErr
+}
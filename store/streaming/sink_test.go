@@ -0,0 +1,166 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// fakeSink records the change sets it acknowledges, failing while failures
+// remain and waiting on block when it is set.
+type fakeSink struct {
+	mtx       sync.Mutex
+	delivered []*types.BlockChangeSet
+	attempts  int
+	failures  int
+	block     chan struct{}
+	closed    bool
+}
+
+func newFakeSink() *fakeSink {
+	return &fakeSink{}
+}
+
+func (s *fakeSink) Deliver(_ context.Context, changeSet *types.BlockChangeSet) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.attempts++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.delivered = append(s.delivered, changeSet)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) heights() []int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	heights := make([]int64, len(s.delivered))
+	for i, changeSet := range s.delivered {
+		heights[i] = changeSet.Height
+	}
+	return heights
+}
+
+var (
+	sinkKeyA = sdk.NewKVStoreKey("a")
+	sinkKeyB = sdk.NewKVStoreKey("b")
+)
+
+func TestSinkServiceOrderedDelivery(t *testing.T) {
+	sink := newFakeSink()
+	ss := NewSinkService(sink, []types.StoreKey{sinkKeyA, sinkKeyB}, 0)
+	require.Len(t, ss.Listeners(), 2)
+
+	wg := new(sync.WaitGroup)
+	require.NoError(t, ss.Stream(wg))
+	require.Error(t, ss.Stream(wg))
+
+	// the stores are written in any order, each of them in key order
+	require.NoError(t, ss.OnWrite(sinkKeyB, []byte("1"), []byte("v"), false))
+	require.NoError(t, ss.OnWrite(sinkKeyB, []byte("2"), nil, true))
+	require.NoError(t, ss.OnWrite(sinkKeyA, []byte("3"), []byte("v"), false))
+	require.NoError(t, ss.ListenCommit(context.Background(), 1))
+	require.NoError(t, ss.ListenCommit(context.Background(), 2))
+	require.NoError(t, ss.OnWrite(sinkKeyA, []byte("4"), []byte("v"), false))
+	require.NoError(t, ss.ListenCommit(context.Background(), 3))
+
+	require.NoError(t, ss.Close())
+	wg.Wait()
+	require.True(t, sink.closed)
+	require.Equal(t, []int64{1, 2, 3}, sink.heights())
+
+	require.Equal(t, []*types.StoreKVPair{
+		{StoreKey: "a", Key: []byte("3"), Value: []byte("v")},
+		{StoreKey: "b", Key: []byte("1"), Value: []byte("v")},
+		{StoreKey: "b", Key: []byte("2"), Delete: true},
+	}, sink.delivered[0].Changes)
+	require.Empty(t, sink.delivered[1].Changes)
+	require.Equal(t, []*types.StoreKVPair{
+		{StoreKey: "a", Key: []byte("4"), Value: []byte("v")},
+	}, sink.delivered[2].Changes)
+
+	require.Error(t, ss.ListenCommit(context.Background(), 4))
+	require.NoError(t, ss.Close())
+}
+
+func TestSinkServiceRetriesUntilAcknowledged(t *testing.T) {
+	sink := newFakeSink()
+	sink.failures = 2
+	ss := NewSinkService(sink, []types.StoreKey{sinkKeyA}, 0)
+
+	wg := new(sync.WaitGroup)
+	require.NoError(t, ss.Stream(wg))
+	require.NoError(t, ss.ListenCommit(context.Background(), 1))
+	require.NoError(t, ss.ListenCommit(context.Background(), 2))
+
+	require.Eventually(t, func() bool { return len(sink.heights()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []int64{1, 2}, sink.heights())
+	require.Equal(t, 4, sink.attempts)
+	require.NoError(t, ss.Close())
+	wg.Wait()
+}
+
+func TestSinkServiceBackpressure(t *testing.T) {
+	sink := newFakeSink()
+	sink.block = make(chan struct{})
+	ss := NewSinkService(sink, []types.StoreKey{sinkKeyA}, 1)
+
+	wg := new(sync.WaitGroup)
+	require.NoError(t, ss.Stream(wg))
+	// the first change set is being delivered and the second one fills the queue
+	require.NoError(t, ss.ListenCommit(context.Background(), 1))
+	require.Eventually(t, func() bool { return len(ss.queue) == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, ss.ListenCommit(context.Background(), 2))
+
+	committed := make(chan struct{})
+	go func() {
+		require.NoError(t, ss.ListenCommit(context.Background(), 3))
+		close(committed)
+	}()
+	select {
+	case <-committed:
+		t.Fatal("commit did not wait for the sink to catch up")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(sink.block)
+	<-committed
+	require.NoError(t, ss.Close())
+	wg.Wait()
+	require.Equal(t, []int64{1, 2, 3}, sink.heights())
+}
+
+func TestSinkServiceCloseStopsRetrying(t *testing.T) {
+	sink := newFakeSink()
+	sink.failures = 1000
+	ss := NewSinkService(sink, []types.StoreKey{sinkKeyA}, 0)
+
+	wg := new(sync.WaitGroup)
+	require.NoError(t, ss.Stream(wg))
+	require.NoError(t, ss.ListenCommit(context.Background(), 1))
+	require.NoError(t, ss.ListenCommit(context.Background(), 2))
+
+	require.NoError(t, ss.Close())
+	wg.Wait()
+	require.True(t, sink.closed)
+	require.Empty(t, sink.heights())
+}
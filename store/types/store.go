@@ -118,6 +118,15 @@ type MultiStore interface {
 	GetStore(StoreKey) Store
 	GetKVStore(StoreKey) KVStore
 
+	// StoreKeys returns the keys of all mounted stores, sorted by name.
+	StoreKeys() []StoreKey
+
+	// SetKVStores replaces every substore with the result of handler applied
+	// to it and returns the modified MultiStore. It is used to wrap the
+	// substores of a branch, e.g. with multi-version stores for parallel
+	// execution.
+	SetKVStores(handler func(key StoreKey, s KVStore) CacheWrap) MultiStore
+
 	// TracingEnabled returns if tracing is enabled for the MultiStore.
 	TracingEnabled() bool
 
@@ -0,0 +1,26 @@
+package tasks
+
+// highConflictRate is the number of re-executions per tx above which an
+// adaptive scheduler halves the workers of the next block.
+const highConflictRate = 0.5
+
+// adaptWorkers sizes the workers of the next block from the stats of the last
+// one. Re-executions mean that txs ran on stale state, and with a high conflict
+// rate additional workers mostly produce work that is thrown away, so the
+// workers are halved; a block without re-executions doubles them, up to the
+// pool size.
+func (s *scheduler) adaptWorkers(st Stats) {
+	if !s.adaptive || st.Txs == 0 {
+		return
+	}
+	rate := float64(st.Incarnations-st.Txs) / float64(st.Txs)
+	switch {
+	case rate >= highConflictRate && s.workers > 1:
+		s.workers /= 2
+	case rate == 0 && s.workers < s.poolSize:
+		s.workers *= 2
+		if s.workers > s.poolSize {
+			s.workers = s.poolSize
+		}
+	}
+}
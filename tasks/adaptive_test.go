@@ -0,0 +1,55 @@
+package tasks
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAdaptWorkers(t *testing.T) {
+	s := &scheduler{adaptive: true, workers: 8, poolSize: 8}
+	conflicting := Stats{Txs: 10, Incarnations: 15}
+	conflictFree := Stats{Txs: 10, Incarnations: 10}
+
+	for _, expected := range []int{4, 2, 1, 1} {
+		s.adaptWorkers(conflicting)
+		require.Equal(t, expected, s.workers)
+	}
+	// a few re-executions keep the current workers
+	s.adaptWorkers(Stats{Txs: 10, Incarnations: 12})
+	require.Equal(t, 1, s.workers)
+	for _, expected := range []int{2, 4, 8, 8} {
+		s.adaptWorkers(conflictFree)
+		require.Equal(t, expected, s.workers)
+	}
+
+	// a fixed pool is never resized
+	s = &scheduler{workers: 8, poolSize: 8}
+	s.adaptWorkers(conflicting)
+	require.Equal(t, 8, s.workers)
+}
+
+func TestProcessAllAdaptiveWorkers(t *testing.T) {
+	s := NewScheduler(0, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}).(*scheduler)
+	require.True(t, s.adaptive)
+	require.Equal(t, runtime.NumCPU(), s.poolSize)
+
+	// start from a reduced number of workers, conflict-free blocks grow it back
+	s.workers = 1
+	ctx := initTestCtx()
+	_, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	require.Equal(t, 1, s.Stats().Workers)
+	require.Equal(t, []byte("19"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("19")))
+	if runtime.NumCPU() > 1 {
+		require.Equal(t, 2, s.workers)
+	}
+}
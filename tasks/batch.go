@@ -0,0 +1,111 @@
+package tasks
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// OCCDeliverTxBatch delivers the txs of a batch with a Scheduler, for an app
+// to plug the scheduler into its block execution without a BaseApp.
+type OCCDeliverTxBatch struct {
+	scheduler Scheduler
+	storeKeys []sdk.StoreKey
+	deliverTx func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx
+}
+
+var _ sdk.DeliverTxBatcher = (*OCCDeliverTxBatch)(nil)
+
+// NewOCCDeliverTxBatch returns an OCCDeliverTxBatch processing the batches with
+// scheduler, which must have been created with deliverTx. storeKeys are the
+// stores deliverTx accesses, which the multi store of every batch must mount.
+func NewOCCDeliverTxBatch(scheduler Scheduler, storeKeys []sdk.StoreKey, deliverTx func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx) *OCCDeliverTxBatch {
+	return &OCCDeliverTxBatch{
+		scheduler: scheduler,
+		storeKeys: storeKeys,
+		deliverTx: deliverTx,
+	}
+}
+
+// DeliverTxBatch implements sdk.DeliverTxBatcher. The txs are processed by the
+// scheduler on a branch of the multi store of ctx, written to it once the
+// scheduler commits the batch. If the scheduler fails, nothing is written and
+// the txs are delivered again sequentially, each on a branch written after its
+// execution, like the scheduler does.
+func (b *OCCDeliverTxBatch) DeliverTxBatch(ctx sdk.Context, req sdk.DeliverTxBatchRequest) sdk.DeliverTxBatchResponse {
+	reqs := make([]types.RequestDeliverTx, len(req.TxEntries))
+	var hints [][]acltypes.AccessOperation
+	for i, entry := range req.TxEntries {
+		reqs[i] = entry.Request
+		if entry.AccessOperations != nil {
+			if hints == nil {
+				hints = make([][]acltypes.AccessOperation, len(req.TxEntries))
+			}
+			hints[i] = entry.AccessOperations
+		}
+	}
+
+	responses, err := b.processAll(ctx, reqs, hints)
+	if err != nil {
+		codespace, code, _ := sdkerrors.ABCIInfo(err, false)
+		logger(ctx).Error("scheduler failed, delivering the txs sequentially", "height", ctx.BlockHeight(),
+			"codespace", codespace, "code", code, "err", err)
+		responses = b.deliverSequentially(ctx, reqs)
+	}
+
+	res := sdk.DeliverTxBatchResponse{Results: make([]*sdk.DeliverTxResult, len(responses))}
+	for i, r := range responses {
+		res.Results[i] = &sdk.DeliverTxResult{Response: r}
+	}
+	return res
+}
+
+// processAll processes reqs with the scheduler on a branch of the multi store
+// of ctx, and writes the branch if the scheduler committed them.
+func (b *OCCDeliverTxBatch) processAll(ctx sdk.Context, reqs []types.RequestDeliverTx, hints [][]acltypes.AccessOperation) ([]types.ResponseDeliverTx, error) {
+	mounted := make(map[sdk.StoreKey]bool)
+	for _, storeKey := range ctx.MultiStore().StoreKeys() {
+		mounted[storeKey] = true
+	}
+	for _, storeKey := range b.storeKeys {
+		if !mounted[storeKey] {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "store %s is not mounted", storeKey.Name())
+		}
+	}
+
+	cms := ctx.MultiStore().CacheMultiStore()
+	responses, err := b.scheduler.ProcessAllWithHints(ctx.WithMultiStore(cms), reqs, hints)
+	if err != nil {
+		return nil, err
+	}
+	cms.Write()
+	return responses, nil
+}
+
+// deliverSequentially delivers reqs one at a time, in order, on the multi store
+// of ctx.
+func (b *OCCDeliverTxBatch) deliverSequentially(ctx sdk.Context, reqs []types.RequestDeliverTx) []types.ResponseDeliverTx {
+	responses := make([]types.ResponseDeliverTx, len(reqs))
+	for i, req := range reqs {
+		responses[i] = b.deliverOne(ctx, i, req)
+	}
+	return responses
+}
+
+// deliverOne delivers the tx at index on a branch of the multi store of ctx,
+// written unless deliverTx panics.
+func (b *OCCDeliverTxBatch) deliverOne(ctx sdk.Context, index int, req types.RequestDeliverTx) (resp types.ResponseDeliverTx) {
+	cms := ctx.MultiStore().CacheMultiStore()
+	txCtx := txContext(ctx.WithMultiStore(cms), index)
+	defer func() {
+		if r := recover(); r != nil {
+			resp = panicResponse(ctx, index, r)
+		}
+	}()
+	resp = b.deliverTx(txCtx, req)
+	cms.Write()
+	ctx.EventManager().EmitEvents(txCtx.EventManager().Events())
+	return resp
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestOCCDeliverTxBatch(t *testing.T) {
+	bankKey, stakingKey := sdk.NewKVStoreKey("bank"), sdk.NewKVStoreKey("staking")
+	newCommitStore := func() sdk.CommitMultiStore {
+		cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+		cms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, nil)
+		cms.MountStoreWithDB(stakingKey, sdk.StoreTypeIAVL, nil)
+		require.NoError(t, cms.LoadLatestVersion())
+		return cms
+	}
+	// txs move a balance between accounts and count the moves
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		i, _ := strconv.Atoi(string(req.Tx))
+		bank := ctx.MultiStore().GetKVStore(bankKey)
+		from, to := []byte(fmt.Sprintf("acc%d", i%4)), []byte(fmt.Sprintf("acc%d", (i+1)%4))
+		balance := append(append([]byte{}, bank.Get(from)...), req.Tx...)
+		bank.Set(to, balance)
+		staking := ctx.MultiStore().GetKVStore(stakingKey)
+		moves, _ := strconv.Atoi(string(staking.Get([]byte("moves"))))
+		staking.Set([]byte("moves"), []byte(strconv.Itoa(moves+1)))
+		ctx.EventManager().EmitEvent(sdk.NewEvent("move", sdk.NewAttribute("tx", string(req.Tx))))
+		return types.ResponseDeliverTx{Data: balance}
+	}
+	header := tmproto.Header{Height: 1}
+	batch := sdk.DeliverTxBatchRequest{}
+	for _, req := range requestList(20) {
+		batch.TxEntries = append(batch.TxEntries, &sdk.DeliverTxEntry{Request: req})
+	}
+
+	sequential := newCommitStore()
+	seqCtx := sdk.NewContext(sequential.CacheMultiStore(), header, false, log.NewNopLogger())
+	var expected []types.ResponseDeliverTx
+	for _, entry := range batch.TxEntries {
+		ms := seqCtx.MultiStore().CacheMultiStore()
+		expected = append(expected, deliverTx(seqCtx.WithMultiStore(ms), entry.Request))
+		ms.Write()
+	}
+	seqCtx.MultiStore().(sdk.CacheMultiStore).Write()
+	expectedHash := sequential.Commit(true)
+
+	for name, storeKeys := range map[string][]sdk.StoreKey{
+		"scheduler": {bankKey, stakingKey},
+		// a store that is not mounted makes the batch fall back to sequential
+		"fallback": {bankKey, stakingKey, sdk.NewKVStoreKey("missing")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var batcher sdk.DeliverTxBatcher = NewOCCDeliverTxBatch(NewScheduler(4, deliverTx), storeKeys, deliverTx)
+			store := newCommitStore()
+			ctx := sdk.NewContext(store.CacheMultiStore(), header, false, log.NewNopLogger())
+
+			res := batcher.DeliverTxBatch(ctx, batch)
+			require.Len(t, res.Results, len(expected))
+			for i, result := range res.Results {
+				require.Equal(t, expected[i].Data, result.Response.Data, "tx %d", i)
+			}
+			events := ctx.EventManager().Events()
+			require.Len(t, events, len(expected))
+			for i, event := range events {
+				require.Equal(t, strconv.Itoa(i), string(event.Attributes[0].Value))
+			}
+			ctx.MultiStore().(sdk.CacheMultiStore).Write()
+			require.Equal(t, expectedHash, store.Commit(true))
+		})
+	}
+}
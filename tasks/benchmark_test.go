@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoopDeliverTx(t *testing.T) {
+	s := NewScheduler(4, NoopDeliverTx)
+	ctx := initTestCtx()
+	res, err := s.ProcessAll(ctx, requestList(10))
+	require.NoError(t, err)
+	require.Len(t, res, 10)
+	for _, r := range res {
+		require.True(t, r.IsOK())
+	}
+	require.Equal(t, 10, s.Stats().Incarnations)
+	require.Empty(t, s.(*scheduler).concurrentWrites())
+}
+
+// BenchmarkProcessAllOverhead measures the cost of the scheduler alone, for
+// blocks of txs that do nothing.
+func BenchmarkProcessAllOverhead(b *testing.B) {
+	for _, txs := range []int{10, 100, 1000} {
+		for _, workers := range []int{1, 4, 16} {
+			b.Run(fmt.Sprintf("txs=%d/workers=%d", txs, workers), func(b *testing.B) {
+				s := NewScheduler(workers, NoopDeliverTx)
+				reqs := requestList(txs)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					ctx := initTestCtx()
+					b.StartTimer()
+					if _, err := s.ProcessAll(ctx, reqs); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
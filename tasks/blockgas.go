@@ -0,0 +1,145 @@
+package tasks
+
+import (
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// blockGas tracks the block gas consumed by the txs of a block, so that each
+// tx runs with the block gas meter it would have had sequentially. runTx
+// refuses a tx once the block gas is exhausted and fails the tx that exhausts
+// it, and both outcomes depend on the gas used by every lower-indexed tx.
+type blockGas struct {
+	// limit is the block gas limit, zero means no limit
+	limit uint64
+	// base is the block gas consumed before the block was processed
+	base uint64
+	// used holds the block gas consumed by the last execution of each tx
+	used []uint64
+}
+
+func newBlockGas(ctx sdk.Context, txs int) *blockGas {
+	bg := &blockGas{used: make([]uint64, txs)}
+	if meter := ctx.BlockGasMeter(); meter != nil {
+		bg.limit = meter.Limit()
+		bg.base = meter.GasConsumed()
+	}
+	return bg
+}
+
+// consumedBefore returns the block gas consumed by the txs below index, as of
+// their last executions.
+func (bg *blockGas) consumedBefore(index int) uint64 {
+	consumed := bg.base
+	for i := 0; i < index; i++ {
+		consumed += atomic.LoadUint64(&bg.used[i])
+	}
+	return consumed
+}
+
+// meter returns a block gas meter that has already consumed consumed.
+func (bg *blockGas) meter(consumed uint64) sdk.GasMeter {
+	if bg.limit == 0 {
+		meter := sdk.NewInfiniteGasMeter()
+		meter.ConsumeGas(consumed, "block gas meter")
+		return meter
+	}
+	// the meter is out of gas either way, and consuming past the limit panics
+	if consumed > bg.limit {
+		consumed = bg.limit
+	}
+	meter := sdk.NewGasMeter(bg.limit)
+	meter.ConsumeGas(consumed, "block gas meter")
+	return meter
+}
+
+// record saves the block gas consumed by an execution of the tx at index. It
+// is what runTx consumes from the block gas meter: the gas used by the tx, up
+// to its gas limit.
+func (bg *blockGas) record(index int, resp types.ResponseDeliverTx) {
+	atomic.StoreUint64(&bg.used[index], blockGasUsed(resp))
+}
+
+// blockGasUsed returns the block gas consumed by a tx that responded resp.
+func blockGasUsed(resp types.ResponseDeliverTx) uint64 {
+	if resp.GasWanted > 0 && resp.GasUsed > resp.GasWanted {
+		return uint64(resp.GasWanted)
+	}
+	return uint64(resp.GasUsed)
+}
+
+// WithGasInvariantCheck makes the scheduler check, before committing a block,
+// that the block gas it is about to consume is the sum of the gas used by the
+// committed responses, in index order, and fail the block with
+// ErrGasInvariantViolated otherwise. A difference is a bug in the tracking of
+// the block gas of the executions, which would make nodes disagree.
+func WithGasInvariantCheck() Option {
+	return func(s *scheduler) {
+		s.checkGasInvariant = true
+	}
+}
+
+// verifyGasInvariant returns an error if the block gas recorded for the tasks
+// differs from the gas of their responses. It is a no-op unless the scheduler
+// was created WithGasInvariantCheck.
+func (s *scheduler) verifyGasInvariant(tasks []*deliverTxTask) error {
+	if !s.checkGasInvariant {
+		return nil
+	}
+	var expected uint64
+	for _, t := range tasks {
+		expected += blockGasUsed(*t.Response)
+	}
+	if recorded := s.blockGas.consumedBefore(len(tasks)) - s.blockGas.base; recorded != expected {
+		return sdkerrors.Wrapf(occ.ErrGasInvariantViolated,
+			"block gas recorded is %d, the responses used %d", recorded, expected)
+	}
+	return nil
+}
+
+// sameOutcome reports whether a tx that used used gas would have been refused
+// or failed for lack of block gas the same way with observed and actual block
+// gas consumed before it. Without a limit txs never run out of block gas.
+func (bg *blockGas) sameOutcome(observed, actual, used uint64) bool {
+	if bg.limit == 0 {
+		return true
+	}
+	refused := actual >= bg.limit
+	if refused != (observed >= bg.limit) {
+		return false
+	}
+	return refused || (actual+used > bg.limit) == (observed+used > bg.limit)
+}
+
+// validateBlockGas reports whether the block gas meter the task's last
+// execution ran with still leads to the same outcome.
+func (s *scheduler) validateBlockGas(task *deliverTxTask) bool {
+	if s.blockGas.limit == 0 {
+		return true
+	}
+	used := atomic.LoadUint64(&s.blockGas.used[task.Index])
+	return s.blockGas.sameOutcome(task.BlockGasBefore, s.blockGas.consumedBefore(task.Index), used)
+}
+
+// consumeBlockGas consumes the block gas of every tx on ctx's block gas meter.
+// Like the tx that exhausts the block gas in runTx, it consumes past the limit
+// and recovers from the out of gas panic.
+func (s *scheduler) consumeBlockGas(ctx sdk.Context) {
+	meter := ctx.BlockGasMeter()
+	if meter == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+		}
+	}()
+	meter.ConsumeGas(s.blockGas.consumedBefore(len(s.blockGas.used))-s.blockGas.base, "block gas meter")
+}
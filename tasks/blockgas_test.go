@@ -0,0 +1,112 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// blockGasDeliverTx uses 10 gas more for each tx index and handles the block gas meter
+// like runTx: a tx is refused once the block gas is exhausted, and the tx that
+// exhausts it fails.
+func blockGasDeliverTx(ctx sdk.Context, req types.RequestDeliverTx) (res types.ResponseDeliverTx) {
+	if ctx.BlockGasMeter().IsOutOfGas() {
+		return types.ResponseDeliverTx{Code: 1}
+	}
+	ctx.MultiStore().GetKVStore(testStoreKey).Set([]byte("last"), req.Tx)
+	gas := uint64(10 * (int(req.Tx[0]-'0') + 1))
+	defer func() {
+		if r := recover(); r != nil {
+			res = types.ResponseDeliverTx{Code: 2, GasUsed: int64(gas)}
+		}
+	}()
+	ctx.BlockGasMeter().ConsumeGas(gas, "block gas meter")
+	return types.ResponseDeliverTx{GasUsed: int64(gas)}
+}
+
+func TestProcessAllBlockGasLimit(t *testing.T) {
+	for _, workers := range []int{1, 4, -1} {
+		s := NewScheduler(workers, blockGasDeliverTx, WithShadowMode())
+		meter := sdk.NewGasMeter(95)
+		meter.ConsumeGas(5, "before the block")
+		ctx := initTestCtx().WithBlockGasMeter(meter)
+
+		// txs use 10, 20, 30 and 40 gas, the last one goes past the limit
+		res, err := s.ProcessAll(ctx, requestList(8))
+		require.NoError(t, err)
+		var codes []uint32
+		for _, r := range res {
+			codes = append(codes, r.Code)
+		}
+		require.Equal(t, []uint32{0, 0, 0, 2, 1, 1, 1, 1}, codes)
+		require.Equal(t, uint64(105), meter.GasConsumed())
+		require.Equal(t, 0, s.Stats().ShadowDivergences)
+	}
+}
+
+func TestProcessAllWithoutBlockGasLimit(t *testing.T) {
+	s := NewScheduler(4, blockGasDeliverTx)
+	meter := sdk.NewInfiniteGasMeter()
+	ctx := initTestCtx().WithBlockGasMeter(meter)
+
+	res, err := s.ProcessAll(ctx, requestList(8))
+	require.NoError(t, err)
+	for _, r := range res {
+		require.Zero(t, r.Code)
+	}
+	require.Equal(t, uint64(360), meter.GasConsumed())
+}
+
+func TestBlockGasSameOutcome(t *testing.T) {
+	bg := &blockGas{limit: 100}
+	// both run and fit in the block
+	require.True(t, bg.sameOutcome(10, 50, 20))
+	// the tx fits with the observed gas but exhausts the block with the actual one
+	require.False(t, bg.sameOutcome(10, 90, 20))
+	// refused either way
+	require.True(t, bg.sameOutcome(100, 120, 0))
+	// refused, but there is block gas left
+	require.False(t, bg.sameOutcome(100, 90, 0))
+
+	// without a limit txs never run out of block gas
+	require.True(t, (&blockGas{}).sameOutcome(10, 1000, 20))
+}
+
+func TestProcessAllWithGasInvariantCheck(t *testing.T) {
+	for _, workers := range []int{1, 4, -1} {
+		s := NewScheduler(workers, blockGasDeliverTx, WithGasInvariantCheck())
+		meter := sdk.NewGasMeter(95)
+		meter.ConsumeGas(5, "before the block")
+		_, err := s.ProcessAll(initTestCtx().WithBlockGasMeter(meter), requestList(8))
+		require.NoError(t, err)
+		require.Equal(t, uint64(105), meter.GasConsumed())
+	}
+}
+
+func TestVerifyGasInvariant(t *testing.T) {
+	s := NewScheduler(1, nil, WithGasInvariantCheck()).(*scheduler)
+	meter := sdk.NewInfiniteGasMeter()
+	meter.ConsumeGas(5, "before the block")
+	s.initBlock(initTestCtx().WithBlockGasMeter(meter), 3)
+	tasks := toTasks(requestList(3))
+	for i, t := range tasks {
+		// the gas past the gas wanted is not consumed from the block
+		t.Response = &types.ResponseDeliverTx{GasWanted: 25, GasUsed: int64(10 * (i + 1))}
+		s.blockGas.record(i, *t.Response)
+	}
+	require.NoError(t, s.verifyGasInvariant(tasks))
+
+	// a gas accounting bug
+	s.blockGas.record(1, types.ResponseDeliverTx{GasUsed: 21})
+	err := s.verifyGasInvariant(tasks)
+	require.ErrorIs(t, err, occ.ErrGasInvariantViolated)
+	require.Contains(t, err.Error(), "block gas recorded is 56, the responses used 55")
+
+	// the check is opt-in
+	s.checkGasInvariant = false
+	require.NoError(t, s.verifyGasInvariant(tasks))
+}
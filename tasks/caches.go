@@ -0,0 +1,27 @@
+package tasks
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithBranchedCaches makes the scheduler give every execution of a tx its own
+// branch of the sdk.BranchableCache stored on the block's context under each
+// of keys, instead of sharing the cache with the txs executing concurrently.
+// The branches are discarded with their execution, leaving the cache as it
+// was before the block.
+func WithBranchedCaches(keys ...interface{}) Option {
+	return func(s *scheduler) {
+		s.branchedCaches = append(s.branchedCaches, keys...)
+	}
+}
+
+// branchCaches returns ctx with a branch of every cache the scheduler was told
+// about.
+func (s *scheduler) branchCaches(ctx sdk.Context) sdk.Context {
+	for _, key := range s.branchedCaches {
+		if cache, ok := ctx.Value(key).(sdk.BranchableCache); ok {
+			ctx = ctx.WithValue(key, cache.Branch())
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	balanceCacheKey struct{}
+	otherCacheKey   struct{}
+	missingCacheKey struct{}
+)
+
+// balanceCache is a cache of balances, like a keeper would keep on the context.
+type balanceCache map[string]int
+
+func (c balanceCache) Branch() sdk.BranchableCache {
+	branch := make(balanceCache, len(c))
+	for k, v := range c {
+		branch[k] = v
+	}
+	return branch
+}
+
+func TestProcessAllWithBranchedCaches(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// every tx increments the balance, through the cache
+		cache := ctx.Value(balanceCacheKey{}).(balanceCache)
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		balance, ok := cache["balance"]
+		if !ok {
+			balance, _ = strconv.Atoi(string(kv.Get([]byte("balance"))))
+		}
+		// the cache is only trusted if it matches the state
+		if stored, _ := strconv.Atoi(string(kv.Get([]byte("balance")))); stored != balance {
+			balance = stored
+		}
+		cache["balance"] = balance + 1
+		kv.Set([]byte("balance"), []byte(strconv.Itoa(balance+1)))
+		return types.ResponseDeliverTx{}
+	}
+
+	cache := balanceCache{"balance": 0}
+	s := NewScheduler(8, deliverTx, WithBranchedCaches(balanceCacheKey{}))
+	for i := 0; i < 10; i++ {
+		ctx := initTestCtx().WithValue(balanceCacheKey{}, cache)
+		_, err := s.ProcessAll(ctx, requestList(50))
+		require.NoError(t, err)
+		require.Equal(t, []byte("50"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("balance")))
+		// the executions only wrote to their branches
+		require.Equal(t, balanceCache{"balance": 0}, cache)
+	}
+}
+
+func TestBranchCaches(t *testing.T) {
+	cache := balanceCache{"balance": 1}
+	ctx := initTestCtx().WithValue(balanceCacheKey{}, cache).WithValue(otherCacheKey{}, balanceCache{})
+
+	s := NewScheduler(1, nil, WithBranchedCaches(balanceCacheKey{}, missingCacheKey{})).(*scheduler)
+	branched := s.branchCaches(ctx)
+	branch := branched.Value(balanceCacheKey{}).(balanceCache)
+	branch["balance"] = 2
+	require.Equal(t, 1, cache["balance"])
+	// the other values are left as they are
+	require.Equal(t, ctx.Value(otherCacheKey{}), branched.Value(otherCacheKey{}))
+	require.Nil(t, branched.Value(missingCacheKey{}))
+}
@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"bytes"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// retainFirstResult keeps the response and the writes of the execution that
+// just completed, if it is the first of the task.
+func (dt *deliverTxTask) retainFirstResult() {
+	if dt.FirstResponse == nil {
+		dt.FirstResponse = dt.Response
+		dt.FirstWriteSet = dt.WriteSet
+	}
+}
+
+// changedAcrossIncarnations returns the indexes of the tasks whose response or
+// writes differ from those of their first execution. A tx re-executed only
+// because it was invalidated normally gives the same result when the values it
+// read did not matter, so a change flags a tx sensitive to the state it
+// observed.
+func changedAcrossIncarnations(tasks []*deliverTxTask) []int {
+	var changed []int
+	for _, t := range tasks {
+		if t.FirstResponse == nil || t.Response == nil {
+			continue
+		}
+		if !sameResponse(*t.FirstResponse, *t.Response) || !sameWriteSets(t.FirstWriteSet, t.WriteSet) {
+			changed = append(changed, t.Index)
+		}
+	}
+	return changed
+}
+
+// sameWriteSets returns whether a and b write the same values to the same
+// keys, an empty writeset being the same as none.
+func sameWriteSets(a, b map[sdk.StoreKey]multiversion.WriteSet) bool {
+	for _, storeKey := range writeSetKeys(a, b) {
+		writesA, writesB := a[storeKey], b[storeKey]
+		if len(writesA) != len(writesB) {
+			return false
+		}
+		for key, valueA := range writesA {
+			valueB, ok := writesB[key]
+			if !ok || (valueA == nil) != (valueB == nil) || !bytes.Equal(valueA, valueB) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// writeSetKeys returns the store keys of a and b.
+func writeSetKeys(a, b map[sdk.StoreKey]multiversion.WriteSet) []sdk.StoreKey {
+	keys := make([]sdk.StoreKey, 0, len(a)+len(b))
+	for storeKey := range a {
+		keys = append(keys, storeKey)
+	}
+	for storeKey := range b {
+		if _, ok := a[storeKey]; !ok {
+			keys = append(keys, storeKey)
+		}
+	}
+	return keys
+}
+
+// ChangedAcrossIncarnations implements Scheduler.
+func (s *scheduler) ChangedAcrossIncarnations() []int {
+	return s.changed
+}
@@ -0,0 +1,61 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestChangedAcrossIncarnations(t *testing.T) {
+	s := NewScheduler(3, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		switch string(req.Tx) {
+		case "0":
+			// slow for the others to read the shared key before it is written
+			time.Sleep(10 * time.Millisecond)
+			kv.Set([]byte("shared"), []byte("0"))
+			return types.ResponseDeliverTx{}
+		case "1":
+			// the response depends on the value read
+			return types.ResponseDeliverTx{Data: kv.Get([]byte("shared"))}
+		default:
+			// invalidated as well, but the result does not depend on the value read
+			kv.Get([]byte("shared"))
+			kv.Set(req.Tx, req.Tx)
+			return types.ResponseDeliverTx{}
+		}
+	})
+	require.Empty(t, s.ChangedAcrossIncarnations())
+
+	res, err := s.ProcessAll(initTestCtx(), requestList(3))
+	require.NoError(t, err)
+	require.Equal(t, []byte("0"), res[1].Data)
+	// both txs reading the shared key executed again
+	require.Equal(t, 5, s.Stats().Incarnations)
+	require.Equal(t, []int{1}, s.ChangedAcrossIncarnations())
+
+	// without re-executions nothing changes
+	_, err = s.ProcessAll(initTestCtx(), requestList(1))
+	require.NoError(t, err)
+	require.Empty(t, s.ChangedAcrossIncarnations())
+}
+
+func TestSameWriteSets(t *testing.T) {
+	otherKey := sdk.NewKVStoreKey("other")
+	writes := map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {"a": []byte("1"), "b": nil}}
+	require.True(t, sameWriteSets(writes, map[sdk.StoreKey]multiversion.WriteSet{
+		testStoreKey: {"a": []byte("1"), "b": nil},
+		otherKey:     {},
+	}))
+	require.True(t, sameWriteSets(nil, map[sdk.StoreKey]multiversion.WriteSet{otherKey: {}}))
+	// a delete is not a write of an empty value
+	require.False(t, sameWriteSets(writes, map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {"a": []byte("1"), "b": {}}}))
+	require.False(t, sameWriteSets(writes, map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {"a": []byte("2"), "b": nil}}))
+	require.False(t, sameWriteSets(writes, map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {"a": []byte("1")}}))
+	require.False(t, sameWriteSets(writes, map[sdk.StoreKey]multiversion.WriteSet{otherKey: {"a": []byte("1"), "b": nil}}))
+}
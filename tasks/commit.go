@@ -0,0 +1,37 @@
+package tasks
+
+import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// WithCommitGas makes the scheduler price the writes it commits for a block
+// with gasConfig, as a gaskv store would, and report the gas in
+// Stats.CommitGas. The gas is not charged to any tx.
+func WithCommitGas(gasConfig storetypes.GasConfig) Option {
+	return func(s *scheduler) {
+		s.commitGasConfig = &gasConfig
+	}
+}
+
+// commitGas returns the gas of the final writes of the block, zero unless the
+// scheduler was created WithCommitGas. Every key written is priced once, with
+// the value it ends up with, so the gas does not depend on how many
+// incarnations wrote it.
+func (s *scheduler) commitGas() uint64 {
+	if s.commitGasConfig == nil {
+		return 0
+	}
+	var gas uint64
+	for _, writes := range s.concurrentWrites() {
+		for key, value := range writes {
+			if value == nil {
+				gas += s.commitGasConfig.DeleteCost
+				continue
+			}
+			gas += s.commitGasConfig.WriteCostFlat + s.commitGasConfig.WriteCostPerByte*uint64(len(key)+len(value))
+		}
+	}
+	telemetry.IncrCounter(float32(gas), "scheduler", "commit_gas")
+	return gas
+}
@@ -0,0 +1,47 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestProcessAllCommitCosts(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// every tx overwrites the shared key, and deletes the key of the one before
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), req.Tx)
+		kv.Set(append([]byte("tx"), req.Tx...), []byte("value"))
+		i, _ := strconv.Atoi(string(req.Tx))
+		kv.Delete(append([]byte("tx"), strconv.Itoa(i-1)...))
+		return types.ResponseDeliverTx{}
+	}
+	gasConfig := storetypes.KVGasConfig()
+
+	// without a gas config, only the duration is recorded
+	s := NewScheduler(4, deliverTx)
+	_, err := s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+	require.Positive(t, s.Stats().CommitDuration)
+	require.Zero(t, s.Stats().CommitGas)
+
+	// "shared" set to "9", "tx9" set to "value", "tx-1" to "tx8" deleted
+	expected := gasConfig.WriteCostFlat + gasConfig.WriteCostPerByte*uint64(len("shared")+len("9")) +
+		gasConfig.WriteCostFlat + gasConfig.WriteCostPerByte*uint64(len("tx9")+len("value")) +
+		10*gasConfig.DeleteCost
+	for _, workers := range []int{1, 4, 10} {
+		for _, opts := range [][]Option{nil, {WithMaxRounds(1)}} {
+			s := NewScheduler(workers, deliverTx, append(opts, WithCommitGas(gasConfig))...)
+			_, err := s.ProcessAll(initTestCtx(), requestList(10))
+			require.NoError(t, err)
+			require.Equal(t, expected, s.Stats().CommitGas)
+			require.Positive(t, s.Stats().CommitDuration)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tasks
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ConflictDetector lets an app tell the scheduler which stale reads do not
+// require a tx to re-execute. It is consulted during validation for the keys a
+// tx read but did not write.
+//
+// A key the tx also wrote is always validated: its new value may have been
+// computed from the stale one, e.g. fees = fees + 1, and would overwrite the
+// updates of the lower-indexed txs. Returning false for a key is therefore only
+// sound if the response and the writes of the tx do not depend on the value it
+// read, for instance a check that the fee collector exists.
+type ConflictDetector interface {
+	IsConflict(storeKey sdk.StoreKey, key []byte) bool
+}
+
+// WithConflictDetector makes the scheduler ignore the stale reads that
+// conflictDetector does not consider conflicts.
+func WithConflictDetector(conflictDetector ConflictDetector) Option {
+	return func(s *scheduler) {
+		s.conflictDetector = conflictDetector
+	}
+}
+
+// conflictingReads drops from readset the keys that the conflict detector
+// allows to be read concurrently, unless they are in writeset.
+func (s *scheduler) conflictingReads(storeKey sdk.StoreKey, readset multiversion.ReadSet, writeset multiversion.WriteSet) multiversion.ReadSet {
+	if s.conflictDetector == nil {
+		return readset
+	}
+	res := make(multiversion.ReadSet, len(readset))
+	for key, value := range readset {
+		if _, written := writeset[key]; written || s.conflictDetector.IsConflict(storeKey, []byte(key)) {
+			res[key] = value
+		}
+	}
+	return res
+}
+
+// staleRead is a key read by a task whose value has since changed.
+type staleRead struct {
+	storeKey sdk.StoreKey
+	key      string
+}
+
+// staleReads returns the reads of the task's last execution that no longer
+// match the multi-version stores, ignoring the ones the conflict detector
+// allows. It returns no reads if the conflict detector panics, the task has
+// then already been invalidated by validateTaskWithRecover.
+func (s *scheduler) staleReads(task *deliverTxTask) (reads []staleRead) {
+	defer func() {
+		if r := recover(); r != nil {
+			reads = nil
+		}
+	}()
+	for storeKey, readset := range task.ReadSet {
+		for key, value := range s.conflictingReads(storeKey, readset, task.WriteSet[storeKey]) {
+			if !s.multiVersionStores[storeKey].ValidateReadset(task.Index, multiversion.ReadSet{key: value}) {
+				reads = append(reads, staleRead{storeKey: storeKey, key: key})
+			}
+		}
+	}
+	return reads
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+type ignoreKeyDetector string
+
+func (key ignoreKeyDetector) IsConflict(_ sdk.StoreKey, k []byte) bool {
+	return string(k) != string(key)
+}
+
+func TestProcessAllWithConflictDetector(t *testing.T) {
+	var executions int64
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		atomic.AddInt64(&executions, 1)
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		// every tx reads the fees, only the first one updates them
+		kv.Get([]byte("fees"))
+		if string(req.Tx) == "0" {
+			kv.Set([]byte("fees"), []byte("1"))
+		}
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}, WithConflictDetector(ignoreKeyDetector("fees")))
+	ctx := initTestCtx()
+
+	_, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	// stale reads of the fees never cause a re-execution
+	require.Equal(t, int64(20), atomic.LoadInt64(&executions))
+	require.Equal(t, 0, s.Stats().ValidationFailures)
+	require.Equal(t, []byte("1"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("fees")))
+}
+
+func TestProcessAllWithConflictDetectorIncrements(t *testing.T) {
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		// every tx adds to the fees it read, the stale reads must be re-executed
+		fees, _ := strconv.Atoi(string(kv.Get([]byte("fees"))))
+		kv.Set([]byte("fees"), []byte(strconv.Itoa(fees+1)))
+		return types.ResponseDeliverTx{}
+	}, WithConflictDetector(ignoreKeyDetector("fees")))
+
+	for i := 0; i < 20; i++ {
+		ctx := initTestCtx()
+		_, err := s.ProcessAll(ctx, requestList(20))
+		require.NoError(t, err)
+		require.Equal(t, []byte("20"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("fees")))
+	}
+}
+
+type panicDetector string
+
+func (key panicDetector) IsConflict(_ sdk.StoreKey, k []byte) bool {
+	if string(k) == string(key) {
+		panic("cannot tell")
+	}
+	return true
+}
+
+func TestProcessAllWithPanickingConflictDetector(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Get([]byte("boom"))
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}, WithConflictDetector(panicDetector("boom")), WithMaxValidationPanics(0), WithMaxIncarnation(3), WithConflictGraph(""))
+	ctx := initTestCtx()
+
+	// the block fails instead of the node
+	_, err := s.ProcessAll(ctx, requestList(5))
+	require.ErrorIs(t, err, occ.ErrMaxIncarnationsExceeded)
+	require.Nil(t, ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("0")))
+}
+
+func TestConflictingReads(t *testing.T) {
+	s := NewScheduler(1, nil).(*scheduler)
+	readset := map[string][]byte{"fees": nil, "balance": []byte("1")}
+	require.Equal(t, readset, map[string][]byte(s.conflictingReads(testStoreKey, readset, nil)))
+
+	s = NewScheduler(1, nil, WithConflictDetector(ignoreKeyDetector("fees"))).(*scheduler)
+	require.Equal(t, map[string][]byte{"balance": []byte("1")}, map[string][]byte(s.conflictingReads(testStoreKey, readset, nil)))
+	// the keys written are validated whatever the detector
+	written := multiversion.WriteSet{"fees": []byte("1")}
+	require.Equal(t, readset, map[string][]byte(s.conflictingReads(testStoreKey, readset, written)))
+}
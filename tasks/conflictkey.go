@@ -0,0 +1,89 @@
+package tasks
+
+import (
+	"sync/atomic"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ConflictKeyFunc maps a key of a store to its conflict key, e.g. the address
+// of the account the key belongs to. Two txs accessing keys with the same
+// conflict key conflict, even if the keys differ.
+//
+// It must be a pure function of its arguments, and must not panic: it is
+// called by the workers concurrently, and an access must map to the same
+// conflict key when the writer publishes it and when the reader is validated.
+// The results of a block do not depend on it, only the txs re-executed do.
+type ConflictKeyFunc func(storeKey sdk.StoreKey, key []byte) []byte
+
+// WithConflictKeyFunc makes a tx that read or wrote a key conflict with the
+// lower-indexed txs writing a key with the same conflict key, as given by
+// conflictKeyFunc. The tx is invalid if such a tx published its writes after
+// the tx started executing, and so it may not have seen them, in addition to
+// the reads whose values changed.
+func WithConflictKeyFunc(conflictKeyFunc ConflictKeyFunc) Option {
+	return func(s *scheduler) {
+		s.conflictKeyFunc = conflictKeyFunc
+	}
+}
+
+// conflictKey returns the conflict key of a key of storeKey, prefixed by the
+// name of the store.
+func (s *scheduler) conflictKey(storeKey sdk.StoreKey, key string) string {
+	return storeKey.Name() + "/" + string(s.conflictKeyFunc(storeKey, []byte(key)))
+}
+
+// publishConflictKeys records the conflict keys written by the last execution
+// of the task as published now.
+func (s *scheduler) publishConflictKeys(task *deliverTxTask) {
+	if s.conflictKeyFunc == nil {
+		return
+	}
+	keys := make(map[string]struct{})
+	for storeKey, writeset := range task.WriteSet {
+		for key := range writeset {
+			keys[s.conflictKey(storeKey, key)] = struct{}{}
+		}
+	}
+
+	s.conflictKeyWritesMtx.Lock()
+	defer s.conflictKeyWritesMtx.Unlock()
+	published := atomic.AddUint64(&s.sequence, 1)
+	for key := range keys {
+		if _, ok := s.conflictKeyWrites[key]; !ok {
+			s.conflictKeyWrites[key] = make(map[int]uint64)
+		}
+		s.conflictKeyWrites[key][task.Index] = published
+	}
+}
+
+// conflictsCoarsely reports whether a lower-indexed task published a write
+// with the conflict key of a key the task read or wrote after the task started
+// its last execution.
+func (s *scheduler) conflictsCoarsely(task *deliverTxTask) bool {
+	if s.conflictKeyFunc == nil {
+		return false
+	}
+	keys := make(map[string]struct{})
+	for storeKey, readset := range task.ReadSet {
+		for key := range readset {
+			keys[s.conflictKey(storeKey, key)] = struct{}{}
+		}
+	}
+	for storeKey, writeset := range task.WriteSet {
+		for key := range writeset {
+			keys[s.conflictKey(storeKey, key)] = struct{}{}
+		}
+	}
+
+	s.conflictKeyWritesMtx.Lock()
+	defer s.conflictKeyWritesMtx.Unlock()
+	for key := range keys {
+		for index, published := range s.conflictKeyWrites[key] {
+			if index < task.Index && published > task.StartSequence {
+				return true
+			}
+		}
+	}
+	return false
+}
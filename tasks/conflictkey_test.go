@@ -0,0 +1,86 @@
+package tasks
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// accountKey is the conflict key of keys of the form account/field.
+func accountKey(_ sdk.StoreKey, key []byte) []byte {
+	if i := bytes.IndexByte(key, '/'); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func TestProcessAllWithConflictKeyFunc(t *testing.T) {
+	for name, tt := range map[string]struct {
+		opts       []Option
+		executions int
+	}{
+		"exact keys":    {executions: 1},
+		"conflict keys": {opts: []Option{WithConflictKeyFunc(accountKey)}, executions: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mtx sync.Mutex
+			executions := make(map[string]int)
+			s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				mtx.Lock()
+				executions[string(req.Tx)]++
+				mtx.Unlock()
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				switch string(req.Tx) {
+				case "0":
+					// the balance of alice changes once tx 1 started
+					time.Sleep(10 * time.Millisecond)
+					kv.Set([]byte("alice/balance"), []byte("10"))
+				case "1":
+					// the nonce of alice is another key of the same account
+					kv.Get([]byte("alice/nonce"))
+				default:
+					kv.Get([]byte("bob/nonce"))
+				}
+				return types.ResponseDeliverTx{}
+			}, tt.opts...)
+			ctx := initTestCtx()
+
+			_, err := s.ProcessAll(ctx, requestList(4))
+			require.NoError(t, err)
+			require.Equal(t, 1, executions["0"])
+			require.Equal(t, tt.executions, executions["1"])
+			// the txs of other accounts are not re-executed
+			require.Equal(t, 1, executions["2"])
+			require.Equal(t, 1, executions["3"])
+			require.Equal(t, []byte("10"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("alice/balance")))
+		})
+	}
+}
+
+func TestConflictsCoarsely(t *testing.T) {
+	s := NewScheduler(1, nil, WithConflictKeyFunc(accountKey)).(*scheduler)
+	s.initBlock(initTestCtx(), 3)
+	writer := &deliverTxTask{Index: 0, WriteSet: map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {"alice/balance": []byte("1")}}}
+	reader := &deliverTxTask{Index: 1, ReadSet: map[sdk.StoreKey]multiversion.ReadSet{testStoreKey: {"alice/nonce": nil}}}
+
+	// the write was published before the reader started
+	s.publishConflictKeys(writer)
+	reader.StartSequence = s.sequence
+	require.False(t, s.conflictsCoarsely(reader))
+
+	// and after
+	s.publishConflictKeys(writer)
+	require.True(t, s.conflictsCoarsely(reader))
+	// a higher-indexed writer does not conflict
+	writer.Index = 2
+	s.initBlock(initTestCtx(), 3)
+	s.publishConflictKeys(writer)
+	require.False(t, s.conflictsCoarsely(reader))
+}
@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// Fault is a failure injected in the executions of a tx, see FaultInjector.
+type Fault int
+
+const (
+	// NoFault executes the tx normally.
+	NoFault Fault = iota
+	// FaultError makes every execution of the tx fail with an error.
+	FaultError
+	// FaultPanic makes every execution of the tx panic.
+	FaultPanic
+	// FaultTimeout makes the first execution of the tx time out, as if it ran
+	// past the task timeout.
+	FaultTimeout
+)
+
+// FaultInjector returns the fault to inject in the executions of the tx at
+// index. The fault of a tx must not depend on its incarnation, for the
+// results of a block to be deterministic.
+type FaultInjector func(index int) Fault
+
+// NewFaultInjector returns a FaultInjector injecting one of faults, picked at
+// random, in the given fraction of the txs. The txs and their faults only
+// depend on seed and their index.
+func NewFaultInjector(seed int64, fraction float64, faults ...Fault) FaultInjector {
+	return func(index int) Fault {
+		if len(faults) == 0 {
+			return NoFault
+		}
+		rng := rand.New(rand.NewSource(seed ^ int64(index)*0x5bd1e995))
+		if rng.Float64() >= fraction {
+			return NoFault
+		}
+		return faults[rng.Intn(len(faults))]
+	}
+}
+
+// WithFaultInjector makes the scheduler inject the faults of faultInjector in
+// the executions of the txs, to test how it recovers from them. It must only
+// be used in tests.
+func WithFaultInjector(faultInjector FaultInjector) Option {
+	return func(s *scheduler) {
+		s.faultInjector = faultInjector
+	}
+}
+
+// injectFault injects the fault of the task, if any, in its execution. It
+// returns whether the execution failed with resp, and panics for the faults
+// recovered as panics and aborts.
+func (s *scheduler) injectFault(task *deliverTxTask) (failed bool, resp types.ResponseDeliverTx) {
+	if s.faultInjector == nil {
+		return false, resp
+	}
+	switch s.faultInjector(task.Index) {
+	case FaultError:
+		return true, sdkerrors.ResponseDeliverTx(sdkerrors.Wrapf(sdkerrors.ErrLogic, "injected fault in tx %d", task.Index), 0, 0, false)
+	case FaultPanic:
+		panic(fmt.Sprintf("injected fault in tx %d", task.Index))
+	case FaultTimeout:
+		if task.Incarnation == 0 {
+			panic(occ.NewTimeoutAbort())
+		}
+	}
+	return false, resp
+}
@@ -0,0 +1,114 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestNewFaultInjector(t *testing.T) {
+	injector := NewFaultInjector(42, 0.5, FaultError, FaultPanic)
+	faults := make(map[Fault]int)
+	for i := 0; i < 1000; i++ {
+		fault := injector(i)
+		// the fault of a tx only depends on the seed and its index
+		require.Equal(t, fault, NewFaultInjector(42, 0.5, FaultError, FaultPanic)(i))
+		faults[fault]++
+	}
+	require.InDelta(t, 500, faults[NoFault], 100)
+	require.NotZero(t, faults[FaultError])
+	require.NotZero(t, faults[FaultPanic])
+	require.Zero(t, faults[FaultTimeout])
+
+	require.Equal(t, NoFault, NewFaultInjector(42, 0, FaultError)(1))
+	require.Equal(t, FaultError, NewFaultInjector(42, 1, FaultError)(1))
+	require.Equal(t, NoFault, NewFaultInjector(42, 1)(1))
+}
+
+func TestProcessAllWithFaultInjector(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// every tx depends on the ones before it
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+		kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Data: []byte(strconv.Itoa(counter))}
+	}
+	const txs = 30
+
+	for faultName, fault := range map[string]Fault{"error": FaultError, "panic": FaultPanic, "timeout": FaultTimeout} {
+		injector := NewFaultInjector(7, 0.3, fault)
+		faulted := 0
+		for i := 0; i < txs; i++ {
+			if injector(i) != NoFault {
+				faulted++
+			}
+		}
+		require.NotZero(t, faulted)
+
+		for name, opts := range map[string][]Option{
+			"concurrent":          nil,
+			"sequential fallback": {WithMaxRounds(1)},
+		} {
+			t.Run(faultName+"/"+name, func(t *testing.T) {
+				s := NewScheduler(8, deliverTx, append(opts, WithFaultInjector(injector))...)
+				ctx := initTestCtx()
+				res, err := s.ProcessAll(ctx, requestList(txs))
+				require.NoError(t, err)
+
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				committed := 0
+				for i, r := range res {
+					switch {
+					case injector(i) == NoFault || fault == FaultTimeout:
+						// timed out txs are executed again
+						require.Zero(t, r.Code, r.Log)
+						require.Equal(t, []byte(strconv.Itoa(committed)), r.Data)
+						require.Equal(t, []byte(strconv.Itoa(i)), kv.Get([]byte(strconv.Itoa(i))))
+						committed++
+					case fault == FaultError:
+						require.Equal(t, sdkerrors.ErrLogic.ABCICode(), r.Code)
+						require.Nil(t, kv.Get([]byte(strconv.Itoa(i))))
+					case fault == FaultPanic:
+						require.Equal(t, sdkerrors.ErrPanic.ABCICode(), r.Code)
+						require.Nil(t, kv.Get([]byte(strconv.Itoa(i))))
+					}
+				}
+				require.Equal(t, []byte(strconv.Itoa(committed)), kv.Get([]byte("counter")))
+				if fault == FaultTimeout {
+					require.Equal(t, faulted, s.Stats().Timeouts)
+				}
+			})
+		}
+	}
+}
+
+func TestProcessAllWithFaultInjectorIsDeterministic(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Data: val}
+	}
+	injector := NewFaultInjector(11, 0.4, FaultError, FaultPanic, FaultTimeout)
+
+	var expected []types.ResponseDeliverTx
+	for _, workers := range []int{1, 4, 8, 8, 8} {
+		s := NewScheduler(workers, deliverTx, WithFaultInjector(injector))
+		ctx := initTestCtx()
+		res, err := s.ProcessAll(ctx, requestList(40))
+		require.NoError(t, err)
+		if expected == nil {
+			expected = res
+			continue
+		}
+		for i := range res {
+			require.True(t, sameResponse(expected[i], res[i]), "tx %d", i)
+		}
+	}
+}
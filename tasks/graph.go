@@ -0,0 +1,178 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ConflictReasonValidation marks a tx that failed validation because a
+	// lower-indexed tx changed a key it read.
+	ConflictReasonValidation = "validation"
+	// ConflictReasonEstimate marks a tx that aborted on an ESTIMATE written by a
+	// lower-indexed tx being re-executed.
+	ConflictReasonEstimate = "estimate"
+)
+
+// ConflictEdge records that the tx at To had to re-execute because of the tx
+// at From. From is -1 when the tx that changed the key is not known, for
+// instance when the version read was removed by a re-execution.
+type ConflictEdge struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Reason string `json:"reason"`
+	// Store and Key are the key that changed, they are empty for estimates
+	Store string `json:"store,omitempty"`
+	Key   string `json:"key,omitempty"`
+}
+
+// ConflictGraph holds the conflicts the scheduler found while processing a
+// block, sorted by the index of the re-executed tx.
+type ConflictGraph struct {
+	Height int64          `json:"height"`
+	Edges  []ConflictEdge `json:"edges"`
+}
+
+// WriteDOT writes the graph in the Graphviz DOT format, with one node per tx.
+func (g ConflictGraph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "digraph \"conflicts_%d\" {\n", g.Height); err != nil {
+		return err
+	}
+	for _, edge := range g.Edges {
+		label := edge.Reason
+		if edge.Key != "" {
+			label = edge.Store + "/" + edge.Key
+		}
+		if _, err := fmt.Fprintf(w, "  %d -> %d [label=%s];\n", edge.From, edge.To, strconv.Quote(label)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// WithConflictGraph makes the scheduler record the conflicts of every block,
+// available from ConflictGraph. If dir is not empty, the graph of each block is
+// also written there as conflicts_<height>.json and conflicts_<height>.dot.
+// Recording has a cost and is meant for debugging.
+func WithConflictGraph(dir string) Option {
+	return func(s *scheduler) {
+		s.conflictGraphDir = dir
+		s.conflictGraph = &conflictGraph{}
+	}
+}
+
+// conflictGraph collects the conflict edges of the block being processed.
+type conflictGraph struct {
+	mtx   sync.Mutex
+	edges []ConflictEdge
+}
+
+func (cg *conflictGraph) add(edges ...ConflictEdge) {
+	cg.mtx.Lock()
+	defer cg.mtx.Unlock()
+	cg.edges = append(cg.edges, edges...)
+}
+
+// graph returns the edges collected so far in a deterministic order, since
+// aborts are recorded by the workers as they happen.
+func (cg *conflictGraph) graph(height int64) ConflictGraph {
+	cg.mtx.Lock()
+	defer cg.mtx.Unlock()
+	edges := make([]ConflictEdge, len(cg.edges))
+	copy(edges, cg.edges)
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		if a.Store != b.Store {
+			return a.Store < b.Store
+		}
+		return a.Key < b.Key
+	})
+	return ConflictGraph{Height: height, Edges: edges}
+}
+
+// recordInvalidation adds an edge for every stale read of a task that failed
+// validation, from the tx whose write the task would now read.
+func (s *scheduler) recordInvalidation(task *deliverTxTask) {
+	if s.conflictGraph == nil {
+		return
+	}
+	var edges []ConflictEdge
+	for _, read := range s.staleReads(task) {
+		from := -1
+		if latest := s.multiVersionStores[read.storeKey].GetLatestBeforeIndex(task.Index, []byte(read.key)); latest != nil {
+			from = latest.Index()
+		}
+		edges = append(edges, ConflictEdge{
+			From:   from,
+			To:     task.Index,
+			Reason: ConflictReasonValidation,
+			Store:  read.storeKey.Name(),
+			Key:    fmt.Sprintf("%X", read.key),
+		})
+	}
+	s.conflictGraph.add(edges...)
+}
+
+// recordAbort adds an edge for a task that aborted on an ESTIMATE.
+func (s *scheduler) recordAbort(task *deliverTxTask) {
+	if s.conflictGraph == nil || task.Abort == nil || task.Abort.DependentTxIdx < 0 {
+		return
+	}
+	s.conflictGraph.add(ConflictEdge{
+		From:   task.Abort.DependentTxIdx,
+		To:     task.Index,
+		Reason: ConflictReasonEstimate,
+	})
+}
+
+// dumpConflictGraph writes the graph of the block to the conflict graph
+// directory, if one is configured.
+func (s *scheduler) dumpConflictGraph(ctx sdk.Context, graph ConflictGraph) {
+	if s.conflictGraphDir == "" {
+		return
+	}
+	if err := writeConflictGraph(s.conflictGraphDir, graph); err != nil {
+		logger(ctx).Error("failed to write conflict graph", "height", graph.Height, "err", err)
+	}
+}
+
+func writeConflictGraph(dir string, graph ConflictGraph) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	bz, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(dir, fmt.Sprintf("conflicts_%d", graph.Height))
+	if err := os.WriteFile(name+".json", bz, 0600); err != nil {
+		return err
+	}
+	f, err := os.Create(name + ".dot")
+	if err != nil {
+		return err
+	}
+	if err := graph.WriteDOT(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
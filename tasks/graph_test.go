@@ -0,0 +1,85 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestConflictGraph(t *testing.T) {
+	dir := t.TempDir()
+	// the first executions all read before any of them writes, so that every
+	// tx but the first conflicts with the one before it
+	var read sync.WaitGroup
+	read.Add(10)
+	var calls int32
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		if atomic.AddInt32(&calls, 1) <= 10 {
+			read.Done()
+			read.Wait()
+		}
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Info: string(val)}
+	}, WithConflictGraph(dir))
+	ctx := initTestCtx().WithBlockHeight(7)
+
+	_, err := s.ProcessAll(ctx, requestList(10))
+	require.NoError(t, err)
+
+	graph := s.ConflictGraph()
+	require.Equal(t, int64(7), graph.Height)
+	require.Contains(t, graph.Edges, ConflictEdge{From: 0, To: 1, Reason: ConflictReasonValidation, Store: testStoreKey.Name(), Key: "736861726564"})
+	for _, edge := range graph.Edges {
+		require.Less(t, edge.From, edge.To)
+		if edge.Reason == ConflictReasonValidation {
+			require.Equal(t, testStoreKey.Name(), edge.Store)
+			require.Equal(t, "736861726564", edge.Key)
+		}
+	}
+
+	bz, err := os.ReadFile(filepath.Join(dir, "conflicts_7.json"))
+	require.NoError(t, err)
+	var dumped ConflictGraph
+	require.NoError(t, json.Unmarshal(bz, &dumped))
+	require.Equal(t, graph, dumped)
+	require.FileExists(t, filepath.Join(dir, "conflicts_7.dot"))
+}
+
+func TestConflictGraphDisabled(t *testing.T) {
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set([]byte("shared"), kv.Get([]byte("shared")))
+		return types.ResponseDeliverTx{}
+	})
+	_, err := s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+	require.Empty(t, s.ConflictGraph().Edges)
+}
+
+func TestConflictGraphWriteDOT(t *testing.T) {
+	graph := ConflictGraph{
+		Height: 3,
+		Edges: []ConflictEdge{
+			{From: 0, To: 2, Reason: ConflictReasonValidation, Store: "bank", Key: "0A"},
+			{From: 1, To: 2, Reason: ConflictReasonEstimate},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, graph.WriteDOT(&buf))
+	require.Equal(t, `digraph "conflicts_3" {
+  0 -> 2 [label="bank/0A"];
+  1 -> 2 [label="estimate"];
+}
+`, buf.String())
+}
@@ -0,0 +1,111 @@
+package tasks
+
+import (
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// AccessOpsConflict reports whether two transactions declaring a and b could
+// touch the same state with at least one of them writing it, in which case the
+// scheduler cannot execute them in the same wave.
+func AccessOpsConflict(a, b []acltypes.AccessOperation) bool {
+	for _, opA := range a {
+		for _, opB := range b {
+			if accessOpConflicts(opA, opB) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func accessOpConflicts(a, b acltypes.AccessOperation) bool {
+	// COMMIT only marks the end of a message's operations
+	if a.AccessType == acltypes.AccessType_COMMIT || b.AccessType == acltypes.AccessType_COMMIT {
+		return false
+	}
+	// an UNKNOWN access may write, so it is treated like one
+	if a.AccessType == acltypes.AccessType_READ && b.AccessType == acltypes.AccessType_READ {
+		return false
+	}
+	if !resourcesOverlap(a.ResourceType, b.ResourceType) {
+		return false
+	}
+	return acltypes.IdentifiersOverlap(a.IdentifierTemplate, b.IdentifierTemplate)
+}
+
+// resourcesOverlap reports whether a and b are the same resource or one
+// contains the other.
+func resourcesOverlap(a, b acltypes.ResourceType) bool {
+	for _, dep := range a.GetResourceDependencies() {
+		if dep == b {
+			return true
+		}
+	}
+	return false
+}
+
+// executionWaves partitions the tasks into waves from their declared access
+// operations. A task is placed in the wave after the last lower-indexed task it
+// conflicts with, so that it executes once the writes it depends on are in the
+// multi-version stores. Tasks without hints are placed in the first wave and
+// rely on validation alone.
+func executionWaves(tasks []*deliverTxTask, hints [][]acltypes.AccessOperation) [][]*deliverTxTask {
+	waveOf := make([]int, len(tasks))
+	var waves [][]*deliverTxTask
+	for i, task := range tasks {
+		if i < len(hints) && len(hints[i]) > 0 {
+			for j := 0; j < i; j++ {
+				if j < len(hints) && waveOf[j] >= waveOf[i] && AccessOpsConflict(hints[i], hints[j]) {
+					waveOf[i] = waveOf[j] + 1
+				}
+			}
+		}
+		if waveOf[i] == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[waveOf[i]] = append(waves[waveOf[i]], task)
+	}
+	return waves
+}
+
+// reexecutionWaves partitions the tasks to re-execute into waves from the
+// aborts of their last execution. A task that aborted on the ESTIMATE of
+// another task being re-executed is placed in the wave after it, so that it
+// only runs once that dependency has replaced its ESTIMATEs with values. Tasks
+// that failed validation or whose dependency is already validated are placed
+// in the first wave. Suspect tasks, and the tasks waiting on them, go in waves
+// after all the others. tasks must be in index order.
+func reexecutionWaves(tasks []*deliverTxTask) [][]*deliverTxTask {
+	deferred := make(map[int]bool)
+	var regular, late []*deliverTxTask
+	for _, task := range tasks {
+		if task.Suspect || (task.Abort != nil && deferred[task.Abort.DependentTxIdx]) {
+			deferred[task.Index] = true
+			late = append(late, task)
+			continue
+		}
+		regular = append(regular, task)
+	}
+	return append(dependencyWaves(regular), dependencyWaves(late)...)
+}
+
+// dependencyWaves places each task in the wave after the task it aborted on,
+// if that task is among tasks.
+func dependencyWaves(tasks []*deliverTxTask) [][]*deliverTxTask {
+	waveOf := make(map[int]int, len(tasks))
+	var waves [][]*deliverTxTask
+	for _, task := range tasks {
+		wave := 0
+		if task.Abort != nil {
+			if dep, ok := waveOf[task.Abort.DependentTxIdx]; ok {
+				wave = dep + 1
+			}
+		}
+		waveOf[task.Index] = wave
+		if wave == len(waves) {
+			waves = append(waves, nil)
+		}
+		waves[wave] = append(waves[wave], task)
+	}
+	return waves
+}
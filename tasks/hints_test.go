@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+func TestAccessOpsConflict(t *testing.T) {
+	read := func(resource acltypes.ResourceType, id string) acltypes.AccessOperation {
+		return acltypes.AccessOperation{AccessType: acltypes.AccessType_READ, ResourceType: resource, IdentifierTemplate: id}
+	}
+	write := func(resource acltypes.ResourceType, id string) acltypes.AccessOperation {
+		return acltypes.AccessOperation{AccessType: acltypes.AccessType_WRITE, ResourceType: resource, IdentifierTemplate: id}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     []acltypes.AccessOperation
+		conflict bool
+	}{
+		{"reads never conflict", []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "a")}, []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "a")}, false},
+		{"write and read of the same identifier", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK, "a")}, []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "a")}, true},
+		{"different identifiers", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK, "a")}, []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK, "b")}, false},
+		{"wildcard identifier", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK, "*")}, []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "b")}, true},
+		{"account prefix and a balance of the account", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK_BALANCES, "0201aa")}, []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK_BALANCES, "0201aa75736569")}, true},
+		{"balances of an account in different denoms", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK_BALANCES, "0201aa75736569")}, []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK_BALANCES, "0201aa7561746f6d")}, false},
+		{"unrelated resources", []acltypes.AccessOperation{write(acltypes.ResourceType_KV_BANK, "*")}, []acltypes.AccessOperation{write(acltypes.ResourceType_KV_STAKING, "*")}, false},
+		{"parent resource", []acltypes.AccessOperation{write(acltypes.ResourceType_KV, "*")}, []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "a")}, true},
+		{"synchronous access ops", acltypes.SynchronousAccessOps(), []acltypes.AccessOperation{read(acltypes.ResourceType_KV_BANK, "a")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.conflict, AccessOpsConflict(tt.a, tt.b))
+			require.Equal(t, tt.conflict, AccessOpsConflict(tt.b, tt.a))
+		})
+	}
+}
+
+func TestExecutionWaves(t *testing.T) {
+	tasks := toTasks(requestList(5))
+	writeA := []acltypes.AccessOperation{{AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV_BANK, IdentifierTemplate: "a"}}
+	writeB := []acltypes.AccessOperation{{AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV_BANK, IdentifierTemplate: "b"}}
+
+	// tx 3 has no hints and is executed optimistically in the first wave
+	waves := executionWaves(tasks, [][]acltypes.AccessOperation{writeA, writeB, writeA, nil, writeA})
+	require.Equal(t, [][]*deliverTxTask{
+		{tasks[0], tasks[1], tasks[3]},
+		{tasks[2]},
+		{tasks[4]},
+	}, waves)
+
+	// without hints every task is in a single wave
+	require.Equal(t, [][]*deliverTxTask{tasks}, executionWaves(tasks, nil))
+}
+
+func TestReexecutionWaves(t *testing.T) {
+	tasks := toTasks(requestList(5))
+	abortOn := func(task *deliverTxTask, dependentTxIdx int) {
+		abort := occ.NewEstimateAbort(dependentTxIdx)
+		task.Abort = &abort
+	}
+	// tx 1 failed validation, tx 2 and 4 aborted on it in turn, and tx 3
+	// aborted on tx 0 which is validated
+	abortOn(tasks[2], 1)
+	abortOn(tasks[3], 0)
+	abortOn(tasks[4], 2)
+
+	waves := reexecutionWaves(tasks[1:])
+	require.Equal(t, [][]*deliverTxTask{
+		{tasks[1], tasks[3]},
+		{tasks[2]},
+		{tasks[4]},
+	}, waves)
+
+	// a suspect task and the tasks waiting on it run after the others
+	tasks[1].Suspect = true
+	waves = reexecutionWaves(tasks[1:])
+	require.Equal(t, [][]*deliverTxTask{
+		{tasks[3]},
+		{tasks[1]},
+		{tasks[2]},
+		{tasks[4]},
+	}, waves)
+}
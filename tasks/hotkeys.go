@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"sort"
+
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// WithMaxConcurrentPerKey limits to max the tasks executing at the same time
+// that declared an access to the same hot key, a key declared by the hints of
+// more than threshold txs of the block. Tasks contending on a hot key then
+// mostly execute one after the other instead of invalidating each other, while
+// the other keys remain fully concurrent. A key is a resource and identifier
+// template of the hints, a wildcard identifier is a key of its own.
+func WithMaxConcurrentPerKey(max, threshold int) Option {
+	return func(s *scheduler) {
+		s.maxConcurrentPerKey = max
+		s.hotKeyThreshold = threshold
+	}
+}
+
+// hotKey is a key declared by the hints of many txs of a block.
+type hotKey struct {
+	resource   acltypes.ResourceType
+	identifier string
+}
+
+func (k hotKey) less(o hotKey) bool {
+	if k.resource != o.resource {
+		return k.resource < o.resource
+	}
+	return k.identifier < o.identifier
+}
+
+// limitHotKeys finds the hot keys of the block, creates their semaphores and
+// assigns each task the hot keys it declared.
+func (s *scheduler) limitHotKeys(tasks []*deliverTxTask, hints [][]acltypes.AccessOperation) {
+	s.keySemaphores = nil
+	if s.maxConcurrentPerKey <= 0 {
+		return
+	}
+	keysOf := make([][]hotKey, len(tasks))
+	declared := make(map[hotKey]int)
+	for i := range tasks {
+		if i >= len(hints) {
+			break
+		}
+		seen := make(map[hotKey]bool)
+		for _, op := range hints[i] {
+			if op.AccessType == acltypes.AccessType_COMMIT {
+				continue
+			}
+			key := hotKey{resource: op.ResourceType, identifier: op.IdentifierTemplate}
+			if !seen[key] {
+				seen[key] = true
+				keysOf[i] = append(keysOf[i], key)
+				declared[key]++
+			}
+		}
+	}
+	for key, txs := range declared {
+		if txs > s.hotKeyThreshold {
+			if s.keySemaphores == nil {
+				s.keySemaphores = make(map[hotKey]chan struct{})
+			}
+			s.keySemaphores[key] = make(chan struct{}, s.maxConcurrentPerKey)
+		}
+	}
+	for i, t := range tasks {
+		t.HotKeys = nil
+		for _, key := range keysOf[i] {
+			if _, hot := s.keySemaphores[key]; hot {
+				t.HotKeys = append(t.HotKeys, key)
+			}
+		}
+		// acquired in the same order by every task, so that they cannot deadlock
+		sort.Slice(t.HotKeys, func(a, b int) bool {
+			return t.HotKeys[a].less(t.HotKeys[b])
+		})
+	}
+}
+
+// acquireHotKeys waits for the task to be allowed to execute on every hot key
+// it declared, and returns the function releasing them.
+func (s *scheduler) acquireHotKeys(task *deliverTxTask) (release func()) {
+	for _, key := range task.HotKeys {
+		s.keySemaphores[key] <- struct{}{}
+	}
+	return func() {
+		for _, key := range task.HotKeys {
+			<-s.keySemaphores[key]
+		}
+	}
+}
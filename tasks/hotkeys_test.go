@@ -0,0 +1,86 @@
+package tasks
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+func TestLimitHotKeys(t *testing.T) {
+	read := func(id string) acltypes.AccessOperation {
+		return acltypes.AccessOperation{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV_BANK, IdentifierTemplate: id}
+	}
+	commit := acltypes.AccessOperation{AccessType: acltypes.AccessType_COMMIT, ResourceType: acltypes.ResourceType_ANY, IdentifierTemplate: "*"}
+	hints := [][]acltypes.AccessOperation{
+		{read("b"), read("a"), read("a"), commit},
+		{read("a"), read("c"), commit},
+		{read("b"), commit},
+		{commit},
+	}
+	tasks := toTasks(requestList(5))
+
+	s := NewScheduler(4, nil, WithMaxConcurrentPerKey(1, 1)).(*scheduler)
+	s.limitHotKeys(tasks, hints)
+	a := hotKey{resource: acltypes.ResourceType_KV_BANK, identifier: "a"}
+	b := hotKey{resource: acltypes.ResourceType_KV_BANK, identifier: "b"}
+	// only the keys declared by more than one tx are hot, in a fixed order
+	require.Len(t, s.keySemaphores, 2)
+	require.Equal(t, []hotKey{a, b}, tasks[0].HotKeys)
+	require.Equal(t, []hotKey{a}, tasks[1].HotKeys)
+	require.Equal(t, []hotKey{b}, tasks[2].HotKeys)
+	require.Empty(t, tasks[3].HotKeys)
+	require.Empty(t, tasks[4].HotKeys)
+
+	s = NewScheduler(4, nil, WithMaxConcurrentPerKey(1, 2)).(*scheduler)
+	s.limitHotKeys(tasks, hints)
+	require.Empty(t, s.keySemaphores)
+	require.Empty(t, tasks[0].HotKeys)
+}
+
+func TestProcessAllWithMaxConcurrentPerKey(t *testing.T) {
+	var executing, maxExecuting int64
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		n := atomic.AddInt64(&executing, 1)
+		defer atomic.AddInt64(&executing, -1)
+		for max := atomic.LoadInt64(&maxExecuting); n > max; max = atomic.LoadInt64(&maxExecuting) {
+			if atomic.CompareAndSwapInt64(&maxExecuting, max, n) {
+				break
+			}
+		}
+		// every tx increments the counter, declared as a read only
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+		time.Sleep(time.Millisecond)
+		kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+		return types.ResponseDeliverTx{}
+	}
+	reqs := requestList(20)
+	hints := make([][]acltypes.AccessOperation, len(reqs))
+	for i := range hints {
+		hints[i] = []acltypes.AccessOperation{{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV, IdentifierTemplate: "counter"}}
+	}
+
+	conflicts := func(s Scheduler) int {
+		ctx := initTestCtx()
+		_, err := s.ProcessAllWithHints(ctx, reqs, hints)
+		require.NoError(t, err)
+		require.Equal(t, []byte("20"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("counter")))
+		return s.Stats().ValidationFailures + s.Stats().Aborts
+	}
+
+	unlimited := conflicts(NewScheduler(8, deliverTx))
+	require.Greater(t, atomic.LoadInt64(&maxExecuting), int64(1))
+
+	atomic.StoreInt64(&maxExecuting, 0)
+	limited := conflicts(NewScheduler(8, deliverTx, WithMaxConcurrentPerKey(1, 10)))
+	// the txs on the hot key execute one at a time, and invalidate each other less
+	require.Equal(t, int64(1), atomic.LoadInt64(&maxExecuting))
+	require.Less(t, limited, unlimited)
+}
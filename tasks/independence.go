@@ -0,0 +1,113 @@
+package tasks
+
+import (
+	"bytes"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// Independence reports whether the txs at indexes i and j of a block, i < j,
+// are known to never access the same state, none of them reading or writing
+// a key the other writes.
+type Independence func(i, j int) bool
+
+// WithIndependenceCheck makes ProcessAllWithIndependence check the declared
+// independence of the txs against the keys they accessed, and fail the block
+// with ErrIndependenceViolated instead of committing it if two txs declared
+// independent accessed the same key. It is meant for tests and debugging, the
+// check comparing the accesses of every pair of txs.
+func WithIndependenceCheck() Option {
+	return func(s *scheduler) {
+		s.checkIndependence = true
+	}
+}
+
+// ProcessAllWithIndependence implements Scheduler. A tx independent of every
+// tx before it cannot have read a value they wrote, so it is valid as soon as
+// it executed, without comparing its reads. The declaration is trusted, unless
+// the scheduler was created WithIndependenceCheck.
+func (s *scheduler) ProcessAllWithIndependence(ctx sdk.Context, reqs []types.RequestDeliverTx, independence Independence) ([]types.ResponseDeliverTx, error) {
+	s.independence = independence
+	s.independentOfLower = independentOfLower(len(reqs), independence)
+	defer func() {
+		s.independence = nil
+		s.independentOfLower = nil
+	}()
+	return s.ProcessAll(ctx, reqs)
+}
+
+// independentOfLower returns, for each of txs, whether it is independent of
+// every tx before it.
+func independentOfLower(txs int, independence Independence) []bool {
+	res := make([]bool, txs)
+	for j := range res {
+		res[j] = true
+		for i := 0; i < j && res[j]; i++ {
+			res[j] = independence(i, j)
+		}
+	}
+	return res
+}
+
+// isIndependentOfLower reports whether the task was declared independent of
+// every lower-indexed task.
+func (s *scheduler) isIndependentOfLower(task *deliverTxTask) bool {
+	return task.Index < len(s.independentOfLower) && s.independentOfLower[task.Index]
+}
+
+// verifyIndependence returns an error if two tasks declared independent
+// accessed the same key in their last execution. It is a no-op unless the
+// scheduler was created WithIndependenceCheck.
+func (s *scheduler) verifyIndependence(tasks []*deliverTxTask) error {
+	if !s.checkIndependence || s.independence == nil {
+		return nil
+	}
+	for j := range tasks {
+		for i := 0; i < j; i++ {
+			if !s.independence(i, j) {
+				continue
+			}
+			storeKey, key, overlap := accessesOverlap(tasks[i], tasks[j])
+			if !overlap {
+				storeKey, key, overlap = accessesOverlap(tasks[j], tasks[i])
+			}
+			if overlap {
+				return sdkerrors.Wrapf(occ.ErrIndependenceViolated,
+					"txs %d and %d are declared independent but access key %X of store %s", i, j, key, storeKey.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// accessesOverlap returns a key written by b that a read, iterated over or
+// wrote, if any.
+func accessesOverlap(a, b *deliverTxTask) (sdk.StoreKey, []byte, bool) {
+	for storeKey, writeset := range b.WriteSet {
+		for key := range writeset {
+			if _, ok := a.ReadSet[storeKey][key]; ok {
+				return storeKey, []byte(key), true
+			}
+			if _, ok := a.WriteSet[storeKey][key]; ok {
+				return storeKey, []byte(key), true
+			}
+			for _, iteration := range a.IterateSet[storeKey] {
+				if inRange(iteration, []byte(key)) {
+					return storeKey, []byte(key), true
+				}
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// inRange reports whether key is in the range of the iteration.
+func inRange(iteration multiversion.Iteration, key []byte) bool {
+	return (iteration.Start == nil || bytes.Compare(key, iteration.Start) >= 0) &&
+		(iteration.End == nil || bytes.Compare(key, iteration.End) < 0)
+}
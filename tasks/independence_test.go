@@ -0,0 +1,78 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// incrementOrWrite makes the even txs increment a shared counter, tx 0 slowly,
+// and the odd txs write a key of their own.
+func incrementOrWrite(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+	kv := ctx.MultiStore().GetKVStore(testStoreKey)
+	i, _ := strconv.Atoi(string(req.Tx))
+	if i%2 == 1 {
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}
+	counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+	if i == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+	return types.ResponseDeliverTx{}
+}
+
+func TestIndependentOfLower(t *testing.T) {
+	independence := func(i, j int) bool { return i%2 == 1 || j%2 == 1 }
+	require.Equal(t, []bool{true, true, false, true, false}, independentOfLower(5, independence))
+}
+
+func TestProcessAllWithIndependence(t *testing.T) {
+	// only the txs incrementing the counter depend on each other
+	independence := func(i, j int) bool { return i%2 == 1 || j%2 == 1 }
+	for _, opts := range [][]Option{nil, {WithIndependenceCheck()}} {
+		s := NewScheduler(4, incrementOrWrite, opts...)
+		ctx := initTestCtx()
+		_, err := s.ProcessAllWithIndependence(ctx, requestList(10), independence)
+		require.NoError(t, err)
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		require.Equal(t, []byte("5"), kv.Get([]byte("counter")))
+		for i := 1; i < 10; i += 2 {
+			require.Equal(t, []byte(strconv.Itoa(i)), kv.Get([]byte(strconv.Itoa(i))))
+		}
+	}
+}
+
+func TestProcessAllWithDishonestIndependence(t *testing.T) {
+	// the txs incrementing the counter are declared independent too
+	independence := func(i, j int) bool { return true }
+
+	// the declaration is trusted: the stale reads of the counter are not
+	// detected
+	s := NewScheduler(4, incrementOrWrite)
+	ctx := initTestCtx()
+	_, err := s.ProcessAllWithIndependence(ctx, requestList(10), independence)
+	require.NoError(t, err)
+	require.NotEqual(t, []byte("5"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("counter")))
+	require.Zero(t, s.Stats().ValidationFailures)
+
+	// the check fails the block
+	s = NewScheduler(4, incrementOrWrite, WithIndependenceCheck())
+	ctx = initTestCtx()
+	_, err = s.ProcessAllWithIndependence(ctx, requestList(10), independence)
+	require.ErrorIs(t, err, occ.ErrIndependenceViolated)
+	require.Contains(t, err.Error(), "txs 0 and 2 are declared independent but access key 636F756E746572 of store mock")
+	require.Nil(t, ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("counter")))
+
+	// the declaration only holds for the block it was given with
+	_, err = s.ProcessAll(ctx, requestList(10))
+	require.NoError(t, err)
+	require.Equal(t, []byte("5"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("counter")))
+}
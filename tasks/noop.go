@@ -0,0 +1,14 @@
+package tasks
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NoopDeliverTx is a deliverTx that succeeds without touching any state. A
+// scheduler created with it only costs its own scheduling, validation and
+// commit, which is what the benchmarks of the scheduler measure.
+func NoopDeliverTx(sdk.Context, types.RequestDeliverTx) types.ResponseDeliverTx {
+	return types.ResponseDeliverTx{}
+}
@@ -0,0 +1,33 @@
+package tasks
+
+// pendingRange is the span of the txs of a block that have not been validated
+// yet, see Scheduler.PendingIndexRange.
+type pendingRange struct {
+	min, max, count int
+}
+
+// updatePending records the txs of tasks that have not been validated yet. It
+// is called by the goroutine processing the block, between the passes.
+func (s *scheduler) updatePending(tasks []*deliverTxTask) {
+	pending := pendingRange{min: -1, max: -1}
+	for _, t := range tasks {
+		if t.Status == statusValidated {
+			continue
+		}
+		if pending.count == 0 {
+			pending.min = t.Index
+		}
+		pending.max = t.Index
+		pending.count++
+	}
+	s.statsMtx.Lock()
+	s.pending = pending
+	s.statsMtx.Unlock()
+}
+
+// PendingIndexRange implements Scheduler.
+func (s *scheduler) PendingIndexRange() (min, max int, count int) {
+	s.statsMtx.Lock()
+	defer s.statsMtx.Unlock()
+	return s.pending.min, s.pending.max, s.pending.count
+}
@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPendingIndexRange(t *testing.T) {
+	type pendingRange struct{ min, max, count int }
+	var mtx sync.Mutex
+	var executing []pendingRange
+	var s Scheduler
+	s = NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		min, max, count := s.PendingIndexRange()
+		mtx.Lock()
+		executing = append(executing, pendingRange{min, max, count})
+		mtx.Unlock()
+		// every tx depends on the one before it
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Info: string(val)}
+	})
+
+	var validated []pendingRange
+	_, err := s.ProcessAllWithCallback(initTestCtx(), requestList(20), func(index int, _ types.ResponseDeliverTx) {
+		min, max, count := s.PendingIndexRange()
+		// a tx is final once every tx up to it is validated
+		require.True(t, count == 0 || min > index)
+		validated = append(validated, pendingRange{min, max, count})
+	})
+	require.NoError(t, err)
+
+	// nothing is validated during the first round
+	require.Equal(t, pendingRange{0, 19, 20}, executing[0])
+	// the range narrows as the txs are validated
+	for i := 1; i < len(executing); i++ {
+		require.GreaterOrEqual(t, executing[i].min, executing[i-1].min)
+		require.LessOrEqual(t, executing[i].count, executing[i-1].count)
+	}
+	for i := 1; i < len(validated); i++ {
+		if validated[i].count > 0 {
+			require.GreaterOrEqual(t, validated[i].min, validated[i-1].min)
+		}
+		require.LessOrEqual(t, validated[i].count, validated[i-1].count)
+	}
+	min, max, count := s.PendingIndexRange()
+	require.Equal(t, pendingRange{-1, -1, 0}, pendingRange{min, max, count})
+}
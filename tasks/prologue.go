@@ -0,0 +1,89 @@
+package tasks
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ProcessAllWithPrologue implements Scheduler. The prologue txs, e.g. an oracle
+// price update, execute one at a time in the order of prologueIndices, each
+// committed before the next starts, then the other txs are processed as in
+// ProcessAll on top of the prologue's writes. The block is committed to ctx's
+// multi store as a whole, or not at all if it fails.
+//
+// Every tx executes with its index in reqs and the responses are indexed like
+// reqs, but the events of the prologue are emitted first. Stats and the other
+// accessors of the last block describe the processing of the txs after the
+// prologue.
+func (s *scheduler) ProcessAllWithPrologue(ctx sdk.Context, reqs []types.RequestDeliverTx, prologueIndices []int) ([]types.ResponseDeliverTx, error) {
+	rest, err := afterPrologue(len(reqs), prologueIndices)
+	if err != nil {
+		return nil, err
+	}
+	if len(prologueIndices) == 0 {
+		return s.ProcessAll(ctx, reqs)
+	}
+	defer func() {
+		s.txIndexes = nil
+	}()
+
+	cms := ctx.MultiStore().CacheMultiStore()
+	branch := ctx.WithMultiStore(cms)
+	res := make([]types.ResponseDeliverTx, len(reqs))
+	for _, i := range prologueIndices {
+		s.txIndexes = []int{i}
+		resps, err := s.ProcessAll(branch, []types.RequestDeliverTx{reqs[i]})
+		if err != nil {
+			return nil, err
+		}
+		res[i] = resps[0]
+	}
+
+	s.txIndexes = rest
+	restReqs := make([]types.RequestDeliverTx, 0, len(rest))
+	for _, i := range rest {
+		restReqs = append(restReqs, reqs[i])
+	}
+	resps, err := s.ProcessAll(branch, restReqs)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range rest {
+		res[i] = resps[j]
+	}
+	cms.Write()
+	return res, nil
+}
+
+// afterPrologue returns, in order, the indexes of the txs of a block of txs
+// that are not in prologueIndices. It fails if an index is out of range or
+// repeated.
+func afterPrologue(txs int, prologueIndices []int) ([]int, error) {
+	inPrologue := make([]bool, txs)
+	for _, i := range prologueIndices {
+		if i < 0 || i >= txs {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "prologue index %d out of range of %d txs", i, txs)
+		}
+		if inPrologue[i] {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "prologue index %d repeated", i)
+		}
+		inPrologue[i] = true
+	}
+	rest := make([]int, 0, txs-len(prologueIndices))
+	for i, prologue := range inPrologue {
+		if !prologue {
+			rest = append(rest, i)
+		}
+	}
+	return rest, nil
+}
+
+// txIndex returns the index in its block of the task at index.
+func (s *scheduler) txIndex(index int) int {
+	if s.txIndexes == nil {
+		return index
+	}
+	return s.txIndexes[index]
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+func TestProcessAllWithPrologue(t *testing.T) {
+	// the oracle tx sets the price, the others read it
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		if string(req.Tx) == "oracle" {
+			kv.Set([]byte("price"), []byte("42"))
+			return types.ResponseDeliverTx{Info: strconv.Itoa(ctx.TxIndex())}
+		}
+		price := kv.Get([]byte("price"))
+		kv.Set(req.Tx, price)
+		return types.ResponseDeliverTx{Data: price, Info: strconv.Itoa(ctx.TxIndex())}
+	}
+	reqs := requestList(10)
+	reqs[7] = types.RequestDeliverTx{Tx: []byte("oracle")}
+
+	for _, workers := range []int{1, 4} {
+		s := NewScheduler(workers, deliverTx)
+		ctx := initTestCtx()
+
+		res, err := s.ProcessAllWithPrologue(ctx, reqs, []int{7})
+		require.NoError(t, err)
+		require.Len(t, res, len(reqs))
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		require.Equal(t, []byte("42"), kv.Get([]byte("price")))
+		for i, resp := range res {
+			// every tx executes with its index in the block
+			require.Equal(t, strconv.Itoa(i), resp.Info)
+			if i == 7 {
+				continue
+			}
+			require.Equal(t, []byte("42"), resp.Data, "tx %d", i)
+			require.Equal(t, []byte("42"), kv.Get(reqs[i].Tx), "tx %d", i)
+		}
+	}
+}
+
+func TestProcessAllWithPrologueOrder(t *testing.T) {
+	// each prologue tx appends to the log, the others read it
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		log := kv.Get([]byte("log"))
+		if n, _ := strconv.Atoi(string(req.Tx)); n >= 5 {
+			kv.Set([]byte("log"), append(append([]byte{}, log...), req.Tx...))
+		}
+		return types.ResponseDeliverTx{Data: log}
+	})
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAllWithPrologue(ctx, requestList(8), []int{6, 5, 7})
+	require.NoError(t, err)
+	require.Equal(t, []byte("657"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("log")))
+	require.Equal(t, []byte("6"), res[5].Data)
+	require.Nil(t, res[6].Data)
+	require.Equal(t, []byte("65"), res[7].Data)
+	for i := 0; i < 5; i++ {
+		require.Equal(t, []byte("657"), res[i].Data)
+	}
+}
+
+func TestProcessAllWithInvalidPrologue(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	})
+	for _, prologue := range [][]int{{5}, {-1}, {1, 1}} {
+		ctx := initTestCtx()
+		_, err := s.ProcessAllWithPrologue(ctx, requestList(5), prologue)
+		require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+		require.Nil(t, ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("0")))
+	}
+}
@@ -0,0 +1,18 @@
+package tasks
+
+// reexecutedBitset returns the bitset of the tasks with an incarnation past
+// the first, one bit per task in index order.
+func reexecutedBitset(tasks []*deliverTxTask) []uint64 {
+	bitset := make([]uint64, (len(tasks)+63)/64)
+	for _, t := range tasks {
+		if t.Incarnation > 0 {
+			bitset[t.Index/64] |= 1 << (t.Index % 64)
+		}
+	}
+	return bitset
+}
+
+// ReexecutedBitset implements Scheduler.
+func (s *scheduler) ReexecutedBitset() []uint64 {
+	return s.reexecuted
+}
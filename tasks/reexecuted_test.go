@@ -0,0 +1,34 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestReexecutedBitset(t *testing.T) {
+	// the first execution of these txs times out, and each tx only writes its
+	// own key, so no other tx is re-executed
+	timedOut := map[int]bool{1: true, 65: true, 70: true}
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}, WithFaultInjector(func(index int) Fault {
+		if timedOut[index] {
+			return FaultTimeout
+		}
+		return NoFault
+	}))
+	require.Nil(t, s.ReexecutedBitset())
+
+	_, err := s.ProcessAll(initTestCtx(), requestList(130))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1 << 1, 1<<(65-64) | 1<<(70-64), 0}, s.ReexecutedBitset())
+
+	_, err = s.ProcessAll(initTestCtx(), requestList(64))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1 << 1}, s.ReexecutedBitset())
+}
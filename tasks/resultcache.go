@@ -0,0 +1,189 @@
+package tasks
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithResultCache makes the scheduler keep the results of up to size txs of
+// the block being decided, so that a tx proposed again at the same height, e.g.
+// in the block a validator locked on and re-proposes in a later round, is not
+// executed again. See resultCache.
+func WithResultCache(size int) Option {
+	return func(s *scheduler) {
+		s.resultCache = newResultCache(size)
+	}
+}
+
+// blockContext is what a tx sees of its block besides the state. A cached
+// result is only reused by a tx executing with the same one. The hash of the
+// header identifies the block, and so the state it is executed on: the reads of
+// a tx are not all recorded, e.g. the keys of the parent store it iterated
+// over, and a block of the same height, time and proposer may still have
+// different evidence or votes, and so different writes in BeginBlock.
+type blockContext struct {
+	hash     string
+	chainID  string
+	height   int64
+	time     time.Time
+	proposer string
+	index    int
+}
+
+func newBlockContext(ctx sdk.Context, index int) blockContext {
+	return blockContext{
+		hash:     string(ctx.HeaderHash()),
+		chainID:  ctx.ChainID(),
+		height:   ctx.BlockHeight(),
+		time:     ctx.BlockTime(),
+		proposer: string(ctx.BlockHeader().ProposerAddress),
+		index:    index,
+	}
+}
+
+func (bc blockContext) equal(other blockContext) bool {
+	return bc.hash == other.hash && bc.chainID == other.chainID && bc.height == other.height && bc.time.Equal(other.time) &&
+		bc.proposer == other.proposer && bc.index == other.index
+}
+
+// cachedResult is the outcome of a validated execution of a tx.
+type cachedResult struct {
+	blockContext   blockContext
+	response       types.ResponseDeliverTx
+	events         sdk.Events
+	blockGasBefore uint64
+	fees           sdk.Coins
+	readSet        map[sdk.StoreKey]multiversion.ReadSet
+	iterateSet     map[sdk.StoreKey]multiversion.IterateSet
+	writeSet       map[sdk.StoreKey]multiversion.WriteSet
+}
+
+// resultCache holds the results of the txs executed at the height being
+// decided, keyed by the hash of the tx. A result only stands for a new
+// execution if the reads it made are still valid, so a reused result is
+// validated like any other execution and the tx is executed if it is invalid.
+// The results are dropped once a block of another height is processed.
+type resultCache struct {
+	mtx     sync.Mutex
+	size    int
+	height  int64
+	results map[[sha256.Size]byte]*cachedResult
+}
+
+func newResultCache(size int) *resultCache {
+	return &resultCache{size: size, results: make(map[[sha256.Size]byte]*cachedResult)}
+}
+
+// get returns the result of tx executed with the same block context as bc. The
+// txs of a block without hash have no result.
+func (rc *resultCache) get(tx []byte, bc blockContext) *cachedResult {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+
+	if bc.hash == "" {
+		return nil
+	}
+	res, ok := rc.results[sha256.Sum256(tx)]
+	if !ok || !res.blockContext.equal(bc) {
+		return nil
+	}
+	return res
+}
+
+// add saves the results of the validated tasks of a block processed with ctx,
+// txIndex returning the index in the block of a task. The results of another height are dropped first, and those of the tasks past
+// the size of the cache are not saved, nor those of a block without hash.
+func (rc *resultCache) add(ctx sdk.Context, tasks []*deliverTxTask, txIndex func(int) int) {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+
+	if ctx.BlockHeight() != rc.height {
+		rc.height = ctx.BlockHeight()
+		rc.results = make(map[[sha256.Size]byte]*cachedResult)
+	}
+	if len(ctx.HeaderHash()) == 0 {
+		return
+	}
+	for _, t := range tasks {
+		hash := sha256.Sum256(t.Request.Tx)
+		if _, ok := rc.results[hash]; !ok && len(rc.results) >= rc.size {
+			continue
+		}
+		rc.results[hash] = &cachedResult{
+			blockContext:   newBlockContext(ctx, txIndex(t.Index)),
+			response:       *t.Response,
+			events:         t.Ctx.EventManager().Events(),
+			blockGasBefore: t.BlockGasBefore,
+			fees:           t.Charges.Fees(),
+			readSet:        t.ReadSet,
+			iterateSet:     t.IterateSet,
+			writeSet:       t.WriteSet,
+		}
+	}
+}
+
+// reuseResult completes the first execution of the task with its cached result,
+// if any, publishing the cached writes to the multi-version stores. It reports
+// whether the task was executed that way.
+func (s *scheduler) reuseResult(ctx sdk.Context, task *deliverTxTask) bool {
+	if s.resultCache == nil || task.Incarnation > 0 {
+		return false
+	}
+	res := s.resultCache.get(task.Request.Tx, newBlockContext(ctx, s.txIndex(task.Index)))
+	if res == nil || !s.hasStores(res) {
+		return false
+	}
+
+	task.Ctx = txContext(ctx, s.txIndex(task.Index))
+	task.Ctx.EventManager().EmitEvents(res.events)
+	task.BlockGasBefore = res.blockGasBefore
+	task.Charges = sdk.NewTxCharges()
+	task.Charges.AddFees(res.fees)
+	// the reads are those of the cached execution
+	task.ReadsFinal = false
+	task.ReadSet = res.readSet
+	task.IterateSet = res.iterateSet
+	task.WriteSet = res.writeSet
+	task.StartSequence = atomic.AddUint64(&s.sequence, 1)
+	for storeKey, writeset := range res.writeSet {
+		s.multiVersionStores[storeKey].SetWriteset(task.Index, task.Incarnation, writeset)
+	}
+	s.publishConflictKeys(task)
+	s.blockGas.record(task.Index, res.response)
+	resp := res.response
+	task.Response = &resp
+	task.Status = statusExecuted
+	task.retainFirstResult()
+	telemetry.IncrCounter(1, "scheduler", "cached_results")
+	return true
+}
+
+// hasStores reports whether the block has a multi-version store for every
+// store res accessed, the stores mounted having possibly changed since res was
+// cached.
+func (s *scheduler) hasStores(res *cachedResult) bool {
+	var storeKeys []sdk.StoreKey
+	for storeKey := range res.readSet {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	for storeKey := range res.iterateSet {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	for storeKey := range res.writeSet {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	for _, storeKey := range storeKeys {
+		if _, ok := s.multiVersionStores[storeKey]; !ok {
+			return false
+		}
+	}
+	return true
+}
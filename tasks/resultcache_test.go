@@ -0,0 +1,100 @@
+package tasks
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestProcessAllWithResultCache(t *testing.T) {
+	var executions int64
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		atomic.AddInt64(&executions, 1)
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		kv.Set(req.Tx, val)
+		ctx.EventManager().EmitEvent(sdk.NewEvent("tx", sdk.NewAttribute("read", string(val))))
+		// the keys of the parent store iterated over are not in the readset
+		var keys int
+		it := kv.Iterator([]byte("k"), []byte("l"))
+		for ; it.Valid(); it.Next() {
+			keys++
+		}
+		it.Close()
+		return types.ResponseDeliverTx{Info: string(val), Data: []byte(strconv.Itoa(keys))}
+	}
+	s := NewScheduler(4, deliverTx, WithResultCache(10))
+	base := initTestCtx().WithBlockHeight(5).WithBlockTime(time.Unix(100, 0)).WithHeaderHash([]byte("block"))
+	base.MultiStore().GetKVStore(testStoreKey).Set([]byte("shared"), []byte("a"))
+	base.MultiStore().GetKVStore(testStoreKey).Set([]byte("k1"), []byte("v"))
+
+	// processBlock processes the txs on a branch of base, returning what the
+	// txs read and the number of executions.
+	processBlock := func(ctx sdk.Context) ([]types.ResponseDeliverTx, int64) {
+		atomic.StoreInt64(&executions, 0)
+		ctx = ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore()).WithEventManager(sdk.NewEventManager())
+		res, err := s.ProcessAll(ctx, requestList(3))
+		require.NoError(t, err)
+		require.Equal(t, []byte("a"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("2")))
+		require.Len(t, ctx.EventManager().Events(), 3)
+		return res, atomic.LoadInt64(&executions)
+	}
+
+	res, n := processBlock(base)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, "a", res[2].Info)
+
+	// the block proposed again is not executed
+	cached, n := processBlock(base)
+	require.Zero(t, n)
+	require.Equal(t, res, cached)
+
+	// in a block of another order, only the tx that kept its index is not executed
+	reordered := requestList(3)
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	atomic.StoreInt64(&executions, 0)
+	_, err := s.ProcessAll(base.WithMultiStore(base.MultiStore().CacheMultiStore()), reordered)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), atomic.LoadInt64(&executions))
+
+	// txs whose reads changed are executed
+	changed := base.WithMultiStore(base.MultiStore().CacheMultiStore())
+	changed.MultiStore().GetKVStore(testStoreKey).Set([]byte("shared"), []byte("b"))
+	atomic.StoreInt64(&executions, 0)
+	res, err = s.ProcessAll(changed, requestList(3))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), atomic.LoadInt64(&executions))
+	require.Equal(t, "b", res[2].Info)
+
+	// as are the txs of a block of another hash, whose parent state may differ
+	// in keys the txs only iterated over
+	iterated := base.WithHeaderHash([]byte("other")).WithMultiStore(base.MultiStore().CacheMultiStore())
+	iterated.MultiStore().GetKVStore(testStoreKey).Set([]byte("k2"), []byte("v"))
+	atomic.StoreInt64(&executions, 0)
+	res, err = s.ProcessAll(iterated, requestList(3))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), atomic.LoadInt64(&executions))
+	require.Equal(t, []byte("2"), res[2].Data)
+
+	// and the txs of a block without hash are always executed
+	_, n = processBlock(base.WithHeaderHash(nil))
+	require.Equal(t, int64(3), n)
+	_, n = processBlock(base.WithHeaderHash(nil))
+	require.Equal(t, int64(3), n)
+
+	// so is a block of another time
+	_, n = processBlock(base.WithBlockTime(time.Unix(101, 0)))
+	require.Equal(t, int64(3), n)
+
+	// the results of another height are dropped
+	_, n = processBlock(base.WithBlockHeight(6))
+	require.Equal(t, int64(3), n)
+	_, n = processBlock(base)
+	require.Equal(t, int64(3), n)
+}
@@ -0,0 +1,1271 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"go.opentelemetry.io/otel/attribute"
+	otrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/cosmos/cosmos-sdk/store/cachemulti"
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+	"github.com/cosmos/cosmos-sdk/utils/tracing"
+)
+
+type status string
+
+const (
+	// statusPending tasks are ready for execution
+	// all executing tasks are in pending state
+	statusPending status = "pending"
+	// statusExecuted tasks are ready for validation
+	// these tasks did not abort during execution
+	statusExecuted status = "executed"
+	// statusAborted means the task has been aborted
+	// these tasks transition to pending upon next execution
+	statusAborted status = "aborted"
+	// statusValidated means the task has been validated
+	// tasks in this status can be reset if an earlier task fails validation
+	statusValidated status = "validated"
+)
+
+type deliverTxTask struct {
+	Ctx           sdk.Context
+	AbortCh       chan occ.Abort
+	Status        status
+	Abort         *occ.Abort
+	Index         int
+	Incarnation   int
+	Request       types.RequestDeliverTx
+	Response      *types.ResponseDeliverTx
+	VersionStores map[sdk.StoreKey]*multiversion.VersionIndexedStore
+	// Branch is the multi-store of the last execution, its stores are the
+	// version stores
+	Branch *cachemulti.BranchStore
+	// ReadSet, IterateSet and WriteSet are the reads, range scans and writes of
+	// the last execution, per store
+	ReadSet    map[sdk.StoreKey]multiversion.ReadSet
+	IterateSet map[sdk.StoreKey]multiversion.IterateSet
+	WriteSet   map[sdk.StoreKey]multiversion.WriteSet
+	// GasEstimate is the expected cost of executing the task, used to start
+	// expensive tasks first. It is the gas used by the last execution, if any.
+	GasEstimate uint64
+	// BlockGasBefore is the block gas consumed by the lower-indexed txs when
+	// the last execution started.
+	BlockGasBefore uint64
+	// Suspect is set when an execution of the task timed out. Suspect tasks are
+	// re-executed without a timeout, after the other tasks of their round.
+	Suspect bool
+	// Charges holds the fees deducted by the last execution. Like its writes,
+	// they only stand if the execution is the one validated.
+	Charges *sdk.TxCharges
+	// ValidationPanics is the number of validations of the task that panicked,
+	// over all its incarnations.
+	ValidationPanics int
+	// ReadsFinal is set when the last execution started once every
+	// lower-indexed task had been validated: its reads are then final, and it
+	// is valid without comparing them.
+	ReadsFinal bool
+	// HotKeys are the hot keys the task declared, see WithMaxConcurrentPerKey
+	HotKeys []hotKey
+	// FirstResponse and FirstWriteSet are the response and the writes of the
+	// first completed execution of the task, see ChangedAcrossIncarnations
+	FirstResponse *types.ResponseDeliverTx
+	FirstWriteSet map[sdk.StoreKey]multiversion.WriteSet
+	// StartSequence is the sequence number of the start of the last execution,
+	// see WithConflictKeyFunc
+	StartSequence uint64
+}
+
+// Increment resets the task for its next incarnation.
+func (dt *deliverTxTask) Increment() {
+	dt.Incarnation++
+	dt.Status = statusPending
+	dt.Response = nil
+	dt.Abort = nil
+	dt.AbortCh = nil
+	dt.Charges = nil
+	dt.ReadsFinal = false
+	dt.VersionStores = nil
+	dt.discardBranch()
+	dt.ReadSet = nil
+	dt.IterateSet = nil
+	dt.WriteSet = nil
+}
+
+// discardBranch recycles the multi-store of the last execution.
+func (dt *deliverTxTask) discardBranch() {
+	if dt.Branch != nil {
+		dt.Branch.Discard()
+		dt.Branch = nil
+	}
+}
+
+// validationKeys returns the number of keys the validation of the task's last
+// execution compares, counting each range scanned as a key.
+func (dt *deliverTxTask) validationKeys() int {
+	keys := 0
+	for _, readset := range dt.ReadSet {
+		keys += len(readset)
+	}
+	for _, iterateset := range dt.IterateSet {
+		for _, iteration := range iterateset {
+			keys += 1 + len(iteration.Observed)
+		}
+	}
+	return keys
+}
+
+// recordAccesses saves the reads and writes of the task's version stores.
+func (dt *deliverTxTask) recordAccesses() {
+	dt.ReadSet = make(map[sdk.StoreKey]multiversion.ReadSet, len(dt.VersionStores))
+	dt.IterateSet = make(map[sdk.StoreKey]multiversion.IterateSet, len(dt.VersionStores))
+	dt.WriteSet = make(map[sdk.StoreKey]multiversion.WriteSet, len(dt.VersionStores))
+	for storeKey, vs := range dt.VersionStores {
+		dt.ReadSet[storeKey] = vs.GetReadset()
+		if iterateset := vs.GetIterateset(); len(iterateset) > 0 {
+			dt.IterateSet[storeKey] = iterateset
+		}
+		dt.WriteSet[storeKey] = vs.GetWriteset()
+	}
+}
+
+// Scheduler processes tasks concurrently
+type Scheduler interface {
+	ProcessAll(ctx sdk.Context, reqs []types.RequestDeliverTx) ([]types.ResponseDeliverTx, error)
+	// ProcessAllWithHints is ProcessAll with the access operations declared by
+	// each request, indexed like reqs, used to keep known conflicts out of the
+	// first execution round.
+	ProcessAllWithHints(ctx sdk.Context, reqs []types.RequestDeliverTx, hints [][]acltypes.AccessOperation) ([]types.ResponseDeliverTx, error)
+	// ProcessAllWithDeadline is ProcessAll bounded by deadline, in addition to
+	// any deadline already set on ctx.
+	ProcessAllWithDeadline(ctx sdk.Context, reqs []types.RequestDeliverTx, deadline time.Time) ([]types.ResponseDeliverTx, error)
+	// ProcessAllWithCallback is ProcessAll calling onValidated with the
+	// response of each tx, in request order, as soon as it is final.
+	ProcessAllWithCallback(ctx sdk.Context, reqs []types.RequestDeliverTx, onValidated ResultCallback) ([]types.ResponseDeliverTx, error)
+	// ProcessAllWithIndependence is ProcessAll trusting that the txs declared
+	// independent by independence do not access the same state, and skipping
+	// their validation against each other.
+	ProcessAllWithIndependence(ctx sdk.Context, reqs []types.RequestDeliverTx, independence Independence) ([]types.ResponseDeliverTx, error)
+	// ProcessAllWithPrologue is ProcessAll executing the txs at prologueIndices
+	// one at a time before the others, which all read the prologue's writes.
+	ProcessAllWithPrologue(ctx sdk.Context, reqs []types.RequestDeliverTx, prologueIndices []int) ([]types.ResponseDeliverTx, error)
+	// Stats returns the stats of the last block processed. It may be called
+	// while a block is processed.
+	Stats() Stats
+	// ModeSelectionReason returns why the last block processed was executed
+	// the way it was, one of the ModeReason constants.
+	ModeSelectionReason() string
+	// ConflictGraph returns the conflicts found in the last block processed. It
+	// is empty unless the scheduler was created WithConflictGraph.
+	ConflictGraph() ConflictGraph
+	// ExecutionTimeline returns the executions of the tasks of the last block
+	// processed, by start time. It is empty unless the scheduler was created
+	// WithExecutionTimeline, and may be called while a block is processed.
+	ExecutionTimeline() []TaskSpan
+	// AssertWriteset returns an error detailing every difference between the
+	// writes committed by the last block processed and expected, which holds
+	// the final value of every key written, keyed by store name and key joined
+	// by a slash, nil for deletes. It must not be called while a block is
+	// processed.
+	AssertWriteset(expected map[string][]byte) error
+	// CommittedValue returns the value the last block processed committed for
+	// key in the store of storeKey, and false if the block deleted the key,
+	// did not write it, or failed. It must not be called while a block is
+	// processed.
+	CommittedValue(storeKey sdk.StoreKey, key []byte) ([]byte, bool)
+	// ChangedAcrossIncarnations returns the indexes of the txs of the last
+	// block processed whose committed response or writes differ from those of
+	// their first execution, in index order. It must not be called while a
+	// block is processed.
+	ChangedAcrossIncarnations() []int
+	// ReexecutedBitset returns a bitset of the txs of the last block processed
+	// that were executed more than once: the tx at index i is bit i%64 of word
+	// i/64. It must not be called while a block is processed.
+	ReexecutedBitset() []uint64
+	// PendingIndexRange returns the lowest and the highest index of the txs of
+	// the block being processed that have not been validated yet, and their
+	// number. It is updated after every validation pass, and may be called
+	// while a block is processed. Both indexes are -1 when no tx is pending.
+	PendingIndexRange() (min, max int, count int)
+	// SetWorkers changes the number of workers, as given to NewScheduler, of
+	// the blocks processed from then on. It may be called while a block is
+	// processed.
+	SetWorkers(workers int)
+}
+
+// ResultCallback receives the final response of the tx at index. The writes of
+// the tx are only applied to the stores once the whole block has been
+// processed without error.
+type ResultCallback func(index int, res types.ResponseDeliverTx)
+
+// IncompleteError is returned when the context of a block is done before every
+// task has been validated. No writes of the block are applied in that case.
+type IncompleteError struct {
+	// Pending holds the indexes of the txs that had not been validated
+	Pending []int
+	Err     error
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("scheduler stopped with %d pending txs: %v", len(e.Pending), e.Err)
+}
+
+func (e *IncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the error stopping the scheduler, for sdkerrors.ABCIInfo to
+// report its codespace and code.
+func (e *IncompleteError) Cause() error {
+	return e.Err
+}
+
+func newIncompleteError(tasks []*deliverTxTask, err error) *IncompleteError {
+	var pending []int
+	for _, t := range tasks {
+		if t.Status != statusValidated {
+			pending = append(pending, t.Index)
+		}
+	}
+	return &IncompleteError{Pending: pending, Err: err}
+}
+
+const (
+	// DefaultMaxIncarnation is the default number of times a task may execute
+	// concurrently before the scheduler falls back to sequential execution.
+	DefaultMaxIncarnation = 20
+	// DefaultMaxRounds is the default number of execute/validate rounds before
+	// the scheduler falls back to sequential execution.
+	DefaultMaxRounds = 100
+	// DefaultMaxValidationPanics is the default number of times the validation
+	// of a task may panic before the tx is failed.
+	DefaultMaxValidationPanics = 3
+)
+
+type scheduler struct {
+	deliverTx      func(ctx sdk.Context, req types.RequestDeliverTx) (res types.ResponseDeliverTx)
+	workers        int
+	maxIncarnation int
+	// poolSize is the number of goroutines in the worker pool. An adaptive
+	// scheduler lets a block use workers of them, through slots.
+	poolSize           int
+	adaptive           bool
+	slots              chan struct{}
+	maxRounds          int
+	multiVersionStores map[sdk.StoreKey]multiversion.MultiVersionStore
+	// committed is whether the writes of multiVersionStores were committed
+	committed bool
+	// changed are the tasks of the last block committed whose results changed
+	// across their incarnations
+	changed []int
+	// reexecuted marks the tasks of the last block committed that executed more
+	// than once
+	reexecuted []uint64
+	// work feeds the worker pool, it is nil when the number of workers is unlimited
+	work         chan workItem
+	tracingInfo  *tracing.Info
+	gasEstimator GasEstimator
+	blockStats   *blockStats
+	// lastStats, pending and lastTimeline are guarded by statsMtx for Stats,
+	// PendingIndexRange and ExecutionTimeline to be called while a block is
+	// processed
+	statsMtx     sync.Mutex
+	lastStats    Stats
+	pending      pendingRange
+	lastTimeline []TaskSpan
+	// timeline, if set, records the executions of the current block
+	timeline *timeline
+	// conflictDetector, if set, filters the reads checked during validation
+	conflictDetector ConflictDetector
+	// maxValidationPanics is the number of panics in the validation of a task
+	// after which the tx fails, zero means no bound
+	maxValidationPanics int
+	// maxValidationKeys is the number of keys a validation compares at most,
+	// zero means no bound
+	maxValidationKeys int
+	// maxConcurrentPerKey is the number of tasks declaring the same hot key
+	// that may execute at the same time, zero means no bound, and
+	// hotKeyThreshold the number of txs declaring a key above which it is hot
+	maxConcurrentPerKey int
+	hotKeyThreshold     int
+	// keySemaphores bounds the executions of the current block per hot key
+	keySemaphores map[hotKey]chan struct{}
+	// faultInjector, if set, injects faults in the executions, for tests
+	faultInjector FaultInjector
+	// commitGasConfig, if set, prices the writes committed by a block
+	commitGasConfig *storetypes.GasConfig
+	// independence is the independence of the txs of the current block, if
+	// declared, and independentOfLower whether each tx is independent of every
+	// tx before it
+	independence       Independence
+	independentOfLower []bool
+	checkIndependence  bool
+	// checkGasInvariant checks the block gas of every block before committing it
+	checkGasInvariant bool
+	// branchedCaches are the keys of the context values branched for every
+	// execution
+	branchedCaches []interface{}
+	// taskSpans emits a span for every execution of a task
+	taskSpans bool
+	// maxVersionedStoreBytes caps the memory of the multi-version stores of a
+	// block, zero means no cap. The txs below flushedBefore were committed to
+	// flushBranch before the end of the block, writing flushedWrites, keyed by
+	// store name and key.
+	maxVersionedStoreBytes int
+	flushBranch            sdk.CacheMultiStore
+	flushedWrites          map[string]map[string][]byte
+	flushedBefore          int
+	// conflictKeyFunc, if set, maps the keys accessed to coarser conflict keys,
+	// and conflictKeyWrites holds the sequence number of the last publication
+	// of each conflict key of the current block, per writer index
+	conflictKeyFunc      ConflictKeyFunc
+	conflictKeyWritesMtx sync.Mutex
+	conflictKeyWrites    map[string]map[int]uint64
+	sequence             uint64
+	// txIndexes, if set, are the indexes in their block of the txs processed,
+	// see ProcessAllWithPrologue
+	txIndexes []int
+	// shadowMode also executes every block sequentially to check the results
+	shadowMode bool
+	strategy   ExecutionStrategy
+	// taskTimeout bounds a concurrent execution, zero means no bound
+	taskTimeout time.Duration
+	blockGas    *blockGas
+	// conflictGraph, if set, records the conflicts of the current block
+	conflictGraph     *conflictGraph
+	conflictGraphDir  string
+	lastConflictGraph ConflictGraph
+	// resultCache, if set, holds the results of the txs of the current height
+	resultCache *resultCache
+	// nextWorkers, if set, is the number of workers SetWorkers switches to
+	// before the next block
+	nextWorkersMtx sync.Mutex
+	nextWorkers    *int
+}
+
+// GasEstimator returns the expected gas cost of a request before it executes,
+// for instance the gas limit of the tx.
+type GasEstimator func(req types.RequestDeliverTx) uint64
+
+// WithGasEstimator makes the scheduler start the tasks with the highest
+// estimated gas first, so that long-running txs do not end up at the tail of a
+// round. Responses are still committed in request order.
+func WithGasEstimator(gasEstimator GasEstimator) Option {
+	return func(s *scheduler) {
+		s.gasEstimator = gasEstimator
+	}
+}
+
+// Option configures a scheduler created by NewScheduler.
+type Option func(*scheduler)
+
+// WithMaxIncarnation caps how many times a task may execute concurrently. A
+// task that needs to re-execute beyond the cap causes the remaining tasks to be
+// executed sequentially.
+func WithMaxIncarnation(maxIncarnation int) Option {
+	return func(s *scheduler) {
+		s.maxIncarnation = maxIncarnation
+	}
+}
+
+// WithMaxRounds caps the number of concurrent execute/validate rounds, after
+// which the remaining tasks are executed sequentially.
+func WithMaxRounds(maxRounds int) Option {
+	return func(s *scheduler) {
+		s.maxRounds = maxRounds
+	}
+}
+
+// WithMaxValidationPanics fails the tx of a task whose validation panicked
+// maxValidationPanics times, rather than re-executing it for as long as its
+// validation panics. The tx is then executed once more, without running
+// deliverTx: it fails with occ.ErrValidationPanic and writes nothing. Zero
+// means no bound, the block then fails if the validation of a task still
+// panics once it executed sequentially.
+func WithMaxValidationPanics(maxValidationPanics int) Option {
+	return func(s *scheduler) {
+		s.maxValidationPanics = maxValidationPanics
+	}
+}
+
+// WithMaxValidationKeys bounds the number of keys read by a task that its
+// validation compares, so that a tx with an enormous read set cannot make every
+// validation pass expensive. A task that read more keys is deemed invalid, and
+// re-executed until it executes once every lower-indexed task is validated, as
+// it is then valid without comparing its reads.
+func WithMaxValidationKeys(maxValidationKeys int) Option {
+	return func(s *scheduler) {
+		s.maxValidationKeys = maxValidationKeys
+	}
+}
+
+// WithTaskTimeout aborts a concurrent execution still running after timeout,
+// so that a slow tx does not hold up its round. The task is marked suspect and
+// re-executed without a timeout once the other tasks of the next round are
+// done. The timeout is checked when the tx accesses its stores, so a tx that
+// stops touching state cannot be interrupted.
+func WithTaskTimeout(timeout time.Duration) Option {
+	return func(s *scheduler) {
+		s.taskTimeout = timeout
+	}
+}
+
+// NewScheduler creates a new scheduler. A positive number of workers starts a
+// pool of that many goroutines that lives as long as the scheduler and
+// executes the tasks of every block. Zero workers starts a pool of one
+// goroutine per CPU and adapts how many of them each block uses to the
+// conflict rate, see adaptWorkers. A negative number of workers runs each task
+// in its own goroutine.
+func NewScheduler(workers int, deliverTxFunc func(ctx sdk.Context, req types.RequestDeliverTx) (res types.ResponseDeliverTx), opts ...Option) Scheduler {
+	s := &scheduler{
+		deliverTx:           deliverTxFunc,
+		maxIncarnation:      DefaultMaxIncarnation,
+		maxRounds:           DefaultMaxRounds,
+		maxValidationPanics: DefaultMaxValidationPanics,
+		blockStats:          &blockStats{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.startPool(workers)
+	return s
+}
+
+// startPool starts the worker pool of the given number of workers, see
+// NewScheduler.
+func (s *scheduler) startPool(workers int) {
+	s.workers = workers
+	s.adaptive = workers == 0
+	s.slots = nil
+	if s.adaptive {
+		s.workers = runtime.NumCPU()
+	}
+	s.poolSize = s.workers
+	if s.workers > 0 {
+		s.startWorkers()
+	}
+}
+
+// SetWorkers implements Scheduler.
+func (s *scheduler) SetWorkers(workers int) {
+	s.nextWorkersMtx.Lock()
+	defer s.nextWorkersMtx.Unlock()
+	s.nextWorkers = &workers
+}
+
+// switchWorkers replaces the worker pool with the one set by SetWorkers, if
+// any. It is called between blocks, when no work is queued, by the goroutine
+// processing the blocks: the fields of the pool are only accessed by it, the
+// other goroutines going through nextWorkers and lastStats, under their locks.
+// The workers of the replaced pool stop once its channel is closed.
+func (s *scheduler) switchWorkers() {
+	s.nextWorkersMtx.Lock()
+	next := s.nextWorkers
+	s.nextWorkers = nil
+	s.nextWorkersMtx.Unlock()
+	if next == nil {
+		return
+	}
+	if s.work != nil {
+		close(s.work)
+		s.work = nil
+	}
+	s.startPool(*next)
+}
+
+func toTasks(reqs []types.RequestDeliverTx) []*deliverTxTask {
+	res := make([]*deliverTxTask, 0, len(reqs))
+	for idx, r := range reqs {
+		res = append(res, &deliverTxTask{
+			Request: r,
+			Index:   idx,
+			Status:  statusPending,
+		})
+	}
+	return res
+}
+
+func collectResponses(tasks []*deliverTxTask) []types.ResponseDeliverTx {
+	res := make([]types.ResponseDeliverTx, 0, len(tasks))
+	for _, t := range tasks {
+		res = append(res, *t.Response)
+	}
+	return res
+}
+
+// initBlock prepares the scheduler to process a block of txs.
+func (s *scheduler) initBlock(ctx sdk.Context, txs int) {
+	s.initMultiVersionStore(ctx)
+	s.blockGas = newBlockGas(ctx, txs)
+	s.conflictKeyWrites = make(map[string]map[int]uint64)
+}
+
+// initMultiVersionStore creates a multi-version store for every store mounted
+// on the block's multi-store. Their parents are the block-level stores, which
+// receive the final writesets once every task has been validated.
+func (s *scheduler) initMultiVersionStore(ctx sdk.Context) {
+	parents := ctx.MultiStore()
+	s.flushBranch = nil
+	if s.maxVersionedStoreBytes > 0 {
+		// the txs flushed during the block are committed with the others
+		s.flushBranch = parents.CacheMultiStore()
+		parents = s.flushBranch
+	}
+	s.flushedWrites = nil
+	s.flushedBefore = 0
+	mvs := make(map[sdk.StoreKey]multiversion.MultiVersionStore)
+	for _, sk := range parents.StoreKeys() {
+		mvs[sk] = multiversion.NewMultiVersionStore(parents.GetKVStore(sk), sk)
+	}
+	s.multiVersionStores = mvs
+	s.committed = false
+	s.changed = nil
+	s.reexecuted = nil
+}
+
+// ProcessAll executes the requests concurrently and returns their responses in
+// request order. The writes of every transaction are applied to the stores of
+// ctx's multi-store once all tasks have been validated, and the events they
+// emitted on their context are then emitted on ctx's event manager.
+func (s *scheduler) ProcessAll(ctx sdk.Context, reqs []types.RequestDeliverTx) ([]types.ResponseDeliverTx, error) {
+	return s.ProcessAllWithHints(ctx, reqs, nil)
+}
+
+// ProcessAllWithDeadline implements Scheduler. Once the deadline passes no new
+// executions start, the ones in flight are waited for, and an IncompleteError
+// listing the txs that were still pending is returned.
+func (s *scheduler) ProcessAllWithDeadline(ctx sdk.Context, reqs []types.RequestDeliverTx, deadline time.Time) ([]types.ResponseDeliverTx, error) {
+	goCtx, cancel := context.WithDeadline(ctx.Context(), deadline)
+	defer cancel()
+	return s.ProcessAll(ctx.WithContext(goCtx), reqs)
+}
+
+// ProcessAllWithCallback implements Scheduler. A tx is final once every tx up to
+// it has been validated, since only higher-indexed txs are re-executed after
+// that.
+func (s *scheduler) ProcessAllWithCallback(ctx sdk.Context, reqs []types.RequestDeliverTx, onValidated ResultCallback) ([]types.ResponseDeliverTx, error) {
+	return s.processAll(ctx, reqs, nil, onValidated)
+}
+
+// ProcessAllWithHints implements Scheduler. The first round executes the tasks
+// in waves built from hints, where each wave only starts once the tasks it
+// conflicts with have executed; later rounds re-execute invalid tasks in waves
+// built from the tasks they aborted on, as in ProcessAll.
+func (s *scheduler) ProcessAllWithHints(ctx sdk.Context, reqs []types.RequestDeliverTx, hints [][]acltypes.AccessOperation) ([]types.ResponseDeliverTx, error) {
+	return s.processAll(ctx, reqs, hints, nil)
+}
+
+// processAll processes the block, calling onValidated, if set, for each task
+// once it is final.
+func (s *scheduler) processAll(ctx sdk.Context, reqs []types.RequestDeliverTx, hints [][]acltypes.AccessOperation, onValidated ResultCallback) ([]types.ResponseDeliverTx, error) {
+	s.switchWorkers()
+	ctx, span := s.startSpan(ctx, "SchedulerProcessAll")
+	defer span.End()
+	span.SetAttributes(attribute.Int("txs", len(reqs)))
+	// re-executions of a tx reuse its decoding and signature verification
+	if ctx.TxCache() == nil {
+		ctx = ctx.WithTxCache(sdk.NewTxCache())
+	}
+
+	// the sequential run must see the state from before the block
+	var shadow *shadowResult
+	if s.shadowMode {
+		shadow = s.runShadow(ctx, reqs)
+	}
+
+	s.initBlock(ctx, len(reqs))
+	s.blockStats = &blockStats{}
+	defer func() {
+		stats := s.blockStats.stats(len(reqs), s.workersFor(len(reqs)))
+		timeline := s.timeline.sorted()
+		s.statsMtx.Lock()
+		s.lastStats = stats
+		if s.timeline != nil {
+			s.lastTimeline = timeline
+		}
+		s.statsMtx.Unlock()
+		s.adaptWorkers(stats)
+		logger(ctx).Debug(
+			"processed block",
+			"height", ctx.BlockHeight(), "txs", stats.Txs,
+			"rounds", stats.Rounds, "incarnations", stats.Incarnations,
+			"validationFailures", stats.ValidationFailures, "aborts", stats.Aborts,
+			"workers", stats.Workers, "parallelism", stats.Parallelism,
+			"mode", stats.ModeReason,
+		)
+	}()
+	if s.adaptive {
+		s.slots = make(chan struct{}, s.workers)
+	}
+	telemetry.SetGauge(float32(s.workersFor(len(reqs))), "scheduler", "workers")
+	if s.conflictGraph != nil {
+		s.conflictGraph = &conflictGraph{}
+		defer func() {
+			s.lastConflictGraph = s.conflictGraph.graph(ctx.BlockHeight())
+			s.dumpConflictGraph(ctx, s.lastConflictGraph)
+		}()
+	}
+	tasks := toTasks(reqs)
+	s.updatePending(tasks)
+	if s.gasEstimator != nil {
+		for _, t := range tasks {
+			t.GasEstimate = s.gasEstimator(t.Request)
+		}
+	}
+	s.limitHotKeys(tasks, hints)
+	waves := executionWaves(tasks, hints)
+	toExecute := tasks
+	// validation watermark: every task below it has been validated and cannot be
+	// invalidated by later rounds, since only higher-indexed tasks re-execute
+	validateFrom := 0
+	// final is the number of tasks passed to onValidated
+	final := 0
+	finalize := func(upTo int) {
+		for ; final < upTo; final++ {
+			if onValidated != nil {
+				onValidated(final, *tasks[final].Response)
+			}
+		}
+	}
+	for round := 1; len(toExecute) > 0; round++ {
+		s.blockStats.rounds = round
+		// every task below the first one to execute is validated
+		toExecute[0].ReadsFinal = true
+
+		// execute sets statuses of tasks to either executed or aborted
+		roundCtx, roundSpan := s.startSpan(ctx, "SchedulerExecuteRound")
+		roundSpan.SetAttributes(attribute.Int("round", round), attribute.Int("tasks", len(toExecute)))
+		for _, wave := range waves {
+			if err := s.executeAll(roundCtx, wave); err != nil {
+				roundSpan.End()
+				return nil, newIncompleteError(tasks, err)
+			}
+		}
+		roundSpan.End()
+
+		// validate returns any that should be re-executed
+		// note this processes every task at or above the watermark, not just
+		// those recently executed, since their reads may depend on the re-executions
+		var err error
+		toExecute, err = s.validateAll(ctx, tasks, validateFrom)
+		if err != nil {
+			return nil, newIncompleteError(tasks, err)
+		}
+		s.updatePending(tasks[validateFrom:])
+		if len(toExecute) > 0 {
+			validateFrom = toExecute[0].Index
+			finalize(validateFrom)
+		} else {
+			finalize(len(tasks))
+		}
+		reason := s.fallbackReason(round, toExecute)
+		if reason == "" && len(toExecute) > 0 && s.limitVersionedStores(validateFrom) {
+			reason = ModeReasonMaxVersionedStoreBytesExceeded
+		}
+		if reason != "" {
+			// concurrent execution is not converging, finish the block deterministically
+			s.blockStats.modeReason = reason
+			telemetry.IncrCounterWithLabels([]string{"scheduler", "sequential_fallback"}, 1, []metrics.Label{telemetry.NewLabel("reason", reason)})
+			err := s.executeSequentially(ctx, tasks[validateFrom:], func(t *deliverTxTask) {
+				s.updatePending(tasks[t.Index+1:])
+				finalize(t.Index + 1)
+				s.limitVersionedStores(t.Index + 1)
+			})
+			if err != nil {
+				return nil, newIncompleteError(tasks, err)
+			}
+			break
+		}
+		// the aborts are cleared by Increment
+		waves = reexecutionWaves(toExecute)
+		for _, t := range toExecute {
+			s.blockStats.discard(t)
+			t.Increment()
+		}
+	}
+	// every task is validated, commit the block's writes in tx order, or none
+	if err := s.verifyIndependence(tasks); err != nil {
+		return nil, err
+	}
+	if err := s.verifyGasInvariant(tasks); err != nil {
+		return nil, err
+	}
+	_, commitSpan := s.startSpan(ctx, "SchedulerCommit")
+	commitStart := time.Now()
+	if err := multiversion.WriteAll(s.multiVersionStores); err != nil {
+		commitSpan.End()
+		return nil, sdkerrors.Wrap(occ.ErrWriteSetApply, err.Error())
+	}
+	if s.flushBranch != nil {
+		s.flushBranch.Write()
+	}
+	commitSpan.End()
+	s.blockStats.commitDuration = time.Since(commitStart)
+	s.blockStats.commitGas = s.commitGas()
+	s.committed = true
+	s.changed = changedAcrossIncarnations(tasks)
+	s.reexecuted = reexecutedBitset(tasks)
+	s.consumeBlockGas(ctx)
+	s.blockStats.charge(tasks)
+	emitTxEvents(ctx, tasks)
+	if s.resultCache != nil {
+		s.resultCache.add(ctx, tasks, s.txIndex)
+	}
+	for _, t := range tasks {
+		t.discardBranch()
+	}
+	if shadow != nil {
+		s.blockStats.shadowDivergences = s.compareShadow(ctx, tasks, shadow)
+	}
+	emitIncarnationMetrics(tasks)
+	return collectResponses(tasks), nil
+}
+
+// emitIncarnationMetrics records how many times each task of a block executed.
+func emitIncarnationMetrics(tasks []*deliverTxTask) {
+	for _, t := range tasks {
+		telemetry.AddSample(float32(t.Incarnation+1), "scheduler", "incarnations")
+	}
+}
+
+// exceedsLimits reports whether re-executing the tasks concurrently would go
+// past the configured caps on rounds or incarnations.
+func (s *scheduler) exceedsLimits(round int, toExecute []*deliverTxTask) bool {
+	return s.fallbackReason(round, toExecute) != ""
+}
+
+// fallbackReason returns the cap that re-executing the tasks concurrently would
+// go past, as a ModeReason, or an empty string if none.
+func (s *scheduler) fallbackReason(round int, toExecute []*deliverTxTask) string {
+	if len(toExecute) == 0 {
+		return ""
+	}
+	if s.maxRounds > 0 && round >= s.maxRounds {
+		return ModeReasonMaxRoundsExceeded
+	}
+	if s.maxIncarnation > 0 {
+		for _, t := range toExecute {
+			if t.Incarnation+1 >= s.maxIncarnation {
+				return ModeReasonMaxIncarnationsExceeded
+			}
+		}
+	}
+	return ""
+}
+
+// executeSequentially executes the invalid tasks one at a time in index order,
+// validating each task against the writes of the tasks before it. Every task
+// below tasks[0] must already be validated, so each task reads final values and
+// is valid after a single execution, unless its validation panics: it is then
+// executed again until its tx fails, see WithMaxValidationPanics. onValidated
+// is called with each task once it is validated.
+func (s *scheduler) executeSequentially(ctx sdk.Context, tasks []*deliverTxTask, onValidated func(*deliverTxTask)) error {
+	ctx, span := s.startSpan(ctx, "SchedulerSequential")
+	defer span.End()
+	span.SetAttributes(attribute.Int("tasks", len(tasks)))
+	for _, t := range tasks {
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+		for executions := 0; t.Status == statusAborted || !s.validateTaskWithRecover(ctx, t); executions++ {
+			if executions > s.maxValidationPanics {
+				return sdkerrors.Wrapf(occ.ErrMaxIncarnationsExceeded, "task %d is invalid after sequential execution", t.Index)
+			}
+			s.blockStats.discard(t)
+			t.Increment()
+			t.ReadsFinal = true
+			// the fallback must run every task to completion
+			start := time.Now()
+			s.executeTaskWithTimeout(ctx, t, 0)
+			s.timeline.record(t.Index, t.Incarnation, SequentialWorker, start)
+		}
+		t.Status = statusValidated
+		onValidated(t)
+	}
+	return nil
+}
+
+// validateAll validates the tasks with an index of at least validateFrom and
+// returns, in index order, the ones that must be re-executed. Tasks below
+// validateFrom were validated in an earlier round and are left untouched.
+//
+// The read sets are checked concurrently on the worker pool and the results are
+// then applied in index order. A task that read from a task invalidated in the
+// same pass may still be marked validated, which is safe because the next pass
+// starts from the lowest invalid index and checks it again.
+func (s *scheduler) validateAll(ctx sdk.Context, tasks []*deliverTxTask, validateFrom int) ([]*deliverTxTask, error) {
+	ctx, span := s.startSpan(ctx, "SchedulerValidate")
+	defer span.End()
+	span.SetAttributes(attribute.Int("validateFrom", validateFrom))
+
+	tasks = tasks[validateFrom:]
+	valid := make([]bool, len(tasks))
+	fns := make([]func(worker int), 0, len(tasks))
+	for i, t := range tasks {
+		i, t := i, t
+		// any aborted tx is known to be suspect here
+		if t.Status == statusAborted {
+			continue
+		}
+		fns = append(fns, func(int) {
+			valid[i] = s.validateTaskWithRecover(ctx, t)
+		})
+	}
+	if err := s.runOnWorkers(ctx, fns, nil); err != nil {
+		return nil, err
+	}
+
+	var res []*deliverTxTask
+	validationFailures := 0
+	for i, t := range tasks {
+		if t.Status == statusAborted {
+			res = append(res, t)
+			continue
+		}
+		if !valid[i] {
+			if span.IsRecording() {
+				span.AddEvent("invalid", otrace.WithAttributes(
+					attribute.Int("txIndex", t.Index),
+					attribute.Int("incarnation", t.Incarnation),
+					attribute.StringSlice("conflictKeys", s.conflictKeys(t)),
+				))
+			}
+			s.recordInvalidation(t)
+			s.invalidateTask(t)
+			res = append(res, t)
+			validationFailures++
+			continue
+		}
+		t.Status = statusValidated
+	}
+	telemetry.IncrCounter(float32(validationFailures), "scheduler", "validation_failures")
+	atomic.AddInt64(&s.blockStats.validationFailures, int64(validationFailures))
+	span.SetAttributes(attribute.Int("invalid", validationFailures), attribute.Int("toExecute", len(res)))
+	return res, nil
+}
+
+// validateTask reports whether every read and range scan of the task's last
+// execution still matches what the multi-version stores would return for its
+// index, including the block gas consumed before it. A task that read more
+// keys than WithMaxValidationKeys allows is only valid if its reads are final.
+func (s *scheduler) validateTask(task *deliverTxTask) bool {
+	if s.maxValidationKeys > 0 && task.validationKeys() > s.maxValidationKeys {
+		if !task.ReadsFinal {
+			telemetry.IncrCounter(1, "scheduler", "capped_validations")
+		}
+		return task.ReadsFinal
+	}
+	if !s.validateBlockGas(task) {
+		return false
+	}
+	if s.isIndependentOfLower(task) {
+		return true
+	}
+	for storeKey, readset := range task.ReadSet {
+		if !s.multiVersionStores[storeKey].ValidateReadset(task.Index, s.conflictingReads(storeKey, readset, task.WriteSet[storeKey])) {
+			return false
+		}
+	}
+	for storeKey, iterateset := range task.IterateSet {
+		if !s.multiVersionStores[storeKey].ValidateIterateset(task.Index, iterateset) {
+			return false
+		}
+	}
+	return !s.conflictsCoarsely(task)
+}
+
+// validateTaskWithRecover is validateTask recovering from a panic, e.g. of the
+// conflict detector, which would otherwise take down the node from a worker
+// goroutine. A task whose validation panicked is invalid, so it is re-executed,
+// and its tx fails once its validation panicked too many times, see
+// WithMaxValidationPanics.
+func (s *scheduler) validateTaskWithRecover(ctx sdk.Context, task *deliverTxTask) (valid bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			task.ValidationPanics++
+			logger(ctx).Error("panic in validation", "index", task.Index, "incarnation", task.Incarnation,
+				"panics", task.ValidationPanics, "panic", r)
+			telemetry.IncrCounter(1, "scheduler", "validation_panics")
+			valid = false
+		}
+	}()
+	return s.validateTask(task)
+}
+
+// invalidateTask replaces the task's writes with ESTIMATEs so that
+// higher-indexed tasks reading them abort until it has re-executed.
+func (s *scheduler) invalidateTask(task *deliverTxTask) {
+	for storeKey := range task.WriteSet {
+		s.multiVersionStores[storeKey].InvalidateWriteset(task.Index, task.Incarnation)
+	}
+	task.Status = statusAborted
+}
+
+// txContext returns the context a tx at index executes with. Concurrent txs
+// must not share an event manager or gas meter, so each execution gets its
+// own; the ante handler sets the tx's gas limit.
+func txContext(ctx sdk.Context, index int) sdk.Context {
+	return ctx.
+		WithTxIndex(index).
+		WithMessageIndex(0).
+		WithEventManager(sdk.NewEventManager()).
+		WithGasMeter(sdk.NewInfiniteGasMeter())
+}
+
+// emitTxEvents emits the events of the validated executions on ctx's event
+// manager, in index order.
+func emitTxEvents(ctx sdk.Context, tasks []*deliverTxTask) {
+	for _, t := range tasks {
+		ctx.EventManager().EmitEvents(t.Ctx.EventManager().Events())
+	}
+}
+
+// prepareTask branches ctx for the task and swaps every store of the branch
+// for a version indexed store at the task's index and incarnation.
+func (s *scheduler) prepareTask(ctx sdk.Context, task *deliverTxTask) {
+	task.StartSequence = atomic.AddUint64(&s.sequence, 1)
+	task.BlockGasBefore = s.blockGas.consumedBefore(task.Index)
+	task.Charges = sdk.NewTxCharges()
+	ctx = s.branchCaches(txContext(ctx, s.txIndex(task.Index))).
+		WithBlockGasMeter(s.blockGas.meter(task.BlockGasBefore)).
+		WithTxCharges(task.Charges).
+		WithTxIncarnation(task.Incarnation)
+
+	// if there are no stores, don't try to wrap, because there's nothing to wrap
+	if len(s.multiVersionStores) > 0 {
+		// every version store may abort once, so the channel never blocks a write
+		abortCh := make(chan occ.Abort, len(s.multiVersionStores))
+
+		// init version stores by store key
+		vs := make(map[sdk.StoreKey]*multiversion.VersionIndexedStore)
+		for storeKey, mvs := range s.multiVersionStores {
+			vs[storeKey] = mvs.VersionedIndexedStore(task.Index, task.Incarnation, abortCh)
+		}
+
+		// save off version store so we can ask it things later
+		task.VersionStores = vs
+		task.AbortCh = abortCh
+		// the branch only materializes the stores the tx accesses
+		task.Branch = cachemulti.NewBranchStore(ctx.MultiStore(), func(k sdk.StoreKey, _ sdk.KVStore) sdk.CacheWrap {
+			return vs[k]
+		})
+
+		ctx = ctx.WithMultiStore(task.Branch)
+	}
+	task.Ctx = ctx
+}
+
+// executeTask runs deliverTx for a single task and publishes its writes to the
+// multi-version stores. If the task read an ESTIMATE it is marked aborted and
+// its writes are published as ESTIMATEs instead, so that later tasks touching
+// the same keys abort rather than read values that are about to change.
+func (s *scheduler) executeTask(ctx sdk.Context, task *deliverTxTask) {
+	timeout := s.taskTimeout
+	if task.Suspect {
+		timeout = 0
+	}
+	defer s.acquireHotKeys(task)()
+	s.executeTaskWithTimeout(ctx, task, timeout)
+}
+
+// executeTaskWithTimeout is executeTask with the execution interrupted after
+// timeout, if positive. An interrupted task is aborted and marked suspect.
+func (s *scheduler) executeTaskWithTimeout(ctx sdk.Context, task *deliverTxTask, timeout time.Duration) {
+	ctx, span := s.startTaskSpan(ctx)
+	defer span.End()
+	span.SetAttributes(attribute.Int("txIndex", task.Index), attribute.Int("incarnation", task.Incarnation))
+
+	if s.reuseResult(ctx, task) {
+		span.SetAttributes(attribute.Bool("cached", true))
+		return
+	}
+	s.prepareTask(ctx, task)
+	atomic.AddInt64(&s.blockStats.executions, 1)
+
+	if timeout > 0 {
+		// the task's fields may be reset by the time the timer fires
+		stores := task.VersionStores
+		timer := time.AfterFunc(timeout, func() {
+			for _, v := range stores {
+				v.Interrupt(occ.NewTimeoutAbort())
+			}
+		})
+		defer timer.Stop()
+	}
+
+	var resp types.ResponseDeliverTx
+	var aborted, panicked bool
+	if s.failsValidation(task) {
+		// the tx reads nothing, so its validation cannot panic anymore
+		resp = sdkerrors.ResponseDeliverTx(sdkerrors.Wrapf(occ.ErrValidationPanic,
+			"validation of tx %d panicked %d times", task.Index, task.ValidationPanics), 0, 0, false)
+	} else {
+		resp, aborted, panicked = s.deliverTxWithAbort(task)
+	}
+	if task.AbortCh != nil {
+		close(task.AbortCh)
+		if abt, ok := <-task.AbortCh; ok {
+			aborted = true
+			task.Abort = &abt
+		}
+	}
+	if aborted && errors.Is(task.Abort.Err, occ.ErrTimeout) {
+		span.SetAttributes(attribute.Bool("timedOut", true))
+		telemetry.IncrCounter(1, "scheduler", "timeouts")
+		atomic.AddInt64(&s.blockStats.timeouts, 1)
+		task.Suspect = true
+	}
+	if panicked && !aborted {
+		// a failed tx leaves no state behind, but its reads are kept so that it
+		// is re-executed if the panic was caused by a stale read
+		for _, v := range task.VersionStores {
+			v.ResetWriteset()
+		}
+	}
+	task.recordAccesses()
+	if aborted {
+		span.SetAttributes(attribute.Bool("aborted", true), attribute.String("abortReason", abortReason(task.Abort)))
+		if task.Abort != nil {
+			span.SetAttributes(attribute.Int("dependentTxIdx", task.Abort.DependentTxIdx))
+		}
+		telemetry.IncrCounter(1, "scheduler", "aborts")
+		atomic.AddInt64(&s.blockStats.aborts, 1)
+		s.recordAbort(task)
+		task.Status = statusAborted
+		for _, v := range task.VersionStores {
+			v.WriteEstimatesToMultiVersionStore()
+		}
+		return
+	}
+
+	// write from version store to multiversion stores
+	for _, v := range task.VersionStores {
+		v.WriteToMultiVersionStore()
+	}
+	s.publishConflictKeys(task)
+
+	s.blockGas.record(task.Index, resp)
+	span.SetAttributes(attribute.Int64("gasUsed", resp.GasUsed))
+	if resp.GasUsed > 0 {
+		task.GasEstimate = uint64(resp.GasUsed)
+	}
+	task.Status = statusExecuted
+	task.Response = &resp
+	task.Suspect = false
+	task.retainFirstResult()
+}
+
+// failsValidation reports whether the validation of the task panicked too many
+// times for its tx to execute again.
+func (s *scheduler) failsValidation(task *deliverTxTask) bool {
+	return s.maxValidationPanics > 0 && task.ValidationPanics >= s.maxValidationPanics
+}
+
+// deliverTxWithAbort runs deliverTx for the task, recovering the panic raised
+// by a version indexed store when the task reads an ESTIMATE. Any other panic
+// is converted into a failed response, as runTx does for panics in message
+// handlers, so that a single tx cannot take down the whole block.
+func (s *scheduler) deliverTxWithAbort(task *deliverTxTask) (resp types.ResponseDeliverTx, aborted bool, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if abt, ok := r.(occ.Abort); ok {
+				task.Abort = &abt
+				aborted = true
+				return
+			}
+			resp = panicResponse(task.Ctx, task.Index, r)
+			panicked = true
+		}
+	}()
+	if failed, resp := s.injectFault(task); failed {
+		return resp, false, false
+	}
+	return s.deliverTx(task.Ctx, task.Request), false, false
+}
+
+// logger returns the logger of the scheduler, whose level can be set apart
+// from the other modules'.
+func logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "tasks")
+}
+
+// panicResponse logs a panic recovered from deliverTx for the tx at index and
+// returns the failed response for it.
+func panicResponse(ctx sdk.Context, index int, r interface{}) types.ResponseDeliverTx {
+	err := sdkerrors.Wrap(
+		sdkerrors.ErrPanic, fmt.Sprintf(
+			"recovered: %v\nstack:\n%v", r, string(debug.Stack()),
+		),
+	)
+	logger(ctx).Error("panic in deliverTx", "index", index, "err", err)
+	telemetry.IncrCounter(1, "scheduler", "panics")
+	return sdkerrors.ResponseDeliverTx(err, 0, 0, false)
+}
+
+// workItem is a unit of work submitted to the worker pool, fn is given the
+// number of the worker running it.
+type workItem struct {
+	fn func(worker int)
+	wg *sync.WaitGroup
+}
+
+// startWorkers starts the long-lived worker pool shared by every block this
+// scheduler processes. The workers are bound to the channel of their pool, so
+// that they stop once it is closed, even after the pool has been replaced.
+func (s *scheduler) startWorkers() {
+	work := make(chan workItem)
+	s.work = work
+	for i := 0; i < s.workers; i++ {
+		worker := i
+		go func() {
+			for item := range work {
+				item.fn(worker)
+				item.wg.Done()
+			}
+		}()
+	}
+}
+
+// runOnWorkers runs fns on the worker pool and returns once they have all
+// finished. If ctx is cancelled, the functions that have not started yet are
+// skipped and the context error is returned once the running ones have
+// finished. The time spent running the functions is added to busy, if set.
+func (s *scheduler) runOnWorkers(ctx sdk.Context, fns []func(worker int), busy *int64) error {
+	work, slots := s.work, s.slots
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		i, fn := i, fn
+		wg.Add(1)
+		item := workItem{
+			fn: func(worker int) {
+				if slots != nil {
+					defer func() { <-slots }()
+				}
+				// the block was cancelled while the work was queued
+				if ctx.Context().Err() != nil {
+					return
+				}
+				start := time.Now()
+				fn(worker)
+				if busy != nil {
+					atomic.AddInt64(busy, int64(time.Since(start)))
+				}
+			},
+			wg: &wg,
+		}
+		// a negative workers value means no limit, every function gets its own goroutine
+		if work == nil {
+			go func() {
+				item.fn(i)
+				wg.Done()
+			}()
+			continue
+		}
+		// an adaptive pool only runs as many functions at once as the block has workers
+		if slots != nil {
+			select {
+			case <-ctx.Context().Done():
+				wg.Done()
+				wg.Wait()
+				return ctx.Context().Err()
+			case slots <- struct{}{}:
+			}
+		}
+		select {
+		case <-ctx.Context().Done():
+			wg.Done()
+			wg.Wait()
+			return ctx.Context().Err()
+		case work <- item:
+		}
+	}
+	wg.Wait()
+	// work dequeued after a cancellation was skipped
+	return ctx.Context().Err()
+}
+
+// executionOrder returns the order in which the tasks are handed to the
+// workers. With a gas estimator the most expensive tasks go first, ties keep
+// index order; otherwise tasks run in index order.
+func (s *scheduler) executionOrder(tasks []*deliverTxTask) []*deliverTxTask {
+	if s.gasEstimator == nil {
+		return tasks
+	}
+	ordered := make([]*deliverTxTask, len(tasks))
+	copy(ordered, tasks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].GasEstimate > ordered[j].GasEstimate
+	})
+	return ordered
+}
+
+// executeAll executes all tasks concurrently on the worker pool and returns
+// once they have all finished. Tasks are updated with their status.
+func (s *scheduler) executeAll(ctx sdk.Context, tasks []*deliverTxTask) error {
+	start := time.Now()
+	defer telemetry.ObserveStage(telemetry.StageSchedulerWave, start)
+
+	fns := make([]func(worker int), 0, len(tasks))
+	for _, task := range s.executionOrder(tasks) {
+		task := task
+		fns = append(fns, func(worker int) {
+			s.executeOn(ctx, task, worker)
+			s.validateEarly(ctx, task, worker)
+		})
+	}
+	// total time spent by all workers executing tasks
+	var busy int64
+	if err := s.runOnWorkers(ctx, fns, &busy); err != nil {
+		return err
+	}
+
+	wall := time.Since(start)
+	s.blockStats.busy += time.Duration(atomic.LoadInt64(&busy))
+	s.blockStats.wall += wall
+	idle := time.Duration(s.workersFor(len(tasks)))*wall - time.Duration(atomic.LoadInt64(&busy))
+	telemetry.AddSample(float32(idle.Milliseconds()), "scheduler", "worker_idle_ms")
+	return nil
+}
+
+// workersFor returns the number of workers available to n tasks.
+func (s *scheduler) workersFor(n int) int {
+	// a negative workers value means no limit
+	if s.workers < 0 {
+		return n
+	}
+	return s.workers
+}
+
+// Stats implements Scheduler.
+func (s *scheduler) Stats() Stats {
+	s.statsMtx.Lock()
+	defer s.statsMtx.Unlock()
+	return s.lastStats
+}
+
+// ModeSelectionReason implements Scheduler. It is empty before the first block.
+func (s *scheduler) ModeSelectionReason() string {
+	return s.Stats().ModeReason
+}
+
+// ConflictGraph implements Scheduler.
+func (s *scheduler) ConflictGraph() ConflictGraph {
+	return s.lastConflictGraph
+}
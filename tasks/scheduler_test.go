@@ -0,0 +1,979 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/multiversion"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+type mockDeliverTxFunc func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx
+
+var (
+	testStoreKey     = sdk.NewKVStoreKey("mock")
+	testTransientKey = sdk.NewTransientStoreKey("mock_transient")
+)
+
+func requestList(n int) []types.RequestDeliverTx {
+	tasks := make([]types.RequestDeliverTx, n)
+	for i := 0; i < n; i++ {
+		tasks[i] = types.RequestDeliverTx{
+			Tx: []byte(fmt.Sprintf("%d", i)),
+		}
+	}
+	return tasks
+}
+
+func initTestCtx() sdk.Context {
+	ctx := testutil.DefaultContext(testStoreKey, testTransientKey).WithContext(context.Background())
+	return ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore())
+}
+
+func TestProcessAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		workers       int
+		requests      []types.RequestDeliverTx
+		deliverTxFunc mockDeliverTxFunc
+		assertions    func(t *testing.T, ctx sdk.Context, res []types.ResponseDeliverTx)
+		expectedErr   error
+	}{
+		{
+			name:     "Test every tx accesses same key",
+			workers:  1,
+			requests: requestList(50),
+			deliverTxFunc: func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				// all txs read and write to the same key to maximize conflicts
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				val := kv.Get([]byte("shared"))
+
+				// write to the store with this tx's index
+				kv.Set([]byte("shared"), req.Tx)
+
+				// return what was read from the store (final attempt should be index-1)
+				return types.ResponseDeliverTx{
+					Info: string(val),
+				}
+			},
+			assertions: func(t *testing.T, ctx sdk.Context, res []types.ResponseDeliverTx) {
+				for idx, response := range res {
+					if idx == 0 {
+						require.Equal(t, "", response.Info)
+					} else {
+						// the info is what was read from the kv store by the tx
+						// each tx writes its own index, so the info should be the index of the previous tx
+						require.Equal(t, fmt.Sprintf("%d", idx-1), response.Info)
+					}
+				}
+				// confirm last write made it to the parent store
+				latest := ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("shared"))
+				require.Equal(t, []byte("49"), latest)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "Test every tx accesses same key concurrently",
+			workers:  50,
+			requests: requestList(50),
+			deliverTxFunc: func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				val := kv.Get([]byte("shared"))
+				kv.Set([]byte("shared"), req.Tx)
+				return types.ResponseDeliverTx{
+					Info: string(val),
+				}
+			},
+			assertions: func(t *testing.T, ctx sdk.Context, res []types.ResponseDeliverTx) {
+				// conflicting reads are re-executed until they match sequential execution
+				for idx, response := range res {
+					if idx == 0 {
+						require.Equal(t, "", response.Info)
+					} else {
+						require.Equal(t, fmt.Sprintf("%d", idx-1), response.Info)
+					}
+				}
+				latest := ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("shared"))
+				require.Equal(t, []byte("49"), latest)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:     "Test no overlap txs",
+			workers:  20,
+			requests: requestList(100),
+			deliverTxFunc: func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				// each tx writes to its own key
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				kv.Set(req.Tx, req.Tx)
+				return types.ResponseDeliverTx{
+					Info: string(req.Tx),
+				}
+			},
+			assertions: func(t *testing.T, ctx sdk.Context, res []types.ResponseDeliverTx) {
+				for idx, response := range res {
+					require.Equal(t, strconv.Itoa(idx), response.Info)
+				}
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				for idx := range res {
+					key := []byte(strconv.Itoa(idx))
+					require.Equal(t, key, kv.Get(key))
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(tt.workers, tt.deliverTxFunc)
+			ctx := initTestCtx()
+
+			res, err := s.ProcessAll(ctx, tt.requests)
+			if err != tt.expectedErr {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			} else {
+				require.Len(t, res, len(tt.requests))
+				if tt.assertions != nil {
+					tt.assertions(t, ctx, res)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteTaskAbortsOnEstimate(t *testing.T) {
+	ctx := initTestCtx()
+	s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set([]byte("other"), req.Tx)
+		val := kv.Get([]byte("shared"))
+		return types.ResponseDeliverTx{Info: string(val)}
+	}).(*scheduler)
+	s.initBlock(ctx, 2)
+
+	// tx 0 is being re-executed, so the key it wrote is an estimate
+	s.multiVersionStores[testStoreKey].SetEstimatedWriteset(0, 1, map[string][]byte{"shared": []byte("0")})
+
+	tasks := toTasks(requestList(2))
+	s.executeTask(ctx, tasks[1])
+
+	require.Equal(t, statusAborted, tasks[1].Status)
+	require.Nil(t, tasks[1].Response)
+	require.NotNil(t, tasks[1].Abort)
+	require.Equal(t, 0, tasks[1].Abort.DependentTxIdx)
+	// the writes of the aborted task are published as estimates
+	require.True(t, s.multiVersionStores[testStoreKey].GetLatest([]byte("other")).IsEstimate())
+
+	// the next incarnation runs once tx 0 has written its value
+	s.multiVersionStores[testStoreKey].SetWriteset(0, 1, map[string][]byte{"shared": []byte("0")})
+	tasks[1].Increment()
+	s.executeTask(ctx, tasks[1])
+
+	require.Equal(t, statusExecuted, tasks[1].Status)
+	require.Nil(t, tasks[1].Abort)
+	require.Equal(t, "0", tasks[1].Response.Info)
+	require.Equal(t, []byte("1"), s.multiVersionStores[testStoreKey].GetLatest([]byte("other")).Value())
+}
+
+func TestValidateAllFromWatermark(t *testing.T) {
+	s := NewScheduler(1, nil).(*scheduler)
+	s.initBlock(initTestCtx(), 5)
+	tasks := toTasks(requestList(5))
+	for _, task := range tasks {
+		task.Status = statusExecuted
+	}
+	tasks[1].Status = statusAborted
+	tasks[3].Status = statusAborted
+
+	res, err := s.validateAll(initTestCtx(), tasks, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*deliverTxTask{tasks[1], tasks[3]}, res)
+	require.Equal(t, statusValidated, tasks[0].Status)
+
+	// tasks below the watermark are not revisited, even if their status changed
+	tasks[0].Status = statusAborted
+	tasks[3].Status = statusExecuted
+	res, err = s.validateAll(initTestCtx(), tasks, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*deliverTxTask{tasks[1]}, res)
+	require.Equal(t, statusAborted, tasks[0].Status)
+	require.Equal(t, statusValidated, tasks[3].Status)
+}
+
+func TestProcessAllWithHints(t *testing.T) {
+	var executions int64
+	s := NewScheduler(20, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		atomic.AddInt64(&executions, 1)
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Info: string(val)}
+	})
+	ctx := initTestCtx()
+
+	reqs := requestList(20)
+	hints := make([][]acltypes.AccessOperation, len(reqs))
+	for i := range hints {
+		hints[i] = []acltypes.AccessOperation{{
+			AccessType:         acltypes.AccessType_WRITE,
+			ResourceType:       acltypes.ResourceType_KV,
+			IdentifierTemplate: "shared",
+		}}
+	}
+
+	res, err := s.ProcessAllWithHints(ctx, reqs, hints)
+	require.NoError(t, err)
+	for idx, response := range res {
+		if idx == 0 {
+			require.Equal(t, "", response.Info)
+		} else {
+			require.Equal(t, strconv.Itoa(idx-1), response.Info)
+		}
+	}
+	// the declared conflicts are ordered up front, so nothing re-executes
+	require.Equal(t, int64(len(reqs)), atomic.LoadInt64(&executions))
+	require.Equal(t, []byte("19"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("shared")))
+}
+
+func TestProcessAllSequentialFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "max rounds", opts: []Option{WithMaxRounds(1)}},
+		{name: "max incarnation", opts: []Option{WithMaxIncarnation(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(50, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				val := kv.Get([]byte("shared"))
+				kv.Set([]byte("shared"), req.Tx)
+				return types.ResponseDeliverTx{Info: string(val)}
+			}, tt.opts...)
+			ctx := initTestCtx()
+
+			res, err := s.ProcessAll(ctx, requestList(50))
+			require.NoError(t, err)
+			for idx, response := range res {
+				if idx == 0 {
+					require.Equal(t, "", response.Info)
+				} else {
+					require.Equal(t, strconv.Itoa(idx-1), response.Info)
+				}
+			}
+			require.Equal(t, []byte("49"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("shared")))
+		})
+	}
+}
+
+func TestProcessAllMaxIncarnationsExceeded(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		if string(req.Tx) == "3" {
+			panic(occ.NewTimeoutAbort())
+		}
+		return types.ResponseDeliverTx{}
+	}, WithMaxIncarnation(2))
+
+	res, err := s.ProcessAll(initTestCtx(), requestList(5))
+	require.Nil(t, res)
+	require.ErrorIs(t, err, occ.ErrMaxIncarnationsExceeded)
+	codespace, code, _ := sdkerrors.ABCIInfo(err, false)
+	require.Equal(t, occ.Codespace, codespace)
+	require.Equal(t, uint32(2), code)
+	var incomplete *IncompleteError
+	require.ErrorAs(t, err, &incomplete)
+	require.Equal(t, []int{3}, incomplete.Pending)
+}
+
+func TestExceedsLimits(t *testing.T) {
+	s := NewScheduler(1, nil, WithMaxIncarnation(3), WithMaxRounds(5)).(*scheduler)
+	tasks := toTasks(requestList(2))
+
+	require.False(t, s.exceedsLimits(10, nil))
+	require.False(t, s.exceedsLimits(1, tasks))
+	require.True(t, s.exceedsLimits(5, tasks))
+
+	tasks[1].Incarnation = 2
+	require.True(t, s.exceedsLimits(1, tasks))
+
+	// a cap of zero disables the limit
+	s = NewScheduler(1, nil, WithMaxIncarnation(0), WithMaxRounds(0)).(*scheduler)
+	require.False(t, s.exceedsLimits(1000, tasks))
+}
+
+func TestProcessAllRecoversPanics(t *testing.T) {
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set(req.Tx, req.Tx)
+		if string(req.Tx) == "3" {
+			panic("deliverTx failed")
+		}
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	})
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(10))
+	require.NoError(t, err)
+	require.Len(t, res, 10)
+
+	kv := ctx.MultiStore().GetKVStore(testStoreKey)
+	for idx, response := range res {
+		key := []byte(strconv.Itoa(idx))
+		if idx == 3 {
+			// the panic fails the tx and discards its writes
+			require.Equal(t, sdkerrors.ErrPanic.ABCICode(), response.Code)
+			require.Equal(t, sdkerrors.ErrPanic.Codespace(), response.Codespace)
+			require.Nil(t, kv.Get(key))
+			continue
+		}
+		require.Equal(t, uint32(0), response.Code)
+		require.Equal(t, key, kv.Get(key))
+	}
+}
+
+func TestProcessAllRecoversValidationPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "concurrent"},
+		{name: "sequential fallback", opts: []Option{WithMaxRounds(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var executions int64
+			s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				// only the validation of tx 3 consults the detector on the key it panics on
+				if string(req.Tx) == "3" {
+					atomic.AddInt64(&executions, 1)
+					kv.Get([]byte("boom"))
+				}
+				kv.Set(req.Tx, req.Tx)
+				return types.ResponseDeliverTx{}
+			}, append([]Option{WithConflictDetector(panicDetector("boom")), WithMaxValidationPanics(2)}, tt.opts...)...)
+			ctx := initTestCtx()
+
+			res, err := s.ProcessAll(ctx, requestList(5))
+			require.NoError(t, err)
+			kv := ctx.MultiStore().GetKVStore(testStoreKey)
+			for i, r := range res {
+				if i == 3 {
+					// the tx is failed, without writes, once its validation panicked twice
+					require.Equal(t, occ.Codespace, r.Codespace)
+					require.Equal(t, occ.ErrValidationPanic.ABCICode(), r.Code)
+					require.Nil(t, kv.Get([]byte("3")))
+					continue
+				}
+				require.Equal(t, uint32(0), r.Code)
+				require.Equal(t, []byte(strconv.Itoa(i)), kv.Get([]byte(strconv.Itoa(i))))
+			}
+			require.Equal(t, int64(2), atomic.LoadInt64(&executions))
+		})
+	}
+}
+
+func TestSchedulerReusesWorkersAcrossBlocks(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	})
+	goroutines := runtime.NumGoroutine()
+
+	for block := 0; block < 3; block++ {
+		ctx := initTestCtx()
+		res, err := s.ProcessAll(ctx, requestList(20))
+		require.NoError(t, err)
+		for idx, response := range res {
+			require.Equal(t, strconv.Itoa(idx), response.Info)
+		}
+	}
+	// the blocks ran on the pool started by NewScheduler
+	require.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
+
+func TestSchedulerSetWorkers(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	})
+	processBlock := func() {
+		res, err := s.ProcessAll(initTestCtx(), requestList(20))
+		require.NoError(t, err)
+		for idx, response := range res {
+			require.Equal(t, strconv.Itoa(idx), response.Info)
+		}
+	}
+	processBlock()
+	require.Equal(t, 4, s.Stats().Workers)
+
+	// the workers change from the next block on
+	s.SetWorkers(2)
+	require.Equal(t, 4, s.Stats().Workers)
+	processBlock()
+	require.Equal(t, 2, s.Stats().Workers)
+
+	s.SetWorkers(-1)
+	processBlock()
+	require.Equal(t, 20, s.Stats().Workers)
+	s.SetWorkers(0)
+	processBlock()
+	require.Equal(t, runtime.NumCPU(), s.Stats().Workers)
+}
+
+func TestSchedulerSetWorkersConcurrently(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	})
+	s.SetWorkers(2)
+	_, err := s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+	goroutines := runtime.NumGoroutine()
+
+	// the workers are changed while the blocks are processed
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			s.SetWorkers(i%4 + 1)
+			_ = s.Stats()
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	for block := 0; block < 20; block++ {
+		res, err := s.ProcessAll(initTestCtx(), requestList(10))
+		require.NoError(t, err)
+		for idx, response := range res {
+			require.Equal(t, strconv.Itoa(idx), response.Info)
+		}
+	}
+	<-done
+	s.SetWorkers(2)
+	_, err = s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+
+	// the workers of the replaced pools stopped
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
+
+func TestProcessAllCancellation(t *testing.T) {
+	var executions int64
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		atomic.AddInt64(&executions, 1)
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	}
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		atomic.StoreInt64(&executions, 0)
+		s := NewScheduler(4, deliverTx)
+		ctx := initTestCtx()
+
+		res, err := s.ProcessAllWithDeadline(ctx, requestList(10), time.Now().Add(-time.Second))
+		require.Nil(t, res)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		var incomplete *IncompleteError
+		require.ErrorAs(t, err, &incomplete)
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, incomplete.Pending)
+		require.Equal(t, int64(0), atomic.LoadInt64(&executions))
+	})
+
+	t.Run("cancelled during execution", func(t *testing.T) {
+		atomic.StoreInt64(&executions, 0)
+		goCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+			if string(req.Tx) == "4" {
+				cancel()
+			}
+			return deliverTx(ctx, req)
+		})
+		ctx := initTestCtx()
+
+		res, err := s.ProcessAll(ctx.WithContext(goCtx), requestList(10))
+		require.Nil(t, res)
+		require.ErrorIs(t, err, context.Canceled)
+		var incomplete *IncompleteError
+		require.ErrorAs(t, err, &incomplete)
+		require.Len(t, incomplete.Pending, 10)
+		// the remaining txs never started, and nothing reached the parent store
+		require.Equal(t, int64(5), atomic.LoadInt64(&executions))
+		require.Nil(t, ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("0")))
+	})
+}
+
+func TestValidateAllReadsets(t *testing.T) {
+	s := NewScheduler(4, nil).(*scheduler)
+	ctx := initTestCtx()
+	s.initBlock(ctx, 20)
+	mvs := s.multiVersionStores[testStoreKey]
+
+	tasks := toTasks(requestList(20))
+	for _, task := range tasks {
+		key := strconv.Itoa(task.Index)
+		mvs.SetWriteset(task.Index, 0, multiversion.WriteSet{key: []byte(key)})
+		task.Status = statusExecuted
+		task.WriteSet = map[sdk.StoreKey]multiversion.WriteSet{testStoreKey: {key: []byte(key)}}
+		if task.Index == 0 {
+			continue
+		}
+		// every tx reads what the previous tx wrote, odd txs read a stale value
+		prev := strconv.Itoa(task.Index - 1)
+		read := []byte(prev)
+		if task.Index%2 == 1 {
+			read = nil
+		}
+		task.ReadSet = map[sdk.StoreKey]multiversion.ReadSet{testStoreKey: {prev: read}}
+	}
+
+	res, err := s.validateAll(ctx, tasks, 0)
+	require.NoError(t, err)
+	require.Len(t, res, 10)
+	for i, task := range res {
+		require.Equal(t, 2*i+1, task.Index)
+		require.Equal(t, statusAborted, task.Status)
+		// invalid txs have their writes replaced by estimates
+		require.True(t, mvs.GetLatestBeforeIndex(task.Index+1, []byte(strconv.Itoa(task.Index))).IsEstimate())
+	}
+	for _, task := range tasks {
+		if task.Index%2 == 0 {
+			require.Equal(t, statusValidated, task.Status)
+		}
+	}
+}
+
+func TestProcessAllWithGasEstimator(t *testing.T) {
+	var order []string
+	s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		order = append(order, string(req.Tx))
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	}, WithGasEstimator(func(req types.RequestDeliverTx) uint64 {
+		// txs with an even index are expensive
+		idx, err := strconv.Atoi(string(req.Tx))
+		require.NoError(t, err)
+		return uint64(1 + (idx+1)%2)
+	}))
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(6))
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "2", "4", "1", "3", "5"}, order)
+	// responses are still returned in request order
+	for idx, response := range res {
+		require.Equal(t, strconv.Itoa(idx), response.Info)
+	}
+}
+
+func TestProcessAllSharesTxCache(t *testing.T) {
+	var mtx sync.Mutex
+	caches := make(map[*sdk.TxCache]struct{})
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		mtx.Lock()
+		caches[ctx.TxCache()] = struct{}{}
+		mtx.Unlock()
+		return types.ResponseDeliverTx{}
+	})
+
+	_, err := s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+	// every execution of the block sees the same cache
+	require.Len(t, caches, 1)
+	for cache := range caches {
+		require.NotNil(t, cache)
+	}
+}
+
+func TestProcessAllWithTaskTimeout(t *testing.T) {
+	var stuck int32 = 1
+	s := NewScheduler(2, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		// the first execution of tx 0 spins until it is interrupted
+		if string(req.Tx) == "0" && atomic.CompareAndSwapInt32(&stuck, 1, 0) {
+			for {
+				kv.Get([]byte("spin"))
+				time.Sleep(time.Millisecond)
+			}
+		}
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{Info: string(req.Tx)}
+	}, WithTaskTimeout(20*time.Millisecond))
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(5))
+	require.NoError(t, err)
+	for i, r := range res {
+		require.Equal(t, strconv.Itoa(i), r.Info)
+		require.Equal(t, []byte(strconv.Itoa(i)), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte(strconv.Itoa(i))))
+	}
+	require.Equal(t, 1, s.Stats().Timeouts)
+	require.Equal(t, 2, s.Stats().Rounds)
+}
+
+func TestProcessAllIsolatesTxContexts(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		i, _ := strconv.Atoi(string(req.Tx))
+		ctx.GasMeter().ConsumeGas(uint64(i+1), "test")
+		ctx.EventManager().EmitEvent(sdk.NewEvent("tx", sdk.NewAttribute("index", string(req.Tx))))
+		// only the events of this tx are visible
+		require.Len(t, ctx.EventManager().Events(), 1)
+		return types.ResponseDeliverTx{GasUsed: int64(ctx.GasMeter().GasConsumed())}
+	})
+	ctx := initTestCtx().WithEventManager(sdk.NewEventManager())
+
+	res, err := s.ProcessAll(ctx, requestList(10))
+	require.NoError(t, err)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 10)
+	for i, r := range res {
+		require.Equal(t, int64(i+1), r.GasUsed)
+		require.Equal(t, strconv.Itoa(i), string(events[i].Attributes[0].Value))
+	}
+}
+
+func TestProcessAllGasMatchesSequential(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// the gas of the reads depends on the values written by the other txs
+		kv := ctx.KVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), append(append([]byte{}, val...), req.Tx...))
+		kv.Set(append([]byte("tx"), req.Tx...), append([]byte("seen:"), val...))
+		iter := kv.Iterator([]byte("tx"), nil)
+		for ; iter.Valid(); iter.Next() {
+			iter.Value()
+		}
+		iter.Close()
+		return types.ResponseDeliverTx{GasUsed: int64(ctx.GasMeter().GasConsumed())}
+	}
+
+	ctx := initTestCtx()
+	expected := make([]types.ResponseDeliverTx, 20)
+	for i, req := range requestList(20) {
+		expected[i] = deliverTx(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), req)
+	}
+
+	s := NewScheduler(4, deliverTx)
+	for i := 0; i < 10; i++ {
+		res, err := s.ProcessAll(initTestCtx(), requestList(20))
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+	}
+}
+
+func TestProcessAllWithCallback(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "concurrent"},
+		{name: "sequential fallback", opts: []Option{WithMaxRounds(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				val := kv.Get([]byte("shared"))
+				kv.Set([]byte("shared"), req.Tx)
+				return types.ResponseDeliverTx{Info: string(val)}
+			}, tt.opts...)
+
+			var indexes []int
+			var streamed []types.ResponseDeliverTx
+			res, err := s.ProcessAllWithCallback(initTestCtx(), requestList(20), func(index int, res types.ResponseDeliverTx) {
+				indexes = append(indexes, index)
+				streamed = append(streamed, res)
+			})
+			require.NoError(t, err)
+			for i := range indexes {
+				require.Equal(t, i, indexes[i])
+			}
+			require.Equal(t, res, streamed)
+		})
+	}
+}
+
+func TestProcessAllWithIdenticalTxs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "concurrent"},
+		{name: "sequential fallback", opts: []Option{WithMaxRounds(1)}},
+		{name: "result cache", opts: []Option{WithResultCache(10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				// every tx increments the counter, and records the value it set
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+				value := []byte(strconv.Itoa(counter + 1))
+				kv.Set([]byte("counter"), value)
+				kv.Set(req.Tx, value)
+				return types.ResponseDeliverTx{Data: value}
+			}, tt.opts...)
+			// the same tx twice, then another one
+			reqs := []types.RequestDeliverTx{{Tx: []byte("dup")}, {Tx: []byte("dup")}, {Tx: []byte("other")}}
+
+			for i := 0; i < 2; i++ {
+				ctx := initTestCtx()
+				res, err := s.ProcessAll(ctx, reqs)
+				require.NoError(t, err)
+				// each copy executed as a tx of its own, in index order
+				require.Len(t, res, 3)
+				require.Equal(t, []byte("1"), res[0].Data)
+				require.Equal(t, []byte("2"), res[1].Data)
+				require.Equal(t, []byte("3"), res[2].Data)
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				require.Equal(t, []byte("3"), kv.Get([]byte("counter")))
+				// the write of the second copy shadows the one of the first
+				require.Equal(t, []byte("2"), kv.Get([]byte("dup")))
+			}
+		})
+	}
+}
+
+func TestProcessAllDetectsPhantomReads(t *testing.T) {
+	// the first executions all iterate before any of them writes, so every tx
+	// but the first misses keys inserted by lower-indexed txs
+	var iterated sync.WaitGroup
+	iterated.Add(10)
+	var calls int32
+	s := NewScheduler(10, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		iter := sdk.KVStorePrefixIterator(kv, []byte("prefix/"))
+		count := 0
+		for ; iter.Valid(); iter.Next() {
+			count++
+		}
+		iter.Close()
+		if atomic.AddInt32(&calls, 1) <= 10 {
+			iterated.Done()
+			iterated.Wait()
+		}
+		kv.Set(append([]byte("prefix/"), req.Tx...), req.Tx)
+		return types.ResponseDeliverTx{Info: strconv.Itoa(count)}
+	})
+
+	res, err := s.ProcessAll(initTestCtx(), requestList(10))
+	require.NoError(t, err)
+	for i, r := range res {
+		require.Equal(t, strconv.Itoa(i), r.Info)
+	}
+}
+
+func TestProcessAllMatchesSequentialAppHash(t *testing.T) {
+	storeKeys := []sdk.StoreKey{sdk.NewKVStoreKey("bank"), sdk.NewKVStoreKey("staking")}
+	newCommitStore := func() sdk.CommitMultiStore {
+		cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+		for _, key := range storeKeys {
+			cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+		}
+		require.NoError(t, cms.LoadLatestVersion())
+		return cms
+	}
+	// txs move a balance around shared keys, delete some of them and iterate
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		i, _ := strconv.Atoi(string(req.Tx))
+		bank := ctx.MultiStore().GetKVStore(storeKeys[0])
+		staking := ctx.MultiStore().GetKVStore(storeKeys[1])
+		from, to := []byte(fmt.Sprintf("acc%d", i%5)), []byte(fmt.Sprintf("acc%d", (i+1)%5))
+		// values read from the store must not be modified in place
+		balance := append(append([]byte{}, bank.Get(from)...), req.Tx...)
+		bank.Set(to, balance)
+		if i%7 == 0 {
+			bank.Delete(from)
+		}
+		count := 0
+		iter := staking.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			count++
+		}
+		iter.Close()
+		staking.Set([]byte(fmt.Sprintf("val%d", i%3)), []byte(strconv.Itoa(count)))
+		return types.ResponseDeliverTx{Info: strconv.Itoa(count)}
+	}
+	header := tmproto.Header{Height: 1}
+	reqs := requestList(30)
+
+	sequential := newCommitStore()
+	seqCtx := sdk.NewContext(sequential.CacheMultiStore(), header, false, log.NewNopLogger())
+	for _, req := range reqs {
+		ms := seqCtx.MultiStore().CacheMultiStore()
+		deliverTx(seqCtx.WithMultiStore(ms), req)
+		ms.Write()
+	}
+	seqCtx.MultiStore().(sdk.CacheMultiStore).Write()
+	expected := sequential.Commit(true)
+
+	for _, workers := range []int{1, 10} {
+		concurrent := newCommitStore()
+		ctx := sdk.NewContext(concurrent.CacheMultiStore(), header, false, log.NewNopLogger())
+		_, err := NewScheduler(workers, deliverTx).ProcessAll(ctx, reqs)
+		require.NoError(t, err)
+		ctx.MultiStore().(sdk.CacheMultiStore).Write()
+		require.Equal(t, expected, concurrent.Commit(true), "workers=%d", workers)
+	}
+}
+
+func TestProcessAllChargesValidatedIncarnations(t *testing.T) {
+	bankKey := sdk.NewKVStoreKey("bank")
+	newCommitStore := func() sdk.CommitMultiStore {
+		cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+		cms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, nil)
+		require.NoError(t, cms.LoadLatestVersion())
+		cms.GetKVStore(bankKey).Set([]byte("payer"), []byte("100"))
+		cms.Commit(true)
+		return cms
+	}
+	// each tx pays a fee of its index plus one into the fee collector's deferred
+	// balance of its index, as the ante handler does, until the payer runs out
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		i, _ := strconv.Atoi(string(req.Tx))
+		bank := ctx.MultiStore().GetKVStore(bankKey)
+		fee := int64(i + 1)
+		balance, _ := strconv.ParseInt(string(bank.Get([]byte("payer"))), 10, 64)
+		if balance < fee {
+			return types.ResponseDeliverTx{Code: sdkerrors.ErrInsufficientFunds.ABCICode(), GasUsed: 10}
+		}
+		bank.Set([]byte("payer"), []byte(strconv.FormatInt(balance-fee, 10)))
+		deferred := []byte(fmt.Sprintf("fee_collector/%d", ctx.TxIndex()))
+		collected, _ := strconv.ParseInt(string(bank.Get(deferred)), 10, 64)
+		bank.Set(deferred, []byte(strconv.FormatInt(collected+fee, 10)))
+		ctx.TxCharges().AddFees(sdk.NewCoins(sdk.NewInt64Coin("usei", fee)))
+		return types.ResponseDeliverTx{GasUsed: 100 + fee}
+	}
+	header := tmproto.Header{Height: 2}
+	reqs := requestList(20)
+
+	sequential := newCommitStore()
+	seqCtx := sdk.NewContext(sequential.CacheMultiStore(), header, false, log.NewNopLogger())
+	var expectedRes []types.ResponseDeliverTx
+	var expectedGas int64
+	expectedFees := sdk.NewCoins()
+	for i, req := range reqs {
+		ms := seqCtx.MultiStore().CacheMultiStore()
+		charges := sdk.NewTxCharges()
+		res := deliverTx(txContext(seqCtx, i).WithMultiStore(ms).WithTxCharges(charges), req)
+		ms.Write()
+		expectedRes = append(expectedRes, res)
+		expectedGas += res.GasUsed
+		expectedFees = expectedFees.Add(charges.Fees()...)
+	}
+	seqCtx.MultiStore().(sdk.CacheMultiStore).Write()
+	expected := sequential.Commit(true)
+
+	// the txs executing last in index order first, every tx but the last one
+	// reads a stale balance and is executed again
+	s := NewScheduler(1, deliverTx, WithGasEstimator(func(req types.RequestDeliverTx) uint64 {
+		i, _ := strconv.Atoi(string(req.Tx))
+		return uint64(i)
+	}))
+	concurrent := newCommitStore()
+	ctx := sdk.NewContext(concurrent.CacheMultiStore(), header, false, log.NewNopLogger())
+	res, err := s.ProcessAll(ctx, reqs)
+	require.NoError(t, err)
+	ctx.MultiStore().(sdk.CacheMultiStore).Write()
+	require.Equal(t, expectedRes, res)
+	require.Equal(t, expected, concurrent.Commit(true))
+
+	stats := s.Stats()
+	require.Greater(t, stats.Incarnations, len(reqs))
+	require.Equal(t, expectedGas, stats.GasUsed)
+	require.Equal(t, expectedFees, stats.Fees)
+	require.False(t, stats.DiscardedFees.IsZero())
+	require.Greater(t, stats.DiscardedGas, int64(0))
+}
+
+func TestProcessAllWithMaxValidationKeys(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		// a read set larger than the cap
+		for i := 0; i < 50; i++ {
+			kv.Get([]byte(fmt.Sprintf("read/%d", i)))
+		}
+		val := kv.Get([]byte("shared"))
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Info: string(val)}
+	}
+	tests := []struct {
+		name string
+		opts []Option
+		// each round only validates the first task it executes, the others
+		// abort or fail validation
+		invalid int
+	}{
+		{name: "concurrent", invalid: 9 + 8 + 7 + 6 + 5 + 4 + 3 + 2 + 1},
+		{name: "sequential fallback", opts: []Option{WithMaxRounds(2)}, invalid: 9 + 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(4, deliverTx, append([]Option{WithMaxValidationKeys(10)}, tt.opts...)...)
+			ctx := initTestCtx()
+			res, err := s.ProcessAll(ctx, requestList(10))
+			require.NoError(t, err)
+			for i, r := range res {
+				if i == 0 {
+					require.Empty(t, r.Info)
+					continue
+				}
+				require.Equal(t, strconv.Itoa(i-1), r.Info)
+			}
+			require.Equal(t, []byte("9"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("shared")))
+			require.Equal(t, tt.invalid, s.Stats().ValidationFailures+s.Stats().Aborts)
+		})
+	}
+}
+
+func TestProcessAllWithAllTxsFailing(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// like the handlers, the tx writes to a branch it drops as it fails
+		branch := ctx.MultiStore().CacheMultiStore()
+		kv := branch.GetKVStore(testStoreKey)
+		counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+		kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+		kv.Set(req.Tx, req.Tx)
+		return sdkerrors.ResponseDeliverTx(sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "tx %s", req.Tx), 10, 5, false)
+	})
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	require.Len(t, res, 20)
+	for i, resp := range res {
+		require.Equal(t, sdkerrors.ErrInsufficientFunds.ABCICode(), resp.Code, "tx %d", i)
+		require.Contains(t, resp.Log, fmt.Sprintf("tx %d", i))
+		require.Equal(t, int64(5), resp.GasUsed)
+	}
+	// nothing was written, so nothing was re-executed
+	require.Equal(t, 1, s.Stats().Rounds)
+	require.Equal(t, 20, s.Stats().Incarnations)
+	require.Equal(t, ModeReasonConcurrent, s.Stats().ModeReason)
+	require.NoError(t, s.AssertWriteset(map[string][]byte{}))
+	kv := ctx.MultiStore().GetKVStore(testStoreKey)
+	require.Nil(t, kv.Get([]byte("counter")))
+	require.Nil(t, kv.Get([]byte("0")))
+}
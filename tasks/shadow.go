@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithShadowMode makes the scheduler also execute every block sequentially,
+// on a branch of the state that is discarded, and compare the responses and
+// the writes of both executions. Divergences are logged and counted in the
+// scheduler_shadow_divergences metric; the concurrent results are always the
+// ones returned and committed.
+//
+// Shadow mode doubles the cost of every block and the side effects of
+// deliverTx outside of the stores, such as streaming hooks, so it is meant for
+// canary nodes only.
+func WithShadowMode() Option {
+	return func(s *scheduler) {
+		s.shadowMode = true
+	}
+}
+
+// shadowResult is the outcome of the sequential execution of a block.
+type shadowResult struct {
+	responses []types.ResponseDeliverTx
+	// final value of every key written, by store name then key, nil for deletes
+	writes map[string]map[string][]byte
+}
+
+// writeRecorder records the final value of every key written to the stores it
+// listens to.
+type writeRecorder struct {
+	writes map[string]map[string][]byte
+}
+
+func (wr *writeRecorder) OnWrite(storeKey sdk.StoreKey, key []byte, value []byte, delete bool) error {
+	writes, ok := wr.writes[storeKey.Name()]
+	if !ok {
+		writes = make(map[string][]byte)
+		wr.writes[storeKey.Name()] = writes
+	}
+	if delete {
+		value = nil
+	}
+	writes[string(key)] = value
+	return nil
+}
+
+// runShadow executes reqs one after the other on a branch of ctx's multi-store.
+func (s *scheduler) runShadow(ctx sdk.Context, reqs []types.RequestDeliverTx) *shadowResult {
+	recorder := &writeRecorder{writes: make(map[string]map[string][]byte)}
+	cms := ctx.MultiStore().CacheMultiStore()
+	ms := cms.SetKVStores(func(k sdk.StoreKey, kvs sdk.KVStore) sdk.CacheWrap {
+		return kvs.CacheWrapWithListeners(k, []storetypes.WriteListener{recorder})
+	})
+
+	// the txs share a copy of the block gas meter, as they would sequentially
+	bg := newBlockGas(ctx, 0)
+	blockGasMeter := bg.meter(bg.base)
+	responses := make([]types.ResponseDeliverTx, len(reqs))
+	// the txs share a branch of the caches, discarded like the state
+	ctx = s.branchCaches(ctx)
+	for i, req := range reqs {
+		txCtx := txContext(ctx.WithMultiStore(ms), i).WithBlockGasMeter(blockGasMeter)
+		responses[i] = s.shadowDeliverTx(txCtx, i, req)
+	}
+	// flush the writes through the listeners into the discarded branch
+	cms.Write()
+	return &shadowResult{responses: responses, writes: recorder.writes}
+}
+
+func (s *scheduler) shadowDeliverTx(ctx sdk.Context, index int, req types.RequestDeliverTx) (resp types.ResponseDeliverTx) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp = panicResponse(ctx, index, r)
+		}
+	}()
+	return s.deliverTx(ctx, req)
+}
+
+// compareShadow reports every difference between the sequential execution and
+// the concurrent execution of the tasks, and returns the number of differences.
+func (s *scheduler) compareShadow(ctx sdk.Context, tasks []*deliverTxTask, shadow *shadowResult) int {
+	divergences := 0
+	for _, t := range tasks {
+		if !sameResponse(*t.Response, shadow.responses[t.Index]) {
+			divergences++
+			logger(ctx).Error(
+				"scheduler response diverges from sequential execution",
+				"index", t.Index,
+				"code", t.Response.Code, "expectedCode", shadow.responses[t.Index].Code,
+				"gasUsed", t.Response.GasUsed, "expectedGasUsed", shadow.responses[t.Index].GasUsed,
+			)
+		}
+	}
+
+	writes := s.concurrentWrites()
+	for _, storeName := range unionKeys(writes, shadow.writes) {
+		actual, expected := writes[storeName], shadow.writes[storeName]
+		for _, key := range unionKeys(actual, expected) {
+			actualValue, actualOk := actual[key]
+			expectedValue, expectedOk := expected[key]
+			if actualOk == expectedOk && bytes.Equal(actualValue, expectedValue) {
+				continue
+			}
+			divergences++
+			logger(ctx).Error(
+				"scheduler write diverges from sequential execution",
+				"store", storeName, "key", []byte(key),
+				"value", actualValue, "expectedValue", expectedValue,
+			)
+		}
+	}
+
+	if divergences > 0 {
+		telemetry.IncrCounter(float32(divergences), "scheduler", "shadow", "divergences")
+	}
+	return divergences
+}
+
+// concurrentWrites returns the final value of every key written by the tasks.
+func (s *scheduler) concurrentWrites() map[string]map[string][]byte {
+	res := make(map[string]map[string][]byte)
+	for storeName, writes := range s.flushedWrites {
+		res[storeName] = make(map[string][]byte, len(writes))
+		for key, value := range writes {
+			res[storeName][key] = value
+		}
+	}
+	for storeKey, mvs := range s.multiVersionStores {
+		for _, keys := range mvs.GetAllWritesetKeys() {
+			for _, key := range keys {
+				latest := mvs.GetLatest([]byte(key))
+				if latest == nil {
+					continue
+				}
+				if _, ok := res[storeKey.Name()]; !ok {
+					res[storeKey.Name()] = make(map[string][]byte)
+				}
+				var value []byte
+				if !latest.IsDeleted() {
+					value = latest.Value()
+				}
+				res[storeKey.Name()][key] = value
+			}
+		}
+	}
+	return res
+}
+
+// sameResponse returns whether a and b are the same response. The event of
+// the resources used by the tx is left out, as it counts the executions of the
+// tx, which differ from one execution mode to the other.
+func sameResponse(a, b types.ResponseDeliverTx) bool {
+	return a.Code == b.Code &&
+		a.Codespace == b.Codespace &&
+		bytes.Equal(a.Data, b.Data) &&
+		a.GasWanted == b.GasWanted &&
+		a.GasUsed == b.GasUsed &&
+		reflect.DeepEqual(withoutResourceEvents(a.Events), withoutResourceEvents(b.Events))
+}
+
+func withoutResourceEvents(events []types.Event) []types.Event {
+	res := make([]types.Event, 0, len(events))
+	for _, e := range events {
+		if e.Type != sdk.EventTypeTxResources {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// unionKeys returns the sorted union of the keys of a and b.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestShadowMode(t *testing.T) {
+	t.Run("matching executions", func(t *testing.T) {
+		s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+			kv := ctx.MultiStore().GetKVStore(testStoreKey)
+			val := kv.Get([]byte("shared"))
+			kv.Set([]byte("shared"), req.Tx)
+			kv.Delete([]byte("deleted"))
+			return types.ResponseDeliverTx{Data: val, GasUsed: 1}
+		}, WithShadowMode())
+		ctx := initTestCtx()
+		ctx.MultiStore().GetKVStore(testStoreKey).Set([]byte("deleted"), []byte("value"))
+
+		res, err := s.ProcessAll(ctx, requestList(10))
+		require.NoError(t, err)
+		require.Equal(t, 0, s.Stats().ShadowDivergences)
+		require.Equal(t, []byte("8"), res[9].Data)
+		// only the concurrent execution reaches the stores
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		require.Equal(t, []byte("9"), kv.Get([]byte("shared")))
+		require.Nil(t, kv.Get([]byte("deleted")))
+	})
+
+	t.Run("diverging executions", func(t *testing.T) {
+		var calls int64
+		s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+			// every call writes a different value, so both executions disagree
+			call := atomic.AddInt64(&calls, 1)
+			ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, []byte(strconv.FormatInt(call, 10)))
+			return types.ResponseDeliverTx{GasUsed: call}
+		}, WithShadowMode())
+		ctx := initTestCtx()
+
+		_, err := s.ProcessAll(ctx, requestList(3))
+		require.NoError(t, err)
+		// three responses and three writes differ
+		require.Equal(t, 6, s.Stats().ShadowDivergences)
+		// the sequential run happened first, the concurrent results are committed
+		require.Equal(t, []byte("4"), ctx.MultiStore().GetKVStore(testStoreKey).Get([]byte("0")))
+	})
+}
@@ -0,0 +1,309 @@
+// Package simulator runs synthetic blocks through the scheduler to measure its
+// speedup over sequential execution for a given workload.
+//
+// Each tx of a synthetic block writes a key of its own and, with the
+// configured conflict rate, increments one of a few hot keys, which is what
+// makes txs conflict. The work of a tx is proportional to the gas it uses,
+// drawn from the configured distribution: every GasPerHash gas costs a SHA-256
+// hash. The responses and the state of every concurrent run are checked
+// against the sequential execution of the block.
+package simulator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/tasks"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasPerHash is the gas a synthetic tx uses for each SHA-256 hash it computes.
+const GasPerHash = 100
+
+// GasDistribution is the distribution the gas used by the synthetic txs is
+// drawn from.
+type GasDistribution string
+
+const (
+	// GasConstant gives every tx the mean gas.
+	GasConstant GasDistribution = "constant"
+	// GasUniform draws the gas uniformly between 0 and twice the mean.
+	GasUniform GasDistribution = "uniform"
+	// GasExponential draws the gas from an exponential distribution, most txs
+	// being cheap and a few very expensive.
+	GasExponential GasDistribution = "exponential"
+)
+
+// Config is the workload to simulate and the scheduler configurations to run
+// it with.
+type Config struct {
+	// Blocks is the number of blocks to simulate
+	Blocks int
+	// Txs is the number of txs per block
+	Txs int
+	// ConflictRate is the probability of a tx writing a hot key
+	ConflictRate float64
+	// HotKeys is the number of keys the conflicting txs write
+	HotKeys int
+	// GasMean is the mean gas used by a tx
+	GasMean uint64
+	// GasDistribution is the distribution of the gas used by the txs
+	GasDistribution GasDistribution
+	// Workers holds the numbers of workers to run the scheduler with, see
+	// tasks.NewScheduler
+	Workers []int
+	// Seed seeds the generation of the blocks
+	Seed int64
+}
+
+// DefaultConfig returns a workload of mostly independent txs of constant gas.
+func DefaultConfig() Config {
+	return Config{
+		Blocks:          10,
+		Txs:             1000,
+		ConflictRate:    0.1,
+		HotKeys:         10,
+		GasMean:         100000,
+		GasDistribution: GasConstant,
+		Workers:         []int{1, 2, 4, 8, 16},
+		Seed:            1,
+	}
+}
+
+// Validate checks that the workload can be generated.
+func (c Config) Validate() error {
+	switch {
+	case c.Blocks <= 0:
+		return errors.New("the number of blocks must be positive")
+	case c.Txs <= 0:
+		return errors.New("the number of txs must be positive")
+	case c.ConflictRate < 0 || c.ConflictRate > 1:
+		return fmt.Errorf("the conflict rate must be between 0 and 1, got %v", c.ConflictRate)
+	case c.ConflictRate > 0 && c.HotKeys <= 0:
+		return errors.New("the number of hot keys must be positive")
+	case len(c.Workers) == 0:
+		return errors.New("no number of workers to run the scheduler with")
+	}
+	switch c.GasDistribution {
+	case GasConstant, GasUniform, GasExponential:
+		return nil
+	default:
+		return fmt.Errorf("unknown gas distribution %s", c.GasDistribution)
+	}
+}
+
+// Result is how the scheduler ran the simulated blocks with a number of
+// workers.
+type Result struct {
+	Workers  int
+	Duration time.Duration
+	// Speedup is the duration of the sequential execution divided by Duration
+	Speedup            float64
+	Incarnations       int
+	ValidationFailures int
+	Aborts             int
+}
+
+// Report is the outcome of a simulation.
+type Report struct {
+	Config Config
+	// Sequential is the duration of the sequential execution of the blocks
+	Sequential time.Duration
+	Results    []Result
+}
+
+var storeKey = sdk.NewKVStoreKey("simulator")
+
+// tx is the plan of a synthetic tx.
+type tx struct {
+	id  uint32
+	gas uint64
+	// hotKey is the index of the hot key the tx writes, or -1
+	hotKey int32
+}
+
+func (t tx) encode() []byte {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint32(bz, t.id)
+	binary.BigEndian.PutUint64(bz[4:], t.gas)
+	binary.BigEndian.PutUint32(bz[12:], uint32(t.hotKey))
+	return bz
+}
+
+func decodeTx(bz []byte) tx {
+	return tx{
+		id:     binary.BigEndian.Uint32(bz),
+		gas:    binary.BigEndian.Uint64(bz[4:]),
+		hotKey: int32(binary.BigEndian.Uint32(bz[12:])),
+	}
+}
+
+func hotKey(i int32) []byte {
+	return []byte("hot/" + strconv.Itoa(int(i)))
+}
+
+// deliverTx executes a synthetic tx: it does the work of its gas, writes its
+// own key and increments its hot key, if any, returning the value it read.
+func deliverTx(ctx sdk.Context, req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	t := decodeTx(req.Tx)
+	hash := sha256.Sum256(req.Tx)
+	for i := uint64(0); i < t.gas/GasPerHash; i++ {
+		hash = sha256.Sum256(hash[:])
+	}
+	kv := ctx.MultiStore().GetKVStore(storeKey)
+	kv.Set([]byte("tx/"+strconv.Itoa(int(t.id))), hash[:])
+	res := abci.ResponseDeliverTx{GasWanted: int64(t.gas), GasUsed: int64(t.gas)}
+	if t.hotKey >= 0 {
+		var counter uint64
+		if bz := kv.Get(hotKey(t.hotKey)); bz != nil {
+			counter = binary.BigEndian.Uint64(bz)
+		}
+		res.Info = strconv.FormatUint(counter, 10)
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, counter+1)
+		kv.Set(hotKey(t.hotKey), bz)
+	}
+	return res
+}
+
+// generateBlocks returns the txs of the blocks of the workload.
+func generateBlocks(cfg Config) [][]abci.RequestDeliverTx {
+	r := rand.New(rand.NewSource(cfg.Seed)) //nolint:gosec // the workload only needs to be reproducible
+	blocks := make([][]abci.RequestDeliverTx, cfg.Blocks)
+	var id uint32
+	for i := range blocks {
+		blocks[i] = make([]abci.RequestDeliverTx, cfg.Txs)
+		for j := range blocks[i] {
+			t := tx{id: id, gas: drawGas(r, cfg), hotKey: -1}
+			if r.Float64() < cfg.ConflictRate {
+				t.hotKey = int32(r.Intn(cfg.HotKeys))
+			}
+			blocks[i][j] = abci.RequestDeliverTx{Tx: t.encode()}
+			id++
+		}
+	}
+	return blocks
+}
+
+func drawGas(r *rand.Rand, cfg Config) uint64 {
+	switch cfg.GasDistribution {
+	case GasUniform:
+		return uint64(r.Int63n(int64(2*cfg.GasMean) + 1))
+	case GasExponential:
+		return uint64(r.ExpFloat64() * float64(cfg.GasMean))
+	default:
+		return cfg.GasMean
+	}
+}
+
+// newState returns an empty state to execute blocks on.
+func newState() (storetypes.CommitMultiStore, error) {
+	db := dbm.NewMemDB()
+	cms := rootmulti.NewStore(db, log.NewNopLogger())
+	cms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	return cms, cms.LoadLatestVersion()
+}
+
+// blockContext returns the context of the block at height, executed on a
+// branch of cms.
+func blockContext(cms storetypes.CommitMultiStore, height int64) sdk.Context {
+	return sdk.NewContext(cms.CacheMultiStore(), tmproto.Header{Height: height}, false, log.NewNopLogger())
+}
+
+// executeSequentially executes the txs one after the other, as BaseApp does
+// without OCC.
+func executeSequentially(ctx sdk.Context, reqs []abci.RequestDeliverTx) []abci.ResponseDeliverTx {
+	res := make([]abci.ResponseDeliverTx, len(reqs))
+	for i, req := range reqs {
+		ms := ctx.MultiStore().CacheMultiStore()
+		res[i] = deliverTx(ctx.WithTxIndex(i).WithMultiStore(ms), req)
+		ms.Write()
+	}
+	return res
+}
+
+// sameState reports whether the simulator's store holds the same values in
+// both contexts.
+func sameState(a, b sdk.Context) bool {
+	itA := a.MultiStore().GetKVStore(storeKey).Iterator(nil, nil)
+	defer itA.Close()
+	itB := b.MultiStore().GetKVStore(storeKey).Iterator(nil, nil)
+	defer itB.Close()
+	for ; itA.Valid() && itB.Valid(); itA.Next() {
+		if !bytes.Equal(itA.Key(), itB.Key()) || !bytes.Equal(itA.Value(), itB.Value()) {
+			return false
+		}
+		itB.Next()
+	}
+	return itA.Valid() == itB.Valid()
+}
+
+// Run simulates the workload of cfg, executing its blocks sequentially and
+// then with the scheduler for every number of workers. Each block executes on
+// the state the sequential execution of the previous blocks left. It fails if
+// the scheduler does not give the results of the sequential execution.
+func Run(cfg Config) (Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return Report{}, err
+	}
+	cms, err := newState()
+	if err != nil {
+		return Report{}, err
+	}
+	report := Report{Config: cfg, Results: make([]Result, len(cfg.Workers))}
+	schedulers := make([]tasks.Scheduler, len(cfg.Workers))
+	for i, workers := range cfg.Workers {
+		report.Results[i].Workers = workers
+		schedulers[i] = tasks.NewScheduler(workers, deliverTx)
+	}
+
+	for i, reqs := range generateBlocks(cfg) {
+		height := int64(i + 1)
+		sequential := blockContext(cms, height)
+		start := time.Now()
+		expected := executeSequentially(sequential, reqs)
+		report.Sequential += time.Since(start)
+
+		for j, s := range schedulers {
+			ctx := blockContext(cms, height)
+			start := time.Now()
+			res, err := s.ProcessAll(ctx, reqs)
+			if err != nil {
+				return report, err
+			}
+			result := &report.Results[j]
+			result.Duration += time.Since(start)
+			stats := s.Stats()
+			result.Incarnations += stats.Incarnations
+			result.ValidationFailures += stats.ValidationFailures
+			result.Aborts += stats.Aborts
+			if !reflect.DeepEqual(expected, res) || !sameState(sequential, ctx) {
+				return report, fmt.Errorf("block %d: the scheduler with %d workers diverged from the sequential execution", height, result.Workers)
+			}
+		}
+
+		sequential.MultiStore().(storetypes.CacheMultiStore).Write()
+		cms.Commit(true)
+	}
+
+	for i := range report.Results {
+		if d := report.Results[i].Duration; d > 0 {
+			report.Results[i].Speedup = float64(report.Sequential) / float64(d)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,69 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	for _, dist := range []GasDistribution{GasConstant, GasUniform, GasExponential} {
+		cfg := Config{
+			Blocks:          3,
+			Txs:             50,
+			ConflictRate:    0.5,
+			HotKeys:         2,
+			GasMean:         1000,
+			GasDistribution: dist,
+			Workers:         []int{1, 4, 0},
+			Seed:            7,
+		}
+		report, err := Run(cfg)
+		require.NoError(t, err)
+		require.Positive(t, report.Sequential)
+		require.Len(t, report.Results, 3)
+		for i, res := range report.Results {
+			require.Equal(t, cfg.Workers[i], res.Workers)
+			require.Positive(t, res.Speedup)
+			require.GreaterOrEqual(t, res.Incarnations, cfg.Blocks*cfg.Txs)
+		}
+	}
+}
+
+func TestGenerateBlocks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Blocks, cfg.Txs, cfg.ConflictRate = 2, 1000, 0.2
+	blocks := generateBlocks(cfg)
+	require.Equal(t, blocks, generateBlocks(cfg))
+
+	conflicting := 0
+	for _, reqs := range blocks {
+		require.Len(t, reqs, cfg.Txs)
+		for _, req := range reqs {
+			tx := decodeTx(req.Tx)
+			require.Equal(t, cfg.GasMean, tx.gas)
+			if tx.hotKey >= 0 {
+				require.Less(t, int(tx.hotKey), cfg.HotKeys)
+				conflicting++
+			}
+		}
+	}
+	require.InDelta(t, 400, conflicting, 80)
+	require.Equal(t, uint32(1999), decodeTx(blocks[1][999].Tx).id)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	cfg.ConflictRate = 1.5
+	require.EqualError(t, cfg.Validate(), "the conflict rate must be between 0 and 1, got 1.5")
+
+	cfg = DefaultConfig()
+	cfg.GasDistribution = "normal"
+	require.EqualError(t, cfg.Validate(), "unknown gas distribution normal")
+
+	cfg = DefaultConfig()
+	cfg.Workers = nil
+	require.Error(t, cfg.Validate())
+}
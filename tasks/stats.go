@@ -0,0 +1,198 @@
+package tasks
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// EventTypeSchedulerStats is the type of the event summarizing how the
+	// scheduler processed a block.
+	EventTypeSchedulerStats = "scheduler_stats"
+
+	AttributeKeyTxs                = "txs"
+	AttributeKeyRounds             = "rounds"
+	AttributeKeyIncarnations       = "incarnations"
+	AttributeKeyValidationFailures = "validation_failures"
+	AttributeKeyAborts             = "aborts"
+	AttributeKeyTimeouts           = "timeouts"
+	AttributeKeyWorkers            = "workers"
+	AttributeKeyParallelism        = "parallelism"
+	AttributeKeyGasUsed            = "gas_used"
+	AttributeKeyFees               = "fees"
+	AttributeKeyDiscardedGas       = "discarded_gas"
+	AttributeKeyDiscardedFees      = "discarded_fees"
+	AttributeKeyCommitGas          = "commit_gas"
+)
+
+// The reasons of the way the scheduler executed a block, see Stats.ModeReason.
+const (
+	// ModeReasonConcurrent is the reason of a block whose txs all executed
+	// concurrently.
+	ModeReasonConcurrent = "concurrent"
+	// ModeReasonMaxRoundsExceeded is the reason of a block finished
+	// sequentially as it needed more rounds than WithMaxRounds allows.
+	ModeReasonMaxRoundsExceeded = "max rounds exceeded"
+	// ModeReasonMaxIncarnationsExceeded is the reason of a block finished
+	// sequentially as a tx needed more executions than WithMaxIncarnation
+	// allows.
+	ModeReasonMaxIncarnationsExceeded = "max incarnations exceeded"
+	// ModeReasonMaxVersionedStoreBytesExceeded is the reason of a block
+	// finished sequentially as its multi-version stores held more than
+	// WithMaxVersionedStoreBytes allows.
+	ModeReasonMaxVersionedStoreBytesExceeded = "max versioned store bytes exceeded"
+)
+
+// Stats summarizes how the scheduler processed a block.
+type Stats struct {
+	// Txs is the number of txs in the block
+	Txs int
+	// Rounds is the number of execute/validate rounds
+	Rounds int
+	// Incarnations is the total number of tx executions
+	Incarnations int
+	// ValidationFailures is the number of executions invalidated by validation
+	ValidationFailures int
+	// Aborts is the number of executions aborted after reading an estimate
+	Aborts int
+	// Timeouts is the number of executions aborted for running past the task timeout
+	Timeouts int
+	// Workers is the number of workers available to the block
+	Workers int
+	// ModeReason is why the block was executed the way it was, one of the
+	// ModeReason constants
+	ModeReason string
+	// Parallelism is the average number of txs executing at the same time
+	Parallelism float64
+	// ShadowDivergences is the number of responses and writes that differ from
+	// the sequential execution, in shadow mode
+	ShadowDivergences int
+	// GasUsed and Fees are the gas used and the fees deducted by the validated
+	// executions, those committed
+	GasUsed int64
+	Fees    sdk.Coins
+	// DiscardedGas and DiscardedFees are the gas used and the fees deducted by
+	// the executions discarded for being invalid or aborted, which left no
+	// trace in the state. The gas of an aborted execution is not known.
+	DiscardedGas  int64
+	DiscardedFees sdk.Coins
+	// CommitDuration is the time taken to apply the writes of the block to its
+	// stores
+	CommitDuration time.Duration
+	// CommitGas is the gas of the writes applied to the stores of the block,
+	// priced by the gas config given WithCommitGas. It only depends on the
+	// final value of each key written, and so is the same on every node.
+	CommitGas uint64
+	// FlushedTxs is the number of txs committed before the end of the block to
+	// free the memory of the multi-version stores, see
+	// WithMaxVersionedStoreBytes
+	FlushedTxs int
+}
+
+// Event returns the stats as an event, for the app to emit with the block.
+func (st Stats) Event() sdk.Event {
+	return sdk.NewEvent(
+		EventTypeSchedulerStats,
+		sdk.NewAttribute(AttributeKeyTxs, strconv.Itoa(st.Txs)),
+		sdk.NewAttribute(AttributeKeyRounds, strconv.Itoa(st.Rounds)),
+		sdk.NewAttribute(AttributeKeyIncarnations, strconv.Itoa(st.Incarnations)),
+		sdk.NewAttribute(AttributeKeyValidationFailures, strconv.Itoa(st.ValidationFailures)),
+		sdk.NewAttribute(AttributeKeyAborts, strconv.Itoa(st.Aborts)),
+		sdk.NewAttribute(AttributeKeyTimeouts, strconv.Itoa(st.Timeouts)),
+		sdk.NewAttribute(AttributeKeyWorkers, strconv.Itoa(st.Workers)),
+		sdk.NewAttribute(AttributeKeyParallelism, strconv.FormatFloat(st.Parallelism, 'f', 2, 64)),
+		sdk.NewAttribute(AttributeKeyGasUsed, strconv.FormatInt(st.GasUsed, 10)),
+		sdk.NewAttribute(AttributeKeyFees, st.Fees.String()),
+		sdk.NewAttribute(AttributeKeyDiscardedGas, strconv.FormatInt(st.DiscardedGas, 10)),
+		sdk.NewAttribute(AttributeKeyDiscardedFees, st.DiscardedFees.String()),
+		sdk.NewAttribute(AttributeKeyCommitGas, strconv.FormatUint(st.CommitGas, 10)),
+	)
+}
+
+// blockStats accumulates the stats of the block being processed.
+type blockStats struct {
+	rounds            int
+	shadowDivergences int
+	// executions, aborts, timeouts and validationFailures are updated by the
+	// workers
+	executions         int64
+	aborts             int64
+	timeouts           int64
+	validationFailures int64
+	// busy is the time spent executing tasks, wall the duration of the
+	// execution phases
+	busy time.Duration
+	wall time.Duration
+	// the charges of the validated and of the discarded executions, updated
+	// between the rounds, and by the workers under discardMtx with the
+	// Pipelined strategy
+	discardMtx    sync.Mutex
+	gasUsed       int64
+	fees          sdk.Coins
+	discardedGas  int64
+	discardedFees sdk.Coins
+	// the cost of applying the writes of the block
+	commitDuration time.Duration
+	commitGas      uint64
+	// modeReason is why the block fell back to sequential execution, if it did
+	modeReason string
+	// flushedTxs is the number of txs committed before the end of the block
+	flushedTxs int
+}
+
+// discard accounts for the charges of the last execution of t, which is about
+// to be discarded for a new incarnation.
+func (bs *blockStats) discard(t *deliverTxTask) {
+	bs.discardMtx.Lock()
+	defer bs.discardMtx.Unlock()
+	if t.Response != nil {
+		bs.discardedGas += t.Response.GasUsed
+	}
+	if t.Charges != nil {
+		bs.discardedFees = bs.discardedFees.Add(t.Charges.Fees()...)
+	}
+}
+
+// charge accounts for the charges of the validated executions of tasks.
+func (bs *blockStats) charge(tasks []*deliverTxTask) {
+	for _, t := range tasks {
+		bs.gasUsed += t.Response.GasUsed
+		if t.Charges != nil {
+			bs.fees = bs.fees.Add(t.Charges.Fees()...)
+		}
+	}
+	telemetry.IncrCounter(float32(bs.discardedGas), "scheduler", "discarded_gas")
+}
+
+func (bs *blockStats) stats(txs, workers int) Stats {
+	st := Stats{
+		Txs:                txs,
+		Rounds:             bs.rounds,
+		Incarnations:       int(atomic.LoadInt64(&bs.executions)),
+		ValidationFailures: int(atomic.LoadInt64(&bs.validationFailures)),
+		Aborts:             int(atomic.LoadInt64(&bs.aborts)),
+		Timeouts:           int(atomic.LoadInt64(&bs.timeouts)),
+		Workers:            workers,
+		ModeReason:         ModeReasonConcurrent,
+		ShadowDivergences:  bs.shadowDivergences,
+		GasUsed:            bs.gasUsed,
+		Fees:               bs.fees,
+		DiscardedGas:       bs.discardedGas,
+		DiscardedFees:      bs.discardedFees,
+		CommitDuration:     bs.commitDuration,
+		CommitGas:          bs.commitGas,
+		FlushedTxs:         bs.flushedTxs,
+	}
+	if bs.modeReason != "" {
+		st.ModeReason = bs.modeReason
+	}
+	if bs.wall > 0 {
+		st.Parallelism = float64(bs.busy) / float64(bs.wall)
+	}
+	return st
+}
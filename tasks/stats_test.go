@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSchedulerStats(t *testing.T) {
+	s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		ctx.MultiStore().GetKVStore(testStoreKey).Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{}
+	})
+	_, err := s.ProcessAll(initTestCtx(), requestList(5))
+	require.NoError(t, err)
+
+	stats := s.Stats()
+	require.Equal(t, 5, stats.Txs)
+	require.Equal(t, 1, stats.Rounds)
+	require.Equal(t, 5, stats.Incarnations)
+	require.Equal(t, 0, stats.ValidationFailures)
+	require.Equal(t, 0, stats.Aborts)
+	require.Equal(t, 1, stats.Workers)
+	require.Greater(t, stats.Parallelism, 0.0)
+	require.LessOrEqual(t, stats.Parallelism, 1.0)
+}
+
+func TestStatsEvent(t *testing.T) {
+	event := Stats{
+		Txs:                10,
+		Rounds:             2,
+		Incarnations:       13,
+		ValidationFailures: 2,
+		Aborts:             1,
+		Timeouts:           1,
+		Workers:            4,
+		Parallelism:        3.456,
+		GasUsed:            500,
+		Fees:               sdk.NewCoins(sdk.NewInt64Coin("usei", 10)),
+		DiscardedGas:       100,
+		DiscardedFees:      sdk.NewCoins(sdk.NewInt64Coin("usei", 2)),
+		CommitGas:          42,
+	}.Event()
+
+	require.Equal(t, EventTypeSchedulerStats, event.Type)
+	attrs := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, map[string]string{
+		AttributeKeyTxs:                "10",
+		AttributeKeyRounds:             "2",
+		AttributeKeyIncarnations:       "13",
+		AttributeKeyValidationFailures: "2",
+		AttributeKeyAborts:             "1",
+		AttributeKeyTimeouts:           "1",
+		AttributeKeyWorkers:            "4",
+		AttributeKeyParallelism:        "3.46",
+		AttributeKeyGasUsed:            "500",
+		AttributeKeyFees:               "10usei",
+		AttributeKeyDiscardedGas:       "100",
+		AttributeKeyDiscardedFees:      "2usei",
+		AttributeKeyCommitGas:          "42",
+	}, attrs)
+}
+
+func TestModeSelectionReason(t *testing.T) {
+	// every tx depends on the one before it, and the first one is slow for the
+	// others to read a stale value
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		if string(req.Tx) == "0" {
+			time.Sleep(10 * time.Millisecond)
+		}
+		kv.Set([]byte("shared"), append(val, req.Tx...))
+		return types.ResponseDeliverTx{}
+	}
+
+	for _, tc := range []struct {
+		opts   []Option
+		reason string
+	}{
+		{nil, ModeReasonConcurrent},
+		{[]Option{WithMaxRounds(1)}, ModeReasonMaxRoundsExceeded},
+		{[]Option{WithMaxRounds(0), WithMaxIncarnation(1)}, ModeReasonMaxIncarnationsExceeded},
+	} {
+		s := NewScheduler(4, deliverTx, tc.opts...)
+		require.Empty(t, s.ModeSelectionReason())
+		_, err := s.ProcessAll(initTestCtx(), requestList(8))
+		require.NoError(t, err)
+		require.Equal(t, tc.reason, s.ModeSelectionReason())
+		require.Equal(t, tc.reason, s.Stats().ModeReason)
+
+		// the reason is the one of the last block
+		_, err = s.ProcessAll(initTestCtx(), requestList(1))
+		require.NoError(t, err)
+		require.Equal(t, ModeReasonConcurrent, s.ModeSelectionReason())
+	}
+}
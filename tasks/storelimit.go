@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// WithMaxVersionedStoreBytes caps the memory of the multi-version stores of a
+// block, approximated by the bytes of the keys and values of their versions.
+// Zero means no cap.
+//
+// When the stores are over the cap after a validation pass, the txs validated
+// so far are committed to a branch of the block's stores, which frees their
+// versions. If the stores are still over the cap, the rest of the block is
+// executed sequentially, each tx being committed once validated whenever the
+// stores go over the cap again. The branch is only written to the block's
+// stores with the rest of the block, so a block that fails commits nothing.
+// The txs whose iterations observed a committed version are re-executed.
+func WithMaxVersionedStoreBytes(max int) Option {
+	return func(s *scheduler) {
+		s.maxVersionedStoreBytes = max
+	}
+}
+
+// versionedStoreBytes returns the memory held by the multi-version stores.
+func (s *scheduler) versionedStoreBytes() int {
+	size := 0
+	for _, mvs := range s.multiVersionStores {
+		size += mvs.Size()
+	}
+	return size
+}
+
+// limitVersionedStores commits the writes of the tasks below validated, which
+// must all be validated, if the multi-version stores are over the cap, and
+// reports whether they still are.
+func (s *scheduler) limitVersionedStores(validated int) bool {
+	if s.maxVersionedStoreBytes <= 0 || s.versionedStoreBytes() <= s.maxVersionedStoreBytes {
+		return false
+	}
+	s.flushBefore(validated)
+	return s.versionedStoreBytes() > s.maxVersionedStoreBytes
+}
+
+// flushBefore commits the writes of the tasks below index to the flush branch
+// and drops their versions from the multi-version stores.
+func (s *scheduler) flushBefore(index int) {
+	if index <= s.flushedBefore {
+		return
+	}
+	if s.flushedWrites == nil {
+		s.flushedWrites = make(map[string]map[string][]byte)
+	}
+	for storeKey, mvs := range s.multiVersionStores {
+		for key, value := range mvs.FlushBefore(index) {
+			if _, ok := s.flushedWrites[storeKey.Name()]; !ok {
+				s.flushedWrites[storeKey.Name()] = make(map[string][]byte)
+			}
+			s.flushedWrites[storeKey.Name()][key] = value
+		}
+	}
+	telemetry.IncrCounter(float32(index-s.flushedBefore), "scheduler", "flushed_txs")
+	s.blockStats.flushedTxs += index - s.flushedBefore
+	s.flushedBefore = index
+}
@@ -0,0 +1,128 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// counterDeliverTx increments the counter and writes valueSize bytes to the
+// key of the tx, tx "0" only writing once the others read the counter.
+func counterDeliverTx(valueSize func(tx string) int) func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+	return func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		if string(req.Tx) == "0" {
+			time.Sleep(10 * time.Millisecond)
+		}
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+		kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+		kv.Set(req.Tx, bytes.Repeat([]byte("x"), valueSize(string(req.Tx))))
+		return types.ResponseDeliverTx{Data: []byte(strconv.Itoa(counter))}
+	}
+}
+
+func requireCounted(t *testing.T, ctx sdk.Context, res []types.ResponseDeliverTx) {
+	kv := ctx.MultiStore().GetKVStore(testStoreKey)
+	require.Equal(t, []byte(strconv.Itoa(len(res))), kv.Get([]byte("counter")))
+	for i, resp := range res {
+		require.Equal(t, []byte(strconv.Itoa(i)), resp.Data)
+		require.NotNil(t, kv.Get([]byte(strconv.Itoa(i))))
+	}
+}
+
+func TestMaxVersionedStoreBytesFlushesPrefix(t *testing.T) {
+	// tx 0 alone puts the stores over the cap, flushing it is enough
+	s := NewScheduler(4, counterDeliverTx(func(tx string) int {
+		if tx == "0" {
+			return 5000
+		}
+		return 1
+	}), WithMaxVersionedStoreBytes(1000))
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	requireCounted(t, ctx, res)
+	require.Equal(t, ModeReasonConcurrent, s.Stats().ModeReason)
+	require.Equal(t, 1, s.Stats().FlushedTxs)
+	require.Greater(t, s.Stats().Rounds, 1)
+	require.NoError(t, s.AssertWriteset(expectedCounterWriteset(res, 5000, 1)))
+	value, ok := s.CommittedValue(testStoreKey, []byte("0"))
+	require.True(t, ok)
+	require.Len(t, value, 5000)
+}
+
+func TestMaxVersionedStoreBytesFallsBackToSequential(t *testing.T) {
+	// every tx writes 1000 bytes, the stores hold a few of them at most
+	s := NewScheduler(4, counterDeliverTx(func(string) int {
+		return 1000
+	}), WithMaxVersionedStoreBytes(3500))
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	requireCounted(t, ctx, res)
+	require.Equal(t, ModeReasonMaxVersionedStoreBytesExceeded, s.Stats().ModeReason)
+	require.Equal(t, ModeReasonMaxVersionedStoreBytesExceeded, s.ModeSelectionReason())
+	require.Equal(t, 1, s.Stats().Rounds)
+	// the sequential execution flushes until the stale versions left fit
+	require.Greater(t, s.Stats().FlushedTxs, 1)
+	require.NoError(t, s.AssertWriteset(expectedCounterWriteset(res, 1000, 1000)))
+}
+
+func TestMaxVersionedStoreBytesFailedBlock(t *testing.T) {
+	var executions int64
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deliverTx := counterDeliverTx(func(string) int {
+		return 1000
+	})
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// the block is canceled during the sequential execution
+		if string(req.Tx) == "10" && atomic.AddInt64(&executions, 1) == 2 {
+			cancel()
+		}
+		return deliverTx(ctx, req)
+	}, WithMaxVersionedStoreBytes(3500))
+	ctx := initTestCtx()
+
+	_, err := s.ProcessAll(ctx.WithContext(goCtx), requestList(20))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Greater(t, s.Stats().FlushedTxs, 0)
+	// the flushed txs are not committed
+	kv := ctx.MultiStore().GetKVStore(testStoreKey)
+	require.Nil(t, kv.Get([]byte("counter")))
+	require.Nil(t, kv.Get([]byte("0")))
+}
+
+func TestMaxVersionedStoreBytesUnderCap(t *testing.T) {
+	s := NewScheduler(4, counterDeliverTx(func(string) int {
+		return 1
+	}), WithMaxVersionedStoreBytes(1<<20))
+	ctx := initTestCtx()
+
+	res, err := s.ProcessAll(ctx, requestList(20))
+	require.NoError(t, err)
+	requireCounted(t, ctx, res)
+	require.Equal(t, 0, s.Stats().FlushedTxs)
+}
+
+// expectedCounterWriteset is the writeset of a block of counterDeliverTx.
+func expectedCounterWriteset(res []types.ResponseDeliverTx, firstSize, size int) map[string][]byte {
+	expected := map[string][]byte{
+		writesetKey(testStoreKey.Name(), "counter"): []byte(strconv.Itoa(len(res))),
+		writesetKey(testStoreKey.Name(), "0"):       bytes.Repeat([]byte("x"), firstSize),
+	}
+	for i := 1; i < len(res); i++ {
+		expected[writesetKey(testStoreKey.Name(), strconv.Itoa(i))] = bytes.Repeat([]byte("x"), size)
+	}
+	return expected
+}
@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecutionStrategy is how the scheduler interleaves the executions and the
+// validations of the tasks of a round.
+type ExecutionStrategy int
+
+const (
+	// BatchOCC executes every task of a round before validating any of them:
+	// the whole block executes speculatively once, then validates once. It is
+	// the default strategy.
+	BatchOCC ExecutionStrategy = iota
+	// Pipelined also validates each task as soon as its execution ends, while
+	// the other tasks of the round execute, and re-executes it right away if it
+	// is already invalid. The tasks are still all validated once the round is
+	// over, so both strategies commit the same state.
+	Pipelined
+)
+
+// WithExecutionStrategy sets the strategy of the scheduler, BatchOCC by
+// default.
+func WithExecutionStrategy(strategy ExecutionStrategy) Option {
+	return func(s *scheduler) {
+		s.strategy = strategy
+	}
+}
+
+// validateEarly validates the task right after its execution, with the
+// Pipelined strategy, and re-executes it once if it is already invalid, e.g.
+// for having read a value that a lower-indexed task has since overwritten. The
+// re-execution is bounded by the incarnations the scheduler allows, as the
+// execution of the rounds.
+func (s *scheduler) validateEarly(ctx sdk.Context, task *deliverTxTask, worker int) {
+	if s.strategy != Pipelined || task.Status != statusExecuted {
+		return
+	}
+	if s.validateTaskWithRecover(ctx, task) {
+		return
+	}
+	if s.maxIncarnation > 0 && task.Incarnation+1 >= s.maxIncarnation {
+		// left for the validation of the round
+		return
+	}
+	telemetry.IncrCounter(1, "scheduler", "validation_failures")
+	atomic.AddInt64(&s.blockStats.validationFailures, 1)
+	s.recordInvalidation(task)
+	s.invalidateTask(task)
+	s.blockStats.discard(task)
+	task.Increment()
+	s.executeOn(ctx, task, worker)
+}
@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestExecutionStrategies(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		// a hot key and a range scan, so that most first executions are invalid
+		counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+		kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+		keys := 0
+		it := kv.Iterator([]byte("tx/"), []byte("tx0"))
+		for ; it.Valid(); it.Next() {
+			keys++
+		}
+		it.Close()
+		kv.Set(append([]byte("tx/"), req.Tx...), []byte(strconv.Itoa(keys)))
+		return types.ResponseDeliverTx{Info: strconv.Itoa(counter), GasUsed: int64(keys)}
+	}
+	process := func(s Scheduler) ([]types.ResponseDeliverTx, map[string]string) {
+		ctx := initTestCtx()
+		res, err := s.ProcessAll(ctx, requestList(30))
+		require.NoError(t, err)
+		state := make(map[string]string)
+		it := ctx.MultiStore().GetKVStore(testStoreKey).Iterator(nil, nil)
+		defer it.Close()
+		for ; it.Valid(); it.Next() {
+			state[string(it.Key())] = string(it.Value())
+		}
+		return res, state
+	}
+
+	// batch OCC is the default
+	require.Equal(t, BatchOCC, NewScheduler(1, deliverTx).(*scheduler).strategy)
+
+	batchRes, batchState := process(NewScheduler(8, deliverTx, WithExecutionStrategy(BatchOCC)))
+	for i := 0; i < 5; i++ {
+		res, state := process(NewScheduler(8, deliverTx, WithExecutionStrategy(Pipelined)))
+		require.Equal(t, batchRes, res)
+		require.Equal(t, batchState, state)
+	}
+	require.Equal(t, "30", batchState["counter"])
+	for i, r := range batchRes {
+		require.Equal(t, strconv.Itoa(i), r.Info)
+	}
+}
@@ -0,0 +1,83 @@
+package tasks
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SequentialWorker is the worker of the executions of the sequential
+// fallback, which run on the goroutine processing the block.
+const SequentialWorker = -1
+
+// TaskSpan is an execution of a task, see Scheduler.ExecutionTimeline.
+type TaskSpan struct {
+	Index       int
+	Incarnation int
+	// Worker is the worker of the pool that ran the execution, or
+	// SequentialWorker. Without a pool, it is the position of the execution
+	// among those of its wave.
+	Worker int
+	Start  time.Time
+	End    time.Time
+}
+
+// WithExecutionTimeline makes the scheduler record when each execution of the
+// last block processed started and ended, and on which worker, for a tool to
+// render how the workers were used, see Scheduler.ExecutionTimeline.
+func WithExecutionTimeline() Option {
+	return func(s *scheduler) {
+		s.timeline = &timeline{}
+	}
+}
+
+// timeline collects the executions of the block being processed.
+type timeline struct {
+	mtx   sync.Mutex
+	spans []TaskSpan
+}
+
+// record adds the execution of the task at index that started at start and
+// ends now. It is a no-op on a nil timeline.
+func (tl *timeline) record(index, incarnation, worker int, start time.Time) {
+	if tl == nil {
+		return
+	}
+	span := TaskSpan{Index: index, Incarnation: incarnation, Worker: worker, Start: start, End: time.Now()}
+	tl.mtx.Lock()
+	defer tl.mtx.Unlock()
+	tl.spans = append(tl.spans, span)
+}
+
+// sorted returns the executions recorded so far by start time, and resets the
+// timeline for the next block.
+func (tl *timeline) sorted() []TaskSpan {
+	if tl == nil {
+		return nil
+	}
+	tl.mtx.Lock()
+	defer tl.mtx.Unlock()
+	spans := tl.spans
+	tl.spans = nil
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	return spans
+}
+
+// executeOn executes the task on worker, recording the execution in the
+// timeline, if any.
+func (s *scheduler) executeOn(ctx sdk.Context, task *deliverTxTask, worker int) {
+	start, incarnation := time.Now(), task.Incarnation
+	s.executeTask(ctx, task)
+	s.timeline.record(task.Index, incarnation, worker, start)
+}
+
+// ExecutionTimeline implements Scheduler.
+func (s *scheduler) ExecutionTimeline() []TaskSpan {
+	s.statsMtx.Lock()
+	defer s.statsMtx.Unlock()
+	return s.lastTimeline
+}
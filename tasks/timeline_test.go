@@ -0,0 +1,83 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestExecutionTimeline(t *testing.T) {
+	deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		// every tx depends on the one before it, and the first one is slow for
+		// the others to read a stale value
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		val := kv.Get([]byte("shared"))
+		if string(req.Tx) == "0" {
+			time.Sleep(10 * time.Millisecond)
+		}
+		kv.Set([]byte("shared"), req.Tx)
+		return types.ResponseDeliverTx{Info: string(val)}
+	}
+
+	for name, tc := range map[string]struct {
+		opts       []Option
+		workers    func(worker int) bool
+		sequential bool
+	}{
+		"concurrent": {
+			workers: func(worker int) bool { return worker >= 0 && worker < 4 },
+		},
+		"sequential": {
+			opts:       []Option{WithMaxRounds(1)},
+			workers:    func(worker int) bool { return worker >= 0 && worker < 4 || worker == SequentialWorker },
+			sequential: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := NewScheduler(4, deliverTx, append(tc.opts, WithExecutionTimeline())...)
+			require.Empty(t, s.ExecutionTimeline())
+
+			_, err := s.ProcessAll(initTestCtx(), requestList(20))
+			require.NoError(t, err)
+
+			spans := s.ExecutionTimeline()
+			// every execution has a span
+			require.Len(t, spans, s.Stats().Incarnations)
+			executions := make(map[[2]int]bool)
+			sequential := false
+			for i, span := range spans {
+				require.True(t, tc.workers(span.Worker), "worker %d", span.Worker)
+				require.False(t, span.End.Before(span.Start))
+				sequential = sequential || span.Worker == SequentialWorker
+				if i > 0 {
+					require.False(t, span.Start.Before(spans[i-1].Start))
+				}
+				execution := [2]int{span.Index, span.Incarnation}
+				require.False(t, executions[execution], "execution %v recorded twice", execution)
+				executions[execution] = true
+			}
+			require.Equal(t, tc.sequential, sequential)
+			for i := 0; i < 20; i++ {
+				require.True(t, executions[[2]int{i, 0}])
+			}
+
+			// the timeline is the one of the last block
+			_, err = s.ProcessAll(initTestCtx(), requestList(3))
+			require.NoError(t, err)
+			require.Len(t, s.ExecutionTimeline(), s.Stats().Incarnations)
+		})
+	}
+}
+
+func TestExecutionTimelineDisabled(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		return types.ResponseDeliverTx{}
+	})
+	_, err := s.ProcessAll(initTestCtx(), requestList(5))
+	require.NoError(t, err)
+	require.Empty(t, s.ExecutionTimeline())
+}
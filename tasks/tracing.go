@@ -0,0 +1,87 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	otrace "go.opentelemetry.io/otel/trace"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+	"github.com/cosmos/cosmos-sdk/utils/tracing"
+)
+
+// Abort reasons recorded on the spans of aborted executions.
+const (
+	AbortReasonEstimate = "estimate"
+	AbortReasonTimeout  = "timeout"
+	AbortReasonOther    = "other"
+)
+
+// WithTracingInfo makes the scheduler emit a span for every block, with a
+// child span for each of its phases: an execution round, a validation pass,
+// the sequential fallback and the commit. The spans started by deliverTx are
+// children of the phase, or of the task span with WithTaskSpans.
+func WithTracingInfo(tracingInfo *tracing.Info) Option {
+	return func(s *scheduler) {
+		s.tracingInfo = tracingInfo
+	}
+}
+
+// WithTracer is WithTracingInfo for a tracer not managed by the app.
+func WithTracer(tracer otrace.Tracer) Option {
+	return WithTracingInfo(&tracing.Info{Tracer: &tracer})
+}
+
+// WithTaskSpans also emits a span for every execution of a task, under the
+// phase that executed it. There can be thousands per block, so they are off by
+// default.
+func WithTaskSpans() Option {
+	return func(s *scheduler) {
+		s.taskSpans = true
+	}
+}
+
+// startSpan starts a span as a child of the span in ctx and returns ctx
+// carrying the new span. Without tracing info the span is a no-op.
+func (s *scheduler) startSpan(ctx sdk.Context, name string) (sdk.Context, otrace.Span) {
+	if s.tracingInfo == nil || s.tracingInfo.Tracer == nil {
+		return ctx, otrace.SpanFromContext(context.Background())
+	}
+	spanCtx, span := s.tracingInfo.StartWithContext(name, ctx.TraceSpanContext())
+	return ctx.WithTraceSpanContext(spanCtx), span
+}
+
+// startTaskSpan is startSpan for the execution of a task, a no-op span unless
+// task spans are enabled.
+func (s *scheduler) startTaskSpan(ctx sdk.Context) (sdk.Context, otrace.Span) {
+	if !s.taskSpans {
+		return ctx, otrace.SpanFromContext(context.Background())
+	}
+	return s.startSpan(ctx, "SchedulerExecute")
+}
+
+// abortReason returns the AbortReason of abort.
+func abortReason(abort *occ.Abort) string {
+	switch {
+	case abort == nil:
+		return AbortReasonOther
+	case errors.Is(abort.Err, occ.ErrTimeout):
+		return AbortReasonTimeout
+	case errors.Is(abort.Err, occ.ErrReadEstimate):
+		return AbortReasonEstimate
+	default:
+		return AbortReasonOther
+	}
+}
+
+// conflictKeys returns the keys, prefixed by their store name, whose values
+// the task read have since changed. It is only computed for tracing.
+func (s *scheduler) conflictKeys(task *deliverTxTask) []string {
+	var keys []string
+	for _, read := range s.staleReads(task) {
+		keys = append(keys, fmt.Sprintf("%s/%X", read.storeKey.Name(), read.key))
+	}
+	return keys
+}
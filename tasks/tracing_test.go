@@ -0,0 +1,140 @@
+package tasks
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	otrace "go.opentelemetry.io/otel/trace"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/utils/tracing"
+)
+
+func TestSchedulerSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	s := NewScheduler(1, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set(req.Tx, req.Tx)
+		return types.ResponseDeliverTx{GasUsed: 10}
+	}, WithTracingInfo(&tracing.Info{Tracer: &tracer}), WithTaskSpans())
+
+	_, err := s.ProcessAll(initTestCtx(), requestList(3))
+	require.NoError(t, err)
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	require.Len(t, spans["SchedulerProcessAll"], 1)
+	require.Len(t, spans["SchedulerValidate"], 1)
+	require.Len(t, spans["SchedulerExecuteRound"], 1)
+	require.Len(t, spans["SchedulerExecute"], 3)
+
+	round := spans["SchedulerExecuteRound"][0].SpanContext().SpanID()
+	for _, span := range spans["SchedulerExecute"] {
+		require.Equal(t, round, span.Parent().SpanID())
+		require.Contains(t, span.Attributes(), attribute.Int64("gasUsed", 10))
+		require.Contains(t, span.Attributes(), attribute.Int("incarnation", 0))
+	}
+}
+
+func TestSchedulerSpanTree(t *testing.T) {
+	for name, taskSpans := range map[string]bool{"phases": false, "tasks": true} {
+		t.Run(name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+			deliverTx := func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+				_, span := tracer.Start(ctx.TraceSpanContext(), "deliverTx")
+				defer span.End()
+				if string(req.Tx) == "0" {
+					// the other txs read the counter before it is updated
+					time.Sleep(10 * time.Millisecond)
+				}
+				kv := ctx.MultiStore().GetKVStore(testStoreKey)
+				counter, _ := strconv.Atoi(string(kv.Get([]byte("counter"))))
+				kv.Set([]byte("counter"), []byte(strconv.Itoa(counter+1)))
+				return types.ResponseDeliverTx{}
+			}
+			opts := []Option{WithTracer(tracer), WithMaxRounds(1)}
+			if taskSpans {
+				opts = append(opts, WithTaskSpans())
+			}
+			s := NewScheduler(4, deliverTx, opts...)
+
+			_, err := s.ProcessAll(initTestCtx(), requestList(5))
+			require.NoError(t, err)
+			require.Equal(t, ModeReasonMaxRoundsExceeded, s.Stats().ModeReason)
+
+			spans := make(map[string][]sdktrace.ReadOnlySpan)
+			names := make(map[otrace.SpanID]string)
+			for _, span := range recorder.Ended() {
+				spans[span.Name()] = append(spans[span.Name()], span)
+				names[span.SpanContext().SpanID()] = span.Name()
+			}
+			parentName := func(span sdktrace.ReadOnlySpan) string {
+				return names[span.Parent().SpanID()]
+			}
+
+			require.Len(t, spans["SchedulerProcessAll"], 1)
+			for _, phase := range []string{"SchedulerExecuteRound", "SchedulerValidate", "SchedulerSequential", "SchedulerCommit"} {
+				require.Len(t, spans[phase], 1, phase)
+				require.Equal(t, "SchedulerProcessAll", parentName(spans[phase][0]), phase)
+			}
+			require.NotEmpty(t, spans["deliverTx"])
+			if !taskSpans {
+				require.Empty(t, spans["SchedulerExecute"])
+				for _, span := range spans["deliverTx"] {
+					require.Contains(t, []string{"SchedulerExecuteRound", "SchedulerSequential"}, parentName(span))
+				}
+				return
+			}
+			require.Len(t, spans["SchedulerExecute"], len(spans["deliverTx"]))
+			for _, span := range spans["SchedulerExecute"] {
+				require.Contains(t, []string{"SchedulerExecuteRound", "SchedulerSequential"}, parentName(span))
+			}
+			for _, span := range spans["deliverTx"] {
+				require.Equal(t, "SchedulerExecute", parentName(span))
+			}
+		})
+	}
+}
+
+func TestSchedulerSpanAbortReason(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	s := NewScheduler(2, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		return types.ResponseDeliverTx{}
+	}, WithTracer(tracer), WithTaskSpans(), WithFaultInjector(NewFaultInjector(1, 1, FaultTimeout)))
+
+	_, err := s.ProcessAll(initTestCtx(), requestList(3))
+	require.NoError(t, err)
+
+	aborted := 0
+	for _, span := range recorder.Ended() {
+		if span.Name() != "SchedulerExecute" {
+			continue
+		}
+		if attrs := span.Attributes(); containsAttribute(attrs, attribute.Bool("aborted", true)) {
+			aborted++
+			require.Contains(t, attrs, attribute.String("abortReason", AbortReasonTimeout))
+		}
+	}
+	require.Equal(t, 3, aborted)
+}
+
+func containsAttribute(attrs []attribute.KeyValue, attr attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == attr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+// writesetKey is the key of a write in the writesets given to AssertWriteset.
+func writesetKey(storeName, key string) string {
+	return storeName + "/" + key
+}
+
+// committedWriteset returns the writes committed by the last block processed,
+// keyed by writesetKey.
+func (s *scheduler) committedWriteset() map[string][]byte {
+	res := make(map[string][]byte)
+	for storeName, writes := range s.concurrentWrites() {
+		for key, value := range writes {
+			res[writesetKey(storeName, key)] = value
+		}
+	}
+	return res
+}
+
+// AssertWriteset implements Scheduler.
+func (s *scheduler) AssertWriteset(expected map[string][]byte) error {
+	if !s.committed {
+		return sdkerrors.Wrap(occ.ErrWritesetMismatch, "no block was committed")
+	}
+	actual := s.committedWriteset()
+	var diff []string
+	for _, key := range unionKeys(actual, expected) {
+		actualValue, actualOk := actual[key]
+		expectedValue, expectedOk := expected[key]
+		switch {
+		case !actualOk:
+			diff = append(diff, fmt.Sprintf("%q: not written, expected %s", key, describeWrite(expectedValue)))
+		case !expectedOk:
+			diff = append(diff, fmt.Sprintf("%q: %s, expected not written", key, describeWrite(actualValue)))
+		case !bytes.Equal(actualValue, expectedValue) || (actualValue == nil) != (expectedValue == nil):
+			diff = append(diff, fmt.Sprintf("%q: %s, expected %s", key, describeWrite(actualValue), describeWrite(expectedValue)))
+		}
+	}
+	if len(diff) > 0 {
+		return sdkerrors.Wrapf(occ.ErrWritesetMismatch, "%d keys differ:\n%s", len(diff), strings.Join(diff, "\n"))
+	}
+	return nil
+}
+
+// CommittedValue implements Scheduler.
+func (s *scheduler) CommittedValue(storeKey sdk.StoreKey, key []byte) ([]byte, bool) {
+	mvs, ok := s.multiVersionStores[storeKey]
+	if !s.committed || !ok {
+		return nil, false
+	}
+	latest := mvs.GetLatest(key)
+	if latest == nil {
+		// the key may have been written by a tx flushed during the block
+		value, ok := s.flushedWrites[storeKey.Name()][string(key)]
+		return value, ok && value != nil
+	}
+	if latest.IsDeleted() {
+		return nil, false
+	}
+	return latest.Value(), true
+}
+
+// describeWrite describes the final write of a key, nil for a delete.
+func describeWrite(value []byte) string {
+	if value == nil {
+		return "deleted"
+	}
+	return fmt.Sprintf("set to %q", value)
+}
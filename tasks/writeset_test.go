@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/occ"
+)
+
+func TestAssertWriteset(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set([]byte("shared"), req.Tx)
+		kv.Set(req.Tx, []byte("value"))
+		if string(req.Tx) == "1" {
+			kv.Delete([]byte("gone"))
+		}
+		return types.ResponseDeliverTx{}
+	})
+	require.ErrorIs(t, s.AssertWriteset(nil), occ.ErrWritesetMismatch)
+
+	ctx := initTestCtx()
+	ctx.MultiStore().GetKVStore(testStoreKey).Set([]byte("gone"), []byte("value"))
+	_, err := s.ProcessAll(ctx, requestList(3))
+	require.NoError(t, err)
+
+	expected := map[string][]byte{
+		"mock/shared": []byte("2"),
+		"mock/0":      []byte("value"),
+		"mock/1":      []byte("value"),
+		"mock/2":      []byte("value"),
+		"mock/gone":   nil,
+	}
+	require.NoError(t, s.AssertWriteset(expected))
+
+	expected["mock/shared"] = []byte("1")
+	expected["mock/gone"] = []byte("value")
+	delete(expected, "mock/2")
+	expected["mock/3"] = []byte("value")
+	err = s.AssertWriteset(expected)
+	require.ErrorIs(t, err, occ.ErrWritesetMismatch)
+	// the differences are listed by key
+	require.Contains(t, err.Error(), "4 keys differ:\n"+
+		`"mock/2": set to "value", expected not written`+"\n"+
+		`"mock/3": not written, expected set to "value"`+"\n"+
+		`"mock/gone": deleted, expected set to "value"`+"\n"+
+		`"mock/shared": set to "2", expected set to "1"`)
+}
+
+func TestCommittedValue(t *testing.T) {
+	s := NewScheduler(4, func(ctx sdk.Context, req types.RequestDeliverTx) types.ResponseDeliverTx {
+		kv := ctx.MultiStore().GetKVStore(testStoreKey)
+		kv.Set([]byte("shared"), req.Tx)
+		if string(req.Tx) == "2" {
+			kv.Delete([]byte("gone"))
+		}
+		return types.ResponseDeliverTx{}
+	})
+	_, ok := s.CommittedValue(testStoreKey, []byte("shared"))
+	require.False(t, ok)
+
+	ctx := initTestCtx()
+	ctx.MultiStore().GetKVStore(testStoreKey).Set([]byte("gone"), []byte("value"))
+	ctx.MultiStore().GetKVStore(testStoreKey).Set([]byte("untouched"), []byte("value"))
+	_, err := s.ProcessAll(ctx, requestList(5))
+	require.NoError(t, err)
+
+	// the value of the last tx writing the key
+	value, ok := s.CommittedValue(testStoreKey, []byte("shared"))
+	require.True(t, ok)
+	require.Equal(t, []byte("4"), value)
+	// deleted
+	value, ok = s.CommittedValue(testStoreKey, []byte("gone"))
+	require.False(t, ok)
+	require.Nil(t, value)
+	// not written by the block
+	_, ok = s.CommittedValue(testStoreKey, []byte("untouched"))
+	require.False(t, ok)
+	_, ok = s.CommittedValue(testTransientKey, []byte("shared"))
+	require.False(t, ok)
+}
@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Block processing stages observed by the stage histograms.
+const (
+	StageBeginBlock = "begin_block"
+	// StageDeliverTx is the execution of a single tx.
+	StageDeliverTx = "deliver_tx"
+	// StageDeliverTxTotal is the time spent delivering all the txs of a block.
+	StageDeliverTxTotal = "deliver_tx_total"
+	StageEndBlock       = "end_block"
+	StageCommit         = "commit"
+	StageSchedulerWave  = "scheduler_wave"
+)
+
+var (
+	// blockStageBuckets are the buckets, in milliseconds, of the stages run
+	// once or a few times per block.
+	blockStageBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	// txStageBuckets are the buckets, in milliseconds, of the stages run once
+	// per tx.
+	txStageBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100, 250, 1000}
+)
+
+// stageHistograms are the Prometheus histograms of the durations of the block
+// processing stages. Unlike the summaries of the go-metrics sinks, histograms
+// can be aggregated across nodes and compared between releases. They are only
+// exported once the Prometheus sink is enabled.
+var stageHistograms = map[string]prometheus.Histogram{
+	StageBeginBlock:     newStageHistogram(StageBeginBlock, "Duration of BeginBlock", blockStageBuckets),
+	StageDeliverTx:      newStageHistogram(StageDeliverTx, "Duration of the execution of a tx", txStageBuckets),
+	StageDeliverTxTotal: newStageHistogram(StageDeliverTxTotal, "Duration of the delivery of all the txs of a block", blockStageBuckets),
+	StageEndBlock:       newStageHistogram(StageEndBlock, "Duration of EndBlock", blockStageBuckets),
+	StageCommit:         newStageHistogram(StageCommit, "Duration of Commit", blockStageBuckets),
+	StageSchedulerWave:  newStageHistogram(StageSchedulerWave, "Duration of an execution wave of the scheduler", blockStageBuckets),
+}
+
+func newStageHistogram(stage string, help string, buckets []float64) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "sei",
+		Subsystem: "cosmos",
+		Name:      stage + "_ms",
+		Help:      help + " in milliseconds.",
+		Buckets:   buckets,
+	})
+}
+
+// registerStageHistograms registers the stage histograms with the default
+// Prometheus registry, which may already have them.
+func registerStageHistograms() error {
+	for _, h := range stageHistograms {
+		if err := prometheus.Register(h); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if !errors.As(err, &are) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ObserveStage observes the duration of the block processing stage started at
+// start. It panics on an unknown stage.
+func ObserveStage(stage string, start time.Time) {
+	ObserveStageDuration(stage, time.Since(start))
+}
+
+// ObserveStageDuration observes a duration of the block processing stage.
+func ObserveStageDuration(stage string, d time.Duration) {
+	h, ok := stageHistograms[stage]
+	if !ok {
+		panic("unknown block processing stage " + stage)
+	}
+	h.Observe(float64(d) / float64(time.Millisecond))
+}
@@ -147,6 +147,9 @@ func (m *Metrics) setupPrometheus(cfg Config) (*metricsprom.PrometheusSink, erro
 	if err != nil {
 		return nil, err
 	}
+	if err := registerStageHistograms(); err != nil {
+		return nil, err
+	}
 
 	return promSink, nil
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +63,38 @@ func TestMetrics_Prom(t *testing.T) {
 	require.True(t, strings.Contains(string(gr.Metrics), "test_dummy_counter 30"))
 }
 
+func TestStageHistograms(t *testing.T) {
+	// registering again is a no-op, as Metrics may be created more than once
+	require.NoError(t, registerStageHistograms())
+	require.NoError(t, registerStageHistograms())
+
+	ObserveStageDuration(StageCommit, 30*time.Millisecond)
+	ObserveStageDuration(StageCommit, 300*time.Millisecond)
+	require.Panics(t, func() { ObserveStage("unknown", time.Now()) })
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var commit *dto.Histogram
+	for _, mf := range families {
+		if mf.GetName() == "sei_cosmos_commit_ms" {
+			commit = mf.GetMetric()[0].GetHistogram()
+		}
+	}
+	require.NotNil(t, commit)
+	require.Equal(t, uint64(2), commit.GetSampleCount())
+	require.InDelta(t, 330, commit.GetSampleSum(), 0.001)
+	for _, b := range commit.GetBucket() {
+		switch b.GetUpperBound() {
+		case 25:
+			require.Equal(t, uint64(0), b.GetCumulativeCount())
+		case 50:
+			require.Equal(t, uint64(1), b.GetCumulativeCount())
+		case 500:
+			require.Equal(t, uint64(2), b.GetCumulativeCount())
+		}
+	}
+}
+
 func emitMetrics() {
 	ticker := time.NewTicker(time.Second)
 	timeout := time.After(30 * time.Second)
@@ -22,6 +22,11 @@ type MidBlocker func(ctx Context, height int64) []abci.Event
 // e.g. BFT timestamps rather than block height for any periodic EndBlock logic
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
 
+// WarmUpper loads the state the application uses the most in its caches, e.g.
+// the validator set or the params, before the node serves its first requests.
+// It runs on a branch of the last committed state, whose writes are discarded.
+type WarmUpper func(ctx Context) error
+
 // PeerFilter responds to p2p filtering queries from Tendermint
 type PeerFilter func(info string) abci.ResponseQuery
 
@@ -31,4 +36,12 @@ type ProcessProposalHandler func(ctx Context, req *abci.RequestProcessProposal)
 
 type FinalizeBlocker func(ctx Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error)
 
+// ExtendVoteHandler returns the extension the node attaches to its precommit
+// for the block of req, e.g. the prices observed by its oracle.
+type ExtendVoteHandler func(ctx Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error)
+
+// VerifyVoteExtensionHandler verifies the extension a validator attached to its
+// precommit, a rejected extension invalidating the precommit.
+type VerifyVoteExtensionHandler func(ctx Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error)
+
 type LoadVersionHandler func() error
@@ -0,0 +1,18 @@
+package accesscontrol
+
+import "strings"
+
+// IdentifierWildcard is the identifier of every key of a resource.
+const IdentifierWildcard = "*"
+
+// IdentifiersOverlap reports whether the identifiers a and b of the same
+// resource type may designate a common key. Identifiers are hex encoded key
+// prefixes, so an identifier covers the keys of every identifier it is a prefix
+// of: e.g. the balances prefix of an account covers the balance of the account
+// in each denom, while the balances of the account in two denoms are disjoint.
+func IdentifiersOverlap(a, b string) bool {
+	if a == IdentifierWildcard || b == IdentifierWildcard {
+		return true
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
@@ -0,0 +1,33 @@
+package accesscontrol
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifiersOverlap(t *testing.T) {
+	balances := hex.EncodeToString([]byte{0x02, 0x01, 'a'})
+	usei := balances + hex.EncodeToString([]byte("usei"))
+	uatom := balances + hex.EncodeToString([]byte("uatom"))
+	other := hex.EncodeToString([]byte{0x02, 0x01, 'b'}) + hex.EncodeToString([]byte("usei"))
+
+	tests := []struct {
+		name    string
+		a, b    string
+		overlap bool
+	}{
+		{"same identifier", usei, usei, true},
+		{"wildcard", IdentifierWildcard, usei, true},
+		{"account prefix covers its denoms", balances, usei, true},
+		{"denoms of an account", usei, uatom, false},
+		{"accounts", usei, other, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.overlap, IdentifiersOverlap(tt.a, tt.b))
+			require.Equal(t, tt.overlap, IdentifiersOverlap(tt.b, tt.a))
+		})
+	}
+}
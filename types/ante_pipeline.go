@@ -0,0 +1,205 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdkacltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// NamedAnteDecorator is a decorator of an AntePipeline.
+type NamedAnteDecorator struct {
+	Name      string
+	Decorator AnteFullDecorator
+	// Required decorators cannot be disabled, e.g. the ones setting up the gas
+	// meter or verifying the signatures
+	Required bool
+}
+
+// AntePipeline composes an ante chain out of named decorators, which apps can
+// insert, replace or disable by name before building the chain.
+//
+// Each decorator of the chain built is wrapped to measure its own duration and
+// gas, those of the decorators it calls being left out, as the samples
+// ante.decorator.time (in milliseconds) and ante.decorator.gas labeled with the
+// name of the decorator and the mode of the tx.
+//
+// The disabled decorators are skipped by CheckTx only, the decorators changing
+// the state of DeliverTx being part of consensus. Disabling one trades the
+// checks of the mempool for the latency of CheckTx.
+type AntePipeline struct {
+	decorators []NamedAnteDecorator
+	disabled   map[string]bool
+}
+
+// NewAntePipeline returns a pipeline of the decorators, the first being the
+// outermost.
+func NewAntePipeline(decorators ...NamedAnteDecorator) *AntePipeline {
+	return &AntePipeline{
+		decorators: append([]NamedAnteDecorator(nil), decorators...),
+		disabled:   make(map[string]bool),
+	}
+}
+
+// Names returns the names of the decorators in chain order.
+func (p *AntePipeline) Names() []string {
+	names := make([]string, len(p.decorators))
+	for i, d := range p.decorators {
+		names[i] = d.Name
+	}
+	return names
+}
+
+func (p *AntePipeline) index(name string) (int, error) {
+	for i, d := range p.decorators {
+		if d.Name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no ante decorator %s", name)
+}
+
+func (p *AntePipeline) insert(i int, decorator NamedAnteDecorator) error {
+	if _, err := p.index(decorator.Name); err == nil {
+		return fmt.Errorf("ante decorator %s already exists", decorator.Name)
+	}
+	p.decorators = append(p.decorators[:i], append([]NamedAnteDecorator{decorator}, p.decorators[i:]...)...)
+	return nil
+}
+
+// Append adds decorator at the end of the chain.
+func (p *AntePipeline) Append(decorator NamedAnteDecorator) error {
+	return p.insert(len(p.decorators), decorator)
+}
+
+// InsertBefore adds decorator right before the decorator named name.
+func (p *AntePipeline) InsertBefore(name string, decorator NamedAnteDecorator) error {
+	i, err := p.index(name)
+	if err != nil {
+		return err
+	}
+	return p.insert(i, decorator)
+}
+
+// InsertAfter adds decorator right after the decorator named name.
+func (p *AntePipeline) InsertAfter(name string, decorator NamedAnteDecorator) error {
+	i, err := p.index(name)
+	if err != nil {
+		return err
+	}
+	return p.insert(i+1, decorator)
+}
+
+// Replace replaces the decorator named name, keeping its name.
+func (p *AntePipeline) Replace(name string, decorator AnteFullDecorator) error {
+	i, err := p.index(name)
+	if err != nil {
+		return err
+	}
+	p.decorators[i].Decorator = decorator
+	return nil
+}
+
+// Disable makes CheckTx skip the decorators named names. It fails if one of
+// them does not exist or is required.
+func (p *AntePipeline) Disable(names ...string) error {
+	for _, name := range names {
+		i, err := p.index(name)
+		if err != nil {
+			return err
+		}
+		if p.decorators[i].Required {
+			return fmt.Errorf("ante decorator %s is required", name)
+		}
+	}
+	for _, name := range names {
+		p.disabled[name] = true
+	}
+	return nil
+}
+
+// Build chains the decorators of the pipeline, see ChainAnteDecorators.
+func (p *AntePipeline) Build() (AnteHandler, AnteDepGenerator) {
+	chain := make([]AnteFullDecorator, len(p.decorators))
+	for i, d := range p.decorators {
+		chain[i] = meteredAnteDecorator{name: d.Name, decorator: d.Decorator, disabled: p.disabled[d.Name]}
+	}
+	return ChainAnteDecorators(chain...)
+}
+
+// meteredAnteDecorator records the duration and the gas of a decorator of an
+// AntePipeline, and skips it in CheckTx if it is disabled.
+type meteredAnteDecorator struct {
+	name      string
+	decorator AnteFullDecorator
+	disabled  bool
+}
+
+func (d meteredAnteDecorator) AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (Context, error) {
+	if d.disabled && ctx.IsCheckTx() && !simulate {
+		return next(ctx, tx, simulate)
+	}
+
+	start := time.Now()
+	startGas := gasConsumed(ctx)
+	startMeter := ctx.GasMeter()
+	var nextDuration time.Duration
+	var gas uint64
+	nextCalled := false
+	newCtx, err := d.decorator.AnteHandle(ctx, tx, simulate, func(nextCtx Context, tx Tx, simulate bool) (Context, error) {
+		nextCalled = true
+		gas = gasUsedSince(nextCtx, startMeter, startGas)
+		nextStart := time.Now()
+		defer func() { nextDuration = time.Since(nextStart) }()
+		return next(nextCtx, tx, simulate)
+	})
+	if !nextCalled {
+		if newCtx.IsZero() {
+			newCtx = ctx
+		}
+		gas = gasUsedSince(newCtx, startMeter, startGas)
+	}
+
+	labels := []metrics.Label{telemetry.NewLabel("decorator", d.name), telemetry.NewLabel("mode", anteMode(ctx, simulate))}
+	telemetry.AddSampleWithLabels([]string{"ante", "decorator", "time"}, float32(time.Since(start)-nextDuration)/float32(time.Millisecond), labels)
+	telemetry.AddSampleWithLabels([]string{"ante", "decorator", "gas"}, float32(gas), labels)
+	return newCtx, err
+}
+
+func (d meteredAnteDecorator) AnteDeps(txDeps []sdkacltypes.AccessOperation, tx Tx, txIndex int, next AnteDepGenerator) ([]sdkacltypes.AccessOperation, error) {
+	return d.decorator.AnteDeps(txDeps, tx, txIndex, next)
+}
+
+func gasConsumed(ctx Context) uint64 {
+	if ctx.GasMeter() == nil {
+		return 0
+	}
+	return ctx.GasMeter().GasConsumed()
+}
+
+// gasUsedSince returns the gas consumed on the meter of ctx since a decorator
+// was called with startMeter at startGas, a decorator setting up a new meter
+// having used all the gas of the new meter.
+func gasUsedSince(ctx Context, startMeter GasMeter, startGas uint64) uint64 {
+	used := gasConsumed(ctx)
+	if ctx.GasMeter() == startMeter && used >= startGas {
+		return used - startGas
+	}
+	return used
+}
+
+func anteMode(ctx Context, simulate bool) string {
+	switch {
+	case simulate:
+		return "simulate"
+	case ctx.IsReCheckTx():
+		return "recheck"
+	case ctx.IsCheckTx():
+		return "check"
+	default:
+		return "deliver"
+	}
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type recordingDecorator struct {
+	name  string
+	calls *[]string
+}
+
+func (d recordingDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	*d.calls = append(*d.calls, d.name)
+	return next(ctx, tx, simulate)
+}
+
+func TestAntePipeline(t *testing.T) {
+	var calls []string
+	named := func(name string, required bool) sdk.NamedAnteDecorator {
+		return sdk.NamedAnteDecorator{Name: name, Decorator: sdk.DefaultWrappedAnteDecorator(recordingDecorator{name: name, calls: &calls}), Required: required}
+	}
+	pipeline := sdk.NewAntePipeline(named("setup", true), named("memo", false), named("sig", true))
+
+	require.NoError(t, pipeline.InsertBefore("memo", named("basic", false)))
+	require.NoError(t, pipeline.InsertAfter("sig", named("sequence", false)))
+	require.NoError(t, pipeline.Append(named("custom", false)))
+	require.Error(t, pipeline.Append(named("memo", false)))
+	require.Error(t, pipeline.InsertAfter("unknown", named("other", false)))
+	require.Equal(t, []string{"setup", "basic", "memo", "sig", "sequence", "custom"}, pipeline.Names())
+
+	require.NoError(t, pipeline.Replace("custom", sdk.DefaultWrappedAnteDecorator(recordingDecorator{name: "replaced", calls: &calls})))
+	require.Error(t, pipeline.Replace("unknown", sdk.DefaultWrappedAnteDecorator(recordingDecorator{name: "replaced", calls: &calls})))
+
+	// the required decorators cannot be disabled, nor the unknown ones
+	require.Error(t, pipeline.Disable("memo", "sig"))
+	require.Error(t, pipeline.Disable("unknown"))
+	require.NoError(t, pipeline.Disable("memo", "sequence"))
+
+	handler, _ := pipeline.Build()
+	run := func(ctx sdk.Context, simulate bool) []string {
+		calls = nil
+		_, err := handler(ctx, nil, simulate)
+		require.NoError(t, err)
+		return calls
+	}
+	// the disabled decorators are skipped by CheckTx only
+	require.Equal(t, []string{"setup", "basic", "sig", "replaced"}, run(sdk.Context{}.WithIsCheckTx(true), false))
+	require.Equal(t, []string{"setup", "basic", "memo", "sig", "sequence", "replaced"}, run(sdk.Context{}.WithIsCheckTx(true), true))
+	require.Equal(t, []string{"setup", "basic", "memo", "sig", "sequence", "replaced"}, run(sdk.Context{}, false))
+}
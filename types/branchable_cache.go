@@ -0,0 +1,14 @@
+package types
+
+// BranchableCache is a cache a module stores on the Context, with WithValue,
+// for instance of the accounts its keeper decoded. Txs executed concurrently
+// must not share a mutable cache: it would race, and what a tx finds in it
+// would depend on the other txs executing at the same time.
+//
+// Branch returns a copy of the cache that can be read and written without
+// affecting the cache or its other branches. The concurrent scheduler gives
+// every execution of a tx its own branch of the caches it is told about, see
+// tasks.WithBranchedCaches.
+type BranchableCache interface {
+	Branch() BranchableCache
+}
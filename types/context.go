@@ -36,11 +36,16 @@ type Context struct {
 	blockGasMeter GasMeter
 	checkTx       bool
 	recheckTx     bool // if recheckTx == true, then checkTx must also be true
+	replacementTx bool // if replacementTx == true, then checkTx must also be true
 	minGasPrice   DecCoins
 	consParams    *tmproto.ConsensusParams
 	eventManager  *EventManager
 	priority      int64 // The tx priority, only relevant in CheckTx
 
+	sequenceWindow uint64 // only relevant in CheckTx
+
+	voteExtensions *abci.ExtendedCommitInfo
+
 	txBlockingChannels   acltypes.MessageAccessOpsChannelMapping
 	txCompletionChannels acltypes.MessageAccessOpsChannelMapping
 	txMsgAccessOps       map[int][]acltypes.AccessOperation
@@ -48,8 +53,15 @@ type Context struct {
 	msgValidator *acltypes.MsgValidator
 	messageIndex int // Used to track current message being processed
 	txIndex      int
+	// txIncarnation is the number of earlier executions of the tx in its block
+	txIncarnation int
 
 	traceSpanContext context.Context
+	txCache          *TxCache   // shared by every execution of a tx within a block, if set
+	txCharges        *TxCharges // the fees deducted by this execution of the tx, if set
+	txTracer         *TxTracer
+	txResources      *TxResources      // the store accesses of this execution of the tx, if set
+	kvGasConfig      *stypes.GasConfig // the default costs of stypes.KVGasConfig if nil
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -104,10 +116,40 @@ func (c Context) IsReCheckTx() bool {
 	return c.recheckTx
 }
 
+// IsReplacementTx reports whether the tx being checked replaces a pending tx of
+// the mempool signed with the same sequence, which its signers already used.
+func (c Context) IsReplacementTx() bool {
+	return c.replacementTx
+}
+
+// VoteExtensions returns the precommits of the previous block with their vote
+// extensions, which Tendermint only passes to the proposer: it is set in the
+// context of PrepareProposal when vote extensions were enabled at the previous
+// height, nil otherwise.
+func (c Context) VoteExtensions() *abci.ExtendedCommitInfo {
+	return c.voteExtensions
+}
+
+// SequenceWindow returns the number of sequences from the sequence of an
+// account, its own included, that the tx being checked may be signed with. A
+// window of 0 or 1 requires the exact sequence.
+func (c Context) SequenceWindow() uint64 {
+	return c.sequenceWindow
+}
+
 func (c Context) MinGasPrices() DecCoins {
 	return c.minGasPrice
 }
 
+// KVGasConfig returns the gas costs of the operations on the KVStores of the
+// Context.
+func (c Context) KVGasConfig() stypes.GasConfig {
+	if c.kvGasConfig == nil {
+		return stypes.KVGasConfig()
+	}
+	return *c.kvGasConfig
+}
+
 func (c Context) EventManager() *EventManager {
 	return c.eventManager
 }
@@ -150,6 +192,26 @@ func (c Context) TraceSpanContext() context.Context {
 	return c.traceSpanContext
 }
 
+func (c Context) TxCache() *TxCache {
+	return c.txCache
+}
+
+func (c Context) TxCharges() *TxCharges {
+	return c.txCharges
+}
+
+func (c Context) TxTracer() *TxTracer {
+	return c.txTracer
+}
+
+func (c Context) TxResources() *TxResources {
+	return c.txResources
+}
+
+func (c Context) TxIncarnation() int {
+	return c.txIncarnation
+}
+
 // WithEventManager returns a Context with an updated tx priority
 func (c Context) WithPriority(p int64) Context {
 	c.priority = p
@@ -291,6 +353,30 @@ func (c Context) WithIsReCheckTx(isRecheckTx bool) Context {
 	return c
 }
 
+// WithIsReplacementTx called with true will also set true on checkTx, as only
+// the txs of the mempool are replaced.
+func (c Context) WithIsReplacementTx(isReplacementTx bool) Context {
+	if isReplacementTx {
+		c.checkTx = true
+	}
+	c.replacementTx = isReplacementTx
+	return c
+}
+
+// WithVoteExtensions returns a Context with the vote extensions of the previous
+// block, see VoteExtensions.
+func (c Context) WithVoteExtensions(extensions *abci.ExtendedCommitInfo) Context {
+	c.voteExtensions = extensions
+	return c
+}
+
+// WithSequenceWindow returns a Context with the sequence window of the tx
+// being checked, see SequenceWindow.
+func (c Context) WithSequenceWindow(window uint64) Context {
+	c.sequenceWindow = window
+	return c
+}
+
 // WithMinGasPrices returns a Context with an updated minimum gas price value
 func (c Context) WithMinGasPrices(gasPrices DecCoins) Context {
 	c.minGasPrice = gasPrices
@@ -349,6 +435,48 @@ func (c Context) WithTraceSpanContext(ctx context.Context) Context {
 	return c
 }
 
+// WithTxCache returns a Context with a cache of decoded txs and verified
+// signatures. Only stateless work may be cached in it.
+func (c Context) WithTxCache(txCache *TxCache) Context {
+	c.txCache = txCache
+	return c
+}
+
+// WithTxCharges returns a Context recording the fees deducted from the payer of
+// its tx in txCharges.
+func (c Context) WithTxCharges(txCharges *TxCharges) Context {
+	c.txCharges = txCharges
+	return c
+}
+
+// WithTxTracer returns a Context recording the store accesses of its txs in
+// txTracer.
+func (c Context) WithTxTracer(txTracer *TxTracer) Context {
+	c.txTracer = txTracer
+	return c
+}
+
+// WithTxResources returns a Context counting the store accesses of its tx in
+// txResources.
+func (c Context) WithTxResources(txResources *TxResources) Context {
+	c.txResources = txResources
+	return c
+}
+
+// WithTxIncarnation returns a Context for the execution of its tx following
+// incarnation earlier ones in its block, by the concurrent scheduler.
+func (c Context) WithTxIncarnation(incarnation int) Context {
+	c.txIncarnation = incarnation
+	return c
+}
+
+// WithKVGasConfig returns a Context charging the operations on its KVStores
+// the costs of gasConfig, e.g. the ones set through governance.
+func (c Context) WithKVGasConfig(gasConfig stypes.GasConfig) Context {
+	c.kvGasConfig = &gasConfig
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -385,20 +513,20 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key StoreKey) KVStore {
-	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), stypes.KVGasConfig())
+	return gaskv.NewStore(c.txResources.countStore(c.txTracer.traceStore(c.MultiStore().GetKVStore(key), key)), c.GasMeter(), c.KVGasConfig())
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key StoreKey) KVStore {
-	return gaskv.NewStore(c.MultiStore().GetKVStore(key), c.GasMeter(), stypes.TransientGasConfig())
+	return gaskv.NewStore(c.txTracer.traceStore(c.MultiStore().GetKVStore(key), key), c.GasMeter(), stypes.TransientGasConfig())
 }
 
 // CacheContext returns a new Context with the multi-store cached and a new
-// EventManager. The cached context is written to the context when writeCache
+// EventManager, with the limits of the current one. The cached context is written to the context when writeCache
 // is called.
 func (c Context) CacheContext() (cc Context, writeCache func()) {
 	cms := c.MultiStore().CacheMultiStore()
-	cc = c.WithMultiStore(cms).WithEventManager(NewEventManager())
+	cc = c.WithMultiStore(cms).WithEventManager(NewEventManagerWithLimits(c.EventManager().Limits()))
 	return cc, cms.Write
 }
 
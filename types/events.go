@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -24,13 +25,29 @@ import (
 // ----------------------------------------------------------------------------
 
 // EventManager implements a simple wrapper around a slice of Event objects that
-// can be emitted from.
+// can be emitted from. It is safe for concurrent use. With EventLimits, the
+// events and attributes past the limits are dropped as they are emitted, and
+// Events reports how many were with a trailing event of type
+// EventTypeEventsTruncated.
 type EventManager struct {
 	events Events
+	limits EventLimits
+	// droppedEvents and droppedAttributes count the events and attributes
+	// dropped for exceeding the limits
+	droppedEvents     int
+	droppedAttributes int
 
 	mtx sync.RWMutex
 }
 
+// EventLimits bounds the events an EventManager keeps, 0 meaning no limit.
+type EventLimits struct {
+	// MaxEvents is the number of events kept
+	MaxEvents int
+	// MaxAttributes is the number of attributes kept per event
+	MaxAttributes int
+}
+
 // Common Event Types and Attributes
 const (
 	EventTypeResourceAccess = "resource_access"
@@ -43,26 +60,67 @@ const (
 	AttributeKeyAccessType      = "access_type"
 	AttributeKeyAccessTypeWrite = "write"
 	AttributeKeyAccessTypeRead  = "read"
+
+	EventTypeDependencyMismatch = "dependency_mismatch"
+
+	AttributeKeyMsgTypeURL       = "msg_type_url"
+	AttributeKeyMsgIndex         = "msg_index"
+	AttributeKeyMissingAccessOps = "missing_access_ops"
+
+	AttributeKeyTxIndex = "tx_index"
+
+	// EventTypeEventsTruncated is the type of the event reporting the events
+	// and attributes an EventManager dropped for exceeding its limits.
+	EventTypeEventsTruncated = "events_truncated"
+
+	AttributeKeyDroppedEvents     = "dropped_events"
+	AttributeKeyDroppedAttributes = "dropped_attributes"
 )
 
 func NewEventManager() *EventManager {
-	em := EventManager{
-		mtx: sync.RWMutex{},
+	return NewEventManagerWithLimits(EventLimits{})
+}
+
+// NewEventManagerWithLimits returns an EventManager keeping the events within
+// limits.
+func NewEventManagerWithLimits(limits EventLimits) *EventManager {
+	return &EventManager{
+		events: EmptyEvents(),
+		limits: limits,
 	}
-	em.mtx.Lock()
-	defer em.mtx.Unlock()
-	em.events = EmptyEvents()
-	return &em
 }
 
-func (em *EventManager) Events() Events { return em.events }
+// Limits returns the limits of the events em keeps, for the event managers of
+// the nested executions to have the same.
+func (em *EventManager) Limits() EventLimits {
+	if em == nil {
+		return EventLimits{}
+	}
+	return em.limits
+}
+
+// Events returns the events emitted so far, followed by an event of type
+// EventTypeEventsTruncated if any was dropped or truncated.
+func (em *EventManager) Events() Events {
+	em.mtx.RLock()
+	defer em.mtx.RUnlock()
+	if em.droppedEvents == 0 && em.droppedAttributes == 0 {
+		return em.events
+	}
+	// the events emitted later must not overwrite the truncation event
+	return append(em.events[:len(em.events):len(em.events)], NewEvent(
+		EventTypeEventsTruncated,
+		NewAttribute(AttributeKeyDroppedEvents, strconv.Itoa(em.droppedEvents)),
+		NewAttribute(AttributeKeyDroppedAttributes, strconv.Itoa(em.droppedAttributes)),
+	))
+}
 
 // EmitEvent stores a single Event object.
 // Deprecated: Use EmitTypedEvent
 func (em *EventManager) EmitEvent(event Event) {
 	em.mtx.Lock()
 	defer em.mtx.Unlock()
-	em.events = em.events.AppendEvent(event)
+	em.appendEvent(event)
 }
 
 // EmitEvents stores a series of Event objects.
@@ -70,14 +128,54 @@ func (em *EventManager) EmitEvent(event Event) {
 func (em *EventManager) EmitEvents(events Events) {
 	em.mtx.Lock()
 	defer em.mtx.Unlock()
-	em.events = em.events.AppendEvents(events)
+	for _, event := range events {
+		em.appendEvent(event)
+	}
+}
+
+// appendEvent stores event within the limits. The truncation events, e.g. of
+// the event managers of nested executions, are added to the counts of em.
+func (em *EventManager) appendEvent(event Event) {
+	if event.Type == EventTypeEventsTruncated {
+		for _, attr := range event.Attributes {
+			n, _ := strconv.Atoi(string(attr.Value))
+			switch string(attr.Key) {
+			case AttributeKeyDroppedEvents:
+				em.droppedEvents += n
+			case AttributeKeyDroppedAttributes:
+				em.droppedAttributes += n
+			}
+		}
+		return
+	}
+	if em.limits.MaxEvents > 0 && len(em.events) >= em.limits.MaxEvents {
+		em.droppedEvents++
+		return
+	}
+	if em.limits.MaxAttributes > 0 && len(event.Attributes) > em.limits.MaxAttributes {
+		em.droppedAttributes += len(event.Attributes) - em.limits.MaxAttributes
+		event.Attributes = event.Attributes[:em.limits.MaxAttributes:em.limits.MaxAttributes]
+	}
+	em.events = append(em.events, event)
+}
+
+// Apply returns events kept within the limits, followed by an event of type
+// EventTypeEventsTruncated if any was dropped or truncated, as an EventManager
+// with the limits would.
+func (l EventLimits) Apply(events []abci.Event) []abci.Event {
+	if l == (EventLimits{}) {
+		return events
+	}
+	em := NewEventManagerWithLimits(l)
+	for _, event := range events {
+		em.appendEvent(Event(event))
+	}
+	return em.Events().ToABCIEvents()
 }
 
 // ABCIEvents returns all stored Event objects as abci.Event objects.
 func (em *EventManager) ABCIEvents() []abci.Event {
-	em.mtx.RLock()
-	defer em.mtx.RUnlock()
-	return em.events.ToABCIEvents()
+	return em.Events().ToABCIEvents()
 }
 
 // EmitTypedEvent takes typed event and emits converting it into Event
@@ -263,6 +361,58 @@ func (e Events) AppendEvents(events Events) Events {
 	return append(e, events...)
 }
 
+// WithMsgIndex returns the events tagged with the msg_index attribute, leaving
+// the ones that already have it as they are.
+func (e Events) WithMsgIndex(msgIndex int) Events {
+	res := make(Events, len(e))
+	for i, ev := range e {
+		res[i] = Event(withIndexAttribute(abci.Event(ev), AttributeKeyMsgIndex, msgIndex))
+	}
+	return res
+}
+
+// SequenceEvents tags the events of the tx at txIndex with the tx_index
+// attribute and orders them by msg index, the events no msg emitted, such as
+// those of the ante handler, coming first. The events of a msg keep their
+// order, so a tx gives the same sequence of events however its execution
+// interleaved with the execution of other txs.
+func SequenceEvents(txIndex int, events []abci.Event) []abci.Event {
+	res := make([]abci.Event, len(events))
+	for i, e := range events {
+		res[i] = withIndexAttribute(e, AttributeKeyTxIndex, txIndex)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return eventMsgIndex(res[i]) < eventMsgIndex(res[j])
+	})
+	return res
+}
+
+// withIndexAttribute returns a copy of e with the attribute key set to index,
+// unless e already has the attribute.
+func withIndexAttribute(e abci.Event, key string, index int) abci.Event {
+	for _, attr := range e.Attributes {
+		if string(attr.Key) == key {
+			return e
+		}
+	}
+	attrs := make([]abci.EventAttribute, len(e.Attributes), len(e.Attributes)+1)
+	copy(attrs, e.Attributes)
+	e.Attributes = append(attrs, abci.EventAttribute{Key: []byte(key), Value: []byte(strconv.Itoa(index))})
+	return e
+}
+
+// eventMsgIndex returns the msg_index attribute of e, or -1 if it has none.
+func eventMsgIndex(e abci.Event) int {
+	for _, attr := range e.Attributes {
+		if string(attr.Key) == AttributeKeyMsgIndex {
+			if index, err := strconv.Atoi(string(attr.Value)); err == nil {
+				return index
+			}
+		}
+	}
+	return -1
+}
+
 // ToABCIEvents converts a slice of Event objects to a slice of abci.Event
 // objects.
 func (e Events) ToABCIEvents() []abci.Event {
@@ -3,6 +3,7 @@ package types_test
 import (
 	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -59,6 +60,29 @@ func (s *eventsTestSuite) TestToABCIEvents() {
 	s.Require().Equal(abciEvents[0].Attributes, e[0].Attributes)
 }
 
+func (s *eventsTestSuite) TestSequenceEvents() {
+	ante := sdk.NewEvent("ante", sdk.NewAttribute("fee", "1usei"))
+	msg0 := sdk.Events{sdk.NewEvent("message", sdk.NewAttribute("action", "send"))}.WithMsgIndex(0)
+	msg1 := sdk.Events{
+		sdk.NewEvent("message", sdk.NewAttribute("action", "delegate")),
+		sdk.NewEvent("delegate", sdk.NewAttribute(sdk.AttributeKeyMsgIndex, "1")),
+	}.WithMsgIndex(1)
+	s.Require().Equal(sdk.NewEvent("message", sdk.NewAttribute("action", "delegate"), sdk.NewAttribute("msg_index", "1")), msg1[0])
+	s.Require().Len(msg1[1].Attributes, 1)
+
+	// the events of the msgs come in msg order, after the ones of the ante handler
+	events := sdk.Events{msg1[0], ante, msg0[0], msg1[1]}.ToABCIEvents()
+	sequenced := sdk.SequenceEvents(3, events)
+	s.Require().Equal(sdk.Events{
+		ante.AppendAttributes(sdk.NewAttribute("tx_index", "3")),
+		msg0[0].AppendAttributes(sdk.NewAttribute("tx_index", "3")),
+		msg1[0].AppendAttributes(sdk.NewAttribute("tx_index", "3")),
+		msg1[1].AppendAttributes(sdk.NewAttribute("tx_index", "3")),
+	}.ToABCIEvents(), sequenced)
+	// the events passed in are left untouched
+	s.Require().Len(events[0].Attributes, 2)
+}
+
 func (s *eventsTestSuite) TestEventManager() {
 	em := sdk.NewEventManager()
 	event := sdk.NewEvent("reward", sdk.NewAttribute("x", "y"))
@@ -71,6 +95,59 @@ func (s *eventsTestSuite) TestEventManager() {
 	s.Require().Equal(em.Events(), events.AppendEvent(event))
 }
 
+func (s *eventsTestSuite) TestEventManagerLimits() {
+	em := sdk.NewEventManagerWithLimits(sdk.EventLimits{MaxEvents: 2, MaxAttributes: 1})
+	e1 := sdk.NewEvent("transfer", sdk.NewAttribute("sender", "foo"), sdk.NewAttribute("amount", "1"))
+	e2 := sdk.NewEvent("message", sdk.NewAttribute("action", "send"))
+	em.EmitEvents(sdk.Events{e1, e2, e2})
+	truncated := sdk.NewEvent(sdk.EventTypeEventsTruncated,
+		sdk.NewAttribute(sdk.AttributeKeyDroppedEvents, "1"),
+		sdk.NewAttribute(sdk.AttributeKeyDroppedAttributes, "1"),
+	)
+	expected := sdk.Events{sdk.NewEvent("transfer", sdk.NewAttribute("sender", "foo")), e2, truncated}
+	s.Require().Equal(expected, em.Events())
+	s.Require().Equal(expected.ToABCIEvents(), em.ABCIEvents())
+	// the attributes of the emitted event are left as they are
+	s.Require().Len(e1.Attributes, 2)
+
+	// the truncation events of the nested event managers add up
+	parent := sdk.NewEventManager()
+	parent.EmitEvents(em.Events())
+	parent.EmitEvent(e2)
+	parent.EmitEvents(em.Events())
+	s.Require().Equal(sdk.Events{
+		expected[0], e2, e2, expected[0], e2,
+		sdk.NewEvent(sdk.EventTypeEventsTruncated,
+			sdk.NewAttribute(sdk.AttributeKeyDroppedEvents, "2"),
+			sdk.NewAttribute(sdk.AttributeKeyDroppedAttributes, "2"),
+		),
+	}, parent.Events())
+	s.Require().Equal(sdk.EventLimits{}, parent.Limits())
+
+	s.Require().Equal(em.ABCIEvents(), em.Limits().Apply(sdk.Events{e1, e2, e2}.ToABCIEvents()))
+	s.Require().Equal(parent.ABCIEvents(), sdk.EventLimits{}.Apply(parent.ABCIEvents()))
+}
+
+func (s *eventsTestSuite) TestEventManagerConcurrentEmit() {
+	em := sdk.NewEventManagerWithLimits(sdk.EventLimits{MaxEvents: 50})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				em.EmitEvent(sdk.NewEvent("transfer"))
+				_ = em.Events()
+			}
+		}()
+	}
+	wg.Wait()
+	events := em.Events()
+	s.Require().Len(events, 51)
+	s.Require().Equal(sdk.EventTypeEventsTruncated, events[50].Type)
+	s.Require().Equal("50", string(events[50].Attributes[0].Value))
+}
+
 func (s *eventsTestSuite) TestEmitTypedEvent() {
 	s.Run("deterministic key-value order", func() {
 		for i := 0; i < 10; i++ {
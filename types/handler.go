@@ -12,6 +12,11 @@ type Handler func(ctx Context, msg Msg) (*Result, error)
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, err error)
 type AnteDepGenerator func(txDeps []sdkacltypes.AccessOperation, tx Tx, txIndex int) (newTxDeps []sdkacltypes.AccessOperation, err error)
 
+// TxPriorityHandler returns the priority of a tx passing the AnteHandler of
+// CheckTx, given the context it returned, whose Priority is the one set by the
+// AnteHandler. An error rejects the tx.
+type TxPriorityHandler func(ctx Context, tx Tx) (priority int64, err error)
+
 // AnteDecorator wraps the next AnteHandler to perform custom pre- and post-processing.
 type AnteDecorator interface {
 	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, err error)
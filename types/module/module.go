@@ -199,6 +199,13 @@ type EndBlockAppModule interface {
 	EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate
 }
 
+// WarmUpAppModule is an extension interface for the AppModules loading the state
+// they use the most in their caches before the node serves its first requests.
+type WarmUpAppModule interface {
+	AppModule
+	WarmUp(sdk.Context) error
+}
+
 // GenesisOnlyAppModule is an AppModule that only has import/export functionality
 type GenesisOnlyAppModule struct {
 	AppModuleGenesis
@@ -246,6 +253,7 @@ type Manager struct {
 	OrderBeginBlockers []string
 	OrderMidBlockers   []string
 	OrderEndBlockers   []string
+	OrderWarmUps       []string
 	OrderMigrations    []string
 }
 
@@ -265,6 +273,7 @@ func NewManager(modules ...AppModule) *Manager {
 		OrderExportGenesis: modulesStr,
 		OrderBeginBlockers: modulesStr,
 		OrderEndBlockers:   modulesStr,
+		OrderWarmUps:       modulesStr,
 	}
 }
 
@@ -297,6 +306,12 @@ func (m *Manager) SetOrderEndBlockers(moduleNames ...string) {
 	m.OrderEndBlockers = moduleNames
 }
 
+// SetOrderWarmUps sets the order of warm-up calls
+func (m *Manager) SetOrderWarmUps(moduleNames ...string) {
+	m.assertNoForgottenModules("SetOrderWarmUps", moduleNames)
+	m.OrderWarmUps = moduleNames
+}
+
 // SetOrderMigrations sets the order of migrations to be run. If not set
 // then migrations will be run with an order defined in `DefaultMigrationsOrder`.
 func (m *Manager) SetOrderMigrations(moduleNames ...string) {
@@ -332,13 +347,26 @@ func (m *Manager) RegisterServices(cfg Configurator) {
 
 // InitGenesis performs init genesis functionality for modules
 func (m *Manager) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, genesisData map[string]json.RawMessage) abci.ResponseInitChain {
+	return m.InitGenesisFrom(ctx, cdc, func(moduleName string) (json.RawMessage, error) {
+		return genesisData[moduleName], nil
+	})
+}
+
+// InitGenesisFrom performs init genesis functionality for modules, loading the
+// genesis state of each module only when the module is initialized. Modules
+// without genesis state are skipped.
+func (m *Manager) InitGenesisFrom(ctx sdk.Context, cdc codec.JSONCodec, load func(moduleName string) (json.RawMessage, error)) abci.ResponseInitChain {
 	var validatorUpdates []abci.ValidatorUpdate
 	for _, moduleName := range m.OrderInitGenesis {
-		if genesisData[moduleName] == nil {
+		genesisData, err := load(moduleName)
+		if err != nil {
+			panic(fmt.Sprintf("failed to load the genesis state of module %s: %s", moduleName, err))
+		}
+		if genesisData == nil {
 			continue
 		}
 
-		moduleValUpdates := m.Modules[moduleName].InitGenesis(ctx, cdc, genesisData[moduleName])
+		moduleValUpdates := m.Modules[moduleName].InitGenesis(ctx, cdc, genesisData)
 
 		// use these validator updates if provided, the module manager assumes
 		// only one module will update the validator set
@@ -358,11 +386,24 @@ func (m *Manager) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, genesisData
 // ExportGenesis performs export genesis functionality for modules
 func (m *Manager) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) map[string]json.RawMessage {
 	genesisData := make(map[string]json.RawMessage)
+	_ = m.ExportGenesisTo(ctx, cdc, func(moduleName string, state json.RawMessage) error {
+		genesisData[moduleName] = state
+		return nil
+	})
+
+	return genesisData
+}
+
+// ExportGenesisTo performs export genesis functionality for modules, passing
+// the genesis state of each module to write as soon as it is exported.
+func (m *Manager) ExportGenesisTo(ctx sdk.Context, cdc codec.JSONCodec, write func(moduleName string, state json.RawMessage) error) error {
 	for _, moduleName := range m.OrderExportGenesis {
-		genesisData[moduleName] = m.Modules[moduleName].ExportGenesis(ctx, cdc)
+		if err := write(moduleName, m.Modules[moduleName].ExportGenesis(ctx, cdc)); err != nil {
+			return err
+		}
 	}
 
-	return genesisData
+	return nil
 }
 
 // assertNoForgottenModules checks that we didn't forget any modules in the
@@ -567,6 +608,24 @@ func (m *Manager) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) abci.Respo
 	}
 }
 
+// WarmUp performs the warm-up of all modules, stopping at the first module
+// failing to warm up. It implements sdk.WarmUpper.
+func (m *Manager) WarmUp(ctx sdk.Context) error {
+	defer telemetry.MeasureSince(time.Now(), "module", "total_warm_up")
+	for _, moduleName := range m.OrderWarmUps {
+		module, ok := m.Modules[moduleName].(WarmUpAppModule)
+		if !ok {
+			continue
+		}
+		moduleStartTime := time.Now()
+		if err := module.WarmUp(ctx); err != nil {
+			return fmt.Errorf("failed to warm up module %s: %w", moduleName, err)
+		}
+		telemetry.ModuleMeasureSince(moduleName, moduleStartTime, "module", "warm_up")
+	}
+	return nil
+}
+
 // GetVersionMap gets consensus version from all modules
 func (m *Manager) GetVersionMap() VersionMap {
 	vermap := make(VersionMap)
@@ -221,6 +221,43 @@ func TestManager_InitGenesis(t *testing.T) {
 	require.Panics(t, func() { mm.InitGenesis(ctx, cdc, genesisData) })
 }
 
+func TestManager_InitGenesisFrom(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockAppModule1 := mocks.NewMockAppModule(mockCtrl)
+	mockAppModule2 := mocks.NewMockAppModule(mockCtrl)
+	mockAppModule1.EXPECT().Name().Times(2).Return("module1")
+	mockAppModule2.EXPECT().Name().Times(2).Return("module2")
+	mm := module.NewManager(mockAppModule1, mockAppModule2)
+
+	ctx := sdk.Context{}
+	interfaceRegistry := types.NewInterfaceRegistry()
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	// the genesis state of a module is loaded when the module is initialized
+	var loaded []string
+	mockAppModule1.EXPECT().InitGenesis(gomock.Eq(ctx), gomock.Eq(cdc), gomock.Eq(json.RawMessage(`{"key": "value"}`))).Times(1).DoAndReturn(
+		func(sdk.Context, codec.JSONCodec, json.RawMessage) []abci.ValidatorUpdate {
+			require.Equal(t, []string{"module1"}, loaded)
+			return nil
+		})
+	res := mm.InitGenesisFrom(ctx, cdc, func(moduleName string) (json.RawMessage, error) {
+		loaded = append(loaded, moduleName)
+		if moduleName == "module1" {
+			return json.RawMessage(`{"key": "value"}`), nil
+		}
+		return nil, nil
+	})
+	require.Equal(t, abci.ResponseInitChain{Validators: []abci.ValidatorUpdate(nil)}, res)
+	require.Equal(t, []string{"module1", "module2"}, loaded)
+
+	// test panic
+	require.Panics(t, func() {
+		mm.InitGenesisFrom(ctx, cdc, func(string) (json.RawMessage, error) { return nil, errors.New("failed") })
+	})
+}
+
 func TestManager_ExportGenesis(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	t.Cleanup(mockCtrl.Finish)
@@ -243,6 +280,11 @@ func TestManager_ExportGenesis(t *testing.T) {
 		"module1": json.RawMessage(`{"key1": "value1"}`),
 		"module2": json.RawMessage(`{"key2": "value2"}`)}
 	require.Equal(t, want, mm.ExportGenesis(ctx, cdc))
+
+	// the export stops at the first error writing a genesis state
+	mockAppModule1.EXPECT().ExportGenesis(gomock.Eq(ctx), gomock.Eq(cdc)).Times(1).Return(json.RawMessage(`{"key1": "value1"}`))
+	err := mm.ExportGenesisTo(ctx, cdc, func(string, json.RawMessage) error { return errors.New("failed") })
+	require.EqualError(t, err, "failed")
 }
 
 func TestManager_BeginBlock(t *testing.T) {
@@ -308,3 +350,37 @@ func TestManager_EndBlock(t *testing.T) {
 	mockAppModule2.EXPECT().EndBlock(gomock.Any(), gomock.Eq(req)).Times(1).Return([]abci.ValidatorUpdate{{}})
 	require.Panics(t, func() { mm.EndBlock(sdk.NewContext(nil, tmproto.Header{}, false, nil), req) })
 }
+
+// warmUpModule is an AppModule warming up with warmUp.
+type warmUpModule struct {
+	*mocks.MockAppModule
+	warmUp func(sdk.Context) error
+}
+
+func (m warmUpModule) WarmUp(ctx sdk.Context) error { return m.warmUp(ctx) }
+
+func TestManager_WarmUp(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	var warmedUp []string
+	newModule := func(name string, err error) module.AppModule {
+		mockAppModule := mocks.NewMockAppModule(mockCtrl)
+		mockAppModule.EXPECT().Name().Times(2).Return(name)
+		return warmUpModule{MockAppModule: mockAppModule, warmUp: func(sdk.Context) error {
+			warmedUp = append(warmedUp, name)
+			return err
+		}}
+	}
+	mockAppModule3 := mocks.NewMockAppModule(mockCtrl)
+	mockAppModule3.EXPECT().Name().Times(2).Return("module3")
+	mm := module.NewManager(newModule("module1", nil), newModule("module2", errFoo), mockAppModule3)
+	require.Equal(t, []string{"module1", "module2", "module3"}, mm.OrderWarmUps)
+	mm.SetOrderWarmUps("module3", "module1", "module2")
+
+	ctx := sdk.NewContext(nil, tmproto.Header{}, false, nil)
+	require.ErrorIs(t, mm.WarmUp(ctx), errFoo)
+	require.Equal(t, []string{"module1", "module2"}, warmedUp)
+
+	require.Panics(t, func() { mm.SetOrderWarmUps("module1") })
+}
@@ -0,0 +1,58 @@
+package occ
+
+import (
+	"errors"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Codespace is the codespace of the failures of the concurrent execution of
+// the txs of a block, for clients and indexers to tell them apart from the
+// failures of the txs themselves and retry the txs they failed.
+const Codespace = "occ"
+
+// The codes of these errors are stable, and must not be reused.
+var (
+	// ErrMaxIncarnationsExceeded is returned for a tx still invalid once it
+	// executed as many times as the scheduler allows.
+	ErrMaxIncarnationsExceeded = sdkerrors.Register(Codespace, 2, "max incarnations exceeded")
+
+	// ErrEstimateReadTimeout is returned for an execution of a tx aborted as it
+	// read the estimated writes of a tx before it, or ran past its time budget
+	// waiting on them.
+	ErrEstimateReadTimeout = sdkerrors.Register(Codespace, 3, "estimate read timeout")
+
+	// ErrWriteSetApply is returned when the writes of the txs of a block
+	// cannot be applied to the stores of the block.
+	ErrWriteSetApply = sdkerrors.Register(Codespace, 4, "failed to apply write set")
+
+	// ErrValidationPanic is returned for a tx whose validation panicked as many
+	// times as the scheduler allows.
+	ErrValidationPanic = sdkerrors.Register(Codespace, 5, "validation panicked")
+
+	// ErrWritesetMismatch is returned when the writes committed for a block
+	// differ from the expected ones.
+	ErrWritesetMismatch = sdkerrors.Register(Codespace, 6, "writeset mismatch")
+
+	// ErrIndependenceViolated is returned when txs declared independent
+	// accessed the same state.
+	ErrIndependenceViolated = sdkerrors.Register(Codespace, 7, "independence violated")
+
+	// ErrGasInvariantViolated is returned when the block gas of the txs of a
+	// block differs from the gas used by their responses.
+	ErrGasInvariantViolated = sdkerrors.Register(Codespace, 8, "gas invariant violated")
+)
+
+// AbortError returns the error of the execution of a tx stopped by abort,
+// carrying the codespace and code of its cause and a log that only depends on
+// abort, so that the same abort always results in the same response.
+func AbortError(abort Abort) error {
+	switch {
+	case errors.Is(abort.Err, ErrTimeout):
+		return sdkerrors.Wrap(ErrEstimateReadTimeout, "execution timed out")
+	case errors.Is(abort.Err, ErrReadEstimate):
+		return sdkerrors.Wrapf(ErrEstimateReadTimeout, "read an estimate of tx %d", abort.DependentTxIdx)
+	default:
+		return sdkerrors.Wrapf(ErrEstimateReadTimeout, "aborted by tx %d: %v", abort.DependentTxIdx, abort.Err)
+	}
+}
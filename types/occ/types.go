@@ -0,0 +1,34 @@
+package occ
+
+import (
+	"errors"
+)
+
+var (
+	ErrReadEstimate = errors.New("multiversion store value contains estimate, cannot read, aborting")
+	ErrTimeout      = errors.New("transaction execution timed out, aborting")
+)
+
+// Abort contains the information for a transaction's conflict
+type Abort struct {
+	DependentTxIdx int
+	Err            error
+}
+
+// NewEstimateAbort returns an Abort for a read of an ESTIMATE written by the
+// transaction at dependentTxIdx.
+func NewEstimateAbort(dependentTxIdx int) Abort {
+	return Abort{
+		DependentTxIdx: dependentTxIdx,
+		Err:            ErrReadEstimate,
+	}
+}
+
+// NewTimeoutAbort returns an Abort for a transaction that ran past its time
+// budget. It does not depend on any other transaction, so DependentTxIdx is -1.
+func NewTimeoutAbort() Abort {
+	return Abort{
+		DependentTxIdx: -1,
+		Err:            ErrTimeout,
+	}
+}
@@ -141,16 +141,17 @@ func Paginate(
 	return res, nil
 }
 
+// getIterator returns the iterator over the keys of prefixStore from start, in
+// ascending order, or else from start down to the first key if reverse is set.
+// The key of start is iterated over whether it is in the store or not, e.g. for
+// the next key of a page whose key was deleted since.
 func getIterator(prefixStore types.KVStore, start []byte, reverse bool) db.Iterator {
 	if reverse {
 		var end []byte
 		if start != nil {
-			itr := prefixStore.Iterator(start, nil)
-			defer itr.Close()
-			if itr.Valid() {
-				itr.Next()
-				end = itr.Key()
-			}
+			// the smallest key greater than start, the end being exclusive
+			end = make([]byte, len(start)+1)
+			copy(end, start)
 		}
 		return prefixStore.ReverseIterator(nil, end)
 	}
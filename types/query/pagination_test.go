@@ -292,6 +292,44 @@ func (s *paginationTestSuite) TestReversePagination() {
 	s.Require().Nil(res.Pagination.NextKey)
 }
 
+func (s *paginationTestSuite) TestReversePaginationKeyNotInStore() {
+	app, ctx, _ := setupTest()
+	store := prefix.NewStore(ctx.KVStore(app.GetKey(types.StoreKey)), []byte("test"))
+	for _, key := range []string{"a", "c", "e"} {
+		store.Set([]byte(key), []byte(key))
+	}
+	paginate := func(pageReq *query.PageRequest) ([]string, *query.PageResponse) {
+		var keys []string
+		pageRes, err := query.Paginate(store, pageReq, func(key []byte, _ []byte) error {
+			keys = append(keys, string(key))
+			return nil
+		})
+		s.Require().NoError(err)
+		return keys, pageRes
+	}
+
+	s.T().Log("verify a reverse page starts from its key, or else from the first key before it")
+	keys, pageRes := paginate(&query.PageRequest{Key: []byte("c"), Limit: 1, Reverse: true})
+	s.Require().Equal([]string{"c"}, keys)
+	s.Require().Equal([]byte("a"), pageRes.NextKey)
+	keys, pageRes = paginate(&query.PageRequest{Key: []byte("d"), Reverse: true})
+	s.Require().Equal([]string{"c", "a"}, keys)
+	s.Require().Nil(pageRes.NextKey)
+	keys, _ = paginate(&query.PageRequest{Key: []byte("f"), Reverse: true})
+	s.Require().Equal([]string{"e", "c", "a"}, keys)
+
+	s.T().Log("verify filtered pagination starts from the same key")
+	var filtered []string
+	_, err := query.FilteredPaginate(store, &query.PageRequest{Key: []byte("d"), Reverse: true}, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			filtered = append(filtered, string(key))
+		}
+		return true, nil
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"c", "a"}, filtered)
+}
+
 func ExamplePaginate() {
 	app, ctx, _ := setupTest()
 
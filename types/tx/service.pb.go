@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	types "github.com/cosmos/cosmos-sdk/types"
+	accesscontrol "github.com/cosmos/cosmos-sdk/types/accesscontrol"
 	query "github.com/cosmos/cosmos-sdk/types/query"
 	_ "github.com/gogo/protobuf/gogoproto"
 	grpc1 "github.com/gogo/protobuf/grpc"
@@ -685,6 +686,277 @@ func (m *GetBlockWithTxsResponse) GetPagination() *query.PageResponse {
 	return nil
 }
 
+// TraceTxRequest is the request type for the Service.TraceTx RPC method.
+type TraceTxRequest struct {
+	// hash is the tx hash to trace, encoded as a hex string.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *TraceTxRequest) Reset()         { *m = TraceTxRequest{} }
+func (m *TraceTxRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceTxRequest) ProtoMessage()    {}
+func (*TraceTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{10}
+}
+func (m *TraceTxRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceTxRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraceTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTxRequest.Merge(m, src)
+}
+func (m *TraceTxRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTxRequest proto.InternalMessageInfo
+
+func (m *TraceTxRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// TraceTxResponse is the response type for the Service.TraceTx RPC method.
+type TraceTxResponse struct {
+	Trace *types.TxTrace `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+}
+
+func (m *TraceTxResponse) Reset()         { *m = TraceTxResponse{} }
+func (m *TraceTxResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceTxResponse) ProtoMessage()    {}
+func (*TraceTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{11}
+}
+func (m *TraceTxResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceTxResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraceTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTxResponse.Merge(m, src)
+}
+func (m *TraceTxResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTxResponse proto.InternalMessageInfo
+
+func (m *TraceTxResponse) GetTrace() *types.TxTrace {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
+// SimulateWithTraceRequest is the request type for the
+// Service.SimulateWithTrace RPC method.
+type SimulateWithTraceRequest struct {
+	// tx_bytes is the raw transaction.
+	TxBytes []byte `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	// include_store_accesses keeps every store access in the trace. Otherwise
+	// the phases of the trace only summarize them.
+	IncludeStoreAccesses bool `protobuf:"varint,2,opt,name=include_store_accesses,json=includeStoreAccesses,proto3" json:"include_store_accesses,omitempty"`
+}
+
+func (m *SimulateWithTraceRequest) Reset()         { *m = SimulateWithTraceRequest{} }
+func (m *SimulateWithTraceRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateWithTraceRequest) ProtoMessage()    {}
+func (*SimulateWithTraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{12}
+}
+func (m *SimulateWithTraceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateWithTraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateWithTraceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateWithTraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateWithTraceRequest.Merge(m, src)
+}
+func (m *SimulateWithTraceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateWithTraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateWithTraceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateWithTraceRequest proto.InternalMessageInfo
+
+func (m *SimulateWithTraceRequest) GetTxBytes() []byte {
+	if m != nil {
+		return m.TxBytes
+	}
+	return nil
+}
+
+func (m *SimulateWithTraceRequest) GetIncludeStoreAccesses() bool {
+	if m != nil {
+		return m.IncludeStoreAccesses
+	}
+	return false
+}
+
+// SimulateWithTraceResponse is the response type for the
+// Service.SimulateWithTrace RPC method.
+type SimulateWithTraceResponse struct {
+	// trace is the trace of the simulation. Its error is set if the transaction
+	// failed.
+	Trace *types.TxTrace `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+	// result is the result of the simulation, or empty if the transaction
+	// failed.
+	Result *types.Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *SimulateWithTraceResponse) Reset()         { *m = SimulateWithTraceResponse{} }
+func (m *SimulateWithTraceResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateWithTraceResponse) ProtoMessage()    {}
+func (*SimulateWithTraceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{13}
+}
+func (m *SimulateWithTraceResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateWithTraceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateWithTraceResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateWithTraceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateWithTraceResponse.Merge(m, src)
+}
+func (m *SimulateWithTraceResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateWithTraceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateWithTraceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateWithTraceResponse proto.InternalMessageInfo
+
+func (m *SimulateWithTraceResponse) GetTrace() *types.TxTrace {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
+func (m *SimulateWithTraceResponse) GetResult() *types.Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// BroadcastTxWithAccessOpsRequest is the request type for the
+// Service.BroadcastTxWithAccessOps RPC method.
+type BroadcastTxWithAccessOpsRequest struct {
+	// tx_bytes is the raw transaction.
+	TxBytes []byte        `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	Mode    BroadcastMode `protobuf:"varint,2,opt,name=mode,proto3,enum=cosmos.tx.v1beta1.BroadcastMode" json:"mode,omitempty"`
+	// access_ops are the access operations of the transaction, its ante handler
+	// and messages included.
+	AccessOps []accesscontrol.AccessOperation `protobuf:"bytes,3,rep,name=access_ops,json=accessOps,proto3" json:"access_ops"`
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) Reset()         { *m = BroadcastTxWithAccessOpsRequest{} }
+func (m *BroadcastTxWithAccessOpsRequest) String() string { return proto.CompactTextString(m) }
+func (*BroadcastTxWithAccessOpsRequest) ProtoMessage()    {}
+func (*BroadcastTxWithAccessOpsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{14}
+}
+func (m *BroadcastTxWithAccessOpsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BroadcastTxWithAccessOpsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BroadcastTxWithAccessOpsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BroadcastTxWithAccessOpsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastTxWithAccessOpsRequest.Merge(m, src)
+}
+func (m *BroadcastTxWithAccessOpsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BroadcastTxWithAccessOpsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastTxWithAccessOpsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastTxWithAccessOpsRequest proto.InternalMessageInfo
+
+func (m *BroadcastTxWithAccessOpsRequest) GetTxBytes() []byte {
+	if m != nil {
+		return m.TxBytes
+	}
+	return nil
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) GetMode() BroadcastMode {
+	if m != nil {
+		return m.Mode
+	}
+	return BroadcastMode_BROADCAST_MODE_UNSPECIFIED
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) GetAccessOps() []accesscontrol.AccessOperation {
+	if m != nil {
+		return m.AccessOps
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("cosmos.tx.v1beta1.OrderBy", OrderBy_name, OrderBy_value)
 	golang_proto.RegisterEnum("cosmos.tx.v1beta1.OrderBy", OrderBy_name, OrderBy_value)
@@ -710,6 +982,16 @@ func init() {
 	golang_proto.RegisterType((*GetBlockWithTxsRequest)(nil), "cosmos.tx.v1beta1.GetBlockWithTxsRequest")
 	proto.RegisterType((*GetBlockWithTxsResponse)(nil), "cosmos.tx.v1beta1.GetBlockWithTxsResponse")
 	golang_proto.RegisterType((*GetBlockWithTxsResponse)(nil), "cosmos.tx.v1beta1.GetBlockWithTxsResponse")
+	proto.RegisterType((*TraceTxRequest)(nil), "cosmos.tx.v1beta1.TraceTxRequest")
+	golang_proto.RegisterType((*TraceTxRequest)(nil), "cosmos.tx.v1beta1.TraceTxRequest")
+	proto.RegisterType((*TraceTxResponse)(nil), "cosmos.tx.v1beta1.TraceTxResponse")
+	golang_proto.RegisterType((*TraceTxResponse)(nil), "cosmos.tx.v1beta1.TraceTxResponse")
+	proto.RegisterType((*SimulateWithTraceRequest)(nil), "cosmos.tx.v1beta1.SimulateWithTraceRequest")
+	golang_proto.RegisterType((*SimulateWithTraceRequest)(nil), "cosmos.tx.v1beta1.SimulateWithTraceRequest")
+	proto.RegisterType((*SimulateWithTraceResponse)(nil), "cosmos.tx.v1beta1.SimulateWithTraceResponse")
+	golang_proto.RegisterType((*SimulateWithTraceResponse)(nil), "cosmos.tx.v1beta1.SimulateWithTraceResponse")
+	proto.RegisterType((*BroadcastTxWithAccessOpsRequest)(nil), "cosmos.tx.v1beta1.BroadcastTxWithAccessOpsRequest")
+	golang_proto.RegisterType((*BroadcastTxWithAccessOpsRequest)(nil), "cosmos.tx.v1beta1.BroadcastTxWithAccessOpsRequest")
 }
 
 func init() { proto.RegisterFile("cosmos/tx/v1beta1/service.proto", fileDescriptor_e0b00a618705eca7) }
@@ -718,68 +1000,83 @@ func init() {
 }
 
 var fileDescriptor_e0b00a618705eca7 = []byte{
-	// 976 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x6f, 0x1a, 0x47,
-	0x14, 0xf7, 0x2e, 0xb6, 0x21, 0x0f, 0x3b, 0x21, 0x63, 0xd7, 0x26, 0x24, 0xc5, 0x64, 0x53, 0x6c,
-	0x07, 0xc9, 0xbb, 0x0a, 0x4d, 0xa5, 0xaa, 0xea, 0xc5, 0xfc, 0x89, 0x8b, 0xda, 0x84, 0x68, 0x70,
-	0x15, 0xa5, 0xaa, 0x84, 0x16, 0x98, 0x2c, 0xab, 0x98, 0x1d, 0xbc, 0x33, 0x58, 0x8b, 0x1c, 0xab,
-	0x52, 0x8f, 0x3d, 0x55, 0xed, 0xa1, 0x1f, 0xa2, 0x5f, 0xa2, 0xc7, 0x1e, 0x2d, 0xf5, 0xd2, 0x63,
-	0x65, 0xf7, 0x03, 0xf4, 0x23, 0x54, 0x3b, 0x3b, 0xc0, 0x82, 0x97, 0x38, 0x8d, 0x7a, 0x81, 0x99,
-	0x9d, 0xdf, 0x7b, 0xef, 0x37, 0xbf, 0xd9, 0xdf, 0x9b, 0x85, 0xad, 0x36, 0x65, 0x3d, 0xca, 0x0c,
-	0xee, 0x19, 0x27, 0x8f, 0x5a, 0x84, 0x9b, 0x8f, 0x0c, 0x46, 0xdc, 0x13, 0xbb, 0x4d, 0xf4, 0xbe,
-	0x4b, 0x39, 0x45, 0xb7, 0x03, 0x80, 0xce, 0x3d, 0x5d, 0x02, 0x32, 0xf7, 0x2c, 0x4a, 0xad, 0x23,
-	0x62, 0x98, 0x7d, 0xdb, 0x30, 0x1d, 0x87, 0x72, 0x93, 0xdb, 0xd4, 0x61, 0x41, 0x40, 0xe6, 0x81,
-	0xcc, 0xd8, 0x32, 0x19, 0x31, 0xcc, 0x56, 0xdb, 0x1e, 0x27, 0xf6, 0x27, 0x12, 0x94, 0xb9, 0x5a,
-	0x96, 0x7b, 0x72, 0x6d, 0xdd, 0xa2, 0x16, 0x15, 0x43, 0xc3, 0x1f, 0xc9, 0xa7, 0x85, 0x70, 0xda,
-	0xe3, 0x01, 0x71, 0x87, 0xe3, 0xc8, 0xbe, 0x69, 0xd9, 0x8e, 0xe0, 0x20, 0xb1, 0xf7, 0x38, 0x71,
-	0x3a, 0xc4, 0xed, 0xd9, 0x0e, 0x37, 0xf8, 0xb0, 0x4f, 0x98, 0xd1, 0x3a, 0xa2, 0xed, 0xd7, 0x73,
-	0x57, 0xc5, 0x6f, 0xb0, 0xaa, 0xfd, 0xaa, 0x00, 0x3a, 0x20, 0xfc, 0xd0, 0x63, 0xd5, 0x13, 0xe2,
-	0x70, 0x4c, 0x8e, 0x07, 0x84, 0x71, 0xb4, 0x01, 0xcb, 0xc4, 0x9f, 0xb3, 0xb4, 0x92, 0x8b, 0xed,
-	0xde, 0xc0, 0x72, 0x86, 0x9e, 0x00, 0x4c, 0xca, 0xa7, 0xd5, 0x9c, 0xb2, 0x9b, 0x2c, 0x6e, 0xeb,
-	0x52, 0x33, 0x9f, 0xab, 0x2e, 0xb8, 0x8e, 0xb4, 0xd3, 0x9f, 0x9b, 0x16, 0x91, 0x39, 0x71, 0x28,
-	0x12, 0x7d, 0x02, 0x09, 0xea, 0x76, 0x88, 0xdb, 0x6c, 0x0d, 0xd3, 0xb1, 0x9c, 0xb2, 0x7b, 0xb3,
-	0x98, 0xd1, 0xaf, 0x28, 0xaf, 0xd7, 0x7d, 0x48, 0x69, 0x88, 0xe3, 0x34, 0x18, 0x68, 0xe7, 0x0a,
-	0xac, 0x4d, 0xb1, 0x65, 0x7d, 0xea, 0x30, 0x82, 0x76, 0x20, 0xc6, 0xbd, 0x80, 0x6b, 0xb2, 0xf8,
-	0x41, 0x44, 0xa6, 0x43, 0x0f, 0xfb, 0x08, 0x74, 0x00, 0x2b, 0xdc, 0x6b, 0xba, 0x32, 0x8e, 0xa5,
-	0x55, 0x11, 0xf1, 0xd1, 0xd4, 0x0e, 0xc4, 0xb9, 0x85, 0x02, 0x25, 0x18, 0x27, 0xf9, 0x78, 0xec,
-	0x27, 0x0a, 0x0b, 0x11, 0x13, 0x42, 0xec, 0x5c, 0x2b, 0x84, 0xcc, 0x14, 0x0a, 0xd5, 0x08, 0xa0,
-	0x92, 0x4b, 0xcd, 0x4e, 0xdb, 0x64, 0xdc, 0x2f, 0x16, 0xe8, 0x7f, 0x07, 0x12, 0xdc, 0x6b, 0xb6,
-	0x86, 0x9c, 0xf8, 0xbb, 0x52, 0x76, 0x57, 0x70, 0x9c, 0x7b, 0x25, 0x7f, 0x8a, 0x1e, 0xc3, 0x62,
-	0x8f, 0x76, 0x88, 0x10, 0xff, 0x66, 0x31, 0x17, 0xb1, 0xd9, 0x71, 0xbe, 0xa7, 0xb4, 0x43, 0xb0,
-	0x40, 0x6b, 0xdf, 0xc2, 0xda, 0x54, 0x19, 0x29, 0x5c, 0x15, 0x92, 0x21, 0x3d, 0x44, 0xa9, 0x77,
-	0x95, 0x03, 0x26, 0x72, 0x68, 0x2f, 0xe0, 0x56, 0xc3, 0xee, 0x0d, 0x8e, 0x4c, 0x3e, 0x3a, 0x6d,
-	0xf4, 0x10, 0x54, 0xee, 0xc9, 0x84, 0xd1, 0x27, 0x52, 0x52, 0xd3, 0x0a, 0x56, 0xb9, 0x37, 0xb5,
-	0x59, 0x75, 0x6a, 0xb3, 0xda, 0x0f, 0x0a, 0xa4, 0x26, 0x99, 0x25, 0xe9, 0xcf, 0x21, 0x61, 0x99,
-	0xac, 0x69, 0x3b, 0xaf, 0xa8, 0x2c, 0x70, 0x7f, 0x3e, 0xe3, 0x03, 0x93, 0xd5, 0x9c, 0x57, 0x14,
-	0xc7, 0xad, 0x60, 0x80, 0x3e, 0x85, 0x65, 0x97, 0xb0, 0xc1, 0x11, 0x97, 0xaf, 0x6f, 0x6e, 0x7e,
-	0x2c, 0x16, 0x38, 0x2c, 0xf1, 0x9a, 0x06, 0x2b, 0xe2, 0xe5, 0x1b, 0x6d, 0x11, 0xc1, 0x62, 0xd7,
-	0x64, 0x5d, 0xc1, 0xe1, 0x06, 0x16, 0x63, 0xed, 0x0c, 0x56, 0x25, 0x46, 0x92, 0xcd, 0x5f, 0xab,
-	0x83, 0xd0, 0x60, 0xe6, 0x20, 0xd4, 0xf7, 0x3c, 0x08, 0x0f, 0x36, 0x0e, 0x08, 0x2f, 0xf9, 0xf6,
-	0x7f, 0x61, 0xf3, 0xee, 0xa1, 0xc7, 0x42, 0x8e, 0xee, 0x12, 0xdb, 0xea, 0x72, 0xc1, 0x25, 0x86,
-	0xe5, 0xec, 0xff, 0x72, 0xb4, 0xf6, 0x8f, 0x02, 0x9b, 0x57, 0x4a, 0xff, 0x57, 0x7b, 0x3e, 0x86,
-	0x84, 0x68, 0x5d, 0x4d, 0xbb, 0x23, 0xa9, 0xdc, 0xd1, 0x27, 0xed, 0x4b, 0x0f, 0x1a, 0x97, 0x28,
-	0x51, 0xab, 0xe0, 0xb8, 0x80, 0xd6, 0x3a, 0x68, 0x0f, 0x96, 0xc4, 0x50, 0xda, 0x70, 0x73, 0x4e,
-	0x08, 0x0e, 0x50, 0x33, 0xd6, 0x5d, 0x7c, 0x6f, 0xeb, 0x16, 0xbe, 0x80, 0xb8, 0xec, 0x50, 0x28,
-	0x0d, 0xeb, 0x75, 0x5c, 0xa9, 0xe2, 0x66, 0xe9, 0x65, 0xf3, 0xeb, 0x67, 0x8d, 0xe7, 0xd5, 0x72,
-	0xed, 0x49, 0xad, 0x5a, 0x49, 0x2d, 0xa0, 0x14, 0xac, 0x8c, 0x57, 0xf6, 0x1b, 0xe5, 0x94, 0x82,
-	0x6e, 0xc3, 0xea, 0xf8, 0x49, 0xa5, 0xda, 0x28, 0xa7, 0xd4, 0xc2, 0x1b, 0x58, 0x9d, 0x32, 0x2d,
-	0xca, 0x42, 0xa6, 0x84, 0xeb, 0xfb, 0x95, 0xf2, 0x7e, 0xe3, 0xb0, 0xf9, 0xb4, 0x5e, 0xa9, 0xce,
-	0x64, 0x4d, 0xc3, 0xfa, 0xcc, 0x7a, 0xe9, 0xab, 0x7a, 0xf9, 0xcb, 0x94, 0x82, 0x36, 0x61, 0x6d,
-	0x66, 0xa5, 0xf1, 0xf2, 0x59, 0x39, 0xa5, 0x46, 0x84, 0xec, 0x8b, 0x95, 0x58, 0xf1, 0xa7, 0x25,
-	0x88, 0x37, 0x82, 0x5b, 0x10, 0x9d, 0x42, 0x62, 0xe4, 0x37, 0xa4, 0x45, 0x9c, 0xd4, 0x8c, 0xcd,
-	0x33, 0x0f, 0xde, 0x8a, 0x91, 0x6f, 0xe5, 0xf6, 0xf7, 0x7f, 0xfc, 0xfd, 0xb3, 0x9a, 0xd3, 0xee,
-	0x1a, 0x11, 0xd7, 0xaf, 0x04, 0x7f, 0xa6, 0x14, 0xd0, 0x31, 0x2c, 0x09, 0xf3, 0xa0, 0xad, 0x88,
-	0xac, 0x61, 0xeb, 0x65, 0x72, 0xf3, 0x01, 0xb2, 0x66, 0x5e, 0xd4, 0xdc, 0x42, 0x1f, 0x1a, 0x51,
-	0x77, 0x2f, 0x33, 0x4e, 0x7d, 0xbb, 0x9e, 0xa1, 0xef, 0x20, 0x19, 0xea, 0x8b, 0x28, 0xff, 0xb6,
-	0x76, 0x3a, 0x29, 0xbf, 0x7d, 0x1d, 0x4c, 0x92, 0xb8, 0x2f, 0x48, 0xdc, 0xd5, 0x36, 0xa2, 0x49,
-	0xf8, 0x7b, 0x7e, 0x03, 0xc9, 0xd0, 0x8d, 0x16, 0x49, 0xe0, 0xea, 0xfd, 0x1c, 0x49, 0x20, 0xe2,
-	0x62, 0xd4, 0xb2, 0x82, 0x40, 0x1a, 0xcd, 0x21, 0x80, 0x7e, 0x51, 0xe0, 0xd6, 0x8c, 0x6b, 0xd1,
-	0xc3, 0xe8, 0xdc, 0x11, 0x4d, 0x25, 0x53, 0x78, 0x17, 0xa8, 0xa4, 0xb2, 0x27, 0xa8, 0xec, 0xa0,
-	0xfc, 0x9c, 0x03, 0x11, 0xe6, 0x34, 0x4e, 0x83, 0xb6, 0x74, 0x56, 0x2a, 0xff, 0x7e, 0x91, 0x55,
-	0xce, 0x2f, 0xb2, 0xca, 0x5f, 0x17, 0x59, 0xe5, 0xc7, 0xcb, 0xec, 0xc2, 0x6f, 0x97, 0x59, 0xe5,
-	0xfc, 0x32, 0xbb, 0xf0, 0xe7, 0x65, 0x76, 0xe1, 0x9b, 0xbc, 0x65, 0xf3, 0xee, 0xa0, 0xa5, 0xb7,
-	0x69, 0x6f, 0x94, 0x2e, 0xf8, 0xdb, 0x63, 0x9d, 0xd7, 0xa3, 0xcf, 0x1c, 0xaf, 0xb5, 0x2c, 0x3e,
-	0x72, 0x3e, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0xb5, 0xaa, 0xba, 0x0d, 0xf7, 0x09, 0x00, 0x00,
+	// 1210 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xda, 0x69, 0xed, 0x3e, 0xa7, 0xad, 0x33, 0x09, 0x89, 0xe3, 0x16, 0xc7, 0xd9, 0xd4,
+	0x49, 0x9a, 0x36, 0x5e, 0xd5, 0x14, 0x81, 0x10, 0x97, 0xf8, 0x4f, 0x43, 0x80, 0xd6, 0xd5, 0xd8,
+	0xa8, 0x2a, 0x42, 0xb2, 0xd6, 0xf6, 0xd4, 0x5e, 0xc5, 0xd9, 0x71, 0x76, 0xc6, 0xd1, 0x46, 0x69,
+	0x84, 0xc4, 0x91, 0x03, 0x42, 0xe2, 0xc0, 0x85, 0x23, 0x37, 0xbe, 0x04, 0x27, 0xd4, 0x63, 0x24,
+	0x0e, 0x70, 0x42, 0x28, 0xe1, 0x03, 0xf0, 0x11, 0xd0, 0xce, 0xce, 0xfa, 0x5f, 0xd6, 0x76, 0x68,
+	0xe1, 0x92, 0xcc, 0xec, 0xfb, 0xbd, 0xf7, 0x7e, 0xef, 0xcd, 0x7b, 0x6f, 0xc6, 0xb0, 0x5c, 0xa3,
+	0x6c, 0x9f, 0x32, 0x8d, 0xdb, 0xda, 0xe1, 0x83, 0x2a, 0xe1, 0xfa, 0x03, 0x8d, 0x11, 0xeb, 0xd0,
+	0xa8, 0x91, 0x74, 0xdb, 0xa2, 0x9c, 0xa2, 0x59, 0x17, 0x90, 0xe6, 0x76, 0x5a, 0x02, 0xe2, 0xb7,
+	0x1b, 0x94, 0x36, 0x5a, 0x44, 0xd3, 0xdb, 0x86, 0xa6, 0x9b, 0x26, 0xe5, 0x3a, 0x37, 0xa8, 0xc9,
+	0x5c, 0x85, 0xf8, 0xaa, 0xb4, 0x58, 0xd5, 0x19, 0xd1, 0xf4, 0x6a, 0xcd, 0xe8, 0x1a, 0x76, 0x36,
+	0x12, 0x14, 0xbf, 0xe8, 0x96, 0xdb, 0x52, 0x36, 0xdf, 0xa0, 0x0d, 0x2a, 0x96, 0x9a, 0xb3, 0x92,
+	0x5f, 0x37, 0xfb, 0xcd, 0x1e, 0x74, 0x88, 0x75, 0xd4, 0xd5, 0x6c, 0xeb, 0x0d, 0xc3, 0x14, 0x1c,
+	0x24, 0xf6, 0x36, 0x27, 0x66, 0x9d, 0x58, 0xfb, 0x86, 0xc9, 0x35, 0x7e, 0xd4, 0x26, 0x4c, 0xab,
+	0xb6, 0x68, 0x6d, 0x6f, 0xa4, 0x54, 0xfc, 0x95, 0xd2, 0x0d, 0xe9, 0x47, 0xaf, 0xd5, 0x08, 0x63,
+	0x35, 0x6a, 0x72, 0x8b, 0xb6, 0x06, 0x77, 0x2e, 0x52, 0xfd, 0x49, 0x01, 0xb4, 0x43, 0x78, 0xd9,
+	0x66, 0x85, 0x43, 0x62, 0x72, 0x4c, 0x0e, 0x3a, 0x84, 0x71, 0xb4, 0x00, 0x57, 0x89, 0xb3, 0x67,
+	0x31, 0x25, 0x19, 0xdc, 0xb8, 0x86, 0xe5, 0x0e, 0x3d, 0x02, 0xe8, 0x11, 0x8d, 0x05, 0x92, 0xca,
+	0x46, 0x24, 0xb3, 0x96, 0x96, 0xd9, 0x75, 0xa2, 0x4a, 0x8b, 0xa8, 0xbc, 0x2c, 0xa7, 0x9f, 0xea,
+	0x0d, 0x22, 0x6d, 0xe2, 0x3e, 0x4d, 0xf4, 0x2e, 0x84, 0xa9, 0x55, 0x27, 0x56, 0xa5, 0x7a, 0x14,
+	0x0b, 0x26, 0x95, 0x8d, 0x1b, 0x99, 0x78, 0xfa, 0xc2, 0x19, 0xa5, 0x8b, 0x0e, 0x24, 0x7b, 0x84,
+	0x43, 0xd4, 0x5d, 0xa8, 0xa7, 0x0a, 0xcc, 0x0d, 0xb0, 0x65, 0x6d, 0x6a, 0x32, 0x82, 0xd6, 0x21,
+	0xc8, 0x6d, 0x97, 0x6b, 0x24, 0xf3, 0x96, 0x8f, 0xa5, 0xb2, 0x8d, 0x1d, 0x04, 0xda, 0x81, 0x19,
+	0x6e, 0x57, 0x2c, 0xa9, 0xc7, 0x62, 0x01, 0xa1, 0x71, 0x67, 0x20, 0x02, 0x71, 0xc2, 0x7d, 0x8a,
+	0x12, 0x8c, 0x23, 0xbc, 0xbb, 0x76, 0x0c, 0xf5, 0x27, 0x22, 0x28, 0x12, 0xb1, 0x3e, 0x31, 0x11,
+	0xd2, 0x52, 0x9f, 0xaa, 0x4a, 0x00, 0x65, 0x2d, 0xaa, 0xd7, 0x6b, 0x3a, 0xe3, 0x65, 0x5b, 0xe6,
+	0x0a, 0x2d, 0x41, 0x98, 0xdb, 0x95, 0xea, 0x11, 0x27, 0x4e, 0x54, 0xca, 0xc6, 0x0c, 0x0e, 0x71,
+	0x3b, 0xeb, 0x6c, 0xd1, 0x43, 0x98, 0xde, 0xa7, 0x75, 0x22, 0x92, 0x7f, 0x23, 0x93, 0xf4, 0x09,
+	0xb6, 0x6b, 0xef, 0x31, 0xad, 0x13, 0x2c, 0xd0, 0xea, 0x17, 0x30, 0x37, 0xe0, 0x46, 0x26, 0xae,
+	0x00, 0x91, 0xbe, 0x7c, 0x08, 0x57, 0x97, 0x4d, 0x07, 0xf4, 0xd2, 0xa1, 0x3e, 0x83, 0x9b, 0x25,
+	0x63, 0xbf, 0xd3, 0xd2, 0xb9, 0x77, 0xda, 0xe8, 0x2e, 0x04, 0xb8, 0x2d, 0x0d, 0xfa, 0x9f, 0x48,
+	0x36, 0x10, 0x53, 0x70, 0x80, 0xdb, 0x03, 0xc1, 0x06, 0x06, 0x82, 0x55, 0xbf, 0x56, 0x20, 0xda,
+	0xb3, 0x2c, 0x49, 0x7f, 0x08, 0xe1, 0x86, 0xce, 0x2a, 0x86, 0xf9, 0x82, 0x4a, 0x07, 0x2b, 0xa3,
+	0x19, 0xef, 0xe8, 0x6c, 0xd7, 0x7c, 0x41, 0x71, 0xa8, 0xe1, 0x2e, 0xd0, 0xfb, 0x70, 0xd5, 0x22,
+	0xac, 0xd3, 0xe2, 0xb2, 0x7c, 0x93, 0xa3, 0x75, 0xb1, 0xc0, 0x61, 0x89, 0x57, 0x55, 0x98, 0x11,
+	0xc5, 0xe7, 0x85, 0x88, 0x60, 0xba, 0xa9, 0xb3, 0xa6, 0xe0, 0x70, 0x0d, 0x8b, 0xb5, 0x7a, 0x02,
+	0xd7, 0x25, 0x46, 0x92, 0x4d, 0x4d, 0xcc, 0x83, 0xc8, 0xc1, 0xd0, 0x41, 0x04, 0x5e, 0xf3, 0x20,
+	0x6c, 0x58, 0xd8, 0x21, 0x3c, 0xeb, 0x0c, 0x8a, 0x67, 0x06, 0x6f, 0x96, 0x6d, 0xd6, 0xd7, 0xd1,
+	0x4d, 0x62, 0x34, 0x9a, 0x5c, 0x70, 0x09, 0x62, 0xb9, 0xfb, 0xaf, 0x3a, 0x5a, 0xfd, 0x5b, 0x81,
+	0xc5, 0x0b, 0xae, 0xff, 0x6d, 0x7b, 0x3e, 0x84, 0xb0, 0x18, 0x72, 0x15, 0xa3, 0x2e, 0xa9, 0x2c,
+	0xa5, 0x7b, 0x83, 0x2e, 0xed, 0x8e, 0x38, 0xe1, 0x62, 0x37, 0x8f, 0x43, 0x02, 0xba, 0x5b, 0x47,
+	0x5b, 0x70, 0x45, 0x2c, 0x65, 0x1b, 0x2e, 0x8e, 0x50, 0xc1, 0x2e, 0x6a, 0xa8, 0x75, 0xa7, 0x5f,
+	0xbf, 0x75, 0xef, 0xc0, 0x8d, 0xb2, 0xa5, 0xd7, 0xc8, 0xf8, 0x8a, 0xf8, 0x18, 0x6e, 0x76, 0x51,
+	0x32, 0x1f, 0xef, 0xc1, 0x15, 0xee, 0x7c, 0x9a, 0x5c, 0xbd, 0x65, 0x5b, 0xe8, 0x62, 0x17, 0xaf,
+	0xee, 0x41, 0xcc, 0xeb, 0x06, 0x91, 0x63, 0x21, 0xbb, 0xcc, 0xc8, 0x58, 0x30, 0xcc, 0x5a, 0xab,
+	0x53, 0x27, 0x15, 0xc6, 0xa9, 0x45, 0x2a, 0xee, 0x4d, 0x20, 0xdb, 0x2d, 0x8c, 0xe7, 0xa5, 0xb4,
+	0xe4, 0x08, 0xb7, 0xa5, 0x4c, 0xfd, 0x46, 0x81, 0x25, 0x1f, 0x6f, 0x6f, 0x18, 0xc3, 0x1b, 0xf4,
+	0xdf, 0x2f, 0x0a, 0x2c, 0xf7, 0x0d, 0x31, 0x87, 0x93, 0x4b, 0xb6, 0xd8, 0x66, 0xff, 0xd7, 0xe0,
+	0x44, 0x18, 0xc0, 0xcd, 0x56, 0x85, 0xb6, 0x59, 0x2c, 0x28, 0x4a, 0x78, 0xcb, 0xd3, 0x1d, 0xbc,
+	0x51, 0x3d, 0x33, 0x1e, 0x29, 0x62, 0x89, 0x3a, 0xc9, 0x4e, 0xbf, 0xfa, 0x63, 0x79, 0x0a, 0x5f,
+	0xd3, 0x3d, 0xae, 0x9b, 0x1f, 0x41, 0x48, 0x5e, 0x6d, 0x28, 0x06, 0xf3, 0x45, 0x9c, 0x2f, 0xe0,
+	0x4a, 0xf6, 0x79, 0xe5, 0xb3, 0x27, 0xa5, 0xa7, 0x85, 0xdc, 0xee, 0xa3, 0xdd, 0x42, 0x3e, 0x3a,
+	0x85, 0xa2, 0x30, 0xd3, 0x95, 0x6c, 0x97, 0x72, 0x51, 0x05, 0xcd, 0xc2, 0xf5, 0xee, 0x97, 0x7c,
+	0xa1, 0x94, 0x8b, 0x06, 0x36, 0x5f, 0xc2, 0xf5, 0x01, 0xd2, 0x28, 0x01, 0xf1, 0x2c, 0x2e, 0x6e,
+	0xe7, 0x73, 0xdb, 0xa5, 0x72, 0xe5, 0x71, 0x31, 0x5f, 0x18, 0xb2, 0x1a, 0x83, 0xf9, 0x21, 0x79,
+	0xf6, 0xd3, 0x62, 0xee, 0x93, 0xa8, 0x82, 0x16, 0x61, 0x6e, 0x48, 0x52, 0x7a, 0xfe, 0x24, 0x17,
+	0x0d, 0xf8, 0xa8, 0x6c, 0x0b, 0x49, 0x30, 0xf3, 0x5b, 0x18, 0x42, 0x25, 0xf7, 0xa1, 0x85, 0x8e,
+	0x21, 0xec, 0x15, 0x0b, 0x52, 0x7d, 0x72, 0x3b, 0x74, 0x3f, 0xc4, 0x57, 0xc7, 0x62, 0xe4, 0x38,
+	0x5b, 0xfb, 0xea, 0xd7, 0xbf, 0xbe, 0x0b, 0x24, 0x3f, 0x50, 0x36, 0xd5, 0x5b, 0x9a, 0xcf, 0x23,
+	0xcf, 0x73, 0x78, 0x00, 0x57, 0xc4, 0xd4, 0x45, 0xcb, 0x3e, 0x56, 0xfb, 0x67, 0x76, 0x3c, 0x39,
+	0x1a, 0x20, 0x7d, 0xa6, 0x84, 0xcf, 0x65, 0xf4, 0xb6, 0xe6, 0xf7, 0xbc, 0x63, 0xda, 0xb1, 0xd3,
+	0xd5, 0x27, 0xe8, 0x4b, 0x88, 0xf4, 0xd5, 0x22, 0x4a, 0x8d, 0x2b, 0xa7, 0x9e, 0xfb, 0xb5, 0x49,
+	0x30, 0x49, 0x62, 0x45, 0x90, 0xb8, 0xe5, 0x04, 0xbe, 0xe0, 0xcf, 0x03, 0xbd, 0x84, 0x48, 0xdf,
+	0x53, 0xc8, 0x97, 0xc0, 0xc5, 0x87, 0x5d, 0x7c, 0x6d, 0x12, 0x4c, 0x12, 0x48, 0x08, 0x02, 0x31,
+	0x34, 0xca, 0xfb, 0xf7, 0x0a, 0xdc, 0x1c, 0x1a, 0xf7, 0xe8, 0xae, 0xbf, 0x6d, 0x9f, 0xdb, 0x28,
+	0xbe, 0x79, 0x19, 0xa8, 0xa4, 0xb2, 0x25, 0xa8, 0xac, 0xa3, 0xd4, 0x88, 0x03, 0x11, 0x53, 0x5d,
+	0x3b, 0x76, 0xef, 0xb3, 0x13, 0x74, 0x02, 0x21, 0x39, 0x6f, 0xd1, 0x8a, 0xdf, 0x55, 0x33, 0x30,
+	0xb1, 0xe3, 0xea, 0x38, 0x88, 0x24, 0x70, 0x4f, 0x10, 0x48, 0xa1, 0xd5, 0xb1, 0x15, 0xa1, 0xb9,
+	0xe3, 0xed, 0x07, 0x05, 0x66, 0x2f, 0x4c, 0x4d, 0x74, 0x6f, 0x4c, 0xb5, 0x0f, 0x4f, 0xf2, 0xf8,
+	0xfd, 0xcb, 0x81, 0x25, 0xbb, 0xfb, 0x82, 0xdd, 0x9a, 0x53, 0x2a, 0x2b, 0x63, 0x7a, 0x44, 0xd2,
+	0xfb, 0x51, 0x81, 0xd8, 0xa8, 0x19, 0x8a, 0x32, 0xe3, 0xab, 0xd3, 0x6f, 0xe0, 0x5e, 0xba, 0xa2,
+	0x27, 0xd0, 0x74, 0xf2, 0xd8, 0x9b, 0xb3, 0xd9, 0xdc, 0xe7, 0xa9, 0x86, 0xc1, 0x9b, 0x9d, 0x6a,
+	0xba, 0x46, 0xf7, 0x3d, 0xb8, 0xfb, 0x6f, 0x8b, 0xd5, 0xf7, 0xbc, 0x9f, 0x3c, 0xf6, 0xab, 0xb3,
+	0x84, 0x72, 0x7a, 0x96, 0x50, 0xfe, 0x3c, 0x4b, 0x28, 0xdf, 0x9e, 0x27, 0xa6, 0x7e, 0x3e, 0x4f,
+	0x28, 0xa7, 0xe7, 0x89, 0xa9, 0xdf, 0xcf, 0x13, 0x53, 0xd5, 0xab, 0xe2, 0x27, 0xce, 0x3b, 0xff,
+	0x0c, 0x00, 0xf0, 0xf5, 0xb5, 0x7e, 0x1f, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -806,6 +1103,19 @@ type ServiceClient interface {
 	//
 	// Since: cosmos-sdk 0.45.2
 	GetBlockWithTxs(ctx context.Context, in *GetBlockWithTxsRequest, opts ...grpc.CallOption) (*GetBlockWithTxsResponse, error)
+	// TraceTx re-executes a committed tx on the state of its block and returns
+	// the trace of its execution. It is disabled unless the node enables it.
+	TraceTx(ctx context.Context, in *TraceTxRequest, opts ...grpc.CallOption) (*TraceTxResponse, error)
+	// SimulateWithTrace simulates executing a transaction as Simulate does, and
+	// returns the trace of its execution along with its result.
+	SimulateWithTrace(ctx context.Context, in *SimulateWithTraceRequest, opts ...grpc.CallOption) (*SimulateWithTraceResponse, error)
+	// BroadcastTxWithAccessOps broadcasts a transaction as BroadcastTx does, along
+	// with the access operations its sender declares it performs. The node
+	// simulates the transaction and rejects it if it accesses state its access
+	// operations do not cover. Otherwise the node's scheduler uses them to place
+	// the transaction when executing its block. It is disabled unless the node
+	// enables it.
+	BroadcastTxWithAccessOps(ctx context.Context, in *BroadcastTxWithAccessOpsRequest, opts ...grpc.CallOption) (*BroadcastTxResponse, error)
 }
 
 type serviceClient struct {
@@ -861,6 +1171,33 @@ func (c *serviceClient) GetBlockWithTxs(ctx context.Context, in *GetBlockWithTxs
 	return out, nil
 }
 
+func (c *serviceClient) TraceTx(ctx context.Context, in *TraceTxRequest, opts ...grpc.CallOption) (*TraceTxResponse, error) {
+	out := new(TraceTxResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.tx.v1beta1.Service/TraceTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) SimulateWithTrace(ctx context.Context, in *SimulateWithTraceRequest, opts ...grpc.CallOption) (*SimulateWithTraceResponse, error) {
+	out := new(SimulateWithTraceResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.tx.v1beta1.Service/SimulateWithTrace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) BroadcastTxWithAccessOps(ctx context.Context, in *BroadcastTxWithAccessOpsRequest, opts ...grpc.CallOption) (*BroadcastTxResponse, error) {
+	out := new(BroadcastTxResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.tx.v1beta1.Service/BroadcastTxWithAccessOps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
 	// Simulate simulates executing a transaction for estimating gas usage.
@@ -875,6 +1212,19 @@ type ServiceServer interface {
 	//
 	// Since: cosmos-sdk 0.45.2
 	GetBlockWithTxs(context.Context, *GetBlockWithTxsRequest) (*GetBlockWithTxsResponse, error)
+	// TraceTx re-executes a committed tx on the state of its block and returns
+	// the trace of its execution. It is disabled unless the node enables it.
+	TraceTx(context.Context, *TraceTxRequest) (*TraceTxResponse, error)
+	// SimulateWithTrace simulates executing a transaction as Simulate does, and
+	// returns the trace of its execution along with its result.
+	SimulateWithTrace(context.Context, *SimulateWithTraceRequest) (*SimulateWithTraceResponse, error)
+	// BroadcastTxWithAccessOps broadcasts a transaction as BroadcastTx does, along
+	// with the access operations its sender declares it performs. The node
+	// simulates the transaction and rejects it if it accesses state its access
+	// operations do not cover. Otherwise the node's scheduler uses them to place
+	// the transaction when executing its block. It is disabled unless the node
+	// enables it.
+	BroadcastTxWithAccessOps(context.Context, *BroadcastTxWithAccessOpsRequest) (*BroadcastTxResponse, error)
 }
 
 // UnimplementedServiceServer can be embedded to have forward compatible implementations.
@@ -896,6 +1246,15 @@ func (*UnimplementedServiceServer) GetTxsEvent(ctx context.Context, req *GetTxsE
 func (*UnimplementedServiceServer) GetBlockWithTxs(ctx context.Context, req *GetBlockWithTxsRequest) (*GetBlockWithTxsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockWithTxs not implemented")
 }
+func (*UnimplementedServiceServer) TraceTx(ctx context.Context, req *TraceTxRequest) (*TraceTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TraceTx not implemented")
+}
+func (*UnimplementedServiceServer) SimulateWithTrace(ctx context.Context, req *SimulateWithTraceRequest) (*SimulateWithTraceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateWithTrace not implemented")
+}
+func (*UnimplementedServiceServer) BroadcastTxWithAccessOps(ctx context.Context, req *BroadcastTxWithAccessOpsRequest) (*BroadcastTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastTxWithAccessOps not implemented")
+}
 
 func RegisterServiceServer(s grpc1.Server, srv ServiceServer) {
 	s.RegisterService(&_Service_serviceDesc, srv)
@@ -991,6 +1350,60 @@ func _Service_GetBlockWithTxs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_TraceTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).TraceTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.tx.v1beta1.Service/TraceTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).TraceTx(ctx, req.(*TraceTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_SimulateWithTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateWithTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SimulateWithTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.tx.v1beta1.Service/SimulateWithTrace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SimulateWithTrace(ctx, req.(*SimulateWithTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_BroadcastTxWithAccessOps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastTxWithAccessOpsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).BroadcastTxWithAccessOps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.tx.v1beta1.Service/BroadcastTxWithAccessOps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).BroadcastTxWithAccessOps(ctx, req.(*BroadcastTxWithAccessOpsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cosmos.tx.v1beta1.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1015,6 +1428,18 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockWithTxs",
 			Handler:    _Service_GetBlockWithTxs_Handler,
 		},
+		{
+			MethodName: "TraceTx",
+			Handler:    _Service_TraceTx_Handler,
+		},
+		{
+			MethodName: "SimulateWithTrace",
+			Handler:    _Service_SimulateWithTrace_Handler,
+		},
+		{
+			MethodName: "BroadcastTxWithAccessOps",
+			Handler:    _Service_BroadcastTxWithAccessOps_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cosmos/tx/v1beta1/service.proto",
@@ -1481,26 +1906,227 @@ func (m *GetBlockWithTxsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintService(dAtA []byte, offset int, v uint64) int {
-	offset -= sovService(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TraceTxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *GetTxsEventRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *TraceTxRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceTxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Events) > 0 {
-		for _, s := range m.Events {
-			l = len(s)
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TraceTxResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraceTxResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Trace != nil {
+		{
+			size, err := m.Trace.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateWithTraceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateWithTraceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateWithTraceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IncludeStoreAccesses {
+		i--
+		if m.IncludeStoreAccesses {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TxBytes) > 0 {
+		i -= len(m.TxBytes)
+		copy(dAtA[i:], m.TxBytes)
+		i = encodeVarintService(dAtA, i, uint64(len(m.TxBytes)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateWithTraceResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateWithTraceResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateWithTraceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Trace != nil {
+		{
+			size, err := m.Trace.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AccessOps) > 0 {
+		for iNdEx := len(m.AccessOps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccessOps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Mode != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TxBytes) > 0 {
+		i -= len(m.TxBytes)
+		copy(dAtA[i:], m.TxBytes)
+		i = encodeVarintService(dAtA, i, uint64(len(m.TxBytes)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetTxsEventRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, s := range m.Events {
+			l = len(s)
 			n += 1 + l + sovService(uint64(l))
 		}
 	}
@@ -1675,6 +2301,87 @@ func (m *GetBlockWithTxsResponse) Size() (n int) {
 	return n
 }
 
+func (m *TraceTxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *TraceTxResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *SimulateWithTraceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxBytes)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.IncludeStoreAccesses {
+		n += 2
+	}
+	return n
+}
+
+func (m *SimulateWithTraceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *BroadcastTxWithAccessOpsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxBytes)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovService(uint64(m.Mode))
+	}
+	if len(m.AccessOps) > 0 {
+		for _, e := range m.AccessOps {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovService(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2904,6 +3611,537 @@ func (m *GetBlockWithTxsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TraceTxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceTxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceTxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TraceTxResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceTxResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceTxResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &types.TxTrace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateWithTraceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateWithTraceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateWithTraceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxBytes = append(m.TxBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxBytes == nil {
+				m.TxBytes = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeStoreAccesses", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeStoreAccesses = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateWithTraceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateWithTraceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateWithTraceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &types.TxTrace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &types.Result{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BroadcastTxWithAccessOpsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BroadcastTxWithAccessOpsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BroadcastTxWithAccessOpsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxBytes = append(m.TxBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxBytes == nil {
+				m.TxBytes = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= BroadcastMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessOps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessOps = append(m.AccessOps, accesscontrol.AccessOperation{})
+			if err := m.AccessOps[len(m.AccessOps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipService(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,43 @@
+package types
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+)
+
+// DeliverTxEntry is a tx of a DeliverTxBatchRequest.
+type DeliverTxEntry struct {
+	Request abci.RequestDeliverTx
+	// AccessOperations are the accesses the tx declares, if known, which keep
+	// the txs expected to conflict from executing concurrently.
+	AccessOperations []acltypes.AccessOperation
+}
+
+// DeliverTxBatchRequest is a batch of txs delivered together, in order.
+type DeliverTxBatchRequest struct {
+	TxEntries []*DeliverTxEntry
+}
+
+// DeliverTxResult is the result of a tx of a DeliverTxBatchRequest.
+type DeliverTxResult struct {
+	Response abci.ResponseDeliverTx
+}
+
+// DeliverTxBatchResponse holds the results of a DeliverTxBatchRequest, in the
+// order of its txs.
+type DeliverTxBatchResponse struct {
+	Results []*DeliverTxResult
+}
+
+// DeliverTxBatcher delivers the txs of a batch, in order, on the multi store of
+// ctx, e.g. a BaseApp.
+type DeliverTxBatcher interface {
+	DeliverTxBatch(ctx Context, req DeliverTxBatchRequest) DeliverTxBatchResponse
+}
+
+// TxBatchVerifier verifies the txs of a batch ahead of their execution, e.g.
+// their signatures, which can be verified faster together than one at a time.
+// It records what it verified in the TxCache of ctx, where the executions of
+// the txs look it up. A tx that it cannot verify is left to its execution.
+type TxBatchVerifier func(ctx Context, txs []Tx, txsBytes [][]byte)
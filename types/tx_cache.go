@@ -0,0 +1,169 @@
+package types
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// TxCache holds work that only depends on the bytes of a tx, so that it can be
+// shared by every execution of the same tx within a block, e.g. when the
+// concurrent scheduler re-executes a tx. Results that depend on state must not
+// be cached here.
+type TxCache struct {
+	mtx sync.RWMutex
+	// decoded txs keyed by the hash of their bytes
+	txs map[[sha256.Size]byte]Tx
+	// keys of the signatures that were verified
+	verifiedSigs map[string]struct{}
+	// sigCache, if set, shares the verified signatures across blocks, see
+	// RecordSignatures and LookUpSignatures
+	sigCache   *SignatureCache
+	recordSigs bool
+}
+
+// NewTxCache returns an empty TxCache.
+func NewTxCache() *TxCache {
+	return &TxCache{
+		txs:          make(map[[sha256.Size]byte]Tx),
+		verifiedSigs: make(map[string]struct{}),
+	}
+}
+
+// GetTx returns the decoded tx for txBytes, if it was cached.
+func (c *TxCache) GetTx(txBytes []byte) (Tx, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	tx, ok := c.txs[sha256.Sum256(txBytes)]
+	return tx, ok
+}
+
+// SetTx caches the decoded tx for txBytes.
+func (c *TxCache) SetTx(txBytes []byte, tx Tx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.txs[sha256.Sum256(txBytes)] = tx
+}
+
+// IsSignatureVerified reports whether the signature identified by key was
+// verified. The key must cover everything the verification depends on, such
+// as the tx bytes, the public key and the signer data.
+func (c *TxCache) IsSignatureVerified(key string) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if _, ok := c.verifiedSigs[key]; ok {
+		return true
+	}
+	return c.sigCache != nil && !c.recordSigs && c.sigCache.use(key)
+}
+
+// SetSignatureVerified records that the signature identified by key was
+// verified.
+func (c *TxCache) SetSignatureVerified(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.verifiedSigs[key] = struct{}{}
+	if c.sigCache != nil && c.recordSigs {
+		c.sigCache.add(key)
+	}
+}
+
+// RecordSignatures makes the signatures verified through c be added to
+// sigCache, as CheckTx does for DeliverTx to look them up. sigCache may be nil.
+func (c *TxCache) RecordSignatures(sigCache *SignatureCache) *TxCache {
+	c.sigCache, c.recordSigs = sigCache, true
+	return c
+}
+
+// LookUpSignatures makes c report the signatures held by sigCache as verified.
+// sigCache may be nil.
+func (c *TxCache) LookUpSignatures(sigCache *SignatureCache) *TxCache {
+	c.sigCache, c.recordSigs = sigCache, false
+	return c
+}
+
+// SignatureCache holds the keys of the signatures verified by CheckTx, so that
+// the txs of the local mempool do not have their signatures verified again by
+// DeliverTx. As a key covers everything its verification depends on, an entry
+// never becomes stale: the entries looked up by DeliverTx are only evicted by
+// Prune once their block is committed, since their txs cannot be delivered
+// again, and the oldest entries are evicted when the cache is full.
+type SignatureCache struct {
+	mtx        sync.Mutex
+	maxEntries int
+	// entries holds the elements of order, which lists the keys from the
+	// oldest to the newest
+	entries map[string]*list.Element
+	order   *list.List
+	// used are the keys looked up since the last Prune
+	used map[string]struct{}
+}
+
+// NewSignatureCache returns an empty SignatureCache holding up to maxEntries
+// signatures.
+func NewSignatureCache(maxEntries int) *SignatureCache {
+	return &SignatureCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		used:       make(map[string]struct{}),
+	}
+}
+
+func (c *SignatureCache) add(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.entries[key]; ok || c.maxEntries <= 0 {
+		return
+	}
+	for c.order.Len() >= c.maxEntries {
+		oldest := c.order.Remove(c.order.Front()).(string)
+		delete(c.entries, oldest)
+		delete(c.used, oldest)
+	}
+	c.entries[key] = c.order.PushBack(key)
+}
+
+func (c *SignatureCache) use(key string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		return false
+	}
+	c.used[key] = struct{}{}
+	return true
+}
+
+// Prune evicts the signatures looked up since the last Prune and returns their
+// number. It is called once the block that looked them up is committed.
+func (c *SignatureCache) Prune() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	pruned := len(c.used)
+	for key := range c.used {
+		c.order.Remove(c.entries[key])
+		delete(c.entries, key)
+	}
+	c.used = make(map[string]struct{})
+	return pruned
+}
+
+// MaxEntries returns the number of signatures the cache holds at most.
+func (c *SignatureCache) MaxEntries() int {
+	return c.maxEntries
+}
+
+// Len returns the number of signatures held by the cache.
+func (c *SignatureCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.order.Len()
+}
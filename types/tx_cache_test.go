@@ -0,0 +1,62 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type cachedTx struct{}
+
+func (cachedTx) GetMsgs() []sdk.Msg   { return nil }
+func (cachedTx) ValidateBasic() error { return nil }
+
+func TestTxCache(t *testing.T) {
+	cache := sdk.NewTxCache()
+
+	_, ok := cache.GetTx([]byte("tx1"))
+	require.False(t, ok)
+	cache.SetTx([]byte("tx1"), cachedTx{})
+	tx, ok := cache.GetTx([]byte("tx1"))
+	require.True(t, ok)
+	require.Equal(t, cachedTx{}, tx)
+	_, ok = cache.GetTx([]byte("tx2"))
+	require.False(t, ok)
+
+	require.False(t, cache.IsSignatureVerified("sig"))
+	cache.SetSignatureVerified("sig")
+	require.True(t, cache.IsSignatureVerified("sig"))
+	require.False(t, cache.IsSignatureVerified("other sig"))
+}
+
+func TestSignatureCache(t *testing.T) {
+	sigCache := sdk.NewSignatureCache(2)
+	checkTxCache := sdk.NewTxCache().RecordSignatures(sigCache)
+	checkTxCache.SetSignatureVerified("sig1")
+	checkTxCache.SetSignatureVerified("sig2")
+	require.Equal(t, 2, sigCache.Len())
+
+	// the signatures recorded by a cache are only looked up by the others
+	deliverTxCache := sdk.NewTxCache().LookUpSignatures(sigCache)
+	require.True(t, deliverTxCache.IsSignatureVerified("sig1"))
+	require.False(t, sdk.NewTxCache().RecordSignatures(sigCache).IsSignatureVerified("sig2"))
+	require.False(t, sdk.NewTxCache().IsSignatureVerified("sig2"))
+	deliverTxCache.SetSignatureVerified("sig3")
+	require.Equal(t, 2, sigCache.Len())
+
+	// the oldest signature is evicted when the cache is full
+	checkTxCache.SetSignatureVerified("sig4")
+	require.Equal(t, 2, sigCache.Len())
+	require.False(t, sdk.NewTxCache().LookUpSignatures(sigCache).IsSignatureVerified("sig1"))
+	require.True(t, sdk.NewTxCache().LookUpSignatures(sigCache).IsSignatureVerified("sig2"))
+
+	// the signatures looked up are evicted by Prune
+	require.Equal(t, 1, sigCache.Prune())
+	require.Equal(t, 1, sigCache.Len())
+	require.False(t, sdk.NewTxCache().LookUpSignatures(sigCache).IsSignatureVerified("sig2"))
+	require.True(t, sdk.NewTxCache().LookUpSignatures(sigCache).IsSignatureVerified("sig4"))
+	require.Equal(t, 1, sigCache.Prune())
+	require.Equal(t, 0, sigCache.Len())
+}
@@ -0,0 +1,33 @@
+package types
+
+import "sync"
+
+// TxCharges records the fees deducted from the payer of a tx during one of its
+// executions. The concurrent scheduler gives each incarnation of a tx its own,
+// so that the fees of the incarnations it discards, whose writes are discarded
+// with them, are told apart from those of the incarnation it commits.
+type TxCharges struct {
+	mtx  sync.Mutex
+	fees Coins
+}
+
+// NewTxCharges returns a TxCharges with no fees recorded.
+func NewTxCharges() *TxCharges {
+	return &TxCharges{}
+}
+
+// AddFees records fees as deducted.
+func (c *TxCharges) AddFees(fees Coins) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.fees = c.fees.Add(fees...)
+}
+
+// Fees returns the fees deducted so far.
+func (c *TxCharges) Fees() Coins {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.fees
+}
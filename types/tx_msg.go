@@ -4,6 +4,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 type (
@@ -67,6 +68,27 @@ type (
 
 		GetTimeoutHeight() uint64
 	}
+
+	// TxWithSigners extends the Tx interface with the accounts signing the
+	// transaction. It is implemented by the transactions whose signers are not
+	// the signers of their messages, such as the transactions of alternative
+	// envelopes, which carry their own signature scheme for the ante handler
+	// to verify.
+	TxWithSigners interface {
+		Tx
+
+		GetSigners() []AccAddress
+	}
+
+	// TxWithNonce extends the TxWithSigners interface with the nonce the first
+	// signer signed the transaction with, ordering the transactions of the
+	// signer. It is implemented by the transactions that do not carry the
+	// signatures of the SDK, which hold the sequences of their signers.
+	TxWithNonce interface {
+		TxWithSigners
+
+		GetNonce() uint64
+	}
 )
 
 // TxDecoder unmarshals transaction bytes
@@ -75,6 +97,35 @@ type TxDecoder func(txBytes []byte) (Tx, error)
 // TxEncoder marshals transaction to bytes
 type TxEncoder func(tx Tx) ([]byte, error)
 
+// ChainTxDecoders returns a decoder decoding transactions with the first of
+// decoders that succeeds, e.g. the protobuf decoder followed by the decoders of
+// the alternative transaction envelopes an app accepts. When none succeeds,
+// the error of the first one is returned. Nil decoders are skipped.
+func ChainTxDecoders(decoders ...TxDecoder) TxDecoder {
+	chain := make([]TxDecoder, 0, len(decoders))
+	for _, decoder := range decoders {
+		if decoder != nil {
+			chain = append(chain, decoder)
+		}
+	}
+	return func(txBytes []byte) (Tx, error) {
+		if len(chain) == 0 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "no tx decoder")
+		}
+		var firstErr error
+		for _, decoder := range chain {
+			tx, err := decoder(txBytes)
+			if err == nil {
+				return tx, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return nil, firstErr
+	}
+}
+
 // MsgTypeURL returns the TypeURL of a `sdk.Msg`.
 func MsgTypeURL(msg Msg) string {
 	return "/" + proto.MessageName(msg)
@@ -1,12 +1,14 @@
 package types_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 type testMsgSuite struct {
@@ -33,3 +35,29 @@ func (s *testMsgSuite) TestMsg() {
 func (s *testMsgSuite) TestMsgTypeURL() {
 	s.Require().Equal("/testdata.TestMsg", sdk.MsgTypeURL(new(testdata.TestMsg)))
 }
+
+type decodedTx struct{ id int }
+
+func (decodedTx) GetMsgs() []sdk.Msg   { return nil }
+func (decodedTx) ValidateBasic() error { return nil }
+
+func (s *testMsgSuite) TestChainTxDecoders() {
+	errFirst := errors.New("first")
+	failing := func(err error) sdk.TxDecoder {
+		return func([]byte) (sdk.Tx, error) { return nil, err }
+	}
+	decoding := func(tx sdk.Tx) sdk.TxDecoder {
+		return func([]byte) (sdk.Tx, error) { return tx, nil }
+	}
+	tx1, tx2 := decodedTx{1}, decodedTx{2}
+
+	tx, err := sdk.ChainTxDecoders(failing(errFirst), nil, decoding(tx1), decoding(tx2))(nil)
+	s.Require().NoError(err)
+	s.Require().Equal(tx1, tx)
+
+	_, err = sdk.ChainTxDecoders(failing(errFirst), failing(errors.New("second")))(nil)
+	s.Require().Equal(errFirst, err)
+
+	_, err = sdk.ChainTxDecoders()(nil)
+	s.Require().ErrorIs(err, sdkerrors.ErrTxDecode)
+}
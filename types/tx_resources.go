@@ -0,0 +1,124 @@
+package types
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+const (
+	// EventTypeTxResources is the type of the event reporting the resources
+	// used by the execution of a tx, see TxResources.
+	EventTypeTxResources = "tx_resources"
+
+	AttributeKeyReads        = "reads"
+	AttributeKeyWrites       = "writes"
+	AttributeKeyBytesWritten = "bytes_written"
+	AttributeKeyIncarnations = "incarnations"
+)
+
+// TxResources counts the store accesses of one execution of a tx, made through
+// the KVStores of a Context holding it: the keys read, iterated keys included,
+// and the keys written or deleted, with the bytes of the keys and values
+// written. The accesses to the transient stores are not counted, as they do
+// not outlive the block.
+type TxResources struct {
+	reads        int64
+	writes       int64
+	bytesWritten int64
+}
+
+// NewTxResources returns a TxResources with no accesses counted.
+func NewTxResources() *TxResources {
+	return &TxResources{}
+}
+
+// Reads returns the number of keys read so far.
+func (r *TxResources) Reads() int64 {
+	return atomic.LoadInt64(&r.reads)
+}
+
+// Writes returns the number of keys written or deleted so far.
+func (r *TxResources) Writes() int64 {
+	return atomic.LoadInt64(&r.writes)
+}
+
+// BytesWritten returns the bytes of the keys and values written so far.
+func (r *TxResources) BytesWritten() int64 {
+	return atomic.LoadInt64(&r.bytesWritten)
+}
+
+// Event returns the resources counted so far as an event, along with the
+// number of times the tx executed in its block.
+func (r *TxResources) Event(incarnations int) Event {
+	return NewEvent(
+		EventTypeTxResources,
+		NewAttribute(AttributeKeyReads, strconv.FormatInt(r.Reads(), 10)),
+		NewAttribute(AttributeKeyWrites, strconv.FormatInt(r.Writes(), 10)),
+		NewAttribute(AttributeKeyBytesWritten, strconv.FormatInt(r.BytesWritten(), 10)),
+		NewAttribute(AttributeKeyIncarnations, strconv.Itoa(incarnations)),
+	)
+}
+
+// countStore wraps store to count its accesses, unless r is nil.
+func (r *TxResources) countStore(store KVStore) KVStore {
+	if r == nil {
+		return store
+	}
+	return resourceCountingStore{KVStore: store, resources: r}
+}
+
+// resourceCountingStore counts the accesses to its KVStore in a TxResources.
+type resourceCountingStore struct {
+	KVStore
+	resources *TxResources
+}
+
+func (s resourceCountingStore) Get(key []byte) []byte {
+	atomic.AddInt64(&s.resources.reads, 1)
+	return s.KVStore.Get(key)
+}
+
+func (s resourceCountingStore) Has(key []byte) bool {
+	atomic.AddInt64(&s.resources.reads, 1)
+	return s.KVStore.Has(key)
+}
+
+func (s resourceCountingStore) Set(key, value []byte) {
+	atomic.AddInt64(&s.resources.writes, 1)
+	atomic.AddInt64(&s.resources.bytesWritten, int64(len(key)+len(value)))
+	s.KVStore.Set(key, value)
+}
+
+func (s resourceCountingStore) Delete(key []byte) {
+	atomic.AddInt64(&s.resources.writes, 1)
+	atomic.AddInt64(&s.resources.bytesWritten, int64(len(key)))
+	s.KVStore.Delete(key)
+}
+
+func (s resourceCountingStore) Iterator(start, end []byte) Iterator {
+	return newResourceCountingIterator(s.KVStore.Iterator(start, end), s.resources)
+}
+
+func (s resourceCountingStore) ReverseIterator(start, end []byte) Iterator {
+	return newResourceCountingIterator(s.KVStore.ReverseIterator(start, end), s.resources)
+}
+
+// resourceCountingIterator counts the keys its Iterator visits as reads.
+type resourceCountingIterator struct {
+	Iterator
+	resources *TxResources
+}
+
+func newResourceCountingIterator(it Iterator, resources *TxResources) Iterator {
+	if it.Valid() {
+		atomic.AddInt64(&resources.reads, 1)
+	}
+	return resourceCountingIterator{Iterator: it, resources: resources}
+}
+
+func (it resourceCountingIterator) Next() {
+	it.Iterator.Next()
+	if it.Iterator.Valid() {
+		atomic.AddInt64(&it.resources.reads, 1)
+	}
+}
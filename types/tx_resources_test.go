@@ -0,0 +1,43 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestTxResources(t *testing.T) {
+	key, tkey := types.NewKVStoreKey("resources"), types.NewTransientStoreKey("transient_resources")
+	ctx := testutil.DefaultContext(key, tkey)
+	ctx.KVStore(key).Set([]byte("a"), []byte("1"))
+	ctx.KVStore(key).Set([]byte("b"), []byte("2"))
+
+	resources := types.NewTxResources()
+	ctx = ctx.WithTxResources(resources)
+	store := ctx.KVStore(key)
+	store.Get([]byte("a"))
+	store.Has([]byte("c"))
+	it := store.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+	}
+	it.Close()
+	store.Set([]byte("c"), []byte("345"))
+	store.Delete([]byte("a"))
+	ctx.TransientStore(tkey).Set([]byte("d"), []byte("6"))
+
+	require.Equal(t, int64(4), resources.Reads())
+	require.Equal(t, int64(2), resources.Writes())
+	require.Equal(t, int64(5), resources.BytesWritten())
+
+	event := resources.Event(2)
+	require.Equal(t, types.EventTypeTxResources, event.Type)
+	require.Equal(t, types.NewEvent(types.EventTypeTxResources,
+		types.NewAttribute(types.AttributeKeyReads, "4"),
+		types.NewAttribute(types.AttributeKeyWrites, "2"),
+		types.NewAttribute(types.AttributeKeyBytesWritten, "5"),
+		types.NewAttribute(types.AttributeKeyIncarnations, "2"),
+	), event)
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store/tracekv"
+)
+
+// TxTraceAntePhase is the name of the phase of a tx trace covering its ante
+// handler.
+const TxTraceAntePhase = "ante"
+
+// TxTracer records the trace of the execution of a tx: the gas used, store
+// accesses and events of its ante handler and of each of its messages. The
+// accesses are recorded through the KVStores of a Context holding the tracer,
+// into the phase started last. A TxTracer is not safe for concurrent use.
+type TxTracer struct {
+	trace TxTrace
+}
+
+// NewTxTracer returns a TxTracer with no phases.
+func NewTxTracer() *TxTracer {
+	return &TxTracer{}
+}
+
+// StartPhase starts a phase of the trace, such as the ante handler or a
+// message, to which the following store accesses belong.
+func (t *TxTracer) StartPhase(name string) {
+	t.trace.Phases = append(t.trace.Phases, TxTracePhase{Name: name})
+}
+
+// EndPhase records the gas used, the events and the error of the phase started
+// last.
+func (t *TxTracer) EndPhase(gasUsed uint64, events []abci.Event, err error) {
+	if len(t.trace.Phases) == 0 {
+		return
+	}
+	phase := &t.trace.Phases[len(t.trace.Phases)-1]
+	phase.GasUsed = gasUsed
+	phase.Events = events
+	if err != nil {
+		phase.Error = err.Error()
+	}
+}
+
+// Trace returns the trace recorded so far.
+func (t *TxTracer) Trace() *TxTrace {
+	return &t.trace
+}
+
+// SummarizeStoreAccesses sets the store access summaries of the phases of the
+// trace from their store accesses.
+func (t *TxTrace) SummarizeStoreAccesses() {
+	for i := range t.Phases {
+		phase := &t.Phases[i]
+		summaries := make(map[string]*StoreAccessSummary)
+		for _, access := range phase.StoreAccesses {
+			summary, ok := summaries[access.Store]
+			if !ok {
+				summary = &StoreAccessSummary{Store: access.Store}
+				summaries[access.Store] = summary
+			}
+			switch access.Operation {
+			case "read":
+				summary.Reads++
+			case "write":
+				summary.Writes++
+			case "delete":
+				summary.Deletes++
+			case "iterKey":
+				// the value of an iterated key is traced separately
+				summary.Iterations++
+			}
+		}
+		phase.StoreAccessSummaries = make([]StoreAccessSummary, 0, len(summaries))
+		for _, summary := range summaries {
+			phase.StoreAccessSummaries = append(phase.StoreAccessSummaries, *summary)
+		}
+		sort.Slice(phase.StoreAccessSummaries, func(i, j int) bool {
+			return phase.StoreAccessSummaries[i].Store < phase.StoreAccessSummaries[j].Store
+		})
+	}
+}
+
+// traceStore wraps store to record its accesses, unless t is nil.
+func (t *TxTracer) traceStore(store KVStore, key StoreKey) KVStore {
+	if t == nil {
+		return store
+	}
+	return tracekv.NewStore(store, storeAccessWriter{t}, TraceContext{"store": key.Name()})
+}
+
+// storeAccessWriter decodes the operations written by a tracekv.Store into the
+// store accesses of the current phase of a TxTracer.
+type storeAccessWriter struct {
+	tracer *TxTracer
+}
+
+func (w storeAccessWriter) Write(p []byte) (int, error) {
+	var op struct {
+		Operation string            `json:"operation"`
+		Key       string            `json:"key"`
+		Value     string            `json:"value"`
+		Metadata  map[string]string `json:"metadata"`
+	}
+	// every operation is written as a JSON object, followed by a newline
+	if len(p) == 0 || p[0] != '{' || len(w.tracer.trace.Phases) == 0 {
+		return len(p), nil
+	}
+	if err := json.Unmarshal(p, &op); err != nil {
+		return 0, err
+	}
+	access := StoreAccess{Store: op.Metadata["store"], Operation: op.Operation}
+	var err error
+	if access.Key, err = base64.StdEncoding.DecodeString(op.Key); err != nil {
+		return 0, err
+	}
+	if access.Value, err = base64.StdEncoding.DecodeString(op.Value); err != nil {
+		return 0, err
+	}
+	phase := &w.tracer.trace.Phases[len(w.tracer.trace.Phases)-1]
+	phase.StoreAccesses = append(phase.StoreAccesses, access)
+	return len(p), nil
+}
@@ -7,10 +7,12 @@ import (
 	"time"
 
 	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
 )
 
 var (
-	// This is set at compile time. Could be cleveldb, defaults is goleveldb.
+	// This is set at compile time. Could be cleveldb or pebbledb, defaults is goleveldb.
 	DBBackend = ""
 	backend   = dbm.GoLevelDBBackend
 )
@@ -86,13 +88,30 @@ func ParseTimeBytes(bz []byte) (time.Time, error) {
 
 // NewLevelDB instantiate a new LevelDB instance according to DBBackend.
 func NewLevelDB(name, dir string) (db dbm.DB, err error) {
+	return NewDB(name, "", dir)
+}
+
+// NewDB instantiates a new database of the given backend, or of the DBBackend
+// one if backendType is empty. On top of the tm-db backends, pebbledb is
+// supported.
+func NewDB(name string, backendType dbm.BackendType, dir string) (db dbm.DB, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("couldn't create db: %v", r)
 		}
 	}()
 
-	return dbm.NewDB(name, backend, dir)
+	if backendType == "" {
+		backendType = backend
+	}
+	if backendType == pebbledb.BackendType {
+		pdb, err := pebbledb.NewDB(name, dir)
+		if err != nil {
+			return nil, err
+		}
+		return pdb, nil
+	}
+	return dbm.NewDB(name, backendType, dir)
 }
 
 // copy bytes
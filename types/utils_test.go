@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	dbm "github.com/tendermint/tm-db"
 
+	"github.com/cosmos/cosmos-sdk/store/pebbledb"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -109,3 +111,21 @@ func (s *utilsTestSuite) TestParseTimeBytes() {
 	_, err = sdk.ParseTimeBytes([]byte{})
 	s.Require().Error(err)
 }
+
+func (s *utilsTestSuite) TestNewDB() {
+	dir := s.T().TempDir()
+	for _, backend := range []dbm.BackendType{"", dbm.GoLevelDBBackend, dbm.MemDBBackend, pebbledb.BackendType} {
+		db, err := sdk.NewDB("test-"+string(backend), backend, dir)
+		s.Require().NoError(err, backend)
+		s.Require().NoError(db.Set([]byte("key"), []byte("value")))
+		s.Require().NoError(db.Close())
+	}
+
+	db, err := sdk.NewDB("test", pebbledb.BackendType, dir)
+	s.Require().NoError(err)
+	s.Require().IsType(&pebbledb.PebbleDB{}, db)
+	s.Require().NoError(db.Close())
+
+	_, err = sdk.NewDB("test", "unknown", dir)
+	s.Require().Error(err)
+}
@@ -0,0 +1,253 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+var _ trace.SpanExporter = (*OTLPExporter)(nil)
+
+// OTLPExporter exports spans to an OpenTelemetry collector with the JSON
+// encoding of the OTLP/HTTP protocol.
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+
+	mtx      sync.RWMutex
+	shutdown bool
+}
+
+// NewOTLPExporter returns an exporter posting the spans to the traces path of
+// the OTLP/HTTP endpoint of a collector, e.g. http://localhost:4318.
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ExportSpans implements trace.SpanExporter.
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	if e.shutdown || len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(otlpTracesRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// the body is drained so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to export %d spans to %s: %s", len(spans), e.url, resp.Status)
+	}
+	return nil
+}
+
+// Shutdown implements trace.SpanExporter. Spans exported afterwards are
+// dropped.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.shutdown = true
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding the exporter
+// writes. Trace and span ids are hex strings and 64-bit integers are decimal
+// strings, as the encoding requires.
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// otlpTracesRequest groups the spans by resource and instrumentation scope.
+func otlpTracesRequest(spans []trace.ReadOnlySpan) otlpExportRequest {
+	var req otlpExportRequest
+	resourceIdx := make(map[attribute.Distinct]int)
+	scopeIdx := make(map[attribute.Distinct]map[otlpScope]int)
+	for _, span := range spans {
+		var resourceKey attribute.Distinct
+		var resourceAttrs []attribute.KeyValue
+		if res := span.Resource(); res != nil {
+			resourceKey = res.Equivalent()
+			resourceAttrs = res.Attributes()
+		}
+		ri, ok := resourceIdx[resourceKey]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resourceIdx[resourceKey] = ri
+			scopeIdx[resourceKey] = make(map[otlpScope]int)
+			req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: otlpAttributes(resourceAttrs)},
+			})
+		}
+
+		lib := span.InstrumentationScope()
+		scope := otlpScope{Name: lib.Name, Version: lib.Version}
+		si, ok := scopeIdx[resourceKey][scope]
+		if !ok {
+			si = len(req.ResourceSpans[ri].ScopeSpans)
+			scopeIdx[resourceKey][scope] = si
+			req.ResourceSpans[ri].ScopeSpans = append(req.ResourceSpans[ri].ScopeSpans, otlpScopeSpans{Scope: scope})
+		}
+
+		ss := &req.ResourceSpans[ri].ScopeSpans[si]
+		ss.Spans = append(ss.Spans, otlpSpanFrom(span))
+	}
+	return req
+}
+
+func otlpSpanFrom(span trace.ReadOnlySpan) otlpSpan {
+	s := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: otlpTime(span.StartTime()),
+		EndTimeUnixNano:   otlpTime(span.EndTime()),
+		Attributes:        otlpAttributes(span.Attributes()),
+		Status:            otlpStatusFrom(span.Status()),
+	}
+	if span.Parent().HasSpanID() {
+		s.ParentSpanID = span.Parent().SpanID().String()
+	}
+	for _, event := range span.Events() {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: otlpTime(event.Time),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	return s
+}
+
+// otlpStatusFrom maps the status of a span to an OTLP status, whose codes are
+// ordered differently.
+func otlpStatusFrom(status trace.Status) otlpStatus {
+	switch status.Code {
+	case codes.Ok:
+		return otlpStatus{Code: 1}
+	case codes.Error:
+		return otlpStatus{Code: 2, Message: status.Description}
+	default:
+		return otlpStatus{}
+	}
+}
+
+func otlpTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var v otlpAnyValue
+		switch attr.Value.Type() {
+		case attribute.BOOL:
+			b := attr.Value.AsBool()
+			v.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(attr.Value.AsInt64(), 10)
+			v.IntValue = &i
+		case attribute.FLOAT64:
+			f := attr.Value.AsFloat64()
+			v.DoubleValue = &f
+		default:
+			// slices are flattened to their string form
+			s := attr.Value.Emit()
+			v.StringValue = &s
+		}
+		kvs = append(kvs, otlpKeyValue{Key: string(attr.Key), Value: v})
+	}
+	return kvs
+}
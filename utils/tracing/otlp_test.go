@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var received []otlpExportRequest
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, otlpTracesPath, r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req otlpExportRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		received = append(received, req)
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPExporter(collector.URL)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+
+	ctx, block := tracer.Start(context.Background(), "Block")
+	block.SetAttributes(attribute.Int64("blockHeight", 7))
+	_, tx := tracer.Start(ctx, "RunTx")
+	tx.SetAttributes(attribute.String("txHash", "AB"), attribute.Bool("ok", false))
+	tx.SetStatus(codes.Error, "out of gas")
+	tx.End()
+	block.End()
+
+	require.Len(t, received, 2)
+	txSpan := received[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	blockSpan := received[1].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	require.Equal(t, "test", received[0].ResourceSpans[0].ScopeSpans[0].Scope.Name)
+
+	require.Equal(t, "RunTx", txSpan.Name)
+	require.Equal(t, blockSpan.TraceID, txSpan.TraceID)
+	require.Equal(t, blockSpan.SpanID, txSpan.ParentSpanID)
+	require.Len(t, txSpan.SpanID, 16)
+	require.Len(t, txSpan.TraceID, 32)
+	require.Equal(t, otlpStatus{Code: 2, Message: "out of gas"}, txSpan.Status)
+	require.Equal(t, "AB", *txSpan.Attributes[0].Value.StringValue)
+	require.False(t, *txSpan.Attributes[1].Value.BoolValue)
+
+	require.Empty(t, blockSpan.ParentSpanID)
+	require.Equal(t, "7", *blockSpan.Attributes[0].Value.IntValue)
+	require.NotEqual(t, "0", blockSpan.StartTimeUnixNano)
+
+	// spans are dropped once the exporter is shut down
+	require.NoError(t, tp.Shutdown(context.Background()))
+	require.NoError(t, exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{nil}))
+	require.Len(t, received, 2)
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPExporter(collector.URL + "/")
+	tp := sdktrace.NewTracerProvider()
+	_, span := tp.Tracer("test").Start(context.Background(), "Commit")
+	span.End()
+	err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	require.ErrorContains(t, err, "503")
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	for _, exporter := range []string{"", ExporterJaeger, ExporterOTLP} {
+		tp, err := NewTracerProvider(exporter, "")
+		require.NoError(t, err)
+		require.NoError(t, tp.Shutdown(context.Background()))
+	}
+	_, err := NewTracerProvider("zipkin", "")
+	require.Error(t, err)
+}
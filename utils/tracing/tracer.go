@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -12,8 +13,21 @@ import (
 )
 
 const DefaultTracingURL = "http://localhost:14268/api/traces"
+const DefaultOTLPEndpoint = "http://localhost:4318"
 const FlagTracing = "tracing"
 
+// FlagTracingExporter selects the exporter of the app spans, jaeger or otlp,
+// and FlagTracingEndpoint the collector they are exported to.
+const (
+	FlagTracingExporter = "tracing-exporter"
+	FlagTracingEndpoint = "tracing-endpoint"
+)
+
+const (
+	ExporterJaeger = "jaeger"
+	ExporterOTLP   = "otlp"
+)
+
 func DefaultTracerProvider() (*trace.TracerProvider, error) {
 	return TracerProvider(DefaultTracingURL)
 }
@@ -28,11 +42,34 @@ func TracerProvider(url string) (*trace.TracerProvider, error) {
 	return tp, nil
 }
 
+// NewTracerProvider returns a provider exporting the spans with the given
+// exporter to endpoint, or to the default endpoint of the exporter if empty.
+func NewTracerProvider(exporter string, endpoint string) (*trace.TracerProvider, error) {
+	switch exporter {
+	case "", ExporterJaeger:
+		if endpoint == "" {
+			endpoint = DefaultTracingURL
+		}
+		return TracerProvider(endpoint)
+	case ExporterOTLP:
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		return trace.NewTracerProvider(tracerProviderOptions(NewOTLPExporter(endpoint))...), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %s, must be %s or %s", exporter, ExporterJaeger, ExporterOTLP)
+	}
+}
+
 func GetTracerProviderOptions(url string) ([]trace.TracerProviderOption, error) {
 	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(url)))
 	if err != nil {
 		return nil, err
 	}
+	return tracerProviderOptions(exp), nil
+}
+
+func tracerProviderOptions(exp trace.SpanExporter) []trace.TracerProviderOption {
 	return []trace.TracerProviderOption{
 		// Always be sure to batch in production.
 		trace.WithBatcher(exp),
@@ -43,7 +80,7 @@ func GetTracerProviderOptions(url string) ([]trace.TracerProviderOption, error)
 			attribute.String("environment", "production"),
 			attribute.Int64("ID", 1),
 		)),
-	}, nil
+	}
 }
 
 type Info struct {
@@ -83,3 +120,30 @@ func (i *Info) SetContext(c context.Context) {
 	defer i.mtx.Unlock()
 	i.tracerContext = c
 }
+
+// StartBlock starts the span of the block at height and makes it the parent of
+// the spans started by Start until EndBlock. The span of a previous block that
+// was never ended is ended first.
+func (i *Info) StartBlock(height int64) context.Context {
+	i.EndBlock()
+	ctx, span := i.StartWithContext("Block", context.Background())
+	span.SetAttributes(attribute.Int64("blockHeight", height))
+
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	i.BlockSpan = &span
+	i.tracerContext = ctx
+	return ctx
+}
+
+// EndBlock ends the span of the current block, if any.
+func (i *Info) EndBlock() {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	if i.BlockSpan == nil {
+		return
+	}
+	(*i.BlockSpan).End()
+	i.BlockSpan = nil
+	i.tracerContext = context.Background()
+}
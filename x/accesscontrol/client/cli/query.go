@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
 )
 
 func GetQueryCmd() *cobra.Command {
@@ -25,6 +26,8 @@ func GetQueryCmd() *cobra.Command {
 		ListResourceDependencyMapping(),
 		GetWasmDependencyAccessOps(),
 		ListWasmDependencyMapping(),
+		GetWasmDependencyMappingHistory(),
+		SimulateAccessOps(),
 	)
 
 	return cmd
@@ -179,3 +182,78 @@ func ListWasmDependencyMapping() *cobra.Command {
 
 	return cmd
 }
+
+func GetWasmDependencyMappingHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wasm-dependency-mapping-history [contractAddr] [flags]",
+		Short: "Get the versions registered of the dependency mapping of a wasm contract",
+		Long: "Get the versions registered of the dependency mapping of a wasm contract, with the height and the registrant of each. E.g.\n" +
+			"$ seid q accesscontrol wasm-dependency-mapping-history [contractAddr] [flags]",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.WasmDependencyMappingHistory(
+				cmd.Context(),
+				&types.WasmDependencyMappingHistoryRequest{ContractAddress: args[0], Pagination: pageReq},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "wasm dependency mapping history")
+
+	return cmd
+}
+
+func SimulateAccessOps() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-access-ops [file] [flags]",
+		Short: "Get the access operations predicted for a tx",
+		Long: "Get the access operations predicted for the ante handler and the msgs of a JSON encoded tx, which needs not be signed. E.g.\n" +
+			"$ seid tx bank send [from] [to] [amount] --generate-only > tx.json\n" +
+			"$ seid q accesscontrol simulate-access-ops tx.json [flags]",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			tx, err := authclient.ReadTxFromFile(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(tx)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.SimulateAccessOps(cmd.Context(), &types.SimulateAccessOpsRequest{TxBytes: txBytes})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
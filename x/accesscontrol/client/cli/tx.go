@@ -26,11 +26,14 @@ func GetTxCmd() *cobra.Command {
 
 	updateResourceDependencyMappingProposalCmd := MsgUpdateResourceDependencyMappingProposalCmd()
 	flags.AddTxFlagsToCmd(updateResourceDependencyMappingProposalCmd)
+	updateWasmDependencyMappingProposalCmd := MsgUpdateWasmDependencyMappingProposalCmd()
+	flags.AddTxFlagsToCmd(updateWasmDependencyMappingProposalCmd)
 	registerWasmDependencyMappingCmd := MsgRegisterWasmDependencyMappingCmd()
 	flags.AddTxFlagsToCmd(registerWasmDependencyMappingCmd)
 
 	cmd.AddCommand(
 		updateResourceDependencyMappingProposalCmd,
+		updateWasmDependencyMappingProposalCmd,
 		registerWasmDependencyMappingCmd,
 	)
 
@@ -88,12 +91,63 @@ func MsgUpdateResourceDependencyMappingProposalCmd() *cobra.Command {
 	return cmd
 }
 
+func MsgUpdateWasmDependencyMappingProposalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-wasm-dependency-mapping [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit an UpdateWasmDependencyMapping proposal",
+		Long: "Submit a proposal to register or update the dependencies of a wasm contract. \n" +
+			"Governance may update the mapping of any contract, including those registered by txs. \n" +
+			"E.g. $ seid update-wasm-dependency-mapping [proposal-file]\n" +
+			"The proposal file should contain the following:\n" +
+			"{\n" +
+			"\t title: [title],\n" +
+			"\t description: [description],\n" +
+			"\t deposit: [deposit],\n" +
+			"\t contract_address: [contract address],\n" +
+			"\t wasm_dependency_mapping: <wasm dependency mapping>\n" +
+			"}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			proposal, err := utils.ParseMsgUpdateWasmDependencyMappingProposalFile(clientCtx.Codec, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := clientCtx.GetFromAddress()
+
+			content := types.NewMsgUpdateWasmDependencyMappingProposal(
+				proposal.Title, proposal.Description, proposal.ContractAddress, proposal.WasmDependencyMapping,
+			)
+
+			deposit, err := sdk.ParseCoinsNormalized(proposal.Deposit)
+			if err != nil {
+				return err
+			}
+
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	return cmd
+}
+
 func MsgRegisterWasmDependencyMappingCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "register-wasm-dependency-mapping [mapping-json-file]",
 		Args:  cobra.ExactArgs(1),
 		Short: "Register dependencies for a wasm contract",
 		Long: "Registers dependencies for a wasm contract\n" +
+			"Once registered, only the same account or governance may update them.\n" +
 			"E.g. $seid register-wasm-dependency-mapping [mapping-json-file]\n" +
 			"The mapping JSON file should contain the following:\n" +
 			"{\n" +
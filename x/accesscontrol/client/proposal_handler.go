@@ -7,3 +7,5 @@ import (
 )
 
 var ResourceDependencyProposalHandler = govclient.NewProposalHandler(cli.MsgUpdateResourceDependencyMappingProposalCmd, rest.UpdateResourceDependencyProposalRESTHandler)
+
+var WasmDependencyProposalHandler = govclient.NewProposalHandler(cli.MsgUpdateWasmDependencyMappingProposalCmd, rest.UpdateWasmDependencyProposalRESTHandler)
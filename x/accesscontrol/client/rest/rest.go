@@ -63,3 +63,54 @@ func newUpdateResourceDependencyPostPlanHandler(clientCtx client.Context) http.H
 		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
 	}
 }
+
+// UpdateWasmDependencyMappingRequest defines a proposal to update the dependency
+// mapping of a wasm contract.
+type UpdateWasmDependencyMappingRequest struct {
+	BaseReq               rest.BaseReq                        `json:"base_req" yaml:"base_req"`
+	Title                 string                              `json:"title" yaml:"title"`
+	Description           string                              `json:"description" yaml:"description"`
+	Deposit               sdk.Coins                           `json:"deposit" yaml:"deposit"`
+	ContractAddress       string                              `json:"contract_address" yaml:"contract_address"`
+	WasmDependencyMapping accesscontrol.WasmDependencyMapping `json:"wasm_dependency_mapping" yaml:"wasm_dependency_mapping"`
+}
+
+func UpdateWasmDependencyProposalRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "update_wasm_dependency_mapping",
+		Handler:  newUpdateWasmDependencyPostPlanHandler(clientCtx),
+	}
+}
+
+func newUpdateWasmDependencyPostPlanHandler(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UpdateWasmDependencyMappingRequest
+
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+
+		content := types.NewMsgUpdateWasmDependencyMappingProposal(
+			req.Title, req.Description, req.ContractAddress, req.WasmDependencyMapping,
+		)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, fromAddr)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
@@ -20,6 +20,21 @@ func ParseMsgUpdateResourceDependencyMappingProposalFile(cdc codec.JSONCodec, pr
 	return proposal, nil
 }
 
+func ParseMsgUpdateWasmDependencyMappingProposalFile(cdc codec.JSONCodec, proposalFile string) (types.MsgUpdateWasmDependencyMappingProposalJsonFile, error) {
+	proposal := types.MsgUpdateWasmDependencyMappingProposalJsonFile{}
+
+	contents, err := os.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
 func ParseRegisterWasmDependencyMappingJSON(cdc codec.JSONCodec, dependencyFile string) (types.RegisterWasmDependencyJSONFile, error) {
 	wasmDependencyJson := types.RegisterWasmDependencyJSONFile{}
 
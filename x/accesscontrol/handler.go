@@ -3,6 +3,7 @@ package accesscontrol
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
@@ -19,11 +20,18 @@ func HandleMsgUpdateResourceDependencyMappingProposal(ctx sdk.Context, k *keeper
 	return nil
 }
 
+func HandleMsgUpdateWasmDependencyMappingProposal(ctx sdk.Context, k *keeper.Keeper, p *types.MsgUpdateWasmDependencyMappingProposal) error {
+	_, err := k.RegisterWasmDependencyMapping(ctx, authtypes.NewModuleAddress(govtypes.ModuleName), p.WasmDependencyMapping)
+	return err
+}
+
 func NewProposalHandler(k keeper.Keeper) govtypes.Handler {
 	return func(ctx sdk.Context, content govtypes.Content) error {
 		switch c := content.(type) {
 		case *types.MsgUpdateResourceDependencyMappingProposal:
 			return HandleMsgUpdateResourceDependencyMappingProposal(ctx, &k, c)
+		case *types.MsgUpdateWasmDependencyMappingProposal:
+			return HandleMsgUpdateWasmDependencyMappingProposal(ctx, &k, c)
 		default:
 			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized accesscontrol proposal content type: %T", c)
 		}
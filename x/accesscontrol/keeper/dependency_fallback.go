@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+)
+
+// ContractAddressResolver returns the address of the wasm contract a msg
+// executes, if any, whose dependency mapping declares the accesses of the msg.
+type ContractAddressResolver func(msg sdk.Msg) (sdk.AccAddress, bool)
+
+// dependencyFallbacks holds the message types and contracts whose dependency
+// mappings missed accesses of their executions, which are treated as
+// synchronous until their mapping is set again. The fallbacks are kept by the
+// node rather than in state: the dependencies only decide which txs run
+// concurrently, not their results.
+type dependencyFallbacks struct {
+	mtx         sync.RWMutex
+	messageKeys map[types.MessageKey]struct{}
+	contracts   map[string]struct{}
+}
+
+func newDependencyFallbacks() *dependencyFallbacks {
+	return &dependencyFallbacks{
+		messageKeys: make(map[types.MessageKey]struct{}),
+		contracts:   make(map[string]struct{}),
+	}
+}
+
+// HandleDependencyMismatch falls back to synchronous access operations for the
+// contract msg executes, or else for the type of msg, whose store accesses
+// exceeded missingAccessOps, and returns the event identifying it. It is meant
+// to be set as the DependencyMismatchHandler of the app.
+func (k Keeper) HandleDependencyMismatch(ctx sdk.Context, msg sdk.Msg, missingAccessOps []acltypes.Comparator) sdk.Events {
+	if k.dependencyFallbacks == nil {
+		return nil
+	}
+	k.dependencyFallbacks.mtx.Lock()
+	defer k.dependencyFallbacks.mtx.Unlock()
+
+	messageKey := types.GenerateMessageKey(msg)
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyMessageKey, string(messageKey)),
+		sdk.NewAttribute(sdk.AttributeKeyMissingAccessOps, fmt.Sprint(len(missingAccessOps))),
+	}
+	if contractAddr, ok := k.resolveContractAddress(msg); ok {
+		k.dependencyFallbacks.contracts[contractAddr.String()] = struct{}{}
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyContractAddress, contractAddr.String()))
+		ctx.Logger().Error("falling back to synchronous dependencies for wasm contract", "contract", contractAddr.String(), "message_key", messageKey)
+	} else {
+		k.dependencyFallbacks.messageKeys[messageKey] = struct{}{}
+		ctx.Logger().Error("falling back to synchronous dependencies for message", "message_key", messageKey)
+	}
+	return sdk.Events{sdk.NewEvent(types.EventTypeDependencyFallback, attributes...)}
+}
+
+func (k Keeper) resolveContractAddress(msg sdk.Msg) (sdk.AccAddress, bool) {
+	if k.ContractAddressResolver == nil {
+		return nil, false
+	}
+	return k.ContractAddressResolver(msg)
+}
+
+// HasDependencyFallback reports whether the message type falls back to
+// synchronous access operations, see HandleDependencyMismatch.
+func (k Keeper) HasDependencyFallback(messageKey types.MessageKey) bool {
+	if k.dependencyFallbacks == nil {
+		return false
+	}
+	k.dependencyFallbacks.mtx.RLock()
+	defer k.dependencyFallbacks.mtx.RUnlock()
+	_, ok := k.dependencyFallbacks.messageKeys[messageKey]
+	return ok
+}
+
+// HasWasmDependencyFallback reports whether the contract falls back to
+// synchronous access operations, see HandleDependencyMismatch.
+func (k Keeper) HasWasmDependencyFallback(contractAddress sdk.AccAddress) bool {
+	if k.dependencyFallbacks == nil {
+		return false
+	}
+	k.dependencyFallbacks.mtx.RLock()
+	defer k.dependencyFallbacks.mtx.RUnlock()
+	_, ok := k.dependencyFallbacks.contracts[contractAddress.String()]
+	return ok
+}
+
+// clearDependencyFallback stops the fallback of the message type, whose mapping
+// was set again.
+func (k Keeper) clearDependencyFallback(messageKey types.MessageKey) {
+	if k.dependencyFallbacks == nil {
+		return
+	}
+	k.dependencyFallbacks.mtx.Lock()
+	defer k.dependencyFallbacks.mtx.Unlock()
+	delete(k.dependencyFallbacks.messageKeys, messageKey)
+}
+
+// clearWasmDependencyFallback stops the fallback of the contract, whose mapping
+// was set again.
+func (k Keeper) clearWasmDependencyFallback(contractAddress sdk.AccAddress) {
+	if k.dependencyFallbacks == nil {
+		return
+	}
+	k.dependencyFallbacks.mtx.Lock()
+	defer k.dependencyFallbacks.mtx.Unlock()
+	delete(k.dependencyFallbacks.contracts, contractAddress.String())
+}
@@ -0,0 +1,107 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	aclkeeper "github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestMessageDependencyFallback(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+
+	msg := &banktypes.MsgSend{}
+	messageKey := types.GenerateMessageKey(msg)
+	mapping := acltypes.MessageDependencyMapping{
+		MessageKey: string(messageKey),
+		AccessOps: []acltypes.AccessOperation{
+			{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV_BANK_BALANCES, IdentifierTemplate: "*"},
+			*types.CommitAccessOp(),
+		},
+	}
+	require.NoError(t, app.AccessControlKeeper.SetResourceDependencyMapping(ctx, mapping))
+	require.Equal(t, mapping.AccessOps, app.AccessControlKeeper.GetMessageDependencies(ctx, msg))
+
+	events := app.AccessControlKeeper.HandleDependencyMismatch(ctx, msg, make([]acltypes.Comparator, 2))
+	require.Equal(t, sdk.Events{sdk.NewEvent(
+		types.EventTypeDependencyFallback,
+		sdk.NewAttribute(types.AttributeKeyMessageKey, string(messageKey)),
+		sdk.NewAttribute(sdk.AttributeKeyMissingAccessOps, "2"),
+	)}, events)
+	require.True(t, app.AccessControlKeeper.HasDependencyFallback(messageKey))
+	require.Equal(t, types.SynchronousAccessOps(), app.AccessControlKeeper.GetMessageDependencies(ctx, msg))
+	// the mapping in state is left as is
+	require.Equal(t, mapping, app.AccessControlKeeper.GetResourceDependencyMapping(ctx, messageKey))
+
+	// setting the mapping again ends the fallback
+	require.NoError(t, app.AccessControlKeeper.SetResourceDependencyMapping(ctx, mapping))
+	require.False(t, app.AccessControlKeeper.HasDependencyFallback(messageKey))
+	require.Equal(t, mapping.AccessOps, app.AccessControlKeeper.GetMessageDependencies(ctx, msg))
+}
+
+func TestWasmDependencyFallback(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+
+	addrs := simapp.AddTestAddrsIncremental(app, ctx, 2, sdk.NewInt(30000000))
+	contractAddress, registrant := addrs[0], addrs[1]
+	wasmMapping := acltypes.WasmDependencyMapping{
+		BaseAccessOps: []*acltypes.WasmAccessOperation{
+			{
+				Operation:    &acltypes.AccessOperation{ResourceType: acltypes.ResourceType_KV, AccessType: acltypes.AccessType_WRITE, IdentifierTemplate: "someResource"},
+				SelectorType: acltypes.AccessOperationSelectorType_NONE,
+			},
+			{
+				Operation:    types.CommitAccessOp(),
+				SelectorType: acltypes.AccessOperationSelectorType_NONE,
+			},
+		},
+		ContractAddress: contractAddress.String(),
+	}
+	_, err := app.AccessControlKeeper.RegisterWasmDependencyMapping(ctx, registrant, wasmMapping)
+	require.NoError(t, err)
+
+	info, _ := types.NewExecuteMessageInfo([]byte("{\"test\":{}}"))
+	accessOps := func() []acltypes.AccessOperation {
+		ops, err := app.AccessControlKeeper.GetWasmDependencyAccessOps(ctx, contractAddress, "", info, make(aclkeeper.ContractReferenceLookupMap))
+		require.NoError(t, err)
+		return ops
+	}
+	mappedOps := accessOps()
+	require.NotEqual(t, types.SynchronousAccessOps(), mappedOps)
+
+	msg := &banktypes.MsgSend{FromAddress: contractAddress.String()}
+	app.AccessControlKeeper.ContractAddressResolver = func(msg sdk.Msg) (sdk.AccAddress, bool) {
+		send, ok := msg.(*banktypes.MsgSend)
+		if !ok {
+			return nil, false
+		}
+		addr, err := sdk.AccAddressFromBech32(send.FromAddress)
+		return addr, err == nil
+	}
+	events := app.AccessControlKeeper.HandleDependencyMismatch(ctx, msg, make([]acltypes.Comparator, 1))
+	require.Equal(t, sdk.Events{sdk.NewEvent(
+		types.EventTypeDependencyFallback,
+		sdk.NewAttribute(types.AttributeKeyMessageKey, string(types.GenerateMessageKey(msg))),
+		sdk.NewAttribute(sdk.AttributeKeyMissingAccessOps, "1"),
+		sdk.NewAttribute(types.AttributeKeyContractAddress, contractAddress.String()),
+	)}, events)
+	// the contract falls back rather than the message type executing it
+	require.True(t, app.AccessControlKeeper.HasWasmDependencyFallback(contractAddress))
+	require.False(t, app.AccessControlKeeper.HasDependencyFallback(types.GenerateMessageKey(msg)))
+	require.Equal(t, types.SynchronousAccessOps(), accessOps())
+
+	// registering the mapping again ends the fallback
+	_, err = app.AccessControlKeeper.RegisterWasmDependencyMapping(ctx, registrant, wasmMapping)
+	require.NoError(t, err)
+	require.False(t, app.AccessControlKeeper.HasWasmDependencyFallback(contractAddress))
+	require.Equal(t, mappedOps, accessOps())
+}
@@ -0,0 +1,182 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+)
+
+// inferredAccess is an access of a message type to a resource, with the
+// identifier of every access being "*".
+type inferredAccess struct {
+	accessType   acltypes.AccessType
+	resourceType acltypes.ResourceType
+}
+
+// DependencyInferenceRegistry infers the access operations of message types
+// from the store accesses of their executions, such as the traces of the
+// simulations of a node (see baseapp.SetSimulationTracer). Each access of a
+// message is mapped to the resource type with the longest prefix of its key in
+// the StoreKeyToResourceTypePrefixMap of the registry, or to ResourceType_KV if
+// its store has none. The inferred operations only cover the executions
+// observed so far, so they are meant as default mappings and scheduler hints
+// rather than as validated dependencies. It is safe for concurrent use.
+type DependencyInferenceRegistry struct {
+	mtx       sync.RWMutex
+	prefixMap acltypes.StoreKeyToResourceTypePrefixMap
+	accesses  map[types.MessageKey]map[inferredAccess]struct{}
+}
+
+// NewDependencyInferenceRegistry returns a DependencyInferenceRegistry mapping
+// store accesses to resource types with prefixMap.
+func NewDependencyInferenceRegistry(prefixMap acltypes.StoreKeyToResourceTypePrefixMap) *DependencyInferenceRegistry {
+	return &DependencyInferenceRegistry{
+		prefixMap: prefixMap,
+		accesses:  make(map[types.MessageKey]map[inferredAccess]struct{}),
+	}
+}
+
+// ObserveTrace records the store accesses of the messages of trace. The
+// accesses of the ante handler are not attributed to any message type.
+func (r *DependencyInferenceRegistry) ObserveTrace(trace *sdk.TxTrace) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, phase := range trace.Phases {
+		if phase.Name == sdk.TxTraceAntePhase {
+			continue
+		}
+		messageKey := types.MessageKey(strings.TrimPrefix(phase.Name, "/"))
+		accesses, ok := r.accesses[messageKey]
+		if !ok {
+			accesses = make(map[inferredAccess]struct{})
+			r.accesses[messageKey] = accesses
+		}
+		for _, access := range phase.StoreAccesses {
+			accessType, ok := storeAccessType(access.Operation)
+			if !ok {
+				continue
+			}
+			accesses[inferredAccess{accessType, r.resourceType(access.Store, access.Key)}] = struct{}{}
+		}
+	}
+}
+
+// storeAccessType returns the access type of a tracekv operation. The values
+// of iterators are skipped, their keys being recorded as reads.
+func storeAccessType(operation string) (acltypes.AccessType, bool) {
+	switch operation {
+	case "read", "iterKey":
+		return acltypes.AccessType_READ, true
+	case "write", "delete":
+		return acltypes.AccessType_WRITE, true
+	default:
+		return acltypes.AccessType_UNKNOWN, false
+	}
+}
+
+// resourceType returns the resource type of key in the store named storeKey.
+func (r *DependencyInferenceRegistry) resourceType(storeKey string, key []byte) acltypes.ResourceType {
+	resourceType, matched := acltypes.ResourceType_KV, -1
+	for candidate, prefix := range r.prefixMap[storeKey] {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		// the lowest resource type wins a tie, for the result not to depend on
+		// the iteration order of the map
+		if len(prefix) > matched || (len(prefix) == matched && candidate < resourceType) {
+			resourceType, matched = candidate, len(prefix)
+		}
+	}
+	return resourceType
+}
+
+// AccessOps returns the access operations inferred for the message type, ending
+// with a COMMIT, and whether any execution of it was observed.
+func (r *DependencyInferenceRegistry) AccessOps(messageKey types.MessageKey) ([]acltypes.AccessOperation, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	accesses, ok := r.accesses[messageKey]
+	if !ok {
+		return nil, false
+	}
+	sorted := make([]inferredAccess, 0, len(accesses))
+	for access := range accesses {
+		sorted = append(sorted, access)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].resourceType != sorted[j].resourceType {
+			return sorted[i].resourceType < sorted[j].resourceType
+		}
+		return sorted[i].accessType < sorted[j].accessType
+	})
+	accessOps := make([]acltypes.AccessOperation, 0, len(sorted)+1)
+	for _, access := range sorted {
+		accessOps = append(accessOps, acltypes.AccessOperation{
+			AccessType:         access.accessType,
+			ResourceType:       access.resourceType,
+			IdentifierTemplate: "*",
+		})
+	}
+	return append(accessOps, *types.CommitAccessOp()), true
+}
+
+// MessageDependencyMappings returns the dependency mappings of the message
+// types observed so far, sorted by message key, e.g. to be set in the genesis
+// of the module or proposed through governance.
+func (r *DependencyInferenceRegistry) MessageDependencyMappings() []acltypes.MessageDependencyMapping {
+	r.mtx.RLock()
+	messageKeys := make([]string, 0, len(r.accesses))
+	for messageKey := range r.accesses {
+		messageKeys = append(messageKeys, string(messageKey))
+	}
+	r.mtx.RUnlock()
+	sort.Strings(messageKeys)
+
+	mappings := make([]acltypes.MessageDependencyMapping, 0, len(messageKeys))
+	for _, messageKey := range messageKeys {
+		accessOps, _ := r.AccessOps(types.MessageKey(messageKey))
+		mappings = append(mappings, acltypes.MessageDependencyMapping{
+			MessageKey: messageKey,
+			AccessOps:  accessOps,
+		})
+	}
+	return mappings
+}
+
+// TxAccessOps returns the access operations inferred for the messages of tx,
+// or nil if the execution of any of them was never observed, in which case
+// no hint can be given for the tx.
+func (r *DependencyInferenceRegistry) TxAccessOps(tx sdk.Tx) []acltypes.AccessOperation {
+	var accessOps []acltypes.AccessOperation
+	for _, msg := range tx.GetMsgs() {
+		msgAccessOps, ok := r.AccessOps(types.GenerateMessageKey(msg))
+		if !ok {
+			return nil
+		}
+		accessOps = append(accessOps, msgAccessOps...)
+	}
+	return accessOps
+}
+
+// DependencyGenerators returns generators of the access operations inferred
+// for the message types observed so far, to be merged with those of a Keeper
+// with WithDependencyGeneratorMappings. The generators read the registry when
+// called, so they follow the executions observed after they are returned.
+func (r *DependencyInferenceRegistry) DependencyGenerators() DependencyGeneratorMap {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	generators := make(DependencyGeneratorMap, len(r.accesses))
+	for messageKey := range r.accesses {
+		messageKey := messageKey
+		generators[messageKey] = func(_ Keeper, _ sdk.Context, _ sdk.Msg) ([]acltypes.AccessOperation, error) {
+			accessOps, _ := r.AccessOps(messageKey)
+			return accessOps, nil
+		}
+	}
+	return generators
+}
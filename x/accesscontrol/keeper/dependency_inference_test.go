@@ -0,0 +1,73 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/testutil"
+	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// testTx is a tx of the given msgs.
+type testTx []sdk.Msg
+
+func (tx testTx) GetMsgs() []sdk.Msg   { return tx }
+func (tx testTx) ValidateBasic() error { return nil }
+
+func msgsTx(msgs ...sdk.Msg) sdk.Tx { return testTx(msgs) }
+
+func TestDependencyInferenceRegistry(t *testing.T) {
+	registry := keeper.NewDependencyInferenceRegistry(testutil.TestingStoreKeyToResourceTypePrefixMap)
+	sendKey := types.GenerateMessageKey(&banktypes.MsgSend{})
+	balance := append(append([]byte{}, banktypes.BalancesPrefix...), 'a')
+
+	_, ok := registry.AccessOps(sendKey)
+	require.False(t, ok)
+	require.Nil(t, registry.TxAccessOps(msgsTx(&banktypes.MsgSend{})))
+
+	registry.ObserveTrace(&sdk.TxTrace{Phases: []sdk.TxTracePhase{
+		{Name: sdk.TxTraceAntePhase, StoreAccesses: []sdk.StoreAccess{
+			{Store: "acc", Operation: "write", Key: []byte("fee payer")},
+		}},
+		{Name: sdk.MsgTypeURL(&banktypes.MsgSend{}), StoreAccesses: []sdk.StoreAccess{
+			{Store: banktypes.StoreKey, Operation: "read", Key: balance},
+			{Store: banktypes.StoreKey, Operation: "write", Key: balance},
+			{Store: banktypes.StoreKey, Operation: "iterKey", Key: balance},
+			{Store: banktypes.StoreKey, Operation: "iterValue", Value: []byte("1usei")},
+		}},
+	}})
+	// the accesses of later executions add to those observed before
+	registry.ObserveTrace(&sdk.TxTrace{Phases: []sdk.TxTracePhase{
+		{Name: sdk.MsgTypeURL(&banktypes.MsgSend{}), StoreAccesses: []sdk.StoreAccess{
+			{Store: banktypes.StoreKey, Operation: "read", Key: []byte("unmapped")},
+			{Store: "unknown", Operation: "delete", Key: []byte("key")},
+		}},
+	}})
+
+	expected := []acltypes.AccessOperation{
+		{AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV, IdentifierTemplate: "*"},
+		{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV_BANK, IdentifierTemplate: "*"},
+		{AccessType: acltypes.AccessType_READ, ResourceType: acltypes.ResourceType_KV_BANK_BALANCES, IdentifierTemplate: "*"},
+		{AccessType: acltypes.AccessType_WRITE, ResourceType: acltypes.ResourceType_KV_BANK_BALANCES, IdentifierTemplate: "*"},
+		*types.CommitAccessOp(),
+	}
+	accessOps, ok := registry.AccessOps(sendKey)
+	require.True(t, ok)
+	require.Equal(t, expected, accessOps)
+	require.NoError(t, types.ValidateAccessOps(accessOps))
+
+	require.Equal(t, []acltypes.MessageDependencyMapping{{MessageKey: string(sendKey), AccessOps: expected}}, registry.MessageDependencyMappings())
+	require.Equal(t, append(expected, expected...), registry.TxAccessOps(msgsTx(&banktypes.MsgSend{}, &banktypes.MsgSend{})))
+	require.Nil(t, registry.TxAccessOps(msgsTx(&banktypes.MsgSend{}, &banktypes.MsgMultiSend{})))
+
+	generators := registry.DependencyGenerators()
+	require.Len(t, generators, 1)
+	generated, err := generators[sendKey](keeper.Keeper{}, sdk.Context{}, &banktypes.MsgSend{})
+	require.NoError(t, err)
+	require.Equal(t, expected, generated)
+}
@@ -23,6 +23,9 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
 		}
 
 	}
+	for _, record := range genState.GetWasmDependencyMappingRecords() {
+		k.SetWasmDependencyMappingRecord(ctx, record)
+	}
 }
 
 func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
@@ -36,9 +39,15 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 		wasmDependencyMappings = append(wasmDependencyMappings, dependencyMapping)
 		return false
 	})
+	var wasmDependencyMappingRecords []types.WasmDependencyMappingRecord
+	k.IterateWasmDependencyMappingRecords(ctx, func(record types.WasmDependencyMappingRecord) (stop bool) {
+		wasmDependencyMappingRecords = append(wasmDependencyMappingRecords, record)
+		return false
+	})
 	return &types.GenesisState{
-		Params:                   k.GetParams(ctx),
-		MessageDependencyMapping: resourceDependencyMappings,
-		WasmDependencyMappings:   wasmDependencyMappings,
+		Params:                       k.GetParams(ctx),
+		MessageDependencyMapping:     resourceDependencyMappings,
+		WasmDependencyMappings:       wasmDependencyMappings,
+		WasmDependencyMappingRecords: wasmDependencyMappingRecords,
 	}
 }
@@ -100,3 +100,29 @@ func TestKeeper_InitGenesis_InvalidDependencies(t *testing.T) {
 	})
 
 }
+
+func TestKeeper_InitAndExportGenesis_WasmDependencyMappingRecords(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+	addresses := simapp.AddTestAddrsIncremental(app, ctx, 2, sdk.NewInt(30000000))
+
+	mapping := types.SynchronousWasmDependencyMapping(addresses[0].String())
+	testGenesis := types.GenesisState{
+		Params:                 types.DefaultParams(),
+		WasmDependencyMappings: []accesscontrol.WasmDependencyMapping{mapping},
+		WasmDependencyMappingRecords: []types.WasmDependencyMappingRecord{
+			{ContractAddress: addresses[0].String(), Version: 1, Height: 5, Registrant: addresses[1].String(), WasmDependencyMapping: mapping},
+			{ContractAddress: addresses[0].String(), Version: 2, Height: 7, Registrant: addresses[1].String(), WasmDependencyMapping: mapping},
+		},
+	}
+	require.NoError(t, types.ValidateGenesis(testGenesis))
+
+	app.AccessControlKeeper.InitGenesis(ctx, testGenesis)
+
+	exportedGenesis := app.AccessControlKeeper.ExportGenesis(ctx)
+	require.Equal(t, testGenesis.WasmDependencyMappingRecords, exportedGenesis.WasmDependencyMappingRecords)
+	// the registrant of the latest version keeps managing the mapping
+	version, err := app.AccessControlKeeper.RegisterWasmDependencyMapping(ctx, addresses[1], mapping)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), version)
+}
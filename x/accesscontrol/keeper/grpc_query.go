@@ -3,8 +3,13 @@ package keeper
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
 )
 
@@ -59,3 +64,57 @@ func (k Keeper) ListWasmDependencyMapping(ctx context.Context, req *types.ListWa
 
 	return &types.ListWasmDependencyMappingResponse{WasmDependencyMappingList: wasmDependencyMappings}, nil
 }
+
+func (k Keeper) WasmDependencyMappingHistory(ctx context.Context, req *types.WasmDependencyMappingHistoryRequest) (*types.WasmDependencyMappingHistoryResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	address, err := sdk.AccAddressFromBech32(req.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	records := []types.WasmDependencyMappingRecord{}
+	store := prefix.NewStore(sdkCtx.KVStore(k.storeKey), types.GetWasmContractHistoryKey(address))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_ []byte, value []byte) error {
+		record := types.WasmDependencyMappingRecord{}
+		if err := k.cdc.Unmarshal(value, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.WasmDependencyMappingHistoryResponse{Records: records, Pagination: pageRes}, nil
+}
+
+func (k Keeper) SimulateAccessOps(ctx context.Context, req *types.SimulateAccessOpsRequest) (*types.SimulateAccessOpsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if k.TxDecoder == nil {
+		return nil, status.Error(codes.Unimplemented, "no tx decoder set to simulate access operations")
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	tx, err := k.TxDecoder(req.TxBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode tx: %s", err)
+	}
+	anteAccessOps := []acltypes.AccessOperation{}
+	if k.AnteDepGenerator != nil {
+		if anteAccessOps, err = GetAnteAccessOps(k.AnteDepGenerator, tx, 0); err != nil {
+			return nil, err
+		}
+	}
+	msgAccessOps := []types.MsgAccessOps{}
+	for _, msg := range tx.GetMsgs() {
+		msgAccessOps = append(msgAccessOps, types.MsgAccessOps{
+			MessageKey: string(types.GenerateMessageKey(msg)),
+			AccessOps:  k.GetMessageDependencies(sdkCtx, msg),
+		})
+	}
+
+	return &types.SimulateAccessOpsResponse{AnteAccessOps: anteAccessOps, MsgAccessOps: msgAccessOps}, nil
+}
@@ -6,9 +6,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/simapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	aclkeeper "github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestParams(t *testing.T) {
@@ -120,3 +125,37 @@ func TestListWasmDependencyMapping(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, result.WasmDependencyMappingList, 1)
 }
+
+func TestSimulateAccessOps(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+	keeper := app.AccessControlKeeper
+	addrs := simapp.AddTestAddrsIncremental(app, ctx, 2, sdk.NewInt(30000000))
+
+	msgs := []sdk.Msg{
+		banktypes.NewMsgSend(addrs[0], addrs[1], sdk.NewCoins(sdk.NewInt64Coin("usei", 1))),
+		stakingtypes.NewMsgDelegate(addrs[0], sdk.ValAddress(addrs[1]), sdk.NewInt64Coin("usei", 1)),
+	}
+	txBuilder := simapp.MakeTestEncodingConfig().TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msgs...))
+	bz, err := simapp.MakeTestEncodingConfig().TxConfig.TxEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	response, err := keeper.SimulateAccessOps(sdk.WrapSDKContext(ctx), &types.SimulateAccessOpsRequest{TxBytes: bz})
+	require.NoError(t, err)
+	anteAccessOps, err := aclkeeper.GetAnteAccessOps(keeper.AnteDepGenerator, txBuilder.GetTx(), 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, anteAccessOps)
+	require.Equal(t, anteAccessOps, response.AnteAccessOps)
+	require.Equal(t, []types.MsgAccessOps{
+		{MessageKey: string(types.GenerateMessageKey(msgs[0])), AccessOps: keeper.GetMessageDependencies(ctx, msgs[0])},
+		{MessageKey: string(types.GenerateMessageKey(msgs[1])), AccessOps: keeper.GetMessageDependencies(ctx, msgs[1])},
+	}, response.MsgAccessOps)
+
+	_, err = keeper.SimulateAccessOps(sdk.WrapSDKContext(ctx), &types.SimulateAccessOpsRequest{TxBytes: []byte("invalid")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	keeper.TxDecoder = nil
+	_, err = keeper.SimulateAccessOps(sdk.WrapSDKContext(ctx), &types.SimulateAccessOpsRequest{TxBytes: bz})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -19,6 +19,8 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 )
@@ -40,6 +42,10 @@ type (
 		MessageDependencyGeneratorMapper DependencyGeneratorMap
 		AccountKeeper                    authkeeper.AccountKeeper
 		StakingKeeper                    stakingkeeper.Keeper
+		ContractAddressResolver          ContractAddressResolver
+		TxDecoder                        sdk.TxDecoder
+		AnteDepGenerator                 sdk.AnteDepGenerator
+		dependencyFallbacks              *dependencyFallbacks
 	}
 )
 
@@ -64,6 +70,7 @@ func NewKeeper(
 		MessageDependencyGeneratorMapper: DefaultMessageDependencyGenerator(),
 		AccountKeeper:                    ak,
 		StakingKeeper:                    sk,
+		dependencyFallbacks:              newDependencyFallbacks(),
 	}
 
 	for _, o := range opts {
@@ -86,9 +93,22 @@ func (k Keeper) GetResourceDependencyMapping(ctx sdk.Context, messageKey types.M
 	return dependencyMapping
 }
 
+// SetResourceDependencyMapping sets the dependency mapping of a message type,
+// which stops any fallback of the type to synchronous access operations.
 func (k Keeper) SetResourceDependencyMapping(
 	ctx sdk.Context,
 	dependencyMapping acltypes.MessageDependencyMapping,
+) error {
+	if err := k.setResourceDependencyMapping(ctx, dependencyMapping); err != nil {
+		return err
+	}
+	k.clearDependencyFallback(types.MessageKey(dependencyMapping.GetMessageKey()))
+	return nil
+}
+
+func (k Keeper) setResourceDependencyMapping(
+	ctx sdk.Context,
+	dependencyMapping acltypes.MessageDependencyMapping,
 ) error {
 	err := types.ValidateMessageDependencyMapping(dependencyMapping)
 	if err != nil {
@@ -117,7 +137,7 @@ func (k Keeper) IterateResourceKeys(ctx sdk.Context, handler func(dependencyMapp
 func (k Keeper) SetDependencyMappingDynamicFlag(ctx sdk.Context, messageKey types.MessageKey, enabled bool) error {
 	dependencyMapping := k.GetResourceDependencyMapping(ctx, messageKey)
 	dependencyMapping.DynamicEnabled = enabled
-	return k.SetResourceDependencyMapping(ctx, dependencyMapping)
+	return k.setResourceDependencyMapping(ctx, dependencyMapping)
 }
 
 type ContractReferenceLookupMap map[string]struct{}
@@ -165,6 +185,10 @@ func (k Keeper) GetWasmDependencyAccessOps(ctx sdk.Context, contractAddress sdk.
 	// add to our lookup so we know we've seen this identifier
 	circularDepLookup[uniqueIdentifier] = struct{}{}
 
+	if k.HasWasmDependencyFallback(contractAddress) {
+		return types.SynchronousAccessOps(), nil
+	}
+
 	dependencyMapping, err := k.GetRawWasmDependencyMapping(ctx, contractAddress)
 	if err != nil {
 		if err == sdkerrors.ErrKeyNotFound {
@@ -455,9 +479,94 @@ func (k Keeper) SetWasmDependencyMapping(
 	}
 	resourceKey := types.GetWasmContractAddressKey(contractAddr)
 	store.Set(resourceKey, b)
+	k.clearWasmDependencyFallback(contractAddr)
 	return nil
 }
 
+// RegisterWasmDependencyMapping sets the dependency mapping of a contract on
+// behalf of registrant, recording it as the next version of the mapping, which
+// it returns. Governance, registering as the gov module, may update any mapping,
+// while other registrants may only update the mappings they registered last. A
+// mapping without any version, such as one set in genesis, is only updated by
+// governance.
+func (k Keeper) RegisterWasmDependencyMapping(
+	ctx sdk.Context,
+	registrant sdk.AccAddress,
+	dependencyMapping acltypes.WasmDependencyMapping,
+) (uint64, error) {
+	contractAddr, err := sdk.AccAddressFromBech32(dependencyMapping.ContractAddress)
+	if err != nil {
+		return 0, err
+	}
+	latest, found := k.GetLatestWasmDependencyMappingRecord(ctx, contractAddr)
+	if !registrant.Equals(authtypes.NewModuleAddress(govtypes.ModuleName)) {
+		if found && latest.Registrant != registrant.String() {
+			return 0, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "wasm dependency mapping of %s is registered by %s", contractAddr, latest.Registrant)
+		}
+		if !found && ctx.KVStore(k.storeKey).Has(types.GetWasmContractAddressKey(contractAddr)) {
+			return 0, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "wasm dependency mapping of %s is managed by governance", contractAddr)
+		}
+	}
+	if err := k.SetWasmDependencyMapping(ctx, dependencyMapping); err != nil {
+		return 0, err
+	}
+
+	record := types.WasmDependencyMappingRecord{
+		ContractAddress:       dependencyMapping.ContractAddress,
+		Version:               latest.Version + 1,
+		Height:                ctx.BlockHeight(),
+		Registrant:            registrant.String(),
+		WasmDependencyMapping: dependencyMapping,
+	}
+	k.SetWasmDependencyMappingRecord(ctx, record)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterWasmDependency,
+			sdk.NewAttribute(types.AttributeKeyContractAddress, record.ContractAddress),
+			sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprint(record.Version)),
+			sdk.NewAttribute(types.AttributeKeyRegistrant, record.Registrant),
+		),
+	)
+	return record.Version, nil
+}
+
+// GetLatestWasmDependencyMappingRecord returns the record of the latest version
+// of the dependency mapping of a contract, if any.
+func (k Keeper) GetLatestWasmDependencyMappingRecord(ctx sdk.Context, contractAddress sdk.AccAddress) (types.WasmDependencyMappingRecord, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStoreReversePrefixIterator(store, types.GetWasmContractHistoryKey(contractAddress))
+	defer iter.Close()
+	record := types.WasmDependencyMappingRecord{}
+	if !iter.Valid() {
+		return record, false
+	}
+	k.cdc.MustUnmarshal(iter.Value(), &record)
+	return record, true
+}
+
+// SetWasmDependencyMappingRecord stores the record of a version of the
+// dependency mapping of a contract, without setting the mapping itself.
+func (k Keeper) SetWasmDependencyMappingRecord(ctx sdk.Context, record types.WasmDependencyMappingRecord) {
+	store := ctx.KVStore(k.storeKey)
+	contractAddr := sdk.MustAccAddressFromBech32(record.ContractAddress)
+	store.Set(types.GetWasmContractRecordKey(contractAddr, record.Version), k.cdc.MustMarshal(&record))
+}
+
+// IterateWasmDependencyMappingRecords iterates over the records of the versions
+// of the wasm dependency mappings, by contract and then by version.
+func (k Keeper) IterateWasmDependencyMappingRecords(ctx sdk.Context, handler func(record types.WasmDependencyMappingRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetWasmMappingHistoryKey())
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		record := types.WasmDependencyMappingRecord{}
+		k.cdc.MustUnmarshal(iter.Value(), &record)
+		if handler(record) {
+			break
+		}
+	}
+}
+
 func (k Keeper) ResetWasmDependencyMapping(
 	ctx sdk.Context,
 	contractAddress sdk.AccAddress,
@@ -476,6 +585,17 @@ func (k Keeper) ResetWasmDependencyMapping(
 	b := k.cdc.MustMarshal(dependencyMapping)
 	resourceKey := types.GetWasmContractAddressKey(contractAddress)
 	store.Set(resourceKey, b)
+
+	// the reset is a version of the mapping, which the registrant of the
+	// mapping may still update
+	latest, _ := k.GetLatestWasmDependencyMappingRecord(ctx, contractAddress)
+	k.SetWasmDependencyMappingRecord(ctx, types.WasmDependencyMappingRecord{
+		ContractAddress:       contractAddress.String(),
+		Version:               latest.Version + 1,
+		Height:                ctx.BlockHeight(),
+		Registrant:            latest.Registrant,
+		WasmDependencyMapping: *dependencyMapping,
+	})
 	return nil
 }
 
@@ -503,24 +623,12 @@ func (k Keeper) BuildDependencyDag(ctx sdk.Context, txDecoder sdk.TxDecoder, ant
 			return nil, err
 		}
 		// get the ante dependencies and add them to the dag
-		anteDeps, err := anteDepGen([]acltypes.AccessOperation{}, tx, txIndex)
+		anteAccessOpsList, err := GetAnteAccessOps(anteDepGen, tx, txIndex)
 		if err != nil {
 			return nil, err
 		}
-		anteDepSet := make(map[acltypes.AccessOperation]struct{})
-		anteAccessOpsList := []acltypes.AccessOperation{}
-		for _, accessOp := range anteDeps {
-			// if found in set, we've already included this access Op in out ante dependencies, so skip it
-			if _, found := anteDepSet[accessOp]; found {
-				continue
-			}
-			anteDepSet[accessOp] = struct{}{}
-			err = types.ValidateAccessOp(accessOp)
-			if err != nil {
-				return nil, err
-			}
+		for _, accessOp := range anteAccessOpsList {
 			dependencyDag.AddNodeBuildDependency(acltypes.ANTE_MSG_INDEX, txIndex, accessOp)
-			anteAccessOpsList = append(anteAccessOpsList, accessOp)
 		}
 		// add Access ops for msg for anteMsg
 		dependencyDag.AddAccessOpsForMsg(acltypes.ANTE_MSG_INDEX, txIndex, anteAccessOpsList)
@@ -548,6 +656,29 @@ func (k Keeper) BuildDependencyDag(ctx sdk.Context, txDecoder sdk.TxDecoder, ant
 	return &dependencyDag, nil
 }
 
+// GetAnteAccessOps returns the access operations generated by anteDepGen for the
+// ante handler of the tx at txIndex, without duplicates.
+func GetAnteAccessOps(anteDepGen sdk.AnteDepGenerator, tx sdk.Tx, txIndex int) ([]acltypes.AccessOperation, error) {
+	anteDeps, err := anteDepGen([]acltypes.AccessOperation{}, tx, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	anteDepSet := make(map[acltypes.AccessOperation]struct{})
+	anteAccessOpsList := []acltypes.AccessOperation{}
+	for _, accessOp := range anteDeps {
+		// if found in set, we've already included this access Op in out ante dependencies, so skip it
+		if _, found := anteDepSet[accessOp]; found {
+			continue
+		}
+		anteDepSet[accessOp] = struct{}{}
+		if err := types.ValidateAccessOp(accessOp); err != nil {
+			return nil, err
+		}
+		anteAccessOpsList = append(anteAccessOpsList, accessOp)
+	}
+	return anteAccessOpsList, nil
+}
+
 // Measures the time taken to build dependency dag
 // Metric Names:
 //
@@ -565,6 +696,9 @@ func MeasureBuildDagDuration(start time.Time, method string) {
 func (k Keeper) GetMessageDependencies(ctx sdk.Context, msg sdk.Msg) []acltypes.AccessOperation {
 	// Default behavior is to get the static dependency mapping for the message
 	messageKey := types.GenerateMessageKey(msg)
+	if k.HasDependencyFallback(messageKey) {
+		return types.SynchronousAccessOps()
+	}
 	dependencyMapping := k.GetResourceDependencyMapping(ctx, messageKey)
 	if dependencyGenerator, ok := k.MessageDependencyGeneratorMapper[types.GenerateMessageKey(msg)]; dependencyMapping.DynamicEnabled && ok {
 		// if we have a dependency generator AND dynamic is enabled, use it
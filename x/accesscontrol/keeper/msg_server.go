@@ -22,10 +22,13 @@ var _ types.MsgServer = msgServer{}
 func (k msgServer) RegisterWasmDependency(goCtx context.Context, msg *types.MsgRegisterWasmDependency) (*types.MsgRegisterWasmDependencyResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	err := k.SetWasmDependencyMapping(ctx, msg.WasmDependencyMapping)
+	registrant, err := sdk.AccAddressFromBech32(msg.FromAddress)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := k.RegisterWasmDependencyMapping(ctx, registrant, msg.WasmDependencyMapping); err != nil {
+		return nil, err
+	}
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -3,8 +3,13 @@ package keeper_test
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	aclmodule "github.com/cosmos/cosmos-sdk/x/accesscontrol"
 	"github.com/cosmos/cosmos-sdk/x/accesscontrol/keeper"
 	acltypes "github.com/cosmos/cosmos-sdk/x/accesscontrol/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 func (suite *KeeperTestSuite) TestMessageRegisterWasmDependency() {
@@ -66,3 +71,72 @@ func (suite *KeeperTestSuite) TestMessageRegisterWasmDepFromJson() {
 	req.NoError(err)
 	req.Equal(acltypes.SynchronousWasmDependencyMapping(contractAddr.String()), *deps)
 }
+
+func (suite *KeeperTestSuite) TestMessageRegisterWasmDependencyVersions() {
+	suite.SetupTest()
+	app := suite.app
+	ctx := suite.ctx.WithBlockHeight(10)
+	req := suite.Require()
+
+	msgServer := keeper.NewMsgServerImpl(app.AccessControlKeeper)
+	proposalHandler := aclmodule.NewProposalHandler(app.AccessControlKeeper)
+	govAddr := authtypes.NewModuleAddress(govtypes.ModuleName)
+
+	contractAddr := suite.addrs[0]
+	fromAddr, otherAddr := suite.addrs[1], sdk.AccAddress("other_______________")
+	register := func(from sdk.AccAddress, reason string) error {
+		mapping := acltypes.SynchronousWasmDependencyMapping(contractAddr.String())
+		mapping.ResetReason = reason
+		_, err := msgServer.RegisterWasmDependency(sdk.WrapSDKContext(ctx), acltypes.NewMsgRegisterWasmDependency(from, contractAddr, mapping))
+		return err
+	}
+
+	req.NoError(register(fromAddr, "v1"))
+	req.NoError(register(fromAddr, "v2"))
+	// only the registrant of the mapping may update it
+	req.ErrorIs(register(otherAddr, "other"), sdkerrors.ErrUnauthorized)
+
+	// while governance may update any mapping, after which only governance may
+	proposal := acltypes.NewMsgUpdateWasmDependencyMappingProposal("title", "description", contractAddr.String(), acltypes.SynchronousWasmDependencyMapping(contractAddr.String()))
+	proposal.WasmDependencyMapping.ResetReason = "v3"
+	req.NoError(proposal.ValidateBasic())
+	req.NoError(proposalHandler(ctx, proposal))
+	req.ErrorIs(register(fromAddr, "v4"), sdkerrors.ErrUnauthorized)
+	req.NoError(app.AccessControlKeeper.ResetWasmDependencyMapping(ctx, contractAddr, "reset"))
+
+	var versions []uint64
+	var reasons, registrants []string
+	res, err := suite.queryClient.WasmDependencyMappingHistory(sdk.WrapSDKContext(ctx), &acltypes.WasmDependencyMappingHistoryRequest{ContractAddress: contractAddr.String()})
+	req.NoError(err)
+	for _, record := range res.Records {
+		req.Equal(int64(10), record.Height)
+		versions = append(versions, record.Version)
+		reasons = append(reasons, record.WasmDependencyMapping.ResetReason)
+		registrants = append(registrants, record.Registrant)
+	}
+	req.Equal([]uint64{1, 2, 3, 4}, versions)
+	req.Equal([]string{"v1", "v2", "v3", "reset"}, reasons)
+	req.Equal([]string{fromAddr.String(), fromAddr.String(), govAddr.String(), govAddr.String()}, registrants)
+
+	res, err = suite.queryClient.WasmDependencyMappingHistory(sdk.WrapSDKContext(ctx), &acltypes.WasmDependencyMappingHistoryRequest{
+		ContractAddress: contractAddr.String(),
+		Pagination:      &query.PageRequest{Offset: 1, Limit: 2},
+	})
+	req.NoError(err)
+	req.Len(res.Records, 2)
+	req.Equal(uint64(2), res.Records[0].Version)
+	req.NotNil(res.Pagination.NextKey)
+
+	// a mapping set without a version, as in genesis, is managed by governance
+	genesisContract := sdk.AccAddress("genesis_contract____")
+	req.NoError(app.AccessControlKeeper.SetWasmDependencyMapping(ctx, acltypes.SynchronousWasmDependencyMapping(genesisContract.String())))
+	_, err = app.AccessControlKeeper.RegisterWasmDependencyMapping(ctx, fromAddr, acltypes.SynchronousWasmDependencyMapping(genesisContract.String()))
+	req.ErrorIs(err, sdkerrors.ErrUnauthorized)
+	version, err := app.AccessControlKeeper.RegisterWasmDependencyMapping(ctx, govAddr, acltypes.SynchronousWasmDependencyMapping(genesisContract.String()))
+	req.NoError(err)
+	req.Equal(uint64(1), version)
+
+	// the mapping proposed must be the one of the contract
+	proposal.ContractAddress = genesisContract.String()
+	req.ErrorIs(proposal.ValidateBasic(), sdkerrors.ErrInvalidRequest)
+}
@@ -1,5 +1,7 @@
 package keeper
 
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
 type optsFn func(*Keeper)
 
 func (f optsFn) Apply(keeper *Keeper) {
@@ -18,6 +20,32 @@ func WithDependencyGeneratorMappings(generator DependencyGeneratorMap) optsFn {
 	})
 }
 
+// WithContractAddressResolver sets the resolver of the contracts executed by
+// msgs, which attributes their dependency mismatches to the contracts rather
+// than to their message types.
+func WithContractAddressResolver(resolver ContractAddressResolver) optsFn {
+	return optsFn(func(k *Keeper) {
+		k.ContractAddressResolver = resolver
+	})
+}
+
+// WithTxDecoder sets the decoder of the txs whose access operations are
+// simulated by the SimulateAccessOps query.
+func WithTxDecoder(txDecoder sdk.TxDecoder) optsFn {
+	return optsFn(func(k *Keeper) {
+		k.TxDecoder = txDecoder
+	})
+}
+
+// WithAnteDepGenerator sets the generator of the access operations of the ante
+// handler of the txs simulated by the SimulateAccessOps query, which should be
+// the one of the app.
+func WithAnteDepGenerator(anteDepGen sdk.AnteDepGenerator) optsFn {
+	return optsFn(func(k *Keeper) {
+		k.AnteDepGenerator = anteDepGen
+	})
+}
+
 func (oldGenerator DependencyGeneratorMap) Merge(newGenerator DependencyGeneratorMap) DependencyGeneratorMap {
 	for messageKey, dependencyGenerator := range newGenerator {
 		// overwrite default generator mappings with the new ones
@@ -0,0 +1,12 @@
+package types
+
+// accesscontrol module event types
+const (
+	EventTypeRegisterWasmDependency = "register_wasm_dependency"
+	EventTypeDependencyFallback     = "dependency_fallback"
+
+	AttributeKeyContractAddress = "contract_address"
+	AttributeKeyVersion         = "version"
+	AttributeKeyRegistrant      = "registrant"
+	AttributeKeyMessageKey      = "message_key"
+)
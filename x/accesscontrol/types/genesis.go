@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
@@ -39,6 +40,19 @@ func ValidateGenesis(data GenesisState) error {
 			return err
 		}
 	}
+	versions := make(map[string]map[uint64]bool)
+	for _, record := range data.WasmDependencyMappingRecords {
+		if err := ValidateWasmDependencyMappingRecord(record); err != nil {
+			return err
+		}
+		if versions[record.ContractAddress] == nil {
+			versions[record.ContractAddress] = make(map[uint64]bool)
+		}
+		if versions[record.ContractAddress][record.Version] {
+			return fmt.Errorf("duplicate version %d of the wasm dependency mapping of %s", record.Version, record.ContractAddress)
+		}
+		versions[record.ContractAddress][record.Version] = true
+	}
 	return data.Params.Validate()
 }
 
@@ -53,3 +67,15 @@ func GetGenesisStateFromAppState(cdc codec.JSONCodec, appState map[string]json.R
 
 	return &genesisState
 }
+
+// ValidateWasmDependencyMappingRecord validates a version of the dependency
+// mapping of a contract.
+func ValidateWasmDependencyMappingRecord(record WasmDependencyMappingRecord) error {
+	if record.Version == 0 {
+		return fmt.Errorf("version of the wasm dependency mapping of %s must be positive", record.ContractAddress)
+	}
+	if record.WasmDependencyMapping.ContractAddress != record.ContractAddress {
+		return fmt.Errorf("record of the wasm dependency mapping of %s holds the mapping of %s", record.ContractAddress, record.WasmDependencyMapping.ContractAddress)
+	}
+	return ValidateWasmDependencyMapping(record.WasmDependencyMapping)
+}
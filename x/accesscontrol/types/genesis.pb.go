@@ -29,6 +29,8 @@ type GenesisState struct {
 	// mapping between every message type and its predetermined resource read/write sequence
 	MessageDependencyMapping []accesscontrol.MessageDependencyMapping `protobuf:"bytes,2,rep,name=message_dependency_mapping,json=messageDependencyMapping,proto3" json:"message_dependency_mapping" yaml:"message_dependency_mapping"`
 	WasmDependencyMappings   []accesscontrol.WasmDependencyMapping    `protobuf:"bytes,3,rep,name=wasm_dependency_mappings,json=wasmDependencyMappings,proto3" json:"wasm_dependency_mappings" yaml:"wasm_dependency_mappings"`
+	// the versions registered of the wasm dependency mappings
+	WasmDependencyMappingRecords []WasmDependencyMappingRecord `protobuf:"bytes,4,rep,name=wasm_dependency_mapping_records,json=wasmDependencyMappingRecords,proto3" json:"wasm_dependency_mapping_records" yaml:"wasm_dependency_mapping_records"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -85,13 +87,102 @@ func (m *GenesisState) GetWasmDependencyMappings() []accesscontrol.WasmDependenc
 	return nil
 }
 
+func (m *GenesisState) GetWasmDependencyMappingRecords() []WasmDependencyMappingRecord {
+	if m != nil {
+		return m.WasmDependencyMappingRecords
+	}
+	return nil
+}
+
+// WasmDependencyMappingRecord is a version of the dependency mapping of a wasm
+// contract, kept for auditing its registrations.
+type WasmDependencyMappingRecord struct {
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty" yaml:"contract_address"`
+	// version numbers the registrations of the mapping of the contract from 1
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// height is the block height at which the version was registered
+	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// registrant is the address that registered the version, the one of the
+	// gov module for the versions set through governance proposals
+	Registrant            string                              `protobuf:"bytes,4,opt,name=registrant,proto3" json:"registrant,omitempty"`
+	WasmDependencyMapping accesscontrol.WasmDependencyMapping `protobuf:"bytes,5,opt,name=wasm_dependency_mapping,json=wasmDependencyMapping,proto3" json:"wasm_dependency_mapping" yaml:"wasm_dependency_mapping"`
+}
+
+func (m *WasmDependencyMappingRecord) Reset()         { *m = WasmDependencyMappingRecord{} }
+func (m *WasmDependencyMappingRecord) String() string { return proto.CompactTextString(m) }
+func (*WasmDependencyMappingRecord) ProtoMessage()    {}
+func (*WasmDependencyMappingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35812e6814a64fba, []int{1}
+}
+func (m *WasmDependencyMappingRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WasmDependencyMappingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WasmDependencyMappingRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WasmDependencyMappingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmDependencyMappingRecord.Merge(m, src)
+}
+func (m *WasmDependencyMappingRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *WasmDependencyMappingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmDependencyMappingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmDependencyMappingRecord proto.InternalMessageInfo
+
+func (m *WasmDependencyMappingRecord) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *WasmDependencyMappingRecord) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WasmDependencyMappingRecord) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *WasmDependencyMappingRecord) GetRegistrant() string {
+	if m != nil {
+		return m.Registrant
+	}
+	return ""
+}
+
+func (m *WasmDependencyMappingRecord) GetWasmDependencyMapping() accesscontrol.WasmDependencyMapping {
+	if m != nil {
+		return m.WasmDependencyMapping
+	}
+	return accesscontrol.WasmDependencyMapping{}
+}
+
 type Params struct {
 }
 
 func (m *Params) Reset()      { *m = Params{} }
 func (*Params) ProtoMessage() {}
 func (*Params) Descriptor() ([]byte, []int) {
-	return fileDescriptor_35812e6814a64fba, []int{1}
+	return fileDescriptor_35812e6814a64fba, []int{2}
 }
 func (m *Params) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -122,6 +213,7 @@ var xxx_messageInfo_Params proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "cosmos.accesscontrol_x.v1beta1.GenesisState")
+	proto.RegisterType((*WasmDependencyMappingRecord)(nil), "cosmos.accesscontrol_x.v1beta1.WasmDependencyMappingRecord")
 	proto.RegisterType((*Params)(nil), "cosmos.accesscontrol_x.v1beta1.Params")
 }
 
@@ -130,29 +222,39 @@ func init() {
 }
 
 var fileDescriptor_35812e6814a64fba = []byte{
-	// 340 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x41, 0x4b, 0x02, 0x41,
-	0x1c, 0xc5, 0x77, 0x52, 0x3c, 0xac, 0xd1, 0x61, 0x89, 0x58, 0x3c, 0xcc, 0xda, 0x12, 0x65, 0x87,
-	0x66, 0x51, 0xa1, 0x83, 0x47, 0x11, 0xba, 0x24, 0x84, 0x1d, 0x82, 0x2e, 0x32, 0x8e, 0xc3, 0x24,
-	0x39, 0x3b, 0x83, 0xff, 0x29, 0xf5, 0x53, 0xd4, 0x29, 0xba, 0xd5, 0xc7, 0xf1, 0xe8, 0xb1, 0x93,
-	0x84, 0x7e, 0x83, 0x3e, 0x41, 0xb8, 0x23, 0x42, 0xb6, 0x7a, 0x9a, 0x19, 0x78, 0xbf, 0xf7, 0x1e,
-	0xf3, 0xdc, 0x13, 0xa6, 0x40, 0x2a, 0x88, 0x28, 0x63, 0x1c, 0x80, 0xa9, 0xd8, 0x0c, 0x54, 0xbf,
-	0x3d, 0x8a, 0x04, 0x8f, 0x39, 0xf4, 0x80, 0xe8, 0x81, 0x32, 0xca, 0xc3, 0x56, 0x45, 0x36, 0x54,
-	0xe4, 0xb9, 0xdc, 0xe1, 0x86, 0x96, 0x0b, 0x87, 0x42, 0x09, 0x95, 0x48, 0xa3, 0xe5, 0xcd, 0x52,
-	0x85, 0x52, 0x9a, 0xf7, 0xdf, 0x97, 0x55, 0x86, 0x2f, 0x19, 0x77, 0xff, 0xca, 0x26, 0xde, 0x1a,
-	0x6a, 0xb8, 0xd7, 0x70, 0x73, 0x9a, 0x0e, 0xa8, 0x04, 0x1f, 0x15, 0x51, 0x29, 0x5f, 0x39, 0x25,
-	0xbb, 0x1b, 0x90, 0x9b, 0x44, 0x5d, 0xcf, 0x4e, 0x66, 0x81, 0xd3, 0x5a, 0xb1, 0xde, 0x07, 0x72,
-	0x0b, 0x92, 0x03, 0x50, 0xc1, 0xdb, 0x5d, 0xae, 0x79, 0xdc, 0xe5, 0x31, 0x1b, 0xb7, 0x25, 0xd5,
-	0xba, 0x17, 0x0b, 0x7f, 0xaf, 0x98, 0x29, 0xe5, 0x2b, 0x97, 0xa9, 0xd6, 0x6b, 0xe3, 0xa6, 0xe5,
-	0x1b, 0x6b, 0xbc, 0x69, 0xe9, 0xfa, 0xf9, 0x32, 0xea, 0x67, 0x16, 0x1c, 0x8f, 0xa9, 0xec, 0xd7,
-	0xc2, 0xed, 0x39, 0x61, 0xcb, 0x97, 0x5b, 0x4c, 0xbc, 0x37, 0xe4, 0xfa, 0x43, 0x0a, 0x32, 0x05,
-	0x03, 0x3f, 0x93, 0xf4, 0xab, 0xee, 0xee, 0x77, 0x47, 0x41, 0xfe, 0x2f, 0x77, 0xb6, 0x2a, 0x17,
-	0xd8, 0x72, 0xdb, 0x22, 0xc2, 0xd6, 0xd1, 0x30, 0x8d, 0x87, 0xf0, 0xc0, 0xcd, 0xd9, 0x2f, 0xad,
-	0x65, 0xdf, 0x3f, 0x03, 0xa7, 0x7e, 0x3d, 0x99, 0x63, 0x34, 0x9d, 0x63, 0xf4, 0x3d, 0xc7, 0xe8,
-	0x75, 0x81, 0x9d, 0xe9, 0x02, 0x3b, 0x5f, 0x0b, 0xec, 0xdc, 0x57, 0x44, 0xcf, 0x3c, 0x3c, 0x75,
-	0x08, 0x53, 0x32, 0x5a, 0x0d, 0x6e, 0x8f, 0x0b, 0xe8, 0x3e, 0x46, 0xa3, 0x8d, 0xf5, 0xcd, 0x58,
-	0x73, 0xe8, 0xe4, 0x92, 0xd9, 0xab, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x42, 0x1c, 0x49, 0x84,
-	0x7e, 0x02, 0x00, 0x00,
+	// 499 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcf, 0x8a, 0xd3, 0x40,
+	0x1c, 0xc7, 0x3b, 0x6d, 0xac, 0x38, 0x2b, 0x2a, 0x83, 0xee, 0x0e, 0x5d, 0x49, 0x6a, 0x90, 0x5a,
+	0x0f, 0x26, 0x6c, 0x17, 0x3c, 0xac, 0x27, 0xc3, 0xa2, 0x17, 0x17, 0x64, 0x3c, 0x08, 0x5e, 0xc2,
+	0x34, 0x19, 0xd2, 0xe0, 0x26, 0x13, 0xf2, 0x1b, 0xb7, 0xdb, 0xb7, 0x10, 0x04, 0xf1, 0xa6, 0xcf,
+	0xe0, 0x33, 0x78, 0xd8, 0x8b, 0xb0, 0x47, 0x4f, 0x45, 0xda, 0x37, 0xd8, 0x27, 0x90, 0x66, 0xd2,
+	0x45, 0x6b, 0x12, 0xc1, 0x53, 0x3b, 0xc3, 0xf7, 0xcf, 0x27, 0x93, 0xf9, 0x05, 0xdf, 0x0f, 0x24,
+	0x24, 0x12, 0x5c, 0x1e, 0x04, 0x02, 0x20, 0x90, 0xa9, 0xca, 0xe5, 0xb1, 0x7f, 0xea, 0x46, 0x22,
+	0x15, 0x10, 0x83, 0x93, 0xe5, 0x52, 0x49, 0x62, 0x6a, 0x95, 0xb3, 0xa1, 0x72, 0x4e, 0xf6, 0xc6,
+	0x42, 0xf1, 0xbd, 0xde, 0xed, 0x48, 0x46, 0xb2, 0x90, 0xba, 0xab, 0x7f, 0xda, 0xd5, 0x1b, 0x56,
+	0x65, 0xff, 0xb9, 0xd2, 0x4a, 0xfb, 0x9b, 0x81, 0xaf, 0x3f, 0xd7, 0x8d, 0xaf, 0x14, 0x57, 0x82,
+	0x1c, 0xe2, 0x6e, 0xc6, 0x73, 0x9e, 0x00, 0x45, 0x7d, 0x34, 0xdc, 0x1a, 0x0d, 0x9c, 0x66, 0x02,
+	0xe7, 0x65, 0xa1, 0xf6, 0x8c, 0xb3, 0xb9, 0xd5, 0x62, 0xa5, 0x97, 0x7c, 0x46, 0xb8, 0x97, 0x08,
+	0x00, 0x1e, 0x09, 0x3f, 0x14, 0x99, 0x48, 0x43, 0x91, 0x06, 0x33, 0x3f, 0xe1, 0x59, 0x16, 0xa7,
+	0x11, 0x6d, 0xf7, 0x3b, 0xc3, 0xad, 0xd1, 0xe3, 0xca, 0xe8, 0xcb, 0xe0, 0x23, 0xed, 0x3f, 0xbc,
+	0xb4, 0x1f, 0x69, 0xb7, 0xf7, 0x70, 0x55, 0x75, 0x31, 0xb7, 0xee, 0xcd, 0x78, 0x72, 0x7c, 0x60,
+	0xd7, 0xf7, 0xd8, 0x8c, 0x26, 0x35, 0x21, 0xe4, 0x23, 0xc2, 0x74, 0xca, 0x21, 0xa9, 0xb0, 0x01,
+	0xed, 0x14, 0x7c, 0xfb, 0xcd, 0x7c, 0xaf, 0x39, 0x24, 0x7f, 0xc3, 0x3d, 0x28, 0xe1, 0x2c, 0x0d,
+	0x57, 0x57, 0x61, 0xb3, 0xed, 0x69, 0x95, 0x1f, 0xc8, 0x57, 0x84, 0xad, 0x1a, 0x97, 0x9f, 0x8b,
+	0x40, 0xe6, 0x21, 0x50, 0xa3, 0xe0, 0x7b, 0xf2, 0xaf, 0x57, 0x53, 0x49, 0xc8, 0x8a, 0x0c, 0xcf,
+	0x29, 0x39, 0x07, 0x8d, 0x9c, 0xeb, 0x46, 0x9b, 0xdd, 0x9d, 0xd6, 0x87, 0x81, 0xfd, 0xbd, 0x8d,
+	0x77, 0x1b, 0xda, 0xc8, 0x33, 0x7c, 0xab, 0xc0, 0xe3, 0x81, 0xf2, 0x79, 0x18, 0xe6, 0x02, 0xf4,
+	0xfd, 0xba, 0xe6, 0xed, 0x5e, 0xcc, 0xad, 0x1d, 0xcd, 0xb0, 0xa9, 0xb0, 0xd9, 0xcd, 0xf5, 0xd6,
+	0x53, 0xbd, 0x43, 0x28, 0xbe, 0x7a, 0x22, 0x72, 0x88, 0x65, 0x4a, 0xdb, 0x7d, 0x34, 0x34, 0xd8,
+	0x7a, 0x49, 0xb6, 0x71, 0x77, 0x22, 0xe2, 0x68, 0xa2, 0x68, 0xa7, 0x8f, 0x86, 0x1d, 0x56, 0xae,
+	0x88, 0x89, 0x71, 0x2e, 0xa2, 0x18, 0x54, 0xce, 0x53, 0x45, 0x8d, 0x55, 0x27, 0xfb, 0x6d, 0x87,
+	0x7c, 0x40, 0x78, 0xa7, 0xe6, 0xe1, 0xe9, 0x95, 0x3e, 0xfa, 0xdf, 0x6b, 0x30, 0x28, 0x8f, 0xd7,
+	0x6c, 0x3c, 0x5e, 0x9b, 0xdd, 0xa9, 0x3c, 0x56, 0xfb, 0x06, 0xee, 0xea, 0xb9, 0x3a, 0x30, 0x3e,
+	0x7d, 0xb1, 0x5a, 0xde, 0x8b, 0xb3, 0x85, 0x89, 0xce, 0x17, 0x26, 0xfa, 0xb9, 0x30, 0xd1, 0xfb,
+	0xa5, 0xd9, 0x3a, 0x5f, 0x9a, 0xad, 0x1f, 0x4b, 0xb3, 0xf5, 0x66, 0x14, 0xc5, 0x6a, 0xf2, 0x6e,
+	0xec, 0x04, 0x32, 0x71, 0xcb, 0xa9, 0xd7, 0x3f, 0x8f, 0x20, 0x7c, 0xeb, 0x9e, 0x6e, 0x7c, 0x02,
+	0xd4, 0x2c, 0x13, 0x30, 0xee, 0x16, 0xb3, 0xbf, 0xff, 0x6b, 0x00, 0x07, 0xbb, 0x65, 0xae, 0x83,
+	0x04, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -175,6 +277,20 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.WasmDependencyMappingRecords) > 0 {
+		for iNdEx := len(m.WasmDependencyMappingRecords) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.WasmDependencyMappingRecords[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.WasmDependencyMappings) > 0 {
 		for iNdEx := len(m.WasmDependencyMappings) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -216,6 +332,63 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *WasmDependencyMappingRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WasmDependencyMappingRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WasmDependencyMappingRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.WasmDependencyMapping.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Registrant) > 0 {
+		i -= len(m.Registrant)
+		copy(dAtA[i:], m.Registrant)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Registrant)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Version != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Params) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -270,6 +443,37 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.WasmDependencyMappingRecords) > 0 {
+		for _, e := range m.WasmDependencyMappingRecords {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WasmDependencyMappingRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovGenesis(uint64(m.Version))
+	}
+	if m.Height != 0 {
+		n += 1 + sovGenesis(uint64(m.Height))
+	}
+	l = len(m.Registrant)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = m.WasmDependencyMapping.Size()
+	n += 1 + l + sovGenesis(uint64(l))
 	return n
 }
 
@@ -418,6 +622,225 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WasmDependencyMappingRecords", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WasmDependencyMappingRecords = append(m.WasmDependencyMappingRecords, WasmDependencyMappingRecord{})
+			if err := m.WasmDependencyMappingRecords[len(m.WasmDependencyMappingRecords)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WasmDependencyMappingRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WasmDependencyMappingRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WasmDependencyMappingRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Registrant", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Registrant = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WasmDependencyMapping", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.WasmDependencyMapping.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -4,9 +4,28 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestGenesisValidation(t *testing.T) {
 	genState := DefaultGenesisState()
 	require.NoError(t, ValidateGenesis(*genState))
 }
+
+func TestGenesisValidationWasmDependencyMappingRecords(t *testing.T) {
+	contract := sdk.AccAddress("contract____________").String()
+	record := WasmDependencyMappingRecord{ContractAddress: contract, Version: 1, WasmDependencyMapping: SynchronousWasmDependencyMapping(contract)}
+	genState := DefaultGenesisState()
+	genState.WasmDependencyMappingRecords = []WasmDependencyMappingRecord{record}
+	require.NoError(t, ValidateGenesis(*genState))
+
+	genState.WasmDependencyMappingRecords = []WasmDependencyMappingRecord{record, record}
+	require.Error(t, ValidateGenesis(*genState))
+
+	record.Version = 0
+	require.Error(t, ValidateWasmDependencyMappingRecord(record))
+	record.Version = 1
+	record.WasmDependencyMapping.ContractAddress = sdk.AccAddress("other_______________").String()
+	require.Error(t, ValidateWasmDependencyMappingRecord(record))
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
@@ -77,8 +79,16 @@ func (p *MsgUpdateWasmDependencyMappingProposal) ProposalType() string {
 }
 
 func (p *MsgUpdateWasmDependencyMappingProposal) ValidateBasic() error {
-	err := govtypes.ValidateAbstract(p)
-	return err
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.ContractAddress); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid contract address %s: %s", p.ContractAddress, err)
+	}
+	if p.WasmDependencyMapping.ContractAddress != p.ContractAddress {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "mapping of contract %s proposed for contract %s", p.WasmDependencyMapping.ContractAddress, p.ContractAddress)
+	}
+	return ValidateWasmDependencyMapping(p.WasmDependencyMapping)
 }
 
 func (p MsgUpdateWasmDependencyMappingProposal) String() string {
@@ -197,6 +197,18 @@ func getAllNodeIDsFromIdentifierMapping(mapping ResourceIdentifierNodeIDMapping)
 	return
 }
 
+// getOverlappingNodeIDsFromIdentifierMapping returns the node IDs of the
+// identifiers overlapping identifier, i.e. the wildcard and the identifiers
+// prefixing or prefixed by it, see acltypes.IdentifiersOverlap.
+func getOverlappingNodeIDsFromIdentifierMapping(mapping ResourceIdentifierNodeIDMapping, identifier string) (nodeIDs []DagNodeID) {
+	for otherIdentifier, otherNodeIDs := range mapping {
+		if acltypes.IdentifiersOverlap(identifier, otherIdentifier) {
+			nodeIDs = append(nodeIDs, otherNodeIDs...)
+		}
+	}
+	return
+}
+
 func (dag *Dag) getDependencyWrites(node DagNode, dependentResource acltypes.ResourceType) mapset.Set {
 	nodeIDs := mapset.NewSet()
 	writeResourceAccess := ResourceAccess{
@@ -210,8 +222,7 @@ func (dag *Dag) getDependencyWrites(node DagNode, dependentResource acltypes.Res
 			nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 		} else {
 			if node.AccessOperation.IdentifierTemplate != "*" {
-				nodeIDsMaybeDependency = identifierNodeMapping[node.AccessOperation.IdentifierTemplate]
-				nodeIDsMaybeDependency = append(nodeIDsMaybeDependency, identifierNodeMapping["*"]...)
+				nodeIDsMaybeDependency = getOverlappingNodeIDsFromIdentifierMapping(identifierNodeMapping, node.AccessOperation.IdentifierTemplate)
 			} else {
 				nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 			}
@@ -243,8 +254,7 @@ func (dag *Dag) getDependencyUnknowns(node DagNode, dependentResource acltypes.R
 			nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 		} else {
 			if node.AccessOperation.IdentifierTemplate != "*" {
-				nodeIDsMaybeDependency = identifierNodeMapping[node.AccessOperation.IdentifierTemplate]
-				nodeIDsMaybeDependency = append(nodeIDsMaybeDependency, identifierNodeMapping["*"]...)
+				nodeIDsMaybeDependency = getOverlappingNodeIDsFromIdentifierMapping(identifierNodeMapping, node.AccessOperation.IdentifierTemplate)
 			} else {
 				nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 			}
@@ -276,8 +286,7 @@ func (dag *Dag) getDependencyReads(node DagNode, dependentResource acltypes.Reso
 			nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 		} else {
 			if node.AccessOperation.IdentifierTemplate != "*" {
-				nodeIDsMaybeDependency = identifierNodeMapping[node.AccessOperation.IdentifierTemplate]
-				nodeIDsMaybeDependency = append(nodeIDsMaybeDependency, identifierNodeMapping["*"]...)
+				nodeIDsMaybeDependency = getOverlappingNodeIDsFromIdentifierMapping(identifierNodeMapping, node.AccessOperation.IdentifierTemplate)
 			} else {
 				nodeIDsMaybeDependency = getAllNodeIDsFromIdentifierMapping(identifierNodeMapping)
 			}
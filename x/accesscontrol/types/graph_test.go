@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/hex"
 	"sort"
 	"testing"
 
@@ -305,3 +306,40 @@ func TestDagResourceIdentifiers(t *testing.T) {
 	require.Equal(t, []DagEdge(nil), dag.EdgesMap[6])
 	require.Equal(t, []DagEdge(nil), dag.EdgesMap[7])
 }
+
+func TestDagKeyPrefixIdentifiers(t *testing.T) {
+	dag := NewDag()
+	commit := *CommitAccessOp()
+	balances := hex.EncodeToString([]byte{0x02, 0x01, 'a'})
+	writeBalances := func(identifier string) acltypes.AccessOperation {
+		return acltypes.AccessOperation{
+			AccessType:         acltypes.AccessType_WRITE,
+			ResourceType:       acltypes.ResourceType_KV_BANK_BALANCES,
+			IdentifierTemplate: identifier,
+		}
+	}
+	writeUsei := writeBalances(balances + hex.EncodeToString([]byte("usei")))
+	writeUatom := writeBalances(balances + hex.EncodeToString([]byte("uatom")))
+	writeAccount := writeBalances(balances)
+
+	dag.AddNodeBuildDependency(0, 0, writeUsei)    // node id 0
+	dag.AddNodeBuildDependency(0, 0, commit)       // node id 1
+	dag.AddNodeBuildDependency(0, 1, writeUatom)   // node id 2
+	dag.AddNodeBuildDependency(0, 1, commit)       // node id 3
+	dag.AddNodeBuildDependency(0, 2, writeAccount) // node id 4
+	dag.AddNodeBuildDependency(0, 2, commit)       // node id 5
+	dag.AddNodeBuildDependency(0, 3, writeUsei)    // node id 6
+	dag.AddNodeBuildDependency(0, 3, commit)       // node id 7
+
+	require.True(t, graph.Acyclic(dag))
+	// the balances of the account in different denoms are independent, while
+	// the write of the account prefix depends on both, and the later usei write
+	// on it and on the first usei write
+	require.Equal(t, []DagEdge(nil), dag.EdgesMap[0])
+	require.Equal(t, []DagEdge{{1, 4}, {1, 6}}, dag.EdgesMap[1])
+	require.Equal(t, []DagEdge(nil), dag.EdgesMap[2])
+	require.Equal(t, []DagEdge{{3, 4}}, dag.EdgesMap[3])
+	require.Equal(t, []DagEdge(nil), dag.EdgesMap[4])
+	require.Equal(t, []DagEdge{{5, 6}}, dag.EdgesMap[5])
+	require.Equal(t, []DagEdge(nil), dag.EdgesMap[6])
+}
@@ -9,6 +9,7 @@ import (
 var (
 	ResourceDependencyMappingKey = 0x01
 	WasmMappingKey               = 0x02
+	WasmMappingHistoryKey        = 0x03
 )
 
 const (
@@ -38,3 +39,19 @@ func GetWasmMappingKey() []byte {
 func GetWasmContractAddressKey(contractAddress sdk.AccAddress) []byte {
 	return append(GetWasmMappingKey(), address.MustLengthPrefix(contractAddress)...)
 }
+
+func GetWasmMappingHistoryKey() []byte {
+	return []byte{byte(WasmMappingHistoryKey)}
+}
+
+// GetWasmContractHistoryKey returns the prefix of the records of the versions
+// of the dependency mapping of a contract.
+func GetWasmContractHistoryKey(contractAddress sdk.AccAddress) []byte {
+	return append(GetWasmMappingHistoryKey(), address.MustLengthPrefix(contractAddress)...)
+}
+
+// GetWasmContractRecordKey returns the key of the record of a version of the
+// dependency mapping of a contract, which sort in version order.
+func GetWasmContractRecordKey(contractAddress sdk.AccAddress, version uint64) []byte {
+	return append(GetWasmContractHistoryKey(contractAddress), sdk.Uint64ToBigEndian(version)...)
+}
@@ -7,7 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	accesscontrol "github.com/cosmos/cosmos-sdk/types/accesscontrol"
-	_ "github.com/cosmos/cosmos-sdk/types/query"
+	query "github.com/cosmos/cosmos-sdk/types/query"
 	_ "github.com/gogo/protobuf/gogoproto"
 	grpc1 "github.com/gogo/protobuf/grpc"
 	proto "github.com/gogo/protobuf/proto"
@@ -456,6 +456,262 @@ func (m *ListWasmDependencyMappingResponse) GetWasmDependencyMappingList() []acc
 	return nil
 }
 
+type WasmDependencyMappingHistoryRequest struct {
+	ContractAddress string             `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty" yaml:"contract_address"`
+	Pagination      *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *WasmDependencyMappingHistoryRequest) Reset()         { *m = WasmDependencyMappingHistoryRequest{} }
+func (m *WasmDependencyMappingHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*WasmDependencyMappingHistoryRequest) ProtoMessage()    {}
+func (*WasmDependencyMappingHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d83f2274e13e6a16, []int{10}
+}
+func (m *WasmDependencyMappingHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WasmDependencyMappingHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WasmDependencyMappingHistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WasmDependencyMappingHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmDependencyMappingHistoryRequest.Merge(m, src)
+}
+func (m *WasmDependencyMappingHistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WasmDependencyMappingHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmDependencyMappingHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmDependencyMappingHistoryRequest proto.InternalMessageInfo
+
+func (m *WasmDependencyMappingHistoryRequest) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *WasmDependencyMappingHistoryRequest) GetPagination() *query.PageRequest {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type WasmDependencyMappingHistoryResponse struct {
+	Records    []WasmDependencyMappingRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records"`
+	Pagination *query.PageResponse           `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *WasmDependencyMappingHistoryResponse) Reset()         { *m = WasmDependencyMappingHistoryResponse{} }
+func (m *WasmDependencyMappingHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*WasmDependencyMappingHistoryResponse) ProtoMessage()    {}
+func (*WasmDependencyMappingHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d83f2274e13e6a16, []int{11}
+}
+func (m *WasmDependencyMappingHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WasmDependencyMappingHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WasmDependencyMappingHistoryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WasmDependencyMappingHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmDependencyMappingHistoryResponse.Merge(m, src)
+}
+func (m *WasmDependencyMappingHistoryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WasmDependencyMappingHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmDependencyMappingHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmDependencyMappingHistoryResponse proto.InternalMessageInfo
+
+func (m *WasmDependencyMappingHistoryResponse) GetRecords() []WasmDependencyMappingRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *WasmDependencyMappingHistoryResponse) GetPagination() *query.PageResponse {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type SimulateAccessOpsRequest struct {
+	// tx_bytes is the encoded tx, with or without signatures.
+	TxBytes []byte `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+}
+
+func (m *SimulateAccessOpsRequest) Reset()         { *m = SimulateAccessOpsRequest{} }
+func (m *SimulateAccessOpsRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateAccessOpsRequest) ProtoMessage()    {}
+func (*SimulateAccessOpsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d83f2274e13e6a16, []int{12}
+}
+func (m *SimulateAccessOpsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateAccessOpsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateAccessOpsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateAccessOpsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateAccessOpsRequest.Merge(m, src)
+}
+func (m *SimulateAccessOpsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateAccessOpsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateAccessOpsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateAccessOpsRequest proto.InternalMessageInfo
+
+func (m *SimulateAccessOpsRequest) GetTxBytes() []byte {
+	if m != nil {
+		return m.TxBytes
+	}
+	return nil
+}
+
+type SimulateAccessOpsResponse struct {
+	// ante_access_ops are the access operations of the ante handler of the tx.
+	AnteAccessOps []accesscontrol.AccessOperation `protobuf:"bytes,1,rep,name=ante_access_ops,json=anteAccessOps,proto3" json:"ante_access_ops" yaml:"ante_access_ops"`
+	// msg_access_ops are the access operations of the msgs of the tx, in order.
+	MsgAccessOps []MsgAccessOps `protobuf:"bytes,2,rep,name=msg_access_ops,json=msgAccessOps,proto3" json:"msg_access_ops" yaml:"msg_access_ops"`
+}
+
+func (m *SimulateAccessOpsResponse) Reset()         { *m = SimulateAccessOpsResponse{} }
+func (m *SimulateAccessOpsResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateAccessOpsResponse) ProtoMessage()    {}
+func (*SimulateAccessOpsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d83f2274e13e6a16, []int{13}
+}
+func (m *SimulateAccessOpsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateAccessOpsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateAccessOpsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateAccessOpsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateAccessOpsResponse.Merge(m, src)
+}
+func (m *SimulateAccessOpsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateAccessOpsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateAccessOpsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateAccessOpsResponse proto.InternalMessageInfo
+
+func (m *SimulateAccessOpsResponse) GetAnteAccessOps() []accesscontrol.AccessOperation {
+	if m != nil {
+		return m.AnteAccessOps
+	}
+	return nil
+}
+
+func (m *SimulateAccessOpsResponse) GetMsgAccessOps() []MsgAccessOps {
+	if m != nil {
+		return m.MsgAccessOps
+	}
+	return nil
+}
+
+// MsgAccessOps are the access operations predicted for a msg of a tx.
+type MsgAccessOps struct {
+	MessageKey string                          `protobuf:"bytes,1,opt,name=message_key,json=messageKey,proto3" json:"message_key,omitempty" yaml:"message_key"`
+	AccessOps  []accesscontrol.AccessOperation `protobuf:"bytes,2,rep,name=access_ops,json=accessOps,proto3" json:"access_ops" yaml:"access_ops"`
+}
+
+func (m *MsgAccessOps) Reset()         { *m = MsgAccessOps{} }
+func (m *MsgAccessOps) String() string { return proto.CompactTextString(m) }
+func (*MsgAccessOps) ProtoMessage()    {}
+func (*MsgAccessOps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d83f2274e13e6a16, []int{14}
+}
+func (m *MsgAccessOps) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgAccessOps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgAccessOps.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgAccessOps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgAccessOps.Merge(m, src)
+}
+func (m *MsgAccessOps) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgAccessOps) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgAccessOps.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgAccessOps proto.InternalMessageInfo
+
+func (m *MsgAccessOps) GetMessageKey() string {
+	if m != nil {
+		return m.MessageKey
+	}
+	return ""
+}
+
+func (m *MsgAccessOps) GetAccessOps() []accesscontrol.AccessOperation {
+	if m != nil {
+		return m.AccessOps
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QueryParamsRequest)(nil), "cosmos.accesscontrol_x.v1beta1.QueryParamsRequest")
 	proto.RegisterType((*QueryParamsResponse)(nil), "cosmos.accesscontrol_x.v1beta1.QueryParamsResponse")
@@ -467,6 +723,11 @@ func init() {
 	proto.RegisterType((*ListResourceDependencyMappingResponse)(nil), "cosmos.accesscontrol_x.v1beta1.ListResourceDependencyMappingResponse")
 	proto.RegisterType((*ListWasmDependencyMappingRequest)(nil), "cosmos.accesscontrol_x.v1beta1.ListWasmDependencyMappingRequest")
 	proto.RegisterType((*ListWasmDependencyMappingResponse)(nil), "cosmos.accesscontrol_x.v1beta1.ListWasmDependencyMappingResponse")
+	proto.RegisterType((*WasmDependencyMappingHistoryRequest)(nil), "cosmos.accesscontrol_x.v1beta1.WasmDependencyMappingHistoryRequest")
+	proto.RegisterType((*WasmDependencyMappingHistoryResponse)(nil), "cosmos.accesscontrol_x.v1beta1.WasmDependencyMappingHistoryResponse")
+	proto.RegisterType((*SimulateAccessOpsRequest)(nil), "cosmos.accesscontrol_x.v1beta1.SimulateAccessOpsRequest")
+	proto.RegisterType((*SimulateAccessOpsResponse)(nil), "cosmos.accesscontrol_x.v1beta1.SimulateAccessOpsResponse")
+	proto.RegisterType((*MsgAccessOps)(nil), "cosmos.accesscontrol_x.v1beta1.MsgAccessOps")
 }
 
 func init() {
@@ -474,56 +735,75 @@ func init() {
 }
 
 var fileDescriptor_d83f2274e13e6a16 = []byte{
-	// 781 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcf, 0x4f, 0x13, 0x4d,
-	0x18, 0xee, 0xf0, 0x7d, 0x1f, 0x09, 0xc3, 0xe1, 0x33, 0x23, 0x08, 0xd4, 0xb2, 0x85, 0x11, 0x0b,
-	0x68, 0xdc, 0x0d, 0x25, 0xd1, 0xc4, 0x48, 0x94, 0x0a, 0x24, 0x06, 0xf0, 0xc7, 0x7a, 0x30, 0x91,
-	0x98, 0x66, 0xba, 0x1d, 0xd6, 0x0d, 0xdd, 0x9d, 0x65, 0x67, 0x2b, 0x34, 0x84, 0x8b, 0x7f, 0x81,
-	0x09, 0x7f, 0x80, 0xd1, 0xbb, 0x7a, 0xf2, 0x6f, 0x90, 0x23, 0x09, 0x07, 0x3d, 0x35, 0x06, 0x4c,
-	0x8c, 0x57, 0x2e, 0x5e, 0xcd, 0xee, 0x4e, 0x49, 0x69, 0xf7, 0x47, 0x01, 0x4f, 0xcb, 0xce, 0xbe,
-	0xef, 0xfb, 0xbc, 0xcf, 0xf3, 0xbe, 0xf3, 0x50, 0x88, 0x35, 0xc6, 0x4d, 0xc6, 0x15, 0xa2, 0x69,
-	0x94, 0x73, 0x8d, 0x59, 0xae, 0xc3, 0x2a, 0xc5, 0x4d, 0x65, 0xbd, 0x4a, 0x9d, 0x9a, 0x6c, 0x3b,
-	0xcc, 0x65, 0x48, 0x0a, 0x62, 0xe4, 0x96, 0x18, 0xf9, 0xd5, 0x54, 0x89, 0xba, 0x64, 0x2a, 0xdd,
-	0xa7, 0x33, 0x9d, 0xf9, 0xa1, 0x8a, 0xf7, 0x57, 0x90, 0x95, 0xce, 0xe8, 0x8c, 0xe9, 0x15, 0xaa,
-	0x10, 0xdb, 0x50, 0x88, 0x65, 0x31, 0x97, 0xb8, 0x06, 0xb3, 0xb8, 0xf8, 0x7a, 0x4d, 0xe0, 0x96,
-	0x08, 0xa7, 0x01, 0x98, 0x22, 0xca, 0x29, 0x36, 0xd1, 0x0d, 0xcb, 0x0f, 0x16, 0xb1, 0x13, 0x61,
-	0x3d, 0x9e, 0x7c, 0x13, 0x91, 0x63, 0x11, 0x6c, 0x74, 0x6a, 0x51, 0x6e, 0x08, 0x6c, 0xdc, 0x07,
-	0xd1, 0x13, 0x0f, 0xf1, 0x31, 0x71, 0x88, 0xc9, 0x55, 0xba, 0x5e, 0xa5, 0xdc, 0xc5, 0x2b, 0xf0,
-	0xe2, 0x89, 0x53, 0x6e, 0x33, 0x8b, 0x53, 0x34, 0x07, 0xbb, 0x6d, 0xff, 0x64, 0x10, 0x8c, 0x80,
-	0x89, 0xde, 0x7c, 0x4e, 0x8e, 0x57, 0x43, 0x0e, 0xf2, 0x0b, 0xff, 0xee, 0xd6, 0xb3, 0x29, 0x55,
-	0xe4, 0x62, 0x03, 0xca, 0x2a, 0xe5, 0xac, 0xea, 0x68, 0x74, 0x8e, 0xda, 0xd4, 0x2a, 0x53, 0x4b,
-	0xab, 0x2d, 0x13, 0xdb, 0x36, 0x2c, 0x7d, 0xc1, 0x61, 0xe6, 0x32, 0xe5, 0x9c, 0xe8, 0x74, 0x91,
-	0xd6, 0x44, 0x3b, 0xe8, 0x16, 0xec, 0x35, 0x83, 0xc3, 0xe2, 0x1a, 0xad, 0xf9, 0xe0, 0x3d, 0x85,
-	0x4b, 0x47, 0xf5, 0x2c, 0xaa, 0x11, 0xb3, 0x72, 0x1b, 0x37, 0x7d, 0xc4, 0x2a, 0x34, 0x8f, 0xf3,
-	0xf1, 0x3e, 0x80, 0x4a, 0xc7, 0x58, 0x82, 0xe4, 0x5b, 0x00, 0xd3, 0x8d, 0x82, 0xe5, 0xe3, 0x9c,
-	0xa2, 0x19, 0x24, 0x09, 0xe6, 0x37, 0x43, 0x99, 0x1f, 0xf3, 0x16, 0x65, 0xdb, 0x20, 0x0b, 0x93,
-	0x9e, 0x12, 0x47, 0xf5, 0xec, 0xe8, 0xc9, 0xc6, 0xdb, 0x71, 0xb0, 0x3a, 0x68, 0x46, 0x14, 0xc1,
-	0xab, 0x30, 0xf3, 0x8c, 0x70, 0xb3, 0xed, 0x43, 0x43, 0xae, 0x05, 0x78, 0xc1, 0x6f, 0x88, 0x68,
-	0x6e, 0x91, 0x94, 0xcb, 0x0e, 0xe5, 0x5c, 0x68, 0x76, 0xf9, 0xa8, 0x9e, 0x1d, 0x08, 0xa0, 0x5b,
-	0x23, 0xb0, 0xfa, 0x7f, 0xe3, 0x68, 0x56, 0x9c, 0x7c, 0x06, 0x70, 0x38, 0x02, 0x48, 0x68, 0xb5,
-	0x03, 0xe0, 0xc0, 0x06, 0xe1, 0x66, 0xb4, 0x50, 0xd3, 0xf1, 0x42, 0x85, 0x96, 0x2f, 0xe4, 0x84,
-	0x4a, 0x52, 0xd0, 0x6a, 0x04, 0x02, 0x56, 0xfb, 0x37, 0xc2, 0xd2, 0x71, 0x0e, 0x8e, 0x2d, 0x19,
-	0xdc, 0x8d, 0x1c, 0x7c, 0x63, 0xcb, 0xbf, 0x02, 0x78, 0x35, 0x21, 0x50, 0xf0, 0xfc, 0x00, 0x60,
-	0x36, 0x7a, 0x56, 0xc5, 0x8a, 0xc1, 0xdd, 0x41, 0x30, 0xf2, 0xcf, 0x39, 0x16, 0x43, 0x16, 0x94,
-	0x73, 0x49, 0x8b, 0xe1, 0x83, 0x61, 0x35, 0x13, 0xb5, 0x1d, 0x1e, 0x21, 0x8c, 0xe1, 0x88, 0xf7,
-	0x8c, 0xdb, 0x12, 0xfc, 0x05, 0xc0, 0xd1, 0x98, 0x20, 0xc1, 0xfc, 0x1d, 0x80, 0x99, 0x08, 0xfd,
-	0x9b, 0x69, 0x9f, 0x69, 0xcc, 0xd7, 0x05, 0xe7, 0x2b, 0xb1, 0x63, 0x16, 0x84, 0x87, 0x42, 0x67,
-	0xed, 0x11, 0xc8, 0x7f, 0xec, 0x81, 0xff, 0xf9, 0x76, 0x85, 0xde, 0x03, 0xd8, 0x1d, 0x78, 0x0e,
-	0xca, 0x27, 0x79, 0x53, 0xbb, 0xed, 0xa5, 0xa7, 0x4f, 0x95, 0x13, 0x28, 0x84, 0x95, 0xd7, 0xfb,
-	0x3f, 0x76, 0xba, 0x26, 0xd1, 0xb8, 0x22, 0x0c, 0x37, 0x78, 0xdc, 0xe0, 0xe5, 0xb5, 0x16, 0x97,
-	0x0e, 0xfc, 0x0f, 0x7d, 0xea, 0x82, 0xe3, 0x1d, 0x9a, 0x12, 0x7a, 0x98, 0xd4, 0xd1, 0xe9, 0x9c,
-	0x34, 0xfd, 0xe8, 0xaf, 0xd5, 0x13, 0xec, 0x35, 0x9f, 0xfd, 0x0b, 0xb4, 0x92, 0xc8, 0xde, 0x11,
-	0x95, 0xc3, 0x46, 0xbc, 0xea, 0x30, 0xb3, 0xd8, 0xe4, 0xe2, 0xca, 0x56, 0xd3, 0xcb, 0x36, 0xfa,
-	0x0d, 0xe0, 0x70, 0xec, 0x45, 0x45, 0x73, 0x49, 0xbc, 0x3a, 0x31, 0x84, 0xf4, 0xfc, 0x39, 0xab,
-	0x08, 0x4d, 0x1e, 0xf8, 0x9a, 0xdc, 0x47, 0xb3, 0x89, 0x9a, 0x78, 0xab, 0x5d, 0x8c, 0x11, 0x06,
-	0xfd, 0x02, 0xb0, 0x3f, 0xf4, 0xf2, 0xa0, 0x3b, 0x49, 0xbd, 0xc6, 0x5d, 0xfe, 0xf4, 0xcc, 0x19,
-	0xb3, 0x05, 0xc3, 0xa7, 0x3e, 0xc3, 0x65, 0xb4, 0x98, 0xc8, 0x30, 0xe2, 0x52, 0x2b, 0x5b, 0xad,
-	0xff, 0x7f, 0xb6, 0xd1, 0x4f, 0x00, 0x87, 0x22, 0x0d, 0x09, 0xdd, 0xeb, 0x64, 0x36, 0xb1, 0x9c,
-	0x67, 0xcf, 0x51, 0x41, 0xf0, 0x9e, 0xf7, 0x79, 0xdf, 0x45, 0x33, 0x9d, 0x4d, 0x36, 0x82, 0x7c,
-	0x61, 0x69, 0xf7, 0x40, 0x02, 0x7b, 0x07, 0x12, 0xf8, 0x7e, 0x20, 0x81, 0x37, 0x87, 0x52, 0x6a,
-	0xef, 0x50, 0x4a, 0x7d, 0x3b, 0x94, 0x52, 0xcf, 0xf3, 0xba, 0xe1, 0xbe, 0xac, 0x96, 0x64, 0x8d,
-	0x99, 0x21, 0x10, 0x9b, 0x2d, 0x20, 0x6e, 0xcd, 0xa6, 0xbc, 0xd4, 0xed, 0xff, 0x92, 0x9b, 0xfe,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0xe8, 0x74, 0x69, 0xa8, 0xbf, 0x0a, 0x00, 0x00,
+	// 1080 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcf, 0x6f, 0xdc, 0x54,
+	0x10, 0xce, 0x5b, 0x20, 0xa5, 0xd3, 0x94, 0xd2, 0x47, 0xd3, 0x26, 0x4b, 0xb2, 0x9b, 0xbe, 0x86,
+	0xa4, 0x2d, 0x74, 0xad, 0x26, 0xfc, 0x6a, 0xa1, 0x2a, 0xd9, 0xa6, 0x01, 0xd4, 0x86, 0x82, 0x23,
+	0x84, 0x44, 0x84, 0x2c, 0xaf, 0xf7, 0xd5, 0xb5, 0xba, 0xf6, 0x73, 0xfc, 0xbc, 0x34, 0xab, 0xaa,
+	0x17, 0x10, 0x9c, 0x91, 0xfa, 0x07, 0x20, 0xb8, 0x23, 0xb8, 0xf4, 0x6f, 0xa0, 0x48, 0x1c, 0x2a,
+	0xf5, 0x00, 0xa7, 0x08, 0x25, 0x48, 0x88, 0x6b, 0x2e, 0x5c, 0x91, 0xed, 0xd9, 0xad, 0x77, 0xd7,
+	0x3f, 0x36, 0x9b, 0x9e, 0x36, 0x7e, 0x9e, 0xf9, 0x66, 0xbe, 0x6f, 0xc6, 0x33, 0x2f, 0xc0, 0x0c,
+	0x21, 0x6d, 0x21, 0x15, 0xdd, 0x30, 0xb8, 0x94, 0x86, 0x70, 0x7c, 0x4f, 0x34, 0xb4, 0x4d, 0x65,
+	0xa3, 0xc9, 0xbd, 0x56, 0xc5, 0xf5, 0x84, 0x2f, 0x68, 0x29, 0xb2, 0xa9, 0xf4, 0xd8, 0x54, 0xbe,
+	0x3c, 0x5f, 0xe3, 0xbe, 0x7e, 0xbe, 0x78, 0xcc, 0x14, 0xa6, 0x08, 0x4d, 0x95, 0xe0, 0xaf, 0xc8,
+	0xab, 0x38, 0x65, 0x0a, 0x61, 0x36, 0xb8, 0xa2, 0xbb, 0x96, 0xa2, 0x3b, 0x8e, 0xf0, 0x75, 0xdf,
+	0x12, 0x8e, 0xc4, 0xb7, 0x67, 0x31, 0x6e, 0x4d, 0x97, 0x3c, 0x0a, 0xa6, 0x20, 0x9c, 0xe2, 0xea,
+	0xa6, 0xe5, 0x84, 0xc6, 0x68, 0x7b, 0x3a, 0x29, 0xc7, 0xee, 0x27, 0xb4, 0x9c, 0x4d, 0x61, 0x63,
+	0x72, 0x87, 0x4b, 0x0b, 0x63, 0xb3, 0x63, 0x40, 0x3f, 0x09, 0x22, 0x7e, 0xac, 0x7b, 0xba, 0x2d,
+	0x55, 0xbe, 0xd1, 0xe4, 0xd2, 0x67, 0xeb, 0xf0, 0x52, 0xd7, 0xa9, 0x74, 0x85, 0x23, 0x39, 0x5d,
+	0x86, 0x51, 0x37, 0x3c, 0x99, 0x20, 0x33, 0xe4, 0xf4, 0xa1, 0x85, 0xb9, 0x4a, 0xb6, 0x1a, 0x95,
+	0xc8, 0xbf, 0xfa, 0xec, 0xc3, 0xad, 0xf2, 0x88, 0x8a, 0xbe, 0xcc, 0x82, 0x8a, 0xca, 0xa5, 0x68,
+	0x7a, 0x06, 0x5f, 0xe6, 0x2e, 0x77, 0xea, 0xdc, 0x31, 0x5a, 0xab, 0xba, 0xeb, 0x5a, 0x8e, 0xb9,
+	0xe2, 0x09, 0x7b, 0x95, 0x4b, 0xa9, 0x9b, 0xfc, 0x1a, 0x6f, 0x61, 0x3a, 0xf4, 0x2d, 0x38, 0x64,
+	0x47, 0x87, 0xda, 0x6d, 0xde, 0x0a, 0x83, 0x1f, 0xac, 0x1e, 0xdf, 0xdd, 0x2a, 0xd3, 0x96, 0x6e,
+	0x37, 0x2e, 0xb2, 0xd8, 0x4b, 0xa6, 0x82, 0xdd, 0xf1, 0x67, 0x8f, 0x09, 0x28, 0x03, 0xc7, 0x42,
+	0x92, 0xdf, 0x13, 0x28, 0xb6, 0x01, 0xeb, 0x1d, 0x1f, 0xcd, 0x8e, 0x9c, 0x90, 0xf9, 0x9b, 0x89,
+	0xcc, 0x3b, 0xbc, 0x11, 0xb6, 0x2f, 0x64, 0xf5, 0x4c, 0xa0, 0xc4, 0xee, 0x56, 0xf9, 0x64, 0x77,
+	0xe2, 0xfd, 0x71, 0x98, 0x3a, 0x61, 0xa7, 0x80, 0xb0, 0x9b, 0x30, 0xf5, 0x99, 0x2e, 0xed, 0xbe,
+	0x17, 0x6d, 0xb9, 0x56, 0xe0, 0xc5, 0x30, 0x21, 0xdd, 0xf0, 0x35, 0xbd, 0x5e, 0xf7, 0xb8, 0x94,
+	0xa8, 0xd9, 0xcb, 0xbb, 0x5b, 0xe5, 0x13, 0x51, 0xe8, 0x5e, 0x0b, 0xa6, 0x1e, 0x69, 0x1f, 0x2d,
+	0xe1, 0xc9, 0x03, 0x02, 0xd3, 0x29, 0x81, 0x50, 0xab, 0xfb, 0x04, 0x4e, 0xdc, 0xd1, 0xa5, 0x9d,
+	0x2e, 0xd4, 0x62, 0xb6, 0x50, 0x89, 0xf0, 0xd5, 0x39, 0x54, 0xa9, 0x14, 0xa5, 0x9a, 0x12, 0x81,
+	0xa9, 0xe3, 0x77, 0x92, 0xdc, 0xd9, 0x1c, 0xcc, 0x5e, 0xb7, 0xa4, 0x9f, 0x5a, 0xf8, 0x76, 0x97,
+	0xff, 0x41, 0xe0, 0x95, 0x1c, 0x43, 0xe4, 0xf9, 0x13, 0x81, 0x72, 0x7a, 0xad, 0xb4, 0x86, 0x25,
+	0xfd, 0x09, 0x32, 0xf3, 0xcc, 0x3e, 0x1a, 0xa3, 0x82, 0x94, 0xe7, 0xf2, 0x1a, 0x23, 0x0c, 0xc6,
+	0xd4, 0xa9, 0xb4, 0xee, 0x08, 0x08, 0x31, 0x06, 0x33, 0xc1, 0x6f, 0x56, 0x97, 0xb0, 0x5f, 0x09,
+	0x9c, 0xcc, 0x30, 0x42, 0xe6, 0x3f, 0x10, 0x98, 0x4a, 0xd1, 0x3f, 0x4e, 0x7b, 0xa8, 0x32, 0xbf,
+	0x8a, 0x9c, 0x4f, 0x65, 0x96, 0x19, 0x09, 0x4f, 0x26, 0xd6, 0x3a, 0x64, 0xfb, 0x80, 0xc0, 0xa9,
+	0xc4, 0x08, 0x1f, 0x58, 0xd2, 0x17, 0x5e, 0xeb, 0x29, 0x7f, 0x17, 0x74, 0x05, 0xe0, 0xc9, 0x5c,
+	0x9e, 0x28, 0x74, 0x8f, 0xc2, 0x60, 0x88, 0x57, 0xa2, 0x8d, 0xf1, 0x64, 0x0a, 0x9a, 0x1c, 0x73,
+	0x50, 0x63, 0x9e, 0xec, 0x77, 0x02, 0xb3, 0xd9, 0x79, 0x63, 0x11, 0xd6, 0xe1, 0x80, 0xc7, 0x0d,
+	0xe1, 0xd5, 0x25, 0xca, 0xfd, 0x4e, 0xde, 0xe0, 0x4d, 0x29, 0x6a, 0x80, 0x81, 0xd3, 0xb8, 0x8d,
+	0x48, 0xdf, 0x4f, 0x60, 0x33, 0x9f, 0xcb, 0x26, 0xca, 0xac, 0x8b, 0xce, 0x1b, 0x30, 0xb1, 0x66,
+	0xd9, 0xcd, 0x86, 0xee, 0xf3, 0xa5, 0x30, 0xad, 0x1b, 0x6e, 0x7b, 0xa1, 0xd0, 0x49, 0x78, 0xde,
+	0xdf, 0xd4, 0x6a, 0x2d, 0x9f, 0x47, 0x92, 0x8f, 0xa9, 0x07, 0xfc, 0xcd, 0x6a, 0xf0, 0xc8, 0xbe,
+	0x29, 0xc0, 0x64, 0x82, 0x1f, 0x52, 0x6f, 0xc2, 0x11, 0xdd, 0xf1, 0xb9, 0x16, 0x11, 0xd5, 0x84,
+	0xdb, 0x96, 0xe0, 0x5c, 0x76, 0xc7, 0xb5, 0x91, 0xb8, 0x17, 0x26, 0x57, 0x2d, 0x61, 0xaf, 0x1d,
+	0x8f, 0xaa, 0xdc, 0x83, 0xc9, 0xd4, 0xc3, 0xc1, 0x49, 0x27, 0x3c, 0xdd, 0x80, 0x17, 0x6c, 0x69,
+	0xc6, 0xa3, 0x16, 0xc2, 0xa8, 0xaf, 0xe5, 0x09, 0xbf, 0x2a, 0xcd, 0x0e, 0x4a, 0x75, 0x1a, 0x83,
+	0x8e, 0xe3, 0x47, 0xdd, 0x85, 0xc8, 0xd4, 0x31, 0x3b, 0x66, 0xcc, 0x7e, 0x21, 0x30, 0x16, 0xf7,
+	0x1e, 0x7a, 0xeb, 0x51, 0x13, 0xa0, 0x2f, 0xf1, 0x3d, 0xca, 0x35, 0x89, 0x99, 0x1f, 0x45, 0xb9,
+	0x62, 0x59, 0x1f, 0xd4, 0xdb, 0x19, 0x2e, 0x7c, 0x7d, 0x18, 0x9e, 0x0b, 0xef, 0x09, 0xf4, 0x47,
+	0x02, 0xa3, 0xd1, 0xb2, 0xa7, 0x0b, 0x79, 0x12, 0xf5, 0xdf, 0x37, 0x8a, 0x8b, 0x7b, 0xf2, 0x89,
+	0x5a, 0x83, 0x29, 0x5f, 0x3d, 0xfe, 0xfb, 0x7e, 0xe1, 0x0c, 0x9d, 0x57, 0x22, 0x67, 0xfc, 0x39,
+	0x27, 0xeb, 0xb7, 0x7b, 0xae, 0x47, 0xd1, 0xc5, 0x83, 0xfe, 0x5c, 0x80, 0xf9, 0x01, 0x6f, 0x03,
+	0xf4, 0xa3, 0xbc, 0x8c, 0xf6, 0x76, 0x85, 0x29, 0xde, 0x78, 0x6a, 0x78, 0xc8, 0xde, 0x08, 0xd9,
+	0x7f, 0x41, 0xd7, 0x73, 0xd9, 0x7b, 0x88, 0x9c, 0x34, 0x5b, 0x6f, 0x7a, 0xc2, 0xd6, 0x62, 0x8d,
+	0xa4, 0xdc, 0x8d, 0x3d, 0xdc, 0xa3, 0xff, 0x11, 0x98, 0xce, 0xdc, 0x90, 0x74, 0x39, 0x8f, 0xd7,
+	0x20, 0x9b, 0xb8, 0x78, 0x75, 0x9f, 0x28, 0xa8, 0xc9, 0x87, 0xa1, 0x26, 0x57, 0xe8, 0x52, 0xae,
+	0x26, 0xc1, 0x4e, 0xd1, 0x32, 0x84, 0xa1, 0xff, 0x12, 0x18, 0x4f, 0x1c, 0xa2, 0xf4, 0xdd, 0x21,
+	0x67, 0x6f, 0xc4, 0xf4, 0xd2, 0x90, 0xde, 0xc8, 0x70, 0x2d, 0x64, 0xb8, 0x4a, 0xaf, 0xe5, 0x32,
+	0x4c, 0xd9, 0xa6, 0xca, 0xdd, 0xde, 0x05, 0x77, 0x8f, 0xfe, 0x43, 0x60, 0x32, 0xf5, 0x26, 0x40,
+	0xdf, 0x1b, 0xa4, 0x36, 0x99, 0x9c, 0x97, 0xf6, 0x81, 0x80, 0xbc, 0xaf, 0x86, 0xbc, 0x2f, 0xd3,
+	0x4b, 0x83, 0x55, 0x36, 0x85, 0x3c, 0xfd, 0xb6, 0x00, 0x53, 0x59, 0x1b, 0x97, 0x5e, 0x19, 0xaa,
+	0x3c, 0xdd, 0xf7, 0x8c, 0xe2, 0xf2, 0xfe, 0x40, 0x90, 0xf2, 0x7a, 0x48, 0xf9, 0x53, 0xba, 0x36,
+	0x6c, 0xa9, 0xb5, 0x5b, 0x11, 0x62, 0x52, 0xc9, 0x7f, 0x23, 0x70, 0xb4, 0x6f, 0xe9, 0xd2, 0xb7,
+	0xf3, 0x12, 0x4f, 0xdb, 0xef, 0xc5, 0x0b, 0x43, 0x78, 0x22, 0xcf, 0xcb, 0x21, 0xcf, 0x0b, 0x17,
+	0xc9, 0x59, 0xf6, 0x7a, 0x2e, 0x55, 0x89, 0x30, 0xb1, 0x3d, 0x5a, 0xbd, 0xfe, 0x70, 0xbb, 0x44,
+	0x1e, 0x6d, 0x97, 0xc8, 0x5f, 0xdb, 0x25, 0xf2, 0xdd, 0x4e, 0x69, 0xe4, 0xd1, 0x4e, 0x69, 0xe4,
+	0xcf, 0x9d, 0xd2, 0xc8, 0xe7, 0x0b, 0xa6, 0xe5, 0xdf, 0x6a, 0xd6, 0x2a, 0x86, 0xb0, 0x13, 0x90,
+	0x37, 0x7b, 0xb0, 0xfd, 0x96, 0xcb, 0x65, 0x6d, 0x34, 0xfc, 0xbf, 0x78, 0xf1, 0xff, 0x01, 0x00,
+	0x09, 0x50, 0xfe, 0xb7, 0x0d, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -543,6 +823,13 @@ type QueryClient interface {
 	ListResourceDependencyMapping(ctx context.Context, in *ListResourceDependencyMappingRequest, opts ...grpc.CallOption) (*ListResourceDependencyMappingResponse, error)
 	WasmDependencyMapping(ctx context.Context, in *WasmDependencyMappingRequest, opts ...grpc.CallOption) (*WasmDependencyMappingResponse, error)
 	ListWasmDependencyMapping(ctx context.Context, in *ListWasmDependencyMappingRequest, opts ...grpc.CallOption) (*ListWasmDependencyMappingResponse, error)
+	// WasmDependencyMappingHistory returns the versions registered of the
+	// dependency mapping of a wasm contract, from the oldest.
+	WasmDependencyMappingHistory(ctx context.Context, in *WasmDependencyMappingHistoryRequest, opts ...grpc.CallOption) (*WasmDependencyMappingHistoryResponse, error)
+	// SimulateAccessOps returns the access operations predicted for a tx, as the
+	// scheduler would build the dependencies of the tx in a block, without
+	// executing it. The tx needs not be signed.
+	SimulateAccessOps(ctx context.Context, in *SimulateAccessOpsRequest, opts ...grpc.CallOption) (*SimulateAccessOpsResponse, error)
 }
 
 type queryClient struct {
@@ -598,6 +885,24 @@ func (c *queryClient) ListWasmDependencyMapping(ctx context.Context, in *ListWas
 	return out, nil
 }
 
+func (c *queryClient) WasmDependencyMappingHistory(ctx context.Context, in *WasmDependencyMappingHistoryRequest, opts ...grpc.CallOption) (*WasmDependencyMappingHistoryResponse, error) {
+	out := new(WasmDependencyMappingHistoryResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.accesscontrol_x.v1beta1.Query/WasmDependencyMappingHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SimulateAccessOps(ctx context.Context, in *SimulateAccessOpsRequest, opts ...grpc.CallOption) (*SimulateAccessOpsResponse, error) {
+	out := new(SimulateAccessOpsResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.accesscontrol_x.v1beta1.Query/SimulateAccessOps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
@@ -605,6 +910,13 @@ type QueryServer interface {
 	ListResourceDependencyMapping(context.Context, *ListResourceDependencyMappingRequest) (*ListResourceDependencyMappingResponse, error)
 	WasmDependencyMapping(context.Context, *WasmDependencyMappingRequest) (*WasmDependencyMappingResponse, error)
 	ListWasmDependencyMapping(context.Context, *ListWasmDependencyMappingRequest) (*ListWasmDependencyMappingResponse, error)
+	// WasmDependencyMappingHistory returns the versions registered of the
+	// dependency mapping of a wasm contract, from the oldest.
+	WasmDependencyMappingHistory(context.Context, *WasmDependencyMappingHistoryRequest) (*WasmDependencyMappingHistoryResponse, error)
+	// SimulateAccessOps returns the access operations predicted for a tx, as the
+	// scheduler would build the dependencies of the tx in a block, without
+	// executing it. The tx needs not be signed.
+	SimulateAccessOps(context.Context, *SimulateAccessOpsRequest) (*SimulateAccessOpsResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -626,6 +938,12 @@ func (*UnimplementedQueryServer) WasmDependencyMapping(ctx context.Context, req
 func (*UnimplementedQueryServer) ListWasmDependencyMapping(ctx context.Context, req *ListWasmDependencyMappingRequest) (*ListWasmDependencyMappingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListWasmDependencyMapping not implemented")
 }
+func (*UnimplementedQueryServer) WasmDependencyMappingHistory(ctx context.Context, req *WasmDependencyMappingHistoryRequest) (*WasmDependencyMappingHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WasmDependencyMappingHistory not implemented")
+}
+func (*UnimplementedQueryServer) SimulateAccessOps(ctx context.Context, req *SimulateAccessOpsRequest) (*SimulateAccessOpsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateAccessOps not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -721,6 +1039,42 @@ func _Query_ListWasmDependencyMapping_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_WasmDependencyMappingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WasmDependencyMappingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).WasmDependencyMappingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.accesscontrol_x.v1beta1.Query/WasmDependencyMappingHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).WasmDependencyMappingHistory(ctx, req.(*WasmDependencyMappingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_SimulateAccessOps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateAccessOpsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).SimulateAccessOps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.accesscontrol_x.v1beta1.Query/SimulateAccessOps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SimulateAccessOps(ctx, req.(*SimulateAccessOpsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cosmos.accesscontrol_x.v1beta1.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -745,6 +1099,14 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListWasmDependencyMapping",
 			Handler:    _Query_ListWasmDependencyMapping_Handler,
 		},
+		{
+			MethodName: "WasmDependencyMappingHistory",
+			Handler:    _Query_WasmDependencyMappingHistory_Handler,
+		},
+		{
+			MethodName: "SimulateAccessOps",
+			Handler:    _Query_SimulateAccessOps_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cosmos/accesscontrol_x/query.proto",
@@ -1052,53 +1414,269 @@ func (m *ListWasmDependencyMappingResponse) MarshalToSizedBuffer(dAtA []byte) (i
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *QueryParamsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WasmDependencyMappingHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QueryParamsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Params.Size()
-	n += 1 + l + sovQuery(uint64(l))
-	return n
+func (m *WasmDependencyMappingHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResourceDependencyMappingFromMessageKeyRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *WasmDependencyMappingHistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.MessageKey)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ResourceDependencyMappingFromMessageKeyResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WasmDependencyMappingHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WasmDependencyMappingHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WasmDependencyMappingHistoryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateAccessOpsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateAccessOpsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateAccessOpsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxBytes) > 0 {
+		i -= len(m.TxBytes)
+		copy(dAtA[i:], m.TxBytes)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.TxBytes)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateAccessOpsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateAccessOpsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateAccessOpsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MsgAccessOps) > 0 {
+		for iNdEx := len(m.MsgAccessOps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MsgAccessOps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.AnteAccessOps) > 0 {
+		for iNdEx := len(m.AnteAccessOps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AnteAccessOps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgAccessOps) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgAccessOps) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgAccessOps) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AccessOps) > 0 {
+		for iNdEx := len(m.AccessOps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccessOps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.MessageKey) > 0 {
+		i -= len(m.MessageKey)
+		copy(dAtA[i:], m.MessageKey)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.MessageKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryParamsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryParamsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *ResourceDependencyMappingFromMessageKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MessageKey)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *ResourceDependencyMappingFromMessageKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -1179,6 +1757,95 @@ func (m *ListWasmDependencyMappingResponse) Size() (n int) {
 	return n
 }
 
+func (m *WasmDependencyMappingHistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *WasmDependencyMappingHistoryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *SimulateAccessOpsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxBytes)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *SimulateAccessOpsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AnteAccessOps) > 0 {
+		for _, e := range m.AnteAccessOps {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if len(m.MsgAccessOps) > 0 {
+		for _, e := range m.MsgAccessOps {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MsgAccessOps) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MessageKey)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.AccessOps) > 0 {
+		for _, e := range m.AccessOps {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1916,6 +2583,562 @@ func (m *ListWasmDependencyMappingResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WasmDependencyMappingHistoryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WasmDependencyMappingHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WasmDependencyMappingHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageRequest{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WasmDependencyMappingHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WasmDependencyMappingHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WasmDependencyMappingHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, WasmDependencyMappingRecord{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageResponse{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateAccessOpsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateAccessOpsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateAccessOpsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxBytes = append(m.TxBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxBytes == nil {
+				m.TxBytes = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateAccessOpsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateAccessOpsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateAccessOpsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AnteAccessOps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AnteAccessOps = append(m.AnteAccessOps, accesscontrol.AccessOperation{})
+			if err := m.AnteAccessOps[len(m.AnteAccessOps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgAccessOps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MsgAccessOps = append(m.MsgAccessOps, MsgAccessOps{})
+			if err := m.MsgAccessOps[len(m.MsgAccessOps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgAccessOps) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgAccessOps: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgAccessOps: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessOps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessOps = append(m.AccessOps, accesscontrol.AccessOperation{})
+			if err := m.AccessOps[len(m.AccessOps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
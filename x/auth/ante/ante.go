@@ -18,47 +18,78 @@ type HandlerOptions struct {
 	SignModeHandler authsigning.SignModeHandler
 	SigGasConsumer  func(meter sdk.GasMeter, sig signing.SignatureV2, params types.Params) error
 	TxFeeChecker    TxFeeChecker
+	// DisabledDecorators names the decorators CheckTx skips
+	DisabledDecorators []string
 }
 
+// The names of the decorators of the default ante pipeline.
+const (
+	DecoratorSetUpContext           = "set_up_context"
+	DecoratorStoreGasParams         = "store_gas_params"
+	DecoratorRejectExtensionOptions = "reject_extension_options"
+	DecoratorValidateBasic          = "validate_basic"
+	DecoratorTxTimeoutHeight        = "tx_timeout_height"
+	DecoratorValidateMemo           = "validate_memo"
+	DecoratorConsumeGasForTxSize    = "consume_gas_for_tx_size"
+	DecoratorDeductFee              = "deduct_fee"
+	DecoratorSetPubKey              = "set_pub_key"
+	DecoratorValidateSigCount       = "validate_sig_count"
+	DecoratorSigGasConsume          = "sig_gas_consume"
+	DecoratorSigVerification        = "sig_verification"
+	DecoratorIncrementSequence      = "increment_sequence"
+)
+
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
-// signer.
+// signer. The decorators named by options.DisabledDecorators are skipped by
+// CheckTx, see sdk.AntePipeline.
 func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, sdk.AnteDepGenerator, error) {
+	pipeline, err := NewAntePipeline(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := pipeline.Disable(options.DisabledDecorators...); err != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, err.Error())
+	}
+	anteHandler, anteDepGenerator := pipeline.Build()
+
+	return anteHandler, anteDepGenerator, nil
+}
+
+// NewAntePipeline returns the pipeline of the decorators of NewAnteHandler, for
+// apps to add their own decorators to before building it.
+func NewAntePipeline(options HandlerOptions) (*sdk.AntePipeline, error) {
 	if options.AccountKeeper == nil {
-		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "account keeper is required for ante builder")
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "account keeper is required for ante builder")
 	}
 
 	if options.BankKeeper == nil {
-		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "bank keeper is required for ante builder")
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "bank keeper is required for ante builder")
 	}
 
 	if options.ParamsKeeper == nil {
-		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "params keeper is required for ante builder")
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "params keeper is required for ante builder")
 	}
 
 	if options.SignModeHandler == nil {
-		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "sign mode handler is required for ante builder")
-	}
-
-	var sigVerifyDecorator sdk.AnteDecorator
-	sequentialVerifyDecorator := NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler)
-	sigVerifyDecorator = sequentialVerifyDecorator
-
-	anteDecorators := []sdk.AnteFullDecorator{
-		sdk.DefaultWrappedAnteDecorator(NewDefaultSetUpContextDecorator()), // outermost AnteDecorator. SetUpContext must be called first
-		sdk.DefaultWrappedAnteDecorator(NewRejectExtensionOptionsDecorator()),
-		sdk.DefaultWrappedAnteDecorator(NewValidateBasicDecorator()),
-		sdk.DefaultWrappedAnteDecorator(NewTxTimeoutHeightDecorator()),
-		sdk.DefaultWrappedAnteDecorator(NewValidateMemoDecorator(options.AccountKeeper)),
-		NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
-		NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.ParamsKeeper.(paramskeeper.Keeper), options.TxFeeChecker),
-		sdk.DefaultWrappedAnteDecorator(NewSetPubKeyDecorator(options.AccountKeeper)), // SetPubKeyDecorator must be called before all signature verification decorators
-		sdk.DefaultWrappedAnteDecorator(NewValidateSigCountDecorator(options.AccountKeeper)),
-		sdk.DefaultWrappedAnteDecorator(NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer)),
-		sdk.DefaultWrappedAnteDecorator(sigVerifyDecorator),
-		NewIncrementSequenceDecorator(options.AccountKeeper),
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "sign mode handler is required for ante builder")
 	}
-	anteHandler, anteDepGenerator := sdk.ChainAnteDecorators(anteDecorators...)
 
-	return anteHandler, anteDepGenerator, nil
+	return sdk.NewAntePipeline(
+		// SetUpContext must be the outermost decorator
+		sdk.NamedAnteDecorator{Name: DecoratorSetUpContext, Decorator: sdk.DefaultWrappedAnteDecorator(NewDefaultSetUpContextDecorator()), Required: true},
+		sdk.NamedAnteDecorator{Name: DecoratorStoreGasParams, Decorator: sdk.DefaultWrappedAnteDecorator(NewStoreGasParamsDecorator(options.ParamsKeeper))},
+		sdk.NamedAnteDecorator{Name: DecoratorRejectExtensionOptions, Decorator: sdk.DefaultWrappedAnteDecorator(NewRejectExtensionOptionsDecorator())},
+		sdk.NamedAnteDecorator{Name: DecoratorValidateBasic, Decorator: sdk.DefaultWrappedAnteDecorator(NewValidateBasicDecorator())},
+		sdk.NamedAnteDecorator{Name: DecoratorTxTimeoutHeight, Decorator: sdk.DefaultWrappedAnteDecorator(NewTxTimeoutHeightDecorator())},
+		sdk.NamedAnteDecorator{Name: DecoratorValidateMemo, Decorator: sdk.DefaultWrappedAnteDecorator(NewValidateMemoDecorator(options.AccountKeeper))},
+		sdk.NamedAnteDecorator{Name: DecoratorConsumeGasForTxSize, Decorator: NewConsumeGasForTxSizeDecorator(options.AccountKeeper)},
+		sdk.NamedAnteDecorator{Name: DecoratorDeductFee, Decorator: NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.ParamsKeeper.(paramskeeper.Keeper), options.TxFeeChecker)},
+		// SetPubKey must be called before all signature verification decorators
+		sdk.NamedAnteDecorator{Name: DecoratorSetPubKey, Decorator: sdk.DefaultWrappedAnteDecorator(NewSetPubKeyDecorator(options.AccountKeeper)), Required: true},
+		sdk.NamedAnteDecorator{Name: DecoratorValidateSigCount, Decorator: sdk.DefaultWrappedAnteDecorator(NewValidateSigCountDecorator(options.AccountKeeper))},
+		sdk.NamedAnteDecorator{Name: DecoratorSigGasConsume, Decorator: sdk.DefaultWrappedAnteDecorator(NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer))},
+		sdk.NamedAnteDecorator{Name: DecoratorSigVerification, Decorator: sdk.DefaultWrappedAnteDecorator(NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler)), Required: true},
+		sdk.NamedAnteDecorator{Name: DecoratorIncrementSequence, Decorator: NewIncrementSequenceDecorator(options.AccountKeeper), Required: true},
+	), nil
 }
@@ -1068,6 +1068,42 @@ func (suite *AnteTestSuite) TestCustomSignatureVerificationGasConsumer() {
 	}
 }
 
+func (suite *AnteTestSuite) TestAnteHandlerDisabledDecorators() {
+	suite.SetupTest(true) // setup
+	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+
+	options := ante.HandlerOptions{
+		AccountKeeper:   suite.app.AccountKeeper,
+		BankKeeper:      suite.app.BankKeeper,
+		FeegrantKeeper:  suite.app.FeeGrantKeeper,
+		ParamsKeeper:    suite.app.ParamsKeeper,
+		SignModeHandler: suite.clientCtx.TxConfig.SignModeHandler(),
+		SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
+	}
+	// the signature verification cannot be disabled
+	options.DisabledDecorators = []string{ante.DecoratorSigVerification}
+	_, _, err := ante.NewAnteHandler(options)
+	suite.Require().Error(err)
+
+	options.DisabledDecorators = []string{ante.DecoratorValidateMemo, ante.DecoratorDeductFee}
+	anteHandler, _, err := ante.NewAnteHandler(options)
+	suite.Require().NoError(err)
+
+	accounts := suite.CreateTestAccounts(1)
+	suite.Require().NoError(suite.txBuilder.SetMsgs(testdata.NewTestMsg(accounts[0].acc.GetAddress())))
+	suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+	suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+	suite.txBuilder.SetMemo(strings.Repeat("01234567890", 500))
+	tx, err := suite.CreateTestTx([]cryptotypes.PrivKey{accounts[0].priv}, []uint64{0}, []uint64{0}, suite.ctx.ChainID())
+	suite.Require().NoError(err)
+
+	// DeliverTx still validates the memo, CheckTx skips it as well as the fee
+	_, err = anteHandler(suite.ctx.WithIsCheckTx(false), tx, false)
+	suite.Require().True(errors.Is(err, sdkerrors.ErrMemoTooLarge))
+	_, err = anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+}
+
 func (suite *AnteTestSuite) TestAnteHandlerReCheck() {
 	suite.SetupTest(false) // setup
 	// Set recheck=true
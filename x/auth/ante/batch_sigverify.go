@@ -3,8 +3,16 @@ package ante
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
+	"github.com/hdevalence/ed25519consensus"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/sr25519"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
@@ -220,3 +228,181 @@ func (svd BatchSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx,
 
 	return next(ctx, tx, simulate)
 }
+
+// BatchSigVerifier verifies the single signatures of the txs of a batch ahead
+// of their execution, as a sdk.TxBatchVerifier. The ed25519 signatures are
+// verified together, and one at a time if the batch fails, while the other
+// signatures, such as secp256k1 ones which cannot be batched, are verified
+// concurrently.
+//
+// The verified signatures are recorded in the TxCache under the keys looked up
+// by the SigVerificationDecorator. A key covers the pubkey and account number
+// read at the start of the batch, so the signatures of a signer changed by an
+// earlier tx of the batch are verified again by their tx.
+type BatchSigVerifier struct {
+	ak              AccountKeeper
+	signModeHandler authsigning.SignModeHandler
+}
+
+func NewBatchSigVerifier(ak AccountKeeper, signModeHandler authsigning.SignModeHandler) BatchSigVerifier {
+	return BatchSigVerifier{
+		ak:              ak,
+		signModeHandler: signModeHandler,
+	}
+}
+
+// pendingSig is a signature to verify and the key under which it is recorded.
+type pendingSig struct {
+	key       string
+	pubKey    cryptotypes.PubKey
+	signBytes []byte
+	signature []byte
+}
+
+// VerifyTxs implements sdk.TxBatchVerifier.
+func (v BatchSigVerifier) VerifyTxs(ctx sdk.Context, txs []sdk.Tx, txsBytes [][]byte) {
+	txCache := ctx.TxCache()
+	if txCache == nil || ctx.IsReCheckTx() {
+		return
+	}
+
+	var ed25519Sigs, otherSigs []pendingSig
+	for i, tx := range txs {
+		for _, sig := range v.pendingSigs(ctx, tx, txsBytes[i]) {
+			if _, ok := sig.pubKey.(*ed25519.PubKey); ok {
+				ed25519Sigs = append(ed25519Sigs, sig)
+			} else {
+				otherSigs = append(otherSigs, sig)
+			}
+		}
+	}
+
+	verified := verifyEd25519Batch(ed25519Sigs)
+	verified = append(verified, verifyConcurrently(otherSigs)...)
+	for _, sig := range verified {
+		txCache.SetSignatureVerified(sig.key)
+	}
+}
+
+// pendingSigs returns the single signatures of tx, signed with the sign bytes
+// that the SigVerificationDecorator verifies them against if the state of
+// their signers does not change. The signatures of signers without accounts,
+// and the ones already verified, are skipped.
+func (v BatchSigVerifier) pendingSigs(ctx sdk.Context, tx sdk.Tx, txBytes []byte) []pendingSig {
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return nil
+	}
+	signerAddrs := sigTx.GetSigners()
+	if len(sigs) != len(signerAddrs) {
+		return nil
+	}
+	// the pubkeys of the txs are set on the accounts that have none by the
+	// SetPubKeyDecorator, before the signatures are verified
+	pubKeys, err := sigTx.GetPubKeys()
+	if err != nil {
+		return nil
+	}
+
+	var pending []pendingSig
+	for i, sig := range sigs {
+		data, ok := sig.Data.(*signing.SingleSignatureData)
+		if !ok {
+			continue
+		}
+		acc, err := GetSignerAcc(ctx, v.ak, signerAddrs[i])
+		if err != nil {
+			continue
+		}
+		pubKey := acc.GetPubKey()
+		if pubKey == nil && i < len(pubKeys) {
+			pubKey = pubKeys[i]
+		}
+		if pubKey == nil {
+			continue
+		}
+
+		var accNum uint64
+		if ctx.BlockHeight() != 0 {
+			accNum = acc.GetAccountNumber()
+		}
+		// a signature verified against another sequence than the one of its
+		// signer is never looked up
+		signerData := authsigning.SignerData{
+			ChainID:       ctx.ChainID(),
+			AccountNumber: accNum,
+			Sequence:      sig.Sequence,
+		}
+		// e.g. verified by CheckTx
+		key := signatureCacheKey(txBytes, i, pubKey, signerData)
+		if ctx.TxCache().IsSignatureVerified(key) {
+			continue
+		}
+		signBytes, err := v.signModeHandler.GetSignBytes(data.SignMode, signerData, tx)
+		if err != nil {
+			continue
+		}
+		pending = append(pending, pendingSig{
+			key:       key,
+			pubKey:    pubKey,
+			signBytes: signBytes,
+			signature: data.Signature,
+		})
+	}
+	return pending
+}
+
+// verifyEd25519Batch returns the valid signatures of sigs, which are ed25519
+// ones. They are verified one at a time if the batch fails, as it does not tell
+// which signatures are invalid.
+func verifyEd25519Batch(sigs []pendingSig) []pendingSig {
+	batch := ed25519consensus.NewBatchVerifier()
+	batched := make([]pendingSig, 0, len(sigs))
+	for _, sig := range sigs {
+		pubKey := sig.pubKey.(*ed25519.PubKey)
+		// PubKey.VerifySignature rejects these before verifying them
+		if len(pubKey.Key) != ed25519.PubKeySize || len(sig.signature) != ed25519.SignatureSize {
+			continue
+		}
+		batch.Add(pubKey.Key, sig.signBytes, sig.signature)
+		batched = append(batched, sig)
+	}
+	if len(batched) == 0 {
+		return nil
+	}
+	if batch.Verify() {
+		return batched
+	}
+	telemetry.IncrCounter(1, "ante", "batch_sig_verification", "fallback")
+	return verifyConcurrently(batched)
+}
+
+// verifyConcurrently verifies sigs one at a time on every CPU and returns the
+// valid ones, in order.
+func verifyConcurrently(sigs []pendingSig) []pendingSig {
+	valid := make([]bool, len(sigs))
+	var next int64 = -1
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0) && w < len(sigs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := atomic.AddInt64(&next, 1); i < int64(len(sigs)); i = atomic.AddInt64(&next, 1) {
+				valid[i] = sigs[i].pubKey.VerifySignature(sigs[i].signBytes, sigs[i].signature)
+			}
+		}()
+	}
+	wg.Wait()
+
+	verified := make([]pendingSig, 0, len(sigs))
+	for i, sig := range sigs {
+		if valid[i] {
+			verified = append(verified, sig)
+		}
+	}
+	return verified
+}
@@ -24,4 +24,5 @@ type FeegrantKeeper interface {
 type ParamsKeeper interface {
 	SetFeesParams(ctx sdk.Context, feesParams paramtypes.FeesParams)
 	GetFeesParams(ctx sdk.Context) paramtypes.FeesParams
+	GetStoreGasParams(ctx sdk.Context) paramtypes.StoreGasParams
 }
@@ -209,6 +209,9 @@ func DeductFees(bankKeeper types.BankKeeper, ctx sdk.Context, acc types.AccountI
 	if err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, err.Error())
 	}
+	if charges := ctx.TxCharges(); charges != nil {
+		charges.AddFees(fees)
+	}
 
 	return nil
 }
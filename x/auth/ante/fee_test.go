@@ -121,18 +121,21 @@ func (suite *AnteTestSuite) TestDeductFees() {
 	dfd := ante.NewDeductFeeDecorator(suite.app.AccountKeeper, suite.app.BankKeeper, nil, suite.app.ParamsKeeper, nil)
 	antehandler, _ := sdk.ChainAnteDecorators(sdk.DefaultWrappedAnteDecorator(dfd))
 
-	_, err = antehandler(suite.ctx, tx, false)
+	charges := sdk.NewTxCharges()
+	_, err = antehandler(suite.ctx.WithTxCharges(charges), tx, false)
 
 	suite.Require().NotNil(err, "Tx did not error when fee payer had insufficient funds")
+	suite.Require().True(charges.Fees().IsZero())
 
 	// Set account with sufficient funds
 	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
 	err = simapp.FundAccount(suite.app.BankKeeper, suite.ctx, addr1, sdk.NewCoins(sdk.NewCoin("atom", sdk.NewInt(200))))
 	suite.Require().NoError(err)
 
-	_, err = antehandler(suite.ctx, tx, false)
+	_, err = antehandler(suite.ctx.WithTxCharges(charges), tx, false)
 
 	suite.Require().Nil(err, "Tx errored after account has been set with sufficient funds")
+	suite.Require().Equal(feeAmount, charges.Fees())
 }
 
 func (suite *AnteTestSuite) TestLazySendToModuleAccount() {
@@ -2,6 +2,7 @@ package ante
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -266,8 +267,8 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "pubkey on account is not set")
 		}
 
-		// Check account sequence number.
-		if sig.Sequence != acc.GetSequence() {
+		// Check account sequence number
+		if !isSequenceAccepted(ctx, sig.Sequence, acc.GetSequence()) {
 			return ctx, sdkerrors.Wrapf(
 				sdkerrors.ErrWrongSequence,
 				"account sequence mismatch, expected %d, got %d", acc.GetSequence(), sig.Sequence,
@@ -284,30 +285,62 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 		signerData := authsigning.SignerData{
 			ChainID:       chainID,
 			AccountNumber: accNum,
-			Sequence:      acc.GetSequence(),
+			Sequence:      sig.Sequence,
 		}
 
-		// no need to verify signatures on recheck tx
-		if !simulate && !ctx.IsReCheckTx() {
+		// no need to verify signatures on recheck tx, or when an earlier execution
+		// of the same tx in this block, or its CheckTx, already verified them
+		// against the same signer
+		txCache := ctx.TxCache()
+		var sigCacheKey string
+		if txCache != nil && pubKey != nil {
+			sigCacheKey = signatureCacheKey(ctx.TxBytes(), i, pubKey, signerData)
+		}
+		if !simulate && !ctx.IsReCheckTx() && (sigCacheKey == "" || !txCache.IsSignatureVerified(sigCacheKey)) {
 			err := authsigning.VerifySignature(pubKey, signerData, sig.Data, svd.signModeHandler, tx)
 			if err != nil {
 				var errMsg string
 				if OnlyLegacyAminoSigners(sig.Data) {
 					// If all signers are using SIGN_MODE_LEGACY_AMINO, we rely on VerifySignature to check account sequence number,
 					// and therefore communicate sequence number as a potential cause of error.
-					errMsg = fmt.Sprintf("signature verification failed; please verify account number (%d), sequence (%d) and chain-id (%s)", accNum, acc.GetSequence(), chainID)
+					errMsg = fmt.Sprintf("signature verification failed; please verify account number (%d), sequence (%d) and chain-id (%s)", accNum, signerData.Sequence, chainID)
 				} else {
 					errMsg = fmt.Sprintf("signature verification failed; please verify account number (%d) and chain-id (%s)", accNum, chainID)
 				}
 				return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, errMsg)
 
 			}
+			if sigCacheKey != "" {
+				txCache.SetSignatureVerified(sigCacheKey)
+			}
 		}
 	}
 
 	return next(ctx, tx, simulate)
 }
 
+// signatureCacheKey identifies the verification of the signature at sigIndex
+// of the tx with the given bytes, by everything the verification depends on.
+func signatureCacheKey(txBytes []byte, sigIndex int, pubKey cryptotypes.PubKey, signerData authsigning.SignerData) string {
+	return fmt.Sprintf("%X/%d/%X/%s/%d/%d", sha256.Sum256(txBytes), sigIndex, pubKey.Bytes(), signerData.ChainID, signerData.AccountNumber, signerData.Sequence)
+}
+
+// isSequenceAccepted reports whether a signature with sequence sigSeq is
+// accepted for an account with sequence accSeq. A tx replacing a pending tx
+// reuses the sequence of the pending tx, which was already incremented, and
+// the sequence window of CheckTx accepts the sequences ahead of the account's
+// within it.
+func isSequenceAccepted(ctx sdk.Context, sigSeq, accSeq uint64) bool {
+	switch {
+	case sigSeq == accSeq:
+		return true
+	case sigSeq < accSeq:
+		return ctx.IsReplacementTx()
+	default:
+		return sigSeq-accSeq < ctx.SequenceWindow()
+	}
+}
+
 // IncrementSequenceDecorator handles incrementing sequences of all signers.
 // Use the IncrementSequenceDecorator decorator to prevent replay attacks. Note,
 // there is no need to execute IncrementSequenceDecorator on RecheckTX since
@@ -352,9 +385,23 @@ func (isd IncrementSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, sim
 		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
 	}
 
-	// increment sequence of all signers
-	for _, addr := range sigTx.GetSigners() {
+	var sigs []signing.SignatureV2
+	if ctx.IsReplacementTx() || ctx.SequenceWindow() > 1 {
+		var err error
+		if sigs, err = sigTx.GetSignaturesV2(); err != nil {
+			return ctx, err
+		}
+	}
+
+	// increment sequence of all signers, except the ones whose sequence was
+	// already incremented by the tx being replaced, and the ones the tx signed
+	// ahead of within the sequence window, which the tx does not use until the
+	// txs preceding it are checked
+	for i, addr := range sigTx.GetSigners() {
 		acc := isd.ak.GetAccount(ctx, addr)
+		if i < len(sigs) && sigs[i].Sequence != acc.GetSequence() {
+			continue
+		}
 		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
 			panic(err)
 		}
@@ -14,6 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/simapp"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
 	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
@@ -201,6 +202,228 @@ func (suite *AnteTestSuite) TestSigVerification() {
 	}
 }
 
+func (suite *AnteTestSuite) TestSigVerificationTxCache() {
+	suite.SetupTest(true) // setup
+	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	spkd := sdk.DefaultWrappedAnteDecorator(ante.NewSetPubKeyDecorator(suite.app.AccountKeeper))
+	svd := sdk.DefaultWrappedAnteDecorator(ante.NewSigVerificationDecorator(suite.app.AccountKeeper, suite.clientCtx.TxConfig.SignModeHandler()))
+	antehandler, _ := sdk.ChainAnteDecorators(spkd, svd)
+
+	privs, accNums, accSeqs := []cryptotypes.PrivKey{priv1}, []uint64{acc.GetAccountNumber()}, []uint64{0}
+	newTx := func() sdk.Tx {
+		suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+		suite.Require().NoError(suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+		suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+		suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+		tx, err := suite.CreateTestTx(privs, accNums, accSeqs, suite.ctx.ChainID())
+		suite.Require().NoError(err)
+		return tx
+	}
+	validTx := newTx()
+
+	newTx()
+	badSig, err := priv1.Sign([]byte("unrelated message"))
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey: priv1.PubKey(),
+		Data: &signing.SingleSignatureData{
+			SignMode:  suite.clientCtx.TxConfig.SignModeHandler().DefaultMode(),
+			Signature: badSig,
+		},
+		Sequence: 0,
+	}))
+	invalidTx := suite.txBuilder.GetTx()
+
+	cachedCtx := suite.ctx.WithTxBytes([]byte("tx")).WithTxCache(sdk.NewTxCache())
+	_, err = antehandler(cachedCtx, validTx, false)
+	suite.Require().NoError(err)
+
+	// the signature is not verified again for the same tx bytes and signer
+	_, err = antehandler(cachedCtx, invalidTx, false)
+	suite.Require().NoError(err)
+
+	// but it is for other tx bytes, or without a cache
+	_, err = antehandler(cachedCtx.WithTxBytes([]byte("other tx")), invalidTx, false)
+	suite.Require().Error(err)
+	_, err = antehandler(suite.ctx.WithTxBytes([]byte("tx")), invalidTx, false)
+	suite.Require().Error(err)
+}
+
+func (suite *AnteTestSuite) TestSigVerificationReplacementTx() {
+	suite.SetupTest(true) // setup
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.Require().NoError(acc.SetPubKey(priv1.PubKey()))
+	suite.Require().NoError(acc.SetSequence(1))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	svd := sdk.DefaultWrappedAnteDecorator(ante.NewSigVerificationDecorator(suite.app.AccountKeeper, suite.clientCtx.TxConfig.SignModeHandler()))
+	antehandler, _ := sdk.ChainAnteDecorators(svd)
+	newTx := func(seq uint64) sdk.Tx {
+		suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+		suite.Require().NoError(suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+		suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+		suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+		tx, err := suite.CreateTestTx([]cryptotypes.PrivKey{priv1}, []uint64{acc.GetAccountNumber()}, []uint64{seq}, suite.ctx.ChainID())
+		suite.Require().NoError(err)
+		return tx
+	}
+
+	// a tx signed with the sequence used by a pending tx only passes when it
+	// replaces it
+	_, err := antehandler(suite.ctx, newTx(0), false)
+	suite.Require().ErrorIs(err, sdkerrors.ErrWrongSequence)
+	_, err = antehandler(suite.ctx.WithIsReplacementTx(true), newTx(0), false)
+	suite.Require().NoError(err)
+	_, err = antehandler(suite.ctx.WithIsReplacementTx(true), newTx(1), false)
+	suite.Require().NoError(err)
+	_, err = antehandler(suite.ctx.WithIsReplacementTx(true), newTx(2), false)
+	suite.Require().ErrorIs(err, sdkerrors.ErrWrongSequence)
+}
+
+func (suite *AnteTestSuite) TestSigVerificationSequenceWindow() {
+	suite.SetupTest(true) // setup
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.Require().NoError(acc.SetPubKey(priv1.PubKey()))
+	suite.Require().NoError(acc.SetSequence(1))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	svd := sdk.DefaultWrappedAnteDecorator(ante.NewSigVerificationDecorator(suite.app.AccountKeeper, suite.clientCtx.TxConfig.SignModeHandler()))
+	isd := sdk.DefaultWrappedAnteDecorator(ante.NewIncrementSequenceDecorator(suite.app.AccountKeeper))
+	antehandler, _ := sdk.ChainAnteDecorators(svd, isd)
+	newTx := func(seq uint64) sdk.Tx {
+		suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+		suite.Require().NoError(suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+		suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+		suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+		tx, err := suite.CreateTestTx([]cryptotypes.PrivKey{priv1}, []uint64{acc.GetAccountNumber()}, []uint64{seq}, suite.ctx.ChainID())
+		suite.Require().NoError(err)
+		return tx
+	}
+	sequence := func() uint64 {
+		return suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetSequence()
+	}
+
+	// a sequence ahead of the account's is rejected without a window, or
+	// beyond it
+	checkCtx := suite.ctx.WithIsCheckTx(true)
+	_, err := antehandler(checkCtx, newTx(2), false)
+	suite.Require().ErrorIs(err, sdkerrors.ErrWrongSequence)
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(4), false)
+	suite.Require().ErrorIs(err, sdkerrors.ErrWrongSequence)
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(0), false)
+	suite.Require().ErrorIs(err, sdkerrors.ErrWrongSequence)
+
+	// and accepted within it, without using the sequence of the account
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(3), false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), sequence())
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(2), false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), sequence())
+
+	// until the tx preceding them is checked
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(1), false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(2), sequence())
+	_, err = antehandler(checkCtx.WithSequenceWindow(3), newTx(2), false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(3), sequence())
+}
+
+func (suite *AnteTestSuite) TestBatchSigVerifier() {
+	suite.SetupTest(false) // setup
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	// signers with secp256k1 and ed25519 keys, the latter of which are
+	// verified as a batch
+	secpPriv, _, secpAddr := testdata.KeyTestPubAddr()
+	edPriv := ed25519.GenPrivKey()
+	edAddr := sdk.AccAddress(edPriv.PubKey().Address())
+	edPriv2 := ed25519.GenPrivKey()
+	edAddr2 := sdk.AccAddress(edPriv2.PubKey().Address())
+	privs := []cryptotypes.PrivKey{secpPriv, edPriv, edPriv2}
+	var accNums []uint64
+	for _, addr := range []sdk.AccAddress{secpAddr, edAddr, edAddr2} {
+		acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr)
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+		accNums = append(accNums, acc.GetAccountNumber())
+	}
+
+	newTx := func(i int, tamper bool) (sdk.Tx, []byte) {
+		suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+		suite.Require().NoError(suite.txBuilder.SetMsgs(testdata.NewTestMsg(sdk.AccAddress(privs[i].PubKey().Address()))))
+		suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+		suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+		tx, err := suite.CreateTestTx(privs[i:i+1], accNums[i:i+1], []uint64{0}, suite.ctx.ChainID())
+		suite.Require().NoError(err)
+		if tamper {
+			sigs, err := tx.GetSignaturesV2()
+			suite.Require().NoError(err)
+			sigs[0].Data.(*signing.SingleSignatureData).Signature[0] ^= 1
+			suite.Require().NoError(suite.txBuilder.SetSignatures(sigs...))
+			tx = suite.txBuilder.GetTx()
+		}
+		txBytes, err := suite.clientCtx.TxConfig.TxEncoder()(tx)
+		suite.Require().NoError(err)
+		return tx, txBytes
+	}
+
+	spkd := sdk.DefaultWrappedAnteDecorator(ante.NewSetPubKeyDecorator(suite.app.AccountKeeper))
+	svd := sdk.DefaultWrappedAnteDecorator(ante.NewSigVerificationDecorator(suite.app.AccountKeeper, suite.clientCtx.TxConfig.SignModeHandler()))
+	antehandler, _ := sdk.ChainAnteDecorators(spkd, svd)
+	verifier := ante.NewBatchSigVerifier(suite.app.AccountKeeper, suite.clientCtx.TxConfig.SignModeHandler())
+
+	for _, tc := range []struct {
+		name     string
+		tampered []bool
+	}{
+		{"all signatures valid", []bool{false, false, false}},
+		{"invalid secp256k1 signature", []bool{true, false, false}},
+		// which fails the ed25519 batch
+		{"invalid ed25519 signature", []bool{false, false, true}},
+	} {
+		var txs []sdk.Tx
+		var txsBytes [][]byte
+		for i, tamper := range tc.tampered {
+			tx, txBytes := newTx(i, tamper)
+			txs = append(txs, tx)
+			txsBytes = append(txsBytes, txBytes)
+		}
+
+		cachedCtx := suite.ctx.WithTxCache(sdk.NewTxCache())
+		verifier.VerifyTxs(cachedCtx, txs, txsBytes)
+
+		for i, tx := range txs {
+			// an invalid signature may only pass the decorator if the batch
+			// recorded it as verified
+			invalidTx, _ := newTx(i, true)
+			ctx, _ := cachedCtx.WithTxBytes(txsBytes[i]).CacheContext()
+			_, err := antehandler(ctx, invalidTx, false)
+			if tc.tampered[i] {
+				suite.Require().Error(err, "%s: tx %d", tc.name, i)
+			} else {
+				suite.Require().NoError(err, "%s: tx %d", tc.name, i)
+			}
+
+			ctx, _ = cachedCtx.WithTxBytes(txsBytes[i]).CacheContext()
+			_, err = antehandler(ctx, tx, false)
+			suite.Require().Equal(tc.tampered[i], err != nil, "%s: tx %d", tc.name, i)
+		}
+	}
+}
+
 // This test is exactly like the one above, but we set the codec explicitly to
 // Amino.
 // Once https://github.com/cosmos/cosmos-sdk/issues/6190 is in, we can remove
@@ -397,6 +620,8 @@ func (suite *AnteTestSuite) TestIncrementSequenceDecorator() {
 		{suite.ctx.WithIsReCheckTx(true), false, 3},
 		{suite.ctx.WithIsReCheckTx(true), false, 4},
 		{suite.ctx.WithIsReCheckTx(true), true, 5},
+		// the sequence of the replaced tx was already incremented
+		{suite.ctx.WithIsReplacementTx(true), false, 5},
 	}
 
 	for i, tc := range testCases {
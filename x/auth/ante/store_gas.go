@@ -0,0 +1,24 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StoreGasParamsDecorator charges the KVStore operations of the tx the gas
+// costs of the store gas params of the params module, which governance can
+// retune as the state grows, instead of the default costs.
+type StoreGasParamsDecorator struct {
+	paramsKeeper ParamsKeeper
+}
+
+func NewStoreGasParamsDecorator(paramsKeeper ParamsKeeper) StoreGasParamsDecorator {
+	return StoreGasParamsDecorator{
+		paramsKeeper: paramsKeeper,
+	}
+}
+
+func (sgd StoreGasParamsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// reading the params is not charged to the tx
+	storeGasParams := sgd.paramsKeeper.GetStoreGasParams(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
+	return next(ctx.WithKVGasConfig(storeGasParams.GasConfig()), tx, simulate)
+}
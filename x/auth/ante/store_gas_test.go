@@ -0,0 +1,36 @@
+package ante_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+func (suite *AnteTestSuite) TestStoreGasParamsDecorator() {
+	suite.SetupTest(true) // setup
+	key := suite.app.GetKey(paramtypes.StoreKey)
+	sgd := ante.NewStoreGasParamsDecorator(suite.app.ParamsKeeper)
+	antehandler, _ := sdk.ChainAnteDecorators(sdk.DefaultWrappedAnteDecorator(sgd))
+
+	readGas := func(ctx sdk.Context) sdk.Gas {
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		ctx.KVStore(key).Get([]byte("key"))
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// the default costs are charged until governance changes them
+	newCtx, err := antehandler(suite.ctx, nil, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(*paramtypes.DefaultStoreGasParams(), paramtypes.NewStoreGasParams(newCtx.KVGasConfig()))
+	suite.Require().Equal(sdk.Gas(1000+3*3), readGas(newCtx))
+
+	params := *paramtypes.DefaultStoreGasParams()
+	params.ReadCostFlat = 5000
+	suite.app.ParamsKeeper.SetStoreGasParams(suite.ctx, params)
+	gasBefore := suite.ctx.GasMeter().GasConsumed()
+	newCtx, err = antehandler(suite.ctx, nil, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.Gas(5000+3*3), readGas(newCtx))
+	// reading the params is free
+	suite.Require().Equal(gasBefore, newCtx.GasMeter().GasConsumed())
+}
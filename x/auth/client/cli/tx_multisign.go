@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -209,8 +208,20 @@ func GetMultiSignBatchCmd() *cobra.Command {
 Read one or more signatures from one or more [signature] file, generate a multisig signature compliant to the
 multisig key [name], and attach the key name to the transaction read from [file].
 
-Example:
-$ %s tx multisign-batch transactions.json multisigk1k2k3 k1sigs.json k2sigs.json k3sig.json
+Each [signature-file] holds the signatures of a signer for the transactions of [file], one per line and in the
+same order, as printed by sign-batch. The transactions are signed with consecutive sequence numbers, starting
+from the one of the multisig account, unless --no-auto-increment is set.
+
+A batch of transactions is signed offline as follows:
+$ %[1]s tx bank send <multisig> <to> 1stake --generate-only >> transactions.json
+$ %[1]s tx sign-batch transactions.json --multisig <multisig> --from k1 --offline \
+	--account-number <number> --sequence <sequence> --output-document k1sigs.json
+$ %[1]s tx multisign-batch transactions.json multisigk1k2k3 k1sigs.json k2sigs.json k3sigs.json --offline \
+	--account-number <number> --sequence <sequence> --output-document signed.json
+
+If the --offline flag is on, the client will not reach out to an external node.
+Account number or sequence number lookups are not performed so you must
+set these parameters manually.
 
 The current multisig implementation defaults to amino-json sign mode.
 The SIGN_MODE_DIRECT sign mode is not supported.'
@@ -227,8 +238,11 @@ The SIGN_MODE_DIRECT sign mode is not supported.'
 		flagMultisig, "",
 		"Address of the multisig account that the transaction signs on behalf of",
 	)
+	cmd.Flags().Bool(flagSigOnly, false, "Print only the generated signatures, then exit")
+	cmd.Flags().Bool(flagAmino, false, "Generate Amino-encoded JSON suitable for submitting to the txs REST endpoint")
 	cmd.Flags().String(flags.FlagOutputDocument, "", "The document is written to the given file instead of STDOUT")
 	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(flags.FlagChainID, "", "network chain ID")
 
 	return cmd
 }
@@ -279,6 +293,25 @@ func makeBatchMultisignCmd() func(cmd *cobra.Command, args []string) error {
 			signatureBatch = append(signatureBatch, sigs)
 		}
 
+		// each signature file holds a signature per transaction, which is
+		// checked before any transaction is printed
+		var unsignedTxs []sdk.Tx
+		for scanner.Scan() {
+			unsignedTxs = append(unsignedTxs, scanner.Tx())
+		}
+		if err := scanner.UnmarshalErr(); err != nil {
+			return err
+		}
+		for i, sigs := range signatureBatch {
+			if len(sigs) != len(unsignedTxs) {
+				return fmt.Errorf("%s holds %d signatures for %d transactions", args[2+i], len(sigs), len(unsignedTxs))
+			}
+		}
+
+		sigOnly, _ := cmd.Flags().GetBool(flagSigOnly)
+		aminoJSON, _ := cmd.Flags().GetBool(flagAmino)
+		noAutoIncrement, _ := cmd.Flags().GetBool(flagNoAutoIncrement)
+
 		if !clientCtx.Offline {
 			accnum, seq, err := clientCtx.AccountRetriever.GetAccountNumberSequence(clientCtx, multisigInfo.GetAddress())
 			if err != nil {
@@ -295,10 +328,10 @@ func makeBatchMultisignCmd() func(cmd *cobra.Command, args []string) error {
 		}
 
 		defer closeFunc()
-		clientCtx.WithOutput(cmd.OutOrStdout())
+		clientCtx = clientCtx.WithOutput(cmd.OutOrStdout())
 
-		for i := 0; scanner.Scan(); i++ {
-			txBldr, err := txCfg.WrapTxBuilder(scanner.Tx())
+		for i, unsignedTx := range unsignedTxs {
+			txBldr, err := txCfg.WrapTxBuilder(unsignedTx)
 			if err != nil {
 				return err
 			}
@@ -333,9 +366,6 @@ func makeBatchMultisignCmd() func(cmd *cobra.Command, args []string) error {
 				return err
 			}
 
-			sigOnly, _ := cmd.Flags().GetBool(flagSigOnly)
-			aminoJSON, _ := cmd.Flags().GetBool(flagAmino)
-
 			var json []byte
 
 			if aminoJSON {
@@ -363,14 +393,14 @@ func makeBatchMultisignCmd() func(cmd *cobra.Command, args []string) error {
 				return err
 			}
 
-			if viper.GetBool(flagNoAutoIncrement) {
+			if noAutoIncrement {
 				continue
 			}
 			sequence := txFactory.Sequence() + 1
 			txFactory = txFactory.WithSequence(sequence)
 		}
 
-		return scanner.UnmarshalErr()
+		return nil
 	}
 }
 
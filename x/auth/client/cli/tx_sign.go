@@ -82,7 +82,7 @@ func makeSignBatchCmd() func(cmd *cobra.Command, args []string) error {
 		}
 
 		defer closeFunc()
-		clientCtx.WithOutput(cmd.OutOrStdout())
+		clientCtx = clientCtx.WithOutput(cmd.OutOrStdout())
 
 		if args[0] != "-" {
 			infile, err = os.Open(args[0])
@@ -121,10 +121,6 @@ func makeSignBatchCmd() func(cmd *cobra.Command, args []string) error {
 				}
 			}
 
-			if err != nil {
-				return err
-			}
-
 			json, err := marshalSignatureJSON(txCfg, txBuilder, printSignatureOnly)
 			if err != nil {
 				return err
@@ -133,10 +129,6 @@ func makeSignBatchCmd() func(cmd *cobra.Command, args []string) error {
 			cmd.Printf("%s\n", json)
 		}
 
-		if err := scanner.UnmarshalErr(); err != nil {
-			return err
-		}
-
 		return scanner.UnmarshalErr()
 	}
 }
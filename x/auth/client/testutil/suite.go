@@ -1063,7 +1063,8 @@ func (s *IntegrationTestSuite) TestMultisignBatch() {
 	s.Require().NoError(err)
 	s.Require().Equal(3, len(strings.Split(strings.Trim(res.String(), "\n"), "\n")))
 	// write sigs to file
-	file1 := testutil.WriteToNewTempFile(s.T(), res.String())
+	file1Sigs := res.String()
+	file1 := testutil.WriteToNewTempFile(s.T(), file1Sigs)
 
 	// sign-batch file with account2
 	res, err = TxSignBatchExec(val.ClientCtx, account2.GetAddress(), filename.Name(), fmt.Sprintf("--%s=%s", flags.FlagChainID, val.ClientCtx.ChainID), "--multisig", multisigInfo.GetAddress().String(), fmt.Sprintf("--%s", flags.FlagOffline), fmt.Sprintf("--%s=%s", flags.FlagAccountNumber, fmt.Sprint(account.GetAccountNumber())), fmt.Sprintf("--%s=%s", flags.FlagSequence, fmt.Sprint(account.GetSequence())))
@@ -1080,9 +1081,21 @@ func (s *IntegrationTestSuite) TestMultisignBatch() {
 	// write sigs to file
 	file3 := testutil.WriteToNewTempFile(s.T(), res.String())
 
-	res, err = TxMultiSignBatchExec(val.ClientCtx, filename.Name(), multisigInfo.GetName(), file1.Name(), file2.Name(), file3.Name())
+	// a signature file missing the signature of a transaction fails the batch
+	sigs := strings.Split(strings.Trim(file1Sigs, "\n"), "\n")
+	partialFile := testutil.WriteToNewTempFile(s.T(), strings.Join(sigs[:2], "\n"))
+	_, err = TxMultiSignBatchExec(val.ClientCtx, filename.Name(), multisigInfo.GetName(), partialFile.Name(), file2.Name())
+	s.Require().EqualError(err, fmt.Sprintf("%s holds 2 signatures for 3 transactions", partialFile.Name()))
+
+	outputFile := testutil.WriteToNewTempFile(s.T(), "")
+	res, err = TxMultiSignBatchExec(val.ClientCtx, filename.Name(), multisigInfo.GetName(), file1.Name(), file2.Name(), file3.Name(),
+		fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, outputFile.Name()))
+	s.Require().NoError(err)
+	s.Require().Empty(res.String())
+	output, err := ioutil.ReadFile(outputFile.Name())
 	s.Require().NoError(err)
-	signedTxs := strings.Split(strings.Trim(res.String(), "\n"), "\n")
+	signedTxs := strings.Split(strings.Trim(string(output), "\n"), "\n")
+	s.Require().Len(signedTxs, 3)
 
 	// Broadcast transactions.
 	for _, signedTx := range signedTxs {
@@ -2,6 +2,7 @@ package tx
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -14,9 +15,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
 	pagination "github.com/cosmos/cosmos-sdk/types/query"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 )
@@ -24,19 +28,45 @@ import (
 // baseAppSimulateFn is the signature of the Baseapp#Simulate function.
 type baseAppSimulateFn func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error)
 
+// baseAppTraceTxFn is the signature of the Baseapp#TraceTx function.
+type baseAppTraceTxFn func(header tmproto.Header, txs [][]byte, index int) (*sdk.TxTrace, error)
+
+// baseAppSimulateWithTraceFn is the signature of the Baseapp#SimulateWithTrace
+// function.
+type baseAppSimulateWithTraceFn func(txBytes []byte) (*sdk.TxTrace, *sdk.Result, error)
+
+// baseAppRegisterTxAccessOpsFn is the signature of the
+// Baseapp#RegisterTxAccessOps function.
+type baseAppRegisterTxAccessOpsFn func(txBytes []byte, accessOps []acltypes.AccessOperation) error
+
 // txServer is the server for the protobuf Tx service.
 type txServer struct {
-	clientCtx         client.Context
-	simulate          baseAppSimulateFn
-	interfaceRegistry codectypes.InterfaceRegistry
+	clientCtx           client.Context
+	simulate            baseAppSimulateFn
+	traceTx             baseAppTraceTxFn
+	simulateWithTrace   baseAppSimulateWithTraceFn
+	registerTxAccessOps baseAppRegisterTxAccessOpsFn
+	interfaceRegistry   codectypes.InterfaceRegistry
 }
 
-// NewTxServer creates a new Tx service server.
-func NewTxServer(clientCtx client.Context, simulate baseAppSimulateFn, interfaceRegistry codectypes.InterfaceRegistry) txtypes.ServiceServer {
+// NewTxServer creates a new Tx service server. traceTx, simulateWithTrace and
+// registerTxAccessOps may be nil, in which case TraceTx, SimulateWithTrace and
+// BroadcastTxWithAccessOps respectively are unimplemented.
+func NewTxServer(
+	clientCtx client.Context,
+	simulate baseAppSimulateFn,
+	traceTx baseAppTraceTxFn,
+	simulateWithTrace baseAppSimulateWithTraceFn,
+	registerTxAccessOps baseAppRegisterTxAccessOpsFn,
+	interfaceRegistry codectypes.InterfaceRegistry,
+) txtypes.ServiceServer {
 	return txServer{
-		clientCtx:         clientCtx,
-		simulate:          simulate,
-		interfaceRegistry: interfaceRegistry,
+		clientCtx:           clientCtx,
+		simulate:            simulate,
+		traceTx:             traceTx,
+		simulateWithTrace:   simulateWithTrace,
+		registerTxAccessOps: registerTxAccessOps,
+		interfaceRegistry:   interfaceRegistry,
 	}
 }
 
@@ -239,20 +269,116 @@ func (s txServer) GetBlockWithTxs(ctx context.Context, req *txtypes.GetBlockWith
 
 }
 
+// TraceTx implements the ServiceServer.TraceTx RPC method.
+func (s txServer) TraceTx(ctx context.Context, req *txtypes.TraceTxRequest) (*txtypes.TraceTxResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if s.traceTx == nil {
+		return nil, status.Error(codes.Unimplemented, "tx tracing is not supported")
+	}
+
+	hash, err := hex.DecodeString(req.Hash)
+	if err != nil || len(hash) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tx hash: %s", req.Hash)
+	}
+
+	node, err := s.clientCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+	resTx, err := node.Tx(ctx, hash, false)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "tx not found: %s", req.Hash)
+		}
+		return nil, err
+	}
+
+	_, block, err := tmservice.GetProtoBlock(ctx, s.clientCtx, &resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := s.traceTx(block.Header, block.Data.Txs, int(resTx.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &txtypes.TraceTxResponse{Trace: trace}, nil
+}
+
+// SimulateWithTrace implements the ServiceServer.SimulateWithTrace RPC method.
+func (s txServer) SimulateWithTrace(ctx context.Context, req *txtypes.SimulateWithTraceRequest) (*txtypes.SimulateWithTraceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid empty tx")
+	}
+	if s.simulateWithTrace == nil {
+		return nil, status.Error(codes.Unimplemented, "simulation tracing is not supported")
+	}
+	if req.TxBytes == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "empty txBytes is not allowed")
+	}
+
+	trace, result, err := s.simulateWithTrace(req.TxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SummarizeStoreAccesses()
+	if !req.IncludeStoreAccesses {
+		for i := range trace.Phases {
+			trace.Phases[i].StoreAccesses = nil
+		}
+	}
+
+	return &txtypes.SimulateWithTraceResponse{
+		Trace:  trace,
+		Result: result,
+	}, nil
+}
+
 func (s txServer) BroadcastTx(ctx context.Context, req *txtypes.BroadcastTxRequest) (*txtypes.BroadcastTxResponse, error) {
 	return client.TxServiceBroadcast(ctx, s.clientCtx, req)
 }
 
+// BroadcastTxWithAccessOps implements the ServiceServer.BroadcastTxWithAccessOps
+// RPC method. The access operations are registered before the tx is
+// broadcast, so that they are known once it reaches a block.
+func (s txServer) BroadcastTxWithAccessOps(ctx context.Context, req *txtypes.BroadcastTxWithAccessOpsRequest) (*txtypes.BroadcastTxResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid empty tx")
+	}
+	if s.registerTxAccessOps == nil {
+		return nil, status.Error(codes.Unimplemented, "tx access operations are not supported")
+	}
+	if req.TxBytes == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "empty txBytes is not allowed")
+	}
+
+	if err := s.registerTxAccessOps(req.TxBytes, req.AccessOps); err != nil {
+		return nil, err
+	}
+
+	return client.TxServiceBroadcast(ctx, s.clientCtx, &txtypes.BroadcastTxRequest{
+		TxBytes: req.TxBytes,
+		Mode:    req.Mode,
+	})
+}
+
 // RegisterTxService registers the tx service on the gRPC router.
 func RegisterTxService(
 	qrt gogogrpc.Server,
 	clientCtx client.Context,
 	simulateFn baseAppSimulateFn,
+	traceTxFn baseAppTraceTxFn,
+	simulateWithTraceFn baseAppSimulateWithTraceFn,
+	registerTxAccessOpsFn baseAppRegisterTxAccessOpsFn,
 	interfaceRegistry codectypes.InterfaceRegistry,
 ) {
 	txtypes.RegisterServiceServer(
 		qrt,
-		NewTxServer(clientCtx, simulateFn, interfaceRegistry),
+		NewTxServer(clientCtx, simulateFn, traceTxFn, simulateWithTraceFn, registerTxAccessOpsFn, interfaceRegistry),
 	)
 }
 
@@ -153,38 +153,81 @@ func (m *GrantAuthorization) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GrantAuthorization proto.InternalMessageInfo
 
+// GrantQueueItem contains the msg type URLs of the grants of a granter to a
+// grantee expiring at the same time, for them to be pruned once expired.
+type GrantQueueItem struct {
+	MsgTypeUrls []string `protobuf:"bytes,1,rep,name=msg_type_urls,json=msgTypeUrls,proto3" json:"msg_type_urls,omitempty"`
+}
+
+func (m *GrantQueueItem) Reset()         { *m = GrantQueueItem{} }
+func (m *GrantQueueItem) String() string { return proto.CompactTextString(m) }
+func (*GrantQueueItem) ProtoMessage()    {}
+func (*GrantQueueItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_544dc2e84b61c637, []int{3}
+}
+func (m *GrantQueueItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GrantQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GrantQueueItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GrantQueueItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantQueueItem.Merge(m, src)
+}
+func (m *GrantQueueItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *GrantQueueItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantQueueItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GrantQueueItem proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*GenericAuthorization)(nil), "cosmos.authz.v1beta1.GenericAuthorization")
 	proto.RegisterType((*Grant)(nil), "cosmos.authz.v1beta1.Grant")
 	proto.RegisterType((*GrantAuthorization)(nil), "cosmos.authz.v1beta1.GrantAuthorization")
+	proto.RegisterType((*GrantQueueItem)(nil), "cosmos.authz.v1beta1.GrantQueueItem")
 }
 
 func init() { proto.RegisterFile("cosmos/authz/v1beta1/authz.proto", fileDescriptor_544dc2e84b61c637) }
 
 var fileDescriptor_544dc2e84b61c637 = []byte{
-	// 343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x48, 0xce, 0x2f, 0xce,
-	0xcd, 0x2f, 0xd6, 0x4f, 0x2c, 0x2d, 0xc9, 0xa8, 0xd2, 0x2f, 0x33, 0x4c, 0x4a, 0x2d, 0x49, 0x34,
-	0x84, 0xf0, 0xf4, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x44, 0x20, 0x2a, 0xf4, 0x20, 0x62, 0x50,
-	0x15, 0x52, 0x92, 0x10, 0xd1, 0x78, 0xb0, 0x1a, 0x7d, 0xa8, 0x12, 0x30, 0x47, 0x4a, 0x3e, 0x3d,
-	0x3f, 0x3f, 0x3d, 0x27, 0x55, 0x1f, 0xcc, 0x4b, 0x2a, 0x4d, 0xd3, 0x2f, 0xc9, 0xcc, 0x4d, 0x2d,
-	0x2e, 0x49, 0xcc, 0x2d, 0x80, 0x2a, 0x10, 0x49, 0xcf, 0x4f, 0xcf, 0x87, 0x68, 0x04, 0xb1, 0xa0,
-	0xa2, 0x92, 0xe8, 0xda, 0x12, 0xf3, 0x2a, 0x21, 0x52, 0x4a, 0xd6, 0x5c, 0x22, 0xee, 0xa9, 0x79,
-	0xa9, 0x45, 0x99, 0xc9, 0x8e, 0xa5, 0x25, 0x19, 0xf9, 0x45, 0x99, 0x55, 0x89, 0x25, 0x99, 0xf9,
-	0x79, 0x42, 0x02, 0x5c, 0xcc, 0xb9, 0xc5, 0xe9, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x20,
-	0xa6, 0x95, 0xe0, 0xa5, 0x2d, 0xba, 0xbc, 0x28, 0x8a, 0x94, 0xe6, 0x30, 0x72, 0xb1, 0xba, 0x17,
-	0x25, 0xe6, 0x95, 0x08, 0xf9, 0x72, 0xf1, 0x26, 0x22, 0x4b, 0x81, 0x35, 0x72, 0x1b, 0x89, 0xe8,
-	0x41, 0x6c, 0xd6, 0x83, 0xd9, 0xac, 0xe7, 0x98, 0x57, 0xe9, 0x24, 0x78, 0x0a, 0xdd, 0xa4, 0x20,
-	0x54, 0xdd, 0x42, 0x2e, 0x5c, 0x5c, 0xa9, 0x15, 0x05, 0x99, 0x45, 0x10, 0xb3, 0x98, 0xc0, 0x66,
-	0x49, 0x61, 0x98, 0x15, 0x02, 0xf3, 0xbc, 0x13, 0xc7, 0x89, 0x7b, 0xf2, 0x0c, 0x13, 0xee, 0xcb,
-	0x33, 0x06, 0x21, 0xe9, 0x53, 0xba, 0xcb, 0xc8, 0x25, 0x04, 0x76, 0x1e, 0xaa, 0xd7, 0x24, 0xb8,
-	0xd8, 0xd3, 0x41, 0xa2, 0xa9, 0x45, 0x50, 0xef, 0xc1, 0xb8, 0x08, 0x99, 0x54, 0xb0, 0x9d, 0x70,
-	0x99, 0x54, 0x4c, 0xff, 0x31, 0x53, 0xd1, 0x7f, 0x2c, 0xe4, 0xf9, 0xcf, 0xc9, 0xe9, 0xc4, 0x43,
-	0x39, 0x86, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2,
-	0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0x52, 0x49, 0xcf, 0x2c,
-	0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0x85, 0x26, 0x22, 0x28, 0xa5, 0x5b, 0x9c, 0x92, 0xad,
-	0x5f, 0x01, 0x49, 0x88, 0x49, 0x6c, 0x60, 0xdb, 0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf5,
-	0xd2, 0x8f, 0xb0, 0xad, 0x02, 0x00, 0x00,
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x52, 0xbd, 0x6e, 0xe2, 0x30,
+	0x1c, 0x8f, 0xe1, 0xbe, 0x30, 0xe2, 0x74, 0x44, 0x19, 0x02, 0x43, 0x40, 0xd1, 0x0d, 0x2c, 0x24,
+	0xe2, 0xee, 0xa6, 0xbb, 0x89, 0xe8, 0x24, 0xd4, 0xa1, 0x43, 0x23, 0xba, 0x74, 0x41, 0x09, 0x75,
+	0x4d, 0x54, 0x1c, 0x47, 0xb6, 0x53, 0x11, 0x9e, 0x82, 0x07, 0xe8, 0x63, 0xf4, 0x21, 0x50, 0x27,
+	0xd4, 0xa9, 0x4b, 0xbf, 0xe0, 0x45, 0x2a, 0xec, 0xa0, 0xf2, 0xb1, 0x55, 0x9d, 0xec, 0xff, 0xef,
+	0xcb, 0xfe, 0x49, 0x7f, 0xd8, 0x1c, 0x52, 0x4e, 0x28, 0x77, 0x83, 0x54, 0x8c, 0xa6, 0xee, 0x55,
+	0x27, 0x44, 0x22, 0xe8, 0xa8, 0xc9, 0x49, 0x18, 0x15, 0x54, 0x37, 0x94, 0xc2, 0x51, 0x58, 0xae,
+	0xa8, 0xd7, 0x14, 0x3a, 0x90, 0x1a, 0x37, 0x97, 0xc8, 0xa1, 0xde, 0xc0, 0x94, 0xe2, 0x31, 0x72,
+	0xe5, 0x14, 0xa6, 0x17, 0xae, 0x88, 0x08, 0xe2, 0x22, 0x20, 0x49, 0x2e, 0x30, 0x30, 0xc5, 0x54,
+	0x19, 0xd7, 0xb7, 0x1c, 0xad, 0xed, 0xdb, 0x82, 0x38, 0x53, 0x94, 0xfd, 0x0f, 0x1a, 0x3d, 0x14,
+	0x23, 0x16, 0x0d, 0xbb, 0xa9, 0x18, 0x51, 0x16, 0x4d, 0x03, 0x11, 0xd1, 0x58, 0xff, 0x01, 0x8b,
+	0x84, 0x63, 0x13, 0x34, 0x41, 0xab, 0xe4, 0xaf, 0xaf, 0x7f, 0xab, 0x77, 0x37, 0xed, 0xca, 0x8e,
+	0xc8, 0xbe, 0x06, 0xf0, 0x73, 0x8f, 0x05, 0xb1, 0xd0, 0x8f, 0x61, 0x25, 0xd8, 0xa6, 0xa4, 0xb1,
+	0xfc, 0xcb, 0x70, 0xd4, 0xcb, 0xce, 0xe6, 0x65, 0xa7, 0x1b, 0x67, 0x5e, 0xf5, 0x76, 0x3f, 0xc9,
+	0xdf, 0x75, 0xeb, 0xff, 0x21, 0x44, 0x93, 0x24, 0x62, 0x2a, 0xab, 0x20, 0xb3, 0xea, 0x07, 0x59,
+	0xfd, 0x4d, 0x79, 0xef, 0xdb, 0xfc, 0xb1, 0xa1, 0xcd, 0x9e, 0x1a, 0xc0, 0xdf, 0xf2, 0xd9, 0x0f,
+	0x00, 0xea, 0xf2, 0x7b, 0xbb, 0xd5, 0x4c, 0xf8, 0x15, 0xaf, 0x51, 0xc4, 0xf2, 0x7a, 0x9b, 0xf1,
+	0x8d, 0x41, 0x66, 0x61, 0x9b, 0x41, 0x87, 0xfd, 0x8a, 0x1f, 0xd8, 0xef, 0xd3, 0x3b, 0xfb, 0xfd,
+	0x81, 0xdf, 0x65, 0xbd, 0x93, 0x14, 0xa5, 0xe8, 0x48, 0x20, 0xa2, 0xdb, 0xb0, 0x42, 0x38, 0x1e,
+	0x88, 0x2c, 0x41, 0x83, 0x94, 0x8d, 0xb9, 0x09, 0x9a, 0xc5, 0x56, 0xc9, 0x2f, 0x13, 0x8e, 0xfb,
+	0x59, 0x82, 0x4e, 0xd9, 0x98, 0x7b, 0xde, 0xfc, 0xc5, 0xd2, 0xe6, 0x4b, 0x0b, 0x2c, 0x96, 0x16,
+	0x78, 0x5e, 0x5a, 0x60, 0xb6, 0xb2, 0xb4, 0xc5, 0xca, 0xd2, 0xee, 0x57, 0x96, 0x76, 0xf6, 0x13,
+	0x47, 0x62, 0x94, 0x86, 0xce, 0x90, 0x92, 0x7c, 0xf5, 0xf2, 0xa3, 0xcd, 0xcf, 0x2f, 0xdd, 0x89,
+	0x5a, 0xdf, 0xf0, 0x8b, 0xfc, 0xe3, 0xef, 0xd7, 0x01, 0x00, 0x8f, 0xf2, 0x21, 0x23, 0xe3, 0x02,
+	0x00, 0x00,
 }
 
 func (m *GenericAuthorization) Marshal() (dAtA []byte, err error) {
@@ -317,6 +360,38 @@ func (m *GrantAuthorization) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GrantQueueItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrantQueueItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GrantQueueItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MsgTypeUrls) > 0 {
+		for iNdEx := len(m.MsgTypeUrls) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.MsgTypeUrls[iNdEx])
+			copy(dAtA[i:], m.MsgTypeUrls[iNdEx])
+			i = encodeVarintAuthz(dAtA, i, uint64(len(m.MsgTypeUrls[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintAuthz(dAtA []byte, offset int, v uint64) int {
 	offset -= sovAuthz(v)
 	base := offset
@@ -379,6 +454,21 @@ func (m *GrantAuthorization) Size() (n int) {
 	return n
 }
 
+func (m *GrantQueueItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.MsgTypeUrls) > 0 {
+		for _, s := range m.MsgTypeUrls {
+			l = len(s)
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovAuthz(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -769,6 +859,88 @@ func (m *GrantAuthorization) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GrantQueueItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrantQueueItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrantQueueItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgTypeUrls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MsgTypeUrls = append(m.MsgTypeUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipAuthz(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
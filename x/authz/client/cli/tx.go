@@ -45,6 +45,7 @@ func GetTxCmd() *cobra.Command {
 	AuthorizationTxCmd.AddCommand(
 		NewCmdGrantAuthorization(),
 		NewCmdRevokeAuthorization(),
+		NewCmdRevokeAllAuthorizations(),
 		NewCmdExecAuthorization(),
 	)
 
@@ -210,6 +211,37 @@ Example:
 	return cmd
 }
 
+func NewCmdRevokeAllAuthorizations() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-all [grantee] --from=[granter]",
+		Short: "revoke all the authorizations granted to a grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`revoke all the authorizations from a granter to a grantee:
+Example:
+ $ %s tx %s revoke-all cosmos1skj.. --from=cosmos1skj..
+			`, version.AppName, authz.ModuleName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevokeAll(clientCtx.GetFromAddress(), grantee)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func NewCmdExecAuthorization() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "exec [msg_tx_json_file] --from [grantee]",
@@ -54,6 +54,11 @@ func (k Keeper) Grants(c context.Context, req *authz.QueryGrantsRequest) (*authz
 	grantsStore := prefix.NewStore(store, key)
 
 	authorizations, pageRes, err := query.GenericFilteredPaginate(k.cdc, grantsStore, req.Pagination, func(key []byte, auth *authz.Grant) (*authz.Grant, error) {
+		// skip the expired grants that are not pruned yet
+		if auth.Expiration.Before(ctx.BlockTime()) {
+			return nil, nil
+		}
+
 		auth1 := auth.GetAuthorization()
 		if err != nil {
 			return nil, err
@@ -96,6 +101,11 @@ func (k Keeper) GranterGrants(c context.Context, req *authz.QueryGranterGrantsRe
 	authzStore := prefix.NewStore(store, grantStoreKey(nil, granter, ""))
 
 	grants, pageRes, err := query.GenericFilteredPaginate(k.cdc, authzStore, req.Pagination, func(key []byte, auth *authz.Grant) (*authz.GrantAuthorization, error) {
+		// skip the expired grants that are not pruned yet
+		if auth.Expiration.Before(ctx.BlockTime()) {
+			return nil, nil
+		}
+
 		auth1 := auth.GetAuthorization()
 		if err != nil {
 			return nil, err
@@ -143,6 +153,11 @@ func (k Keeper) GranteeGrants(c context.Context, req *authz.QueryGranteeGrantsRe
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), GrantKey)
 
 	authorizations, pageRes, err := query.GenericFilteredPaginate(k.cdc, store, req.Pagination, func(key []byte, auth *authz.Grant) (*authz.GrantAuthorization, error) {
+		// skip the expired grants that are not pruned yet
+		if auth.Expiration.Before(ctx.BlockTime()) {
+			return nil, nil
+		}
+
 		auth1 := auth.GetAuthorization()
 		if err != nil {
 			return nil, err
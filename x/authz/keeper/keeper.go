@@ -149,8 +149,16 @@ func (k Keeper) SaveGrant(ctx sdk.Context, grantee, granter sdk.AccAddress, auth
 		return err
 	}
 
-	bz := k.cdc.MustMarshal(&grant)
 	skey := grantStoreKey(grantee, granter, authorization.MsgTypeURL())
+	existing, found := k.getGrant(ctx, skey)
+	if !found || !existing.Expiration.Equal(expiration) {
+		if found {
+			k.removeFromGrantQueue(ctx, existing.Expiration, granter, grantee, authorization.MsgTypeURL())
+		}
+		k.insertIntoGrantQueue(ctx, expiration, granter, grantee, authorization.MsgTypeURL())
+	}
+
+	bz := k.cdc.MustMarshal(&grant)
 	store.Set(skey, bz)
 	return ctx.EventManager().EmitTypedEvent(&authz.EventGrant{
 		MsgTypeUrl: authorization.MsgTypeURL(),
@@ -164,11 +172,12 @@ func (k Keeper) SaveGrant(ctx sdk.Context, grantee, granter sdk.AccAddress, auth
 func (k Keeper) DeleteGrant(ctx sdk.Context, grantee sdk.AccAddress, granter sdk.AccAddress, msgType string) error {
 	store := ctx.KVStore(k.storeKey)
 	skey := grantStoreKey(grantee, granter, msgType)
-	_, found := k.getGrant(ctx, skey)
+	grant, found := k.getGrant(ctx, skey)
 	if !found {
 		return sdkerrors.ErrNotFound.Wrap("authorization not found")
 	}
 	store.Delete(skey)
+	k.removeFromGrantQueue(ctx, grant.Expiration, granter, grantee, msgType)
 	return ctx.EventManager().EmitTypedEvent(&authz.EventRevoke{
 		MsgTypeUrl: msgType,
 		Granter:    granter.String(),
@@ -176,6 +185,100 @@ func (k Keeper) DeleteGrant(ctx sdk.Context, grantee sdk.AccAddress, granter sdk
 	})
 }
 
+// DeleteAllGrants revokes all the authorizations granted to the grantee by the
+// granter. It returns an error if there is none.
+func (k Keeper) DeleteAllGrants(ctx sdk.Context, grantee sdk.AccAddress, granter sdk.AccAddress) error {
+	var msgTypes []string
+	prefix := grantStoreKey(grantee, granter, "")
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	for ; iter.Valid(); iter.Next() {
+		msgTypes = append(msgTypes, string(iter.Key()[len(prefix):]))
+	}
+	iter.Close()
+
+	if len(msgTypes) == 0 {
+		return sdkerrors.ErrNotFound.Wrap("authorization not found")
+	}
+	for _, msgType := range msgTypes {
+		if err := k.DeleteGrant(ctx, grantee, granter, msgType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGrantQueueItem returns the msg types of the grants of the granter to the
+// grantee expiring at the given time.
+func (k Keeper) getGrantQueueItem(ctx sdk.Context, expiration time.Time, granter, grantee sdk.AccAddress) authz.GrantQueueItem {
+	var item authz.GrantQueueItem
+	bz := ctx.KVStore(k.storeKey).Get(grantQueueKey(expiration, granter, grantee))
+	if bz != nil {
+		k.cdc.MustUnmarshal(bz, &item)
+	}
+	return item
+}
+
+func (k Keeper) setGrantQueueItem(ctx sdk.Context, expiration time.Time, granter, grantee sdk.AccAddress, item authz.GrantQueueItem) {
+	store := ctx.KVStore(k.storeKey)
+	key := grantQueueKey(expiration, granter, grantee)
+	if len(item.MsgTypeUrls) == 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshal(&item))
+}
+
+// insertIntoGrantQueue queues the grant of msgType for its pruning once
+// expired.
+func (k Keeper) insertIntoGrantQueue(ctx sdk.Context, expiration time.Time, granter, grantee sdk.AccAddress, msgType string) {
+	item := k.getGrantQueueItem(ctx, expiration, granter, grantee)
+	item.MsgTypeUrls = append(item.MsgTypeUrls, msgType)
+	k.setGrantQueueItem(ctx, expiration, granter, grantee, item)
+}
+
+// removeFromGrantQueue removes the grant of msgType from the grant expiration
+// queue.
+func (k Keeper) removeFromGrantQueue(ctx sdk.Context, expiration time.Time, granter, grantee sdk.AccAddress, msgType string) {
+	item := k.getGrantQueueItem(ctx, expiration, granter, grantee)
+	for i, t := range item.MsgTypeUrls {
+		if t == msgType {
+			item.MsgTypeUrls = append(item.MsgTypeUrls[:i], item.MsgTypeUrls[i+1:]...)
+			break
+		}
+	}
+	k.setGrantQueueItem(ctx, expiration, granter, grantee, item)
+}
+
+// DequeueAndDeleteExpiredGrants deletes the grants that expired before the
+// block time, the ones expiring first first, and returns the number of deleted
+// grants. It stops once it deleted at least limit grants, the grants of a
+// granter to a grantee expiring at the same time being deleted together, for
+// the next blocks to delete the rest. A limit of 0 deletes all of them.
+func (k Keeper) DequeueAndDeleteExpiredGrants(ctx sdk.Context, limit int) int {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(GrantQueuePrefix, grantQueueTimePrefix(ctx.BlockTime()))
+	var keys [][]byte
+	var items []authz.GrantQueueItem
+	deleted := 0
+	for ; iter.Valid() && (limit == 0 || deleted < limit); iter.Next() {
+		var item authz.GrantQueueItem
+		k.cdc.MustUnmarshal(iter.Value(), &item)
+		keys = append(keys, iter.Key())
+		items = append(items, item)
+		deleted += len(item.MsgTypeUrls)
+	}
+	iter.Close()
+
+	for i, key := range keys {
+		granter, grantee := addressesFromGrantQueueKey(key)
+		for _, msgType := range items[i].MsgTypeUrls {
+			store.Delete(grantStoreKey(grantee, granter, msgType))
+		}
+		store.Delete(key)
+	}
+	return deleted
+}
+
 // GetAuthorizations Returns list of `Authorizations` granted to the grantee by the granter.
 func (k Keeper) GetAuthorizations(ctx sdk.Context, grantee sdk.AccAddress, granter sdk.AccAddress) (authorizations []authz.Authorization) {
 	store := ctx.KVStore(k.storeKey)
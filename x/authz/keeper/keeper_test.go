@@ -11,7 +11,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/simapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/authz"
+	authzkeeper "github.com/cosmos/cosmos-sdk/x/authz/keeper"
+	authzmodule "github.com/cosmos/cosmos-sdk/x/authz/module"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
@@ -250,6 +253,100 @@ func (s *TestSuite) TestDispatchedEvents() {
 	}
 }
 
+func (s *TestSuite) TestPruneExpiredGrants() {
+	require := s.Require()
+	app, ctx, addrs := s.app, s.ctx, s.addrs
+	granterAddr, granteeAddr, otherAddr := addrs[0], addrs[1], addrs[2]
+	now := ctx.BlockHeader().Time
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin("steak", 100))
+	genericMsgType := "/cosmos.gov.v1beta1.MsgVote"
+
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, granterAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(time.Hour)))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, granterAddr, authz.NewGenericAuthorization(genericMsgType), now.Add(time.Hour)))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, otherAddr, granterAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(2*time.Hour)))
+	// a grant extended past its initial expiration is queued again
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, otherAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(time.Hour)))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, otherAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(3*time.Hour)))
+
+	// nothing expired yet, and a grant expiring at the block time is not expired
+	require.Equal(0, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx, 0))
+	ctx = ctx.WithBlockTime(now.Add(time.Hour))
+	require.Equal(0, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx, 0))
+
+	// the expired grants are not returned by the queries before being pruned
+	ctx = ctx.WithBlockTime(now.Add(time.Hour + time.Second))
+	res, err := app.AuthzKeeper.GranterGrants(sdk.WrapSDKContext(ctx), &authz.QueryGranterGrantsRequest{Granter: granterAddr.String()})
+	require.NoError(err)
+	require.Len(res.Grants, 1)
+	require.Equal(otherAddr.String(), res.Grants[0].Grantee)
+
+	// the grants of a granter to a grantee expiring together are pruned
+	// together
+	require.Equal(2, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx, 1))
+	require.Empty(app.AuthzKeeper.GetAuthorizations(ctx, granteeAddr, granterAddr))
+	require.Len(app.AuthzKeeper.GetAuthorizations(ctx, otherAddr, granterAddr), 1)
+	require.Len(app.AuthzKeeper.GetAuthorizations(ctx, granteeAddr, otherAddr), 1)
+
+	// the end blocker prunes the grants expiring later
+	ctx = ctx.WithBlockTime(now.Add(4 * time.Hour))
+	authzmodule.EndBlocker(ctx, app.AuthzKeeper)
+	require.Empty(app.AuthzKeeper.GetAuthorizations(ctx, otherAddr, granterAddr))
+	require.Empty(app.AuthzKeeper.GetAuthorizations(ctx, granteeAddr, otherAddr))
+	require.Equal(0, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx, 0))
+}
+
+func (s *TestSuite) TestRevokeAll() {
+	require := s.Require()
+	app, ctx, addrs := s.app, s.ctx, s.addrs
+	granterAddr, granteeAddr, otherAddr := addrs[0], addrs[1], addrs[2]
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin("steak", 100))
+
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, granterAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, expiration))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, granteeAddr, granterAddr, authz.NewGenericAuthorization("/cosmos.gov.v1beta1.MsgVote"), expiration))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, otherAddr, granterAddr, &banktypes.SendAuthorization{SpendLimit: spendLimit}, expiration))
+
+	msg := authz.NewMsgRevokeAll(granterAddr, granteeAddr)
+	_, err := app.AuthzKeeper.RevokeAll(sdk.WrapSDKContext(ctx), &msg)
+	require.NoError(err)
+	require.Empty(app.AuthzKeeper.GetAuthorizations(ctx, granteeAddr, granterAddr))
+	require.Len(app.AuthzKeeper.GetAuthorizations(ctx, otherAddr, granterAddr), 1)
+
+	// the revoked grants left the expiration queue
+	require.Equal(1, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx.WithBlockTime(expiration.Add(time.Second)), 0))
+
+	_, err = app.AuthzKeeper.RevokeAll(sdk.WrapSDKContext(ctx), &msg)
+	require.ErrorIs(err, sdkerrors.ErrNotFound)
+}
+
+func (s *TestSuite) TestMigrate1to2() {
+	require := s.Require()
+	app, ctx, addrs := s.app, s.ctx, s.addrs
+	now := ctx.BlockHeader().Time
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin("steak", 100))
+
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, addrs[1], addrs[0], &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(time.Hour)))
+	require.NoError(app.AuthzKeeper.SaveGrant(ctx, addrs[2], addrs[0], &banktypes.SendAuthorization{SpendLimit: spendLimit}, now.Add(2*time.Hour)))
+
+	// drop the queue, as the grants of version 1 are not queued
+	store := ctx.KVStore(app.GetKey(authzkeeper.StoreKey))
+	iter := sdk.KVStorePrefixIterator(store, authzkeeper.GrantQueuePrefix)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	require.Len(keys, 2)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	require.NoError(authzkeeper.NewMigrator(app.AuthzKeeper).Migrate1to2(ctx))
+	require.Equal(1, app.AuthzKeeper.DequeueAndDeleteExpiredGrants(ctx.WithBlockTime(now.Add(90*time.Minute)), 0))
+	require.Empty(app.AuthzKeeper.GetAuthorizations(ctx, addrs[1], addrs[0]))
+	require.Len(app.AuthzKeeper.GetAuthorizations(ctx, addrs[2], addrs[0]), 1)
+}
+
 func TestTestSuite(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/internal/conv"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
@@ -10,7 +12,8 @@ import (
 
 // Keys for store prefixes
 var (
-	GrantKey = []byte{0x01} // prefix for each key
+	GrantKey         = []byte{0x01} // prefix for each key
+	GrantQueuePrefix = []byte{0x02} // prefix for the grant expiration queue
 )
 
 // StoreKey is the store key string for authz
@@ -55,3 +58,46 @@ func firstAddressFromGrantStoreKey(key []byte) sdk.AccAddress {
 	addrLen := key[0]
 	return sdk.AccAddress(key[1 : 1+addrLen])
 }
+
+// grantQueueKey - return the key of the grants of a granter to a grantee
+// expiring at the given time in the grant expiration queue
+// Items are stored with the following key: values
+//
+// - 0x02<expirationLen (1 Byte)><expiration_Bytes><granterAddressLen (1 Byte)><granterAddress_Bytes><granteeAddressLen (1 Byte)><granteeAddress_Bytes>: GrantQueueItem
+//
+// The expiration is length prefixed for its parsing, which keeps the keys
+// sorted by expiration as a longer expiration is a later one.
+func grantQueueKey(expiration time.Time, granter sdk.AccAddress, grantee sdk.AccAddress) []byte {
+	prefix := grantQueueTimePrefix(expiration)
+	granter = address.MustLengthPrefix(granter)
+	grantee = address.MustLengthPrefix(grantee)
+
+	key := make([]byte, 0, len(prefix)+len(granter)+len(grantee))
+	key = append(key, prefix...)
+	key = append(key, granter...)
+	return append(key, grantee...)
+}
+
+// grantQueueTimePrefix - return the prefix of the keys of the grants expiring
+// at the given time in the grant expiration queue
+func grantQueueTimePrefix(expiration time.Time) []byte {
+	return append(append([]byte{}, GrantQueuePrefix...), address.MustLengthPrefix(sdk.FormatTimeBytes(expiration))...)
+}
+
+// addressesFromGrantQueueKey - split granter & grantee address from the grant
+// expiration queue key
+func addressesFromGrantQueueKey(key []byte) (granterAddr, granteeAddr sdk.AccAddress) {
+	// key is of format:
+	// 0x02<expirationLen (1 Byte)><expiration_Bytes><granterAddressLen (1 Byte)><granterAddress_Bytes><granteeAddressLen (1 Byte)><granteeAddress_Bytes>
+	kv.AssertKeyAtLeastLength(key, 2)
+	expirationLen := int(key[1])
+	kv.AssertKeyAtLeastLength(key, 3+expirationLen)
+	granterAddrLen := int(key[2+expirationLen])
+	kv.AssertKeyAtLeastLength(key, 4+expirationLen+granterAddrLen)
+	granterAddr = sdk.AccAddress(key[3+expirationLen : 3+expirationLen+granterAddrLen])
+	granteeAddrLen := int(key[3+expirationLen+granterAddrLen])
+	kv.AssertKeyAtLeastLength(key, 4+expirationLen+granterAddrLen+granteeAddrLen)
+	granteeAddr = sdk.AccAddress(key[4+expirationLen+granterAddrLen : 4+expirationLen+granterAddrLen+granteeAddrLen])
+
+	return granterAddr, granteeAddr
+}
@@ -1,8 +1,10 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -36,3 +38,19 @@ func TestGrantkey(t *testing.T) {
 	require.Equal(specialGranter, granter1)
 	require.Equal(specialGrantee, grantee1)
 }
+
+func TestGrantQueueKey(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	granter1, grantee1 := addressesFromGrantQueueKey(grantQueueKey(now, granter, grantee))
+	require.Equal(granter, granter1)
+	require.Equal(grantee, grantee1)
+
+	// the keys are sorted by expiration, even past the year 9999
+	later := grantQueueKey(now.Add(time.Nanosecond), sdk.AccAddress("a"), sdk.AccAddress("b"))
+	require.Equal(-1, bytes.Compare(grantQueueKey(now, granter, grantee), later))
+	farFuture := grantQueueKey(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), sdk.AccAddress("a"), sdk.AccAddress("b"))
+	require.Equal(-1, bytes.Compare(later, farFuture))
+	require.Equal(-1, bytes.Compare(farFuture, append(GrantQueuePrefix, 0xff)))
+}
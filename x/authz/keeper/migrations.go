@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates from version 1 to 2. It queues the existing grants by
+// expiration, for the expired ones to be pruned.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	type queuedGrant struct {
+		granter, grantee sdk.AccAddress
+		grant            authz.Grant
+	}
+	var grants []queuedGrant
+	m.keeper.IterateGrants(ctx, func(granter, grantee sdk.AccAddress, grant authz.Grant) bool {
+		grants = append(grants, queuedGrant{granter, grantee, grant})
+		return false
+	})
+
+	for _, g := range grants {
+		m.keeper.insertIntoGrantQueue(ctx, g.grant.Expiration, g.granter, g.grantee, g.grant.GetAuthorization().MsgTypeURL())
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package authz
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/authz/keeper"
+)
+
+// MaxPrunedGrantsPerBlock bounds the number of expired grants pruned at the end
+// of a block, the remaining ones being pruned by the next blocks.
+const MaxPrunedGrantsPerBlock = 200
+
+// EndBlocker prunes the grants that expired.
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	defer telemetry.ModuleMeasureSince(authz.ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
+
+	if pruned := k.DequeueAndDeleteExpiredGrants(ctx, MaxPrunedGrantsPerBlock); pruned > 0 {
+		telemetry.IncrCounter(float32(pruned), authz.ModuleName, "pruned_grants")
+	}
+}
@@ -66,6 +66,27 @@ func TestMsgRevokeAuthorization(t *testing.T) {
 	}
 }
 
+func TestMsgRevokeAllAuthorizations(t *testing.T) {
+	tests := []struct {
+		title            string
+		granter, grantee sdk.AccAddress
+		expectPass       bool
+	}{
+		{"nil Granter address", nil, grantee, false},
+		{"nil Grantee address", granter, nil, false},
+		{"same Granter and Grantee address", granter, granter, false},
+		{"valid test case", granter, grantee, true},
+	}
+	for i, tc := range tests {
+		msg := authz.NewMsgRevokeAll(tc.granter, tc.grantee)
+		if tc.expectPass {
+			require.NoError(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.Error(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
 func TestMsgGrantAuthorization(t *testing.T) {
 	tests := []struct {
 		title            string
@@ -23,9 +23,13 @@ func TestDecodeStore(t *testing.T) {
 	grant, _ := authz.NewGrant(banktypes.NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("foo", 123))), time.Now().UTC())
 	grantBz, err := cdc.Marshal(&grant)
 	require.NoError(t, err)
+	item := authz.GrantQueueItem{MsgTypeUrls: []string{banktypes.SendAuthorization{}.MsgTypeURL()}}
+	itemBz, err := cdc.Marshal(&item)
+	require.NoError(t, err)
 	kvPairs := kv.Pairs{
 		Pairs: []kv.Pair{
 			{Key: []byte(keeper.GrantKey), Value: grantBz},
+			{Key: []byte(keeper.GrantQueuePrefix), Value: itemBz},
 			{Key: []byte{0x99}, Value: []byte{0x99}},
 		},
 	}
@@ -36,6 +40,7 @@ func TestDecodeStore(t *testing.T) {
 		expectedLog string
 	}{
 		{"Grant", false, fmt.Sprintf("%v\n%v", grant, grant)},
+		{"GrantQueueItem", false, fmt.Sprintf("%v\n%v", item, item)},
 		{"other", true, ""},
 	}
 
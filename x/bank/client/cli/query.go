@@ -132,7 +132,12 @@ To query for the client metadata of a specific coin denomination use:
 			queryClient := types.NewQueryClient(clientCtx)
 
 			if denom == "" {
-				res, err := queryClient.DenomsMetadata(cmd.Context(), &types.QueryDenomsMetadataRequest{})
+				pageReq, err := client.ReadPageRequest(cmd.Flags())
+				if err != nil {
+					return err
+				}
+
+				res, err := queryClient.DenomsMetadata(cmd.Context(), &types.QueryDenomsMetadataRequest{Pagination: pageReq})
 				if err != nil {
 					return err
 				}
@@ -151,6 +156,7 @@ To query for the client metadata of a specific coin denomination use:
 
 	cmd.Flags().String(FlagDenom, "", "The specific denomination to query client metadata for")
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "all denominations metadata")
 
 	return cmd
 }
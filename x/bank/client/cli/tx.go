@@ -1,13 +1,19 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/bank/types"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 // NewTxCmd returns a root CLI command handler for all x/bank transaction commands.
@@ -20,7 +26,10 @@ func NewTxCmd() *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
-	txCmd.AddCommand(NewSendTxCmd())
+	txCmd.AddCommand(
+		NewSendTxCmd(),
+		NewMultiTransferTxCmd(),
+	)
 
 	return txCmd
 }
@@ -62,3 +71,132 @@ func NewSendTxCmd() *cobra.Command {
 
 	return cmd
 }
+
+// NewMultiTransferTxCmd returns a CLI command handler for creating a
+// MsgMultiTransfer transaction sending funds from an account to several others.
+func NewMultiTransferTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "multi-transfer [from_key_or_address] [to_address] [amount] [[to_address] [amount]...]",
+		Short: `Send funds from one account to several others in a single message, all of the transfers failing if one does.
+		Note, the '--from' flag is ignored as it is implied from [from_key_or_address].
+		When using '--dry-run' a key name cannot be used, only a bech32 address.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 || len(args)%2 == 0 {
+				return fmt.Errorf("expected a from address and pairs of to address and amount, got %d args", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Flags().Set(flags.FlagFrom, args[0])
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var transfers []types.Transfer
+			for i := 1; i < len(args); i += 2 {
+				coins, err := sdk.ParseCoinsNormalized(args[i+1])
+				if err != nil {
+					return err
+				}
+
+				transfers = append(transfers, types.Transfer{
+					FromAddress: clientCtx.GetFromAddress().String(),
+					ToAddress:   args[i],
+					Amount:      coins,
+				})
+			}
+
+			msg := types.NewMsgMultiTransfer(transfers...)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewCmdSubmitUpdateDenomMetadataProposal implements a command handler for
+// submitting a proposal setting the metadata of a denom.
+func NewCmdSubmitUpdateDenomMetadataProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-denom-metadata [metadata-file] [flags]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal setting the metadata of a denom",
+		Long: `Submit a proposal setting the metadata of a denom, replacing its current
+metadata if any. The metadata is keyed by its base denom and is read from a
+JSON file in the format returned by the denom-metadata query, e.g.:
+
+{
+  "description": "The native staking token of the chain.",
+  "denom_units": [
+    {"denom": "uatom", "exponent": 0, "aliases": ["microatom"]},
+    {"denom": "atom", "exponent": 6}
+  ],
+  "base": "uatom",
+  "display": "atom",
+  "name": "Atom",
+  "symbol": "ATOM"
+}
+`,
+		Example: fmt.Sprintf("$ %s tx gov submit-proposal update-denom-metadata metadata.json --title=... --description=... --deposit=...", version.AppName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var metadata types.Metadata
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &metadata); err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+			isExpedited, err := cmd.Flags().GetBool(govcli.FlagIsExpedited)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewUpdateDenomMetadataProposal(title, description, metadata)
+			msg, err := govtypes.NewMsgSubmitProposalWithExpedite(content, deposit, clientCtx.GetFromAddress(), isExpedited)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Bool(govcli.FlagIsExpedited, false, "flag indicating whether a proposal is expedited")
+	cmd.MarkFlagRequired(govcli.FlagTitle)
+	cmd.MarkFlagRequired(govcli.FlagDescription)
+
+	return cmd
+}
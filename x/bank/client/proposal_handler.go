@@ -0,0 +1,10 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/bank/client/rest"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+)
+
+// UpdateDenomMetadataProposalHandler is the denom metadata update proposal handler.
+var UpdateDenomMetadataProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitUpdateDenomMetadataProposal, rest.UpdateDenomMetadataProposalRESTHandler)
@@ -10,6 +10,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/bank/types"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 // SendReq defines the properties of a send request's body.
@@ -49,3 +51,53 @@ func NewSendRequestHandlerFn(clientCtx client.Context) http.HandlerFunc {
 		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
 	}
 }
+
+// UpdateDenomMetadataProposalReq defines a proposal setting the metadata of a
+// denom.
+type UpdateDenomMetadataProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req" yaml:"base_req"`
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	IsExpedited bool           `json:"is_expedited" yaml:"is_expedited"`
+	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	Metadata    types.Metadata `json:"metadata" yaml:"metadata"`
+}
+
+// UpdateDenomMetadataProposalRESTHandler returns a ProposalRESTHandler that
+// exposes the denom metadata update REST handler with a given sub-route.
+func UpdateDenomMetadataProposalRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "update_denom_metadata",
+		Handler:  postUpdateDenomMetadataProposalHandlerFn(clientCtx),
+	}
+}
+
+func postUpdateDenomMetadataProposalHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UpdateDenomMetadataProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+
+		content := types.NewUpdateDenomMetadataProposal(req.Title, req.Description, req.Metadata)
+		msg, err := govtypes.NewMsgSubmitProposalWithExpedite(content, req.Deposit, fromAddr, req.IsExpedited)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
@@ -23,6 +23,10 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			res, err := msgServer.MultiSend(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
 
+		case *types.MsgMultiTransfer:
+			res, err := msgServer.MultiTransfer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized bank message type: %T", msg)
 		}
@@ -153,6 +153,34 @@ func (suite *IntegrationTestSuite) TestQueryTotalSupply() {
 	suite.Require().Equal(expectedTotalSupply, res.Supply)
 }
 
+func (suite *IntegrationTestSuite) TestQueryTotalSupplyReverse() {
+	app, ctx, queryClient := suite.app, suite.ctx, suite.queryClient
+	test1Supply := sdk.NewInt64Coin("test1", 4000000)
+	test2Supply := sdk.NewInt64Coin("test2", 700000000)
+	suite.
+		Require().
+		NoError(app.BankKeeper.MintCoins(ctx, minttypes.ModuleName, sdk.NewCoins(test1Supply, test2Supply)))
+
+	res, err := queryClient.TotalSupply(gocontext.Background(), &types.QueryTotalSupplyRequest{
+		Pagination: &query.PageRequest{Reverse: true},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.Coins{test2Supply, test1Supply}, res.Supply)
+
+	// the next key of a reverse page continues in descending order
+	res, err = queryClient.TotalSupply(gocontext.Background(), &types.QueryTotalSupplyRequest{
+		Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.Coins{test2Supply}, res.Supply)
+	res, err = queryClient.TotalSupply(gocontext.Background(), &types.QueryTotalSupplyRequest{
+		Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Reverse: true},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.Coins{test1Supply}, res.Supply)
+	suite.Require().Nil(res.Pagination.NextKey)
+}
+
 func (suite *IntegrationTestSuite) TestQueryTotalSupplyOf() {
 	app, ctx, queryClient := suite.app, suite.ctx, suite.queryClient
 
@@ -71,7 +71,8 @@ type MintingRestrictionFn func(ctx sdk.Context, coins sdk.Coins) error
 type SubFn func(ctx sdk.Context, moduleName string, amounts sdk.Coins) error
 type AddFn func(ctx sdk.Context, moduleName string, amounts sdk.Coins) error
 
-// GetPaginatedTotalSupply queries for the supply, ignoring 0 coins, with a given pagination
+// GetPaginatedTotalSupply queries for the supply, ignoring 0 coins, with a given pagination.
+// The coins are in the order of the pagination, i.e. by descending denom if it is reversed.
 func (k BaseKeeper) GetPaginatedTotalSupply(ctx sdk.Context, pagination *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
 	store := ctx.KVStore(k.storeKey)
 	supplyStore := prefix.NewStore(store, types.SupplyKey)
@@ -85,8 +86,10 @@ func (k BaseKeeper) GetPaginatedTotalSupply(ctx sdk.Context, pagination *query.P
 			return fmt.Errorf("unable to convert amount string to Int %v", err)
 		}
 
-		// `Add` omits the 0 coins addition to the `supply`.
-		supply = supply.Add(sdk.NewCoin(string(key), amount))
+		// appended rather than added, as `Add` sorts the coins
+		if !amount.IsZero() {
+			supply = append(supply, sdk.NewCoin(string(key), amount))
+		}
 		return nil
 	})
 
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/simapp"
 	tmtime "github.com/cosmos/cosmos-sdk/std"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -867,6 +868,66 @@ func (suite *IntegrationTestSuite) TestMsgMultiSendEvents() {
 	suite.Require().Equal(abci.Event(event4), events[27])
 }
 
+func (suite *IntegrationTestSuite) TestMsgMultiTransfer() {
+	app, ctx := suite.app, suite.ctx
+	msgServer := keeper.NewMsgServerImpl(app.BankKeeper)
+
+	addr1 := sdk.AccAddress([]byte("addr1_______________"))
+	addr2 := sdk.AccAddress([]byte("addr2_______________"))
+	addr3 := sdk.AccAddress([]byte("addr3_______________"))
+	suite.Require().NoError(simapp.FundAccount(app.BankKeeper, ctx, addr1, sdk.NewCoins(newFooCoin(100), newBarCoin(100))))
+	suite.Require().NoError(simapp.FundAccount(app.BankKeeper, ctx, addr2, sdk.NewCoins(newFooCoin(100))))
+
+	msg := types.NewMsgMultiTransfer(
+		types.NewTransfer(addr1, addr2, sdk.NewCoins(newBarCoin(40))),
+		types.NewTransfer(addr1, addr3, sdk.NewCoins(newFooCoin(30), newBarCoin(10))),
+		types.NewTransfer(addr2, addr3, sdk.NewCoins(newFooCoin(20))),
+	)
+	suite.Require().Equal([]sdk.AccAddress{addr1, addr2}, msg.GetSigners())
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err := msgServer.MultiTransfer(sdk.WrapSDKContext(ctx), msg)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(newFooCoin(70), newBarCoin(50)), app.BankKeeper.GetAllBalances(ctx, addr1))
+	suite.Require().Equal(sdk.NewCoins(newFooCoin(80), newBarCoin(40)), app.BankKeeper.GetAllBalances(ctx, addr2))
+	suite.Require().Equal(sdk.NewCoins(newFooCoin(50), newBarCoin(10)), app.BankKeeper.GetAllBalances(ctx, addr3))
+
+	// every transfer has its own event
+	var transfers []types.Transfer
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != types.EventTypeTransfer {
+			continue
+		}
+		var transfer types.Transfer
+		for _, attr := range event.Attributes {
+			switch string(attr.Key) {
+			case types.AttributeKeySender:
+				transfer.FromAddress = string(attr.Value)
+			case types.AttributeKeyRecipient:
+				transfer.ToAddress = string(attr.Value)
+			case sdk.AttributeKeyAmount:
+				transfer.Amount, err = sdk.ParseCoinsNormalized(string(attr.Value))
+				suite.Require().NoError(err)
+			}
+		}
+		transfers = append(transfers, transfer)
+	}
+	suite.Require().Equal(msg.Transfers, transfers)
+
+	// a transfer failing fails the msg
+	_, err = msgServer.MultiTransfer(sdk.WrapSDKContext(ctx), types.NewMsgMultiTransfer(
+		types.NewTransfer(addr3, addr1, sdk.NewCoins(newFooCoin(1))),
+		types.NewTransfer(addr3, addr1, sdk.NewCoins(newFooCoin(100))),
+	))
+	suite.Require().ErrorIs(err, sdkerrors.ErrInsufficientFunds)
+	suite.Require().Contains(err.Error(), "transfer 1")
+
+	_, err = msgServer.MultiTransfer(sdk.WrapSDKContext(ctx), types.NewMsgMultiTransfer(
+		types.NewTransfer(addr3, authtypes.NewModuleAddress(minttypes.ModuleName), sdk.NewCoins(newFooCoin(1))),
+	))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+}
+
 func (suite *IntegrationTestSuite) TestSpendableCoins() {
 	app, ctx := suite.app, suite.ctx
 	now := tmtime.Now()
@@ -102,3 +102,41 @@ func (k msgServer) MultiSend(goCtx context.Context, msg *types.MsgMultiSend) (*t
 
 	return &types.MsgMultiSendResponse{}, nil
 }
+
+func (k msgServer) MultiTransfer(goCtx context.Context, msg *types.MsgMultiTransfer) (*types.MsgMultiTransferResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// the transfers are executed in the cache of the msg, so that a failing
+	// transfer fails the ones before it too
+	for i, transfer := range msg.Transfers {
+		if err := k.IsSendEnabledCoins(ctx, transfer.Amount...); err != nil {
+			return nil, sdkerrors.Wrapf(err, "transfer %d", i)
+		}
+
+		from, err := sdk.AccAddressFromBech32(transfer.FromAddress)
+		if err != nil {
+			return nil, err
+		}
+		to, err := sdk.AccAddressFromBech32(transfer.ToAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		if k.BlockedAddr(to) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive funds", transfer.ToAddress)
+		}
+
+		if err := k.SendCoins(ctx, from, to, transfer.Amount); err != nil {
+			return nil, sdkerrors.Wrapf(err, "transfer %d", i)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	)
+
+	return &types.MsgMultiTransferResponse{}, nil
+}
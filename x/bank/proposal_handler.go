@@ -0,0 +1,35 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	"github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// NewUpdateDenomMetadataProposalHandler creates a new governance Handler for an
+// UpdateDenomMetadataProposal. Since the handler only runs for proposals that
+// passed, governance is the authority allowed to set the metadata of denoms
+// after genesis.
+func NewUpdateDenomMetadataProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *types.UpdateDenomMetadataProposal:
+			return handleUpdateDenomMetadataProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized bank proposal content type: %T", c)
+		}
+	}
+}
+
+func handleUpdateDenomMetadataProposal(ctx sdk.Context, k keeper.Keeper, p *types.UpdateDenomMetadataProposal) error {
+	if err := p.Metadata.Validate(); err != nil {
+		return err
+	}
+
+	k.SetDenomMetaData(ctx, p.Metadata)
+	ctx.Logger().Info("updated denom metadata", "denom", p.Metadata.Base)
+	return nil
+}
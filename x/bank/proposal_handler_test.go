@@ -0,0 +1,65 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func testMetadata(symbol string) types.Metadata {
+	return types.Metadata{
+		Description: "The native token of the test chain",
+		DenomUnits: []*types.DenomUnit{
+			{Denom: "utest", Exponent: 0, Aliases: []string{"microtest"}},
+			{Denom: "test", Exponent: 6},
+		},
+		Base:    "utest",
+		Display: "test",
+		Name:    "Test",
+		Symbol:  symbol,
+	}
+}
+
+func TestUpdateDenomMetadataProposalHandler(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{})
+	hdlr := bank.NewUpdateDenomMetadataProposalHandler(app.BankKeeper)
+
+	_, found := app.BankKeeper.GetDenomMetaData(ctx, "utest")
+	require.False(t, found)
+
+	// the metadata of a denom without metadata is set
+	require.NoError(t, hdlr(ctx, types.NewUpdateDenomMetadataProposal("title", "description", testMetadata("TST"))))
+	metadata, found := app.BankKeeper.GetDenomMetaData(ctx, "utest")
+	require.True(t, found)
+	require.Equal(t, testMetadata("TST"), metadata)
+
+	// the metadata of a denom with metadata is replaced
+	require.NoError(t, hdlr(ctx, types.NewUpdateDenomMetadataProposal("title", "description", testMetadata("TEST"))))
+	metadata, found = app.BankKeeper.GetDenomMetaData(ctx, "utest")
+	require.True(t, found)
+	require.Equal(t, testMetadata("TEST"), metadata)
+
+	invalid := testMetadata("TEST")
+	invalid.Display = "mtest"
+	require.Error(t, hdlr(ctx, types.NewUpdateDenomMetadataProposal("title", "description", invalid)))
+	metadata, _ = app.BankKeeper.GetDenomMetaData(ctx, "utest")
+	require.Equal(t, testMetadata("TEST"), metadata)
+
+	require.Error(t, hdlr(ctx, &distrtypes.CommunityPoolSpendProposal{}))
+}
+
+func TestUpdateDenomMetadataProposalValidateBasic(t *testing.T) {
+	require.NoError(t, types.NewUpdateDenomMetadataProposal("title", "description", testMetadata("TST")).ValidateBasic())
+	require.Error(t, types.NewUpdateDenomMetadataProposal("", "description", testMetadata("TST")).ValidateBasic())
+
+	invalid := testMetadata("TST")
+	invalid.Base = ""
+	require.Error(t, types.NewUpdateDenomMetadataProposal("title", "description", invalid).ValidateBasic())
+}
@@ -0,0 +1,308 @@
+package streaming
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// DefaultBufferSize is the default number of blocks of balance changes that
+// may wait to be sent to a subscriber before it is disconnected.
+const DefaultBufferSize = 100
+
+var (
+	_ baseapp.StreamingService = (*Service)(nil)
+	_ baseapp.CommitListener   = (*Service)(nil)
+	_ storetypes.WriteListener = (*Service)(nil)
+	_ BalanceChangesServer     = (*Service)(nil)
+)
+
+// Service is a StreamingService listening to the writes of the balances to the
+// bank store, which it serves as balance changes to the subscribers of the
+// BalanceChanges gRPC service once their block is committed. The balances at
+// the start of the block are read from the previous version of the store, so
+// that the changes do not depend on the events emitted by the modules.
+//
+// The changes are pushed to each subscriber through a buffer of bufferSize
+// blocks, so that a slow subscriber never delays the commits of the node: one
+// whose buffer is full is disconnected instead.
+type Service struct {
+	ms         sdk.MultiStore
+	storeKey   sdk.StoreKey
+	bufferSize int
+
+	mtx sync.Mutex
+	// writes holds the last balance written to each key of the balances since
+	// the previous commit, nil for a deleted one
+	writes      map[string][]byte
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// subscriber receives the balance changes of its accounts until changes is
+// closed, at which point err tells why.
+type subscriber struct {
+	addresses map[string]struct{}
+	changes   chan *BlockBalanceChanges
+	err       error
+}
+
+// NewService creates a Service streaming the balance changes written to the
+// bank store of storeKey in ms, buffering up to bufferSize blocks for each
+// subscriber. A bufferSize of 0 means DefaultBufferSize.
+func NewService(ms sdk.MultiStore, storeKey sdk.StoreKey, bufferSize int) *Service {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Service{
+		ms:          ms,
+		storeKey:    storeKey,
+		bufferSize:  bufferSize,
+		writes:      make(map[string][]byte),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Stream satisfies the baseapp.StreamingService interface. The changes are
+// pushed to the subscribers by ListenCommit, so there is no loop to run.
+func (s *Service) Stream(_ *sync.WaitGroup) error {
+	return nil
+}
+
+// Listeners satisfies the baseapp.StreamingService interface by listening to
+// the bank store.
+func (s *Service) Listeners() map[storetypes.StoreKey][]storetypes.WriteListener {
+	return map[storetypes.StoreKey][]storetypes.WriteListener{s.storeKey: {s}}
+}
+
+// OnWrite satisfies the types.WriteListener interface by keeping the balances
+// written until the block is committed.
+func (s *Service) OnWrite(_ storetypes.StoreKey, key []byte, value []byte, delete bool) error {
+	if len(key) == 0 || key[0] != types.BalancesPrefix[0] {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if delete {
+		value = nil
+	}
+	s.writes[string(key)] = value
+	return nil
+}
+
+// ListenBeginBlock satisfies the baseapp.ABCIListener interface
+func (s *Service) ListenBeginBlock(sdk.Context, abci.RequestBeginBlock, abci.ResponseBeginBlock) error {
+	return nil
+}
+
+// ListenEndBlock satisfies the baseapp.ABCIListener interface
+func (s *Service) ListenEndBlock(sdk.Context, abci.RequestEndBlock, abci.ResponseEndBlock) error {
+	return nil
+}
+
+// ListenDeliverTx satisfies the baseapp.ABCIListener interface
+func (s *Service) ListenDeliverTx(sdk.Context, abci.RequestDeliverTx, abci.ResponseDeliverTx) error {
+	return nil
+}
+
+// ListenCommit pushes the balance changes of the committed block to the
+// subscribers of their accounts, disconnecting the ones that fell behind.
+func (s *Service) ListenCommit(_ context.Context, height int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	writes := s.writes
+	s.writes = make(map[string][]byte)
+	if len(writes) == 0 || len(s.subscribers) == 0 {
+		return nil
+	}
+
+	changes, err := s.balanceChanges(height, writes)
+	if err != nil {
+		// the subscribers would otherwise miss the changes of the block
+		for sub := range s.subscribers {
+			s.removeLocked(sub, status.Errorf(codes.Internal, "failed to compute the balance changes of height %d: %s", height, err))
+		}
+		return err
+	}
+	for sub := range s.subscribers {
+		var subChanges []BalanceChange
+		for _, change := range changes {
+			if _, ok := sub.addresses[change.Address]; ok {
+				subChanges = append(subChanges, change)
+			}
+		}
+		if len(subChanges) == 0 {
+			continue
+		}
+		select {
+		case sub.changes <- &BlockBalanceChanges{Height: height, Changes: subChanges}:
+		default:
+			telemetry.IncrCounter(1, "streaming", "balance_changes", "dropped_subscribers")
+			s.removeLocked(sub, status.Errorf(codes.ResourceExhausted, "subscriber fell more than %d blocks behind", s.bufferSize))
+		}
+	}
+	return nil
+}
+
+// balanceChanges returns the changes of the balances written by the block at
+// height to the accounts having a subscriber, sorted by account and denom.
+func (s *Service) balanceChanges(height int64, writes map[string][]byte) ([]BalanceChange, error) {
+	keys := make([]string, 0, len(writes))
+	addresses := make(map[string]string)
+	for key := range writes {
+		addr, err := types.AddressFromBalancesStore([]byte(key)[1:])
+		if err != nil {
+			return nil, err
+		}
+		bech32Addr, ok := addresses[string(addr)]
+		if !ok {
+			bech32Addr = addr.String()
+			addresses[string(addr)] = bech32Addr
+		}
+		if s.hasSubscriberLocked(bech32Addr) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sort.Strings(keys)
+
+	previous, err := s.ms.CacheMultiStoreWithVersion(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	previousStore := previous.GetKVStore(s.storeKey)
+	changes := make([]BalanceChange, 0, len(keys))
+	for _, key := range keys {
+		before, err := unmarshalBalance(previousStore.Get([]byte(key)))
+		if err != nil {
+			return nil, err
+		}
+		after, err := unmarshalBalance(writes[key])
+		if err != nil {
+			return nil, err
+		}
+		if before.Equal(after) {
+			continue
+		}
+		// the key is the address, length prefixed, followed by the denom
+		addrLen := int([]byte(key)[1])
+		changes = append(changes, BalanceChange{
+			Address: addresses[key[2:2+addrLen]],
+			Denom:   key[2+addrLen:],
+			Delta:   after.Sub(before),
+			Balance: after,
+		})
+	}
+	return changes, nil
+}
+
+func (s *Service) hasSubscriberLocked(address string) bool {
+	for sub := range s.subscribers {
+		if _, ok := sub.addresses[address]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalBalance returns the amount of a balance as stored by the bank
+// keeper, which deletes the zero balances.
+func unmarshalBalance(bz []byte) (sdk.Int, error) {
+	if bz == nil {
+		return sdk.ZeroInt(), nil
+	}
+	var balance sdk.Coin
+	if err := balance.Unmarshal(bz); err != nil {
+		return sdk.Int{}, err
+	}
+	return balance.Amount, nil
+}
+
+// Subscribe implements the BalanceChanges/Subscribe RPC method.
+func (s *Service) Subscribe(req *SubscribeRequest, stream BalanceChanges_SubscribeServer) error {
+	if req == nil || len(req.Addresses) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one address is required")
+	}
+	addresses := make(map[string]struct{}, len(req.Addresses))
+	for _, address := range req.Addresses {
+		addr, err := sdk.AccAddressFromBech32(address)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid address %s: %s", address, err)
+		}
+		// the changes are matched against the canonical form of the address
+		addresses[addr.String()] = struct{}{}
+	}
+
+	sub, err := s.subscribe(addresses)
+	if err != nil {
+		return err
+	}
+	defer s.unsubscribe(sub)
+	// the headers tell the subscriber that the blocks committed from now on
+	// are streamed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case changes, ok := <-sub.changes:
+			if !ok {
+				return sub.err
+			}
+			if err := stream.Send(changes); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *Service) subscribe(addresses map[string]struct{}) (*subscriber, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return nil, status.Error(codes.Unavailable, "balance changes service is closed")
+	}
+	sub := &subscriber{addresses: addresses, changes: make(chan *BlockBalanceChanges, s.bufferSize)}
+	s.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+func (s *Service) unsubscribe(sub *subscriber) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	// a subscriber removed by the service has its changes closed already
+	delete(s.subscribers, sub)
+}
+
+// removeLocked disconnects sub with err once it sent the changes it holds.
+func (s *Service) removeLocked(sub *subscriber, err error) {
+	sub.err = err
+	close(sub.changes)
+	delete(s.subscribers, sub)
+}
+
+// Close disconnects the subscribers once they sent the changes they hold.
+func (s *Service) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	for sub := range s.subscribers {
+		s.removeLocked(sub, status.Error(codes.Unavailable, "balance changes service is closed"))
+	}
+	return nil
+}
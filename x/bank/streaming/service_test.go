@@ -0,0 +1,164 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+var (
+	addr1 = sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	addr2 = sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	addr3 = sdk.AccAddress(bytes.Repeat([]byte{3}, 20))
+)
+
+func startServer(t *testing.T, s *Service) BalanceChangesClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcSrv := grpc.NewServer()
+	RegisterBalanceChangesServer(grpcSrv, s)
+	go grpcSrv.Serve(lis) //nolint:errcheck
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewBalanceChangesClient(conn)
+}
+
+// setupService returns a multistore with a bank store whose balance writes are
+// listened to by the returned Service.
+func setupService(t *testing.T, bufferSize int) (*rootmulti.Store, *Service) {
+	rs := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	rs.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, rs.LoadLatestVersion())
+	s := NewService(rs, key, bufferSize)
+	for key, listeners := range s.Listeners() {
+		rs.AddListeners(key, listeners)
+	}
+	return rs, s
+}
+
+type balance struct {
+	addr sdk.AccAddress
+	coin sdk.Coin
+}
+
+// commitBlock writes the balances the way the bank keeper does and commits
+// them as the next block.
+func commitBlock(t *testing.T, rs *rootmulti.Store, s *Service, balances ...balance) {
+	cms := rs.CacheMultiStore()
+	store := cms.GetKVStore(s.storeKey)
+	// a write to the other data of the bank store is not a balance change
+	store.Set(types.DenomMetadataKey("usei"), []byte("metadata"))
+	for _, b := range balances {
+		key := types.CreatePrefixedAccountStoreKey(b.addr, []byte(b.coin.Denom))
+		if b.coin.IsZero() {
+			store.Delete(key)
+		} else {
+			bz, err := b.coin.Marshal()
+			require.NoError(t, err)
+			store.Set(key, bz)
+		}
+	}
+	cms.Write()
+	commitID := rs.Commit(true)
+	require.NoError(t, s.ListenCommit(context.Background(), commitID.Version))
+}
+
+func TestSubscribe(t *testing.T) {
+	rs, s := setupService(t, 0)
+	client := startServer(t, s)
+
+	// the blocks committed before the subscription are not streamed
+	commitBlock(t, rs, s, balance{addr1, sdk.NewInt64Coin("usei", 100)})
+
+	stream, err := client.Subscribe(context.Background(), &SubscribeRequest{Addresses: []string{addr2.String(), addr1.String()}})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	commitBlock(t, rs, s,
+		balance{addr1, sdk.NewInt64Coin("usei", 70)},
+		balance{addr1, sdk.NewInt64Coin("uatom", 10)},
+		balance{addr2, sdk.NewInt64Coin("usei", 30)},
+		balance{addr3, sdk.NewInt64Coin("usei", 5)},
+	)
+	// a block not changing the balances of the accounts is skipped
+	commitBlock(t, rs, s, balance{addr3, sdk.NewInt64Coin("usei", 10)})
+	commitBlock(t, rs, s,
+		balance{addr1, sdk.NewInt64Coin("usei", 0)},
+		// a balance written with its previous amount did not change
+		balance{addr2, sdk.NewInt64Coin("usei", 30)},
+	)
+
+	changes, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, &BlockBalanceChanges{
+		Height: 2,
+		Changes: []BalanceChange{
+			{Address: addr1.String(), Denom: "uatom", Delta: sdk.NewInt(10), Balance: sdk.NewInt(10)},
+			{Address: addr1.String(), Denom: "usei", Delta: sdk.NewInt(-30), Balance: sdk.NewInt(70)},
+			{Address: addr2.String(), Denom: "usei", Delta: sdk.NewInt(30), Balance: sdk.NewInt(30)},
+		},
+	}, changes)
+	changes, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, &BlockBalanceChanges{
+		Height: 4,
+		Changes: []BalanceChange{
+			{Address: addr1.String(), Denom: "usei", Delta: sdk.NewInt(-70), Balance: sdk.ZeroInt()},
+		},
+	}, changes)
+
+	// closing the service ends the subscriptions
+	require.NoError(t, s.Close())
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSubscribeInvalidRequest(t *testing.T) {
+	_, s := setupService(t, 0)
+	client := startServer(t, s)
+
+	for _, req := range []*SubscribeRequest{
+		{},
+		{Addresses: []string{addr1.String(), "invalid"}},
+	} {
+		stream, err := client.Subscribe(context.Background(), req)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestSubscriberFallingBehind(t *testing.T) {
+	rs, s := setupService(t, 1)
+	sub, err := s.subscribe(map[string]struct{}{addr1.String(): {}})
+	require.NoError(t, err)
+
+	commitBlock(t, rs, s, balance{addr1, sdk.NewInt64Coin("usei", 1)})
+	commitBlock(t, rs, s, balance{addr1, sdk.NewInt64Coin("usei", 2)})
+
+	// the buffered changes are still sent before the subscriber is disconnected
+	changes, ok := <-sub.changes
+	require.True(t, ok)
+	require.Equal(t, int64(1), changes.Height)
+	_, ok = <-sub.changes
+	require.False(t, ok)
+	require.Equal(t, codes.ResourceExhausted, status.Code(sub.err))
+}
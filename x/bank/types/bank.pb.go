@@ -417,6 +417,46 @@ func (m *Metadata) GetSymbol() string {
 	return ""
 }
 
+// UpdateDenomMetadataProposal is a gov Content type for setting the metadata
+// of a denom, or replacing the metadata of a denom already having one.
+type UpdateDenomMetadataProposal struct {
+	Title       string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Metadata    Metadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata"`
+}
+
+func (m *UpdateDenomMetadataProposal) Reset()      { *m = UpdateDenomMetadataProposal{} }
+func (*UpdateDenomMetadataProposal) ProtoMessage() {}
+func (*UpdateDenomMetadataProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dd052eee12edf988, []int{7}
+}
+func (m *UpdateDenomMetadataProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateDenomMetadataProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateDenomMetadataProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateDenomMetadataProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDenomMetadataProposal.Merge(m, src)
+}
+func (m *UpdateDenomMetadataProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateDenomMetadataProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDenomMetadataProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDenomMetadataProposal proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Params)(nil), "cosmos.bank.v1beta1.Params")
 	proto.RegisterType((*SendEnabled)(nil), "cosmos.bank.v1beta1.SendEnabled")
@@ -425,49 +465,54 @@ func init() {
 	proto.RegisterType((*Supply)(nil), "cosmos.bank.v1beta1.Supply")
 	proto.RegisterType((*DenomUnit)(nil), "cosmos.bank.v1beta1.DenomUnit")
 	proto.RegisterType((*Metadata)(nil), "cosmos.bank.v1beta1.Metadata")
+	proto.RegisterType((*UpdateDenomMetadataProposal)(nil), "cosmos.bank.v1beta1.UpdateDenomMetadataProposal")
 }
 
 func init() { proto.RegisterFile("cosmos/bank/v1beta1/bank.proto", fileDescriptor_dd052eee12edf988) }
 
 var fileDescriptor_dd052eee12edf988 = []byte{
-	// 592 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xbf, 0x6f, 0xd3, 0x40,
-	0x14, 0xf6, 0x35, 0x8d, 0x49, 0x2f, 0xb0, 0x1c, 0x15, 0x72, 0x23, 0x61, 0x1b, 0x4b, 0x48, 0x29,
-	0xa2, 0x4e, 0x0a, 0x0c, 0x28, 0x0b, 0x52, 0xca, 0x0f, 0x75, 0x40, 0x20, 0x57, 0x08, 0x09, 0x86,
-	0xe8, 0x9c, 0xbb, 0x06, 0xab, 0xf6, 0x9d, 0x95, 0x3b, 0x57, 0xf5, 0x7f, 0xc0, 0x04, 0x8c, 0x8c,
-	0x9d, 0x59, 0xe1, 0x7f, 0xa0, 0x63, 0x05, 0x0b, 0x53, 0x40, 0xc9, 0xc2, 0xdc, 0xbf, 0x00, 0xf9,
-	0xce, 0xf9, 0x51, 0x29, 0x20, 0x06, 0x06, 0xa6, 0xbc, 0xef, 0xbd, 0xef, 0x7d, 0xef, 0xe9, 0xbb,
-	0xe7, 0x40, 0xbb, 0xcf, 0x45, 0xc2, 0x45, 0x2b, 0xc4, 0xec, 0xa0, 0x75, 0xb8, 0x1d, 0x52, 0x89,
-	0xb7, 0x15, 0xf0, 0xd3, 0x21, 0x97, 0x1c, 0x5d, 0xd6, 0x75, 0x5f, 0xa5, 0xca, 0x7a, 0x63, 0x7d,
-	0xc0, 0x07, 0x5c, 0xd5, 0x5b, 0x45, 0xa4, 0xa9, 0x8d, 0x0d, 0x4d, 0xed, 0xe9, 0x42, 0xd9, 0xa7,
-	0x4b, 0xf3, 0x29, 0x82, 0xce, 0xa6, 0xf4, 0x79, 0xc4, 0x74, 0xdd, 0xfb, 0x0a, 0xa0, 0xf9, 0x14,
-	0x0f, 0x71, 0x22, 0xd0, 0x3e, 0xbc, 0x28, 0x28, 0x23, 0x3d, 0xca, 0x70, 0x18, 0x53, 0x62, 0x01,
-	0xb7, 0xd2, 0xac, 0xdf, 0x72, 0xfd, 0x25, 0x7b, 0xf8, 0x7b, 0x94, 0x91, 0x07, 0x9a, 0xd7, 0xbd,
-	0x76, 0x36, 0x72, 0xae, 0xe6, 0x38, 0x89, 0x3b, 0xde, 0x62, 0xff, 0x4d, 0x9e, 0x44, 0x92, 0x26,
-	0xa9, 0xcc, 0xbd, 0xa0, 0x2e, 0xe6, 0x7c, 0xf4, 0x12, 0xae, 0x13, 0xba, 0x8f, 0xb3, 0x58, 0xf6,
-	0xce, 0xcd, 0x5b, 0x71, 0x41, 0xb3, 0xd6, 0xdd, 0x3c, 0x1b, 0x39, 0xd7, 0xb5, 0xda, 0x32, 0xd6,
-	0xa2, 0x2a, 0x2a, 0x09, 0x0b, 0xcb, 0x74, 0x56, 0xdf, 0x1f, 0x3b, 0x86, 0xf7, 0x08, 0xd6, 0x17,
-	0x92, 0x68, 0x1d, 0x56, 0x09, 0x65, 0x3c, 0xb1, 0x80, 0x0b, 0x9a, 0x6b, 0x81, 0x06, 0xc8, 0x82,
-	0x17, 0xce, 0x8d, 0x0e, 0xa6, 0xb0, 0x53, 0x2b, 0x44, 0x7e, 0x1e, 0x3b, 0xc0, 0x7b, 0x03, 0x60,
-	0x75, 0x97, 0xa5, 0x99, 0x2c, 0xd8, 0x98, 0x90, 0x21, 0x15, 0xa2, 0x54, 0x99, 0x42, 0x84, 0x61,
-	0xb5, 0x30, 0x54, 0x58, 0x2b, 0xca, 0xb0, 0x8d, 0xb9, 0x61, 0x82, 0xce, 0x0c, 0xdb, 0xe1, 0x11,
-	0xeb, 0xb6, 0x4f, 0x46, 0x8e, 0xf1, 0xe1, 0xbb, 0xd3, 0x1c, 0x44, 0xf2, 0x55, 0x16, 0xfa, 0x7d,
-	0x9e, 0x94, 0xaf, 0x55, 0xfe, 0x6c, 0x09, 0x72, 0xd0, 0x92, 0x79, 0x4a, 0x85, 0x6a, 0x10, 0x81,
-	0x56, 0xee, 0xd4, 0x5e, 0xeb, 0x85, 0x0c, 0xef, 0x2d, 0x80, 0xe6, 0x93, 0x4c, 0xfe, 0x47, 0x1b,
-	0x7d, 0x04, 0xd0, 0xdc, 0xcb, 0xd2, 0x34, 0xce, 0x8b, 0xb9, 0x92, 0x4b, 0x1c, 0x97, 0xa7, 0xf3,
-	0x6f, 0xe7, 0x2a, 0xe5, 0xce, 0xc3, 0x72, 0x2e, 0xf8, 0xf2, 0x69, 0xeb, 0xee, 0x8d, 0x3f, 0x76,
-	0x1f, 0xe9, 0x4f, 0x2b, 0xa6, 0x03, 0xdc, 0xcf, 0x5b, 0x87, 0xed, 0x3b, 0x6d, 0x5f, 0xef, 0xb9,
-	0x6b, 0x01, 0xef, 0x39, 0x5c, 0xbb, 0x5f, 0x5c, 0xc1, 0x33, 0x16, 0xc9, 0xdf, 0xdc, 0x47, 0x03,
-	0xd6, 0xe8, 0x51, 0xca, 0x19, 0x65, 0x52, 0x1d, 0xc8, 0xa5, 0x60, 0x86, 0x95, 0xf7, 0x71, 0x84,
-	0x05, 0x15, 0x56, 0xc5, 0xad, 0x28, 0xef, 0x35, 0xf4, 0x3e, 0x03, 0x58, 0x7b, 0x4c, 0x25, 0x26,
-	0x58, 0x62, 0xe4, 0xc2, 0x3a, 0xa1, 0xa2, 0x3f, 0x8c, 0x52, 0x19, 0x71, 0x56, 0xca, 0x2f, 0xa6,
-	0xd0, 0xbd, 0x82, 0xc1, 0x78, 0xd2, 0xcb, 0x58, 0x24, 0xa7, 0x0f, 0x66, 0x2f, 0xfd, 0xe6, 0x66,
-	0xfb, 0x06, 0x90, 0x4c, 0x43, 0x81, 0x10, 0x5c, 0x2d, 0xec, 0xb5, 0x2a, 0x4a, 0x5b, 0xc5, 0xc5,
-	0x76, 0x24, 0x12, 0x69, 0x8c, 0x73, 0x6b, 0x55, 0x5f, 0x46, 0x09, 0x0b, 0x36, 0xc3, 0x09, 0xb5,
-	0xaa, 0x9a, 0x5d, 0xc4, 0xe8, 0x0a, 0x34, 0x45, 0x9e, 0x84, 0x3c, 0xb6, 0x4c, 0x95, 0x2d, 0x51,
-	0x77, 0xe7, 0x64, 0x6c, 0x83, 0xd3, 0xb1, 0x0d, 0x7e, 0x8c, 0x6d, 0xf0, 0x6e, 0x62, 0x1b, 0xa7,
-	0x13, 0xdb, 0xf8, 0x36, 0xb1, 0x8d, 0x17, 0x9b, 0x7f, 0xe3, 0xbb, 0x7a, 0xbc, 0xd0, 0x54, 0x7f,
-	0x33, 0xb7, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0xcc, 0x03, 0xbf, 0xe9, 0xee, 0x04, 0x00, 0x00,
+	// 649 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x3d, 0x6f, 0x13, 0x31,
+	0x18, 0x3e, 0x37, 0x1f, 0xa4, 0x4e, 0x59, 0x4c, 0x85, 0xae, 0x45, 0xbd, 0x84, 0x93, 0x90, 0x52,
+	0x44, 0x93, 0xb6, 0x30, 0xa0, 0x2c, 0x48, 0x29, 0x1f, 0xea, 0x80, 0xa8, 0xae, 0xaa, 0x90, 0x60,
+	0x88, 0x9c, 0xd8, 0x0d, 0xa7, 0xde, 0xd9, 0xa7, 0xd8, 0x57, 0xf5, 0xfe, 0x01, 0x13, 0x30, 0x32,
+	0x76, 0x60, 0x62, 0x85, 0xff, 0x40, 0xc7, 0x0a, 0x16, 0xa6, 0x82, 0xda, 0x85, 0xb9, 0xbf, 0x00,
+	0xf9, 0xe3, 0x92, 0x14, 0x05, 0xc4, 0x82, 0xc4, 0x14, 0x3f, 0x7e, 0x1f, 0x3f, 0xcf, 0xeb, 0xe7,
+	0x5e, 0x07, 0x7a, 0x7d, 0x2e, 0x62, 0x2e, 0x5a, 0x3d, 0xcc, 0xf6, 0x5a, 0xfb, 0x6b, 0x3d, 0x2a,
+	0xf1, 0x9a, 0x06, 0xcd, 0x64, 0xc8, 0x25, 0x47, 0x57, 0x4c, 0xbd, 0xa9, 0xb7, 0x6c, 0x7d, 0x71,
+	0x7e, 0xc0, 0x07, 0x5c, 0xd7, 0x5b, 0x6a, 0x65, 0xa8, 0x8b, 0x0b, 0x86, 0xda, 0x35, 0x05, 0x7b,
+	0xce, 0x94, 0xc6, 0x2e, 0x82, 0x8e, 0x5c, 0xfa, 0x3c, 0x64, 0xa6, 0xee, 0x7f, 0x01, 0xb0, 0xbc,
+	0x85, 0x87, 0x38, 0x16, 0x68, 0x17, 0xce, 0x09, 0xca, 0x48, 0x97, 0x32, 0xdc, 0x8b, 0x28, 0x71,
+	0x41, 0xbd, 0xd0, 0xa8, 0xae, 0xd7, 0x9b, 0x53, 0xfa, 0x68, 0x6e, 0x53, 0x46, 0x1e, 0x18, 0x5e,
+	0xe7, 0xfa, 0xf9, 0x49, 0x6d, 0x29, 0xc3, 0x71, 0xd4, 0xf6, 0x27, 0xcf, 0xdf, 0xe2, 0x71, 0x28,
+	0x69, 0x9c, 0xc8, 0xcc, 0x0f, 0xaa, 0x62, 0xcc, 0x47, 0xcf, 0xe1, 0x3c, 0xa1, 0xbb, 0x38, 0x8d,
+	0x64, 0xf7, 0x82, 0xdf, 0x4c, 0x1d, 0x34, 0x2a, 0x9d, 0xe5, 0xf3, 0x93, 0xda, 0x0d, 0xa3, 0x36,
+	0x8d, 0x35, 0xa9, 0x8a, 0x2c, 0x61, 0xa2, 0x99, 0x76, 0xf1, 0xed, 0x61, 0xcd, 0xf1, 0x1f, 0xc1,
+	0xea, 0xc4, 0x26, 0x9a, 0x87, 0x25, 0x42, 0x19, 0x8f, 0x5d, 0x50, 0x07, 0x8d, 0xd9, 0xc0, 0x00,
+	0xe4, 0xc2, 0x4b, 0x17, 0xac, 0x83, 0x1c, 0xb6, 0x2b, 0x4a, 0xe4, 0xc7, 0x61, 0x0d, 0xf8, 0xaf,
+	0x00, 0x2c, 0x6d, 0xb2, 0x24, 0x95, 0x8a, 0x8d, 0x09, 0x19, 0x52, 0x21, 0xac, 0x4a, 0x0e, 0x11,
+	0x86, 0x25, 0x15, 0xa8, 0x70, 0x67, 0x74, 0x60, 0x0b, 0xe3, 0xc0, 0x04, 0x1d, 0x05, 0xb6, 0xc1,
+	0x43, 0xd6, 0x59, 0x3d, 0x3a, 0xa9, 0x39, 0xef, 0xbf, 0xd5, 0x1a, 0x83, 0x50, 0xbe, 0x48, 0x7b,
+	0xcd, 0x3e, 0x8f, 0xed, 0xd7, 0xb2, 0x3f, 0x2b, 0x82, 0xec, 0xb5, 0x64, 0x96, 0x50, 0xa1, 0x0f,
+	0x88, 0xc0, 0x28, 0xb7, 0x2b, 0x2f, 0x4d, 0x43, 0x8e, 0xff, 0x1a, 0xc0, 0xf2, 0x93, 0x54, 0xfe,
+	0x47, 0x1d, 0x7d, 0x00, 0xb0, 0xbc, 0x9d, 0x26, 0x49, 0x94, 0x29, 0x5f, 0xc9, 0x25, 0x8e, 0x5c,
+	0xf0, 0x0f, 0x7c, 0xb5, 0x72, 0xfb, 0xa1, 0xf5, 0x05, 0x9f, 0x3f, 0xae, 0xdc, 0xbd, 0xf9, 0xc7,
+	0xd3, 0x07, 0xe6, 0x69, 0x45, 0x74, 0x80, 0xfb, 0x59, 0x6b, 0x7f, 0xf5, 0xce, 0x6a, 0xd3, 0xf4,
+	0xb9, 0xe9, 0x02, 0xff, 0x29, 0x9c, 0xbd, 0xaf, 0xa6, 0x60, 0x87, 0x85, 0xf2, 0x37, 0xf3, 0xb1,
+	0x08, 0x2b, 0xf4, 0x20, 0xe1, 0x8c, 0x32, 0xa9, 0x07, 0xe4, 0x72, 0x30, 0xc2, 0x3a, 0xfb, 0x28,
+	0xc4, 0x82, 0x0a, 0xb7, 0x50, 0x2f, 0xe8, 0xec, 0x0d, 0xf4, 0x3f, 0x01, 0x58, 0x79, 0x4c, 0x25,
+	0x26, 0x58, 0x62, 0x54, 0x87, 0x55, 0x42, 0x45, 0x7f, 0x18, 0x26, 0x32, 0xe4, 0xcc, 0xca, 0x4f,
+	0x6e, 0xa1, 0x7b, 0x8a, 0xc1, 0x78, 0xdc, 0x4d, 0x59, 0x28, 0xf3, 0x0f, 0xe6, 0x4d, 0x7d, 0x73,
+	0xa3, 0x7e, 0x03, 0x48, 0xf2, 0xa5, 0x40, 0x08, 0x16, 0x55, 0xbc, 0x6e, 0x41, 0x6b, 0xeb, 0xb5,
+	0xea, 0x8e, 0x84, 0x22, 0x89, 0x70, 0xe6, 0x16, 0xcd, 0x64, 0x58, 0xa8, 0xd8, 0x0c, 0xc7, 0xd4,
+	0x2d, 0x19, 0xb6, 0x5a, 0xa3, 0xab, 0xb0, 0x2c, 0xb2, 0xb8, 0xc7, 0x23, 0xb7, 0xac, 0x77, 0x2d,
+	0xf2, 0xdf, 0x01, 0x78, 0x6d, 0x27, 0x21, 0x58, 0x52, 0xed, 0x9c, 0x5f, 0x6a, 0x6b, 0xc8, 0x13,
+	0x2e, 0x70, 0xa4, 0x52, 0x93, 0xa1, 0x8c, 0x68, 0x9e, 0x9a, 0x06, 0xbf, 0x5e, 0x79, 0x66, 0xda,
+	0x95, 0x2b, 0xb1, 0xd5, 0xd2, 0x5d, 0x57, 0xd7, 0x97, 0xa6, 0xde, 0x37, 0x37, 0xec, 0x14, 0xd5,
+	0xb0, 0x04, 0xa3, 0x43, 0xed, 0x39, 0x35, 0x03, 0xf6, 0x89, 0x3a, 0x9d, 0x8d, 0xa3, 0x53, 0x0f,
+	0x1c, 0x9f, 0x7a, 0xe0, 0xfb, 0xa9, 0x07, 0xde, 0x9c, 0x79, 0xce, 0xf1, 0x99, 0xe7, 0x7c, 0x3d,
+	0xf3, 0x9c, 0x67, 0xcb, 0x7f, 0x33, 0x1e, 0x7a, 0xc6, 0x7a, 0x65, 0xfd, 0x6f, 0x78, 0xfb, 0xe7,
+	0x00, 0x56, 0x67, 0x97, 0xad, 0x95, 0x05, 0x00, 0x00,
 }
 
 func (this *SendEnabled) Equal(that interface{}) bool {
@@ -854,6 +899,53 @@ func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *UpdateDenomMetadataProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateDenomMetadataProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateDenomMetadataProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintBank(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintBank(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintBank(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintBank(dAtA []byte, offset int, v uint64) int {
 	offset -= sovBank(v)
 	base := offset
@@ -1009,6 +1101,25 @@ func (m *Metadata) Size() (n int) {
 	return n
 }
 
+func (m *UpdateDenomMetadataProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovBank(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovBank(uint64(l))
+	}
+	l = m.Metadata.Size()
+	n += 1 + l + sovBank(uint64(l))
+	return n
+}
+
 func sovBank(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1914,6 +2025,153 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UpdateDenomMetadataProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBank
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateDenomMetadataProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateDenomMetadataProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBank
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBank
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBank
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBank
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBank
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBank
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBank
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBank
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBank
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBank(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBank
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipBank(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/hex"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 	"github.com/cosmos/cosmos-sdk/types/kv"
@@ -74,6 +76,19 @@ func CreatePrefixedAccountStoreKey(addr []byte, denom []byte) []byte {
 	return append(CreateAccountBalancesPrefix(addr), denom...)
 }
 
+// AccountBalancesIdentifier returns the access control identifier of the
+// balances of an account in every denomination.
+func AccountBalancesIdentifier(addr []byte) string {
+	return hex.EncodeToString(CreateAccountBalancesPrefix(addr))
+}
+
+// BalanceIdentifier returns the access control identifier of the balance of an
+// account in the given denomination, which does not overlap with the ones of
+// its other denominations.
+func BalanceIdentifier(addr []byte, denom string) string {
+	return hex.EncodeToString(CreatePrefixedAccountStoreKey(addr, []byte(denom)))
+}
+
 // This creates the prefix for use for the mem KV store used to track deferred balances by module name
 func CreateDeferredCacheModulePrefix(moduleAddr []byte) []byte {
 	return append(DeferredCachePrefix, address.MustLengthPrefix(moduleAddr)...)
@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"encoding/hex"
 	"errors"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	acltypes "github.com/cosmos/cosmos-sdk/types/accesscontrol"
 	"github.com/cosmos/cosmos-sdk/types/address"
 	"github.com/cosmos/cosmos-sdk/x/bank/types"
 )
@@ -55,3 +57,13 @@ func TestAddressFromBalancesStore(t *testing.T) {
 		})
 	}
 }
+
+func TestBalanceIdentifiers(t *testing.T) {
+	addr := sdk.AccAddress("addr1_______________")
+	other := sdk.AccAddress("addr2_______________")
+
+	require.Equal(t, hex.EncodeToString(types.CreatePrefixedAccountStoreKey(addr, []byte("usei"))), types.BalanceIdentifier(addr, "usei"))
+	require.True(t, acltypes.IdentifiersOverlap(types.AccountBalancesIdentifier(addr), types.BalanceIdentifier(addr, "usei")))
+	require.False(t, acltypes.IdentifiersOverlap(types.BalanceIdentifier(addr, "usei"), types.BalanceIdentifier(addr, "uatom")))
+	require.False(t, acltypes.IdentifiersOverlap(types.AccountBalancesIdentifier(addr), types.AccountBalancesIdentifier(other)))
+}
@@ -7,8 +7,9 @@ import (
 
 // bank message types
 const (
-	TypeMsgSend      = "send"
-	TypeMsgMultiSend = "multisend"
+	TypeMsgSend          = "send"
+	TypeMsgMultiSend     = "multisend"
+	TypeMsgMultiTransfer = "multitransfer"
 )
 
 var _ sdk.Msg = &MsgSend{}
@@ -106,6 +107,67 @@ func (msg MsgMultiSend) GetSigners() []sdk.AccAddress {
 	return addrs
 }
 
+var _ sdk.Msg = &MsgMultiTransfer{}
+
+// NewMsgMultiTransfer - construct a msg executing the transfers atomically.
+func NewMsgMultiTransfer(transfers ...Transfer) *MsgMultiTransfer {
+	return &MsgMultiTransfer{Transfers: transfers}
+}
+
+// Route Implements Msg
+func (msg MsgMultiTransfer) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgMultiTransfer) Type() string { return TypeMsgMultiTransfer }
+
+// ValidateBasic Implements Msg.
+func (msg MsgMultiTransfer) ValidateBasic() error {
+	if len(msg.Transfers) == 0 {
+		return ErrNoTransfers
+	}
+
+	for _, transfer := range msg.Transfers {
+		if err := transfer.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgMultiTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners Implements Msg. The senders sign once each, in the order of their
+// first transfer.
+func (msg MsgMultiTransfer) GetSigners() []sdk.AccAddress {
+	var addrs []sdk.AccAddress
+	seen := make(map[string]bool)
+	for _, transfer := range msg.Transfers {
+		if seen[transfer.FromAddress] {
+			continue
+		}
+		seen[transfer.FromAddress] = true
+		addr, _ := sdk.AccAddressFromBech32(transfer.FromAddress)
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
+
+// NewTransfer - create a transfer, used with MsgMultiTransfer
+//nolint:interfacer
+func NewTransfer(fromAddr, toAddr sdk.AccAddress, amount sdk.Coins) Transfer {
+	return Transfer{FromAddress: fromAddr.String(), ToAddress: toAddr.String(), Amount: amount}
+}
+
+// ValidateBasic - validate a transfer
+func (t Transfer) ValidateBasic() error {
+	return MsgSend{FromAddress: t.FromAddress, ToAddress: t.ToAddress, Amount: t.Amount}.ValidateBasic()
+}
+
 // ValidateBasic - validate transaction input
 func (in Input) ValidateBasic() error {
 	_, err := sdk.AccAddressFromBech32(in.Address)
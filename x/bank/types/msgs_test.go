@@ -251,6 +251,55 @@ func TestMsgMultiSendGetSigners(t *testing.T) {
 	require.Equal(t, "[696E707574313131313131313131313131313131 696E707574323232323232323232323232323232 696E707574333333333333333333333333333333]", fmt.Sprintf("%v", res))
 }
 
+func TestMsgMultiTransferValidation(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("from________________"))
+	addr2 := sdk.AccAddress([]byte("to__________________"))
+	atom123 := sdk.NewCoins(sdk.NewInt64Coin("atom", 123))
+
+	cases := []struct {
+		expectedErr string // empty means no error expected
+		msg         *MsgMultiTransfer
+	}{
+		{"", NewMsgMultiTransfer(NewTransfer(addr1, addr2, atom123))},
+		{"", NewMsgMultiTransfer(NewTransfer(addr1, addr2, atom123), NewTransfer(addr2, addr1, atom123))},
+		{"no transfers to execute", NewMsgMultiTransfer()},
+		{"0atom: invalid coins", NewMsgMultiTransfer(NewTransfer(addr1, addr2, atom123), NewTransfer(addr1, addr2, sdk.Coins{sdk.NewInt64Coin("atom", 0)}))},
+		{"Invalid recipient address (empty address string is not allowed): invalid address", NewMsgMultiTransfer(NewTransfer(addr1, sdk.AccAddress{}, atom123))},
+	}
+
+	for _, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.expectedErr == "" {
+			require.Nil(t, err)
+		} else {
+			require.EqualError(t, err, tc.expectedErr)
+		}
+	}
+}
+
+func TestMsgMultiTransferGetSignBytes(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("input"))
+	addr2 := sdk.AccAddress([]byte("output"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	res := NewMsgMultiTransfer(NewTransfer(addr1, addr2, coins)).GetSignBytes()
+
+	expected := `{"type":"cosmos-sdk/MsgMultiTransfer","value":{"transfers":[{"amount":[{"amount":"10","denom":"atom"}],"from_address":"cosmos1d9h8qat57ljhcm","to_address":"cosmos1da6hgur4wsmpnjyg"}]}}`
+	require.Equal(t, expected, string(res))
+}
+
+func TestMsgMultiTransferGetSigners(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("input111111111111111"))
+	addr2 := sdk.AccAddress([]byte("input222222222222222"))
+	addr3 := sdk.AccAddress([]byte("input333333333333333"))
+	msg := NewMsgMultiTransfer(
+		NewTransfer(addr2, addr1, nil),
+		NewTransfer(addr1, addr3, nil),
+		NewTransfer(addr2, addr3, nil),
+	)
+
+	require.Equal(t, []sdk.AccAddress{addr2, addr1}, msg.GetSigners())
+}
+
 func TestMsgSendSigners(t *testing.T) {
 	signers := []sdk.AccAddress{
 		{1, 2, 3},
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeUpdateDenomMetadata defines the type for an UpdateDenomMetadataProposal
+	ProposalTypeUpdateDenomMetadata = "UpdateDenomMetadata"
+)
+
+// Assert UpdateDenomMetadataProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = &UpdateDenomMetadataProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeUpdateDenomMetadata)
+	govtypes.RegisterProposalTypeCodec(&UpdateDenomMetadataProposal{}, "cosmos-sdk/UpdateDenomMetadataProposal")
+}
+
+// NewUpdateDenomMetadataProposal creates a new denom metadata update proposal.
+func NewUpdateDenomMetadataProposal(title, description string, metadata Metadata) *UpdateDenomMetadataProposal {
+	return &UpdateDenomMetadataProposal{title, description, metadata}
+}
+
+// GetTitle returns the title of a denom metadata update proposal.
+func (p *UpdateDenomMetadataProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of a denom metadata update proposal.
+func (p *UpdateDenomMetadataProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of a denom metadata update proposal.
+func (p *UpdateDenomMetadataProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a denom metadata update proposal.
+func (p *UpdateDenomMetadataProposal) ProposalType() string { return ProposalTypeUpdateDenomMetadata }
+
+// ValidateBasic runs basic stateless validity checks
+func (p *UpdateDenomMetadataProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+
+	return p.Metadata.Validate()
+}
+
+// String implements the Stringer interface.
+func (p UpdateDenomMetadataProposal) String() string {
+	return fmt.Sprintf(`Update Denom Metadata Proposal:
+  Title:       %s
+  Description: %s
+  Metadata:    %s
+`, p.Title, p.Description, p.Metadata.String())
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/x/capability/types"
+)
+
+// capabilityMap maps capability indexes to their in-memory capabilities. It is
+// shared by the keeper and all its scoped keepers, and is safe for concurrent
+// use since txs claiming or creating capabilities may be executed in parallel.
+//
+// Unlike the memstore, the map is not reverted along with a failed or aborted
+// tx. A capability is therefore never replaced once mapped, so that every
+// execution creating the capability of an index gets the same pointer, the
+// forward keys in the memstore being derived from the pointer.
+type capabilityMap struct {
+	mtx  sync.RWMutex
+	caps map[uint64]*types.Capability
+}
+
+func newCapabilityMap() *capabilityMap {
+	return &capabilityMap{caps: make(map[uint64]*types.Capability)}
+}
+
+// get returns the capability of an index, or nil if none is mapped.
+func (m *capabilityMap) get(index uint64) *types.Capability {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.caps[index]
+}
+
+// getOrCreate returns the capability of an index, mapping a new one if none is
+// mapped yet.
+func (m *capabilityMap) getOrCreate(index uint64) *types.Capability {
+	if cap := m.get(index); cap != nil {
+		return cap
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	cap, ok := m.caps[index]
+	if !ok {
+		cap = types.NewCapability(index)
+		m.caps[index] = cap
+	}
+	return cap
+}
@@ -30,7 +30,7 @@ type (
 		cdc           codec.BinaryCodec
 		storeKey      sdk.StoreKey
 		memKey        sdk.StoreKey
-		capMap        map[uint64]*types.Capability
+		capMap        *capabilityMap
 		scopedModules map[string]struct{}
 		sealed        bool
 	}
@@ -45,7 +45,7 @@ type (
 		cdc      codec.BinaryCodec
 		storeKey sdk.StoreKey
 		memKey   sdk.StoreKey
-		capMap   map[uint64]*types.Capability
+		capMap   *capabilityMap
 		module   string
 	}
 )
@@ -57,7 +57,7 @@ func NewKeeper(cdc codec.BinaryCodec, storeKey, memKey sdk.StoreKey) *Keeper {
 		cdc:           cdc,
 		storeKey:      storeKey,
 		memKey:        memKey,
-		capMap:        make(map[uint64]*types.Capability),
+		capMap:        newCapabilityMap(),
 		scopedModules: make(map[string]struct{}),
 		sealed:        false,
 	}
@@ -198,7 +198,7 @@ func (k Keeper) InitializeCapability(ctx sdk.Context, index uint64, owners types
 
 	memStore := ctx.KVStore(k.memKey)
 
-	cap := types.NewCapability(index)
+	cap := k.capMap.getOrCreate(index)
 	for _, owner := range owners.Owners {
 		// Set the forward mapping between the module and capability tuple and the
 		// capability name in the memKVStore
@@ -209,9 +209,6 @@ func (k Keeper) InitializeCapability(ctx sdk.Context, index uint64, owners types
 		// will change memory address of capability, we simply store index as value here
 		// and retrieve the in-memory pointer to the capability from our map
 		memStore.Set(types.RevCapabilityKey(owner.Module, owner.Name), sdk.Uint64ToBigEndian(index))
-
-		// Set the mapping from index from index to in-memory capability in the go map
-		k.capMap[index] = cap
 	}
 
 }
@@ -235,9 +232,10 @@ func (sk ScopedKeeper) NewCapability(ctx sdk.Context, name string) (*types.Capab
 		return nil, sdkerrors.Wrapf(types.ErrCapabilityTaken, fmt.Sprintf("module: %s, name: %s", sk.module, name))
 	}
 
-	// create new capability with the current global index
+	// create new capability with the current global index, reusing the in-memory
+	// capability left by a reverted or concurrent execution creating it
 	index := types.IndexFromKey(store.Get(types.KeyIndex))
-	cap := types.NewCapability(index)
+	cap := sk.capMap.getOrCreate(index)
 
 	// update capability owner set
 	if err := sk.addOwner(ctx, cap, name); err != nil {
@@ -259,9 +257,6 @@ func (sk ScopedKeeper) NewCapability(ctx sdk.Context, name string) (*types.Capab
 	// and retrieve the in-memory pointer to the capability from our map
 	memStore.Set(types.RevCapabilityKey(sk.module, name), sdk.Uint64ToBigEndian(index))
 
-	// Set the mapping from index from index to in-memory capability in the go map
-	sk.capMap[index] = cap
-
 	logger(ctx).Info("created new capability", "module", sk.module, "name", name)
 
 	return cap, nil
@@ -346,10 +341,9 @@ func (sk ScopedKeeper) ReleaseCapability(ctx sdk.Context, cap *types.Capability)
 	indexKey := types.IndexToKey(cap.GetIndex())
 
 	if len(capOwners.Owners) == 0 {
-		// remove capability owner set
+		// remove capability owner set. The capability is kept in the map, as the
+		// release may still be reverted.
 		prefixStore.Delete(indexKey)
-		// since no one owns capability, we can delete capability from map
-		delete(sk.capMap, cap.GetIndex())
 	} else {
 		// update capability owner set
 		prefixStore.Set(indexKey, sk.cdc.MustMarshal(capOwners))
@@ -382,7 +376,7 @@ func (sk ScopedKeeper) GetCapability(ctx sdk.Context, name string) (*types.Capab
 		return nil, false
 	}
 
-	cap := sk.capMap[index]
+	cap := sk.capMap.get(index)
 	if cap == nil {
 		panic("capability found in memstore is missing from map")
 	}
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -305,6 +306,60 @@ func (suite KeeperTestSuite) TestRevertCapability() {
 	suite.Require().Equal(cap, got, "did not get correct capability from context")
 }
 
+func (suite *KeeperTestSuite) TestConcurrentCapabilities() {
+	const numModules = 8
+
+	sks := make([]keeper.ScopedKeeper, numModules)
+	for i := range sks {
+		sks[i] = suite.keeper.ScopeToModule(fmt.Sprintf("module%d", i))
+	}
+
+	shared, err := sks[0].NewCapability(suite.ctx, "shared")
+	suite.Require().NoError(err)
+
+	// every module claims the shared capability and creates its own capability,
+	// each on its own branch of the state, as parallel txs would
+	created := make([]*types.Capability, numModules)
+	errs := make([]error, numModules)
+	var wg sync.WaitGroup
+	for i := range sks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := suite.ctx.WithMultiStore(suite.ctx.MultiStore().CacheMultiStore())
+			sk := sks[i]
+
+			if i > 0 {
+				if errs[i] = sk.ClaimCapability(ctx, shared, "shared"); errs[i] != nil {
+					return
+				}
+			}
+			if got, ok := sk.GetCapability(ctx, "shared"); !ok || got != shared {
+				errs[i] = fmt.Errorf("module%d did not get the shared capability", i)
+				return
+			}
+			if !sk.AuthenticateCapability(ctx, shared, "shared") {
+				errs[i] = fmt.Errorf("module%d could not authenticate the shared capability", i)
+				return
+			}
+
+			created[i], errs[i] = sk.NewCapability(ctx, "own")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range sks {
+		suite.Require().NoError(errs[i])
+	}
+
+	// all the branches created a capability with the same index, which maps to
+	// a single in-memory capability
+	for i := range sks {
+		suite.Require().Equal(shared.GetIndex()+1, created[i].GetIndex())
+		suite.Require().Same(created[0], created[i])
+	}
+}
+
 func TestKeeperTestSuite(t *testing.T) {
 	suite.Run(t, new(KeeperTestSuite))
 }
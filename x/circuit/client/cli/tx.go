@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// GetTxCmd returns the transaction commands for the circuit module.
+func GetTxCmd() *cobra.Command {
+	circuitTxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Circuit breaker transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	circuitTxCmd.AddCommand(
+		NewCmdTripCircuitBreaker(),
+		NewCmdResetCircuitBreaker(),
+	)
+
+	return circuitTxCmd
+}
+
+// NewCmdTripCircuitBreaker implements a command disabling the routing of msg
+// types on behalf of a circuit breaker authority.
+func NewCmdTripCircuitBreaker() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "trip [msg-type-url]...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Disable the routing of msg types as a circuit breaker authority",
+		Example: "trip /cosmos.bank.v1beta1.MsgSend --from authority",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTripCircuitBreaker(clientCtx.GetFromAddress(), args)
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewCmdResetCircuitBreaker implements a command enabling the routing of
+// disabled msg types again on behalf of a circuit breaker authority.
+func NewCmdResetCircuitBreaker() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reset [msg-type-url]...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Enable the routing of disabled msg types again as a circuit breaker authority",
+		Example: "reset /cosmos.bank.v1beta1.MsgSend --from authority",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgResetCircuitBreaker(clientCtx.GetFromAddress(), args)
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewCmdSubmitTripCircuitBreakerProposal implements a command handler for
+// submitting a proposal disabling the routing of msg types.
+func NewCmdSubmitTripCircuitBreakerProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trip-circuit-breaker [msg-type-url]... [flags]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Submit a proposal disabling the routing of msg types",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitProposal(cmd, args, types.NewTripCircuitBreakerProposal)
+		},
+	}
+
+	addProposalFlags(cmd)
+
+	return cmd
+}
+
+// NewCmdSubmitResetCircuitBreakerProposal implements a command handler for
+// submitting a proposal enabling the routing of disabled msg types again.
+func NewCmdSubmitResetCircuitBreakerProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reset-circuit-breaker [msg-type-url]... [flags]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Submit a proposal enabling the routing of disabled msg types again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitProposal(cmd, args, types.NewResetCircuitBreakerProposal)
+		},
+	}
+
+	addProposalFlags(cmd)
+
+	return cmd
+}
+
+func addProposalFlags(cmd *cobra.Command) {
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Bool(govcli.FlagIsExpedited, false, "flag indicating whether a proposal is expedited")
+	cmd.MarkFlagRequired(govcli.FlagTitle)
+	cmd.MarkFlagRequired(govcli.FlagDescription)
+}
+
+func submitProposal(cmd *cobra.Command, typeURLs []string, newContent func(title, description string, typeURLs []string) govtypes.Content) error {
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	title, err := cmd.Flags().GetString(govcli.FlagTitle)
+	if err != nil {
+		return err
+	}
+	description, err := cmd.Flags().GetString(govcli.FlagDescription)
+	if err != nil {
+		return err
+	}
+	depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+	if err != nil {
+		return err
+	}
+	deposit, err := sdk.ParseCoinsNormalized(depositStr)
+	if err != nil {
+		return err
+	}
+	isExpedited, err := cmd.Flags().GetBool(govcli.FlagIsExpedited)
+	if err != nil {
+		return err
+	}
+
+	content := newContent(title, description, typeURLs)
+	msg, err := govtypes.NewMsgSubmitProposalWithExpedite(content, deposit, clientCtx.GetFromAddress(), isExpedited)
+	if err != nil {
+		return err
+	}
+
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+}
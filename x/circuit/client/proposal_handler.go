@@ -0,0 +1,10 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/circuit/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/circuit/client/rest"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+)
+
+var TripProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitTripCircuitBreakerProposal, rest.TripProposalRESTHandler)
+var ResetProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitResetCircuitBreakerProposal, rest.ResetProposalRESTHandler)
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// CircuitBreakerProposalReq defines a proposal tripping or resetting the
+// circuit breaker of msg types.
+type CircuitBreakerProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	IsExpedited bool         `json:"is_expedited" yaml:"is_expedited"`
+	Deposit     sdk.Coins    `json:"deposit" yaml:"deposit"`
+	MsgTypeURLs []string     `json:"msg_type_urls" yaml:"msg_type_urls"`
+}
+
+// TripProposalRESTHandler returns a ProposalRESTHandler that exposes the trip
+// circuit breaker REST handler with a given sub-route.
+func TripProposalRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "trip_circuit_breaker",
+		Handler:  postProposalHandlerFn(clientCtx, types.NewTripCircuitBreakerProposal),
+	}
+}
+
+// ResetProposalRESTHandler returns a ProposalRESTHandler that exposes the
+// reset circuit breaker REST handler with a given sub-route.
+func ResetProposalRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "reset_circuit_breaker",
+		Handler:  postProposalHandlerFn(clientCtx, types.NewResetCircuitBreakerProposal),
+	}
+}
+
+func postProposalHandlerFn(clientCtx client.Context, newContent func(title, description string, typeURLs []string) govtypes.Content) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CircuitBreakerProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+
+		content := newContent(req.Title, req.Description, req.MsgTypeURLs)
+		msg, err := govtypes.NewMsgSubmitProposalWithExpedite(content, req.Deposit, fromAddr, req.IsExpedited)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
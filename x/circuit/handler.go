@@ -0,0 +1,29 @@
+package circuit
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/circuit/keeper"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// NewCircuitBreakerProposalHandler creates a governance handler to manage new
+// proposal types. It enables TripCircuitBreakerProposal to disable the routing
+// of msg types, and ResetCircuitBreakerProposal to enable it again.
+func NewCircuitBreakerProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *types.TripCircuitBreakerProposal:
+			k.DisableMsgTypeURLs(ctx, c.MsgTypeURLs)
+			return nil
+
+		case *types.ResetCircuitBreakerProposal:
+			k.EnableMsgTypeURLs(ctx, c.MsgTypeURLs)
+			return nil
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized circuit proposal content type: %T", c)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+// Params returns params of the circuit module.
+func (k Keeper) Params(c context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	params := k.GetParams(ctx)
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+// DisabledMsgTypeURLs returns the msg types whose routing is disabled.
+func (k Keeper) DisabledMsgTypeURLs(c context.Context, _ *types.QueryDisabledMsgTypeURLsRequest) (*types.QueryDisabledMsgTypeURLsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	typeURLs := k.GetDisabledMsgTypeURLs(ctx)
+
+	return &types.QueryDisabledMsgTypeURLsResponse{MsgTypeURLs: typeURLs}, nil
+}
@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Keeper of the circuit store. It implements baseapp.CircuitBreaker.
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	paramSpace paramtypes.Subspace
+}
+
+// NewKeeper creates a new circuit Keeper instance
+func NewKeeper(key sdk.StoreKey, paramSpace paramtypes.Subspace) Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		storeKey:   key,
+		paramSpace: paramSpace,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// IsAllowed returns whether the msgs of type typeURL may be routed to their
+// handler. It is checked for every msg, so it reads the store without
+// consuming gas, which would change the gas used by every tx.
+func (k Keeper) IsAllowed(ctx sdk.Context, typeURL string) bool {
+	store := ctx.MultiStore().GetKVStore(k.storeKey)
+	return !store.Has(types.DisabledMsgTypeURLKey(typeURL))
+}
+
+// DisableMsgTypeURLs disables the routing of the msgs of the given types.
+func (k Keeper) DisableMsgTypeURLs(ctx sdk.Context, typeURLs []string) {
+	store := ctx.KVStore(k.storeKey)
+	for _, typeURL := range typeURLs {
+		store.Set(types.DisabledMsgTypeURLKey(typeURL), []byte{})
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeTripCircuitBreaker,
+			sdk.NewAttribute(types.AttributeKeyMsgTypeURL, typeURL),
+		))
+	}
+}
+
+// EnableMsgTypeURLs enables the routing of the msgs of the given types again.
+func (k Keeper) EnableMsgTypeURLs(ctx sdk.Context, typeURLs []string) {
+	store := ctx.KVStore(k.storeKey)
+	for _, typeURL := range typeURLs {
+		store.Delete(types.DisabledMsgTypeURLKey(typeURL))
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeResetCircuitBreaker,
+			sdk.NewAttribute(types.AttributeKeyMsgTypeURL, typeURL),
+		))
+	}
+}
+
+// GetDisabledMsgTypeURLs returns the msg types whose routing is disabled,
+// sorted.
+func (k Keeper) GetDisabledMsgTypeURLs(ctx sdk.Context) []string {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.DisabledMsgTypeURLKeyPrefix)
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	var typeURLs []string
+	for ; iterator.Valid(); iterator.Next() {
+		typeURLs = append(typeURLs, string(iterator.Key()))
+	}
+	return typeURLs
+}
+
+// GetParams returns the total set of circuit parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the total set of circuit parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
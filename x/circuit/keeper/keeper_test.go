@@ -0,0 +1,101 @@
+package keeper_test
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/circuit"
+	"github.com/cosmos/cosmos-sdk/x/circuit/keeper"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+)
+
+var msgSendTypeURL = sdk.MsgTypeURL(&banktypes.MsgSend{})
+
+type CircuitTestSuite struct {
+	suite.Suite
+
+	app         *simapp.SimApp
+	ctx         sdk.Context
+	addrs       []sdk.AccAddress
+	queryClient types.QueryClient
+}
+
+func (suite *CircuitTestSuite) SetupTest() {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 1})
+	addrs := simapp.AddTestAddrs(app, ctx, 2, sdk.NewInt(1000))
+	app.CircuitKeeper.SetParams(ctx, types.NewParams([]string{addrs[0].String()}))
+
+	queryHelper := baseapp.NewQueryServerTestHelper(ctx, app.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, app.CircuitKeeper)
+
+	suite.app = app
+	suite.ctx = ctx
+	suite.addrs = addrs
+	suite.queryClient = types.NewQueryClient(queryHelper)
+}
+
+func (suite *CircuitTestSuite) send() error {
+	msg := banktypes.NewMsgSend(suite.addrs[0], suite.addrs[1], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	_, err := suite.app.MsgServiceRouter().Handler(msg)(suite.ctx, msg)
+	return err
+}
+
+func (suite *CircuitTestSuite) TestMsgServer() {
+	app, ctx, addrs := suite.app, suite.ctx, suite.addrs
+	msgServer := keeper.NewMsgServerImpl(app.CircuitKeeper)
+	goCtx := sdk.WrapSDKContext(ctx)
+
+	_, err := msgServer.TripCircuitBreaker(goCtx, types.NewMsgTripCircuitBreaker(addrs[1], []string{msgSendTypeURL}))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	suite.Require().NoError(suite.send())
+
+	_, err = msgServer.TripCircuitBreaker(goCtx, types.NewMsgTripCircuitBreaker(addrs[0], []string{msgSendTypeURL}))
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(suite.send(), sdkerrors.ErrUnauthorized)
+
+	res, err := suite.queryClient.DisabledMsgTypeURLs(gocontext.Background(), &types.QueryDisabledMsgTypeURLsRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{msgSendTypeURL}, res.MsgTypeURLs)
+
+	_, err = msgServer.ResetCircuitBreaker(goCtx, types.NewMsgResetCircuitBreaker(addrs[0], []string{msgSendTypeURL}))
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.send())
+	suite.Require().Empty(app.CircuitKeeper.GetDisabledMsgTypeURLs(ctx))
+}
+
+func (suite *CircuitTestSuite) TestProposalHandler() {
+	app, ctx := suite.app, suite.ctx
+	handler := circuit.NewCircuitBreakerProposalHandler(app.CircuitKeeper)
+
+	suite.Require().NoError(handler(ctx, types.NewTripCircuitBreakerProposal("title", "description", []string{msgSendTypeURL})))
+	suite.Require().ErrorIs(suite.send(), sdkerrors.ErrUnauthorized)
+
+	// the msgs nested in an authz exec are disabled as well
+	exec := authztypes.NewMsgExec(suite.addrs[0], []sdk.Msg{banktypes.NewMsgSend(suite.addrs[0], suite.addrs[1], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))})
+	_, err := app.MsgServiceRouter().Handler(&exec)(ctx, &exec)
+	suite.Require().ErrorContains(err, "disabled by the circuit breaker")
+
+	suite.Require().NoError(handler(ctx, types.NewResetCircuitBreakerProposal("title", "description", []string{msgSendTypeURL})))
+	suite.Require().NoError(suite.send())
+}
+
+func (suite *CircuitTestSuite) TestIsAllowedConsumesNoGas() {
+	app, ctx := suite.app, suite.ctx
+	gasMeter := sdk.NewGasMeter(1000000)
+	suite.Require().True(app.CircuitKeeper.IsAllowed(ctx.WithGasMeter(gasMeter), msgSendTypeURL))
+	suite.Require().Zero(gasMeter.GasConsumed())
+}
+
+func TestCircuitTestSuite(t *testing.T) {
+	suite.Run(t, new(CircuitTestSuite))
+}
@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/circuit/types"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+// NewMsgServerImpl returns an implementation of the circuit MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(keeper Keeper) types.MsgServer {
+	return &msgServer{Keeper: keeper}
+}
+
+var _ types.MsgServer = msgServer{}
+
+// TripCircuitBreaker disables the routing of msg types on behalf of an
+// authority.
+func (k msgServer) TripCircuitBreaker(goCtx context.Context, msg *types.MsgTripCircuitBreaker) (*types.MsgTripCircuitBreakerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.checkAuthority(ctx, msg.Authority); err != nil {
+		return nil, err
+	}
+
+	k.DisableMsgTypeURLs(ctx, msg.MsgTypeURLs)
+	return &types.MsgTripCircuitBreakerResponse{}, nil
+}
+
+// ResetCircuitBreaker enables the routing of msg types again on behalf of an
+// authority.
+func (k msgServer) ResetCircuitBreaker(goCtx context.Context, msg *types.MsgResetCircuitBreaker) (*types.MsgResetCircuitBreakerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.checkAuthority(ctx, msg.Authority); err != nil {
+		return nil, err
+	}
+
+	k.EnableMsgTypeURLs(ctx, msg.MsgTypeURLs)
+	return &types.MsgResetCircuitBreakerResponse{}, nil
+}
+
+func (k msgServer) checkAuthority(ctx sdk.Context, authority string) error {
+	if !k.GetParams(ctx).IsAuthority(authority) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a circuit breaker authority", authority)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package types
+
+// circuit module event types
+const (
+	EventTypeTripCircuitBreaker  = "trip_circuit_breaker"
+	EventTypeResetCircuitBreaker = "reset_circuit_breaker"
+
+	AttributeKeyMsgTypeURL = "msg_type_url"
+)
@@ -0,0 +1,28 @@
+package types
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(params Params, disabledTypeURLs []string) *GenesisState {
+	return &GenesisState{
+		Params:              params,
+		DisabledMsgTypeURLs: disabledTypeURLs,
+	}
+}
+
+// DefaultGenesisState creates a default GenesisState object
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// ValidateGenesis validates the provided genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	if len(data.DisabledMsgTypeURLs) == 0 {
+		return nil
+	}
+	return ValidateMsgTypeURLs(data.DisabledMsgTypeURLs)
+}
@@ -0,0 +1,22 @@
+package types
+
+const (
+	// ModuleName is the name of the circuit module
+	ModuleName = "circuit"
+
+	// StoreKey is the default store key for circuit
+	StoreKey = ModuleName
+
+	// RouterKey is used to route governance proposals
+	RouterKey = ModuleName
+)
+
+// DisabledMsgTypeURLKeyPrefix is the prefix of the keys of the msg types whose
+// routing is disabled.
+var DisabledMsgTypeURLKeyPrefix = []byte{0x01}
+
+// DisabledMsgTypeURLKey returns the key marking the routing of the msg type
+// typeURL as disabled.
+func DisabledMsgTypeURLKey(typeURL string) []byte {
+	return append(append([]byte{}, DisabledMsgTypeURLKeyPrefix...), typeURL...)
+}
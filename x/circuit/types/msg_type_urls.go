@@ -0,0 +1,35 @@
+package types
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// circuitMsgTypeURLPrefix is the prefix of the type URLs of the msgs of the
+// circuit module, which cannot be disabled so that the breakers can always be
+// reset.
+const circuitMsgTypeURLPrefix = "/cosmos.circuit."
+
+// ValidateMsgTypeURLs validates the msg types of a circuit breaker, which must
+// be distinct type URLs of msgs of other modules.
+func ValidateMsgTypeURLs(typeURLs []string) error {
+	if len(typeURLs) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no msg type urls")
+	}
+
+	seen := make(map[string]bool, len(typeURLs))
+	for _, typeURL := range typeURLs {
+		if !strings.HasPrefix(typeURL, "/") || len(typeURL) == 1 {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid msg type url %q", typeURL)
+		}
+		if strings.HasPrefix(typeURL, circuitMsgTypeURLPrefix) {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "cannot disable the circuit msg type %s", typeURL)
+		}
+		if seen[typeURL] {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "duplicate msg type url %s", typeURL)
+		}
+		seen[typeURL] = true
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+)
+
+// circuit message types
+const (
+	TypeMsgTripCircuitBreaker  = "trip_circuit_breaker"
+	TypeMsgResetCircuitBreaker = "reset_circuit_breaker"
+)
+
+var (
+	_, _ sdk.Msg            = &MsgTripCircuitBreaker{}, &MsgResetCircuitBreaker{}
+	_, _ legacytx.LegacyMsg = &MsgTripCircuitBreaker{}, &MsgResetCircuitBreaker{}
+)
+
+// NewMsgTripCircuitBreaker creates a new MsgTripCircuitBreaker.
+//
+//nolint:interfacer
+func NewMsgTripCircuitBreaker(authority sdk.AccAddress, typeURLs []string) *MsgTripCircuitBreaker {
+	return &MsgTripCircuitBreaker{Authority: authority.String(), MsgTypeURLs: typeURLs}
+}
+
+// Route implements the LegacyMsg interface.
+func (msg MsgTripCircuitBreaker) Route() string { return RouterKey }
+
+// Type implements the LegacyMsg interface.
+func (msg MsgTripCircuitBreaker) Type() string { return TypeMsgTripCircuitBreaker }
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgTripCircuitBreaker) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+	return ValidateMsgTypeURLs(msg.MsgTypeURLs)
+}
+
+// GetSignBytes implements the LegacyMsg interface.
+func (msg MsgTripCircuitBreaker) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgTripCircuitBreaker) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+// NewMsgResetCircuitBreaker creates a new MsgResetCircuitBreaker.
+//
+//nolint:interfacer
+func NewMsgResetCircuitBreaker(authority sdk.AccAddress, typeURLs []string) *MsgResetCircuitBreaker {
+	return &MsgResetCircuitBreaker{Authority: authority.String(), MsgTypeURLs: typeURLs}
+}
+
+// Route implements the LegacyMsg interface.
+func (msg MsgResetCircuitBreaker) Route() string { return RouterKey }
+
+// Type implements the LegacyMsg interface.
+func (msg MsgResetCircuitBreaker) Type() string { return TypeMsgResetCircuitBreaker }
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgResetCircuitBreaker) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+	return ValidateMsgTypeURLs(msg.MsgTypeURLs)
+}
+
+// GetSignBytes implements the LegacyMsg interface.
+func (msg MsgResetCircuitBreaker) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgResetCircuitBreaker) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{authority}
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Parameter store keys
+var (
+	KeyAuthorities = []byte("Authorities")
+)
+
+// ParamKeyTable for circuit module.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func NewParams(authorities []string) Params {
+	return Params{
+		Authorities: authorities,
+	}
+}
+
+// default circuit module parameters, with which the circuit breakers are only
+// tripped by governance
+func DefaultParams() Params {
+	return Params{}
+}
+
+// validate params
+func (p Params) Validate() error {
+	return validateAuthorities(p.Authorities)
+}
+
+// IsAuthority returns whether address may trip and reset circuit breakers
+// without a governance proposal.
+func (p Params) IsAuthority(address string) bool {
+	for _, authority := range p.Authorities {
+		if authority == address {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the Stringer interface.
+func (p Params) String() string {
+	out, _ := yaml.Marshal(p)
+	return string(out)
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyAuthorities, &p.Authorities, validateAuthorities),
+	}
+}
+
+func validateAuthorities(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(v))
+	for _, authority := range v {
+		if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+			return fmt.Errorf("invalid authority %s: %w", authority, err)
+		}
+		if seen[authority] {
+			return fmt.Errorf("duplicate authority %s", authority)
+		}
+		seen[authority] = true
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	ProposalTypeTripCircuitBreaker  string = "TripCircuitBreaker"
+	ProposalTypeResetCircuitBreaker string = "ResetCircuitBreaker"
+)
+
+// Implements Proposal Interface
+var (
+	_ govtypes.Content = &TripCircuitBreakerProposal{}
+	_ govtypes.Content = &ResetCircuitBreakerProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeTripCircuitBreaker)
+	govtypes.RegisterProposalTypeCodec(&TripCircuitBreakerProposal{}, "cosmos-sdk/TripCircuitBreakerProposal")
+	govtypes.RegisterProposalType(ProposalTypeResetCircuitBreaker)
+	govtypes.RegisterProposalTypeCodec(&ResetCircuitBreakerProposal{}, "cosmos-sdk/ResetCircuitBreakerProposal")
+}
+
+func NewTripCircuitBreakerProposal(title, description string, typeURLs []string) govtypes.Content {
+	return &TripCircuitBreakerProposal{title, description, typeURLs}
+}
+
+func (p *TripCircuitBreakerProposal) GetTitle() string       { return p.Title }
+func (p *TripCircuitBreakerProposal) GetDescription() string { return p.Description }
+func (p *TripCircuitBreakerProposal) ProposalRoute() string  { return RouterKey }
+func (p *TripCircuitBreakerProposal) ProposalType() string   { return ProposalTypeTripCircuitBreaker }
+func (p *TripCircuitBreakerProposal) ValidateBasic() error {
+	if err := ValidateMsgTypeURLs(p.MsgTypeURLs); err != nil {
+		return err
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p TripCircuitBreakerProposal) String() string {
+	return fmt.Sprintf(`Trip Circuit Breaker Proposal:
+  Title:         %s
+  Description:   %s
+  Msg Type URLs: %s
+`, p.Title, p.Description, strings.Join(p.MsgTypeURLs, ", "))
+}
+
+func NewResetCircuitBreakerProposal(title, description string, typeURLs []string) govtypes.Content {
+	return &ResetCircuitBreakerProposal{title, description, typeURLs}
+}
+
+func (p *ResetCircuitBreakerProposal) GetTitle() string       { return p.Title }
+func (p *ResetCircuitBreakerProposal) GetDescription() string { return p.Description }
+func (p *ResetCircuitBreakerProposal) ProposalRoute() string  { return RouterKey }
+func (p *ResetCircuitBreakerProposal) ProposalType() string   { return ProposalTypeResetCircuitBreaker }
+func (p *ResetCircuitBreakerProposal) ValidateBasic() error {
+	if err := ValidateMsgTypeURLs(p.MsgTypeURLs); err != nil {
+		return err
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p ResetCircuitBreakerProposal) String() string {
+	return fmt.Sprintf(`Reset Circuit Breaker Proposal:
+  Title:         %s
+  Description:   %s
+  Msg Type URLs: %s
+`, p.Title, p.Description, strings.Join(p.MsgTypeURLs, ", "))
+}
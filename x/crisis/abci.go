@@ -13,9 +13,16 @@ import (
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
 	defer telemetry.ModuleMeasureSince(types.ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
 
+	if k.AsyncInvariantChecks() {
+		k.ReportBrokenInvariants(ctx)
+	}
 	if k.InvCheckPeriod() == 0 || ctx.BlockHeight()%int64(k.InvCheckPeriod()) != 0 {
 		// skip running the invariant check
 		return
 	}
+	if k.AsyncInvariantChecks() {
+		k.CheckInvariantsAsync(ctx)
+		return
+	}
 	k.AssertInvariants(ctx)
 }
@@ -0,0 +1,145 @@
+package keeper
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis/types"
+)
+
+// MultiStoreLoader branches the committed state at the given height. The
+// branch is only read by the invariants, it is never written back.
+type MultiStoreLoader func(height int64) (sdk.CacheMultiStore, error)
+
+// BrokenInvariant is an invariant found broken by an asynchronous check.
+type BrokenInvariant struct {
+	Route  types.InvarRoute
+	Height int64
+	Msg    string
+}
+
+// asyncInvariantChecker runs the invariants on a background goroutine, one
+// check at a time, and keeps the broken invariants until they are reported.
+type asyncInvariantChecker struct {
+	loadMultiStore MultiStoreLoader
+
+	mtx     sync.Mutex
+	running bool
+	broken  []BrokenInvariant
+	wg      sync.WaitGroup
+}
+
+// SetAsyncInvariantChecks makes the EndBlocker check the invariants against the
+// state loaded by loader on a background goroutine instead of asserting them
+// inline. Broken invariants are then reported by events, logs and telemetry,
+// they do not halt the chain.
+func (k *Keeper) SetAsyncInvariantChecks(loader MultiStoreLoader) {
+	if k.async != nil {
+		panic("cannot set asynchronous invariant checks twice")
+	}
+	k.async = &asyncInvariantChecker{loadMultiStore: loader}
+}
+
+// AsyncInvariantChecks returns whether the invariants are checked asynchronously.
+func (k Keeper) AsyncInvariantChecks() bool { return k.async != nil }
+
+// CheckInvariantsAsync starts checking all registered invariants against the
+// state committed by the previous block. It returns false without starting a
+// check if the previous check is still running or the state cannot be loaded.
+func (k Keeper) CheckInvariantsAsync(ctx sdk.Context) bool {
+	logger := k.Logger(ctx)
+	height := ctx.BlockHeight() - 1
+
+	k.async.mtx.Lock()
+	defer k.async.mtx.Unlock()
+	if k.async.running {
+		logger.Info("skipping invariant check, the previous check is still running", "height", height)
+		telemetry.IncrCounter(1, types.ModuleName, "invariant_checks", "skipped")
+		return false
+	}
+
+	cms, err := k.async.loadMultiStore(height)
+	if err != nil {
+		logger.Error("failed to load the state for the invariant check", "height", height, "err", err)
+		return false
+	}
+	checkCtx := ctx.WithMultiStore(cms).
+		WithBlockHeight(height).
+		WithGasMeter(sdk.NewInfiniteGasMeter()).
+		WithEventManager(sdk.NewEventManager())
+
+	k.async.running = true
+	k.async.wg.Add(1)
+	go func() {
+		defer k.async.wg.Done()
+		broken := k.checkInvariants(checkCtx)
+
+		k.async.mtx.Lock()
+		defer k.async.mtx.Unlock()
+		k.async.broken = append(k.async.broken, broken...)
+		k.async.running = false
+	}()
+	return true
+}
+
+// checkInvariants checks all registered invariants, a panicking invariant is
+// reported as broken.
+func (k Keeper) checkInvariants(ctx sdk.Context) []BrokenInvariant {
+	logger := k.Logger(ctx)
+
+	start := time.Now()
+	var broken []BrokenInvariant
+	for _, ir := range k.Routes() {
+		msg, stop := func() (msg string, stop bool) {
+			defer func() {
+				if r := recover(); r != nil {
+					msg, stop = fmt.Sprintf("invariant panicked: %v", r), true
+				}
+			}()
+			return ir.Invar(ctx)
+		}()
+		if !stop {
+			continue
+		}
+
+		logger.Error("invariant broken", "name", ir.FullRoute(), "height", ctx.BlockHeight(), "msg", msg)
+		telemetry.IncrCounter(1, types.ModuleName, "invariants", "broken")
+		broken = append(broken, BrokenInvariant{Route: ir, Height: ctx.BlockHeight(), Msg: msg})
+	}
+
+	logger.Info("checked all invariants", "duration", time.Since(start), "height", ctx.BlockHeight(), "broken", len(broken))
+	telemetry.MeasureSince(start, types.ModuleName, "invariant_checks", "duration")
+	return broken
+}
+
+// ReportBrokenInvariants emits an event for each invariant found broken by the
+// asynchronous checks completed since the last report. As the checks finish at
+// no particular block, the events are not deterministic across nodes.
+func (k Keeper) ReportBrokenInvariants(ctx sdk.Context) {
+	k.async.mtx.Lock()
+	broken := k.async.broken
+	k.async.broken = nil
+	k.async.mtx.Unlock()
+
+	for _, b := range broken {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeInvariantBroken,
+				sdk.NewAttribute(types.AttributeKeyRoute, b.Route.FullRoute()),
+				sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprint(b.Height)),
+				sdk.NewAttribute(types.AttributeKeyMessage, b.Msg),
+			),
+		)
+	}
+}
+
+// WaitForInvariantChecks blocks until the running asynchronous check, if any,
+// has finished.
+func (k Keeper) WaitForInvariantChecks() {
+	if k.async != nil {
+		k.async.wg.Wait()
+	}
+}
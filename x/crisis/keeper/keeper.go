@@ -16,6 +16,7 @@ type Keeper struct {
 	routes         []types.InvarRoute
 	paramSpace     paramtypes.Subspace
 	invCheckPeriod uint
+	async          *asyncInvariantChecker
 
 	supplyKeeper types.SupplyKeeper
 
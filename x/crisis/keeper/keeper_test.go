@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -46,3 +47,48 @@ func TestAssertInvariants(t *testing.T) {
 	app.CrisisKeeper.RegisterRoute("testModule", "testRoute2", func(sdk.Context) (string, bool) { return "", true })
 	require.Panics(t, func() { app.CrisisKeeper.AssertInvariants(ctx) })
 }
+
+func TestCheckInvariantsAsync(t *testing.T) {
+	app := simapp.Setup(false)
+	app.Commit(context.Background())
+
+	app.CrisisKeeper.SetAsyncInvariantChecks(app.CommitMultiStore().CacheMultiStoreWithVersion)
+	require.True(t, app.CrisisKeeper.AsyncInvariantChecks())
+	require.Panics(t, func() {
+		app.CrisisKeeper.SetAsyncInvariantChecks(app.CommitMultiStore().CacheMultiStoreWithVersion)
+	})
+
+	release := make(chan struct{})
+	app.CrisisKeeper.RegisterRoute("testModule", "testRoute1", func(sdk.Context) (string, bool) {
+		<-release
+		return "", false
+	})
+	app.CrisisKeeper.RegisterRoute("testModule", "testRoute2", func(sdk.Context) (string, bool) { return "broken", true })
+	app.CrisisKeeper.RegisterRoute("testModule", "testRoute3", func(sdk.Context) (string, bool) { panic("panicked") })
+
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight() + 1})
+	require.True(t, app.CrisisKeeper.CheckInvariantsAsync(ctx))
+	// only one check runs at a time
+	require.False(t, app.CrisisKeeper.CheckInvariantsAsync(ctx))
+
+	close(release)
+	app.CrisisKeeper.WaitForInvariantChecks()
+
+	app.CrisisKeeper.ReportBrokenInvariants(ctx)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	for i, route := range []string{"testModule/testRoute2", "testModule/testRoute3"} {
+		require.Equal(t, types.EventTypeInvariantBroken, events[i].Type)
+		require.Equal(t, route, string(events[i].Attributes[0].Value))
+		require.Equal(t, fmt.Sprint(app.LastBlockHeight()), string(events[i].Attributes[1].Value))
+	}
+
+	// the broken invariants are reported once
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	app.CrisisKeeper.ReportBrokenInvariants(ctx)
+	require.Empty(t, ctx.EventManager().Events())
+
+	// the next check can start once the previous one is done
+	require.True(t, app.CrisisKeeper.CheckInvariantsAsync(ctx))
+	app.CrisisKeeper.WaitForInvariantChecks()
+}
@@ -29,6 +29,7 @@ var (
 // Module init related flags
 const (
 	FlagSkipGenesisInvariants = "x-crisis-skip-assert-invariants"
+	FlagAsyncInvariants       = "x-crisis-async-invariants"
 )
 
 // AppModuleBasic defines the basic application module used by the crisis module.
@@ -108,6 +109,7 @@ func NewAppModule(keeper *keeper.Keeper, skipGenesisInvariants bool) AppModule {
 // AddModuleInitFlags implements servertypes.ModuleInitFlags interface.
 func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Bool(FlagSkipGenesisInvariants, false, "Skip x/crisis invariants check on startup")
+	startCmd.Flags().Bool(FlagAsyncInvariants, false, "Check the x/crisis invariants on a background goroutine, reporting broken invariants instead of halting")
 }
 
 // Name returns the crisis module's name.
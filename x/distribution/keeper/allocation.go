@@ -26,6 +26,11 @@ func (k Keeper) AllocateTokens(
 	feesCollectedInt := k.bankKeeper.GetAllBalances(ctx, feeCollector.GetAddress())
 	feesCollected := sdk.NewDecCoinsFromCoins(feesCollectedInt...)
 
+	// nothing to distribute, skip the writes to the fee pool and to every bonded validator
+	if feesCollectedInt.IsZero() {
+		return
+	}
+
 	// transfer collected fees to the distribution module account
 	err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, k.feeCollectorName, types.ModuleName, feesCollectedInt)
 	if err != nil {
@@ -50,6 +55,10 @@ func (k Keeper) AllocateTokens(
 	proposerMultiplier := baseProposerReward.Add(bonusProposerReward.MulTruncate(previousFractionVotes))
 	proposerReward := feesCollected.MulDecTruncate(proposerMultiplier)
 
+	// the allocations of the block are accumulated per validator and written once at the end,
+	// the previous proposer being allocated both its proposer reward and its voting power share
+	allocations := newValidatorAllocations()
+
 	// pay previous proposer
 	remaining := feesCollected
 	proposerValidator := k.stakingKeeper.ValidatorByConsAddr(ctx, previousProposer)
@@ -63,7 +72,7 @@ func (k Keeper) AllocateTokens(
 			),
 		)
 
-		k.AllocateTokensToValidator(ctx, proposerValidator, proposerReward)
+		allocations.add(ctx, proposerValidator, proposerReward)
 		remaining = remaining.Sub(proposerReward)
 	} else {
 		// previous proposer can be unknown if say, the unbonding period is 1 block, so
@@ -97,10 +106,12 @@ func (k Keeper) AllocateTokens(
 		powerFraction := sdk.NewDec(vote.Validator.Power).QuoTruncate(sdk.NewDec(totalPreviousPower))
 		reward := feeMultiplier.MulDecTruncate(powerFraction)
 
-		k.AllocateTokensToValidator(ctx, validator, reward)
+		allocations.add(ctx, validator, reward)
 		remaining = remaining.Sub(reward)
 	}
 
+	k.writeValidatorAllocations(ctx, allocations)
+
 	// allocate community funding
 	feePool.CommunityPool = feePool.CommunityPool.Add(remaining...)
 	k.SetFeePool(ctx, feePool)
@@ -109,11 +120,37 @@ func (k Keeper) AllocateTokens(
 // AllocateTokensToValidator allocate tokens to a particular validator,
 // splitting according to commission.
 func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val stakingtypes.ValidatorI, tokens sdk.DecCoins) {
-	// split tokens between validator and delegators according to commission
+	allocations := newValidatorAllocations()
+	allocations.add(ctx, val, tokens)
+	k.writeValidatorAllocations(ctx, allocations)
+}
+
+// validatorAllocation is the sum of the tokens allocated to a validator, split
+// according to its commission.
+type validatorAllocation struct {
+	validator  stakingtypes.ValidatorI
+	commission sdk.DecCoins
+	shared     sdk.DecCoins
+	tokens     sdk.DecCoins
+}
+
+// validatorAllocations accumulates the allocations of several validators,
+// keeping the order in which the validators were first allocated tokens.
+type validatorAllocations struct {
+	operators   []string
+	allocations map[string]*validatorAllocation
+}
+
+func newValidatorAllocations() *validatorAllocations {
+	return &validatorAllocations{allocations: make(map[string]*validatorAllocation)}
+}
+
+// add splits tokens between the validator and its delegators according to
+// commission and adds them to the validator allocation.
+func (a *validatorAllocations) add(ctx sdk.Context, val stakingtypes.ValidatorI, tokens sdk.DecCoins) {
 	commission := tokens.MulDec(val.GetCommission())
 	shared := tokens.Sub(commission)
 
-	// update current commission
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeCommission,
@@ -121,17 +158,6 @@ func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val stakingtypes.Vali
 			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
 		),
 	)
-	currentCommission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
-	currentCommission.Commission = currentCommission.Commission.Add(commission...)
-	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), currentCommission)
-
-	// update current rewards
-	currentRewards := k.GetValidatorCurrentRewards(ctx, val.GetOperator())
-	currentRewards.Rewards = currentRewards.Rewards.Add(shared...)
-
-	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), currentRewards)
-
-	// update outstanding rewards
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeRewards,
@@ -140,7 +166,39 @@ func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val stakingtypes.Vali
 		),
 	)
 
-	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
-	outstanding.Rewards = outstanding.Rewards.Add(tokens...)
-	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+	operator := val.GetOperator().String()
+	allocation, ok := a.allocations[operator]
+	if !ok {
+		allocation = &validatorAllocation{validator: val}
+		a.allocations[operator] = allocation
+		a.operators = append(a.operators, operator)
+	}
+	allocation.commission = allocation.commission.Add(commission...)
+	allocation.shared = allocation.shared.Add(shared...)
+	allocation.tokens = allocation.tokens.Add(tokens...)
+}
+
+// writeValidatorAllocations adds the accumulated allocations to the commission,
+// current rewards and outstanding rewards of each validator, writing each of
+// these records once per validator.
+func (k Keeper) writeValidatorAllocations(ctx sdk.Context, allocations *validatorAllocations) {
+	for _, operator := range allocations.operators {
+		allocation := allocations.allocations[operator]
+		valAddr := allocation.validator.GetOperator()
+
+		// update current commission
+		currentCommission := k.GetValidatorAccumulatedCommission(ctx, valAddr)
+		currentCommission.Commission = currentCommission.Commission.Add(allocation.commission...)
+		k.SetValidatorAccumulatedCommission(ctx, valAddr, currentCommission)
+
+		// update current rewards
+		currentRewards := k.GetValidatorCurrentRewards(ctx, valAddr)
+		currentRewards.Rewards = currentRewards.Rewards.Add(allocation.shared...)
+		k.SetValidatorCurrentRewards(ctx, valAddr, currentRewards)
+
+		// update outstanding rewards
+		outstanding := k.GetValidatorOutstandingRewards(ctx, valAddr)
+		outstanding.Rewards = outstanding.Rewards.Add(allocation.tokens...)
+		k.SetValidatorOutstandingRewards(ctx, valAddr, outstanding)
+	}
 }
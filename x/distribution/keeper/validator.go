@@ -34,13 +34,16 @@ func (k Keeper) IncrementValidatorPeriod(ctx sdk.Context, val stakingtypes.Valid
 	if val.GetTokens().IsZero() {
 
 		// can't calculate ratio for zero-token validators
-		// ergo we instead add to the community pool
-		feePool := k.GetFeePool(ctx)
-		outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
-		feePool.CommunityPool = feePool.CommunityPool.Add(rewards.Rewards...)
-		outstanding.Rewards = outstanding.GetRewards().Sub(rewards.Rewards)
-		k.SetFeePool(ctx, feePool)
-		k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+		// ergo we instead add to the community pool, leaving
+		// the fee pool untouched when there is nothing to add
+		if !rewards.Rewards.IsZero() {
+			feePool := k.GetFeePool(ctx)
+			outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+			feePool.CommunityPool = feePool.CommunityPool.Add(rewards.Rewards...)
+			outstanding.Rewards = outstanding.GetRewards().Sub(rewards.Rewards)
+			k.SetFeePool(ctx, feePool)
+			k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+		}
 
 		current = sdk.DecCoins{}
 	} else {
@@ -0,0 +1,61 @@
+package epochs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/epochs/keeper"
+	"github.com/cosmos/cosmos-sdk/x/epochs/types"
+)
+
+// BeginBlocker starts the epochs whose start time, or whose running epoch end
+// time, is reached, calling the epoch hooks. An epoch advances at most once
+// per block, so epochs missed during a halt are caught up one block at a time.
+func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
+	defer telemetry.ModuleMeasureSince(types.ModuleName, time.Now(), telemetry.MetricKeyBeginBlocker)
+
+	blockTime := ctx.BlockTime()
+	for _, epoch := range k.AllEpochInfos(ctx) {
+		if !epoch.EpochCountingStarted {
+			if blockTime.Before(epoch.StartTime) {
+				continue
+			}
+
+			epoch.EpochCountingStarted = true
+			epoch.CurrentEpoch = 1
+			epoch.CurrentEpochStartTime = epoch.StartTime
+		} else {
+			if blockTime.Before(epoch.EndTime()) {
+				continue
+			}
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeEpochEnd,
+					sdk.NewAttribute(types.AttributeKeyIdentifier, epoch.Identifier),
+					sdk.NewAttribute(types.AttributeKeyEpochNumber, fmt.Sprint(epoch.CurrentEpoch)),
+				),
+			)
+			k.AfterEpochEnd(ctx, epoch.Identifier, epoch.CurrentEpoch)
+
+			epoch.CurrentEpoch++
+			epoch.CurrentEpochStartTime = epoch.EndTime()
+		}
+
+		epoch.CurrentEpochStartHeight = ctx.BlockHeight()
+		k.SetEpochInfo(ctx, epoch)
+
+		k.Logger(ctx).Info("starting epoch", "identifier", epoch.Identifier, "number", epoch.CurrentEpoch)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeEpochStart,
+				sdk.NewAttribute(types.AttributeKeyIdentifier, epoch.Identifier),
+				sdk.NewAttribute(types.AttributeKeyEpochNumber, fmt.Sprint(epoch.CurrentEpoch)),
+				sdk.NewAttribute(types.AttributeKeyEpochStartTime, epoch.CurrentEpochStartTime.Format(time.RFC3339Nano)),
+			),
+		)
+		k.BeforeEpochStart(ctx, epoch.Identifier, epoch.CurrentEpoch)
+	}
+}
@@ -0,0 +1,108 @@
+package epochs_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/epochs"
+	"github.com/cosmos/cosmos-sdk/x/epochs/types"
+)
+
+// hookRecorder records the epoch hook calls of an identifier.
+type hookRecorder struct {
+	identifier string
+	calls      []string
+}
+
+func (h *hookRecorder) AfterEpochEnd(_ sdk.Context, identifier string, epochNumber int64) {
+	if identifier == h.identifier {
+		h.calls = append(h.calls, fmt.Sprintf("end %d", epochNumber))
+	}
+}
+
+func (h *hookRecorder) BeforeEpochStart(_ sdk.Context, identifier string, epochNumber int64) {
+	if identifier == h.identifier {
+		h.calls = append(h.calls, fmt.Sprintf("start %d", epochNumber))
+	}
+}
+
+func TestBeginBlockerStartsEpochs(t *testing.T) {
+	app := simapp.Setup(false)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 1, Time: now})
+
+	recorder := &hookRecorder{identifier: "hour"}
+	k := app.EpochsKeeper
+	k.SetHooks(types.NewMultiEpochHooks(recorder))
+	require.NoError(t, k.AddEpochInfo(ctx, types.NewEpochInfo("hour", now.Add(time.Minute), time.Hour)))
+
+	beginBlock := func(height int64, blockTime time.Time) types.EpochInfo {
+		ctx = ctx.WithBlockHeight(height).WithBlockTime(blockTime)
+		epochs.BeginBlocker(ctx, k)
+		epoch, found := k.GetEpochInfo(ctx, "hour")
+		require.True(t, found)
+		return epoch
+	}
+
+	// nothing happens before the start time
+	epoch := beginBlock(1, now)
+	require.False(t, epoch.EpochCountingStarted)
+	require.Empty(t, recorder.calls)
+
+	// the first epoch starts at the start time
+	epoch = beginBlock(2, now.Add(time.Minute))
+	require.True(t, epoch.EpochCountingStarted)
+	require.Equal(t, int64(1), epoch.CurrentEpoch)
+	require.Equal(t, int64(2), epoch.CurrentEpochStartHeight)
+	require.Equal(t, now.Add(time.Minute), epoch.CurrentEpochStartTime)
+	require.Equal(t, []string{"start 1"}, recorder.calls)
+
+	// and lasts its duration
+	epoch = beginBlock(3, now.Add(time.Hour))
+	require.Equal(t, int64(1), epoch.CurrentEpoch)
+	require.Equal(t, []string{"start 1"}, recorder.calls)
+
+	// missed epochs are caught up one per block
+	epoch = beginBlock(4, now.Add(3*time.Hour))
+	require.Equal(t, int64(2), epoch.CurrentEpoch)
+	require.Equal(t, int64(4), epoch.CurrentEpochStartHeight)
+	require.Equal(t, now.Add(time.Hour+time.Minute), epoch.CurrentEpochStartTime)
+
+	epoch = beginBlock(5, now.Add(3*time.Hour))
+	require.Equal(t, int64(3), epoch.CurrentEpoch)
+	require.Equal(t, now.Add(2*time.Hour+time.Minute), epoch.CurrentEpochStartTime)
+
+	epoch = beginBlock(6, now.Add(3*time.Hour))
+	require.Equal(t, int64(3), epoch.CurrentEpoch)
+	require.Equal(t, []string{"start 1", "end 1", "start 2", "end 2", "start 3"}, recorder.calls)
+
+	numBlocks, err := k.NumBlocksSinceEpochStart(ctx, "hour")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), numBlocks)
+}
+
+func TestInitAndExportGenesis(t *testing.T) {
+	app := simapp.Setup(false)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 7, Time: now})
+	for _, epoch := range app.EpochsKeeper.AllEpochInfos(ctx) {
+		app.EpochsKeeper.DeleteEpochInfo(ctx, epoch.Identifier)
+	}
+
+	epochs.InitGenesis(ctx, app.EpochsKeeper, types.DefaultGenesisState())
+
+	// the default epochs start at the genesis block time
+	day := types.NewEpochInfo(types.DayEpochID, now, 24*time.Hour)
+	day.CurrentEpochStartHeight = 7
+	week := types.NewEpochInfo(types.WeekEpochID, now, 7*24*time.Hour)
+	week.CurrentEpochStartHeight = 7
+	require.Equal(t, types.NewGenesisState([]types.EpochInfo{day, week}), epochs.ExportGenesis(ctx, app.EpochsKeeper))
+
+	require.Panics(t, func() { epochs.InitGenesis(ctx, app.EpochsKeeper, types.DefaultGenesisState()) })
+}
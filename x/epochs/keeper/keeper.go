@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/epochs/types"
+)
+
+// Keeper of the epochs store
+type Keeper struct {
+	cdc      codec.BinaryCodec
+	storeKey sdk.StoreKey
+	hooks    types.EpochHooks
+}
+
+// NewKeeper creates a new epochs Keeper instance
+func NewKeeper(cdc codec.BinaryCodec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc:      cdc,
+		storeKey: key,
+	}
+}
+
+// SetHooks sets the epoch hooks
+func (k *Keeper) SetHooks(eh types.EpochHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set epoch hooks twice")
+	}
+
+	k.hooks = eh
+
+	return k
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// GetEpochInfo returns the epoch info of an identifier.
+func (k Keeper) GetEpochInfo(ctx sdk.Context, identifier string) (epoch types.EpochInfo, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.EpochInfoKey(identifier))
+	if bz == nil {
+		return epoch, false
+	}
+
+	k.cdc.MustUnmarshal(bz, &epoch)
+	return epoch, true
+}
+
+// SetEpochInfo sets the epoch info of its identifier.
+func (k Keeper) SetEpochInfo(ctx sdk.Context, epoch types.EpochInfo) {
+	bz := k.cdc.MustMarshal(&epoch)
+	ctx.KVStore(k.storeKey).Set(types.EpochInfoKey(epoch.Identifier), bz)
+}
+
+// AddEpochInfo adds a new epoch, starting at the current block time if its
+// start time is zero.
+func (k Keeper) AddEpochInfo(ctx sdk.Context, epoch types.EpochInfo) error {
+	if err := epoch.Validate(); err != nil {
+		return err
+	}
+	if _, found := k.GetEpochInfo(ctx, epoch.Identifier); found {
+		return sdkerrors.Wrap(types.ErrEpochAlreadyExists, epoch.Identifier)
+	}
+
+	if epoch.StartTime.IsZero() {
+		epoch.StartTime = ctx.BlockTime()
+	}
+	if epoch.CurrentEpochStartTime.IsZero() {
+		epoch.CurrentEpochStartTime = epoch.StartTime
+	}
+	if !epoch.EpochCountingStarted {
+		epoch.CurrentEpochStartHeight = ctx.BlockHeight()
+	}
+
+	k.SetEpochInfo(ctx, epoch)
+	return nil
+}
+
+// DeleteEpochInfo deletes the epoch info of an identifier.
+func (k Keeper) DeleteEpochInfo(ctx sdk.Context, identifier string) {
+	ctx.KVStore(k.storeKey).Delete(types.EpochInfoKey(identifier))
+}
+
+// IterateEpochInfo iterates over the epoch infos, ordered by identifier, until
+// cb returns true.
+func (k Keeper) IterateEpochInfo(ctx sdk.Context, cb func(epoch types.EpochInfo) (stop bool)) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixEpoch)
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var epoch types.EpochInfo
+		k.cdc.MustUnmarshal(iterator.Value(), &epoch)
+
+		if cb(epoch) {
+			break
+		}
+	}
+}
+
+// AllEpochInfos returns all the epoch infos, ordered by identifier.
+func (k Keeper) AllEpochInfos(ctx sdk.Context) []types.EpochInfo {
+	epochs := []types.EpochInfo{}
+	k.IterateEpochInfo(ctx, func(epoch types.EpochInfo) bool {
+		epochs = append(epochs, epoch)
+		return false
+	})
+	return epochs
+}
+
+// NumBlocksSinceEpochStart returns the number of blocks since the start of the
+// running epoch of an identifier.
+func (k Keeper) NumBlocksSinceEpochStart(ctx sdk.Context, identifier string) (int64, error) {
+	epoch, found := k.GetEpochInfo(ctx, identifier)
+	if !found {
+		return 0, sdkerrors.Wrap(types.ErrEpochNotFound, identifier)
+	}
+	return ctx.BlockHeight() - epoch.CurrentEpochStartHeight, nil
+}
+
+// AfterEpochEnd calls the AfterEpochEnd hook, if the hooks are set.
+func (k Keeper) AfterEpochEnd(ctx sdk.Context, identifier string, epochNumber int64) {
+	if k.hooks != nil {
+		k.hooks.AfterEpochEnd(ctx, identifier, epochNumber)
+	}
+}
+
+// BeforeEpochStart calls the BeforeEpochStart hook, if the hooks are set.
+func (k Keeper) BeforeEpochStart(ctx sdk.Context, identifier string, epochNumber int64) {
+	if k.hooks != nil {
+		k.hooks.BeforeEpochStart(ctx, identifier, epochNumber)
+	}
+}
@@ -0,0 +1,87 @@
+package keeper_test
+
+import (
+	gocontext "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/epochs/types"
+)
+
+type EpochsTestSuite struct {
+	suite.Suite
+
+	app         *simapp.SimApp
+	ctx         sdk.Context
+	queryClient types.QueryClient
+}
+
+func (suite *EpochsTestSuite) SetupTest() {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 1, Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	queryHelper := baseapp.NewQueryServerTestHelper(ctx, app.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, app.EpochsKeeper)
+	queryClient := types.NewQueryClient(queryHelper)
+
+	suite.app = app
+	suite.ctx = ctx
+
+	suite.queryClient = queryClient
+}
+
+func (suite *EpochsTestSuite) TestAddEpochInfo() {
+	app, ctx := suite.app, suite.ctx
+
+	suite.Require().Error(app.EpochsKeeper.AddEpochInfo(ctx, types.NewEpochInfo("", time.Time{}, time.Hour)))
+	suite.Require().Error(app.EpochsKeeper.AddEpochInfo(ctx, types.NewEpochInfo("hour", time.Time{}, 0)))
+
+	suite.Require().NoError(app.EpochsKeeper.AddEpochInfo(ctx, types.NewEpochInfo("hour", time.Time{}, time.Hour)))
+	suite.Require().ErrorIs(app.EpochsKeeper.AddEpochInfo(ctx, types.NewEpochInfo("hour", time.Time{}, time.Hour)), types.ErrEpochAlreadyExists)
+
+	// a zero start time is replaced by the block time
+	epoch, found := app.EpochsKeeper.GetEpochInfo(ctx, "hour")
+	suite.Require().True(found)
+	suite.Require().Equal(ctx.BlockTime(), epoch.StartTime)
+	suite.Require().Equal(ctx.BlockTime(), epoch.CurrentEpochStartTime)
+	suite.Require().Equal(ctx.BlockHeight(), epoch.CurrentEpochStartHeight)
+
+	app.EpochsKeeper.DeleteEpochInfo(ctx, "hour")
+	_, found = app.EpochsKeeper.GetEpochInfo(ctx, "hour")
+	suite.Require().False(found)
+
+	_, err := app.EpochsKeeper.NumBlocksSinceEpochStart(ctx, "hour")
+	suite.Require().ErrorIs(err, types.ErrEpochNotFound)
+}
+
+func (suite *EpochsTestSuite) TestGRPCQueries() {
+	app, ctx, queryClient := suite.app, suite.ctx, suite.queryClient
+	epoch := types.NewEpochInfo("hour", ctx.BlockTime(), time.Hour)
+	epoch.EpochCountingStarted = true
+	epoch.CurrentEpoch = 3
+	app.EpochsKeeper.SetEpochInfo(ctx, epoch)
+
+	infos, err := queryClient.EpochInfos(gocontext.Background(), &types.QueryEpochInfosRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Equal(app.EpochsKeeper.AllEpochInfos(ctx), infos.Epochs)
+	suite.Require().Contains(infos.Epochs, epoch)
+
+	current, err := queryClient.CurrentEpoch(gocontext.Background(), &types.QueryCurrentEpochRequest{Identifier: "hour"})
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(3), current.CurrentEpoch)
+
+	_, err = queryClient.CurrentEpoch(gocontext.Background(), &types.QueryCurrentEpochRequest{Identifier: "unknown"})
+	suite.Require().Error(err)
+	_, err = queryClient.CurrentEpoch(gocontext.Background(), &types.QueryCurrentEpochRequest{})
+	suite.Require().Error(err)
+}
+
+func TestEpochsTestSuite(t *testing.T) {
+	suite.Run(t, new(EpochsTestSuite))
+}
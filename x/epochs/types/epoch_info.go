@@ -0,0 +1,49 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// DayEpochID is the identifier of the default daily epoch.
+	DayEpochID = "day"
+	// WeekEpochID is the identifier of the default weekly epoch.
+	WeekEpochID = "week"
+)
+
+// NewEpochInfo creates an epoch starting at startTime, which hasn't started
+// counting yet.
+func NewEpochInfo(identifier string, startTime time.Time, duration time.Duration) EpochInfo {
+	return EpochInfo{
+		Identifier:            identifier,
+		StartTime:             startTime,
+		Duration:              duration,
+		CurrentEpochStartTime: startTime,
+	}
+}
+
+// Validate performs a stateless validation of the epoch info.
+func (e EpochInfo) Validate() error {
+	if strings.TrimSpace(e.Identifier) == "" {
+		return sdkerrors.Wrap(ErrInvalidEpoch, "identifier cannot be blank")
+	}
+	if e.Duration <= 0 {
+		return sdkerrors.Wrapf(ErrInvalidEpoch, "duration of %s must be positive: %s", e.Identifier, e.Duration)
+	}
+	if e.CurrentEpoch < 0 {
+		return sdkerrors.Wrapf(ErrInvalidEpoch, "current epoch of %s cannot be negative: %d", e.Identifier, e.CurrentEpoch)
+	}
+	if e.CurrentEpochStartHeight < 0 {
+		return sdkerrors.Wrapf(ErrInvalidEpoch, "current epoch start height of %s cannot be negative: %d", e.Identifier, e.CurrentEpochStartHeight)
+	}
+
+	return nil
+}
+
+// EndTime returns the time at which the running epoch ends.
+func (e EpochInfo) EndTime() time.Time {
+	return e.CurrentEpochStartTime.Add(e.Duration)
+}
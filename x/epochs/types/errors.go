@@ -0,0 +1,12 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// x/epochs module sentinel errors
+var (
+	ErrEpochNotFound      = sdkerrors.Register(ModuleName, 2, "epoch not found")
+	ErrEpochAlreadyExists = sdkerrors.Register(ModuleName, 3, "epoch already exists")
+	ErrInvalidEpoch       = sdkerrors.Register(ModuleName, 4, "invalid epoch")
+)
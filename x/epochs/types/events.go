@@ -0,0 +1,11 @@
+package types
+
+// epochs module event types
+const (
+	EventTypeEpochStart = "epoch_start"
+	EventTypeEpochEnd   = "epoch_end"
+
+	AttributeKeyIdentifier     = "identifier"
+	AttributeKeyEpochNumber    = "epoch_number"
+	AttributeKeyEpochStartTime = "start_time"
+)
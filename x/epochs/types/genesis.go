@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(epochs []EpochInfo) *GenesisState {
+	return &GenesisState{Epochs: epochs}
+}
+
+// DefaultGenesisState creates a default GenesisState object with a daily and
+// a weekly epoch, starting at the genesis block time.
+func DefaultGenesisState() *GenesisState {
+	return NewGenesisState([]EpochInfo{
+		NewEpochInfo(DayEpochID, time.Time{}, time.Hour*24),
+		NewEpochInfo(WeekEpochID, time.Time{}, time.Hour*24*7),
+	})
+}
+
+// ValidateGenesis validates the provided genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	identifiers := make(map[string]bool, len(data.Epochs))
+	for _, epoch := range data.Epochs {
+		if err := epoch.Validate(); err != nil {
+			return err
+		}
+		if identifiers[epoch.Identifier] {
+			return fmt.Errorf("duplicate epoch identifier %s", epoch.Identifier)
+		}
+		identifiers[epoch.Identifier] = true
+	}
+
+	return nil
+}
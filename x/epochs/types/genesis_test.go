@@ -0,0 +1,40 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/x/epochs/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	hour := types.NewEpochInfo("hour", time.Time{}, time.Hour)
+	negative := hour
+	negative.CurrentEpoch = -1
+
+	testCases := []struct {
+		name     string
+		genesis  types.GenesisState
+		expError bool
+	}{
+		{"default", *types.DefaultGenesisState(), false},
+		{"empty", types.GenesisState{}, false},
+		{"blank identifier", *types.NewGenesisState([]types.EpochInfo{types.NewEpochInfo(" ", time.Time{}, time.Hour)}), true},
+		{"zero duration", *types.NewGenesisState([]types.EpochInfo{types.NewEpochInfo("hour", time.Time{}, 0)}), true},
+		{"negative current epoch", *types.NewGenesisState([]types.EpochInfo{negative}), true},
+		{"duplicate identifier", *types.NewGenesisState([]types.EpochInfo{hour, hour}), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.ValidateGenesis(tc.genesis)
+			if tc.expError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EpochHooks is implemented by the modules running logic at the boundaries of the epochs.
+type EpochHooks interface {
+	AfterEpochEnd(ctx sdk.Context, identifier string, epochNumber int64)    // Called at the end of an epoch, in the BeginBlock of the next one
+	BeforeEpochStart(ctx sdk.Context, identifier string, epochNumber int64) // Called at the start of an epoch, including the first one
+}
+
+var _ EpochHooks = MultiEpochHooks{}
+
+// combine multiple epoch hooks, all hook functions are run in array sequence
+type MultiEpochHooks []EpochHooks
+
+func NewMultiEpochHooks(hooks ...EpochHooks) MultiEpochHooks {
+	return hooks
+}
+
+func (h MultiEpochHooks) AfterEpochEnd(ctx sdk.Context, identifier string, epochNumber int64) {
+	for i := range h {
+		h[i].AfterEpochEnd(ctx, identifier, epochNumber)
+	}
+}
+
+func (h MultiEpochHooks) BeforeEpochStart(ctx sdk.Context, identifier string, epochNumber int64) {
+	for i := range h {
+		h[i].BeforeEpochStart(ctx, identifier, epochNumber)
+	}
+}
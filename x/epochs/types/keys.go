@@ -0,0 +1,17 @@
+package types
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "epochs"
+
+	// StoreKey defines the primary module store key
+	StoreKey = ModuleName
+)
+
+// KeyPrefixEpoch is the prefix of the epoch infos, stored by identifier.
+var KeyPrefixEpoch = []byte{0x01}
+
+// EpochInfoKey returns the store key of the epoch info of an identifier.
+func EpochInfoKey(identifier string) []byte {
+	return append(KeyPrefixEpoch, []byte(identifier)...)
+}
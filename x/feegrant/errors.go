@@ -22,4 +22,6 @@ var (
 	ErrNoMessages = sdkerrors.Register(DefaultCodespace, 6, "allowed messages are empty")
 	// ErrMessageNotAllowed error if message is not allowed
 	ErrMessageNotAllowed = sdkerrors.Register(DefaultCodespace, 7, "message not allowed")
+	// ErrFeeDenomNotAllowed error if the fee is paid in a denom the allowance doesn't cover
+	ErrFeeDenomNotAllowed = sdkerrors.Register(DefaultCodespace, 8, "fee denom not allowed")
 )
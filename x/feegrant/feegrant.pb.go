@@ -218,6 +218,64 @@ func (m *AllowedMsgAllowance) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AllowedMsgAllowance proto.InternalMessageInfo
 
+// FilteredPeriodicAllowance combines a periodic allowance with a restriction
+// on the message types it pays for, in a single grant. Fees can only be paid
+// in the denoms of the period spend limit, each denom being capped by its own
+// amount.
+type FilteredPeriodicAllowance struct {
+	// periodic specifies the spend limits and the refill period of the allowance.
+	Periodic PeriodicAllowance `protobuf:"bytes,1,opt,name=periodic,proto3" json:"periodic"`
+	// allowed_messages are the messages for which the grantee has the access.
+	AllowedMessages []string `protobuf:"bytes,2,rep,name=allowed_messages,json=allowedMessages,proto3" json:"allowed_messages,omitempty"`
+}
+
+func (m *FilteredPeriodicAllowance) Reset()         { *m = FilteredPeriodicAllowance{} }
+func (m *FilteredPeriodicAllowance) String() string { return proto.CompactTextString(m) }
+func (*FilteredPeriodicAllowance) ProtoMessage()    {}
+func (*FilteredPeriodicAllowance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7279582900c30aea, []int{3}
+}
+func (m *FilteredPeriodicAllowance) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FilteredPeriodicAllowance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FilteredPeriodicAllowance.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FilteredPeriodicAllowance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilteredPeriodicAllowance.Merge(m, src)
+}
+func (m *FilteredPeriodicAllowance) XXX_Size() int {
+	return m.Size()
+}
+func (m *FilteredPeriodicAllowance) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilteredPeriodicAllowance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilteredPeriodicAllowance proto.InternalMessageInfo
+
+func (m *FilteredPeriodicAllowance) GetPeriodic() PeriodicAllowance {
+	if m != nil {
+		return m.Periodic
+	}
+	return PeriodicAllowance{}
+}
+
+func (m *FilteredPeriodicAllowance) GetAllowedMessages() []string {
+	if m != nil {
+		return m.AllowedMessages
+	}
+	return nil
+}
+
 // Grant is stored in the KVStore to record a grant with full context
 type Grant struct {
 	// granter is the address of the user granting an allowance of their funds.
@@ -232,7 +290,7 @@ func (m *Grant) Reset()         { *m = Grant{} }
 func (m *Grant) String() string { return proto.CompactTextString(m) }
 func (*Grant) ProtoMessage()    {}
 func (*Grant) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7279582900c30aea, []int{3}
+	return fileDescriptor_7279582900c30aea, []int{4}
 }
 func (m *Grant) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -286,6 +344,7 @@ func init() {
 	proto.RegisterType((*BasicAllowance)(nil), "cosmos.feegrant.v1beta1.BasicAllowance")
 	proto.RegisterType((*PeriodicAllowance)(nil), "cosmos.feegrant.v1beta1.PeriodicAllowance")
 	proto.RegisterType((*AllowedMsgAllowance)(nil), "cosmos.feegrant.v1beta1.AllowedMsgAllowance")
+	proto.RegisterType((*FilteredPeriodicAllowance)(nil), "cosmos.feegrant.v1beta1.FilteredPeriodicAllowance")
 	proto.RegisterType((*Grant)(nil), "cosmos.feegrant.v1beta1.Grant")
 }
 
@@ -294,43 +353,45 @@ func init() {
 }
 
 var fileDescriptor_7279582900c30aea = []byte{
-	// 562 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x3f, 0x6f, 0xd3, 0x40,
-	0x14, 0x8f, 0x9b, 0xa4, 0x90, 0x0b, 0x94, 0xc6, 0x14, 0xe1, 0x64, 0x70, 0xa2, 0x0e, 0x34, 0x0c,
-	0xb5, 0x69, 0xd9, 0xca, 0x42, 0x1c, 0xa0, 0x42, 0xa2, 0x12, 0x32, 0x4c, 0x2c, 0xd1, 0xd9, 0x79,
-	0x35, 0x27, 0x6c, 0x9f, 0xe5, 0xbb, 0x40, 0xb3, 0x32, 0x31, 0x76, 0x64, 0x42, 0xcc, 0xcc, 0x7c,
-	0x88, 0x8a, 0xa9, 0x82, 0x85, 0x89, 0xa2, 0xe4, 0x8b, 0x20, 0xdf, 0x9d, 0x9d, 0x90, 0xf0, 0x47,
-	0xaa, 0x3a, 0xc5, 0x77, 0xef, 0xfd, 0xfe, 0xbd, 0x77, 0x0a, 0xba, 0xe5, 0x53, 0x16, 0x51, 0x66,
-	0x1f, 0x02, 0x04, 0x29, 0x8e, 0xb9, 0xfd, 0x7a, 0xc7, 0x03, 0x8e, 0x77, 0x8a, 0x0b, 0x2b, 0x49,
-	0x29, 0xa7, 0xfa, 0x4d, 0xd9, 0x67, 0x15, 0xd7, 0xaa, 0xaf, 0xb5, 0x11, 0xd0, 0x80, 0x8a, 0x1e,
-	0x3b, 0xfb, 0x92, 0xed, 0xad, 0x66, 0x40, 0x69, 0x10, 0x82, 0x2d, 0x4e, 0xde, 0xe8, 0xd0, 0xc6,
-	0xf1, 0x38, 0x2f, 0x49, 0xa6, 0x81, 0xc4, 0x28, 0x5a, 0x59, 0x32, 0x95, 0x19, 0x0f, 0x33, 0x28,
-	0x8c, 0xf8, 0x94, 0xc4, 0xaa, 0xde, 0x5e, 0x64, 0xe5, 0x24, 0x02, 0xc6, 0x71, 0x94, 0xe4, 0x04,
-	0x8b, 0x0d, 0xc3, 0x51, 0x8a, 0x39, 0xa1, 0x8a, 0x60, 0xf3, 0x9b, 0x86, 0xd6, 0x1c, 0xcc, 0x88,
-	0xdf, 0x0b, 0x43, 0xfa, 0x06, 0xc7, 0x3e, 0xe8, 0x21, 0xaa, 0xb3, 0x04, 0xe2, 0xe1, 0x20, 0x24,
-	0x11, 0xe1, 0x86, 0xd6, 0x29, 0x77, 0xeb, 0xbb, 0x4d, 0x4b, 0xf9, 0xca, 0x9c, 0xe4, 0x51, 0xad,
-	0x3e, 0x25, 0xb1, 0x73, 0xe7, 0xe4, 0x47, 0xbb, 0xf4, 0xe9, 0xac, 0xdd, 0x0d, 0x08, 0x7f, 0x39,
-	0xf2, 0x2c, 0x9f, 0x46, 0x2a, 0x84, 0xfa, 0xd9, 0x66, 0xc3, 0x57, 0x36, 0x1f, 0x27, 0xc0, 0x04,
-	0x80, 0xb9, 0x48, 0xf0, 0x3f, 0xc9, 0xe8, 0xf5, 0xfb, 0x08, 0xc1, 0x51, 0x42, 0xa4, 0x29, 0x63,
-	0xa5, 0xa3, 0x75, 0xeb, 0xbb, 0x2d, 0x4b, 0xba, 0xb6, 0x72, 0xd7, 0xd6, 0xf3, 0x3c, 0x96, 0x53,
-	0x39, 0x3e, 0x6b, 0x6b, 0xee, 0x1c, 0x66, 0xaf, 0xf1, 0xf5, 0xf3, 0xf6, 0xd5, 0x47, 0x00, 0x45,
-	0x82, 0xc7, 0x9b, 0xd3, 0x32, 0x6a, 0x3c, 0x85, 0x94, 0xd0, 0xe1, 0x7c, 0xb0, 0x3e, 0xaa, 0x7a,
-	0x59, 0x54, 0x43, 0x13, 0x2a, 0x5b, 0xd6, 0x5f, 0x36, 0x68, 0xfd, 0x3e, 0x10, 0xa7, 0x92, 0x05,
-	0x74, 0x25, 0x56, 0xbf, 0x87, 0x56, 0x13, 0xc1, 0xac, 0xbc, 0x36, 0x97, 0xbc, 0x3e, 0x50, 0x13,
-	0x76, 0x2e, 0x67, 0xb8, 0xf7, 0x99, 0x5d, 0x05, 0xd1, 0xc7, 0x48, 0x97, 0x5f, 0x83, 0xf9, 0x09,
-	0x97, 0x2f, 0x7e, 0xc2, 0xeb, 0x52, 0xe6, 0xd9, 0x6c, 0xce, 0x23, 0xa4, 0xee, 0x06, 0x3e, 0x8e,
-	0xa5, 0xbc, 0x51, 0xb9, 0x78, 0xe1, 0x35, 0x29, 0xd2, 0xc7, 0xb1, 0xd0, 0xd6, 0xf7, 0xd1, 0x15,
-	0x25, 0x9b, 0x02, 0x03, 0x6e, 0x54, 0xff, 0xbb, 0x60, 0x31, 0x35, 0xb1, 0xe4, 0xba, 0x44, 0xba,
-	0x19, 0xf0, 0x4f, 0x5b, 0xfe, 0xa0, 0xa1, 0xeb, 0xe2, 0x08, 0xc3, 0x03, 0x16, 0xcc, 0xf6, 0xfc,
-	0x10, 0xd5, 0x70, 0x7e, 0x50, 0xbb, 0xde, 0x58, 0x12, 0xec, 0xc5, 0x63, 0xa7, 0xf1, 0x65, 0x91,
-	0xd3, 0x9d, 0x21, 0xf5, 0xdb, 0x68, 0x1d, 0x4b, 0xf6, 0x41, 0x04, 0x8c, 0xe1, 0x00, 0x98, 0xb1,
-	0xd2, 0x29, 0x77, 0x6b, 0xee, 0x35, 0x75, 0x7f, 0xa0, 0xae, 0xf7, 0x6e, 0xbc, 0xfb, 0xd8, 0x2e,
-	0x2d, 0x1b, 0x7c, 0xab, 0xa1, 0xea, 0x7e, 0xf6, 0xb2, 0x74, 0x03, 0x5d, 0x12, 0x4f, 0x0c, 0x52,
-	0x61, 0xa8, 0xe6, 0xe6, 0xc7, 0x59, 0x05, 0xc4, 0x83, 0x2a, 0x2a, 0x0b, 0x31, 0xca, 0xe7, 0x8d,
-	0xe1, 0xf4, 0x4e, 0x26, 0xa6, 0x76, 0x3a, 0x31, 0xb5, 0x9f, 0x13, 0x53, 0x3b, 0x9e, 0x9a, 0xa5,
-	0xd3, 0xa9, 0x59, 0xfa, 0x3e, 0x35, 0x4b, 0x2f, 0xb6, 0xfe, 0xb9, 0xd5, 0xa3, 0xe2, 0x0f, 0xcf,
-	0x5b, 0x15, 0x72, 0x77, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0xd0, 0x08, 0x9a, 0x3d, 0x1b, 0x05,
-	0x00, 0x00,
+	// 596 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xbf, 0x6f, 0xd3, 0x4e,
+	0x14, 0x8f, 0x9b, 0xa6, 0xdf, 0xe6, 0xf2, 0xa5, 0x34, 0xa6, 0x08, 0x27, 0x83, 0x13, 0x75, 0xa0,
+	0x01, 0xa9, 0x36, 0x2d, 0x5b, 0x59, 0x88, 0x03, 0xad, 0x90, 0x5a, 0x09, 0x19, 0x26, 0x96, 0xe8,
+	0x6c, 0xbf, 0x1a, 0x0b, 0xdb, 0x67, 0xf9, 0x2e, 0xd0, 0xac, 0x4c, 0x8c, 0x1d, 0x99, 0x10, 0x1b,
+	0x12, 0x33, 0x7f, 0x44, 0xc5, 0x54, 0xc1, 0xc2, 0x44, 0x51, 0xf2, 0x8f, 0x20, 0xdf, 0x9d, 0x9d,
+	0x90, 0xb4, 0x20, 0x50, 0xa7, 0xf8, 0xde, 0xbd, 0xcf, 0x8f, 0xf7, 0x79, 0xa7, 0xa0, 0x9b, 0x2e,
+	0xa1, 0x11, 0xa1, 0xe6, 0x21, 0x80, 0x9f, 0xe2, 0x98, 0x99, 0x2f, 0xb7, 0x1c, 0x60, 0x78, 0xab,
+	0x28, 0x18, 0x49, 0x4a, 0x18, 0x51, 0x6f, 0x88, 0x3e, 0xa3, 0x28, 0xcb, 0xbe, 0xe6, 0x9a, 0x4f,
+	0x7c, 0xc2, 0x7b, 0xcc, 0xec, 0x4b, 0xb4, 0x37, 0x1b, 0x3e, 0x21, 0x7e, 0x08, 0x26, 0x3f, 0x39,
+	0x83, 0x43, 0x13, 0xc7, 0xc3, 0xfc, 0x4a, 0x30, 0xf5, 0x05, 0x46, 0xd2, 0x8a, 0x2b, 0x5d, 0x9a,
+	0x71, 0x30, 0x85, 0xc2, 0x88, 0x4b, 0x82, 0x58, 0xde, 0xb7, 0x66, 0x59, 0x59, 0x10, 0x01, 0x65,
+	0x38, 0x4a, 0x72, 0x82, 0xd9, 0x06, 0x6f, 0x90, 0x62, 0x16, 0x10, 0x49, 0xb0, 0xfe, 0x55, 0x41,
+	0x2b, 0x16, 0xa6, 0x81, 0xdb, 0x0d, 0x43, 0xf2, 0x0a, 0xc7, 0x2e, 0xa8, 0x21, 0xaa, 0xd1, 0x04,
+	0x62, 0xaf, 0x1f, 0x06, 0x51, 0xc0, 0x34, 0xa5, 0x5d, 0xee, 0xd4, 0xb6, 0x1b, 0x86, 0xf4, 0x95,
+	0x39, 0xc9, 0x47, 0x35, 0x7a, 0x24, 0x88, 0xad, 0x3b, 0x27, 0xdf, 0x5b, 0xa5, 0x8f, 0x67, 0xad,
+	0x8e, 0x1f, 0xb0, 0xe7, 0x03, 0xc7, 0x70, 0x49, 0x24, 0x87, 0x90, 0x3f, 0x9b, 0xd4, 0x7b, 0x61,
+	0xb2, 0x61, 0x02, 0x94, 0x03, 0xa8, 0x8d, 0x38, 0xff, 0x7e, 0x46, 0xaf, 0xde, 0x47, 0x08, 0x8e,
+	0x92, 0x40, 0x98, 0xd2, 0x16, 0xda, 0x4a, 0xa7, 0xb6, 0xdd, 0x34, 0x84, 0x6b, 0x23, 0x77, 0x6d,
+	0x3c, 0xcd, 0xc7, 0xb2, 0x16, 0x8f, 0xcf, 0x5a, 0x8a, 0x3d, 0x85, 0xd9, 0xa9, 0x7f, 0xf9, 0xb4,
+	0x79, 0x65, 0x17, 0xa0, 0x98, 0xe0, 0xd1, 0xfa, 0xb8, 0x8c, 0xea, 0x8f, 0x21, 0x0d, 0x88, 0x37,
+	0x3d, 0x58, 0x0f, 0x55, 0x9c, 0x6c, 0x54, 0x4d, 0xe1, 0x2a, 0x1b, 0xc6, 0x05, 0x1b, 0x34, 0x7e,
+	0x0d, 0xc4, 0x5a, 0xcc, 0x06, 0xb4, 0x05, 0x56, 0xbd, 0x87, 0x96, 0x12, 0xce, 0x2c, 0xbd, 0x36,
+	0xe6, 0xbc, 0x3e, 0x90, 0x09, 0x5b, 0xcb, 0x19, 0xee, 0x6d, 0x66, 0x57, 0x42, 0xd4, 0x21, 0x52,
+	0xc5, 0x57, 0x7f, 0x3a, 0xe1, 0xf2, 0xe5, 0x27, 0xbc, 0x2a, 0x64, 0x9e, 0x4c, 0x72, 0x1e, 0x20,
+	0x59, 0xeb, 0xbb, 0x38, 0x16, 0xf2, 0xda, 0xe2, 0xe5, 0x0b, 0xaf, 0x08, 0x91, 0x1e, 0x8e, 0xb9,
+	0xb6, 0xba, 0x87, 0xfe, 0x97, 0xb2, 0x29, 0x50, 0x60, 0x5a, 0xe5, 0x8f, 0x0b, 0xe6, 0xa9, 0xf1,
+	0x25, 0xd7, 0x04, 0xd2, 0xce, 0x80, 0xe7, 0x6d, 0xf9, 0x9d, 0x82, 0xae, 0xf1, 0x23, 0x78, 0x07,
+	0xd4, 0x9f, 0xec, 0xf9, 0x21, 0xaa, 0xe2, 0xfc, 0x20, 0x77, 0xbd, 0x36, 0x27, 0xd8, 0x8d, 0x87,
+	0x56, 0xfd, 0xf3, 0x2c, 0xa7, 0x3d, 0x41, 0xaa, 0xb7, 0xd0, 0x2a, 0x16, 0xec, 0xfd, 0x08, 0x28,
+	0xc5, 0x3e, 0x50, 0x6d, 0xa1, 0x5d, 0xee, 0x54, 0xed, 0xab, 0xb2, 0x7e, 0x20, 0xcb, 0x3b, 0xd7,
+	0xdf, 0xbc, 0x6f, 0x95, 0xe6, 0x0d, 0x7e, 0x50, 0x50, 0x63, 0x37, 0x08, 0x19, 0xa4, 0xe0, 0xcd,
+	0x3f, 0xc7, 0x7d, 0xb4, 0x9c, 0xc8, 0xa2, 0x74, 0x79, 0xfb, 0xc2, 0x17, 0x39, 0x87, 0x96, 0x8f,
+	0xb2, 0x60, 0xf8, 0x1b, 0xb7, 0xe7, 0x44, 0xf9, 0x5a, 0x41, 0x95, 0xbd, 0x4c, 0x51, 0xd5, 0xd0,
+	0x7f, 0x5c, 0x1a, 0x52, 0x6e, 0xaa, 0x6a, 0xe7, 0xc7, 0xc9, 0x0d, 0x68, 0x0b, 0xd3, 0x37, 0x33,
+	0x81, 0x97, 0xff, 0x35, 0x70, 0xab, 0x7b, 0x32, 0xd2, 0x95, 0xd3, 0x91, 0xae, 0xfc, 0x18, 0xe9,
+	0xca, 0xf1, 0x58, 0x2f, 0x9d, 0x8e, 0xf5, 0xd2, 0xb7, 0xb1, 0x5e, 0x7a, 0xb6, 0xf1, 0xdb, 0xf7,
+	0x77, 0x54, 0xfc, 0x35, 0x3b, 0x4b, 0x5c, 0xee, 0xee, 0xcf, 0x01, 0x00, 0x39, 0x62, 0xe9, 0xda,
+	0xc5, 0x05, 0x00, 0x00,
 }
 
 func (m *BasicAllowance) Marshal() (dAtA []byte, err error) {
@@ -501,6 +562,48 @@ func (m *AllowedMsgAllowance) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FilteredPeriodicAllowance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FilteredPeriodicAllowance) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FilteredPeriodicAllowance) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AllowedMessages) > 0 {
+		for iNdEx := len(m.AllowedMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedMessages[iNdEx])
+			copy(dAtA[i:], m.AllowedMessages[iNdEx])
+			i = encodeVarintFeegrant(dAtA, i, uint64(len(m.AllowedMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Periodic.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintFeegrant(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *Grant) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -626,6 +729,23 @@ func (m *AllowedMsgAllowance) Size() (n int) {
 	return n
 }
 
+func (m *FilteredPeriodicAllowance) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Periodic.Size()
+	n += 1 + l + sovFeegrant(uint64(l))
+	if len(m.AllowedMessages) > 0 {
+		for _, s := range m.AllowedMessages {
+			l = len(s)
+			n += 1 + l + sovFeegrant(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Grant) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1108,6 +1228,121 @@ func (m *AllowedMsgAllowance) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FilteredPeriodicAllowance) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeegrant
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FilteredPeriodicAllowance: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FilteredPeriodicAllowance: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Periodic", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeegrant
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeegrant
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeegrant
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Periodic.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedMessages", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeegrant
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeegrant
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeegrant
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedMessages = append(m.AllowedMessages, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeegrant(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeegrant
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Grant) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
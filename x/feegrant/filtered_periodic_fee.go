@@ -0,0 +1,56 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var _ FeeAllowanceI = (*FilteredPeriodicAllowance)(nil)
+
+// NewFilteredPeriodicAllowance creates a periodic fee allowance restricted to the given message types.
+func NewFilteredPeriodicAllowance(periodic PeriodicAllowance, allowedMsgs []string) *FilteredPeriodicAllowance {
+	return &FilteredPeriodicAllowance{
+		Periodic:        periodic,
+		AllowedMessages: allowedMsgs,
+	}
+}
+
+// Accept rejects the fee if any of the messages is not allowed or if it is paid in a
+// denom outside the period spend limit, then deducts it from the periodic allowance.
+func (a *FilteredPeriodicAllowance) Accept(ctx sdk.Context, fee sdk.Coins, msgs []sdk.Msg) (bool, error) {
+	if !a.allMsgTypesAllowed(ctx, msgs) {
+		return false, sdkerrors.Wrap(ErrMessageNotAllowed, "message does not exist in allowed messages")
+	}
+
+	if !fee.DenomsSubsetOf(a.Periodic.PeriodSpendLimit) {
+		return false, sdkerrors.Wrapf(ErrFeeDenomNotAllowed, "fee %s, allowed denoms of %s", fee, a.Periodic.PeriodSpendLimit)
+	}
+
+	return a.Periodic.Accept(ctx, fee, msgs)
+}
+
+func (a *FilteredPeriodicAllowance) allMsgTypesAllowed(ctx sdk.Context, msgs []sdk.Msg) bool {
+	msgsMap := make(map[string]bool, len(a.AllowedMessages))
+	for _, msg := range a.AllowedMessages {
+		ctx.GasMeter().ConsumeGas(gasCostPerIteration, "check msg")
+		msgsMap[msg] = true
+	}
+
+	for _, msg := range msgs {
+		ctx.GasMeter().ConsumeGas(gasCostPerIteration, "check msg")
+		if !msgsMap[sdk.MsgTypeURL(msg)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateBasic implements FeeAllowance and enforces basic sanity checks
+func (a FilteredPeriodicAllowance) ValidateBasic() error {
+	if len(a.AllowedMessages) == 0 {
+		return sdkerrors.Wrap(ErrNoMessages, "allowed messages shouldn't be empty")
+	}
+
+	return a.Periodic.ValidateBasic()
+}
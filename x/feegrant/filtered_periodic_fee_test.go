@@ -0,0 +1,193 @@
+package feegrant_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+func TestFilteredPeriodicFeeValidAllow(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{
+		Time: time.Now(),
+	})
+
+	atom := sdk.NewCoins(sdk.NewInt64Coin("atom", 555))
+	smallAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 43))
+	oneAtom := sdk.NewCoins(sdk.NewInt64Coin("atom", 1))
+	eth := sdk.NewCoins(sdk.NewInt64Coin("eth", 1))
+	multi := sdk.NewCoins(sdk.NewInt64Coin("atom", 43), sdk.NewInt64Coin("eth", 5))
+
+	now := ctx.BlockTime()
+	oneHour := now.Add(1 * time.Hour)
+	tenMinutes := time.Duration(10) * time.Minute
+
+	send := &banktypes.MsgSend{}
+	multiSend := &banktypes.MsgMultiSend{}
+	allowed := []string{sdk.MsgTypeURL(send)}
+
+	cases := map[string]struct {
+		allow         *feegrant.FilteredPeriodicAllowance
+		fee           sdk.Coins
+		msgs          []sdk.Msg
+		blockTime     time.Time
+		valid         bool // all other checks are ignored if valid=false
+		accept        bool
+		remove        bool
+		remainsPeriod sdk.Coins
+	}{
+		"no allowed messages": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+			}, nil),
+			valid: false,
+		},
+		"no period spend limit": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Basic:  feegrant.BasicAllowance{SpendLimit: atom},
+				Period: tenMinutes,
+			}, allowed),
+			valid: false,
+		},
+		"allowed message": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Basic:            feegrant.BasicAllowance{SpendLimit: atom},
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:         true,
+			fee:           smallAtom,
+			msgs:          []sdk.Msg{send},
+			blockTime:     now,
+			accept:        true,
+			remainsPeriod: nil,
+		},
+		"message not allowed": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:     true,
+			fee:       oneAtom,
+			msgs:      []sdk.Msg{send, multiSend},
+			blockTime: now,
+			accept:    false,
+		},
+		"denom not allowed": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:     true,
+			fee:       eth,
+			msgs:      []sdk.Msg{send},
+			blockTime: now,
+			accept:    false,
+		},
+		"per denom limits": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Period:           tenMinutes,
+				PeriodSpendLimit: multi,
+				PeriodReset:      now,
+			}, allowed),
+			valid:         true,
+			fee:           oneAtom.Add(eth...),
+			msgs:          []sdk.Msg{send},
+			blockTime:     now,
+			accept:        true,
+			remainsPeriod: sdk.NewCoins(sdk.NewInt64Coin("atom", 42), sdk.NewInt64Coin("eth", 4)),
+		},
+		"denom limit exceeded": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Period:           tenMinutes,
+				PeriodSpendLimit: multi,
+				PeriodReset:      now,
+			}, allowed),
+			valid:     true,
+			fee:       sdk.NewCoins(sdk.NewInt64Coin("eth", 6)),
+			msgs:      []sdk.Msg{send},
+			blockTime: now,
+			accept:    false,
+		},
+		"period refill": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Basic:            feegrant.BasicAllowance{SpendLimit: atom},
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+				PeriodCanSpend:   oneAtom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:         true,
+			fee:           oneAtom,
+			msgs:          []sdk.Msg{send},
+			blockTime:     now.Add(tenMinutes),
+			accept:        true,
+			remainsPeriod: smallAtom.Sub(oneAtom),
+		},
+		"expired": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Basic:            feegrant.BasicAllowance{SpendLimit: atom, Expiration: &now},
+				Period:           tenMinutes,
+				PeriodSpendLimit: smallAtom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:     true,
+			fee:       oneAtom,
+			msgs:      []sdk.Msg{send},
+			blockTime: oneHour,
+			accept:    false,
+		},
+		"spend limit used up": {
+			allow: feegrant.NewFilteredPeriodicAllowance(feegrant.PeriodicAllowance{
+				Basic:            feegrant.BasicAllowance{SpendLimit: smallAtom},
+				Period:           tenMinutes,
+				PeriodSpendLimit: atom,
+				PeriodReset:      now,
+			}, allowed),
+			valid:     true,
+			fee:       smallAtom,
+			msgs:      []sdk.Msg{send},
+			blockTime: now,
+			accept:    true,
+			remove:    true,
+		},
+	}
+
+	for name, stc := range cases {
+		tc := stc // to make scopelint happy
+		t.Run(name, func(t *testing.T) {
+			err := tc.allow.ValidateBasic()
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := app.BaseApp.NewContext(false, tmproto.Header{}).WithBlockTime(tc.blockTime)
+			remove, err := tc.allow.Accept(ctx, tc.fee, tc.msgs)
+			if !tc.accept {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tc.remove, remove)
+			if !remove {
+				assert.Equal(t, tc.remainsPeriod, tc.allow.Periodic.PeriodCanSpend)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,36 @@
+package feemarket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/keeper"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// EndBlocker adjusts the base fee of the next block to the gas used by the
+// current one.
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	defer telemetry.ModuleMeasureSince(types.ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
+
+	params := k.GetParams(ctx)
+	if !params.Enabled {
+		return
+	}
+
+	gasUsed := ctx.BlockGasMeter().GasConsumedToLimit()
+	baseFee := types.NextBaseFee(params, k.GetBaseFee(ctx), gasUsed)
+	k.SetBaseFee(ctx, baseFee)
+
+	defer telemetry.ModuleSetGauge(types.ModuleName, float32(baseFee.MustFloat64()), "base_fee")
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeBaseFee,
+			sdk.NewAttribute(types.AttributeKeyBaseFee, baseFee.String()),
+			sdk.NewAttribute(types.AttributeKeyBlockGasUsed, fmt.Sprint(gasUsed)),
+		),
+	)
+}
@@ -0,0 +1,39 @@
+package feemarket_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+func TestEndBlockerAdjustsBaseFee(t *testing.T) {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 1})
+	params := app.FeeMarketKeeper.GetParams(ctx)
+	app.FeeMarketKeeper.SetBaseFee(ctx, sdk.NewDec(8))
+
+	// a full block raises the base fee by 1/8
+	blockGasMeter := sdk.NewInfiniteGasMeter()
+	blockGasMeter.ConsumeGas(2*params.TargetBlockGas, "txs")
+	feemarket.EndBlocker(ctx.WithBlockGasMeter(blockGasMeter), app.FeeMarketKeeper)
+	require.Equal(t, sdk.NewDec(9), app.FeeMarketKeeper.GetBaseFee(ctx))
+
+	// and an empty block lowers it by 1/8
+	feemarket.EndBlocker(ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()), app.FeeMarketKeeper)
+	require.Equal(t, sdk.NewDecWithPrec(7875, 3), app.FeeMarketKeeper.GetBaseFee(ctx))
+
+	// unless the base fee is disabled
+	params.Enabled = false
+	app.FeeMarketKeeper.SetParams(ctx, params)
+	feemarket.EndBlocker(ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()), app.FeeMarketKeeper)
+	require.Equal(t, sdk.NewDecWithPrec(7875, 3), app.FeeMarketKeeper.GetBaseFee(ctx))
+
+	gs := feemarket.ExportGenesis(ctx, app.FeeMarketKeeper)
+	require.Equal(t, types.NewGenesisState(params, sdk.NewDecWithPrec(7875, 3)), gs)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+)
+
+// TxFeeChecker returns an ante.TxFeeChecker requiring the fee of a tx to cover
+// the base fee for its gas limit before deferring to next, which returns the
+// effective fee and priority of the tx. Unlike the minimum gas prices of a
+// validator, the base fee is part of the state, and is thus checked in
+// DeliverTx too. The txs of the genesis block pay no base fee.
+func (k Keeper) TxFeeChecker(next ante.TxFeeChecker) ante.TxFeeChecker {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool, paramsKeeper paramskeeper.Keeper) (sdk.Coins, int64, error) {
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return nil, 0, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+		}
+
+		if !simulate && ctx.BlockHeight() > 0 {
+			if params := k.GetParams(ctx); params.Enabled {
+				requiredFee := types.RequiredFee(params, k.GetBaseFee(ctx), feeTx.GetGas())
+				if fee := feeTx.GetFee(); fee.AmountOf(requiredFee.Denom).LT(requiredFee.Amount) {
+					return nil, 0, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees for the base fee; got: %s required: %s", fee, requiredFee)
+				}
+			}
+		}
+
+		return next(ctx, tx, simulate, paramsKeeper)
+	}
+}
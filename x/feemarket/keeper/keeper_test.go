@@ -0,0 +1,96 @@
+package keeper_test
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// feeTx is a tx paying fee for gas.
+type feeTx struct {
+	fee sdk.Coins
+	gas uint64
+}
+
+func (tx feeTx) GetMsgs() []sdk.Msg         { return nil }
+func (tx feeTx) ValidateBasic() error       { return nil }
+func (tx feeTx) GetGas() uint64             { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins          { return tx.fee }
+func (tx feeTx) FeePayer() sdk.AccAddress   { return nil }
+func (tx feeTx) FeeGranter() sdk.AccAddress { return nil }
+
+type FeeMarketTestSuite struct {
+	suite.Suite
+
+	app         *simapp.SimApp
+	ctx         sdk.Context
+	queryClient types.QueryClient
+}
+
+func (suite *FeeMarketTestSuite) SetupTest() {
+	app := simapp.Setup(false)
+	ctx := app.BaseApp.NewContext(false, tmproto.Header{Height: 1})
+
+	queryHelper := baseapp.NewQueryServerTestHelper(ctx, app.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, app.FeeMarketKeeper)
+	queryClient := types.NewQueryClient(queryHelper)
+
+	suite.app = app
+	suite.ctx = ctx
+
+	suite.queryClient = queryClient
+}
+
+func (suite *FeeMarketTestSuite) TestGRPCQueries() {
+	app, ctx, queryClient := suite.app, suite.ctx, suite.queryClient
+	app.FeeMarketKeeper.SetBaseFee(ctx, sdk.NewDecWithPrec(15, 1))
+
+	params, err := queryClient.Params(gocontext.Background(), &types.QueryParamsRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Equal(params.Params, app.FeeMarketKeeper.GetParams(ctx))
+
+	baseFee, err := queryClient.BaseFee(gocontext.Background(), &types.QueryBaseFeeRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecWithPrec(15, 1), baseFee.BaseFee)
+}
+
+func (suite *FeeMarketTestSuite) TestTxFeeChecker() {
+	app, ctx := suite.app, suite.ctx
+	params := app.FeeMarketKeeper.GetParams(ctx)
+	app.FeeMarketKeeper.SetBaseFee(ctx, sdk.NewDecWithPrec(5, 1))
+	checker := app.FeeMarketKeeper.TxFeeChecker(ante.CheckTxFeeWithValidatorMinGasPrices)
+	underpaid := feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(params.BaseFeeDenom, 49)), gas: 100}
+	paid := feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin(params.BaseFeeDenom, 50)), gas: 100}
+
+	// the base fee is enforced in CheckTx and DeliverTx alike
+	for _, checkTx := range []bool{true, false} {
+		_, _, err := checker(ctx.WithIsCheckTx(checkTx), underpaid, false, app.ParamsKeeper)
+		suite.Require().ErrorIs(err, sdkerrors.ErrInsufficientFee)
+		fee, _, err := checker(ctx.WithIsCheckTx(checkTx), paid, false, app.ParamsKeeper)
+		suite.Require().NoError(err)
+		suite.Require().Equal(paid.fee, fee)
+	}
+
+	// but not in simulations, the genesis block or when disabled
+	_, _, err := checker(ctx, underpaid, true, app.ParamsKeeper)
+	suite.Require().NoError(err)
+	_, _, err = checker(ctx.WithBlockHeight(0), underpaid, false, app.ParamsKeeper)
+	suite.Require().NoError(err)
+	params.Enabled = false
+	app.FeeMarketKeeper.SetParams(ctx, params)
+	_, _, err = checker(ctx, underpaid, false, app.ParamsKeeper)
+	suite.Require().NoError(err)
+}
+
+func TestFeeMarketTestSuite(t *testing.T) {
+	suite.Run(t, new(FeeMarketTestSuite))
+}
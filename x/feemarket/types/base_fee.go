@@ -0,0 +1,39 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NextBaseFee returns the base fee following a block that used gasUsed gas.
+// The base fee grows when the block used more gas than the target and shrinks
+// when it used less, in proportion to the distance to the target and by at most
+// 1/BaseFeeChangeDenominator of itself (for blocks of twice the target), but
+// never below MinBaseFee. A growing base fee grows by at least the smallest
+// Dec, so that a base fee of zero can grow too.
+func NextBaseFee(params Params, baseFee sdk.Dec, gasUsed uint64) sdk.Dec {
+	target := params.TargetBlockGas
+	if gasUsed > 2*target {
+		gasUsed = 2 * target
+	}
+
+	var next sdk.Dec
+	switch {
+	case gasUsed > target:
+		delta := baseFee.MulInt64(int64(gasUsed - target)).QuoInt64(int64(target)).QuoInt64(int64(params.BaseFeeChangeDenominator))
+		next = baseFee.Add(sdk.MaxDec(delta, sdk.SmallestDec()))
+	case gasUsed < target:
+		delta := baseFee.MulInt64(int64(target - gasUsed)).QuoInt64(int64(target)).QuoInt64(int64(params.BaseFeeChangeDenominator))
+		next = baseFee.Sub(delta)
+	default:
+		next = baseFee
+	}
+
+	return sdk.MaxDec(next, params.MinBaseFee)
+}
+
+// RequiredFee returns the fee a tx of the given gas limit pays at least, where
+// fee = ceil(baseFee * gasLimit).
+func RequiredFee(params Params, baseFee sdk.Dec, gas uint64) sdk.Coin {
+	fee := baseFee.MulInt64(int64(gas))
+	return sdk.NewCoin(params.BaseFeeDenom, fee.Ceil().RoundInt())
+}
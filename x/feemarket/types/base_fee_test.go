@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	params := DefaultParams()
+	params.TargetBlockGas = 1000
+	params.MinBaseFee = sdk.NewDecWithPrec(5, 1)
+	baseFee := sdk.NewDec(8)
+
+	tests := []struct {
+		gasUsed uint64
+		exp     sdk.Dec
+	}{
+		{1000, sdk.NewDec(8)},
+		// at most 1/8 of the base fee up or down
+		{2000, sdk.NewDec(9)},
+		{5000, sdk.NewDec(9)},
+		{1500, sdk.NewDecWithPrec(85, 1)},
+		{500, sdk.NewDecWithPrec(75, 1)},
+		{0, sdk.NewDec(7)},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.exp, NextBaseFee(params, baseFee, tc.gasUsed), "gas used %d", tc.gasUsed)
+	}
+
+	// the base fee stays at least the min base fee
+	require.Equal(t, params.MinBaseFee, NextBaseFee(params, sdk.NewDecWithPrec(5, 1), 0))
+	// and grows from zero
+	params.MinBaseFee = sdk.ZeroDec()
+	require.Equal(t, sdk.SmallestDec(), NextBaseFee(params, sdk.ZeroDec(), 1001))
+}
+
+func TestRequiredFee(t *testing.T) {
+	params := DefaultParams()
+	require.Equal(t, sdk.NewInt64Coin(params.BaseFeeDenom, 34), RequiredFee(params, sdk.NewDecWithPrec(333, 3), 100))
+	require.Equal(t, sdk.NewInt64Coin(params.BaseFeeDenom, 0), RequiredFee(params, sdk.ZeroDec(), 100))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	require.NoError(t, ValidateGenesis(*DefaultGenesisState()))
+
+	params := DefaultParams()
+	params.MinBaseFee = sdk.OneDec()
+	require.Error(t, ValidateGenesis(*NewGenesisState(params, sdk.NewDecWithPrec(5, 1))))
+	require.NoError(t, ValidateGenesis(*NewGenesisState(params, sdk.NewDec(2))))
+
+	params.BaseFeeChangeDenominator = 0
+	require.Error(t, ValidateGenesis(*NewGenesisState(params, sdk.NewDec(2))))
+}
@@ -0,0 +1,166 @@
+package genutil
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// FlagAppStateFile is the flag of the file the app state of the genesis is
+// read from, one module at a time, instead of the genesis file.
+const FlagAppStateFile = "x-genutil-app-state-file"
+
+// AddModuleInitFlags implements servertypes.ModuleInitFlags interface.
+func AddModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().String(FlagAppStateFile, "", "Read the app state of the genesis from this file, one module at a time, instead of the genesis file")
+}
+
+// AppStateWriter writes the app state of a genesis, the JSON object of the
+// genesis states of the modules, one module at a time, so that the genesis
+// states of all the modules are never in memory at once.
+type AppStateWriter struct {
+	w       *bufio.Writer
+	modules map[string]bool
+}
+
+// NewAppStateWriter returns an AppStateWriter writing to w.
+func NewAppStateWriter(w io.Writer) *AppStateWriter {
+	return &AppStateWriter{
+		w:       bufio.NewWriter(w),
+		modules: make(map[string]bool),
+	}
+}
+
+// WriteModule writes the genesis state of a module.
+func (aw *AppStateWriter) WriteModule(moduleName string, state json.RawMessage) error {
+	if aw.modules[moduleName] {
+		return fmt.Errorf("genesis state of module %s already written", moduleName)
+	}
+	sep := ",\n"
+	if len(aw.modules) == 0 {
+		sep = "{\n"
+	}
+	aw.modules[moduleName] = true
+
+	name, err := json.Marshal(moduleName)
+	if err != nil {
+		return err
+	}
+	if _, err := aw.w.WriteString(sep); err != nil {
+		return err
+	}
+	if _, err := aw.w.Write(name); err != nil {
+		return err
+	}
+	if err := aw.w.WriteByte(':'); err != nil {
+		return err
+	}
+	// a module without genesis state is written as null, like json.Marshal does
+	if len(state) == 0 {
+		state = json.RawMessage("null")
+	}
+	if _, err := aw.w.Write(state); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close ends the app state and flushes it to the underlying writer, which is
+// not closed.
+func (aw *AppStateWriter) Close() error {
+	end := "\n}\n"
+	if len(aw.modules) == 0 {
+		end = "{}\n"
+	}
+	if _, err := aw.w.WriteString(end); err != nil {
+		return err
+	}
+	return aw.w.Flush()
+}
+
+// appStateSection is the location of the genesis state of a module in an app
+// state.
+type appStateSection struct {
+	offset int64
+	size   int64
+}
+
+// AppStateReader reads the genesis states of the modules from an app state,
+// only loading the genesis state of a module when it is read.
+type AppStateReader struct {
+	r        io.ReaderAt
+	sections map[string]appStateSection
+}
+
+// NewAppStateReader indexes the genesis states of the modules in the app state
+// of the given size read from r. The genesis states are scanned one at a time.
+func NewAppStateReader(r io.ReaderAt, size int64) (*AppStateReader, error) {
+	dec := json.NewDecoder(io.NewSectionReader(r, 0, size))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("app state must be a JSON object")
+	}
+
+	sections := make(map[string]appStateSection)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		moduleName := tok.(string)
+		if _, ok := sections[moduleName]; ok {
+			return nil, fmt.Errorf("duplicate genesis state of module %s", moduleName)
+		}
+
+		var state json.RawMessage
+		if err := dec.Decode(&state); err != nil {
+			return nil, fmt.Errorf("invalid genesis state of module %s: %w", moduleName, err)
+		}
+		// the raw message is the exact input the decoder has just consumed
+		end := dec.InputOffset()
+		sections[moduleName] = appStateSection{offset: end - int64(len(state)), size: int64(len(state))}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return &AppStateReader{r: r, sections: sections}, nil
+}
+
+// ModuleState returns the genesis state of a module, nil if it has none.
+func (ar *AppStateReader) ModuleState(moduleName string) (json.RawMessage, error) {
+	section, ok := ar.sections[moduleName]
+	if !ok {
+		return nil, nil
+	}
+	state := make(json.RawMessage, section.size)
+	if _, err := ar.r.ReadAt(state, section.offset); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ReadAppStateFile opens the app state file at path, calls fn with a reader of
+// it and closes it.
+func ReadAppStateFile(path string, fn func(*AppStateReader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	ar, err := NewAppStateReader(f, info.Size())
+	if err != nil {
+		return fmt.Errorf("failed to read app state file %s: %w", path, err)
+	}
+	return fn(ar)
+}
@@ -0,0 +1,98 @@
+package genutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppStateWriterReader(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	aw := NewAppStateWriter(&buf)
+	require.NoError(t, aw.WriteModule("bank", json.RawMessage(`{"balances":[{"address":"addr","coins":[]}]}`)))
+	require.NoError(t, aw.WriteModule("params", nil))
+	require.NoError(t, aw.WriteModule("auth", json.RawMessage(`{"accounts":[]}`)))
+	require.Error(t, aw.WriteModule("bank", json.RawMessage(`{}`)))
+	require.NoError(t, aw.Close())
+
+	// the written app state is the JSON object of the genesis states
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &appState))
+	require.Len(t, appState, 3)
+
+	ar, err := NewAppStateReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	for name, state := range appState {
+		moduleState, err := ar.ModuleState(name)
+		require.NoError(t, err)
+		require.Equal(t, state, moduleState)
+	}
+	moduleState, err := ar.ModuleState("gov")
+	require.NoError(t, err)
+	require.Nil(t, moduleState)
+}
+
+func TestEmptyAppState(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewAppStateWriter(&buf).Close())
+	require.Equal(t, "{}\n", buf.String())
+
+	ar, err := NewAppStateReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	moduleState, err := ar.ModuleState("bank")
+	require.NoError(t, err)
+	require.Nil(t, moduleState)
+}
+
+func TestAppStateReaderIndentedAppState(t *testing.T) {
+	t.Parallel()
+
+	appState := "{\n  \"auth\": {\n    \"accounts\": []\n  },\n  \"bank\" :  [1, 2]\n}"
+	ar, err := NewAppStateReader(bytes.NewReader([]byte(appState)), int64(len(appState)))
+	require.NoError(t, err)
+
+	moduleState, err := ar.ModuleState("auth")
+	require.NoError(t, err)
+	require.Equal(t, "{\n    \"accounts\": []\n  }", string(moduleState))
+	moduleState, err = ar.ModuleState("bank")
+	require.NoError(t, err)
+	require.Equal(t, "[1, 2]", string(moduleState))
+}
+
+func TestAppStateReaderInvalidAppState(t *testing.T) {
+	t.Parallel()
+
+	for _, appState := range []string{
+		``,
+		`[]`,
+		`{"auth":{}, "auth":{}}`,
+		`{"auth":{}`,
+		`{"auth":{"accounts":}}`,
+	} {
+		_, err := NewAppStateReader(bytes.NewReader([]byte(appState)), int64(len(appState)))
+		require.Error(t, err, appState)
+	}
+}
+
+func TestReadAppStateFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app_state.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"auth":{"accounts":[]}}`), 0o600))
+
+	require.NoError(t, ReadAppStateFile(path, func(ar *AppStateReader) error {
+		moduleState, err := ar.ModuleState("auth")
+		require.NoError(t, err)
+		require.Equal(t, `{"accounts":[]}`, string(moduleState))
+		return nil
+	}))
+	require.Error(t, ReadAppStateFile(filepath.Join(t.TempDir(), "missing.json"), func(*AppStateReader) error { return nil }))
+}
@@ -0,0 +1,206 @@
+package genutil
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/store/export"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// The kinds of the rows of a balances snapshot.
+const (
+	SnapshotRowBalance    = "balance"
+	SnapshotRowDelegation = "delegation"
+)
+
+// The formats of a balances snapshot.
+const (
+	// SnapshotFormatCSV writes a header and then a line per row, with the
+	// columns kind, address, validator, denom, amount and shares.
+	SnapshotFormatCSV = "csv"
+	// SnapshotFormatJSONL writes a SnapshotRow per line as a JSON object.
+	SnapshotFormatJSONL = "jsonl"
+)
+
+// SnapshotRow is a balance or a delegation of a balances snapshot. The amount of
+// a delegation is the number of bonded tokens its shares are worth, truncated.
+type SnapshotRow struct {
+	Kind      string `json:"kind"`
+	Address   string `json:"address"`
+	Validator string `json:"validator,omitempty"`
+	Denom     string `json:"denom"`
+	Amount    string `json:"amount"`
+	Shares    string `json:"shares,omitempty"`
+}
+
+// SnapshotWriter writes the rows of a balances snapshot. Close must be called
+// once all of them are written, and does not close the underlying io.Writer.
+type SnapshotWriter interface {
+	Write(row SnapshotRow) error
+	Close() error
+}
+
+// NewSnapshotWriter returns a SnapshotWriter encoding the rows written to w in
+// format, either SnapshotFormatCSV or SnapshotFormatJSONL.
+func NewSnapshotWriter(w io.Writer, format string) (SnapshotWriter, error) {
+	switch format {
+	case SnapshotFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"kind", "address", "validator", "denom", "amount", "shares"}); err != nil {
+			return nil, err
+		}
+		return csvSnapshotWriter{w: cw}, nil
+	case SnapshotFormatJSONL:
+		buf := bufio.NewWriter(w)
+		return jsonlSnapshotWriter{buf: buf, enc: json.NewEncoder(buf)}, nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot format %q, expected %s or %s", format, SnapshotFormatCSV, SnapshotFormatJSONL)
+	}
+}
+
+type csvSnapshotWriter struct {
+	w *csv.Writer
+}
+
+func (cw csvSnapshotWriter) Write(row SnapshotRow) error {
+	return cw.w.Write([]string{row.Kind, row.Address, row.Validator, row.Denom, row.Amount, row.Shares})
+}
+
+func (cw csvSnapshotWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type jsonlSnapshotWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (jw jsonlSnapshotWriter) Write(row SnapshotRow) error {
+	return jw.enc.Encode(row)
+}
+
+func (jw jsonlSnapshotWriter) Close() error {
+	return jw.buf.Flush()
+}
+
+// WriteBalancesSnapshot writes every balance of the bank store to w, followed by
+// every delegation of the staking store if delegations is set, as stored at
+// height, 0 being the latest committed height. The rows are written as the
+// stores are iterated, so that the snapshot of a large state is never held in
+// memory. It returns the number of rows written.
+func WriteBalancesSnapshot(ms storetypes.CommitMultiStore, height int64, delegations bool, w SnapshotWriter) (int64, error) {
+	bank, err := export.KVStoreAt(ms, height, banktypes.StoreKey)
+	if err != nil {
+		return 0, err
+	}
+	count, err := writeBalances(bank, w)
+	if err != nil || !delegations {
+		return count, err
+	}
+
+	staking, err := export.KVStoreAt(ms, height, stakingtypes.StoreKey)
+	if err != nil {
+		return count, err
+	}
+	params, err := export.KVStoreAt(ms, height, paramstypes.StoreKey)
+	if err != nil {
+		return count, err
+	}
+	n, err := writeDelegations(staking, params, w)
+	return count + n, err
+}
+
+func writeBalances(bank storetypes.KVStore, w SnapshotWriter) (int64, error) {
+	itr := storetypes.KVStorePrefixIterator(bank, banktypes.BalancesPrefix)
+	defer itr.Close()
+
+	var count int64
+	for ; itr.Valid(); itr.Next() {
+		addr, err := banktypes.AddressFromBalancesStore(itr.Key()[len(banktypes.BalancesPrefix):])
+		if err != nil {
+			return count, err
+		}
+		var balance sdk.Coin
+		if err := balance.Unmarshal(itr.Value()); err != nil {
+			return count, fmt.Errorf("invalid balance of %s: %w", addr, err)
+		}
+		err = w.Write(SnapshotRow{
+			Kind:    SnapshotRowBalance,
+			Address: addr.String(),
+			Denom:   balance.Denom,
+			Amount:  balance.Amount.String(),
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func writeDelegations(staking, params storetypes.KVStore, w SnapshotWriter) (int64, error) {
+	var bondDenom string
+	bz := params.Get(append([]byte(stakingtypes.ModuleName+"/"), stakingtypes.KeyBondDenom...))
+	if bz == nil {
+		return 0, fmt.Errorf("no bond denom in the staking params")
+	}
+	if err := json.Unmarshal(bz, &bondDenom); err != nil {
+		return 0, fmt.Errorf("invalid bond denom: %w", err)
+	}
+
+	// the delegations are keyed by delegator, so the validators are looked up
+	// once each and kept for the delegations to them
+	validators := make(map[string]stakingtypes.Validator)
+	itr := storetypes.KVStorePrefixIterator(staking, stakingtypes.DelegationKey)
+	defer itr.Close()
+
+	var count int64
+	for ; itr.Valid(); itr.Next() {
+		var delegation stakingtypes.Delegation
+		if err := delegation.Unmarshal(itr.Value()); err != nil {
+			return count, fmt.Errorf("invalid delegation %X: %w", itr.Key(), err)
+		}
+		validator, ok := validators[delegation.ValidatorAddress]
+		if !ok {
+			valAddr, err := sdk.ValAddressFromBech32(delegation.ValidatorAddress)
+			if err != nil {
+				return count, err
+			}
+			bz := staking.Get(stakingtypes.GetValidatorKey(valAddr))
+			if bz == nil {
+				return count, fmt.Errorf("no validator %s of the delegation of %s", delegation.ValidatorAddress, delegation.DelegatorAddress)
+			}
+			if err := validator.Unmarshal(bz); err != nil {
+				return count, fmt.Errorf("invalid validator %s: %w", delegation.ValidatorAddress, err)
+			}
+			validators[delegation.ValidatorAddress] = validator
+		}
+
+		amount := sdk.ZeroInt()
+		if !validator.DelegatorShares.IsZero() {
+			amount = validator.TokensFromSharesTruncated(delegation.Shares).TruncateInt()
+		}
+		err := w.Write(SnapshotRow{
+			Kind:      SnapshotRowDelegation,
+			Address:   delegation.DelegatorAddress,
+			Validator: delegation.ValidatorAddress,
+			Denom:     bondDenom,
+			Amount:    amount.String(),
+			Shares:    delegation.Shares.String(),
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
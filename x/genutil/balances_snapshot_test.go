@@ -0,0 +1,90 @@
+package genutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestWriteBalancesSnapshot(t *testing.T) {
+	bankKey := storetypes.NewKVStoreKey(banktypes.StoreKey)
+	stakingKey := storetypes.NewKVStoreKey(stakingtypes.StoreKey)
+	paramsKey := storetypes.NewKVStoreKey(paramstypes.StoreKey)
+	ms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+	for _, key := range []storetypes.StoreKey{bankKey, stakingKey, paramsKey} {
+		ms.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, ms.LoadLatestVersion())
+
+	alice, bob := sdk.AccAddress("alice_______________"), sdk.AccAddress("bob_________________")
+	valAddr := sdk.ValAddress("validator___________")
+	setBalance := func(addr sdk.AccAddress, coin sdk.Coin) {
+		bz, err := coin.Marshal()
+		require.NoError(t, err)
+		ms.GetKVStore(bankKey).Set(banktypes.CreatePrefixedAccountStoreKey(addr, []byte(coin.Denom)), bz)
+	}
+	setBalance(alice, sdk.NewInt64Coin("usei", 100))
+	setBalance(alice, sdk.NewInt64Coin("uatom", 5))
+	setBalance(bob, sdk.NewInt64Coin("usei", 7))
+
+	// the validator has 300 tokens for 600 shares
+	validator := stakingtypes.Validator{OperatorAddress: valAddr.String(), Tokens: sdk.NewInt(300), DelegatorShares: sdk.NewDec(600)}
+	bz, err := validator.Marshal()
+	require.NoError(t, err)
+	ms.GetKVStore(stakingKey).Set(stakingtypes.GetValidatorKey(valAddr), bz)
+	delegation := stakingtypes.NewDelegation(bob, valAddr, sdk.NewDec(101))
+	bz, err = delegation.Marshal()
+	require.NoError(t, err)
+	ms.GetKVStore(stakingKey).Set(stakingtypes.GetDelegationKey(bob, valAddr), bz)
+	ms.GetKVStore(paramsKey).Set([]byte("staking/BondDenom"), []byte(`"usei"`))
+	ms.Commit(true)
+
+	// the balances changed after the height of the snapshot are left out
+	setBalance(bob, sdk.NewInt64Coin("usei", 1000))
+	ms.Commit(true)
+
+	var buf bytes.Buffer
+	w, err := genutil.NewSnapshotWriter(&buf, genutil.SnapshotFormatCSV)
+	require.NoError(t, err)
+	count, err := genutil.WriteBalancesSnapshot(ms, 1, true, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, int64(4), count)
+	require.Equal(t, "kind,address,validator,denom,amount,shares\n"+
+		"balance,"+alice.String()+",,uatom,5,\n"+
+		"balance,"+alice.String()+",,usei,100,\n"+
+		"balance,"+bob.String()+",,usei,7,\n"+
+		"delegation,"+bob.String()+","+valAddr.String()+",usei,50,101.000000000000000000\n", buf.String())
+
+	buf.Reset()
+	w, err = genutil.NewSnapshotWriter(&buf, genutil.SnapshotFormatJSONL)
+	require.NoError(t, err)
+	count, err = genutil.WriteBalancesSnapshot(ms, 0, false, w)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, int64(3), count)
+	var rows []genutil.SnapshotRow
+	for dec := json.NewDecoder(&buf); dec.More(); {
+		var row genutil.SnapshotRow
+		require.NoError(t, dec.Decode(&row))
+		rows = append(rows, row)
+	}
+	require.Equal(t, genutil.SnapshotRow{Kind: genutil.SnapshotRowBalance, Address: bob.String(), Denom: "usei", Amount: "1000"}, rows[2])
+
+	_, err = genutil.WriteBalancesSnapshot(ms, 3, true, w)
+	require.Error(t, err)
+	_, err = genutil.NewSnapshotWriter(&buf, "xml")
+	require.Error(t, err)
+}
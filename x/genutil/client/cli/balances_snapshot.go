@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+)
+
+const (
+	flagSnapshotFormat        = "format"
+	flagSnapshotOutput        = "output"
+	flagSnapshotNoDelegations = "no-delegations"
+)
+
+// BalancesSnapshotCmd returns a command that writes the balances and the
+// delegations of every account at a given height, e.g. for an airdrop.
+func BalancesSnapshotCmd(appCreator servertypes.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balances-snapshot",
+		Short: "Write the balances and delegations of every account at a given height",
+		Long: `Walk the bank and staking stores as stored at --height and write a row per
+balance of an account and per delegation, as they are read, so that the
+snapshot of a large state is never held in memory.
+
+The amount of a delegation is the number of bonded tokens its shares are worth
+at that height, truncated. The balances include those of the module accounts.
+
+With --format csv, the rows have the columns kind, address, validator, denom,
+amount and shares. With --format jsonl, each line is a JSON object with those
+fields.
+
+The node must be stopped, or the command pointed at a copy of its data, and
+the height must not have been pruned.
+`,
+		Example: "balances-snapshot --height 1000000 --format csv --output snapshot.csv",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			height, err := cmd.Flags().GetInt64(server.FlagHeight)
+			if err != nil {
+				return err
+			}
+			if height < 0 {
+				return fmt.Errorf("invalid height %d", height)
+			}
+			format, err := cmd.Flags().GetString(flagSnapshotFormat)
+			if err != nil {
+				return err
+			}
+			noDelegations, err := cmd.Flags().GetBool(flagSnapshotNoDelegations)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(flagSnapshotOutput)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			w, err := genutil.NewSnapshotWriter(out, format)
+			if err != nil {
+				return err
+			}
+
+			ctx := server.GetServerContextFromCmd(cmd)
+			db, err := server.OpenDB(ctx.Config.RootDir, server.GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			app := appCreator(ctx.Logger, db, nil, ctx.Config, ctx.Viper)
+			defer app.Close()
+
+			count, err := genutil.WriteBalancesSnapshot(app.CommitMultiStore(), height, !noDelegations, w)
+			if err != nil {
+				return err
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			if output != "" {
+				cmd.Printf("wrote %d rows to %s\n", count, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(server.FlagHeight, 0, "Height to take the snapshot at, 0 for the latest height")
+	cmd.Flags().String(flagSnapshotFormat, genutil.SnapshotFormatCSV, "Output format (csv|jsonl)")
+	cmd.Flags().String(flagSnapshotOutput, "", "File to write the snapshot to, defaults to stdout")
+	cmd.Flags().Bool(flagSnapshotNoDelegations, false, "Leave the delegations out of the snapshot")
+	return cmd
+}
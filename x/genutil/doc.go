@@ -5,6 +5,7 @@ for usage within a blockchain application. Namely:
     - commands for collection and creation of gentxs
 	- initchain processing of gentxs
  - Genesis file validation
+ - App state files, written and read one module at a time
  - Tendermint related initialization
 */
 package genutil
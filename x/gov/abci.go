@@ -53,8 +53,11 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 		// If an expedited proposal fails, we do not want to update
 		// the deposit at this point since the proposal is converted to regular.
 		// As a result, the deposits are either deleted or refunded in all casses
-		// EXCEPT when an expedited proposal fails.
+		// EXCEPT when an expedited proposal fails. The votes are kept as well,
+		// so that they carry over to the regular voting period.
 		if !(proposal.IsExpedited && !passes) {
+			keeper.DeleteVotes(ctx, proposal.ProposalId)
+
 			if burnDeposits {
 				keeper.DeleteDeposits(ctx, proposal.ProposalId)
 			} else {
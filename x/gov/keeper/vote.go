@@ -129,6 +129,19 @@ func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID uint64, voterAddr sd
 	store.Delete(types.VoteKey(proposalID, voterAddr))
 }
 
+// DeleteVotes deletes all the votes of a given proposalID from the store
+func (keeper Keeper) DeleteVotes(ctx sdk.Context, proposalID uint64) {
+	var voters []sdk.AccAddress
+	keeper.IterateVotes(ctx, proposalID, func(vote types.Vote) bool {
+		voters = append(voters, sdk.MustAccAddressFromBech32(vote.Voter))
+		return false
+	})
+
+	for _, voter := range voters {
+		keeper.deleteVote(ctx, proposalID, voter)
+	}
+}
+
 // populateLegacyOption adds graceful fallback of deprecated `Option` field, in case
 // there's only 1 VoteOption.
 func populateLegacyOption(vote *types.Vote) {
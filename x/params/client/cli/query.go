@@ -23,6 +23,7 @@ func NewQueryCmd() *cobra.Command {
 
 	cmd.AddCommand(NewQuerySubspaceParamsCmd())
 	cmd.AddCommand(NewQueryFeeParamsCmd())
+	cmd.AddCommand(NewQueryStoreGasParamsCmd())
 	cmd.AddCommand(NewQueryBlockParamsCmd())
 
 	return cmd
@@ -87,6 +88,37 @@ func NewQueryFeeParamsCmd() *cobra.Command {
 	return cmd
 }
 
+func NewQueryStoreGasParamsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storegasparams",
+		Short: "Query for the gas costs of the store operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := proposal.NewQueryClient(clientCtx)
+
+			params := proposal.QueryParamsRequest{Subspace: types.ModuleName, Key: string(types.ParamStoreKeyStoreGasParams)}
+			res, err := queryClient.Params(cmd.Context(), &params)
+			if err != nil {
+				return err
+			}
+
+			storeGasParams := types.StoreGasParams{}
+			if err := clientCtx.Codec.UnmarshalJSON([]byte(res.Param.Value), &storeGasParams); err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(&storeGasParams)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
 func NewQueryBlockParamsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "blockparams",
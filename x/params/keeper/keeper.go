@@ -18,6 +18,7 @@ type Keeper struct {
 	key         sdk.StoreKey
 	tkey        sdk.StoreKey
 	spaces      map[string]*types.Subspace
+	validators  map[string]types.ParamChangeValidator
 }
 
 // NewKeeper constructs a params keeper
@@ -29,6 +30,7 @@ func NewKeeper(cdc codec.BinaryCodec, legacyAmino *codec.LegacyAmino, key, tkey
 		key:         key,
 		tkey:        tkey,
 		spaces:      make(map[string]*types.Subspace),
+		validators:  make(map[string]types.ParamChangeValidator),
 	}
 
 	newKeeper.Subspace(types.ModuleName).WithKeyTable(types.ParamKeyTable())
@@ -59,6 +61,25 @@ func (k Keeper) GetFeesParams(ctx sdk.Context) types.FeesParams {
 	return feesParams
 }
 
+func (k Keeper) SetStoreGasParams(ctx sdk.Context, storeGasParams types.StoreGasParams) {
+	subspace, exist := k.GetSubspace(types.ModuleName)
+	if !exist {
+		panic("subspace params should exist")
+	}
+	subspace.Set(ctx, types.ParamStoreKeyStoreGasParams, storeGasParams)
+}
+
+// GetStoreGasParams returns the gas costs of the KVStore operations, the
+// default ones if they were never set, e.g. on chains started before they
+// were governable.
+func (k Keeper) GetStoreGasParams(ctx sdk.Context) types.StoreGasParams {
+	subspace, _ := k.GetSubspace(types.ModuleName)
+
+	storeGasParams := *types.DefaultStoreGasParams()
+	subspace.GetIfExists(ctx, types.ParamStoreKeyStoreGasParams, &storeGasParams)
+	return storeGasParams
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", "x/"+proposal.ModuleName)
@@ -88,3 +109,22 @@ func (k Keeper) GetSubspace(s string) (types.Subspace, bool) {
 	}
 	return *space, ok
 }
+
+// RegisterParamChangeValidator registers the validator of the parameters of the
+// subspace changed by a parameter change proposal.
+func (k Keeper) RegisterParamChangeValidator(s string, validator types.ParamChangeValidator) {
+	if _, ok := k.spaces[s]; !ok {
+		panic("cannot register a validator for an unknown subspace")
+	}
+	if _, ok := k.validators[s]; ok {
+		panic("validator of subspace already registered")
+	}
+	k.validators[s] = validator
+}
+
+// GetParamChangeValidator returns the validator of the parameters of the
+// subspace changed by a parameter change proposal, if any.
+func (k Keeper) GetParamChangeValidator(s string) (types.ParamChangeValidator, bool) {
+	validator, ok := k.validators[s]
+	return validator, ok
+}
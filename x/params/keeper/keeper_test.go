@@ -261,3 +261,44 @@ func TestJSONUpdate(t *testing.T) {
 	space.Get(ctx, key, &param)
 	require.Equal(t, paramJSON{40964096, "goodbyeworld"}, param)
 }
+
+func TestRegisterParamChangeValidator(t *testing.T) {
+	_, _, _, _, keeper := testComponents()
+	keeper.Subspace("test")
+
+	validator := func(sdk.Context, types.Subspace) error { return nil }
+	require.Panics(t, func() { keeper.RegisterParamChangeValidator("unknown", validator) })
+
+	_, ok := keeper.GetParamChangeValidator("test")
+	require.False(t, ok)
+	keeper.RegisterParamChangeValidator("test", validator)
+	_, ok = keeper.GetParamChangeValidator("test")
+	require.True(t, ok)
+	require.Panics(t, func() { keeper.RegisterParamChangeValidator("test", validator) })
+}
+
+func (suite *KeeperTestSuite) TestStoreGasParams() {
+	// the default costs are returned until the params are set
+	suite.Require().Equal(*types.DefaultStoreGasParams(), suite.app.ParamsKeeper.GetStoreGasParams(suite.ctx))
+
+	params := *types.DefaultStoreGasParams()
+	params.WriteCostPerByte = 60
+	suite.app.ParamsKeeper.SetStoreGasParams(suite.ctx, params)
+	suite.Require().Equal(params, suite.app.ParamsKeeper.GetStoreGasParams(suite.ctx))
+	suite.Require().Equal(sdk.Gas(60), params.GasConfig().WriteCostPerByte)
+
+	params.IterNextCostFlat = 0
+	suite.Require().EqualError(params.Validate(), "iter next cost flat must be positive")
+	subspace, _ := suite.app.ParamsKeeper.GetSubspace(types.ModuleName)
+	suite.Require().Error(subspace.Validate(suite.ctx, types.ParamStoreKeyStoreGasParams, params))
+}
+
+func (suite *KeeperTestSuite) TestExecutionParams() {
+	// the params are set by a param change proposal of the baseapp subspace
+	subspace, ok := suite.app.ParamsKeeper.GetSubspace(baseapp.Paramspace)
+	suite.Require().True(ok)
+	suite.Require().NoError(subspace.Update(suite.ctx, baseapp.ParamStoreKeyExecutionParams, []byte(`{"sequential_from_height": "100"}`)))
+	suite.Require().Equal(baseapp.ExecutionParams{SequentialFromHeight: 100}, suite.app.GetExecutionParams(suite.ctx))
+
+	suite.Require().Error(subspace.Update(suite.ctx, baseapp.ParamStoreKeyExecutionParams, []byte(`{"sequential_from_height": "-1"}`)))
+}
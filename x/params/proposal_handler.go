@@ -24,11 +24,15 @@ func NewParamChangeProposalHandler(k keeper.Keeper) govtypes.Handler {
 }
 
 func handleParameterChangeProposal(ctx sdk.Context, k keeper.Keeper, p *proposal.ParameterChangeProposal) error {
+	var changed []string
 	for _, c := range p.Changes {
 		ss, ok := k.GetSubspace(c.Subspace)
 		if !ok {
 			return sdkerrors.Wrap(proposal.ErrUnknownSubspace, c.Subspace)
 		}
+		if !ss.IsRegistered([]byte(c.Key)) {
+			return sdkerrors.Wrapf(proposal.ErrUnknownKey, "subspace: %s, key: %s", c.Subspace, c.Key)
+		}
 
 		k.Logger(ctx).Info(
 			fmt.Sprintf("attempt to set new parameter value; key: %s, value: %s", c.Key, c.Value),
@@ -37,7 +41,31 @@ func handleParameterChangeProposal(ctx sdk.Context, k keeper.Keeper, p *proposal
 		if err := ss.Update(ctx, []byte(c.Key), []byte(c.Value)); err != nil {
 			return sdkerrors.Wrapf(proposal.ErrSettingParameter, "key: %s, value: %s, err: %s", c.Key, c.Value, err.Error())
 		}
+		if !contains(changed, c.Subspace) {
+			changed = append(changed, c.Subspace)
+		}
+	}
+
+	// validate the parameters of each changed subspace with all the changes applied
+	for _, s := range changed {
+		validate, ok := k.GetParamChangeValidator(s)
+		if !ok {
+			continue
+		}
+		ss, _ := k.GetSubspace(s)
+		if err := validate(ctx, ss); err != nil {
+			return sdkerrors.Wrapf(proposal.ErrInvalidParams, "subspace: %s, err: %s", s, err.Error())
+		}
 	}
 
 	return nil
 }
+
+func contains(subspaces []string, s string) bool {
+	for _, ss := range subspaces {
+		if ss == s {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/simapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -88,6 +89,31 @@ func (suite *HandlerTestSuite) TestProposalHandler() {
 			},
 			true,
 		},
+		{
+			"unknown key",
+			testProposal(proposal.NewParamChange(stakingtypes.ModuleName, "UnknownKey", "1")),
+			func() {},
+			true,
+		},
+		{
+			"parameter set invalid once changed",
+			testProposal(proposal.NewParamChange(minttypes.ModuleName, string(minttypes.KeyInflationMin), `"0.500000000000000000"`)),
+			func() {},
+			true,
+		},
+		{
+			"parameter set valid once all changes applied",
+			testProposal(
+				proposal.NewParamChange(minttypes.ModuleName, string(minttypes.KeyInflationMin), `"0.500000000000000000"`),
+				proposal.NewParamChange(minttypes.ModuleName, string(minttypes.KeyInflationMax), `"0.600000000000000000"`),
+			),
+			func() {
+				params := suite.app.MintKeeper.GetParams(suite.ctx)
+				suite.Require().Equal(sdk.NewDecWithPrec(5, 1), params.InflationMin)
+				suite.Require().Equal(sdk.NewDecWithPrec(6, 1), params.InflationMax)
+			},
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -103,3 +129,15 @@ func (suite *HandlerTestSuite) TestProposalHandler() {
 		})
 	}
 }
+
+func (suite *HandlerTestSuite) TestSubmitInvalidProposal() {
+	// the changes are validated when the proposal is submitted
+	_, err := suite.app.GovKeeper.SubmitProposal(suite.ctx, testProposal(
+		proposal.NewParamChange(minttypes.ModuleName, string(minttypes.KeyInflationMin), `"0.500000000000000000"`),
+	))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+	suite.Require().Contains(err.Error(), proposal.ErrInvalidParams.Error())
+
+	// the state is left untouched
+	suite.Require().Equal(minttypes.DefaultParams().InflationMin, suite.app.MintKeeper.GetParams(suite.ctx).InflationMin)
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -12,13 +13,24 @@ func DefaultFeesParams() *FeesParams {
 	}
 }
 
+// DefaultStoreGasParams returns the store gas params of the default costs of
+// the KVStores.
+func DefaultStoreGasParams() *StoreGasParams {
+	params := NewStoreGasParams(storetypes.KVGasConfig())
+	return &params
+}
+
 // DefaultGenesis returns the default Capability genesis state
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
-		FeesParams: *DefaultFeesParams(),
+		FeesParams:     *DefaultFeesParams(),
+		StoreGasParams: *DefaultStoreGasParams(),
 	}
 }
 
 func (gs GenesisState) Validate() error {
-	return gs.FeesParams.Validate()
+	if err := gs.FeesParams.Validate(); err != nil {
+		return err
+	}
+	return gs.StoreGasParams.Validate()
 }
@@ -3,10 +3,14 @@ package types
 import (
 	"fmt"
 
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-var ParamStoreKeyFeesParams = []byte("FeesParams")
+var (
+	ParamStoreKeyFeesParams     = []byte("FeesParams")
+	ParamStoreKeyStoreGasParams = []byte("StoreGasParams")
+)
 
 func NewFeesParams(minGasPrices sdk.DecCoins) FeesParams {
 	return FeesParams{
@@ -18,6 +22,7 @@ func NewFeesParams(minGasPrices sdk.DecCoins) FeesParams {
 func ParamKeyTable() KeyTable {
 	return NewKeyTable(
 		NewParamSetPair(ParamStoreKeyFeesParams, &FeesParams{}, validateFeesParams),
+		NewParamSetPair(ParamStoreKeyStoreGasParams, &StoreGasParams{}, validateStoreGasParams),
 	)
 }
 
@@ -41,3 +46,56 @@ func validateFeesParams(i interface{}) error {
 	}
 	return nil
 }
+
+// NewStoreGasParams returns the store gas params of the costs of gasConfig.
+func NewStoreGasParams(gasConfig storetypes.GasConfig) StoreGasParams {
+	return StoreGasParams{
+		HasCost:          gasConfig.HasCost,
+		DeleteCost:       gasConfig.DeleteCost,
+		ReadCostFlat:     gasConfig.ReadCostFlat,
+		ReadCostPerByte:  gasConfig.ReadCostPerByte,
+		WriteCostFlat:    gasConfig.WriteCostFlat,
+		WriteCostPerByte: gasConfig.WriteCostPerByte,
+		IterNextCostFlat: gasConfig.IterNextCostFlat,
+	}
+}
+
+// GasConfig returns the gas config the KVStores charge the costs of the params
+// with.
+func (sp StoreGasParams) GasConfig() storetypes.GasConfig {
+	return storetypes.GasConfig{
+		HasCost:          sp.HasCost,
+		DeleteCost:       sp.DeleteCost,
+		ReadCostFlat:     sp.ReadCostFlat,
+		ReadCostPerByte:  sp.ReadCostPerByte,
+		WriteCostFlat:    sp.WriteCostFlat,
+		WriteCostPerByte: sp.WriteCostPerByte,
+		IterNextCostFlat: sp.IterNextCostFlat,
+	}
+}
+
+// Validate checks that none of the flat costs is free, which would let the txs
+// access the state for free.
+func (sp *StoreGasParams) Validate() error {
+	for name, cost := range map[string]uint64{
+		"has cost":            sp.HasCost,
+		"delete cost":         sp.DeleteCost,
+		"read cost flat":      sp.ReadCostFlat,
+		"write cost flat":     sp.WriteCostFlat,
+		"iter next cost flat": sp.IterNextCostFlat,
+	} {
+		if cost == 0 {
+			return fmt.Errorf("%s must be positive", name)
+		}
+	}
+	return nil
+}
+
+func validateStoreGasParams(i interface{}) error {
+	v, ok := i.(StoreGasParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return v.Validate()
+}
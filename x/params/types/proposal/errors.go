@@ -12,4 +12,6 @@ var (
 	ErrEmptySubspace    = sdkerrors.Register(ModuleName, 5, "parameter subspace is empty")
 	ErrEmptyKey         = sdkerrors.Register(ModuleName, 6, "parameter key is empty")
 	ErrEmptyValue       = sdkerrors.Register(ModuleName, 7, "parameter value is empty")
+	ErrUnknownKey       = sdkerrors.Register(ModuleName, 8, "unknown parameter key")
+	ErrInvalidParams    = sdkerrors.Register(ModuleName, 9, "invalid parameters")
 )
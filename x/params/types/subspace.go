@@ -82,6 +82,13 @@ func (s Subspace) transientStore(ctx sdk.Context) sdk.KVStore {
 	return prefix.NewStore(ctx.TransientStore(s.tkey), s.suffixedName)
 }
 
+// IsRegistered returns true if the parameter key is registered in the KeyTable
+// of the subspace.
+func (s Subspace) IsRegistered(key []byte) bool {
+	_, ok := s.table.m[string(key)]
+	return ok
+}
+
 // Validate attempts to validate a parameter value by its key. If the key is not
 // registered or if the validation of the value fails, an error is returned.
 func (s Subspace) Validate(ctx sdk.Context, key []byte, value interface{}) error {
@@ -70,15 +70,110 @@ func (m *FeesParams) GetGlobalMinimumGasPrices() github_com_cosmos_cosmos_sdk_ty
 	return nil
 }
 
+// Defines the gas costs of the KVStore operations that are controlled through
+// governance
+type StoreGasParams struct {
+	HasCost          uint64 `protobuf:"varint,1,opt,name=has_cost,json=hasCost,proto3" json:"has_cost,omitempty"`
+	DeleteCost       uint64 `protobuf:"varint,2,opt,name=delete_cost,json=deleteCost,proto3" json:"delete_cost,omitempty"`
+	ReadCostFlat     uint64 `protobuf:"varint,3,opt,name=read_cost_flat,json=readCostFlat,proto3" json:"read_cost_flat,omitempty"`
+	ReadCostPerByte  uint64 `protobuf:"varint,4,opt,name=read_cost_per_byte,json=readCostPerByte,proto3" json:"read_cost_per_byte,omitempty"`
+	WriteCostFlat    uint64 `protobuf:"varint,5,opt,name=write_cost_flat,json=writeCostFlat,proto3" json:"write_cost_flat,omitempty"`
+	WriteCostPerByte uint64 `protobuf:"varint,6,opt,name=write_cost_per_byte,json=writeCostPerByte,proto3" json:"write_cost_per_byte,omitempty"`
+	IterNextCostFlat uint64 `protobuf:"varint,7,opt,name=iter_next_cost_flat,json=iterNextCostFlat,proto3" json:"iter_next_cost_flat,omitempty"`
+}
+
+func (m *StoreGasParams) Reset()         { *m = StoreGasParams{} }
+func (m *StoreGasParams) String() string { return proto.CompactTextString(m) }
+func (*StoreGasParams) ProtoMessage()    {}
+func (*StoreGasParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56d782f42fecdb16, []int{1}
+}
+func (m *StoreGasParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreGasParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreGasParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreGasParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreGasParams.Merge(m, src)
+}
+func (m *StoreGasParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreGasParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreGasParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreGasParams proto.InternalMessageInfo
+
+func (m *StoreGasParams) GetHasCost() uint64 {
+	if m != nil {
+		return m.HasCost
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetDeleteCost() uint64 {
+	if m != nil {
+		return m.DeleteCost
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetReadCostFlat() uint64 {
+	if m != nil {
+		return m.ReadCostFlat
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetReadCostPerByte() uint64 {
+	if m != nil {
+		return m.ReadCostPerByte
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetWriteCostFlat() uint64 {
+	if m != nil {
+		return m.WriteCostFlat
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetWriteCostPerByte() uint64 {
+	if m != nil {
+		return m.WriteCostPerByte
+	}
+	return 0
+}
+
+func (m *StoreGasParams) GetIterNextCostFlat() uint64 {
+	if m != nil {
+		return m.IterNextCostFlat
+	}
+	return 0
+}
+
 type GenesisState struct {
-	FeesParams FeesParams `protobuf:"bytes,1,opt,name=fees_params,json=feesParams,proto3" json:"fees_params"`
+	FeesParams     FeesParams     `protobuf:"bytes,1,opt,name=fees_params,json=feesParams,proto3" json:"fees_params"`
+	StoreGasParams StoreGasParams `protobuf:"bytes,2,opt,name=store_gas_params,json=storeGasParams,proto3" json:"store_gas_params"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
 func (m *GenesisState) String() string { return proto.CompactTextString(m) }
 func (*GenesisState) ProtoMessage()    {}
 func (*GenesisState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56d782f42fecdb16, []int{1}
+	return fileDescriptor_56d782f42fecdb16, []int{2}
 }
 func (m *GenesisState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -114,35 +209,53 @@ func (m *GenesisState) GetFeesParams() FeesParams {
 	return FeesParams{}
 }
 
+func (m *GenesisState) GetStoreGasParams() StoreGasParams {
+	if m != nil {
+		return m.StoreGasParams
+	}
+	return StoreGasParams{}
+}
+
 func init() {
 	proto.RegisterType((*FeesParams)(nil), "cosmos.params.v1beta1.FeesParams")
+	proto.RegisterType((*StoreGasParams)(nil), "cosmos.params.v1beta1.StoreGasParams")
 	proto.RegisterType((*GenesisState)(nil), "cosmos.params.v1beta1.GenesisState")
 }
 
 func init() { proto.RegisterFile("cosmos/params/types/types.proto", fileDescriptor_56d782f42fecdb16) }
 
 var fileDescriptor_56d782f42fecdb16 = []byte{
-	// 306 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4f, 0xce, 0x2f, 0xce,
-	0xcd, 0x2f, 0xd6, 0x2f, 0x48, 0x2c, 0x4a, 0xcc, 0x2d, 0xd6, 0x2f, 0xa9, 0x2c, 0x48, 0x85, 0x92,
-	0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xa2, 0x10, 0x05, 0x7a, 0x10, 0x05, 0x7a, 0x65, 0x86,
-	0x49, 0xa9, 0x25, 0x89, 0x86, 0x52, 0x22, 0xe9, 0xf9, 0xe9, 0xf9, 0x60, 0x15, 0xfa, 0x20, 0x16,
-	0x44, 0xb1, 0x94, 0x1c, 0xd4, 0xb4, 0xa4, 0xc4, 0xe2, 0x54, 0x7d, 0xa8, 0x52, 0xfd, 0xe4, 0xfc,
-	0xcc, 0x3c, 0x88, 0xbc, 0xd2, 0x6c, 0x46, 0x2e, 0x2e, 0xb7, 0xd4, 0xd4, 0xe2, 0x00, 0xb0, 0x61,
-	0x42, 0x3d, 0x8c, 0x5c, 0x92, 0xe9, 0x39, 0xf9, 0x49, 0x89, 0x39, 0xf1, 0xb9, 0x99, 0x79, 0x99,
-	0xb9, 0xa5, 0xb9, 0xf1, 0xe9, 0x89, 0xc5, 0xf1, 0x05, 0x45, 0x99, 0xc9, 0xa9, 0xc5, 0x12, 0x8c,
-	0x0a, 0xcc, 0x1a, 0xdc, 0x46, 0x32, 0x7a, 0x50, 0x07, 0x80, 0xcc, 0x84, 0x59, 0xaf, 0xe7, 0x92,
-	0x9a, 0xec, 0x9c, 0x9f, 0x99, 0xe7, 0x64, 0x7c, 0xe2, 0x9e, 0x3c, 0xc3, 0xaa, 0xfb, 0xf2, 0xda,
-	0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x50, 0x37, 0x40, 0x28, 0xdd,
-	0xe2, 0x94, 0x6c, 0xa8, 0x7f, 0xa0, 0x7a, 0x8a, 0x83, 0xc4, 0x20, 0x76, 0xfa, 0x42, 0xac, 0x74,
-	0x4f, 0x2c, 0x0e, 0x00, 0x5b, 0xa8, 0x14, 0xc1, 0xc5, 0xe3, 0x9e, 0x9a, 0x97, 0x5a, 0x9c, 0x59,
-	0x1c, 0x5c, 0x92, 0x58, 0x92, 0x2a, 0xe4, 0xc1, 0xc5, 0x9d, 0x96, 0x9a, 0x5a, 0x1c, 0x0f, 0xf1,
-	0xba, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0xa2, 0x1e, 0xd6, 0x00, 0xd1, 0x43, 0x78, 0xcb,
-	0x89, 0x05, 0xe4, 0xa8, 0x20, 0xae, 0x34, 0x84, 0x88, 0xe7, 0x8a, 0x47, 0x72, 0x8c, 0x27, 0x1e,
-	0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17,
-	0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x85, 0xdf, 0xf1, 0x15, 0x28, 0x71, 0x93, 0xc4,
-	0x06, 0x0e, 0x49, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x3d, 0x85, 0xe4, 0xf2, 0xb9, 0x01,
-	0x00, 0x00,
+	// 477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0x87, 0xb3, 0x69, 0x68, 0xd1, 0xa6, 0xa4, 0x95, 0x0b, 0x28, 0xad, 0x90, 0x53, 0x22, 0x40,
+	0x95, 0xaa, 0xd8, 0x6a, 0xfb, 0x06, 0x29, 0x6a, 0xe0, 0x00, 0x8a, 0x52, 0x71, 0xe1, 0x62, 0xad,
+	0x9d, 0x89, 0x63, 0x61, 0x7b, 0xad, 0x9d, 0x29, 0x24, 0xef, 0xc0, 0x81, 0x3b, 0x67, 0x24, 0xc4,
+	0x85, 0xd7, 0xe8, 0x31, 0x47, 0x4e, 0x80, 0x92, 0x17, 0x41, 0xde, 0xb5, 0xf3, 0x47, 0x02, 0x2e,
+	0x89, 0x35, 0xf3, 0xf9, 0x9b, 0xb5, 0xe7, 0x67, 0xde, 0x0a, 0x24, 0x26, 0x12, 0xdd, 0x4c, 0x28,
+	0x91, 0xa0, 0x4b, 0xd3, 0x0c, 0x8a, 0x5f, 0x27, 0x53, 0x92, 0xa4, 0xf5, 0xc0, 0x00, 0x8e, 0x01,
+	0x9c, 0xf7, 0x67, 0x3e, 0x90, 0x38, 0x3b, 0xba, 0x1f, 0xca, 0x50, 0x6a, 0xc2, 0xcd, 0xaf, 0x0c,
+	0x7c, 0x64, 0x17, 0x36, 0x5f, 0x20, 0xb8, 0x05, 0xea, 0x06, 0x32, 0x4a, 0x4d, 0xbf, 0xfd, 0x99,
+	0x71, 0x7e, 0x05, 0x80, 0x7d, 0x2d, 0xb3, 0x3e, 0x32, 0x7e, 0x18, 0xc6, 0xd2, 0x17, 0xb1, 0x97,
+	0x44, 0x69, 0x94, 0xdc, 0x24, 0x5e, 0x28, 0xd0, 0xcb, 0x54, 0x14, 0x00, 0x36, 0xd9, 0xf1, 0xd6,
+	0x49, 0xfd, 0xfc, 0x91, 0x53, 0x1c, 0x20, 0x77, 0x96, 0xe3, 0x9d, 0xe7, 0x10, 0x5c, 0xca, 0x28,
+	0xed, 0x5e, 0xdc, 0xfe, 0x6c, 0x55, 0xbe, 0xfd, 0x6a, 0x9d, 0x86, 0x11, 0x8d, 0x6f, 0x7c, 0x27,
+	0x90, 0x89, 0x5b, 0x9c, 0xc1, 0xfc, 0x75, 0x70, 0xf8, 0xae, 0x78, 0x9e, 0xe2, 0x1e, 0x1c, 0x3c,
+	0x34, 0x33, 0x5f, 0x99, 0x91, 0x3d, 0x81, 0x7d, 0x3d, 0xb0, 0xfd, 0xa5, 0xca, 0x1b, 0xd7, 0x24,
+	0x15, 0xf4, 0x44, 0x79, 0xc2, 0x43, 0x7e, 0x77, 0x2c, 0xd0, 0x0b, 0x24, 0x52, 0x93, 0x1d, 0xb3,
+	0x93, 0xda, 0x60, 0x67, 0x2c, 0xf0, 0x52, 0x22, 0x59, 0x2d, 0x5e, 0x1f, 0x42, 0x0c, 0x04, 0xa6,
+	0x5b, 0xd5, 0x5d, 0x6e, 0x4a, 0x1a, 0x78, 0xc2, 0x1b, 0x0a, 0xc4, 0x50, 0xb7, 0xbd, 0x51, 0x2c,
+	0xa8, 0xb9, 0xa5, 0x99, 0xdd, 0xbc, 0x9a, 0x13, 0x57, 0xb1, 0x20, 0xeb, 0x94, 0x5b, 0x2b, 0x2a,
+	0x03, 0xe5, 0xf9, 0x53, 0x82, 0x66, 0x4d, 0x93, 0x7b, 0x25, 0xd9, 0x07, 0xd5, 0x9d, 0x12, 0x58,
+	0xcf, 0xf8, 0xde, 0x07, 0x15, 0x11, 0xac, 0x39, 0xef, 0x68, 0xf2, 0x9e, 0x2e, 0x2f, 0xa5, 0x1d,
+	0x7e, 0xb0, 0xc6, 0x2d, 0xad, 0xdb, 0x9a, 0xdd, 0x5f, 0xb2, 0xa5, 0xb6, 0xc3, 0x0f, 0x22, 0x02,
+	0xe5, 0xa5, 0x30, 0xa1, 0x35, 0xf5, 0x8e, 0xc1, 0xf3, 0xd6, 0x6b, 0x98, 0x50, 0x69, 0x6f, 0x7f,
+	0x67, 0x7c, 0xb7, 0x07, 0x29, 0x60, 0x84, 0xd7, 0x24, 0x08, 0xac, 0x17, 0xbc, 0x3e, 0x02, 0x40,
+	0xcf, 0x64, 0x44, 0xbf, 0xa8, 0xfa, 0xf9, 0x63, 0xe7, 0xaf, 0xc9, 0x71, 0x56, 0xfb, 0xef, 0xd6,
+	0xf2, 0xed, 0x0d, 0xf8, 0x68, 0x95, 0x88, 0x37, 0x7c, 0x1f, 0xf3, 0x0d, 0x98, 0x1c, 0x18, 0x5d,
+	0x55, 0xeb, 0x9e, 0xfe, 0x43, 0xb7, 0xb9, 0xb0, 0x42, 0xd9, 0xc0, 0xcd, 0xea, 0xcb, 0xaf, 0x73,
+	0x9b, 0xdd, 0xce, 0x6d, 0x36, 0x9b, 0xdb, 0xec, 0xf7, 0xdc, 0x66, 0x9f, 0x16, 0x76, 0x65, 0xb6,
+	0xb0, 0x2b, 0x3f, 0x16, 0x76, 0xe5, 0xed, 0xff, 0xc3, 0x33, 0xd9, 0xf8, 0x36, 0xfc, 0x6d, 0x9d,
+	0xe4, 0x8b, 0x3f, 0x03, 0x00, 0x32, 0x0d, 0xd9, 0xda, 0x39, 0x03, 0x00, 0x00,
 }
 
 func (this *FeesParams) Equal(that interface{}) bool {
@@ -174,6 +287,48 @@ func (this *FeesParams) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *StoreGasParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StoreGasParams)
+	if !ok {
+		that2, ok := that.(StoreGasParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.HasCost != that1.HasCost {
+		return false
+	}
+	if this.DeleteCost != that1.DeleteCost {
+		return false
+	}
+	if this.ReadCostFlat != that1.ReadCostFlat {
+		return false
+	}
+	if this.ReadCostPerByte != that1.ReadCostPerByte {
+		return false
+	}
+	if this.WriteCostFlat != that1.WriteCostFlat {
+		return false
+	}
+	if this.WriteCostPerByte != that1.WriteCostPerByte {
+		return false
+	}
+	if this.IterNextCostFlat != that1.IterNextCostFlat {
+		return false
+	}
+	return true
+}
 func (this *GenesisState) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -196,6 +351,9 @@ func (this *GenesisState) Equal(that interface{}) bool {
 	if !this.FeesParams.Equal(&that1.FeesParams) {
 		return false
 	}
+	if !this.StoreGasParams.Equal(&that1.StoreGasParams) {
+		return false
+	}
 	return true
 }
 func (m *FeesParams) Marshal() (dAtA []byte, err error) {
@@ -235,6 +393,64 @@ func (m *FeesParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StoreGasParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreGasParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreGasParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IterNextCostFlat != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.IterNextCostFlat))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.WriteCostPerByte != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.WriteCostPerByte))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.WriteCostFlat != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.WriteCostFlat))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ReadCostPerByte != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ReadCostPerByte))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ReadCostFlat != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ReadCostFlat))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DeleteCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DeleteCost))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.HasCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.HasCost))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -255,6 +471,16 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.StoreGasParams.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
 	{
 		size, err := m.FeesParams.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -294,6 +520,36 @@ func (m *FeesParams) Size() (n int) {
 	return n
 }
 
+func (m *StoreGasParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HasCost != 0 {
+		n += 1 + sovTypes(uint64(m.HasCost))
+	}
+	if m.DeleteCost != 0 {
+		n += 1 + sovTypes(uint64(m.DeleteCost))
+	}
+	if m.ReadCostFlat != 0 {
+		n += 1 + sovTypes(uint64(m.ReadCostFlat))
+	}
+	if m.ReadCostPerByte != 0 {
+		n += 1 + sovTypes(uint64(m.ReadCostPerByte))
+	}
+	if m.WriteCostFlat != 0 {
+		n += 1 + sovTypes(uint64(m.WriteCostFlat))
+	}
+	if m.WriteCostPerByte != 0 {
+		n += 1 + sovTypes(uint64(m.WriteCostPerByte))
+	}
+	if m.IterNextCostFlat != 0 {
+		n += 1 + sovTypes(uint64(m.IterNextCostFlat))
+	}
+	return n
+}
+
 func (m *GenesisState) Size() (n int) {
 	if m == nil {
 		return 0
@@ -302,6 +558,8 @@ func (m *GenesisState) Size() (n int) {
 	_ = l
 	l = m.FeesParams.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = m.StoreGasParams.Size()
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -395,6 +653,189 @@ func (m *FeesParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StoreGasParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreGasParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreGasParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasCost", wireType)
+			}
+			m.HasCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HasCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteCost", wireType)
+			}
+			m.DeleteCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeleteCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadCostFlat", wireType)
+			}
+			m.ReadCostFlat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadCostFlat |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadCostPerByte", wireType)
+			}
+			m.ReadCostPerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadCostPerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteCostFlat", wireType)
+			}
+			m.WriteCostFlat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteCostFlat |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteCostPerByte", wireType)
+			}
+			m.WriteCostPerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteCostPerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IterNextCostFlat", wireType)
+			}
+			m.IterNextCostFlat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IterNextCostFlat |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *GenesisState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -457,6 +898,39 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreGasParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StoreGasParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,28 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ParamChangeValidator validates the parameters of a subspace changed by a
+// parameter change proposal. It is given the subspace with the changes applied,
+// so that it can check the constraints between parameters which the
+// validation of each parameter value cannot.
+type ParamChangeValidator func(ctx sdk.Context, ss Subspace) error
+
+// ValidatedParamSet is a ParamSet able to validate itself as a whole.
+type ValidatedParamSet interface {
+	ParamSet
+	Validate() error
+}
+
+// ValidateParamSet returns a ParamChangeValidator reading the parameters of the
+// subspace into the ParamSet returned by newParamSet and validating it.
+// Parameters missing from the store keep the values set by newParamSet.
+func ValidateParamSet(newParamSet func() ValidatedParamSet) ParamChangeValidator {
+	return func(ctx sdk.Context, ss Subspace) error {
+		ps := newParamSet()
+		ss.GetParamSetIfExists(ctx, ps)
+		return ps.Validate()
+	}
+}
@@ -372,10 +372,17 @@ func (k Keeper) UBDQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterato
 // DequeueAllMatureUBDQueue returns a concatenated list of all the timeslices inclusively previous to
 // currTime, and deletes the timeslices from the queue.
 func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime time.Time) (matureUnbonds []types.DVPair) {
+	return k.DequeueMatureUBDQueue(ctx, currTime, 0)
+}
+
+// DequeueMatureUBDQueue returns at most limit pairs from the timeslices inclusively previous to
+// currTime, and deletes them from the queue. The pairs left in a partially dequeued timeslice stay
+// at the head of the queue for the next call. A limit of 0 dequeues all the mature pairs.
+func (k Keeper) DequeueMatureUBDQueue(ctx sdk.Context, currTime time.Time, limit int) (matureUnbonds []types.DVPair) {
 	store := ctx.KVStore(k.storeKey)
 
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, ctx.BlockHeader().Time)
+	// gets an iterator for all timeslices from time 0 until currTime
+	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, currTime)
 	defer unbondingTimesliceIterator.Close()
 
 	for ; unbondingTimesliceIterator.Valid(); unbondingTimesliceIterator.Next() {
@@ -383,9 +390,20 @@ func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime time.Time) (m
 		value := unbondingTimesliceIterator.Value()
 		k.cdc.MustUnmarshal(value, &timeslice)
 
+		if limit > 0 && len(matureUnbonds)+len(timeslice.Pairs) > limit {
+			n := limit - len(matureUnbonds)
+			matureUnbonds = append(matureUnbonds, timeslice.Pairs[:n]...)
+			store.Set(unbondingTimesliceIterator.Key(), k.cdc.MustMarshal(&types.DVPairs{Pairs: timeslice.Pairs[n:]}))
+			break
+		}
+
 		matureUnbonds = append(matureUnbonds, timeslice.Pairs...)
 
 		store.Delete(unbondingTimesliceIterator.Key())
+
+		if limit > 0 && len(matureUnbonds) == limit {
+			break
+		}
 	}
 
 	return matureUnbonds
@@ -607,10 +625,18 @@ func (k Keeper) RedelegationQueueIterator(ctx sdk.Context, endTime time.Time) sd
 // timeslices inclusively previous to currTime, and deletes the timeslices from
 // the queue.
 func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time.Time) (matureRedelegations []types.DVVTriplet) {
+	return k.DequeueMatureRedelegationQueue(ctx, currTime, 0)
+}
+
+// DequeueMatureRedelegationQueue returns at most limit triplets from the timeslices inclusively
+// previous to currTime, and deletes them from the queue. The triplets left in a partially dequeued
+// timeslice stay at the head of the queue for the next call. A limit of 0 dequeues all the mature
+// triplets.
+func (k Keeper) DequeueMatureRedelegationQueue(ctx sdk.Context, currTime time.Time, limit int) (matureRedelegations []types.DVVTriplet) {
 	store := ctx.KVStore(k.storeKey)
 
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, ctx.BlockHeader().Time)
+	// gets an iterator for all timeslices from time 0 until currTime
+	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, currTime)
 	defer redelegationTimesliceIterator.Close()
 
 	for ; redelegationTimesliceIterator.Valid(); redelegationTimesliceIterator.Next() {
@@ -618,9 +644,20 @@ func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time
 		value := redelegationTimesliceIterator.Value()
 		k.cdc.MustUnmarshal(value, &timeslice)
 
+		if limit > 0 && len(matureRedelegations)+len(timeslice.Triplets) > limit {
+			n := limit - len(matureRedelegations)
+			matureRedelegations = append(matureRedelegations, timeslice.Triplets[:n]...)
+			store.Set(redelegationTimesliceIterator.Key(), k.cdc.MustMarshal(&types.DVVTriplets{Triplets: timeslice.Triplets[n:]}))
+			break
+		}
+
 		matureRedelegations = append(matureRedelegations, timeslice.Triplets...)
 
 		store.Delete(redelegationTimesliceIterator.Key())
+
+		if limit > 0 && len(matureRedelegations) == limit {
+			break
+		}
 	}
 
 	return matureRedelegations
@@ -278,6 +278,38 @@ func TestUnbondingDelegation(t *testing.T) {
 	require.Equal(t, 0, len(resUnbonds))
 }
 
+func TestDequeueMatureUBDQueueLimit(t *testing.T) {
+	_, app, ctx := createTestInput()
+
+	delAddrs := simapp.AddTestAddrsIncremental(app, ctx, 5, sdk.NewInt(10000))
+	valAddrs := simapp.ConvertAddrsToValAddrs(delAddrs)
+
+	now := time.Unix(1000, 0).UTC()
+	ctx = ctx.WithBlockTime(now)
+
+	// three pairs maturing in a first timeslice, two in a second one and one in the future
+	completionTimes := []time.Time{now.Add(-2 * time.Second), now.Add(-2 * time.Second), now.Add(-2 * time.Second), now.Add(-time.Second), now, now.Add(time.Second)}
+	var expected []types.DVPair
+	for i, completionTime := range completionTimes {
+		ubd := types.NewUnbondingDelegation(delAddrs[i%5], valAddrs[i/5], 0, completionTime, sdk.NewInt(5))
+		app.StakingKeeper.InsertUBDQueue(ctx, ubd, completionTime)
+		expected = append(expected, types.DVPair{DelegatorAddress: ubd.DelegatorAddress, ValidatorAddress: ubd.ValidatorAddress})
+	}
+
+	// the first timeslice is split, its remaining pair is carried over
+	require.Equal(t, expected[:2], app.StakingKeeper.DequeueMatureUBDQueue(ctx, now, 2))
+	require.Equal(t, expected[2:3], app.StakingKeeper.GetUBDQueueTimeSlice(ctx, completionTimes[0]))
+
+	require.Equal(t, expected[2:4], app.StakingKeeper.DequeueMatureUBDQueue(ctx, now, 2))
+	require.Empty(t, app.StakingKeeper.GetUBDQueueTimeSlice(ctx, completionTimes[0]))
+
+	require.Equal(t, expected[4:5], app.StakingKeeper.DequeueMatureUBDQueue(ctx, now, 2))
+	require.Empty(t, app.StakingKeeper.DequeueMatureUBDQueue(ctx, now, 2))
+
+	// the immature pair is left in the queue
+	require.Equal(t, expected[5:], app.StakingKeeper.DequeueAllMatureUBDQueue(ctx, now.Add(time.Second)))
+}
+
 func TestUnbondDelegation(t *testing.T) {
 	_, app, ctx := createTestInput()
 
@@ -789,6 +821,32 @@ func TestRedelegation(t *testing.T) {
 	require.Equal(t, 0, len(redelegations))
 }
 
+func TestDequeueMatureRedelegationQueueLimit(t *testing.T) {
+	_, app, ctx := createTestInput()
+
+	delAddrs := simapp.AddTestAddrsIncremental(app, ctx, 3, sdk.NewInt(10000))
+	valAddrs := simapp.ConvertAddrsToValAddrs(delAddrs)
+
+	now := time.Unix(1000, 0).UTC()
+	ctx = ctx.WithBlockTime(now)
+
+	var expected []types.DVVTriplet
+	for i := 0; i < 3; i++ {
+		red := types.NewRedelegation(delAddrs[i], valAddrs[0], valAddrs[1], 0, now, sdk.NewInt(5), sdk.NewDec(5))
+		app.StakingKeeper.InsertRedelegationQueue(ctx, red, now)
+		expected = append(expected, types.DVVTriplet{
+			DelegatorAddress:    red.DelegatorAddress,
+			ValidatorSrcAddress: red.ValidatorSrcAddress,
+			ValidatorDstAddress: red.ValidatorDstAddress,
+		})
+	}
+
+	require.Equal(t, expected[:2], app.StakingKeeper.DequeueMatureRedelegationQueue(ctx, now, 2))
+	require.Equal(t, expected[2:], app.StakingKeeper.GetRedelegationQueueTimeSlice(ctx, now))
+	require.Equal(t, expected[2:], app.StakingKeeper.DequeueMatureRedelegationQueue(ctx, now, 2))
+	require.Empty(t, app.StakingKeeper.GetRedelegationQueueTimeSlice(ctx, now))
+}
+
 func TestRedelegateToSameValidator(t *testing.T) {
 	_, app, ctx := createTestInput()
 
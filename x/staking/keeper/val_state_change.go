@@ -12,6 +12,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
+// MaxMatureEntriesPerBlock bounds the number of mature unbonding delegations, and of mature
+// redelegations, completed in a single EndBlock. The entries over the bound are carried over to
+// the next blocks, so that a mass unbonding doesn't exceed the EndBlock time budget.
+const MaxMatureEntriesPerBlock = 1000
+
 // BlockValidatorUpdates calculates the ValidatorUpdates for the current block
 // Called in each EndBlock
 func (k Keeper) BlockValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
@@ -32,8 +37,8 @@ func (k Keeper) BlockValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
 	// unbond all mature validators from the unbonding queue
 	k.UnbondAllMatureValidators(ctx)
 
-	// Remove all mature unbonding delegations from the ubd queue.
-	matureUnbonds := k.DequeueAllMatureUBDQueue(ctx, ctx.BlockHeader().Time)
+	// Remove the mature unbonding delegations from the ubd queue, a bounded batch per block.
+	matureUnbonds := k.DequeueMatureUBDQueue(ctx, ctx.BlockHeader().Time, MaxMatureEntriesPerBlock)
 	for _, dvPair := range matureUnbonds {
 		addr, err := sdk.ValAddressFromBech32(dvPair.ValidatorAddress)
 		if err != nil {
@@ -56,8 +61,8 @@ func (k Keeper) BlockValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
 		)
 	}
 
-	// Remove all mature redelegations from the red queue.
-	matureRedelegations := k.DequeueAllMatureRedelegationQueue(ctx, ctx.BlockHeader().Time)
+	// Remove the mature redelegations from the red queue, a bounded batch per block.
+	matureRedelegations := k.DequeueMatureRedelegationQueue(ctx, ctx.BlockHeader().Time, MaxMatureEntriesPerBlock)
 	for _, dvvTriplet := range matureRedelegations {
 		valSrcAddr, err := sdk.ValAddressFromBech32(dvvTriplet.ValidatorSrcAddress)
 		if err != nil {
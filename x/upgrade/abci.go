@@ -96,6 +96,13 @@ func BeginBlocker(k keeper.Keeper, ctx sdk.Context, _ abci.RequestBeginBlock) {
 
 // panicUpgradeNeeded shuts down the node and prints a message that the upgrade needs to be applied.
 func panicUpgradeNeeded(k keeper.Keeper, ctx sdk.Context, plan types.Plan) {
+	// Validate the preconditions of the upgrade before letting the sidecar process switch binaries.
+	if err := k.RunPreUpgradeHandler(ctx, plan); err != nil {
+		preUpgradeMsg := fmt.Sprintf("UPGRADE \"%s\" PRE-UPGRADE VALIDATION FAILED at %s: %s", plan.Name, plan.DueAt(), err)
+		ctx.Logger().Error(preUpgradeMsg)
+		panic(preUpgradeMsg)
+	}
+
 	// Write the upgrade info to disk. The UpgradeStoreLoader uses this info to perform or skip
 	// store migrations.
 	err := k.DumpUpgradeInfoWithInfoToDisk(ctx.BlockHeight(), plan.Name, plan.Info)
@@ -110,6 +117,12 @@ func panicUpgradeNeeded(k keeper.Keeper, ctx sdk.Context, plan types.Plan) {
 }
 
 func applyUpgrade(k keeper.Keeper, ctx sdk.Context, plan types.Plan) {
+	if err := k.VerifyUpgradeBinary(plan); err != nil {
+		wrongBinaryMsg := fmt.Sprintf("WRONG BINARY FOR UPGRADE \"%s\" at %s: %s", plan.Name, plan.DueAt(), err)
+		ctx.Logger().Error(wrongBinaryMsg)
+		panic(wrongBinaryMsg)
+	}
+
 	ctx.Logger().Info(fmt.Sprintf("applying upgrade \"%s\" at %s", plan.Name, plan.DueAt()))
 	ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
 	k.ApplyUpgrade(ctx, plan)
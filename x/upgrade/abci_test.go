@@ -2,6 +2,8 @@ package upgrade_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -581,3 +583,84 @@ func TestBinaryVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestPreUpgradeHandler(t *testing.T) {
+	s := setupTest(10, map[int64]bool{})
+	err := s.handler(s.ctx, &types.SoftwareUpgradeProposal{Title: "prop", Plan: types.Plan{Name: "test", Height: s.ctx.BlockHeight() + 1}})
+	require.NoError(t, err)
+
+	upgradeInfoFilePath, err := s.keeper.GetUpgradeInfoPath()
+	require.NoError(t, err)
+	os.Remove(upgradeInfoFilePath)
+
+	newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
+	req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
+
+	t.Log("Verify that a failed pre-upgrade validation halts without dumping the upgrade info")
+	s.keeper.SetPreUpgradeHandler("test", func(ctx sdk.Context, plan types.Plan) error {
+		return fmt.Errorf("precondition not met")
+	})
+	require.PanicsWithValue(t, `UPGRADE "test" PRE-UPGRADE VALIDATION FAILED at height: 11: precondition not met`, func() {
+		s.module.BeginBlock(newCtx, req)
+	})
+	_, err = os.Stat(upgradeInfoFilePath)
+	require.True(t, os.IsNotExist(err))
+
+	t.Log("Verify that a successful pre-upgrade validation halts for the upgrade")
+	called := false
+	s.keeper.SetPreUpgradeHandler("test", func(ctx sdk.Context, plan types.Plan) error {
+		called = true
+		return nil
+	})
+	require.PanicsWithValue(t, upgrade.BuildUpgradeNeededMsg(types.Plan{Name: "test", Height: 11}), func() {
+		s.module.BeginBlock(newCtx, req)
+	})
+	require.True(t, called)
+	_, err = os.Stat(upgradeInfoFilePath)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(upgradeInfoFilePath))
+}
+
+func TestVerifyUpgradeBinary(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	bz, err := ioutil.ReadFile(executable)
+	require.NoError(t, err)
+	sum := sha256.Sum256(bz)
+
+	for _, tc := range []struct {
+		name     string
+		checksum string
+		expPanic bool
+	}{
+		{"wrong binary", hex.EncodeToString(make([]byte, sha256.Size)), true},
+		{"right binary", hex.EncodeToString(sum[:]), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := setupTest(10, map[int64]bool{})
+			s.keeper.SetBinaryChecksumVerification(true)
+			s.module = upgrade.NewAppModule(s.keeper)
+
+			info := fmt.Sprintf(`{"binaries":{"%s":"https://foo.bar/appd?checksum=sha256:%s"}}`, types.OSArch(), tc.checksum)
+			err := s.handler(s.ctx, &types.SoftwareUpgradeProposal{Title: "prop", Plan: types.Plan{Name: "test", Height: s.ctx.BlockHeight() + 1, Info: info}})
+			require.NoError(t, err)
+			s.keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan types.Plan, vm module.VersionMap) (module.VersionMap, error) {
+				return vm, nil
+			})
+
+			newCtx := s.ctx.WithBlockHeight(s.ctx.BlockHeight() + 1).WithBlockTime(time.Now())
+			req := abci.RequestBeginBlock{Header: newCtx.BlockHeader()}
+			if tc.expPanic {
+				require.Panics(t, func() {
+					s.module.BeginBlock(newCtx, req)
+				})
+				VerifyNotDone(t, newCtx, "test")
+			} else {
+				require.NotPanics(t, func() {
+					s.module.BeginBlock(newCtx, req)
+				})
+				VerifyDone(t, newCtx, "test")
+			}
+		})
+	}
+}
@@ -35,13 +35,15 @@ type upgrade struct {
 }
 
 type Keeper struct {
-	homePath           string                          // root directory of app config
-	skipUpgradeHeights map[int64]bool                  // map of heights to skip for an upgrade
-	storeKey           sdk.StoreKey                    // key to access x/upgrade store
-	cdc                codec.BinaryCodec               // App-wide binary codec
-	upgradeHandlers    map[string]types.UpgradeHandler // map of plan name to upgrade handler
-	versionSetter      xp.ProtocolVersionSetter        // implements setting the protocol version field on BaseApp
-	downgradeVerified  bool                            // tells if we've already sanity checked that this binary version isn't being used against an old state.
+	homePath           string                             // root directory of app config
+	skipUpgradeHeights map[int64]bool                     // map of heights to skip for an upgrade
+	storeKey           sdk.StoreKey                       // key to access x/upgrade store
+	cdc                codec.BinaryCodec                  // App-wide binary codec
+	upgradeHandlers    map[string]types.UpgradeHandler    // map of plan name to upgrade handler
+	preUpgradeHandlers map[string]types.PreUpgradeHandler // map of plan name to pre-upgrade handler
+	verifyBinary       bool                               // tells if the checksum of the binary is verified before applying an upgrade
+	versionSetter      xp.ProtocolVersionSetter           // implements setting the protocol version field on BaseApp
+	downgradeVerified  bool                               // tells if we've already sanity checked that this binary version isn't being used against an old state.
 }
 
 // NewKeeper constructs an upgrade Keeper which requires the following arguments:
@@ -57,6 +59,7 @@ func NewKeeper(skipUpgradeHeights map[int64]bool, storeKey sdk.StoreKey, cdc cod
 		storeKey:           storeKey,
 		cdc:                cdc,
 		upgradeHandlers:    map[string]types.UpgradeHandler{},
+		preUpgradeHandlers: map[string]types.PreUpgradeHandler{},
 		versionSetter:      vs,
 	}
 }
@@ -68,6 +71,23 @@ func (k Keeper) SetUpgradeHandler(name string, upgradeHandler types.UpgradeHandl
 	k.upgradeHandlers[name] = upgradeHandler
 }
 
+// SetPreUpgradeHandler sets a PreUpgradeHandler for the upgrade specified by name. This handler is called by
+// the binary being upgraded when the upgrade with this name is due, before halting.
+func (k Keeper) SetPreUpgradeHandler(name string, preUpgradeHandler types.PreUpgradeHandler) {
+	k.preUpgradeHandlers[name] = preUpgradeHandler
+}
+
+// RunPreUpgradeHandler calls the PreUpgradeHandler of the plan, if any. It runs on a branch of the state, which
+// is discarded.
+func (k Keeper) RunPreUpgradeHandler(ctx sdk.Context, plan types.Plan) error {
+	handler, ok := k.preUpgradeHandlers[plan.Name]
+	if !ok {
+		return nil
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	return handler(cacheCtx, plan)
+}
+
 // setProtocolVersion sets the protocol version to state
 func (k Keeper) setProtocolVersion(ctx sdk.Context, v uint64) {
 	store := ctx.KVStore(k.storeKey)
@@ -461,6 +481,29 @@ type upgradeInfo struct {
 	Info string `json:"info,omitempty"`
 }
 
+// SetBinaryChecksumVerification sets whether the checksum of the running binary is verified against the checksum
+// of the binary of the plan before applying the upgrade.
+func (k *Keeper) SetBinaryChecksumVerification(enabled bool) {
+	k.verifyBinary = enabled
+}
+
+// VerifyUpgradeBinary verifies the running binary against the checksum of the binary of the plan for its os/arch,
+// if the verification is enabled.
+func (k Keeper) VerifyUpgradeBinary(plan types.Plan) error {
+	if !k.verifyBinary {
+		return nil
+	}
+	details, err := plan.UpgradeDetails()
+	if err != nil {
+		return nil
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return details.VerifyBinary(types.OSArch(), path)
+}
+
 // SetDowngradeVerified updates downgradeVerified.
 func (k *Keeper) SetDowngradeVerified(v bool) {
 	k.downgradeVerified = v
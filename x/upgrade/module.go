@@ -29,6 +29,11 @@ var (
 	_ module.AppModuleBasic = AppModuleBasic{}
 )
 
+// Module init related flags
+const (
+	FlagVerifyBinaryChecksum = "x-upgrade-verify-binary-checksum"
+)
+
 // AppModuleBasic implements the sdk.AppModuleBasic interface
 type AppModuleBasic struct{}
 
@@ -80,6 +85,11 @@ func NewAppModule(keeper keeper.Keeper) AppModule {
 	}
 }
 
+// AddModuleInitFlags implements servertypes.ModuleInitFlags interface.
+func AddModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().Bool(FlagVerifyBinaryChecksum, false, "Verify the checksum of the binary against the checksum in the upgrade plan before applying the upgrade")
+}
+
 // RegisterInvariants does nothing, there are no invariants to enforce
 func (AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
 
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"crypto/md5"  // #nosec
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// AnyOSArch is the key of the binary that runs on any os/arch in the binaries
+// of the upgrade details.
+const AnyOSArch = "any"
+
+// checksumHashes are the checksum types supported by cosmovisor.
+var checksumHashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// archiveSuffixes are the extensions of the archives cosmovisor unpacks, the
+// checksum of an archive is not the checksum of the binary it contains.
+var archiveSuffixes = []string{".zip", ".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz", ".gz", ".bz2", ".xz"}
+
+// OSArch returns the os/arch of the running binary, as used to key the
+// binaries of the upgrade details.
+func OSArch() string {
+	return fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// BinaryChecksum is the checksum of a binary of an upgrade, given by the
+// checksum query parameter of its url, e.g. "?checksum=sha256:<hex>".
+type BinaryChecksum struct {
+	Type  string
+	Value string
+}
+
+// parseBinaryURL returns the checksum of the binary at rawURL, if any, and
+// whether the url is an archive.
+func parseBinaryURL(rawURL string) (*BinaryChecksum, bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, false, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid binary url %q: %s", rawURL, err)
+	}
+	isArchive := false
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(u.Path, suffix) {
+			isArchive = true
+			break
+		}
+	}
+
+	param := u.Query().Get("checksum")
+	if param == "" {
+		return nil, isArchive, nil
+	}
+	parts := strings.SplitN(param, ":", 2)
+	if len(parts) != 2 {
+		return nil, false, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid checksum %q, expected <type>:<value>", param)
+	}
+	newHash, ok := checksumHashes[parts[0]]
+	if !ok {
+		return nil, false, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unsupported checksum type %q", parts[0])
+	}
+	if bz, err := hex.DecodeString(parts[1]); err != nil || len(bz) != newHash().Size() {
+		return nil, false, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid %s checksum %q", parts[0], parts[1])
+	}
+	return &BinaryChecksum{Type: parts[0], Value: strings.ToLower(parts[1])}, isArchive, nil
+}
+
+// BinaryURL returns the url of the binary for osArch, falling back to the
+// binary for any os/arch.
+func (ud UpgradeDetails) BinaryURL(osArch string) (string, bool) {
+	if u, ok := ud.Binaries[osArch]; ok {
+		return u, true
+	}
+	u, ok := ud.Binaries[AnyOSArch]
+	return u, ok
+}
+
+// ValidateBinaries validates the urls of the binaries, and their checksums
+// if any.
+func (ud UpgradeDetails) ValidateBinaries() error {
+	for osArch, u := range ud.Binaries {
+		if osArch == "" || u == "" {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "binaries cannot have an empty os/arch or url")
+		}
+		if _, _, err := parseBinaryURL(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyBinary verifies the checksum of the binary file at path against the
+// checksum of the binary for osArch. There is nothing to verify if the binary
+// for osArch has no checksum or is an archive.
+func (ud UpgradeDetails) VerifyBinary(osArch, path string) error {
+	u, ok := ud.BinaryURL(osArch)
+	if !ok {
+		return nil
+	}
+	checksum, isArchive, err := parseBinaryURL(u)
+	if err != nil {
+		return err
+	}
+	if checksum == nil || isArchive {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := checksumHashes[checksum.Type]()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != checksum.Value {
+		return fmt.Errorf("wrong binary %s: %s checksum %s, expected %s", path, checksum.Type, sum, checksum.Value)
+	}
+	return nil
+}
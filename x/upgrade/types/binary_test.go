@@ -0,0 +1,65 @@
+package types_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+func TestBinaryURL(t *testing.T) {
+	details := types.UpgradeDetails{Binaries: map[string]string{
+		"linux/arm": "https://foo.bar/arm-only",
+		"any":       "https://foo.bar/portable",
+	}}
+
+	u, ok := details.BinaryURL("linux/arm")
+	require.True(t, ok)
+	require.Equal(t, "https://foo.bar/arm-only", u)
+
+	u, ok = details.BinaryURL("linux/amd64")
+	require.True(t, ok)
+	require.Equal(t, "https://foo.bar/portable", u)
+
+	_, ok = types.UpgradeDetails{}.BinaryURL("linux/amd64")
+	require.False(t, ok)
+}
+
+func TestVerifyBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appd")
+	require.NoError(t, os.WriteFile(path, []byte("binary"), 0o600))
+	sum := sha256.Sum256([]byte("binary"))
+	checksum := hex.EncodeToString(sum[:])
+	wrongChecksum := hex.EncodeToString(make([]byte, sha256.Size))
+
+	tests := []struct {
+		name     string
+		binaries map[string]string
+		wantErr  bool
+	}{
+		{"no binaries", nil, false},
+		{"no binary for os/arch", map[string]string{"windows/amd64": "https://foo.bar/appd?checksum=sha256:" + wrongChecksum}, false},
+		{"no checksum", map[string]string{"linux/amd64": "https://foo.bar/appd"}, false},
+		{"matching checksum", map[string]string{"linux/amd64": "https://foo.bar/appd?checksum=sha256:" + checksum}, false},
+		{"matching checksum of any os/arch", map[string]string{"any": "https://foo.bar/appd?checksum=sha256:" + checksum}, false},
+		{"wrong checksum", map[string]string{"linux/amd64": "https://foo.bar/appd?checksum=sha256:" + wrongChecksum}, true},
+		{"checksum of an archive", map[string]string{"linux/amd64": "https://foo.bar/appd.tar.gz?checksum=sha256:" + wrongChecksum}, false},
+		{"invalid checksum", map[string]string{"linux/amd64": "https://foo.bar/appd?checksum=sha256"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.UpgradeDetails{Binaries: tc.binaries}.VerifyBinary("linux/amd64", path)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -24,3 +24,9 @@ import (
 //
 // Please also refer to docs/core/upgrade.md for more information.
 type UpgradeHandler func(ctx sdk.Context, plan Plan, fromVM module.VersionMap) (module.VersionMap, error)
+
+// PreUpgradeHandler specifies the type of function that is called by the
+// binary being upgraded at the height of the plan, before it halts waiting for
+// the new binary. It validates the preconditions of the upgrade, an error halts
+// the node without letting the sidecar process switch to the new binary.
+type PreUpgradeHandler func(ctx sdk.Context, plan Plan) error
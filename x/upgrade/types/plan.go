@@ -31,6 +31,12 @@ func (p Plan) ValidateBasic() error {
 	if p.Height <= 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "height must be greater than 0")
 	}
+	// the info is free-form, only the binaries of json upgrade details are checked
+	if details, err := p.UpgradeDetails(); err == nil {
+		if err := details.ValidateBinaries(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -52,6 +58,9 @@ func (p Plan) DueAt() string {
 // This is held in the Info object of an upgrade Plan
 type UpgradeDetails struct {
 	UpgradeType string `json:"upgradeType"`
+	// Binaries maps an os/arch to the url of its binary, in the format used by
+	// cosmovisor to download them.
+	Binaries map[string]string `json:"binaries,omitempty"`
 }
 
 // UpgradeDetails parses and returns a details struct from the Info field of a Plan
@@ -92,6 +92,43 @@ func TestPlanValid(t *testing.T) {
 				Height: -12345,
 			},
 		},
+		"binaries with checksum": {
+			p: types.Plan{
+				Name:   "binaries",
+				Height: 123450000,
+				Info:   `{"binaries":{"linux/amd64":"https://foo.bar/appd?checksum=sha256:e6bc7851600a2a9917f7bf88eb7bdee1ec162c671101485690b4deb089077b0d","any":"https://foo.bar/appd.zip"}}`,
+			},
+			valid: true,
+		},
+		"free-form info": {
+			p: types.Plan{
+				Name:   "free-form",
+				Height: 123450000,
+				Info:   "https://foo.bar/upgrade-info.json?checksum=invalid",
+			},
+			valid: true,
+		},
+		"binary with unsupported checksum type": {
+			p: types.Plan{
+				Name:   "binaries",
+				Height: 123450000,
+				Info:   `{"binaries":{"linux/amd64":"https://foo.bar/appd?checksum=crc32:e6bc7851"}}`,
+			},
+		},
+		"binary with truncated checksum": {
+			p: types.Plan{
+				Name:   "binaries",
+				Height: 123450000,
+				Info:   `{"binaries":{"linux/amd64":"https://foo.bar/appd?checksum=sha256:e6bc7851"}}`,
+			},
+		},
+		"binary with empty url": {
+			p: types.Plan{
+				Name:   "binaries",
+				Height: 123450000,
+				Info:   `{"binaries":{"linux/amd64":""}}`,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -183,9 +220,7 @@ func TestUpgradeDetails(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			got, _ := test.plan.UpgradeDetails()
-			if got != test.want {
-				t.Errorf("UpgradeDetails() = %v, want %v", got, test.want)
-			}
+			assert.Equal(t, test.want, got)
 		})
 	}
 }